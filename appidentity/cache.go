@@ -16,12 +16,13 @@ var (
 // Get loads the application identity using automatic discovery and caching.
 //
 // Identity is loaded once per process and cached. Subsequent calls return
-// the cached instance. Discovery follows this precedence:
+// the cached instance (replaced on change when a Watcher is running). Discovery follows this precedence:
 //
 //  1. Context injection (via WithIdentity) - highest priority
 //  2. ExplicitPath in options (via GetWithOptions)
 //  3. Environment variable (FULMEN_APP_IDENTITY_PATH)
-//  4. Nearest ancestor search from current directory
+//  4. Compiled default (see SetCompiledDefault)
+//  5. Nearest ancestor search from current directory
 //
 // This function is thread-safe and uses sync.Once to ensure the identity
 // is loaded exactly once, even under concurrent access.
@@ -48,7 +49,8 @@ func Get(ctx context.Context) (*Identity, error) {
 //  1. Context injection (via WithIdentity)
 //  2. opts.ExplicitPath
 //  3. Environment variable (FULMEN_APP_IDENTITY_PATH)
-//  4. Nearest ancestor search from opts.RepoRoot (default: cwd)
+//  4. Compiled default (see SetCompiledDefault)
+//  5. Nearest ancestor search from opts.RepoRoot (default: cwd)
 //
 // Example:
 //
@@ -72,9 +74,23 @@ func GetWithOptions(ctx context.Context, opts Options) (*Identity, error) {
 		cachedIdentity, cacheErr = discoverIdentity(ctx, opts)
 	})
 
+	// A Watcher may replace the cached identity after the initial load
+	cacheMu.RLock()
+	defer cacheMu.RUnlock()
 	return cachedIdentity, cacheErr
 }
 
+// storeCachedIdentity replaces the process-level cached identity (used by Watcher).
+func storeCachedIdentity(identity *Identity) {
+	// Mark the cache as loaded so Get does not overwrite the stored identity
+	cacheOnce.Do(func() {})
+
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	cachedIdentity = identity
+	cacheErr = nil
+}
+
 // Must loads the application identity and panics on error.
 //
 // This is a convenience wrapper around Get for use in main() or init()
@@ -0,0 +1,110 @@
+package appidentity
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Identity fields baked in at link time, for binaries that run where no
+// .fulmen/app.yaml exists (e.g., scratch containers):
+//
+//	go build -ldflags "\
+//	  -X github.com/fulmenhq/gofulmen/appidentity.compiledBinaryName=myapp \
+//	  -X github.com/fulmenhq/gofulmen/appidentity.compiledVendor=myvendor \
+//	  '-X github.com/fulmenhq/gofulmen/appidentity.compiledDescription=My application'"
+//
+// compiledEnvPrefix and compiledConfigName may also be set; unset values derive
+// from the binary name as in Init. A SetCompiledDefault call takes precedence.
+var (
+	compiledBinaryName  string
+	compiledVendor      string
+	compiledEnvPrefix   string
+	compiledConfigName  string
+	compiledDescription string
+)
+
+var (
+	compiledMu       sync.RWMutex
+	compiledIdentity *Identity
+)
+
+// SetCompiledDefault registers an identity compiled into the binary. It is used
+// when no identity file is selected explicitly (Options.ExplicitPath or
+// FULMEN_APP_IDENTITY_PATH), ahead of the ancestor search, so a binary with a
+// baked-in identity does not pick up another repository's .fulmen/app.yaml.
+//
+// Precedence: context injection → ExplicitPath → FULMEN_APP_IDENTITY_PATH →
+// compiled default → ancestor search. The identity is validated against the
+// app-identity schema. Call it before the first Get (typically from init).
+//
+// Example:
+//
+//	//go:embed app.yaml
+//	var appYAML []byte
+//
+//	func init() {
+//	    if err := appidentity.SetCompiledDefaultYAML(appYAML); err != nil {
+//	        panic(err)
+//	    }
+//	}
+func SetCompiledDefault(identity Identity) error {
+	if err := ValidateIdentity(context.Background(), &identity); err != nil {
+		return err
+	}
+	identity.Provenance = nil
+
+	compiledMu.Lock()
+	defer compiledMu.Unlock()
+	compiledIdentity = &identity
+	return nil
+}
+
+// SetCompiledDefaultYAML parses an identity document in the .fulmen/app.yaml
+// layout (e.g., embedded with go:embed) and registers it with SetCompiledDefault.
+func SetCompiledDefaultYAML(data []byte) error {
+	var file identityFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return &MalformedError{Path: "<compiled>", Err: err}
+	}
+	file.App.Metadata = file.Metadata
+	return SetCompiledDefault(file.App)
+}
+
+// compiledDefault returns a copy of the compiled-in identity, if any. Link-time
+// values are validated on use so an invalid build fails loudly.
+func compiledDefault(ctx context.Context) (*Identity, error) {
+	compiledMu.RLock()
+	registered := compiledIdentity
+	compiledMu.RUnlock()
+	if registered != nil {
+		identity := *registered
+		return &identity, nil
+	}
+
+	if compiledBinaryName == "" {
+		return nil, nil
+	}
+	// Unset env prefix, config name, and description derive from the binary name
+	opts := InitOptions{
+		BinaryName:  compiledBinaryName,
+		Vendor:      compiledVendor,
+		EnvPrefix:   compiledEnvPrefix,
+		ConfigName:  compiledConfigName,
+		Description: compiledDescription,
+	}
+	opts.fillDerived()
+	identity := &Identity{
+		BinaryName:  opts.BinaryName,
+		Vendor:      opts.Vendor,
+		EnvPrefix:   opts.EnvPrefix,
+		ConfigName:  opts.ConfigName,
+		Description: opts.Description,
+	}
+	if err := ValidateIdentity(ctx, identity); err != nil {
+		return nil, fmt.Errorf("invalid link-time identity (-ldflags -X): %w", err)
+	}
+	return identity, nil
+}
@@ -0,0 +1,124 @@
+package appidentity
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// resetCompiledDefault clears compiled-in identity state after a test.
+func resetCompiledDefault(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		compiledMu.Lock()
+		compiledIdentity = nil
+		compiledMu.Unlock()
+		compiledBinaryName, compiledVendor, compiledEnvPrefix, compiledConfigName, compiledDescription = "", "", "", "", ""
+	})
+}
+
+func TestSetCompiledDefault(t *testing.T) {
+	resetCompiledDefault(t)
+	t.Setenv(EnvIdentityPath, "")
+	if err := SetCompiledDefault(*NewFixture()); err != nil {
+		t.Fatalf("SetCompiledDefault() error = %v", err)
+	}
+
+	// Used instead of an ancestor identity file
+	root := t.TempDir()
+	writeWatchIdentity(t, mustMkdirIdentity(t, root), "from_file")
+
+	identity, err := GetWithOptions(context.Background(), Options{RepoRoot: root, NoCache: true})
+	if err != nil {
+		t.Fatalf("GetWithOptions() error = %v", err)
+	}
+	if identity.BinaryName != "testapp" {
+		t.Errorf("BinaryName = %q, want compiled default", identity.BinaryName)
+	}
+
+	// Returned identities are copies
+	identity.BinaryName = "mutated"
+	again, _ := GetWithOptions(context.Background(), Options{RepoRoot: root, NoCache: true})
+	if again.BinaryName != "testapp" {
+		t.Error("compiled default was mutated through a returned identity")
+	}
+}
+
+func TestCompiledDefaultRanksBelowExplicitPaths(t *testing.T) {
+	resetCompiledDefault(t)
+	if err := SetCompiledDefault(*NewFixture()); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	writeWatchIdentity(t, path, "explicit")
+
+	identity, err := GetWithOptions(context.Background(), Options{ExplicitPath: path, NoCache: true})
+	if err != nil || identity.BinaryName != "watchapp" {
+		t.Errorf("ExplicitPath: got %v, %v", identity, err)
+	}
+
+	t.Setenv(EnvIdentityPath, path)
+	identity, err = GetWithOptions(context.Background(), Options{NoCache: true})
+	if err != nil || identity.BinaryName != "watchapp" {
+		t.Errorf("%s: got %v, %v", EnvIdentityPath, identity, err)
+	}
+
+	injected := NewFixture(func(id *Identity) { id.BinaryName = "injected" })
+	identity, _ = GetWithOptions(WithIdentity(context.Background(), injected), Options{NoCache: true})
+	if identity.BinaryName != "injected" {
+		t.Errorf("context injection: got %q", identity.BinaryName)
+	}
+}
+
+func TestSetCompiledDefaultValidates(t *testing.T) {
+	resetCompiledDefault(t)
+	if err := SetCompiledDefault(Identity{BinaryName: "x"}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("SetCompiledDefault() error = %v, want ErrInvalid", err)
+	}
+	if err := SetCompiledDefaultYAML([]byte("app: [")); !errors.Is(err, ErrMalformed) {
+		t.Errorf("SetCompiledDefaultYAML() error = %v, want ErrMalformed", err)
+	}
+
+	data, err := os.ReadFile("testdata/valid-complete.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SetCompiledDefaultYAML(data); err != nil {
+		t.Fatalf("SetCompiledDefaultYAML() error = %v", err)
+	}
+	identity, err := compiledDefault(context.Background())
+	if err != nil || identity.Metadata.License != "MIT" {
+		t.Errorf("compiledDefault() = %+v, %v", identity, err)
+	}
+}
+
+func TestLinkTimeIdentity(t *testing.T) {
+	resetCompiledDefault(t)
+	t.Setenv(EnvIdentityPath, "")
+	compiledBinaryName = "scratch-svc"
+	compiledVendor = "acme"
+
+	identity, err := GetWithOptions(context.Background(), Options{RepoRoot: t.TempDir(), NoCache: true})
+	if err != nil {
+		t.Fatalf("GetWithOptions() error = %v", err)
+	}
+	if identity.EnvPrefix != "SCRATCH_SVC_" || identity.ConfigName != "scratch-svc" {
+		t.Errorf("derived fields = %+v", identity)
+	}
+
+	compiledVendor = "Not Valid"
+	if _, err := GetWithOptions(context.Background(), Options{RepoRoot: t.TempDir(), NoCache: true}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("invalid link-time identity error = %v, want ErrInvalid", err)
+	}
+}
+
+func mustMkdirIdentity(t *testing.T, root string) string {
+	t.Helper()
+	dir := filepath.Join(root, DefaultIdentityDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, DefaultIdentityFilename)
+}
@@ -14,6 +14,24 @@
 //  3. Environment variable - FULMEN_APP_IDENTITY_PATH
 //  4. Nearest ancestor search - Walk from cwd to filesystem root looking for .fulmen/app.yaml
 //
+// # Environment Overlays
+//
+// When FULMEN_ENV (or Options.Environment) is set, an overlay next to the base
+// file is deep-merged over it, e.g. FULMEN_ENV=staging merges
+// .fulmen/app.staging.yaml over .fulmen/app.yaml. The merged result is
+// validated against the schema, and Identity.Provenance records which file
+// supplied each field:
+//
+//	identity, err := appidentity.GetWithOptions(ctx, appidentity.Options{Environment: "staging"})
+//	fmt.Println(identity.Provenance["metadata.telemetry_namespace"])
+//
+// # Identity Inheritance
+//
+// An identity file may name a parent with a top-level extends field, resolved
+// relative to the file (a directory means its .fulmen/app.yaml). Parents are
+// merged first, so nested projects in a monorepo inherit workspace defaults
+// and override only what differs. Cycles return ErrExtendsCycle.
+//
 // # Usage
 //
 // Basic usage with automatic discovery:
@@ -0,0 +1,173 @@
+package appidentity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvEnvironment is the environment variable selecting the identity overlay
+// (e.g., FULMEN_ENV=staging loads .fulmen/app.staging.yaml over .fulmen/app.yaml).
+const EnvEnvironment = "FULMEN_ENV"
+
+// environmentNamePattern restricts environment names to safe filename components.
+var environmentNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// OverlayPath returns the environment overlay path for a base identity file.
+//
+// Example:
+//
+//	appidentity.OverlayPath(".fulmen/app.yaml", "staging") // ".fulmen/app.staging.yaml"
+func OverlayPath(basePath, environment string) string {
+	ext := filepath.Ext(basePath)
+	return strings.TrimSuffix(basePath, ext) + "." + environment + ext
+}
+
+// LoadFromEnvironment loads the base identity at path and merges the overlay
+// for environment over it, if present.
+//
+// The overlay (see OverlayPath) is deep-merged: mappings merge key by key and
+// any other value in the overlay replaces the base value. When an overlay is
+// applied, the merged result is validated against the app-identity schema.
+// A missing overlay is not an error; the base identity is returned unchanged.
+// An empty environment loads the base identity only.
+//
+// Identity.Provenance records which file supplied each field when an overlay is applied.
+//
+// Example:
+//
+//	identity, err := appidentity.LoadFromEnvironment(ctx, ".fulmen/app.yaml", "staging")
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(identity.Provenance["metadata.telemetry_namespace"]) // ".fulmen/app.staging.yaml"
+func LoadFromEnvironment(ctx context.Context, path, environment string) (*Identity, error) {
+	return loadLayeredIdentity(path, environment)
+}
+
+// resolveEnvironment returns the overlay environment from options or FULMEN_ENV.
+func resolveEnvironment(opts Options) string {
+	if opts.Environment != "" {
+		return opts.Environment
+	}
+	return os.Getenv(EnvEnvironment)
+}
+
+// loadLayeredIdentity loads the base identity file, the parents it extends,
+// and an environment overlay, merged in that order of precedence.
+func loadLayeredIdentity(basePath, environment string) (*Identity, error) {
+	if environment != "" && !environmentNamePattern.MatchString(environment) {
+		return nil, fmt.Errorf("invalid identity environment %q: must match %s", environment, environmentNamePattern)
+	}
+
+	layers, err := readIdentityChain(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if environment != "" {
+		overlayPath := OverlayPath(basePath, environment)
+		overlay, err := readIdentityDocument(overlayPath)
+		if err == nil {
+			layers = append(layers, identityLayer{path: overlayPath, doc: overlay})
+		} else {
+			var notFound *NotFoundError
+			if !errors.As(err, &notFound) {
+				return nil, err
+			}
+		}
+	}
+
+	// A single plain file loads as before, without merging
+	if len(layers) == 1 {
+		return loadIdentityFile(basePath)
+	}
+
+	merged, provenance := mergeLayers(layers)
+
+	// Validate the merged document, not the individual layers
+	v, err := getValidator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize validator: %w", err)
+	}
+	diagnostics, err := v.ValidateData(merged)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if valErr := diagnosticsToValidationError(layerPaths(layers), diagnostics); valErr != nil {
+		return nil, valErr
+	}
+
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode merged identity: %w", err)
+	}
+	var file identityFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, &MalformedError{Path: layers[len(layers)-1].path, Err: err}
+	}
+
+	file.App.Metadata = file.Metadata
+	file.App.Provenance = provenance
+	return &file.App, nil
+}
+
+// readIdentityDocument reads an identity file as a generic YAML mapping.
+func readIdentityDocument(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, &NotFoundError{SearchedPaths: []string{path}}
+		}
+		return nil, fmt.Errorf("failed to read identity file: %w", err)
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, &MalformedError{Path: path, Err: err}
+	}
+	if doc == nil {
+		doc = make(map[string]any)
+	}
+	return doc, nil
+}
+
+// mergeIdentityMaps deep-merges overlay over base, returning a new map.
+func mergeIdentityMaps(base, overlay map[string]any) map[string]any {
+	merged := make(map[string]any, len(base))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		baseMap, baseIsMap := merged[k].(map[string]any)
+		overlayMap, overlayIsMap := v.(map[string]any)
+		if baseIsMap && overlayIsMap {
+			merged[k] = mergeIdentityMaps(baseMap, overlayMap)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// recordProvenance records source for every leaf field of doc under prefix.
+// Later calls overwrite earlier ones, mirroring merge precedence.
+func recordProvenance(provenance map[string]string, prefix string, doc map[string]any, source string) {
+	for k, v := range doc {
+		field := k
+		if prefix != "" {
+			field = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]any); ok {
+			recordProvenance(provenance, field, nested, source)
+			continue
+		}
+		provenance[field] = source
+	}
+}
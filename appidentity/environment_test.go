@@ -0,0 +1,155 @@
+package appidentity
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeLayeredIdentity creates .fulmen/app.yaml (from valid-complete.yaml) and
+// optionally an overlay for env in a temp repo root.
+func writeLayeredIdentity(t *testing.T, env, overlay string) string {
+	t.Helper()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, DefaultIdentityDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create identity dir: %v", err)
+	}
+
+	base, err := os.ReadFile(filepath.Join("testdata", "valid-complete.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	basePath := filepath.Join(dir, DefaultIdentityFilename)
+	if err := os.WriteFile(basePath, base, 0644); err != nil {
+		t.Fatalf("failed to write base identity: %v", err)
+	}
+
+	if overlay != "" {
+		if err := os.WriteFile(OverlayPath(basePath, env), []byte(overlay), 0644); err != nil {
+			t.Fatalf("failed to write overlay: %v", err)
+		}
+	}
+	return root
+}
+
+func TestOverlayPath(t *testing.T) {
+	got := OverlayPath(filepath.Join(".fulmen", "app.yaml"), "staging")
+	want := filepath.Join(".fulmen", "app.staging.yaml")
+	if got != want {
+		t.Errorf("OverlayPath() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadWithEnvironmentOverlay(t *testing.T) {
+	root := writeLayeredIdentity(t, "staging", `
+app:
+  description: Staging deployment
+metadata:
+  telemetry_namespace: myapp_staging
+  python:
+    package_name: my_app_staging
+`)
+	basePath := filepath.Join(root, DefaultIdentityPath)
+	overlayPath := OverlayPath(basePath, "staging")
+
+	identity, err := LoadFromEnvironment(context.Background(), basePath, "staging")
+	if err != nil {
+		t.Fatalf("LoadFromEnvironment() failed: %v", err)
+	}
+
+	if identity.Description != "Staging deployment" {
+		t.Errorf("expected overlay description, got %q", identity.Description)
+	}
+	if identity.BinaryName != "myapp" {
+		t.Errorf("expected base binary name to be kept, got %q", identity.BinaryName)
+	}
+	if identity.TelemetryNamespace() != "myapp_staging" {
+		t.Errorf("expected overlay telemetry namespace, got %q", identity.TelemetryNamespace())
+	}
+	if identity.Metadata.Python == nil || identity.Metadata.Python.PackageName != "my_app_staging" {
+		t.Errorf("expected nested overlay value, got %+v", identity.Metadata.Python)
+	}
+	if identity.Metadata.Python.DistributionName != "my-app" {
+		t.Errorf("expected nested base value to be kept, got %q", identity.Metadata.Python.DistributionName)
+	}
+	if identity.Metadata.Extras["custom_field"] != "custom_value" {
+		t.Errorf("expected base extras to be kept, got %v", identity.Metadata.Extras)
+	}
+
+	provenance := map[string]string{
+		"app.description":                   overlayPath,
+		"app.binary_name":                   basePath,
+		"metadata.telemetry_namespace":      overlayPath,
+		"metadata.python.package_name":      overlayPath,
+		"metadata.python.distribution_name": basePath,
+	}
+	for field, want := range provenance {
+		if got := identity.Provenance[field]; got != want {
+			t.Errorf("Provenance[%q] = %q, want %q", field, got, want)
+		}
+	}
+}
+
+func TestLoadWithEnvironmentMissingOverlay(t *testing.T) {
+	root := writeLayeredIdentity(t, "prod", "")
+
+	identity, err := LoadFromEnvironment(context.Background(), filepath.Join(root, DefaultIdentityPath), "prod")
+	if err != nil {
+		t.Fatalf("LoadFromEnvironment() failed: %v", err)
+	}
+	if identity.BinaryName != "myapp" {
+		t.Errorf("expected base identity, got %q", identity.BinaryName)
+	}
+	if identity.Provenance != nil {
+		t.Errorf("expected no provenance without overlay, got %v", identity.Provenance)
+	}
+}
+
+func TestLoadWithEnvironmentInvalidMerge(t *testing.T) {
+	root := writeLayeredIdentity(t, "staging", `
+app:
+  env_prefix: lowercase_
+`)
+
+	_, err := LoadFromEnvironment(context.Background(), filepath.Join(root, DefaultIdentityPath), "staging")
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid for invalid merged identity, got %v", err)
+	}
+}
+
+func TestLoadWithEnvironmentRejectsUnsafeName(t *testing.T) {
+	root := writeLayeredIdentity(t, "staging", "")
+
+	if _, err := LoadFromEnvironment(context.Background(), filepath.Join(root, DefaultIdentityPath), "../prod"); err == nil {
+		t.Fatal("expected error for unsafe environment name")
+	}
+}
+
+func TestGetWithOptionsUsesFulmenEnv(t *testing.T) {
+	root := writeLayeredIdentity(t, "staging", `
+app:
+  description: From FULMEN_ENV
+`)
+	t.Setenv(EnvEnvironment, "staging")
+
+	identity, err := GetWithOptions(context.Background(), Options{RepoRoot: root, NoCache: true})
+	if err != nil {
+		t.Fatalf("GetWithOptions() failed: %v", err)
+	}
+	if identity.Description != "From FULMEN_ENV" {
+		t.Errorf("expected FULMEN_ENV overlay, got %q", identity.Description)
+	}
+
+	// Explicit option takes precedence over FULMEN_ENV
+	identity, err = GetWithOptions(context.Background(), Options{RepoRoot: root, NoCache: true, Environment: "prod"})
+	if err != nil {
+		t.Fatalf("GetWithOptions() failed: %v", err)
+	}
+	if identity.Description == "From FULMEN_ENV" {
+		t.Error("expected Options.Environment to override FULMEN_ENV")
+	}
+}
@@ -16,6 +16,9 @@ var (
 
 	// ErrMalformed is returned when YAML cannot be parsed.
 	ErrMalformed = errors.New("app identity file malformed")
+
+	// ErrExtendsCycle is returned when identity files extend each other in a cycle.
+	ErrExtendsCycle = errors.New("app identity extends cycle")
 )
 
 // NotFoundError provides detailed information about identity file discovery failure.
@@ -0,0 +1,117 @@
+package appidentity
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxExtendsDepth is the maximum number of parent identities an extends chain may contain.
+const MaxExtendsDepth = 10
+
+// extendsKey is the top-level identity file field naming a parent identity.
+const extendsKey = "extends"
+
+// identityLayer is one identity document in merge order.
+type identityLayer struct {
+	path string
+	doc  map[string]any
+}
+
+// readIdentityChain reads the identity at path and every parent it extends,
+// returning the layers from the outermost parent down to path. The extends
+// field is removed from each document.
+//
+// An extends value is a path relative to the extending file. A directory
+// refers to its .fulmen/app.yaml, so a nested project can write:
+//
+//	extends: ../..   # workspace-level .fulmen/app.yaml
+//	app:
+//	  binary_name: api
+func readIdentityChain(path string) ([]identityLayer, error) {
+	var chain []identityLayer
+	seen := make(map[string]bool)
+
+	current := path
+	for {
+		abs, err := filepath.Abs(current)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve identity path %s: %w", current, err)
+		}
+		if seen[abs] {
+			return nil, fmt.Errorf("%w: %s", ErrExtendsCycle, chainDescription(chain, current))
+		}
+		seen[abs] = true
+
+		doc, err := readIdentityDocument(current)
+		if err != nil {
+			if len(chain) > 0 {
+				return nil, fmt.Errorf("identity %s extends %s: %w", chain[len(chain)-1].path, current, err)
+			}
+			return nil, err
+		}
+		parent, hasParent := doc[extendsKey]
+		delete(doc, extendsKey)
+		chain = append(chain, identityLayer{path: current, doc: doc})
+		if !hasParent {
+			break
+		}
+
+		parentPath, ok := parent.(string)
+		if !ok || strings.TrimSpace(parentPath) == "" {
+			return nil, &MalformedError{Path: current, Err: errors.New("extends must be a non-empty path")}
+		}
+		if len(chain) > MaxExtendsDepth {
+			return nil, fmt.Errorf("identity %s: extends chain exceeds %d levels", path, MaxExtendsDepth)
+		}
+		current = resolveExtendsPath(current, parentPath)
+	}
+
+	// Reverse into merge order: outermost parent first
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain, nil
+}
+
+// resolveExtendsPath resolves an extends value against the extending file.
+func resolveExtendsPath(from, target string) string {
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(from), target)
+	}
+	if info, err := os.Stat(target); err == nil && info.IsDir() {
+		target = filepath.Join(target, DefaultIdentityPath)
+	}
+	return filepath.Clean(target)
+}
+
+// chainDescription renders the files visited so far, ending with next.
+func chainDescription(chain []identityLayer, next string) string {
+	paths := make([]string, 0, len(chain)+1)
+	for _, layer := range chain {
+		paths = append(paths, layer.path)
+	}
+	return strings.Join(append(paths, next), " -> ")
+}
+
+// mergeLayers deep-merges layers in order and records which file supplied each field.
+func mergeLayers(layers []identityLayer) (map[string]any, map[string]string) {
+	merged := make(map[string]any)
+	provenance := make(map[string]string)
+	for _, layer := range layers {
+		recordProvenance(provenance, "", layer.doc, layer.path)
+		merged = mergeIdentityMaps(merged, layer.doc)
+	}
+	return merged, provenance
+}
+
+// layerPaths joins layer paths for error messages.
+func layerPaths(layers []identityLayer) string {
+	paths := make([]string, len(layers))
+	for i, layer := range layers {
+		paths[i] = layer.path
+	}
+	return strings.Join(paths, " + ")
+}
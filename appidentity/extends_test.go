@@ -0,0 +1,175 @@
+package appidentity
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeIdentity writes content to path, creating parent directories.
+func writeIdentity(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write identity: %v", err)
+	}
+}
+
+// writeWorkspace creates a workspace identity and a nested project that extends it.
+func writeWorkspace(t *testing.T, child string) (workspacePath, childPath string) {
+	t.Helper()
+
+	root := t.TempDir()
+	workspacePath = filepath.Join(root, DefaultIdentityPath)
+	writeIdentity(t, workspacePath, `
+app:
+  binary_name: workspace
+  vendor: acme
+  env_prefix: ACME_
+  config_name: workspace
+  description: Shared workspace defaults
+metadata:
+  license: Apache-2.0
+  project_url: https://github.com/acme/monorepo
+`)
+
+	childPath = filepath.Join(root, "services", "api", DefaultIdentityPath)
+	writeIdentity(t, childPath, child)
+	return workspacePath, childPath
+}
+
+func TestLoadWithExtends(t *testing.T) {
+	workspacePath, childPath := writeWorkspace(t, `
+extends: ../../..
+app:
+  binary_name: api
+  env_prefix: API_
+  config_name: api
+metadata:
+  telemetry_namespace: acme_api
+`)
+
+	identity, err := LoadFromEnvironment(context.Background(), childPath, "")
+	if err != nil {
+		t.Fatalf("LoadFromEnvironment() failed: %v", err)
+	}
+
+	if identity.BinaryName != "api" {
+		t.Errorf("expected child binary name, got %q", identity.BinaryName)
+	}
+	if identity.Vendor != "acme" {
+		t.Errorf("expected inherited vendor, got %q", identity.Vendor)
+	}
+	if identity.Metadata.License != "Apache-2.0" {
+		t.Errorf("expected inherited license, got %q", identity.Metadata.License)
+	}
+
+	provenance := map[string]string{
+		"app.binary_name":              childPath,
+		"app.vendor":                   workspacePath,
+		"metadata.license":             workspacePath,
+		"metadata.telemetry_namespace": childPath,
+	}
+	for field, want := range provenance {
+		if got := identity.Provenance[field]; got != want {
+			t.Errorf("Provenance[%q] = %q, want %q", field, got, want)
+		}
+	}
+	if _, ok := identity.Provenance[extendsKey]; ok {
+		t.Error("extends should not be recorded in provenance")
+	}
+}
+
+func TestLoadWithExtendsAndOverlay(t *testing.T) {
+	_, childPath := writeWorkspace(t, `
+extends: ../../../.fulmen/app.yaml
+app:
+  binary_name: api
+  env_prefix: API_
+  config_name: api
+`)
+	overlayPath := OverlayPath(childPath, "staging")
+	writeIdentity(t, overlayPath, `
+app:
+  vendor: acmestaging
+`)
+
+	identity, err := LoadFromEnvironment(context.Background(), childPath, "staging")
+	if err != nil {
+		t.Fatalf("LoadFromEnvironment() failed: %v", err)
+	}
+	if identity.Vendor != "acmestaging" {
+		t.Errorf("expected overlay to win over parent, got %q", identity.Vendor)
+	}
+	if got := identity.Provenance["app.vendor"]; got != overlayPath {
+		t.Errorf("Provenance[app.vendor] = %q, want %q", got, overlayPath)
+	}
+}
+
+func TestLoadWithExtendsInvalidMerge(t *testing.T) {
+	_, childPath := writeWorkspace(t, `
+extends: ../../..
+app:
+  env_prefix: lowercase_
+`)
+
+	_, err := LoadFromEnvironment(context.Background(), childPath, "")
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid for invalid merged identity, got %v", err)
+	}
+}
+
+func TestLoadWithExtendsCycle(t *testing.T) {
+	root := t.TempDir()
+	a := filepath.Join(root, "a.yaml")
+	b := filepath.Join(root, "b.yaml")
+	writeIdentity(t, a, "extends: b.yaml\n")
+	writeIdentity(t, b, "extends: a.yaml\n")
+
+	_, err := LoadFromEnvironment(context.Background(), a, "")
+	if !errors.Is(err, ErrExtendsCycle) {
+		t.Fatalf("expected ErrExtendsCycle, got %v", err)
+	}
+	if !strings.Contains(err.Error(), a+" -> "+b+" -> "+a) {
+		t.Errorf("expected cycle chain in error, got %v", err)
+	}
+}
+
+func TestLoadWithExtendsMissingParent(t *testing.T) {
+	root := t.TempDir()
+	childPath := filepath.Join(root, DefaultIdentityPath)
+	writeIdentity(t, childPath, "extends: ../missing.yaml\n")
+
+	_, err := LoadFromEnvironment(context.Background(), childPath, "")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for missing parent, got %v", err)
+	}
+}
+
+func TestLoadWithExtendsInvalidValue(t *testing.T) {
+	root := t.TempDir()
+	childPath := filepath.Join(root, DefaultIdentityPath)
+	writeIdentity(t, childPath, "extends: [a, b]\n")
+
+	_, err := LoadFromEnvironment(context.Background(), childPath, "")
+	if !errors.Is(err, ErrMalformed) {
+		t.Fatalf("expected ErrMalformed for non-string extends, got %v", err)
+	}
+}
+
+func TestValidateWithExtends(t *testing.T) {
+	_, childPath := writeWorkspace(t, `
+extends: ../../..
+app:
+  binary_name: api
+`)
+
+	if err := Validate(context.Background(), childPath); err != nil {
+		t.Errorf("Validate() failed for merged identity: %v", err)
+	}
+}
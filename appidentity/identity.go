@@ -31,6 +31,11 @@ type Identity struct {
 	// the schema expects it as a sibling of "app", not nested within it.
 	// Use the identityFile wrapper for proper YAML/JSON structure.
 	Metadata Metadata `yaml:"metadata,omitempty" json:"-"`
+
+	// Provenance maps each field (dotted path, e.g., "app.vendor" or
+	// "metadata.license") to the file that supplied it. It is populated only
+	// when an environment overlay was merged (see LoadFromEnvironment).
+	Provenance map[string]string `yaml:"-" json:"-"`
 }
 
 // Metadata holds optional identity metadata for enhanced application information.
@@ -58,6 +63,9 @@ type Metadata struct {
 	// Python contains Python-specific packaging metadata (optional).
 	Python *PythonMetadata `yaml:"python,omitempty" json:"python,omitempty"`
 
+	// Paths overrides per-application directories (optional, see Identity.Dir).
+	Paths *PathsMetadata `yaml:"paths,omitempty" json:"paths,omitempty"`
+
 	// Extras holds additional properties for extensibility.
 	// Applications can store custom metadata here beyond the standard fields.
 	//
@@ -0,0 +1,255 @@
+package appidentity
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"gopkg.in/yaml.v3"
+)
+
+// InitOptions supplies values for a generated identity file. Empty fields are
+// inferred (see InferInitOptions) or derived from BinaryName.
+type InitOptions struct {
+	// BinaryName is the application's binary name. Default: last element of
+	// the go.mod module path (without a /vN suffix), else the directory name.
+	BinaryName string
+
+	// Vendor is the vendor namespace. Default: the module path owner
+	// (e.g., "fulmenhq" for github.com/fulmenhq/gofulmen).
+	Vendor string
+
+	// EnvPrefix is the environment variable prefix. Default: BinaryName in
+	// upper snake case with a trailing underscore (e.g., "MY_APP_").
+	EnvPrefix string
+
+	// ConfigName is the config directory name. Default: BinaryName.
+	ConfigName string
+
+	// Description is a one-line description (10-200 characters).
+	// Default: "<BinaryName> application".
+	Description string
+
+	// ProjectURL sets metadata.project_url. Default: https://<module path>
+	// for modules hosted on a known forge (github.com, gitlab.com, ...).
+	ProjectURL string
+
+	// License sets metadata.license (SPDX identifier). Optional.
+	License string
+
+	// RepositoryCategory sets metadata.repository_category (e.g., "cli"). Optional.
+	RepositoryCategory string
+
+	// Overwrite replaces an existing identity file.
+	Overwrite bool
+}
+
+// knownForges are module hosts whose module paths map to browsable project URLs.
+var knownForges = map[string]bool{
+	"github.com":    true,
+	"gitlab.com":    true,
+	"bitbucket.org": true,
+	"codeberg.org":  true,
+}
+
+// InferInitOptions infers identity values for a repository rooted at (or
+// containing) dir from the nearest go.mod module path. Fields that cannot be
+// inferred are left empty, except BinaryName, which falls back to the
+// directory name.
+//
+// Example:
+//
+//	opts := appidentity.InferInitOptions(".")
+//	// module github.com/fulmenhq/gofulmen →
+//	// BinaryName "gofulmen", Vendor "fulmenhq", EnvPrefix "GOFULMEN_",
+//	// ProjectURL "https://github.com/fulmenhq/gofulmen"
+func InferInitOptions(dir string) InitOptions {
+	var opts InitOptions
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		absDir = dir
+	}
+
+	if modulePath := findModulePath(absDir); modulePath != "" {
+		prefix, _, ok := module.SplitPathVersion(modulePath)
+		if !ok {
+			prefix = modulePath
+		}
+		elems := strings.Split(prefix, "/")
+		opts.BinaryName = sanitizeSlug(elems[len(elems)-1])
+		if len(elems) >= 3 && strings.Contains(elems[0], ".") {
+			opts.Vendor = sanitizeVendor(elems[1])
+			if knownForges[elems[0]] {
+				opts.ProjectURL = "https://" + strings.Join(elems[:3], "/")
+			}
+		}
+	}
+	if opts.BinaryName == "" {
+		opts.BinaryName = sanitizeSlug(filepath.Base(absDir))
+	}
+
+	opts.fillDerived()
+	return opts
+}
+
+// Init generates a schema-valid identity file at path (default:
+// DefaultIdentityPath). Values missing from opts are inferred from the
+// repository containing path (the parent of a ".fulmen" directory).
+//
+// The identity is validated before anything is written. Init refuses to
+// replace an existing file unless opts.Overwrite is set.
+//
+// Example:
+//
+//	identity, err := appidentity.Init(".fulmen/app.yaml", appidentity.InitOptions{
+//	    Description: "Fulmen workhorse for schema exports",
+//	    License:     "MIT",
+//	})
+func Init(path string, opts InitOptions) (*Identity, error) {
+	if path == "" {
+		path = DefaultIdentityPath
+	}
+	if !opts.Overwrite {
+		if _, err := os.Stat(path); err == nil {
+			return nil, fmt.Errorf("identity file %s already exists: %w", path, os.ErrExist)
+		}
+	}
+
+	repoRoot := filepath.Dir(path)
+	if filepath.Base(repoRoot) == DefaultIdentityDir {
+		repoRoot = filepath.Dir(repoRoot)
+	}
+	inferred := InferInitOptions(repoRoot)
+	if opts.BinaryName == "" {
+		opts.BinaryName = inferred.BinaryName
+	}
+	if opts.Vendor == "" {
+		opts.Vendor = inferred.Vendor
+	}
+	if opts.ProjectURL == "" {
+		opts.ProjectURL = inferred.ProjectURL
+	}
+	opts.fillDerived()
+
+	identity := &Identity{
+		BinaryName:  opts.BinaryName,
+		Vendor:      opts.Vendor,
+		EnvPrefix:   opts.EnvPrefix,
+		ConfigName:  opts.ConfigName,
+		Description: opts.Description,
+		Metadata: Metadata{
+			ProjectURL:         opts.ProjectURL,
+			License:            opts.License,
+			RepositoryCategory: opts.RepositoryCategory,
+		},
+	}
+	if err := ValidateIdentity(context.Background(), identity); err != nil {
+		return nil, err
+	}
+
+	data, err := marshalIdentityFile(identity)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { // #nosec G301 -- identity directory is committed to the repository
+		return nil, fmt.Errorf("failed to create identity directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil { // #nosec G306 -- identity file is committed to the repository
+		return nil, fmt.Errorf("failed to write identity file: %w", err)
+	}
+	return identity, nil
+}
+
+// fillDerived fills EnvPrefix, ConfigName, and Description from BinaryName.
+func (o *InitOptions) fillDerived() {
+	if o.BinaryName == "" {
+		return
+	}
+	if o.EnvPrefix == "" {
+		o.EnvPrefix = strings.ToUpper(strings.ReplaceAll(o.BinaryName, "-", "_")) + "_"
+	}
+	if o.ConfigName == "" {
+		o.ConfigName = o.BinaryName
+	}
+	if o.Description == "" {
+		o.Description = o.BinaryName + " application"
+	}
+}
+
+// marshalIdentityFile renders identity in the .fulmen/app.yaml layout.
+func marshalIdentityFile(identity *Identity) ([]byte, error) {
+	app := *identity
+	app.Metadata = Metadata{}
+	file := struct {
+		App      Identity  `yaml:"app"`
+		Metadata *Metadata `yaml:"metadata,omitempty"`
+	}{App: app}
+	if !isZeroMetadata(identity.Metadata) {
+		file.Metadata = &identity.Metadata
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# Application identity (see appidentity package documentation)\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(file); err != nil {
+		return nil, fmt.Errorf("failed to encode identity: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode identity: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func isZeroMetadata(m Metadata) bool {
+	return m.ProjectURL == "" && m.SupportEmail == "" && m.License == "" &&
+		m.RepositoryCategory == "" && m.TelemetryNamespace == "" && m.RegistryID == "" &&
+		m.Python == nil && m.Paths == nil && len(m.Extras) == 0
+}
+
+// findModulePath returns the module path of the nearest go.mod at or above dir.
+func findModulePath(dir string) string {
+	for depth := 0; depth < MaxSearchDepth; depth++ {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod")) // #nosec G304 -- go.mod discovery walks parent directories by design
+		if err == nil {
+			return modfile.ModulePath(data)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return ""
+}
+
+// sanitizeSlug lowercases s and replaces characters outside [a-z0-9-] with hyphens.
+func sanitizeSlug(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	// Slugs must start with a letter and end with a letter or digit
+	return strings.TrimRight(strings.TrimLeft(b.String(), "-0123456789"), "-")
+}
+
+// sanitizeVendor lowercases s and drops characters outside [a-z0-9].
+func sanitizeVendor(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimLeft(b.String(), "0123456789")
+}
@@ -0,0 +1,131 @@
+package appidentity
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeGoMod(t *testing.T, dir, modulePath string) {
+	t.Helper()
+	content := "module " + modulePath + "\n\ngo 1.25\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInferInitOptions(t *testing.T) {
+	tests := []struct {
+		module     string
+		binary     string
+		vendor     string
+		envPrefix  string
+		projectURL string
+	}{
+		{"github.com/fulmenhq/gofulmen", "gofulmen", "fulmenhq", "GOFULMEN_", "https://github.com/fulmenhq/gofulmen"},
+		{"github.com/Acme-Corp/my-tool/v2", "my-tool", "acmecorp", "MY_TOOL_", "https://github.com/Acme-Corp/my-tool"},
+		{"example.com/team/svc/cmd/worker", "worker", "team", "WORKER_", ""},
+		{"localtool", "localtool", "", "LOCALTOOL_", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.module, func(t *testing.T) {
+			dir := t.TempDir()
+			writeGoMod(t, dir, tt.module)
+			sub := filepath.Join(dir, "internal")
+			if err := os.Mkdir(sub, 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			opts := InferInitOptions(sub)
+			if opts.BinaryName != tt.binary || opts.Vendor != tt.vendor || opts.EnvPrefix != tt.envPrefix || opts.ProjectURL != tt.projectURL {
+				t.Errorf("InferInitOptions() = %+v", opts)
+			}
+			if opts.ConfigName != tt.binary {
+				t.Errorf("ConfigName = %q, want %q", opts.ConfigName, tt.binary)
+			}
+		})
+	}
+}
+
+func TestInitGeneratesValidIdentity(t *testing.T) {
+	dir := t.TempDir()
+	writeGoMod(t, dir, "github.com/fulmenhq/widget")
+	path := filepath.Join(dir, ".fulmen", "app.yaml")
+
+	identity, err := Init(path, InitOptions{License: "MIT", RepositoryCategory: "cli"})
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if identity.BinaryName != "widget" || identity.Vendor != "fulmenhq" || identity.EnvPrefix != "WIDGET_" {
+		t.Errorf("Init() identity = %+v", identity)
+	}
+
+	if err := Validate(context.Background(), path); err != nil {
+		t.Fatalf("generated file is invalid: %v", err)
+	}
+	loaded, err := LoadFrom(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Metadata.License != "MIT" || loaded.Metadata.ProjectURL != "https://github.com/fulmenhq/widget" {
+		t.Errorf("loaded metadata = %+v", loaded.Metadata)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "  metadata:") {
+		t.Errorf("metadata must be a sibling of app:\n%s", data)
+	}
+}
+
+func TestInitRefusesOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.yaml")
+	opts := InitOptions{BinaryName: "myapp", Vendor: "myvendor"}
+
+	if _, err := Init(path, opts); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if _, err := Init(path, opts); !errors.Is(err, os.ErrExist) {
+		t.Errorf("second Init() error = %v, want os.ErrExist", err)
+	}
+
+	opts.Overwrite = true
+	opts.Description = "Replacement identity description"
+	identity, err := Init(path, opts)
+	if err != nil {
+		t.Fatalf("Init() with Overwrite error = %v", err)
+	}
+	if identity.Description != opts.Description {
+		t.Errorf("Description = %q", identity.Description)
+	}
+}
+
+func TestInitValidatesBeforeWriting(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".fulmen", "app.yaml")
+
+	// No go.mod and no vendor: the generated identity is incomplete
+	_, err := Init(path, InitOptions{BinaryName: "myapp"})
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Init() error = %v, want ErrInvalid", err)
+	}
+	if _, statErr := os.Stat(path); !os.IsNotExist(statErr) {
+		t.Error("invalid identity should not be written")
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	if got := sanitizeSlug("My_Tool.v2"); got != "my-tool-v2" {
+		t.Errorf("sanitizeSlug() = %q", got)
+	}
+	if got := sanitizeSlug("9lives-"); got != "lives" {
+		t.Errorf("sanitizeSlug() = %q", got)
+	}
+	if got := sanitizeVendor("Fulmen-HQ"); got != "fulmenhq" {
+		t.Errorf("sanitizeVendor() = %q", got)
+	}
+}
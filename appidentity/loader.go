@@ -62,6 +62,11 @@ type Options struct {
 	// Default: current working directory.
 	RepoRoot string
 
+	// Environment selects an identity overlay (e.g., "staging" loads
+	// .fulmen/app.staging.yaml over .fulmen/app.yaml).
+	// Default: the FULMEN_ENV environment variable; empty loads the base identity only.
+	Environment string
+
 	// NoCache bypasses the process-level cache (testing only).
 	// When true, each call loads identity fresh from disk.
 	NoCache bool
@@ -71,7 +76,8 @@ type Options struct {
 //
 // This function is useful for testing or when you need to load identity from a
 // non-standard location. It does not perform validation - use Validate() separately
-// if schema validation is needed.
+// if schema validation is needed. A file that extends a parent identity is merged
+// with its parents, and the merged result is validated.
 //
 // Example:
 //
@@ -80,7 +86,7 @@ type Options struct {
 //	    return fmt.Errorf("failed to load identity: %w", err)
 //	}
 func LoadFrom(ctx context.Context, path string) (*Identity, error) {
-	identity, err := loadIdentityFile(path)
+	identity, err := loadLayeredIdentity(path, "")
 	if err != nil {
 		return nil, err
 	}
@@ -170,8 +176,30 @@ func findIdentityFile(startDir string) (string, error) {
 //  1. Context injection (checked by caller)
 //  2. ExplicitPath in Options
 //  3. Environment variable (FULMEN_APP_IDENTITY_PATH)
-//  4. Nearest ancestor search from RepoRoot (default: cwd)
+//  4. Compiled default (SetCompiledDefault or -ldflags)
+//  5. Nearest ancestor search from RepoRoot (default: cwd)
+//
+// If an environment is set (opts.Environment or FULMEN_ENV), its overlay file
+// next to the discovered identity is merged over it.
 func discoverIdentity(ctx context.Context, opts Options) (*Identity, error) {
+	// A compiled-in identity ranks below explicit selections but above the ancestor search
+	if opts.ExplicitPath == "" && os.Getenv(EnvIdentityPath) == "" {
+		identity, err := compiledDefault(ctx)
+		if err != nil || identity != nil {
+			return identity, err
+		}
+	}
+
+	identityPath, err := resolveIdentityPath(opts)
+	if err != nil {
+		return nil, err
+	}
+	return loadLayeredIdentity(identityPath, resolveEnvironment(opts))
+}
+
+// resolveIdentityPath locates the base identity file using the discovery
+// precedence of discoverIdentity.
+func resolveIdentityPath(opts Options) (string, error) {
 	var identityPath string
 	var err error
 
@@ -180,11 +208,11 @@ func discoverIdentity(ctx context.Context, opts Options) (*Identity, error) {
 		identityPath = opts.ExplicitPath
 		if _, err := os.Stat(identityPath); err != nil {
 			if os.IsNotExist(err) {
-				return nil, &NotFoundError{
+				return "", &NotFoundError{
 					SearchedPaths: []string{identityPath + " (explicit path)"},
 				}
 			}
-			return nil, fmt.Errorf("failed to access identity file: %w", err)
+			return "", fmt.Errorf("failed to access identity file: %w", err)
 		}
 	} else {
 		// Priority 2-4: Environment variable or ancestor search (handled by findIdentityFile)
@@ -192,15 +220,15 @@ func discoverIdentity(ctx context.Context, opts Options) (*Identity, error) {
 		if startDir == "" {
 			startDir, err = os.Getwd()
 			if err != nil {
-				return nil, fmt.Errorf("failed to get current directory: %w", err)
+				return "", fmt.Errorf("failed to get current directory: %w", err)
 			}
 		}
 
 		identityPath, err = findIdentityFile(startDir)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 	}
 
-	return loadIdentityFile(identityPath)
+	return identityPath, nil
 }
@@ -0,0 +1,224 @@
+package appidentity
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ErrNoHomeDir is returned when a per-app directory cannot be derived because
+// neither an override nor the user's home directory is available.
+var ErrNoHomeDir = errors.New("cannot determine user home directory")
+
+// DirKind identifies a per-application directory category.
+type DirKind string
+
+// Directory kinds resolved by Identity.Dir.
+const (
+	DirConfig DirKind = "config"
+	DirCache  DirKind = "cache"
+	DirData   DirKind = "data"
+	DirState  DirKind = "state"
+)
+
+// PathsMetadata overrides per-application directories from the identity file:
+//
+//	metadata:
+//	  paths:
+//	    cache_dir: /var/cache/myapp
+//
+// A leading "~" expands to the user's home directory.
+type PathsMetadata struct {
+	ConfigDir string `yaml:"config_dir,omitempty" json:"config_dir,omitempty"`
+	CacheDir  string `yaml:"cache_dir,omitempty" json:"cache_dir,omitempty"`
+	DataDir   string `yaml:"data_dir,omitempty" json:"data_dir,omitempty"`
+	StateDir  string `yaml:"state_dir,omitempty" json:"state_dir,omitempty"`
+}
+
+// ConfigDir returns the application's configuration directory.
+//
+// See Dir for resolution order.
+func (i *Identity) ConfigDir() (string, error) {
+	return i.Dir(DirConfig)
+}
+
+// CacheDir returns the application's cache directory.
+func (i *Identity) CacheDir() (string, error) {
+	return i.Dir(DirCache)
+}
+
+// DataDir returns the application's data directory.
+func (i *Identity) DataDir() (string, error) {
+	return i.Dir(DirData)
+}
+
+// StateDir returns the application's state directory (logs, history, runtime state).
+func (i *Identity) StateDir() (string, error) {
+	return i.Dir(DirState)
+}
+
+// Dir returns the per-application directory of the given kind. Resolution order:
+//
+//  1. Environment variable <EnvPrefix><KIND>_DIR (e.g., GOFULMEN_CACHE_DIR)
+//  2. metadata.paths.<kind>_dir in the identity file
+//  3. Platform default: <base>/<vendor>/<config_name>
+//
+// Platform bases follow the Fulmen config path standard. XDG_CONFIG_HOME,
+// XDG_CACHE_HOME, XDG_DATA_HOME, and XDG_STATE_HOME are honored on every
+// platform when set; otherwise:
+//
+//	          Linux/Unix       macOS                          Windows
+//	config    ~/.config        ~/Library/Application Support  %APPDATA%
+//	cache     ~/.cache         ~/Library/Caches               %LOCALAPPDATA%\Cache
+//	data      ~/.local/share   ~/Library/Application Support  %LOCALAPPDATA%
+//	state     ~/.local/state   ~/Library/Application Support  %LOCALAPPDATA%
+//
+// ConfigName is used as the directory name, falling back to BinaryName.
+// Directories are not created.
+//
+// Example:
+//
+//	dir, err := identity.CacheDir() // ~/.cache/fulmenhq/gofulmen on Linux
+func (i *Identity) Dir(kind DirKind) (string, error) {
+	return i.resolveDir(kind, runtime.GOOS, os.Getenv, os.UserHomeDir)
+}
+
+// BaseDir returns the platform base directory of the given kind, without any
+// per-application component (e.g., ~/.config on Linux, ~/Library/Application
+// Support on macOS). Dir appends <vendor>/<config_name> to it; packages that
+// derive directories from a bare app name (such as config.GetAppConfigDir)
+// use it so every Fulmen directory follows the same platform rules.
+func BaseDir(kind DirKind) (string, error) {
+	return platformBaseDir(kind, runtime.GOOS, os.Getenv, os.UserHomeDir)
+}
+
+// resolveDir implements Dir with injectable platform inputs for testing.
+func (i *Identity) resolveDir(kind DirKind, goos string, getenv func(string) string, homeDir func() (string, error)) (string, error) {
+	override, err := i.dirOverride(kind, getenv)
+	if err != nil {
+		return "", err
+	}
+	if override != "" {
+		return expandHome(override, homeDir)
+	}
+
+	base, err := platformBaseDir(kind, goos, getenv, homeDir)
+	if err != nil {
+		return "", err
+	}
+
+	name := i.ConfigName
+	if name == "" {
+		name = i.BinaryName
+	}
+	if name == "" {
+		return "", fmt.Errorf("identity has no config_name or binary_name for %s directory", kind)
+	}
+	if i.Vendor == "" {
+		return filepath.Join(base, name), nil
+	}
+	return filepath.Join(base, i.Vendor, name), nil
+}
+
+// dirOverride returns the environment or identity-file override for kind.
+func (i *Identity) dirOverride(kind DirKind, getenv func(string) string) (string, error) {
+	var fromFile string
+	switch kind {
+	case DirConfig:
+		fromFile = i.paths().ConfigDir
+	case DirCache:
+		fromFile = i.paths().CacheDir
+	case DirData:
+		fromFile = i.paths().DataDir
+	case DirState:
+		fromFile = i.paths().StateDir
+	default:
+		return "", fmt.Errorf("unknown directory kind %q", kind)
+	}
+
+	if i.EnvPrefix != "" {
+		if v := getenv(i.EnvVar(strings.ToUpper(string(kind)) + "_DIR")); v != "" {
+			return v, nil
+		}
+	}
+	return fromFile, nil
+}
+
+func (i *Identity) paths() PathsMetadata {
+	if i.Metadata.Paths == nil {
+		return PathsMetadata{}
+	}
+	return *i.Metadata.Paths
+}
+
+// platformBaseDir returns the base directory for kind on goos.
+func platformBaseDir(kind DirKind, goos string, getenv func(string) string, homeDir func() (string, error)) (string, error) {
+	xdgVar := map[DirKind]string{
+		DirConfig: "XDG_CONFIG_HOME",
+		DirCache:  "XDG_CACHE_HOME",
+		DirData:   "XDG_DATA_HOME",
+		DirState:  "XDG_STATE_HOME",
+	}[kind]
+	if v := getenv(xdgVar); v != "" {
+		return v, nil
+	}
+
+	if goos == "windows" {
+		appData, localAppData := getenv("APPDATA"), getenv("LOCALAPPDATA")
+		switch {
+		case kind == DirConfig && appData != "":
+			return appData, nil
+		case kind == DirCache && localAppData != "":
+			return filepath.Join(localAppData, "Cache"), nil
+		case kind != DirConfig && kind != DirCache && localAppData != "":
+			return localAppData, nil
+		}
+	}
+
+	home, err := homeDir()
+	if err != nil || home == "" {
+		return "", ErrNoHomeDir
+	}
+
+	switch goos {
+	case "darwin":
+		if kind == DirCache {
+			return filepath.Join(home, "Library", "Caches"), nil
+		}
+		return filepath.Join(home, "Library", "Application Support"), nil
+	case "windows":
+		if kind == DirConfig {
+			return filepath.Join(home, "AppData", "Roaming"), nil
+		}
+		if kind == DirCache {
+			return filepath.Join(home, "AppData", "Local", "Cache"), nil
+		}
+		return filepath.Join(home, "AppData", "Local"), nil
+	default:
+		switch kind {
+		case DirConfig:
+			return filepath.Join(home, ".config"), nil
+		case DirCache:
+			return filepath.Join(home, ".cache"), nil
+		case DirData:
+			return filepath.Join(home, ".local", "share"), nil
+		default:
+			return filepath.Join(home, ".local", "state"), nil
+		}
+	}
+}
+
+// expandHome expands a leading "~" in path and cleans it.
+func expandHome(path string, homeDir func() (string, error)) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return filepath.Clean(path), nil
+	}
+	home, err := homeDir()
+	if err != nil || home == "" {
+		return "", ErrNoHomeDir
+	}
+	return filepath.Join(home, path[1:]), nil
+}
@@ -0,0 +1,184 @@
+package appidentity
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func pathsTestIdentity() *Identity {
+	return &Identity{
+		BinaryName: "myapp",
+		Vendor:     "myvendor",
+		EnvPrefix:  "MYAPP_",
+		ConfigName: "myapp",
+	}
+}
+
+func fakeEnv(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func fakeHome(home string) func() (string, error) {
+	return func() (string, error) { return home, nil }
+}
+
+func TestResolveDirPlatformDefaults(t *testing.T) {
+	home := filepath.Join("home", "user")
+	tests := []struct {
+		goos string
+		env  map[string]string
+		kind DirKind
+		want string
+	}{
+		{"linux", nil, DirConfig, filepath.Join(home, ".config", "myvendor", "myapp")},
+		{"linux", nil, DirCache, filepath.Join(home, ".cache", "myvendor", "myapp")},
+		{"linux", nil, DirData, filepath.Join(home, ".local", "share", "myvendor", "myapp")},
+		{"linux", nil, DirState, filepath.Join(home, ".local", "state", "myvendor", "myapp")},
+		{"linux", map[string]string{"XDG_CACHE_HOME": "/xdg/cache"}, DirCache, filepath.Join("/xdg/cache", "myvendor", "myapp")},
+		{"linux", map[string]string{"XDG_STATE_HOME": "/xdg/state"}, DirState, filepath.Join("/xdg/state", "myvendor", "myapp")},
+		{"darwin", nil, DirConfig, filepath.Join(home, "Library", "Application Support", "myvendor", "myapp")},
+		{"darwin", nil, DirCache, filepath.Join(home, "Library", "Caches", "myvendor", "myapp")},
+		{"darwin", nil, DirState, filepath.Join(home, "Library", "Application Support", "myvendor", "myapp")},
+		{"darwin", map[string]string{"XDG_CONFIG_HOME": "/xdg/config"}, DirConfig, filepath.Join("/xdg/config", "myvendor", "myapp")},
+		{"windows", map[string]string{"APPDATA": "roaming"}, DirConfig, filepath.Join("roaming", "myvendor", "myapp")},
+		{"windows", map[string]string{"LOCALAPPDATA": "local"}, DirCache, filepath.Join("local", "Cache", "myvendor", "myapp")},
+		{"windows", map[string]string{"LOCALAPPDATA": "local"}, DirData, filepath.Join("local", "myvendor", "myapp")},
+		{"windows", nil, DirConfig, filepath.Join(home, "AppData", "Roaming", "myvendor", "myapp")},
+		{"windows", nil, DirState, filepath.Join(home, "AppData", "Local", "myvendor", "myapp")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos+"/"+string(tt.kind), func(t *testing.T) {
+			got, err := pathsTestIdentity().resolveDir(tt.kind, tt.goos, fakeEnv(tt.env), fakeHome(home))
+			if err != nil {
+				t.Fatalf("resolveDir() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveDirOverrides(t *testing.T) {
+	home := filepath.Join("home", "user")
+	identity := pathsTestIdentity()
+	identity.Metadata.Paths = &PathsMetadata{
+		CacheDir: "/var/cache/myapp",
+		StateDir: "~/.myapp/state",
+	}
+
+	got, err := identity.resolveDir(DirCache, "linux", fakeEnv(nil), fakeHome(home))
+	if err != nil || got != filepath.Clean("/var/cache/myapp") {
+		t.Errorf("identity-file override = %q, %v", got, err)
+	}
+
+	got, err = identity.resolveDir(DirState, "linux", fakeEnv(nil), fakeHome(home))
+	if err != nil || got != filepath.Join(home, ".myapp", "state") {
+		t.Errorf("home-relative override = %q, %v", got, err)
+	}
+
+	env := fakeEnv(map[string]string{"MYAPP_CACHE_DIR": "/tmp/cache", "XDG_CACHE_HOME": "/xdg"})
+	got, err = identity.resolveDir(DirCache, "linux", env, fakeHome(home))
+	if err != nil || got != filepath.Clean("/tmp/cache") {
+		t.Errorf("env override should win over identity file, got %q, %v", got, err)
+	}
+
+	got, err = identity.resolveDir(DirConfig, "linux", env, fakeHome(home))
+	if err != nil || got != filepath.Join(home, ".config", "myvendor", "myapp") {
+		t.Errorf("unrelated kinds should use defaults, got %q, %v", got, err)
+	}
+}
+
+func TestResolveDirFallbacks(t *testing.T) {
+	identity := &Identity{BinaryName: "tool"}
+	got, err := identity.resolveDir(DirData, "linux", fakeEnv(nil), fakeHome("h"))
+	if err != nil || got != filepath.Join("h", ".local", "share", "tool") {
+		t.Errorf("binary-name fallback = %q, %v", got, err)
+	}
+
+	noHome := func() (string, error) { return "", errors.New("no home") }
+	_, err = pathsTestIdentity().resolveDir(DirConfig, "linux", fakeEnv(nil), noHome)
+	if !errors.Is(err, ErrNoHomeDir) {
+		t.Errorf("expected ErrNoHomeDir, got %v", err)
+	}
+
+	if _, err := pathsTestIdentity().Dir("bogus"); err == nil {
+		t.Error("expected error for unknown directory kind")
+	}
+	if _, err := (&Identity{}).resolveDir(DirConfig, "linux", fakeEnv(nil), fakeHome("h")); err == nil {
+		t.Error("expected error for identity without names")
+	}
+}
+
+func TestIdentityDirMethods(t *testing.T) {
+	t.Setenv("MYAPP_CONFIG_DIR", filepath.Join(os.TempDir(), "cfg"))
+	t.Setenv("MYAPP_CACHE_DIR", filepath.Join(os.TempDir(), "cache"))
+	t.Setenv("MYAPP_DATA_DIR", filepath.Join(os.TempDir(), "data"))
+	t.Setenv("MYAPP_STATE_DIR", filepath.Join(os.TempDir(), "state"))
+
+	identity := pathsTestIdentity()
+	for name, fn := range map[string]func() (string, error){
+		"cfg":   identity.ConfigDir,
+		"cache": identity.CacheDir,
+		"data":  identity.DataDir,
+		"state": identity.StateDir,
+	} {
+		got, err := fn()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if want := filepath.Join(os.TempDir(), name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestBaseDir(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(os.TempDir(), "xdg-config"))
+
+	base, err := BaseDir(DirConfig)
+	if err != nil {
+		t.Fatalf("BaseDir() error = %v", err)
+	}
+	if want := filepath.Join(os.TempDir(), "xdg-config"); base != want {
+		t.Errorf("BaseDir(DirConfig) = %q, want %q", base, want)
+	}
+
+	identity := &Identity{ConfigName: "myapp"}
+	dir, err := identity.ConfigDir()
+	if err != nil || dir != filepath.Join(base, "myapp") {
+		t.Errorf("ConfigDir() = %q, %v; want it under BaseDir", dir, err)
+	}
+}
+
+func TestPathsMetadataFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.yaml")
+	content := `app:
+  binary_name: myapp
+  vendor: myvendor
+  env_prefix: MYAPP_
+  config_name: myapp
+  description: Test application for paths
+metadata:
+  paths:
+    cache_dir: /srv/cache
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := loadIdentityFile(path)
+	if err != nil {
+		t.Fatalf("loadIdentityFile() error = %v", err)
+	}
+	if identity.Metadata.Paths == nil || identity.Metadata.Paths.CacheDir != "/srv/cache" {
+		t.Fatalf("metadata.paths not loaded: %+v", identity.Metadata.Paths)
+	}
+	if _, ok := identity.Metadata.Extras["paths"]; ok {
+		t.Error("paths should not be captured in Extras")
+	}
+}
@@ -62,6 +62,15 @@ func Validate(ctx context.Context, path string) error {
 		}
 	}
 
+	// Files that extend a parent are validated as merged
+	if doc, ok := payload.(map[string]interface{}); ok && doc[extendsKey] != nil {
+		layers, err := readIdentityChain(path)
+		if err != nil {
+			return err
+		}
+		payload, _ = mergeLayers(layers)
+	}
+
 	// Get validator
 	v, err := getValidator()
 	if err != nil {
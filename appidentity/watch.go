@@ -0,0 +1,267 @@
+package appidentity
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DefaultWatchInterval is the polling interval used by Watch when
+// WatchOptions.Interval is zero.
+const DefaultWatchInterval = 2 * time.Second
+
+// WatchOptions controls identity hot-reload.
+//
+// The embedded Options select the identity file and environment overlay using
+// the same discovery rules as GetWithOptions. Unless NoCache is set, each
+// successful reload also replaces the process-level identity returned by Get.
+type WatchOptions struct {
+	Options
+
+	// Interval is how often the identity file (and overlay) is checked for
+	// changes. Default: DefaultWatchInterval. A negative interval disables
+	// polling; reloads then happen only through Watcher.Reload (e.g., on SIGHUP).
+	Interval time.Duration
+
+	// OnError is called when a changed identity file fails to load or validate.
+	// The previous identity remains in effect. Optional.
+	OnError func(error)
+}
+
+// Change describes a reloaded identity delivered to subscribers.
+type Change struct {
+	// Previous is the identity in effect before the reload.
+	Previous *Identity
+
+	// Current is the newly loaded identity.
+	Current *Identity
+}
+
+// Watcher reloads the application identity when its file changes and notifies
+// subscribers. Create one with Watch.
+type Watcher struct {
+	path        string
+	environment string
+	updateCache bool
+	onError     func(error)
+
+	reloadMu sync.Mutex
+	stamps   []fileStamp
+
+	mu          sync.RWMutex
+	current     *Identity
+	subscribers map[int]func(Change)
+	nextID      int
+
+	done chan struct{}
+}
+
+// fileStamp records the observable state of a watched file.
+type fileStamp struct {
+	path    string
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+// Watch loads and validates the application identity, then watches its file
+// (the parents it extends, and its environment overlay, if any) for changes
+// until ctx is cancelled.
+//
+// On change the identity is re-read and re-validated; subscribers are notified
+// only when the reloaded identity differs from the current one. Invalid edits
+// are reported through WatchOptions.OnError and the previous identity is kept.
+//
+// Watcher.Reload has the signals.ReloadFunc signature, so a SIGHUP reload can
+// be wired through pkg/signals.
+//
+// Example:
+//
+//	watcher, err := appidentity.Watch(ctx, appidentity.WatchOptions{})
+//	if err != nil {
+//	    return err
+//	}
+//	watcher.Subscribe(func(c appidentity.Change) {
+//	    metrics.SetNamespace(c.Current.TelemetryNamespace())
+//	})
+//	signals.OnReload(watcher.Reload)
+func Watch(ctx context.Context, opts WatchOptions) (*Watcher, error) {
+	path, err := resolveIdentityPath(opts.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		path:        path,
+		environment: resolveEnvironment(opts.Options),
+		updateCache: !opts.NoCache,
+		onError:     opts.OnError,
+		subscribers: make(map[int]func(Change)),
+		done:        make(chan struct{}),
+	}
+
+	w.stamps = w.statFiles()
+	identity, err := loadValidatedIdentity(ctx, w.path, w.environment)
+	if err != nil {
+		return nil, err
+	}
+	w.current = identity
+	if w.updateCache {
+		storeCachedIdentity(identity)
+	}
+
+	interval := opts.Interval
+	if interval == 0 {
+		interval = DefaultWatchInterval
+	}
+	go w.run(ctx, interval)
+
+	return w, nil
+}
+
+// Current returns the identity currently in effect.
+func (w *Watcher) Current() *Identity {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// Path returns the base identity file being watched.
+func (w *Watcher) Path() string {
+	return w.path
+}
+
+// Done is closed when the watcher stops (its context was cancelled).
+func (w *Watcher) Done() <-chan struct{} {
+	return w.done
+}
+
+// Subscribe registers fn to be called after each identity change. Callbacks
+// run synchronously on the reloading goroutine, in no particular order.
+// The returned function removes the subscription.
+func (w *Watcher) Subscribe(fn func(Change)) (cancel func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	id := w.nextID
+	w.nextID++
+	w.subscribers[id] = fn
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subscribers, id)
+	}
+}
+
+// Reload re-reads and re-validates the identity file immediately, notifying
+// subscribers if the identity changed. On error the previous identity is kept
+// and the error is returned (aborting a signals reload chain).
+func (w *Watcher) Reload(ctx context.Context) error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+	w.stamps = w.statFiles()
+	return w.reload(ctx)
+}
+
+// run polls the watched files until ctx is cancelled.
+func (w *Watcher) run(ctx context.Context, interval time.Duration) {
+	defer close(w.done)
+	if interval < 0 {
+		<-ctx.Done()
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+// poll reloads the identity when a watched file changed since the last check.
+func (w *Watcher) poll(ctx context.Context) {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	stamps := w.statFiles()
+	if reflect.DeepEqual(stamps, w.stamps) {
+		return
+	}
+	// Record the new state first so an invalid edit is reported once
+	w.stamps = stamps
+	if err := w.reload(ctx); err != nil && w.onError != nil {
+		w.onError(err)
+	}
+}
+
+// reload loads the identity and notifies subscribers on change. Callers hold reloadMu.
+func (w *Watcher) reload(ctx context.Context) error {
+	identity, err := loadValidatedIdentity(ctx, w.path, w.environment)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	previous := w.current
+	if reflect.DeepEqual(previous, identity) {
+		w.mu.Unlock()
+		return nil
+	}
+	w.current = identity
+	subscribers := make([]func(Change), 0, len(w.subscribers))
+	for _, fn := range w.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	w.mu.Unlock()
+
+	if w.updateCache {
+		storeCachedIdentity(identity)
+	}
+	change := Change{Previous: previous, Current: identity}
+	for _, fn := range subscribers {
+		fn(change)
+	}
+	return nil
+}
+
+// statFiles stats the base identity file, the parents it extends, and its
+// environment overlay.
+func (w *Watcher) statFiles() []fileStamp {
+	paths := []string{w.path}
+	if layers, err := readIdentityChain(w.path); err == nil {
+		paths = paths[:0]
+		for _, layer := range layers {
+			paths = append(paths, layer.path)
+		}
+	}
+	if w.environment != "" {
+		paths = append(paths, OverlayPath(w.path, w.environment))
+	}
+
+	stamps := make([]fileStamp, len(paths))
+	for i, path := range paths {
+		stamps[i].path = path
+		if info, err := os.Stat(path); err == nil {
+			stamps[i] = fileStamp{path: path, exists: true, size: info.Size(), modTime: info.ModTime()}
+		}
+	}
+	return stamps
+}
+
+// loadValidatedIdentity loads the identity (with overlay) and validates it
+// against the app-identity schema.
+func loadValidatedIdentity(ctx context.Context, path, environment string) (*Identity, error) {
+	// Layered loads validate the merged document themselves when an overlay exists
+	if _, err := os.Stat(OverlayPath(path, environment)); environment == "" || err != nil {
+		if err := Validate(ctx, path); err != nil {
+			return nil, err
+		}
+	}
+	return loadLayeredIdentity(path, environment)
+}
@@ -0,0 +1,197 @@
+package appidentity
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeWatchIdentity(t *testing.T, path, namespace string) {
+	t.Helper()
+	content := `app:
+  binary_name: watchapp
+  vendor: testvendor
+  env_prefix: WATCHAPP_
+  config_name: watchapp
+  description: Identity used by watcher tests
+metadata:
+  telemetry_namespace: ` + namespace + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatchNotifiesOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	writeWatchIdentity(t, path, "first")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, err := Watch(ctx, WatchOptions{
+		Options:  Options{ExplicitPath: path, NoCache: true},
+		Interval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if got := w.Current().TelemetryNamespace(); got != "first" {
+		t.Fatalf("initial namespace = %q, want first", got)
+	}
+
+	changes := make(chan Change, 1)
+	w.Subscribe(func(c Change) { changes <- c })
+
+	writeWatchIdentity(t, path, "second_namespace")
+
+	select {
+	case c := <-changes:
+		if c.Previous.TelemetryNamespace() != "first" || c.Current.TelemetryNamespace() != "second_namespace" {
+			t.Errorf("change = %q -> %q", c.Previous.TelemetryNamespace(), c.Current.TelemetryNamespace())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for identity change")
+	}
+	if got := w.Current().TelemetryNamespace(); got != "second_namespace" {
+		t.Errorf("Current() namespace = %q", got)
+	}
+
+	cancel()
+	select {
+	case <-w.Done():
+	case <-time.After(time.Second):
+		t.Fatal("watcher did not stop after cancel")
+	}
+}
+
+func TestWatchKeepsPreviousOnInvalidEdit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	writeWatchIdentity(t, path, "stable")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 4)
+	w, err := Watch(ctx, WatchOptions{
+		Options:  Options{ExplicitPath: path, NoCache: true},
+		Interval: 10 * time.Millisecond,
+		OnError:  func(err error) { errs <- err },
+	})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	w.Subscribe(func(Change) { t.Error("subscriber should not be notified for invalid identity") })
+
+	if err := os.WriteFile(path, []byte("app:\n  binary_name: INVALID NAME\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("OnError got %v, want ErrInvalid", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload error")
+	}
+	if got := w.Current().TelemetryNamespace(); got != "stable" {
+		t.Errorf("Current() namespace = %q, want previous identity", got)
+	}
+}
+
+func TestWatcherReloadWithoutPolling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	writeWatchIdentity(t, path, "before")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, err := Watch(ctx, WatchOptions{
+		Options:  Options{ExplicitPath: path, NoCache: true},
+		Interval: -1,
+	})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	notified := 0
+	unsubscribe := w.Subscribe(func(Change) { notified++ })
+
+	// Reload without changes does not notify
+	if err := w.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if notified != 0 {
+		t.Errorf("notified = %d for unchanged identity", notified)
+	}
+
+	writeWatchIdentity(t, path, "after")
+	if err := w.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if notified != 1 || w.Current().TelemetryNamespace() != "after" {
+		t.Errorf("notified = %d, namespace = %q", notified, w.Current().TelemetryNamespace())
+	}
+
+	unsubscribe()
+	writeWatchIdentity(t, path, "unsubscribed")
+	if err := w.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if notified != 1 {
+		t.Errorf("notified = %d after unsubscribe", notified)
+	}
+
+	if err := os.WriteFile(path, []byte("not: [valid"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Reload(ctx); !errors.Is(err, ErrMalformed) {
+		t.Errorf("Reload() error = %v, want ErrMalformed", err)
+	}
+}
+
+func TestWatchUpdatesProcessCache(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	writeWatchIdentity(t, path, "cached")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, err := Watch(ctx, WatchOptions{Options: Options{ExplicitPath: path}, Interval: -1})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	identity, err := Get(ctx)
+	if err != nil || identity.TelemetryNamespace() != "cached" {
+		t.Fatalf("Get() = %v, %v", identity, err)
+	}
+
+	writeWatchIdentity(t, path, "recached")
+	if err := w.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+	identity, err = Get(ctx)
+	if err != nil || identity.TelemetryNamespace() != "recached" {
+		t.Errorf("Get() after reload = %v, %v", identity, err)
+	}
+}
+
+func TestWatchInvalidInitialIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	if err := os.WriteFile(path, []byte("app:\n  binary_name: x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Watch(context.Background(), WatchOptions{Options: Options{ExplicitPath: path}}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Watch() error = %v, want ErrInvalid", err)
+	}
+	if _, err := Watch(context.Background(), WatchOptions{Options: Options{ExplicitPath: path + ".missing"}}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Watch() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,152 @@
+package ascii
+
+import (
+	"strings"
+
+	"github.com/clipperhouse/uax29/v2/graphemes"
+)
+
+const esc = '\x1b'
+
+// ansiSequenceLength returns the byte length of the escape sequence starting
+// at s[0], or 0 if s does not start with one. Recognizes CSI sequences
+// (colors, cursor movement), OSC sequences (titles, hyperlinks) terminated
+// by BEL or ST, and two-byte escapes.
+func ansiSequenceLength(s string) int {
+	if len(s) < 2 || s[0] != esc {
+		return 0
+	}
+	switch s[1] {
+	case '[': // CSI: parameters and intermediates, then a final byte in 0x40-0x7E
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+		return len(s)
+	case ']': // OSC: terminated by BEL or ESC \
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == esc && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	default:
+		return 2
+	}
+}
+
+// splitANSI calls fn for each escape sequence and each run of visible text
+// in s, in order.
+func splitANSI(s string, fn func(segment string, escape bool)) {
+	for len(s) > 0 {
+		if n := ansiSequenceLength(s); n > 0 {
+			fn(s[:n], true)
+			s = s[n:]
+			continue
+		}
+		next := strings.IndexByte(s[1:], esc)
+		if next < 0 {
+			fn(s, false)
+			return
+		}
+		fn(s[:next+1], false)
+		s = s[next+1:]
+	}
+}
+
+// StripANSI removes ANSI escape sequences from s.
+//
+// Example:
+//
+//	StripANSI("\x1b[31mError\x1b[0m") // "Error"
+func StripANSI(s string) string {
+	if strings.IndexByte(s, esc) < 0 {
+		return s
+	}
+	var sb strings.Builder
+	splitANSI(s, func(segment string, escape bool) {
+		if !escape {
+			sb.WriteString(segment)
+		}
+	})
+	return sb.String()
+}
+
+// WidthIgnoringANSI returns the display width of s, treating ANSI escape
+// sequences as zero-width. Visible text is measured with StringWidth.
+//
+// Example:
+//
+//	WidthIgnoringANSI("\x1b[1;32m✓ done\x1b[0m") // 6
+func WidthIgnoringANSI(s string) int {
+	return StringWidth(StripANSI(s))
+}
+
+// TruncateToWidth shortens s to at most width display cells, ending with
+// ellipsis (e.g., "…"; may be empty). Escape sequences are zero-width and
+// are all kept, including those after the cut, so colors are still reset.
+// Grapheme clusters are never split.
+//
+// Example:
+//
+//	TruncateToWidth("\x1b[31mconfiguration\x1b[0m", 6, "…") // "\x1b[31mconfi…\x1b[0m"
+func TruncateToWidth(s string, width int, ellipsis string) string {
+	if WidthIgnoringANSI(s) <= width {
+		return s
+	}
+	limit := width - StringWidth(ellipsis)
+	if limit < 0 {
+		limit, ellipsis = 0, ""
+	}
+
+	var sb strings.Builder
+	used, cut := 0, false
+	splitANSI(s, func(segment string, escape bool) {
+		if escape {
+			sb.WriteString(segment)
+			return
+		}
+		if cut {
+			return
+		}
+		g := graphemes.FromString(segment)
+		for g.Next() {
+			w := StringWidth(g.Value())
+			if used+w > limit {
+				sb.WriteString(ellipsis)
+				cut = true
+				return
+			}
+			sb.WriteString(g.Value())
+			used += w
+		}
+	})
+	return sb.String()
+}
+
+// PadToWidth pads s with spaces to width display cells using the given
+// alignment, treating ANSI escape sequences as zero-width. Strings already
+// at least width cells wide are returned unchanged.
+//
+// Example:
+//
+//	PadToWidth("\x1b[32mok\x1b[0m", 6, AlignCenter) // "  \x1b[32mok\x1b[0m  "
+func PadToWidth(s string, width int, align Alignment) string {
+	gap := width - WidthIgnoringANSI(s)
+	if gap <= 0 {
+		return s
+	}
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", gap) + s
+	case AlignCenter:
+		left := gap / 2
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", gap-left)
+	default:
+		return s + strings.Repeat(" ", gap)
+	}
+}
@@ -76,7 +76,7 @@ func DrawBoxWithOptions(content string, opts BoxOptions) string {
 	// Find content width
 	contentWidth := 0
 	for _, line := range lines {
-		lineWidth := StringWidth(line)
+		lineWidth := WidthIgnoringANSI(line)
 		if lineWidth > contentWidth {
 			contentWidth = lineWidth
 		}
@@ -101,15 +101,14 @@ func DrawBoxWithOptions(content string, opts BoxOptions) string {
 	result.WriteString("\n")
 
 	for _, line := range lines {
-		lineWidth := StringWidth(line)
+		lineWidth := WidthIgnoringANSI(line)
 
 		result.WriteString(chars.Vertical)
 		result.WriteString(" ")
 
 		// Truncate if exceeds max width
 		if opts.MaxWidth > 0 && lineWidth > opts.MaxWidth {
-			// TODO: Proper truncation respecting rune boundaries
-			result.WriteString(line[:opts.MaxWidth])
+			result.WriteString(TruncateToWidth(line, opts.MaxWidth, ""))
 			lineWidth = opts.MaxWidth
 		} else {
 			result.WriteString(line)
@@ -151,7 +150,7 @@ func MaxContentWidth(contents []string) int {
 	for _, content := range contents {
 		lines := strings.Split(content, "\n")
 		for _, line := range lines {
-			width := StringWidth(line)
+			width := WidthIgnoringANSI(line)
 			if width > maxWidth {
 				maxWidth = width
 			}
@@ -1,8 +1,11 @@
 package ascii
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestDrawBox(t *testing.T) {
@@ -418,3 +421,392 @@ func TestSanitize(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderProgressBar(t *testing.T) {
+	opts := ProgressBarOptions{Width: 10, Fill: "#", Empty: "-", LabelWidth: 12, Bytes: true}
+
+	line := RenderProgressBar(512*1024, 2048*1024, 10*time.Second, "data/file.bin", opts)
+	if !strings.HasPrefix(line, "[##--------]  25% 512.0 KiB/2.0 MiB ETA 30s ") {
+		t.Errorf("Unexpected progress line: %q", line)
+	}
+	if !strings.HasSuffix(line, "…ta/file.bin") {
+		t.Errorf("Expected label truncated from the left, got %q", line)
+	}
+
+	if line := RenderProgressBar(5, 5, time.Second, "", opts); line != "[##########] 100% 5 B/5 B" {
+		t.Errorf("Unexpected complete line: %q", line)
+	}
+	if line := RenderProgressBar(42, 0, time.Second, "", ProgressBarOptions{Width: 3}); line != "[░░░] 42" {
+		t.Errorf("Unexpected unknown-total line: %q", line)
+	}
+}
+
+func TestProgressBarUpdate(t *testing.T) {
+	var buf strings.Builder
+	bar := NewProgressBar(&buf, &ProgressBarOptions{Width: 4})
+	bar.Update(1, 2, "long-label")
+	bar.Update(2, 2, "x")
+	bar.Finish()
+
+	out := buf.String()
+	if strings.Count(out, "\r") != 2 || !strings.HasSuffix(out, "\n") {
+		t.Errorf("Expected two in-place redraws and trailing newline, got %q", out)
+	}
+	// The shorter second line is padded to clear the first
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\r")
+	if StringWidth(lines[2]) < StringWidth(lines[1]) {
+		t.Errorf("Expected padding on shorter redraw, got %q", out)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[int64]string{0: "0 B", 1023: "1023 B", 1536: "1.5 KiB", 5 * 1024 * 1024 * 1024: "5.0 GiB"}
+	for n, expected := range cases {
+		if got := FormatBytes(n); got != expected {
+			t.Errorf("FormatBytes(%d) = %q, expected %q", n, got, expected)
+		}
+	}
+}
+
+func TestTableRender(t *testing.T) {
+	table := NewTable([]Column{
+		{Header: "Name"},
+		{Header: "Size", Align: AlignRight},
+		{Header: "State", Align: AlignCenter},
+	}, nil)
+	table.AddRow("alpha", "10", "ok")
+	table.AddRow("beta", "2048", "failed")
+
+	expected := "" +
+		"┌───────┬──────┬────────┐\n" +
+		"│ Name  │ Size │ State  │\n" +
+		"├───────┼──────┼────────┤\n" +
+		"│ alpha │   10 │   ok   │\n" +
+		"│ beta  │ 2048 │ failed │\n" +
+		"└───────┴──────┴────────┘\n"
+	if got := table.Render(); got != expected {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestTableStyles(t *testing.T) {
+	tests := []struct {
+		name     string
+		style    TableStyle
+		expected string
+	}{
+		{"ASCII", TableStyleASCII(), "+----+\n| ID |\n+----+\n| 7  |\n+----+\n"},
+		{"Rounded", TableStyleRounded(), "╭────╮\n│ ID │\n├────┤\n│ 7  │\n╰────╯\n"},
+		{"Heavy", TableStyleHeavy(), "┏━━━━┓\n┃ ID ┃\n┣━━━━┫\n┃ 7  ┃\n┗━━━━┛\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable([]Column{{Header: "ID"}}, &TableOptions{Style: &tt.style})
+			table.AddRow("7")
+			if got := table.Render(); got != tt.expected {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTableMarkdown(t *testing.T) {
+	style := TableStyleMarkdown()
+	table := NewTable([]Column{
+		{Header: "Key"},
+		{Header: "Value", Align: AlignRight},
+		{Header: "Note", Align: AlignCenter},
+	}, &TableOptions{Style: &style})
+	table.AddRow("a|b", "1", "multi\nline")
+
+	expected := "" +
+		"| Key  | Value |    Note    |\n" +
+		"| ---- | ----: | :--------: |\n" +
+		"| a\\|b |     1 | multi line |\n"
+	if got := table.Render(); got != expected {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestTableTruncateAndWrap(t *testing.T) {
+	style := TableStyleASCII()
+	table := NewTable([]Column{
+		{Header: "Path", MaxWidth: 8},
+		{Header: "Description", Width: 11, Wrap: true},
+	}, &TableOptions{Style: &style})
+	table.AddRow("schemas/fulmen/config.yaml", "Layered configuration schema")
+
+	expected := "" +
+		"+----------+-------------+\n" +
+		"| Path     | Description |\n" +
+		"+----------+-------------+\n" +
+		"| schemas… | Layered     |\n" +
+		"|          | configurati |\n" +
+		"|          | on schema   |\n" +
+		"+----------+-------------+\n"
+	if got := table.Render(); got != expected {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestTableWideCharacters(t *testing.T) {
+	table := NewTable([]Column{{Header: "Name", MaxWidth: 5}, {Header: "Icon"}}, nil)
+	table.AddRow("こんにちは", "🚀")
+	table.AddRow("abc", "ok")
+
+	for _, line := range strings.Split(strings.TrimSuffix(table.Render(), "\n"), "\n") {
+		if w := StringWidth(line); w != 16 {
+			t.Errorf("Line %q has width %d, want 16", line, w)
+		}
+	}
+	if !strings.Contains(table.Render(), "こん…") {
+		t.Errorf("Expected CJK truncation at a character boundary:\n%s", table.Render())
+	}
+}
+
+func TestTableMaxWidth(t *testing.T) {
+	table := NewTable([]Column{{Header: "ID", Width: 4}, {Header: "Description"}}, &TableOptions{MaxWidth: 20})
+	table.AddRow("1", "a fairly long description")
+
+	for _, line := range strings.Split(strings.TrimSuffix(table.Render(), "\n"), "\n") {
+		if w := StringWidth(line); w != 20 {
+			t.Errorf("Line %q has width %d, want 20", line, w)
+		}
+	}
+}
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Plain", "hello", "hello"},
+		{"SGR", "\x1b[31mError\x1b[0m", "Error"},
+		{"MultipleParams", "\x1b[1;38;5;208mwarn\x1b[m: disk", "warn: disk"},
+		{"CursorMovement", "\x1b[2K\x1b[1Gdone", "done"},
+		{"OSCHyperlink", "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", "link"},
+		{"OSCTitleBEL", "\x1b]0;title\atext", "text"},
+		{"TwoByteEscape", "\x1b7saved\x1b8", "saved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripANSI(tt.input); got != tt.expected {
+				t.Errorf("StripANSI(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWidthIgnoringANSI(t *testing.T) {
+	if got := WidthIgnoringANSI("\x1b[1;32m✓ done\x1b[0m"); got != 6 {
+		t.Errorf("Expected width 6, got %d", got)
+	}
+	if got := WidthIgnoringANSI("\x1b[33m🚀 こんにちは\x1b[0m"); got != 13 {
+		t.Errorf("Expected width 13, got %d", got)
+	}
+}
+
+func TestTruncateToWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		ellipsis string
+		expected string
+	}{
+		{"Fits", "hello", 5, "…", "hello"},
+		{"Plain", "configuration", 6, "…", "confi…"},
+		{"NoEllipsis", "configuration", 6, "", "config"},
+		{"KeepsResets", "\x1b[31mconfiguration\x1b[0m", 6, "…", "\x1b[31mconfi…\x1b[0m"},
+		{"MidColorChange", "\x1b[31mred\x1b[32mgreen\x1b[0m", 5, "...", "\x1b[31mre...\x1b[32m\x1b[0m"},
+		{"CJKBoundary", "こんにちは", 6, "…", "こん…"},
+		{"ZWJEmoji", "👨‍👩‍👧 family", 3, "…", "👨‍👩‍👧…"},
+		{"TooNarrow", "hello", 0, "…", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateToWidth(tt.input, tt.width, tt.ellipsis)
+			if got != tt.expected {
+				t.Errorf("TruncateToWidth(%q, %d) = %q, want %q", tt.input, tt.width, got, tt.expected)
+			}
+			if w := WidthIgnoringANSI(got); w > tt.width {
+				t.Errorf("Result width %d exceeds %d", w, tt.width)
+			}
+		})
+	}
+}
+
+func TestPadToWidth(t *testing.T) {
+	colored := "\x1b[32mok\x1b[0m"
+	if got := PadToWidth(colored, 6, AlignLeft); got != colored+"    " {
+		t.Errorf("AlignLeft = %q", got)
+	}
+	if got := PadToWidth(colored, 6, AlignRight); got != "    "+colored {
+		t.Errorf("AlignRight = %q", got)
+	}
+	if got := PadToWidth(colored, 5, AlignCenter); got != " "+colored+"  " {
+		t.Errorf("AlignCenter = %q", got)
+	}
+	if got := PadToWidth("toolong", 3, AlignLeft); got != "toolong" {
+		t.Errorf("Expected unchanged string, got %q", got)
+	}
+}
+
+func TestDrawBox_ANSI(t *testing.T) {
+	box := DrawBox("\x1b[31mred\x1b[0m\nplain", 0)
+	lines := strings.Split(strings.TrimSuffix(StripANSI(box), "\n"), "\n")
+	for _, line := range lines {
+		if w := StringWidth(line); w != 9 {
+			t.Errorf("Line %q has width %d, want 9", line, w)
+		}
+	}
+}
+
+func TestTable_ANSI(t *testing.T) {
+	table := NewTable([]Column{{Header: "Status", MaxWidth: 6}, {Header: "N", Align: AlignRight}}, nil)
+	table.AddRow("\x1b[32mpassed\x1b[0m", "1")
+	table.AddRow("\x1b[31mfailed badly\x1b[0m", "20")
+
+	for _, line := range strings.Split(strings.TrimSuffix(table.Render(), "\n"), "\n") {
+		if w := WidthIgnoringANSI(line); w != 15 {
+			t.Errorf("Line %q has width %d, want 15", line, w)
+		}
+	}
+}
+
+func TestUserTerminalOverrideFiles(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	fulmenDir := filepath.Join(configHome, "fulmen")
+	dropInDir := filepath.Join(fulmenDir, "terminal-overrides.d")
+	if err := os.MkdirAll(dropInDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(filepath.Join(fulmenDir, "terminal-overrides.yaml"), "terminals:\n  exoterm:\n    name: Exotic\n    overrides:\n      \"🔧\": 1\n")
+	writeFile(filepath.Join(dropInDir, "10-ghostty.yaml"), "terminals:\n  ghostty:\n    overrides:\n      \"🔧\": 3\n")
+	writeFile(filepath.Join(dropInDir, "20-exoterm.yml"), "terminals:\n  exoterm:\n    overrides:\n      \"🔧\": 2\n")
+	writeFile(filepath.Join(dropInDir, "ignored.txt"), "not yaml")
+	envFile := filepath.Join(t.TempDir(), "app.yaml")
+	writeFile(envFile, "terminals:\n  appterm:\n    name: App Terminal\n")
+	t.Setenv(EnvTerminalOverrides, envFile)
+	defer func() {
+		_ = os.Unsetenv(EnvTerminalOverrides)
+		_ = ReloadTerminalOverrides()
+	}()
+
+	paths := UserTerminalOverridePaths()
+	if len(paths) != 4 || paths[3] != envFile {
+		t.Fatalf("UserTerminalOverridePaths() = %v", paths)
+	}
+
+	if err := ReloadTerminalOverrides(); err != nil {
+		t.Fatalf("ReloadTerminalOverrides() error: %v", err)
+	}
+	if err := OverrideError(); err != nil {
+		t.Errorf("OverrideError() = %v, want nil", err)
+	}
+	configs := GetAllTerminalConfigs()
+	if got := configs["exoterm"]; got.Name != "Exotic" || got.Overrides["🔧"] != 2 {
+		t.Errorf("exoterm = %+v, want later drop-in to win", got)
+	}
+	if got := configs["ghostty"]; got.Overrides["🔧"] != 3 || got.Overrides["⚠️"] != 2 {
+		t.Errorf("ghostty = %+v, want user override merged over built-ins", got)
+	}
+	if _, ok := configs["appterm"]; !ok {
+		t.Error("Expected appterm from FULMEN_TERMINAL_OVERRIDES")
+	}
+}
+
+func TestUserTerminalOverrides_Invalid(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	fulmenDir := filepath.Join(configHome, "fulmen")
+	if err := os.MkdirAll(filepath.Join(fulmenDir, "terminal-overrides.d"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(fulmenDir, "terminal-overrides.yaml"), []byte("terminals:\n  ghostty:\n    overrides:\n      \"🔧\": wide\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(fulmenDir, "terminal-overrides.d", "ok.yaml"), []byte("terminals:\n  okterm:\n    name: OK\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ReloadTerminalOverrides() }()
+
+	err := ReloadTerminalOverrides()
+	if err == nil || !strings.Contains(err.Error(), "terminal-overrides.yaml") {
+		t.Fatalf("Expected validation error naming the file, got %v", err)
+	}
+	if OverrideError() != err {
+		t.Errorf("OverrideError() = %v, want %v", OverrideError(), err)
+	}
+
+	configs := GetAllTerminalConfigs()
+	if _, ok := configs["okterm"]; !ok {
+		t.Error("Valid override files should still be applied")
+	}
+	if got := configs["ghostty"]; got.Overrides["⚠️"] != 2 || got.Overrides["🔧"] != 0 {
+		t.Errorf("ghostty = %+v, want built-ins without the invalid override", got)
+	}
+}
+
+func TestValidateTerminalOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"Valid", "version: \"1.0.0\"\nterminals:\n  myterm:\n    name: Mine\n    overrides:\n      \"🔧\": 2\n", false},
+		{"Defaults", string(terminalOverridesDefaults), false},
+		{"MissingTerminals", "version: \"1.0.0\"\n", true},
+		{"WidthNotInteger", "terminals:\n  myterm:\n    overrides:\n      \"🔧\": wide\n", true},
+		{"WidthOutOfRange", "terminals:\n  myterm:\n    overrides:\n      \"🔧\": 9\n", true},
+		{"UnknownField", "terminals:\n  myterm:\n    widths: {}\n", true},
+		{"Malformed", "terminals: [", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTerminalOverrides([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTerminalOverrides() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadTerminalOverridesFile(t *testing.T) {
+	t.Setenv("TERM_PROGRAM", "loadterm")
+	path := filepath.Join(t.TempDir(), "overrides.yaml")
+	if err := os.WriteFile(path, []byte("terminals:\n  loadterm:\n    name: Loaded\n    overrides:\n      \"🔧\": 5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ReloadTerminalOverrides() }()
+
+	if err := LoadTerminalOverridesFile(path); err == nil {
+		t.Fatal("Expected error for out-of-range width")
+	}
+
+	if err := os.WriteFile(path, []byte("terminals:\n  loadterm:\n    name: Loaded\n    overrides:\n      \"🔧\": 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadTerminalOverridesFile(path); err != nil {
+		t.Fatalf("LoadTerminalOverridesFile() error: %v", err)
+	}
+	if cfg := GetTerminalConfig(); cfg == nil || cfg.Name != "Loaded" {
+		t.Errorf("Expected loadterm to be detected, got %+v", cfg)
+	}
+	if got := StringWidth("🔧"); got != 3 {
+		t.Errorf("StringWidth(🔧) = %d, want 3", got)
+	}
+}
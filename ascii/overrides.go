@@ -2,18 +2,30 @@ package ascii
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/fulmenhq/gofulmen/config"
+	"github.com/fulmenhq/gofulmen/schema"
 	"gopkg.in/yaml.v3"
 )
 
 //go:embed assets/terminal-overrides-defaults.yaml
 var terminalOverridesDefaults []byte
 
+//go:embed assets/terminal-overrides.schema.json
+var terminalOverridesSchema []byte
+
+// EnvTerminalOverrides lists extra terminal override files, separated by the
+// OS path list separator. They are merged after the files in the Fulmen
+// config directory.
+const EnvTerminalOverrides = "FULMEN_TERMINAL_OVERRIDES"
+
 type TerminalOverrides struct {
 	Version   string                    `yaml:"version" json:"version"`
 	Terminals map[string]TerminalConfig `yaml:"terminals" json:"terminals"`
@@ -28,15 +40,29 @@ type TerminalConfig struct {
 var (
 	terminalCatalog       *TerminalOverrides
 	currentTerminalConfig *TerminalConfig
+
+	// overrideErr is the error from the last load of the user override files
+	overrideErr error
+
+	overridesValidator     *schema.Validator
+	overridesValidatorErr  error
+	overridesValidatorOnce sync.Once
 )
 
 func init() {
-	if err := loadTerminalCatalog(); err != nil {
-		return
-	}
+	// A broken user file is skipped; built-in and other valid overrides still
+	// apply, and the error is reported by OverrideError.
+	overrideErr = loadTerminalCatalog()
 	detectCurrentTerminal()
 }
 
+// OverrideError returns the error from the last load of the user terminal
+// override files, at package initialization or by ReloadTerminalOverrides, or
+// nil if they all loaded. Files that failed to load were skipped.
+func OverrideError() error {
+	return overrideErr
+}
+
 func loadTerminalCatalog() error {
 	// Layer 1: Load embedded defaults from crucible SSOT
 	// Create a fresh instance to avoid modifying any existing config
@@ -46,16 +72,112 @@ func loadTerminalCatalog() error {
 	}
 	terminalCatalog = &catalog
 
-	// Layer 2: Merge user overrides from GetFulmenConfigDir()
+	// Layer 2: Merge user override files in order; later files win
+	var errs []error
+	for _, path := range UserTerminalOverridePaths() {
+		if err := loadUserOverrides(path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// UserTerminalOverridePaths returns the user terminal override files that
+// exist, in merge order:
+//
+//  1. <fulmen config dir>/terminal-overrides.yaml
+//     (e.g., $XDG_CONFIG_HOME/fulmen/terminal-overrides.yaml)
+//  2. <fulmen config dir>/terminal-overrides.d/*.yaml and *.yml, sorted by name
+//  3. Files listed in FULMEN_TERMINAL_OVERRIDES (listed even if missing, so
+//     typos are reported)
+func UserTerminalOverridePaths() []string {
 	fulmenConfigDir := config.GetFulmenConfigDir()
-	userConfigPath := filepath.Join(fulmenConfigDir, "terminal-overrides.yaml")
 
+	var paths []string
+	userConfigPath := filepath.Join(fulmenConfigDir, "terminal-overrides.yaml")
 	if _, err := os.Stat(userConfigPath); err == nil {
-		if err := loadUserOverrides(userConfigPath); err != nil {
-			return err
+		paths = append(paths, userConfigPath)
+	}
+
+	dropIns, _ := filepath.Glob(filepath.Join(fulmenConfigDir, "terminal-overrides.d", "*.y*ml"))
+	sort.Strings(dropIns)
+	for _, path := range dropIns {
+		if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+			paths = append(paths, path)
 		}
 	}
 
+	for _, path := range filepath.SplitList(os.Getenv(EnvTerminalOverrides)) {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// TerminalOverridesSchema returns the JSON Schema that user terminal override
+// files are validated against.
+func TerminalOverridesSchema() []byte {
+	return terminalOverridesSchema
+}
+
+// ValidateTerminalOverrides validates a YAML (or JSON) terminal overrides
+// document against TerminalOverridesSchema.
+//
+// Example:
+//
+//	data, _ := os.ReadFile("terminal-overrides.yaml")
+//	if err := ascii.ValidateTerminalOverrides(data); err != nil {
+//	    fmt.Println(err) // e.g. "invalid terminal overrides: /terminals/myterm/overrides/🔧: ..."
+//	}
+func ValidateTerminalOverrides(data []byte) error {
+	var payload interface{}
+	if err := yaml.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("failed to parse terminal overrides: %w", err)
+	}
+	return validateTerminalOverrides(payload)
+}
+
+func validateTerminalOverrides(payload interface{}) error {
+	overridesValidatorOnce.Do(func() {
+		overridesValidator, overridesValidatorErr = schema.NewValidator(terminalOverridesSchema)
+	})
+	if overridesValidatorErr != nil {
+		return fmt.Errorf("failed to compile terminal overrides schema: %w", overridesValidatorErr)
+	}
+
+	diags, err := overridesValidator.ValidateData(payload)
+	if err != nil {
+		return fmt.Errorf("failed to validate terminal overrides: %w", err)
+	}
+	if len(diags) > 0 {
+		messages := make([]string, 0, len(diags))
+		for _, d := range diags {
+			messages = append(messages, fmt.Sprintf("%s: %s", d.Pointer, d.Message))
+		}
+		return fmt.Errorf("invalid terminal overrides: %s", strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// LoadTerminalOverridesFile validates a terminal overrides file and merges it
+// over the current configuration, then re-detects the current terminal.
+// Applications can use this to ship overrides for terminals they support.
+//
+// Example:
+//
+//	if err := ascii.LoadTerminalOverridesFile("/etc/myapp/terminal-overrides.yaml"); err != nil {
+//	    log.Printf("ignoring terminal overrides: %v", err)
+//	}
+func LoadTerminalOverridesFile(path string) error {
+	if terminalCatalog == nil {
+		terminalCatalog = &TerminalOverrides{Version: "1.0.0"}
+	}
+	if err := loadUserOverrides(path); err != nil {
+		return err
+	}
+	detectCurrentTerminal()
 	return nil
 }
 
@@ -65,10 +187,21 @@ func loadUserOverrides(path string) error {
 	if err != nil {
 		return fmt.Errorf("failed to read user terminal overrides: %w", err)
 	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return nil
+	}
+
+	var payload interface{}
+	if err := yaml.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("failed to parse user terminal overrides %s: %w", path, err)
+	}
+	if err := validateTerminalOverrides(payload); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
 
 	var userConfig TerminalOverrides
 	if err := yaml.Unmarshal(data, &userConfig); err != nil {
-		return fmt.Errorf("failed to parse user terminal overrides: %w", err)
+		return fmt.Errorf("failed to parse user terminal overrides %s: %w", path, err)
 	}
 
 	mergeTerminalConfigs(terminalCatalog, &userConfig)
@@ -190,5 +323,6 @@ func SetTerminalConfig(terminalName string, cfg TerminalConfig) {
 // ReloadTerminalOverrides reloads the terminal configuration from defaults and user overrides
 // This is useful if you want to reset after using SetTerminalOverrides or SetTerminalConfig
 func ReloadTerminalOverrides() error {
-	return loadTerminalCatalog()
+	overrideErr = loadTerminalCatalog()
+	return overrideErr
 }
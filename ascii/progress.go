@@ -0,0 +1,162 @@
+package ascii
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ProgressBarOptions configures progress bar rendering.
+type ProgressBarOptions struct {
+	// Width is the number of bar cells (default: 30).
+	Width int
+	// Fill and Empty are the bar cell characters (defaults: "█" and "░").
+	Fill  string
+	Empty string
+	// LabelWidth truncates the label to this display width (default: 40).
+	LabelWidth int
+	// Bytes renders current/total as byte sizes instead of plain counts.
+	Bytes bool
+}
+
+// DefaultProgressBarOptions returns the default progress bar options.
+func DefaultProgressBarOptions() ProgressBarOptions {
+	return ProgressBarOptions{
+		Width:      30,
+		Fill:       "█",
+		Empty:      "░",
+		LabelWidth: 40,
+	}
+}
+
+// ProgressBar renders a single-line, in-place progress bar with percentage and ETA.
+//
+// It is safe for concurrent use.
+type ProgressBar struct {
+	w         io.Writer
+	opts      ProgressBarOptions
+	start     time.Time
+	now       func() time.Time
+	mu        sync.Mutex
+	lastWidth int
+}
+
+// NewProgressBar creates a progress bar writing to w (typically os.Stderr).
+// Zero-valued option fields fall back to DefaultProgressBarOptions.
+//
+// Example:
+//
+//	bar := ascii.NewProgressBar(os.Stderr, &ascii.ProgressBarOptions{Bytes: true})
+//	for ... {
+//	    bar.Update(done, total, currentFile)
+//	}
+//	bar.Finish()
+func NewProgressBar(w io.Writer, opts *ProgressBarOptions) *ProgressBar {
+	resolved := DefaultProgressBarOptions()
+	if opts != nil {
+		if opts.Width > 0 {
+			resolved.Width = opts.Width
+		}
+		if opts.Fill != "" {
+			resolved.Fill = opts.Fill
+		}
+		if opts.Empty != "" {
+			resolved.Empty = opts.Empty
+		}
+		if opts.LabelWidth > 0 {
+			resolved.LabelWidth = opts.LabelWidth
+		}
+		resolved.Bytes = opts.Bytes
+	}
+	return &ProgressBar{w: w, opts: resolved, start: time.Now(), now: time.Now}
+}
+
+// Update redraws the bar in place. A total of 0 or less renders without
+// percentage and ETA.
+func (b *ProgressBar) Update(current, total int64, label string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	line := RenderProgressBar(current, total, b.now().Sub(b.start), label, b.opts)
+
+	// Pad with spaces to clear leftovers from a longer previous line
+	width := StringWidth(line)
+	if pad := b.lastWidth - width; pad > 0 {
+		line += strings.Repeat(" ", pad)
+	}
+	b.lastWidth = width
+	_, _ = fmt.Fprint(b.w, "\r"+line)
+}
+
+// Finish ends the progress line so subsequent output starts on a new line.
+func (b *ProgressBar) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, _ = fmt.Fprintln(b.w)
+	b.lastWidth = 0
+}
+
+// RenderProgressBar renders one progress line, e.g.
+// "[██████░░░░] 60% 1.2 GB/2.0 GB ETA 1m20s backup/db.sql".
+//
+// ETA is estimated from the average rate over elapsed.
+func RenderProgressBar(current, total int64, elapsed time.Duration, label string, opts ProgressBarOptions) string {
+	if opts.Width <= 0 {
+		opts.Width = DefaultProgressBarOptions().Width
+	}
+	if opts.Fill == "" {
+		opts.Fill = DefaultProgressBarOptions().Fill
+	}
+	if opts.Empty == "" {
+		opts.Empty = DefaultProgressBarOptions().Empty
+	}
+
+	count := func(n int64) string {
+		if opts.Bytes {
+			return FormatBytes(n)
+		}
+		return fmt.Sprintf("%d", n)
+	}
+
+	var sb strings.Builder
+	if total > 0 {
+		if current > total {
+			current = total
+		}
+		filled := int(int64(opts.Width) * current / total)
+		sb.WriteString("[" + strings.Repeat(opts.Fill, filled) + strings.Repeat(opts.Empty, opts.Width-filled) + "]")
+		fmt.Fprintf(&sb, " %3d%% %s/%s", current*100/total, count(current), count(total))
+		if current > 0 && current < total && elapsed > 0 {
+			eta := time.Duration(float64(elapsed) * float64(total-current) / float64(current))
+			sb.WriteString(" ETA " + eta.Round(time.Second).String())
+		}
+	} else {
+		sb.WriteString("[" + strings.Repeat(opts.Empty, opts.Width) + "] " + count(current))
+	}
+
+	if label != "" {
+		if opts.LabelWidth > 0 && StringWidth(label) > opts.LabelWidth {
+			label = runewidth.TruncateLeft(label, StringWidth(label)-opts.LabelWidth+1, "…")
+		}
+		sb.WriteString(" " + label)
+	}
+	return sb.String()
+}
+
+// FormatBytes renders a byte count using binary units (e.g., "1.5 MiB").
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
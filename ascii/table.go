@@ -0,0 +1,390 @@
+package ascii
+
+import (
+	"strings"
+
+	"github.com/clipperhouse/uax29/v2/graphemes"
+)
+
+// Alignment controls how cell content is padded within a column.
+type Alignment int
+
+const (
+	AlignLeft Alignment = iota
+	AlignCenter
+	AlignRight
+)
+
+// Column defines a table column.
+type Column struct {
+	Header string
+	Align  Alignment
+	// Width fixes the column content width (default: 0, sized to content).
+	Width int
+	// MaxWidth caps the content width when sizing to content (default: 0, unlimited).
+	MaxWidth int
+	// Wrap wraps overflowing cells onto additional lines instead of truncating them.
+	Wrap bool
+}
+
+// TableStyle holds the border characters for a table.
+type TableStyle struct {
+	TopLeft     string
+	TopJunction string
+	TopRight    string
+
+	HeaderLeft       string
+	HeaderJunction   string
+	HeaderRight      string
+	HeaderHorizontal string
+
+	BottomLeft     string
+	BottomJunction string
+	BottomRight    string
+
+	Horizontal string
+	Vertical   string
+
+	// Markdown renders a GitHub-flavored markdown table: no top or bottom
+	// border, alignment markers in the header separator, escaped pipes, and
+	// no wrapping (multi-line cells are joined with spaces).
+	Markdown bool
+}
+
+// TableStyleLight returns the default style, matching DefaultBoxChars.
+func TableStyleLight() TableStyle {
+	return TableStyle{
+		TopLeft: "┌", TopJunction: "┬", TopRight: "┐",
+		HeaderLeft: "├", HeaderJunction: "┼", HeaderRight: "┤", HeaderHorizontal: "─",
+		BottomLeft: "└", BottomJunction: "┴", BottomRight: "┘",
+		Horizontal: "─", Vertical: "│",
+	}
+}
+
+// TableStyleASCII returns a style using only ASCII characters.
+func TableStyleASCII() TableStyle {
+	return TableStyle{
+		TopLeft: "+", TopJunction: "+", TopRight: "+",
+		HeaderLeft: "+", HeaderJunction: "+", HeaderRight: "+", HeaderHorizontal: "-",
+		BottomLeft: "+", BottomJunction: "+", BottomRight: "+",
+		Horizontal: "-", Vertical: "|",
+	}
+}
+
+// TableStyleRounded returns the light style with rounded corners.
+func TableStyleRounded() TableStyle {
+	style := TableStyleLight()
+	style.TopLeft, style.TopRight = "╭", "╮"
+	style.BottomLeft, style.BottomRight = "╰", "╯"
+	return style
+}
+
+// TableStyleHeavy returns a style using heavy box-drawing characters.
+func TableStyleHeavy() TableStyle {
+	return TableStyle{
+		TopLeft: "┏", TopJunction: "┳", TopRight: "┓",
+		HeaderLeft: "┣", HeaderJunction: "╋", HeaderRight: "┫", HeaderHorizontal: "━",
+		BottomLeft: "┗", BottomJunction: "┻", BottomRight: "┛",
+		Horizontal: "━", Vertical: "┃",
+	}
+}
+
+// TableStyleMarkdown returns a GitHub-flavored markdown table style.
+func TableStyleMarkdown() TableStyle {
+	return TableStyle{
+		HeaderLeft: "|", HeaderJunction: "|", HeaderRight: "|", HeaderHorizontal: "-",
+		Vertical: "|",
+		Markdown: true,
+	}
+}
+
+// TableOptions configures table rendering.
+type TableOptions struct {
+	// Style selects the border characters (default: TableStyleLight()).
+	Style *TableStyle
+	// MaxWidth caps the total rendered width; columns without a fixed Width
+	// shrink, widest first, until the table fits (default: 0, unlimited).
+	MaxWidth int
+	// Ellipsis marks truncated cells (default: "…").
+	Ellipsis string
+}
+
+// Table renders rows of cells in aligned columns. Widths are measured with
+// WidthIgnoringANSI, so emoji, CJK, terminal overrides, and colored cells
+// are handled.
+type Table struct {
+	columns []Column
+	rows    [][]string
+	opts    TableOptions
+}
+
+// NewTable creates a table with the given columns.
+// Zero-valued option fields fall back to defaults.
+//
+// Example:
+//
+//	style := ascii.TableStyleRounded()
+//	table := ascii.NewTable([]ascii.Column{
+//	    {Header: "Tool"},
+//	    {Header: "Version", Align: ascii.AlignRight},
+//	    {Header: "Description", MaxWidth: 40, Wrap: true},
+//	}, &ascii.TableOptions{Style: &style})
+//	table.AddRow("goneat", "v0.3.1", "Formatting and assessment")
+//	fmt.Print(table.Render())
+func NewTable(columns []Column, opts *TableOptions) *Table {
+	resolved := TableOptions{Ellipsis: "…"}
+	if opts != nil {
+		resolved.Style = opts.Style
+		resolved.MaxWidth = opts.MaxWidth
+		if opts.Ellipsis != "" {
+			resolved.Ellipsis = opts.Ellipsis
+		}
+	}
+	if resolved.Style == nil {
+		style := TableStyleLight()
+		resolved.Style = &style
+	}
+
+	cols := make([]Column, len(columns))
+	copy(cols, columns)
+	return &Table{columns: cols, opts: resolved}
+}
+
+// AddRow appends a row. Missing cells render empty; extra cells are ignored.
+func (t *Table) AddRow(cells ...string) {
+	row := make([]string, len(t.columns))
+	copy(row, cells)
+	t.rows = append(t.rows, row)
+}
+
+// Render returns the table as a string ending in a newline.
+func (t *Table) Render() string {
+	style := *t.opts.Style
+	widths := t.columnWidths()
+
+	var sb strings.Builder
+	if !style.Markdown {
+		t.writeBorder(&sb, widths, style.TopLeft, style.TopJunction, style.TopRight, style.Horizontal)
+	}
+
+	headers := make([]string, len(t.columns))
+	for i, col := range t.columns {
+		headers[i] = col.Header
+	}
+	t.writeRow(&sb, widths, headers)
+
+	if style.Markdown {
+		t.writeMarkdownSeparator(&sb, widths)
+	} else {
+		t.writeBorder(&sb, widths, style.HeaderLeft, style.HeaderJunction, style.HeaderRight, style.HeaderHorizontal)
+	}
+
+	for _, row := range t.rows {
+		t.writeRow(&sb, widths, row)
+	}
+
+	if !style.Markdown {
+		t.writeBorder(&sb, widths, style.BottomLeft, style.BottomJunction, style.BottomRight, style.Horizontal)
+	}
+	return sb.String()
+}
+
+// columnWidths computes content widths for every column, honoring fixed
+// widths, per-column caps, and the table MaxWidth.
+func (t *Table) columnWidths() []int {
+	minWidth := 1
+	if t.opts.Style.Markdown {
+		minWidth = 3 // room for the "---" separator
+	}
+
+	widths := make([]int, len(t.columns))
+	for i, col := range t.columns {
+		if col.Width > 0 {
+			widths[i] = max(col.Width, minWidth)
+			continue
+		}
+		w := maxLineWidth(t.cellText(col.Header))
+		for _, row := range t.rows {
+			if cw := maxLineWidth(t.cellText(row[i])); cw > w {
+				w = cw
+			}
+		}
+		if col.MaxWidth > 0 && w > col.MaxWidth {
+			w = col.MaxWidth
+		}
+		widths[i] = max(w, minWidth)
+	}
+
+	if t.opts.MaxWidth <= 0 {
+		return widths
+	}
+
+	// Each column adds two padding spaces and one vertical border, plus the
+	// closing border.
+	total := 1
+	for _, w := range widths {
+		total += w + 3
+	}
+	for total > t.opts.MaxWidth {
+		widest := -1
+		for i, col := range t.columns {
+			if col.Width == 0 && widths[i] > minWidth && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// cellText prepares raw cell content for the table style.
+func (t *Table) cellText(s string) string {
+	if t.opts.Style.Markdown {
+		s = strings.ReplaceAll(s, "|", `\|`)
+		s = strings.Join(strings.Fields(s), " ")
+	}
+	return s
+}
+
+// writeRow writes one logical row, which may span several lines when cells
+// wrap or contain newlines.
+func (t *Table) writeRow(sb *strings.Builder, widths []int, cells []string) {
+	lines := make([][]string, len(t.columns))
+	height := 1
+	for i, col := range t.columns {
+		lines[i] = t.layoutCell(t.cellText(cells[i]), widths[i], col.Wrap)
+		if len(lines[i]) > height {
+			height = len(lines[i])
+		}
+	}
+
+	vertical := t.opts.Style.Vertical
+	for l := 0; l < height; l++ {
+		sb.WriteString(vertical)
+		for i, col := range t.columns {
+			text := ""
+			if l < len(lines[i]) {
+				text = lines[i][l]
+			}
+			sb.WriteString(" ")
+			sb.WriteString(PadToWidth(text, widths[i], col.Align))
+			sb.WriteString(" ")
+			sb.WriteString(vertical)
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// layoutCell splits content into lines no wider than width.
+func (t *Table) layoutCell(content string, width int, wrap bool) []string {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if WidthIgnoringANSI(line) <= width {
+			lines = append(lines, line)
+		} else if wrap && !t.opts.Style.Markdown {
+			lines = append(lines, wrapWidth(line, width)...)
+		} else {
+			lines = append(lines, TruncateToWidth(line, width, t.opts.Ellipsis))
+		}
+	}
+	return lines
+}
+
+// writeBorder writes a horizontal border line.
+func (t *Table) writeBorder(sb *strings.Builder, widths []int, left, junction, right, horizontal string) {
+	sb.WriteString(left)
+	for i, w := range widths {
+		if i > 0 {
+			sb.WriteString(junction)
+		}
+		sb.WriteString(strings.Repeat(horizontal, w+2))
+	}
+	sb.WriteString(right)
+	sb.WriteString("\n")
+}
+
+// writeMarkdownSeparator writes the header separator with alignment markers.
+func (t *Table) writeMarkdownSeparator(sb *strings.Builder, widths []int) {
+	sb.WriteString("|")
+	for i, col := range t.columns {
+		dashes := strings.Repeat("-", widths[i])
+		switch col.Align {
+		case AlignCenter:
+			dashes = ":" + dashes[2:] + ":"
+		case AlignRight:
+			dashes = dashes[1:] + ":"
+		}
+		sb.WriteString(" " + dashes + " |")
+	}
+	sb.WriteString("\n")
+}
+
+// maxLineWidth returns the widest line in s.
+func maxLineWidth(s string) int {
+	widest := 0
+	for _, line := range strings.Split(s, "\n") {
+		if w := WidthIgnoringANSI(line); w > widest {
+			widest = w
+		}
+	}
+	return widest
+}
+
+// wrapWidth breaks s into lines of at most width display cells, preferring
+// to break at spaces. Words wider than width are split between grapheme
+// clusters. Escape sequences are zero-width.
+func wrapWidth(s string, width int) []string {
+	if width <= 0 {
+		return []string{s}
+	}
+
+	var lines []string
+	var line strings.Builder
+	lineWidth := 0
+	flush := func() {
+		lines = append(lines, line.String())
+		line.Reset()
+		lineWidth = 0
+	}
+
+	for _, word := range strings.Fields(s) {
+		wordWidth := WidthIgnoringANSI(word)
+		if lineWidth > 0 && lineWidth+1+wordWidth <= width {
+			line.WriteString(" " + word)
+			lineWidth += 1 + wordWidth
+			continue
+		}
+		if lineWidth > 0 {
+			flush()
+		}
+		if wordWidth <= width {
+			line.WriteString(word)
+			lineWidth = wordWidth
+			continue
+		}
+
+		splitANSI(word, func(segment string, escape bool) {
+			if escape {
+				line.WriteString(segment)
+				return
+			}
+			g := graphemes.FromString(segment)
+			for g.Next() {
+				w := StringWidth(g.Value())
+				if lineWidth+w > width && lineWidth > 0 {
+					flush()
+				}
+				line.WriteString(g.Value())
+				lineWidth += w
+			}
+		})
+	}
+	if lineWidth > 0 || len(lines) == 0 {
+		flush()
+	}
+	return lines
+}
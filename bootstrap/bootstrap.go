@@ -1,16 +1,57 @@
 package bootstrap
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 type Options struct {
 	ManifestPath string
-	Force        bool
-	Verbose      bool
+	// LockPath overrides the lock file location (default: LockPath(ManifestPath)).
+	LockPath string
+	// Force re-downloads tools, replacing their shared cache entries.
+	Force   bool
+	Verbose bool
+	// Update re-resolves every version constraint instead of reusing locked versions.
+	Update bool
+	// Jobs limits concurrent 'go' and 'download' installs (default: runtime.NumCPU()).
+	Jobs int
+	// CacheDir overrides the shared download cache (default: DefaultCacheDir()).
+	CacheDir string
+	// NoCache downloads directly into the destination without the shared cache.
+	NoCache bool
+}
+
+// UpdateLock resolves the manifest's version constraints and writes the
+// lock file without installing anything. Locked versions are kept unless
+// opts.Update is set or the manifest constraint changed.
+func UpdateLock(opts Options) (*Lock, error) {
+	if opts.ManifestPath == "" {
+		opts.ManifestPath = ".goneat/tools.yaml"
+	}
+
+	manifestPath := resolveManifestPath(opts.ManifestPath)
+
+	manifest, err := LoadManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return syncLock(context.Background(), manifest, lockPathFor(opts, manifestPath), opts.Update)
+}
+
+func lockPathFor(opts Options, manifestPath string) string {
+	if opts.LockPath != "" {
+		return opts.LockPath
+	}
+	return LockPath(manifestPath)
 }
 
 func InstallTools(opts Options) error {
@@ -25,6 +66,11 @@ func InstallTools(opts Options) error {
 		return err
 	}
 
+	lock, err := syncLock(context.Background(), manifest, lockPathFor(opts, manifestPath), opts.Update)
+	if err != nil {
+		return err
+	}
+
 	platform := GetPlatform()
 
 	if supported, msg := IsPlatformSupported(platform); !supported {
@@ -39,31 +85,22 @@ func InstallTools(opts Options) error {
 		fmt.Printf("Tools: %d\n\n", len(manifest.Tools))
 	}
 
+	results := installAll(manifest.Tools, lock, platform, opts)
+
 	var errors []error
 	successCount := 0
 
-	for _, tool := range manifest.Tools {
-		if opts.Verbose {
-			fmt.Printf("📦 %s (%s)...", tool.ID, tool.Install.Type)
+	for i, err := range results {
+		tool := manifest.Tools[i]
+		if err == errSkipped {
+			continue
 		}
-
-		err := installTool(&tool, platform, opts)
 		if err != nil {
-			if opts.Verbose {
-				fmt.Printf(" ❌\n")
-			}
 			errors = append(errors, fmt.Errorf("%s: %w", tool.ID, err))
-
-			if tool.Required {
-				if opts.Verbose {
-					fmt.Printf("\n❌ Required tool %s failed to install\n", tool.ID)
-				}
-				break
+			if tool.Required && opts.Verbose {
+				fmt.Printf("\n❌ Required tool %s failed to install\n", tool.ID)
 			}
 		} else {
-			if opts.Verbose {
-				fmt.Printf(" ✅\n")
-			}
 			successCount++
 		}
 	}
@@ -85,6 +122,72 @@ func InstallTools(opts Options) error {
 	return nil
 }
 
+// errSkipped marks tools not attempted because a required tool failed.
+var errSkipped = errors.New("skipped")
+
+// installAll installs every tool and returns one result per tool, in
+// manifest order. 'go' and 'download' installs are independent and run
+// concurrently, up to opts.Jobs at a time; 'verify' and 'link' steps may
+// depend on them, so they run afterwards in manifest order. Once a required
+// tool fails, tools not yet started are skipped.
+func installAll(tools []Tool, lock *Lock, platform Platform, opts Options) []error {
+	jobs := opts.Jobs
+	if jobs <= 0 {
+		jobs = runtime.NumCPU()
+	}
+	cache := newToolCache(opts)
+
+	results := make([]error, len(tools))
+	var mu sync.Mutex
+	var requiredFailed atomic.Bool
+
+	install := func(i int) {
+		tool := tools[i]
+		if requiredFailed.Load() {
+			results[i] = errSkipped
+			return
+		}
+		applyLock(&tool, lock)
+
+		err := installTool(&tool, platform, cache)
+		results[i] = err
+		if err != nil && tool.Required {
+			requiredFailed.Store(true)
+		}
+
+		if opts.Verbose {
+			status := "✅"
+			if err != nil {
+				status = "❌"
+			}
+			mu.Lock()
+			fmt.Printf("📦 %s (%s)... %s\n", tool.ID, tool.Install.Type, status)
+			mu.Unlock()
+		}
+	}
+
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	for i, tool := range tools {
+		if tool.Install.Type != "go" && tool.Install.Type != "download" {
+			continue
+		}
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			install(i)
+		})
+	}
+	wg.Wait()
+
+	for i, tool := range tools {
+		if tool.Install.Type != "go" && tool.Install.Type != "download" {
+			install(i)
+		}
+	}
+	return results
+}
+
 func VerifyTools(opts Options) error {
 	if opts.ManifestPath == "" {
 		opts.ManifestPath = ".goneat/tools.yaml"
@@ -139,16 +242,13 @@ func VerifyTools(opts Options) error {
 	return nil
 }
 
-func installTool(tool *Tool, platform Platform, opts Options) error {
+func installTool(tool *Tool, platform Platform, cache *toolCache) error {
 	switch tool.Install.Type {
 	case "verify":
 		return installVerify(tool)
 
-	case "go":
-		return installGo(tool)
-
-	case "download":
-		return installDownload(tool, platform)
+	case "go", "download":
+		return installArtifact(tool, platform, cache)
 
 	case "link":
 		return installLink(tool)
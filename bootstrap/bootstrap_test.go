@@ -1,10 +1,24 @@
 package bootstrap
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/foundry"
 )
 
 func TestGetPlatform(t *testing.T) {
@@ -347,6 +361,19 @@ func TestValidateTool(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Download type with invalid retry",
+			tool: Tool{
+				ID: "test",
+				Install: Install{
+					Type:    "download",
+					URL:     "https://example.com/tool.tar.gz",
+					BinName: "tool",
+					Retry:   &foundry.RetryPolicy{MaxAttempts: 0},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Missing ID",
 			tool: Tool{
@@ -410,3 +437,208 @@ func TestValidateTool(t *testing.T) {
 		})
 	}
 }
+
+func TestDownloadFileRetry(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		switch {
+		case r.URL.Path == "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case attempts < 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte("payload"))
+		}
+	}))
+	defer server.Close()
+
+	policy := &foundry.RetryPolicy{
+		InitialInterval: foundry.HumanDuration(time.Millisecond),
+		MaxInterval:     foundry.HumanDuration(time.Millisecond),
+		Multiplier:      1,
+		MaxAttempts:     3,
+		Jitter:          foundry.JitterNone,
+	}
+	dest := filepath.Join(t.TempDir(), "tool.tar.gz")
+
+	if err := downloadFile(context.Background(), policy, server.URL+"/tool.tar.gz", dest); err != nil {
+		t.Fatalf("downloadFile failed: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if data, _ := os.ReadFile(dest); string(data) != "payload" {
+		t.Errorf("unexpected file content %q", data)
+	}
+
+	attempts = 0
+	err := downloadFile(context.Background(), policy, server.URL+"/missing", dest)
+	var statusErr *foundry.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("expected HTTP 404 status error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 404 not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestDownloadFileRetryAfter(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	// A requested delay above MaxInterval ends the retries
+	policy := &foundry.RetryPolicy{
+		InitialInterval: foundry.HumanDuration(time.Millisecond),
+		MaxInterval:     foundry.HumanDuration(time.Second),
+		Multiplier:      1,
+		MaxAttempts:     3,
+	}
+	err := downloadFile(context.Background(), policy, server.URL, filepath.Join(t.TempDir(), "tool"))
+	var statusErr *foundry.HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.RetryAfter != time.Minute {
+		t.Errorf("expected Retry-After of 1m, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	tests := map[string]string{
+		"": "",
+		`<https://api.github.com/repositories/1/releases?page=2>; rel="next", <https://api.github.com/repositories/1/releases?page=5>; rel="last"`: "https://api.github.com/repositories/1/releases?page=2",
+		`<https://api.github.com/repositories/1/releases?page=1>; rel="prev"`:                                                                      "",
+		`<https://example.com/next>; title="x"; rel="next last"`:                                                                                   "https://example.com/next",
+	}
+	for header, want := range tests {
+		if got := nextLink(header); got != want {
+			t.Errorf("nextLink(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+// newArchiveServer serves a .tar.gz containing a "tool" binary over TLS and
+// counts downloads.
+func newArchiveServer(t *testing.T) (*httptest.Server, string, *atomic.Int32) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	content := []byte("#!/bin/sh\necho tool\n")
+	if err := tw.WriteHeader(&tar.Header{Name: "tool", Mode: 0755, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = tw.Write(content)
+	_ = tw.Close()
+	_ = gz.Close()
+	archive := buf.Bytes()
+	sum := sha256.Sum256(archive)
+
+	var downloads atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		_, _ = w.Write(archive)
+	}))
+	t.Cleanup(server.Close)
+
+	origClient := http.DefaultClient
+	http.DefaultClient = server.Client()
+	t.Cleanup(func() { http.DefaultClient = origClient })
+	return server, hex.EncodeToString(sum[:]), &downloads
+}
+
+func TestInstallDownloadSharedCache(t *testing.T) {
+	server, checksum, downloads := newArchiveServer(t)
+	platform := GetPlatform()
+	cache := &toolCache{dir: t.TempDir()}
+
+	newTool := func(dest string) *Tool {
+		return &Tool{
+			ID: "tool",
+			Install: Install{
+				Type:        "download",
+				URL:         server.URL + "/tool_{{os}}_{{arch}}.tar.gz",
+				BinName:     "tool",
+				Destination: dest,
+				Version:     "v1.0.0",
+				Checksum:    map[string]string{platform.String(): checksum},
+			},
+		}
+	}
+
+	repoA, repoB := t.TempDir(), t.TempDir()
+	if err := installArtifact(newTool(repoA), platform, cache); err != nil {
+		t.Fatalf("first install failed: %v", err)
+	}
+	if err := installArtifact(newTool(repoB), platform, cache); err != nil {
+		t.Fatalf("second install failed: %v", err)
+	}
+	if got := downloads.Load(); got != 1 {
+		t.Errorf("expected 1 download with a warm cache, got %d", got)
+	}
+	for _, dir := range []string{repoA, repoB} {
+		if data, err := os.ReadFile(filepath.Join(dir, "tool")); err != nil || !strings.Contains(string(data), "echo tool") {
+			t.Errorf("binary not installed in %s: %v", dir, err)
+		}
+	}
+
+	// Reinstalling over a hard-linked binary must not corrupt the cache.
+	if err := installArtifact(newTool(repoA), platform, nil); err != nil {
+		t.Fatalf("uncached install failed: %v", err)
+	}
+	cache.refresh = true
+	if err := installArtifact(newTool(repoB), platform, cache); err != nil {
+		t.Fatalf("refresh install failed: %v", err)
+	}
+	if got := downloads.Load(); got != 3 {
+		t.Errorf("expected uncached and refresh installs to download, got %d downloads", got)
+	}
+}
+
+func TestInstallAllSkipsAfterRequiredFailure(t *testing.T) {
+	server, _, _ := newArchiveServer(t)
+	platform := GetPlatform()
+
+	tools := []Tool{
+		{
+			ID:       "broken",
+			Required: true,
+			Install: Install{
+				Type:        "download",
+				URL:         server.URL + "/tool.tar.gz",
+				BinName:     "tool",
+				Destination: t.TempDir(),
+				Checksum:    map[string]string{platform.String(): strings.Repeat("0", 64)},
+			},
+		},
+		{ID: "shell", Install: Install{Type: "verify", Command: "sh"}},
+	}
+
+	results := installAll(tools, &Lock{}, platform, Options{NoCache: true, Jobs: 2})
+	var mismatch *ChecksumMismatchError
+	if !errors.As(results[0], &mismatch) {
+		t.Errorf("expected checksum mismatch, got %v", results[0])
+	}
+	if results[1] != errSkipped {
+		t.Errorf("expected verify step to be skipped, got %v", results[1])
+	}
+}
+
+func TestDefaultCacheDir(t *testing.T) {
+	t.Setenv(EnvCacheDir, "/tmp/custom-cache")
+	if got := DefaultCacheDir(); got != "/tmp/custom-cache" {
+		t.Errorf("DefaultCacheDir() = %q, want env override", got)
+	}
+
+	t.Setenv(EnvCacheDir, "")
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg-cache")
+	if got := DefaultCacheDir(); got != filepath.Join("/tmp/xdg-cache", "fulmen", "bootstrap") {
+		t.Errorf("DefaultCacheDir() = %q", got)
+	}
+}
@@ -0,0 +1,130 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/fulmenhq/gofulmen/config"
+)
+
+// EnvCacheDir overrides the shared tool cache directory.
+const EnvCacheDir = "FULMEN_BOOTSTRAP_CACHE"
+
+// DefaultCacheDir returns the per-user cache shared by every repository
+// bootstrapping tools on this machine: $FULMEN_BOOTSTRAP_CACHE, or
+// bootstrap/ under the Fulmen cache directory (~/.cache/fulmen/bootstrap).
+// Returns "" when no absolute location can be determined.
+func DefaultCacheDir() string {
+	if dir := os.Getenv(EnvCacheDir); dir != "" {
+		return dir
+	}
+	dir := filepath.Join(config.GetFulmenCacheDir(), "bootstrap")
+	if !filepath.IsAbs(dir) {
+		return ""
+	}
+	return dir
+}
+
+// toolCache stores verified download binaries keyed by tool, version,
+// platform, and archive digest, so repositories pinning the same release
+// share one download.
+type toolCache struct {
+	dir string
+	// refresh replaces cached entries instead of reusing them.
+	refresh bool
+}
+
+// newToolCache returns the cache selected by opts, or nil when caching is
+// disabled.
+func newToolCache(opts Options) *toolCache {
+	if opts.NoCache {
+		return nil
+	}
+	dir := opts.CacheDir
+	if dir == "" {
+		dir = DefaultCacheDir()
+	}
+	if dir == "" {
+		return nil
+	}
+	return &toolCache{dir: dir, refresh: opts.Force}
+}
+
+// path returns the cache location of a tool's binary. The digest pins the
+// exact archive, so a changed checksum never reuses a stale binary.
+func (c *toolCache) path(tool *Tool, platform Platform, digest string) string {
+	version := tool.Install.Version
+	if version == "" {
+		version = "unversioned"
+	}
+	if len(digest) > 16 {
+		digest = digest[:16]
+	}
+	return filepath.Join(c.dir, tool.ID, version, platform.String()+"-"+digest, tool.Install.BinName)
+}
+
+// lookup reports whether a usable cached binary exists at path.
+func (c *toolCache) lookup(path string) bool {
+	if c.refresh {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// store copies binPath into the cache at path. The copy is written to a
+// temporary file and renamed, so concurrent installs never observe a
+// partial binary.
+func (c *toolCache) store(binPath, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	tmpPath := tmp.Name()
+	_ = tmp.Close()
+	defer os.Remove(tmpPath) //nolint:errcheck // already renamed on success
+
+	if err := copyFile(binPath, tmpPath); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := makeExecutable(tmpPath); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// linkOrCopy places src at dst, replacing any existing file. A hard link is
+// used when src and dst share a filesystem; otherwise the file is copied.
+func linkOrCopy(src, dst string) error {
+	if err := os.Remove(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to replace %s: %w", dst, err)
+	}
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+	if err := copyFile(src, dst); err != nil {
+		return fmt.Errorf("failed to copy binary to %s: %w", dst, err)
+	}
+	return makeExecutable(dst)
+}
+
+func makeExecutable(path string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	// #nosec G302 -- binary files require executable permissions (0755)
+	if err := os.Chmod(path, 0755); err != nil {
+		return fmt.Errorf("failed to make binary executable: %w", err)
+	}
+	return nil
+}
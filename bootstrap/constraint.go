@@ -0,0 +1,257 @@
+package bootstrap
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// Constraint is a parsed semver version constraint such as ">=1.5 <2",
+// "~1.4", or "^0.3 || ^1".
+//
+// Syntax:
+//   - Comparators: =, !=, >, >=, <, <= followed by a version ("1", "1.5", "v1.5.2")
+//   - ~1.4 allows patch updates (>=1.4.0 <1.5.0); ~1 allows minor updates
+//   - ^1.4 allows updates that do not change the leftmost non-zero component
+//   - 1.x and 1.4.* are wildcards; "*", "x", "latest", and "" match any release
+//   - Whitespace or commas join comparators (AND); "||" joins alternatives (OR)
+//
+// Pre-releases only match when a comparator in the same alternative names a
+// pre-release.
+type Constraint struct {
+	raw          string
+	alternatives [][]comparator
+}
+
+type comparator struct {
+	op      string
+	version string // canonical "vMAJOR.MINOR.PATCH[-pre]"
+}
+
+// ParseConstraint parses a version constraint.
+//
+// Example:
+//
+//	c, err := bootstrap.ParseConstraint(">=1.5 <2")
+//	c.Check("v1.9.3") // true
+//	c.Check("v2.0.0") // false
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{raw: strings.TrimSpace(s)}
+	for _, alt := range strings.Split(c.raw, "||") {
+		fields := strings.Fields(strings.ReplaceAll(alt, ",", " "))
+		comparators := []comparator{}
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			// Allow a space between operator and version (">= 1.5")
+			if isOperator(field) && i+1 < len(fields) {
+				field += fields[i+1]
+				i++
+			}
+			parsed, err := parseComparator(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version constraint %q: %w", s, err)
+			}
+			comparators = append(comparators, parsed...)
+		}
+		c.alternatives = append(c.alternatives, comparators)
+	}
+	return c, nil
+}
+
+// String returns the constraint as written.
+func (c *Constraint) String() string {
+	return c.raw
+}
+
+// Check reports whether version (with or without a leading "v") satisfies
+// the constraint. Invalid versions never match.
+func (c *Constraint) Check(version string) bool {
+	v := canonicalVersion(version)
+	if v == "" {
+		return false
+	}
+	for _, comparators := range c.alternatives {
+		if matchesAll(v, comparators) {
+			return true
+		}
+	}
+	return false
+}
+
+// Latest returns the highest version in versions satisfying the constraint,
+// as written in versions, or "" if none match.
+func (c *Constraint) Latest(versions []string) string {
+	best, bestCanonical := "", ""
+	for _, version := range versions {
+		if !c.Check(version) {
+			continue
+		}
+		canonical := canonicalVersion(version)
+		if best == "" || semver.Compare(canonical, bestCanonical) > 0 {
+			best, bestCanonical = version, canonical
+		}
+	}
+	return best
+}
+
+// IsExactVersion reports whether s names a single full version (e.g.,
+// "v1.5.2" or "1.5.2") rather than a constraint.
+func IsExactVersion(s string) bool {
+	s = strings.TrimSpace(s)
+	v := canonicalVersion(s)
+	return v != "" && strings.Count(strings.SplitN(strings.TrimPrefix(s, "v"), "-", 2)[0], ".") == 2
+}
+
+func matchesAll(v string, comparators []comparator) bool {
+	allowPrerelease := semver.Prerelease(v) == ""
+	for _, cmp := range comparators {
+		if semver.Prerelease(cmp.version) != "" {
+			allowPrerelease = true
+		}
+		result := semver.Compare(v, cmp.version)
+		var ok bool
+		switch cmp.op {
+		case "=":
+			ok = result == 0
+		case "!=":
+			ok = result != 0
+		case ">":
+			ok = result > 0
+		case ">=":
+			ok = result >= 0
+		case "<":
+			ok = result < 0
+		case "<=":
+			ok = result <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return allowPrerelease
+}
+
+func isOperator(s string) bool {
+	switch s {
+	case "=", "!=", ">", ">=", "<", "<=", "~", "^":
+		return true
+	}
+	return false
+}
+
+// parseComparator expands one constraint term into primitive comparators.
+func parseComparator(term string) ([]comparator, error) {
+	switch term {
+	case "*", "x", "X", "latest":
+		return nil, nil
+	}
+
+	op := ""
+	for _, candidate := range []string{">=", "<=", "!=", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(term, candidate) {
+			op = candidate
+			break
+		}
+	}
+	version := strings.TrimPrefix(strings.TrimPrefix(term, op), "v")
+
+	// Split off any pre-release and count the numeric components given.
+	core, pre := version, ""
+	if i := strings.IndexByte(version, '-'); i >= 0 {
+		core, pre = version[:i], version[i:]
+	}
+	parts := strings.Split(core, ".")
+	for len(parts) > 0 {
+		last := parts[len(parts)-1]
+		if last != "x" && last != "X" && last != "*" {
+			break
+		}
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) == 0 {
+		if op == "" || op == "=" {
+			return nil, nil // "x.x" style wildcard
+		}
+		return nil, fmt.Errorf("missing version in %q", term)
+	}
+	if len(parts) > 3 || (pre != "" && len(parts) < 3) {
+		return nil, fmt.Errorf("malformed version %q", version)
+	}
+
+	nums := make([]int, 3)
+	for i, part := range parts {
+		if _, err := fmt.Sscanf(part, "%d", &nums[i]); err != nil || fmt.Sprint(nums[i]) != part {
+			return nil, fmt.Errorf("malformed version %q", version)
+		}
+	}
+	given := len(parts)
+	low := fmt.Sprintf("v%d.%d.%d%s", nums[0], nums[1], nums[2], pre)
+	if !semver.IsValid(low) {
+		return nil, fmt.Errorf("malformed version %q", version)
+	}
+
+	// upper returns the first version past the range fixed by the first n components.
+	upper := func(n int) string {
+		switch n {
+		case 1:
+			return fmt.Sprintf("v%d.0.0", nums[0]+1)
+		case 2:
+			return fmt.Sprintf("v%d.%d.0", nums[0], nums[1]+1)
+		default:
+			return fmt.Sprintf("v%d.%d.%d", nums[0], nums[1], nums[2]+1)
+		}
+	}
+
+	switch op {
+	case "", "=":
+		if given == 3 {
+			return []comparator{{"=", low}}, nil
+		}
+		return []comparator{{">=", low}, {"<", upper(given)}}, nil
+	case "!=":
+		if given < 3 {
+			return nil, fmt.Errorf("%q requires a full version", term)
+		}
+		return []comparator{{"!=", low}}, nil
+	case ">=", "<":
+		return []comparator{{op, low}}, nil
+	case ">":
+		if given == 3 {
+			return []comparator{{">", low}}, nil
+		}
+		return []comparator{{">=", upper(given)}}, nil
+	case "<=":
+		if given == 3 {
+			return []comparator{{"<=", low}}, nil
+		}
+		return []comparator{{"<", upper(given)}}, nil
+	case "~":
+		if given == 1 {
+			return []comparator{{">=", low}, {"<", upper(1)}}, nil
+		}
+		return []comparator{{">=", low}, {"<", upper(2)}}, nil
+	default: // "^"
+		switch {
+		case nums[0] > 0 || given == 1:
+			return []comparator{{">=", low}, {"<", upper(1)}}, nil
+		case nums[1] > 0 || given == 2:
+			return []comparator{{">=", low}, {"<", upper(2)}}, nil
+		default:
+			return []comparator{{">=", low}, {"<", upper(3)}}, nil
+		}
+	}
+}
+
+// canonicalVersion returns "vMAJOR.MINOR.PATCH[-pre]" for a version with
+// or without a leading "v", or "" if it is not valid semver.
+func canonicalVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if !semver.IsValid(version) {
+		return ""
+	}
+	return semver.Canonical(version)
+}
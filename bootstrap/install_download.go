@@ -1,25 +1,53 @@
 package bootstrap
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	"github.com/fulmenhq/gofulmen/foundry"
 )
 
-func installDownload(tool *Tool, platform Platform) error {
-	url := InterpolateURL(tool.Install.URL, platform)
+// installArtifact installs a 'go' or 'download' tool through its provider:
+// fetch, verify, extract the binary when the artifact is an archive, and
+// place it in the destination. Verified binaries are kept in the shared
+// cache when the tool is pinned by version or digest.
+func installArtifact(tool *Tool, platform Platform, cache *toolCache) error {
+	provider, err := providerFor(tool)
+	if err != nil {
+		return err
+	}
 
-	if !strings.HasPrefix(url, "https://") {
-		return fmt.Errorf("only HTTPS URLs are allowed, got: %s", url)
+	destDir := toolDestination(tool)
+	if err := os.MkdirAll(destDir, 0750); err != nil {
+		return fmt.Errorf("failed to create destination directory %s: %w", destDir, err)
 	}
 
-	expectedChecksum, ok := tool.Install.Checksum[platform.String()]
-	if !ok {
-		return fmt.Errorf("no checksum found for platform %s", platform)
+	binName := toolBinName(tool)
+	destPath := filepath.Join(destDir, binName)
+
+	digest := tool.Install.Checksum[platform.String()]
+	if digest == "" && !IsExactVersion(tool.Install.Version) {
+		cache = nil // nothing pins the binary, so a cached copy could be stale
+	}
+	var cachePath string
+	if cache != nil {
+		cachePath = cache.path(tool, platform, digest)
+		if cache.lookup(cachePath) {
+			if err := linkOrCopy(cachePath, destPath); err != nil {
+				return err
+			}
+			writeReceipt(tool, destPath)
+			return nil
+		}
 	}
 
 	tempDir, err := os.MkdirTemp("", "bootstrap-*")
@@ -28,65 +56,237 @@ func installDownload(tool *Tool, platform Platform) error {
 	}
 	defer os.RemoveAll(tempDir) //nolint:errcheck // defer RemoveAll error is commonly ignored in Go
 
-	archiveName := filepath.Base(url)
-	archivePath := filepath.Join(tempDir, archiveName)
-	if err := downloadFile(url, archivePath); err != nil {
-		return &DownloadError{URL: url, Platform: platform, Err: err}
+	artifact, err := provider.Fetch(context.Background(), tool, platform, tempDir)
+	if err != nil {
+		return err
 	}
 
-	if err := VerifySHA256(archivePath, expectedChecksum); err != nil {
+	if err := provider.Verify(tool, platform, artifact); err != nil {
 		return err
 	}
 
-	extractDir := filepath.Join(tempDir, "extract")
-	if err := os.MkdirAll(extractDir, 0750); err != nil {
-		return fmt.Errorf("failed to create extraction directory: %w", err)
+	binPath := artifact
+	if isArchive(artifact) {
+		extractDir := filepath.Join(tempDir, "extract")
+		if err := os.MkdirAll(extractDir, 0750); err != nil {
+			return fmt.Errorf("failed to create extraction directory: %w", err)
+		}
+
+		if err := ExtractArchive(artifact, extractDir); err != nil {
+			return err
+		}
+
+		binPath, err = findBinary(extractDir, binName)
+		if err != nil {
+			return fmt.Errorf("failed to find binary %s in extracted archive: %w", binName, err)
+		}
+	}
+
+	if cache != nil {
+		if err := cache.store(binPath, cachePath); err != nil {
+			return err
+		}
+		if err := linkOrCopy(cachePath, destPath); err != nil {
+			return err
+		}
+		writeReceipt(tool, destPath)
+		return nil
+	}
+
+	if err := os.Remove(destPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to replace %s: %w", destPath, err)
+	}
+	if err := copyFile(binPath, destPath); err != nil {
+		return fmt.Errorf("failed to copy binary to %s: %w", destPath, err)
 	}
 
-	if err := ExtractArchive(archivePath, extractDir); err != nil {
+	if err := makeExecutable(destPath); err != nil {
 		return err
 	}
+	writeReceipt(tool, destPath)
+	return nil
+}
 
-	binPath, err := findBinary(extractDir, tool.Install.BinName)
-	if err != nil {
-		return fmt.Errorf("failed to find binary %s in extracted archive: %w", tool.Install.BinName, err)
+// toolDestination returns the directory a tool's binary is installed into:
+// GOBIN (or GOPATH/bin) for 'go' tools, install.destination (default
+// ./bin) otherwise.
+func toolDestination(tool *Tool) string {
+	if tool.Install.Type == "go" && tool.Install.Destination == "" {
+		return goBinDir()
 	}
+	if tool.Install.Destination == "" {
+		return "./bin"
+	}
+	return tool.Install.Destination
+}
 
-	destDir := tool.Install.Destination
-	if destDir == "" {
-		destDir = "./bin"
+// toolBinName returns install.binName, or for 'go' tools the command name
+// go install derives from the module path.
+func toolBinName(tool *Tool) string {
+	if tool.Install.BinName != "" || tool.Install.Type != "go" {
+		return tool.Install.BinName
+	}
+	name := path.Base(tool.Install.Module)
+	if isMajorVersionSuffix(name) {
+		name = path.Base(path.Dir(tool.Install.Module))
 	}
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
 
-	if err := os.MkdirAll(destDir, 0750); err != nil {
-		return fmt.Errorf("failed to create destination directory %s: %w", destDir, err)
+func isMajorVersionSuffix(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isArchive(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".tar.gz") || strings.HasSuffix(lower, ".zip")
+}
+
+// httpProvider downloads from any HTTPS URL. Header values may reference
+// environment variables (e.g., "Bearer ${ARTIFACTORY_TOKEN}").
+type httpProvider struct{}
+
+func (httpProvider) Resolve(_ context.Context, tool *Tool) (LockedTool, error) {
+	return pinnedVersion(tool, ProviderHTTP)
+}
+
+func (httpProvider) Fetch(ctx context.Context, tool *Tool, platform Platform, dir string) (string, error) {
+	url := InterpolateURL(interpolateVersion(tool.Install.URL, tool.Install.Version), platform)
+
+	if !strings.HasPrefix(url, "https://") {
+		return "", fmt.Errorf("only HTTPS URLs are allowed, got: %s", url)
 	}
 
-	destPath := filepath.Join(destDir, tool.Install.BinName)
+	// Check before downloading so a missing checksum fails fast.
+	if _, ok := tool.Install.Checksum[platform.String()]; !ok {
+		return "", fmt.Errorf("no checksum found for platform %s", platform)
+	}
 
-	if err := copyFile(binPath, destPath); err != nil {
-		return fmt.Errorf("failed to copy binary to %s: %w", destPath, err)
+	headers := make(map[string]string, len(tool.Install.Headers))
+	for k, v := range tool.Install.Headers {
+		headers[k] = os.ExpandEnv(v)
+	}
+
+	artifactPath := filepath.Join(dir, path.Base(url))
+	if err := downloadFileWithHeaders(ctx, tool.Install.Retry, url, artifactPath, headers); err != nil {
+		return "", &DownloadError{URL: url, Platform: platform, Err: err}
+	}
+	return artifactPath, nil
+}
+
+func (httpProvider) Verify(tool *Tool, platform Platform, artifact string) error {
+	return verifyChecksum(tool, platform, artifact)
+}
+
+// githubProvider downloads GitHub release assets and resolves version
+// constraints from the releases of install.repo.
+type githubProvider struct {
+	httpProvider
+}
+
+func (githubProvider) Resolve(ctx context.Context, tool *Tool) (LockedTool, error) {
+	spec := tool.Install.Version
+	locked := LockedTool{Constraint: spec}
+
+	c, err := ParseConstraint(spec)
+	if err != nil {
+		return locked, err
+	}
+	releases, err := fetchGitHubReleases(ctx, tool.Install.Retry, tool.Install.Repo)
+	if err != nil {
+		return locked, err
+	}
+	versions := make([]string, len(releases))
+	for i, r := range releases {
+		versions[i] = r.Version
+	}
+	if locked.Version = c.Latest(versions); locked.Version == "" {
+		return locked, fmt.Errorf("no release of %s satisfies %q", tool.Install.Repo, spec)
 	}
 
-	if runtime.GOOS != "windows" {
-		// #nosec G302 -- binary files require executable permissions (0755)
-		if err := os.Chmod(destPath, 0755); err != nil {
-			return fmt.Errorf("failed to make binary executable: %w", err)
+	for _, r := range releases {
+		if r.Version != locked.Version {
+			continue
 		}
+		locked.Checksum = map[string]string{}
+		for _, platform := range lockPlatforms {
+			asset := path.Base(InterpolateURL(interpolateVersion(tool.Install.URL, r.Version), platform))
+			if digest, ok := r.Digests[asset]; ok {
+				locked.Checksum[platform.String()] = digest
+			}
+		}
+	}
+	if len(locked.Checksum) == 0 {
+		return locked, fmt.Errorf("release %s of %s publishes no digests for %s", locked.Version, tool.Install.Repo, path.Base(tool.Install.URL))
 	}
+	return locked, nil
+}
 
-	return nil
+// archiveProvider installs from a local archive at install.source, such as
+// a vendored or pre-mirrored release.
+type archiveProvider struct{}
+
+func (archiveProvider) Resolve(_ context.Context, tool *Tool) (LockedTool, error) {
+	return pinnedVersion(tool, ProviderArchive)
+}
+
+func (archiveProvider) Fetch(_ context.Context, tool *Tool, platform Platform, _ string) (string, error) {
+	source := InterpolateURL(interpolateVersion(tool.Install.Source, tool.Install.Version), platform)
+	if _, err := os.Stat(source); err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("source archive not found: %s", source)
+		}
+		return "", fmt.Errorf("failed to access source archive: %w", err)
+	}
+	return source, nil
 }
 
-func downloadFile(url, destPath string) error {
+func (archiveProvider) Verify(tool *Tool, platform Platform, artifact string) error {
+	return verifyChecksum(tool, platform, artifact)
+}
+
+// downloadFile fetches url into destPath, retrying transient failures
+// (network errors and statuses foundry.HTTPStatusHelper.IsRetryable accepts)
+// according to policy (nil uses the default), honoring Retry-After.
+func downloadFile(ctx context.Context, policy *foundry.RetryPolicy, url, destPath string) error {
+	return downloadFileWithHeaders(ctx, policy, url, destPath, nil)
+}
+
+// downloadFileWithHeaders is downloadFile with extra request headers.
+func downloadFileWithHeaders(ctx context.Context, policy *foundry.RetryPolicy, url, destPath string, headers map[string]string) error {
+	return policy.Do(ctx, func(ctx context.Context) error {
+		return downloadOnce(ctx, url, destPath, headers)
+	})
+}
+
+func downloadOnce(ctx context.Context, url, destPath string, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return foundry.Permanent(err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
 	// #nosec G107 -- URL comes from validated manifest in bootstrap process
-	resp, err := http.Get(url)
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close() //nolint:errcheck // defer Close() error is commonly ignored in Go
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	if err := responseError(resp); err != nil {
+		return err
 	}
 
 	// #nosec G304 -- destPath is controlled path from manifest for tool installation
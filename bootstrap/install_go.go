@@ -1,15 +1,45 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 )
 
-func installGo(tool *Tool) error {
+// goInstallProvider builds tools with `go install`. Module content is
+// verified by the go command against the checksum database, so Verify only
+// checks that the binary was produced.
+type goInstallProvider struct{}
+
+func (goInstallProvider) Resolve(ctx context.Context, tool *Tool) (LockedTool, error) {
+	spec := tool.Install.Version
+	locked := LockedTool{Constraint: spec}
+
+	if IsExactVersion(spec) {
+		locked.Version = spec
+		return locked, nil
+	}
+	c, err := ParseConstraint(spec)
+	if err != nil {
+		// Not a semver constraint (e.g., a branch name); go install resolves it.
+		locked.Version = spec
+		return locked, nil
+	}
+	versions, err := fetchGoModuleVersions(ctx, tool.Install.Retry, tool.Install.Module)
+	if err != nil {
+		return locked, err
+	}
+	if locked.Version = c.Latest(versions); locked.Version == "" {
+		return locked, fmt.Errorf("no version of %s satisfies %q", tool.Install.Module, spec)
+	}
+	return locked, nil
+}
+
+func (goInstallProvider) Fetch(ctx context.Context, tool *Tool, _ Platform, dir string) (string, error) {
 	if _, err := exec.LookPath("go"); err != nil {
-		return &CommandNotFoundError{
+		return "", &CommandNotFoundError{
 			Command:    "go",
 			Suggestion: "Install Go from https://go.dev/dl/",
 		}
@@ -18,33 +48,37 @@ func installGo(tool *Tool) error {
 	moduleVersion := fmt.Sprintf("%s@%s", tool.Install.Module, tool.Install.Version)
 
 	// #nosec G204 -- module version comes from validated manifest for intentional go install
-	cmd := exec.Command("go", "install", moduleVersion)
+	cmd := exec.CommandContext(ctx, "go", "install", moduleVersion)
+	cmd.Env = append(os.Environ(), "GOBIN="+dir)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to install %s: %w", moduleVersion, err)
+		return "", fmt.Errorf("failed to install %s: %w", moduleVersion, err)
 	}
 
-	binName := filepath.Base(tool.Install.Module)
-	gopath := os.Getenv("GOPATH")
-	if gopath == "" {
-		home, err := os.UserHomeDir()
-		if err == nil {
-			gopath = filepath.Join(home, "go")
-		}
-	}
+	return filepath.Join(dir, toolBinName(tool)), nil
+}
 
-	if gopath != "" {
-		binPath := filepath.Join(gopath, "bin", binName)
-		if _, err := os.Stat(binPath); err == nil {
-			return nil
-		}
+func (goInstallProvider) Verify(tool *Tool, _ Platform, artifact string) error {
+	if _, err := os.Stat(artifact); err != nil {
+		return fmt.Errorf("go install of %s did not produce %s", tool.Install.Module, filepath.Base(artifact))
 	}
+	return nil
+}
 
-	if _, err := exec.LookPath(binName); err != nil {
-		return fmt.Errorf("installed %s but cannot find in PATH - ensure GOPATH/bin or GOBIN is in your PATH", binName)
+// goBinDir returns the directory go install uses by default: GOBIN, or the
+// bin directory of the first GOPATH entry (default ~/go).
+func goBinDir() string {
+	if gobin := os.Getenv("GOBIN"); gobin != "" {
+		return gobin
 	}
-
-	return nil
+	gopath := filepath.SplitList(os.Getenv("GOPATH"))
+	if len(gopath) > 0 && gopath[0] != "" {
+		return filepath.Join(gopath[0], "bin")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, "go", "bin")
+	}
+	return "./bin"
 }
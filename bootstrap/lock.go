@@ -0,0 +1,172 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LockFileVersion is the format version written to new lock files.
+const LockFileVersion = "v1.0.0"
+
+// lockPlatforms are the platforms whose download digests are recorded in
+// the lock file, so one lock serves every supported machine.
+var lockPlatforms = []Platform{
+	{OS: "darwin", Arch: "amd64"},
+	{OS: "darwin", Arch: "arm64"},
+	{OS: "linux", Arch: "amd64"},
+	{OS: "linux", Arch: "arm64"},
+	{OS: "windows", Arch: "amd64"},
+	{OS: "windows", Arch: "arm64"},
+}
+
+// Lock pins every versioned tool in a manifest to an exact version.
+type Lock struct {
+	Version string                `yaml:"version"`
+	Tools   map[string]LockedTool `yaml:"tools"`
+}
+
+// LockedTool is the resolved version of one tool.
+type LockedTool struct {
+	// Constraint is the manifest version the entry was resolved from; the
+	// tool is re-resolved when the manifest changes it.
+	Constraint string `yaml:"constraint"`
+	Version    string `yaml:"version"`
+	// Checksum maps "os-arch" to the SHA-256 of the download for 'download' tools.
+	Checksum map[string]string `yaml:"checksum,omitempty"`
+}
+
+// LockPath returns the lock file path for a manifest: tools.yaml and
+// tools.local.yaml both lock to tools.lock in the same directory.
+func LockPath(manifestPath string) string {
+	dir := filepath.Dir(manifestPath)
+	name := strings.TrimSuffix(filepath.Base(manifestPath), filepath.Ext(manifestPath))
+	name = strings.TrimSuffix(name, ".local")
+	return filepath.Join(dir, name+".lock")
+}
+
+// LoadLock reads a lock file. A missing file yields an empty lock.
+func LoadLock(path string) (*Lock, error) {
+	// #nosec G304 -- intentional file access for loading bootstrap lock files in controlled bootstrap process
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Lock{Version: LockFileVersion, Tools: map[string]LockedTool{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file %s: %w", path, err)
+	}
+
+	var lock Lock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("invalid lock file %s: %w", path, err)
+	}
+	if lock.Tools == nil {
+		lock.Tools = map[string]LockedTool{}
+	}
+	return &lock, nil
+}
+
+// WriteLock writes a lock file with tools sorted by ID.
+func WriteLock(path string, lock *Lock) error {
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("failed to encode lock file: %w", err)
+	}
+	header := "# Generated by gofulmen bootstrap. Do not edit; run with --update to refresh.\n"
+	// #nosec G306 -- lock files are committed alongside the manifest and must be readable
+	if err := os.WriteFile(path, append([]byte(header), data...), 0644); err != nil {
+		return fmt.Errorf("failed to write lock file %s: %w", path, err)
+	}
+	return nil
+}
+
+// ResolveLock pins every 'go' and 'download' tool in the manifest to an
+// exact version. Entries in current are kept while their constraint is
+// unchanged and their version still satisfies it; other tools are resolved
+// to the highest matching release. With update set, every tool is
+// re-resolved. Tools no longer in the manifest are dropped.
+//
+// Each tool resolves through its Provider: the built-in go-install provider
+// uses the Go module proxy, and the github provider uses the releases of
+// install.repo, recording per-platform digests from the asset metadata.
+func ResolveLock(ctx context.Context, manifest *Manifest, current *Lock, update bool) (*Lock, error) {
+	lock := &Lock{Version: LockFileVersion, Tools: map[string]LockedTool{}}
+	for _, tool := range manifest.Tools {
+		if tool.Install.Type != "go" && tool.Install.Type != "download" {
+			continue
+		}
+		if tool.Install.Type == "download" && tool.Install.Version == "" {
+			continue // unversioned URL; checksums come from the manifest
+		}
+		if current != nil && !update {
+			if locked, ok := current.Tools[tool.ID]; ok && lockedSatisfies(locked, tool.Install.Version) {
+				lock.Tools[tool.ID] = locked
+				continue
+			}
+		}
+
+		provider, err := providerFor(&tool)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tool.ID, err)
+		}
+		locked, err := provider.Resolve(ctx, &tool)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tool.ID, err)
+		}
+		lock.Tools[tool.ID] = locked
+	}
+	return lock, nil
+}
+
+// lockedSatisfies reports whether a lock entry is still valid for the
+// manifest version.
+func lockedSatisfies(locked LockedTool, version string) bool {
+	if locked.Constraint != version {
+		return false
+	}
+	if IsExactVersion(version) {
+		return locked.Version == version
+	}
+	c, err := ParseConstraint(version)
+	return err == nil && c.Check(locked.Version)
+}
+
+// applyLock installs the locked version and digests into a tool definition.
+func applyLock(tool *Tool, lock *Lock) {
+	locked, ok := lock.Tools[tool.ID]
+	if !ok {
+		return
+	}
+	tool.Install.Version = locked.Version
+	if len(locked.Checksum) > 0 {
+		tool.Install.Checksum = locked.Checksum
+	}
+}
+
+// syncLock loads the lock file for a manifest, resolves it, and rewrites
+// it when anything changed.
+func syncLock(ctx context.Context, manifest *Manifest, lockPath string, update bool) (*Lock, error) {
+	current, err := LoadLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := ResolveLock(ctx, manifest, current, update)
+	if err != nil {
+		return nil, err
+	}
+
+	before, _ := yaml.Marshal(current) //nolint:errcheck // Lock always marshals
+	after, _ := yaml.Marshal(lock)     //nolint:errcheck // Lock always marshals
+	if string(before) != string(after) {
+		if err := WriteLock(lockPath, lock); err != nil {
+			return nil, err
+		}
+	}
+	return lock, nil
+}
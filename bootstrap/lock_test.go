@@ -0,0 +1,320 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{">=1.5 <2", "v1.5.0", true},
+		{">=1.5 <2", "1.9.3", true},
+		{">=1.5 <2", "v2.0.0", false},
+		{">=1.5 <2", "v1.4.9", false},
+		{">=1.5, <2", "v1.7.0", true},
+		{">= 1.5 < 2", "v1.7.0", true},
+		{"~1.4", "v1.4.7", true},
+		{"~1.4", "v1.5.0", false},
+		{"~1", "v1.9.0", true},
+		{"^1.4", "v1.9.0", true},
+		{"^1.4", "v2.0.0", false},
+		{"^0.3", "v0.3.9", true},
+		{"^0.3", "v0.4.0", false},
+		{"^0.0.3", "v0.0.4", false},
+		{"1.x", "v1.2.3", true},
+		{"1.4.*", "v1.5.0", false},
+		{"v1.5.2", "1.5.2", true},
+		{"v1.5.2", "v1.5.3", false},
+		{"!=1.5.2", "v1.5.3", true},
+		{">1.5", "v1.5.9", false},
+		{">1.5", "v1.6.0", true},
+		{"<=1.5", "v1.5.9", true},
+		{"^0.3 || ^1", "v1.2.0", true},
+		{"^0.3 || ^1", "v0.5.0", false},
+		{"latest", "v9.9.9", true},
+		{"", "v0.0.1", true},
+		{">=1.5", "v2.0.0-rc.1", false},
+		{">=2.0.0-rc.1", "v2.0.0-rc.2", true},
+		{">=1.5", "not-a-version", false},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Fatalf("ParseConstraint(%q) error: %v", tt.constraint, err)
+		}
+		if got := c.Check(tt.version); got != tt.want {
+			t.Errorf("%q.Check(%q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestParseConstraintInvalid(t *testing.T) {
+	for _, s := range []string{">=", ">=1.a", "1.2.3.4", "!=1.5", "~>1.2", ">=01.2"} {
+		if _, err := ParseConstraint(s); err == nil {
+			t.Errorf("ParseConstraint(%q) expected error", s)
+		}
+	}
+}
+
+func TestConstraintLatest(t *testing.T) {
+	c, err := ParseConstraint(">=1.5 <2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	versions := []string{"v1.4.0", "v1.10.0", "v1.9.0", "v2.0.0", "v1.11.0-rc.1", "junk"}
+	if got := c.Latest(versions); got != "v1.10.0" {
+		t.Errorf("Latest() = %q, want v1.10.0", got)
+	}
+	if got := c.Latest([]string{"v3.0.0"}); got != "" {
+		t.Errorf("Latest() = %q, want empty", got)
+	}
+}
+
+func TestIsExactVersion(t *testing.T) {
+	tests := map[string]bool{
+		"v1.5.2":     true,
+		"1.5.2":      true,
+		"v1.5.2-rc1": true,
+		"v1.5":       false,
+		">=1.5.2":    false,
+		"latest":     false,
+		"master":     false,
+	}
+	for s, want := range tests {
+		if got := IsExactVersion(s); got != want {
+			t.Errorf("IsExactVersion(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestLockPath(t *testing.T) {
+	tests := map[string]string{
+		".goneat/tools.yaml":       filepath.Join(".goneat", "tools.lock"),
+		".goneat/tools.local.yaml": filepath.Join(".goneat", "tools.lock"),
+		"tools.yml":                "tools.lock",
+	}
+	for manifest, want := range tests {
+		if got := LockPath(manifest); got != want {
+			t.Errorf("LockPath(%q) = %q, want %q", manifest, got, want)
+		}
+	}
+}
+
+func testDigest(tag string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(tag)))
+}
+
+// newReleaseServer serves GitHub release metadata and Go proxy version lists.
+func newReleaseServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	releases := []map[string]any{}
+	for _, tag := range []string{"v1.4.0", "v1.5.0", "v1.6.1", "v2.0.0"} {
+		releases = append(releases, map[string]any{
+			"tag_name": tag,
+			"assets": []map[string]string{
+				{"name": "tool_" + tag + "_linux_amd64.tar.gz", "digest": "sha256:" + testDigest(tag)},
+				{"name": "tool_" + tag + "_darwin_arm64.tar.gz", "digest": "sha256:" + strings.Repeat("b", 64)},
+				{"name": "checksums.txt"},
+			},
+		})
+	}
+	releases = append(releases, map[string]any{"tag_name": "v1.9.0", "draft": true})
+
+	mux := http.NewServeMux()
+	// Two pages, linked like the GitHub API does
+	var server *httptest.Server
+	mux.HandleFunc("/repos/example/tool/releases", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_ = json.NewEncoder(w).Encode(releases[2:])
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/example/tool/releases?per_page=100&page=2>; rel="next", <%s/repos/example/tool/releases?per_page=100&page=2>; rel="last"`, server.URL, server.URL))
+		_ = json.NewEncoder(w).Encode(releases[:2])
+	})
+	mux.HandleFunc("/github.com/!example/linter/@v/list", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("v0.9.0\nv1.2.0\nv1.3.0\nv2.0.0\n"))
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	origGitHub, origProxy := githubAPIURL, goProxyURL
+	githubAPIURL, goProxyURL = server.URL, server.URL
+	t.Cleanup(func() { githubAPIURL, goProxyURL = origGitHub, origProxy })
+	return server
+}
+
+func constraintManifest(toolVersion, linterVersion string) *Manifest {
+	return &Manifest{
+		Version: "v1.0.0",
+		Tools: []Tool{
+			{
+				ID: "tool",
+				Install: Install{
+					Type:    "download",
+					URL:     "https://example.com/releases/{{version}}/tool_{{version}}_{{os}}_{{arch}}.tar.gz",
+					BinName: "tool",
+					Version: toolVersion,
+					Repo:    "example/tool",
+				},
+			},
+			{
+				ID:      "linter",
+				Install: Install{Type: "go", Module: "github.com/Example/linter", Version: linterVersion},
+			},
+			{
+				ID:      "git",
+				Install: Install{Type: "verify", Command: "git"},
+			},
+		},
+	}
+}
+
+func TestResolveLock(t *testing.T) {
+	newReleaseServer(t)
+
+	lock, err := ResolveLock(context.Background(), constraintManifest(">=1.5 <2", "^1"), nil, false)
+	if err != nil {
+		t.Fatalf("ResolveLock() error: %v", err)
+	}
+
+	tool := lock.Tools["tool"]
+	if tool.Version != "v1.6.1" || tool.Constraint != ">=1.5 <2" {
+		t.Errorf("tool locked as %+v, want v1.6.1 from >=1.5 <2", tool)
+	}
+	if len(tool.Checksum) != 2 || tool.Checksum["linux-amd64"] != testDigest("v1.6.1") {
+		t.Errorf("tool checksums = %v", tool.Checksum)
+	}
+	if got := lock.Tools["linter"].Version; got != "v1.3.0" {
+		t.Errorf("linter locked as %q, want v1.3.0", got)
+	}
+	if _, ok := lock.Tools["git"]; ok {
+		t.Error("verify tools should not be locked")
+	}
+}
+
+func TestResolveLockReusesLockedVersions(t *testing.T) {
+	newReleaseServer(t)
+	ctx := context.Background()
+
+	current := &Lock{Version: LockFileVersion, Tools: map[string]LockedTool{
+		"tool":   {Constraint: ">=1.5 <2", Version: "v1.5.0", Checksum: map[string]string{"linux-amd64": "abc"}},
+		"linter": {Constraint: "^1", Version: "v1.2.0"},
+		"stale":  {Constraint: "^1", Version: "v1.0.0"},
+	}}
+
+	lock, err := ResolveLock(ctx, constraintManifest(">=1.5 <2", "^1"), current, false)
+	if err != nil {
+		t.Fatalf("ResolveLock() error: %v", err)
+	}
+	if got := lock.Tools["tool"].Version; got != "v1.5.0" {
+		t.Errorf("tool = %q, want locked v1.5.0", got)
+	}
+	if got := lock.Tools["linter"].Version; got != "v1.2.0" {
+		t.Errorf("linter = %q, want locked v1.2.0", got)
+	}
+	if _, ok := lock.Tools["stale"]; ok {
+		t.Error("tools removed from the manifest should be dropped")
+	}
+
+	// A changed constraint re-resolves that tool only.
+	lock, err = ResolveLock(ctx, constraintManifest("~1.4", "^1"), current, false)
+	if err != nil {
+		t.Fatalf("ResolveLock() error: %v", err)
+	}
+	if got := lock.Tools["tool"].Version; got != "v1.4.0" {
+		t.Errorf("tool = %q, want v1.4.0 after constraint change", got)
+	}
+	if got := lock.Tools["linter"].Version; got != "v1.2.0" {
+		t.Errorf("linter = %q, want locked v1.2.0", got)
+	}
+
+	// Update refreshes everything within constraints.
+	lock, err = ResolveLock(ctx, constraintManifest(">=1.5 <2", "^1"), current, true)
+	if err != nil {
+		t.Fatalf("ResolveLock() error: %v", err)
+	}
+	if lock.Tools["tool"].Version != "v1.6.1" || lock.Tools["linter"].Version != "v1.3.0" {
+		t.Errorf("update resolved %+v", lock.Tools)
+	}
+}
+
+func TestResolveLockNoMatch(t *testing.T) {
+	newReleaseServer(t)
+
+	_, err := ResolveLock(context.Background(), constraintManifest(">=3", "^1"), nil, false)
+	if err == nil || !strings.Contains(err.Error(), "no release of example/tool") {
+		t.Errorf("expected no-match error, got %v", err)
+	}
+}
+
+func TestUpdateLockWritesLockFile(t *testing.T) {
+	newReleaseServer(t)
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "tools.yaml")
+	manifest := `version: v1.0.0
+tools:
+  - id: tool
+    install:
+      type: download
+      repo: example/tool
+      version: ">=1.5 <2"
+      url: https://example.com/releases/{{version}}/tool_{{version}}_{{os}}_{{arch}}.tar.gz
+      binName: tool
+`
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := UpdateLock(Options{ManifestPath: manifestPath}); err != nil {
+		t.Fatalf("UpdateLock() error: %v", err)
+	}
+
+	lock, err := LoadLock(filepath.Join(dir, "tools.lock"))
+	if err != nil {
+		t.Fatalf("LoadLock() error: %v", err)
+	}
+	if lock.Version != LockFileVersion || lock.Tools["tool"].Version != "v1.6.1" {
+		t.Errorf("lock = %+v", lock)
+	}
+}
+
+func TestLoadLockMissing(t *testing.T) {
+	lock, err := LoadLock(filepath.Join(t.TempDir(), "tools.lock"))
+	if err != nil {
+		t.Fatalf("LoadLock() error: %v", err)
+	}
+	if len(lock.Tools) != 0 {
+		t.Errorf("expected empty lock, got %+v", lock)
+	}
+}
+
+func TestValidateToolVersionConstraint(t *testing.T) {
+	tool := constraintManifest(">=1.5 <2", "^1").Tools[0]
+	if err := validateTool(&tool); err != nil {
+		t.Errorf("valid constraint rejected: %v", err)
+	}
+
+	tool.Install.Repo = ""
+	if err := validateTool(&tool); err == nil || !strings.Contains(err.Error(), "requires 'repo'") {
+		t.Errorf("expected repo error, got %v", err)
+	}
+
+	tool.Install.Version = "v1.5.0"
+	tool.Install.URL = "https://example.com/tool.tar.gz"
+	if err := validateTool(&tool); err == nil || !strings.Contains(err.Error(), "{{version}}") {
+		t.Errorf("expected placeholder error, got %v", err)
+	}
+}
@@ -3,7 +3,9 @@ package bootstrap
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/fulmenhq/gofulmen/foundry"
 	"gopkg.in/yaml.v3"
 )
 
@@ -30,6 +32,17 @@ type Install struct {
 	BinName     string            `yaml:"binName,omitempty"`
 	Destination string            `yaml:"destination,omitempty"`
 	Checksum    map[string]string `yaml:"checksum,omitempty"`
+	// Repo is the GitHub repository ("owner/name") whose releases resolve
+	// version constraints for 'download' installs.
+	Repo string `yaml:"repo,omitempty"`
+	// Provider selects the source for 'go' and 'download' installs (see
+	// Provider); inferred from the install type and repo when empty.
+	Provider string `yaml:"provider,omitempty"`
+	// Headers are sent with 'http' and 'github' downloads; values expand
+	// environment variables (e.g., "Bearer ${ARTIFACTORY_TOKEN}").
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Retry overrides foundry.DefaultRetryPolicy for 'download' installs.
+	Retry *foundry.RetryPolicy `yaml:"retry,omitempty"`
 }
 
 func LoadManifest(path string) (*Manifest, error) {
@@ -78,6 +91,15 @@ func validateTool(t *Tool) error {
 		return fmt.Errorf("missing required field: install.type")
 	}
 
+	if t.Install.Provider != "" {
+		if t.Install.Type != "go" && t.Install.Type != "download" {
+			return fmt.Errorf("'provider' applies only to 'go' and 'download' installs")
+		}
+		if _, ok := LookupProvider(t.Install.Provider); !ok {
+			return fmt.Errorf("unknown provider %q (registered: %v)", t.Install.Provider, RegisteredProviders())
+		}
+	}
+
 	switch t.Install.Type {
 	case "go":
 		if t.Install.Module == "" {
@@ -86,6 +108,11 @@ func validateTool(t *Tool) error {
 		if t.Install.Version == "" {
 			return fmt.Errorf("type 'go' requires 'version' field")
 		}
+		if strings.ContainsAny(t.Install.Version, "<>=~^*| ,") {
+			if _, err := ParseConstraint(t.Install.Version); err != nil {
+				return err
+			}
+		}
 
 	case "verify":
 		if t.Install.Command == "" {
@@ -93,12 +120,39 @@ func validateTool(t *Tool) error {
 		}
 
 	case "download":
-		if t.Install.URL == "" {
-			return fmt.Errorf("type 'download' requires 'url' field")
+		provider := providerName(t)
+		switch provider {
+		case ProviderArchive:
+			if t.Install.Source == "" {
+				return fmt.Errorf("provider 'archive' requires 'source' field")
+			}
+		case ProviderGitHub, ProviderHTTP:
+			if t.Install.URL == "" {
+				return fmt.Errorf("type 'download' requires 'url' field")
+			}
 		}
 		if t.Install.BinName == "" {
 			return fmt.Errorf("type 'download' requires 'binName' field")
 		}
+		if t.Install.Version != "" {
+			if _, err := ParseConstraint(t.Install.Version); err != nil {
+				return err
+			}
+			if (provider == ProviderGitHub || provider == ProviderHTTP) && !strings.Contains(t.Install.URL, "{{version}}") {
+				return fmt.Errorf("type 'download' with 'version' requires a {{version}} placeholder in 'url'")
+			}
+			if !IsExactVersion(t.Install.Version) && (provider == ProviderHTTP || provider == ProviderArchive) {
+				if t.Install.Provider == "" {
+					return fmt.Errorf("version constraint %q requires 'repo' field", t.Install.Version)
+				}
+				return fmt.Errorf("provider %q cannot resolve version constraint %q; pin an exact version", provider, t.Install.Version)
+			}
+		}
+		if t.Install.Retry != nil {
+			if err := t.Install.Retry.Validate(); err != nil {
+				return fmt.Errorf("install.retry: %w", err)
+			}
+		}
 
 	case "link":
 		if t.Install.Source == "" {
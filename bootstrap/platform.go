@@ -66,6 +66,12 @@ func InterpolateURL(urlTemplate string, platform Platform) string {
 	return result
 }
 
+// interpolateVersion replaces the {{version}} placeholder with a resolved
+// version, as tagged (e.g., "v0.3.4").
+func interpolateVersion(urlTemplate, version string) string {
+	return strings.ReplaceAll(urlTemplate, "{{version}}", version)
+}
+
 // IsPlatformSupported checks if the current platform is supported for downloads
 // (macOS and Linux are fully supported, Windows has limitations)
 func IsPlatformSupported(platform Platform) (bool, string) {
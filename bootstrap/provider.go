@@ -0,0 +1,160 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Provider obtains tools from one kind of source. Built-in providers:
+//
+//   - "github": GitHub release assets; resolves constraints from the
+//     releases of install.repo and records per-platform asset digests
+//   - "http": any HTTPS URL (internal mirrors, Artifactory); exact versions
+//     only, checksums from the manifest, optional install.headers for auth
+//   - "archive": a local or pre-mirrored archive at install.source
+//   - "go-install": builds install.module with `go install`; resolves
+//     constraints from the Go module proxy
+//
+// Register additional providers with RegisterProvider and select them with
+// install.provider in the manifest.
+type Provider interface {
+	// Resolve pins tool.Install.Version (an exact version or constraint) to
+	// an exact version, with per-platform SHA-256 digests when the source
+	// publishes them.
+	Resolve(ctx context.Context, tool *Tool) (LockedTool, error)
+
+	// Fetch obtains the artifact for platform (an archive or a binary) and
+	// returns its path. Downloads belong in dir, a temporary directory
+	// removed after installation. tool.Install.Version is already resolved.
+	Fetch(ctx context.Context, tool *Tool, platform Platform, dir string) (string, error)
+
+	// Verify checks a fetched artifact, typically against
+	// tool.Install.Checksum[platform.String()].
+	Verify(tool *Tool, platform Platform, artifact string) error
+}
+
+// Built-in provider names.
+const (
+	ProviderGitHub    = "github"
+	ProviderHTTP      = "http"
+	ProviderArchive   = "archive"
+	ProviderGoInstall = "go-install"
+)
+
+var builtinProviders = map[string]Provider{
+	ProviderGitHub:    githubProvider{},
+	ProviderHTTP:      httpProvider{},
+	ProviderArchive:   archiveProvider{},
+	ProviderGoInstall: goInstallProvider{},
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = make(map[string]Provider)
+)
+
+// RegisterProvider registers a custom provider under name, selectable with
+// install.provider. Registering an existing custom name replaces it;
+// built-in providers cannot be overridden. Register providers before
+// loading manifests (typically from an init function).
+//
+// Example:
+//
+//	_ = bootstrap.RegisterProvider("nexus", &nexusProvider{baseURL: os.Getenv("NEXUS_URL")})
+func RegisterProvider(name string, p Provider) error {
+	if name == "" {
+		return errors.New("provider name is required")
+	}
+	if p == nil {
+		return fmt.Errorf("provider %q: implementation is required", name)
+	}
+	if _, builtin := builtinProviders[name]; builtin {
+		return fmt.Errorf("provider %q is built in and cannot be overridden", name)
+	}
+
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[name] = p
+	return nil
+}
+
+// UnregisterProvider removes a custom provider registered with RegisterProvider.
+func UnregisterProvider(name string) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	delete(providers, name)
+}
+
+// RegisteredProviders returns the names of all providers, built-in and
+// custom, sorted.
+func RegisteredProviders() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	names := make([]string, 0, len(builtinProviders)+len(providers))
+	for name := range builtinProviders {
+		names = append(names, name)
+	}
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupProvider returns the provider registered under name.
+func LookupProvider(name string) (Provider, bool) {
+	if p, ok := builtinProviders[name]; ok {
+		return p, true
+	}
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	p, ok := providers[name]
+	return p, ok
+}
+
+// providerName returns the provider selected for a tool: install.provider
+// when set, otherwise go-install for 'go' tools, github for downloads with
+// a repo, and http for other downloads.
+func providerName(tool *Tool) string {
+	switch {
+	case tool.Install.Provider != "":
+		return tool.Install.Provider
+	case tool.Install.Type == "go":
+		return ProviderGoInstall
+	case tool.Install.Repo != "":
+		return ProviderGitHub
+	default:
+		return ProviderHTTP
+	}
+}
+
+func providerFor(tool *Tool) (Provider, error) {
+	name := providerName(tool)
+	p, ok := LookupProvider(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q (registered: %v)", name, RegisteredProviders())
+	}
+	return p, nil
+}
+
+// pinnedVersion resolves an exact version (or no version) for providers
+// without release metadata, taking digests from the manifest.
+func pinnedVersion(tool *Tool, provider string) (LockedTool, error) {
+	spec := tool.Install.Version
+	if spec != "" && !IsExactVersion(spec) {
+		return LockedTool{}, fmt.Errorf("provider %q cannot resolve version constraint %q; pin an exact version", provider, spec)
+	}
+	return LockedTool{Constraint: spec, Version: spec, Checksum: tool.Install.Checksum}, nil
+}
+
+// verifyChecksum checks an artifact against the tool's checksum for platform.
+func verifyChecksum(tool *Tool, platform Platform, artifact string) error {
+	expected, ok := tool.Install.Checksum[platform.String()]
+	if !ok {
+		return fmt.Errorf("no checksum found for platform %s", platform)
+	}
+	return VerifySHA256(artifact, expected)
+}
@@ -0,0 +1,224 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeProvider serves a fixed script as a raw (non-archive) binary.
+type fakeProvider struct {
+	verified int
+}
+
+func (p *fakeProvider) Resolve(_ context.Context, tool *Tool) (LockedTool, error) {
+	return LockedTool{Constraint: tool.Install.Version, Version: "v9.9.9"}, nil
+}
+
+func (p *fakeProvider) Fetch(_ context.Context, tool *Tool, _ Platform, dir string) (string, error) {
+	artifact := filepath.Join(dir, tool.Install.BinName)
+	return artifact, os.WriteFile(artifact, []byte("#!/bin/sh\necho "+tool.Install.Version+"\n"), 0600)
+}
+
+func (p *fakeProvider) Verify(*Tool, Platform, string) error {
+	p.verified++
+	return nil
+}
+
+func TestRegisterProvider(t *testing.T) {
+	if err := RegisterProvider(ProviderGitHub, &fakeProvider{}); err == nil {
+		t.Error("expected error overriding a built-in provider")
+	}
+	if err := RegisterProvider("", &fakeProvider{}); err == nil {
+		t.Error("expected error for empty name")
+	}
+	if err := RegisterProvider("fake", nil); err == nil {
+		t.Error("expected error for nil provider")
+	}
+
+	if err := RegisterProvider("fake", &fakeProvider{}); err != nil {
+		t.Fatalf("RegisterProvider() error: %v", err)
+	}
+	defer UnregisterProvider("fake")
+
+	want := []string{"archive", "fake", "github", "go-install", "http"}
+	if got := RegisteredProviders(); !slices.Equal(got, want) {
+		t.Errorf("RegisteredProviders() = %v, want %v", got, want)
+	}
+}
+
+func TestCustomProviderInstall(t *testing.T) {
+	provider := &fakeProvider{}
+	if err := RegisterProvider("fake", provider); err != nil {
+		t.Fatal(err)
+	}
+	defer UnregisterProvider("fake")
+
+	manifest := &Manifest{Version: "v1.0.0", Tools: []Tool{{
+		ID: "internal",
+		Install: Install{
+			Type:        "download",
+			Provider:    "fake",
+			Version:     "^9",
+			BinName:     "internal-tool",
+			Destination: t.TempDir(),
+		},
+	}}}
+	if err := validateManifest(manifest); err != nil {
+		t.Fatalf("validateManifest() error: %v", err)
+	}
+
+	lock, err := ResolveLock(context.Background(), manifest, nil, false)
+	if err != nil {
+		t.Fatalf("ResolveLock() error: %v", err)
+	}
+	if got := lock.Tools["internal"].Version; got != "v9.9.9" {
+		t.Errorf("locked version = %q, want v9.9.9 from the custom provider", got)
+	}
+
+	tool := manifest.Tools[0]
+	applyLock(&tool, lock)
+	if err := installArtifact(&tool, GetPlatform(), nil); err != nil {
+		t.Fatalf("installArtifact() error: %v", err)
+	}
+	if provider.verified != 1 {
+		t.Errorf("Verify called %d times, want 1", provider.verified)
+	}
+	data, err := os.ReadFile(filepath.Join(tool.Install.Destination, "internal-tool"))
+	if err != nil || !strings.Contains(string(data), "v9.9.9") {
+		t.Errorf("installed binary = %q, %v", data, err)
+	}
+}
+
+func TestArchiveProvider(t *testing.T) {
+	server, checksum, _ := newArchiveServer(t)
+	platform := GetPlatform()
+
+	// Mirror the served archive to a local path, as a vendored release would be.
+	resp, err := server.Client().Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // test cleanup
+	mirror := filepath.Join(t.TempDir(), "tool_v1.0.0_"+platform.OS+"_"+platform.Arch+".tar.gz")
+	out, _ := os.Create(mirror)
+	_, _ = out.ReadFrom(resp.Body)
+	_ = out.Close()
+
+	tool := &Tool{
+		ID: "vendored",
+		Install: Install{
+			Type:        "download",
+			Provider:    ProviderArchive,
+			Version:     "v1.0.0",
+			Source:      filepath.Join(filepath.Dir(mirror), "tool_{{version}}_{{os}}_{{arch}}.tar.gz"),
+			BinName:     "tool",
+			Destination: t.TempDir(),
+			Checksum:    map[string]string{platform.String(): checksum},
+		},
+	}
+	if err := validateTool(tool); err != nil {
+		t.Fatalf("validateTool() error: %v", err)
+	}
+	if err := installArtifact(tool, platform, nil); err != nil {
+		t.Fatalf("installArtifact() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tool.Install.Destination, "tool")); err != nil {
+		t.Errorf("binary not installed: %v", err)
+	}
+
+	tool.Install.Checksum[platform.String()] = strings.Repeat("0", 64)
+	if err := installArtifact(tool, platform, nil); err == nil {
+		t.Error("expected checksum mismatch")
+	}
+}
+
+func TestHTTPProviderHeaders(t *testing.T) {
+	server, checksum, _ := newArchiveServer(t)
+	platform := GetPlatform()
+	t.Setenv("TEST_ARTIFACTORY_TOKEN", "s3cret")
+
+	var gotAuth string
+	handler := server.Config.Handler
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		handler.ServeHTTP(w, r)
+	})
+
+	tool := &Tool{
+		ID: "mirrored",
+		Install: Install{
+			Type:        "download",
+			URL:         server.URL + "/artifactory/tool.tar.gz",
+			BinName:     "tool",
+			Destination: t.TempDir(),
+			Headers:     map[string]string{"Authorization": "Bearer ${TEST_ARTIFACTORY_TOKEN}"},
+			Checksum:    map[string]string{platform.String(): checksum},
+		},
+	}
+	if err := installArtifact(tool, platform, nil); err != nil {
+		t.Fatalf("installArtifact() error: %v", err)
+	}
+	if gotAuth != "Bearer s3cret" {
+		t.Errorf("Authorization header = %q, want expanded token", gotAuth)
+	}
+}
+
+func TestProviderSelection(t *testing.T) {
+	tests := []struct {
+		install Install
+		want    string
+	}{
+		{Install{Type: "go", Module: "example.com/tool"}, ProviderGoInstall},
+		{Install{Type: "download", Repo: "owner/tool"}, ProviderGitHub},
+		{Install{Type: "download", URL: "https://mirror.example.com/tool.tar.gz"}, ProviderHTTP},
+		{Install{Type: "download", Provider: ProviderArchive}, ProviderArchive},
+	}
+	for _, tt := range tests {
+		if got := providerName(&Tool{Install: tt.install}); got != tt.want {
+			t.Errorf("providerName(%+v) = %q, want %q", tt.install, got, tt.want)
+		}
+	}
+}
+
+func TestValidateToolProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		install Install
+		wantErr string
+	}{
+		{"unknown provider", Install{Type: "download", Provider: "nexus", URL: "https://x", BinName: "x"}, "unknown provider"},
+		{"provider on verify", Install{Type: "verify", Provider: ProviderHTTP, Command: "git"}, "applies only"},
+		{"archive without source", Install{Type: "download", Provider: ProviderArchive, BinName: "x"}, "requires 'source'"},
+		{"http constraint", Install{Type: "download", Provider: ProviderHTTP, URL: "https://x/{{version}}", BinName: "x", Version: "^1"}, "cannot resolve"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTool(&Tool{ID: "tool", Install: tt.install})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateTool() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToolBinName(t *testing.T) {
+	exe := ""
+	if runtime.GOOS == "windows" {
+		exe = ".exe"
+	}
+	tests := map[string]string{
+		"github.com/golangci/golangci-lint/cmd/golangci-lint": "golangci-lint" + exe,
+		"github.com/example/tool/v2":                          "tool" + exe,
+	}
+	for module, want := range tests {
+		if got := toolBinName(&Tool{Install: Install{Type: "go", Module: module}}); got != want {
+			t.Errorf("toolBinName(%q) = %q, want %q", module, got, want)
+		}
+	}
+}
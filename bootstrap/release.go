@@ -0,0 +1,187 @@
+package bootstrap
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"golang.org/x/mod/module"
+)
+
+// Release metadata endpoints; variables so tests can use local servers.
+var (
+	githubAPIURL = "https://api.github.com"
+	goProxyURL   = "https://proxy.golang.org"
+)
+
+// maxReleasePages bounds release list pagination (100 releases per page).
+const maxReleasePages = 20
+
+// Release is a published version of a tool and the SHA-256 digests of its
+// assets, keyed by asset file name.
+type Release struct {
+	Version string
+	Digests map[string]string
+}
+
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	Draft   bool   `json:"draft"`
+	Assets  []struct {
+		Name   string `json:"name"`
+		Digest string `json:"digest"`
+	} `json:"assets"`
+}
+
+// fetchGitHubReleases lists the published releases of repo ("owner/name"),
+// following the Link rel="next" pagination header up to maxReleasePages.
+// Asset digests come from the "digest" field GitHub reports for each asset.
+// GITHUB_TOKEN, when set, is sent to raise API rate limits.
+func fetchGitHubReleases(ctx context.Context, policy *foundry.RetryPolicy, repo string) ([]Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=100", githubAPIURL, repo)
+	headers := map[string]string{"Accept": "application/vnd.github+json"}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+
+	var raw []githubRelease
+	for page := 0; url != "" && page < maxReleasePages; page++ {
+		var (
+			items []githubRelease
+			next  string
+		)
+		err := policy.Do(ctx, func(ctx context.Context) error {
+			resp, err := getMetadata(ctx, url, headers)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close() //nolint:errcheck // defer Close() error is commonly ignored in Go
+			items = items[:0]
+			if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+				return foundry.Permanent(fmt.Errorf("invalid release metadata: %w", err))
+			}
+			next = nextLink(resp.Header.Get("Link"))
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list releases for %s: %w", repo, err)
+		}
+		raw = append(raw, items...)
+		url = next
+	}
+
+	releases := make([]Release, 0, len(raw))
+	for _, r := range raw {
+		if r.Draft {
+			continue
+		}
+		release := Release{Version: r.TagName, Digests: map[string]string{}}
+		for _, asset := range r.Assets {
+			if digest, ok := strings.CutPrefix(asset.Digest, "sha256:"); ok {
+				release.Digests[asset.Name] = digest
+			}
+		}
+		releases = append(releases, release)
+	}
+	return releases, nil
+}
+
+// fetchGoModuleVersions lists the tagged versions of a Go module from the
+// module proxy.
+func fetchGoModuleVersions(ctx context.Context, policy *foundry.RetryPolicy, modulePath string) ([]string, error) {
+	escaped, err := module.EscapePath(modulePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module path %s: %w", modulePath, err)
+	}
+	url := fmt.Sprintf("%s/%s/@v/list", goProxyURL, escaped)
+
+	var versions []string
+	err = policy.Do(ctx, func(ctx context.Context) error {
+		resp, err := getMetadata(ctx, url, nil)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close() //nolint:errcheck // defer Close() error is commonly ignored in Go
+		versions = versions[:0]
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if v := strings.TrimSpace(scanner.Text()); v != "" {
+				versions = append(versions, v)
+			}
+		}
+		return scanner.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions for %s: %w", modulePath, err)
+	}
+	return versions, nil
+}
+
+// getMetadata issues a GET request, classifying failures like downloadOnce.
+// The caller closes the response body.
+func getMetadata(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, foundry.Permanent(err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	// #nosec G107 -- URL is built from validated manifest fields
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := responseError(resp); err != nil {
+		resp.Body.Close() //nolint:errcheck,gosec // body is discarded on error
+		return nil, err
+	}
+	return resp, nil
+}
+
+// statusHelper classifies failed responses for retries.
+var statusHelper = sync.OnceValue(func() *foundry.HTTPStatusHelper {
+	helper, err := foundry.GetDefaultCatalog().GetHTTPStatusHelper()
+	if err != nil {
+		// Classification does not depend on the catalog; only reason phrases are lost
+		return foundry.NewHTTPStatusHelper(nil)
+	}
+	return helper
+})
+
+// responseError returns nil for 200 OK. Retryable statuses return a
+// *foundry.HTTPStatusError carrying any Retry-After delay; other statuses
+// are permanent.
+func responseError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	if err := statusHelper().RetryError(resp); err != nil {
+		return err
+	}
+	return foundry.Permanent(fmt.Errorf("unexpected response %s", resp.Status))
+}
+
+// nextLink returns the rel="next" target of an RFC 8288 Link header, or "".
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		target, params, ok := strings.Cut(link, ";")
+		if !ok {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(key, "rel") && slices.Contains(strings.Fields(strings.Trim(value, `"`)), "next") {
+				return strings.Trim(strings.TrimSpace(target), "<>")
+			}
+		}
+	}
+	return ""
+}
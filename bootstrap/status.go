@@ -0,0 +1,246 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// Tool states reported by Status.
+const (
+	// StateOK means the tool is installed at the expected version.
+	StateOK = "ok"
+	// StateMissing means the tool is not installed.
+	StateMissing = "missing"
+	// StateOutdated means the installed version differs from the expected one.
+	StateOutdated = "outdated"
+	// StateModified means the binary changed since bootstrap installed it.
+	StateModified = "modified"
+	// StateUnverified means the tool is installed but its version could not
+	// be determined.
+	StateUnverified = "unverified"
+)
+
+// ToolStatus is the state of one manifest tool.
+type ToolStatus struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	State    string `json:"state"`
+	// Location is the path of the installed binary.
+	Location string `json:"location,omitempty"`
+	// InstalledVersion comes from the install receipt, or from running the
+	// binary with --version when bootstrap did not install it.
+	InstalledVersion string `json:"installedVersion,omitempty"`
+	// ExpectedVersion is the locked version, or the manifest version when
+	// the tool is not locked.
+	ExpectedVersion string `json:"expectedVersion,omitempty"`
+	// DigestMatch reports whether the binary is byte-identical to the one
+	// bootstrap installed (after verifying its download). Nil when no
+	// install receipt exists.
+	DigestMatch *bool  `json:"digestMatch,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// StatusReport describes the tools of a manifest on this machine.
+type StatusReport struct {
+	Manifest string `json:"manifest"`
+	Platform string `json:"platform"`
+	// Healthy is true when every required tool is ok or unverified.
+	Healthy bool         `json:"healthy"`
+	Tools   []ToolStatus `json:"tools"`
+}
+
+// receipt records what bootstrap installed, stored beside the binary in
+// <destination>/.bootstrap/<binName>.json.
+type receipt struct {
+	Tool        string    `json:"tool"`
+	Version     string    `json:"version,omitempty"`
+	SHA256      string    `json:"sha256"`
+	InstalledAt time.Time `json:"installedAt"`
+}
+
+func receiptPath(binPath string) string {
+	return filepath.Join(filepath.Dir(binPath), ".bootstrap", filepath.Base(binPath)+".json")
+}
+
+// writeReceipt records an installed binary. Failures are ignored: Status
+// falls back to probing the binary.
+func writeReceipt(tool *Tool, binPath string) {
+	digest, err := ComputeSHA256(binPath)
+	if err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(receipt{
+		Tool:        tool.ID,
+		Version:     tool.Install.Version,
+		SHA256:      digest,
+		InstalledAt: time.Now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return
+	}
+	path := receiptPath(binPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0600)
+}
+
+func readReceipt(binPath string) (*receipt, bool) {
+	// #nosec G304 -- receipt path is derived from the manifest destination
+	data, err := os.ReadFile(receiptPath(binPath))
+	if err != nil {
+		return nil, false
+	}
+	var r receipt
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, false
+	}
+	return &r, true
+}
+
+// Status reports the installed state of every manifest tool without
+// installing or contacting the network. Expected versions come from the
+// lock file when present.
+//
+// Example:
+//
+//	report, err := bootstrap.Status(bootstrap.Options{})
+//	if err != nil {
+//	    return err
+//	}
+//	if !report.Healthy {
+//	    // report.Tools lists what is missing, outdated, or modified
+//	}
+func Status(opts Options) (*StatusReport, error) {
+	if opts.ManifestPath == "" {
+		opts.ManifestPath = ".goneat/tools.yaml"
+	}
+
+	manifestPath := resolveManifestPath(opts.ManifestPath)
+
+	manifest, err := LoadManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := LoadLock(lockPathFor(opts, manifestPath))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &StatusReport{
+		Manifest: manifestPath,
+		Platform: GetPlatform().String(),
+		Healthy:  true,
+		Tools:    make([]ToolStatus, 0, len(manifest.Tools)),
+	}
+	for _, tool := range manifest.Tools {
+		applyLock(&tool, lock)
+		status := toolStatus(&tool)
+		if tool.Required && status.State != StateOK && status.State != StateUnverified {
+			report.Healthy = false
+		}
+		report.Tools = append(report.Tools, status)
+	}
+	return report, nil
+}
+
+func toolStatus(tool *Tool) ToolStatus {
+	status := ToolStatus{
+		ID:              tool.ID,
+		Type:            tool.Install.Type,
+		Required:        tool.Required,
+		ExpectedVersion: tool.Install.Version,
+	}
+
+	switch tool.Install.Type {
+	case "verify":
+		path, err := exec.LookPath(tool.Install.Command)
+		if err != nil {
+			status.State = StateMissing
+			status.Message = fmt.Sprintf("command not found: %s", tool.Install.Command)
+			return status
+		}
+		status.Location = path
+		status.State = StateOK
+		return status
+	case "link":
+		status.Location = filepath.Join(toolDestination(tool), tool.Install.BinName)
+	default:
+		status.Location = filepath.Join(toolDestination(tool), toolBinName(tool))
+	}
+
+	if _, err := os.Stat(status.Location); err != nil {
+		status.State = StateMissing
+		status.Message = fmt.Sprintf("not installed at %s", status.Location)
+		return status
+	}
+	if tool.Install.Type == "link" {
+		status.State = StateOK
+		return status
+	}
+
+	if r, ok := readReceipt(status.Location); ok {
+		digest, err := ComputeSHA256(status.Location)
+		match := err == nil && digest == r.SHA256
+		status.DigestMatch = &match
+		if !match {
+			status.State = StateModified
+			status.Message = "binary differs from the one bootstrap installed"
+			status.InstalledVersion = probeVersion(status.Location)
+			return status
+		}
+		status.InstalledVersion = r.Version
+	} else {
+		status.InstalledVersion = probeVersion(status.Location)
+	}
+
+	switch {
+	case status.ExpectedVersion == "":
+		status.State = StateOK
+	case status.InstalledVersion == "":
+		status.State = StateUnverified
+		status.Message = "installed version could not be determined"
+	case versionSatisfies(status.InstalledVersion, status.ExpectedVersion):
+		status.State = StateOK
+	default:
+		status.State = StateOutdated
+		status.Message = fmt.Sprintf("installed %s, expected %s", status.InstalledVersion, status.ExpectedVersion)
+	}
+	return status
+}
+
+// versionSatisfies compares an installed version with an exact version or
+// constraint; non-semver expectations (e.g., branch names) must match
+// exactly.
+func versionSatisfies(installed, expected string) bool {
+	if IsExactVersion(expected) {
+		return canonicalVersion(installed) == canonicalVersion(expected)
+	}
+	if c, err := ParseConstraint(expected); err == nil {
+		return c.Check(installed)
+	}
+	return installed == expected
+}
+
+var versionPattern = regexp.MustCompile(`v?\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?`)
+
+// probeVersion runs "<binary> --version" and returns the first semver in
+// its output, or "" if none is found within a few seconds.
+func probeVersion(binPath string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// #nosec G204 -- binary path comes from the validated manifest
+	out, err := exec.CommandContext(ctx, binPath, "--version").CombinedOutput()
+	if err != nil && len(out) == 0 {
+		return ""
+	}
+	return versionPattern.FindString(string(out))
+}
@@ -0,0 +1,162 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func writeStatusManifest(t *testing.T, dir, body string) string {
+	t.Helper()
+	path := filepath.Join(dir, "tools.yaml")
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestStatus(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as tool binaries")
+	}
+	dir := t.TempDir()
+	binDir := filepath.Join(dir, "bin")
+	manifestPath := writeStatusManifest(t, dir, `version: v1.0.0
+tools:
+  - id: shell
+    required: true
+    install:
+      type: verify
+      command: sh
+  - id: pinned
+    required: true
+    install:
+      type: download
+      version: v1.2.0
+      url: https://example.com/pinned_{{version}}_{{os}}_{{arch}}.tar.gz
+      binName: pinned
+      destination: `+binDir+`
+  - id: probed
+    install:
+      type: download
+      url: https://example.com/probed.tar.gz
+      binName: probed
+      destination: `+binDir+`
+  - id: stale
+    install:
+      type: download
+      version: v2.0.0
+      url: https://example.com/stale_{{version}}.tar.gz
+      binName: stale
+      destination: `+binDir+`
+  - id: absent
+    install:
+      type: download
+      url: https://example.com/absent.tar.gz
+      binName: absent
+      destination: `+binDir+`
+`)
+
+	if err := os.MkdirAll(binDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	writeScript := func(name, output string) string {
+		path := filepath.Join(binDir, name)
+		if err := os.WriteFile(path, []byte("#!/bin/sh\necho '"+output+"'\n"), 0700); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	// Installed by bootstrap: the receipt supplies the version.
+	pinned := writeScript("pinned", "pinned (no version flag)")
+	writeReceipt(&Tool{ID: "pinned", Install: Install{Version: "v1.2.0"}}, pinned)
+	// Installed elsewhere: the version is probed.
+	writeScript("probed", "probed version 0.4.1")
+	writeScript("stale", "stale v1.9.0 (linux/amd64)")
+
+	report, err := Status(Options{ManifestPath: manifestPath})
+	if err != nil {
+		t.Fatalf("Status() error: %v", err)
+	}
+	if !report.Healthy {
+		t.Errorf("expected healthy report (only optional tools have problems): %+v", report.Tools)
+	}
+
+	want := map[string]struct{ state, version string }{
+		"shell":  {StateOK, ""},
+		"pinned": {StateOK, "v1.2.0"},
+		"probed": {StateOK, "0.4.1"},
+		"stale":  {StateOutdated, "v1.9.0"},
+		"absent": {StateMissing, ""},
+	}
+	for _, tool := range report.Tools {
+		w := want[tool.ID]
+		if tool.State != w.state || tool.InstalledVersion != w.version {
+			t.Errorf("%s: state=%s version=%q, want %s %q (%s)", tool.ID, tool.State, tool.InstalledVersion, w.state, w.version, tool.Message)
+		}
+	}
+	if got := report.Tools[1].DigestMatch; got == nil || !*got {
+		t.Errorf("pinned DigestMatch = %v, want true", got)
+	}
+	if report.Tools[2].DigestMatch != nil {
+		t.Error("probed tool has no receipt, so DigestMatch should be nil")
+	}
+
+	// Replacing a bootstrap-installed binary is detected.
+	writeScript("pinned", "pinned v6.6.6")
+	report, err = Status(Options{ManifestPath: manifestPath})
+	if err != nil {
+		t.Fatalf("Status() error: %v", err)
+	}
+	if tool := report.Tools[1]; tool.State != StateModified || tool.DigestMatch == nil || *tool.DigestMatch {
+		t.Errorf("pinned: state=%s digestMatch=%v, want modified/false", tool.State, tool.DigestMatch)
+	}
+	if report.Healthy {
+		t.Error("a modified required tool should make the report unhealthy")
+	}
+}
+
+func TestStatusUsesLockedVersion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as tool binaries")
+	}
+	dir := t.TempDir()
+	binDir := filepath.Join(dir, "bin")
+	manifestPath := writeStatusManifest(t, dir, `version: v1.0.0
+tools:
+  - id: tool
+    required: true
+    install:
+      type: download
+      repo: example/tool
+      version: ">=1.5 <2"
+      url: https://example.com/tool_{{version}}.tar.gz
+      binName: tool
+      destination: `+binDir+`
+`)
+	if err := WriteLock(filepath.Join(dir, "tools.lock"), &Lock{Version: LockFileVersion, Tools: map[string]LockedTool{
+		"tool": {Constraint: ">=1.5 <2", Version: "v1.6.1"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(binDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(binDir, "tool"), []byte("#!/bin/sh\necho 'tool 1.5.0'\n"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Status(Options{ManifestPath: manifestPath})
+	if err != nil {
+		t.Fatalf("Status() error: %v", err)
+	}
+	tool := report.Tools[0]
+	if tool.ExpectedVersion != "v1.6.1" || tool.State != StateOutdated {
+		t.Errorf("state=%s expected=%q, want outdated against locked v1.6.1", tool.State, tool.ExpectedVersion)
+	}
+	if report.Healthy {
+		t.Error("an outdated required tool should make the report unhealthy")
+	}
+}
@@ -1,9 +1,15 @@
+// Command bootstrap installs tools from a goneat tools manifest.
+//
+// Deprecated: use "gofulmen bootstrap"; this entry point is kept for
+// "go run ./cmd/bootstrap" in Makefiles and CI.
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/fulmenhq/gofulmen/bootstrap"
 )
@@ -12,29 +18,54 @@ func main() {
 	var (
 		install      = flag.Bool("install", false, "Install tools from manifest")
 		verify       = flag.Bool("verify", false, "Verify tools are available")
+		update       = flag.Bool("update", false, "Refresh locked versions within manifest constraints")
+		status       = flag.Bool("status", false, "Report installed tool state (also: status or doctor subcommand)")
+		format       = flag.String("format", "text", "Status output format: text or json")
 		manifestPath = flag.String("manifest", ".goneat/tools.yaml", "Path to tools manifest")
+		lockPath     = flag.String("lock", "", "Path to lock file (default: tools.lock next to manifest)")
 		force        = flag.Bool("force", false, "Force reinstall even if exists")
+		jobs         = flag.Int("jobs", 0, "Maximum concurrent installs (default: number of CPUs)")
+		cacheDir     = flag.String("cache-dir", "", "Shared download cache (default: ~/.cache/fulmen/bootstrap)")
+		noCache      = flag.Bool("no-cache", false, "Download directly without the shared cache")
 		verbose      = flag.Bool("verbose", false, "Verbose output")
 		help         = flag.Bool("help", false, "Show usage information")
 	)
 
-	flag.Parse()
+	// "status" and "doctor" subcommands are aliases for --status.
+	args := os.Args[1:]
+	subcommand := len(args) > 0 && (args[0] == "status" || args[0] == "doctor")
+	if subcommand {
+		args = args[1:]
+	}
+	_ = flag.CommandLine.Parse(args) // ExitOnError: exits on invalid flags
+	if subcommand {
+		*status = true
+	}
 
 	if *help {
 		printUsage()
 		os.Exit(0)
 	}
 
-	if !*install && !*verify {
-		fmt.Fprintf(os.Stderr, "Error: must specify --install or --verify\n\n")
+	if !*install && !*verify && !*update && !*status {
+		fmt.Fprintf(os.Stderr, "Error: must specify --install, --verify, --update, or --status\n\n")
 		printUsage()
 		os.Exit(1)
 	}
 
 	opts := bootstrap.Options{
 		ManifestPath: *manifestPath,
+		LockPath:     *lockPath,
 		Force:        *force,
 		Verbose:      *verbose,
+		Update:       *update,
+		Jobs:         *jobs,
+		CacheDir:     *cacheDir,
+		NoCache:      *noCache,
+	}
+
+	if *status {
+		os.Exit(reportStatus(opts, *format))
 	}
 
 	var err error
@@ -43,6 +74,8 @@ func main() {
 		err = bootstrap.InstallTools(opts)
 	} else if *verify {
 		err = bootstrap.VerifyTools(opts)
+	} else if *update {
+		err = updateLock(opts)
 	}
 
 	if err != nil {
@@ -53,6 +86,80 @@ func main() {
 	}
 }
 
+func updateLock(opts bootstrap.Options) error {
+	lock, err := bootstrap.UpdateLock(opts)
+	if err != nil {
+		return err
+	}
+	if opts.Verbose {
+		ids := make([]string, 0, len(lock.Tools))
+		for id := range lock.Tools {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			tool := lock.Tools[id]
+			fmt.Printf("🔒 %s %s (%s)\n", id, tool.Version, tool.Constraint)
+		}
+	}
+	return nil
+}
+
+// reportStatus prints the status report and returns the exit code: 0 when
+// healthy, 1 otherwise.
+func reportStatus(opts bootstrap.Options, format string) int {
+	report, err := bootstrap.Status(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+	case "text":
+		fmt.Printf("Manifest: %s (%s)\n\n", report.Manifest, report.Platform)
+		for _, tool := range report.Tools {
+			icon := "✅"
+			switch tool.State {
+			case bootstrap.StateUnverified:
+				icon = "❔"
+			case bootstrap.StateMissing, bootstrap.StateOutdated, bootstrap.StateModified:
+				icon = "❌"
+				if !tool.Required {
+					icon = "⚠️ "
+				}
+			}
+			line := fmt.Sprintf("%s %s: %s", icon, tool.ID, tool.State)
+			if tool.InstalledVersion != "" {
+				line += " " + tool.InstalledVersion
+			}
+			if tool.Message != "" {
+				line += " (" + tool.Message + ")"
+			}
+			fmt.Println(line)
+		}
+		if report.Healthy {
+			fmt.Printf("\n✅ Environment healthy\n")
+		} else {
+			fmt.Printf("\n❌ Required tools need attention; run --install\n")
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported format %q (use text or json)\n", format)
+		return 1
+	}
+
+	if !report.Healthy {
+		return 1
+	}
+	return 0
+}
+
 func printUsage() {
 	fmt.Println(`Bootstrap - Simple tool installation for Go repositories
 
@@ -62,8 +169,17 @@ Usage:
 Options:
   --install            Install tools from manifest
   --verify             Verify tools are available
+  --status             Report installed tool state (alias: status, doctor)
+  --format <fmt>       Status output format: text (default) or json
+  --update             Refresh locked versions within manifest constraints
+                       (combine with --install to install the refreshed versions)
   --manifest <path>    Path to tools manifest (default: .goneat/tools.yaml)
-  --force              Force reinstall even if exists
+  --lock <path>        Path to lock file (default: tools.lock next to manifest)
+  --force              Force reinstall even if exists (refreshes cached downloads)
+  --jobs <n>           Maximum concurrent installs (default: number of CPUs)
+  --cache-dir <path>   Shared download cache (default: ~/.cache/fulmen/bootstrap,
+                       or $FULMEN_BOOTSTRAP_CACHE)
+  --no-cache           Download directly without the shared cache
   --verbose            Verbose output
   --help               Show this help message
 
@@ -74,6 +190,12 @@ Examples:
   # Verify all tools are available
   go run github.com/fulmenhq/gofulmen/cmd/bootstrap --verify
 
+  # Assert environment health in CI or a pre-commit hook (exit 1 if unhealthy)
+  go run github.com/fulmenhq/gofulmen/cmd/bootstrap status --format json
+
+  # Refresh tools.lock to the newest versions allowed by the manifest
+  go run github.com/fulmenhq/gofulmen/cmd/bootstrap --update
+
   # Custom manifest path
   go run github.com/fulmenhq/gofulmen/cmd/bootstrap --manifest /path/to/tools.yaml --install
 
@@ -1,4 +1,6 @@
 // Package main provides a CLI tool to export Crucible schemas with provenance metadata
+//
+// Deprecated: use "gofulmen export" (--out-format replaces --format).
 package main
 
 import (
@@ -17,13 +19,23 @@ const (
 
 Usage:
   gofulmen-export-schema --schema-id=<id> --out=<path> [options]
+  gofulmen-export-schema (--all | --prefix=<path>) --out-dir=<dir> [options]
 
-Required Flags:
+Required Flags (single schema):
   --schema-id string
         Crucible schema identifier (e.g., "logging/v1.0.0/config")
   --out string
         Output file path
 
+Required Flags (batch):
+  --all
+        Export every Crucible schema (metaschemas excluded)
+  --prefix string
+        Export schemas whose path starts with prefix (e.g., "observability/logging")
+  --out-dir string
+        Output directory; schemas keep their Crucible paths and an
+        index.json manifest (IDs, versions, digests, provenance) is written
+
 Optional Flags:
   --format string
         Output format: json|yaml (default: auto-detect from extension)
@@ -33,6 +45,10 @@ Optional Flags:
         Disable provenance metadata inclusion
   --no-validate
         Skip schema validation before export
+  --bundle
+        Inline external $ref targets into a self-contained schema
+  --manifest string
+        Batch manifest file name (default: index.json)
   --force
         Overwrite existing files without prompting
   --help
@@ -42,7 +58,7 @@ Exit Codes:
   0  - Success
   40 - Invalid arguments (ExitInvalidArgument)
   54 - File write error (ExitFileWriteError)
-  60 - Schema validation error (ExitDataInvalid)
+  60 - Schema validation or bundling error (ExitDataInvalid)
 
 Examples:
   # Export logging config schema as JSON
@@ -61,6 +77,17 @@ Examples:
     --schema-id=logging/v1.0.0/config \
     --out=schema.json \
     --no-provenance
+
+  # Export a self-contained schema for air-gapped validation
+  gofulmen-export-schema \
+    --schema-id=observability/logging/v1.0.0/logger-config.schema.json \
+    --out=logger-config.bundled.json \
+    --bundle
+
+  # Vendor the logging schema family with an index manifest
+  gofulmen-export-schema \
+    --prefix=observability/logging \
+    --out-dir=vendor/crucible/schemas
 `
 )
 
@@ -71,7 +98,12 @@ type cliOptions struct {
 	provenanceStyle string
 	noProvenance    bool
 	noValidate      bool
+	bundle          bool
 	force           bool
+	all             bool
+	prefix          string
+	outDir          string
+	manifest        string
 	help            bool
 }
 
@@ -90,17 +122,31 @@ func run() int {
 		return 0
 	}
 
-	// Validate required flags
-	if opts.schemaID == "" {
-		fmt.Fprintf(os.Stderr, "Error: --schema-id is required\n\n")
-		fmt.Fprint(os.Stderr, usageText)
-		return foundry.ExitInvalidArgument
-	}
+	batch := opts.all || opts.prefix != ""
+	if batch {
+		if opts.schemaID != "" || opts.outPath != "" {
+			fmt.Fprintf(os.Stderr, "Error: --all/--prefix cannot be combined with --schema-id or --out\n\n")
+			fmt.Fprint(os.Stderr, usageText)
+			return foundry.ExitInvalidArgument
+		}
+		if opts.outDir == "" {
+			fmt.Fprintf(os.Stderr, "Error: --out-dir is required with --all or --prefix\n\n")
+			fmt.Fprint(os.Stderr, usageText)
+			return foundry.ExitInvalidArgument
+		}
+	} else {
+		// Validate required flags
+		if opts.schemaID == "" {
+			fmt.Fprintf(os.Stderr, "Error: --schema-id is required\n\n")
+			fmt.Fprint(os.Stderr, usageText)
+			return foundry.ExitInvalidArgument
+		}
 
-	if opts.outPath == "" {
-		fmt.Fprintf(os.Stderr, "Error: --out is required\n\n")
-		fmt.Fprint(os.Stderr, usageText)
-		return foundry.ExitInvalidArgument
+		if opts.outPath == "" {
+			fmt.Fprintf(os.Stderr, "Error: --out is required\n\n")
+			fmt.Fprint(os.Stderr, usageText)
+			return foundry.ExitInvalidArgument
+		}
 	}
 
 	// Build export options
@@ -142,40 +188,69 @@ func run() int {
 		exportOpts.ValidateSchema = false
 	}
 
+	exportOpts.Bundle = opts.bundle
 	exportOpts.Overwrite = opts.force
 
-	// Perform the export
 	ctx := context.Background()
+
+	// Perform a batch export
+	if batch {
+		setOpts := export.ExportSetOptions{
+			Prefix:            opts.prefix,
+			OutDir:            opts.outDir,
+			Format:            exportOpts.Format,
+			ManifestName:      opts.manifest,
+			IncludeProvenance: exportOpts.IncludeProvenance,
+			ProvenanceStyle:   exportOpts.ProvenanceStyle,
+			ValidateSchema:    exportOpts.ValidateSchema,
+			Overwrite:         exportOpts.Overwrite,
+			Bundle:            exportOpts.Bundle,
+			IdentityProvider:  exportOpts.IdentityProvider,
+		}
+		manifest, err := export.ExportSet(ctx, setOpts)
+		if err != nil {
+			return exitCodeForError(err)
+		}
+		_, _ = fmt.Fprintf(os.Stdout, "Successfully exported %d schemas to: %s\n", len(manifest.Schemas), opts.outDir)
+		return 0
+	}
+
+	// Perform the export
 	if err := export.Export(ctx, exportOpts); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeForError(err)
+	}
+
+	// Success
+	_, _ = fmt.Fprintf(os.Stdout, "Successfully exported schema to: %s\n", opts.outPath)
+	return 0
+}
 
-		// Map error to appropriate exit code using errors.Is
-		switch {
-		case errors.Is(err, export.ErrFileExists):
-			_, _ = fmt.Fprintf(os.Stderr, "\nHint: Use --force to overwrite existing files\n")
-			return foundry.ExitFileWriteError
+// exitCodeForError reports err and maps it to an exit code
+func exitCodeForError(err error) int {
+	_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 
-		case errors.Is(err, export.ErrSchemaNotFound):
-			return foundry.ExitConfigInvalid
+	// Map error to appropriate exit code using errors.Is
+	switch {
+	case errors.Is(err, export.ErrFileExists):
+		_, _ = fmt.Fprintf(os.Stderr, "\nHint: Use --force to overwrite existing files\n")
+		return foundry.ExitFileWriteError
 
-		case errors.Is(err, export.ErrSchemaValidation):
-			return foundry.ExitDataInvalid
+	case errors.Is(err, export.ErrSchemaNotFound):
+		return foundry.ExitConfigInvalid
 
-		case errors.Is(err, export.ErrPathValidation):
-			return foundry.ExitFileWriteError
+	case errors.Is(err, export.ErrSchemaValidation), errors.Is(err, export.ErrSchemaBundle):
+		return foundry.ExitDataInvalid
 
-		case errors.Is(err, export.ErrFileWrite):
-			return foundry.ExitFileWriteError
+	case errors.Is(err, export.ErrPathValidation):
+		return foundry.ExitFileWriteError
 
-		default:
-			// For option validation errors or unknown errors
-			return foundry.ExitInvalidArgument
-		}
-	}
+	case errors.Is(err, export.ErrFileWrite):
+		return foundry.ExitFileWriteError
 
-	// Success
-	_, _ = fmt.Fprintf(os.Stdout, "Successfully exported schema to: %s\n", opts.outPath)
-	return 0
+	default:
+		// For option validation errors or unknown errors
+		return foundry.ExitInvalidArgument
+	}
 }
 
 func parseFlags() cliOptions {
@@ -187,7 +262,12 @@ func parseFlags() cliOptions {
 	flag.StringVar(&opts.provenanceStyle, "provenance-style", "", "Provenance style (object|comment|none)")
 	flag.BoolVar(&opts.noProvenance, "no-provenance", false, "Disable provenance metadata")
 	flag.BoolVar(&opts.noValidate, "no-validate", false, "Skip schema validation")
+	flag.BoolVar(&opts.bundle, "bundle", false, "Inline external $ref targets")
 	flag.BoolVar(&opts.force, "force", false, "Overwrite existing files")
+	flag.BoolVar(&opts.all, "all", false, "Export every Crucible schema")
+	flag.StringVar(&opts.prefix, "prefix", "", "Export schemas under a path prefix")
+	flag.StringVar(&opts.outDir, "out-dir", "", "Output directory for batch export")
+	flag.StringVar(&opts.manifest, "manifest", export.DefaultManifestName, "Batch manifest file name")
 	flag.BoolVar(&opts.help, "help", false, "Show help message")
 
 	flag.Parse()
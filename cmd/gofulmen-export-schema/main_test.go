@@ -41,6 +41,23 @@ func TestCLISuccess(t *testing.T) {
 	assert.Equal(t, "terminal/v1.0.0/schema.json", provenance["schema_id"])
 }
 
+func TestCLIBundle(t *testing.T) {
+	tempDir := t.TempDir()
+	outPath := filepath.Join(tempDir, "bundled.json")
+
+	cmd := exec.Command("go", "run", ".",
+		"--schema-id=observability/logging/v1.0.0/logger-config.schema.json",
+		"--out="+outPath,
+		"--bundle")
+
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "CLI should succeed: %s", string(output))
+
+	data, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"$ref": "https://`)
+}
+
 func TestCLIHelp(t *testing.T) {
 	cmd := exec.Command("go", "run", ".", "--help")
 	output, err := cmd.CombinedOutput()
@@ -167,3 +184,56 @@ func TestCLIProvenanceStyles(t *testing.T) {
 		})
 	}
 }
+
+func TestCLIBatchPrefix(t *testing.T) {
+	outDir := t.TempDir()
+
+	cmd := exec.Command("go", "run", ".",
+		"--prefix=observability/logging",
+		"--out-dir="+outDir)
+
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "CLI should succeed: %s", string(output))
+
+	data, err := os.ReadFile(filepath.Join(outDir, "index.json"))
+	require.NoError(t, err)
+
+	var manifest struct {
+		Schemas []struct {
+			SchemaID string `json:"schema_id"`
+			Path     string `json:"path"`
+			Digest   string `json:"digest"`
+		} `json:"schemas"`
+	}
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	require.NotEmpty(t, manifest.Schemas)
+	for _, entry := range manifest.Schemas {
+		assert.FileExists(t, filepath.Join(outDir, filepath.FromSlash(entry.Path)))
+		assert.Contains(t, entry.Digest, "sha256:")
+	}
+}
+
+func TestCLIBatchInvalidArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "missing out-dir",
+			args: []string{"--all"},
+		},
+		{
+			name: "prefix with schema-id",
+			args: []string{"--prefix=observability", "--schema-id=terminal/v1.0.0/schema.json", "--out-dir=/tmp/x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := append([]string{"run", "."}, tt.args...)
+			cmd := exec.Command("go", args...)
+			err := cmd.Run()
+			require.Error(t, err, "CLI should reject invalid batch args")
+		})
+	}
+}
@@ -1,3 +1,7 @@
+// Command gofulmen-schema validates data and schemas against the Crucible catalog.
+//
+// Deprecated: use "gofulmen schema"; this binary is kept for existing scripts
+// and the goneat (--use-goneat) integration.
 package main
 
 import (
@@ -68,8 +72,9 @@ func schemaValidate(args []string) error {
 	fs.SetOutput(os.Stderr)
 
 	schemaID := fs.String("schema-id", "", "Catalog schema identifier (e.g., pathfinder/v1.0.0/path-result)")
-	format := fs.String("format", "text", "Output format (text|json)")
+	format := fs.String("format", "text", "Output format (text|json|sarif)")
 	useGoneat := fs.Bool("use-goneat", false, "Use goneat CLI if available (falls back to local validation)")
+	strictYAML := fs.Bool("strict-yaml", false, "Report duplicate keys, tab indentation, anchors, ambiguous scalars, tags, and non-string keys in YAML input")
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
@@ -92,12 +97,26 @@ func schemaValidate(args []string) error {
 		}
 	}
 
-	diags, err := schema.ValidateFileByID(*schemaID, dataPath)
+	catalog := schema.DefaultCatalog()
+	if *strictYAML {
+		catalog = catalog.WithCompileOptions(&schema.CompileOptions{StrictYAML: &schema.YAMLOptions{}})
+	}
+	diags, err := catalog.ValidateFileByID(*schemaID, dataPath)
 	if err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
 	switch strings.ToLower(*format) {
+	case "sarif":
+		content, err := os.ReadFile(dataPath) // #nosec G304 -- User-provided path is intentional for CLI tool
+		if err != nil {
+			return fmt.Errorf("read data: %w", err)
+		}
+		renderer, err := schema.NewRenderer(dataPath, content)
+		if err != nil {
+			return err
+		}
+		return renderer.Render(os.Stdout, diags, schema.RenderSARIF)
 	case "json":
 		payload := map[string]any{
 			"file":        dataPath,
@@ -114,6 +133,10 @@ func schemaValidate(args []string) error {
 		} else {
 			fmt.Printf("❌ %s invalid against %s\n", dataPath, *schemaID)
 			for _, d := range diags {
+				if d.Location != nil {
+					fmt.Printf("  - %s:%d:%d %s (%s): %s\n", dataPath, d.Location.Line, d.Location.Column, d.Pointer, d.Keyword, d.Message)
+					continue
+				}
 				fmt.Printf("  - %s (%s): %s\n", d.Pointer, d.Keyword, d.Message)
 			}
 		}
@@ -28,3 +28,27 @@ func TestSchemaValidateCommand(t *testing.T) {
 		t.Fatalf("cli validate command failed: %v (stdout=%s, stderr=%s)", err, stdout.String(), stderr.String())
 	}
 }
+
+func TestSchemaValidateCommandSARIF(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping CLI integration test in short mode")
+	}
+
+	data := "relativePath: file.txt\nsourcePath: /tmp/file.txt\nlogicalPath: file.txt\nloaderType: bogus\nmetadata: {}\n"
+	tmpDir := t.TempDir()
+	dataFile := filepath.Join(tmpDir, "path-result.yaml")
+	if err := os.WriteFile(dataFile, []byte(data), 0o600); err != nil {
+		t.Fatalf("write data file: %v", err)
+	}
+
+	cmd := exec.Command("go", "run", "./main.go", "schema", "validate", "--format", "sarif", "--schema-id", "pathfinder/v1.0.0/path-result", dataFile)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("cli validate command failed: %v (stdout=%s, stderr=%s)", err, stdout.String(), stderr.String())
+	}
+	if !bytes.Contains(stdout.Bytes(), []byte(`"version": "2.1.0"`)) || !bytes.Contains(stdout.Bytes(), []byte(`"startLine": 4`)) {
+		t.Fatalf("expected SARIF result on line 4, got %s", stdout.String())
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/fulmenhq/gofulmen/bootstrap"
+	"github.com/fulmenhq/gofulmen/foundry"
+)
+
+func bootstrapCommand() *command {
+	return &command{
+		name:    "bootstrap",
+		summary: "Install, verify, and report tools from a goneat tools manifest",
+		subcommands: []*command{
+			{name: "install", summary: "Install tools from the manifest", setup: bootstrapInstallFlags},
+			{name: "verify", summary: "Verify tools are available", setup: bootstrapVerifyFlags},
+			{name: "update", summary: "Refresh locked versions within manifest constraints", setup: bootstrapUpdateFlags},
+			{name: "status", aliases: []string{"doctor"}, summary: "Report installed tool state (exit 1 when unhealthy)", setup: bootstrapStatusFlags},
+		},
+	}
+}
+
+// bootstrapOptions registers the flags shared by the bootstrap commands and
+// returns a function building bootstrap.Options from them.
+func bootstrapOptions(fs *flag.FlagSet) func(c *cli) bootstrap.Options {
+	manifestPath := fs.String("manifest", ".goneat/tools.yaml", "Path to tools manifest")
+	lockPath := fs.String("lock", "", "Path to lock file (default: tools.lock next to manifest)")
+	return func(c *cli) bootstrap.Options {
+		return bootstrap.Options{
+			ManifestPath: *manifestPath,
+			LockPath:     *lockPath,
+			Verbose:      c.globals.verbose,
+		}
+	}
+}
+
+func bootstrapInstallFlags(fs *flag.FlagSet) runFunc {
+	options := bootstrapOptions(fs)
+	force := fs.Bool("force", false, "Force reinstall even if exists (refreshes cached downloads)")
+	update := fs.Bool("update", false, "Refresh locked versions before installing")
+	jobs := fs.Int("jobs", 0, "Maximum concurrent installs (default: number of CPUs)")
+	cacheDir := fs.String("cache-dir", "", "Shared download cache (default: ~/.cache/fulmen/bootstrap, or $FULMEN_BOOTSTRAP_CACHE)")
+	noCache := fs.Bool("no-cache", false, "Download directly without the shared cache")
+	return func(_ context.Context, c *cli, _ []string) error {
+		opts := options(c)
+		opts.Force = *force
+		opts.Update = *update
+		opts.Jobs = *jobs
+		opts.CacheDir = *cacheDir
+		opts.NoCache = *noCache
+		return withExitCode(foundry.ExitMissingDependency, bootstrap.InstallTools(opts))
+	}
+}
+
+func bootstrapVerifyFlags(fs *flag.FlagSet) runFunc {
+	options := bootstrapOptions(fs)
+	return func(_ context.Context, c *cli, _ []string) error {
+		return withExitCode(foundry.ExitMissingDependency, bootstrap.VerifyTools(options(c)))
+	}
+}
+
+func bootstrapUpdateFlags(fs *flag.FlagSet) runFunc {
+	options := bootstrapOptions(fs)
+	return func(_ context.Context, c *cli, _ []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		lock, err := bootstrap.UpdateLock(options(c))
+		if err != nil {
+			return err
+		}
+		if c.jsonOutput() {
+			return c.printJSON(lock)
+		}
+		ids := make([]string, 0, len(lock.Tools))
+		for id := range lock.Tools {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			tool := lock.Tools[id]
+			fmt.Fprintf(c.stdout, "🔒 %s %s (%s)\n", id, tool.Version, tool.Constraint)
+		}
+		return nil
+	}
+}
+
+func bootstrapStatusFlags(fs *flag.FlagSet) runFunc {
+	options := bootstrapOptions(fs)
+	return func(_ context.Context, c *cli, _ []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		report, err := bootstrap.Status(options(c))
+		if err != nil {
+			return err
+		}
+
+		if c.jsonOutput() {
+			if err := c.printJSON(report); err != nil {
+				return err
+			}
+		} else {
+			fmt.Fprintf(c.stdout, "Manifest: %s (%s)\n\n", report.Manifest, report.Platform)
+			for _, tool := range report.Tools {
+				icon := "✅"
+				switch tool.State {
+				case bootstrap.StateUnverified:
+					icon = "❔"
+				case bootstrap.StateMissing, bootstrap.StateOutdated, bootstrap.StateModified:
+					icon = "❌"
+					if !tool.Required {
+						icon = "⚠️ "
+					}
+				}
+				line := fmt.Sprintf("%s %s: %s", icon, tool.ID, tool.State)
+				if tool.InstalledVersion != "" {
+					line += " " + tool.InstalledVersion
+				}
+				if tool.Message != "" {
+					line += " (" + tool.Message + ")"
+				}
+				fmt.Fprintln(c.stdout, line)
+			}
+			if report.Healthy {
+				fmt.Fprintf(c.stdout, "\n✅ Environment healthy\n")
+			} else {
+				fmt.Fprintf(c.stdout, "\n❌ Required tools need attention; run gofulmen bootstrap install\n")
+			}
+		}
+
+		if !report.Healthy {
+			return withExitCode(foundry.ExitFailure, fmt.Errorf("required tools need attention"))
+		}
+		return nil
+	}
+}
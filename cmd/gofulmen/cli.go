@@ -0,0 +1,327 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/foundry/similarity"
+	"github.com/fulmenhq/gofulmen/logging"
+)
+
+// Output formats accepted by --format.
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// globalOptions holds the flags shared by every command. They are accepted
+// before the command name and by every leaf command.
+type globalOptions struct {
+	format        string
+	verbose       bool
+	correlationID string
+}
+
+// register adds the shared flags to fs, keeping values already parsed.
+func (g *globalOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&g.format, "format", g.format, "Output format (text|json)")
+	fs.BoolVar(&g.verbose, "verbose", g.verbose, "Verbose output (debug logs to stderr)")
+	fs.StringVar(&g.correlationID, "correlation-id", g.correlationID, "Correlation ID for logs and errors (default: generated UUIDv7)")
+}
+
+// runFunc executes a leaf command with its positional arguments.
+type runFunc func(ctx context.Context, c *cli, args []string) error
+
+// command is a gofulmen command. Group commands have subcommands; leaf
+// commands register their flags with setup, which returns the runner bound
+// to them.
+type command struct {
+	name        string
+	aliases     []string
+	summary     string
+	args        string // positional argument synopsis, e.g. "<file>"
+	hidden      bool   // omitted from usage, suggestions, and completion
+	subcommands []*command
+	setup       func(fs *flag.FlagSet) runFunc
+}
+
+// lookup returns the subcommand named name (or aliased to it).
+func (cmd *command) lookup(name string) *command {
+	for _, sub := range cmd.subcommands {
+		if sub.name == name {
+			return sub
+		}
+		for _, alias := range sub.aliases {
+			if alias == name {
+				return sub
+			}
+		}
+	}
+	return nil
+}
+
+// names returns the visible subcommand names, for suggestions and completion.
+func (cmd *command) names() []string {
+	names := make([]string, 0, len(cmd.subcommands))
+	for _, sub := range cmd.subcommands {
+		if sub.hidden {
+			continue
+		}
+		names = append(names, sub.name)
+	}
+	return names
+}
+
+// exitError carries the foundry exit code for an error.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// withExitCode attaches a foundry exit code to err.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// usageErrorf reports invalid command-line usage (foundry.ExitUsage).
+func usageErrorf(format string, args ...any) error {
+	return withExitCode(foundry.ExitUsage, fmt.Errorf(format, args...))
+}
+
+// exitCode maps err to a foundry exit code.
+func exitCode(err error) int {
+	var exitErr *exitError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return foundry.ExitSuccess
+	case errors.As(err, &exitErr):
+		return exitErr.code
+	default:
+		return foundry.ExitFailure
+	}
+}
+
+// cli runs gofulmen commands against the given streams.
+type cli struct {
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
+	root    *command
+	globals globalOptions
+	logger  *slog.Logger
+}
+
+func newCLI(stdin io.Reader, stdout, stderr io.Writer) *cli {
+	c := &cli{stdin: stdin, stdout: stdout, stderr: stderr, globals: globalOptions{format: formatText}}
+	c.root = rootCommand()
+	return c
+}
+
+// run executes args (without the program name) and returns the exit code.
+func (c *cli) run(args []string) int {
+	err := c.dispatch(args)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		c.reportError(err)
+	}
+	return exitCode(err)
+}
+
+func (c *cli) dispatch(args []string) error {
+	fs := c.newFlagSet("gofulmen")
+	fs.Usage = func() { c.printGroupUsage(nil, c.root) }
+	if err := fs.Parse(args); err != nil {
+		return withExitCode(foundry.ExitUsage, err)
+	}
+	args = fs.Args()
+
+	cmd := c.root
+	var path []string
+	for len(cmd.subcommands) > 0 {
+		if len(args) == 0 {
+			c.printGroupUsage(path, cmd)
+			return usageErrorf("%s requires a command", commandName(path))
+		}
+		name := args[0]
+		if name == "help" || name == "-h" || name == "--help" {
+			return c.help(path, cmd, args[1:])
+		}
+		sub := cmd.lookup(name)
+		if sub == nil {
+			return c.unknownCommand(path, cmd, name)
+		}
+		cmd, path, args = sub, append(path, sub.name), args[1:]
+	}
+
+	fs = c.newFlagSet(commandName(path))
+	run := cmd.setup(fs)
+	fs.Usage = func() { c.printLeafUsage(path, cmd, fs) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return withExitCode(foundry.ExitUsage, err)
+	}
+
+	ctx, err := c.prepare(context.Background())
+	if err != nil {
+		return err
+	}
+	c.logger.DebugContext(ctx, "running command", "command", commandName(path), "args", fs.Args())
+	return run(ctx, c, fs.Args())
+}
+
+// prepare validates --correlation-id, sets up logging, and binds the
+// correlation ID to ctx. Commands validate --format (see requireFormat).
+func (c *cli) prepare(ctx context.Context) (context.Context, error) {
+	id := foundry.NewCorrelationIDValue()
+	if c.globals.correlationID != "" {
+		parsed, err := foundry.ParseCorrelationIDValue(c.globals.correlationID)
+		if err != nil {
+			return ctx, usageErrorf("invalid --correlation-id: %v", err)
+		}
+		id = parsed
+	}
+	c.globals.correlationID = id.String()
+
+	level := logging.WARN
+	if c.globals.verbose {
+		level = logging.DEBUG
+	}
+	logger, err := logging.NewSlog(logging.SlogOptions{
+		Service: "gofulmen",
+		Format:  logging.SlogFormatConsole,
+		Level:   level,
+		Writer:  c.stderr,
+	})
+	if err != nil {
+		return ctx, err
+	}
+	c.logger = logger
+	return foundry.WithCorrelationID(ctx, id), nil
+}
+
+// requireFormat rejects --format values other than text and json (plus any
+// extra formats the command supports).
+func (c *cli) requireFormat(extra ...string) error {
+	allowed := append([]string{formatText, formatJSON}, extra...)
+	for _, f := range allowed {
+		if c.globals.format == f {
+			return nil
+		}
+	}
+	return usageErrorf("unsupported --format %q (use %s)", c.globals.format, strings.Join(allowed, ", "))
+}
+
+// jsonOutput reports whether --format json was requested.
+func (c *cli) jsonOutput() bool {
+	return c.globals.format == formatJSON
+}
+
+// printJSON writes v to stdout as indented JSON.
+func (c *cli) printJSON(v any) error {
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// reportError writes err to stderr as text or, with --format json, as a
+// JSON object carrying the exit code and correlation ID.
+func (c *cli) reportError(err error) {
+	if c.globals.format == formatJSON {
+		enc := json.NewEncoder(c.stderr)
+		payload := map[string]any{"error": err.Error(), "exit_code": exitCode(err)}
+		if c.globals.correlationID != "" {
+			payload["correlation_id"] = c.globals.correlationID
+		}
+		_ = enc.Encode(payload)
+		return
+	}
+	fmt.Fprintf(c.stderr, "Error: %v\n", err)
+	if c.globals.verbose && c.globals.correlationID != "" {
+		fmt.Fprintf(c.stderr, "correlation_id: %s\n", c.globals.correlationID)
+	}
+}
+
+// unknownCommand reports name with "did you mean" suggestions from the
+// available commands.
+func (c *cli) unknownCommand(path []string, cmd *command, name string) error {
+	msg := fmt.Sprintf("unknown command %q for %s", name, commandName(path))
+	var matches []string
+	for _, s := range similarity.Suggest(name, cmd.names(), similarity.DefaultSuggestOptions()) {
+		matches = append(matches, s.Value)
+	}
+	if len(matches) > 0 {
+		msg += "\n\nDid you mean this?\n\t" + strings.Join(matches, "\n\t")
+	}
+	msg += fmt.Sprintf("\n\nRun '%s help' for usage.", commandName(path))
+	return withExitCode(foundry.ExitUsage, errors.New(msg))
+}
+
+// help prints usage for the command named by args under cmd.
+func (c *cli) help(path []string, cmd *command, args []string) error {
+	for _, name := range args {
+		if len(cmd.subcommands) == 0 {
+			break
+		}
+		sub := cmd.lookup(name)
+		if sub == nil {
+			return c.unknownCommand(path, cmd, name)
+		}
+		cmd, path = sub, append(path, sub.name)
+	}
+	if len(cmd.subcommands) > 0 {
+		c.printGroupUsage(path, cmd)
+		return nil
+	}
+	fs := c.newFlagSet(commandName(path))
+	cmd.setup(fs)
+	c.printLeafUsage(path, cmd, fs)
+	return nil
+}
+
+func (c *cli) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	c.globals.register(fs)
+	return fs
+}
+
+func (c *cli) printGroupUsage(path []string, cmd *command) {
+	name := commandName(path)
+	if cmd.summary != "" {
+		fmt.Fprintf(c.stderr, "%s - %s\n\n", name, cmd.summary)
+	}
+	fmt.Fprintf(c.stderr, "Usage:\n  %s [--format text|json] [--verbose] [--correlation-id id] <command> [flags]\n\nCommands:\n", name)
+	subs := append([]*command(nil), cmd.subcommands...)
+	sort.Slice(subs, func(i, j int) bool { return subs[i].name < subs[j].name })
+	for _, sub := range subs {
+		if sub.hidden {
+			continue
+		}
+		fmt.Fprintf(c.stderr, "  %-12s %s\n", sub.name, sub.summary)
+	}
+	fmt.Fprintf(c.stderr, "\nRun '%s help <command>' for details.\n", name)
+}
+
+func (c *cli) printLeafUsage(path []string, cmd *command, fs *flag.FlagSet) {
+	fmt.Fprintf(c.stderr, "%s\n\nUsage:\n  %s [flags] %s\n\nFlags:\n", cmd.summary, commandName(path), cmd.args)
+	fs.PrintDefaults()
+}
+
+func commandName(path []string) string {
+	return strings.TrimSpace("gofulmen " + strings.Join(path, " "))
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+)
+
+// runCLI runs gofulmen with args and returns the exit code and output.
+func runCLI(stdin string, args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = newCLI(strings.NewReader(stdin), &out, &errOut).run(args)
+	return code, out.String(), errOut.String()
+}
+
+func TestCLIUnknownCommandSuggestions(t *testing.T) {
+	code, _, stderr := runCLI("", "shcema", "list")
+	if code != foundry.ExitUsage {
+		t.Errorf("exit code = %d, want %d", code, foundry.ExitUsage)
+	}
+	if !strings.Contains(stderr, "Did you mean this?") || !strings.Contains(stderr, "schema") {
+		t.Errorf("expected schema suggestion, got:\n%s", stderr)
+	}
+
+	code, _, stderr = runCLI("", "fulpack", "verfy")
+	if code != foundry.ExitUsage || !strings.Contains(stderr, "verify") {
+		t.Errorf("expected verify suggestion (exit %d), got %d:\n%s", foundry.ExitUsage, code, stderr)
+	}
+}
+
+func TestCLIHelp(t *testing.T) {
+	for _, args := range [][]string{{"help"}, {"--help"}, {"help", "schema", "validate"}, {"schema", "validate", "-h"}} {
+		code, _, stderr := runCLI("", args...)
+		if code != foundry.ExitSuccess {
+			t.Errorf("%v: exit code = %d, want 0", args, code)
+		}
+		if !strings.Contains(stderr, "Usage:") {
+			t.Errorf("%v: expected usage, got:\n%s", args, stderr)
+		}
+	}
+	if code, _, _ := runCLI(""); code != foundry.ExitUsage {
+		t.Errorf("no command: exit code = %d, want %d", code, foundry.ExitUsage)
+	}
+}
+
+func TestCLIGlobalFlags(t *testing.T) {
+	// --format is accepted before the command name and by the command
+	for _, args := range [][]string{
+		{"--format", "json", "schema", "list", "--prefix", "pathfinder"},
+		{"schema", "list", "--format=json", "--prefix", "pathfinder"},
+	} {
+		code, stdout, stderr := runCLI("", args...)
+		if code != foundry.ExitSuccess {
+			t.Fatalf("%v: exit code %d: %s", args, code, stderr)
+		}
+		var ids []string
+		if err := json.Unmarshal([]byte(stdout), &ids); err != nil || len(ids) == 0 {
+			t.Errorf("%v: expected JSON schema IDs, got %q (%v)", args, stdout, err)
+		}
+	}
+
+	code, _, stderr := runCLI("", "--format", "xml", "schema", "list")
+	if code != foundry.ExitUsage || !strings.Contains(stderr, "unsupported --format") {
+		t.Errorf("expected usage error for --format xml, got %d: %s", code, stderr)
+	}
+
+	code, _, stderr = runCLI("", "--correlation-id", "not-a-uuid", "schema", "list")
+	if code != foundry.ExitUsage || !strings.Contains(stderr, "correlation-id") {
+		t.Errorf("expected usage error for invalid correlation ID, got %d: %s", code, stderr)
+	}
+}
+
+func TestCLIErrorJSON(t *testing.T) {
+	id := foundry.NewCorrelationIDValue().String()
+	code, _, stderr := runCLI("", "--format", "json", "--correlation-id", id, "docscribe", "inspect", filepath.Join(t.TempDir(), "missing.md"))
+	if code != foundry.ExitFileReadError {
+		t.Errorf("exit code = %d, want %d", code, foundry.ExitFileReadError)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal([]byte(stderr), &payload); err != nil {
+		t.Fatalf("expected JSON error, got %q: %v", stderr, err)
+	}
+	if payload["correlation_id"] != id || payload["exit_code"] != float64(foundry.ExitFileReadError) {
+		t.Errorf("unexpected error payload %v", payload)
+	}
+}
+
+func TestCLIDocscribeLint(t *testing.T) {
+	code, stdout, _ := runCLI("# One\n\n# Two\n", "docscribe", "lint", "-")
+	if code != foundry.ExitDataInvalid {
+		t.Errorf("exit code = %d, want %d", code, foundry.ExitDataInvalid)
+	}
+	if !strings.Contains(stdout, "single-h1") {
+		t.Errorf("expected single-h1 finding, got:\n%s", stdout)
+	}
+
+	code, stdout, stderr := runCLI("---\ntitle: Hello\n---\n# Body\n", "--format", "json", "docscribe", "frontmatter", "-")
+	if code != foundry.ExitSuccess || !strings.Contains(stdout, `"title": "Hello"`) {
+		t.Errorf("frontmatter: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+}
+
+func TestCLIFulpackAndPathfind(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	src := "src"
+	if err := os.MkdirAll(src, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "main.go"), []byte("package main\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI("", "pathfind", "--include", "*.go", src)
+	if code != foundry.ExitSuccess || strings.TrimSpace(stdout) != "main.go" {
+		t.Errorf("pathfind: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+
+	archive := "out.tar.gz"
+	if code, _, stderr := runCLI("", "fulpack", "create", archive, src); code != foundry.ExitSuccess {
+		t.Fatalf("fulpack create: exit %d: %s", code, stderr)
+	}
+	code, stdout, stderr = runCLI("", "fulpack", "list", archive)
+	if code != foundry.ExitSuccess || !strings.Contains(stdout, "main.go") {
+		t.Errorf("fulpack list: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	code, stdout, stderr = runCLI("", "--format", "json", "fulpack", "info", archive)
+	if code != foundry.ExitSuccess || !strings.Contains(stdout, `"entry_count": 1`) {
+		t.Errorf("fulpack info: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+	code, stdout, _ = runCLI("", "fulpack", "diff", archive, archive)
+	if code != foundry.ExitSuccess || !strings.Contains(stdout, "0 added, 0 removed, 0 modified, 1 unchanged") {
+		t.Errorf("fulpack diff: exit %d, stdout %q", code, stdout)
+	}
+	if code, _, _ := runCLI("", "fulpack", "info", filepath.Join(dir, "missing.zip")); code != foundry.ExitFileNotFound {
+		t.Errorf("fulpack info missing: exit %d, want %d", code, foundry.ExitFileNotFound)
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Completion scripts delegate to the hidden __complete command, so they stay
+// in step with the command tree without being regenerated. "--" stops
+// __complete from parsing the completed words as its own flags.
+const (
+	bashCompletion = `# bash completion for gofulmen
+# Load with: source <(gofulmen completion bash)
+_gofulmen() {
+	local IFS=$'\n'
+	COMPREPLY=($(gofulmen __complete -- "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _gofulmen gofulmen
+`
+
+	zshCompletion = `#compdef gofulmen
+# zsh completion for gofulmen
+# Load with: source <(gofulmen completion zsh), or save as _gofulmen on $fpath
+_gofulmen() {
+	local -a candidates
+	candidates=("${(@f)$(gofulmen __complete -- "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+	if [[ -n ${candidates[1]} ]]; then
+		compadd -a candidates
+	else
+		_files
+	fi
+}
+if [[ $funcstack[1] == _gofulmen ]]; then
+	_gofulmen "$@"
+else
+	compdef _gofulmen gofulmen
+fi
+`
+
+	fishCompletion = `# fish completion for gofulmen
+# Load with: gofulmen completion fish | source
+function __gofulmen_complete
+	set -l words (commandline -opc)
+	gofulmen __complete -- $words[2..-1] (commandline -ct) 2>/dev/null
+end
+complete -c gofulmen -a '(__gofulmen_complete)'
+`
+)
+
+func completionCommand() *command {
+	script := func(body string) func(*flag.FlagSet) runFunc {
+		return func(*flag.FlagSet) runFunc {
+			return func(_ context.Context, c *cli, _ []string) error {
+				_, err := fmt.Fprint(c.stdout, body)
+				return err
+			}
+		}
+	}
+	return &command{
+		name:    "completion",
+		summary: "Print a shell completion script",
+		subcommands: []*command{
+			{name: "bash", summary: "Print the bash completion script (source <(gofulmen completion bash))", setup: script(bashCompletion)},
+			{name: "fish", summary: "Print the fish completion script (gofulmen completion fish | source)", setup: script(fishCompletion)},
+			{name: "zsh", summary: "Print the zsh completion script (source <(gofulmen completion zsh))", setup: script(zshCompletion)},
+		},
+	}
+}
+
+func completeCommand() *command {
+	return &command{
+		name:    "__complete",
+		summary: "Print completion candidates for the given words (the last is the word being completed)",
+		args:    "-- <word>...",
+		hidden:  true,
+		setup: func(*flag.FlagSet) runFunc {
+			return func(_ context.Context, c *cli, args []string) error {
+				for _, candidate := range complete(c.root, args) {
+					fmt.Fprintln(c.stdout, candidate)
+				}
+				return nil
+			}
+		},
+	}
+}
+
+// complete returns the candidates for the last of words, given the words
+// before it. It returns nothing where the shell should fall back to file
+// names: positional arguments and flag values.
+func complete(root *command, words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	cur := words[len(words)-1]
+
+	cmd := root
+	expectValue := false
+	for _, word := range words[:len(words)-1] {
+		switch {
+		case expectValue:
+			expectValue = false
+		case strings.HasPrefix(word, "-"):
+			name, _, hasValue := strings.Cut(strings.TrimLeft(word, "-"), "=")
+			if f := completionFlags(cmd).Lookup(name); f != nil && !hasValue && flagType(f) != "bool" {
+				expectValue = true
+			}
+		case len(cmd.subcommands) > 0:
+			if cmd = cmd.lookup(word); cmd == nil {
+				return nil
+			}
+		}
+	}
+	if expectValue {
+		return nil
+	}
+
+	var candidates []string
+	switch {
+	case strings.HasPrefix(cur, "-"):
+		completionFlags(cmd).VisitAll(func(f *flag.Flag) {
+			candidates = append(candidates, "--"+f.Name)
+		})
+	case len(cmd.subcommands) > 0:
+		candidates = cmd.names()
+		sort.Strings(candidates)
+	}
+
+	matches := candidates[:0]
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, cur) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// completionFlags returns the flags cmd accepts: the global flags, plus its
+// own for leaf commands.
+func completionFlags(cmd *command) *flag.FlagSet {
+	var globals globalOptions
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	globals.register(fs)
+	if cmd.setup != nil {
+		cmd.setup(fs)
+	}
+	return fs
+}
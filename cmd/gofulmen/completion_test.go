@@ -0,0 +1,60 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+)
+
+func TestComplete(t *testing.T) {
+	root := rootCommand()
+	tests := []struct {
+		words []string
+		want  []string
+	}{
+		{[]string{"sch"}, []string{"schema"}},
+		{[]string{"schema", "val"}, []string{"validate", "validate-schema"}},
+		{[]string{"--format", "json", "fulpack", "v"}, []string{"verify"}},
+		{[]string{"serve", "--st"}, []string{"--stdio"}},
+		{[]string{"--v"}, []string{"--verbose"}},
+		{[]string{"__"}, nil},                        // hidden commands
+		{[]string{"pathfind", "--include", ""}, nil}, // flag value
+		{[]string{"docscribe", "lint", ""}, nil},     // positional argument
+		{[]string{"nosuch", ""}, nil},                // unknown command
+		{[]string{"completion", ""}, []string{"bash", "fish", "zsh"}},
+	}
+	for _, tt := range tests {
+		got := complete(root, tt.words)
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("complete(%q) = %q, want %q", tt.words, got, tt.want)
+		}
+	}
+
+	code, stdout, stderr := runCLI("", "__complete", "--", "bootstrap", "")
+	if code != foundry.ExitSuccess || strings.Fields(stdout)[0] != "install" {
+		t.Errorf("__complete: exit %d, stdout %q, stderr %q", code, stdout, stderr)
+	}
+}
+
+func TestCompletionScripts(t *testing.T) {
+	for shell, marker := range map[string]string{
+		"bash": "complete -o default -F _gofulmen gofulmen",
+		"zsh":  "#compdef gofulmen",
+		"fish": "complete -c gofulmen",
+	} {
+		code, stdout, stderr := runCLI("", "completion", shell)
+		if code != foundry.ExitSuccess || !strings.Contains(stdout, marker) || !strings.Contains(stdout, "__complete --") {
+			t.Errorf("completion %s: exit %d, stdout %q, stderr %q", shell, code, stdout, stderr)
+		}
+	}
+
+	_, _, stderr := runCLI("", "help")
+	if strings.Contains(stderr, "__manifest") || strings.Contains(stderr, "__complete") {
+		t.Errorf("hidden commands listed in usage:\n%s", stderr)
+	}
+}
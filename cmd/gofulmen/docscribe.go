@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/docscribe"
+	"github.com/fulmenhq/gofulmen/foundry"
+)
+
+func docscribeCommand() *command {
+	return &command{
+		name:    "docscribe",
+		summary: "Inspect, parse, and lint Markdown documents",
+		subcommands: []*command{
+			{name: "inspect", summary: "Report document format, frontmatter, header, and line counts", args: "<file|->", setup: docscribeInspectFlags},
+			{name: "headers", summary: "List document headers with anchors", args: "<file|->", setup: docscribeHeadersFlags},
+			{name: "frontmatter", summary: "Print parsed frontmatter metadata", args: "<file|->", setup: docscribeFrontmatterFlags},
+			{name: "lint", summary: "Lint a document with the default rule set (exit 60 on errors or warnings)", args: "<file|->", setup: docscribeLintFlags},
+		},
+	}
+}
+
+// readDocument reads the single file argument, or stdin for "-".
+func readDocument(c *cli, args []string) (string, []byte, error) {
+	if len(args) != 1 {
+		return "", nil, usageErrorf("provide exactly one file (or - for stdin)")
+	}
+	var content []byte
+	var err error
+	if args[0] == "-" {
+		content, err = io.ReadAll(c.stdin)
+	} else {
+		content, err = os.ReadFile(args[0]) // #nosec G304 -- User-provided path is intentional for CLI tool
+	}
+	if err != nil {
+		return "", nil, withExitCode(foundry.ExitFileReadError, err)
+	}
+	return args[0], content, nil
+}
+
+func docscribeInspectFlags(*flag.FlagSet) runFunc {
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		_, content, err := readDocument(c, args)
+		if err != nil {
+			return err
+		}
+		info, err := docscribe.InspectDocument(content)
+		if err != nil {
+			return withExitCode(foundry.ExitParseError, err)
+		}
+		if c.jsonOutput() {
+			return c.printJSON(info)
+		}
+		fmt.Fprintf(c.stdout, "Format:      %s\nFrontmatter: %t\nHeaders:     %d\nSections:    %d\nLines:       %d\n",
+			info.Format, info.HasFrontmatter, info.HeaderCount, info.EstimatedSections, info.LineCount)
+		if info.Encrypted {
+			fmt.Fprintf(c.stdout, "Encrypted:   %s\n", info.EncryptionScheme)
+		}
+		return nil
+	}
+}
+
+func docscribeHeadersFlags(*flag.FlagSet) runFunc {
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		_, content, err := readDocument(c, args)
+		if err != nil {
+			return err
+		}
+		headers, err := docscribe.ExtractHeaders(content)
+		if err != nil {
+			return withExitCode(foundry.ExitParseError, err)
+		}
+		if c.jsonOutput() {
+			if headers == nil {
+				headers = []docscribe.Header{}
+			}
+			return c.printJSON(headers)
+		}
+		for _, h := range headers {
+			fmt.Fprintf(c.stdout, "%s%s (#%s, line %d)\n", strings.Repeat("  ", h.Level-1), h.Text, h.Anchor, h.LineNumber)
+		}
+		return nil
+	}
+}
+
+func docscribeFrontmatterFlags(*flag.FlagSet) runFunc {
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		_, content, err := readDocument(c, args)
+		if err != nil {
+			return err
+		}
+		_, metadata, err := docscribe.ParseFrontmatter(content)
+		if err != nil {
+			return withExitCode(foundry.ExitParseError, err)
+		}
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+		}
+		if c.jsonOutput() {
+			return c.printJSON(metadata)
+		}
+		keys := make([]string, 0, len(metadata))
+		for key := range metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(c.stdout, "%s: %v\n", key, metadata[key])
+		}
+		return nil
+	}
+}
+
+func docscribeLintFlags(*flag.FlagSet) runFunc {
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		name, content, err := readDocument(c, args)
+		if err != nil {
+			return err
+		}
+		diags, err := docscribe.Lint(content, docscribe.DefaultRuleSet())
+		if err != nil {
+			return withExitCode(foundry.ExitParseError, err)
+		}
+
+		if c.jsonOutput() {
+			if diags == nil {
+				diags = []docscribe.Diagnostic{}
+			}
+			if err := c.printJSON(diags); err != nil {
+				return err
+			}
+		} else {
+			for _, d := range diags {
+				fmt.Fprintf(c.stdout, "%s:%d:%d %s [%s] %s\n", name, d.Line, d.Column, d.Severity, d.Rule, d.Message)
+			}
+		}
+
+		findings := 0
+		for _, d := range diags {
+			if d.Severity != docscribe.SeverityInfo {
+				findings++
+			}
+		}
+		if findings > 0 {
+			return withExitCode(foundry.ExitDataInvalid, fmt.Errorf("%s: %d lint finding(s)", name, findings))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/schema/export"
+)
+
+func exportCommand() *command {
+	return &command{
+		name:    "export",
+		summary: "Export Crucible schemas with provenance metadata (--schema-id/--out, or --all/--prefix with --out-dir)",
+		setup:   exportFlags,
+	}
+}
+
+func exportFlags(fs *flag.FlagSet) runFunc {
+	schemaID := fs.String("schema-id", "", "Crucible schema identifier (e.g., logging/v1.0.0/config)")
+	outPath := fs.String("out", "", "Output file path")
+	outFormat := fs.String("out-format", "", "Exported schema format: json|yaml (default: from --out extension)")
+	provenanceStyle := fs.String("provenance-style", "", "Provenance style: object|comment|none (default: object)")
+	noProvenance := fs.Bool("no-provenance", false, "Disable provenance metadata")
+	noValidate := fs.Bool("no-validate", false, "Skip schema validation before export")
+	bundle := fs.Bool("bundle", false, "Inline external $ref targets into a self-contained schema")
+	force := fs.Bool("force", false, "Overwrite existing files")
+	all := fs.Bool("all", false, "Export every Crucible schema (metaschemas excluded)")
+	prefix := fs.String("prefix", "", "Export schemas whose path starts with prefix (e.g., observability/logging)")
+	outDir := fs.String("out-dir", "", "Output directory for --all/--prefix; an index manifest is written alongside")
+	manifest := fs.String("manifest", export.DefaultManifestName, "Batch manifest file name")
+
+	return func(ctx context.Context, c *cli, _ []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+
+		batch := *all || *prefix != ""
+		switch {
+		case batch && (*schemaID != "" || *outPath != ""):
+			return usageErrorf("--all/--prefix cannot be combined with --schema-id or --out")
+		case batch && *outDir == "":
+			return withExitCode(foundry.ExitMissingRequiredArgument, fmt.Errorf("--out-dir is required with --all or --prefix"))
+		case !batch && *schemaID == "":
+			return withExitCode(foundry.ExitMissingRequiredArgument, fmt.Errorf("--schema-id is required"))
+		case !batch && *outPath == "":
+			return withExitCode(foundry.ExitMissingRequiredArgument, fmt.Errorf("--out is required"))
+		}
+
+		exportOpts := export.NewExportOptions(*schemaID, *outPath)
+		switch *outFormat {
+		case "":
+		case "json":
+			exportOpts.Format = export.FormatJSON
+		case "yaml", "yml":
+			exportOpts.Format = export.FormatYAML
+		default:
+			return withExitCode(foundry.ExitInvalidArgument, fmt.Errorf("invalid --out-format %q (must be json or yaml)", *outFormat))
+		}
+		switch *provenanceStyle {
+		case "":
+		case "object":
+			exportOpts.ProvenanceStyle = export.ProvenanceObject
+		case "comment":
+			exportOpts.ProvenanceStyle = export.ProvenanceComment
+		case "none":
+			exportOpts.ProvenanceStyle = export.ProvenanceNone
+			exportOpts.IncludeProvenance = false
+		default:
+			return withExitCode(foundry.ExitInvalidArgument, fmt.Errorf("invalid --provenance-style %q (must be object, comment, or none)", *provenanceStyle))
+		}
+		if *noProvenance {
+			exportOpts.IncludeProvenance = false
+		}
+		if *noValidate {
+			exportOpts.ValidateSchema = false
+		}
+		exportOpts.Bundle = *bundle
+		exportOpts.Overwrite = *force
+
+		if batch {
+			result, err := export.ExportSet(ctx, export.ExportSetOptions{
+				Prefix:            *prefix,
+				OutDir:            *outDir,
+				Format:            exportOpts.Format,
+				ManifestName:      *manifest,
+				IncludeProvenance: exportOpts.IncludeProvenance,
+				ProvenanceStyle:   exportOpts.ProvenanceStyle,
+				ValidateSchema:    exportOpts.ValidateSchema,
+				Overwrite:         exportOpts.Overwrite,
+				Bundle:            exportOpts.Bundle,
+				IdentityProvider:  exportOpts.IdentityProvider,
+			})
+			if err != nil {
+				return exportError(err)
+			}
+			if c.jsonOutput() {
+				return c.printJSON(result)
+			}
+			fmt.Fprintf(c.stdout, "Successfully exported %d schemas to: %s\n", len(result.Schemas), *outDir)
+			return nil
+		}
+
+		if err := export.Export(ctx, exportOpts); err != nil {
+			return exportError(err)
+		}
+		if c.jsonOutput() {
+			return c.printJSON(map[string]any{"schema_id": *schemaID, "out": *outPath})
+		}
+		fmt.Fprintf(c.stdout, "Successfully exported schema to: %s\n", *outPath)
+		return nil
+	}
+}
+
+// exportError maps export errors to exit codes.
+func exportError(err error) error {
+	switch {
+	case errors.Is(err, export.ErrFileExists):
+		return withExitCode(foundry.ExitFileWriteError, fmt.Errorf("%w (use --force to overwrite)", err))
+	case errors.Is(err, export.ErrSchemaNotFound):
+		return withExitCode(foundry.ExitConfigInvalid, err)
+	case errors.Is(err, export.ErrSchemaValidation), errors.Is(err, export.ErrSchemaBundle):
+		return withExitCode(foundry.ExitDataInvalid, err)
+	case errors.Is(err, export.ErrPathValidation), errors.Is(err, export.ErrFileWrite):
+		return withExitCode(foundry.ExitFileWriteError, err)
+	default:
+		return withExitCode(foundry.ExitInvalidArgument, err)
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/fulpack"
+)
+
+func fulpackCommand() *command {
+	return &command{
+		name:    "fulpack",
+		summary: "Create, extract, list, verify, and diff tar, tar.gz, zip, and gzip archives",
+		subcommands: []*command{
+			{name: "create", summary: "Create an archive from files and directories", args: "<output> <source>...", setup: fulpackCreateFlags},
+			{name: "extract", summary: "Extract an archive into a directory", args: "<archive> <destination>", setup: fulpackExtractFlags},
+			{name: "list", summary: "List archive entries", args: "<archive>", setup: fulpackListFlags},
+			{name: "verify", summary: "Verify archive integrity and safety (exit 60 when invalid)", args: "<archive>", setup: fulpackVerifyFlags},
+			{name: "diff", summary: "Compare two archives entry by entry (exit 1 when they differ)", args: "<old-archive> <new-archive>", setup: fulpackDiffFlags},
+			{name: "info", summary: "Show archive format, entry count, and sizes", args: "<archive>", setup: fulpackInfoFlags},
+		},
+	}
+}
+
+// fulpackError maps fulpack errors to exit codes.
+func fulpackError(err error) error {
+	var fpErr *fulpack.FulpackError
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return withExitCode(foundry.ExitFileNotFound, err)
+	case errors.As(err, &fpErr):
+		return withExitCode(foundry.ExitDataInvalid, err)
+	default:
+		return err
+	}
+}
+
+// archiveFormat returns the --archive-format value, or the format implied by
+// the output file name.
+func archiveFormat(format, output string) (fulpack.ArchiveFormat, error) {
+	if format != "" {
+		return fulpack.ArchiveFormat(format), nil
+	}
+	switch {
+	case strings.HasSuffix(output, ".tar.gz"), strings.HasSuffix(output, ".tgz"):
+		return fulpack.ArchiveFormatTARGZ, nil
+	case strings.HasSuffix(output, ".tar"):
+		return fulpack.ArchiveFormatTAR, nil
+	case strings.HasSuffix(output, ".zip"):
+		return fulpack.ArchiveFormatZIP, nil
+	case strings.HasSuffix(output, ".gz"):
+		return fulpack.ArchiveFormatGZIP, nil
+	}
+	return "", usageErrorf("cannot infer archive format from %q (set --archive-format)", output)
+}
+
+func fulpackCreateFlags(fs *flag.FlagSet) runFunc {
+	format := fs.String("archive-format", "", "Archive format: tar|tar.gz|zip|gzip (default: from output extension)")
+	var opts fulpack.CreateOptions
+	fs.IntVar(&opts.CompressionLevel, "level", 0, "Compression level 1-9 (default: format default)")
+	exclude := fs.String("exclude", "", "Comma-separated glob patterns to exclude (e.g., **/.git,**/*.tmp)")
+	links := fs.String("links", string(fulpack.LinkPolicyPreserve), "Symlink handling: preserve|follow|skip|error")
+	fs.BoolVar(&opts.DetectHardlinks, "hardlinks", false, "Store repeated hardlinked files once (tar formats)")
+	metadata := fs.Bool("metadata", false, "Record ownership, extended attributes, and sub-second mtimes (tar formats)")
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		if len(args) < 2 {
+			return usageErrorf("provide an output archive and at least one source")
+		}
+		archive, err := archiveFormat(*format, args[0])
+		if err != nil {
+			return err
+		}
+		if *exclude != "" {
+			opts.ExcludePatterns = strings.Split(*exclude, ",")
+		}
+		opts.LinkPolicy = fulpack.LinkPolicy(*links)
+		opts.PreserveOwnership = *metadata
+		opts.PreserveXattrs = *metadata
+		opts.PreciseModTimes = *metadata
+		info, err := fulpack.Create(args[1:], args[0], archive, &opts)
+		if err != nil {
+			return fulpackError(err)
+		}
+		if c.jsonOutput() {
+			return c.printJSON(info)
+		}
+		fmt.Fprintf(c.stdout, "Created %s (%s, %d entries, %d bytes)\n", args[0], info.Format, info.EntryCount, info.CompressedSize)
+		for _, w := range info.Warnings {
+			fmt.Fprintf(c.stdout, "  ⚠️  %s\n", w)
+		}
+		return nil
+	}
+}
+
+func fulpackExtractFlags(fs *flag.FlagSet) runFunc {
+	untrusted := fs.Bool("untrusted", false, "Apply the hardened profile for untrusted archives (size, entry, and symlink limits)")
+	overwrite := fs.String("overwrite", string(fulpack.OverwritePolicyError), "Existing files: error|skip|overwrite")
+	metadata := fs.Bool("metadata", false, "Restore recorded mtimes and extended attributes (tar formats)")
+	sameOwner := fs.Bool("same-owner", false, "Restore recorded ownership (tar formats, requires superuser)")
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		if len(args) != 2 {
+			return usageErrorf("provide an archive and a destination directory")
+		}
+		opts := &fulpack.ExtractOptions{}
+		if *untrusted {
+			opts = fulpack.UntrustedProfile()
+		}
+		opts.Overwrite = fulpack.OverwritePolicy(*overwrite)
+		opts.PreserveModTimes = *metadata
+		opts.PreserveXattrs = *metadata
+		opts.PreserveOwnership = *sameOwner
+
+		result, err := fulpack.Extract(args[0], args[1], opts)
+		if err != nil {
+			return fulpackError(err)
+		}
+		if c.jsonOutput() {
+			if err := c.printJSON(result); err != nil {
+				return err
+			}
+		} else {
+			fmt.Fprintf(c.stdout, "Extracted %d entries to %s (%d skipped, %d errors)\n", result.ExtractedCount, args[1], result.SkippedCount, result.ErrorCount)
+			for _, e := range result.Errors {
+				fmt.Fprintf(c.stdout, "  - %s: %s\n", e.Path, e.Error)
+			}
+		}
+		if result.ErrorCount > 0 {
+			return withExitCode(foundry.ExitDataInvalid, fmt.Errorf("%d entries could not be extracted", result.ErrorCount))
+		}
+		return nil
+	}
+}
+
+func fulpackListFlags(*flag.FlagSet) runFunc {
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		if len(args) != 1 {
+			return usageErrorf("provide exactly one archive")
+		}
+		entries, err := fulpack.Scan(args[0], nil)
+		if err != nil {
+			return fulpackError(err)
+		}
+		if c.jsonOutput() {
+			if entries == nil {
+				entries = []fulpack.ArchiveEntry{}
+			}
+			return c.printJSON(entries)
+		}
+		for _, e := range entries {
+			fmt.Fprintf(c.stdout, "%-8s %10d  %s\n", e.Type, e.Size, e.Path)
+		}
+		return nil
+	}
+}
+
+func fulpackVerifyFlags(*flag.FlagSet) runFunc {
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		if len(args) != 1 {
+			return usageErrorf("provide exactly one archive")
+		}
+		result, err := fulpack.Verify(args[0], nil)
+		if err != nil {
+			return fulpackError(err)
+		}
+		if c.jsonOutput() {
+			if err := c.printJSON(result); err != nil {
+				return err
+			}
+		} else if result.Valid {
+			fmt.Fprintf(c.stdout, "✅ %s valid (%d entries, %d checksums verified)\n", args[0], result.EntryCount, result.ChecksumsVerified)
+		} else {
+			fmt.Fprintf(c.stdout, "❌ %s invalid\n", args[0])
+			for _, e := range result.Errors {
+				fmt.Fprintf(c.stdout, "  - [%s] %s %s\n", e.Code, e.Path, e.Message)
+			}
+		}
+		if !result.Valid {
+			return withExitCode(foundry.ExitDataInvalid, fmt.Errorf("%s failed verification", args[0]))
+		}
+		return nil
+	}
+}
+
+func fulpackDiffFlags(fs *flag.FlagSet) runFunc {
+	var opts fulpack.DiffOptions
+	fs.BoolVar(&opts.CompareModes, "modes", false, "Report permission changes")
+	fs.BoolVar(&opts.CompareModTimes, "mtimes", false, "Report modification time changes")
+	include := fs.String("include", "", "Comma-separated glob patterns of entries to compare")
+	exclude := fs.String("exclude", "", "Comma-separated glob patterns of entries to ignore")
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		if len(args) != 2 {
+			return usageErrorf("provide two archives to compare")
+		}
+		if *include != "" {
+			opts.IncludePatterns = strings.Split(*include, ",")
+		}
+		if *exclude != "" {
+			opts.ExcludePatterns = strings.Split(*exclude, ",")
+		}
+		result, err := fulpack.Diff(args[0], args[1], &opts)
+		if err != nil {
+			return fulpackError(err)
+		}
+		if c.jsonOutput() {
+			err = result.WriteJSON(c.stdout)
+		} else {
+			err = result.WriteText(c.stdout)
+		}
+		if err != nil {
+			return err
+		}
+		if !result.Identical() {
+			return withExitCode(foundry.ExitFailure, fmt.Errorf("archives differ (%d changes)", len(result.Changes)))
+		}
+		return nil
+	}
+}
+
+func fulpackInfoFlags(*flag.FlagSet) runFunc {
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		if len(args) != 1 {
+			return usageErrorf("provide exactly one archive")
+		}
+		info, err := fulpack.Info(args[0])
+		if err != nil {
+			return fulpackError(err)
+		}
+		if c.jsonOutput() {
+			return c.printJSON(info)
+		}
+		fmt.Fprintf(c.stdout, "Format:      %s\nCompression: %s\nEntries:     %d\nSize:        %d\nCompressed:  %d\n",
+			info.Format, info.Compression, info.EntryCount, info.TotalSize, info.CompressedSize)
+		return nil
+	}
+}
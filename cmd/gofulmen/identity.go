@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/appidentity"
+	"github.com/fulmenhq/gofulmen/foundry"
+)
+
+func identityCommand() *command {
+	return &command{
+		name:    "identity",
+		summary: "Manage the application identity (.fulmen/app.yaml)",
+		subcommands: []*command{{
+			name:    "init",
+			summary: "Generate a schema-valid .fulmen/app.yaml from flags, go.mod inference, and prompts (--interactive)",
+			setup:   identityInitFlags,
+		}},
+	}
+}
+
+// identityInitFlags registers the identity init flags. The command generates
+// a .fulmen/app.yaml from flags, go.mod inference, and (with --interactive)
+// prompts.
+func identityInitFlags(fs *flag.FlagSet) runFunc {
+	path := fs.String("path", appidentity.DefaultIdentityPath, "Identity file to write")
+	interactive := fs.Bool("interactive", false, "Prompt for each value (flags and go.mod inference supply defaults)")
+	force := fs.Bool("force", false, "Overwrite an existing identity file")
+	var opts appidentity.InitOptions
+	fs.StringVar(&opts.BinaryName, "binary", "", "Binary name (default: inferred from go.mod)")
+	fs.StringVar(&opts.Vendor, "vendor", "", "Vendor namespace (default: inferred from go.mod)")
+	fs.StringVar(&opts.EnvPrefix, "env-prefix", "", "Environment variable prefix (default: BINARY_)")
+	fs.StringVar(&opts.ConfigName, "config-name", "", "Config directory name (default: binary name)")
+	fs.StringVar(&opts.Description, "description", "", "One-line description (10-200 characters)")
+	fs.StringVar(&opts.ProjectURL, "project-url", "", "Project URL (default: inferred from go.mod)")
+	fs.StringVar(&opts.License, "license", "", "SPDX license identifier")
+	fs.StringVar(&opts.RepositoryCategory, "category", "", "Repository category (cli, workhorse, service, library, ...)")
+	return func(_ context.Context, c *cli, _ []string) error {
+		return runIdentityInit(*path, *interactive, *force, opts, c.stdin, c.stdout)
+	}
+}
+
+func runIdentityInit(path string, interactive, force bool, opts appidentity.InitOptions, in io.Reader, out io.Writer) error {
+	opts.Overwrite = force
+
+	if interactive {
+		repoRoot := filepath.Dir(path)
+		if filepath.Base(repoRoot) == appidentity.DefaultIdentityDir {
+			repoRoot = filepath.Dir(repoRoot)
+		}
+		if err := promptInitOptions(&opts, appidentity.InferInitOptions(repoRoot), in, out); err != nil {
+			return err
+		}
+	}
+
+	identity, err := appidentity.Init(path, opts)
+	if err != nil {
+		var valErr *appidentity.ValidationError
+		if errors.As(err, &valErr) {
+			return withExitCode(foundry.ExitInvalidArgument, fmt.Errorf("%w\nSet the missing values with flags (see gofulmen identity init -h)", err))
+		}
+		return err
+	}
+	_, err = fmt.Fprintf(out, "Created %s for %s/%s\n", path, identity.Vendor, identity.BinaryName)
+	return err
+}
+
+// promptInitOptions asks for each identity value, offering flag values or
+// inferred values as defaults.
+func promptInitOptions(opts *appidentity.InitOptions, inferred appidentity.InitOptions, in io.Reader, out io.Writer) error {
+	reader := bufio.NewReader(in)
+	ask := func(label string, value *string, fallback string) error {
+		def := *value
+		if def == "" {
+			def = fallback
+		}
+		if _, err := fmt.Fprintf(out, "%s [%s]: ", label, def); err != nil {
+			return err
+		}
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			def = line
+		}
+		*value = def
+		return nil
+	}
+
+	if err := ask("Binary name", &opts.BinaryName, inferred.BinaryName); err != nil {
+		return err
+	}
+	if err := ask("Vendor", &opts.Vendor, inferred.Vendor); err != nil {
+		return err
+	}
+
+	// Derived defaults follow the (possibly edited) binary name, as in appidentity.Init
+	envPrefix := strings.ToUpper(strings.ReplaceAll(opts.BinaryName, "-", "_")) + "_"
+	prompts := []struct {
+		label    string
+		value    *string
+		fallback string
+	}{
+		{"Environment prefix", &opts.EnvPrefix, envPrefix},
+		{"Config name", &opts.ConfigName, opts.BinaryName},
+		{"Description", &opts.Description, opts.BinaryName + " application"},
+		{"Project URL", &opts.ProjectURL, inferred.ProjectURL},
+		{"License", &opts.License, ""},
+		{"Repository category", &opts.RepositoryCategory, ""},
+	}
+	for _, p := range prompts {
+		if err := ask(p.label, p.value, p.fallback); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/appidentity"
+	"github.com/fulmenhq/gofulmen/foundry"
+)
+
+func TestIdentityInitFlags(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".fulmen", "app.yaml")
+
+	code, out, stderr := runCLI("", "identity", "init", "--path", path, "--binary", "mytool", "--vendor", "acme", "--license", "MIT")
+	if code != foundry.ExitSuccess {
+		t.Fatalf("identity init failed (%d): %s", code, stderr)
+	}
+	if !strings.Contains(out, "Created") {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	identity, err := appidentity.LoadFrom(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.EnvPrefix != "MYTOOL_" || identity.Metadata.License != "MIT" {
+		t.Errorf("identity = %+v", identity)
+	}
+
+	// Existing files are kept unless --force is given
+	if code, _, _ := runCLI("", "identity", "init", "--path", path, "--binary", "mytool", "--vendor", "acme"); code == foundry.ExitSuccess {
+		t.Error("expected error for existing identity file")
+	}
+	if code, _, stderr := runCLI("", "identity", "init", "--path", path, "--binary", "mytool", "--vendor", "acme", "--force"); code != foundry.ExitSuccess {
+		t.Errorf("--force failed: %s", stderr)
+	}
+}
+
+func TestIdentityInitInteractive(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/acme/widget\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, ".fulmen", "app.yaml")
+
+	// Accept inferred binary and vendor, override the description, keep the rest
+	answers := "\n\n\n\nWidget service for acme tests\n\nApache-2.0\nservice\n"
+	code, out, stderr := runCLI(answers, "identity", "init", "--path", path, "--interactive")
+	if code != foundry.ExitSuccess {
+		t.Fatalf("identity init failed (%d): %s\n%s", code, stderr, out)
+	}
+	if !strings.Contains(out, "Binary name [widget]") || !strings.Contains(out, "Environment prefix [WIDGET_]") {
+		t.Errorf("prompts did not show inferred defaults:\n%s", out)
+	}
+
+	identity, err := appidentity.LoadFrom(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Vendor != "acme" || identity.Description != "Widget service for acme tests" ||
+		identity.Metadata.License != "Apache-2.0" || identity.Metadata.RepositoryCategory != "service" ||
+		identity.Metadata.ProjectURL != "https://github.com/acme/widget" {
+		t.Errorf("identity = %+v, metadata = %+v", identity, identity.Metadata)
+	}
+}
+
+func TestIdentityUsage(t *testing.T) {
+	if code, _, _ := runCLI("", "identity"); code != foundry.ExitUsage {
+		t.Errorf("expected usage exit code without subcommand, got %d", code)
+	}
+}
@@ -0,0 +1,66 @@
+// Command gofulmen bundles the gofulmen tools in one multi-command CLI:
+// schema validation and export, tool bootstrap, document, path, and archive
+// operations, identity scaffolding, terminal diagnostics, and a JSON-RPC
+// server. Every command accepts --format, --verbose, and --correlation-id
+// and exits with foundry exit codes.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+)
+
+func main() {
+	// Keep stdout for command output only; telemetry emitted by library
+	// calls goes to stderr.
+	telemetry.SetDefaultOutput(os.Stderr)
+	os.Exit(newCLI(os.Stdin, os.Stdout, os.Stderr).run(os.Args[1:]))
+}
+
+// rootCommand returns the gofulmen command tree.
+func rootCommand() *command {
+	return &command{
+		summary: "Fulmen helper library tools",
+		subcommands: []*command{
+			bootstrapCommand(),
+			completionCommand(),
+			docscribeCommand(),
+			exportCommand(),
+			fulpackCommand(),
+			identityCommand(),
+			pathfindCommand(),
+			schemaCommand(),
+			serveCommand(),
+			terminalCommand(),
+			completeCommand(),
+			manifestCommand(),
+		},
+	}
+}
+
+func serveCommand() *command {
+	return &command{
+		name:    "serve",
+		summary: `Serve docscribe, schema, pathfinder, and similarity operations as JSON-RPC 2.0 (call "rpc.methods" to list them)`,
+		setup: func(fs *flag.FlagSet) runFunc {
+			stdio := fs.Bool("stdio", false, "Serve JSON-RPC 2.0 over stdin/stdout (one message per line)")
+			return func(ctx context.Context, c *cli, _ []string) error {
+				if !*stdio {
+					return usageErrorf("serve requires --stdio (the only supported transport)")
+				}
+				return runServe(ctx, c)
+			}
+		},
+	}
+}
+
+func runServe(ctx context.Context, c *cli) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return newServer().serve(ctx, c.stdin, c.stdout)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// call sends requests (one per line) through the server and decodes responses.
+func call(t *testing.T, requests ...string) []map[string]any {
+	t.Helper()
+	var out bytes.Buffer
+	if err := newServer().serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("serve failed: %v", err)
+	}
+
+	var responses []map[string]any
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var resp map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid response %q: %v", scanner.Text(), err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func errorCode(resp map[string]any) float64 {
+	if e, ok := resp["error"].(map[string]any); ok {
+		return e["code"].(float64)
+	}
+	return 0
+}
+
+func TestServeProtocol(t *testing.T) {
+	responses := call(t,
+		`{"jsonrpc":"2.0","id":1,"method":"rpc.methods"}`,
+		`not json`,
+		`{"jsonrpc":"1.0","id":2,"method":"rpc.methods"}`,
+		`{"jsonrpc":"2.0","id":"x","method":"nope"}`,
+		`{"jsonrpc":"2.0","method":"rpc.methods"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"similarity.score"}`,
+	)
+	if len(responses) != 5 {
+		t.Fatalf("expected 5 responses (notification gets none), got %d: %v", len(responses), responses)
+	}
+
+	methods, _ := responses[0]["result"].([]any)
+	if len(methods) == 0 || responses[0]["id"].(float64) != 1 {
+		t.Errorf("unexpected rpc.methods response %v", responses[0])
+	}
+	wantCodes := []float64{codeParseError, codeInvalidRequest, codeMethodNotFound, codeInvalidParams}
+	for i, want := range wantCodes {
+		if got := errorCode(responses[i+1]); got != want {
+			t.Errorf("response %d: expected error code %v, got %v", i+1, want, responses[i+1])
+		}
+	}
+	if responses[3]["id"] != "x" {
+		t.Errorf("expected string id to be echoed, got %v", responses[3]["id"])
+	}
+}
+
+func TestServeBatch(t *testing.T) {
+	var out bytes.Buffer
+	input := strings.Join([]string{
+		`[{"jsonrpc":"2.0","id":1,"method":"similarity.score","params":{"a":"a","b":"a"}},{"jsonrpc":"2.0","method":"rpc.methods"},1,{"jsonrpc":"2.0","id":2,"method":"nope"}]`,
+		`[{"jsonrpc":"2.0","method":"rpc.methods"}]`,
+		`[]`,
+		`[{"jsonrpc":"2.0","id":1`,
+	}, "\n") + "\n"
+	if err := newServer().serve(context.Background(), strings.NewReader(input), &out); err != nil {
+		t.Fatalf("serve failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 response lines (notification-only batch gets none), got %d: %s", len(lines), out.String())
+	}
+
+	var batch []map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &batch); err != nil {
+		t.Fatalf("expected batch response array, got %s: %v", lines[0], err)
+	}
+	if len(batch) != 3 {
+		t.Fatalf("expected 3 batch responses (notification gets none), got %v", batch)
+	}
+	if batch[0]["id"].(float64) != 1 || batch[0]["result"] == nil {
+		t.Errorf("unexpected first batch response %v", batch[0])
+	}
+	if errorCode(batch[1]) != codeInvalidRequest || errorCode(batch[2]) != codeMethodNotFound {
+		t.Errorf("expected invalid request and method not found, got %v", batch[1:])
+	}
+
+	for i, want := range []float64{codeInvalidRequest, codeParseError} {
+		var resp map[string]any
+		if err := json.Unmarshal([]byte(lines[i+1]), &resp); err != nil || errorCode(resp) != want {
+			t.Errorf("line %d: expected single error response with code %v, got %s", i+2, want, lines[i+1])
+		}
+	}
+}
+
+func TestServeOperations(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	findParams, _ := json.Marshal(map[string]any{"root": dir, "include": []string{"*.go"}})
+
+	responses := call(t,
+		`{"jsonrpc":"2.0","id":1,"method":"docscribe.parseFrontmatter","params":{"content":"---\ntitle: Hello\n---\n# Body\n"}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"docscribe.extractHeaders","params":{"content":"# One\n## Two\n"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"schema.validate","params":{"schemaId":"pathfinder/v1.0.0/path-result","data":{"relativePath":"a"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"pathfinder.find","params":`+string(findParams)+`}`,
+		`{"jsonrpc":"2.0","id":5,"method":"similarity.suggest","params":{"input":"docscrib","candidates":["docscribe","foundry"]}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"schema.validate","params":{"schemaId":"pathfinder/v1.0.0/path-result"}}`,
+	)
+	if len(responses) != 6 {
+		t.Fatalf("expected 6 responses, got %d", len(responses))
+	}
+	for i, resp := range responses[:5] {
+		if resp["error"] != nil {
+			t.Fatalf("response %d returned error %v", i+1, resp["error"])
+		}
+	}
+
+	frontmatter := responses[0]["result"].(map[string]any)
+	if frontmatter["metadata"].(map[string]any)["title"] != "Hello" {
+		t.Errorf("unexpected frontmatter result %v", frontmatter)
+	}
+	if headers := responses[1]["result"].([]any); len(headers) != 2 {
+		t.Errorf("expected 2 headers, got %v", headers)
+	}
+	validation := responses[2]["result"].(map[string]any)
+	if validation["valid"] != false || len(validation["diagnostics"].([]any)) == 0 {
+		t.Errorf("expected diagnostics for incomplete path result, got %v", validation)
+	}
+	if found := responses[3]["result"].([]any); len(found) != 1 {
+		t.Errorf("expected 1 file, got %v", found)
+	}
+	suggestions := responses[4]["result"].([]any)
+	if len(suggestions) == 0 || suggestions[0].(map[string]any)["value"] != "docscribe" {
+		t.Errorf("unexpected suggestions %v", suggestions)
+	}
+	if errorCode(responses[5]) != codeInvalidParams {
+		t.Errorf("expected invalid params without data or path, got %v", responses[5])
+	}
+}
+
+func TestServeStdioCommand(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping CLI integration test in short mode")
+	}
+
+	cmd := exec.Command("go", "run", ".", "serve", "--stdio")
+	cmd.Stdin = strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"similarity.score","params":{"a":"kitten","b":"sitting"}}` + "\n")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("serve command failed: %v (stderr=%s)", err, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `"result":{"score":`) {
+		t.Fatalf("unexpected stdout %s", stdout.String())
+	}
+}
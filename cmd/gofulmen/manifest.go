@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+// manifestVersion is the version of the command manifest format.
+const manifestVersion = "1.0.0"
+
+//go:embed command-manifest.schema.json
+var commandManifestSchema []byte
+
+var (
+	manifestValidator     *schema.Validator
+	manifestValidatorErr  error
+	manifestValidatorOnce sync.Once
+)
+
+// cliManifest is a machine-readable description of the gofulmen command
+// tree, for wrappers, completion generators, and documentation tooling.
+type cliManifest struct {
+	ManifestVersion string            `json:"manifestVersion"`
+	Name            string            `json:"name"`
+	Version         string            `json:"version"`
+	Summary         string            `json:"summary"`
+	GlobalFlags     []flagManifest    `json:"globalFlags"` // Accepted before the command name and by every leaf command
+	Commands        []commandManifest `json:"commands"`
+}
+
+// commandManifest describes one command. Group commands list subcommands;
+// leaf commands list their own flags (global flags are not repeated).
+type commandManifest struct {
+	Name     string            `json:"name"`
+	Path     string            `json:"path"` // Full invocation, e.g. "gofulmen schema validate"
+	Summary  string            `json:"summary"`
+	Aliases  []string          `json:"aliases,omitempty"`
+	Args     string            `json:"args,omitempty"`
+	Flags    []flagManifest    `json:"flags,omitempty"`
+	Commands []commandManifest `json:"commands,omitempty"`
+}
+
+// flagManifest describes one command-line flag.
+type flagManifest struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"` // bool, int, float, duration, or string
+	Default string `json:"default"`
+	Usage   string `json:"usage"`
+}
+
+// buildManifest describes the command tree rooted at root. Hidden commands
+// are omitted.
+func buildManifest(root *command) cliManifest {
+	globals := globalOptions{format: formatText}
+	fs := flag.NewFlagSet("gofulmen", flag.ContinueOnError)
+	globals.register(fs)
+
+	return cliManifest{
+		ManifestVersion: manifestVersion,
+		Name:            "gofulmen",
+		Version:         foundry.GofulmenVersion(),
+		Summary:         root.summary,
+		GlobalFlags:     describeFlags(fs),
+		Commands:        describeCommands(nil, root),
+	}
+}
+
+func describeCommands(path []string, cmd *command) []commandManifest {
+	var out []commandManifest
+	for _, sub := range cmd.subcommands {
+		if sub.hidden {
+			continue
+		}
+		subPath := append(append([]string(nil), path...), sub.name)
+		entry := commandManifest{
+			Name:    sub.name,
+			Path:    commandName(subPath),
+			Summary: sub.summary,
+			Aliases: sub.aliases,
+			Args:    sub.args,
+		}
+		if len(sub.subcommands) > 0 {
+			entry.Commands = describeCommands(subPath, sub)
+		} else {
+			fs := flag.NewFlagSet(entry.Path, flag.ContinueOnError)
+			sub.setup(fs)
+			entry.Flags = describeFlags(fs)
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// describeFlags lists the flags registered on fs in lexical order.
+func describeFlags(fs *flag.FlagSet) []flagManifest {
+	flags := []flagManifest{}
+	fs.VisitAll(func(f *flag.Flag) {
+		flags = append(flags, flagManifest{Name: f.Name, Type: flagType(f), Default: f.DefValue, Usage: f.Usage})
+	})
+	return flags
+}
+
+// flagType reports the value type of f.
+func flagType(f *flag.Flag) string {
+	if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		return "bool"
+	}
+	if g, ok := f.Value.(flag.Getter); ok {
+		switch g.Get().(type) {
+		case int, int64, uint, uint64:
+			return "int"
+		case float64:
+			return "float"
+		case time.Duration:
+			return "duration"
+		}
+	}
+	return "string"
+}
+
+// validateManifest checks the encoded manifest against the embedded
+// command-manifest schema.
+func validateManifest(data []byte) error {
+	manifestValidatorOnce.Do(func() {
+		manifestValidator, manifestValidatorErr = schema.NewValidator(commandManifestSchema)
+	})
+	if manifestValidatorErr != nil {
+		return fmt.Errorf("failed to initialize command manifest validator: %w", manifestValidatorErr)
+	}
+	diags, err := manifestValidator.ValidateJSON(data)
+	if err != nil {
+		return fmt.Errorf("command manifest validation failed: %w", err)
+	}
+	if verrs := schema.DiagnosticsToValidationErrors(diags); len(verrs) > 0 {
+		return verrs
+	}
+	return nil
+}
+
+func manifestCommand() *command {
+	return &command{
+		name:    "__manifest",
+		summary: "Print the command manifest as JSON (for wrappers and docs generation)",
+		hidden:  true,
+		setup: func(*flag.FlagSet) runFunc {
+			return func(_ context.Context, c *cli, args []string) error {
+				if len(args) != 0 {
+					return usageErrorf("__manifest takes no arguments")
+				}
+				data, err := json.MarshalIndent(buildManifest(c.root), "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := validateManifest(data); err != nil {
+					return err
+				}
+				_, err = fmt.Fprintf(c.stdout, "%s\n", data)
+				return err
+			}
+		},
+	}
+}
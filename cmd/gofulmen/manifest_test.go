@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+)
+
+func TestManifest(t *testing.T) {
+	code, stdout, stderr := runCLI("", "__manifest")
+	if code != foundry.ExitSuccess {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if err := validateManifest([]byte(stdout)); err != nil {
+		t.Fatalf("manifest does not match schema: %v", err)
+	}
+
+	var manifest cliManifest
+	if err := json.Unmarshal([]byte(stdout), &manifest); err != nil {
+		t.Fatalf("invalid manifest JSON: %v", err)
+	}
+	if manifest.Version != foundry.GofulmenVersion() || len(manifest.GlobalFlags) != 3 {
+		t.Errorf("unexpected manifest header: version %q, %d global flags", manifest.Version, len(manifest.GlobalFlags))
+	}
+
+	byPath := make(map[string]commandManifest)
+	var walk func([]commandManifest)
+	walk = func(commands []commandManifest) {
+		for _, cmd := range commands {
+			byPath[cmd.Path] = cmd
+			walk(cmd.Commands)
+		}
+	}
+	walk(manifest.Commands)
+
+	for _, hidden := range []string{"gofulmen __manifest", "gofulmen __complete"} {
+		if _, ok := byPath[hidden]; ok {
+			t.Errorf("hidden command %q listed in manifest", hidden)
+		}
+	}
+	if status := byPath["gofulmen bootstrap status"]; len(status.Aliases) != 1 || status.Aliases[0] != "doctor" {
+		t.Errorf("expected doctor alias for bootstrap status, got %+v", status)
+	}
+
+	flags := make(map[string]flagManifest)
+	for _, f := range byPath["gofulmen fulpack create"].Flags {
+		flags[f.Name] = f
+	}
+	if f := flags["level"]; f.Type != "int" || f.Default != "0" {
+		t.Errorf("unexpected --level flag %+v", f)
+	}
+	if f := byPath["gofulmen serve"].Flags; len(f) != 1 || f[0].Name != "stdio" || f[0].Type != "bool" {
+		t.Errorf("unexpected serve flags %+v", f)
+	}
+}
+
+func TestValidateManifestRejectsInvalid(t *testing.T) {
+	if err := validateManifest([]byte(`{"manifestVersion":"1.0.0","name":"gofulmen"}`)); err == nil {
+		t.Error("expected validation error for manifest missing required fields")
+	}
+}
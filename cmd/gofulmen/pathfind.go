@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/pathfinder"
+)
+
+func pathfindCommand() *command {
+	return &command{
+		name:    "pathfind",
+		summary: "Find files under a root with glob include/exclude patterns",
+		args:    "[root]",
+		setup:   pathfindFlags,
+	}
+}
+
+func pathfindFlags(fs *flag.FlagSet) runFunc {
+	include := fs.String("include", "**/*", "Comma-separated glob patterns to include")
+	exclude := fs.String("exclude", "", "Comma-separated glob patterns to exclude")
+	maxDepth := fs.Int("max-depth", 0, "Maximum directory depth (0 = unlimited)")
+	hidden := fs.Bool("hidden", false, "Include hidden files and directories")
+	followSymlinks := fs.Bool("follow-symlinks", false, "Follow symbolic links")
+	checksums := fs.Bool("checksums", false, "Calculate file checksums (reported with --format json)")
+	return func(ctx context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		root := "."
+		switch len(args) {
+		case 0:
+		case 1:
+			root = args[0]
+		default:
+			return usageErrorf("provide at most one root directory")
+		}
+		if _, err := os.Stat(root); err != nil {
+			return withExitCode(foundry.ExitDirectoryNotFound, err)
+		}
+
+		query := pathfinder.FindQuery{
+			Root:               root,
+			Include:            splitPatterns(*include),
+			Exclude:            splitPatterns(*exclude),
+			MaxDepth:           *maxDepth,
+			IncludeHidden:      *hidden,
+			FollowSymlinks:     *followSymlinks,
+			CalculateChecksums: *checksums,
+		}
+		results, err := pathfinder.NewFinder().FindFilesWithEnvelope(ctx, query, c.globals.correlationID)
+		if err != nil {
+			return err
+		}
+		if c.jsonOutput() {
+			if results == nil {
+				results = []pathfinder.PathResult{}
+			}
+			return c.printJSON(results)
+		}
+		for _, r := range results {
+			fmt.Fprintln(c.stdout, r.RelativePath)
+		}
+		return nil
+	}
+}
+
+// splitPatterns splits a comma-separated pattern list, dropping empty items.
+func splitPatterns(s string) []string {
+	var patterns []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
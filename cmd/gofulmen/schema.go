@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+const formatSARIF = "sarif"
+
+func schemaCommand() *command {
+	return &command{
+		name:    "schema",
+		summary: "Validate data and schemas against the Crucible catalog",
+		subcommands: []*command{
+			{
+				name:    "validate",
+				summary: "Validate a JSON/YAML data file against a catalog schema",
+				args:    "<data-file>",
+				setup:   schemaValidateFlags,
+			},
+			{
+				name:    "validate-schema",
+				summary: "Validate a schema definition using the embedded metaschemas",
+				args:    "<schema-file>",
+				setup:   schemaValidateSchemaFlags,
+			},
+			{
+				name:    "list",
+				summary: "List catalog schema IDs",
+				setup:   schemaListFlags,
+			},
+		},
+	}
+}
+
+func schemaValidateFlags(fs *flag.FlagSet) runFunc {
+	schemaID := fs.String("schema-id", "", "Catalog schema identifier (e.g., pathfinder/v1.0.0/path-result)")
+	strictYAML := fs.Bool("strict-yaml", false, "Report duplicate keys, tab indentation, anchors, ambiguous scalars, tags, and non-string keys in YAML input")
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(formatSARIF); err != nil {
+			return err
+		}
+		if *schemaID == "" {
+			return withExitCode(foundry.ExitMissingRequiredArgument, fmt.Errorf("--schema-id is required"))
+		}
+		if len(args) != 1 {
+			return usageErrorf("provide exactly one data file")
+		}
+		dataPath := args[0]
+
+		catalog := schema.DefaultCatalog()
+		if *strictYAML {
+			catalog = catalog.WithCompileOptions(&schema.CompileOptions{StrictYAML: &schema.YAMLOptions{}})
+		}
+		diags, err := catalog.ValidateFileByID(*schemaID, dataPath)
+		if err != nil {
+			return withExitCode(foundry.ExitConfigInvalid, fmt.Errorf("validation failed: %w", err))
+		}
+
+		switch c.globals.format {
+		case formatSARIF:
+			content, err := os.ReadFile(dataPath) // #nosec G304 -- User-provided path is intentional for CLI tool
+			if err != nil {
+				return withExitCode(foundry.ExitFileReadError, fmt.Errorf("read data: %w", err))
+			}
+			renderer, err := schema.NewRenderer(dataPath, content)
+			if err != nil {
+				return err
+			}
+			if err := renderer.Render(c.stdout, diags, schema.RenderSARIF); err != nil {
+				return err
+			}
+		case formatJSON:
+			if diags == nil {
+				diags = []schema.Diagnostic{}
+			}
+			if err := c.printJSON(map[string]any{
+				"file":        dataPath,
+				"schema_id":   *schemaID,
+				"valid":       len(diags) == 0,
+				"diagnostics": diags,
+			}); err != nil {
+				return err
+			}
+		default:
+			if len(diags) == 0 {
+				fmt.Fprintf(c.stdout, "✅ %s valid against %s\n", dataPath, *schemaID)
+			} else {
+				fmt.Fprintf(c.stdout, "❌ %s invalid against %s\n", dataPath, *schemaID)
+				for _, d := range diags {
+					if d.Location != nil {
+						fmt.Fprintf(c.stdout, "  - %s:%d:%d %s (%s): %s\n", dataPath, d.Location.Line, d.Location.Column, d.Pointer, d.Keyword, d.Message)
+						continue
+					}
+					fmt.Fprintf(c.stdout, "  - %s (%s): %s\n", d.Pointer, d.Keyword, d.Message)
+				}
+			}
+		}
+		if len(diags) > 0 {
+			return withExitCode(foundry.ExitDataInvalid, fmt.Errorf("%s is invalid against %s", dataPath, *schemaID))
+		}
+		return nil
+	}
+}
+
+func schemaValidateSchemaFlags(fs *flag.FlagSet) runFunc {
+	return func(_ context.Context, c *cli, args []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		if len(args) != 1 {
+			return usageErrorf("provide exactly one schema file")
+		}
+		path := args[0]
+
+		content, err := os.ReadFile(path) // #nosec G304 -- User-provided path is intentional for CLI tool
+		if err != nil {
+			return withExitCode(foundry.ExitFileReadError, fmt.Errorf("read schema: %w", err))
+		}
+		diags, err := schema.ValidateSchemaBytes(content)
+		if err != nil {
+			return withExitCode(foundry.ExitParseError, fmt.Errorf("schema compilation failed: %w", err))
+		}
+
+		if c.jsonOutput() {
+			if diags == nil {
+				diags = []schema.Diagnostic{}
+			}
+			if err := c.printJSON(map[string]any{
+				"file":        filepath.Clean(path),
+				"valid":       len(diags) == 0,
+				"diagnostics": diags,
+			}); err != nil {
+				return err
+			}
+		} else if len(diags) == 0 {
+			fmt.Fprintf(c.stdout, "✅ %s schema is valid\n", path)
+		} else {
+			fmt.Fprintf(c.stdout, "❌ %s schema has issues\n", path)
+			for _, d := range diags {
+				fmt.Fprintf(c.stdout, "  - %s (%s): %s\n", d.Pointer, d.Keyword, d.Message)
+			}
+		}
+		if len(diags) > 0 {
+			return withExitCode(foundry.ExitDataInvalid, fmt.Errorf("%s schema has issues", path))
+		}
+		return nil
+	}
+}
+
+func schemaListFlags(fs *flag.FlagSet) runFunc {
+	prefix := fs.String("prefix", "", "Only list schemas whose ID starts with prefix (e.g., observability/logging)")
+	return func(_ context.Context, c *cli, _ []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		descriptors, err := schema.DefaultCatalog().ListSchemas(*prefix)
+		if err != nil {
+			return withExitCode(foundry.ExitConfigInvalid, err)
+		}
+		ids := make([]string, 0, len(descriptors))
+		for _, d := range descriptors {
+			ids = append(ids, d.ID)
+		}
+		if c.jsonOutput() {
+			return c.printJSON(ids)
+		}
+		for _, id := range ids {
+			fmt.Fprintln(c.stdout, id)
+		}
+		return nil
+	}
+}
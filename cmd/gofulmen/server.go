@@ -0,0 +1,376 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/fulmenhq/gofulmen/docscribe"
+	"github.com/fulmenhq/gofulmen/foundry/similarity"
+	"github.com/fulmenhq/gofulmen/pathfinder"
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+// JSON-RPC 2.0 error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// maxMessageSize bounds a single request line.
+const maxMessageSize = 16 << 20
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+func invalidParams(format string, args ...any) error {
+	return &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+type handlerFunc func(ctx context.Context, params json.RawMessage) (any, error)
+
+// server dispatches newline-delimited JSON-RPC 2.0 requests to library calls.
+type server struct {
+	handlers map[string]handlerFunc
+	finder   *pathfinder.Finder
+}
+
+func newServer() *server {
+	s := &server{finder: pathfinder.NewFinder()}
+	s.handlers = map[string]handlerFunc{
+		"rpc.methods":                s.methods,
+		"docscribe.inspect":          docscribeInspect,
+		"docscribe.parseFrontmatter": docscribeParseFrontmatter,
+		"docscribe.extractHeaders":   docscribeExtractHeaders,
+		"docscribe.splitDocuments":   docscribeSplitDocuments,
+		"schema.validate":            schemaValidate,
+		"schema.list":                schemaList,
+		"pathfinder.find":            s.pathfinderFind,
+		"similarity.score":           similarityScore,
+		"similarity.suggest":         similaritySuggest,
+	}
+	return s
+}
+
+// serve reads one message per line from r and writes responses to w until r
+// is exhausted or ctx is cancelled. A message is a request or a batch (array)
+// of requests; requests are handled sequentially.
+func (s *server) serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var resp any
+		var notify bool
+		if line[0] == '[' {
+			resp, notify = s.handleBatch(ctx, line)
+		} else {
+			resp, notify = s.handle(ctx, line)
+		}
+		if notify {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			return fmt.Errorf("write response: %w", err)
+		}
+	}
+	return scanner.Err()
+}
+
+// handleBatch processes a batch message. Responses to its requests are
+// returned as an array in request order; an empty or unparsable batch gets a
+// single error response, and a batch of only notifications gets none.
+func (s *server) handleBatch(ctx context.Context, line []byte) (resp any, notify bool) {
+	var messages []json.RawMessage
+	if err := json.Unmarshal(line, &messages); err != nil {
+		return rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: &rpcError{Code: codeParseError, Message: "parse error: " + err.Error()}}, false
+	}
+	if len(messages) == 0 {
+		return rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: &rpcError{Code: codeInvalidRequest, Message: "invalid request: empty batch"}}, false
+	}
+
+	var responses []rpcResponse
+	for _, message := range messages {
+		if resp, notify := s.handle(ctx, message); !notify {
+			responses = append(responses, resp)
+		}
+	}
+	return responses, len(responses) == 0
+}
+
+// handle processes one request. notify is true for notifications (no id),
+// which receive no response.
+func (s *server) handle(ctx context.Context, line []byte) (resp rpcResponse, notify bool) {
+	resp = rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null")}
+
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		if json.Valid(line) {
+			// Well-formed JSON that is not a request object (e.g. a batch element of 1)
+			resp.Error = &rpcError{Code: codeInvalidRequest, Message: "invalid request: " + err.Error()}
+		} else {
+			resp.Error = &rpcError{Code: codeParseError, Message: "parse error: " + err.Error()}
+		}
+		return resp, false
+	}
+	if len(req.ID) > 0 {
+		resp.ID = req.ID
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{Code: codeInvalidRequest, Message: `invalid request: jsonrpc must be "2.0" and method is required`}
+		return resp, false
+	}
+
+	handler, ok := s.handlers[req.Method]
+	if !ok {
+		resp.Error = &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+		return resp, len(req.ID) == 0
+	}
+
+	result, err := handler(ctx, req.Params)
+	if err != nil {
+		var rpcErr *rpcError
+		if errors.As(err, &rpcErr) {
+			resp.Error = rpcErr
+		} else {
+			resp.Error = &rpcError{Code: codeInternalError, Message: err.Error()}
+		}
+	} else {
+		resp.Result = result
+	}
+	return resp, len(req.ID) == 0
+}
+
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return invalidParams("params are required")
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return invalidParams("invalid params: %v", err)
+	}
+	return nil
+}
+
+func (s *server) methods(context.Context, json.RawMessage) (any, error) {
+	names := make([]string, 0, len(s.handlers))
+	for name := range s.handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+type contentParams struct {
+	Content string `json:"content"`
+}
+
+func docscribeInspect(_ context.Context, params json.RawMessage) (any, error) {
+	var p contentParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	return docscribe.InspectDocument([]byte(p.Content))
+}
+
+func docscribeParseFrontmatter(_ context.Context, params json.RawMessage) (any, error) {
+	var p contentParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	body, metadata, err := docscribe.ParseFrontmatter([]byte(p.Content))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"body": body, "metadata": metadata}, nil
+}
+
+func docscribeExtractHeaders(_ context.Context, params json.RawMessage) (any, error) {
+	var p contentParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	headers, err := docscribe.ExtractHeaders([]byte(p.Content))
+	if err != nil {
+		return nil, err
+	}
+	if headers == nil {
+		headers = []docscribe.Header{}
+	}
+	return headers, nil
+}
+
+func docscribeSplitDocuments(_ context.Context, params json.RawMessage) (any, error) {
+	var p contentParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	return docscribe.SplitDocuments([]byte(p.Content))
+}
+
+type schemaValidateParams struct {
+	SchemaID string          `json:"schemaId"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	Path     string          `json:"path,omitempty"`
+}
+
+// schemaValidate validates inline JSON data or a JSON/YAML file against a catalog schema.
+func schemaValidate(_ context.Context, params json.RawMessage) (any, error) {
+	var p schemaValidateParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.SchemaID == "" {
+		return nil, invalidParams("schemaId is required")
+	}
+	if (len(p.Data) == 0) == (p.Path == "") {
+		return nil, invalidParams("provide exactly one of data or path")
+	}
+
+	var diags []schema.Diagnostic
+	var err error
+	if p.Path != "" {
+		diags, err = schema.ValidateFileByID(p.SchemaID, p.Path)
+	} else {
+		diags, err = schema.ValidateDataByID(p.SchemaID, p.Data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if diags == nil {
+		diags = []schema.Diagnostic{}
+	}
+	return map[string]any{"valid": len(diags) == 0, "diagnostics": diags}, nil
+}
+
+type schemaListParams struct {
+	Prefix string `json:"prefix,omitempty"`
+}
+
+func schemaList(_ context.Context, params json.RawMessage) (any, error) {
+	var p schemaListParams
+	if len(params) > 0 {
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+	}
+	descriptors, err := schema.DefaultCatalog().ListSchemas(p.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(descriptors))
+	for _, d := range descriptors {
+		ids = append(ids, d.ID)
+	}
+	return ids, nil
+}
+
+func (s *server) pathfinderFind(ctx context.Context, params json.RawMessage) (any, error) {
+	var query pathfinder.FindQuery
+	if err := decodeParams(params, &query); err != nil {
+		return nil, err
+	}
+	if query.Root == "" {
+		return nil, invalidParams("root is required")
+	}
+	if _, err := os.Stat(query.Root); err != nil {
+		return nil, invalidParams("root %q: %v", query.Root, err)
+	}
+	results, err := s.finder.FindFiles(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = []pathfinder.PathResult{}
+	}
+	return results, nil
+}
+
+type similarityScoreParams struct {
+	A         string `json:"a"`
+	B         string `json:"b"`
+	Algorithm string `json:"algorithm,omitempty"`
+}
+
+func similarityScore(_ context.Context, params json.RawMessage) (any, error) {
+	var p similarityScoreParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Algorithm == "" {
+		return map[string]any{"score": similarity.Score(p.A, p.B)}, nil
+	}
+	score, err := similarity.ScoreWithAlgorithm(p.A, p.B, similarity.Algorithm(p.Algorithm), nil)
+	if err != nil {
+		return nil, invalidParams("%v", err)
+	}
+	return map[string]any{"score": score}, nil
+}
+
+type similaritySuggestParams struct {
+	Input          string   `json:"input"`
+	Candidates     []string `json:"candidates"`
+	MinScore       *float64 `json:"minScore,omitempty"`
+	MaxSuggestions *int     `json:"maxSuggestions,omitempty"`
+}
+
+type suggestionResult struct {
+	Value string  `json:"value"`
+	Score float64 `json:"score"`
+}
+
+func similaritySuggest(_ context.Context, params json.RawMessage) (any, error) {
+	var p similaritySuggestParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	opts := similarity.DefaultSuggestOptions()
+	if p.MinScore != nil {
+		opts.MinScore = *p.MinScore
+	}
+	if p.MaxSuggestions != nil {
+		opts.MaxSuggestions = *p.MaxSuggestions
+	}
+
+	results := []suggestionResult{}
+	for _, s := range similarity.Suggest(p.Input, p.Candidates, opts) {
+		results = append(results, suggestionResult{Value: s.Value, Score: s.Score})
+	}
+	return results, nil
+}
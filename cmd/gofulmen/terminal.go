@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/fulmenhq/gofulmen/ascii"
+)
+
+// terminalSampleEmojis are variation-selector emojis whose rendered width
+// differs between terminals.
+var terminalSampleEmojis = []string{
+	"⏱️", "☠️", "☹️", "⚠️", "✌️",
+	"🎗️", "🎟️", "🖐️", "🛠️", "ℹ️",
+}
+
+func terminalCommand() *command {
+	return &command{
+		name:    "terminal",
+		summary: "Show the detected terminal, its width overrides, and sample emoji widths",
+		setup:   terminalFlags,
+	}
+}
+
+func terminalFlags(*flag.FlagSet) runFunc {
+	return func(_ context.Context, c *cli, _ []string) error {
+		if err := c.requireFormat(); err != nil {
+			return err
+		}
+		config := ascii.GetTerminalConfig()
+		widths := make(map[string]int, len(terminalSampleEmojis))
+		for _, emoji := range terminalSampleEmojis {
+			widths[emoji] = ascii.StringWidth(emoji)
+		}
+
+		if c.jsonOutput() {
+			return c.printJSON(map[string]any{
+				"term_program": os.Getenv("TERM_PROGRAM"),
+				"terminal":     config,
+				"widths":       widths,
+			})
+		}
+
+		fmt.Fprintln(c.stdout, "TERM_PROGRAM:", os.Getenv("TERM_PROGRAM"))
+		if config != nil {
+			fmt.Fprintf(c.stdout, "Detected terminal: %s (%d overrides)\n\n", config.Name, len(config.Overrides))
+		} else {
+			fmt.Fprintf(c.stdout, "No terminal config detected (using defaults)\n\n")
+		}
+		for _, emoji := range terminalSampleEmojis {
+			line := fmt.Sprintf("%s Width: %d", emoji, widths[emoji])
+			fmt.Fprintf(c.stdout, "%s (line width: %d)\n", line, ascii.StringWidth(line))
+		}
+		fmt.Fprintln(c.stdout)
+		fmt.Fprintln(c.stdout, ascii.DrawBox("⚠️  Important Warning ☠️", 30))
+		return nil
+	}
+}
@@ -1,3 +1,6 @@
+// Command test-terminal prints terminal override and emoji width diagnostics.
+//
+// Deprecated: use "gofulmen terminal".
 package main
 
 import (
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/fulmenhq/gofulmen/appidentity"
 )
 
 func TestGetConfigPaths(t *testing.T) {
@@ -87,6 +89,11 @@ func TestGetAppConfigDir(t *testing.T) {
 	if !strings.Contains(dir, "testapp") {
 		t.Errorf("App config dir should contain app name, got: %s", dir)
 	}
+	// Same location as an identity without a vendor
+	identityDir, err := (&appidentity.Identity{ConfigName: "testapp"}).ConfigDir()
+	if err != nil || dir != identityDir {
+		t.Errorf("GetAppConfigDir() = %s, identity ConfigDir() = %s (%v)", dir, identityDir, err)
+	}
 	t.Logf("App config dir: %s", dir)
 }
 
@@ -108,7 +115,11 @@ func TestGetAppCacheDir(t *testing.T) {
 
 func TestGetFulmenConfigDir(t *testing.T) {
 	dir := GetFulmenConfigDir()
-	expected := filepath.Join(os.Getenv("HOME"), ".config", "fulmen")
+	base, err := appidentity.BaseDir(appidentity.DirConfig)
+	if err != nil {
+		t.Fatalf("BaseDir() error = %v", err)
+	}
+	expected := filepath.Join(base, "fulmen")
 	if dir != expected {
 		t.Errorf("Expected %s, got %s", expected, dir)
 	}
@@ -0,0 +1,515 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/appidentity"
+	"github.com/fulmenhq/gofulmen/errors"
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/schema"
+	"github.com/fulmenhq/gofulmen/signals"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvKeySeparator separates nested keys in environment variable names:
+// MYAPP_SERVER__MAX_CONNS sets server.max_conns.
+const EnvKeySeparator = "__"
+
+// defaultConfigFiles are looked up in the identity's config directory when
+// LoaderOptions.FileNames is empty.
+var defaultConfigFiles = []string{"config.yaml", "config.yml", "config.json"}
+
+// reservedEnvKeys are identity environment variables (see appidentity
+// Identity.Dir) that are not configuration values.
+var reservedEnvKeys = map[string]bool{
+	"CONFIG_DIR": true,
+	"CACHE_DIR":  true,
+	"DATA_DIR":   true,
+	"STATE_DIR":  true,
+}
+
+// LoaderOptions describes the layers merged by a Loader, lowest precedence
+// first: Defaults, a config file, environment variables, then flags.
+type LoaderOptions struct {
+	Identity  *appidentity.Identity // Application identity: config directory and env prefix (required)
+	Defaults  map[string]any        // Built-in defaults (lowest precedence)
+	File      string                // Explicit config file; must exist when set (e.g., from a --config flag)
+	FileNames []string              // Names looked up in the identity config dir when File is empty (default: config.yaml, config.yml, config.json)
+	Flags     *flag.FlagSet         // Flags set on the command line override all other layers; "server.port" sets server.port
+	SchemaID  string                // Optional catalog schema ID the merged configuration must satisfy
+	Catalog   *schema.Catalog       // Optional catalog to use for validation
+	Secrets   *SecretResolver       // Optional: resolves ${env:VAR}-style references in the merged configuration
+}
+
+// Loader loads layered configuration for an application and keeps the
+// merged result for typed access. It is safe for concurrent use; Reload
+// replaces the configuration atomically.
+//
+// Layers, lowest precedence first:
+//
+//  1. LoaderOptions.Defaults
+//  2. The config file: LoaderOptions.File, or the first of FileNames found in
+//     the identity's config directory (identity.ConfigDir())
+//  3. Environment variables starting with identity.EnvPrefix; the rest of the
+//     name is snake_cased and EnvKeySeparator nests keys (MYAPP_LOG__LEVEL
+//     sets log.level). Segments match existing keys by their snake_case
+//     form, so MYAPP_SERVER__MAX_CONNS overrides server.maxConns. Values are parsed as YAML scalars, so "8080" is an
+//     integer and "true" a boolean. <prefix>CONFIG_DIR and the other
+//     identity directory overrides are skipped.
+//  4. Flags explicitly set on LoaderOptions.Flags
+//
+// With LoaderOptions.Secrets, references in the merged configuration are
+// then resolved to Secret values, which All and Lookup return redacted when
+// printed; Get, Unmarshal, and schema validation see the plain values.
+type Loader struct {
+	opts LoaderOptions
+
+	mu       sync.RWMutex
+	data     map[string]any
+	file     string
+	onChange []func(map[string]any)
+}
+
+// NewLoader creates a Loader and loads the configuration.
+//
+// Example:
+//
+//	identity := appidentity.Must(ctx)
+//	loader, err := config.NewLoader(config.LoaderOptions{
+//	    Identity: identity,
+//	    Defaults: map[string]any{"server": map[string]any{"port": 8080}},
+//	    Flags:    flag.CommandLine,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	port, err := config.Get[int](loader, "server.port")
+//	loader.RegisterReload(nil) // SIGHUP reloads
+func NewLoader(opts LoaderOptions) (*Loader, error) {
+	if opts.Identity == nil {
+		envelope := errors.NewErrorEnvelope("CONFIG_LOAD_ERROR", "Configuration loading failed: identity is required")
+		envelope = errors.SafeWithSeverity(envelope, errors.SeverityHigh)
+		envelope = errors.SafeWithContext(envelope, map[string]interface{}{
+			"component":  "config",
+			"operation":  "new_loader",
+			"error_type": "missing_parameters",
+		})
+		return nil, envelope
+	}
+
+	l := &Loader{opts: opts}
+	if err := l.Reload(context.Background()); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Reload reloads every layer and replaces the configuration. If loading or
+// validation fails, the previous configuration is kept and the error is
+// returned. Functions registered with OnChange run after a successful reload.
+//
+// Reload is a signals.ReloadFunc; see RegisterReload.
+func (l *Loader) Reload(ctx context.Context) error {
+	start := time.Now()
+	status := metrics.StatusSuccess
+	category := l.opts.Identity.ConfigName
+	if category == "" {
+		category = l.opts.Identity.BinaryName
+	}
+	telSys := getTelemetrySystem()
+	defer func() {
+		if telSys != nil {
+			_ = telSys.Histogram(metrics.ConfigLoadMs, time.Since(start), map[string]string{
+				metrics.TagCategory: category,
+				metrics.TagStatus:   status,
+				metrics.TagVersion:  "unknown",
+			})
+		}
+	}()
+
+	data, file, err := l.load(ctx)
+	if err != nil {
+		status = metrics.StatusError
+		if telSys != nil {
+			errorCode := "CONFIG_LOAD_ERROR"
+			if envelope, ok := err.(*errors.ErrorEnvelope); ok {
+				errorCode = envelope.Code
+			}
+			_ = telSys.Counter(metrics.ConfigLoadErrors, 1, map[string]string{
+				"category":   category,
+				"error_code": errorCode,
+			})
+		}
+		return err
+	}
+
+	l.mu.Lock()
+	l.data = data
+	l.file = file
+	handlers := append([]func(map[string]any){}, l.onChange...)
+	l.mu.Unlock()
+
+	for _, fn := range handlers {
+		fn(deepCopyMap(data))
+	}
+	return nil
+}
+
+// RegisterReload registers Reload as a reload handler on m, or on the
+// default signals manager when m is nil, so SIGHUP reloads configuration.
+func (l *Loader) RegisterReload(m *signals.Manager) {
+	if m == nil {
+		signals.OnReload(l.Reload)
+		return
+	}
+	m.OnReload(l.Reload)
+}
+
+// OnChange registers fn to receive a copy of the configuration after each
+// successful Reload.
+func (l *Loader) OnChange(fn func(map[string]any)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onChange = append(l.onChange, fn)
+}
+
+// File returns the config file loaded by the last successful load, or "" if
+// none was found.
+func (l *Loader) File() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.file
+}
+
+// All returns a copy of the merged configuration.
+func (l *Loader) All() map[string]any {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return deepCopyMap(l.data)
+}
+
+// Lookup returns the value at a dotted key path (e.g., "server.port"). Maps
+// and slices are returned as copies.
+func (l *Loader) Lookup(key string) (any, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var current any = l.data
+	for _, part := range strings.Split(key, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+
+	switch v := current.(type) {
+	case map[string]any:
+		return deepCopyMap(v), true
+	case []any:
+		return deepCopySlice(v), true
+	default:
+		return v, true
+	}
+}
+
+// Unmarshal decodes the merged configuration into v (typically a pointer to
+// a struct with json tags).
+func (l *Loader) Unmarshal(v any) error {
+	return decodeValue(l.All(), v, "")
+}
+
+// UnmarshalKey decodes the value at a dotted key path into v.
+func (l *Loader) UnmarshalKey(key string, v any) error {
+	value, ok := l.Lookup(key)
+	if !ok {
+		return keyNotFound(key)
+	}
+	return decodeValue(value, v, key)
+}
+
+// Get returns the value at a dotted key path converted to T. Numbers convert
+// between numeric types, and maps decode into structs, as with Unmarshal.
+//
+// Example:
+//
+//	port, err := config.Get[int](loader, "server.port")
+func Get[T any](l *Loader, key string) (T, error) {
+	var out T
+	value, ok := l.Lookup(key)
+	if !ok {
+		return out, keyNotFound(key)
+	}
+	if typed, ok := value.(T); ok {
+		return typed, nil
+	}
+	err := decodeValue(value, &out, key)
+	return out, err
+}
+
+// GetOr returns Get's value, or fallback if the key is missing or cannot be
+// converted to T.
+func GetOr[T any](l *Loader, key string, fallback T) T {
+	value, err := Get[T](l, key)
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// load reads and merges every layer and validates the result.
+func (l *Loader) load(ctx context.Context) (map[string]any, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	merged := deepCopyMap(l.opts.Defaults)
+	if merged == nil {
+		merged = make(map[string]any)
+	}
+
+	file, err := l.findFile()
+	if err != nil {
+		return nil, "", err
+	}
+	if file != "" {
+		layer, err := loadConfigFile(file)
+		if err != nil {
+			envelope := errors.NewErrorEnvelope("CONFIG_USER_LOAD_ERROR", fmt.Sprintf("Failed to load configuration from %s", file))
+			envelope = errors.SafeWithSeverity(envelope, errors.SeverityHigh)
+			envelope = errors.SafeWithContext(envelope, map[string]interface{}{
+				"component":  "config",
+				"operation":  "load_config_file",
+				"error_type": "file_load_error",
+				"path":       file,
+			})
+			return nil, "", envelope.WithCause(err)
+		}
+		merged = mergeMaps(merged, layer)
+	}
+
+	merged = mergeMaps(merged, envLayer(l.opts.Identity.EnvPrefix, os.Environ(), merged))
+	merged = mergeMaps(merged, flagLayer(l.opts.Flags))
+
+	if l.opts.Secrets != nil {
+		if merged, err = l.opts.Secrets.Resolve(ctx, merged); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if l.opts.SchemaID != "" {
+		if err := l.validate(merged); err != nil {
+			return nil, "", err
+		}
+	}
+	return merged, file, nil
+}
+
+// findFile returns the config file to load, or "" if there is none.
+func (l *Loader) findFile() (string, error) {
+	if l.opts.File != "" {
+		if _, err := os.Stat(l.opts.File); err != nil {
+			envelope := errors.NewErrorEnvelope("CONFIG_USER_LOAD_ERROR", fmt.Sprintf("Configuration file %s not found", l.opts.File))
+			envelope = errors.SafeWithSeverity(envelope, errors.SeverityHigh)
+			envelope = errors.SafeWithContext(envelope, map[string]interface{}{
+				"component":  "config",
+				"operation":  "find_config_file",
+				"error_type": "file_not_found",
+				"path":       l.opts.File,
+			})
+			return "", envelope.WithCause(err)
+		}
+		return l.opts.File, nil
+	}
+
+	dir, err := l.opts.Identity.ConfigDir()
+	if err != nil {
+		// Without a config directory there is no file layer
+		return "", nil
+	}
+	names := l.opts.FileNames
+	if len(names) == 0 {
+		names = defaultConfigFiles
+	}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// validate checks merged against LoaderOptions.SchemaID.
+func (l *Loader) validate(merged map[string]any) error {
+	catalog := l.opts.Catalog
+	if catalog == nil {
+		catalog = schemaCatalog()
+	}
+
+	payload, err := json.Marshal(revealSecrets(merged))
+	if err == nil {
+		var diags []schema.Diagnostic
+		diags, err = catalog.ValidateDataByID(l.opts.SchemaID, payload)
+		if err == nil {
+			var failures []schema.Diagnostic
+			for _, diag := range diags {
+				if diag.Severity == schema.SeverityError {
+					failures = append(failures, diag)
+				}
+			}
+			if len(failures) == 0 {
+				return nil
+			}
+			err = fmt.Errorf("%d schema violation(s)", len(failures))
+			diags = failures
+		}
+
+		envelope := errors.NewErrorEnvelope("CONFIG_VALIDATION_ERROR", "Configuration validation failed")
+		envelope = errors.SafeWithSeverity(envelope, errors.SeverityHigh)
+		envelope = errors.SafeWithContext(envelope, map[string]interface{}{
+			"component":   "config",
+			"operation":   "validate_config",
+			"error_type":  "validation_error",
+			"schema_id":   l.opts.SchemaID,
+			"diagnostics": schema.DiagnosticsToStringSlice(diags),
+		})
+		return envelope.WithCause(err)
+	}
+
+	envelope := errors.NewErrorEnvelope("CONFIG_ENCODE_ERROR", "Failed to encode merged configuration")
+	envelope = errors.SafeWithSeverity(envelope, errors.SeverityHigh)
+	envelope = errors.SafeWithContext(envelope, map[string]interface{}{
+		"component":  "config",
+		"operation":  "encode_config",
+		"error_type": "json_encode_error",
+	})
+	return envelope.WithCause(err)
+}
+
+// envLayer builds the environment layer from environ entries with prefix.
+// Each key segment is snake_cased and matched against the keys already in
+// base, so MYAPP_SERVER__MAX_CONNS overrides an existing server.maxConns.
+func envLayer(prefix string, environ []string, base map[string]any) map[string]any {
+	layer := make(map[string]any)
+	if prefix == "" {
+		return layer
+	}
+	for _, entry := range environ {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		key := strings.TrimPrefix(name, prefix)
+		if key == "" || reservedEnvKeys[key] {
+			continue
+		}
+
+		var path []string
+		level := base
+		for _, part := range strings.Split(key, EnvKeySeparator) {
+			if part == "" {
+				continue
+			}
+			name := matchKey(level, foundry.ToSnakeCase(part))
+			path = append(path, name)
+			level, _ = level[name].(map[string]any)
+		}
+		setNestedValue(layer, path, parseScalar(value))
+	}
+	return layer
+}
+
+// matchKey returns the key in level whose snake_case form is name, or name
+// itself when there is none.
+func matchKey(level map[string]any, name string) string {
+	if _, ok := level[name]; ok {
+		return name
+	}
+	for key := range level {
+		if foundry.ToSnakeCase(key) == name {
+			return key
+		}
+	}
+	return name
+}
+
+// flagLayer builds the flag layer from the flags set on fs.
+func flagLayer(fs *flag.FlagSet) map[string]any {
+	layer := make(map[string]any)
+	if fs == nil {
+		return layer
+	}
+	fs.Visit(func(f *flag.Flag) {
+		var value any = f.Value.String()
+		if getter, ok := f.Value.(flag.Getter); ok {
+			switch v := getter.Get().(type) {
+			case bool, int, int64, uint, uint64, float64, string:
+				value = v
+			}
+		}
+		setNestedValue(layer, strings.Split(f.Name, "."), value)
+	})
+	return layer
+}
+
+// parseScalar interprets an environment value as a YAML scalar, falling back
+// to the raw string.
+func parseScalar(value string) any {
+	var parsed any
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil || parsed == nil {
+		return value
+	}
+	switch parsed.(type) {
+	case map[string]any, []any:
+		// Structured values are only taken from files
+		return value
+	}
+	return parsed
+}
+
+// decodeValue converts value to v through its JSON representation, with
+// secrets revealed.
+func decodeValue(value any, v any, key string) error {
+	data, err := json.Marshal(revealSecrets(value))
+	if err == nil {
+		err = json.Unmarshal(data, v)
+	}
+	if err == nil {
+		return nil
+	}
+
+	envelope := errors.NewErrorEnvelope("CONFIG_DECODE_ERROR", fmt.Sprintf("Failed to decode configuration into %T", v))
+	envelope = errors.SafeWithSeverity(envelope, errors.SeverityMedium)
+	envelope = errors.SafeWithContext(envelope, map[string]interface{}{
+		"component":  "config",
+		"operation":  "decode_config",
+		"error_type": "decode_error",
+		"key":        key,
+	})
+	return envelope.WithCause(err)
+}
+
+// keyNotFound returns the error for a missing key.
+func keyNotFound(key string) error {
+	envelope := errors.NewErrorEnvelope("CONFIG_KEY_NOT_FOUND", fmt.Sprintf("Configuration key %q not found", key))
+	envelope = errors.SafeWithSeverity(envelope, errors.SeverityLow)
+	envelope = errors.SafeWithContext(envelope, map[string]interface{}{
+		"component":  "config",
+		"operation":  "get",
+		"error_type": "key_not_found",
+		"key":        key,
+	})
+	return envelope
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/appidentity"
+	"github.com/fulmenhq/gofulmen/errors"
+	schemaPkg "github.com/fulmenhq/gofulmen/schema"
+)
+
+func sampleIdentity(t *testing.T) (*appidentity.Identity, string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("SAMPLE_CONFIG_DIR", dir)
+	return &appidentity.Identity{
+		BinaryName: "sample",
+		Vendor:     "fulmenhq",
+		EnvPrefix:  "SAMPLE_",
+		ConfigName: "sample",
+	}, dir
+}
+
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+}
+
+func TestLoader_LayerPrecedence(t *testing.T) {
+	identity, dir := sampleIdentity(t)
+	writeConfigFile(t, filepath.Join(dir, "config.yaml"), `server:
+  host: file.example
+  port: 9000
+log:
+  level: info
+`)
+	t.Setenv("SAMPLE_SERVER__PORT", "9100")
+	t.Setenv("SAMPLE_LOG__LEVEL", "debug")
+
+	fs := flag.NewFlagSet("sample", flag.ContinueOnError)
+	fs.String("log.level", "warn", "log level")
+	fs.Bool("server.tls", false, "enable TLS")
+	if err := fs.Parse([]string{"-log.level=error"}); err != nil {
+		t.Fatalf("parse flags: %v", err)
+	}
+
+	loader, err := NewLoader(LoaderOptions{
+		Identity: identity,
+		Defaults: map[string]any{
+			"server": map[string]any{"host": "localhost", "port": 8080, "tls": true},
+			"name":   "sample",
+		},
+		Flags: fs,
+	})
+	if err != nil {
+		t.Fatalf("NewLoader returned error: %v", err)
+	}
+
+	if got := loader.File(); got != filepath.Join(dir, "config.yaml") {
+		t.Errorf("File() = %q, want config.yaml in %s", got, dir)
+	}
+	if got := GetOr(loader, "name", ""); got != "sample" {
+		t.Errorf("name = %q, want default", got)
+	}
+	if got := GetOr(loader, "server.host", ""); got != "file.example" {
+		t.Errorf("server.host = %q, want file value", got)
+	}
+	if got, err := Get[int](loader, "server.port"); err != nil || got != 9100 {
+		t.Errorf("server.port = %v (%v), want env value 9100", got, err)
+	}
+	if got := GetOr(loader, "log.level", ""); got != "error" {
+		t.Errorf("log.level = %q, want flag value", got)
+	}
+	// Flags left at their default do not override other layers
+	if got := GetOr(loader, "server.tls", false); !got {
+		t.Errorf("server.tls = %v, want default true", got)
+	}
+}
+
+func TestLoader_EnvMatchesCamelCaseKeys(t *testing.T) {
+	identity, _ := sampleIdentity(t)
+	t.Setenv("SAMPLE_SERVER__MAX_CONNS", "64")
+	t.Setenv("SAMPLE_SERVER__READ_TIMEOUT", "5s")
+
+	loader, err := NewLoader(LoaderOptions{
+		Identity: identity,
+		Defaults: map[string]any{"server": map[string]any{"maxConns": 16}},
+	})
+	if err != nil {
+		t.Fatalf("NewLoader returned error: %v", err)
+	}
+	if got, err := Get[int](loader, "server.maxConns"); err != nil || got != 64 {
+		t.Errorf("server.maxConns = %v (%v), want env value 64", got, err)
+	}
+	if _, ok := loader.Lookup("server.max_conns"); ok {
+		t.Error("env override should reuse the existing key, not add server.max_conns")
+	}
+	// Keys with no existing counterpart are snake_cased
+	if got := GetOr(loader, "server.read_timeout", ""); got != "5s" {
+		t.Errorf("server.read_timeout = %q, want 5s", got)
+	}
+}
+
+func TestLoader_ReservedEnvIgnored(t *testing.T) {
+	identity, _ := sampleIdentity(t)
+	t.Setenv("SAMPLE_CACHE_DIR", "/tmp/cache")
+
+	loader, err := NewLoader(LoaderOptions{Identity: identity})
+	if err != nil {
+		t.Fatalf("NewLoader returned error: %v", err)
+	}
+	for _, key := range []string{"config_dir", "cache_dir"} {
+		if _, ok := loader.Lookup(key); ok {
+			t.Errorf("identity variable %s should not become a config key", key)
+		}
+	}
+}
+
+func TestLoader_GetAndUnmarshal(t *testing.T) {
+	identity, _ := sampleIdentity(t)
+	loader, err := NewLoader(LoaderOptions{
+		Identity: identity,
+		Defaults: map[string]any{
+			"server": map[string]any{"host": "localhost", "port": 8080},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewLoader returned error: %v", err)
+	}
+
+	type serverConfig struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+	var cfg struct {
+		Server serverConfig `json:"server"`
+	}
+	if err := loader.Unmarshal(&cfg); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if cfg.Server.Host != "localhost" || cfg.Server.Port != 8080 {
+		t.Errorf("Unmarshal = %+v", cfg)
+	}
+
+	server, err := Get[serverConfig](loader, "server")
+	if err != nil || server != cfg.Server {
+		t.Errorf("Get[serverConfig] = %+v (%v)", server, err)
+	}
+	if port, err := Get[float64](loader, "server.port"); err != nil || port != 8080 {
+		t.Errorf("Get[float64] = %v (%v)", port, err)
+	}
+
+	if _, err := Get[int](loader, "server.missing"); !errors.HasCode(err, "CONFIG_KEY_NOT_FOUND") {
+		t.Errorf("expected CONFIG_KEY_NOT_FOUND, got %v", err)
+	}
+	if _, err := Get[int](loader, "server.host"); !errors.HasCode(err, "CONFIG_DECODE_ERROR") {
+		t.Errorf("expected CONFIG_DECODE_ERROR, got %v", err)
+	}
+	if got := GetOr(loader, "server.missing", 3); got != 3 {
+		t.Errorf("GetOr fallback = %d, want 3", got)
+	}
+}
+
+func TestLoader_SchemaValidation(t *testing.T) {
+	identity, dir := sampleIdentity(t)
+	opts := LoaderOptions{
+		Identity: identity,
+		Defaults: map[string]any{
+			"version":  "v1.0.0",
+			"settings": map[string]any{"retries": 3, "endpoints": []any{"https://api.fulmen.dev"}},
+		},
+		SchemaID: "sample/v1.0.0/schema",
+		Catalog:  schemaPkg.NewCatalog(filepath.Join("..", "schemas", "testdata")),
+	}
+
+	loader, err := NewLoader(opts)
+	if err != nil {
+		t.Fatalf("NewLoader returned error: %v", err)
+	}
+
+	var changes int
+	loader.OnChange(func(map[string]any) { changes++ })
+
+	t.Setenv("SAMPLE_SETTINGS__RETRIES", "-1")
+	err = loader.Reload(context.Background())
+	if !errors.HasCode(err, "CONFIG_VALIDATION_ERROR") {
+		t.Fatalf("expected CONFIG_VALIDATION_ERROR, got %v", err)
+	}
+	if got := GetOr(loader, "settings.retries", 0); got != 3 {
+		t.Errorf("failed reload should keep previous config, retries = %d", got)
+	}
+	if changes != 0 {
+		t.Errorf("OnChange called %d times after failed reload", changes)
+	}
+
+	t.Setenv("SAMPLE_SETTINGS__RETRIES", "7")
+	writeConfigFile(t, filepath.Join(dir, "config.json"), `{"version": "v1.0.1"}`)
+	if err := loader.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	if got := GetOr(loader, "settings.retries", 0); got != 7 {
+		t.Errorf("retries = %d, want 7", got)
+	}
+	if got := GetOr(loader, "version", ""); got != "v1.0.1" {
+		t.Errorf("version = %q, want v1.0.1", got)
+	}
+	if changes != 1 {
+		t.Errorf("OnChange called %d times, want 1", changes)
+	}
+}
+
+func TestLoader_Errors(t *testing.T) {
+	if _, err := NewLoader(LoaderOptions{}); !errors.HasCode(err, "CONFIG_LOAD_ERROR") {
+		t.Errorf("expected CONFIG_LOAD_ERROR without identity, got %v", err)
+	}
+
+	identity, dir := sampleIdentity(t)
+	if _, err := NewLoader(LoaderOptions{Identity: identity, File: filepath.Join(dir, "missing.yaml")}); !errors.HasCode(err, "CONFIG_USER_LOAD_ERROR") {
+		t.Errorf("expected CONFIG_USER_LOAD_ERROR for missing file, got %v", err)
+	}
+
+	bad := filepath.Join(dir, "bad.yaml")
+	writeConfigFile(t, bad, "- not\n- a map\n")
+	if _, err := NewLoader(LoaderOptions{Identity: identity, File: bad}); !errors.HasCode(err, "CONFIG_USER_LOAD_ERROR") {
+		t.Errorf("expected CONFIG_USER_LOAD_ERROR for malformed file, got %v", err)
+	}
+}
@@ -0,0 +1,256 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fulmenhq/gofulmen/errors"
+)
+
+// RedactedPlaceholder replaces secret values when they are printed,
+// serialized, or logged.
+const RedactedPlaceholder = "[REDACTED]"
+
+// Secret is a configuration value resolved from a secret reference. It
+// redacts itself when formatted, marshaled to JSON or YAML, logged with slog
+// or the logging redaction middleware, or added to an error envelope's
+// context (errors.Redactable), so configuration holding secrets is safe to
+// log. Reveal returns the value.
+type Secret string
+
+// Reveal returns the secret value.
+func (s Secret) Reveal() string { return string(s) }
+
+// Redacted returns RedactedPlaceholder. It implements errors.Redactable.
+func (s Secret) Redacted() string { return RedactedPlaceholder }
+
+// String returns RedactedPlaceholder.
+func (s Secret) String() string { return RedactedPlaceholder }
+
+// GoString returns RedactedPlaceholder, covering %#v.
+func (s Secret) GoString() string { return RedactedPlaceholder }
+
+// MarshalJSON encodes RedactedPlaceholder.
+func (s Secret) MarshalJSON() ([]byte, error) { return []byte(`"` + RedactedPlaceholder + `"`), nil }
+
+// MarshalYAML encodes RedactedPlaceholder.
+func (s Secret) MarshalYAML() (interface{}, error) { return RedactedPlaceholder, nil }
+
+// LogValue implements slog.LogValuer.
+func (s Secret) LogValue() slog.Value { return slog.StringValue(RedactedPlaceholder) }
+
+// SecretProvider resolves the reference part of ${scheme:reference} for the
+// scheme it is registered under.
+type SecretProvider interface {
+	ResolveSecret(ctx context.Context, ref string) (string, error)
+}
+
+// SecretProviderFunc adapts a function to SecretProvider.
+type SecretProviderFunc func(ctx context.Context, ref string) (string, error)
+
+// ResolveSecret calls f.
+func (f SecretProviderFunc) ResolveSecret(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+// SecretResolver replaces ${scheme:reference} references in configuration
+// strings with values from SecretProviders. A string containing a reference
+// resolves to a Secret (the whole string, for values such as
+// "postgres://app:${env:DB_PASSWORD}@db/app"); other strings are unchanged.
+// "$${" escapes a literal "${".
+//
+// Built-in schemes:
+//
+//	${env:VAR}                 environment variable VAR (must be set)
+//	${file:/run/secrets/name}  file contents, without the trailing newline
+type SecretResolver struct {
+	mu        sync.RWMutex
+	providers map[string]SecretProvider
+}
+
+// NewSecretResolver returns a resolver with the env and file providers.
+//
+// Example:
+//
+//	resolver := config.NewSecretResolver()
+//	resolver.Register("vault", vaultProvider)
+//	loader, err := config.NewLoader(config.LoaderOptions{Identity: identity, Secrets: resolver})
+func NewSecretResolver() *SecretResolver {
+	r := &SecretResolver{providers: make(map[string]SecretProvider)}
+	r.Register("env", SecretProviderFunc(resolveEnvSecret))
+	r.Register("file", SecretProviderFunc(resolveFileSecret))
+	return r
+}
+
+// Register adds or replaces the provider for scheme.
+func (r *SecretResolver) Register(scheme string, provider SecretProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[scheme] = provider
+}
+
+// ResolveString resolves the references in s. It returns a Secret when s
+// contains at least one reference, otherwise s itself.
+func (r *SecretResolver) ResolveString(ctx context.Context, s string) (any, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var out strings.Builder
+	resolved := false
+	rest := s
+	for {
+		i := strings.Index(rest, "${")
+		if i < 0 {
+			out.WriteString(rest)
+			break
+		}
+		if i > 0 && rest[i-1] == '$' {
+			// Escaped: "$${" is a literal "${"
+			out.WriteString(rest[:i-1] + "${")
+			rest = rest[i+2:]
+			continue
+		}
+		out.WriteString(rest[:i])
+
+		end := strings.IndexByte(rest[i:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated secret reference")
+		}
+		reference := rest[i+2 : i+end]
+		scheme, ref, ok := strings.Cut(reference, ":")
+		if !ok || scheme == "" || ref == "" {
+			return nil, fmt.Errorf("invalid secret reference ${%s}: want ${scheme:reference}", reference)
+		}
+
+		r.mu.RLock()
+		provider, ok := r.providers[scheme]
+		r.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("unknown secret provider %q in ${%s}", scheme, reference)
+		}
+		value, err := provider.ResolveSecret(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("resolve ${%s}: %w", reference, err)
+		}
+		out.WriteString(value)
+		resolved = true
+		rest = rest[i+end+1:]
+	}
+
+	if !resolved {
+		return out.String(), nil
+	}
+	return Secret(out.String()), nil
+}
+
+// Resolve returns a copy of cfg with the references in every string value
+// resolved (see ResolveString). All failures are reported together in a
+// CONFIG_SECRET_ERROR aggregate envelope, one CONFIG_SECRET_REFERENCE_ERROR
+// per failing key.
+func (r *SecretResolver) Resolve(ctx context.Context, cfg map[string]any) (map[string]any, error) {
+	agg := errors.NewAggregate("CONFIG_SECRET_ERROR", "Failed to resolve secret references")
+	agg = errors.SafeWithSeverity(agg, errors.SeverityHigh)
+	resolved := r.resolveMap(ctx, cfg, "", agg)
+	if err := agg.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+	return resolved, nil
+}
+
+func (r *SecretResolver) resolveMap(ctx context.Context, m map[string]any, prefix string, agg *errors.ErrorEnvelope) map[string]any {
+	if m == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys) // deterministic error order
+
+	out := make(map[string]any, len(m))
+	for _, key := range keys {
+		out[key] = r.resolveValue(ctx, m[key], joinKey(prefix, key), agg)
+	}
+	return out
+}
+
+func (r *SecretResolver) resolveValue(ctx context.Context, value any, key string, agg *errors.ErrorEnvelope) any {
+	switch v := value.(type) {
+	case string:
+		resolved, err := r.ResolveString(ctx, v)
+		if err != nil {
+			envelope := errors.NewErrorEnvelope("CONFIG_SECRET_REFERENCE_ERROR", fmt.Sprintf("Failed to resolve secret reference in %s", key))
+			envelope = errors.SafeWithContext(envelope, map[string]interface{}{
+				"component":  "config",
+				"operation":  "resolve_secrets",
+				"error_type": "secret_reference_error",
+				"key":        key,
+			})
+			agg.Add(envelope.WithCause(err))
+			return v
+		}
+		return resolved
+	case map[string]any:
+		return r.resolveMap(ctx, v, key, agg)
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i] = r.resolveValue(ctx, elem, fmt.Sprintf("%s[%d]", key, i), agg)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func resolveEnvSecret(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+func resolveFileSecret(_ context.Context, path string) (string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- secret file paths come from trusted configuration
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// revealSecrets returns value with every Secret replaced by its plain
+// string, copying maps and slices.
+func revealSecrets(value any) any {
+	switch v := value.(type) {
+	case Secret:
+		return v.Reveal()
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, elem := range v {
+			out[key] = revealSecrets(elem)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i] = revealSecrets(elem)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/errors"
+)
+
+func TestSecret_Redacted(t *testing.T) {
+	secret := Secret("hunter2")
+
+	if secret.Reveal() != "hunter2" {
+		t.Errorf("Reveal() = %q", secret.Reveal())
+	}
+	for _, formatted := range []string{fmt.Sprint(secret), fmt.Sprintf("%v %s %#v", secret, secret, secret)} {
+		if strings.Contains(formatted, "hunter2") {
+			t.Errorf("formatted secret leaked: %s", formatted)
+		}
+	}
+
+	data, err := json.Marshal(map[string]any{"password": secret})
+	if err != nil || string(data) != `{"password":"[REDACTED]"}` {
+		t.Errorf("json.Marshal = %s (%v)", data, err)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("config", "password", secret)
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("slog output leaked secret: %s", buf.String())
+	}
+
+	envelope, err := errors.NewErrorEnvelope("CONFIG_INVALID", "rejected").WithContext(map[string]interface{}{"password": secret})
+	if err != nil {
+		t.Fatalf("WithContext: %v", err)
+	}
+	data, err = errors.Marshal(envelope)
+	if err != nil || strings.Contains(string(data), "hunter2") {
+		t.Errorf("envelope leaked secret: %s (%v)", data, err)
+	}
+}
+
+func TestSecretResolver_ResolveString(t *testing.T) {
+	t.Setenv("SECRET_TEST_PASSWORD", "s3cret")
+	secretFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(secretFile, []byte("tok-123\n"), 0o600); err != nil {
+		t.Fatalf("write secret file: %v", err)
+	}
+
+	r := NewSecretResolver()
+	r.Register("static", SecretProviderFunc(func(_ context.Context, ref string) (string, error) {
+		return "static-" + ref, nil
+	}))
+
+	tests := []struct {
+		in   string
+		want any
+	}{
+		{"plain", "plain"},
+		{"${env:SECRET_TEST_PASSWORD}", Secret("s3cret")},
+		{"${file:" + secretFile + "}", Secret("tok-123")},
+		{"postgres://app:${env:SECRET_TEST_PASSWORD}@db/app", Secret("postgres://app:s3cret@db/app")},
+		{"${static:a}/${static:b}", Secret("static-a/static-b")},
+		{"cost: $${env:HOME}", "cost: ${env:HOME}"},
+	}
+	for _, tt := range tests {
+		got, err := r.ResolveString(context.Background(), tt.in)
+		if err != nil {
+			t.Errorf("ResolveString(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveString(%q) = %#v (%T), want %T", tt.in, got, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"${env:SECRET_TEST_MISSING}", "${vault:db}", "${nocolon}", "${env:X"} {
+		if _, err := r.ResolveString(context.Background(), bad); err == nil {
+			t.Errorf("ResolveString(%q) expected error", bad)
+		}
+	}
+}
+
+func TestSecretResolver_Resolve(t *testing.T) {
+	t.Setenv("SECRET_TEST_PASSWORD", "s3cret")
+	r := NewSecretResolver()
+
+	cfg := map[string]any{
+		"db":    map[string]any{"password": "${env:SECRET_TEST_PASSWORD}", "port": 5432},
+		"hosts": []any{"a", "${env:SECRET_TEST_PASSWORD}"},
+	}
+	resolved, err := r.Resolve(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if got := resolved["db"].(map[string]any)["password"]; got != Secret("s3cret") {
+		t.Errorf("db.password = %#v", got)
+	}
+	if got := resolved["hosts"].([]any)[1]; got != Secret("s3cret") {
+		t.Errorf("hosts[1] = %#v", got)
+	}
+	if cfg["db"].(map[string]any)["password"] != "${env:SECRET_TEST_PASSWORD}" {
+		t.Error("Resolve modified its input")
+	}
+
+	_, err = r.Resolve(context.Background(), map[string]any{
+		"a": "${env:SECRET_TEST_MISSING_A}",
+		"b": map[string]any{"c": "${unknown:x}"},
+	})
+	if !errors.HasCode(err, "CONFIG_SECRET_ERROR") {
+		t.Fatalf("expected CONFIG_SECRET_ERROR, got %v", err)
+	}
+	var keys []interface{}
+	for _, envelope := range errors.Envelopes(err) {
+		if envelope.Code == "CONFIG_SECRET_REFERENCE_ERROR" {
+			keys = append(keys, envelope.Context["key"])
+		}
+	}
+	if fmt.Sprint(keys) != "[a b.c]" {
+		t.Errorf("failing keys = %v, want [a b.c]", keys)
+	}
+}
+
+func TestLoader_Secrets(t *testing.T) {
+	identity, _ := sampleIdentity(t)
+	t.Setenv("SECRET_TEST_PASSWORD", "s3cret")
+
+	loader, err := NewLoader(LoaderOptions{
+		Identity: identity,
+		Defaults: map[string]any{"db": map[string]any{"password": "${env:SECRET_TEST_PASSWORD}"}},
+		Secrets:  NewSecretResolver(),
+	})
+	if err != nil {
+		t.Fatalf("NewLoader returned error: %v", err)
+	}
+
+	if got, err := Get[string](loader, "db.password"); err != nil || got != "s3cret" {
+		t.Errorf("Get[string] = %q (%v), want revealed value", got, err)
+	}
+	var cfg struct {
+		DB struct {
+			Password string `json:"password"`
+		} `json:"db"`
+	}
+	if err := loader.Unmarshal(&cfg); err != nil || cfg.DB.Password != "s3cret" {
+		t.Errorf("Unmarshal password = %q (%v)", cfg.DB.Password, err)
+	}
+	if dump := fmt.Sprint(loader.All()); strings.Contains(dump, "s3cret") {
+		t.Errorf("All() leaked secret when printed: %s", dump)
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/fulmenhq/gofulmen/appidentity"
 	"github.com/fulmenhq/gofulmen/errors"
 )
 
-// XDGBaseDirs provides XDG Base Directory paths
+// XDGBaseDirs provides the base configuration, data, and cache directories.
+// They are resolved by appidentity.BaseDir: XDG_*_HOME when set, otherwise
+// the platform default (~/.config on Linux, ~/Library/Application Support on
+// macOS, %APPDATA% on Windows, and so on).
 type XDGBaseDirs struct {
 	ConfigHome string
 	DataHome   string
@@ -43,51 +47,43 @@ func GetXDGBaseDirsWithEnvelope(correlationID string) (XDGBaseDirs, error) {
 }
 
 func getXDGConfigHome() string {
-	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
-		return configHome
-	}
-	if home := os.Getenv("HOME"); home != "" {
-		return filepath.Join(home, ".config")
-	}
-	return ""
+	return baseDir(appidentity.DirConfig)
 }
 
 func getXDGDataHome() string {
-	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
-		return dataHome
-	}
-	if home := os.Getenv("HOME"); home != "" {
-		return filepath.Join(home, ".local", "share")
-	}
-	return ""
+	return baseDir(appidentity.DirData)
 }
 
 func getXDGCacheHome() string {
-	if cacheHome := os.Getenv("XDG_CACHE_HOME"); cacheHome != "" {
-		return cacheHome
-	}
-	if home := os.Getenv("HOME"); home != "" {
-		return filepath.Join(home, ".cache")
+	return baseDir(appidentity.DirCache)
+}
+
+// baseDir resolves a base directory with the same platform rules as
+// appidentity.Identity.Dir, returning "" when no home directory is available.
+func baseDir(kind appidentity.DirKind) string {
+	dir, err := appidentity.BaseDir(kind)
+	if err != nil {
+		return ""
 	}
-	return ""
+	return dir
 }
 
 // GetAppConfigDir returns the config directory for a given app name
-// Uses XDG Base Directory specification: $XDG_CONFIG_HOME/appName or ~/.config/appName
+// Uses $XDG_CONFIG_HOME/appName, or the platform default (~/.config/appName on Linux)
 func GetAppConfigDir(appName string) string {
 	xdg := GetXDGBaseDirs()
 	return filepath.Join(xdg.ConfigHome, appName)
 }
 
 // GetAppDataDir returns the data directory for a given app name
-// Uses XDG Base Directory specification: $XDG_DATA_HOME/appName or ~/.local/share/appName
+// Uses $XDG_DATA_HOME/appName, or the platform default (~/.local/share/appName on Linux)
 func GetAppDataDir(appName string) string {
 	xdg := GetXDGBaseDirs()
 	return filepath.Join(xdg.DataHome, appName)
 }
 
 // GetAppCacheDir returns the cache directory for a given app name
-// Uses XDG Base Directory specification: $XDG_CACHE_HOME/appName or ~/.cache/appName
+// Uses $XDG_CACHE_HOME/appName, or the platform default (~/.cache/appName on Linux)
 func GetAppCacheDir(appName string) string {
 	xdg := GetXDGBaseDirs()
 	return filepath.Join(xdg.CacheHome, appName)
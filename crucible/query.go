@@ -0,0 +1,325 @@
+package crucible
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/fulmenhq/gofulmen/docscribe"
+	"gopkg.in/yaml.v3"
+)
+
+// AssetKind identifies the Crucible tree an asset lives in.
+type AssetKind string
+
+const (
+	AssetKindDoc    AssetKind = "doc"
+	AssetKindSchema AssetKind = "schema"
+	AssetKindConfig AssetKind = "config"
+)
+
+// Asset describes one embedded Crucible asset.
+type Asset struct {
+	Kind AssetKind `json:"kind"`
+	// Path is relative to the kind's tree, as accepted by GetDoc, GetSchema,
+	// or GetConfig.
+	Path string `json:"path"`
+	// Version is the doc frontmatter "version", or the vMAJOR.MINOR.PATCH
+	// directory in the path (e.g., "v1.0.0").
+	Version string `json:"version,omitempty"`
+	// Title is the doc frontmatter "title" (falling back to the first
+	// heading) or the schema "title".
+	Title string `json:"title,omitempty"`
+	// SchemaID is the schema "$id".
+	SchemaID string `json:"schemaId,omitempty"`
+	// Format is the content format detected by docscribe (e.g., "markdown", "json").
+	Format string `json:"format"`
+	// Frontmatter holds the parsed doc frontmatter, if any.
+	Frontmatter map[string]any `json:"frontmatter,omitempty"`
+}
+
+// AssetFilter selects assets for Query. Zero-valued fields match everything.
+type AssetFilter struct {
+	// Kinds limits results to these kinds.
+	Kinds []AssetKind
+	// Pattern is a glob matched against Path, with "**" matching any number
+	// of directories (the same doublestar syntax as pathfinder includes; see
+	// Query).
+	Pattern string
+	// Frontmatter requires each key to be present in the asset frontmatter
+	// with the given value. List values match when any element matches;
+	// non-string values are compared by their formatted form.
+	Frontmatter map[string]string
+	// Version matches Asset.Version exactly.
+	Version string
+}
+
+// Query lists embedded Crucible assets matching filter, sorted by kind and
+// path.
+//
+// Docs are enumerated from ListAvailableDocs; schemas and configs are
+// discovered by walking the embedded trees. The resulting metadata index is
+// built on the first successful call and cached for the life of the process:
+// embedded assets cannot change at runtime, so it never goes stale. Returned
+// assets are copies, so callers may modify them (including Frontmatter)
+// without affecting the cache.
+//
+// Patterns are matched with doublestar directly rather than through
+// pathfinder, which other gofulmen packages use for globbing: pathfinder
+// depends on crucible (through docscribe and foundry), so importing it here
+// would create an import cycle. The glob syntax is the same.
+//
+// Example:
+//
+//	standards, err := crucible.Query(ctx, crucible.AssetFilter{
+//	    Kinds:       []crucible.AssetKind{crucible.AssetKindDoc},
+//	    Pattern:     "standards/**/*.md",
+//	    Frontmatter: map[string]string{"status": "approved"},
+//	})
+func Query(ctx context.Context, filter AssetFilter) ([]Asset, error) {
+	if filter.Pattern != "" && !doublestar.ValidatePattern(filter.Pattern) {
+		return nil, fmt.Errorf("invalid asset pattern %q", filter.Pattern)
+	}
+
+	assets, err := assetIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Asset
+	for _, asset := range assets {
+		if filter.matches(asset) {
+			asset.Frontmatter = cloneFrontmatter(asset.Frontmatter)
+			matches = append(matches, asset)
+		}
+	}
+	return matches, nil
+}
+
+// cloneFrontmatter deep-copies parsed frontmatter so cached index entries
+// cannot be modified through returned assets.
+func cloneFrontmatter(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	clone := make(map[string]any, len(m))
+	for k, v := range m {
+		clone[k] = cloneFrontmatterValue(v)
+	}
+	return clone
+}
+
+func cloneFrontmatterValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return cloneFrontmatter(v)
+	case []any:
+		clone := make([]any, len(v))
+		for i, item := range v {
+			clone[i] = cloneFrontmatterValue(item)
+		}
+		return clone
+	default:
+		return v
+	}
+}
+
+func (f AssetFilter) matches(asset Asset) bool {
+	if len(f.Kinds) > 0 {
+		found := false
+		for _, kind := range f.Kinds {
+			if kind == asset.Kind {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Pattern != "" {
+		if ok, _ := doublestar.Match(f.Pattern, asset.Path); !ok {
+			return false
+		}
+	}
+	if f.Version != "" && f.Version != asset.Version {
+		return false
+	}
+	for key, want := range f.Frontmatter {
+		if !frontmatterMatches(asset.Frontmatter[key], want) {
+			return false
+		}
+	}
+	return true
+}
+
+func frontmatterMatches(value any, want string) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case []any:
+		for _, item := range v {
+			if frontmatterMatches(item, want) {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprint(v) == want
+	}
+}
+
+var (
+	indexMu sync.Mutex
+	index   []Asset
+)
+
+// assetIndex builds the asset list on first use and caches it process-wide.
+// Errors (including a cancelled ctx) are not cached.
+func assetIndex(ctx context.Context) ([]Asset, error) {
+	indexMu.Lock()
+	defer indexMu.Unlock()
+	if index != nil {
+		return index, nil
+	}
+
+	var assets []Asset
+	for _, docs := range ListAvailableDocs() {
+		for _, p := range docs {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			content, err := GetDoc(p)
+			if err != nil {
+				continue // listed but not embedded in this Crucible version
+			}
+			assets = append(assets, docAsset(p, []byte(content)))
+		}
+	}
+
+	schemaPaths, err := walkAssets(ctx, ListSchemas)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schemas: %w", err)
+	}
+	for _, p := range schemaPaths {
+		data, err := GetSchema(p)
+		if err != nil {
+			continue
+		}
+		assets = append(assets, schemaAsset(p, data))
+	}
+
+	configPaths, err := walkAssets(ctx, ListConfigs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configs: %w", err)
+	}
+	for _, p := range configPaths {
+		data, err := GetConfig(p)
+		if err != nil {
+			continue
+		}
+		assets = append(assets, Asset{
+			Kind:    AssetKindConfig,
+			Path:    p,
+			Version: pathVersion(p),
+			Format:  docscribe.DetectFormat(data),
+		})
+	}
+
+	sort.Slice(assets, func(i, j int) bool {
+		if assets[i].Kind != assets[j].Kind {
+			return assets[i].Kind < assets[j].Kind
+		}
+		return assets[i].Path < assets[j].Path
+	})
+	index = assets
+	return index, nil
+}
+
+// walkAssets lists every file in an embedded tree. Entries that can be listed
+// are directories, whatever their name (e.g. "v1.0.0"); the rest are files.
+func walkAssets(ctx context.Context, list func(string) ([]string, error)) ([]string, error) {
+	var files []string
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		entries, err := list(dir)
+		if err != nil {
+			return err
+		}
+		for _, name := range entries {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			p := path.Join(dir, name)
+			if err := walk(p); err != nil {
+				files = append(files, p)
+			}
+		}
+		return nil
+	}
+	if err := walk(""); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func docAsset(p string, content []byte) Asset {
+	asset := Asset{
+		Kind:    AssetKindDoc,
+		Path:    p,
+		Version: pathVersion(p),
+		Format:  docscribe.DetectFormat(content),
+	}
+	if _, metadata, err := docscribe.ParseFrontmatter(content); err == nil && len(metadata) > 0 {
+		asset.Frontmatter = metadata
+		if title, ok := metadata["title"].(string); ok {
+			asset.Title = title
+		}
+		if version, ok := metadata["version"]; ok {
+			asset.Version = fmt.Sprint(version)
+		}
+	}
+	if asset.Title == "" {
+		if headers, err := docscribe.ExtractHeaders(content); err == nil && len(headers) > 0 {
+			asset.Title = headers[0].Text
+		}
+	}
+	return asset
+}
+
+func schemaAsset(p string, data []byte) Asset {
+	asset := Asset{
+		Kind:    AssetKindSchema,
+		Path:    p,
+		Version: pathVersion(p),
+		Format:  docscribe.DetectFormat(data),
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return asset
+		}
+	}
+	if id, ok := doc["$id"].(string); ok {
+		asset.SchemaID = id
+	}
+	if title, ok := doc["title"].(string); ok {
+		asset.Title = title
+	}
+	return asset
+}
+
+var pathVersionPattern = regexp.MustCompile(`(?:^|/)(v\d+\.\d+\.\d+)(?:/|$)`)
+
+// pathVersion returns the version directory in an asset path, if any.
+func pathVersion(p string) string {
+	if m := pathVersionPattern.FindStringSubmatch(p); m != nil {
+		return m[1]
+	}
+	return ""
+}
@@ -0,0 +1,183 @@
+package crucible
+
+import (
+	"context"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	assets, err := Query(context.Background(), AssetFilter{})
+	if err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+
+	kinds := make(map[AssetKind]int)
+	for _, asset := range assets {
+		kinds[asset.Kind]++
+	}
+	for _, kind := range []AssetKind{AssetKindDoc, AssetKindSchema, AssetKindConfig} {
+		if kinds[kind] == 0 {
+			t.Errorf("expected %s assets", kind)
+		}
+	}
+	t.Logf("Indexed %d docs, %d schemas, %d configs", kinds[AssetKindDoc], kinds[AssetKindSchema], kinds[AssetKindConfig])
+}
+
+func TestQuerySchemaMetadata(t *testing.T) {
+	assets, err := Query(context.Background(), AssetFilter{
+		Kinds:   []AssetKind{AssetKindSchema},
+		Pattern: "observability/logging/**/log-event.schema.json",
+	})
+	if err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	if len(assets) == 0 {
+		t.Fatal("expected the log event schema")
+	}
+
+	asset := assets[0]
+	if asset.Version != "v1.0.0" {
+		t.Errorf("Version = %q, want v1.0.0 from the path", asset.Version)
+	}
+	if asset.SchemaID == "" {
+		t.Error("SchemaID should come from $id")
+	}
+	if asset.Format != "json" {
+		t.Errorf("Format = %q, want json", asset.Format)
+	}
+}
+
+func TestQueryVersionedSchemas(t *testing.T) {
+	assets, err := Query(context.Background(), AssetFilter{Kinds: []AssetKind{AssetKindSchema}})
+	if err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+
+	found := false
+	for _, asset := range assets {
+		switch asset.Path {
+		case "observability/logging/v1.0.0/log-event.schema.json":
+			found = true
+		case "observability/logging/v1.0.0":
+			t.Errorf("version directory %q indexed as a file", asset.Path)
+		}
+	}
+	if !found {
+		t.Error("expected schemas under vX.Y.Z directories, e.g. observability/logging/v1.0.0/log-event.schema.json")
+	}
+}
+
+func TestQueryDocs(t *testing.T) {
+	assets, err := Query(context.Background(), AssetFilter{
+		Kinds:   []AssetKind{AssetKindDoc},
+		Pattern: "standards/coding/go.md",
+	})
+	if err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	if len(assets) != 1 {
+		t.Fatalf("expected 1 doc, got %d", len(assets))
+	}
+	if assets[0].Title == "" {
+		t.Error("Title should come from frontmatter or the first heading")
+	}
+
+	// Filtering on a frontmatter field the doc has returns it again.
+	for key, value := range assets[0].Frontmatter {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		matched, err := Query(context.Background(), AssetFilter{
+			Pattern:     "standards/coding/go.md",
+			Frontmatter: map[string]string{key: s},
+		})
+		if err != nil || len(matched) != 1 {
+			t.Errorf("frontmatter filter %s=%q: got %d assets, %v", key, s, len(matched), err)
+		}
+		break
+	}
+
+	none, err := Query(context.Background(), AssetFilter{
+		Kinds:       []AssetKind{AssetKindDoc},
+		Frontmatter: map[string]string{"no-such-field": "x"},
+	})
+	if err != nil || len(none) != 0 {
+		t.Errorf("expected no docs with an unknown frontmatter field, got %d, %v", len(none), err)
+	}
+}
+
+func TestQueryReturnsCopies(t *testing.T) {
+	filter := AssetFilter{Pattern: "standards/coding/go.md"}
+	assets, err := Query(context.Background(), filter)
+	if err != nil || len(assets) != 1 || len(assets[0].Frontmatter) == 0 {
+		t.Fatalf("expected the go coding standard with frontmatter, got %v, %v", assets, err)
+	}
+	for key := range assets[0].Frontmatter {
+		delete(assets[0].Frontmatter, key)
+	}
+
+	again, err := Query(context.Background(), filter)
+	if err != nil || len(again) != 1 || len(again[0].Frontmatter) == 0 {
+		t.Errorf("modifying a returned asset changed the cached index: %v, %v", again, err)
+	}
+}
+
+func TestQueryConfigs(t *testing.T) {
+	assets, err := Query(context.Background(), AssetFilter{
+		Kinds:   []AssetKind{AssetKindConfig},
+		Pattern: "library/foundry/*.yaml",
+	})
+	if err != nil {
+		t.Fatalf("Query() error: %v", err)
+	}
+	found := false
+	for _, asset := range assets {
+		if asset.Path == "library/foundry/patterns.yaml" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected library/foundry/patterns.yaml")
+	}
+}
+
+func TestQueryInvalidPattern(t *testing.T) {
+	if _, err := Query(context.Background(), AssetFilter{Pattern: "[unclosed"}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestFrontmatterMatches(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+		match bool
+	}{
+		{"approved", "approved", true},
+		{"draft", "approved", false},
+		{[]any{"go", "python"}, "go", true},
+		{[]any{"python"}, "go", false},
+		{2, "2", true},
+		{nil, "x", false},
+	}
+	for _, tt := range tests {
+		if got := frontmatterMatches(tt.value, tt.want); got != tt.match {
+			t.Errorf("frontmatterMatches(%v, %q) = %v, want %v", tt.value, tt.want, got, tt.match)
+		}
+	}
+}
+
+func TestPathVersion(t *testing.T) {
+	tests := map[string]string{
+		"observability/logging/v1.0.0/log-event.schema.json": "v1.0.0",
+		"v2.1.0/schema.json":           "v2.1.0",
+		"standards/coding/go.md":       "",
+		"terminal/v1.0.0x/schema.json": "",
+	}
+	for p, want := range tests {
+		if got := pathVersion(p); got != want {
+			t.Errorf("pathVersion(%q) = %q, want %q", p, got, want)
+		}
+	}
+}
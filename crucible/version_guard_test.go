@@ -1,4 +1,4 @@
-package crucible
+package crucible_test
 
 import (
 	"fmt"
@@ -0,0 +1,389 @@
+package docscribe
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Admonition types for status banners, rendered as GitHub alerts ("> [!WARNING]").
+const (
+	AdmonitionNote      = "NOTE"
+	AdmonitionTip       = "TIP"
+	AdmonitionImportant = "IMPORTANT"
+	AdmonitionWarning   = "WARNING"
+	AdmonitionCaution   = "CAUTION"
+)
+
+// Markers delimiting the block managed by InjectBanners.
+const (
+	bannerStartMarker = "<!-- docscribe:banners -->"
+	bannerEndMarker   = "<!-- /docscribe:banners -->"
+)
+
+// supersededByField is the frontmatter key whose value is linked from status banners.
+const supersededByField = "superseded_by"
+
+// StatusBanner is the admonition rendered for a frontmatter status value.
+type StatusBanner struct {
+	// Type is the admonition type (e.g., AdmonitionWarning)
+	Type string `json:"type" yaml:"type"`
+
+	// Message is the banner text
+	Message string `json:"message" yaml:"message"`
+}
+
+// Badge renders a shields.io badge from a frontmatter field.
+type Badge struct {
+	// Field is the frontmatter key; documents without it get no badge
+	Field string `json:"field" yaml:"field"`
+
+	// Label is the left-hand badge text. Default: Field
+	Label string `json:"label,omitempty" yaml:"label,omitempty"`
+
+	// Color is the badge color (shields.io name or hex). Default: "blue"
+	Color string `json:"color,omitempty" yaml:"color,omitempty"`
+
+	// Colors overrides Color for specific field values (case-insensitive)
+	Colors map[string]string `json:"colors,omitempty" yaml:"colors,omitempty"`
+
+	// Link is an optional URL the badge links to
+	Link string `json:"link,omitempty" yaml:"link,omitempty"`
+}
+
+// BannerOptions configures InjectBanners.
+type BannerOptions struct {
+	// StatusField is the frontmatter key selecting the banner. Default: "status"
+	StatusField string `json:"status_field,omitempty" yaml:"status_field,omitempty"`
+
+	// Banners maps status values (case-insensitive) to banners. Statuses
+	// without an entry get no banner. Default: DefaultStatusBanners()
+	Banners map[string]StatusBanner `json:"banners,omitempty" yaml:"banners,omitempty"`
+
+	// Badges are rendered in order on one line above the banner.
+	Badges []Badge `json:"badges,omitempty" yaml:"badges,omitempty"`
+}
+
+// DefaultStatusBanners returns banners for the lifecycle statuses used in
+// Crucible and ADR frontmatter: draft, proposal, experimental, deprecated,
+// superseded, and retired.
+func DefaultStatusBanners() map[string]StatusBanner {
+	return map[string]StatusBanner{
+		"draft":        {Type: AdmonitionNote, Message: "This document is a draft and may change."},
+		"proposal":     {Type: AdmonitionNote, Message: "This document is a proposal and has not been accepted."},
+		"experimental": {Type: AdmonitionCaution, Message: "This document describes experimental behavior that may change without notice."},
+		"deprecated":   {Type: AdmonitionWarning, Message: "This document is deprecated."},
+		"superseded":   {Type: AdmonitionWarning, Message: "This document has been superseded."},
+		"retired":      {Type: AdmonitionWarning, Message: "This document is retired and no longer maintained."},
+	}
+}
+
+// DefaultBannerOptions returns the default banner options: status banners
+// from the "status" field and no badges.
+func DefaultBannerOptions() BannerOptions {
+	return BannerOptions{
+		StatusField: "status",
+		Banners:     DefaultStatusBanners(),
+	}
+}
+
+// StatusBadge returns a badge for the "status" field colored by lifecycle
+// stage: green when stable or approved, yellow while in progress, red when
+// deprecated or retired.
+func StatusBadge() Badge {
+	return Badge{
+		Field: "status",
+		Color: "lightgrey",
+		Colors: map[string]string{
+			"stable": "green", "approved": "green", "accepted": "green", "active": "green",
+			"draft": "yellow", "proposal": "yellow", "planned": "yellow", "experimental": "orange",
+			"deprecated": "red", "superseded": "red", "retired": "red",
+		},
+	}
+}
+
+// BannerResult is the output of InjectBanners.
+type BannerResult struct {
+	// Content is the rewritten document.
+	Content string `json:"content"`
+
+	// Changed reports whether Content differs from the input.
+	Changed bool `json:"changed"`
+
+	// Banner is the rendered admonition ("" if the status has no banner).
+	Banner string `json:"banner,omitempty"`
+
+	// Badges are the rendered badge images, in BannerOptions.Badges order.
+	Badges []string `json:"badges,omitempty"`
+}
+
+// InjectBanners inserts or updates a status banner and badges under the
+// document's H1 (or at the top of the body when there is none), based on
+// frontmatter fields. The generated markdown is wrapped in
+// "<!-- docscribe:banners -->" comments so later runs replace it in place,
+// and removed when the frontmatter no longer calls for it; running
+// InjectBanners on its own output changes nothing. When the frontmatter sets
+// superseded_by, the banner links to it. opts may be nil for
+// DefaultBannerOptions.
+//
+// A document with frontmatter
+//
+//	status: deprecated
+//	superseded_by: logging-v2.md
+//
+// gets, below its title:
+//
+//	<!-- docscribe:banners -->
+//	> [!WARNING]
+//	> This document is deprecated. Use [logging-v2.md](logging-v2.md) instead.
+//	<!-- /docscribe:banners -->
+//
+// Example:
+//
+//	opts := docscribe.DefaultBannerOptions()
+//	opts.Badges = []docscribe.Badge{docscribe.StatusBadge()}
+//	result, err := docscribe.InjectBanners(content, &opts)
+//	if err != nil {
+//	    return err
+//	}
+//	if result.Changed {
+//	    os.WriteFile(path, []byte(result.Content), 0644)
+//	}
+//
+// Returns a ParseError if the frontmatter is malformed or a banner block is
+// not terminated.
+func InjectBanners(content []byte, opts *BannerOptions) (*BannerResult, error) {
+	options := DefaultBannerOptions()
+	if opts != nil {
+		options = *opts
+	}
+	if options.StatusField == "" {
+		options.StatusField = "status"
+	}
+
+	eol := "\n"
+	if strings.Contains(string(content), "\r\n") {
+		eol = "\r\n"
+	}
+
+	doc, diags, err := newLintContext(content, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range diags {
+		if d.Rule == RuleFrontmatterSyntax {
+			return nil, newParseErrorWithLine(d.Message, d.Line)
+		}
+	}
+
+	// Drop any previous block, then locate the insertion point afresh
+	lines, removed, err := removeBannerBlock(doc)
+	if err != nil {
+		return nil, err
+	}
+	if removed {
+		if doc, _, err = newLintContext([]byte(strings.Join(lines, "\n")), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &BannerResult{}
+	for _, badge := range options.Badges {
+		if rendered := renderBadge(badge, doc.Frontmatter); rendered != "" {
+			result.Badges = append(result.Badges, rendered)
+		}
+	}
+	status := strings.ToLower(frontmatterString(doc.Frontmatter[options.StatusField]))
+	if banner, ok := lookupBanner(options.Banners, status); ok {
+		result.Banner = renderBanner(banner, frontmatterString(doc.Frontmatter[supersededByField]))
+	}
+
+	var block []string
+	if len(result.Badges) > 0 {
+		block = append(block, strings.Join(result.Badges, " "))
+	}
+	if result.Banner != "" {
+		if len(block) > 0 {
+			block = append(block, "")
+		}
+		block = append(block, strings.Split(result.Banner, "\n")...)
+	}
+	if len(block) > 0 {
+		block = append(append([]string{bannerStartMarker}, block...), bannerEndMarker)
+		lines = insertBlock(doc.Lines, bannerInsertLine(doc), block)
+	} else {
+		lines = doc.Lines
+	}
+
+	result.Content = strings.Join(lines, eol)
+	result.Changed = result.Content != string(content)
+	return result, nil
+}
+
+// removeBannerBlock returns the document lines without the managed block and
+// the blank line that separated it from the following content.
+func removeBannerBlock(doc *LintContext) ([]string, bool, error) {
+	start := -1
+	for i := doc.BodyLine - 1; i < len(doc.Lines); i++ {
+		if doc.InCodeBlock(i + 1) {
+			continue
+		}
+		switch strings.TrimSpace(doc.Lines[i]) {
+		case bannerStartMarker:
+			if start < 0 {
+				start = i
+			}
+		case bannerEndMarker:
+			if start < 0 {
+				continue
+			}
+			end := i + 1
+			if start > 0 && strings.TrimSpace(doc.Lines[start-1]) == "" &&
+				end < len(doc.Lines) && strings.TrimSpace(doc.Lines[end]) == "" {
+				end++
+			}
+			lines := append(append([]string{}, doc.Lines[:start]...), doc.Lines[end:]...)
+			return lines, true, nil
+		}
+	}
+	if start >= 0 {
+		return nil, false, newParseErrorWithLine("banner block is missing its closing "+bannerEndMarker, start+1)
+	}
+	return doc.Lines, false, nil
+}
+
+// bannerInsertLine returns the 0-based line the block is inserted before:
+// after the first H1 (including a Setext underline), else at the body start.
+func bannerInsertLine(doc *LintContext) int {
+	for _, h := range doc.Headers {
+		if h.Level != 1 {
+			continue
+		}
+		idx := h.LineNumber - 1
+		if !strings.HasPrefix(strings.TrimSpace(doc.Lines[idx]), "#") {
+			idx++ // Setext underline
+		}
+		return idx + 1
+	}
+	return doc.BodyLine - 1
+}
+
+// insertBlock inserts block before line at, separated from its neighbors by
+// blank lines.
+func insertBlock(lines []string, at int, block []string) []string {
+	out := make([]string, 0, len(lines)+len(block)+2)
+	out = append(out, lines[:at]...)
+	if at > 0 && strings.TrimSpace(lines[at-1]) != "" {
+		out = append(out, "")
+	}
+	out = append(out, block...)
+	if at >= len(lines) || strings.TrimSpace(lines[at]) != "" {
+		out = append(out, "")
+	}
+	return append(out, lines[at:]...)
+}
+
+// lookupBanner finds the banner for a lower-cased status.
+func lookupBanner(banners map[string]StatusBanner, status string) (StatusBanner, bool) {
+	if status == "" {
+		return StatusBanner{}, false
+	}
+	if banner, ok := banners[status]; ok {
+		return banner, true
+	}
+	for key, banner := range banners {
+		if strings.ToLower(key) == status {
+			return banner, true
+		}
+	}
+	return StatusBanner{}, false
+}
+
+// renderBanner formats a banner as a GitHub alert.
+func renderBanner(banner StatusBanner, supersededBy string) string {
+	kind := strings.ToUpper(banner.Type)
+	if kind == "" {
+		kind = AdmonitionNote
+	}
+	message := banner.Message
+	if supersededBy != "" {
+		message = strings.TrimSpace(fmt.Sprintf("%s Use [%s](%s) instead.", message, supersededBy, supersededBy))
+	}
+
+	lines := []string{"> [!" + kind + "]"}
+	for _, line := range strings.Split(message, "\n") {
+		lines = append(lines, strings.TrimRight("> "+line, " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderBadge formats a shields.io static badge, or "" if the field is unset.
+func renderBadge(badge Badge, frontmatter map[string]interface{}) string {
+	value := frontmatterString(frontmatter[badge.Field])
+	if value == "" {
+		return ""
+	}
+	label := badge.Label
+	if label == "" {
+		label = badge.Field
+	}
+	color := badge.Color
+	if color == "" {
+		color = "blue"
+	}
+	keys := make([]string, 0, len(badge.Colors))
+	for key := range badge.Colors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if strings.EqualFold(key, value) {
+			color = badge.Colors[key]
+			break
+		}
+	}
+
+	src := "https://img.shields.io/badge/" + shieldsEscape(label) + "-" + shieldsEscape(value) + "-" + shieldsEscape(color)
+	image := fmt.Sprintf("![%s: %s](%s)", label, value, src)
+	if badge.Link != "" {
+		return fmt.Sprintf("[%s](%s)", image, badge.Link)
+	}
+	return image
+}
+
+// shieldsEscape escapes badge text for a shields.io path segment, where "-"
+// and "_" separate fields and "_" stands for a space.
+func shieldsEscape(s string) string {
+	s = strings.NewReplacer("-", "--", "_", "__", " ", "_").Replace(s)
+	return url.PathEscape(s)
+}
+
+// frontmatterString renders a scalar or list frontmatter value as text; maps
+// and missing values render as "".
+func frontmatterString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case time.Time:
+		if v.Equal(v.Truncate(24 * time.Hour)) {
+			return v.Format("2006-01-02")
+		}
+		return v.Format(time.RFC3339)
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if s := frontmatterString(item); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -23,15 +23,38 @@
 // Header Extraction:
 //   - ExtractHeaders: Extract all markdown headers with hierarchy, anchors, and line numbers
 //
+// Transforms:
+//   - NumberHeadings: Insert, update, or strip hierarchical section numbers, keeping in-document links valid
+//   - InjectBanners: Idempotently insert or update frontmatter-driven status banners (admonitions)
+//     and shields.io badges under the H1
+//
 // Format Detection:
 //   - DetectFormat: Heuristic-based format detection (markdown, yaml, json, etc.)
 //
 // Document Inspection:
 //   - InspectDocument: Quick analysis without full parsing (<1ms target)
+//   - DetectEncryption: Recognize SOPS and age encrypted content
+//
+// Encoding:
+//   - DetectEncoding: Report charset, BOM, line endings, and tab indentation
+//   - Normalize: Strip BOMs, decode UTF-16, unify line endings, and apply a tab policy
+//
+// Linting:
+//   - Lint: Check documents against a RuleSet (single H1, header increments, required
+//     frontmatter, line length, trailing whitespace, final newline, frontmatter key
+//     order, link style, or custom Rules)
+//   - Fix: Apply the safe edits attached to lint diagnostics and report what changed
+//   - ExtractDefinitions, CheckTerminology: Extract defined terms and flag inconsistent
+//     capitalization or deprecated terms across a document set (Terminology rule)
 //
 // Multi-Document Handling:
 //   - SplitDocuments: Split YAML streams and concatenated markdown documents
 //
+// Corpus Analysis:
+//   - BuildIndex: Index titles, headers, anchors, and frontmatter across a document set
+//     for search, "path#anchor" lookup, and frontmatter filtering (JSON-serializable)
+//   - FindSimilarDocuments: Flag near-duplicate documents via section digests and outline similarity
+//
 // # Usage Example
 //
 //	import (
@@ -87,11 +110,32 @@
 //   - SplitDocuments: <10ms for 10-document stream
 //   - ExtractHeaders: <50ms for 1MB document
 //
+// # Untrusted Input
+//
+// Services processing uploaded documents should bound the work per document
+// with Limits, either process-wide or per call:
+//
+//	docscribe.SetDefaultLimits(docscribe.Limits{MaxSize: 10 << 20, ParseTimeout: 2 * time.Second})
+//	headers, err := docscribe.ExtractHeaders(upload, docscribe.WithMaxSize(1<<20))
+//
+// Limits also cap frontmatter size and header count and length. ParseOptions
+// adds strict frontmatter YAML (no anchors, aliases, tags, or non-string keys)
+// and an allow-list of frontmatter formats, applied per call with
+// WithParseOptions or the individual With* options:
+//
+//	body, metadata, err := docscribe.ParseFrontmatter(upload,
+//	    docscribe.WithMaxFrontmatterSize(16<<10),
+//	    docscribe.WithStrictYAML(),
+//	    docscribe.WithFrontmatterFormats(docscribe.FormatYAML))
+//
 // # Error Handling
 //
 // The package uses typed errors for different failure modes:
 //   - ParseError: Malformed YAML or content structure issues (includes line numbers)
 //   - FormatError: Content doesn't match expected format
+//   - EncryptedContentError: Content or frontmatter is encrypted (SOPS, age)
+//   - LimitExceededError: Content exceeds a configured limit (size, frontmatter size,
+//     header count or length, or parse deadline)
 //
 // All errors implement standard error unwrapping for inspection.
 package docscribe
@@ -1,9 +1,14 @@
 package docscribe
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Test fixtures directory
@@ -574,6 +579,75 @@ func TestErrorTypes(t *testing.T) {
 	})
 }
 
+// TestEncryptedContent tests SOPS and age marker recognition
+func TestEncryptedContent(t *testing.T) {
+	t.Run("SOPS frontmatter", func(t *testing.T) {
+		content := loadFixture(t, "sops-frontmatter.md")
+
+		body, metadata, err := ParseFrontmatter(content)
+		var encErr *EncryptedContentError
+		if !errors.As(err, &encErr) {
+			t.Fatalf("Expected EncryptedContentError, got %v", err)
+		}
+		if encErr.Scheme != EncryptionSOPS || !encErr.Frontmatter {
+			t.Errorf("Unexpected error details: %+v", encErr)
+		}
+		if metadata != nil {
+			t.Error("Expected nil metadata for encrypted frontmatter")
+		}
+		if !contains(body, "# Encrypted Document") {
+			t.Errorf("Expected plaintext body to be returned, got %q", body)
+		}
+
+		if _, err := ExtractMetadata(content); !errors.As(err, &encErr) {
+			t.Errorf("ExtractMetadata: expected EncryptedContentError, got %v", err)
+		}
+	})
+
+	t.Run("age armored document", func(t *testing.T) {
+		content := loadFixture(t, "age-armored.md")
+
+		_, _, err := ParseFrontmatter(content)
+		var encErr *EncryptedContentError
+		if !errors.As(err, &encErr) || encErr.Scheme != EncryptionAge {
+			t.Fatalf("Expected age EncryptedContentError, got %v", err)
+		}
+	})
+
+	t.Run("InspectDocument flags encryption", func(t *testing.T) {
+		info, err := InspectDocument(loadFixture(t, "sops-frontmatter.md"))
+		if err != nil {
+			t.Fatalf("InspectDocument failed: %v", err)
+		}
+		if !info.Encrypted || info.EncryptionScheme != EncryptionSOPS {
+			t.Errorf("Expected SOPS encryption flag, got %+v", info)
+		}
+
+		info, err = InspectDocument(loadFixture(t, "age-armored.md"))
+		if err != nil {
+			t.Fatalf("InspectDocument failed: %v", err)
+		}
+		if !info.Encrypted || info.EncryptionScheme != EncryptionAge {
+			t.Errorf("Expected age encryption flag, got %+v", info)
+		}
+	})
+
+	t.Run("plaintext is not flagged", func(t *testing.T) {
+		for _, fixture := range []string{"valid-frontmatter.md", "yaml-content.yaml", "json-content.json"} {
+			if scheme := DetectEncryption(loadFixture(t, fixture)); scheme != "" {
+				t.Errorf("%s: expected no encryption, got %q", fixture, scheme)
+			}
+		}
+	})
+
+	t.Run("SOPS JSON", func(t *testing.T) {
+		content := []byte("{\n  \"token\": \"ENC[AES256_GCM,data:abc]\",\n  \"sops\": {\"version\": \"3.9.0\"}\n}")
+		if scheme := DetectEncryption(content); scheme != EncryptionSOPS {
+			t.Errorf("Expected sops, got %q", scheme)
+		}
+	})
+}
+
 // TestCountLines tests line counting edge cases
 func TestCountLines(t *testing.T) {
 	tests := []struct {
@@ -689,3 +763,752 @@ func stringContains(s, substr string) bool {
 func hasPrefix(s, prefix string) bool {
 	return len(s) >= len(prefix) && s[:len(prefix)] == prefix
 }
+
+// TestFindSimilarDocuments tests near-duplicate detection across a corpus
+func TestFindSimilarDocuments(t *testing.T) {
+	corpus := []CorpusDocument{
+		{ID: "original", Content: loadFixture(t, "similar-original.md")},
+		{ID: "copy", Content: loadFixture(t, "similar-copy.md")},
+		{ID: "unrelated", Content: loadFixture(t, "code-blocks.md")},
+	}
+
+	reports := FindSimilarDocuments(corpus, nil)
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 similar pair, got %d: %+v", len(reports), reports)
+	}
+
+	r := reports[0]
+	if r.A != "original" || r.B != "copy" {
+		t.Errorf("Expected pair original/copy, got %s/%s", r.A, r.B)
+	}
+	if len(r.SharedSections) != 2 {
+		t.Fatalf("Expected 2 shared sections, got %d", len(r.SharedSections))
+	}
+	if r.SharedSections[0].HeadingA != "Overview" || r.SharedSections[1].HeadingB != "Severity levels" {
+		t.Errorf("Unexpected shared sections: %+v", r.SharedSections)
+	}
+	if r.SectionOverlap < 0.6 || r.SectionOverlap > 0.7 {
+		t.Errorf("Expected section overlap of 2/3, got %.2f", r.SectionOverlap)
+	}
+	if r.OutlineScore <= 0.5 {
+		t.Errorf("Expected similar outlines, got %.2f", r.OutlineScore)
+	}
+
+	// A higher threshold filters the pair out
+	if strict := FindSimilarDocuments(corpus, &SimilarityOptions{Threshold: 0.95}); len(strict) != 0 {
+		t.Errorf("Expected no pairs above 0.95, got %d", len(strict))
+	}
+
+	// Identical documents score 1.0
+	twins := FindSimilarDocuments([]CorpusDocument{corpus[0], {ID: "twin", Content: corpus[0].Content}}, nil)
+	if len(twins) != 1 || twins[0].Score < 0.999 {
+		t.Errorf("Expected identical documents to score 1.0, got %+v", twins)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	content := []byte("---\ntitle: Test\n---\n# Header\n\nBody text.\n")
+
+	t.Run("max size per call", func(t *testing.T) {
+		_, _, err := ParseFrontmatter(content, WithMaxSize(10))
+		var limitErr *LimitExceededError
+		if !errors.As(err, &limitErr) {
+			t.Fatalf("Expected LimitExceededError, got %v", err)
+		}
+		if limitErr.Limit != LimitMaxSize || limitErr.Size != int64(len(content)) || limitErr.MaxSize != 10 {
+			t.Errorf("Unexpected error fields: %+v", limitErr)
+		}
+
+		if _, _, err := ParseFrontmatter(content, WithMaxSize(int64(len(content)))); err != nil {
+			t.Errorf("Content at the limit should parse: %v", err)
+		}
+	})
+
+	t.Run("all entry points", func(t *testing.T) {
+		calls := map[string]func() error{
+			"ExtractMetadata": func() error { _, err := ExtractMetadata(content, WithMaxSize(1)); return err },
+			"ExtractHeaders":  func() error { _, err := ExtractHeaders(content, WithMaxSize(1)); return err },
+			"InspectDocument": func() error { _, err := InspectDocument(content, WithMaxSize(1)); return err },
+			"SplitDocuments":  func() error { _, err := SplitDocuments(content, WithMaxSize(1)); return err },
+		}
+		for name, call := range calls {
+			var limitErr *LimitExceededError
+			if err := call(); !errors.As(err, &limitErr) {
+				t.Errorf("%s: expected LimitExceededError, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("parse timeout", func(t *testing.T) {
+		var large []byte
+		for i := 0; i < 5000; i++ {
+			large = append(large, "## Section\n\ntext\n"...)
+		}
+
+		_, err := ExtractHeaders(large, WithParseTimeout(time.Nanosecond))
+		var limitErr *LimitExceededError
+		if !errors.As(err, &limitErr) || limitErr.Limit != LimitParseTimeout {
+			t.Fatalf("Expected parse timeout error, got %v", err)
+		}
+
+		if _, err := ExtractHeaders(large, WithParseTimeout(time.Minute)); err != nil {
+			t.Errorf("Generous timeout should not trip: %v", err)
+		}
+	})
+
+	t.Run("process-wide defaults", func(t *testing.T) {
+		SetDefaultLimits(Limits{MaxSize: 10})
+		defer SetDefaultLimits(Limits{})
+
+		if _, err := ExtractHeaders(content); err == nil {
+			t.Error("Expected default limit to apply")
+		}
+		if _, err := ExtractHeaders(content, WithMaxSize(0)); err != nil {
+			t.Errorf("Per-call option should override default: %v", err)
+		}
+	})
+}
+
+func TestParseOptions(t *testing.T) {
+	t.Run("frontmatter size", func(t *testing.T) {
+		content := []byte("---\ntitle: A long enough title\n---\n# Header\n")
+		_, err := ExtractMetadata(content, WithMaxFrontmatterSize(10))
+		var limitErr *LimitExceededError
+		if !errors.As(err, &limitErr) || limitErr.Limit != LimitMaxFrontmatterSize || limitErr.Size != 26 {
+			t.Fatalf("Expected frontmatter size error, got %v", err)
+		}
+		if _, _, err := ParseFrontmatter(content, WithMaxFrontmatterSize(26)); err != nil {
+			t.Errorf("Frontmatter at the limit should parse: %v", err)
+		}
+	})
+
+	t.Run("strict YAML", func(t *testing.T) {
+		rejected := map[string]string{
+			"alias":   "base: &b {a: 1}\nderived: *b\n",
+			"tag":     "data: !!binary aGVsbG8=\n",
+			"int key": "1: one\n",
+		}
+		for name, yamlBlock := range rejected {
+			content := []byte("---\n" + yamlBlock + "---\nBody\n")
+			if _, err := ExtractMetadata(content); err != nil {
+				t.Errorf("%s: lenient parsing should accept it: %v", name, err)
+			}
+			var parseErr *ParseError
+			if _, err := ExtractMetadata(content, WithStrictYAML()); !errors.As(err, &parseErr) || parseErr.LineNumber == 0 {
+				t.Errorf("%s: expected ParseError with a line, got %v", name, err)
+			}
+		}
+
+		metadata, err := ExtractMetadata([]byte("---\ntitle: Doc\ntags: [a, b]\n---\n"), WithStrictYAML())
+		if err != nil || metadata["title"] != "Doc" {
+			t.Errorf("Plain metadata should pass strict parsing: %v, %v", metadata, err)
+		}
+	})
+
+	t.Run("frontmatter formats", func(t *testing.T) {
+		jsonDoc := []byte("---\n{\"title\": \"Doc\"}\n---\nBody\n")
+		yamlDoc := []byte("---\ntitle: Doc\n---\nBody\n")
+
+		if metadata, err := ExtractMetadata(jsonDoc, WithFrontmatterFormats(FormatJSON)); err != nil || metadata["title"] != "Doc" {
+			t.Errorf("JSON frontmatter should be accepted: %v, %v", metadata, err)
+		}
+		var formatErr *FormatError
+		if _, _, err := ParseFrontmatter(yamlDoc, WithFrontmatterFormats(FormatJSON)); !errors.As(err, &formatErr) || formatErr.Actual != FormatYAML {
+			t.Errorf("Expected FormatError for YAML frontmatter, got %v", err)
+		}
+		if _, err := ExtractMetadata(jsonDoc, WithFrontmatterFormats(FormatYAML)); !errors.As(err, &formatErr) || formatErr.Actual != FormatJSON {
+			t.Errorf("Expected FormatError for JSON frontmatter, got %v", err)
+		}
+	})
+
+	t.Run("header limits", func(t *testing.T) {
+		content := []byte("# One\n\n## Two\n\nThree\n-----\n")
+		var limitErr *LimitExceededError
+		if _, err := ExtractHeaders(content, WithMaxHeaders(2)); !errors.As(err, &limitErr) || limitErr.Limit != LimitMaxHeaders {
+			t.Errorf("ExtractHeaders: expected header count error, got %v", err)
+		}
+		if _, err := InspectDocument(content, WithMaxHeaders(2)); !errors.As(err, &limitErr) || limitErr.Limit != LimitMaxHeaders {
+			t.Errorf("InspectDocument: expected header count error, got %v", err)
+		}
+		if headers, err := ExtractHeaders(content, WithMaxHeaders(3)); err != nil || len(headers) != 3 {
+			t.Errorf("Headers at the limit should extract: %v, %v", headers, err)
+		}
+
+		_, err := ExtractHeaders(content, WithLimits(Limits{MaxHeaderLength: 4}))
+		if !errors.As(err, &limitErr) || limitErr.Limit != LimitMaxHeaderLength || limitErr.Size != 5 {
+			t.Errorf("Expected header length error, got %v", err)
+		}
+	})
+
+	t.Run("options struct", func(t *testing.T) {
+		opts := WithParseOptions(ParseOptions{
+			Limits:             Limits{MaxSize: 1 << 10, MaxFrontmatterSize: 64},
+			StrictYAML:         true,
+			FrontmatterFormats: []string{FormatYAML},
+		})
+		if _, _, err := ParseFrontmatter([]byte("---\ntitle: Doc\n---\n# Doc\n"), opts); err != nil {
+			t.Errorf("ParseFrontmatter failed: %v", err)
+		}
+		if _, err := ExtractMetadata([]byte("---\na: &x 1\nb: *x\n---\n"), opts); err == nil {
+			t.Error("Expected strict parsing from ParseOptions")
+		}
+	})
+}
+
+func TestNumberHeadings(t *testing.T) {
+	content := []byte(`---
+title: Standard
+---
+# Logging Standard
+
+See [scope](#1-2-scope) and [levels](#levels).
+
+## 1. Overview
+
+### 1.2 Scope
+
+### Goals
+
+## Levels
+
+#### Deep
+
+` + "```" + `
+## Not a header
+` + "```" + `
+`)
+
+	result, err := NumberHeadings(content, nil)
+	if err != nil {
+		t.Fatalf("NumberHeadings failed: %v", err)
+	}
+
+	headers, _ := ExtractHeaders([]byte(StripFrontmatter([]byte(result.Content))))
+	var got []string
+	for _, h := range headers {
+		got = append(got, h.Text)
+	}
+	want := []string{"Logging Standard", "1. Overview", "1.1 Scope", "1.2 Goals", "2. Levels", "2.0.1 Deep"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d headers, got %v", len(want), got)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("Header %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+
+	if !strings.Contains(result.Content, "title: Standard\n---\n") || !strings.Contains(result.Content, "## Not a header") {
+		t.Error("Frontmatter and code blocks should be unchanged")
+	}
+	if !strings.Contains(result.Content, "[scope](#1-1-scope)") || !strings.Contains(result.Content, "[levels](#2-levels)") {
+		t.Errorf("In-document links should follow renamed anchors:\n%s", result.Content)
+	}
+
+	if len(result.Changes) != 4 {
+		t.Fatalf("Expected 4 changes (Overview unchanged), got %+v", result.Changes)
+	}
+	if c := result.Changes[0]; c.LineNumber != 10 || c.OldAnchor != "1-2-scope" || c.NewAnchor != "1-1-scope" {
+		t.Errorf("Unexpected first change: %+v", c)
+	}
+
+	// Renumbering is idempotent
+	again, err := NumberHeadings([]byte(result.Content), nil)
+	if err != nil || again.Content != result.Content || len(again.Changes) != 0 {
+		t.Errorf("Expected no changes on second pass, got %+v (err %v)", again.Changes, err)
+	}
+
+	// Strip removes numbers and restores the original anchors
+	stripped, err := NumberHeadings([]byte(result.Content), &NumberingOptions{Strip: true, MaxLevel: 3, UpdateLinks: true})
+	if err != nil {
+		t.Fatalf("NumberHeadings strip failed: %v", err)
+	}
+	if !strings.Contains(stripped.Content, "## Overview\n") || !strings.Contains(stripped.Content, "[scope](#scope)") {
+		t.Errorf("Expected numbering stripped:\n%s", stripped.Content)
+	}
+	if !strings.Contains(stripped.Content, "#### 2.0.1 Deep") {
+		t.Error("Levels outside MinLevel..MaxLevel should be untouched")
+	}
+}
+
+func TestNumberHeadingsRepeatedTitles(t *testing.T) {
+	content := []byte("# Guide\n\nSee [first](#setup) and [second](#setup-1).\n\n## Setup\n\n## Usage\n\n### Setup\n\n" +
+		"```md\n[example](#setup)\n```\n")
+
+	result, err := NumberHeadings(content, nil)
+	if err != nil {
+		t.Fatalf("NumberHeadings failed: %v", err)
+	}
+	if !strings.Contains(result.Content, "[first](#1-setup) and [second](#2-1-setup)") {
+		t.Errorf("Links to repeated titles should follow their own header:\n%s", result.Content)
+	}
+	if !strings.Contains(result.Content, "```md\n[example](#setup)\n```") {
+		t.Errorf("Links inside fenced code should be untouched:\n%s", result.Content)
+	}
+	if c := result.Changes[2]; c.OldAnchor != "setup-1" || c.NewAnchor != "2-1-setup" {
+		t.Errorf("Unexpected change for the second Setup: %+v", c)
+	}
+
+	// Stripping makes the titles collide again; anchors are disambiguated
+	stripped, err := NumberHeadings([]byte(result.Content), &NumberingOptions{Strip: true, UpdateLinks: true})
+	if err != nil {
+		t.Fatalf("NumberHeadings strip failed: %v", err)
+	}
+	if stripped.Content != string(content) {
+		t.Errorf("Expected the original document back, got:\n%s", stripped.Content)
+	}
+}
+
+func TestSectionNumberRegexKeepsTitles(t *testing.T) {
+	for _, text := range []string{"2024 Roadmap", "v1.2 Changes", "Overview"} {
+		if sectionNumberRegex.MatchString(text) {
+			t.Errorf("%q should not be treated as numbered", text)
+		}
+	}
+}
+
+func TestDetectEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    Encoding
+	}{
+		{"plain", []byte("# Title\n\tcode\n"), Encoding{Charset: CharsetUTF8, ValidUTF8: true, LineEnding: LineEndingLF, IndentTabs: 1}},
+		{"windows", append([]byte{0xEF, 0xBB, 0xBF}, "---\r\ntitle: x\r\n---\r\n"...), Encoding{Charset: CharsetUTF8, BOM: true, ValidUTF8: true, LineEnding: LineEndingCRLF}},
+		{"mixed", []byte("a\r\nb\nc"), Encoding{Charset: CharsetUTF8, ValidUTF8: true, LineEnding: LineEndingMixed}},
+		{"single line", []byte("text"), Encoding{Charset: CharsetUTF8, ValidUTF8: true}},
+		{"invalid utf-8", []byte{'a', 0xFF, '\n'}, Encoding{Charset: CharsetUTF8, ValidUTF8: false, LineEnding: LineEndingLF}},
+		{"utf-16le", []byte{0xFF, 0xFE, 'a', 0, '\r', 0, '\n', 0}, Encoding{Charset: CharsetUTF16LE, BOM: true, ValidUTF8: true, LineEnding: LineEndingCRLF}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectEncoding(tt.content); got != tt.want {
+				t.Errorf("DetectEncoding() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	windows := append([]byte{0xEF, 0xBB, 0xBF}, "---\r\ntitle: Test\r\n---\r\n# Title\r\n\r\n\t- item\r\n"...)
+
+	out, err := Normalize(windows, nil)
+	if err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+	if string(out) != "---\ntitle: Test\n---\n# Title\n\n\t- item\n" {
+		t.Errorf("Unexpected normalized content: %q", out)
+	}
+
+	out, err = Normalize(windows, &NormalizeOptions{Tabs: TabsExpand, TabWidth: 2, KeepBOM: true})
+	if err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+	if !bytes.HasPrefix(out, []byte{0xEF, 0xBB, 0xBF}) || !bytes.HasSuffix(out, []byte("\n  - item\n")) {
+		t.Errorf("Expected BOM kept and tabs expanded: %q", out)
+	}
+
+	_, err = Normalize(windows, &NormalizeOptions{Tabs: TabsReject})
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.LineNumber != 6 || parseErr.Column != 1 {
+		t.Errorf("Expected ParseError at line 6, got %v", err)
+	}
+
+	out, err = Normalize([]byte("a\nb\n"), &NormalizeOptions{LineEnding: LineEndingCRLF})
+	if err != nil || string(out) != "a\r\nb\r\n" {
+		t.Errorf("Expected CRLF output, got %q (%v)", out, err)
+	}
+	if _, err := Normalize([]byte("a"), &NormalizeOptions{LineEnding: LineEndingCR}); err == nil {
+		t.Error("Expected error for unsupported line ending")
+	}
+
+	utf16 := []byte{0xFE, 0xFF, 0, 'h', 0, 'i', 0, '\n'}
+	if out, err := Normalize(utf16, nil); err != nil || string(out) != "hi\n" {
+		t.Errorf("Expected UTF-16 decoded, got %q (%v)", out, err)
+	}
+}
+
+func TestParseFrontmatterWithBOM(t *testing.T) {
+	content := append([]byte{0xEF, 0xBB, 0xBF}, "---\r\ntitle: Windows\r\n---\r\n# Body\r\n"...)
+
+	body, metadata, err := ParseFrontmatter(content)
+	if err != nil {
+		t.Fatalf("ParseFrontmatter failed: %v", err)
+	}
+	if metadata["title"] != "Windows" {
+		t.Errorf("Expected title from frontmatter behind a BOM, got %v", metadata)
+	}
+	if !strings.HasPrefix(body, "# Body") {
+		t.Errorf("Unexpected body: %q", body)
+	}
+
+	info, err := InspectDocument(content)
+	if err != nil || !info.HasFrontmatter {
+		t.Errorf("InspectDocument should detect frontmatter behind a BOM: %+v (%v)", info, err)
+	}
+}
+
+func TestLint(t *testing.T) {
+	content := []byte("---\ntitle: Guide\n---\n# Guide \n\nSee [docs](https://example.com) and [spec][ref].\n\n#### Deep\n\n# Second Title\n\nLine with hard break  \n\n```\n# not a header   \n```\n\n[ref]: https://example.com/spec\n")
+
+	diags, err := Lint(content, append(DefaultRuleSet(), RequiredFrontmatter("title", "status")))
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+
+	type finding struct {
+		rule string
+		line int
+	}
+	want := []finding{
+		{RuleRequiredFrontmatter, 3},
+		{RuleTrailingWhitespace, 4},
+		{RuleLinkStyle, 6},
+		{RuleHeaderIncrement, 8},
+		{RuleSingleH1, 10},
+		{RuleTrailingWhitespace, 15},
+	}
+	if len(diags) != len(want) {
+		t.Fatalf("Expected %d diagnostics, got %d: %+v", len(want), len(diags), diags)
+	}
+	for i, w := range want {
+		if diags[i].Rule != w.rule || diags[i].Line != w.line {
+			t.Errorf("Diagnostic %d: expected %s at line %d, got %s at line %d", i, w.rule, w.line, diags[i].Rule, diags[i].Line)
+		}
+	}
+
+	if d := diags[1]; d.Column != 8 || d.Edit == nil || d.Edit.StartColumn != 8 || d.Edit.EndColumn != 9 {
+		t.Errorf("Unexpected trailing whitespace diagnostic: %+v (edit %+v)", d, d.Edit)
+	}
+	if d := diags[2]; d.Column != 37 || !strings.Contains(d.Message, "reference link") {
+		t.Errorf("Expected the reference link flagged against the first (inline) style: %+v", d)
+	}
+	if e := diags[3].Edit; e == nil || e.NewText != "##" || e.EndColumn != 5 {
+		t.Errorf("Expected H4 -> H2 edit, got %+v", e)
+	}
+	if diags[0].Severity != SeverityError || !strings.Contains(diags[0].Message, "status") {
+		t.Errorf("Unexpected frontmatter diagnostic: %+v", diags[0])
+	}
+}
+
+func TestLintRules(t *testing.T) {
+	t.Run("max line length", func(t *testing.T) {
+		long := strings.Repeat("word ", 30)
+		content := []byte(long + "\n" + strings.Repeat("x", 200) + "\n| " + long + "|\n")
+		diags, _ := Lint(content, RuleSet{MaxLineLength(80)})
+		if len(diags) != 1 || diags[0].Line != 1 || diags[0].Column != 81 {
+			t.Errorf("Expected only the prose line flagged at column 81, got %+v", diags)
+		}
+	})
+
+	t.Run("missing frontmatter", func(t *testing.T) {
+		diags, _ := Lint([]byte("# Doc\n"), RuleSet{RequiredFrontmatter("title")})
+		if len(diags) != 1 || diags[0].Message != "document has no frontmatter" {
+			t.Errorf("Unexpected diagnostics: %+v", diags)
+		}
+	})
+
+	t.Run("malformed frontmatter", func(t *testing.T) {
+		diags, _ := Lint([]byte("---\ntitle: [unclosed\n---\n# Doc\n"), RuleSet{RequiredFrontmatter("title")})
+		if len(diags) != 1 || diags[0].Rule != RuleFrontmatterSyntax || diags[0].Severity != SeverityError {
+			t.Errorf("Expected only a frontmatter-syntax error, got %+v", diags)
+		}
+	})
+
+	t.Run("reference style", func(t *testing.T) {
+		diags, _ := Lint([]byte("[a](x) and `[b](y)` and [c][d]\n"), RuleSet{LinkStyle(LinkStyleReference)})
+		if len(diags) != 1 || diags[0].Column != 1 {
+			t.Errorf("Expected only the inline link outside code spans, got %+v", diags)
+		}
+	})
+
+	t.Run("custom rule", func(t *testing.T) {
+		noTODO := RuleFunc("no-todo", func(doc *LintContext) []Diagnostic {
+			var diags []Diagnostic
+			for i, line := range doc.Lines {
+				if col := strings.Index(line, "TODO"); col >= 0 && !doc.InCodeBlock(i+1) {
+					diags = append(diags, Diagnostic{Message: "unresolved TODO", Line: i + 1, Column: col + 1})
+				}
+			}
+			return diags
+		})
+		diags, _ := Lint([]byte("TODO: write\n```\nTODO in code\n```\n"), RuleSet{noTODO})
+		if len(diags) != 1 || diags[0].Rule != "no-todo" || diags[0].Severity != SeverityWarning {
+			t.Errorf("Expected custom rule diagnostic with defaults filled in, got %+v", diags)
+		}
+	})
+}
+
+func TestFix(t *testing.T) {
+	content := []byte("---\nstatus: draft\n# owner\ntags:\n  - a\ntitle: Guide\n---\n# Guide \n\n#### Deep\n\nSee [a](x) and [b][c].\n\n[c]: y")
+	rules := append(DefaultRuleSet(), FrontmatterOrder("title", "status"))
+
+	diags, err := Lint(content, rules)
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	result, err := Fix(content, diags)
+	if err != nil {
+		t.Fatalf("Fix failed: %v", err)
+	}
+
+	want := "---\ntitle: Guide\nstatus: draft\n# owner\ntags:\n  - a\n---\n# Guide\n\n## Deep\n\nSee [a](x) and [b][c].\n\n[c]: y\n"
+	if result.Content != want {
+		t.Errorf("Unexpected fixed content:\n%q\nwant:\n%q", result.Content, want)
+	}
+	if len(result.Applied) != 4 {
+		t.Errorf("Expected 4 applied fixes, got %+v", result.Applied)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Rule != RuleLinkStyle {
+		t.Errorf("Expected the link style diagnostic to be skipped, got %+v", result.Skipped)
+	}
+
+	again, _ := Lint([]byte(result.Content), rules)
+	if len(again) != 1 || again[0].Rule != RuleLinkStyle {
+		t.Errorf("Expected only the link style diagnostic after fixing, got %+v", again)
+	}
+
+	t.Run("preserves CRLF and BOM", func(t *testing.T) {
+		content := []byte("\xef\xbb\xbf# Title   \r\n\r\nText\t\r\n")
+		diags, _ := Lint(content, RuleSet{TrailingWhitespace()})
+		result, err := Fix(content, diags)
+		if err != nil {
+			t.Fatalf("Fix failed: %v", err)
+		}
+		if result.Content != "\xef\xbb\xbf# Title\r\n\r\nText\r\n" {
+			t.Errorf("Unexpected content: %q", result.Content)
+		}
+	})
+
+	t.Run("overlapping edits", func(t *testing.T) {
+		edit := &TextEdit{StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 3, NewText: "#"}
+		diags := []Diagnostic{{Rule: "a", Line: 1, Edit: edit}, {Rule: "b", Line: 1, Edit: edit}}
+		result, err := Fix([]byte("## Title\n"), diags)
+		if err != nil {
+			t.Fatalf("Fix failed: %v", err)
+		}
+		if result.Content != "# Title\n" || len(result.Applied) != 1 || len(result.Skipped) != 1 {
+			t.Errorf("Expected one edit applied and the overlap skipped, got %+v", result)
+		}
+	})
+
+	t.Run("stale diagnostics", func(t *testing.T) {
+		diags := []Diagnostic{{Rule: "a", Line: 5, Edit: &TextEdit{StartLine: 5, StartColumn: 1, EndLine: 5, EndColumn: 1}}}
+		if _, err := Fix([]byte("# Title\n"), diags); err == nil {
+			t.Error("Expected an error for an edit outside the document")
+		}
+	})
+}
+
+func TestBuildIndex(t *testing.T) {
+	docs := map[string][]byte{
+		"guides/setup.md":   []byte("---\ntitle: Setup Guide\ntags: [install, cli]\ndraft: true\n---\n# Setup\n\n## Install\n\n## Installing Plugins\n"),
+		"guides/usage.md":   []byte("# Usage\n\n## Reinstall Steps\n\n```\n# not a header\n```\n"),
+		"notes/untitled.md": []byte("Just text.\n"),
+	}
+
+	idx, err := BuildIndex(docs)
+	if err != nil {
+		t.Fatalf("BuildIndex failed: %v", err)
+	}
+	if len(idx.Documents) != 3 || idx.Documents[0].Path != "guides/setup.md" {
+		t.Fatalf("Expected 3 documents sorted by path, got %+v", idx.Documents)
+	}
+
+	titles := []string{"Setup Guide", "Usage", "untitled"}
+	for i, want := range titles {
+		if idx.Documents[i].Title != want {
+			t.Errorf("Document %s: expected title %q, got %q", idx.Documents[i].Path, want, idx.Documents[i].Title)
+		}
+	}
+
+	hits := idx.Search("install")
+	if len(hits) != 3 || hits[0].Text != "Install" || hits[1].Text != "Installing Plugins" || hits[2].Text != "Reinstall Steps" {
+		t.Errorf("Expected exact, prefix, then substring matches, got %+v", hits)
+	}
+	if hits[0].LineNumber != 8 || hits[0].Anchor != "install" || hits[0].Title != "Setup Guide" {
+		t.Errorf("Expected line numbers relative to the whole document, got %+v", hits[0])
+	}
+
+	if e, ok := idx.Lookup("guides/usage.md#reinstall-steps"); !ok || e.Level != 2 {
+		t.Errorf("Lookup by anchor failed: %+v, %v", e, ok)
+	}
+	if e, ok := idx.Lookup("guides/usage.md"); !ok || e.Text != "Usage" {
+		t.Errorf("Lookup by path failed: %+v, %v", e, ok)
+	}
+	if _, ok := idx.Lookup("guides/usage.md#missing"); ok {
+		t.Error("Lookup should fail for an unknown anchor")
+	}
+
+	if got := idx.Filter("tags", "cli"); len(got) != 1 || got[0].Path != "guides/setup.md" {
+		t.Errorf("Filter by list field failed: %+v", got)
+	}
+	if got := idx.Filter("draft", "true"); len(got) != 1 {
+		t.Errorf("Filter by boolean field failed: %+v", got)
+	}
+
+	data, err := json.Marshal(idx)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var loaded Index
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if e, ok := loaded.Lookup("guides/setup.md#installing-plugins"); !ok || e.LineNumber != 10 {
+		t.Errorf("Expected a round-tripped index to support lookups, got %+v, %v", e, ok)
+	}
+
+	_, err = BuildIndex(map[string][]byte{"bad.md": []byte("---\ntitle: [unclosed\n---\n# Bad\n")})
+	if err == nil || !strings.Contains(err.Error(), "bad.md") {
+		t.Errorf("Expected an error naming the malformed document, got %v", err)
+	}
+}
+
+func TestExtractDefinitions(t *testing.T) {
+	content := []byte("---\ntitle: Glossary\n---\n# Glossary\n\nCrucible\n: The single source of truth for schemas.\n\n- **Pathfinder**: File discovery.\n**SSOT:** Single source of truth.\n**Foundry** - Shared catalogs.\n\n```\n**NotATerm**: inside code\n```\n")
+
+	defs, err := ExtractDefinitions(content)
+	if err != nil {
+		t.Fatalf("ExtractDefinitions failed: %v", err)
+	}
+
+	want := []Definition{
+		{Term: "Crucible", Definition: "The single source of truth for schemas.", LineNumber: 6, Style: DefinitionList},
+		{Term: "Pathfinder", Definition: "File discovery.", LineNumber: 9, Style: DefinitionBold},
+		{Term: "SSOT", Definition: "Single source of truth.", LineNumber: 10, Style: DefinitionBold},
+		{Term: "Foundry", Definition: "Shared catalogs.", LineNumber: 11, Style: DefinitionBold},
+	}
+	if len(defs) != len(want) {
+		t.Fatalf("Expected %d definitions, got %+v", len(want), defs)
+	}
+	for i := range want {
+		if defs[i] != want[i] {
+			t.Errorf("Definition %d: expected %+v, got %+v", i, want[i], defs[i])
+		}
+	}
+}
+
+func TestCheckTerminology(t *testing.T) {
+	docs := map[string][]byte{
+		"glossary.md": []byte("# Glossary\n\n**gofulmen**: The Go helper library.\n**Crucible**: Shared standards.\n"),
+		"guide.md":    []byte("# Guide\n\nGofulmen wraps crucible data. Add it to the whitelist.\n\nSee `crucible` and [docs](https://crucible.dev).\n"),
+	}
+
+	results, err := CheckTerminology(docs, nil)
+	if err != nil {
+		t.Fatalf("CheckTerminology failed: %v", err)
+	}
+	if _, ok := results["glossary.md"]; ok {
+		t.Errorf("Expected no findings in the glossary itself, got %+v", results["glossary.md"])
+	}
+	diags := results["guide.md"]
+	if len(diags) != 1 || diags[0].Rule != RuleTermCase || diags[0].Column != 16 {
+		t.Fatalf("Expected only lowercase \"crucible\" flagged (sentence-start \"Gofulmen\", code spans, and links allowed), got %+v", diags)
+	}
+
+	fixed, err := Fix(docs["guide.md"], diags)
+	if err != nil || !strings.Contains(fixed.Content, "wraps Crucible data") {
+		t.Errorf("Expected the capitalization edit to apply, got %+v (%v)", fixed, err)
+	}
+
+	glossary := &Glossary{Terms: []string{"Crucible"}, Deprecated: map[string]string{"whitelist": "allowlist"}}
+	results, err = CheckTerminology(docs, glossary)
+	if err != nil {
+		t.Fatalf("CheckTerminology failed: %v", err)
+	}
+	diags = results["guide.md"]
+	if len(diags) != 2 || diags[1].Rule != RuleDeprecatedTerm || diags[1].Suggestion != `use "allowlist"` || diags[1].Edit != nil {
+		t.Errorf("Expected a deprecated-term warning without an edit, got %+v", diags)
+	}
+}
+
+func TestInjectBanners(t *testing.T) {
+	content := []byte(`---
+title: Logging Standard
+status: deprecated
+superseded_by: logging-v2.md
+---
+# Logging Standard
+
+Body text.
+`)
+
+	opts := DefaultBannerOptions()
+	opts.Badges = []Badge{StatusBadge(), {Field: "owner"}}
+	result, err := InjectBanners(content, &opts)
+	if err != nil {
+		t.Fatalf("InjectBanners failed: %v", err)
+	}
+
+	want := `# Logging Standard
+
+<!-- docscribe:banners -->
+![status: deprecated](https://img.shields.io/badge/status-deprecated-red)
+
+> [!WARNING]
+> This document is deprecated. Use [logging-v2.md](logging-v2.md) instead.
+<!-- /docscribe:banners -->
+
+Body text.
+`
+	if !result.Changed || !strings.HasSuffix(result.Content, want) {
+		t.Errorf("Unexpected content:\n%s", result.Content)
+	}
+	if len(result.Badges) != 1 {
+		t.Errorf("Expected only the status badge (owner unset), got %v", result.Badges)
+	}
+
+	// Injection is idempotent
+	again, err := InjectBanners([]byte(result.Content), &opts)
+	if err != nil || again.Changed || again.Content != result.Content {
+		t.Errorf("Expected no change on second pass, got:\n%s (err %v)", again.Content, err)
+	}
+
+	// A status change updates the block in place; a status without a banner removes it
+	updated := strings.Replace(result.Content, "status: deprecated", "status: Draft", 1)
+	again, err = InjectBanners([]byte(updated), nil)
+	if err != nil || !strings.Contains(again.Content, "> [!NOTE]\n> This document is a draft and may change. Use [logging-v2.md]") ||
+		strings.Count(again.Content, "docscribe:banners") != 2 {
+		t.Errorf("Expected the draft banner to replace the previous block:\n%s", again.Content)
+	}
+	stable := strings.Replace(result.Content, "status: deprecated", "status: stable", 1)
+	again, err = InjectBanners([]byte(stable), nil)
+	if err != nil || again.Content != strings.Replace(string(content), "status: deprecated", "status: stable", 1) {
+		t.Errorf("Expected the block to be removed:\n%s", again.Content)
+	}
+}
+
+func TestInjectBannersPlacement(t *testing.T) {
+	opts := &BannerOptions{Badges: []Badge{{Field: "owner", Label: "team owner", Link: "https://example.com"}}}
+
+	// Setext H1, with a marker in a code block that must be ignored
+	content := []byte("---\nowner: docs-team\n---\nTitle\n=====\n```\n<!-- docscribe:banners -->\n```\n")
+	result, err := InjectBanners(content, opts)
+	if err != nil {
+		t.Fatalf("InjectBanners failed: %v", err)
+	}
+	badge := "[![team owner: docs-team](https://img.shields.io/badge/team_owner-docs--team-blue)](https://example.com)"
+	if !strings.Contains(result.Content, "Title\n=====\n\n<!-- docscribe:banners -->\n"+badge+"\n<!-- /docscribe:banners -->\n\n```") {
+		t.Errorf("Expected badges under the Setext title:\n%s", result.Content)
+	}
+
+	// Without an H1 the block opens the body; CRLF line endings are kept
+	content = []byte("---\r\nowner: docs\r\n---\r\nIntro.\r\n")
+	result, err = InjectBanners(content, opts)
+	if err != nil {
+		t.Fatalf("InjectBanners failed: %v", err)
+	}
+	if !strings.HasPrefix(result.Content, "---\r\nowner: docs\r\n---\r\n\r\n<!-- docscribe:banners -->\r\n") {
+		t.Errorf("Expected the block at the top of the body:\n%q", result.Content)
+	}
+
+	// Errors
+	if _, err := InjectBanners([]byte("# T\n\n<!-- docscribe:banners -->\n"), nil); err == nil {
+		t.Error("Expected an error for an unterminated banner block")
+	}
+	var parseErr *ParseError
+	if _, err := InjectBanners([]byte("---\nstatus: [draft\n---\n# T\n"), nil); !errors.As(err, &parseErr) {
+		t.Errorf("Expected ParseError for malformed frontmatter, got %v", err)
+	}
+}
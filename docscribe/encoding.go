@@ -0,0 +1,289 @@
+package docscribe
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Character sets reported by DetectEncoding.
+const (
+	CharsetUTF8    = "utf-8"
+	CharsetUTF16LE = "utf-16le"
+	CharsetUTF16BE = "utf-16be"
+)
+
+// Line ending styles reported by DetectEncoding and accepted by NormalizeOptions.
+const (
+	LineEndingLF    = "lf"
+	LineEndingCRLF  = "crlf"
+	LineEndingCR    = "cr"
+	LineEndingMixed = "mixed"
+)
+
+// TabPolicy controls how Normalize treats tab characters in indentation.
+type TabPolicy string
+
+const (
+	// TabsKeep leaves tabs untouched.
+	TabsKeep TabPolicy = "keep"
+	// TabsExpand replaces tabs in leading indentation with spaces.
+	TabsExpand TabPolicy = "expand"
+	// TabsReject returns a ParseError for the first indentation tab.
+	TabsReject TabPolicy = "reject"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// Encoding describes the byte-level encoding of a document.
+type Encoding struct {
+	// Charset is the character set: "utf-8", "utf-16le", or "utf-16be"
+	// (UTF-16 is recognized by its byte order mark only)
+	Charset string `json:"charset"`
+
+	// BOM indicates a byte order mark precedes the content
+	BOM bool `json:"bom"`
+
+	// ValidUTF8 is false when UTF-8 content contains invalid sequences
+	ValidUTF8 bool `json:"valid_utf8"`
+
+	// LineEnding is "lf", "crlf", "cr", "mixed", or "" when the content has no line breaks
+	LineEnding string `json:"line_ending"`
+
+	// IndentTabs is the number of lines whose leading indentation contains a tab
+	IndentTabs int `json:"indent_tabs"`
+}
+
+// DetectEncoding reports the charset, byte order mark, line endings, and tab
+// indentation of content. UTF-16 content is decoded before line endings and
+// tabs are examined.
+//
+// Example:
+//
+//	enc := docscribe.DetectEncoding(content)
+//	if enc.BOM || enc.LineEnding != docscribe.LineEndingLF {
+//	    content, _ = docscribe.Normalize(content, nil)
+//	}
+func DetectEncoding(content []byte) Encoding {
+	enc := Encoding{Charset: CharsetUTF8, ValidUTF8: true}
+
+	text := content
+	switch {
+	case bytes.HasPrefix(content, bomUTF8):
+		enc.BOM = true
+		text = content[len(bomUTF8):]
+	case bytes.HasPrefix(content, bomUTF16LE):
+		enc.Charset, enc.BOM = CharsetUTF16LE, true
+		text = decodeUTF16(content[2:], false)
+	case bytes.HasPrefix(content, bomUTF16BE):
+		enc.Charset, enc.BOM = CharsetUTF16BE, true
+		text = decodeUTF16(content[2:], true)
+	}
+	if enc.Charset == CharsetUTF8 {
+		enc.ValidUTF8 = utf8.Valid(text)
+	}
+
+	var lf, crlf, cr int
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\n':
+			lf++
+		case '\r':
+			if i+1 < len(text) && text[i+1] == '\n' {
+				crlf++
+				i++
+			} else {
+				cr++
+			}
+		}
+	}
+	switch {
+	case lf+crlf+cr == 0:
+		// no line breaks
+	case lf > 0 && crlf == 0 && cr == 0:
+		enc.LineEnding = LineEndingLF
+	case crlf > 0 && lf == 0 && cr == 0:
+		enc.LineEnding = LineEndingCRLF
+	case cr > 0 && lf == 0 && crlf == 0:
+		enc.LineEnding = LineEndingCR
+	default:
+		enc.LineEnding = LineEndingMixed
+	}
+
+	for _, line := range bytes.Split(text, []byte("\n")) {
+		if tabInIndent(line) >= 0 {
+			enc.IndentTabs++
+		}
+	}
+	return enc
+}
+
+// NormalizeOptions configures Normalize.
+type NormalizeOptions struct {
+	// KeepBOM preserves a UTF-8 byte order mark. Default: false (stripped)
+	KeepBOM bool
+
+	// LineEnding is the line ending to write: "lf" or "crlf". Default: "lf"
+	LineEnding string
+
+	// Tabs is the policy for tabs in leading indentation. Default: TabsKeep
+	Tabs TabPolicy
+
+	// TabWidth is the tab stop used by TabsExpand. Default: 4
+	TabWidth int
+}
+
+// DefaultNormalizeOptions returns the default normalization options.
+func DefaultNormalizeOptions() NormalizeOptions {
+	return NormalizeOptions{
+		LineEnding: LineEndingLF,
+		Tabs:       TabsKeep,
+		TabWidth:   4,
+	}
+}
+
+// Normalize converts content to UTF-8 with consistent line endings: UTF-16
+// input is decoded, the byte order mark is stripped, CRLF and lone CR line
+// breaks become opts.LineEnding, and indentation tabs follow opts.Tabs. opts
+// may be nil for DefaultNormalizeOptions.
+//
+// Returns ParseError if opts.Tabs is TabsReject and an indentation tab is found,
+// or an error for an unsupported option value.
+//
+// Example:
+//
+//	clean, err := docscribe.Normalize(upload, &docscribe.NormalizeOptions{Tabs: docscribe.TabsExpand})
+//	if err != nil {
+//	    return err
+//	}
+//	body, metadata, err := docscribe.ParseFrontmatter(clean)
+func Normalize(content []byte, opts *NormalizeOptions) ([]byte, error) {
+	options := DefaultNormalizeOptions()
+	if opts != nil {
+		options = *opts
+	}
+	if options.LineEnding == "" {
+		options.LineEnding = LineEndingLF
+	}
+	if options.Tabs == "" {
+		options.Tabs = TabsKeep
+	}
+	if options.TabWidth <= 0 {
+		options.TabWidth = 4
+	}
+
+	var eol string
+	switch options.LineEnding {
+	case LineEndingLF:
+		eol = "\n"
+	case LineEndingCRLF:
+		eol = "\r\n"
+	default:
+		return nil, fmt.Errorf("unsupported line ending %q (must be %s or %s)", options.LineEnding, LineEndingLF, LineEndingCRLF)
+	}
+	switch options.Tabs {
+	case TabsKeep, TabsExpand, TabsReject:
+	default:
+		return nil, fmt.Errorf("unsupported tab policy %q", options.Tabs)
+	}
+
+	var bom bool
+	text := content
+	switch {
+	case bytes.HasPrefix(content, bomUTF8):
+		bom = true
+		text = content[len(bomUTF8):]
+	case bytes.HasPrefix(content, bomUTF16LE):
+		text = decodeUTF16(content[2:], false)
+	case bytes.HasPrefix(content, bomUTF16BE):
+		text = decodeUTF16(content[2:], true)
+	}
+
+	normalized := strings.ReplaceAll(string(text), "\r\n", "\n")
+	normalized = strings.ReplaceAll(normalized, "\r", "\n")
+	lines := strings.Split(normalized, "\n")
+
+	for i, line := range lines {
+		col := tabInIndent([]byte(line))
+		if col < 0 {
+			continue
+		}
+		switch options.Tabs {
+		case TabsReject:
+			return nil, newParseErrorWithLocation("tab character in indentation", i+1, col+1)
+		case TabsExpand:
+			lines[i] = expandIndentTabs(line, options.TabWidth)
+		}
+	}
+
+	var out bytes.Buffer
+	if bom && options.KeepBOM {
+		out.Write(bomUTF8)
+	}
+	out.WriteString(strings.Join(lines, eol))
+	return out.Bytes(), nil
+}
+
+// tabInIndent returns the byte offset of the first tab in line's leading
+// whitespace, or -1 if there is none (or the line is blank).
+func tabInIndent(line []byte) int {
+	for i, b := range line {
+		switch b {
+		case '\t':
+			if len(bytes.TrimSpace(line)) == 0 {
+				return -1
+			}
+			return i
+		case ' ':
+		default:
+			return -1
+		}
+	}
+	return -1
+}
+
+// expandIndentTabs replaces tabs in leading whitespace with spaces up to the
+// next tab stop.
+func expandIndentTabs(line string, width int) string {
+	var sb strings.Builder
+	col := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\t':
+			n := width - col%width
+			sb.WriteString(strings.Repeat(" ", n))
+			col += n
+		case ' ':
+			sb.WriteByte(' ')
+			col++
+		default:
+			sb.WriteString(line[i:])
+			return sb.String()
+		}
+	}
+	return sb.String()
+}
+
+// decodeUTF16 converts UTF-16 bytes (without BOM) to UTF-8.
+func decodeUTF16(b []byte, bigEndian bool) []byte {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(b[2*i])<<8 | uint16(b[2*i+1])
+		} else {
+			units[i] = uint16(b[2*i+1])<<8 | uint16(b[2*i])
+		}
+	}
+	return []byte(string(utf16.Decode(units)))
+}
+
+// trimBOM removes a leading UTF-8 byte order mark.
+func trimBOM(content []byte) []byte {
+	return bytes.TrimPrefix(content, bomUTF8)
+}
@@ -0,0 +1,62 @@
+package docscribe
+
+import (
+	"bytes"
+)
+
+// Encryption scheme identifiers reported by DetectEncryption and EncryptedContentError.
+const (
+	EncryptionSOPS = "sops"
+	EncryptionAge  = "age"
+)
+
+var (
+	// ageBinaryHeader is the first line of a binary age payload.
+	ageBinaryHeader = []byte("age-encryption.org/v1")
+
+	// ageArmorHeader is the first line of an ASCII-armored age payload.
+	ageArmorHeader = []byte("-----BEGIN AGE ENCRYPTED FILE-----")
+
+	// sopsValueMarker prefixes every value SOPS encrypts in place.
+	sopsValueMarker = []byte("ENC[")
+)
+
+// DetectEncryption reports the encryption scheme applied to content, or "" if
+// the content does not appear to be encrypted.
+//
+// Detection is marker-based and never attempts decryption:
+//   - age: content begins with the binary or ASCII-armored age header
+//   - sops: a top-level "sops" key is present alongside ENC[...] values
+//     (YAML, JSON, or YAML frontmatter)
+func DetectEncryption(content []byte) string {
+	trimmed := bytes.TrimLeft(content, " \t\r\n")
+	if bytes.HasPrefix(trimmed, ageBinaryHeader) || bytes.HasPrefix(trimmed, ageArmorHeader) {
+		return EncryptionAge
+	}
+
+	if hasSOPSMarkers(content) {
+		return EncryptionSOPS
+	}
+
+	return ""
+}
+
+// hasSOPSMarkers checks for the SOPS metadata key and at least one encrypted value.
+func hasSOPSMarkers(content []byte) bool {
+	if !bytes.Contains(content, sopsValueMarker) {
+		return false
+	}
+
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		// YAML: top-level "sops:" key (no indentation)
+		if bytes.HasPrefix(line, []byte("sops:")) {
+			return true
+		}
+		// JSON: "sops" object key at any indentation
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte(`"sops":`)) {
+			return true
+		}
+	}
+
+	return false
+}
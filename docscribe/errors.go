@@ -117,6 +117,24 @@ func (e *FormatError) Unwrap() error {
 	return e.Underlying
 }
 
+// EncryptedContentError indicates content (or its frontmatter) is encrypted and
+// cannot be parsed until it is decrypted. Pipelines can detect this with
+// errors.As and route the document to a decryption step.
+type EncryptedContentError struct {
+	// Scheme identifies the encryption tool (EncryptionSOPS or EncryptionAge)
+	Scheme string
+
+	// Frontmatter is true when only the frontmatter block is encrypted
+	Frontmatter bool
+}
+
+func (e *EncryptedContentError) Error() string {
+	if e.Frontmatter {
+		return "encrypted content: frontmatter is " + e.Scheme + "-encrypted"
+	}
+	return "encrypted content: document is " + e.Scheme + "-encrypted"
+}
+
 // newParseError creates a ParseError with the given message.
 func newParseError(message string) *ParseError {
 	return &ParseError{
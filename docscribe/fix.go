@@ -0,0 +1,119 @@
+package docscribe
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FixResult is the output of Fix.
+type FixResult struct {
+	// Content is the rewritten document.
+	Content string `json:"content"`
+
+	// Applied lists the diagnostics whose edits were applied, in document order.
+	Applied []Diagnostic `json:"applied"`
+
+	// Skipped lists the diagnostics left for manual review: those without an
+	// edit, and those whose edit overlaps one applied earlier in the document.
+	// Run Lint again after Fix to pick up any that remain.
+	Skipped []Diagnostic `json:"skipped"`
+}
+
+// Fix applies the edits attached to diagnostics (as returned by Lint for the
+// same content) and returns the rewritten document with a report of what was
+// applied and skipped. Built-in rules attach edits only for safe fixes:
+// trailing whitespace, header level normalization (single-h1,
+// header-increment), a missing final newline, and frontmatter key ordering.
+// Edits are applied in document order; an edit overlapping an earlier one is
+// skipped. The byte order mark and CRLF line endings of content are preserved.
+//
+// Example:
+//
+//	rules := append(docscribe.DefaultRuleSet(), docscribe.FrontmatterOrder("title", "status"))
+//	diags, err := docscribe.Lint(content, rules)
+//	if err != nil {
+//	    return err
+//	}
+//	result, err := docscribe.Fix(content, diags)
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Printf("fixed %d, %d need review\n", len(result.Applied), len(result.Skipped))
+//	os.WriteFile(path, []byte(result.Content), 0644)
+//
+// Returns an error if an edit lies outside content, which means the
+// diagnostics were produced for a different version of the document.
+func Fix(content []byte, diagnostics []Diagnostic) (*FixResult, error) {
+	text := strings.ReplaceAll(string(trimBOM(content)), "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+
+	// Byte offset of each line start in text
+	starts := make([]int, len(lines))
+	for i := 1; i < len(lines); i++ {
+		starts[i] = starts[i-1] + len(lines[i-1]) + 1
+	}
+	offset := func(line, col int) (int, bool) {
+		if line < 1 || line > len(lines) || col < 1 || col > len(lines[line-1])+1 {
+			return 0, false
+		}
+		return starts[line-1] + col - 1, true
+	}
+
+	type fix struct {
+		start, end int
+		diag       Diagnostic
+	}
+	result := &FixResult{}
+	var fixes []fix
+	for _, d := range diagnostics {
+		if d.Edit == nil {
+			result.Skipped = append(result.Skipped, d)
+			continue
+		}
+		start, okStart := offset(d.Edit.StartLine, d.Edit.StartColumn)
+		end, okEnd := offset(d.Edit.EndLine, d.Edit.EndColumn)
+		if !okStart || !okEnd || end < start {
+			return nil, fmt.Errorf("%s diagnostic at line %d: edit range %d:%d-%d:%d is outside the document",
+				d.Rule, d.Line, d.Edit.StartLine, d.Edit.StartColumn, d.Edit.EndLine, d.Edit.EndColumn)
+		}
+		fixes = append(fixes, fix{start, end, d})
+	}
+
+	sort.SliceStable(fixes, func(i, j int) bool {
+		if fixes[i].start != fixes[j].start {
+			return fixes[i].start < fixes[j].start
+		}
+		return fixes[i].end < fixes[j].end
+	})
+
+	var sb strings.Builder
+	pos, lastInsert := 0, -1
+	for _, f := range fixes {
+		// Two insertions at the same point are ambiguous, so only the first wins
+		if f.start < pos || f.start == lastInsert {
+			result.Skipped = append(result.Skipped, f.diag)
+			continue
+		}
+		sb.WriteString(text[pos:f.start])
+		sb.WriteString(f.diag.Edit.NewText)
+		pos, lastInsert = f.end, -1
+		if f.start == f.end {
+			lastInsert = f.start
+		}
+		result.Applied = append(result.Applied, f.diag)
+	}
+	sb.WriteString(text[pos:])
+
+	out := sb.String()
+	if DetectEncoding(content).LineEnding == LineEndingCRLF {
+		out = strings.ReplaceAll(out, "\n", "\r\n")
+	}
+	if len(content) != len(trimBOM(content)) {
+		out = string(bomUTF8) + out
+	}
+	result.Content = out
+
+	sortDiagnostics(result.Skipped)
+	return result, nil
+}
@@ -2,8 +2,11 @@ package docscribe
 
 import (
 	"bytes"
+	"encoding/json"
+	"slices"
 	"strings"
 
+	"github.com/fulmenhq/gofulmen/schema"
 	"gopkg.in/yaml.v3"
 )
 
@@ -19,7 +22,8 @@ const (
 // document. If frontmatter is found, it returns:
 //   - body: The document content with frontmatter removed
 //   - metadata: The parsed YAML frontmatter as a map
-//   - error: nil on success, ParseError if YAML is malformed
+//   - error: nil on success, ParseError if YAML is malformed,
+//     EncryptedContentError if the frontmatter or document is encrypted
 //
 // If no frontmatter is present, returns:
 //   - body: The original content unchanged
@@ -40,7 +44,22 @@ const (
 // Returns:
 //   - body: "# My Document\n\nThis is the content."
 //   - metadata: map[string]interface{}{"title": "My Document", "author": "Jane Doe", ...}
-func ParseFrontmatter(content []byte) (string, map[string]interface{}, error) {
+//
+// Options (WithMaxSize, WithMaxFrontmatterSize, WithParseTimeout) override the
+// process-wide Limits; LimitExceededError is returned when a limit is
+// exceeded. WithStrictYAML and WithFrontmatterFormats tighten frontmatter
+// parsing; see ParseOptions.
+func ParseFrontmatter(content []byte, opts ...Option) (string, map[string]interface{}, error) {
+	guard, err := newParseGuard(content, opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Whole-document encryption (age) has no parseable structure
+	if scheme := DetectEncryption(content); scheme == EncryptionAge {
+		return string(content), nil, &EncryptedContentError{Scheme: scheme}
+	}
+
 	// Fast path: check if content could have frontmatter
 	if !hasFrontmatter(content) {
 		return string(content), nil, nil
@@ -52,8 +71,16 @@ func ParseFrontmatter(content []byte) (string, map[string]interface{}, error) {
 		return string(content), nil, nil
 	}
 
-	// Parse the YAML frontmatter
-	metadata, err := parseFrontmatterYAML(yamlBlock)
+	// SOPS-encrypted frontmatter parses as YAML but its values are ciphertext
+	if hasSOPSMarkers(yamlBlock) {
+		return string(body), nil, &EncryptedContentError{Scheme: EncryptionSOPS, Frontmatter: true}
+	}
+
+	if err := guard.check(); err != nil {
+		return "", nil, err
+	}
+
+	metadata, err := decodeFrontmatter(yamlBlock, resolveParseOptions(opts))
 	if err != nil {
 		return string(body), nil, err
 	}
@@ -67,6 +94,10 @@ func ParseFrontmatter(content []byte) (string, map[string]interface{}, error) {
 //
 // Returns nil if no frontmatter is present.
 // Returns ParseError if frontmatter exists but YAML is malformed.
+// Returns EncryptedContentError if the frontmatter or document is encrypted.
+// Returns LimitExceededError if the content exceeds the configured Limits.
+// Returns FormatError if the frontmatter format is not in
+// ParseOptions.FrontmatterFormats.
 //
 // Example:
 //
@@ -78,7 +109,16 @@ func ParseFrontmatter(content []byte) (string, map[string]interface{}, error) {
 //	    title := metadata["title"].(string)
 //	    fmt.Printf("Document title: %s\n", title)
 //	}
-func ExtractMetadata(content []byte) (map[string]interface{}, error) {
+func ExtractMetadata(content []byte, opts ...Option) (map[string]interface{}, error) {
+	guard, err := newParseGuard(content, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheme := DetectEncryption(content); scheme == EncryptionAge {
+		return nil, &EncryptedContentError{Scheme: scheme}
+	}
+
 	// Fast path: check if content could have frontmatter
 	if !hasFrontmatter(content) {
 		return nil, nil
@@ -90,8 +130,15 @@ func ExtractMetadata(content []byte) (map[string]interface{}, error) {
 		return nil, nil
 	}
 
-	// Parse the YAML frontmatter
-	metadata, err := parseFrontmatterYAML(yamlBlock)
+	if hasSOPSMarkers(yamlBlock) {
+		return nil, &EncryptedContentError{Scheme: EncryptionSOPS, Frontmatter: true}
+	}
+
+	if err := guard.check(); err != nil {
+		return nil, err
+	}
+
+	metadata, err := decodeFrontmatter(yamlBlock, resolveParseOptions(opts))
 	if err != nil {
 		return nil, err
 	}
@@ -128,8 +175,8 @@ func StripFrontmatter(content []byte) string {
 // hasFrontmatter performs a fast check to see if content might contain frontmatter.
 // This avoids expensive parsing for content that clearly has no frontmatter.
 func hasFrontmatter(content []byte) bool {
-	// Must start with "---" (possibly after leading whitespace)
-	trimmed := bytes.TrimLeft(content, " \t")
+	// Must start with "---" (possibly after a UTF-8 BOM and leading whitespace)
+	trimmed := bytes.TrimLeft(trimBOM(content), " \t")
 	return bytes.HasPrefix(trimmed, []byte(frontmatterDelimiter))
 }
 
@@ -139,10 +186,10 @@ func hasFrontmatter(content []byte) bool {
 //   - body: The remaining content after frontmatter
 //   - found: true if frontmatter was found and extracted
 //
-// The frontmatter must be at the very start of the document (after optional leading whitespace)
-// and must be properly delimited by "---" on separate lines.
+// The frontmatter must be at the very start of the document (after an optional UTF-8 BOM
+// and leading whitespace) and must be properly delimited by "---" on separate lines.
 func extractFrontmatterBlock(content []byte) (yamlBlock []byte, body []byte, found bool) {
-	lines := bytes.Split(content, []byte("\n"))
+	lines := bytes.Split(trimBOM(content), []byte("\n"))
 	if len(lines) < 3 {
 		// Need at minimum: "---", yaml content, "---"
 		return nil, content, false
@@ -219,3 +266,46 @@ func parseFrontmatterYAML(yamlContent []byte) (map[string]interface{}, error) {
 
 	return metadata, nil
 }
+
+// decodeFrontmatter checks a frontmatter block against options and parses it.
+func decodeFrontmatter(block []byte, options ParseOptions) (map[string]interface{}, error) {
+	if options.MaxFrontmatterSize > 0 && int64(len(block)) > options.MaxFrontmatterSize {
+		return nil, &LimitExceededError{Limit: LimitMaxFrontmatterSize, Size: int64(len(block)), MaxSize: options.MaxFrontmatterSize}
+	}
+
+	format := frontmatterFormat(block)
+	if len(options.FrontmatterFormats) > 0 && !slices.Contains(options.FrontmatterFormats, format) {
+		return nil, newFormatError(strings.Join(options.FrontmatterFormats, " or "), format, "frontmatter format not allowed")
+	}
+	if options.StrictYAML && format == FormatYAML {
+		if err := checkStrictYAML(block); err != nil {
+			return nil, err
+		}
+	}
+	return parseFrontmatterYAML(block)
+}
+
+// frontmatterFormat reports FormatJSON for a block holding a JSON object and
+// FormatYAML otherwise. JSON is a subset of YAML, so both parse the same way.
+func frontmatterFormat(block []byte) string {
+	trimmed := bytes.TrimSpace(block)
+	if len(trimmed) > 0 && trimmed[0] == '{' && json.Valid(trimmed) {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// checkStrictYAML rejects anything schema.CheckYAML reports (duplicate keys,
+// tab indentation, anchors, aliases, explicit tags, non-string mapping keys)
+// except YAML 1.1 ambiguous scalars.
+func checkStrictYAML(yamlContent []byte) error {
+	diags, err := schema.CheckYAML(yamlContent, &schema.YAMLOptions{AllowAmbiguousScalars: true})
+	if err != nil {
+		return wrapParseError("invalid frontmatter YAML", err)
+	}
+	if len(diags) == 0 {
+		return nil
+	}
+	d := diags[0]
+	return newParseErrorWithLocation("strict frontmatter YAML: "+d.Message, d.Location.Line, d.Location.Column)
+}
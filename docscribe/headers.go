@@ -31,9 +31,27 @@ var atxHeaderRegex = regexp.MustCompile(`^(#{1,6})\s+(.+?)(?:\s+#*)?$`)
 //	        strings.Repeat("#", h.Level), h.Text, h.Anchor, h.LineNumber)
 //	}
 //
-// Returns a slice of Header structs, or an error if content cannot be processed.
-func ExtractHeaders(content []byte) ([]Header, error) {
+// Returns a slice of Header structs, or an error if content cannot be processed
+// (LimitExceededError when the content exceeds the configured Limits,
+// including MaxHeaders and MaxHeaderLength).
+func ExtractHeaders(content []byte, opts ...Option) ([]Header, error) {
+	guard, err := newParseGuard(content, opts)
+	if err != nil {
+		return nil, err
+	}
+	limits := resolveParseOptions(opts).Limits
+
 	var headers []Header
+	addHeader := func(h Header) error {
+		if limits.MaxHeaderLength > 0 && len(h.Text) > limits.MaxHeaderLength {
+			return &LimitExceededError{Limit: LimitMaxHeaderLength, Size: int64(len(h.Text)), MaxSize: int64(limits.MaxHeaderLength)}
+		}
+		if limits.MaxHeaders > 0 && len(headers) >= limits.MaxHeaders {
+			return &LimitExceededError{Limit: LimitMaxHeaders, Size: int64(len(headers) + 1), MaxSize: int64(limits.MaxHeaders)}
+		}
+		headers = append(headers, h)
+		return nil
+	}
 	lines := bytes.Split(content, []byte("\n"))
 
 	inCodeBlock := false
@@ -43,6 +61,10 @@ func ExtractHeaders(content []byte) ([]Header, error) {
 		line := lines[i]
 		lineNum := i + 1 // 1-based line numbers
 
+		if err := guard.line(); err != nil {
+			return nil, err
+		}
+
 		// Track code block state
 		if isCodeBlockFence(line) {
 			fence := getCodeBlockFence(line)
@@ -65,7 +87,9 @@ func ExtractHeaders(content []byte) ([]Header, error) {
 
 		// Try ATX-style header first (# Header)
 		if header, found := parseATXHeader(line, lineNum); found {
-			headers = append(headers, header)
+			if err := addHeader(header); err != nil {
+				return nil, err
+			}
 			continue
 		}
 
@@ -73,7 +97,9 @@ func ExtractHeaders(content []byte) ([]Header, error) {
 		// Need to look at next line for underline
 		if i+1 < len(lines) {
 			if header, found := parseSetextHeader(line, lines[i+1], lineNum); found {
-				headers = append(headers, header)
+				if err := addHeader(header); err != nil {
+					return nil, err
+				}
 				i++ // Skip the underline line
 				continue
 			}
@@ -0,0 +1,249 @@
+package docscribe
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Index is a searchable index of titles, headers, anchors, and frontmatter
+// across a document set, built by BuildIndex. It serializes with
+// encoding/json, so CLIs can build it once and load it for lookups.
+type Index struct {
+	// Documents are the indexed documents, sorted by path.
+	Documents []IndexedDocument `json:"documents"`
+}
+
+// IndexedDocument is one document in an Index.
+type IndexedDocument struct {
+	// Path is the key the document was supplied under (e.g., "guides/setup.md").
+	Path string `json:"path"`
+
+	// Title is the frontmatter "title", else the first H1, else the file name
+	// without extension.
+	Title string `json:"title"`
+
+	// Headers are the document's headers; LineNumber is relative to the whole
+	// document, including frontmatter.
+	Headers []Header `json:"headers,omitempty"`
+
+	// Frontmatter is the parsed frontmatter (nil if absent or encrypted).
+	Frontmatter map[string]interface{} `json:"frontmatter,omitempty"`
+
+	// Encrypted indicates the frontmatter (or whole document) is encrypted;
+	// encrypted parts are not indexed.
+	Encrypted bool `json:"encrypted,omitempty"`
+}
+
+// IndexEntry is a search result: a document title (Level 0, no Anchor) or a
+// header within a document.
+type IndexEntry struct {
+	Path       string `json:"path"`
+	Title      string `json:"title"`
+	Text       string `json:"text"`
+	Anchor     string `json:"anchor,omitempty"`
+	Level      int    `json:"level"`
+	LineNumber int    `json:"line_number"`
+}
+
+// BuildIndex indexes docs, keyed by path. Documents with encrypted frontmatter
+// are indexed by their headers only; age-encrypted documents by path only.
+//
+// Example:
+//
+//	idx, err := docscribe.BuildIndex(docs)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, e := range idx.Search("install") {
+//	    fmt.Printf("%s#%s  %s\n", e.Path, e.Anchor, e.Text)
+//	}
+//	data, _ := json.Marshal(idx) // cache for later runs
+//
+// Returns an error naming the path of the first document whose frontmatter
+// is malformed or that exceeds the configured Limits.
+func BuildIndex(docs map[string][]byte, opts ...Option) (*Index, error) {
+	paths := make([]string, 0, len(docs))
+	for p := range docs {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	idx := &Index{Documents: make([]IndexedDocument, 0, len(paths))}
+	for _, p := range paths {
+		doc, err := indexDocument(p, docs[p], opts)
+		if err != nil {
+			return nil, fmt.Errorf("index %s: %w", p, err)
+		}
+		idx.Documents = append(idx.Documents, doc)
+	}
+	return idx, nil
+}
+
+// indexDocument extracts the title, headers, and frontmatter of one document.
+func indexDocument(p string, content []byte, opts []Option) (IndexedDocument, error) {
+	doc := IndexedDocument{Path: p}
+
+	_, metadata, err := ParseFrontmatter(content, opts...)
+	var encErr *EncryptedContentError
+	switch {
+	case errors.As(err, &encErr):
+		doc.Encrypted = true
+		if !encErr.Frontmatter {
+			doc.Title = fileTitle(p)
+			return doc, nil
+		}
+	case err != nil:
+		return doc, err
+	}
+	doc.Frontmatter = metadata
+
+	body, offset := splitFrontmatter(content)
+	headers, err := ExtractHeaders(body, opts...)
+	if err != nil {
+		return doc, err
+	}
+	for _, h := range headers {
+		h.LineNumber += offset
+		doc.Headers = append(doc.Headers, h)
+	}
+
+	if title, ok := metadata["title"].(string); ok && strings.TrimSpace(title) != "" {
+		doc.Title = strings.TrimSpace(title)
+	}
+	for _, h := range doc.Headers {
+		if doc.Title != "" {
+			break
+		}
+		if h.Level == 1 {
+			doc.Title = h.Text
+		}
+	}
+	if doc.Title == "" {
+		doc.Title = fileTitle(p)
+	}
+	return doc, nil
+}
+
+// fileTitle returns the base name of p without its extension.
+func fileTitle(p string) string {
+	base := path.Base(p)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// Document returns the indexed document at path.
+func (idx *Index) Document(p string) (IndexedDocument, bool) {
+	i := sort.Search(len(idx.Documents), func(i int) bool { return idx.Documents[i].Path >= p })
+	if i < len(idx.Documents) && idx.Documents[i].Path == p {
+		return idx.Documents[i], true
+	}
+	return IndexedDocument{}, false
+}
+
+// Lookup resolves a "path#anchor" reference (or a bare path, for the
+// document itself) to its entry.
+func (idx *Index) Lookup(ref string) (IndexEntry, bool) {
+	p, anchor, _ := strings.Cut(ref, "#")
+	doc, ok := idx.Document(p)
+	if !ok {
+		return IndexEntry{}, false
+	}
+	if anchor == "" {
+		return IndexEntry{Path: doc.Path, Title: doc.Title, Text: doc.Title}, true
+	}
+	for _, h := range doc.Headers {
+		if h.Anchor == anchor {
+			return headerEntry(doc, h), true
+		}
+	}
+	return IndexEntry{}, false
+}
+
+// Search returns the titles and headers matching query, case-insensitively.
+// Exact matches rank first, then prefix matches, then substring matches;
+// ties keep document order. An empty query matches nothing.
+func (idx *Index) Search(query string) []IndexEntry {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return nil
+	}
+
+	type hit struct {
+		entry IndexEntry
+		rank  int
+	}
+	var hits []hit
+	match := func(e IndexEntry) {
+		text := strings.ToLower(e.Text)
+		switch {
+		case text == q:
+			hits = append(hits, hit{e, 0})
+		case strings.HasPrefix(text, q):
+			hits = append(hits, hit{e, 1})
+		case strings.Contains(text, q):
+			hits = append(hits, hit{e, 2})
+		}
+	}
+	for _, doc := range idx.Documents {
+		match(IndexEntry{Path: doc.Path, Title: doc.Title, Text: doc.Title})
+		for _, h := range doc.Headers {
+			match(headerEntry(doc, h))
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].rank < hits[j].rank })
+	entries := make([]IndexEntry, len(hits))
+	for i, h := range hits {
+		entries[i] = h.entry
+	}
+	return entries
+}
+
+// Filter returns the documents whose frontmatter key equals value, or
+// contains it when the field is a list. Values are compared by their
+// fmt.Sprint form, so "true" matches a boolean and "2" an integer.
+func (idx *Index) Filter(key, value string) []IndexedDocument {
+	var docs []IndexedDocument
+	for _, doc := range idx.Documents {
+		field, ok := doc.Frontmatter[key]
+		if !ok {
+			continue
+		}
+		if fieldMatches(field, value) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
+
+// fieldMatches reports whether a frontmatter value equals value or, for a
+// list, contains it.
+func fieldMatches(field interface{}, value string) bool {
+	switch f := field.(type) {
+	case nil:
+		return false
+	case []interface{}:
+		for _, item := range f {
+			if fmt.Sprint(item) == value {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprint(f) == value
+	}
+}
+
+// headerEntry converts a header of doc to an IndexEntry.
+func headerEntry(doc IndexedDocument, h Header) IndexEntry {
+	return IndexEntry{
+		Path:       doc.Path,
+		Title:      doc.Title,
+		Text:       h.Text,
+		Anchor:     h.Anchor,
+		Level:      h.Level,
+		LineNumber: h.LineNumber,
+	}
+}
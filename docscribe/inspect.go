@@ -14,6 +14,7 @@ import (
 //   - Format detection (markdown, yaml, json, etc.)
 //   - Line counting
 //   - Section estimation (based on header hierarchy)
+//   - Encryption marker detection (SOPS, age)
 //
 // This function does not parse frontmatter YAML or extract full header details.
 // For complete parsing, use ParseFrontmatter or ExtractHeaders instead.
@@ -29,8 +30,14 @@ import (
 //	fmt.Printf("Headers: %d, Estimated sections: %d\n",
 //	    info.HeaderCount, info.EstimatedSections)
 //
-// Returns DocumentInfo with inspection results, or an error if content cannot be processed.
-func InspectDocument(content []byte) (*DocumentInfo, error) {
+// Returns DocumentInfo with inspection results, or an error if content cannot be processed
+// (LimitExceededError when the content exceeds the configured Limits, including MaxHeaders).
+func InspectDocument(content []byte, opts ...Option) (*DocumentInfo, error) {
+	guard, err := newParseGuard(content, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	info := &DocumentInfo{}
 
 	// 1. Detect format (uses existing heuristics)
@@ -42,10 +49,24 @@ func InspectDocument(content []byte) (*DocumentInfo, error) {
 	// 3. Count lines
 	info.LineCount = countLines(content)
 
-	// 4. Quick header count and section estimation
+	// 4. Flag encrypted content so callers can route it to a decryption step
+	if scheme := DetectEncryption(content); scheme != "" {
+		info.Encrypted = true
+		info.EncryptionScheme = scheme
+		if scheme == EncryptionAge {
+			// Ciphertext has no meaningful structure to analyze
+			info.Format = FormatText
+			return info, nil
+		}
+	}
+
+	// 5. Quick header count and section estimation
 	// Only do this for markdown content
 	if info.Format == FormatMarkdown || info.Format == FormatMultiMarkdown {
-		headerCount, sectionCount := analyzeHeaderStructure(content)
+		headerCount, sectionCount, err := analyzeHeaderStructure(content, guard, resolveParseOptions(opts).MaxHeaders)
+		if err != nil {
+			return nil, err
+		}
 		info.HeaderCount = headerCount
 		info.EstimatedSections = sectionCount
 	} else {
@@ -80,7 +101,10 @@ func countLines(content []byte) int {
 //   - H1 headers typically denote major sections
 //   - H2 headers under H1s are subsections (count as separate sections if substantial)
 //   - Estimate is conservative: count H1s + significant H2s
-func analyzeHeaderStructure(content []byte) (int, int) {
+//
+// A positive maxHeaders stops the scan with a LimitExceededError once the
+// document has more headers.
+func analyzeHeaderStructure(content []byte, guard *parseGuard, maxHeaders int) (int, int, error) {
 	lines := bytes.Split(content, []byte("\n"))
 
 	headerCount := 0
@@ -91,6 +115,13 @@ func analyzeHeaderStructure(content []byte) (int, int) {
 	for i := 0; i < len(lines); i++ {
 		line := lines[i]
 
+		if err := guard.line(); err != nil {
+			return 0, 0, err
+		}
+		if maxHeaders > 0 && headerCount > maxHeaders {
+			return 0, 0, &LimitExceededError{Limit: LimitMaxHeaders, Size: int64(headerCount), MaxSize: int64(maxHeaders)}
+		}
+
 		// Track code blocks
 		if isCodeBlockFence(line) {
 			inCodeBlock = !inCodeBlock
@@ -142,6 +173,10 @@ func analyzeHeaderStructure(content []byte) (int, int) {
 		}
 	}
 
+	if maxHeaders > 0 && headerCount > maxHeaders {
+		return 0, 0, &LimitExceededError{Limit: LimitMaxHeaders, Size: int64(headerCount), MaxSize: int64(maxHeaders)}
+	}
+
 	// Estimate sections:
 	// - Each H1 is a major section
 	// - H2s are subsections, but only count them as separate sections if there are many
@@ -162,7 +197,7 @@ func analyzeHeaderStructure(content []byte) (int, int) {
 		estimatedSections = 1
 	}
 
-	return headerCount, estimatedSections
+	return headerCount, estimatedSections, nil
 }
 
 // isSetextUnderlineFast is a faster version of isSetextUnderline for inspection.
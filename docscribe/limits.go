@@ -0,0 +1,238 @@
+package docscribe
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Limit names reported by LimitExceededError.
+const (
+	LimitMaxSize            = "max_size"
+	LimitMaxFrontmatterSize = "max_frontmatter_size"
+	LimitMaxHeaders         = "max_headers"
+	LimitMaxHeaderLength    = "max_header_length"
+	LimitParseTimeout       = "parse_timeout"
+)
+
+// Limits bounds the resources spent processing a single document. Services
+// handling untrusted uploads should set at least MaxSize and ParseTimeout so
+// a pathological document (e.g. hundreds of megabytes on one line) cannot
+// wedge a worker.
+type Limits struct {
+	// MaxSize is the maximum content size in bytes (0 = unlimited)
+	MaxSize int64
+
+	// MaxFrontmatterSize is the maximum frontmatter block size in bytes
+	// (0 = unlimited). Enforced by ParseFrontmatter and ExtractMetadata.
+	MaxFrontmatterSize int64
+
+	// MaxHeaders is the maximum number of headers in a document (0 = unlimited).
+	// Enforced by ExtractHeaders and InspectDocument.
+	MaxHeaders int
+
+	// MaxHeaderLength is the maximum header text length in bytes
+	// (0 = unlimited). Enforced by ExtractHeaders.
+	MaxHeaderLength int
+
+	// ParseTimeout is the maximum time spent in a single call (0 = unlimited).
+	// It is checked between lines, so a single YAML frontmatter parse is bounded
+	// by MaxSize rather than the deadline.
+	ParseTimeout time.Duration
+}
+
+var (
+	defaultLimits   Limits
+	defaultLimitsMu sync.RWMutex
+)
+
+// SetDefaultLimits sets the process-wide limits applied when a call passes no options.
+// The initial defaults are unlimited, preserving existing behavior.
+func SetDefaultLimits(limits Limits) {
+	defaultLimitsMu.Lock()
+	defer defaultLimitsMu.Unlock()
+	defaultLimits = limits
+}
+
+// DefaultLimits returns the current process-wide limits.
+func DefaultLimits() Limits {
+	defaultLimitsMu.RLock()
+	defer defaultLimitsMu.RUnlock()
+	return defaultLimits
+}
+
+// ParseOptions configures a single parsing call: the resource Limits plus
+// how strictly frontmatter is parsed.
+type ParseOptions struct {
+	Limits
+
+	// StrictYAML rejects YAML frontmatter that uses anchors, aliases, explicit
+	// tags, or non-string keys, none of which plain metadata needs, as well as
+	// duplicate keys and tab indentation. Aliases in particular let a small
+	// document expand into a very large value. The checks are those of
+	// schema.CheckYAML.
+	StrictYAML bool
+
+	// FrontmatterFormats lists the accepted frontmatter formats (FormatYAML,
+	// FormatJSON); frontmatter in any other format is rejected with a
+	// FormatError. A block is FormatJSON when it is a valid JSON object.
+	// Default: nil (all formats)
+	FrontmatterFormats []string
+}
+
+// DefaultParseOptions returns the options applied when a call passes none:
+// the process-wide limits with lenient frontmatter parsing.
+func DefaultParseOptions() ParseOptions {
+	return ParseOptions{Limits: DefaultLimits()}
+}
+
+// Option configures a single parsing call, overriding the process-wide limits
+// and DefaultParseOptions.
+type Option func(*ParseOptions)
+
+// WithParseOptions replaces all options for the call.
+//
+// Example:
+//
+//	opts := docscribe.WithParseOptions(docscribe.ParseOptions{
+//	    Limits:             docscribe.Limits{MaxSize: 1 << 20, MaxFrontmatterSize: 16 << 10, MaxHeaders: 1000},
+//	    StrictYAML:         true,
+//	    FrontmatterFormats: []string{docscribe.FormatYAML},
+//	})
+//	body, metadata, err := docscribe.ParseFrontmatter(upload, opts)
+func WithParseOptions(options ParseOptions) Option {
+	return func(o *ParseOptions) {
+		*o = options
+	}
+}
+
+// WithLimits replaces all limits for the call.
+func WithLimits(limits Limits) Option {
+	return func(o *ParseOptions) {
+		o.Limits = limits
+	}
+}
+
+// WithMaxSize sets the maximum content size in bytes for the call (0 = unlimited).
+func WithMaxSize(bytes int64) Option {
+	return func(o *ParseOptions) {
+		o.MaxSize = bytes
+	}
+}
+
+// WithMaxFrontmatterSize sets the maximum frontmatter size in bytes for the call (0 = unlimited).
+func WithMaxFrontmatterSize(bytes int64) Option {
+	return func(o *ParseOptions) {
+		o.MaxFrontmatterSize = bytes
+	}
+}
+
+// WithMaxHeaders sets the maximum number of headers for the call (0 = unlimited).
+func WithMaxHeaders(n int) Option {
+	return func(o *ParseOptions) {
+		o.MaxHeaders = n
+	}
+}
+
+// WithParseTimeout sets the parse deadline for the call (0 = unlimited).
+func WithParseTimeout(d time.Duration) Option {
+	return func(o *ParseOptions) {
+		o.ParseTimeout = d
+	}
+}
+
+// WithStrictYAML enables strict frontmatter YAML parsing for the call.
+func WithStrictYAML() Option {
+	return func(o *ParseOptions) {
+		o.StrictYAML = true
+	}
+}
+
+// WithFrontmatterFormats restricts the accepted frontmatter formats for the call.
+func WithFrontmatterFormats(formats ...string) Option {
+	return func(o *ParseOptions) {
+		o.FrontmatterFormats = formats
+	}
+}
+
+// resolveParseOptions applies opts to DefaultParseOptions.
+func resolveParseOptions(opts []Option) ParseOptions {
+	options := DefaultParseOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// LimitExceededError indicates content was rejected or parsing was abandoned
+// because it exceeded a configured limit. Callers can detect it with errors.As.
+type LimitExceededError struct {
+	// Limit is one of the Limit* constants
+	Limit string
+
+	// Size and MaxSize are the observed and permitted values for the size,
+	// header count, and header length limits
+	Size    int64
+	MaxSize int64
+
+	// Timeout is set for LimitParseTimeout
+	Timeout time.Duration
+}
+
+func (e *LimitExceededError) Error() string {
+	switch e.Limit {
+	case LimitParseTimeout:
+		return fmt.Sprintf("limit exceeded: parsing did not complete within %s", e.Timeout)
+	case LimitMaxFrontmatterSize:
+		return fmt.Sprintf("limit exceeded: frontmatter size %d bytes exceeds maximum of %d bytes", e.Size, e.MaxSize)
+	case LimitMaxHeaders:
+		return fmt.Sprintf("limit exceeded: document has more than %d headers", e.MaxSize)
+	case LimitMaxHeaderLength:
+		return fmt.Sprintf("limit exceeded: header length %d bytes exceeds maximum of %d bytes", e.Size, e.MaxSize)
+	}
+	return fmt.Sprintf("limit exceeded: content size %d bytes exceeds maximum of %d bytes", e.Size, e.MaxSize)
+}
+
+// guardCheckInterval is the number of lines processed between deadline checks.
+const guardCheckInterval = 1024
+
+// parseGuard enforces Limits over the course of one call. A nil guard is
+// valid and never trips.
+type parseGuard struct {
+	timeout  time.Duration
+	deadline time.Time
+	lines    int
+}
+
+// newParseGuard resolves limits for a call and checks the content size.
+func newParseGuard(content []byte, opts []Option) (*parseGuard, error) {
+	limits := resolveParseOptions(opts).Limits
+
+	if limits.MaxSize > 0 && int64(len(content)) > limits.MaxSize {
+		return nil, &LimitExceededError{Limit: LimitMaxSize, Size: int64(len(content)), MaxSize: limits.MaxSize}
+	}
+	if limits.ParseTimeout <= 0 {
+		return nil, nil
+	}
+	return &parseGuard{timeout: limits.ParseTimeout, deadline: time.Now().Add(limits.ParseTimeout)}, nil
+}
+
+// line counts one processed line, checking the deadline every guardCheckInterval lines.
+func (g *parseGuard) line() error {
+	if g == nil {
+		return nil
+	}
+	g.lines++
+	if g.lines%guardCheckInterval != 0 {
+		return nil
+	}
+	return g.check()
+}
+
+// check returns a LimitExceededError once the deadline has passed.
+func (g *parseGuard) check() error {
+	if g == nil || time.Now().Before(g.deadline) {
+		return nil
+	}
+	return &LimitExceededError{Limit: LimitParseTimeout, Timeout: g.timeout}
+}
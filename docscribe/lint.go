@@ -0,0 +1,327 @@
+package docscribe
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is the importance of a lint diagnostic.
+type Severity string
+
+// Diagnostic severities.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// RuleFrontmatterSyntax is reported by Lint when the frontmatter YAML cannot
+// be parsed; it is not part of any RuleSet.
+const RuleFrontmatterSyntax = "frontmatter-syntax"
+
+// Diagnostic is a finding reported by Lint.
+type Diagnostic struct {
+	// Rule is the name of the rule that reported the finding (e.g., "single-h1")
+	Rule string `json:"rule"`
+
+	// Severity is "error", "warning", or "info"
+	Severity Severity `json:"severity"`
+
+	// Message describes the problem
+	Message string `json:"message"`
+
+	// Line and Column are the 1-based position of the problem; Column counts
+	// bytes and is 0 when the finding applies to the whole line
+	Line   int `json:"line"`
+	Column int `json:"column"`
+
+	// Suggestion describes how to fix the problem, if known
+	Suggestion string `json:"suggestion,omitempty"`
+
+	// Edit is a machine-applicable fix, applied by Fix. Rules attach an Edit
+	// only when the fix is safe to apply without review.
+	Edit *TextEdit `json:"edit,omitempty"`
+}
+
+// TextEdit replaces the text from (StartLine, StartColumn) up to, but not
+// including, (EndLine, EndColumn) with NewText. Positions are 1-based, refer
+// to LintContext.Lines (no BOM or line terminators), and columns count bytes.
+type TextEdit struct {
+	StartLine   int    `json:"start_line"`
+	StartColumn int    `json:"start_column"`
+	EndLine     int    `json:"end_line"`
+	EndColumn   int    `json:"end_column"`
+	NewText     string `json:"new_text"`
+}
+
+// Rule checks a document and reports diagnostics. Implement Rule (or use
+// RuleFunc) to add project-specific checks to a RuleSet.
+type Rule interface {
+	// Name identifies the rule in diagnostics (e.g., "single-h1").
+	Name() string
+
+	// Check reports the rule's findings for the document.
+	Check(doc *LintContext) []Diagnostic
+}
+
+// RuleFunc adapts a function to the Rule interface.
+//
+// Example:
+//
+//	noTODO := docscribe.RuleFunc("no-todo", func(doc *docscribe.LintContext) []docscribe.Diagnostic {
+//	    var diags []docscribe.Diagnostic
+//	    for i, line := range doc.Lines {
+//	        if col := strings.Index(line, "TODO"); col >= 0 && !doc.InCodeBlock(i+1) {
+//	            diags = append(diags, docscribe.Diagnostic{
+//	                Severity: docscribe.SeverityWarning, Message: "unresolved TODO",
+//	                Line: i + 1, Column: col + 1,
+//	            })
+//	        }
+//	    }
+//	    return diags
+//	})
+//	diags, err := docscribe.Lint(content, append(docscribe.DefaultRuleSet(), noTODO))
+func RuleFunc(name string, check func(doc *LintContext) []Diagnostic) Rule {
+	return funcRule{name: name, check: check}
+}
+
+type funcRule struct {
+	name  string
+	check func(doc *LintContext) []Diagnostic
+}
+
+func (r funcRule) Name() string                        { return r.name }
+func (r funcRule) Check(doc *LintContext) []Diagnostic { return r.check(doc) }
+
+// RuleSet is the list of rules applied by Lint.
+type RuleSet []Rule
+
+// DefaultRuleSet returns the built-in rules with their default settings:
+// single H1, no skipped header levels, 120-character lines, no trailing
+// whitespace, a final newline, and consistent link style. Frontmatter keys
+// are project-specific, so RequiredFrontmatter and FrontmatterOrder are not
+// included.
+func DefaultRuleSet() RuleSet {
+	return RuleSet{
+		SingleH1(),
+		HeaderIncrement(),
+		MaxLineLength(120),
+		TrailingWhitespace(),
+		FinalNewline(),
+		LinkStyle(LinkStyleConsistent),
+	}
+}
+
+// LintContext is the parsed document passed to each Rule.
+type LintContext struct {
+	// Content is the raw document
+	Content []byte
+
+	// Lines holds the document lines without line terminators
+	Lines []string
+
+	// Headers are the markdown headers outside frontmatter and code blocks;
+	// LineNumber is relative to the whole document
+	Headers []Header
+
+	// HasFrontmatter reports whether the document starts with a frontmatter block
+	HasFrontmatter bool
+
+	// Frontmatter is the parsed frontmatter (nil if absent or malformed)
+	Frontmatter map[string]interface{}
+
+	// FrontmatterKeys lists the top-level frontmatter keys in document order
+	FrontmatterKeys []string
+
+	// BodyLine is the 1-based line where the body starts (1 without frontmatter)
+	BodyLine int
+
+	codeLines map[int]bool
+	// keyLines holds the document line of each FrontmatterKeys entry
+	keyLines []int
+}
+
+// InCodeBlock reports whether the 1-based line is inside (or delimits) a
+// fenced code block.
+func (c *LintContext) InCodeBlock(line int) bool {
+	return c.codeLines[line]
+}
+
+// InFrontmatter reports whether the 1-based line is part of the frontmatter block.
+func (c *LintContext) InFrontmatter(line int) bool {
+	return line < c.BodyLine
+}
+
+// Lint checks content against rules and returns the diagnostics sorted by
+// position. A nil rules uses DefaultRuleSet. Malformed frontmatter is
+// reported as a "frontmatter-syntax" error rather than returned as an error.
+//
+// Example:
+//
+//	rules := append(docscribe.DefaultRuleSet(), docscribe.RequiredFrontmatter("title", "status"))
+//	diags, err := docscribe.Lint(content, rules)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, d := range diags {
+//	    fmt.Printf("%s:%d:%d %s [%s] %s\n", path, d.Line, d.Column, d.Severity, d.Rule, d.Message)
+//	}
+//
+// Returns LimitExceededError if the content exceeds the configured Limits.
+func Lint(content []byte, rules RuleSet, opts ...Option) ([]Diagnostic, error) {
+	if rules == nil {
+		rules = DefaultRuleSet()
+	}
+
+	doc, diags, err := newLintContext(content, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		for _, d := range rule.Check(doc) {
+			if d.Rule == "" {
+				d.Rule = rule.Name()
+			}
+			if d.Severity == "" {
+				d.Severity = SeverityWarning
+			}
+			diags = append(diags, d)
+		}
+	}
+
+	sortDiagnostics(diags)
+	return diags, nil
+}
+
+// sortDiagnostics orders diagnostics by line, then column.
+func sortDiagnostics(diags []Diagnostic) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		if diags[i].Line != diags[j].Line {
+			return diags[i].Line < diags[j].Line
+		}
+		return diags[i].Column < diags[j].Column
+	})
+}
+
+// newLintContext parses content for rules, returning any frontmatter syntax
+// diagnostic alongside it.
+func newLintContext(content []byte, opts []Option) (*LintContext, []Diagnostic, error) {
+	guard, err := newParseGuard(content, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	text := strings.ReplaceAll(string(trimBOM(content)), "\r\n", "\n")
+	doc := &LintContext{
+		Content:   content,
+		Lines:     strings.Split(text, "\n"),
+		BodyLine:  1,
+		codeLines: make(map[int]bool),
+	}
+
+	var diags []Diagnostic
+	body, offset := splitFrontmatter(content)
+	if offset > 0 {
+		doc.HasFrontmatter = true
+		doc.BodyLine = offset + 1
+		yamlBlock, _, _ := extractFrontmatterBlock(content)
+		keys, keyLines, metadata, err := parseFrontmatterKeys(yamlBlock)
+		if err != nil {
+			line := extractLineNumberFromError(err)
+			if line > 0 {
+				line++ // account for the opening delimiter
+			}
+			diags = append(diags, Diagnostic{
+				Rule:     RuleFrontmatterSyntax,
+				Severity: SeverityError,
+				Message:  "invalid frontmatter YAML: " + err.Error(),
+				Line:     max(line, 1),
+			})
+		}
+		// YAML lines are relative to the opening delimiter
+		opening := 0
+		for opening < len(doc.Lines) && strings.TrimSpace(doc.Lines[opening]) == "" {
+			opening++
+		}
+		for i := range keyLines {
+			keyLines[i] += opening + 1
+		}
+		doc.FrontmatterKeys = keys
+		doc.keyLines = keyLines
+		doc.Frontmatter = metadata
+	}
+
+	headers, err := ExtractHeaders(body, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, h := range headers {
+		h.LineNumber += offset
+		doc.Headers = append(doc.Headers, h)
+	}
+
+	fence := ""
+	for i := doc.BodyLine - 1; i < len(doc.Lines); i++ {
+		if err := guard.line(); err != nil {
+			return nil, nil, err
+		}
+		line := []byte(doc.Lines[i])
+		if isCodeBlockFence(line) {
+			f := getCodeBlockFence(line)
+			switch {
+			case fence == "":
+				fence = f
+			case f == fence:
+				fence = ""
+			}
+			doc.codeLines[i+1] = true
+			continue
+		}
+		if fence != "" {
+			doc.codeLines[i+1] = true
+		}
+	}
+	return doc, diags, nil
+}
+
+// splitFrontmatter returns the document body and the number of lines the
+// frontmatter block (if any) occupies before it.
+func splitFrontmatter(content []byte) ([]byte, int) {
+	if !hasFrontmatter(content) {
+		return content, 0
+	}
+	_, body, found := extractFrontmatterBlock(content)
+	if !found {
+		return content, 0
+	}
+	return body, bytes.Count(trimBOM(content), []byte("\n")) - bytes.Count(body, []byte("\n"))
+}
+
+// parseFrontmatterKeys parses frontmatter YAML, returning the top-level keys
+// in document order with their 1-based lines in the block, along with the
+// decoded map.
+func parseFrontmatterKeys(yamlBlock []byte) ([]string, []int, map[string]interface{}, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(yamlBlock, &node); err != nil {
+		return nil, nil, nil, err
+	}
+	metadata, err := parseFrontmatterYAML(yamlBlock)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var keys []string
+	var lines []int
+	if len(node.Content) > 0 && node.Content[0].Kind == yaml.MappingNode {
+		mapping := node.Content[0]
+		for i := 0; i+1 < len(mapping.Content); i += 2 {
+			keys = append(keys, mapping.Content[i].Value)
+			lines = append(lines, mapping.Content[i].Line)
+		}
+	}
+	return keys, lines, metadata, nil
+}
@@ -52,8 +52,14 @@ import (
 //
 // Returns: ["---\ntitle: Single Doc\n---\n# Content"] (one document)
 //
-// Returns a slice of document strings, or an error if splitting fails.
-func SplitDocuments(content []byte) ([]string, error) {
+// Returns a slice of document strings, or an error if splitting fails
+// (LimitExceededError when the content exceeds the configured Limits).
+func SplitDocuments(content []byte, opts ...Option) ([]string, error) {
+	guard, err := newParseGuard(content, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(content) == 0 {
 		return []string{}, nil
 	}
@@ -73,6 +79,10 @@ func SplitDocuments(content []byte) ([]string, error) {
 	for i := 0; i < len(lines); i++ {
 		line := lines[i]
 
+		if err := guard.line(); err != nil {
+			return nil, err
+		}
+
 		// Track code block boundaries
 		if isCodeBlockFence(line) {
 			state.inCodeBlock = !state.inCodeBlock
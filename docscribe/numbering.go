@@ -0,0 +1,223 @@
+package docscribe
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NumberingOptions configures NumberHeadings.
+type NumberingOptions struct {
+	// MinLevel is the shallowest header level that is numbered; its headers
+	// get "1.", "2.", ... Default: 2 (the H1 is the document title)
+	MinLevel int
+
+	// MaxLevel is the deepest header level that is numbered. Default: 4
+	MaxLevel int
+
+	// Strip removes existing section numbers instead of renumbering.
+	Strip bool
+
+	// UpdateLinks rewrites in-document links ("](#anchor)") to headers whose
+	// anchor changed, so existing references keep working. Links inside
+	// fenced code blocks are left alone. Default: true
+	UpdateLinks bool
+}
+
+// DefaultNumberingOptions returns the default heading numbering options.
+func DefaultNumberingOptions() NumberingOptions {
+	return NumberingOptions{
+		MinLevel:    2,
+		MaxLevel:    4,
+		UpdateLinks: true,
+	}
+}
+
+// HeaderChange records a header rewritten by NumberHeadings.
+type HeaderChange struct {
+	// LineNumber is the 1-based line of the header in the original content.
+	LineNumber int `json:"line_number"`
+
+	// Level is the header depth (1-6).
+	Level int `json:"level"`
+
+	OldText   string `json:"old_text"`
+	NewText   string `json:"new_text"`
+	OldAnchor string `json:"old_anchor"`
+	NewAnchor string `json:"new_anchor"`
+}
+
+// NumberingResult is the output of NumberHeadings.
+type NumberingResult struct {
+	// Content is the rewritten document.
+	Content string `json:"content"`
+
+	// Changes lists the headers whose text changed, in document order. Use
+	// the anchor pairs to update links from other documents.
+	Changes []HeaderChange `json:"changes"`
+}
+
+// sectionNumberRegex matches a leading section number such as "1. ", "1.2 ",
+// or "1.2.3. ". A dot is required so titles like "2024 Roadmap" are kept.
+var sectionNumberRegex = regexp.MustCompile(`^(?:\d+\.)+\d*\s+`)
+
+// inDocumentLinkRegex matches the target of an in-document markdown link.
+var inDocumentLinkRegex = regexp.MustCompile(`\]\(#([^)\s]+)\)`)
+
+// NumberHeadings inserts or updates hierarchical section numbers (1., 1.1,
+// 1.1.1) in the headers between opts.MinLevel and opts.MaxLevel, or removes
+// them when opts.Strip is set. Headers are located with ExtractHeaders, so
+// code blocks and frontmatter are left untouched. A skipped level numbers
+// as 0 (e.g., an H4 directly under "1." becomes "1.0.1"). Anchors of repeated
+// titles get GitHub's "-1", "-2" suffixes. opts may be nil for
+// DefaultNumberingOptions.
+//
+// Example:
+//
+//	result, err := docscribe.NumberHeadings(content, nil)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, c := range result.Changes {
+//	    fmt.Printf("#%s -> #%s\n", c.OldAnchor, c.NewAnchor)
+//	}
+//	os.WriteFile(path, []byte(result.Content), 0644)
+func NumberHeadings(content []byte, opts *NumberingOptions) (*NumberingResult, error) {
+	options := DefaultNumberingOptions()
+	if opts != nil {
+		options = *opts
+	}
+	if options.MinLevel < 1 {
+		options.MinLevel = 2
+	}
+	if options.MaxLevel == 0 {
+		options.MaxLevel = 4
+	}
+	if options.MaxLevel < options.MinLevel || options.MaxLevel > 6 {
+		options.MaxLevel = 6
+	}
+
+	// Headers are extracted from the body so a frontmatter closing "---" is
+	// not mistaken for a Setext underline; offset maps body lines back.
+	body, offset := splitFrontmatter(content)
+	headers, err := ExtractHeaders(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Number every header first: anchors of repeated titles depend on the
+	// whole document ("setup", "setup-1", ...)
+	texts := make([]string, len(headers))
+	oldAnchors := make([]string, len(headers))
+	newAnchors := make([]string, len(headers))
+	var counters [7]int
+	for i, h := range headers {
+		texts[i] = h.Text
+		oldAnchors[i] = h.Anchor
+		if h.Level >= options.MinLevel && h.Level <= options.MaxLevel {
+			texts[i] = sectionNumberRegex.ReplaceAllString(h.Text, "")
+			if !options.Strip {
+				counters[h.Level]++
+				for level := h.Level + 1; level < len(counters); level++ {
+					counters[level] = 0
+				}
+				texts[i] = sectionNumber(counters[options.MinLevel:h.Level+1]) + " " + texts[i]
+			}
+		}
+		newAnchors[i] = generateAnchor(texts[i])
+	}
+	oldAnchors = uniqueAnchors(oldAnchors)
+	newAnchors = uniqueAnchors(newAnchors)
+
+	lines := strings.Split(string(content), "\n")
+	result := &NumberingResult{}
+	renamed := make(map[string]string)
+	for i, h := range headers {
+		if oldAnchors[i] != newAnchors[i] {
+			renamed[oldAnchors[i]] = newAnchors[i]
+		}
+		if texts[i] == h.Text {
+			continue
+		}
+
+		idx := offset + h.LineNumber - 1
+		line := lines[idx]
+		pos := strings.Index(line, h.Text)
+		if pos < 0 {
+			continue
+		}
+		lines[idx] = line[:pos] + texts[i] + line[pos+len(h.Text):]
+
+		result.Changes = append(result.Changes, HeaderChange{
+			LineNumber: offset + h.LineNumber,
+			Level:      h.Level,
+			OldText:    h.Text,
+			NewText:    texts[i],
+			OldAnchor:  oldAnchors[i],
+			NewAnchor:  newAnchors[i],
+		})
+	}
+
+	if options.UpdateLinks && len(renamed) > 0 {
+		rewriteLinks(lines[offset:], renamed)
+	}
+	result.Content = strings.Join(lines, "\n")
+	return result, nil
+}
+
+// uniqueAnchors disambiguates repeated anchors the way GitHub does: the
+// second "setup" becomes "setup-1", the third "setup-2".
+func uniqueAnchors(anchors []string) []string {
+	unique := make([]string, len(anchors))
+	used := make(map[string]bool, len(anchors))
+	suffixes := make(map[string]int)
+	for i, anchor := range anchors {
+		candidate := anchor
+		for used[candidate] {
+			suffixes[anchor]++
+			candidate = anchor + "-" + strconv.Itoa(suffixes[anchor])
+		}
+		used[candidate] = true
+		unique[i] = candidate
+	}
+	return unique
+}
+
+// rewriteLinks points in-document links at renamed anchors, leaving fenced
+// code blocks untouched.
+func rewriteLinks(lines []string, renamed map[string]string) {
+	fence := ""
+	for i, line := range lines {
+		if isCodeBlockFence([]byte(line)) {
+			if f := getCodeBlockFence([]byte(line)); fence == "" {
+				fence = f
+			} else if f == fence {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+		lines[i] = inDocumentLinkRegex.ReplaceAllStringFunc(line, func(link string) string {
+			anchor := link[3 : len(link)-1]
+			if to, ok := renamed[anchor]; ok {
+				return "](#" + to + ")"
+			}
+			return link
+		})
+	}
+}
+
+// sectionNumber formats counters as "1." for a single level or "1.2.3" for
+// nested levels.
+func sectionNumber(counters []int) string {
+	parts := make([]string, len(counters))
+	for i, n := range counters {
+		parts[i] = strconv.Itoa(n)
+	}
+	if len(parts) == 1 {
+		return parts[0] + "."
+	}
+	return strings.Join(parts, ".")
+}
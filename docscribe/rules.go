@@ -0,0 +1,363 @@
+package docscribe
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Built-in rule names.
+const (
+	RuleSingleH1            = "single-h1"
+	RuleHeaderIncrement     = "header-increment"
+	RuleRequiredFrontmatter = "frontmatter-required"
+	RuleMaxLineLength       = "line-length"
+	RuleTrailingWhitespace  = "trailing-whitespace"
+	RuleLinkStyle           = "link-style"
+	RuleFinalNewline        = "final-newline"
+	RuleFrontmatterOrder    = "frontmatter-order"
+)
+
+// SingleH1 reports every H1 after the first; a document has one title.
+func SingleH1() Rule {
+	return RuleFunc(RuleSingleH1, func(doc *LintContext) []Diagnostic {
+		var diags []Diagnostic
+		first := 0
+		for _, h := range doc.Headers {
+			if h.Level != 1 {
+				continue
+			}
+			if first == 0 {
+				first = h.LineNumber
+				continue
+			}
+			diags = append(diags, Diagnostic{
+				Severity:   SeverityWarning,
+				Message:    fmt.Sprintf("multiple H1 headers (first at line %d)", first),
+				Line:       h.LineNumber,
+				Column:     1,
+				Suggestion: "demote this header to H2",
+				Edit:       headerLevelEdit(doc, h, 2),
+			})
+		}
+		return diags
+	})
+}
+
+// HeaderIncrement reports headers that skip a level (e.g., an H4 directly
+// after an H2).
+func HeaderIncrement() Rule {
+	return RuleFunc(RuleHeaderIncrement, func(doc *LintContext) []Diagnostic {
+		var diags []Diagnostic
+		prev := 0
+		for _, h := range doc.Headers {
+			if prev > 0 && h.Level > prev+1 {
+				diags = append(diags, Diagnostic{
+					Severity:   SeverityWarning,
+					Message:    fmt.Sprintf("header level jumps from H%d to H%d", prev, h.Level),
+					Line:       h.LineNumber,
+					Column:     1,
+					Suggestion: fmt.Sprintf("use H%d", prev+1),
+					Edit:       headerLevelEdit(doc, h, prev+1),
+				})
+				prev++ // judge following headers against the corrected level
+				continue
+			}
+			prev = h.Level
+		}
+		return diags
+	})
+}
+
+// RequiredFrontmatter reports a missing frontmatter block or missing keys.
+func RequiredFrontmatter(keys ...string) Rule {
+	return RuleFunc(RuleRequiredFrontmatter, func(doc *LintContext) []Diagnostic {
+		if len(keys) == 0 {
+			return nil
+		}
+		if !doc.HasFrontmatter {
+			return []Diagnostic{{
+				Severity:   SeverityError,
+				Message:    "document has no frontmatter",
+				Line:       1,
+				Suggestion: fmt.Sprintf("add a frontmatter block with %s", strings.Join(keys, ", ")),
+			}}
+		}
+		if doc.Frontmatter == nil && len(doc.FrontmatterKeys) == 0 {
+			return nil // malformed; reported as frontmatter-syntax
+		}
+
+		var diags []Diagnostic
+		for _, key := range keys {
+			if _, ok := doc.Frontmatter[key]; ok {
+				continue
+			}
+			diags = append(diags, Diagnostic{
+				Severity:   SeverityError,
+				Message:    fmt.Sprintf("frontmatter is missing required key %q", key),
+				Line:       doc.BodyLine - 1, // closing delimiter
+				Suggestion: fmt.Sprintf("add %q to the frontmatter", key+": ..."),
+			})
+		}
+		return diags
+	})
+}
+
+// MaxLineLength reports lines longer than limit characters. Code blocks,
+// frontmatter, tables, and lines without spaces (long URLs) are exempt.
+func MaxLineLength(limit int) Rule {
+	return RuleFunc(RuleMaxLineLength, func(doc *LintContext) []Diagnostic {
+		if limit <= 0 {
+			return nil
+		}
+		var diags []Diagnostic
+		for i, line := range doc.Lines {
+			lineNum := i + 1
+			length := utf8.RuneCountInString(line)
+			if length <= limit || doc.InCodeBlock(lineNum) || doc.InFrontmatter(lineNum) {
+				continue
+			}
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "|") || !strings.Contains(trimmed, " ") {
+				continue
+			}
+			diags = append(diags, Diagnostic{
+				Severity:   SeverityWarning,
+				Message:    fmt.Sprintf("line is %d characters long (limit %d)", length, limit),
+				Line:       lineNum,
+				Column:     runeColumn(line, limit) + 1,
+				Suggestion: "wrap the line",
+			})
+		}
+		return diags
+	})
+}
+
+// TrailingWhitespace reports spaces or tabs at the end of a line. Exactly two
+// trailing spaces (a markdown hard line break) are allowed outside code blocks.
+func TrailingWhitespace() Rule {
+	return RuleFunc(RuleTrailingWhitespace, func(doc *LintContext) []Diagnostic {
+		var diags []Diagnostic
+		for i, line := range doc.Lines {
+			lineNum := i + 1
+			trimmed := strings.TrimRight(line, " \t")
+			if trimmed == line {
+				continue
+			}
+			trailing := line[len(trimmed):]
+			if trailing == "  " && trimmed != "" && !doc.InCodeBlock(lineNum) {
+				continue // hard line break
+			}
+			diags = append(diags, Diagnostic{
+				Severity:   SeverityWarning,
+				Message:    "trailing whitespace",
+				Line:       lineNum,
+				Column:     len(trimmed) + 1,
+				Suggestion: "remove the trailing whitespace",
+				Edit: &TextEdit{
+					StartLine: lineNum, StartColumn: len(trimmed) + 1,
+					EndLine: lineNum, EndColumn: len(line) + 1,
+				},
+			})
+		}
+		return diags
+	})
+}
+
+// FinalNewline reports a document that does not end with a newline.
+func FinalNewline() Rule {
+	return RuleFunc(RuleFinalNewline, func(doc *LintContext) []Diagnostic {
+		last := len(doc.Lines)
+		line := doc.Lines[last-1]
+		if line == "" {
+			return nil // empty document or trailing newline
+		}
+		return []Diagnostic{{
+			Severity:   SeverityWarning,
+			Message:    "file does not end with a newline",
+			Line:       last,
+			Column:     len(line) + 1,
+			Suggestion: "add a newline at the end of the file",
+			Edit: &TextEdit{
+				StartLine: last, StartColumn: len(line) + 1,
+				EndLine: last, EndColumn: len(line) + 1,
+				NewText: "\n",
+			},
+		}}
+	})
+}
+
+// FrontmatterOrder reports frontmatter whose top-level keys are out of order:
+// the listed keys must come first, in the given order; other keys follow in
+// their existing order. The edit moves each key with the lines below it
+// (nested values and comments), so formatting is preserved.
+func FrontmatterOrder(keys ...string) Rule {
+	return RuleFunc(RuleFrontmatterOrder, func(doc *LintContext) []Diagnostic {
+		if len(doc.FrontmatterKeys) < 2 || len(doc.keyLines) != len(doc.FrontmatterKeys) {
+			return nil
+		}
+
+		rank := make(map[string]int, len(keys))
+		for i, key := range keys {
+			rank[key] = i
+		}
+		order := make([]int, len(doc.FrontmatterKeys))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			ra, okA := rank[doc.FrontmatterKeys[order[a]]]
+			rb, okB := rank[doc.FrontmatterKeys[order[b]]]
+			switch {
+			case okA && okB:
+				return ra < rb
+			default:
+				return okA && !okB
+			}
+		})
+
+		first := -1
+		for i, idx := range order {
+			if idx != i {
+				first = i
+				break
+			}
+		}
+		if first < 0 {
+			return nil
+		}
+
+		// Each key owns its lines up to the next key; the last runs to the
+		// closing delimiter.
+		closing := doc.BodyLine - 1
+		chunk := func(i int) []string {
+			end := closing
+			if i+1 < len(doc.keyLines) {
+				end = doc.keyLines[i+1]
+			}
+			return doc.Lines[doc.keyLines[i]-1 : end-1]
+		}
+		var reordered []string
+		for _, idx := range order {
+			reordered = append(reordered, chunk(idx)...)
+		}
+
+		startLine := doc.keyLines[0]
+		endLine := closing - 1
+		want := make([]string, len(order))
+		for i, idx := range order {
+			want[i] = doc.FrontmatterKeys[idx]
+		}
+		return []Diagnostic{{
+			Severity:   SeverityInfo,
+			Message:    fmt.Sprintf("frontmatter key %q is out of order", doc.FrontmatterKeys[order[first]]),
+			Line:       doc.keyLines[order[first]],
+			Column:     1,
+			Suggestion: fmt.Sprintf("order keys as: %s", strings.Join(want, ", ")),
+			Edit: &TextEdit{
+				StartLine: startLine, StartColumn: 1,
+				EndLine: endLine, EndColumn: len(doc.Lines[endLine-1]) + 1,
+				NewText: strings.Join(reordered, "\n"),
+			},
+		}}
+	})
+}
+
+// LinkStyle values for the LinkStyle rule.
+const (
+	// LinkStyleInline requires [text](url) links.
+	LinkStyleInline = "inline"
+	// LinkStyleReference requires [text][ref] links with [ref]: url definitions.
+	LinkStyleReference = "reference"
+	// LinkStyleConsistent requires every link to use the style of the first one.
+	LinkStyleConsistent = "consistent"
+)
+
+var (
+	inlineLinkRegex    = regexp.MustCompile(`!?\[[^\]]*\]\([^)]*\)`)
+	referenceLinkRegex = regexp.MustCompile(`!?\[[^\]]+\]\[[^\]]*\]`)
+	codeSpanRegex      = regexp.MustCompile("`+[^`]*`+")
+)
+
+// LinkStyle reports links (and images) that do not use style:
+// LinkStyleInline, LinkStyleReference, or LinkStyleConsistent.
+func LinkStyle(style string) Rule {
+	return RuleFunc(RuleLinkStyle, func(doc *LintContext) []Diagnostic {
+		type link struct {
+			line, col int
+			style     string
+		}
+		var links []link
+		for i, line := range doc.Lines {
+			lineNum := i + 1
+			if doc.InCodeBlock(lineNum) || doc.InFrontmatter(lineNum) {
+				continue
+			}
+			// Blank out code spans so their contents are not read as links
+			masked := codeSpanRegex.ReplaceAllStringFunc(line, func(s string) string {
+				return strings.Repeat(" ", len(s))
+			})
+			for _, loc := range inlineLinkRegex.FindAllStringIndex(masked, -1) {
+				links = append(links, link{lineNum, loc[0] + 1, LinkStyleInline})
+			}
+			for _, loc := range referenceLinkRegex.FindAllStringIndex(masked, -1) {
+				links = append(links, link{lineNum, loc[0] + 1, LinkStyleReference})
+			}
+		}
+
+		want := style
+		if want == LinkStyleConsistent && len(links) > 0 {
+			first := links[0]
+			for _, l := range links[1:] {
+				if l.line < first.line || l.line == first.line && l.col < first.col {
+					first = l
+				}
+			}
+			want = first.style
+		}
+
+		var diags []Diagnostic
+		for _, l := range links {
+			if l.style == want {
+				continue
+			}
+			diags = append(diags, Diagnostic{
+				Severity:   SeverityWarning,
+				Message:    fmt.Sprintf("%s link; this document uses %s links", l.style, want),
+				Line:       l.line,
+				Column:     l.col,
+				Suggestion: fmt.Sprintf("rewrite the link in %s style", want),
+			})
+		}
+		return diags
+	})
+}
+
+// headerLevelEdit returns an edit changing an ATX header to level, or nil
+// for Setext headers.
+func headerLevelEdit(doc *LintContext, h Header, level int) *TextEdit {
+	line := doc.Lines[h.LineNumber-1]
+	start := strings.Index(line, "#")
+	if start < 0 || strings.TrimSpace(line[:start]) != "" {
+		return nil
+	}
+	return &TextEdit{
+		StartLine: h.LineNumber, StartColumn: start + 1,
+		EndLine: h.LineNumber, EndColumn: start + h.Level + 1,
+		NewText: strings.Repeat("#", level),
+	}
+}
+
+// runeColumn returns the byte offset of the n-th rune in s.
+func runeColumn(s string, n int) int {
+	count := 0
+	for i := range s {
+		if count == n {
+			return i
+		}
+		count++
+	}
+	return len(s)
+}
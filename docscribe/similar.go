@@ -0,0 +1,249 @@
+package docscribe
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/foundry/similarity"
+	"github.com/fulmenhq/gofulmen/fulhash"
+)
+
+// CorpusDocument is a named document submitted to FindSimilarDocuments.
+type CorpusDocument struct {
+	// ID identifies the document in reports (e.g., a file path).
+	ID string
+
+	// Content is the raw document content (frontmatter is ignored).
+	Content []byte
+}
+
+// SimilarityOptions configures near-duplicate detection.
+type SimilarityOptions struct {
+	// Threshold is the minimum combined score (0.0-1.0) for a pair to be reported.
+	// Default: 0.6
+	Threshold float64
+
+	// ContentWeight is the weight (0.0-1.0) of section overlap in the combined score;
+	// the outline score receives the remainder. Default: 0.7
+	ContentWeight float64
+
+	// MinSectionChars ignores sections whose normalized body is shorter than this,
+	// so boilerplate like "TBD" does not count as shared content. Default: 40
+	MinSectionChars int
+}
+
+// DefaultSimilarityOptions returns the default near-duplicate detection options.
+func DefaultSimilarityOptions() SimilarityOptions {
+	return SimilarityOptions{
+		Threshold:       0.6,
+		ContentWeight:   0.7,
+		MinSectionChars: 40,
+	}
+}
+
+// SimilarityReport describes an overlapping pair of documents.
+type SimilarityReport struct {
+	// A and B are the IDs of the compared documents (A precedes B in the corpus).
+	A string `json:"a"`
+	B string `json:"b"`
+
+	// Score is the combined similarity (0.0-1.0).
+	Score float64 `json:"score"`
+
+	// SectionOverlap is the fraction of the smaller document's sections whose
+	// normalized content also appears in the other document.
+	SectionOverlap float64 `json:"section_overlap"`
+
+	// OutlineScore measures how closely the header outlines match.
+	OutlineScore float64 `json:"outline_score"`
+
+	// SharedSections lists sections with identical normalized content.
+	SharedSections []SharedSection `json:"shared_sections,omitempty"`
+}
+
+// SharedSection identifies one section duplicated between two documents.
+type SharedSection struct {
+	// HeadingA and HeadingB are the section headings in each document
+	// ("" for content before the first header).
+	HeadingA string `json:"heading_a"`
+	HeadingB string `json:"heading_b"`
+
+	// Digest is the fulhash digest of the normalized section content.
+	Digest string `json:"digest"`
+}
+
+// section is a header-delimited block of a document body.
+type section struct {
+	heading string
+	digest  string
+}
+
+// documentProfile holds the comparison features of one document.
+type documentProfile struct {
+	id       string
+	sections []section
+	outline  []string
+}
+
+// FindSimilarDocuments compares every pair of documents in a corpus and reports
+// near-duplicates or heavily-overlapping pairs, highest score first.
+//
+// Each document is split into header-delimited sections whose normalized content
+// (case-folded, whitespace-collapsed) is digested with fulhash. Pairs are scored on:
+//   - Section overlap: shared section digests relative to the smaller document
+//   - Outline score: fuzzy header matching via foundry/similarity
+//
+// Example:
+//
+//	reports := docscribe.FindSimilarDocuments([]docscribe.CorpusDocument{
+//	    {ID: "standards/logging.md", Content: loggingDoc},
+//	    {ID: "standards/observability.md", Content: observabilityDoc},
+//	}, nil)
+//	for _, r := range reports {
+//	    fmt.Printf("%s ~ %s (%.0f%%, %d shared sections)\n",
+//	        r.A, r.B, r.Score*100, len(r.SharedSections))
+//	}
+func FindSimilarDocuments(docs []CorpusDocument, opts *SimilarityOptions) []SimilarityReport {
+	options := DefaultSimilarityOptions()
+	if opts != nil {
+		if opts.Threshold > 0 {
+			options.Threshold = opts.Threshold
+		}
+		if opts.ContentWeight > 0 {
+			options.ContentWeight = opts.ContentWeight
+		}
+		if opts.MinSectionChars > 0 {
+			options.MinSectionChars = opts.MinSectionChars
+		}
+	}
+
+	profiles := make([]documentProfile, len(docs))
+	for i, doc := range docs {
+		profiles[i] = buildProfile(doc, options.MinSectionChars)
+	}
+
+	var reports []SimilarityReport
+	for i := 0; i < len(profiles); i++ {
+		for j := i + 1; j < len(profiles); j++ {
+			report := compareProfiles(&profiles[i], &profiles[j], options.ContentWeight)
+			if report.Score >= options.Threshold {
+				reports = append(reports, report)
+			}
+		}
+	}
+
+	sort.SliceStable(reports, func(a, b int) bool {
+		return reports[a].Score > reports[b].Score
+	})
+	return reports
+}
+
+// buildProfile splits a document into digested sections and a normalized outline.
+func buildProfile(doc CorpusDocument, minSectionChars int) documentProfile {
+	profile := documentProfile{id: doc.ID}
+	body := []byte(StripFrontmatter(doc.Content))
+
+	headers, err := ExtractHeaders(body)
+	if err != nil {
+		headers = nil
+	}
+	headerAt := make(map[int]Header, len(headers))
+	for _, h := range headers {
+		headerAt[h.LineNumber] = h
+		profile.outline = append(profile.outline, normalizeText(h.Text))
+	}
+
+	var heading string
+	var content []string
+	flush := func() {
+		normalized := normalizeText(strings.Join(content, "\n"))
+		if len(normalized) >= minSectionChars {
+			if digest, err := fulhash.HashString(normalized); err == nil {
+				profile.sections = append(profile.sections, section{
+					heading: heading,
+					digest:  fulhash.FormatDigest(digest),
+				})
+			}
+		}
+		content = content[:0]
+	}
+
+	lines := bytes.Split(body, []byte("\n"))
+	for i := 0; i < len(lines); i++ {
+		if h, ok := headerAt[i+1]; ok {
+			flush()
+			heading = h.Text
+			// Setext headers span the text line and its underline
+			if i+1 < len(lines) && (isSetextUnderline(string(bytes.TrimSpace(lines[i+1])), '=') ||
+				isSetextUnderline(string(bytes.TrimSpace(lines[i+1])), '-')) {
+				i++
+			}
+			continue
+		}
+		content = append(content, string(lines[i]))
+	}
+	flush()
+
+	return profile
+}
+
+// compareProfiles scores one pair of documents.
+func compareProfiles(a, b *documentProfile, contentWeight float64) SimilarityReport {
+	report := SimilarityReport{A: a.id, B: b.id}
+
+	byDigest := make(map[string]string, len(b.sections))
+	for _, s := range b.sections {
+		if _, exists := byDigest[s.digest]; !exists {
+			byDigest[s.digest] = s.heading
+		}
+	}
+	for _, s := range a.sections {
+		if headingB, ok := byDigest[s.digest]; ok {
+			report.SharedSections = append(report.SharedSections, SharedSection{
+				HeadingA: s.heading,
+				HeadingB: headingB,
+				Digest:   s.digest,
+			})
+		}
+	}
+
+	if smaller := min(len(a.sections), len(b.sections)); smaller > 0 {
+		report.SectionOverlap = float64(len(report.SharedSections)) / float64(smaller)
+		if report.SectionOverlap > 1 {
+			report.SectionOverlap = 1
+		}
+	}
+
+	report.OutlineScore = outlineScore(a.outline, b.outline)
+	report.Score = contentWeight*report.SectionOverlap + (1-contentWeight)*report.OutlineScore
+	return report
+}
+
+// outlineScore averages, over the shorter outline, each header's best fuzzy
+// match in the other outline. Documents without headers score 0.
+func outlineScore(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	var total float64
+	for _, ha := range a {
+		var best float64
+		for _, hb := range b {
+			if score := similarity.Score(ha, hb); score > best {
+				best = score
+			}
+		}
+		total += best
+	}
+	return total / float64(len(a))
+}
+
+// normalizeText case-folds and collapses whitespace for comparison.
+func normalizeText(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
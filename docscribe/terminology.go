@@ -0,0 +1,320 @@
+package docscribe
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Terminology rule names.
+const (
+	RuleTermCase       = "term-case"
+	RuleDeprecatedTerm = "deprecated-term"
+)
+
+// Definition styles recognized by ExtractDefinitions.
+const (
+	// DefinitionList is a "Term" line followed by a ": definition" line.
+	DefinitionList = "definition-list"
+	// DefinitionBold is "**Term**: definition" (or "**Term:** definition",
+	// "**Term** - definition"), optionally as a list item.
+	DefinitionBold = "bold"
+)
+
+// Definition is a term defined in a document.
+type Definition struct {
+	// Term is the defined term as written
+	Term string `json:"term"`
+
+	// Definition is the definition text
+	Definition string `json:"definition"`
+
+	// LineNumber is the 1-based line of the term
+	LineNumber int `json:"line_number"`
+
+	// Style is DefinitionList or DefinitionBold
+	Style string `json:"style"`
+}
+
+var (
+	definitionItemRegex = regexp.MustCompile(`^:\s+(\S.*)$`)
+	boldTermRegex       = regexp.MustCompile(`^\s*(?:[-*+]\s+)?\*\*([^*]+?)\*\*\s*(?::|-|–|—)\s+(\S.*)$`)
+	boldTermColonRegex  = regexp.MustCompile(`^\s*(?:[-*+]\s+)?\*\*([^*]+?):\*\*\s+(\S.*)$`)
+)
+
+// ExtractDefinitions returns the terms defined in content, in document order.
+// Definition lists and bolded-term patterns are recognized outside
+// frontmatter and code blocks.
+//
+// Example:
+//
+//	defs, err := docscribe.ExtractDefinitions(content)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, d := range defs {
+//	    fmt.Printf("%s: %s\n", d.Term, d.Definition)
+//	}
+//
+// Returns LimitExceededError if the content exceeds the configured Limits.
+func ExtractDefinitions(content []byte, opts ...Option) ([]Definition, error) {
+	doc, _, err := newLintContext(content, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var defs []Definition
+	for i := doc.BodyLine - 1; i < len(doc.Lines); i++ {
+		lineNum := i + 1
+		if doc.InCodeBlock(lineNum) {
+			continue
+		}
+		line := doc.Lines[i]
+
+		if m := definitionItemRegex.FindStringSubmatch(line); m != nil {
+			// The term is the preceding line; further ": " lines add definitions
+			term := i - 1
+			for term >= doc.BodyLine-1 && definitionItemRegex.MatchString(doc.Lines[term]) {
+				term--
+			}
+			if term >= doc.BodyLine-1 && !doc.InCodeBlock(term+1) && isDefinitionTerm(doc.Lines[term]) {
+				defs = append(defs, Definition{
+					Term:       strings.TrimSpace(doc.Lines[term]),
+					Definition: strings.TrimSpace(m[1]),
+					LineNumber: term + 1,
+					Style:      DefinitionList,
+				})
+			}
+			continue
+		}
+
+		m := boldTermColonRegex.FindStringSubmatch(line)
+		if m == nil {
+			m = boldTermRegex.FindStringSubmatch(line)
+		}
+		if m != nil {
+			defs = append(defs, Definition{
+				Term:       strings.TrimSpace(m[1]),
+				Definition: strings.TrimSpace(m[2]),
+				LineNumber: lineNum,
+				Style:      DefinitionBold,
+			})
+		}
+	}
+	return defs, nil
+}
+
+// isDefinitionTerm reports whether line can be a definition list term: a
+// single line of text that is not a header, list item, or table row.
+func isDefinitionTerm(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || line != strings.TrimLeft(line, " \t") {
+		return false
+	}
+	switch trimmed[0] {
+	case '#', '-', '*', '+', '>', '|':
+		return false
+	}
+	return true
+}
+
+// Glossary is the approved terminology checked by CheckTerminology and the
+// Terminology rule.
+type Glossary struct {
+	// Terms are the canonical spellings (e.g., "Kubernetes", "gofulmen")
+	Terms []string `json:"terms" yaml:"terms"`
+
+	// Deprecated maps a discouraged term to its replacement ("" if none)
+	Deprecated map[string]string `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+// GlossaryFromDefinitions builds a Glossary whose terms are the defined
+// terms; when a term is defined with different capitalization, the first
+// definition wins.
+func GlossaryFromDefinitions(defs []Definition) *Glossary {
+	glossary := &Glossary{}
+	seen := make(map[string]bool)
+	for _, d := range defs {
+		key := strings.ToLower(d.Term)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		glossary.Terms = append(glossary.Terms, d.Term)
+	}
+	return glossary
+}
+
+// Terminology reports glossary terms written with the wrong capitalization
+// (RuleTermCase, with an Edit to the canonical spelling) and deprecated
+// terms (RuleDeprecatedTerm). A capitalized first letter is accepted at the
+// start of a sentence, header, or list item. Frontmatter, code blocks, code
+// spans, and link targets are not checked.
+func Terminology(glossary *Glossary) Rule {
+	type pattern struct {
+		re          *regexp.Regexp
+		term        string
+		deprecated  bool
+		replacement string
+	}
+	var patterns []pattern
+	if glossary != nil {
+		deprecated := make(map[string]bool)
+		for term, replacement := range glossary.Deprecated {
+			deprecated[strings.ToLower(term)] = true
+			patterns = append(patterns, pattern{termRegex(term), term, true, replacement})
+		}
+		for _, term := range glossary.Terms {
+			if !deprecated[strings.ToLower(term)] {
+				patterns = append(patterns, pattern{re: termRegex(term), term: term})
+			}
+		}
+		sort.SliceStable(patterns, func(i, j int) bool { return patterns[i].term < patterns[j].term })
+	}
+
+	return RuleFunc(RuleTermCase, func(doc *LintContext) []Diagnostic {
+		var diags []Diagnostic
+		for i, line := range doc.Lines {
+			lineNum := i + 1
+			if doc.InCodeBlock(lineNum) || doc.InFrontmatter(lineNum) {
+				continue
+			}
+			masked := maskCode(line)
+			for _, p := range patterns {
+				for _, loc := range p.re.FindAllStringIndex(masked, -1) {
+					found := line[loc[0]:loc[1]]
+					switch {
+					case p.deprecated:
+						d := Diagnostic{
+							Rule:     RuleDeprecatedTerm,
+							Severity: SeverityWarning,
+							Message:  fmt.Sprintf("%q is deprecated", found),
+							Line:     lineNum,
+							Column:   loc[0] + 1,
+						}
+						if p.replacement != "" {
+							d.Suggestion = fmt.Sprintf("use %q", p.replacement)
+						}
+						diags = append(diags, d)
+					case found != p.term && !(found == capitalize(p.term) && sentenceStart(line[:loc[0]])):
+						diags = append(diags, Diagnostic{
+							Rule:       RuleTermCase,
+							Severity:   SeverityWarning,
+							Message:    fmt.Sprintf("%q should be written %q", found, p.term),
+							Line:       lineNum,
+							Column:     loc[0] + 1,
+							Suggestion: fmt.Sprintf("use %q", p.term),
+							Edit: &TextEdit{
+								StartLine: lineNum, StartColumn: loc[0] + 1,
+								EndLine: lineNum, EndColumn: loc[1] + 1,
+								NewText: p.term,
+							},
+						})
+					}
+				}
+			}
+		}
+		return diags
+	})
+}
+
+// CheckTerminology runs the Terminology rule over docs, keyed by path, and
+// returns the diagnostics for each document that has any. A nil glossary is
+// built from the definitions found in docs (GlossaryFromDefinitions, in path
+// order), so terms are checked for consistent capitalization across the set.
+//
+// Example:
+//
+//	glossary := &docscribe.Glossary{
+//	    Terms:      []string{"Crucible", "gofulmen"},
+//	    Deprecated: map[string]string{"whitelist": "allowlist"},
+//	}
+//	results, err := docscribe.CheckTerminology(docs, glossary)
+//	if err != nil {
+//	    return err
+//	}
+//	for path, diags := range results {
+//	    for _, d := range diags {
+//	        fmt.Printf("%s:%d:%d [%s] %s\n", path, d.Line, d.Column, d.Rule, d.Message)
+//	    }
+//	}
+//
+// Returns an error naming the path of the first document that exceeds the
+// configured Limits.
+func CheckTerminology(docs map[string][]byte, glossary *Glossary, opts ...Option) (map[string][]Diagnostic, error) {
+	paths := make([]string, 0, len(docs))
+	for p := range docs {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	if glossary == nil {
+		var defs []Definition
+		for _, p := range paths {
+			found, err := ExtractDefinitions(docs[p], opts...)
+			if err != nil {
+				return nil, fmt.Errorf("extract definitions from %s: %w", p, err)
+			}
+			defs = append(defs, found...)
+		}
+		glossary = GlossaryFromDefinitions(defs)
+	}
+
+	rules := RuleSet{Terminology(glossary)}
+	results := make(map[string][]Diagnostic)
+	for _, p := range paths {
+		diags, err := Lint(docs[p], rules, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("check terminology in %s: %w", p, err)
+		}
+		var found []Diagnostic
+		for _, d := range diags {
+			if d.Rule != RuleFrontmatterSyntax {
+				found = append(found, d)
+			}
+		}
+		if len(found) > 0 {
+			results[p] = found
+		}
+	}
+	return results, nil
+}
+
+// termRegex matches term case-insensitively as a whole word.
+func termRegex(term string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(term) + `\b`)
+}
+
+// linkTargetRegex matches the target of an inline link or a reference definition.
+var linkTargetRegex = regexp.MustCompile(`\]\([^)]*\)|^\s*\[[^\]]+\]:\s*\S+`)
+
+// maskCode blanks code spans and link targets so their contents are not
+// checked, keeping byte offsets.
+func maskCode(line string) string {
+	blank := func(s string) string { return strings.Repeat(" ", len(s)) }
+	line = codeSpanRegex.ReplaceAllStringFunc(line, blank)
+	return linkTargetRegex.ReplaceAllStringFunc(line, blank)
+}
+
+// capitalize upper-cases the first letter of s.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// sentenceStart reports whether a word following prefix starts a sentence,
+// header, or list item.
+func sentenceStart(prefix string) bool {
+	prefix = strings.TrimRight(prefix, " \t*_\"'([")
+	if strings.Trim(prefix, "#>-*+0123456789. \t") == "" {
+		return true
+	}
+	switch prefix[len(prefix)-1] {
+	case '.', '!', '?', ':':
+		return true
+	}
+	return false
+}
@@ -20,6 +20,12 @@ type DocumentInfo struct {
 	// EstimatedSections is a heuristic estimate of major document sections
 	// based on header hierarchy and structure
 	EstimatedSections int `json:"estimated_sections"`
+
+	// Encrypted indicates the document or its frontmatter carries encryption markers
+	Encrypted bool `json:"encrypted"`
+
+	// EncryptionScheme identifies the encryption tool ("sops", "age") when Encrypted is true
+	EncryptionScheme string `json:"encryption_scheme,omitempty"`
 }
 
 // Header represents a markdown header with its metadata.
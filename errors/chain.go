@@ -0,0 +1,164 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// joinedErrors is the cause of an envelope with several causes. Like the
+// result of errors.Join, it unwraps to its errors.
+type joinedErrors []error
+
+func (j joinedErrors) Error() string {
+	messages := make([]string, len(j))
+	for i, err := range j {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (j joinedErrors) Unwrap() []error {
+	return j
+}
+
+// WithCauses records several causes, as errors.Join would combine them: nil
+// errors are dropped, errors.Is/errors.As see through the envelope to each
+// cause, and Causes lists each cause's chain in order. With a single cause
+// it is equivalent to WithCause.
+func (e *ErrorEnvelope) WithCauses(errs ...error) *ErrorEnvelope {
+	e.cause, e.causes, e.Original, e.Causes = nil, nil, nil, nil
+	for _, err := range errs {
+		e.Add(err)
+	}
+	return e
+}
+
+// Add appends err to the envelope's causes (see WithCauses). It is the
+// accumulating form used with NewAggregate; a nil err is ignored.
+func (e *ErrorEnvelope) Add(err error) *ErrorEnvelope {
+	if err == nil {
+		return e
+	}
+	if len(e.causes) == 0 {
+		if e.cause == nil {
+			return e.WithCause(err)
+		}
+		// Keep a cause recorded earlier by WithCause
+		e.causes = []error{e.cause}
+	}
+
+	e.causes = append(e.causes, err)
+	e.cause = joinedErrors(e.causes)
+	e.Original = fmt.Sprintf("%s (and %d more)", e.causes[0].Error(), len(e.causes)-1)
+	if len(e.Causes) < maxCauseDepth {
+		e.Causes = append(e.Causes, causeChain(err)...)
+		if len(e.Causes) > maxCauseDepth {
+			e.Causes = e.Causes[:maxCauseDepth]
+		}
+	}
+	return e
+}
+
+// Errors returns the envelope's causes: those recorded by WithCauses or Add,
+// or the single cause recorded by WithCause.
+func (e *ErrorEnvelope) Errors() []error {
+	if len(e.causes) > 0 {
+		return append([]error(nil), e.causes...)
+	}
+	if e.cause != nil {
+		return []error{e.cause}
+	}
+	return nil
+}
+
+// NewAggregate creates an envelope collecting the errors of a batch
+// operation (e.g., per-entry failures during extraction). Further errors are
+// added with Add; ErrorOrNil returns nil if none were collected.
+//
+// Example:
+//
+//	agg := errors.NewAggregate("EXTRACT_PARTIAL", "some entries failed to extract")
+//	for _, entry := range entries {
+//	    if err := extract(entry); err != nil {
+//	        agg.Add(errors.NewErrorEnvelope("ENTRY_FAILED", err.Error()).WithPath(entry.Name))
+//	    }
+//	}
+//	return agg.ErrorOrNil()
+func NewAggregate(code, message string, errs ...error) *ErrorEnvelope {
+	return NewErrorEnvelope(code, message).WithCauses(errs...)
+}
+
+// ErrorOrNil returns the envelope as an error, or nil if it has no causes.
+// It avoids returning a non-nil error interface for an empty aggregate.
+func (e *ErrorEnvelope) ErrorOrNil() error {
+	if e == nil || (e.cause == nil && len(e.causes) == 0) {
+		return nil
+	}
+	return e
+}
+
+// Is reports whether target is an envelope with the same non-empty code, so
+// errors.Is(err, errors.NewErrorEnvelope("CONFIG_INVALID", "")) finds an
+// envelope by code anywhere in err's tree.
+func (e *ErrorEnvelope) Is(target error) bool {
+	t, ok := target.(*ErrorEnvelope)
+	return ok && t.Code != "" && t.Code == e.Code
+}
+
+// Walk calls fn for err and every error it wraps, depth first and in order,
+// following both Unwrap() error and Unwrap() []error (errors.Join). Walking
+// stops when fn returns false.
+func Walk(err error, fn func(error) bool) {
+	stack := []error{err}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current == nil {
+			continue
+		}
+		if !fn(current) {
+			return
+		}
+
+		switch wrapped := current.(type) {
+		case interface{ Unwrap() []error }:
+			errs := wrapped.Unwrap()
+			for i := len(errs) - 1; i >= 0; i-- {
+				stack = append(stack, errs[i])
+			}
+		case interface{ Unwrap() error }:
+			stack = append(stack, wrapped.Unwrap())
+		}
+	}
+}
+
+// Envelopes returns every ErrorEnvelope in err's tree, in Walk order.
+func Envelopes(err error) []*ErrorEnvelope {
+	var envelopes []*ErrorEnvelope
+	Walk(err, func(current error) bool {
+		if envelope, ok := current.(*ErrorEnvelope); ok {
+			envelopes = append(envelopes, envelope)
+		}
+		return true
+	})
+	return envelopes
+}
+
+// FindCode returns the first envelope in err's tree with the given code, or
+// nil if there is none.
+func FindCode(err error, code string) *ErrorEnvelope {
+	var found *ErrorEnvelope
+	Walk(err, func(current error) bool {
+		if envelope, ok := current.(*ErrorEnvelope); ok && envelope.Code == code {
+			found = envelope
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// HasCode reports whether err's tree contains an envelope with the given code.
+func HasCode(err error, code string) bool {
+	return FindCode(err, code) != nil
+}
@@ -0,0 +1,141 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCauses(t *testing.T) {
+	missing := fmt.Errorf("open app.yaml: %w", fs.ErrNotExist)
+	denied := NewErrorEnvelope("ACCESS_DENIED", "cannot read secrets.yaml")
+	envelope := NewErrorEnvelope("CONFIG_INVALID", "configuration rejected").WithCauses(missing, nil, denied)
+
+	assert.Equal(t, []error{missing, denied}, envelope.Errors())
+	assert.ErrorIs(t, envelope, fs.ErrNotExist)
+	var target *ErrorEnvelope
+	require.True(t, errors.As(envelope.Unwrap(), &target))
+	assert.Equal(t, "ACCESS_DENIED", target.Code)
+
+	assert.Equal(t, []ErrorCause{
+		{Message: missing.Error()},
+		{Message: fs.ErrNotExist.Error()},
+		{Message: "cannot read secrets.yaml", Code: "ACCESS_DENIED"},
+	}, envelope.Causes)
+	assert.Equal(t, missing.Error()+" (and 1 more)", envelope.Original)
+
+	data, err := Marshal(envelope)
+	require.NoError(t, err)
+	decoded, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, envelope.Causes, decoded.Causes)
+}
+
+func TestWithCauses_Single(t *testing.T) {
+	cause := errors.New("boom")
+	assert.Equal(t,
+		NewErrorEnvelope("X", "x").WithCause(cause).Causes,
+		NewErrorEnvelope("X", "x").WithCauses(cause).Causes)
+
+	envelope := NewErrorEnvelope("X", "x").WithCauses(nil)
+	assert.Nil(t, envelope.Unwrap())
+	assert.Empty(t, envelope.Errors())
+}
+
+func TestAggregate(t *testing.T) {
+	agg := NewAggregate("EXTRACT_PARTIAL", "some entries failed to extract")
+	assert.NoError(t, agg.ErrorOrNil())
+
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		agg.Add(NewErrorEnvelope("ENTRY_FAILED", "cannot extract "+name).WithPath(name))
+	}
+	agg.Add(nil)
+
+	err := agg.ErrorOrNil()
+	require.Error(t, err)
+	assert.Len(t, agg.Errors(), 3)
+	assert.Len(t, agg.Causes, 3)
+	assert.Equal(t, agg.Errors()[0].Error()+" (and 2 more)", agg.Original)
+	assert.ErrorIs(t, err, NewErrorEnvelope("ENTRY_FAILED", ""))
+	assert.NotErrorIs(t, err, NewErrorEnvelope("IO_FAILED", ""))
+}
+
+func TestAggregate_KeepsEarlierCause(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+	envelope := NewErrorEnvelope("X", "x").WithCause(first).Add(second)
+
+	assert.Equal(t, []error{first, second}, envelope.Errors())
+	assert.ErrorIs(t, envelope, first)
+	assert.ErrorIs(t, envelope, second)
+}
+
+func TestAggregate_CausesBounded(t *testing.T) {
+	agg := NewAggregate("BATCH_FAILED", "batch failed")
+	for i := 0; i < maxCauseDepth*2; i++ {
+		agg.Add(fmt.Errorf("entry %d", i))
+	}
+	assert.Len(t, agg.Errors(), maxCauseDepth*2)
+	assert.Len(t, agg.Causes, maxCauseDepth)
+}
+
+func TestWalk(t *testing.T) {
+	inner := NewErrorEnvelope("IO_FAILED", "read failed")
+	outer := NewErrorEnvelope("LOAD_FAILED", "load failed").
+		WithCauses(errors.New("first"), fmt.Errorf("second: %w", inner))
+	wrapped := fmt.Errorf("startup: %w", outer)
+
+	var messages []string
+	Walk(wrapped, func(err error) bool {
+		messages = append(messages, err.Error())
+		return true
+	})
+	assert.Equal(t, []string{
+		wrapped.Error(),
+		outer.Error(),
+		"first\nsecond: " + inner.Error(),
+		"first",
+		"second: " + inner.Error(),
+		inner.Error(),
+	}, messages)
+
+	var visited int
+	Walk(wrapped, func(err error) bool {
+		visited++
+		return visited < 2
+	})
+	assert.Equal(t, 2, visited)
+
+	Walk(nil, func(error) bool {
+		t.Fatal("fn called for nil error")
+		return true
+	})
+}
+
+func TestFindCode(t *testing.T) {
+	inner := NewErrorEnvelope("IO_FAILED", "read failed")
+	outer := NewErrorEnvelope("LOAD_FAILED", "load failed").
+		WithCauses(errors.New("first"), fmt.Errorf("second: %w", inner))
+	wrapped := fmt.Errorf("startup: %w", outer)
+
+	assert.Same(t, inner, FindCode(wrapped, "IO_FAILED"))
+	assert.Same(t, outer, FindCode(wrapped, "LOAD_FAILED"))
+	assert.Nil(t, FindCode(wrapped, "CONFIG_INVALID"))
+	assert.True(t, HasCode(wrapped, "IO_FAILED"))
+	assert.False(t, HasCode(errors.New("plain"), "IO_FAILED"))
+
+	envelopes := Envelopes(wrapped)
+	require.Len(t, envelopes, 2)
+	assert.Same(t, outer, envelopes[0])
+	assert.Same(t, inner, envelopes[1])
+}
+
+func TestEnvelopeIs(t *testing.T) {
+	envelope := NewErrorEnvelope("CONFIG_INVALID", "configuration rejected")
+	assert.ErrorIs(t, fmt.Errorf("wrap: %w", envelope), NewErrorEnvelope("CONFIG_INVALID", "other message"))
+	assert.NotErrorIs(t, envelope, NewErrorEnvelope("", ""))
+}
@@ -49,6 +49,16 @@ type ErrorEnvelope struct {
 	ExitCode      *int                   `json:"exit_code,omitempty"`
 	Context       map[string]interface{} `json:"context,omitempty"`
 	Original      interface{}            `json:"original,omitempty"`
+	Causes        []ErrorCause           `json:"causes,omitempty"`
+
+	// Retry metadata (see Classify, IsRetryable, RetryAfter)
+	Classification Classification `json:"classification,omitempty"`
+	Retryable      *bool          `json:"retryable,omitempty"`
+	RetryAfterMs   int64          `json:"retry_after_ms,omitempty"`
+
+	cause      error   // set by WithCause or WithCauses, not serialized
+	causes     []error // set by WithCauses or Add, not serialized
+	httpStatus int     // set by WithHTTPStatus, not serialized
 }
 
 // NewErrorEnvelope creates a new error envelope with required fields
@@ -101,8 +111,15 @@ func (e *ErrorEnvelope) WithExitCode(code int) *ErrorEnvelope {
 	return e
 }
 
+// Redactable is implemented by sensitive values, such as config.Secret.
+// WithContext records their Redacted form instead of the value.
+type Redactable interface {
+	Redacted() string
+}
+
 // WithContext adds structured context, validating entries against schema constraints.
 // Only allows: string, number, boolean, or array of strings.
+// Redactable values are stored redacted.
 // Invalid entries are filtered out and an error is returned.
 func (e *ErrorEnvelope) WithContext(context map[string]interface{}) (*ErrorEnvelope, error) {
 	if context == nil {
@@ -114,6 +131,9 @@ func (e *ErrorEnvelope) WithContext(context map[string]interface{}) (*ErrorEnvel
 	var validationErrors []string
 
 	for key, value := range context {
+		if r, ok := value.(Redactable); ok {
+			value = r.Redacted()
+		}
 		if err := validateContextValue(value); err != nil {
 			validationErrors = append(validationErrors, fmt.Sprintf("key %q: %s", key, err))
 			continue // Skip invalid entries
@@ -0,0 +1,111 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// ProblemContentType is the RFC 9457 media type for problem details.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 9457 problem details object. The envelope's code,
+// severity, correlation and trace IDs, timestamp, and context are carried as
+// extension members; details, the original error, and the cause chain are
+// omitted so internal error text is not exposed to clients.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	Code          string                 `json:"code,omitempty"`
+	Severity      Severity               `json:"severity,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	TraceID       string                 `json:"trace_id,omitempty"`
+	Timestamp     string                 `json:"timestamp,omitempty"`
+	Context       map[string]interface{} `json:"context,omitempty"`
+}
+
+// WithHTTPStatus sets the HTTP status used when the envelope is written as a
+// problem response. It is not part of the serialized envelope.
+func (e *ErrorEnvelope) WithHTTPStatus(status int) *ErrorEnvelope {
+	e.httpStatus = status
+	return e
+}
+
+// HTTPStatus returns the status set by WithHTTPStatus, or 500.
+func (e *ErrorEnvelope) HTTPStatus() int {
+	if e.httpStatus == 0 {
+		return http.StatusInternalServerError
+	}
+	return e.httpStatus
+}
+
+// Problem converts the envelope to RFC 9457 problem details with the given
+// status. The type is "about:blank", so the title is the status text.
+func (e *ErrorEnvelope) Problem(status int) Problem {
+	return Problem{
+		Type:          "about:blank",
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        e.Message,
+		Code:          e.Code,
+		Severity:      e.Severity,
+		CorrelationID: e.CorrelationID,
+		TraceID:       e.TraceID,
+		Timestamp:     e.Timestamp,
+		Context:       e.Context,
+	}
+}
+
+// WriteProblem writes err as an application/problem+json response. An
+// *ErrorEnvelope anywhere in err's chain supplies the status (see
+// WithHTTPStatus) and extension members, and WithRetryAfter sets the
+// Retry-After header; other errors become a generic 500 INTERNAL_ERROR
+// problem without their message.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	var envelope *ErrorEnvelope
+	if !errors.As(err, &envelope) {
+		envelope = &ErrorEnvelope{Code: "INTERNAL_ERROR", Message: http.StatusText(http.StatusInternalServerError)}
+	}
+
+	status := envelope.HTTPStatus()
+	problem := envelope.Problem(status)
+	if r != nil && r.URL != nil {
+		problem.Instance = r.URL.Path
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	if envelope.RetryAfterMs > 0 {
+		// Retry-After is in whole seconds; round up so clients never retry early
+		w.Header().Set("Retry-After", strconv.FormatInt((envelope.RetryAfterMs+999)/1000, 10))
+	}
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// HandlerFunc is an HTTP handler that reports failure by returning an error.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP implements http.Handler, writing a returned error with WriteProblem.
+//
+// Example:
+//
+//	http.Handle("/config", errors.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+//	    cfg, err := loadConfig(r.Context())
+//	    if err != nil {
+//	        return errors.NewErrorEnvelope("CONFIG_UNAVAILABLE", "configuration unavailable").
+//	            WithHTTPStatus(http.StatusServiceUnavailable).
+//	            WithCause(err)
+//	    }
+//	    return json.NewEncoder(w).Encode(cfg)
+//	}))
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f(w, r); err != nil {
+		WriteProblem(w, r, err)
+	}
+}
@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteProblemFromEnvelope(t *testing.T) {
+	envelope, err := NewErrorEnvelope("NOT_FOUND", "profile not found").
+		WithCorrelationID("corr-1").
+		WithHTTPStatus(http.StatusNotFound).
+		WithSeverity(SeverityLow)
+	require.NoError(t, err)
+	envelope.WithCause(errors.New("sql: no rows in result set"))
+
+	req := httptest.NewRequest(http.MethodGet, "/profiles/42?expand=1", nil)
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, req, envelope)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "about:blank", body["type"])
+	assert.Equal(t, "Not Found", body["title"])
+	assert.Equal(t, float64(404), body["status"])
+	assert.Equal(t, "profile not found", body["detail"])
+	assert.Equal(t, "/profiles/42", body["instance"])
+	assert.Equal(t, "NOT_FOUND", body["code"])
+	assert.Equal(t, "corr-1", body["correlation_id"])
+	assert.NotContains(t, rec.Body.String(), "sql:", "causes are not exposed")
+}
+
+func TestWriteProblemPlainError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, httptest.NewRequest(http.MethodGet, "/", nil), errors.New("secret internal detail"))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "secret")
+	assert.Contains(t, rec.Body.String(), `"code":"INTERNAL_ERROR"`)
+}
+
+func TestHandlerFunc(t *testing.T) {
+	handler := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		if r.URL.Query().Get("fail") != "" {
+			envelope := NewErrorEnvelope("BAD_INPUT", "invalid input").WithHTTPStatus(http.StatusBadRequest)
+			return errors.Join(errors.New("wrapped"), envelope)
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?fail=1", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"BAD_INPUT"`)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
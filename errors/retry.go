@@ -0,0 +1,202 @@
+package errors
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+)
+
+// Classification tells generic retry logic how to treat an error.
+type Classification string
+
+const (
+	// ClassificationTransient errors may succeed if retried (I/O hiccups,
+	// timeouts, unavailable dependencies).
+	ClassificationTransient Classification = "transient"
+
+	// ClassificationPermanent errors fail the same way on every attempt
+	// (invalid input, corrupt data, missing files).
+	ClassificationPermanent Classification = "permanent"
+
+	// ClassificationSecurity errors are rejected for safety reasons (path
+	// traversal, decompression bombs) and must never be retried.
+	ClassificationSecurity Classification = "security"
+)
+
+var (
+	classificationsMu   sync.RWMutex
+	codeClassifications = map[string]Classification{}
+)
+
+// RegisterClassifications records the classification of error codes. Each
+// module registers its own codes when it is imported (fulpack, pathfinder,
+// schema/validation); a later registration of a code replaces the earlier
+// one.
+//
+// Example:
+//
+//	func init() {
+//	    errors.RegisterClassifications(map[string]errors.Classification{
+//	        "UPSTREAM_BUSY": errors.ClassificationTransient,
+//	    })
+//	}
+func RegisterClassifications(classes map[string]Classification) {
+	classificationsMu.Lock()
+	defer classificationsMu.Unlock()
+	for code, c := range classes {
+		codeClassifications[code] = c
+	}
+}
+
+// Classifier is implemented by errors that know their classification, such
+// as *fulpack.FulpackError. An empty result means unclassified.
+type Classifier interface {
+	Classification() Classification
+}
+
+// ClassifyCode returns the registered classification of an error code, or
+// "" for codes no imported module has registered.
+func ClassifyCode(code string) Classification {
+	classificationsMu.RLock()
+	defer classificationsMu.RUnlock()
+	return codeClassifications[code]
+}
+
+// WithClassification sets the envelope's classification, overriding the one
+// derived from its code.
+func (e *ErrorEnvelope) WithClassification(c Classification) *ErrorEnvelope {
+	e.Classification = c
+	return e
+}
+
+// WithRetryable records whether the operation may be retried, overriding
+// the default derived from the classification.
+func (e *ErrorEnvelope) WithRetryable(retryable bool) *ErrorEnvelope {
+	e.Retryable = &retryable
+	return e
+}
+
+// WithRetryAfter records how long callers should wait before retrying
+// (rounded to milliseconds). WriteProblem sends it as a Retry-After header.
+func (e *ErrorEnvelope) WithRetryAfter(d time.Duration) *ErrorEnvelope {
+	e.RetryAfterMs = d.Milliseconds()
+	return e
+}
+
+// Classify returns the classification of the first error in err's tree
+// (see Walk) that has one: an envelope's Classification or code, or a
+// Classifier. It agrees with foundry.RetryPolicy: errors wrapped with
+// foundry.Permanent are permanent, and a *foundry.HTTPStatusError is
+// transient when HTTPStatusHelper.IsRetryable accepts its status and
+// permanent otherwise. context.DeadlineExceeded and errors reporting
+// Timeout() are transient. It returns "" if nothing in the tree is
+// classified.
+func Classify(err error) Classification {
+	var found Classification
+	Walk(err, func(current error) bool {
+		found = classifyOne(current)
+		return found == ""
+	})
+	return found
+}
+
+func classifyOne(err error) Classification {
+	if envelope, ok := err.(*ErrorEnvelope); ok {
+		if envelope.Classification != "" {
+			return envelope.Classification
+		}
+		return ClassifyCode(envelope.Code)
+	}
+	if classifier, ok := err.(Classifier); ok {
+		return classifier.Classification()
+	}
+	if foundry.IsPermanent(err) {
+		return ClassificationPermanent
+	}
+	if status, ok := err.(*foundry.HTTPStatusError); ok {
+		if status.Class == foundry.RetryClassNone {
+			return ClassificationPermanent
+		}
+		return ClassificationTransient
+	}
+	if timeout, ok := err.(interface{ Timeout() bool }); (ok && timeout.Timeout()) || err == context.DeadlineExceeded {
+		return ClassificationTransient
+	}
+	return ""
+}
+
+// IsRetryable reports whether retrying the operation that returned err may
+// succeed. The first envelope in err's tree with WithRetryable set decides;
+// otherwise err is retryable when Classify reports it transient.
+//
+// Example:
+//
+//	for attempt := 1; ; attempt++ {
+//	    err := op(ctx)
+//	    if err == nil || attempt == maxAttempts || !errors.IsRetryable(err) {
+//	        return err
+//	    }
+//	    time.Sleep(max(errors.RetryAfter(err), backoff(attempt)))
+//	}
+func IsRetryable(err error) bool {
+	var explicit *bool
+	Walk(err, func(current error) bool {
+		if envelope, ok := current.(*ErrorEnvelope); ok && envelope.Retryable != nil {
+			explicit = envelope.Retryable
+		}
+		return explicit == nil
+	})
+	if explicit != nil {
+		return *explicit
+	}
+	return err != nil && Classify(err) == ClassificationTransient
+}
+
+// RetryAfter returns the delay requested by the first error in err's tree
+// that carries one: an envelope with WithRetryAfter set, or a
+// *foundry.HTTPStatusError with a Retry-After header. It returns 0 if none
+// does.
+func RetryAfter(err error) time.Duration {
+	var after time.Duration
+	Walk(err, func(current error) bool {
+		if delayer, ok := current.(interface{ RetryDelay() (time.Duration, bool) }); ok {
+			if delay, ok := delayer.RetryDelay(); ok {
+				after = delay
+			}
+		}
+		return after == 0
+	})
+	return after
+}
+
+// RetryDelay returns the WithRetryAfter delay, so foundry.RetryPolicy.Do
+// waits as long as the envelope asks before the next attempt.
+func (e *ErrorEnvelope) RetryDelay() (time.Duration, bool) {
+	return time.Duration(e.RetryAfterMs) * time.Millisecond, e.RetryAfterMs > 0
+}
+
+// RetryDecision is a foundry.RetryClassifier built on IsRetryable. Set it as
+// a policy's Classifier so Do stops on errors classified permanent or
+// security; unclassified errors are left to Do's default of retrying.
+//
+// Example:
+//
+//	policy := foundry.DefaultRetryPolicy()
+//	policy.Classifier = errors.RetryDecision
+func RetryDecision(err error) (retryable bool, known bool) {
+	if Classify(err) == "" {
+		var explicit bool
+		Walk(err, func(current error) bool {
+			if envelope, ok := current.(*ErrorEnvelope); ok && envelope.Retryable != nil {
+				explicit = true
+			}
+			return !explicit
+		})
+		if !explicit {
+			return false, false
+		}
+	}
+	return IsRetryable(err), true
+}
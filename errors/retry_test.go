@@ -0,0 +1,168 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "i/o timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+type classifiedError struct{ c Classification }
+
+func (e classifiedError) Error() string                  { return string(e.c) }
+func (e classifiedError) Classification() Classification { return e.c }
+
+// Modules register their own codes; these stand in for them in this package
+func init() {
+	RegisterClassifications(map[string]Classification{
+		"PATH_TRAVERSAL":     ClassificationSecurity,
+		"DECOMPRESSION_BOMB": ClassificationSecurity,
+		"CHECKSUM_MISMATCH":  ClassificationPermanent,
+		"FILE_ACCESS_ERROR":  ClassificationTransient,
+	})
+}
+
+func TestClassifyCode(t *testing.T) {
+	assert.Equal(t, ClassificationSecurity, ClassifyCode("PATH_TRAVERSAL"))
+	assert.Equal(t, ClassificationTransient, ClassifyCode("FILE_ACCESS_ERROR"))
+	assert.Equal(t, Classification(""), ClassifyCode("SOMETHING_ELSE"))
+
+	RegisterClassifications(map[string]Classification{"SOMETHING_ELSE": ClassificationPermanent})
+	t.Cleanup(func() {
+		classificationsMu.Lock()
+		delete(codeClassifications, "SOMETHING_ELSE")
+		classificationsMu.Unlock()
+	})
+	assert.Equal(t, ClassificationPermanent, ClassifyCode("SOMETHING_ELSE"))
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Classification
+	}{
+		{"nil", nil, ""},
+		{"plain error", errors.New("boom"), ""},
+		{"envelope code", NewErrorEnvelope("DECOMPRESSION_BOMB", "too big"), ClassificationSecurity},
+		{"explicit classification", NewErrorEnvelope("PATH_TRAVERSAL", "x").WithClassification(ClassificationPermanent), ClassificationPermanent},
+		{"wrapped envelope", fmt.Errorf("extract: %w", NewErrorEnvelope("CHECKSUM_MISMATCH", "x")), ClassificationPermanent},
+		{"unknown code with classified cause", NewErrorEnvelope("LOAD_FAILED", "x").WithCause(timeoutError{}), ClassificationTransient},
+		{"classifier", fmt.Errorf("op: %w", classifiedError{ClassificationSecurity}), ClassificationSecurity},
+		{"deadline exceeded", fmt.Errorf("fetch: %w", context.DeadlineExceeded), ClassificationTransient},
+		{"foundry permanent", foundry.Permanent(errors.New("bad request")), ClassificationPermanent},
+		{"retryable status", &foundry.HTTPStatusError{StatusCode: 503, Class: foundry.RetryClassThrottled}, ClassificationTransient},
+		{"non-retryable status", foundry.Permanent(&foundry.HTTPStatusError{StatusCode: 404, Class: foundry.RetryClassNone}), ClassificationPermanent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Classify(tt.err))
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.False(t, IsRetryable(nil))
+	assert.False(t, IsRetryable(errors.New("boom")))
+	assert.True(t, IsRetryable(NewErrorEnvelope("FILE_ACCESS_ERROR", "x")))
+	assert.False(t, IsRetryable(NewErrorEnvelope("PATH_TRAVERSAL", "x")))
+	assert.True(t, IsRetryable(context.DeadlineExceeded))
+
+	// An explicit setting overrides the classification
+	assert.False(t, IsRetryable(NewErrorEnvelope("FILE_ACCESS_ERROR", "x").WithRetryable(false)))
+	assert.True(t, IsRetryable(fmt.Errorf("wrap: %w", NewErrorEnvelope("UPSTREAM_BUSY", "x").WithRetryable(true))))
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Zero(t, RetryAfter(errors.New("boom")))
+
+	envelope := NewErrorEnvelope("RATE_LIMITED", "slow down").WithRetryAfter(1500 * time.Millisecond)
+	assert.Equal(t, 1500*time.Millisecond, RetryAfter(fmt.Errorf("call: %w", envelope)))
+
+	rec := httptest.NewRecorder()
+	WriteProblem(rec, nil, envelope.WithHTTPStatus(429))
+	assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+}
+
+func TestRetryAfter_HTTPStatusError(t *testing.T) {
+	err := fmt.Errorf("fetch: %w", &foundry.HTTPStatusError{StatusCode: 429, Class: foundry.RetryClassThrottled, RetryAfter: 3 * time.Second})
+	assert.Equal(t, 3*time.Second, RetryAfter(err))
+	assert.True(t, IsRetryable(err))
+}
+
+// TestRetryPolicyHonorsClassification verifies foundry.RetryPolicy.Do with
+// RetryDecision stops on errors classified permanent or security and honors
+// envelope Retry-After
+func TestRetryPolicyHonorsClassification(t *testing.T) {
+	policy := &foundry.RetryPolicy{
+		InitialInterval: foundry.HumanDuration(time.Millisecond),
+		MaxInterval:     foundry.HumanDuration(time.Second),
+		Multiplier:      2,
+		MaxAttempts:     3,
+		Jitter:          foundry.JitterNone,
+	}
+
+	// Importing errors does not change policies without a Classifier
+	attempts := 0
+	err := policy.Do(context.Background(), func(context.Context) error {
+		attempts++
+		return fmt.Errorf("extract: %w", NewErrorEnvelope("PATH_TRAVERSAL", "x"))
+	})
+	require.Error(t, err)
+	assert.Equal(t, 3, attempts, "no classifier retries every error")
+
+	policy.Classifier = RetryDecision
+	attempts = 0
+	err = policy.Do(context.Background(), func(context.Context) error {
+		attempts++
+		return fmt.Errorf("extract: %w", NewErrorEnvelope("PATH_TRAVERSAL", "x"))
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts, "security errors are not retried")
+
+	attempts = 0
+	start := time.Now()
+	err = policy.Do(context.Background(), func(context.Context) error {
+		attempts++
+		return NewErrorEnvelope("FILE_ACCESS_ERROR", "x").WithRetryAfter(20 * time.Millisecond)
+	})
+	require.Error(t, err)
+	assert.Equal(t, 3, attempts, "transient errors are retried")
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "envelope Retry-After is honored")
+
+	attempts = 0
+	_ = policy.Do(context.Background(), func(context.Context) error {
+		attempts++
+		return errors.New("unclassified")
+	})
+	assert.Equal(t, 3, attempts, "unclassified errors keep the default of retrying")
+}
+
+func TestRetryMetadataRoundTrip(t *testing.T) {
+	envelope := newWireEnvelope(t).
+		WithClassification(ClassificationTransient).
+		WithRetryable(true).
+		WithRetryAfter(2 * time.Second)
+
+	data, err := Marshal(envelope)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"classification":"transient","retryable":true,"retry_after_ms":2000`)
+
+	decoded, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, ClassificationTransient, decoded.Classification)
+	require.NotNil(t, decoded.Retryable)
+	assert.True(t, *decoded.Retryable)
+	assert.Equal(t, 2*time.Second, RetryAfter(decoded))
+}
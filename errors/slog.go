@@ -0,0 +1,143 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+)
+
+// Log attribute keys used for envelopes and by CorrelationHandler. They match
+// the envelope's wire format.
+const (
+	LogKeyError         = "error"
+	LogKeyCorrelationID = "correlation_id"
+)
+
+// LogAttrs renders the envelope as slog attributes: code, message, severity,
+// path, correlation_id and trace_id when set, and Context as a "context"
+// group. Details and the original error are not included.
+//
+// Example:
+//
+//	logger.LogAttrs(ctx, slog.LevelError, "request failed", envelope.LogAttrs()...)
+func (e *ErrorEnvelope) LogAttrs() []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("code", e.Code),
+		slog.String("message", e.Message),
+	}
+	if e.Severity != "" {
+		attrs = append(attrs, slog.String("severity", string(e.Severity)))
+	}
+	if e.Path != "" {
+		attrs = append(attrs, slog.String("path", e.Path))
+	}
+	if e.CorrelationID != "" {
+		attrs = append(attrs, slog.String(LogKeyCorrelationID, e.CorrelationID))
+	}
+	if e.TraceID != "" {
+		attrs = append(attrs, slog.String("trace_id", e.TraceID))
+	}
+	if len(e.Context) > 0 {
+		keys := make([]string, 0, len(e.Context))
+		for key := range e.Context {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fields := make([]any, 0, len(keys))
+		for _, key := range keys {
+			fields = append(fields, slog.Any(key, e.Context[key]))
+		}
+		attrs = append(attrs, slog.Group("context", fields...))
+	}
+	return attrs
+}
+
+// LogValue implements slog.LogValuer, so an envelope logged as a value
+// renders as a group of its LogAttrs.
+func (e *ErrorEnvelope) LogValue() slog.Value {
+	return slog.GroupValue(e.LogAttrs()...)
+}
+
+// LogAttr returns an "error" attribute for err: a group of the outermost
+// envelope's LogAttrs if err is or wraps an ErrorEnvelope, otherwise err's
+// message. A nil err yields an empty attribute, which slog ignores.
+//
+// Example:
+//
+//	if err := load(ctx); err != nil {
+//	    logger.ErrorContext(ctx, "load failed", errors.LogAttr(err))
+//	}
+func LogAttr(err error) slog.Attr {
+	if err == nil {
+		return slog.Attr{}
+	}
+	var envelope *ErrorEnvelope
+	if errors.As(err, &envelope) {
+		return slog.Attr{Key: LogKeyError, Value: envelope.LogValue()}
+	}
+	return slog.String(LogKeyError, err.Error())
+}
+
+// CorrelationHandler is a slog.Handler that adds the correlation ID from
+// the record's context (see foundry.WithCorrelationID) as a correlation_id
+// attribute. Records that already carry a top-level correlation_id, or are
+// logged without one in context, pass through unchanged.
+//
+// Attributes are added to the record, so after WithGroup the correlation ID
+// is nested in the open group.
+type CorrelationHandler struct {
+	next           slog.Handler
+	hasCorrelation bool // correlation_id already added with WithAttrs
+}
+
+// NewCorrelationHandler wraps next with correlation ID enrichment.
+//
+// Example:
+//
+//	logger := slog.New(errors.NewCorrelationHandler(slog.NewJSONHandler(os.Stderr, nil)))
+//	ctx := foundry.WithCorrelationID(r.Context(), foundry.NewCorrelationIDValue())
+//	logger.ErrorContext(ctx, "request failed", errors.LogAttr(err))
+func NewCorrelationHandler(next slog.Handler) *CorrelationHandler {
+	return &CorrelationHandler{next: next}
+}
+
+// Enabled reports whether the wrapped handler handles records at level.
+func (h *CorrelationHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle adds the context's correlation ID to r and passes it on.
+func (h *CorrelationHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id, ok := foundry.CorrelationIDFromContext(ctx); ok && !h.hasCorrelation && !recordHasKey(r, LogKeyCorrelationID) {
+		r = r.Clone()
+		r.AddAttrs(slog.String(LogKeyCorrelationID, id.String()))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs returns a CorrelationHandler wrapping next.WithAttrs(attrs).
+func (h *CorrelationHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	has := h.hasCorrelation
+	for _, attr := range attrs {
+		has = has || attr.Key == LogKeyCorrelationID
+	}
+	return &CorrelationHandler{next: h.next.WithAttrs(attrs), hasCorrelation: has}
+}
+
+// WithGroup returns a CorrelationHandler wrapping next.WithGroup(name).
+func (h *CorrelationHandler) WithGroup(name string) slog.Handler {
+	return &CorrelationHandler{next: h.next.WithGroup(name), hasCorrelation: h.hasCorrelation}
+}
+
+// recordHasKey reports whether r has a top-level attribute named key.
+func recordHasKey(r slog.Record, key string) bool {
+	found := false
+	r.Attrs(func(attr slog.Attr) bool {
+		found = attr.Key == key
+		return !found
+	})
+	return found
+}
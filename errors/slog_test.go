@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newJSONLogger(handler func(slog.Handler) slog.Handler) (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	var h slog.Handler = slog.NewJSONHandler(&buf, nil)
+	if handler != nil {
+		h = handler(h)
+	}
+	return slog.New(h), &buf
+}
+
+func decodeLogLine(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	return record
+}
+
+func TestLogAttr_Envelope(t *testing.T) {
+	logger, buf := newJSONLogger(nil)
+	envelope := newWireEnvelope(t).WithPath("app.yaml")
+
+	logger.Error("load failed", LogAttr(fmt.Errorf("startup: %w", envelope)))
+
+	record := decodeLogLine(t, buf)
+	logged, ok := record["error"].(map[string]interface{})
+	require.True(t, ok, "error should be a group: %v", record["error"])
+	assert.Equal(t, "CONFIG_INVALID", logged["code"])
+	assert.Equal(t, "configuration rejected", logged["message"])
+	assert.Equal(t, "high", logged["severity"])
+	assert.Equal(t, "app.yaml", logged["path"])
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", logged["correlation_id"])
+	assert.Equal(t, map[string]interface{}{"file": "app.yaml", "line": float64(12)}, logged["context"])
+}
+
+func TestLogAttr_PlainError(t *testing.T) {
+	logger, buf := newJSONLogger(nil)
+	logger.Error("load failed", LogAttr(errors.New("boom")), LogAttr(nil))
+
+	record := decodeLogLine(t, buf)
+	assert.Equal(t, "boom", record["error"])
+}
+
+func TestEnvelopeLogValue(t *testing.T) {
+	logger, buf := newJSONLogger(nil)
+	logger.Info("rejected", "envelope", NewErrorEnvelope("X", "x"))
+
+	record := decodeLogLine(t, buf)
+	assert.Equal(t, map[string]interface{}{"code": "X", "message": "x"}, record["envelope"])
+}
+
+func TestCorrelationHandler(t *testing.T) {
+	id := foundry.NewCorrelationIDValue()
+	ctx := foundry.WithCorrelationID(context.Background(), id)
+
+	t.Run("adds correlation ID from context", func(t *testing.T) {
+		logger, buf := newJSONLogger(func(h slog.Handler) slog.Handler { return NewCorrelationHandler(h) })
+		logger.InfoContext(ctx, "hello")
+		assert.Equal(t, id.String(), decodeLogLine(t, buf)["correlation_id"])
+	})
+
+	t.Run("without correlation ID in context", func(t *testing.T) {
+		logger, buf := newJSONLogger(func(h slog.Handler) slog.Handler { return NewCorrelationHandler(h) })
+		logger.InfoContext(context.Background(), "hello")
+		assert.NotContains(t, decodeLogLine(t, buf), "correlation_id")
+	})
+
+	t.Run("keeps explicit correlation ID", func(t *testing.T) {
+		logger, buf := newJSONLogger(func(h slog.Handler) slog.Handler { return NewCorrelationHandler(h) })
+		logger.InfoContext(ctx, "hello", "correlation_id", "explicit")
+		assert.Equal(t, "explicit", decodeLogLine(t, buf)["correlation_id"])
+
+		buf.Reset()
+		logger.With("correlation_id", "bound").InfoContext(ctx, "hello")
+		assert.Equal(t, "bound", decodeLogLine(t, buf)["correlation_id"])
+	})
+
+	t.Run("level delegated", func(t *testing.T) {
+		h := NewCorrelationHandler(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn}))
+		assert.False(t, h.Enabled(ctx, slog.LevelInfo))
+		assert.True(t, h.Enabled(ctx, slog.LevelError))
+	})
+}
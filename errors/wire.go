@@ -0,0 +1,175 @@
+package errors
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+// EnvelopeSchemaID is the catalog ID of the schema describing the serialized
+// ErrorEnvelope wire format.
+const EnvelopeSchemaID = "error-handling/v1.0.0/error-response"
+
+// maxCauseDepth bounds the cause chain recorded by WithCause.
+const maxCauseDepth = 32
+
+// The Crucible error-response schema is synced from the Crucible SSOT, so the
+// envelope fields gofulmen adds ahead of Crucible (causes, classification,
+// retryable, retry_after_ms) are described in a schema owned by this package
+// and validated in addition to the Crucible one.
+//
+//go:embed error-response-ext.schema.json
+var envelopeExtSchema []byte
+
+var (
+	envelopeValidatorOnce sync.Once
+	envelopeValidator     *schema.Validator
+	envelopeExtValidator  *schema.Validator
+	envelopeValidatorErr  error
+)
+
+// EnvelopeExtSchema returns the JSON Schema for the envelope fields gofulmen
+// adds to the Crucible error-response schema.
+func EnvelopeExtSchema() []byte {
+	return append([]byte(nil), envelopeExtSchema...)
+}
+
+// ErrorCause is one entry of an envelope's serialized cause chain.
+type ErrorCause struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// WithCause records err as the envelope's cause: Original is set to its
+// message, Causes lists the wrapped error chain (outermost first), and
+// errors.Is/errors.As see through the envelope to err.
+func (e *ErrorEnvelope) WithCause(err error) *ErrorEnvelope {
+	if err == nil {
+		return e
+	}
+	e.cause, e.causes = err, nil
+	e.Original = err.Error()
+	e.Causes = causeChain(err)
+	return e
+}
+
+// Unwrap returns the error recorded by WithCause, if any.
+func (e *ErrorEnvelope) Unwrap() error {
+	return e.cause
+}
+
+// causeChain flattens err and the errors it wraps, depth first.
+func causeChain(err error) []ErrorCause {
+	var chain []ErrorCause
+	Walk(err, func(current error) bool {
+		cause := ErrorCause{Message: current.Error()}
+		if envelope, ok := current.(*ErrorEnvelope); ok {
+			cause.Message = envelope.Message
+			cause.Code = envelope.Code
+		}
+		chain = append(chain, cause)
+		return len(chain) < maxCauseDepth
+	})
+	return chain
+}
+
+// Marshal encodes an envelope in its canonical JSON wire format, validated
+// against the error-response schema.
+//
+// Example:
+//
+//	envelope := errors.NewErrorEnvelope("CONFIG_INVALID", "config rejected").
+//	    WithCorrelationID(correlationID).
+//	    WithCause(err)
+//	data, err := errors.Marshal(envelope)
+func Marshal(e *ErrorEnvelope) ([]byte, error) {
+	if e == nil {
+		return nil, fmt.Errorf("error envelope is nil")
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode error envelope: %w", err)
+	}
+	if err := validateEnvelopeJSON(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Unmarshal decodes an envelope from its JSON wire format after validating it
+// against the error-response schema.
+func Unmarshal(data []byte) (*ErrorEnvelope, error) {
+	if err := validateEnvelopeJSON(data); err != nil {
+		return nil, err
+	}
+	var envelope ErrorEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode error envelope: %w", err)
+	}
+	return &envelope, nil
+}
+
+// MarshalYAML encodes an envelope as YAML with the same field names and
+// validation as Marshal.
+func MarshalYAML(e *ErrorEnvelope) ([]byte, error) {
+	data, err := Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to encode error envelope: %w", err)
+	}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode error envelope as YAML: %w", err)
+	}
+	return out, nil
+}
+
+// UnmarshalYAML decodes an envelope from YAML produced by MarshalYAML (or
+// any YAML document matching the error-response schema).
+func UnmarshalYAML(data []byte) (*ErrorEnvelope, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode error envelope: %w", err)
+	}
+	return Unmarshal(jsonData)
+}
+
+// validateEnvelopeJSON validates serialized envelope JSON against the
+// Crucible schema and the gofulmen extension schema.
+func validateEnvelopeJSON(data []byte) error {
+	envelopeValidatorOnce.Do(func() {
+		envelopeValidator, envelopeValidatorErr = schema.DefaultCatalog().ValidatorByID(EnvelopeSchemaID)
+		if envelopeValidatorErr == nil {
+			envelopeExtValidator, envelopeValidatorErr = schema.NewValidator(envelopeExtSchema)
+		}
+	})
+	if envelopeValidatorErr != nil {
+		return fmt.Errorf("failed to initialize error envelope validator: %w", envelopeValidatorErr)
+	}
+
+	diags, err := envelopeValidator.ValidateJSON(data)
+	if err != nil {
+		return fmt.Errorf("error envelope validation failed: %w", err)
+	}
+	extDiags, err := envelopeExtValidator.ValidateJSON(data)
+	if err != nil {
+		return fmt.Errorf("error envelope validation failed: %w", err)
+	}
+	diags = append(diags, extDiags...)
+	if verrs := schema.DiagnosticsToValidationErrors(diags); len(verrs) > 0 {
+		return verrs
+	}
+	return nil
+}
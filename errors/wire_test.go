@@ -0,0 +1,130 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWireEnvelope(t *testing.T) *ErrorEnvelope {
+	t.Helper()
+	envelope, err := NewErrorEnvelope("CONFIG_INVALID", "configuration rejected").
+		WithCorrelationID("550e8400-e29b-41d4-a716-446655440000").
+		WithExitCode(2).
+		WithSeverity(SeverityHigh)
+	require.NoError(t, err)
+	envelope, err = envelope.WithContext(map[string]interface{}{"file": "app.yaml", "line": 12})
+	require.NoError(t, err)
+	return envelope
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	cause := fmt.Errorf("load app.yaml: %w", fs.ErrNotExist)
+	envelope := newWireEnvelope(t).WithCause(cause)
+
+	data, err := Marshal(envelope)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"causes":[{"message":"load app.yaml: file does not exist"},{"message":"file does not exist"}]`)
+
+	decoded, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, envelope.Code, decoded.Code)
+	assert.Equal(t, envelope.Message, decoded.Message)
+	assert.Equal(t, SeverityHigh, decoded.Severity)
+	assert.Equal(t, 3, decoded.SeverityLevel)
+	assert.Equal(t, envelope.CorrelationID, decoded.CorrelationID)
+	assert.Equal(t, 2, *decoded.ExitCode)
+	assert.Equal(t, "app.yaml", decoded.Context["file"])
+	assert.Equal(t, envelope.Causes, decoded.Causes)
+	assert.Equal(t, cause.Error(), decoded.Original)
+}
+
+func TestMarshalYAMLRoundTrip(t *testing.T) {
+	envelope := newWireEnvelope(t).WithCause(errors.New("boom"))
+
+	data, err := MarshalYAML(envelope)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "code: CONFIG_INVALID")
+	assert.Contains(t, string(data), "correlation_id: 550e8400-e29b-41d4-a716-446655440000")
+
+	decoded, err := UnmarshalYAML(data)
+	require.NoError(t, err)
+	assert.Equal(t, envelope.Timestamp, decoded.Timestamp)
+	assert.Equal(t, envelope.Causes, decoded.Causes)
+	assert.Equal(t, 2, *decoded.ExitCode)
+}
+
+func TestMarshalRejectsInvalidEnvelope(t *testing.T) {
+	envelope := NewErrorEnvelope("EXIT", "bad exit code").WithExitCode(300)
+	_, err := Marshal(envelope)
+	assert.Error(t, err, "exit codes above 255 violate the schema")
+
+	_, err = Marshal(nil)
+	assert.Error(t, err)
+}
+
+func TestUnmarshalValidatesSchema(t *testing.T) {
+	_, err := Unmarshal([]byte(`{"message": "missing code"}`))
+	assert.Error(t, err)
+
+	_, err = Unmarshal([]byte(`{"code": "X", "message": "m", "severity": "urgent"}`))
+	assert.Error(t, err)
+
+	_, err = UnmarshalYAML([]byte("code: [unclosed"))
+	assert.Error(t, err)
+
+	decoded, err := UnmarshalYAML([]byte("code: DISK_FULL\nmessage: no space left\nseverity: critical\nseverity_level: 4\n"))
+	require.NoError(t, err)
+	assert.Equal(t, SeverityCritical, decoded.Severity)
+}
+
+func TestUnmarshalValidatesExtensionFields(t *testing.T) {
+	decoded, err := Unmarshal([]byte(`{"code": "RATE_LIMITED", "message": "slow down", "timestamp": "2025-01-01T00:00:00Z",
+		"classification": "transient", "retryable": true, "retry_after_ms": 1500, "causes": [{"message": "429", "code": "HTTP"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, ClassificationTransient, decoded.Classification)
+	assert.Equal(t, int64(1500), decoded.RetryAfterMs)
+
+	invalid := []string{
+		`{"code": "X", "message": "m", "classification": "sometimes"}`,
+		`{"code": "X", "message": "m", "retryable": "yes"}`,
+		`{"code": "X", "message": "m", "retry_after_ms": -1}`,
+		`{"code": "X", "message": "m", "causes": [{"code": "NO_MESSAGE"}]}`,
+		`{"code": "X", "message": "m", "causes": "boom"}`,
+	}
+	for _, data := range invalid {
+		_, err := Unmarshal([]byte(data))
+		assert.Error(t, err, data)
+	}
+
+	envelope := NewErrorEnvelope("RATE_LIMITED", "slow down").WithClassification(ClassificationTransient).WithRetryable(true)
+	_, err = Marshal(envelope)
+	require.NoError(t, err)
+}
+
+func TestWithCauseChain(t *testing.T) {
+	inner := NewErrorEnvelope("IO_FAILED", "read failed").WithCause(fs.ErrPermission)
+	joined := errors.Join(errors.New("first"), fmt.Errorf("second: %w", inner))
+	envelope := NewErrorEnvelope("REQUEST_FAILED", "request failed").WithCause(joined)
+
+	assert.True(t, errors.Is(envelope, fs.ErrPermission))
+	var target *ErrorEnvelope
+	require.True(t, errors.As(envelope.Unwrap(), &target))
+	assert.Equal(t, "IO_FAILED", target.Code)
+
+	codes := make([]string, 0, len(envelope.Causes))
+	for _, c := range envelope.Causes {
+		codes = append(codes, c.Code)
+	}
+	assert.Equal(t, []string{"", "", "", "IO_FAILED", ""}, codes)
+	assert.Equal(t, "first", envelope.Causes[1].Message)
+	assert.True(t, strings.HasPrefix(envelope.Causes[2].Message, "second: "))
+	assert.Equal(t, "permission denied", envelope.Causes[4].Message)
+
+	assert.Same(t, envelope, envelope.WithCause(nil))
+}
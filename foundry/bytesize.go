@@ -0,0 +1,241 @@
+package foundry
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ByteSize is a size in bytes that parses human-readable values such as
+// "10GiB", "512 MB", or "1.5KiB" in JSON and YAML configuration.
+//
+// Binary units (KiB, MiB, GiB, TiB, PiB, EiB) are powers of 1024; decimal
+// units (KB, MB, GB, TB, PB, EB, or just K, M, G, ...) are powers of 1000.
+// Units are case-insensitive and a bare number is a count of bytes, so
+// existing integer settings keep working.
+//
+// Values marshal to their canonical form: the largest binary or decimal unit
+// that represents the size exactly ("1GiB", "2MB", "1500B").
+//
+// Example:
+//
+//	type Limits struct {
+//	    MaxUpload foundry.ByteSize `json:"max_upload" yaml:"max_upload"`
+//	}
+//
+//	// max_upload: 10GiB
+//	if size > int64(limits.MaxUpload) { ... }
+type ByteSize int64
+
+// Byte size units.
+const (
+	Byte ByteSize = 1
+
+	KB ByteSize = 1000 * Byte
+	MB ByteSize = 1000 * KB
+	GB ByteSize = 1000 * MB
+	TB ByteSize = 1000 * GB
+	PB ByteSize = 1000 * TB
+	EB ByteSize = 1000 * PB
+
+	KiB ByteSize = 1024 * Byte
+	MiB ByteSize = 1024 * KiB
+	GiB ByteSize = 1024 * MiB
+	TiB ByteSize = 1024 * GiB
+	PiB ByteSize = 1024 * TiB
+	EiB ByteSize = 1024 * PiB
+)
+
+// byteSizeUnits lists units from largest to smallest, in the order canonical
+// formatting tries them.
+var byteSizeUnits = []struct {
+	name string
+	size ByteSize
+}{
+	{"EiB", EiB}, {"EB", EB}, {"PiB", PiB}, {"PB", PB}, {"TiB", TiB}, {"TB", TB},
+	{"GiB", GiB}, {"GB", GB}, {"MiB", MiB}, {"MB", MB}, {"KiB", KiB}, {"KB", KB},
+}
+
+// byteSizeSuffixes maps lowercase unit suffixes to their size.
+var byteSizeSuffixes = map[string]ByteSize{
+	"": Byte, "b": Byte,
+	"k": KB, "kb": KB, "ki": KiB, "kib": KiB,
+	"m": MB, "mb": MB, "mi": MiB, "mib": MiB,
+	"g": GB, "gb": GB, "gi": GiB, "gib": GiB,
+	"t": TB, "tb": TB, "ti": TiB, "tib": TiB,
+	"p": PB, "pb": PB, "pi": PiB, "pib": PiB,
+	"e": EB, "eb": EB, "ei": EiB, "eib": EiB,
+}
+
+// ParseByteSize parses a human-readable size such as "10GiB", "250 MB",
+// "1.5KiB", or "4096".
+//
+// Example:
+//
+//	size, err := foundry.ParseByteSize("10GiB")
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(int64(size)) // 10737418240
+func ParseByteSize(s string) (ByteSize, error) {
+	trimmed := strings.TrimSpace(s)
+	split := strings.IndexFunc(trimmed, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	number, unit := trimmed, ""
+	if split >= 0 {
+		number, unit = trimmed[:split], strings.TrimSpace(trimmed[split:])
+	}
+
+	multiplier, ok := byteSizeSuffixes[strings.ToLower(unit)]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size %q: unknown unit %q", s, unit)
+	}
+	value, ok := new(big.Rat).SetString(number)
+	if number == "" || !ok {
+		return 0, fmt.Errorf("invalid byte size %q: expected a number with an optional unit such as \"10GiB\"", s)
+	}
+
+	value.Mul(value, new(big.Rat).SetInt64(int64(multiplier)))
+	if !value.IsInt() {
+		return 0, fmt.Errorf("invalid byte size %q: not a whole number of bytes", s)
+	}
+	if !value.Num().IsInt64() {
+		return 0, fmt.Errorf("invalid byte size %q: exceeds %d bytes", s, int64(math.MaxInt64))
+	}
+	return ByteSize(value.Num().Int64()), nil
+}
+
+// MustByteSize parses a size and panics on error. Use for constants in code.
+func MustByteSize(s string) ByteSize {
+	size, err := ParseByteSize(s)
+	if err != nil {
+		panic(err)
+	}
+	return size
+}
+
+// String returns the canonical form, e.g. "10GiB", "1500B".
+func (b ByteSize) String() string {
+	if b < 0 {
+		return fmt.Sprintf("%dB", int64(b))
+	}
+	if b > 0 {
+		for _, unit := range byteSizeUnits {
+			if b%unit.size == 0 {
+				return fmt.Sprintf("%d%s", b/unit.size, unit.name)
+			}
+		}
+	}
+	return fmt.Sprintf("%dB", int64(b))
+}
+
+// Bytes returns the size as a byte count.
+func (b ByteSize) Bytes() int64 {
+	return int64(b)
+}
+
+// Validate checks that the size is not negative.
+func (b ByteSize) Validate() error {
+	if b < 0 {
+		return fmt.Errorf("byte size must not be negative, got %d", int64(b))
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the canonical form.
+func (b ByteSize) MarshalText() ([]byte, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	parsed, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = parsed
+	return nil
+}
+
+// MarshalJSON encodes the size as its canonical string.
+func (b ByteSize) MarshalJSON() ([]byte, error) {
+	text, err := b.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes a size string such as "10GiB" or a number of bytes.
+func (b *ByteSize) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		// Not a string: accept a plain number of bytes
+		s = string(data)
+	}
+	return b.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML encodes the size as its canonical string.
+func (b ByteSize) MarshalYAML() (interface{}, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b.String(), nil
+}
+
+// UnmarshalYAML decodes a size string such as "10GiB" or a number of bytes.
+func (b *ByteSize) UnmarshalYAML(node *yaml.Node) error {
+	var s string
+	if err := node.Decode(&s); err != nil {
+		return fmt.Errorf("byte size must be a scalar: %w", err)
+	}
+	return b.UnmarshalText([]byte(s))
+}
+
+// Value implements database/sql/driver.Valuer for database integration.
+//
+// The size is stored as a byte count (BIGINT column).
+func (b ByteSize) Value() (driver.Value, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return int64(b), nil
+}
+
+// Scan implements database/sql.Scanner for database integration.
+//
+// Reads byte counts from integer columns and human-readable sizes from
+// CHAR/VARCHAR/TEXT columns.
+func (b *ByteSize) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*b = 0
+		return nil
+	case int64:
+		if err := ByteSize(v).Validate(); err != nil {
+			return err
+		}
+		*b = ByteSize(v)
+		return nil
+	case string:
+		return b.UnmarshalText([]byte(v))
+	case []byte:
+		return b.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into ByteSize", src)
+	}
+}
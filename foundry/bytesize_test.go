@@ -0,0 +1,176 @@
+package foundry
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestParseByteSize tests parsing human-readable sizes
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected ByteSize
+		wantErr  bool
+	}{
+		{"Bytes", "4096", 4096, false},
+		{"BytesUnit", "512B", 512, false},
+		{"Binary", "10GiB", 10 * GiB, false},
+		{"BinaryShort", "2Mi", 2 * MiB, false},
+		{"Decimal", "250MB", 250 * MB, false},
+		{"DecimalShort", "3k", 3 * KB, false},
+		{"Lowercase", "1gib", GiB, false},
+		{"Space", " 512 MiB ", 512 * MiB, false},
+		{"Fraction", "1.5KiB", 1536, false},
+		{"FractionalBytes", "1.5B", 0, true},
+		{"UnknownUnit", "10XB", 0, true},
+		{"Negative", "-1KiB", 0, true},
+		{"Empty", "", 0, true},
+		{"UnitOnly", "GiB", 0, true},
+		{"Overflow", "9EiB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, err := ParseByteSize(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseByteSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if size != tt.expected {
+				t.Errorf("ParseByteSize(%q) = %d, want %d", tt.input, size, tt.expected)
+			}
+		})
+	}
+}
+
+// TestByteSize_String tests canonical formatting
+func TestByteSize_String(t *testing.T) {
+	tests := map[ByteSize]string{
+		0:                      "0B",
+		1500:                   "1500B",
+		KiB:                    "1KiB",
+		10 * GiB:               "10GiB",
+		1536 * MiB:             "1536MiB",
+		2 * MB:                 "2MB",
+		1000 * GB:              "1TB",
+		MustByteSize("1.5KiB"): "1536B",
+	}
+	for size, want := range tests {
+		if got := size.String(); got != want {
+			t.Errorf("ByteSize(%d).String() = %q, want %q", int64(size), got, want)
+		}
+		// The canonical form parses back to the same size
+		if parsed, err := ParseByteSize(size.String()); err != nil || parsed != size {
+			t.Errorf("ParseByteSize(%q) = %d, %v; want %d", size.String(), parsed, err, int64(size))
+		}
+	}
+}
+
+// TestByteSize_JSONRoundTrip tests JSON marshaling and unmarshaling
+func TestByteSize_JSONRoundTrip(t *testing.T) {
+	type Limits struct {
+		MaxSize ByteSize `json:"max_size"`
+	}
+
+	data, err := json.Marshal(Limits{MaxSize: 10 * GiB})
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if string(data) != `{"max_size":"10GiB"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var limits Limits
+	if err := json.Unmarshal([]byte(`{"max_size":"250MB"}`), &limits); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if limits.MaxSize != 250*MB {
+		t.Errorf("json.Unmarshal() MaxSize = %d, want %d", limits.MaxSize, 250*MB)
+	}
+
+	// Plain integers are byte counts
+	if err := json.Unmarshal([]byte(`{"max_size":1048576}`), &limits); err != nil {
+		t.Fatalf("json.Unmarshal(number) error: %v", err)
+	}
+	if limits.MaxSize != MiB {
+		t.Errorf("json.Unmarshal(number) MaxSize = %d, want %d", limits.MaxSize, MiB)
+	}
+
+	if err := json.Unmarshal([]byte(`{"max_size":"lots"}`), &limits); err == nil {
+		t.Error("Expected error unmarshaling invalid size")
+	}
+	if _, err := json.Marshal(Limits{MaxSize: -1}); err == nil {
+		t.Error("Expected error marshaling negative size")
+	}
+}
+
+// TestByteSize_YAMLRoundTrip tests YAML marshaling and unmarshaling
+func TestByteSize_YAMLRoundTrip(t *testing.T) {
+	type Config struct {
+		MaxSize ByteSize `yaml:"max_size"`
+	}
+
+	data, err := yaml.Marshal(Config{MaxSize: 64 * MiB})
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error: %v", err)
+	}
+	if string(data) != "max_size: 64MiB\n" {
+		t.Errorf("yaml.Marshal() = %q", data)
+	}
+
+	var config Config
+	for input, want := range map[string]ByteSize{
+		"max_size: 1GiB\n": GiB,
+		"max_size: 2048\n": 2048,
+	} {
+		if err := yaml.Unmarshal([]byte(input), &config); err != nil {
+			t.Fatalf("yaml.Unmarshal(%q) error: %v", input, err)
+		}
+		if config.MaxSize != want {
+			t.Errorf("yaml.Unmarshal(%q) MaxSize = %d, want %d", input, config.MaxSize, want)
+		}
+	}
+}
+
+// TestByteSize_Database tests database/sql Value and Scan
+func TestByteSize_Database(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected ByteSize
+		wantErr  bool
+	}{
+		{"Int64", int64(4096), 4096, false},
+		{"String", "1GiB", GiB, false},
+		{"Bytes", []byte("2KB"), 2 * KB, false},
+		{"Nil", nil, 0, false},
+		{"Negative", int64(-1), 0, true},
+		{"Invalid_Type", 1.5, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var size ByteSize
+			err := size.Scan(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ByteSize.Scan(%v) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if size != tt.expected {
+				t.Errorf("ByteSize.Scan(%v) = %d, want %d", tt.input, size, tt.expected)
+			}
+
+			value, err := size.Value()
+			if err != nil {
+				t.Fatalf("Value() error: %v", err)
+			}
+			if value != int64(tt.expected) {
+				t.Errorf("Value() = %v, want %d", value, int64(tt.expected))
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package foundry
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// CaseStyle identifies an identifier casing convention.
+type CaseStyle string
+
+const (
+	CaseSnake          CaseStyle = "snake"           // http_server_url
+	CaseScreamingSnake CaseStyle = "screaming_snake" // HTTP_SERVER_URL
+	CaseKebab          CaseStyle = "kebab"           // http-server-url
+	CaseCamel          CaseStyle = "camel"           // httpServerURL
+	CasePascal         CaseStyle = "pascal"          // HTTPServerURL
+)
+
+// CaseConverter converts identifiers between casing conventions with
+// acronym-aware word splitting and rendering.
+//
+// Example:
+//
+//	acronyms, _ := foundry.GetDefaultCatalog().Acronyms()
+//	conv := foundry.NewCaseConverter(acronyms)
+//	conv.Convert("HTTPServerURL", foundry.CaseSnake)  // "http_server_url"
+//	conv.Convert("http_server_url", foundry.CaseCamel) // "httpServerURL"
+type CaseConverter struct {
+	acronyms map[string]string // lowercase -> canonical uppercase form
+}
+
+// NewCaseConverter creates a converter that recognizes the given acronyms.
+// Acronyms are matched case-insensitively; pass nil for plain word casing.
+func NewCaseConverter(acronyms []string) *CaseConverter {
+	c := &CaseConverter{acronyms: make(map[string]string, len(acronyms))}
+	for _, a := range acronyms {
+		if a == "" {
+			continue
+		}
+		c.acronyms[strings.ToLower(a)] = strings.ToUpper(a)
+	}
+	return c
+}
+
+// CaseConverter returns a converter using the catalog's acronym list.
+//
+// The converter is built once and cached. If the acronym dataset fails to
+// load (e.g., an invalid overlay), the converter uses plain word casing; call
+// Load or Acronyms to see the error.
+func (c *Catalog) CaseConverter() *CaseConverter {
+	c.caseConverterOnce.Do(func() {
+		acronyms, _ := c.Acronyms()
+		c.caseConverter = NewCaseConverter(acronyms)
+	})
+	return c.caseConverter
+}
+
+// Acronyms returns the catalog's acronym list used for case conversion.
+//
+// Acronyms are kept fully uppercase when rendering camel and Pascal case
+// (e.g., "server_url" -> "ServerURL") and are recognized as single words when
+// splitting mixed-case input (e.g., "HTTPServerURL" -> http, server, url).
+// The list comes from the embedded acronyms.yaml; overlays (see WithOverlay)
+// add entries with an acronyms.yaml of the same shape:
+//
+//	acronyms:
+//	  - K8S
+//	  - GRPC
+func (c *Catalog) Acronyms() ([]string, error) {
+	if err := c.loadAcronyms(); err != nil {
+		return nil, err
+	}
+	acronyms := make([]string, len(c.acronyms))
+	copy(acronyms, c.acronyms)
+	return acronyms, nil
+}
+
+// loadAcronyms loads the acronym list (lazy loading).
+func (c *Catalog) loadAcronyms() error {
+	c.acronymsOnce.Do(func() {
+		data, err := c.loadYAML("acronyms.yaml")
+		if err != nil {
+			c.acronymsErr = fmt.Errorf("failed to load acronyms config: %w", err)
+			return
+		}
+
+		entries, ok := data["acronyms"].([]interface{})
+		if !ok {
+			c.acronymsErr = fmt.Errorf("acronyms config has invalid format")
+			return
+		}
+		for _, entry := range entries {
+			if acronym, ok := entry.(string); ok && acronym != "" {
+				c.acronyms = append(c.acronyms, strings.ToUpper(acronym))
+			}
+		}
+	})
+	return c.acronymsErr
+}
+
+// Words splits an identifier into lowercase words.
+//
+// Boundaries are separators ('_', '-', '.', whitespace), lower-to-upper
+// transitions ("userName"), and the end of an uppercase run before a new
+// capitalized word ("HTTPServer"). A known acronym followed by a lowercase
+// "s" is kept as one plural word ("IDs" -> "ids").
+func (cc *CaseConverter) Words(s string) []string {
+	var words []string
+	var current []rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == '.' || unicode.IsSpace(r) {
+			flush()
+			continue
+		}
+
+		if i > 0 && len(current) > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			switch {
+			case unicode.IsLower(prev) || unicode.IsDigit(prev):
+				// "userName", "utf8String"
+				flush()
+			case unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+				// End of an uppercase run: "HTTPServer" splits before "S",
+				// unless the run is a pluralized acronym such as "IDs"
+				if !cc.isPluralAcronym(current, runes, i) {
+					flush()
+				}
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	return words
+}
+
+// isPluralAcronym reports whether current+runes[i] forms a known acronym
+// followed by a trailing lowercase "s" (e.g., "IDs" in "userIDs").
+func (cc *CaseConverter) isPluralAcronym(current []rune, runes []rune, i int) bool {
+	if runes[i+1] != 's' || (i+2 < len(runes) && unicode.IsLower(runes[i+2])) {
+		return false
+	}
+	candidate := strings.ToLower(string(current) + string(runes[i]))
+	_, ok := cc.acronyms[candidate]
+	return ok
+}
+
+// Convert renders an identifier in the requested case style.
+// Unknown styles return the input unchanged.
+func (cc *CaseConverter) Convert(s string, style CaseStyle) string {
+	words := cc.Words(s)
+
+	switch style {
+	case CaseSnake:
+		return strings.Join(words, "_")
+	case CaseScreamingSnake:
+		return strings.ToUpper(strings.Join(words, "_"))
+	case CaseKebab:
+		return strings.Join(words, "-")
+	case CaseCamel:
+		var b strings.Builder
+		for i, w := range words {
+			if i == 0 {
+				b.WriteString(w)
+				continue
+			}
+			b.WriteString(cc.title(w))
+		}
+		return b.String()
+	case CasePascal:
+		var b strings.Builder
+		for _, w := range words {
+			b.WriteString(cc.title(w))
+		}
+		return b.String()
+	default:
+		return s
+	}
+}
+
+// title capitalizes a lowercase word, uppercasing known acronyms (and their plurals).
+func (cc *CaseConverter) title(word string) string {
+	if upper, ok := cc.acronyms[word]; ok {
+		return upper
+	}
+	if strings.HasSuffix(word, "s") {
+		if upper, ok := cc.acronyms[strings.TrimSuffix(word, "s")]; ok {
+			return upper + "s"
+		}
+	}
+	runes := []rune(word)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// CaseConvert converts an identifier to the given style using the default
+// catalog's acronym list.
+//
+// Example:
+//
+//	foundry.CaseConvert("HTTPServerURL", foundry.CaseKebab) // "http-server-url"
+//	foundry.CaseConvert("user_ids", foundry.CasePascal)     // "UserIDs"
+func CaseConvert(s string, style CaseStyle) string {
+	return GetDefaultCatalog().CaseConverter().Convert(s, style)
+}
+
+// ToSnakeCase converts an identifier to snake_case.
+func ToSnakeCase(s string) string { return CaseConvert(s, CaseSnake) }
+
+// ToScreamingSnakeCase converts an identifier to SCREAMING_SNAKE_CASE.
+func ToScreamingSnakeCase(s string) string { return CaseConvert(s, CaseScreamingSnake) }
+
+// ToKebabCase converts an identifier to kebab-case.
+func ToKebabCase(s string) string { return CaseConvert(s, CaseKebab) }
+
+// ToCamelCase converts an identifier to camelCase.
+func ToCamelCase(s string) string { return CaseConvert(s, CaseCamel) }
+
+// ToPascalCase converts an identifier to PascalCase.
+func ToPascalCase(s string) string { return CaseConvert(s, CasePascal) }
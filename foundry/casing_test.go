@@ -0,0 +1,116 @@
+package foundry
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestCaseConvert(t *testing.T) {
+	tests := []struct {
+		input string
+		style CaseStyle
+		want  string
+	}{
+		{"HTTPServerURL", CaseSnake, "http_server_url"},
+		{"HTTPServerURL", CaseKebab, "http-server-url"},
+		{"HTTPServerURL", CaseScreamingSnake, "HTTP_SERVER_URL"},
+		{"HTTPServerURL", CaseCamel, "httpServerURL"},
+		{"http_server_url", CasePascal, "HTTPServerURL"},
+		{"http-server-url", CaseCamel, "httpServerURL"},
+		{"HTTP_SERVER_URL", CasePascal, "HTTPServerURL"},
+		{"userID", CaseSnake, "user_id"},
+		{"user_id", CaseCamel, "userID"},
+		{"userIDs", CaseSnake, "user_ids"},
+		{"user_ids", CasePascal, "UserIDs"},
+		{"parseJSONConfig", CaseKebab, "parse-json-config"},
+		{"maxRetries", CaseScreamingSnake, "MAX_RETRIES"},
+		{"log.level", CaseCamel, "logLevel"},
+		{"simple", CasePascal, "Simple"},
+		{"utf8String", CaseSnake, "utf8_string"},
+		{"", CaseSnake, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.style)+"/"+tt.input, func(t *testing.T) {
+			if got := CaseConvert(tt.input, tt.style); got != tt.want {
+				t.Errorf("CaseConvert(%q, %s) = %q, want %q", tt.input, tt.style, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaseConverter_RoundTrip(t *testing.T) {
+	conv := NewCatalog().CaseConverter()
+	identifiers := []string{"HTTPServerURL", "userIDs", "apiKeyTTL", "XMLHTTPRequest"}
+
+	for _, id := range identifiers {
+		pascal := conv.Convert(id, CasePascal)
+		for _, style := range []CaseStyle{CaseSnake, CaseKebab, CaseCamel, CaseScreamingSnake} {
+			if got := conv.Convert(conv.Convert(pascal, style), CasePascal); got != pascal {
+				t.Errorf("round trip via %s: %q -> %q, want %q", style, id, got, pascal)
+			}
+		}
+	}
+}
+
+func TestCaseConverter_CustomAcronyms(t *testing.T) {
+	plain := NewCaseConverter(nil)
+	if got := plain.Convert("server_url", CasePascal); got != "ServerUrl" {
+		t.Errorf("without acronyms: got %q, want %q", got, "ServerUrl")
+	}
+
+	custom := NewCaseConverter([]string{"k8s"})
+	if got := custom.Convert("k8s_cluster", CasePascal); got != "K8SCluster" {
+		t.Errorf("with custom acronym: got %q, want %q", got, "K8SCluster")
+	}
+}
+
+func TestCatalogAcronyms_ReturnsCopy(t *testing.T) {
+	catalog := NewCatalog()
+	acronyms, err := catalog.Acronyms()
+	if err != nil || len(acronyms) == 0 {
+		t.Fatalf("expected embedded acronyms, got %v (err %v)", acronyms, err)
+	}
+	acronyms[0] = "MUTATED"
+	if again, _ := catalog.Acronyms(); again[0] == "MUTATED" {
+		t.Error("Acronyms() should return a copy")
+	}
+}
+
+func TestCatalogAcronyms_Overlay(t *testing.T) {
+	catalog := NewCatalog().WithOverlay(fstest.MapFS{
+		"acronyms.yaml": {Data: []byte("acronyms:\n  - k8s\n  - http\n")},
+	})
+	acronyms, err := catalog.Acronyms()
+	if err != nil {
+		t.Fatalf("Acronyms failed: %v", err)
+	}
+	count := 0
+	for _, a := range acronyms {
+		if a == "HTTP" {
+			count++
+		}
+	}
+	if count != 1 || acronyms[len(acronyms)-1] != "K8S" {
+		t.Errorf("expected K8S appended and HTTP deduplicated, got %v", acronyms)
+	}
+	if got := catalog.CaseConverter().Convert("k8s_cluster", CasePascal); got != "K8SCluster" {
+		t.Errorf("overlay acronym not applied: got %q", got)
+	}
+
+	invalid := NewCatalog().WithOverlay(fstest.MapFS{
+		"acronyms.yaml": {Data: []byte("acronyms: [HTTP, 42]\n")},
+	})
+	if err := invalid.Load(); err == nil {
+		t.Error("expected invalid acronym overlay to fail Load")
+	}
+	if got := invalid.CaseConverter().Convert("server_url", CasePascal); got != "ServerUrl" {
+		t.Errorf("expected plain casing after a failed load, got %q", got)
+	}
+}
+
+func TestCaseConvert_UnknownStyle(t *testing.T) {
+	if got := CaseConvert("someValue", CaseStyle("title")); got != "someValue" {
+		t.Errorf("unknown style should return input, got %q", got)
+	}
+}
@@ -1,9 +1,15 @@
 package foundry
 
 import (
+	"embed"
 	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/fulmenhq/gofulmen/crucible"
 	"gopkg.in/yaml.v3"
@@ -13,7 +19,10 @@ import (
 //
 // The catalog loads patterns, MIME types, and HTTP status groups from
 // Crucible's embedded configuration using lazy loading for performance.
+// ISO 4217 currencies, ISO 639 languages, IANA time zones, and per-country
+// phone and postal code formats are embedded in gofulmen itself.
 // All data is cached after first access and works offline in compiled binaries.
+// Deployments can extend or override entries with WithOverlay.
 // Config files are accessed directly from the Crucible Go module (v0.2.1+).
 //
 // Example:
@@ -24,12 +33,16 @@ import (
 //	    // Valid email
 //	}
 type Catalog struct {
+	// Overlays layered over the embedded data, in precedence order (see WithOverlay)
+	overlays []fs.FS
+
 	// Lazy-loaded data with mutex protection
 	patterns     map[string]*Pattern
 	patternsOnce sync.Once
 	patternsErr  error
 
 	mimeTypes     map[string]*MimeType
+	mimeTypesExt  map[string]*MimeType // keyed by lowercase extension without dot
 	mimeTypesOnce sync.Once
 	mimeTypesErr  error
 
@@ -39,11 +52,39 @@ type Catalog struct {
 	countriesOnce    sync.Once
 	countriesErr     error
 
+	currencies        map[string]*Currency // keyed by uppercase alphabetic code
+	currenciesNumeric map[string]*Currency // keyed by zero-padded numeric (e.g., "840")
+	currenciesOnce    sync.Once
+	currenciesErr     error
+
+	languages       map[string]*Language // keyed by lowercase Alpha2
+	languagesAlpha3 map[string]*Language // keyed by lowercase Alpha3
+	languagesOnce   sync.Once
+	languagesErr    error
+
+	timezones          map[string]*Timezone   // keyed by lowercase ID and aliases
+	timezoneList       []*Timezone            // sorted by ID
+	timezonesByCountry map[string][]*Timezone // keyed by uppercase Alpha2
+	timezonesOnce      sync.Once
+	timezonesErr       error
+
+	countryFormats       map[string]*CountryFormat   // keyed by uppercase Alpha2
+	countryFormatsByCode map[string][]*CountryFormat // keyed by calling code
+	countryFormatsOnce   sync.Once
+	countryFormatsErr    error
+
 	httpGroups      []*HTTPStatusGroup
 	httpGroupsOnce  sync.Once
 	httpGroupsErr   error
 	httpCodeToGroup map[int]string
 	httpHelper      *HTTPStatusHelper
+
+	acronyms     []string
+	acronymsOnce sync.Once
+	acronymsErr  error
+
+	caseConverter     *CaseConverter
+	caseConverterOnce sync.Once
 }
 
 // NewCatalog creates a new Catalog instance.
@@ -68,18 +109,43 @@ func NewCatalog() *Catalog {
 //	catalog := GetDefaultCatalog()
 //	pattern, _ := catalog.GetPattern("slug")
 func GetDefaultCatalog() *Catalog {
-	defaultCatalogOnce.Do(func() {
-		defaultCatalog = NewCatalog()
-	})
-	return defaultCatalog
+	if catalog := defaultCatalog.Load(); catalog != nil {
+		return catalog
+	}
+	defaultCatalog.CompareAndSwap(nil, NewCatalog())
+	return defaultCatalog.Load()
 }
 
-var (
-	defaultCatalog     *Catalog
-	defaultCatalogOnce sync.Once
-)
+// SetDefaultCatalog replaces the catalog used by the package-level lookup
+// functions (GetCountry, ValidateCountryCode, GetMimeTypeByExtension, ...).
+// Passing nil restores a catalog with only the embedded data.
+//
+// Use it with WithOverlayDir to hot-reload deployment overrides; call Load
+// first so a broken overlay never replaces a working catalog.
+//
+// Example:
+//
+//	catalog := foundry.NewCatalog().WithOverlayDir("/etc/myapp/foundry")
+//	if err := catalog.Load(); err != nil {
+//	    return err
+//	}
+//	foundry.SetDefaultCatalog(catalog)
+func SetDefaultCatalog(catalog *Catalog) {
+	if catalog == nil {
+		catalog = NewCatalog()
+	}
+	defaultCatalog.Store(catalog)
+}
+
+var defaultCatalog atomic.Pointer[Catalog]
+
+// assets holds datasets maintained in gofulmen rather than Crucible.
+//
+//go:embed assets/currency-codes.yaml assets/language-codes.yaml assets/timezones.yaml assets/country-formats.yaml assets/acronyms.yaml
+var assets embed.FS
 
-// loadYAML loads a YAML file from Crucible's embedded config and returns the parsed data.
+// loadYAML loads a YAML file from Crucible's embedded config (or the embedded
+// gofulmen assets) and returns the parsed data.
 func (c *Catalog) loadYAML(filename string) (map[string]interface{}, error) {
 	// Load from Crucible's embedded config
 	var data []byte
@@ -96,12 +162,14 @@ func (c *Catalog) loadYAML(filename string) (map[string]interface{}, error) {
 		data, err = crucible.ConfigRegistry.Library().Foundry().MIMETypes()
 	case "similarity-fixtures.yaml":
 		data, err = crucible.ConfigRegistry.Library().Foundry().SimilarityFixtures()
+	case "currency-codes.yaml", "language-codes.yaml", "timezones.yaml", "country-formats.yaml", "acronyms.yaml":
+		data, err = assets.ReadFile("assets/" + filename)
 	default:
 		return nil, fmt.Errorf("unknown config file: %s", filename)
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to read Crucible config %s: %w", filename, err)
+		return nil, fmt.Errorf("failed to read config %s: %w", filename, err)
 	}
 
 	var result map[string]interface{}
@@ -109,6 +177,10 @@ func (c *Catalog) loadYAML(filename string) (map[string]interface{}, error) {
 		return nil, fmt.Errorf("failed to parse YAML from %s: %w", filename, err)
 	}
 
+	if err := c.applyOverlays(filename, result); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -215,6 +287,7 @@ func (c *Catalog) loadMimeTypes() error {
 		}
 
 		mimeTypes := make(map[string]*MimeType)
+		mimeTypesExt := make(map[string]*MimeType)
 
 		for _, item := range typesData {
 			typeMap, ok := item.(map[string]interface{})
@@ -249,10 +322,15 @@ func (c *Catalog) loadMimeTypes() error {
 
 			if mimeType.ID != "" {
 				mimeTypes[mimeType.ID] = mimeType
+				// Later entries (e.g., overlay additions) win extension conflicts
+				for _, ext := range mimeType.Extensions {
+					mimeTypesExt[strings.ToLower(strings.TrimPrefix(ext, "."))] = mimeType
+				}
 			}
 		}
 
 		c.mimeTypes = mimeTypes
+		c.mimeTypesExt = mimeTypesExt
 	})
 
 	return c.mimeTypesErr
@@ -406,6 +484,337 @@ func (c *Catalog) loadCountries() error {
 	return c.countriesErr
 }
 
+// loadCurrencies loads ISO 4217 currencies from the embedded assets (lazy loading).
+// Builds two indexes for efficient lookup:
+// - Code (uppercase, e.g., "USD")
+// - Numeric (zero-padded to 3 digits, e.g., "840")
+func (c *Catalog) loadCurrencies() error {
+	c.currenciesOnce.Do(func() {
+		data, err := c.loadYAML("currency-codes.yaml")
+		if err != nil {
+			c.currenciesErr = fmt.Errorf("failed to load currency-codes config: %w", err)
+			return
+		}
+
+		currenciesData, ok := data["currencies"].([]interface{})
+		if !ok {
+			c.currenciesErr = fmt.Errorf("currency-codes config has invalid format")
+			return
+		}
+
+		currencies := make(map[string]*Currency)
+		currenciesNumeric := make(map[string]*Currency)
+
+		for _, item := range currenciesData {
+			currencyMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			currency := &Currency{}
+
+			if code, ok := currencyMap["code"].(string); ok {
+				currency.Code = strings.ToUpper(code)
+			}
+			if numeric, ok := currencyMap["numeric"].(string); ok {
+				currency.Numeric = padNumericCode(numeric)
+			}
+			if name, ok := currencyMap["name"].(string); ok {
+				currency.Name = name
+			}
+			if symbol, ok := currencyMap["symbol"].(string); ok {
+				currency.Symbol = symbol
+			}
+			if decimals, ok := currencyMap["decimals"].(int); ok {
+				currency.Decimals = decimals
+			}
+
+			if currency.Code != "" {
+				currencies[currency.Code] = currency
+			}
+			if currency.Numeric != "" {
+				currenciesNumeric[currency.Numeric] = currency
+			}
+		}
+
+		c.currencies = currencies
+		c.currenciesNumeric = currenciesNumeric
+	})
+
+	return c.currenciesErr
+}
+
+// loadLanguages loads ISO 639 languages from the embedded assets (lazy loading).
+// Builds two indexes for efficient lookup:
+// - Alpha2 (lowercase ISO 639-1, e.g., "en")
+// - Alpha3 (lowercase ISO 639-2/T, e.g., "eng")
+func (c *Catalog) loadLanguages() error {
+	c.languagesOnce.Do(func() {
+		data, err := c.loadYAML("language-codes.yaml")
+		if err != nil {
+			c.languagesErr = fmt.Errorf("failed to load language-codes config: %w", err)
+			return
+		}
+
+		languagesData, ok := data["languages"].([]interface{})
+		if !ok {
+			c.languagesErr = fmt.Errorf("language-codes config has invalid format")
+			return
+		}
+
+		languages := make(map[string]*Language)
+		languagesAlpha3 := make(map[string]*Language)
+
+		for _, item := range languagesData {
+			languageMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			language := &Language{}
+
+			if alpha2, ok := languageMap["alpha2"].(string); ok {
+				language.Alpha2 = strings.ToLower(alpha2)
+			}
+			if alpha3, ok := languageMap["alpha3"].(string); ok {
+				language.Alpha3 = strings.ToLower(alpha3)
+			}
+			if name, ok := languageMap["name"].(string); ok {
+				language.Name = name
+			}
+
+			if language.Alpha2 != "" {
+				languages[language.Alpha2] = language
+			}
+			if language.Alpha3 != "" {
+				languagesAlpha3[language.Alpha3] = language
+			}
+		}
+
+		c.languages = languages
+		c.languagesAlpha3 = languagesAlpha3
+	})
+
+	return c.languagesErr
+}
+
+// loadTimezones loads IANA time zones from the embedded assets (lazy loading).
+// Builds two indexes for efficient lookup:
+// - ID and aliases (lowercase, e.g., "america/new_york", "us/eastern")
+// - Country (uppercase Alpha2, e.g., "US")
+func (c *Catalog) loadTimezones() error {
+	c.timezonesOnce.Do(func() {
+		data, err := c.loadYAML("timezones.yaml")
+		if err != nil {
+			c.timezonesErr = fmt.Errorf("failed to load timezones config: %w", err)
+			return
+		}
+
+		timezonesData, ok := data["timezones"].([]interface{})
+		if !ok {
+			c.timezonesErr = fmt.Errorf("timezones config has invalid format")
+			return
+		}
+
+		timezones := make(map[string]*Timezone)
+		timezoneList := make([]*Timezone, 0, len(timezonesData))
+		timezonesByCountry := make(map[string][]*Timezone)
+
+		for _, item := range timezonesData {
+			timezoneMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			timezone := &Timezone{}
+
+			if id, ok := timezoneMap["id"].(string); ok {
+				timezone.ID = id
+			}
+			if timezone.ID == "" {
+				continue
+			}
+			timezone.Countries = stringList(timezoneMap["countries"])
+			timezone.Aliases = stringList(timezoneMap["aliases"])
+
+			utcOffset, _ := timezoneMap["utcOffset"].(string)
+			timezone.UTCOffset, err = parseUTCOffset(utcOffset)
+			if err != nil {
+				c.timezonesErr = fmt.Errorf("timezone %s: %w", timezone.ID, err)
+				return
+			}
+			timezone.DSTOffset = timezone.UTCOffset
+			if dstOffset, ok := timezoneMap["dstOffset"].(string); ok {
+				timezone.DSTOffset, err = parseUTCOffset(dstOffset)
+				if err != nil {
+					c.timezonesErr = fmt.Errorf("timezone %s: %w", timezone.ID, err)
+					return
+				}
+			}
+
+			timezones[strings.ToLower(timezone.ID)] = timezone
+			for _, alias := range timezone.Aliases {
+				timezones[strings.ToLower(alias)] = timezone
+			}
+			for _, country := range timezone.Countries {
+				country = strings.ToUpper(country)
+				timezonesByCountry[country] = append(timezonesByCountry[country], timezone)
+			}
+			timezoneList = append(timezoneList, timezone)
+		}
+
+		sort.Slice(timezoneList, func(i, j int) bool { return timezoneList[i].ID < timezoneList[j].ID })
+		for _, list := range timezonesByCountry {
+			sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+		}
+
+		c.timezones = timezones
+		c.timezoneList = timezoneList
+		c.timezonesByCountry = timezonesByCountry
+	})
+
+	return c.timezonesErr
+}
+
+// loadCountryFormats loads per-country phone and postal code formats from the
+// embedded assets (lazy loading). Builds two indexes for efficient lookup:
+// - Alpha2 (uppercase, e.g., "US")
+// - Calling code (e.g., "1" → US, CA)
+func (c *Catalog) loadCountryFormats() error {
+	c.countryFormatsOnce.Do(func() {
+		data, err := c.loadYAML("country-formats.yaml")
+		if err != nil {
+			c.countryFormatsErr = fmt.Errorf("failed to load country-formats config: %w", err)
+			return
+		}
+
+		formatsData, ok := data["formats"].([]interface{})
+		if !ok {
+			c.countryFormatsErr = fmt.Errorf("country-formats config has invalid format")
+			return
+		}
+
+		countryFormats := make(map[string]*CountryFormat)
+		countryFormatsByCode := make(map[string][]*CountryFormat)
+
+		for _, item := range formatsData {
+			formatMap, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			format := &CountryFormat{}
+
+			if alpha2, ok := formatMap["alpha2"].(string); ok {
+				format.Alpha2 = strings.ToUpper(alpha2)
+			}
+			if format.Alpha2 == "" {
+				continue
+			}
+			if callingCode, ok := formatMap["callingCode"].(string); ok {
+				format.CallingCode = callingCode
+			}
+			if trunkPrefix, ok := formatMap["trunkPrefix"].(string); ok {
+				format.TrunkPrefix = trunkPrefix
+			}
+
+			id := strings.ToLower(format.Alpha2)
+			if pattern, ok := formatMap["nationalNumber"].(string); ok {
+				format.NationalNumber = &Pattern{
+					ID:          "phone-national-" + id,
+					Name:        format.Alpha2 + " National Phone Number",
+					Kind:        PatternKindRegex,
+					Pattern:     pattern,
+					Description: "National significant number (digits after +" + format.CallingCode + ")",
+				}
+				if example, ok := formatMap["phoneExample"].(string); ok {
+					format.NationalNumber.Examples = []string{strings.TrimPrefix(example, "+"+format.CallingCode)}
+				}
+			}
+			if pattern, ok := formatMap["postalCode"].(string); ok {
+				format.PostalCode = &Pattern{
+					ID:          "postal-code-" + id,
+					Name:        format.Alpha2 + " Postal Code",
+					Kind:        PatternKindRegex,
+					Pattern:     pattern,
+					Description: "Postal code (uppercase, single spaces)",
+				}
+				if example, ok := formatMap["postalExample"].(string); ok {
+					format.PostalCode.Examples = []string{example}
+				}
+			}
+
+			countryFormats[format.Alpha2] = format
+			if format.CallingCode != "" {
+				countryFormatsByCode[format.CallingCode] = append(countryFormatsByCode[format.CallingCode], format)
+			}
+		}
+
+		c.countryFormats = countryFormats
+		c.countryFormatsByCode = countryFormatsByCode
+	})
+
+	return c.countryFormatsErr
+}
+
+// countryAlpha2 returns the uppercase Alpha2 code for a country code. Alpha-2
+// codes are used directly; Alpha-3 and Numeric codes are resolved through the
+// country catalog.
+func countryAlpha2(code CountryCode) (string, error) {
+	alpha2 := strings.ToUpper(string(code))
+	if len(alpha2) == 2 && isAlpha(alpha2) {
+		return alpha2, nil
+	}
+	country, err := code.Country()
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(country.Alpha2), nil
+}
+
+// stringList converts a YAML sequence to a string slice, skipping non-strings.
+func stringList(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// parseUTCOffset parses a "+hh:mm" or "-hh:mm" UTC offset.
+func parseUTCOffset(offset string) (time.Duration, error) {
+	if len(offset) != 6 || (offset[0] != '+' && offset[0] != '-') || offset[3] != ':' {
+		return 0, fmt.Errorf("invalid UTC offset: %q", offset)
+	}
+	hours, err := strconv.Atoi(offset[1:3])
+	if err != nil {
+		return 0, fmt.Errorf("invalid UTC offset: %q", offset)
+	}
+	minutes, err := strconv.Atoi(offset[4:6])
+	if err != nil || minutes >= 60 {
+		return 0, fmt.Errorf("invalid UTC offset: %q", offset)
+	}
+	d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	if offset[0] == '-' {
+		d = -d
+	}
+	return d, nil
+}
+
+// padNumericCode zero-pads a numeric ISO code to 3 digits.
+func padNumericCode(numeric string) string {
+	for len(numeric) < 3 {
+		numeric = "0" + numeric
+	}
+	return numeric
+}
+
 // GetPattern retrieves a pattern by ID.
 //
 // Returns nil if the pattern is not found.
@@ -480,13 +889,7 @@ func (c *Catalog) GetMimeTypeByExtension(extension string) (*MimeType, error) {
 		return nil, err
 	}
 
-	for _, mimeType := range c.mimeTypes {
-		if mimeType.MatchesExtension(extension) {
-			return mimeType, nil
-		}
-	}
-
-	return nil, nil
+	return c.mimeTypesExt[strings.ToLower(strings.TrimPrefix(extension, "."))], nil
 }
 
 // GetAllMimeTypes returns all available MIME types.
@@ -692,3 +1095,252 @@ func (c *Catalog) ListCountries() ([]*Country, error) {
 
 	return result, nil
 }
+
+// GetCurrency retrieves a currency by its ISO 4217 alphabetic code.
+//
+// The code is normalized to uppercase for case-insensitive lookup.
+// Returns nil if the currency is not found.
+//
+// Example:
+//
+//	currency, err := catalog.GetCurrency("eur")
+//	if err != nil {
+//	    // Handle error
+//	}
+//	if currency != nil {
+//	    fmt.Println(currency.Symbol, currency.Decimals) // "€" 2
+//	}
+func (c *Catalog) GetCurrency(code string) (*Currency, error) {
+	if err := c.loadCurrencies(); err != nil {
+		return nil, err
+	}
+	return c.currencies[strings.ToUpper(code)], nil
+}
+
+// GetCurrencyByNumeric retrieves a currency by its ISO 4217 numeric code.
+//
+// Accepts numeric codes with or without leading zeros (e.g., "978", "36").
+// Returns nil if the currency is not found.
+//
+// Example:
+//
+//	currency, err := catalog.GetCurrencyByNumeric("392") // Yen
+func (c *Catalog) GetCurrencyByNumeric(numeric string) (*Currency, error) {
+	if err := c.loadCurrencies(); err != nil {
+		return nil, err
+	}
+	return c.currenciesNumeric[padNumericCode(numeric)], nil
+}
+
+// ListCurrencies returns all currencies from the catalog, sorted by code.
+//
+// Example:
+//
+//	currencies, err := catalog.ListCurrencies()
+//	if err != nil {
+//	    // Handle error
+//	}
+//	for _, currency := range currencies {
+//	    fmt.Printf("%s: %s\n", currency.Code, currency.Name)
+//	}
+func (c *Catalog) ListCurrencies() ([]*Currency, error) {
+	if err := c.loadCurrencies(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*Currency, 0, len(c.currencies))
+	for _, currency := range c.currencies {
+		result = append(result, currency)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Code < result[j].Code })
+
+	return result, nil
+}
+
+// GetLanguage retrieves a language by its ISO 639-1 two-letter code.
+//
+// The code is normalized to lowercase for case-insensitive lookup.
+// Returns nil if the language is not found.
+//
+// Example:
+//
+//	language, err := catalog.GetLanguage("DE")
+//	if err != nil {
+//	    // Handle error
+//	}
+//	if language != nil {
+//	    fmt.Println(language.Name) // "German"
+//	}
+func (c *Catalog) GetLanguage(alpha2 string) (*Language, error) {
+	if err := c.loadLanguages(); err != nil {
+		return nil, err
+	}
+	return c.languages[strings.ToLower(alpha2)], nil
+}
+
+// GetLanguageByAlpha3 retrieves a language by its ISO 639-2/T three-letter code.
+//
+// The code is normalized to lowercase for case-insensitive lookup. Languages
+// without a two-letter code (e.g., "fil") are only reachable this way.
+// Returns nil if the language is not found.
+//
+// Example:
+//
+//	language, err := catalog.GetLanguageByAlpha3("deu") // German
+func (c *Catalog) GetLanguageByAlpha3(alpha3 string) (*Language, error) {
+	if err := c.loadLanguages(); err != nil {
+		return nil, err
+	}
+	return c.languagesAlpha3[strings.ToLower(alpha3)], nil
+}
+
+// ListLanguages returns all languages from the catalog, sorted by Alpha3 code.
+//
+// Example:
+//
+//	languages, err := catalog.ListLanguages()
+//	if err != nil {
+//	    // Handle error
+//	}
+//	for _, language := range languages {
+//	    fmt.Printf("%s: %s\n", language.Alpha3, language.Name)
+//	}
+func (c *Catalog) ListLanguages() ([]*Language, error) {
+	if err := c.loadLanguages(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*Language, 0, len(c.languagesAlpha3))
+	for _, language := range c.languagesAlpha3 {
+		result = append(result, language)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Alpha3 < result[j].Alpha3 })
+
+	return result, nil
+}
+
+// GetTimezone retrieves a time zone by its IANA ID or alias.
+//
+// Lookup is case-insensitive, and aliases (e.g., "US/Eastern") resolve to
+// their zone. Returns nil if the time zone is not found.
+//
+// Example:
+//
+//	timezone, err := catalog.GetTimezone("Asia/Calcutta")
+//	if err != nil {
+//	    // Handle error
+//	}
+//	if timezone != nil {
+//	    fmt.Println(timezone.ID) // "Asia/Kolkata"
+//	}
+func (c *Catalog) GetTimezone(id string) (*Timezone, error) {
+	if err := c.loadTimezones(); err != nil {
+		return nil, err
+	}
+	return c.timezones[strings.ToLower(id)], nil
+}
+
+// ListTimezones returns all time zones from the catalog, sorted by ID.
+//
+// Aliases are not listed separately; see Timezone.Aliases.
+//
+// Example:
+//
+//	timezones, err := catalog.ListTimezones()
+//	if err != nil {
+//	    // Handle error
+//	}
+//	for _, timezone := range timezones {
+//	    fmt.Printf("%s (UTC%s)\n", timezone.ID, FormatUTCOffset(timezone.UTCOffset))
+//	}
+func (c *Catalog) ListTimezones() ([]*Timezone, error) {
+	if err := c.loadTimezones(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*Timezone, len(c.timezoneList))
+	copy(result, c.timezoneList)
+	return result, nil
+}
+
+// TimezonesForCountry returns the time zones used in a country, sorted by ID.
+//
+// Alpha-2 codes are used directly; Alpha-3 and Numeric codes are resolved
+// through the country catalog. Returns an empty slice for countries without
+// time zones in the catalog.
+//
+// Example:
+//
+//	timezones, err := catalog.TimezonesForCountry(MustCountryCode("CA"))
+//	// America/Cambridge_Bay, America/Dawson, ..., America/Toronto, ...
+func (c *Catalog) TimezonesForCountry(code CountryCode) ([]*Timezone, error) {
+	if err := c.loadTimezones(); err != nil {
+		return nil, err
+	}
+
+	alpha2, err := countryAlpha2(code)
+	if err != nil {
+		return nil, err
+	}
+
+	timezones := c.timezonesByCountry[alpha2]
+	result := make([]*Timezone, len(timezones))
+	copy(result, timezones)
+	return result, nil
+}
+
+// GetCountryFormat retrieves the phone and postal code formats for a country.
+//
+// Alpha-2 codes are used directly; Alpha-3 and Numeric codes are resolved
+// through the country catalog. Returns nil if the catalog has no formats for
+// the country.
+//
+// Example:
+//
+//	format, err := catalog.GetCountryFormat(MustCountryCode("US"))
+//	if err != nil {
+//	    // Handle error
+//	}
+//	if format != nil {
+//	    fmt.Println(format.CallingCode) // "1"
+//	}
+func (c *Catalog) GetCountryFormat(code CountryCode) (*CountryFormat, error) {
+	if err := c.loadCountryFormats(); err != nil {
+		return nil, err
+	}
+	alpha2, err := countryAlpha2(code)
+	if err != nil {
+		return nil, err
+	}
+	return c.countryFormats[alpha2], nil
+}
+
+// GetCountryFormatsByCallingCode retrieves the formats of all countries
+// sharing an E.164 calling code (e.g., "1" → CA, US), sorted by Alpha2.
+//
+// Returns an empty slice if the calling code is not in the catalog.
+func (c *Catalog) GetCountryFormatsByCallingCode(callingCode string) ([]*CountryFormat, error) {
+	if err := c.loadCountryFormats(); err != nil {
+		return nil, err
+	}
+
+	formats := c.countryFormatsByCode[strings.TrimPrefix(callingCode, "+")]
+	result := make([]*CountryFormat, len(formats))
+	copy(result, formats)
+	sort.Slice(result, func(i, j int) bool { return result[i].Alpha2 < result[j].Alpha2 })
+	return result, nil
+}
+
+// ListCountryFormats returns all country formats from the catalog, sorted by Alpha2.
+func (c *Catalog) ListCountryFormats() ([]*CountryFormat, error) {
+	if err := c.loadCountryFormats(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*CountryFormat, 0, len(c.countryFormats))
+	for _, format := range c.countryFormats {
+		result = append(result, format)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Alpha2 < result[j].Alpha2 })
+	return result, nil
+}
@@ -11,6 +11,10 @@ type contextKey string
 // correlationIDKey is the context key for correlation IDs.
 const correlationIDKey contextKey = "correlation_id"
 
+// CorrelationIDHeader is the header (or gRPC metadata key, lowercased) that
+// carries correlation IDs between services.
+const CorrelationIDHeader = "X-Correlation-ID"
+
 // WithCorrelationID returns a new context with the correlation ID attached.
 //
 // This is the standard Go pattern for propagating correlation IDs across
@@ -69,13 +73,112 @@ func MustCorrelationIDFromContext(ctx context.Context) CorrelationID {
 	return id
 }
 
+// CorrelationCarrier reads and writes propagation fields on a transport's
+// headers, such as HTTP headers or gRPC metadata.
+type CorrelationCarrier interface {
+	// Get returns the first value for key, or "" if absent.
+	Get(key string) string
+
+	// Set replaces the values for key.
+	Set(key, value string)
+}
+
+// HeaderCarrier adapts http.Header to CorrelationCarrier.
+type HeaderCarrier http.Header
+
+// Get returns the first value for key.
+func (h HeaderCarrier) Get(key string) string {
+	return http.Header(h).Get(key)
+}
+
+// Set replaces the values for key.
+func (h HeaderCarrier) Set(key, value string) {
+	http.Header(h).Set(key, value)
+}
+
+// ExtractCorrelation reads the correlation ID and W3C trace context from an
+// incoming carrier and returns a context with both attached.
+//
+// The correlation ID is derived in order of precedence:
+//   - A valid UUIDv7 in the X-Correlation-ID header
+//   - The traceparent trace ID, if it is a UUIDv7
+//   - A newly generated correlation ID
+//
+// A valid traceparent (with its tracestate) is attached with
+// WithTraceContext so it can be propagated unchanged; an invalid traceparent
+// is ignored along with its tracestate.
+//
+// Example:
+//
+//	ctx := foundry.ExtractCorrelation(r.Context(), foundry.HeaderCarrier(r.Header))
+//	corrID := foundry.MustCorrelationIDFromContext(ctx)
+func ExtractCorrelation(ctx context.Context, carrier CorrelationCarrier) context.Context {
+	var corrID CorrelationID
+
+	// Try to extract from X-Correlation-ID header
+	if headerValue := carrier.Get(CorrelationIDHeader); headerValue != "" {
+		parsed, err := ParseCorrelationIDValue(headerValue)
+		if err == nil && parsed.IsValid() {
+			corrID = parsed
+		}
+	}
+
+	if tc, err := ParseTraceParent(carrier.Get(TraceParentHeader)); err == nil {
+		tc.State = carrier.Get(TraceStateHeader)
+		ctx = WithTraceContext(ctx, tc)
+
+		// Fall back to the trace ID when it carries a correlation ID
+		if corrID == "" {
+			corrID, _ = tc.CorrelationID()
+		}
+	}
+
+	// Generate new ID if not present or invalid
+	if corrID == "" {
+		corrID = NewCorrelationIDValue()
+	}
+
+	return WithCorrelationID(ctx, corrID)
+}
+
+// InjectCorrelation writes the correlation ID and W3C trace context from ctx
+// to an outgoing carrier. Fields absent from ctx are not written.
+//
+// The trace context is propagated unchanged (gofulmen does not create spans).
+//
+// Example:
+//
+//	req = req.Clone(ctx)
+//	foundry.InjectCorrelation(ctx, foundry.HeaderCarrier(req.Header))
+func InjectCorrelation(ctx context.Context, carrier CorrelationCarrier) {
+	if corrID, ok := CorrelationIDFromContext(ctx); ok {
+		carrier.Set(CorrelationIDHeader, corrID.String())
+	}
+	if tc, ok := TraceContextFromContext(ctx); ok && tc.IsValid() {
+		carrier.Set(TraceParentHeader, tc.TraceParent())
+		if tc.State != "" {
+			carrier.Set(TraceStateHeader, tc.State)
+		}
+	}
+}
+
+// hasCorrelation reports whether ctx carries anything InjectCorrelation writes.
+func hasCorrelation(ctx context.Context) bool {
+	if _, ok := CorrelationIDFromContext(ctx); ok {
+		return true
+	}
+	_, ok := TraceContextFromContext(ctx)
+	return ok
+}
+
 // CorrelationIDMiddleware is HTTP middleware that extracts or generates correlation IDs.
 //
 // This middleware:
 //   - Checks for X-Correlation-ID header in incoming request
 //   - Validates and uses it if present
+//   - Falls back to a UUIDv7 traceparent trace ID (see ExtractCorrelation)
 //   - Generates a new correlation ID if not present or invalid
-//   - Attaches correlation ID to request context
+//   - Attaches correlation ID and W3C trace context to request context
 //   - Sets X-Correlation-ID header in response
 //
 // Example:
@@ -88,27 +191,12 @@ func MustCorrelationIDFromContext(ctx context.Context) CorrelationID {
 //	http.ListenAndServe(":8080", handler)
 func CorrelationIDMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		var corrID CorrelationID
-
-		// Try to extract from X-Correlation-ID header
-		headerValue := r.Header.Get("X-Correlation-ID")
-		if headerValue != "" {
-			parsed, err := ParseCorrelationIDValue(headerValue)
-			if err == nil && parsed.IsValid() {
-				corrID = parsed
-			}
-		}
-
-		// Generate new ID if not present or invalid
-		if corrID == "" {
-			corrID = NewCorrelationIDValue()
-		}
+		ctx := ExtractCorrelation(r.Context(), HeaderCarrier(r.Header))
 
 		// Set response header
-		w.Header().Set("X-Correlation-ID", corrID.String())
+		corrID, _ := CorrelationIDFromContext(ctx)
+		w.Header().Set(CorrelationIDHeader, corrID.String())
 
-		// Attach to context and continue
-		ctx := WithCorrelationID(r.Context(), corrID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -131,8 +219,10 @@ func CorrelationIDHandler(next http.HandlerFunc) http.Handler {
 // This RoundTripper extracts the correlation ID from the request context
 // and sets it as the X-Correlation-ID header on outbound HTTP requests,
 // enabling automatic correlation ID propagation across service boundaries.
+// W3C trace context (traceparent/tracestate) in the context is propagated
+// as well.
 //
-// If no correlation ID is found in the context, the request proceeds without
+// If neither is found in the context, the request proceeds without
 // modification.
 //
 // Example:
@@ -169,17 +259,14 @@ func NewCorrelationIDRoundTripper(base http.RoundTripper) *CorrelationIDRoundTri
 }
 
 // RoundTrip executes a single HTTP transaction, adding the X-Correlation-ID
-// header from the request context if present.
+// and traceparent/tracestate headers from the request context if present.
 //
 // This method implements the http.RoundTripper interface.
 func (t *CorrelationIDRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	// Extract correlation ID from request context
-	if corrID, ok := CorrelationIDFromContext(req.Context()); ok {
+	if hasCorrelation(req.Context()) {
 		// Clone the request to avoid mutating the original
 		req = req.Clone(req.Context())
-
-		// Set X-Correlation-ID header
-		req.Header.Set("X-Correlation-ID", corrID.String())
+		InjectCorrelation(req.Context(), HeaderCarrier(req.Header))
 	}
 
 	// Execute request with base transport
@@ -0,0 +1,96 @@
+package foundry
+
+import "strings"
+
+// CountryFormat holds a country's telephone numbering and postal code
+// formats from the Foundry catalog.
+//
+// The patterns are regular catalog Patterns, so services validate phone
+// numbers and postal codes with the same expressions instead of embedding
+// their own.
+type CountryFormat struct {
+	// Alpha2 is the ISO 3166-1 alpha-2 country code (e.g., "US").
+	Alpha2 string
+
+	// CallingCode is the ITU-T E.164 country calling code without "+" (e.g., "1", "44").
+	// Several countries can share a calling code (e.g., US and CA share "1").
+	CallingCode string
+
+	// TrunkPrefix is the prefix dialed before national numbers within the
+	// country (e.g., "0" in GB); empty if there is none.
+	TrunkPrefix string
+
+	// NationalNumber matches national significant numbers, the digits that
+	// follow the calling code in E.164 form. Nil if unknown.
+	NationalNumber *Pattern
+
+	// PostalCode matches postal codes in uppercase with single spaces. Nil
+	// for countries without a postal code format in the catalog.
+	PostalCode *Pattern
+}
+
+// MatchesNationalNumber reports whether digits is a valid national
+// significant number for the country.
+func (f *CountryFormat) MatchesNationalNumber(digits string) bool {
+	if f.NationalNumber == nil {
+		return false
+	}
+	matched, err := f.NationalNumber.Match(digits)
+	return err == nil && matched
+}
+
+// MatchesPostalCode reports whether code is a valid postal code for the
+// country. The code is normalized (uppercase, single spaces) before matching.
+//
+// Example:
+//
+//	format, _ := GetCountryFormat(MustCountryCode("CA"))
+//	format.MatchesPostalCode("k1a 0b1") // true
+func (f *CountryFormat) MatchesPostalCode(code string) bool {
+	if f.PostalCode == nil {
+		return false
+	}
+	matched, err := f.PostalCode.Match(normalizePostalCode(code))
+	return err == nil && matched
+}
+
+// normalizePostalCode uppercases a postal code and collapses whitespace.
+func normalizePostalCode(code string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(code), " "))
+}
+
+// GetCountryFormat retrieves the phone and postal code formats for a country
+// from the default catalog.
+//
+// Returns nil if the catalog has no formats for the country.
+//
+// Example:
+//
+//	format, err := GetCountryFormat(MustCountryCode("US"))
+//	if err != nil {
+//	    // Handle error
+//	}
+//	if format != nil && format.MatchesPostalCode("94105-1234") {
+//	    // Valid ZIP+4
+//	}
+func GetCountryFormat(code CountryCode) (*CountryFormat, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.GetCountryFormat(code)
+}
+
+// GetCountryFormatsByCallingCode retrieves the formats of all countries
+// sharing an E.164 calling code from the default catalog.
+//
+// Example:
+//
+//	formats, err := GetCountryFormatsByCallingCode("7") // KZ, RU
+func GetCountryFormatsByCallingCode(callingCode string) ([]*CountryFormat, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.GetCountryFormatsByCallingCode(callingCode)
+}
+
+// ListCountryFormats returns all country formats from the default catalog, sorted by Alpha2.
+func ListCountryFormats() ([]*CountryFormat, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.ListCountryFormats()
+}
@@ -0,0 +1,127 @@
+package foundry
+
+import "testing"
+
+// TestGetCountryFormat tests per-country format lookup
+func TestGetCountryFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		code        CountryCode
+		alpha2      string
+		callingCode string
+		trunkPrefix string
+	}{
+		{"Alpha2", "US", "US", "1", "1"},
+		{"Alpha2_Lowercase", "gb", "GB", "44", "0"},
+		{"Alpha3", "USA", "US", "1", "1"},
+		{"Numeric", "076", "BR", "55", "0"},
+		{"NoTrunkPrefix", "SG", "SG", "65", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := GetCountryFormat(tt.code)
+			if err != nil {
+				t.Fatalf("GetCountryFormat(%q) error: %v", tt.code, err)
+			}
+			if format == nil {
+				t.Fatalf("GetCountryFormat(%q) returned nil", tt.code)
+			}
+			if format.Alpha2 != tt.alpha2 || format.CallingCode != tt.callingCode || format.TrunkPrefix != tt.trunkPrefix {
+				t.Errorf("GetCountryFormat(%q) = %+v", tt.code, format)
+			}
+		})
+	}
+
+	format, err := GetCountryFormat("AQ")
+	if err != nil {
+		t.Fatalf("GetCountryFormat(\"AQ\") error: %v", err)
+	}
+	if format != nil {
+		t.Errorf("Expected nil format for AQ, got %+v", format)
+	}
+}
+
+// TestCountryFormat_Examples tests that every catalog example matches its own pattern
+func TestCountryFormat_Examples(t *testing.T) {
+	formats, err := ListCountryFormats()
+	if err != nil {
+		t.Fatalf("ListCountryFormats() error: %v", err)
+	}
+	if len(formats) < 20 {
+		t.Fatalf("Expected at least 20 country formats, got %d", len(formats))
+	}
+
+	for i, format := range formats {
+		if i > 0 && formats[i-1].Alpha2 >= format.Alpha2 {
+			t.Errorf("ListCountryFormats() not sorted at %s", format.Alpha2)
+		}
+		for _, pattern := range []*Pattern{format.NationalNumber, format.PostalCode} {
+			if pattern == nil {
+				continue
+			}
+			if len(pattern.Examples) == 0 {
+				t.Errorf("%s: pattern %s has no examples", format.Alpha2, pattern.ID)
+			}
+			for _, example := range pattern.Examples {
+				if !pattern.MustMatch(example) {
+					t.Errorf("%s: pattern %s does not match its example %q", format.Alpha2, pattern.ID, example)
+				}
+			}
+		}
+	}
+}
+
+// TestCountryFormat_MatchesPostalCode tests postal code matching with normalization
+func TestCountryFormat_MatchesPostalCode(t *testing.T) {
+	tests := []struct {
+		country CountryCode
+		code    string
+		want    bool
+	}{
+		{"US", "94105", true},
+		{"US", "94105-1234", true},
+		{"US", "9410", false},
+		{"CA", "k1a 0b1", true},
+		{"CA", "K1A0B1", true},
+		{"CA", "D1A 0B1", false},
+		{"GB", "sw1a  1aa", true},
+		{"GB", "EC1A 1BB", true},
+		{"DE", "10117", true},
+		{"DE", "1011", false},
+		{"JP", "100-0001", true},
+		{"NL", "1012 ab", true},
+		{"KZ", "010000", false}, // no postal format in catalog
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.country)+"_"+tt.code, func(t *testing.T) {
+			format, err := GetCountryFormat(tt.country)
+			if err != nil || format == nil {
+				t.Fatalf("GetCountryFormat(%q) = %v, %v", tt.country, format, err)
+			}
+			if got := format.MatchesPostalCode(tt.code); got != tt.want {
+				t.Errorf("MatchesPostalCode(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGetCountryFormatsByCallingCode tests lookup of countries sharing a calling code
+func TestGetCountryFormatsByCallingCode(t *testing.T) {
+	formats, err := GetCountryFormatsByCallingCode("1")
+	if err != nil {
+		t.Fatalf("GetCountryFormatsByCallingCode(\"1\") error: %v", err)
+	}
+	if len(formats) != 2 || formats[0].Alpha2 != "CA" || formats[1].Alpha2 != "US" {
+		t.Errorf("GetCountryFormatsByCallingCode(\"1\") = %v, want [CA US]", formats)
+	}
+
+	formats, err = GetCountryFormatsByCallingCode("999")
+	if err != nil {
+		t.Fatalf("GetCountryFormatsByCallingCode(\"999\") error: %v", err)
+	}
+	if len(formats) != 0 {
+		t.Errorf("Expected no formats for +999, got %v", formats)
+	}
+}
@@ -0,0 +1,115 @@
+package foundry
+
+// Currency represents an ISO 4217 currency from the Foundry catalog.
+//
+// Currencies cover circulating national and regional currencies; fund codes,
+// precious metals, and testing codes are not included.
+type Currency struct {
+	// Code is the ISO 4217 alphabetic currency code (e.g., "USD", "EUR").
+	Code string
+
+	// Numeric is the ISO 4217 numeric currency code as a string (e.g., "840", "978").
+	Numeric string
+
+	// Name is the ISO 4217 English name of the currency (e.g., "US Dollar").
+	Name string
+
+	// Symbol is the commonly used currency symbol (e.g., "$", "€").
+	// Symbols are not unique across currencies.
+	Symbol string
+
+	// Decimals is the number of minor unit digits (e.g., 2 for USD, 0 for JPY, 3 for KWD).
+	Decimals int
+}
+
+// MinorUnits returns the number of minor units in one major unit
+// (e.g., 100 for USD, 1 for JPY, 1000 for KWD).
+//
+// Example:
+//
+//	currency, _ := GetCurrency("USD")
+//	cents := int64(amount * float64(currency.MinorUnits()))
+func (c *Currency) MinorUnits() int64 {
+	units := int64(1)
+	for i := 0; i < c.Decimals; i++ {
+		units *= 10
+	}
+	return units
+}
+
+// GetCurrency retrieves a currency by its ISO 4217 alphabetic code from the default catalog.
+//
+// Returns nil if the currency is not found or if an error occurs.
+//
+// Example:
+//
+//	currency, err := GetCurrency("JPY")
+//	if err != nil {
+//	    // Handle error
+//	}
+//	if currency != nil {
+//	    fmt.Println(currency.Name, currency.Decimals) // "Yen" 0
+//	}
+func GetCurrency(code string) (*Currency, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.GetCurrency(code)
+}
+
+// GetCurrencyByNumeric retrieves a currency by its ISO 4217 numeric code from the default catalog.
+//
+// Accepts numeric codes with or without leading zeros.
+// Returns nil if the currency is not found or if an error occurs.
+//
+// Example:
+//
+//	currency, err := GetCurrencyByNumeric("978") // Euro
+func GetCurrencyByNumeric(numeric string) (*Currency, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.GetCurrencyByNumeric(numeric)
+}
+
+// ValidateCurrencyCode checks if the given code (alphabetic or numeric) is a
+// valid ISO 4217 currency code.
+//
+// Alphabetic codes are matched case-insensitively; numeric codes may omit
+// leading zeros.
+//
+// Example:
+//
+//	if ValidateCurrencyCode("eur") { // Alphabetic (case-insensitive)
+//	    // Valid currency code
+//	}
+//	if ValidateCurrencyCode("978") { // Numeric
+//	    // Valid currency code
+//	}
+func ValidateCurrencyCode(code string) bool {
+	if code == "" {
+		return false
+	}
+
+	catalog := GetDefaultCatalog()
+
+	if isNumericCode(code) {
+		currency, _ := catalog.GetCurrencyByNumeric(code)
+		return currency != nil
+	}
+
+	currency, _ := catalog.GetCurrency(code)
+	return currency != nil
+}
+
+// ListCurrencies returns all currencies from the default catalog, sorted by code.
+//
+// Example:
+//
+//	currencies, err := ListCurrencies()
+//	if err != nil {
+//	    // Handle error
+//	}
+//	for _, currency := range currencies {
+//	    fmt.Printf("%s: %s\n", currency.Code, currency.Name)
+//	}
+func ListCurrencies() ([]*Currency, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.ListCurrencies()
+}
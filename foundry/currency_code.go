@@ -0,0 +1,189 @@
+package foundry
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// CurrencyCode is a validated ISO 4217 currency code.
+//
+// Supports alphabetic (USD) and numeric (840) codes with automatic
+// normalization. Implements standard Go interfaces for seamless integration
+// with JSON, YAML, TOML, and SQL databases.
+//
+// The zero value is an invalid currency code. Use NewCurrencyCode or
+// MustCurrencyCode to create valid instances.
+//
+// Example:
+//
+//	type Price struct {
+//	    Amount   int64        `json:"amount"`
+//	    Currency CurrencyCode `json:"currency" db:"currency"`
+//	}
+//
+//	price := Price{Amount: 1999, Currency: MustCurrencyCode("usd")}
+//	json.Marshal(price) // {"amount":1999,"currency":"USD"}
+type CurrencyCode string
+
+// NewCurrencyCode creates a validated CurrencyCode from an ISO 4217 code.
+//
+// Accepts alphabetic (USD, usd) or numeric (840, 36) codes. Numeric codes are
+// canonicalized to 3 digits with zero-padding (e.g., "36" → "036").
+// Returns an error if the code is invalid.
+//
+// Example:
+//
+//	code, err := NewCurrencyCode("EUR")  // Alphabetic → "EUR"
+//	code, err := NewCurrencyCode("jpy")  // Alphabetic → "JPY" (case-insensitive)
+//	code, err := NewCurrencyCode("36")   // Numeric → "036" (canonicalized)
+func NewCurrencyCode(code string) (CurrencyCode, error) {
+	if code == "" {
+		return "", fmt.Errorf("currency code cannot be empty")
+	}
+
+	if !ValidateCurrencyCode(code) {
+		return "", fmt.Errorf("invalid currency code: %s", code)
+	}
+
+	if isNumericCode(code) {
+		return CurrencyCode(padNumericCode(code)), nil
+	}
+
+	return CurrencyCode(strings.ToUpper(code)), nil
+}
+
+// MustCurrencyCode creates a CurrencyCode or panics if invalid.
+//
+// Use this for package-level defaults or when the code is known to be valid.
+//
+// Example:
+//
+//	var DefaultCurrency = MustCurrencyCode("USD")
+func MustCurrencyCode(code string) CurrencyCode {
+	c, err := NewCurrencyCode(code)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// String returns the currency code as a string.
+func (c CurrencyCode) String() string {
+	return string(c)
+}
+
+// Validate checks if the currency code is valid.
+//
+// Returns an error if the code is not a recognized ISO 4217 code.
+func (c CurrencyCode) Validate() error {
+	if c == "" {
+		return fmt.Errorf("currency code is empty")
+	}
+
+	if !ValidateCurrencyCode(string(c)) {
+		return fmt.Errorf("invalid currency code: %s", c)
+	}
+
+	return nil
+}
+
+// IsValid returns true if the currency code is valid.
+func (c CurrencyCode) IsValid() bool {
+	return c.Validate() == nil
+}
+
+// Currency retrieves the full Currency metadata (name, symbol, decimals)
+// from the catalog.
+//
+// Returns an error if the code is invalid or the catalog cannot be loaded.
+//
+// Example:
+//
+//	code := MustCurrencyCode("KWD")
+//	currency, err := code.Currency()
+//	if err == nil {
+//	    fmt.Println(currency.Name, currency.Decimals) // "Kuwaiti Dinar" 3
+//	}
+func (c CurrencyCode) Currency() (*Currency, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	var currency *Currency
+	var err error
+	if isNumericCode(string(c)) {
+		currency, err = GetCurrencyByNumeric(string(c))
+	} else {
+		currency, err = GetCurrency(string(c))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if currency == nil {
+		return nil, fmt.Errorf("currency not found for code: %s", c)
+	}
+
+	return currency, nil
+}
+
+// MarshalText implements encoding.TextMarshaler for JSON, YAML, TOML support.
+//
+// The currency code is marshaled as-is (uppercase normalized).
+func (c CurrencyCode) MarshalText() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(c), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for JSON, YAML, TOML support.
+//
+// Validates and normalizes the currency code on unmarshal.
+// Accepts alphabetic codes in any case, or numeric codes.
+func (c *CurrencyCode) UnmarshalText(text []byte) error {
+	code, err := NewCurrencyCode(string(text))
+	if err != nil {
+		return err
+	}
+	*c = code
+	return nil
+}
+
+// Value implements database/sql/driver.Valuer for database integration.
+//
+// The currency code is stored as a string (CHAR(3)/VARCHAR/TEXT column).
+func (c CurrencyCode) Value() (driver.Value, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return string(c), nil
+}
+
+// Scan implements database/sql.Scanner for database integration.
+//
+// Reads currency codes from CHAR/VARCHAR/TEXT columns with validation.
+func (c *CurrencyCode) Scan(src interface{}) error {
+	if src == nil {
+		*c = ""
+		return nil
+	}
+
+	var code string
+	switch v := src.(type) {
+	case string:
+		code = v
+	case []byte:
+		code = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into CurrencyCode", src)
+	}
+
+	parsed, err := NewCurrencyCode(code)
+	if err != nil {
+		return err
+	}
+
+	*c = parsed
+	return nil
+}
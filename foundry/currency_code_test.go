@@ -0,0 +1,203 @@
+package foundry
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestNewCurrencyCode tests creating CurrencyCode from valid and invalid inputs
+func TestNewCurrencyCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"Alpha_USD", "USD", "USD", false},
+		{"Alpha_Lowercase", "eur", "EUR", false},
+		{"Alpha_MixedCase", "JpY", "JPY", false},
+		{"Numeric_840", "840", "840", false},
+		{"Numeric_36", "36", "036", false}, // canonicalized to 3 digits
+		{"Unknown", "XXX", "", true},
+		{"CountryCode", "US", "", true},
+		{"Empty", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := NewCurrencyCode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewCurrencyCode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && string(code) != tt.expected {
+				t.Errorf("NewCurrencyCode(%q) = %q, want %q", tt.input, code, tt.expected)
+			}
+		})
+	}
+}
+
+// TestMustCurrencyCode_Panic tests that MustCurrencyCode panics on invalid input
+func TestMustCurrencyCode_Panic(t *testing.T) {
+	if code := MustCurrencyCode("usd"); code != "USD" {
+		t.Errorf("MustCurrencyCode(\"usd\") = %q, want \"USD\"", code)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected MustCurrencyCode to panic on invalid code")
+		}
+	}()
+	MustCurrencyCode("XXX")
+}
+
+// TestCurrencyCode_Validate tests Validate and IsValid
+func TestCurrencyCode_Validate(t *testing.T) {
+	tests := []struct {
+		code    CurrencyCode
+		wantErr bool
+	}{
+		{"USD", false},
+		{"978", false},
+		{"XXX", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			err := tt.code.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CurrencyCode(%q).Validate() error = %v, wantErr %v", tt.code, err, tt.wantErr)
+			}
+			if tt.code.IsValid() == tt.wantErr {
+				t.Errorf("CurrencyCode(%q).IsValid() = %v, want %v", tt.code, !tt.wantErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestCurrencyCode_Currency tests metadata lookup for alphabetic and numeric codes
+func TestCurrencyCode_Currency(t *testing.T) {
+	for _, code := range []CurrencyCode{"BHD", "048"} {
+		t.Run(string(code), func(t *testing.T) {
+			currency, err := code.Currency()
+			if err != nil {
+				t.Fatalf("Currency() error: %v", err)
+			}
+
+			if currency.Code != "BHD" || currency.Decimals != 3 {
+				t.Errorf("Currency() = %+v, want BHD with 3 decimals", currency)
+			}
+		})
+	}
+
+	if _, err := CurrencyCode("XXX").Currency(); err == nil {
+		t.Error("Expected error for invalid currency code")
+	}
+}
+
+// TestCurrencyCode_JSONRoundTrip tests JSON marshaling and unmarshaling
+func TestCurrencyCode_JSONRoundTrip(t *testing.T) {
+	type Price struct {
+		Amount   int64        `json:"amount"`
+		Currency CurrencyCode `json:"currency"`
+	}
+
+	data, err := json.Marshal(Price{Amount: 1999, Currency: MustCurrencyCode("usd")})
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if string(data) != `{"amount":1999,"currency":"USD"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var price Price
+	if err := json.Unmarshal([]byte(`{"amount":5,"currency":"eur"}`), &price); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if price.Currency != "EUR" {
+		t.Errorf("json.Unmarshal() Currency = %q, want \"EUR\"", price.Currency)
+	}
+
+	if err := json.Unmarshal([]byte(`{"currency":"XXX"}`), &price); err == nil {
+		t.Error("Expected error unmarshaling invalid currency code")
+	}
+
+	if _, err := json.Marshal(Price{Currency: "XXX"}); err == nil {
+		t.Error("Expected error marshaling invalid currency code")
+	}
+}
+
+// TestCurrencyCode_YAMLRoundTrip tests YAML marshaling and unmarshaling
+func TestCurrencyCode_YAMLRoundTrip(t *testing.T) {
+	type Config struct {
+		Currency CurrencyCode `yaml:"currency"`
+	}
+
+	data, err := yaml.Marshal(Config{Currency: "GBP"})
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error: %v", err)
+	}
+	if !containsString(string(data), "currency: GBP") {
+		t.Errorf("yaml.Marshal() = %q", data)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal([]byte("currency: chf\n"), &config); err != nil {
+		t.Fatalf("yaml.Unmarshal() error: %v", err)
+	}
+	if config.Currency != "CHF" {
+		t.Errorf("yaml.Unmarshal() Currency = %q, want \"CHF\"", config.Currency)
+	}
+
+	if err := yaml.Unmarshal([]byte("currency: XXX\n"), &config); err == nil {
+		t.Error("Expected error unmarshaling invalid currency code")
+	}
+}
+
+// TestCurrencyCode_Database tests database/sql Value and Scan
+func TestCurrencyCode_Database(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected CurrencyCode
+		wantErr  bool
+	}{
+		{"String", "USD", "USD", false},
+		{"Bytes", []byte("jpy"), "JPY", false},
+		{"Numeric", "978", "978", false},
+		{"Nil", nil, "", false},
+		{"Invalid_String", "XXX", "", true},
+		{"Invalid_Type", 840, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var code CurrencyCode
+			err := code.Scan(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CurrencyCode.Scan(%v) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr || code == "" {
+				return
+			}
+			if code != tt.expected {
+				t.Errorf("CurrencyCode.Scan(%v) = %q, want %q", tt.input, code, tt.expected)
+			}
+
+			value, err := code.Value()
+			if err != nil {
+				t.Fatalf("Value() error: %v", err)
+			}
+			if value != string(tt.expected) {
+				t.Errorf("Value() = %v, want %q", value, tt.expected)
+			}
+		})
+	}
+
+	if _, err := CurrencyCode("").Value(); err == nil {
+		t.Error("Expected error from Value() on empty currency code")
+	}
+}
@@ -0,0 +1,159 @@
+package foundry
+
+import (
+	"testing"
+)
+
+func TestGetCurrency(t *testing.T) {
+	tests := []struct {
+		code     string
+		name     string
+		symbol   string
+		decimals int
+	}{
+		{"USD", "US Dollar", "$", 2},
+		{"eur", "Euro", "€", 2},
+		{"JPY", "Yen", "¥", 0},
+		{"KWD", "Kuwaiti Dinar", "KD", 3},
+		{"GBP", "Pound Sterling", "£", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			currency, err := GetCurrency(tt.code)
+			if err != nil {
+				t.Fatalf("Failed to get currency: %v", err)
+			}
+
+			if currency == nil {
+				t.Fatalf("Expected non-nil currency for %q", tt.code)
+			}
+
+			if currency.Name != tt.name {
+				t.Errorf("Expected name %q, got %q", tt.name, currency.Name)
+			}
+			if currency.Symbol != tt.symbol {
+				t.Errorf("Expected symbol %q, got %q", tt.symbol, currency.Symbol)
+			}
+			if currency.Decimals != tt.decimals {
+				t.Errorf("Expected decimals %d, got %d", tt.decimals, currency.Decimals)
+			}
+		})
+	}
+}
+
+func TestGetCurrency_NotFound(t *testing.T) {
+	currency, err := GetCurrency("XXX")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if currency != nil {
+		t.Error("Expected nil currency for non-existent code")
+	}
+}
+
+func TestGetCurrencyByNumeric(t *testing.T) {
+	tests := []struct {
+		numeric string
+		code    string
+	}{
+		{"840", "USD"},
+		{"978", "EUR"},
+		{"036", "AUD"},
+		{"36", "AUD"}, // normalized to "036"
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.numeric, func(t *testing.T) {
+			currency, err := GetCurrencyByNumeric(tt.numeric)
+			if err != nil {
+				t.Fatalf("Failed to get currency: %v", err)
+			}
+
+			if currency == nil || currency.Code != tt.code {
+				t.Fatalf("GetCurrencyByNumeric(%q) = %v, want %s", tt.numeric, currency, tt.code)
+			}
+		})
+	}
+}
+
+func TestValidateCurrencyCode(t *testing.T) {
+	tests := []struct {
+		code  string
+		valid bool
+	}{
+		{"USD", true},
+		{"usd", true},
+		{"978", true},
+		{"36", true},
+		{"XXX", false},
+		{"US", false},
+		{"999", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := ValidateCurrencyCode(tt.code); got != tt.valid {
+				t.Errorf("ValidateCurrencyCode(%q) = %v, want %v", tt.code, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestListCurrencies(t *testing.T) {
+	currencies, err := ListCurrencies()
+	if err != nil {
+		t.Fatalf("Failed to list currencies: %v", err)
+	}
+
+	if len(currencies) < 150 {
+		t.Errorf("Expected at least 150 currencies, got %d", len(currencies))
+	}
+
+	numerics := make(map[string]string)
+	for i, currency := range currencies {
+		if len(currency.Code) != 3 || len(currency.Numeric) != 3 || currency.Name == "" {
+			t.Errorf("Incomplete currency entry: %+v", currency)
+		}
+		if i > 0 && currencies[i-1].Code >= currency.Code {
+			t.Errorf("Currencies not sorted: %s before %s", currencies[i-1].Code, currency.Code)
+		}
+		if other, ok := numerics[currency.Numeric]; ok {
+			t.Errorf("Numeric code %s shared by %s and %s", currency.Numeric, other, currency.Code)
+		}
+		numerics[currency.Numeric] = currency.Code
+	}
+}
+
+func TestCurrency_MinorUnits(t *testing.T) {
+	tests := []struct {
+		decimals int
+		expected int64
+	}{
+		{0, 1},
+		{2, 100},
+		{3, 1000},
+	}
+
+	for _, tt := range tests {
+		currency := &Currency{Decimals: tt.decimals}
+		if got := currency.MinorUnits(); got != tt.expected {
+			t.Errorf("MinorUnits() with %d decimals = %d, want %d", tt.decimals, got, tt.expected)
+		}
+	}
+}
+
+func TestCatalog_GetCurrency(t *testing.T) {
+	catalog := NewCatalog()
+
+	currency, err := catalog.GetCurrency("CHF")
+	if err != nil {
+		t.Fatalf("Failed to get currency: %v", err)
+	}
+
+	if currency == nil || currency.Numeric != "756" {
+		t.Errorf("Expected CHF with numeric 756, got %v", currency)
+	}
+}
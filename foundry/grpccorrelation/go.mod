@@ -0,0 +1,33 @@
+module github.com/fulmenhq/gofulmen/foundry/grpccorrelation
+
+go 1.25.1
+
+require (
+	github.com/fulmenhq/gofulmen v0.1.20-0.20261016103749-25c22bfde606
+	github.com/stretchr/testify v1.8.1
+	google.golang.org/grpc v1.75.0
+	google.golang.org/protobuf v1.36.6
+)
+
+require (
+	github.com/antzucaro/matchr v0.0.0-20221106193745-7bed6ef61ef9 // indirect
+	github.com/bmatcuk/doublestar/v4 v4.9.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/fulmenhq/crucible v0.2.19 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/klauspost/cpuid/v2 v2.0.9 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1 // indirect
+	github.com/zeebo/xxh3 v1.0.2 // indirect
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/sys v0.37.0 // indirect
+	golang.org/x/text v0.30.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+// Local development builds against the working tree. The require above must
+// still name a published gofulmen version (tag or pseudo-version) that has
+// foundry.ExtractCorrelation/InjectCorrelation, since replace directives are
+// ignored when this module is consumed as a dependency.
+replace github.com/fulmenhq/gofulmen => ../..
@@ -0,0 +1,146 @@
+// Package grpccorrelation propagates Foundry correlation IDs and W3C trace
+// context across gRPC boundaries.
+//
+// It applies the same rules as foundry.CorrelationIDMiddleware and
+// foundry.CorrelationIDRoundTripper to gRPC metadata, so a correlation ID
+// flows unchanged between HTTP and gRPC services. Metadata keys are the
+// lowercase HTTP header names: "x-correlation-id", "traceparent", and
+// "tracestate".
+//
+// The package is its own Go module, so importing gofulmen does not pull in
+// gRPC. It requires a gofulmen version with foundry.ExtractCorrelation and
+// foundry.InjectCorrelation; the root module is tagged before this one.
+//
+// Example:
+//
+//	server := grpc.NewServer(
+//	    grpc.ChainUnaryInterceptor(grpccorrelation.UnaryServerInterceptor()),
+//	    grpc.ChainStreamInterceptor(grpccorrelation.StreamServerInterceptor()),
+//	)
+//
+//	conn, err := grpc.NewClient(target,
+//	    grpc.WithChainUnaryInterceptor(grpccorrelation.UnaryClientInterceptor()),
+//	    grpc.WithChainStreamInterceptor(grpccorrelation.StreamClientInterceptor()),
+//	)
+package grpccorrelation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataCarrier adapts gRPC metadata to foundry.CorrelationCarrier.
+// Keys are lowercased, as gRPC requires.
+type MetadataCarrier metadata.MD
+
+// Get returns the first value for key.
+func (m MetadataCarrier) Get(key string) string {
+	values := metadata.MD(m).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set replaces the values for key.
+func (m MetadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(strings.ToLower(key), value)
+}
+
+// FromIncomingContext reads the correlation ID and trace context from the
+// incoming gRPC metadata in ctx and returns a context with both attached
+// (see foundry.ExtractCorrelation). A correlation ID is generated if the
+// metadata has none.
+//
+// Example:
+//
+//	ctx = grpccorrelation.FromIncomingContext(ctx)
+//	corrID := foundry.MustCorrelationIDFromContext(ctx)
+func FromIncomingContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return foundry.ExtractCorrelation(ctx, MetadataCarrier(md))
+}
+
+// AppendToOutgoingContext returns a context whose outgoing gRPC metadata
+// carries the correlation ID and trace context from ctx (see
+// foundry.InjectCorrelation). Existing outgoing metadata is preserved.
+//
+// Example:
+//
+//	ctx = grpccorrelation.AppendToOutgoingContext(ctx)
+//	resp, err := client.GetUser(ctx, req)
+func AppendToOutgoingContext(ctx context.Context) context.Context {
+	fields := metadata.MD{}
+	foundry.InjectCorrelation(ctx, MetadataCarrier(fields))
+	if len(fields) == 0 {
+		return ctx
+	}
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = metadata.Join(md, nil) // copy; outgoing metadata must not be mutated
+	for key, values := range fields {
+		md[key] = values
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// UnaryServerInterceptor extracts or generates the correlation ID for each
+// unary call, attaches it to the handler's context, and returns it to the
+// client in the "x-correlation-id" response header.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = FromIncomingContext(ctx)
+		setResponseHeader(ctx, func(md metadata.MD) error { return grpc.SetHeader(ctx, md) })
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor extracts or generates the correlation ID for each
+// streaming call, attaches it to the stream's context, and returns it to the
+// client in the "x-correlation-id" response header.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := FromIncomingContext(ss.Context())
+		setResponseHeader(ctx, ss.SetHeader)
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// UnaryClientInterceptor propagates the correlation ID and trace context
+// from the call's context to the server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(AppendToOutgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor propagates the correlation ID and trace context
+// from the stream's context to the server.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(AppendToOutgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// setResponseHeader sends the context's correlation ID as response metadata.
+// Failures (e.g., headers already sent) are ignored; the call proceeds.
+func setResponseHeader(ctx context.Context, set func(metadata.MD) error) {
+	if corrID, ok := foundry.CorrelationIDFromContext(ctx); ok {
+		_ = set(metadata.Pairs(strings.ToLower(foundry.CorrelationIDHeader), corrID.String()))
+	}
+}
+
+// serverStream overrides a ServerStream's context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context with correlation attached.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,210 @@
+package grpccorrelation
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// echoServer records the context seen by each handler.
+type echoServer struct {
+	unaryCtx  context.Context
+	streamCtx context.Context
+}
+
+// newTestConn starts an in-memory server with the server interceptors and
+// returns a client connection with the client interceptors.
+func newTestConn(t *testing.T) (*grpc.ClientConn, *echoServer, *grpc.StreamDesc) {
+	t.Helper()
+
+	echo := &echoServer{}
+	streamDesc := grpc.StreamDesc{
+		StreamName:    "Watch",
+		ServerStreams: true,
+		ClientStreams: true,
+		Handler: func(_ interface{}, stream grpc.ServerStream) error {
+			echo.streamCtx = stream.Context()
+			in := new(emptypb.Empty)
+			if err := stream.RecvMsg(in); err != nil {
+				return err
+			}
+			return stream.SendMsg(in)
+		},
+	}
+	desc := grpc.ServiceDesc{
+		ServiceName: "test.Echo",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Ping",
+			Handler: func(_ interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(emptypb.Empty)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					echo.unaryCtx = ctx
+					return req, nil
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{FullMethod: "/test.Echo/Ping"}, handler)
+			},
+		}},
+		Streams: []grpc.StreamDesc{streamDesc},
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor()),
+	)
+	server.RegisterService(&desc, struct{}{})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn, echo, &streamDesc
+}
+
+func TestMetadataCarrier(t *testing.T) {
+	md := metadata.MD{}
+	carrier := MetadataCarrier(md)
+
+	carrier.Set(foundry.CorrelationIDHeader, "abc")
+	assert.Equal(t, []string{"abc"}, md["x-correlation-id"])
+	assert.Equal(t, "abc", carrier.Get("X-Correlation-ID"))
+	assert.Equal(t, "", carrier.Get("traceparent"))
+}
+
+func TestFromIncomingContext(t *testing.T) {
+	corrID := foundry.NewCorrelationIDValue()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-correlation-id", corrID.String(),
+		"traceparent", testTraceParent,
+		"tracestate", "congo=t61rcWkgMzE",
+	))
+
+	ctx = FromIncomingContext(ctx)
+	assert.Equal(t, corrID, foundry.MustCorrelationIDFromContext(ctx))
+
+	tc, ok := foundry.TraceContextFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", tc.TraceID)
+	assert.Equal(t, "congo=t61rcWkgMzE", tc.State)
+
+	// No metadata at all: a correlation ID is generated
+	generated := foundry.MustCorrelationIDFromContext(FromIncomingContext(context.Background()))
+	assert.True(t, generated.IsValid())
+}
+
+func TestAppendToOutgoingContext(t *testing.T) {
+	corrID := foundry.NewCorrelationIDValue()
+	tc, err := foundry.ParseTraceParent(testTraceParent)
+	require.NoError(t, err)
+
+	existing := metadata.Pairs("authorization", "Bearer token")
+	ctx := metadata.NewOutgoingContext(context.Background(), existing)
+	ctx = foundry.WithCorrelationID(ctx, corrID)
+	ctx = foundry.WithTraceContext(ctx, tc)
+
+	md, ok := metadata.FromOutgoingContext(AppendToOutgoingContext(ctx))
+	require.True(t, ok)
+	assert.Equal(t, []string{corrID.String()}, md.Get("x-correlation-id"))
+	assert.Equal(t, []string{testTraceParent}, md.Get("traceparent"))
+	assert.Equal(t, []string{"Bearer token"}, md.Get("authorization"))
+	assert.Empty(t, existing.Get("x-correlation-id"), "existing metadata must not be mutated")
+
+	// Nothing to propagate: context is returned unchanged
+	plain := context.Background()
+	assert.Equal(t, plain, AppendToOutgoingContext(plain))
+}
+
+func TestUnaryInterceptors(t *testing.T) {
+	conn, echo, _ := newTestConn(t)
+
+	t.Run("PropagatesClientID", func(t *testing.T) {
+		corrID := foundry.NewCorrelationIDValue()
+		tc, err := foundry.ParseTraceParent(testTraceParent)
+		require.NoError(t, err)
+		ctx := foundry.WithTraceContext(foundry.WithCorrelationID(context.Background(), corrID), tc)
+
+		var header metadata.MD
+		err = conn.Invoke(ctx, "/test.Echo/Ping", &emptypb.Empty{}, &emptypb.Empty{}, grpc.Header(&header))
+		require.NoError(t, err)
+
+		assert.Equal(t, corrID, foundry.MustCorrelationIDFromContext(echo.unaryCtx))
+		serverTC, ok := foundry.TraceContextFromContext(echo.unaryCtx)
+		require.True(t, ok)
+		assert.Equal(t, testTraceParent, serverTC.TraceParent())
+		assert.Equal(t, []string{corrID.String()}, header.Get("x-correlation-id"))
+	})
+
+	t.Run("GeneratesID", func(t *testing.T) {
+		var header metadata.MD
+		err := conn.Invoke(context.Background(), "/test.Echo/Ping", &emptypb.Empty{}, &emptypb.Empty{}, grpc.Header(&header))
+		require.NoError(t, err)
+
+		serverID := foundry.MustCorrelationIDFromContext(echo.unaryCtx)
+		assert.True(t, serverID.IsValid())
+		assert.Equal(t, []string{serverID.String()}, header.Get("x-correlation-id"))
+	})
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	conn, echo, streamDesc := newTestConn(t)
+
+	corrID := foundry.NewCorrelationIDValue()
+	ctx := foundry.WithCorrelationID(context.Background(), corrID)
+
+	stream, err := conn.NewStream(ctx, streamDesc, "/test.Echo/Watch")
+	require.NoError(t, err)
+	require.NoError(t, stream.SendMsg(&emptypb.Empty{}))
+	require.NoError(t, stream.CloseSend())
+	require.NoError(t, stream.RecvMsg(&emptypb.Empty{}))
+
+	header, err := stream.Header()
+	require.NoError(t, err)
+	assert.Equal(t, []string{corrID.String()}, header.Get("x-correlation-id"))
+	assert.Equal(t, corrID, foundry.MustCorrelationIDFromContext(echo.streamCtx))
+}
+
+func TestHTTPToGRPC(t *testing.T) {
+	conn, echo, _ := newTestConn(t)
+
+	handler := foundry.CorrelationIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := conn.Invoke(r.Context(), "/test.Echo/Ping", &emptypb.Empty{}, &emptypb.Empty{})
+		require.NoError(t, err)
+	}))
+
+	corrID := foundry.NewCorrelationIDValue()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(foundry.CorrelationIDHeader, corrID.String())
+	req.Header.Set(foundry.TraceParentHeader, testTraceParent)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, corrID, foundry.MustCorrelationIDFromContext(echo.unaryCtx))
+	tc, ok := foundry.TraceContextFromContext(echo.unaryCtx)
+	require.True(t, ok)
+	assert.Equal(t, testTraceParent, tc.TraceParent())
+}
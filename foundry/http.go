@@ -1,5 +1,13 @@
 package foundry
 
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // HTTPStatusCode represents an individual HTTP status code with its reason phrase.
 type HTTPStatusCode struct {
 	// Value is the HTTP status code (e.g., 200, 404).
@@ -190,6 +198,187 @@ func (h *HTTPStatusHelper) GetGroup(statusCode int) *HTTPStatusGroup {
 	return h.groups[groupID]
 }
 
+// RetryClass classifies how a client should treat a response status when
+// deciding whether to retry.
+type RetryClass string
+
+const (
+	// RetryClassNone means the request must not be retried as-is (success,
+	// redirect, and most client errors).
+	RetryClassNone RetryClass = "none"
+
+	// RetryClassTransient means the failure is likely temporary (e.g., 500,
+	// 502, 504, 408) and the request may be retried with backoff.
+	RetryClassTransient RetryClass = "transient"
+
+	// RetryClassThrottled means the server is rate limiting or overloaded
+	// (429, 503); retry after Retry-After if sent, otherwise with a longer
+	// backoff.
+	RetryClassThrottled RetryClass = "throttled"
+)
+
+// retryClasses lists the retryable status codes. Everything else is
+// RetryClassNone.
+var retryClasses = map[int]RetryClass{
+	http.StatusRequestTimeout:      RetryClassTransient,
+	http.StatusTooEarly:            RetryClassTransient,
+	http.StatusInternalServerError: RetryClassTransient,
+	http.StatusBadGateway:          RetryClassTransient,
+	http.StatusGatewayTimeout:      RetryClassTransient,
+	http.StatusTooManyRequests:     RetryClassThrottled,
+	http.StatusServiceUnavailable:  RetryClassThrottled,
+}
+
+// ClassifyRetry returns the retry class for the given status code.
+//
+// Example:
+//
+//	helper.ClassifyRetry(503) // RetryClassThrottled
+//	helper.ClassifyRetry(502) // RetryClassTransient
+//	helper.ClassifyRetry(404) // RetryClassNone
+func (h *HTTPStatusHelper) ClassifyRetry(statusCode int) RetryClass {
+	if class, ok := retryClasses[statusCode]; ok {
+		return class
+	}
+	return RetryClassNone
+}
+
+// IsRetryable checks if a request that received the status code may be
+// retried (408, 425, 429, 500, 502, 503, 504).
+//
+// Example:
+//
+//	if helper.IsRetryable(resp.StatusCode) {
+//	    // Retry with backoff
+//	}
+func (h *HTTPStatusHelper) IsRetryable(statusCode int) bool {
+	return h.ClassifyRetry(statusCode) != RetryClassNone
+}
+
+// SuggestedBackoff returns the un-jittered delay before the given retry
+// (1-based) after receiving statusCode, following DefaultRetryPolicy.
+// Throttled statuses back off one step further than transient ones.
+// Returns 0 for statuses that are not retryable.
+//
+// A Retry-After header takes precedence over this suggestion (see
+// ParseRetryAfter).
+//
+// Example:
+//
+//	helper.SuggestedBackoff(502, 1) // 500ms
+//	helper.SuggestedBackoff(429, 1) // 1s
+//	helper.SuggestedBackoff(404, 1) // 0
+func (h *HTTPStatusHelper) SuggestedBackoff(statusCode, attempt int) time.Duration {
+	if attempt < 1 {
+		return 0
+	}
+	switch h.ClassifyRetry(statusCode) {
+	case RetryClassTransient:
+		return DefaultRetryPolicy().Backoff(attempt)
+	case RetryClassThrottled:
+		return DefaultRetryPolicy().Backoff(attempt + 1)
+	default:
+		return 0
+	}
+}
+
+// RetryError converts a response into an error for RetryPolicy.Do.
+//
+// Returns nil for statuses below 400. Retryable statuses return an
+// *HTTPStatusError carrying any Retry-After delay, which Do honors; other
+// statuses return the *HTTPStatusError wrapped with Permanent so Do stops
+// immediately. The response body is not read or closed.
+//
+// Example:
+//
+//	helper, _ := foundry.GetDefaultCatalog().GetHTTPStatusHelper()
+//	err := foundry.DefaultRetryPolicy().Do(ctx, func(ctx context.Context) error {
+//	    resp, err := client.Do(req.WithContext(ctx))
+//	    if err != nil {
+//	        return err
+//	    }
+//	    defer resp.Body.Close()
+//	    return helper.RetryError(resp)
+//	})
+func (h *HTTPStatusHelper) RetryError(resp *http.Response) error {
+	if resp == nil || resp.StatusCode < 400 {
+		return nil
+	}
+
+	err := &HTTPStatusError{
+		StatusCode: resp.StatusCode,
+		Reason:     h.GetReasonPhrase(resp.StatusCode),
+		Class:      h.ClassifyRetry(resp.StatusCode),
+	}
+	if err.Class == RetryClassNone {
+		return Permanent(err)
+	}
+	if delay, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		err.RetryAfter = delay
+	}
+	return err
+}
+
+// HTTPStatusError reports a failed HTTP response.
+type HTTPStatusError struct {
+	// StatusCode is the response status code.
+	StatusCode int
+
+	// Reason is the catalog reason phrase, if known.
+	Reason string
+
+	// Class is the retry classification of StatusCode.
+	Class RetryClass
+
+	// RetryAfter is the delay requested by the Retry-After header (0 if absent).
+	RetryAfter time.Duration
+}
+
+// Error returns the status as "HTTP <code> <reason>".
+func (e *HTTPStatusError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("HTTP %d", e.StatusCode)
+	}
+	return fmt.Sprintf("HTTP %d %s", e.StatusCode, e.Reason)
+}
+
+// RetryDelay returns the server-requested delay before retrying, if any.
+func (e *HTTPStatusError) RetryDelay() (time.Duration, bool) {
+	return e.RetryAfter, e.RetryAfter > 0
+}
+
+// ParseRetryAfter parses a Retry-After header value, which is either a
+// number of seconds or an HTTP-date, and returns the delay relative to now.
+// Dates in the past yield a zero delay. Returns false if the value is empty
+// or malformed.
+//
+// Example:
+//
+//	delay, ok := foundry.ParseRetryAfter("120", time.Now())                           // 2m0s, true
+//	delay, ok := foundry.ParseRetryAfter("Wed, 21 Oct 2015 07:28:00 GMT", time.Now()) // 0s, true (past)
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 || seconds > int64(time.Duration(1<<63-1)/time.Second) {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
+
 // isInGroup checks if a status code belongs to a specific group.
 func (h *HTTPStatusHelper) isInGroup(statusCode int, groupID string) bool {
 	actualGroupID, exists := h.codeToGroupID[statusCode]
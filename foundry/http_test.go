@@ -0,0 +1,168 @@
+package foundry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestHTTPStatusHelper_ClassifyRetry tests retry classification and IsRetryable
+func TestHTTPStatusHelper_ClassifyRetry(t *testing.T) {
+	helper, err := GetDefaultCatalog().GetHTTPStatusHelper()
+	if err != nil {
+		t.Fatalf("Failed to get HTTP status helper: %v", err)
+	}
+
+	tests := []struct {
+		code  int
+		class RetryClass
+	}{
+		{200, RetryClassNone},
+		{301, RetryClassNone},
+		{400, RetryClassNone},
+		{404, RetryClassNone},
+		{408, RetryClassTransient},
+		{425, RetryClassTransient},
+		{429, RetryClassThrottled},
+		{500, RetryClassTransient},
+		{501, RetryClassNone},
+		{502, RetryClassTransient},
+		{503, RetryClassThrottled},
+		{504, RetryClassTransient},
+		{999, RetryClassNone},
+	}
+
+	for _, tt := range tests {
+		if got := helper.ClassifyRetry(tt.code); got != tt.class {
+			t.Errorf("ClassifyRetry(%d) = %q, want %q", tt.code, got, tt.class)
+		}
+		if got := helper.IsRetryable(tt.code); got != (tt.class != RetryClassNone) {
+			t.Errorf("IsRetryable(%d) = %v", tt.code, got)
+		}
+	}
+}
+
+// TestHTTPStatusHelper_SuggestedBackoff tests backoff suggestions per class
+func TestHTTPStatusHelper_SuggestedBackoff(t *testing.T) {
+	helper, _ := GetDefaultCatalog().GetHTTPStatusHelper()
+
+	tests := []struct {
+		code    int
+		attempt int
+		want    time.Duration
+	}{
+		{502, 1, 500 * time.Millisecond},
+		{502, 2, time.Second},
+		{502, 10, 10 * time.Second}, // capped at MaxInterval
+		{429, 1, time.Second},
+		{503, 2, 2 * time.Second},
+		{404, 1, 0},
+		{502, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := helper.SuggestedBackoff(tt.code, tt.attempt); got != tt.want {
+			t.Errorf("SuggestedBackoff(%d, %d) = %v, want %v", tt.code, tt.attempt, got, tt.want)
+		}
+	}
+}
+
+// TestParseRetryAfter tests delay-seconds and HTTP-date forms
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 10, 21, 7, 28, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{" 0 ", 0, true},
+		{"Tue, 21 Oct 2025 07:30:00 GMT", 2 * time.Minute, true},
+		{"Tuesday, 21-Oct-25 07:29:00 GMT", time.Minute, true}, // RFC 850
+		{"Tue, 21 Oct 2025 07:00:00 GMT", 0, true},             // in the past
+		{"-5", 0, false},
+		{"1.5", 0, false},
+		{"soon", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// TestHTTPStatusHelper_RetryError tests converting responses to retry errors
+func TestHTTPStatusHelper_RetryError(t *testing.T) {
+	helper, _ := GetDefaultCatalog().GetHTTPStatusHelper()
+
+	if err := helper.RetryError(&http.Response{StatusCode: 204}); err != nil {
+		t.Errorf("Expected nil error for 204, got %v", err)
+	}
+
+	throttled := &http.Response{StatusCode: 429, Header: http.Header{"Retry-After": []string{"3"}}}
+	err := helper.RetryError(throttled)
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("Expected *HTTPStatusError, got %T", err)
+	}
+	if statusErr.Class != RetryClassThrottled || statusErr.RetryAfter != 3*time.Second {
+		t.Errorf("RetryError(429) = %+v", statusErr)
+	}
+	if err.Error() != "HTTP 429 Too Many Requests" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+
+	// Non-retryable statuses stop RetryPolicy.Do immediately
+	calls := 0
+	err = DefaultRetryPolicy().Do(context.Background(), func(context.Context) error {
+		calls++
+		return helper.RetryError(&http.Response{StatusCode: 404, Header: http.Header{}})
+	})
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != 404 || calls != 1 {
+		t.Errorf("Expected permanent 404 after 1 call, got %v after %d calls", err, calls)
+	}
+}
+
+// TestHTTPStatusHelper_RetryError_Integration tests Retry-After handling end to end
+func TestHTTPStatusHelper_RetryError_Integration(t *testing.T) {
+	helper, _ := GetDefaultCatalog().GetHTTPStatusHelper()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	policy := &RetryPolicy{
+		InitialInterval: HumanDuration(time.Millisecond),
+		MaxInterval:     HumanDuration(time.Second),
+		Multiplier:      2,
+		MaxAttempts:     3,
+		Jitter:          JitterNone,
+	}
+	err := policy.Do(context.Background(), func(ctx context.Context) error {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		return helper.RetryError(resp)
+	})
+	if err != nil || requests != 2 {
+		t.Errorf("Expected success on second request, got %v after %d requests", err, requests)
+	}
+}
@@ -0,0 +1,227 @@
+package foundry
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HumanDuration is a time.Duration that parses human-readable values such as
+// "250ms", "1h30m", "2d", or "1w 3d" in JSON and YAML configuration.
+//
+// It accepts Go duration syntax plus "d" (24h) and "w" (7d) units, with
+// optional spaces between components. A bare number is a count of seconds,
+// so existing integer settings such as TTLs keep working. Assigned from Go
+// code it is a time.Duration, so use HumanDuration(10 * time.Minute) rather
+// than a bare number.
+//
+// Values marshal to their canonical form, which is valid Go duration syntax
+// without zero components ("1h30m", "250ms", "36h").
+//
+// Example:
+//
+//	type CacheConfig struct {
+//	    TTL foundry.HumanDuration `json:"ttl" yaml:"ttl"`
+//	}
+//
+//	// ttl: 1h30m
+//	cache.SetTTL(config.TTL.Duration())
+type HumanDuration time.Duration
+
+// humanDurationUnits are the units added on top of Go duration syntax.
+var humanDurationUnits = map[string]time.Duration{
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// ParseHumanDuration parses a duration such as "250ms", "1h30m", "2d", or
+// "3600" (seconds).
+//
+// Example:
+//
+//	ttl, err := foundry.ParseHumanDuration("1h30m")
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(ttl.Duration()) // 1h30m0s
+func ParseHumanDuration(s string) (HumanDuration, error) {
+	trimmed := strings.Join(strings.Fields(s), "")
+	if trimmed == "" {
+		return 0, fmt.Errorf("invalid duration %q: empty", s)
+	}
+
+	// A bare number is seconds
+	if seconds, err := strconv.ParseFloat(trimmed, 64); err == nil {
+		if math.IsNaN(seconds) || math.Abs(seconds) > math.MaxInt64/float64(time.Second) {
+			return 0, fmt.Errorf("invalid duration %q: out of range", s)
+		}
+		return HumanDuration(time.Duration(seconds * float64(time.Second))), nil
+	}
+
+	sign := time.Duration(1)
+	rest := trimmed
+	if rest[0] == '-' || rest[0] == '+' {
+		if rest[0] == '-' {
+			sign = -1
+		}
+		rest = rest[1:]
+	}
+
+	// Split off day and week components; Go handles the remainder
+	var total time.Duration
+	for rest != "" {
+		numberEnd := strings.IndexFunc(rest, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if numberEnd <= 0 {
+			break
+		}
+		unit, ok := humanDurationUnits[rest[numberEnd:numberEnd+1]]
+		if !ok {
+			break
+		}
+		value, err := strconv.ParseFloat(rest[:numberEnd], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		total += time.Duration(value * float64(unit))
+		rest = rest[numberEnd+1:]
+	}
+
+	if rest != "" {
+		parsed, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: expected a value such as \"250ms\", \"1h30m\", or \"2d\"", s)
+		}
+		total += parsed
+	}
+	return HumanDuration(sign * total), nil
+}
+
+// MustHumanDuration parses a duration and panics on error. Use for constants
+// in code.
+func MustHumanDuration(s string) HumanDuration {
+	d, err := ParseHumanDuration(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Duration returns the value as a time.Duration.
+func (d HumanDuration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// String returns the canonical form, e.g. "1h30m", "250ms", "0s".
+func (d HumanDuration) String() string {
+	duration := time.Duration(d)
+	switch {
+	case duration == 0:
+		return "0s"
+	case duration == math.MinInt64:
+		return duration.String()
+	case duration < 0:
+		return "-" + HumanDuration(-duration).String()
+	case duration < time.Second:
+		return duration.String()
+	}
+
+	var b strings.Builder
+	if hours := duration / time.Hour; hours > 0 {
+		fmt.Fprintf(&b, "%dh", hours)
+		duration -= hours * time.Hour
+	}
+	if minutes := duration / time.Minute; minutes > 0 {
+		fmt.Fprintf(&b, "%dm", minutes)
+		duration -= minutes * time.Minute
+	}
+	if duration > 0 {
+		// Sub-minute remainder, e.g. "30s", "1.5s", or "500ms"
+		b.WriteString(duration.String())
+	}
+	return b.String()
+}
+
+// MarshalText implements encoding.TextMarshaler using the canonical form.
+func (d HumanDuration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *HumanDuration) UnmarshalText(text []byte) error {
+	parsed, err := ParseHumanDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON encodes the duration as its canonical string.
+func (d HumanDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a duration string such as "1h30m" or a number of
+// seconds.
+func (d *HumanDuration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		// Not a string: accept a plain number of seconds
+		s = string(data)
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML encodes the duration as its canonical string.
+func (d HumanDuration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// UnmarshalYAML decodes a duration string such as "1h30m" or a number of
+// seconds.
+func (d *HumanDuration) UnmarshalYAML(node *yaml.Node) error {
+	var s string
+	if err := node.Decode(&s); err != nil {
+		return fmt.Errorf("duration must be a scalar: %w", err)
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// Value implements database/sql/driver.Valuer for database integration.
+//
+// The duration is stored in its canonical form (VARCHAR/TEXT column).
+func (d HumanDuration) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements database/sql.Scanner for database integration.
+//
+// Reads durations from CHAR/VARCHAR/TEXT columns, or nanoseconds from
+// integer columns.
+func (d *HumanDuration) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = 0
+		return nil
+	case int64:
+		*d = HumanDuration(v)
+		return nil
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case []byte:
+		return d.UnmarshalText(v)
+	default:
+		return fmt.Errorf("cannot scan %T into HumanDuration", src)
+	}
+}
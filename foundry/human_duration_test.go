@@ -0,0 +1,158 @@
+package foundry
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestParseHumanDuration tests parsing human-readable durations
+func TestParseHumanDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"Milliseconds", "250ms", 250 * time.Millisecond, false},
+		{"Compound", "1h30m", 90 * time.Minute, false},
+		{"Days", "2d", 48 * time.Hour, false},
+		{"DaysAndHours", "1d12h", 36 * time.Hour, false},
+		{"WeeksAndDays", "1w 3d", 10 * 24 * time.Hour, false},
+		{"FractionalDays", "1.5d", 36 * time.Hour, false},
+		{"Seconds", "3600", time.Hour, false},
+		{"FractionalSeconds", "0.5", 500 * time.Millisecond, false},
+		{"Negative", "-1d", -24 * time.Hour, false},
+		{"Spaces", " 1h 30m ", 90 * time.Minute, false},
+		{"UnknownUnit", "10y", 0, true},
+		{"DaysAfterHours", "12h1d", 0, true},
+		{"Empty", "", 0, true},
+		{"Garbage", "soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ParseHumanDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHumanDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if d.Duration() != tt.expected {
+				t.Errorf("ParseHumanDuration(%q) = %v, want %v", tt.input, d.Duration(), tt.expected)
+			}
+		})
+	}
+}
+
+// TestHumanDuration_String tests canonical formatting
+func TestHumanDuration_String(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                                   "0s",
+		250 * time.Millisecond:              "250ms",
+		90 * time.Minute:                    "1h30m",
+		time.Hour:                           "1h",
+		36 * time.Hour:                      "36h",
+		time.Hour + 30*time.Second:          "1h30s",
+		time.Minute + 1500*time.Millisecond: "1m1.5s",
+		-90 * time.Second:                   "-1m30s",
+	}
+	for d, want := range tests {
+		got := HumanDuration(d).String()
+		if got != want {
+			t.Errorf("HumanDuration(%v).String() = %q, want %q", d, got, want)
+		}
+		// The canonical form is valid Go duration syntax
+		if parsed, err := time.ParseDuration(got); err != nil || parsed != d {
+			t.Errorf("time.ParseDuration(%q) = %v, %v; want %v", got, parsed, err, d)
+		}
+	}
+}
+
+// TestHumanDuration_JSONRoundTrip tests JSON marshaling and unmarshaling
+func TestHumanDuration_JSONRoundTrip(t *testing.T) {
+	type Config struct {
+		TTL HumanDuration `json:"ttl"`
+	}
+
+	data, err := json.Marshal(Config{TTL: HumanDuration(90 * time.Minute)})
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if string(data) != `{"ttl":"1h30m"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var config Config
+	if err := json.Unmarshal([]byte(`{"ttl":"2d"}`), &config); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if config.TTL.Duration() != 48*time.Hour {
+		t.Errorf("json.Unmarshal() TTL = %v, want 48h", config.TTL.Duration())
+	}
+
+	// Plain numbers are seconds
+	if err := json.Unmarshal([]byte(`{"ttl":300}`), &config); err != nil {
+		t.Fatalf("json.Unmarshal(number) error: %v", err)
+	}
+	if config.TTL.Duration() != 5*time.Minute {
+		t.Errorf("json.Unmarshal(number) TTL = %v, want 5m", config.TTL.Duration())
+	}
+
+	if err := json.Unmarshal([]byte(`{"ttl":"later"}`), &config); err == nil {
+		t.Error("Expected error unmarshaling invalid duration")
+	}
+}
+
+// TestHumanDuration_YAMLRoundTrip tests YAML marshaling and unmarshaling
+func TestHumanDuration_YAMLRoundTrip(t *testing.T) {
+	type Config struct {
+		TTL HumanDuration `yaml:"ttl"`
+	}
+
+	data, err := yaml.Marshal(Config{TTL: HumanDuration(250 * time.Millisecond)})
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error: %v", err)
+	}
+	if string(data) != "ttl: 250ms\n" {
+		t.Errorf("yaml.Marshal() = %q", data)
+	}
+
+	var config Config
+	for input, want := range map[string]time.Duration{
+		"ttl: 1w\n": 7 * 24 * time.Hour,
+		"ttl: 60\n": time.Minute,
+	} {
+		if err := yaml.Unmarshal([]byte(input), &config); err != nil {
+			t.Fatalf("yaml.Unmarshal(%q) error: %v", input, err)
+		}
+		if config.TTL.Duration() != want {
+			t.Errorf("yaml.Unmarshal(%q) TTL = %v, want %v", input, config.TTL.Duration(), want)
+		}
+	}
+}
+
+// TestHumanDuration_Database tests database/sql Value and Scan
+func TestHumanDuration_Database(t *testing.T) {
+	var d HumanDuration
+	if err := d.Scan("1h30m"); err != nil || d.Duration() != 90*time.Minute {
+		t.Errorf("Scan(string) = %v, %v", d.Duration(), err)
+	}
+	if err := d.Scan([]byte("2d")); err != nil || d.Duration() != 48*time.Hour {
+		t.Errorf("Scan([]byte) = %v, %v", d.Duration(), err)
+	}
+	if err := d.Scan(int64(time.Second)); err != nil || d.Duration() != time.Second {
+		t.Errorf("Scan(int64) = %v, %v", d.Duration(), err)
+	}
+	if err := d.Scan(nil); err != nil || d != 0 {
+		t.Errorf("Scan(nil) = %v, %v", d.Duration(), err)
+	}
+	if err := d.Scan(1.5); err == nil {
+		t.Error("Expected error scanning float64")
+	}
+
+	value, err := HumanDuration(90 * time.Minute).Value()
+	if err != nil || value != "1h30m" {
+		t.Errorf("Value() = %v, %v; want \"1h30m\"", value, err)
+	}
+}
@@ -0,0 +1,418 @@
+package foundry
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/internal/ulid"
+	"github.com/google/uuid"
+)
+
+// ULID is a validated ULID newtype: a 26-character Crockford base32 identifier
+// made of a 48-bit millisecond timestamp and 80 random bits.
+//
+// ULIDs sort lexicographically in creation order, so they work as database
+// keys, object names, and event IDs where UUID hyphens are unwelcome. The
+// canonical form is uppercase; ParseULID and UnmarshalText normalize input.
+//
+// Example:
+//
+//	type Order struct {
+//	    ID    foundry.ULID `json:"id" db:"id"`
+//	    Total int64        `json:"total"`
+//	}
+//
+//	order := Order{ID: foundry.NewULID()}
+//	fmt.Println(order.ID.Time()) // creation time, millisecond precision
+type ULID string
+
+// UUIDv7 is a validated UUIDv7 newtype: the time-sortable UUID format used by
+// CorrelationID, for identifiers that are not correlation IDs (row keys,
+// resource IDs). The canonical form is lowercase 8-4-4-4-12 hex.
+//
+// Example:
+//
+//	id := foundry.NewUUIDv7()
+//	fmt.Println(id, id.Time())
+type UUIDv7 string
+
+// IDOptions configures an IDGenerator.
+type IDOptions struct {
+	// Monotonic guarantees strictly increasing IDs from the generator, even
+	// within one millisecond or when the clock steps backwards: the random
+	// part of the previous ID is incremented instead of regenerated.
+	// Default: false (IDs from the same millisecond are unordered).
+	Monotonic bool
+
+	// Clock returns the current time. Default: time.Now.
+	Clock func() time.Time
+
+	// Entropy supplies random bits. Default: crypto/rand.Reader.
+	Entropy io.Reader
+}
+
+// IDGenerator generates ULIDs and UUIDv7s. It is safe for concurrent use.
+type IDGenerator struct {
+	opts IDOptions
+
+	mu       sync.Mutex
+	lastULID [16]byte
+	lastUUID [16]byte
+}
+
+// NewIDGenerator creates an ID generator with the given options.
+//
+// Example:
+//
+//	gen := foundry.NewIDGenerator(foundry.IDOptions{Monotonic: true})
+//	a, b := gen.ULID(), gen.ULID()
+//	// a < b, even if both were generated in the same millisecond
+func NewIDGenerator(opts IDOptions) *IDGenerator {
+	if opts.Clock == nil {
+		opts.Clock = time.Now
+	}
+	if opts.Entropy == nil {
+		opts.Entropy = rand.Reader
+	}
+	return &IDGenerator{opts: opts}
+}
+
+var defaultIDGenerator = NewIDGenerator(IDOptions{})
+
+// NewULID generates a new ULID for the current time.
+//
+// Example:
+//
+//	id := foundry.NewULID()
+//	fmt.Println(id) // 01HF8Z3K4M7Q2W5XJ9RT6VBN0C
+func NewULID() ULID {
+	return defaultIDGenerator.ULID()
+}
+
+// NewUUIDv7 generates a new UUIDv7 for the current time.
+//
+// Example:
+//
+//	id := foundry.NewUUIDv7()
+//	fmt.Println(id) // 018b2c5e-8f4a-7890-b123-456789abcdef
+func NewUUIDv7() UUIDv7 {
+	return defaultIDGenerator.UUIDv7()
+}
+
+// ULID generates a ULID.
+func (g *IDGenerator) ULID() ULID {
+	return ULID(ulid.Encode(g.next(&g.lastULID, ulidRandomMask, nil)))
+}
+
+// UUIDv7 generates a UUIDv7.
+func (g *IDGenerator) UUIDv7() UUIDv7 {
+	id := g.next(&g.lastUUID, uuidv7RandomMask, func(id *[16]byte) {
+		id[6] = id[6]&0x0f | 0x70 // version 7
+		id[8] = id[8]&0x3f | 0x80 // RFC 4122 variant
+	})
+	return UUIDv7(uuid.UUID(id).String())
+}
+
+// Random bits of each layout; everything after the 48-bit timestamp for a
+// ULID, and everything except the version and variant bits for a UUIDv7.
+var (
+	ulidRandomMask   = [16]byte{6: 0xff, 7: 0xff, 8: 0xff, 9: 0xff, 10: 0xff, 11: 0xff, 12: 0xff, 13: 0xff, 14: 0xff, 15: 0xff}
+	uuidv7RandomMask = [16]byte{6: 0x0f, 7: 0xff, 8: 0x3f, 9: 0xff, 10: 0xff, 11: 0xff, 12: 0xff, 13: 0xff, 14: 0xff, 15: 0xff}
+)
+
+// next builds a 128-bit ID with the current millisecond timestamp and random
+// bits under mask. In monotonic mode, an ID that would not sort after last
+// instead increments last's random bits.
+func (g *IDGenerator) next(last *[16]byte, mask [16]byte, stamp func(*[16]byte)) [16]byte {
+	var id [16]byte
+	ulid.PutTimestamp(&id, g.opts.Clock())
+	if _, err := io.ReadFull(g.opts.Entropy, id[6:]); err != nil {
+		panic(fmt.Sprintf("foundry: failed to read entropy: %v", err))
+	}
+	if stamp != nil {
+		stamp(&id)
+	}
+	if !g.opts.Monotonic {
+		return id
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if *last != ([16]byte{}) && ulid.Timestamp(id) <= ulid.Timestamp(*last) {
+		id = *last
+		if incrementMasked(&id, mask) {
+			// Random bits exhausted within one millisecond: borrow the next one
+			ulid.PutTimestamp(&id, time.UnixMilli(ulid.Timestamp(id)+1))
+		}
+	}
+	*last = id
+	return id
+}
+
+// incrementMasked adds one to the bits of id under mask, treated as a single
+// big-endian integer, and reports whether it overflowed. Each byte's mask must
+// cover its low bits.
+func incrementMasked(id *[16]byte, mask [16]byte) bool {
+	for i := len(id) - 1; i >= 0; i-- {
+		m := mask[i]
+		if m == 0 {
+			continue
+		}
+		v := int(id[i]&m) + 1
+		id[i] = id[i]&^m | byte(v)&m
+		if v <= int(m) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseULID parses and validates a ULID string, normalizing it to uppercase.
+//
+// Example:
+//
+//	id, err := foundry.ParseULID("01hf8z3k4m7q2w5xj9rt6vbn0c")
+//	if err != nil {
+//	    return fmt.Errorf("invalid order ID: %w", err)
+//	}
+func ParseULID(s string) (ULID, error) {
+	normalized := strings.ToUpper(s)
+	if _, err := ulid.Decode(normalized); err != nil {
+		return "", fmt.Errorf("invalid ULID format: %w", err)
+	}
+	return ULID(normalized), nil
+}
+
+// String returns the ULID as a string.
+func (u ULID) String() string {
+	return string(u)
+}
+
+// Validate checks if the ULID is a canonical ULID.
+func (u ULID) Validate() error {
+	if u == "" {
+		return fmt.Errorf("ULID is empty")
+	}
+	if _, err := ulid.Decode(string(u)); err != nil {
+		return fmt.Errorf("invalid ULID format: %w", err)
+	}
+	return nil
+}
+
+// IsValid returns true if the ULID is valid.
+func (u ULID) IsValid() bool {
+	return u.Validate() == nil
+}
+
+// Time returns the ULID's embedded timestamp (millisecond precision), or the
+// zero time if the ULID is invalid.
+func (u ULID) Time() time.Time {
+	id, err := ulid.Decode(string(u))
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ulid.Timestamp(id))
+}
+
+// Compare returns -1, 0, or +1 as u sorts before, equal to, or after other.
+// Canonical ULIDs sort in creation order.
+func (u ULID) Compare(other ULID) int {
+	return strings.Compare(string(u), string(other))
+}
+
+// Before reports whether u sorts before other.
+func (u ULID) Before(other ULID) bool {
+	return u.Compare(other) < 0
+}
+
+// MarshalText implements encoding.TextMarshaler for JSON, YAML, TOML support.
+func (u ULID) MarshalText() ([]byte, error) {
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(u), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for JSON, YAML, TOML support.
+//
+// Validates and normalizes the ULID on unmarshal.
+func (u *ULID) UnmarshalText(text []byte) error {
+	parsed, err := ParseULID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// Value implements database/sql/driver.Valuer for database integration.
+//
+// The ULID is stored as a string (CHAR(26)/VARCHAR/TEXT column).
+func (u ULID) Value() (driver.Value, error) {
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	return string(u), nil
+}
+
+// Scan implements database/sql.Scanner for database integration.
+//
+// Reads ULIDs from CHAR/VARCHAR/TEXT columns with validation.
+func (u *ULID) Scan(src interface{}) error {
+	if src == nil {
+		*u = ""
+		return nil
+	}
+
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into ULID", src)
+	}
+
+	parsed, err := ParseULID(s)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// parseUUIDv7 parses s and enforces version 7.
+func parseUUIDv7(s string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.UUID{}, fmt.Errorf("invalid UUIDv7 format: %w", err)
+	}
+	if parsed.Version() != 7 {
+		return uuid.UUID{}, fmt.Errorf("UUID must be version 7, got version %d", parsed.Version())
+	}
+	return parsed, nil
+}
+
+// ParseUUIDv7 parses and validates a UUIDv7 string, normalizing it to the
+// canonical lowercase hyphenated form.
+//
+// Example:
+//
+//	id, err := foundry.ParseUUIDv7("018b2c5e-8f4a-7890-b123-456789abcdef")
+//	if err != nil {
+//	    return fmt.Errorf("invalid resource ID: %w", err)
+//	}
+func ParseUUIDv7(s string) (UUIDv7, error) {
+	parsed, err := parseUUIDv7(s)
+	if err != nil {
+		return "", err
+	}
+	return UUIDv7(parsed.String()), nil
+}
+
+// String returns the UUIDv7 as a string.
+func (u UUIDv7) String() string {
+	return string(u)
+}
+
+// Validate checks if the value is a valid UUIDv7.
+func (u UUIDv7) Validate() error {
+	if u == "" {
+		return fmt.Errorf("UUIDv7 is empty")
+	}
+	_, err := parseUUIDv7(string(u))
+	return err
+}
+
+// IsValid returns true if the UUIDv7 is valid.
+func (u UUIDv7) IsValid() bool {
+	return u.Validate() == nil
+}
+
+// Time returns the UUIDv7's embedded timestamp (millisecond precision), or
+// the zero time if the value is invalid.
+func (u UUIDv7) Time() time.Time {
+	parsed, err := parseUUIDv7(string(u))
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ulid.Timestamp(parsed))
+}
+
+// Compare returns -1, 0, or +1 as u sorts before, equal to, or after other.
+// Canonical UUIDv7s sort in creation order.
+func (u UUIDv7) Compare(other UUIDv7) int {
+	return strings.Compare(string(u), string(other))
+}
+
+// Before reports whether u sorts before other.
+func (u UUIDv7) Before(other UUIDv7) bool {
+	return u.Compare(other) < 0
+}
+
+// MarshalText implements encoding.TextMarshaler for JSON, YAML, TOML support.
+func (u UUIDv7) MarshalText() ([]byte, error) {
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(u), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for JSON, YAML, TOML support.
+//
+// Validates and normalizes the UUIDv7 on unmarshal.
+func (u *UUIDv7) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUIDv7(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// Value implements database/sql/driver.Valuer for database integration.
+//
+// The UUIDv7 is stored as a string (UUID/CHAR(36)/TEXT column).
+func (u UUIDv7) Value() (driver.Value, error) {
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	return string(u), nil
+}
+
+// Scan implements database/sql.Scanner for database integration.
+//
+// Reads UUIDv7s from UUID, CHAR/VARCHAR/TEXT, or 16-byte binary columns.
+func (u *UUIDv7) Scan(src interface{}) error {
+	if src == nil {
+		*u = ""
+		return nil
+	}
+
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		if len(v) == 16 {
+			s = uuid.UUID(v).String()
+		} else {
+			s = string(v)
+		}
+	default:
+		return fmt.Errorf("cannot scan %T into UUIDv7", src)
+	}
+
+	parsed, err := ParseUUIDv7(s)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
@@ -0,0 +1,267 @@
+package foundry
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// fixedClock returns a clock that always reports t.
+func fixedClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+func TestNewULID(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	id := NewULID()
+	after := time.Now()
+
+	if err := id.Validate(); err != nil {
+		t.Fatalf("NewULID() = %q is invalid: %v", id, err)
+	}
+	if len(id) != 26 {
+		t.Errorf("NewULID() length = %d, want 26", len(id))
+	}
+	if ts := id.Time(); ts.Before(before) || ts.After(after) {
+		t.Errorf("ULID.Time() = %v, want between %v and %v", ts, before, after)
+	}
+}
+
+func TestNewUUIDv7(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	id := NewUUIDv7()
+	after := time.Now()
+
+	if err := id.Validate(); err != nil {
+		t.Fatalf("NewUUIDv7() = %q is invalid: %v", id, err)
+	}
+	parsed := uuid.MustParse(id.String())
+	if parsed.Variant() != uuid.RFC4122 {
+		t.Errorf("NewUUIDv7() variant = %v, want RFC4122", parsed.Variant())
+	}
+	if ts := id.Time(); ts.Before(before) || ts.After(after) {
+		t.Errorf("UUIDv7.Time() = %v, want between %v and %v", ts, before, after)
+	}
+	if !IsValidCorrelationID(id.String()) {
+		t.Errorf("UUIDv7 %q should be a valid correlation ID", id)
+	}
+}
+
+func TestULID_KnownValue(t *testing.T) {
+	// Timestamp 1469918176385 ms and all-zero randomness, from the ULID spec examples
+	gen := NewIDGenerator(IDOptions{
+		Clock:   fixedClock(time.UnixMilli(1469918176385)),
+		Entropy: bytes.NewReader(make([]byte, 10)),
+	})
+	id := gen.ULID()
+	if id != "01ARYZ6S410000000000000000" {
+		t.Errorf("ULID() = %q, want %q", id, "01ARYZ6S410000000000000000")
+	}
+	if got := id.Time().UnixMilli(); got != 1469918176385 {
+		t.Errorf("ULID.Time() = %d, want 1469918176385", got)
+	}
+}
+
+func TestParseULID(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected ULID
+		wantErr  bool
+	}{
+		{"Canonical", "01ARYZ6S41TSV4RRFFQ69G5FAV", "01ARYZ6S41TSV4RRFFQ69G5FAV", false},
+		{"Lowercase", "01aryz6s41tsv4rrffq69g5fav", "01ARYZ6S41TSV4RRFFQ69G5FAV", false},
+		{"TooShort", "01ARYZ6S41", "", true},
+		{"InvalidChar", "01ARYZ6S41TSV4RRFFQ69G5FAU", "", true},
+		{"Overflow", "81ARYZ6S41TSV4RRFFQ69G5FAV", "", true},
+		{"Empty", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := ParseULID(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseULID(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if id != tt.expected {
+				t.Errorf("ParseULID(%q) = %q, want %q", tt.input, id, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseUUIDv7(t *testing.T) {
+	v7 := NewUUIDv7()
+
+	if id, err := ParseUUIDv7(strings.ToUpper(v7.String())); err != nil || id != v7 {
+		t.Errorf("ParseUUIDv7(upper) = %q, %v; want %q", id, err, v7)
+	}
+	if _, err := ParseUUIDv7(uuid.New().String()); err == nil {
+		t.Error("Expected error for UUIDv4")
+	}
+	if _, err := ParseUUIDv7("not-a-uuid"); err == nil {
+		t.Error("Expected error for invalid UUID")
+	}
+	if UUIDv7("").IsValid() {
+		t.Error("Empty UUIDv7 should be invalid")
+	}
+}
+
+func TestIDGenerator_Monotonic(t *testing.T) {
+	// A frozen clock forces every ID into the same millisecond
+	clock := fixedClock(time.UnixMilli(1700000000000))
+	gen := NewIDGenerator(IDOptions{Monotonic: true, Clock: clock})
+
+	ulids := make([]ULID, 1000)
+	uuids := make([]UUIDv7, 1000)
+	for i := range ulids {
+		ulids[i] = gen.ULID()
+		uuids[i] = gen.UUIDv7()
+	}
+
+	for i := 1; i < len(ulids); i++ {
+		if !ulids[i-1].Before(ulids[i]) {
+			t.Fatalf("ULID %d (%s) does not sort after %s", i, ulids[i], ulids[i-1])
+		}
+		if !uuids[i-1].Before(uuids[i]) {
+			t.Fatalf("UUIDv7 %d (%s) does not sort after %s", i, uuids[i], uuids[i-1])
+		}
+	}
+	if err := uuids[len(uuids)-1].Validate(); err != nil {
+		t.Errorf("incremented UUIDv7 is invalid: %v", err)
+	}
+}
+
+func TestIDGenerator_MonotonicClockBackwards(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	gen := NewIDGenerator(IDOptions{Monotonic: true, Clock: func() time.Time { return now }})
+
+	first := gen.ULID()
+	now = now.Add(-time.Second)
+	second := gen.ULID()
+
+	if !first.Before(second) {
+		t.Errorf("ULID after clock step back (%s) should sort after %s", second, first)
+	}
+	if !second.Time().Equal(first.Time()) {
+		t.Errorf("ULID.Time() = %v, want previous timestamp %v", second.Time(), first.Time())
+	}
+}
+
+func TestIDGenerator_MonotonicOverflow(t *testing.T) {
+	// Maximal randomness overflows on the next increment
+	full := bytes.Repeat([]byte{0xff}, 10)
+	gen := NewIDGenerator(IDOptions{
+		Monotonic: true,
+		Clock:     fixedClock(time.UnixMilli(1700000000000)),
+		Entropy:   bytes.NewReader(append(append([]byte{}, full...), full...)),
+	})
+
+	first := gen.ULID()
+	second := gen.ULID()
+	if !first.Before(second) {
+		t.Errorf("ULID after overflow (%s) should sort after %s", second, first)
+	}
+	if got := second.Time().UnixMilli(); got != 1700000000001 {
+		t.Errorf("ULID.Time() after overflow = %d, want next millisecond", got)
+	}
+}
+
+func TestIDs_TimeSortable(t *testing.T) {
+	var ulids []ULID
+	var uuids []UUIDv7
+	for i := 0; i < 3; i++ {
+		ulids = append(ulids, NewULID())
+		uuids = append(uuids, NewUUIDv7())
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	if !sort.SliceIsSorted(ulids, func(i, j int) bool { return ulids[i].Before(ulids[j]) }) {
+		t.Errorf("ULIDs are not time-sorted: %v", ulids)
+	}
+	if !sort.SliceIsSorted(uuids, func(i, j int) bool { return uuids[i].Before(uuids[j]) }) {
+		t.Errorf("UUIDv7s are not time-sorted: %v", uuids)
+	}
+	if ulids[0].Compare(ulids[0]) != 0 || ulids[1].Compare(ulids[0]) != 1 {
+		t.Error("ULID.Compare() ordering mismatch")
+	}
+}
+
+func TestIDs_JSONRoundTrip(t *testing.T) {
+	type Record struct {
+		ID    ULID   `json:"id"`
+		RowID UUIDv7 `json:"row_id"`
+	}
+
+	want := Record{ID: NewULID(), RowID: NewUUIDv7()}
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+
+	var got Record
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if got != want {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id":"bogus"}`), &got); err == nil {
+		t.Error("Expected error unmarshaling invalid ULID")
+	}
+	if _, err := json.Marshal(Record{ID: "bogus", RowID: NewUUIDv7()}); err == nil {
+		t.Error("Expected error marshaling invalid ULID")
+	}
+}
+
+func TestIDs_YAMLRoundTrip(t *testing.T) {
+	type Config struct {
+		ID ULID `yaml:"id"`
+	}
+
+	var config Config
+	if err := yaml.Unmarshal([]byte("id: 01aryz6s41tsv4rrffq69g5fav\n"), &config); err != nil {
+		t.Fatalf("yaml.Unmarshal() error: %v", err)
+	}
+	if config.ID != "01ARYZ6S41TSV4RRFFQ69G5FAV" {
+		t.Errorf("yaml.Unmarshal() ID = %q", config.ID)
+	}
+}
+
+func TestIDs_Database(t *testing.T) {
+	ulid := NewULID()
+	var scannedULID ULID
+	if err := scannedULID.Scan([]byte(strings.ToLower(ulid.String()))); err != nil || scannedULID != ulid {
+		t.Errorf("ULID.Scan() = %q, %v; want %q", scannedULID, err, ulid)
+	}
+	if value, err := scannedULID.Value(); err != nil || value != ulid.String() {
+		t.Errorf("ULID.Value() = %v, %v", value, err)
+	}
+
+	v7 := NewUUIDv7()
+	raw := uuid.MustParse(v7.String())
+	var scannedUUID UUIDv7
+	if err := scannedUUID.Scan(raw[:]); err != nil || scannedUUID != v7 {
+		t.Errorf("UUIDv7.Scan(binary) = %q, %v; want %q", scannedUUID, err, v7)
+	}
+	if err := scannedUUID.Scan(v7.String()); err != nil || scannedUUID != v7 {
+		t.Errorf("UUIDv7.Scan(string) = %q, %v; want %q", scannedUUID, err, v7)
+	}
+
+	if err := scannedULID.Scan(nil); err != nil || scannedULID != "" {
+		t.Errorf("ULID.Scan(nil) = %q, %v", scannedULID, err)
+	}
+	if err := scannedUUID.Scan(42); err == nil {
+		t.Error("Expected error scanning int into UUIDv7")
+	}
+	if _, err := UUIDv7("").Value(); err == nil {
+		t.Error("Expected error for empty UUIDv7 Value()")
+	}
+}
@@ -0,0 +1,107 @@
+package foundry
+
+import "strings"
+
+// Language represents an ISO 639 language from the Foundry catalog.
+//
+// The catalog covers every ISO 639-1 language plus a few widely used
+// languages that only have three-letter codes (e.g., "fil", "yue").
+type Language struct {
+	// Alpha2 is the ISO 639-1 two-letter language code (e.g., "en", "de").
+	// Empty for languages without a two-letter code.
+	Alpha2 string
+
+	// Alpha3 is the ISO 639-2/T three-letter language code (e.g., "eng", "deu").
+	Alpha3 string
+
+	// Name is the English name of the language (e.g., "English", "German").
+	Name string
+}
+
+// Code returns the preferred BCP-47 primary language subtag: Alpha2 when the
+// language has one, otherwise Alpha3.
+func (l *Language) Code() string {
+	if l.Alpha2 != "" {
+		return l.Alpha2
+	}
+	return l.Alpha3
+}
+
+// MatchesCode checks if the given code matches this language's Alpha2 or Alpha3 code.
+//
+// Matching is case-insensitive.
+//
+// Example:
+//
+//	language := &Language{Alpha2: "en", Alpha3: "eng"}
+//	if language.MatchesCode("EN") {  // true
+//	    // Matched
+//	}
+func (l *Language) MatchesCode(code string) bool {
+	lowerCode := strings.ToLower(code)
+	return (l.Alpha2 != "" && l.Alpha2 == lowerCode) || l.Alpha3 == lowerCode
+}
+
+// GetLanguage retrieves a language by its ISO 639-1 code from the default catalog.
+//
+// Returns nil if the language is not found or if an error occurs.
+//
+// Example:
+//
+//	language, err := GetLanguage("fr")
+//	if err != nil {
+//	    // Handle error
+//	}
+//	if language != nil {
+//	    fmt.Println(language.Name) // "French"
+//	}
+func GetLanguage(alpha2 string) (*Language, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.GetLanguage(alpha2)
+}
+
+// GetLanguageByAlpha3 retrieves a language by its ISO 639-2/T code from the default catalog.
+//
+// Returns nil if the language is not found or if an error occurs.
+//
+// Example:
+//
+//	language, err := GetLanguageByAlpha3("fil") // Filipino
+func GetLanguageByAlpha3(alpha3 string) (*Language, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.GetLanguageByAlpha3(alpha3)
+}
+
+// ValidateLanguageCode checks if the given BCP-47 language tag is valid.
+//
+// Plain ISO 639 codes ("en", "eng") are valid tags. The primary language
+// subtag must be in the catalog; script, region, variant, extension, and
+// private-use subtags are checked for well-formedness. Underscores are
+// accepted as separators ("en_US").
+//
+// Example:
+//
+//	ValidateLanguageCode("en")         // true
+//	ValidateLanguageCode("pt-BR")      // true
+//	ValidateLanguageCode("zh-Hant-TW") // true
+//	ValidateLanguageCode("xx-US")      // false (unknown language)
+func ValidateLanguageCode(code string) bool {
+	_, err := parseLanguageTag(code)
+	return err == nil
+}
+
+// ListLanguages returns all languages from the default catalog, sorted by Alpha3 code.
+//
+// Example:
+//
+//	languages, err := ListLanguages()
+//	if err != nil {
+//	    // Handle error
+//	}
+//	for _, language := range languages {
+//	    fmt.Printf("%s: %s\n", language.Code(), language.Name)
+//	}
+func ListLanguages() ([]*Language, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.ListLanguages()
+}
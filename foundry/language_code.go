@@ -0,0 +1,345 @@
+package foundry
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// LanguageCode is a validated BCP-47 language tag (RFC 5646).
+//
+// Accepts plain ISO 639 codes ("en", "eng") and full tags with script,
+// region, variant, extension, and private-use subtags ("zh-Hant-TW",
+// "en-US", "sl-rozaj", "de-DE-u-co-phonebk"). Tags are canonicalized:
+// the primary subtag uses the ISO 639-1 code when one exists, scripts are
+// title case, regions are uppercase, everything else is lowercase, and "_"
+// separators become "-". Implements standard Go interfaces for seamless
+// integration with JSON, YAML, TOML, and SQL databases.
+//
+// Only the primary language subtag is checked against the catalog; other
+// subtags are checked for well-formedness. Extended language subtags and
+// grandfathered tags are not supported.
+//
+// The zero value is an invalid language code. Use NewLanguageCode or
+// MustLanguageCode to create valid instances.
+//
+// Example:
+//
+//	type Profile struct {
+//	    Name   string       `json:"name"`
+//	    Locale LanguageCode `json:"locale" db:"locale"`
+//	}
+//
+//	profile := Profile{Name: "Alice", Locale: MustLanguageCode("en_us")}
+//	json.Marshal(profile) // {"name":"Alice","locale":"en-US"}
+type LanguageCode string
+
+// languageTag is a parsed BCP-47 language tag.
+type languageTag struct {
+	language *Language
+	base     string
+	script   string
+	region   string
+	rest     []string // variants, extensions, and private use, lowercase
+}
+
+// String returns the canonical form of the tag.
+func (t languageTag) String() string {
+	parts := []string{t.base}
+	if t.script != "" {
+		parts = append(parts, t.script)
+	}
+	if t.region != "" {
+		parts = append(parts, t.region)
+	}
+	parts = append(parts, t.rest...)
+	return strings.Join(parts, "-")
+}
+
+// NewLanguageCode creates a validated, canonicalized LanguageCode.
+//
+// Returns an error if the tag is malformed or its primary language is not
+// in the catalog.
+//
+// Example:
+//
+//	code, err := NewLanguageCode("en")          // → "en"
+//	code, err := NewLanguageCode("eng")         // → "en" (shortest ISO 639 code)
+//	code, err := NewLanguageCode("pt_br")       // → "pt-BR"
+//	code, err := NewLanguageCode("ZH-hant-tw")  // → "zh-Hant-TW"
+//	code, err := NewLanguageCode("es-419")      // → "es-419" (UN M.49 region)
+func NewLanguageCode(code string) (LanguageCode, error) {
+	tag, err := parseLanguageTag(code)
+	if err != nil {
+		return "", err
+	}
+	return LanguageCode(tag.String()), nil
+}
+
+// MustLanguageCode creates a LanguageCode or panics if invalid.
+//
+// Use this for package-level defaults or when the tag is known to be valid.
+//
+// Example:
+//
+//	var DefaultLocale = MustLanguageCode("en-US")
+func MustLanguageCode(code string) LanguageCode {
+	c, err := NewLanguageCode(code)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// String returns the language tag as a string.
+func (c LanguageCode) String() string {
+	return string(c)
+}
+
+// Validate checks if the language tag is valid.
+//
+// Returns an error if the tag is malformed or its primary language is not
+// a recognized ISO 639 code.
+func (c LanguageCode) Validate() error {
+	if c == "" {
+		return fmt.Errorf("language code is empty")
+	}
+	_, err := parseLanguageTag(string(c))
+	return err
+}
+
+// IsValid returns true if the language tag is valid.
+func (c LanguageCode) IsValid() bool {
+	return c.Validate() == nil
+}
+
+// Language retrieves the Language metadata for the tag's primary language
+// subtag from the catalog.
+//
+// Returns an error if the tag is invalid or the catalog cannot be loaded.
+//
+// Example:
+//
+//	code := MustLanguageCode("pt-BR")
+//	language, err := code.Language()
+//	if err == nil {
+//	    fmt.Println(language.Name) // "Portuguese"
+//	}
+func (c LanguageCode) Language() (*Language, error) {
+	tag, err := parseLanguageTag(string(c))
+	if err != nil {
+		return nil, err
+	}
+	return tag.language, nil
+}
+
+// Base returns the primary language subtag (e.g., "pt" for "pt-BR"), or ""
+// if the tag is invalid.
+func (c LanguageCode) Base() string {
+	tag, err := parseLanguageTag(string(c))
+	if err != nil {
+		return ""
+	}
+	return tag.base
+}
+
+// Script returns the script subtag (e.g., "Hant" for "zh-Hant-TW"), or "" if
+// the tag has none or is invalid.
+func (c LanguageCode) Script() string {
+	tag, err := parseLanguageTag(string(c))
+	if err != nil {
+		return ""
+	}
+	return tag.script
+}
+
+// Region returns the region subtag (e.g., "BR" for "pt-BR", "419" for
+// "es-419"), or "" if the tag has none or is invalid. Alpha-2 regions are
+// ISO 3166-1 codes and can be passed to NewCountryCode.
+func (c LanguageCode) Region() string {
+	tag, err := parseLanguageTag(string(c))
+	if err != nil {
+		return ""
+	}
+	return tag.region
+}
+
+// MarshalText implements encoding.TextMarshaler for JSON, YAML, TOML support.
+//
+// The language tag is marshaled as-is (canonical form).
+func (c LanguageCode) MarshalText() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(c), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for JSON, YAML, TOML support.
+//
+// Validates and canonicalizes the language tag on unmarshal.
+func (c *LanguageCode) UnmarshalText(text []byte) error {
+	code, err := NewLanguageCode(string(text))
+	if err != nil {
+		return err
+	}
+	*c = code
+	return nil
+}
+
+// Value implements database/sql/driver.Valuer for database integration.
+//
+// The language tag is stored as a string (VARCHAR/TEXT column).
+func (c LanguageCode) Value() (driver.Value, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return string(c), nil
+}
+
+// Scan implements database/sql.Scanner for database integration.
+//
+// Reads language tags from VARCHAR/TEXT columns with validation.
+func (c *LanguageCode) Scan(src interface{}) error {
+	if src == nil {
+		*c = ""
+		return nil
+	}
+
+	var code string
+	switch v := src.(type) {
+	case string:
+		code = v
+	case []byte:
+		code = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into LanguageCode", src)
+	}
+
+	parsed, err := NewLanguageCode(code)
+	if err != nil {
+		return err
+	}
+
+	*c = parsed
+	return nil
+}
+
+// parseLanguageTag parses and canonicalizes a BCP-47 language tag:
+//
+//	language ["-" script] ["-" region] *("-" variant) *("-" extension) ["-" privateuse]
+func parseLanguageTag(code string) (languageTag, error) {
+	var tag languageTag
+	if code == "" {
+		return tag, fmt.Errorf("language code cannot be empty")
+	}
+
+	parts := strings.Split(strings.ReplaceAll(code, "_", "-"), "-")
+	for i, part := range parts {
+		if part == "" || len(part) > 8 || !isAlphanumeric(part) {
+			return tag, fmt.Errorf("malformed language tag: %s", code)
+		}
+		parts[i] = strings.ToLower(part)
+	}
+
+	// Primary language subtag
+	base := parts[0]
+	if (len(base) != 2 && len(base) != 3) || !isAlpha(base) {
+		return tag, fmt.Errorf("invalid language subtag %q in language tag: %s", base, code)
+	}
+	var language *Language
+	var err error
+	if len(base) == 2 {
+		language, err = GetLanguage(base)
+	} else {
+		language, err = GetLanguageByAlpha3(base)
+	}
+	if err != nil {
+		return tag, err
+	}
+	if language == nil {
+		return tag, fmt.Errorf("unknown language %q in language tag: %s", base, code)
+	}
+	tag.language = language
+	tag.base = language.Code()
+
+	i := 1
+
+	// Script: 4 letters, title case
+	if i < len(parts) && len(parts[i]) == 4 && isAlpha(parts[i]) {
+		tag.script = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		i++
+	}
+
+	// Region: ISO 3166-1 alpha-2 or UN M.49 numeric
+	if i < len(parts) && ((len(parts[i]) == 2 && isAlpha(parts[i])) || (len(parts[i]) == 3 && isNumericCode(parts[i]))) {
+		tag.region = strings.ToUpper(parts[i])
+		i++
+	}
+
+	// Variants: 5-8 alphanumerics, or a digit followed by 3 alphanumerics
+	seen := make(map[string]bool)
+	for i < len(parts) && (len(parts[i]) >= 5 || (len(parts[i]) == 4 && parts[i][0] >= '0' && parts[i][0] <= '9')) {
+		if seen[parts[i]] {
+			return tag, fmt.Errorf("duplicate variant %q in language tag: %s", parts[i], code)
+		}
+		seen[parts[i]] = true
+		tag.rest = append(tag.rest, parts[i])
+		i++
+	}
+
+	// Extensions: a singleton other than "x" followed by 2-8 character subtags
+	singletons := make(map[string]bool)
+	for i < len(parts) && len(parts[i]) == 1 && parts[i] != "x" {
+		singleton := parts[i]
+		if singletons[singleton] {
+			return tag, fmt.Errorf("duplicate extension %q in language tag: %s", singleton, code)
+		}
+		singletons[singleton] = true
+		tag.rest = append(tag.rest, singleton)
+		i++
+
+		start := i
+		for i < len(parts) && len(parts[i]) >= 2 {
+			tag.rest = append(tag.rest, parts[i])
+			i++
+		}
+		if i == start {
+			return tag, fmt.Errorf("empty extension %q in language tag: %s", singleton, code)
+		}
+	}
+
+	// Private use: "x" followed by 1-8 character subtags
+	if i < len(parts) && parts[i] == "x" {
+		if i == len(parts)-1 {
+			return tag, fmt.Errorf("empty private use subtag in language tag: %s", code)
+		}
+		tag.rest = append(tag.rest, parts[i:]...)
+		i = len(parts)
+	}
+
+	if i < len(parts) {
+		return tag, fmt.Errorf("invalid subtag %q in language tag: %s", parts[i], code)
+	}
+
+	return tag, nil
+}
+
+// isAlpha checks if a string contains only ASCII letters.
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isAlphanumeric checks if a string contains only ASCII letters and digits.
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
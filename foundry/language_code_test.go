@@ -0,0 +1,217 @@
+package foundry
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestNewLanguageCode tests canonicalization of BCP-47 tags
+func TestNewLanguageCode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"en", "en"},
+		{"EN", "en"},
+		{"eng", "en"}, // shortest ISO 639 code
+		{"en-us", "en-US"},
+		{"pt_BR", "pt-BR"},
+		{"ZH-hant-tw", "zh-Hant-TW"},
+		{"es-419", "es-419"},
+		{"SL-Rozaj", "sl-rozaj"},
+		{"de-DE-U-CO-PHONEBK", "de-DE-u-co-phonebk"},
+		{"en-x-Custom", "en-x-custom"},
+		{"fil-ph", "fil-PH"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			code, err := NewLanguageCode(tt.input)
+			if err != nil {
+				t.Fatalf("NewLanguageCode(%q) returned error: %v", tt.input, err)
+			}
+
+			if string(code) != tt.expected {
+				t.Errorf("NewLanguageCode(%q) = %q, want %q", tt.input, code, tt.expected)
+			}
+
+			if !code.IsValid() {
+				t.Errorf("Expected code %q to be valid", code)
+			}
+		})
+	}
+}
+
+// TestNewLanguageCode_Invalid tests rejection of malformed tags and unknown languages
+func TestNewLanguageCode_Invalid(t *testing.T) {
+	for _, input := range []string{"", "xx", "xx-US", "en-", "en-US-GB", "en-u", "x-private", "en US"} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := NewLanguageCode(input); err == nil {
+				t.Errorf("NewLanguageCode(%q) expected error", input)
+			}
+		})
+	}
+}
+
+// TestMustLanguageCode_Panic tests that MustLanguageCode panics on invalid input
+func TestMustLanguageCode_Panic(t *testing.T) {
+	if code := MustLanguageCode("en_gb"); code != "en-GB" {
+		t.Errorf("MustLanguageCode(\"en_gb\") = %q, want \"en-GB\"", code)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected MustLanguageCode to panic on invalid tag")
+		}
+	}()
+	MustLanguageCode("xx")
+}
+
+// TestLanguageCode_Subtags tests Base, Script, Region, and Language accessors
+func TestLanguageCode_Subtags(t *testing.T) {
+	tests := []struct {
+		code   LanguageCode
+		base   string
+		script string
+		region string
+		name   string
+	}{
+		{"en", "en", "", "", "English"},
+		{"pt-BR", "pt", "", "BR", "Portuguese"},
+		{"zh-Hant-TW", "zh", "Hant", "TW", "Chinese"},
+		{"sr-Latn", "sr", "Latn", "", "Serbian"},
+		{"es-419", "es", "", "419", "Spanish"},
+		{"yue-HK", "yue", "", "HK", "Cantonese"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			if got := tt.code.Base(); got != tt.base {
+				t.Errorf("Base() = %q, want %q", got, tt.base)
+			}
+			if got := tt.code.Script(); got != tt.script {
+				t.Errorf("Script() = %q, want %q", got, tt.script)
+			}
+			if got := tt.code.Region(); got != tt.region {
+				t.Errorf("Region() = %q, want %q", got, tt.region)
+			}
+
+			language, err := tt.code.Language()
+			if err != nil {
+				t.Fatalf("Language() error: %v", err)
+			}
+			if language.Name != tt.name {
+				t.Errorf("Language().Name = %q, want %q", language.Name, tt.name)
+			}
+		})
+	}
+
+	invalid := LanguageCode("xx-US")
+	if invalid.Base() != "" || invalid.Region() != "" {
+		t.Error("Expected empty subtags for invalid tag")
+	}
+	if _, err := invalid.Language(); err == nil {
+		t.Error("Expected error from Language() for invalid tag")
+	}
+}
+
+// TestLanguageCode_JSONRoundTrip tests JSON marshaling and unmarshaling
+func TestLanguageCode_JSONRoundTrip(t *testing.T) {
+	type Profile struct {
+		Name   string       `json:"name"`
+		Locale LanguageCode `json:"locale"`
+	}
+
+	data, err := json.Marshal(Profile{Name: "Alice", Locale: MustLanguageCode("en_us")})
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if string(data) != `{"name":"Alice","locale":"en-US"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var profile Profile
+	if err := json.Unmarshal([]byte(`{"name":"Bob","locale":"zh-hant"}`), &profile); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if profile.Locale != "zh-Hant" {
+		t.Errorf("json.Unmarshal() Locale = %q, want \"zh-Hant\"", profile.Locale)
+	}
+
+	if err := json.Unmarshal([]byte(`{"locale":"xx"}`), &profile); err == nil {
+		t.Error("Expected error unmarshaling invalid language tag")
+	}
+
+	if _, err := json.Marshal(Profile{Locale: "xx"}); err == nil {
+		t.Error("Expected error marshaling invalid language tag")
+	}
+}
+
+// TestLanguageCode_YAMLRoundTrip tests YAML marshaling and unmarshaling
+func TestLanguageCode_YAMLRoundTrip(t *testing.T) {
+	type Config struct {
+		Locale LanguageCode `yaml:"locale"`
+	}
+
+	data, err := yaml.Marshal(Config{Locale: "fr-CA"})
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error: %v", err)
+	}
+	if !containsString(string(data), "locale: fr-CA") {
+		t.Errorf("yaml.Marshal() = %q", data)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal([]byte("locale: de_at\n"), &config); err != nil {
+		t.Fatalf("yaml.Unmarshal() error: %v", err)
+	}
+	if config.Locale != "de-AT" {
+		t.Errorf("yaml.Unmarshal() Locale = %q, want \"de-AT\"", config.Locale)
+	}
+}
+
+// TestLanguageCode_Database tests database/sql Value and Scan
+func TestLanguageCode_Database(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected LanguageCode
+		wantErr  bool
+	}{
+		{"String", "en-US", "en-US", false},
+		{"Bytes", []byte("ja_jp"), "ja-JP", false},
+		{"Nil", nil, "", false},
+		{"Invalid_String", "xx", "", true},
+		{"Invalid_Type", 42, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var code LanguageCode
+			err := code.Scan(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LanguageCode.Scan(%v) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr || code == "" {
+				return
+			}
+			if code != tt.expected {
+				t.Errorf("LanguageCode.Scan(%v) = %q, want %q", tt.input, code, tt.expected)
+			}
+
+			value, err := code.Value()
+			if err != nil {
+				t.Fatalf("Value() error: %v", err)
+			}
+			if value != string(tt.expected) {
+				t.Errorf("Value() = %v, want %q", value, tt.expected)
+			}
+		})
+	}
+
+	if _, err := LanguageCode("").Value(); err == nil {
+		t.Error("Expected error from Value() on empty language code")
+	}
+}
@@ -0,0 +1,157 @@
+package foundry
+
+import (
+	"testing"
+)
+
+func TestGetLanguage(t *testing.T) {
+	tests := []struct {
+		alpha2 string
+		alpha3 string
+		name   string
+	}{
+		{"en", "eng", "English"},
+		{"DE", "deu", "German"},
+		{"zh", "zho", "Chinese"},
+		{"nb", "nob", "Norwegian Bokmål"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alpha2, func(t *testing.T) {
+			language, err := GetLanguage(tt.alpha2)
+			if err != nil {
+				t.Fatalf("Failed to get language: %v", err)
+			}
+
+			if language == nil {
+				t.Fatalf("Expected non-nil language for %q", tt.alpha2)
+			}
+
+			if language.Alpha3 != tt.alpha3 {
+				t.Errorf("Expected Alpha3 %q, got %q", tt.alpha3, language.Alpha3)
+			}
+			if language.Name != tt.name {
+				t.Errorf("Expected name %q, got %q", tt.name, language.Name)
+			}
+		})
+	}
+}
+
+func TestGetLanguage_NotFound(t *testing.T) {
+	language, err := GetLanguage("xx")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if language != nil {
+		t.Error("Expected nil language for non-existent code")
+	}
+}
+
+func TestGetLanguageByAlpha3(t *testing.T) {
+	tests := []struct {
+		alpha3 string
+		code   string
+	}{
+		{"eng", "en"},
+		{"FRA", "fr"},
+		{"fil", "fil"}, // no ISO 639-1 code
+		{"yue", "yue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alpha3, func(t *testing.T) {
+			language, err := GetLanguageByAlpha3(tt.alpha3)
+			if err != nil {
+				t.Fatalf("Failed to get language: %v", err)
+			}
+
+			if language == nil || language.Code() != tt.code {
+				t.Fatalf("GetLanguageByAlpha3(%q) = %v, want code %q", tt.alpha3, language, tt.code)
+			}
+		})
+	}
+}
+
+func TestValidateLanguageCode(t *testing.T) {
+	tests := []struct {
+		code  string
+		valid bool
+	}{
+		{"en", true},
+		{"eng", true},
+		{"en-US", true},
+		{"en_us", true},
+		{"zh-Hant-TW", true},
+		{"es-419", true},
+		{"sl-rozaj-biske", true},
+		{"de-CH-1901", true},
+		{"de-DE-u-co-phonebk", true},
+		{"en-a-bbb-x-a-ccc", true},
+		{"fil-PH", true},
+		{"", false},
+		{"xx", false},
+		{"e", false},
+		{"english", false},
+		{"en-", false},
+		{"en--US", false},
+		{"en-US-US", false},
+		{"en-u", false},
+		{"en-u-foo-u-bar", false},
+		{"en-x", false},
+		{"de-1901-1901", false},
+		{"en-US!", false},
+		{"i-klingon", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := ValidateLanguageCode(tt.code); got != tt.valid {
+				t.Errorf("ValidateLanguageCode(%q) = %v, want %v", tt.code, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestListLanguages(t *testing.T) {
+	languages, err := ListLanguages()
+	if err != nil {
+		t.Fatalf("Failed to list languages: %v", err)
+	}
+
+	alpha2Count := 0
+	for i, language := range languages {
+		if len(language.Alpha3) != 3 || language.Name == "" {
+			t.Errorf("Incomplete language entry: %+v", language)
+		}
+		if i > 0 && languages[i-1].Alpha3 >= language.Alpha3 {
+			t.Errorf("Languages not sorted: %s before %s", languages[i-1].Alpha3, language.Alpha3)
+		}
+		if language.Alpha2 != "" {
+			alpha2Count++
+		}
+	}
+
+	// Every ISO 639-1 code is present
+	if alpha2Count != 183 {
+		t.Errorf("Expected 183 ISO 639-1 languages, got %d", alpha2Count)
+	}
+}
+
+func TestLanguage_MatchesCode(t *testing.T) {
+	language := &Language{Alpha2: "en", Alpha3: "eng", Name: "English"}
+
+	for _, code := range []string{"en", "EN", "eng", "Eng"} {
+		if !language.MatchesCode(code) {
+			t.Errorf("Expected %q to match", code)
+		}
+	}
+	if language.MatchesCode("fr") {
+		t.Error("Expected \"fr\" not to match")
+	}
+
+	alpha3Only := &Language{Alpha3: "fil", Name: "Filipino"}
+	if alpha3Only.MatchesCode("") {
+		t.Error("Expected empty code not to match a language without Alpha2")
+	}
+}
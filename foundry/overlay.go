@@ -0,0 +1,184 @@
+package foundry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/crucible"
+	"gopkg.in/yaml.v3"
+)
+
+// overlayDataset describes a catalog file that overlays may extend.
+type overlayDataset struct {
+	schemaPath string // Crucible schema the overlay file must satisfy ("" for a plain string list)
+	listKey    string // top-level key holding the entries
+	idKey      string // entry field identifying an entry ("" when entries are strings)
+}
+
+// overlayDatasets lists the catalog files that support overlays, keyed by
+// the file name an overlay provides.
+var overlayDatasets = map[string]overlayDataset{
+	"patterns.yaml":      {schemaPath: "library/foundry/v1.0.0/patterns.schema.json", listKey: "patterns", idKey: "id"},
+	"mime-types.yaml":    {schemaPath: "library/foundry/v1.0.0/mime-types.schema.json", listKey: "types", idKey: "id"},
+	"country-codes.yaml": {schemaPath: "library/foundry/v1.0.0/country-codes.schema.json", listKey: "countries", idKey: "alpha2"},
+	"acronyms.yaml":      {listKey: "acronyms"},
+}
+
+// WithOverlay returns a new catalog that layers the catalog files in fsys
+// over this catalog's data.
+//
+// An overlay may provide patterns.yaml, mime-types.yaml, and
+// country-codes.yaml in the same format as the embedded Crucible files, and
+// acronyms.yaml (a list of strings under "acronyms"); missing files are
+// skipped. Each file is validated against its Crucible schema when the
+// dataset is first loaded, and a dataset with an invalid overlay fails to
+// load (use Load to check eagerly).
+//
+// Precedence: entries are matched by ID (pattern id, MIME type id, country
+// alpha2, case-insensitive acronym). A matching entry replaces the embedded
+// entry wholesale; new entries are added. Later overlays take precedence over
+// earlier ones.
+//
+// Example:
+//
+//	// /etc/myapp/foundry/mime-types.yaml:
+//	//   version: v1.0.0
+//	//   types:
+//	//     - id: acme-report
+//	//       mime: application/vnd.acme.report+json
+//	//       name: Acme Report
+//	//       extensions: [acmr]
+//	catalog := foundry.NewCatalog().WithOverlayDir("/etc/myapp/foundry")
+//	mimeType, _ := catalog.GetMimeTypeByExtension(".acmr")
+func (c *Catalog) WithOverlay(fsys fs.FS) *Catalog {
+	overlays := make([]fs.FS, 0, len(c.overlays)+1)
+	overlays = append(overlays, c.overlays...)
+	return &Catalog{overlays: append(overlays, fsys)}
+}
+
+// WithOverlayDir returns a new catalog with the catalog files in dir layered
+// over this catalog's data (see WithOverlay).
+func (c *Catalog) WithOverlayDir(dir string) *Catalog {
+	return c.WithOverlay(os.DirFS(dir))
+}
+
+// Load eagerly loads the datasets that support overlays (patterns, MIME
+// types, countries, and acronyms), returning the first load or overlay
+// validation error. Other datasets still load lazily.
+func (c *Catalog) Load() error {
+	for _, load := range []func() error{c.loadPatterns, c.loadMimeTypes, c.loadCountries, c.loadAcronyms} {
+		if err := load(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyOverlays merges each overlay's copy of filename into data, in order.
+func (c *Catalog) applyOverlays(filename string, data map[string]interface{}) error {
+	dataset, ok := overlayDatasets[filename]
+	if !ok || len(c.overlays) == 0 {
+		return nil
+	}
+
+	for i, fsys := range c.overlays {
+		raw, err := fs.ReadFile(fsys, filename)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read overlay %d %s: %w", i, filename, err)
+		}
+
+		entries, err := parseOverlay(dataset, raw)
+		if err != nil {
+			return fmt.Errorf("invalid overlay %d %s: %w", i, filename, err)
+		}
+
+		base, _ := data[dataset.listKey].([]interface{})
+		data[dataset.listKey] = mergeOverlayEntries(base, entries, dataset.idKey)
+	}
+	return nil
+}
+
+// parseOverlay parses an overlay file and validates it against the dataset schema.
+func parseOverlay(dataset overlayDataset, raw []byte) ([]interface{}, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	if dataset.schemaPath == "" {
+		entries, ok := doc[dataset.listKey].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list", dataset.listKey)
+		}
+		for i, entry := range entries {
+			if s, ok := entry.(string); !ok || s == "" {
+				return nil, fmt.Errorf("%s[%d] must be a non-empty string", dataset.listKey, i)
+			}
+		}
+		return entries, nil
+	}
+
+	schemaData, err := crucible.GetSchema(dataset.schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema %s: %w", dataset.schemaPath, err)
+	}
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert overlay to JSON: %w", err)
+	}
+	if err := crucible.ValidateAgainstSchema(schemaData, jsonData); err != nil {
+		return nil, err
+	}
+
+	entries, _ := doc[dataset.listKey].([]interface{})
+	return entries, nil
+}
+
+// mergeOverlayEntries replaces base entries whose idKey matches an overlay
+// entry and appends the rest, preserving base order.
+func mergeOverlayEntries(base, overlay []interface{}, idKey string) []interface{} {
+	merged := make([]interface{}, len(base), len(base)+len(overlay))
+	copy(merged, base)
+
+	index := make(map[string]int, len(merged))
+	for i, entry := range merged {
+		if id, ok := overlayEntryID(entry, idKey); ok {
+			index[id] = i
+		}
+	}
+
+	for _, entry := range overlay {
+		id, ok := overlayEntryID(entry, idKey)
+		if !ok {
+			continue
+		}
+		if i, exists := index[id]; exists {
+			merged[i] = entry
+			continue
+		}
+		index[id] = len(merged)
+		merged = append(merged, entry)
+	}
+	return merged
+}
+
+// overlayEntryID returns an entry's identifying field, or the upper-cased
+// entry itself for string lists.
+func overlayEntryID(entry interface{}, idKey string) (string, bool) {
+	if s, ok := entry.(string); ok && idKey == "" {
+		return strings.ToUpper(s), s != ""
+	}
+	fields, ok := entry.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	id, ok := fields[idKey].(string)
+	return id, ok && id != ""
+}
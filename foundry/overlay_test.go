@@ -0,0 +1,202 @@
+package foundry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+const overlayMimeTypes = `version: v1.0.0
+types:
+  - id: acme-report
+    mime: application/vnd.acme.report+json
+    name: Acme Report
+    extensions: [acmr, json]
+  - id: csv
+    mime: text/csv
+    name: Comma-Separated Values (overridden)
+    extensions: [csv]
+`
+
+func TestCatalog_WithOverlay_MimeTypes(t *testing.T) {
+	base := NewCatalog()
+	catalog := base.WithOverlay(fstest.MapFS{
+		"mime-types.yaml": {Data: []byte(overlayMimeTypes)},
+	})
+
+	added, err := catalog.GetMimeType("acme-report")
+	if err != nil {
+		t.Fatalf("GetMimeType failed: %v", err)
+	}
+	if added == nil || added.Mime != "application/vnd.acme.report+json" {
+		t.Fatalf("Expected overlay MIME type, got %v", added)
+	}
+
+	byExt, _ := catalog.GetMimeTypeByExtension(".acmr")
+	if byExt != added {
+		t.Errorf("Expected .acmr to resolve to overlay type, got %v", byExt)
+	}
+
+	// Overlay entries win extension conflicts
+	byExt, _ = catalog.GetMimeTypeByExtension("json")
+	if byExt != added {
+		t.Errorf("Expected json extension to resolve to overlay type, got %v", byExt)
+	}
+
+	replaced, _ := catalog.GetMimeType("csv")
+	if replaced == nil || replaced.Name != "Comma-Separated Values (overridden)" {
+		t.Errorf("Expected csv to be replaced, got %v", replaced)
+	}
+
+	// Embedded entries not named in the overlay are kept
+	if yamlType, _ := catalog.GetMimeType("yaml"); yamlType == nil {
+		t.Error("Expected embedded yaml MIME type to remain")
+	}
+
+	// The base catalog is unchanged
+	if baseType, _ := base.GetMimeType("acme-report"); baseType != nil {
+		t.Error("Expected base catalog to be unaffected by overlay")
+	}
+}
+
+func TestCatalog_WithOverlay_Countries(t *testing.T) {
+	catalog := NewCatalog().WithOverlay(fstest.MapFS{
+		"country-codes.yaml": {Data: []byte(`version: v1.0.0
+countries:
+  - alpha2: FR
+    alpha3: FRA
+    numeric: '250'
+    name: France
+`)},
+	})
+
+	country, err := catalog.GetCountry("fr")
+	if err != nil {
+		t.Fatalf("GetCountry failed: %v", err)
+	}
+	if country == nil || country.Name != "France" {
+		t.Fatalf("Expected overlay country, got %v", country)
+	}
+	if byNumeric, _ := catalog.GetCountryByNumeric("250"); byNumeric != country {
+		t.Errorf("Expected numeric index to include overlay country, got %v", byNumeric)
+	}
+	if us, _ := catalog.GetCountry("US"); us == nil {
+		t.Error("Expected embedded US country to remain")
+	}
+}
+
+func TestCatalog_WithOverlay_Patterns(t *testing.T) {
+	catalog := NewCatalog().WithOverlay(fstest.MapFS{
+		"patterns.yaml": {Data: []byte(`version: v1.0.0
+patterns:
+  - id: acme-ticket
+    name: Acme Ticket
+    kind: regex
+    pattern: '^ACME-[0-9]+$'
+`)},
+	})
+
+	pattern, err := catalog.GetPattern("acme-ticket")
+	if err != nil {
+		t.Fatalf("GetPattern failed: %v", err)
+	}
+	if pattern == nil || !pattern.MustMatch("ACME-42") || pattern.MustMatch("acme") {
+		t.Errorf("Expected overlay pattern to match ACME tickets, got %v", pattern)
+	}
+}
+
+func TestCatalog_WithOverlay_Precedence(t *testing.T) {
+	first := fstest.MapFS{"mime-types.yaml": {Data: []byte(`version: v1.0.0
+types:
+  - id: acme-report
+    mime: application/vnd.acme.report+json
+    name: First
+`)}}
+	second := fstest.MapFS{"mime-types.yaml": {Data: []byte(`version: v1.0.0
+types:
+  - id: acme-report
+    mime: application/vnd.acme.report+json
+    name: Second
+`)}}
+
+	catalog := NewCatalog().WithOverlay(first).WithOverlay(second)
+	mimeType, err := catalog.GetMimeType("acme-report")
+	if err != nil {
+		t.Fatalf("GetMimeType failed: %v", err)
+	}
+	if mimeType == nil || mimeType.Name != "Second" {
+		t.Errorf("Expected later overlay to win, got %v", mimeType)
+	}
+}
+
+func TestCatalog_WithOverlay_Invalid(t *testing.T) {
+	catalog := NewCatalog().WithOverlay(fstest.MapFS{
+		"country-codes.yaml": {Data: []byte(`version: v1.0.0
+countries:
+  - alpha2: france
+    name: France
+`)},
+	})
+
+	if _, err := catalog.GetCountry("US"); err == nil {
+		t.Error("Expected schema validation error for invalid overlay")
+	}
+	if err := catalog.Load(); err == nil {
+		t.Error("Expected Load to report invalid overlay")
+	}
+
+	malformed := NewCatalog().WithOverlay(fstest.MapFS{
+		"mime-types.yaml": {Data: []byte("types: [")},
+	})
+	if err := malformed.Load(); err == nil {
+		t.Error("Expected Load to report malformed overlay YAML")
+	}
+}
+
+func TestCatalog_WithOverlay_MissingFiles(t *testing.T) {
+	catalog := NewCatalog().WithOverlay(fstest.MapFS{})
+	if err := catalog.Load(); err != nil {
+		t.Fatalf("Expected empty overlay to load, got %v", err)
+	}
+	if country, _ := catalog.GetCountry("US"); country == nil {
+		t.Error("Expected embedded data with empty overlay")
+	}
+}
+
+func TestCatalog_WithOverlayDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "mime-types.yaml"), []byte(overlayMimeTypes), 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	catalog := NewCatalog().WithOverlayDir(dir)
+	if err := catalog.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if mimeType, _ := catalog.GetMimeTypeByExtension("acmr"); mimeType == nil {
+		t.Error("Expected overlay MIME type from directory")
+	}
+}
+
+func TestSetDefaultCatalog(t *testing.T) {
+	original := GetDefaultCatalog()
+	t.Cleanup(func() { SetDefaultCatalog(original) })
+
+	catalog := NewCatalog().WithOverlay(fstest.MapFS{
+		"mime-types.yaml": {Data: []byte(overlayMimeTypes)},
+	})
+	SetDefaultCatalog(catalog)
+
+	if GetDefaultCatalog() != catalog {
+		t.Fatal("Expected default catalog to be replaced")
+	}
+	if mimeType, _ := GetMimeTypeByExtension("acmr"); mimeType == nil {
+		t.Error("Expected package-level lookup to use the replaced catalog")
+	}
+
+	SetDefaultCatalog(nil)
+	if mimeType, _ := GetMimeTypeByExtension("acmr"); mimeType != nil {
+		t.Error("Expected SetDefaultCatalog(nil) to restore embedded data only")
+	}
+}
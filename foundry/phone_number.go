@@ -0,0 +1,292 @@
+package foundry
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// PhoneNumber is a validated ITU-T E.164 telephone number (e.g., "+14155552671").
+//
+// Numbers are canonicalized to "+" followed by digits only. When the calling
+// code is in the catalog, the national significant number must match one of
+// the country formats for that code (see CountryFormat); numbers with other
+// calling codes are checked for E.164 structure only. Implements standard Go
+// interfaces for seamless integration with JSON, YAML, TOML, and SQL
+// databases.
+//
+// The zero value is an invalid phone number. Use NewPhoneNumber,
+// NewPhoneNumberForCountry, or MustPhoneNumber to create valid instances.
+//
+// Example:
+//
+//	type Contact struct {
+//	    Name  string      `json:"name"`
+//	    Phone PhoneNumber `json:"phone" db:"phone"`
+//	}
+//
+//	contact := Contact{Name: "Alice", Phone: MustPhoneNumber("+1 (202) 555-0143")}
+//	json.Marshal(contact) // {"name":"Alice","phone":"+12025550143"}
+type PhoneNumber string
+
+// phoneParts is a parsed E.164 number.
+type phoneParts struct {
+	callingCode string           // empty if not in the catalog
+	national    string           // national significant number
+	formats     []*CountryFormat // country formats the number matches
+}
+
+// NewPhoneNumber creates a validated PhoneNumber from E.164 input.
+//
+// Spaces, dashes, dots, slashes, and parentheses are ignored. Returns an
+// error if the number is not in international form or does not match the
+// catalog formats for its calling code.
+//
+// Example:
+//
+//	phone, err := NewPhoneNumber("+44 20 7183 8750") // → "+442071838750"
+//	phone, err := NewPhoneNumber("020 7183 8750")    // error: national form (use NewPhoneNumberForCountry)
+func NewPhoneNumber(number string) (PhoneNumber, error) {
+	canonical, _, err := parsePhoneNumber(number)
+	if err != nil {
+		return "", err
+	}
+	return PhoneNumber(canonical), nil
+}
+
+// NewPhoneNumberForCountry creates a validated PhoneNumber from a number
+// dialed within country, removing the country's trunk prefix (e.g., the
+// leading "0" in GB) and adding its calling code. International input
+// ("+...") is accepted if it is a valid number for the country.
+//
+// Example:
+//
+//	phone, err := NewPhoneNumberForCountry("020 7183 8750", "GB")  // → "+442071838750"
+//	phone, err := NewPhoneNumberForCountry("(202) 555-0143", "US") // → "+12025550143"
+func NewPhoneNumberForCountry(number string, country CountryCode) (PhoneNumber, error) {
+	if number == "" {
+		return "", fmt.Errorf("phone number cannot be empty")
+	}
+
+	format, err := GetCountryFormat(country)
+	if err != nil {
+		return "", err
+	}
+	if format == nil || format.NationalNumber == nil {
+		return "", fmt.Errorf("no phone number format for country: %s", country)
+	}
+
+	digits := stripPhoneFormatting(number)
+	if !strings.HasPrefix(digits, "+") {
+		if !isNumericCode(digits) {
+			return "", fmt.Errorf("invalid phone number: %s", number)
+		}
+		if format.TrunkPrefix != "" {
+			digits = strings.TrimPrefix(digits, format.TrunkPrefix)
+		}
+		digits = "+" + format.CallingCode + digits
+	}
+
+	canonical, parts, err := parsePhoneNumber(digits)
+	if err != nil {
+		return "", err
+	}
+	for _, matched := range parts.formats {
+		if matched == format {
+			return PhoneNumber(canonical), nil
+		}
+	}
+	return "", fmt.Errorf("invalid phone number for country %s: %s", format.Alpha2, number)
+}
+
+// MustPhoneNumber creates a PhoneNumber or panics if invalid.
+//
+// Use this for package-level defaults or when the number is known to be valid.
+//
+// Example:
+//
+//	var SupportLine = MustPhoneNumber("+18005550199")
+func MustPhoneNumber(number string) PhoneNumber {
+	p, err := NewPhoneNumber(number)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the phone number in E.164 form.
+func (p PhoneNumber) String() string {
+	return string(p)
+}
+
+// Validate checks if the phone number is valid.
+//
+// Returns an error if the number is not valid E.164 or does not match the
+// catalog formats for its calling code.
+func (p PhoneNumber) Validate() error {
+	if p == "" {
+		return fmt.Errorf("phone number is empty")
+	}
+	_, _, err := parsePhoneNumber(string(p))
+	return err
+}
+
+// IsValid returns true if the phone number is valid.
+func (p PhoneNumber) IsValid() bool {
+	return p.Validate() == nil
+}
+
+// CallingCode returns the E.164 country calling code without "+" (e.g.,
+// "44"), or "" if the calling code is not in the catalog or the number is
+// invalid.
+func (p PhoneNumber) CallingCode() string {
+	_, parts, err := parsePhoneNumber(string(p))
+	if err != nil {
+		return ""
+	}
+	return parts.callingCode
+}
+
+// NationalNumber returns the national significant number (the digits after
+// the calling code), or "" if the calling code is not in the catalog or the
+// number is invalid.
+func (p PhoneNumber) NationalNumber() string {
+	_, parts, err := parsePhoneNumber(string(p))
+	if err != nil {
+		return ""
+	}
+	return parts.national
+}
+
+// Countries returns the countries whose formats the number matches. Shared
+// calling codes can match several countries (e.g., "+1" numbers match both
+// CA and US).
+//
+// Returns an error if the number is invalid; returns an empty slice if its
+// calling code is not in the catalog.
+func (p PhoneNumber) Countries() ([]CountryCode, error) {
+	_, parts, err := parsePhoneNumber(string(p))
+	if err != nil {
+		return nil, err
+	}
+	countries := make([]CountryCode, 0, len(parts.formats))
+	for _, format := range parts.formats {
+		countries = append(countries, CountryCode(format.Alpha2))
+	}
+	return countries, nil
+}
+
+// MarshalText implements encoding.TextMarshaler for JSON, YAML, TOML support.
+//
+// The phone number is marshaled in E.164 form.
+func (p PhoneNumber) MarshalText() ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(p), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for JSON, YAML, TOML support.
+//
+// Validates and canonicalizes the phone number on unmarshal.
+func (p *PhoneNumber) UnmarshalText(text []byte) error {
+	number, err := NewPhoneNumber(string(text))
+	if err != nil {
+		return err
+	}
+	*p = number
+	return nil
+}
+
+// Value implements database/sql/driver.Valuer for database integration.
+//
+// The phone number is stored in E.164 form (VARCHAR(16)/TEXT column).
+func (p PhoneNumber) Value() (driver.Value, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return string(p), nil
+}
+
+// Scan implements database/sql.Scanner for database integration.
+//
+// Reads phone numbers from VARCHAR/TEXT columns with validation.
+func (p *PhoneNumber) Scan(src interface{}) error {
+	if src == nil {
+		*p = ""
+		return nil
+	}
+
+	var number string
+	switch v := src.(type) {
+	case string:
+		number = v
+	case []byte:
+		number = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into PhoneNumber", src)
+	}
+
+	parsed, err := NewPhoneNumber(number)
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+	return nil
+}
+
+// parsePhoneNumber canonicalizes and validates an E.164 number.
+func parsePhoneNumber(number string) (string, phoneParts, error) {
+	var parts phoneParts
+	if number == "" {
+		return "", parts, fmt.Errorf("phone number cannot be empty")
+	}
+
+	stripped := stripPhoneFormatting(number)
+	digits, ok := strings.CutPrefix(stripped, "+")
+	if !ok {
+		return "", parts, fmt.Errorf("phone number must start with + and a country calling code: %s", number)
+	}
+	// E.164 allows at most 15 digits; the shortest national numbers are 4 digits
+	if !isNumericCode(digits) || len(digits) < 7 || len(digits) > 15 || digits[0] == '0' {
+		return "", parts, fmt.Errorf("invalid phone number: %s", number)
+	}
+	canonical := "+" + digits
+
+	// Calling codes are prefix-free, so at most one length matches
+	for n := 1; n <= 3; n++ {
+		formats, err := GetCountryFormatsByCallingCode(digits[:n])
+		if err != nil {
+			return "", parts, err
+		}
+		if len(formats) == 0 {
+			continue
+		}
+
+		parts.callingCode = digits[:n]
+		parts.national = digits[n:]
+		for _, format := range formats {
+			if format.MatchesNationalNumber(parts.national) {
+				parts.formats = append(parts.formats, format)
+			}
+		}
+		if len(parts.formats) == 0 {
+			return "", phoneParts{}, fmt.Errorf("invalid phone number for calling code +%s: %s", parts.callingCode, number)
+		}
+		return canonical, parts, nil
+	}
+
+	return canonical, parts, nil
+}
+
+// stripPhoneFormatting removes spaces and common separators from a phone number.
+func stripPhoneFormatting(number string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '-', '.', '/', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(number))
+}
@@ -0,0 +1,266 @@
+package foundry
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestNewPhoneNumber tests creating PhoneNumber from valid and invalid inputs
+func TestNewPhoneNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"US_Formatted", "+1 (202) 555-0143", "+12025550143", false},
+		{"GB_Spaced", "+44 20 7183 8750", "+442071838750", false},
+		{"DE_Dashed", "+49-30-901820", "+4930901820", false},
+		{"JP_Dotted", "+81.3.1234.5678", "+81312345678", false},
+		{"KZ_SharedCode", "+7 717 212 3456", "+77172123456", false},
+		{"UnknownCallingCode", "+3581234567", "+3581234567", false}, // structural check only
+		{"US_InvalidAreaCode", "+1 123 555 0143", "", true},
+		{"GB_TooShort", "+44 20 7183", "", true},
+		{"NationalForm", "020 7183 8750", "", true},
+		{"Letters", "+1 202 CALL NOW", "", true},
+		{"TooLong", "+1234567890123456", "", true},
+		{"LeadingZero", "+0123456789", "", true},
+		{"Empty", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phone, err := NewPhoneNumber(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPhoneNumber(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && string(phone) != tt.expected {
+				t.Errorf("NewPhoneNumber(%q) = %q, want %q", tt.input, phone, tt.expected)
+			}
+		})
+	}
+}
+
+// TestNewPhoneNumberForCountry tests creating PhoneNumber from national input
+func TestNewPhoneNumberForCountry(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		country  CountryCode
+		expected string
+		wantErr  bool
+	}{
+		{"GB_TrunkPrefix", "020 7183 8750", "GB", "+442071838750", false},
+		{"US_NoTrunkPrefix", "(202) 555-0143", "US", "+12025550143", false},
+		{"US_TrunkPrefix", "1-202-555-0143", "USA", "+12025550143", false},
+		{"FR_TrunkPrefix", "01 42 68 53 00", "FR", "+33142685300", false},
+		{"International_SameCode", "+1 613 555 0143", "US", "+16135550143", false},
+		{"International_OtherCountry", "+44 20 7183 8750", "US", "", true},
+		{"Invalid", "020 7183", "GB", "", true},
+		{"NoFormat", "123456789", "AQ", "", true},
+		{"Empty", "", "US", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phone, err := NewPhoneNumberForCountry(tt.input, tt.country)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPhoneNumberForCountry(%q, %q) error = %v, wantErr %v", tt.input, tt.country, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && string(phone) != tt.expected {
+				t.Errorf("NewPhoneNumberForCountry(%q, %q) = %q, want %q", tt.input, tt.country, phone, tt.expected)
+			}
+		})
+	}
+}
+
+// TestMustPhoneNumber_Panic tests that MustPhoneNumber panics on invalid input
+func TestMustPhoneNumber_Panic(t *testing.T) {
+	if phone := MustPhoneNumber("+1 202 555 0143"); phone != "+12025550143" {
+		t.Errorf("MustPhoneNumber() = %q, want \"+12025550143\"", phone)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected MustPhoneNumber to panic on invalid number")
+		}
+	}()
+	MustPhoneNumber("555-0143")
+}
+
+// TestPhoneNumber_Parts tests CallingCode, NationalNumber, and Countries
+func TestPhoneNumber_Parts(t *testing.T) {
+	tests := []struct {
+		phone       PhoneNumber
+		callingCode string
+		national    string
+		countries   []CountryCode
+	}{
+		{"+12025550143", "1", "2025550143", []CountryCode{"CA", "US"}},
+		{"+442071838750", "44", "2071838750", []CountryCode{"GB"}},
+		{"+74951234567", "7", "4951234567", []CountryCode{"RU"}},
+		{"+77172123456", "7", "7172123456", []CountryCode{"KZ"}},
+		{"+3581234567", "", "", []CountryCode{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.phone), func(t *testing.T) {
+			if got := tt.phone.CallingCode(); got != tt.callingCode {
+				t.Errorf("CallingCode() = %q, want %q", got, tt.callingCode)
+			}
+			if got := tt.phone.NationalNumber(); got != tt.national {
+				t.Errorf("NationalNumber() = %q, want %q", got, tt.national)
+			}
+
+			countries, err := tt.phone.Countries()
+			if err != nil {
+				t.Fatalf("Countries() error: %v", err)
+			}
+			if len(countries) != len(tt.countries) {
+				t.Fatalf("Countries() = %v, want %v", countries, tt.countries)
+			}
+			for i := range countries {
+				if countries[i] != tt.countries[i] {
+					t.Errorf("Countries() = %v, want %v", countries, tt.countries)
+				}
+			}
+		})
+	}
+
+	if _, err := PhoneNumber("+1123").Countries(); err == nil {
+		t.Error("Expected error from Countries() on invalid number")
+	}
+}
+
+// TestPhoneNumber_Validate tests Validate and IsValid
+func TestPhoneNumber_Validate(t *testing.T) {
+	tests := []struct {
+		phone   PhoneNumber
+		wantErr bool
+	}{
+		{"+12025550143", false},
+		{"+1 202 555 0143", false}, // formatting is tolerated
+		{"+11234567890", true},
+		{"12025550143", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.phone), func(t *testing.T) {
+			err := tt.phone.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.phone.IsValid() == tt.wantErr {
+				t.Errorf("IsValid() = %v, want %v", tt.phone.IsValid(), !tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestPhoneNumber_JSONRoundTrip tests JSON marshaling and unmarshaling
+func TestPhoneNumber_JSONRoundTrip(t *testing.T) {
+	type Contact struct {
+		Name  string      `json:"name"`
+		Phone PhoneNumber `json:"phone"`
+	}
+
+	data, err := json.Marshal(Contact{Name: "Alice", Phone: MustPhoneNumber("+1 (202) 555-0143")})
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if string(data) != `{"name":"Alice","phone":"+12025550143"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var contact Contact
+	if err := json.Unmarshal([]byte(`{"phone":"+44 20 7183 8750"}`), &contact); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if contact.Phone != "+442071838750" {
+		t.Errorf("json.Unmarshal() Phone = %q, want \"+442071838750\"", contact.Phone)
+	}
+
+	if err := json.Unmarshal([]byte(`{"phone":"555-0143"}`), &contact); err == nil {
+		t.Error("Expected error unmarshaling invalid phone number")
+	}
+
+	if _, err := json.Marshal(Contact{Phone: "555-0143"}); err == nil {
+		t.Error("Expected error marshaling invalid phone number")
+	}
+}
+
+// TestPhoneNumber_YAMLRoundTrip tests YAML marshaling and unmarshaling
+func TestPhoneNumber_YAMLRoundTrip(t *testing.T) {
+	type Config struct {
+		Phone PhoneNumber `yaml:"phone"`
+	}
+
+	data, err := yaml.Marshal(Config{Phone: "+4930901820"})
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error: %v", err)
+	}
+	if !containsString(string(data), "+4930901820") {
+		t.Errorf("yaml.Marshal() = %q", data)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal([]byte("phone: '+81 3 1234 5678'\n"), &config); err != nil {
+		t.Fatalf("yaml.Unmarshal() error: %v", err)
+	}
+	if config.Phone != "+81312345678" {
+		t.Errorf("yaml.Unmarshal() Phone = %q, want \"+81312345678\"", config.Phone)
+	}
+
+	if err := yaml.Unmarshal([]byte("phone: '12345'\n"), &config); err == nil {
+		t.Error("Expected error unmarshaling invalid phone number")
+	}
+}
+
+// TestPhoneNumber_Database tests database/sql Value and Scan
+func TestPhoneNumber_Database(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected PhoneNumber
+		wantErr  bool
+	}{
+		{"String", "+12025550143", "+12025550143", false},
+		{"Bytes", []byte("+44 20 7183 8750"), "+442071838750", false},
+		{"Nil", nil, "", false},
+		{"Invalid_String", "555-0143", "", true},
+		{"Invalid_Type", 12025550143, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var phone PhoneNumber
+			err := phone.Scan(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PhoneNumber.Scan(%v) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr || phone == "" {
+				return
+			}
+			if phone != tt.expected {
+				t.Errorf("PhoneNumber.Scan(%v) = %q, want %q", tt.input, phone, tt.expected)
+			}
+
+			value, err := phone.Value()
+			if err != nil {
+				t.Fatalf("Value() error: %v", err)
+			}
+			if value != string(tt.expected) {
+				t.Errorf("Value() = %v, want %q", value, tt.expected)
+			}
+		})
+	}
+
+	if _, err := PhoneNumber("").Value(); err == nil {
+		t.Error("Expected error from Value() on empty phone number")
+	}
+}
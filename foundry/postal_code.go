@@ -0,0 +1,183 @@
+package foundry
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// PostalCode is a postal code validated against its country's catalog format.
+//
+// A postal code is only meaningful together with its country, so the value
+// is stored qualified as "<alpha2>:<code>" (e.g., "US:94105", "CA:K1A 0B1").
+// Codes are normalized to uppercase with single spaces. Implements standard
+// Go interfaces for seamless integration with JSON, YAML, TOML, and SQL
+// databases using the qualified form.
+//
+// The zero value is an invalid postal code. Use NewPostalCode or
+// MustPostalCode to create valid instances.
+//
+// Example:
+//
+//	type Address struct {
+//	    Street     string     `json:"street"`
+//	    PostalCode PostalCode `json:"postalCode" db:"postal_code"`
+//	}
+//
+//	addr := Address{Street: "24 Sussex Dr", PostalCode: MustPostalCode("k1a 0b1", "CA")}
+//	json.Marshal(addr) // {"street":"24 Sussex Dr","postalCode":"CA:K1A 0B1"}
+type PostalCode string
+
+// postalCodeSeparator separates the country from the code in the qualified form.
+const postalCodeSeparator = ":"
+
+// NewPostalCode creates a validated PostalCode for a country.
+//
+// Alpha-2 country codes are used directly; Alpha-3 and Numeric codes are
+// resolved through the country catalog. Returns an error if the country has
+// no postal code format in the catalog or the code does not match it.
+//
+// Example:
+//
+//	code, err := NewPostalCode("94105-1234", "US") // → "US:94105-1234"
+//	code, err := NewPostalCode("sw1a 1aa", "GB")   // → "GB:SW1A 1AA"
+//	code, err := NewPostalCode("1234", "US")       // error
+func NewPostalCode(code string, country CountryCode) (PostalCode, error) {
+	normalized := normalizePostalCode(code)
+	if normalized == "" {
+		return "", fmt.Errorf("postal code cannot be empty")
+	}
+
+	format, err := GetCountryFormat(country)
+	if err != nil {
+		return "", err
+	}
+	if format == nil || format.PostalCode == nil {
+		return "", fmt.Errorf("no postal code format for country: %s", country)
+	}
+	if !format.MatchesPostalCode(normalized) {
+		return "", fmt.Errorf("invalid postal code for country %s: %s", format.Alpha2, code)
+	}
+
+	return PostalCode(format.Alpha2 + postalCodeSeparator + normalized), nil
+}
+
+// ParsePostalCode creates a validated PostalCode from its qualified
+// "<alpha2>:<code>" form.
+//
+// Example:
+//
+//	code, err := ParsePostalCode("de:10117") // → "DE:10117"
+func ParsePostalCode(qualified string) (PostalCode, error) {
+	country, code, ok := strings.Cut(qualified, postalCodeSeparator)
+	if !ok {
+		return "", fmt.Errorf("postal code must be qualified as <country>:<code>: %s", qualified)
+	}
+	return NewPostalCode(code, CountryCode(strings.TrimSpace(country)))
+}
+
+// MustPostalCode creates a PostalCode or panics if invalid.
+//
+// Use this for package-level defaults or when the code is known to be valid.
+//
+// Example:
+//
+//	var HeadOffice = MustPostalCode("94105", "US")
+func MustPostalCode(code string, country CountryCode) PostalCode {
+	p, err := NewPostalCode(code, country)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the qualified postal code (e.g., "US:94105").
+func (p PostalCode) String() string {
+	return string(p)
+}
+
+// Country returns the postal code's country (e.g., "US").
+func (p PostalCode) Country() CountryCode {
+	country, _, _ := strings.Cut(string(p), postalCodeSeparator)
+	return CountryCode(country)
+}
+
+// Code returns the postal code without its country (e.g., "94105").
+func (p PostalCode) Code() string {
+	_, code, _ := strings.Cut(string(p), postalCodeSeparator)
+	return code
+}
+
+// Validate checks if the postal code is valid for its country.
+func (p PostalCode) Validate() error {
+	if p == "" {
+		return fmt.Errorf("postal code is empty")
+	}
+	_, err := ParsePostalCode(string(p))
+	return err
+}
+
+// IsValid returns true if the postal code is valid.
+func (p PostalCode) IsValid() bool {
+	return p.Validate() == nil
+}
+
+// MarshalText implements encoding.TextMarshaler for JSON, YAML, TOML support.
+//
+// The postal code is marshaled in qualified form.
+func (p PostalCode) MarshalText() ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(p), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for JSON, YAML, TOML support.
+//
+// Validates and normalizes the qualified postal code on unmarshal.
+func (p *PostalCode) UnmarshalText(text []byte) error {
+	code, err := ParsePostalCode(string(text))
+	if err != nil {
+		return err
+	}
+	*p = code
+	return nil
+}
+
+// Value implements database/sql/driver.Valuer for database integration.
+//
+// The postal code is stored in qualified form (VARCHAR/TEXT column).
+func (p PostalCode) Value() (driver.Value, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return string(p), nil
+}
+
+// Scan implements database/sql.Scanner for database integration.
+//
+// Reads qualified postal codes from VARCHAR/TEXT columns with validation.
+func (p *PostalCode) Scan(src interface{}) error {
+	if src == nil {
+		*p = ""
+		return nil
+	}
+
+	var qualified string
+	switch v := src.(type) {
+	case string:
+		qualified = v
+	case []byte:
+		qualified = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into PostalCode", src)
+	}
+
+	parsed, err := ParsePostalCode(qualified)
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+	return nil
+}
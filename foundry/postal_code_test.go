@@ -0,0 +1,230 @@
+package foundry
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestNewPostalCode tests creating PostalCode from valid and invalid inputs
+func TestNewPostalCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		country  CountryCode
+		expected string
+		wantErr  bool
+	}{
+		{"US_ZIP", "94105", "US", "US:94105", false},
+		{"US_ZIP4", "94105-1234", "US", "US:94105-1234", false},
+		{"CA_Normalized", " k1a   0b1 ", "CA", "CA:K1A 0B1", false},
+		{"GB_Lowercase", "sw1a 1aa", "GB", "GB:SW1A 1AA", false},
+		{"DE_Alpha3", "10117", "DEU", "DE:10117", false},
+		{"DE_Numeric", "10117", "276", "DE:10117", false},
+		{"US_TooShort", "1234", "US", "", true},
+		{"CA_InvalidLetter", "D1A 0B1", "CA", "", true},
+		{"NoPostalFormat", "010000", "KZ", "", true},
+		{"NoCountryFormat", "12345", "AQ", "", true},
+		{"Empty", "  ", "US", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := NewPostalCode(tt.code, tt.country)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPostalCode(%q, %q) error = %v, wantErr %v", tt.code, tt.country, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && string(code) != tt.expected {
+				t.Errorf("NewPostalCode(%q, %q) = %q, want %q", tt.code, tt.country, code, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParsePostalCode tests parsing the qualified form
+func TestParsePostalCode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"US:94105", "US:94105", false},
+		{"de:10117", "DE:10117", false},
+		{"gb:ec1a 1bb", "GB:EC1A 1BB", false},
+		{"94105", "", true},
+		{"US:ABCDE", "", true},
+		{":94105", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			code, err := ParsePostalCode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePostalCode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && string(code) != tt.expected {
+				t.Errorf("ParsePostalCode(%q) = %q, want %q", tt.input, code, tt.expected)
+			}
+		})
+	}
+}
+
+// TestMustPostalCode_Panic tests that MustPostalCode panics on invalid input
+func TestMustPostalCode_Panic(t *testing.T) {
+	if code := MustPostalCode("100-0001", "JP"); code != "JP:100-0001" {
+		t.Errorf("MustPostalCode() = %q, want \"JP:100-0001\"", code)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected MustPostalCode to panic on invalid code")
+		}
+	}()
+	MustPostalCode("1000001", "US")
+}
+
+// TestPostalCode_Parts tests Country and Code accessors
+func TestPostalCode_Parts(t *testing.T) {
+	code := MustPostalCode("k1a 0b1", "CA")
+	if code.Country() != "CA" {
+		t.Errorf("Country() = %q, want \"CA\"", code.Country())
+	}
+	if code.Code() != "K1A 0B1" {
+		t.Errorf("Code() = %q, want \"K1A 0B1\"", code.Code())
+	}
+	if code.String() != "CA:K1A 0B1" {
+		t.Errorf("String() = %q, want \"CA:K1A 0B1\"", code.String())
+	}
+}
+
+// TestPostalCode_Validate tests Validate and IsValid
+func TestPostalCode_Validate(t *testing.T) {
+	tests := []struct {
+		code    PostalCode
+		wantErr bool
+	}{
+		{"US:94105", false},
+		{"BR:01310-100", false},
+		{"US:9410", true},
+		{"94105", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			err := tt.code.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.code.IsValid() == tt.wantErr {
+				t.Errorf("IsValid() = %v, want %v", tt.code.IsValid(), !tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestPostalCode_JSONRoundTrip tests JSON marshaling and unmarshaling
+func TestPostalCode_JSONRoundTrip(t *testing.T) {
+	type Address struct {
+		Street     string     `json:"street"`
+		PostalCode PostalCode `json:"postalCode"`
+	}
+
+	data, err := json.Marshal(Address{Street: "24 Sussex Dr", PostalCode: MustPostalCode("k1a 0b1", "CA")})
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if string(data) != `{"street":"24 Sussex Dr","postalCode":"CA:K1A 0B1"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var addr Address
+	if err := json.Unmarshal([]byte(`{"postalCode":"nl:1012 ab"}`), &addr); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if addr.PostalCode != "NL:1012 AB" {
+		t.Errorf("json.Unmarshal() PostalCode = %q, want \"NL:1012 AB\"", addr.PostalCode)
+	}
+
+	if err := json.Unmarshal([]byte(`{"postalCode":"94105"}`), &addr); err == nil {
+		t.Error("Expected error unmarshaling unqualified postal code")
+	}
+
+	if _, err := json.Marshal(Address{PostalCode: "US:1"}); err == nil {
+		t.Error("Expected error marshaling invalid postal code")
+	}
+}
+
+// TestPostalCode_YAMLRoundTrip tests YAML marshaling and unmarshaling
+func TestPostalCode_YAMLRoundTrip(t *testing.T) {
+	type Config struct {
+		PostalCode PostalCode `yaml:"postalCode"`
+	}
+
+	data, err := yaml.Marshal(Config{PostalCode: "DE:10117"})
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error: %v", err)
+	}
+	if !containsString(string(data), "DE:10117") {
+		t.Errorf("yaml.Marshal() = %q", data)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal([]byte("postalCode: 'gb:sw1a 1aa'\n"), &config); err != nil {
+		t.Fatalf("yaml.Unmarshal() error: %v", err)
+	}
+	if config.PostalCode != "GB:SW1A 1AA" {
+		t.Errorf("yaml.Unmarshal() PostalCode = %q, want \"GB:SW1A 1AA\"", config.PostalCode)
+	}
+
+	if err := yaml.Unmarshal([]byte("postalCode: 'US:ABC'\n"), &config); err == nil {
+		t.Error("Expected error unmarshaling invalid postal code")
+	}
+}
+
+// TestPostalCode_Database tests database/sql Value and Scan
+func TestPostalCode_Database(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected PostalCode
+		wantErr  bool
+	}{
+		{"String", "US:94105", "US:94105", false},
+		{"Bytes", []byte("ca:k1a 0b1"), "CA:K1A 0B1", false},
+		{"Nil", nil, "", false},
+		{"Unqualified", "94105", "", true},
+		{"Invalid_Type", 94105, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var code PostalCode
+			err := code.Scan(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PostalCode.Scan(%v) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr || code == "" {
+				return
+			}
+			if code != tt.expected {
+				t.Errorf("PostalCode.Scan(%v) = %q, want %q", tt.input, code, tt.expected)
+			}
+
+			value, err := code.Value()
+			if err != nil {
+				t.Fatalf("Value() error: %v", err)
+			}
+			if value != string(tt.expected) {
+				t.Errorf("Value() = %v, want %q", value, tt.expected)
+			}
+		})
+	}
+
+	if _, err := PostalCode("").Value(); err == nil {
+		t.Error("Expected error from Value() on empty postal code")
+	}
+}
@@ -0,0 +1,278 @@
+package foundry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+// ErrInvalidRetryPolicy is returned when a RetryPolicy fails validation.
+var ErrInvalidRetryPolicy = errors.New("invalid retry policy")
+
+// JitterStrategy controls how randomness is applied to retry delays.
+type JitterStrategy string
+
+const (
+	// JitterNone uses the exact exponential delay.
+	JitterNone JitterStrategy = "none"
+	// JitterFull picks a delay uniformly in [0, delay].
+	JitterFull JitterStrategy = "full"
+	// JitterEqual picks a delay uniformly in [delay/2, delay].
+	JitterEqual JitterStrategy = "equal"
+)
+
+// RetryPolicy configures exponential backoff for retried operations such as
+// bootstrap downloads. It is a plain configuration value that can be loaded
+// from JSON or YAML and validated against an embedded schema.
+//
+// Example YAML:
+//
+//	retry:
+//	  initialInterval: 500ms
+//	  maxInterval: 10s
+//	  multiplier: 2
+//	  maxAttempts: 4
+//	  jitter: full
+type RetryPolicy struct {
+	// InitialInterval is the delay before the first retry.
+	InitialInterval HumanDuration `json:"initialInterval" yaml:"initialInterval"`
+
+	// MaxInterval caps the delay between attempts.
+	MaxInterval HumanDuration `json:"maxInterval" yaml:"maxInterval"`
+
+	// Multiplier grows the delay after each attempt (>= 1).
+	Multiplier float64 `json:"multiplier" yaml:"multiplier"`
+
+	// MaxAttempts is the total number of attempts including the first (>= 1).
+	MaxAttempts int `json:"maxAttempts" yaml:"maxAttempts"`
+
+	// Jitter selects the randomization strategy (default: full).
+	Jitter JitterStrategy `json:"jitter,omitempty" yaml:"jitter,omitempty"`
+
+	// Classifier, if set, is consulted before retrying an error that is not
+	// Permanent; Do returns errors it reports known and not retryable
+	// immediately. Set it to errors.RetryDecision so fulpack, pathfinder,
+	// and envelope errors classified permanent or security are not retried.
+	Classifier RetryClassifier `json:"-" yaml:"-"`
+}
+
+// RetryClassifier decides whether err may be retried. known is false when
+// the classifier has no opinion, leaving Do's default of retrying.
+type RetryClassifier func(err error) (retryable bool, known bool)
+
+// DefaultRetryPolicy returns the policy used when none is configured:
+// 4 attempts starting at 500ms, doubling up to 10s, with full jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialInterval: HumanDuration(500 * time.Millisecond),
+		MaxInterval:     HumanDuration(10 * time.Second),
+		Multiplier:      2,
+		MaxAttempts:     4,
+		Jitter:          JitterFull,
+	}
+}
+
+// retryPolicySchema is the JSON Schema for the marshaled form of RetryPolicy.
+const retryPolicySchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Retry Policy",
+  "type": "object",
+  "required": ["initialInterval", "maxInterval", "multiplier", "maxAttempts"],
+  "additionalProperties": false,
+  "properties": {
+    "initialInterval": {"$ref": "#/$defs/duration"},
+    "maxInterval": {"$ref": "#/$defs/duration"},
+    "multiplier": {"type": "number", "minimum": 1},
+    "maxAttempts": {"type": "integer", "minimum": 1},
+    "jitter": {"enum": ["none", "full", "equal"]}
+  },
+  "$defs": {
+    "duration": {"type": "string", "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"}
+  }
+}`
+
+var (
+	retrySchemaOnce      sync.Once
+	retrySchemaValidator *schema.Validator
+	retrySchemaErr       error
+)
+
+// RetryPolicySchema returns the JSON Schema used to validate retry policies.
+func RetryPolicySchema() []byte {
+	return []byte(retryPolicySchema)
+}
+
+// Validate checks the policy against the retry policy schema and verifies
+// that InitialInterval does not exceed MaxInterval.
+func (p *RetryPolicy) Validate() error {
+	retrySchemaOnce.Do(func() {
+		retrySchemaValidator, retrySchemaErr = schema.NewValidator([]byte(retryPolicySchema))
+	})
+	if retrySchemaErr != nil {
+		return fmt.Errorf("failed to compile retry policy schema: %w", retrySchemaErr)
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRetryPolicy, err)
+	}
+	diags, err := retrySchemaValidator.ValidateJSON(data)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRetryPolicy, err)
+	}
+	if len(diags) > 0 {
+		messages := make([]string, 0, len(diags))
+		for _, d := range diags {
+			messages = append(messages, fmt.Sprintf("%s: %s", d.Pointer, d.Message))
+		}
+		return fmt.Errorf("%w: %s", ErrInvalidRetryPolicy, strings.Join(messages, "; "))
+	}
+
+	if p.InitialInterval < 0 || p.MaxInterval < 0 {
+		return fmt.Errorf("%w: intervals must not be negative", ErrInvalidRetryPolicy)
+	}
+	if p.InitialInterval > p.MaxInterval {
+		return fmt.Errorf("%w: initialInterval %s exceeds maxInterval %s", ErrInvalidRetryPolicy, p.InitialInterval, p.MaxInterval)
+	}
+	return nil
+}
+
+// Backoff returns the un-jittered delay before the given retry (1-based):
+// InitialInterval * Multiplier^(retry-1), capped at MaxInterval.
+func (p *RetryPolicy) Backoff(retry int) time.Duration {
+	if retry < 1 {
+		return 0
+	}
+	delay := float64(p.InitialInterval) * math.Pow(p.Multiplier, float64(retry-1))
+	if delay > float64(p.MaxInterval) || math.IsInf(delay, 0) || math.IsNaN(delay) {
+		return time.Duration(p.MaxInterval)
+	}
+	return time.Duration(delay)
+}
+
+// Delay returns the jittered delay before the given retry (1-based).
+func (p *RetryPolicy) Delay(retry int) time.Duration {
+	delay := p.Backoff(retry)
+	if delay <= 0 {
+		return 0
+	}
+	switch p.Jitter {
+	case JitterNone:
+		return delay
+	case JitterEqual:
+		half := delay / 2
+		return half + time.Duration(rand.Int64N(int64(delay-half)+1))
+	default:
+		return time.Duration(rand.Int64N(int64(delay) + 1))
+	}
+}
+
+// permanentError marks an error that must not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// retryDelayer is implemented by errors that carry a server-requested delay
+// before the next attempt, such as *HTTPStatusError with Retry-After.
+type retryDelayer interface {
+	RetryDelay() (time.Duration, bool)
+}
+
+// Permanent wraps err so that Do returns it immediately without retrying.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err, or an error it wraps, was marked with
+// Permanent.
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// Do calls fn until it succeeds, returns a Permanent error, the policy's
+// attempts are exhausted, or ctx is done. The last error from fn is returned
+// (unwrapped from Permanent). A nil policy uses DefaultRetryPolicy. Errors
+// the policy's Classifier rejects are also returned without retrying.
+//
+// If fn's error carries a server-requested delay (e.g., an *HTTPStatusError
+// from HTTPStatusHelper.RetryError with Retry-After), Do waits that long
+// instead of the policy delay; a requested delay above MaxInterval ends the
+// retries and returns the error.
+//
+// Example:
+//
+//	policy := foundry.DefaultRetryPolicy()
+//	err := policy.Do(ctx, func(ctx context.Context) error {
+//	    resp, err := client.Do(req.WithContext(ctx))
+//	    if err != nil {
+//	        return err
+//	    }
+//	    defer resp.Body.Close()
+//	    if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+//	        return foundry.Permanent(fmt.Errorf("HTTP %d", resp.StatusCode))
+//	    }
+//	    return nil
+//	})
+func (p *RetryPolicy) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if p == nil {
+		p = DefaultRetryPolicy()
+	}
+	attempts := p.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			delay := p.Delay(attempt - 1)
+			var delayer retryDelayer
+			if errors.As(err, &delayer) {
+				if requested, ok := delayer.RetryDelay(); ok {
+					if requested > time.Duration(p.MaxInterval) {
+						return err
+					}
+					delay = requested
+				}
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return errors.Join(err, ctx.Err())
+			case <-timer.C:
+			}
+		}
+
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if p.Classifier != nil {
+			if retryable, known := p.Classifier(err); known && !retryable {
+				return err
+			}
+		}
+	}
+	return err
+}
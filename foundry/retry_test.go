@@ -0,0 +1,211 @@
+package foundry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRetryPolicyMarshal(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	data, err := json.Marshal(policy)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	want := `{"initialInterval":"500ms","maxInterval":"10s","multiplier":2,"maxAttempts":4,"jitter":"full"}`
+	if string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var decoded RetryPolicy
+	input := "initialInterval: 1.5s\nmaxInterval: 1m\nmultiplier: 3\nmaxAttempts: 5\njitter: equal\n"
+	if err := yaml.Unmarshal([]byte(input), &decoded); err != nil {
+		t.Fatalf("yaml.Unmarshal failed: %v", err)
+	}
+	if time.Duration(decoded.InitialInterval) != 1500*time.Millisecond || time.Duration(decoded.MaxInterval) != time.Minute {
+		t.Errorf("unexpected durations: %+v", decoded)
+	}
+	if err := decoded.Validate(); err != nil {
+		t.Errorf("Validate failed: %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"initialInterval":"soon"}`), &decoded); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+}
+
+func TestRetryPolicyValidate(t *testing.T) {
+	invalid := map[string]*RetryPolicy{
+		"zero attempts":    {InitialInterval: HumanDuration(time.Second), MaxInterval: HumanDuration(time.Second), Multiplier: 2},
+		"small multiplier": {InitialInterval: HumanDuration(time.Second), MaxInterval: HumanDuration(time.Second), Multiplier: 0.5, MaxAttempts: 3},
+		"unknown jitter":   {InitialInterval: HumanDuration(time.Second), MaxInterval: HumanDuration(time.Second), Multiplier: 2, MaxAttempts: 3, Jitter: "random"},
+		"initial over max": {InitialInterval: HumanDuration(time.Minute), MaxInterval: HumanDuration(time.Second), Multiplier: 2, MaxAttempts: 3},
+		"negative":         {InitialInterval: HumanDuration(-time.Second), MaxInterval: HumanDuration(time.Second), Multiplier: 2, MaxAttempts: 3},
+	}
+	for name, policy := range invalid {
+		if err := policy.Validate(); !errors.Is(err, ErrInvalidRetryPolicy) {
+			t.Errorf("%s: expected ErrInvalidRetryPolicy, got %v", name, err)
+		}
+	}
+	if err := DefaultRetryPolicy().Validate(); err != nil {
+		t.Errorf("default policy should be valid: %v", err)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialInterval: HumanDuration(100 * time.Millisecond),
+		MaxInterval:     HumanDuration(time.Second),
+		Multiplier:      2,
+		MaxAttempts:     10,
+		Jitter:          JitterNone,
+	}
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, want := range expected {
+		if got := policy.Delay(i + 1); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", i+1, got, want)
+		}
+	}
+
+	for _, jitter := range []JitterStrategy{JitterFull, JitterEqual} {
+		policy.Jitter = jitter
+		for i := 0; i < 100; i++ {
+			got := policy.Delay(3)
+			lower := time.Duration(0)
+			if jitter == JitterEqual {
+				lower = 200 * time.Millisecond
+			}
+			if got < lower || got > 400*time.Millisecond {
+				t.Fatalf("%s jitter delay %v out of range", jitter, got)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialInterval: HumanDuration(time.Millisecond),
+		MaxInterval:     HumanDuration(time.Millisecond),
+		Multiplier:      1,
+		MaxAttempts:     3,
+		Jitter:          JitterNone,
+	}
+	ctx := context.Background()
+
+	calls := 0
+	err := policy.Do(ctx, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("expected success on third attempt, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	errFatal := errors.New("fatal")
+	err = policy.Do(ctx, func(context.Context) error {
+		calls++
+		return Permanent(errFatal)
+	})
+	if err != errFatal || calls != 1 {
+		t.Errorf("expected permanent error after 1 call, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	err = policy.Do(ctx, func(context.Context) error {
+		calls++
+		return errors.New("transient")
+	})
+	if err == nil || calls != 3 {
+		t.Errorf("expected exhaustion after 3 calls, got %v after %d calls", err, calls)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	policy.InitialInterval, policy.MaxInterval = HumanDuration(time.Hour), HumanDuration(time.Hour)
+	err = policy.Do(cancelled, func(context.Context) error { return errors.New("transient") })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRetryPolicyDoClassifier(t *testing.T) {
+	errRejected := errors.New("rejected")
+	policy := &RetryPolicy{
+		InitialInterval: HumanDuration(time.Millisecond),
+		MaxInterval:     HumanDuration(time.Millisecond),
+		Multiplier:      1,
+		MaxAttempts:     3,
+		Jitter:          JitterNone,
+		Classifier: func(err error) (bool, bool) {
+			return false, err == errRejected
+		},
+	}
+
+	calls := 0
+	err := policy.Do(context.Background(), func(context.Context) error {
+		calls++
+		return errRejected
+	})
+	if err != errRejected || calls != 1 {
+		t.Errorf("expected rejected error after 1 call, got %v after %d calls", err, calls)
+	}
+
+	// Errors the classifier does not know keep the default of retrying
+	calls = 0
+	_ = policy.Do(context.Background(), func(context.Context) error {
+		calls++
+		return errors.New("unknown")
+	})
+	if calls != 3 {
+		t.Errorf("expected 3 calls for unknown errors, got %d", calls)
+	}
+
+	// The classifier is not part of the serialized policy
+	if err := policy.Validate(); err != nil {
+		t.Errorf("Validate() with classifier failed: %v", err)
+	}
+}
+
+func TestRetryPolicyDoRetryAfter(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialInterval: HumanDuration(time.Hour),
+		MaxInterval:     HumanDuration(time.Hour),
+		Multiplier:      1,
+		MaxAttempts:     2,
+		Jitter:          JitterNone,
+	}
+
+	// A requested delay replaces the (hour-long) policy delay
+	calls := 0
+	start := time.Now()
+	err := policy.Do(context.Background(), func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return &HTTPStatusError{StatusCode: 429, Class: RetryClassThrottled, RetryAfter: time.Millisecond}
+		}
+		return nil
+	})
+	if err != nil || calls != 2 || time.Since(start) > time.Minute {
+		t.Errorf("expected Retry-After delay to be honored, got %v after %d calls", err, calls)
+	}
+
+	// A requested delay above MaxInterval stops retrying
+	policy.InitialInterval, policy.MaxInterval = HumanDuration(time.Millisecond), HumanDuration(time.Millisecond)
+	calls = 0
+	err = policy.Do(context.Background(), func(context.Context) error {
+		calls++
+		return &HTTPStatusError{StatusCode: 503, Class: RetryClassThrottled, RetryAfter: time.Hour}
+	})
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || calls != 1 {
+		t.Errorf("expected to give up after 1 call, got %v after %d calls", err, calls)
+	}
+}
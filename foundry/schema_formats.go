@@ -0,0 +1,101 @@
+package foundry
+
+import (
+	"fmt"
+
+	"github.com/fulmenhq/gofulmen/fulhash"
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+// JSON Schema format names registered by RegisterSchemaFormats.
+const (
+	// SchemaFormatCountryCode accepts ISO 3166-1 alpha-2, alpha-3, or numeric codes.
+	SchemaFormatCountryCode = "country-code"
+
+	// SchemaFormatCurrencyCode accepts ISO 4217 alphabetic or numeric codes.
+	SchemaFormatCurrencyCode = "currency-code"
+
+	// SchemaFormatLanguageCode accepts BCP-47 language tags.
+	SchemaFormatLanguageCode = "language-code"
+
+	// SchemaFormatTimezoneID accepts IANA time zone IDs and aliases.
+	SchemaFormatTimezoneID = "timezone-id"
+
+	// SchemaFormatPhoneNumber accepts E.164 phone numbers valid for their calling code.
+	SchemaFormatPhoneNumber = "phone-number"
+
+	// SchemaFormatCorrelationID accepts UUIDv7 correlation IDs.
+	SchemaFormatCorrelationID = "correlation-id"
+
+	// SchemaFormatFulHashDigest accepts "algorithm:hex" FulHash digests.
+	SchemaFormatFulHashDigest = "fulhash-digest"
+
+	// SchemaPatternFormatPrefix prefixes catalog pattern IDs, e.g. "foundry-pattern:slug".
+	SchemaPatternFormatPrefix = "foundry-pattern:"
+)
+
+// RegisterSchemaFormats registers Fulmen formats with the schema format
+// registry: country-code, currency-code, language-code, timezone-id,
+// phone-number, correlation-id, fulhash-digest, and one
+// "foundry-pattern:<id>" format per pattern in catalog (nil uses the default
+// catalog). The formats apply to validators compiled with
+// schema.CompileOptions{AssertFormats: true}.
+//
+// Example:
+//
+//	if err := foundry.RegisterSchemaFormats(nil); err != nil {
+//	    return err
+//	}
+//	catalog := schema.DefaultCatalog().WithCompileOptions(&schema.CompileOptions{AssertFormats: true})
+//	diags, err := catalog.ValidateFileByID("app/v1.0.0/config", "config.yaml")
+func RegisterSchemaFormats(catalog *Catalog) error {
+	if catalog == nil {
+		catalog = GetDefaultCatalog()
+	}
+
+	builtins := map[string]func(string) bool{
+		SchemaFormatCountryCode:  ValidateCountryCode,
+		SchemaFormatCurrencyCode: ValidateCurrencyCode,
+		SchemaFormatLanguageCode: ValidateLanguageCode,
+		SchemaFormatTimezoneID:   ValidateTimezoneID,
+		SchemaFormatPhoneNumber: func(s string) bool {
+			return PhoneNumber(s).IsValid()
+		},
+		SchemaFormatCorrelationID: func(s string) bool {
+			return CorrelationID(s).IsValid()
+		},
+		SchemaFormatFulHashDigest: func(s string) bool {
+			_, err := fulhash.ParseDigest(s)
+			return err == nil
+		},
+	}
+	for name, check := range builtins {
+		if err := schema.RegisterFormat(name, stringFormat(check)); err != nil {
+			return err
+		}
+	}
+
+	patterns, err := catalog.GetAllPatterns()
+	if err != nil {
+		return fmt.Errorf("failed to load patterns: %w", err)
+	}
+	for id, pattern := range patterns {
+		pattern := pattern
+		match := func(s string) bool {
+			matched, err := pattern.Match(s)
+			return err == nil && matched
+		}
+		if err := schema.RegisterFormat(SchemaPatternFormatPrefix+id, stringFormat(match)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stringFormat adapts a string check to schema.FormatFunc; non-strings pass.
+func stringFormat(check func(string) bool) schema.FormatFunc {
+	return func(value interface{}) bool {
+		s, ok := value.(string)
+		return !ok || check(s)
+	}
+}
@@ -0,0 +1,69 @@
+package foundry
+
+import (
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+func TestRegisterSchemaFormats(t *testing.T) {
+	if err := RegisterSchemaFormats(nil); err != nil {
+		t.Fatalf("RegisterSchemaFormats failed: %v", err)
+	}
+
+	schemaData := []byte(`{
+	  "$schema": "https://json-schema.org/draft/2020-12/schema",
+	  "properties": {
+	    "country": {"type": "string", "format": "country-code"},
+	    "currency": {"type": "string", "format": "currency-code"},
+	    "locale": {"type": "string", "format": "language-code"},
+	    "timezone": {"type": "string", "format": "timezone-id"},
+	    "phone": {"type": "string", "format": "phone-number"},
+	    "correlationId": {"type": "string", "format": "correlation-id"},
+	    "digest": {"type": "string", "format": "fulhash-digest"},
+	    "slug": {"type": "string", "format": "foundry-pattern:slug"}
+	  }
+	}`)
+	validator, err := schema.NewValidatorWithOptions(schemaData, &schema.CompileOptions{AssertFormats: true})
+	if err != nil {
+		t.Fatalf("NewValidatorWithOptions failed: %v", err)
+	}
+
+	valid := map[string]interface{}{
+		"country":       "usa",
+		"currency":      "eur",
+		"locale":        "pt_BR",
+		"timezone":      "US/Eastern",
+		"phone":         "+442071838750",
+		"correlationId": GenerateCorrelationID(),
+		"digest":        "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"slug":          "my-project",
+	}
+	if diags, err := validator.ValidateData(valid); err != nil || len(diags) != 0 {
+		t.Errorf("expected valid document, got %v %v", diags, err)
+	}
+
+	invalid := map[string]interface{}{
+		"country":       "ZZZ",
+		"currency":      "XXX",
+		"locale":        "xx-US",
+		"timezone":      "Mars/Olympus_Mons",
+		"phone":         "+11234567890",
+		"correlationId": "550e8400-e29b-41d4-a716-446655440000", // UUIDv4
+		"digest":        "sha256:nothex",
+		"slug":          "Not A Slug",
+	}
+	diags, err := validator.ValidateData(invalid)
+	if err != nil {
+		t.Fatalf("ValidateData failed: %v", err)
+	}
+	pointers := map[string]bool{}
+	for _, d := range diags {
+		pointers[d.Pointer] = true
+	}
+	for _, p := range []string{"/country", "/currency", "/locale", "/timezone", "/phone", "/correlationId", "/digest", "/slug"} {
+		if !pointers[p] {
+			t.Errorf("expected format failure at %s, got %v", p, diags)
+		}
+	}
+}
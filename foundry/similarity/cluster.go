@@ -0,0 +1,174 @@
+package similarity
+
+// ClusterOptions configures Cluster and Dedup.
+//
+// Default values:
+//   - Algorithm: AlgorithmLevenshtein
+//   - Threshold: 0.85
+//   - Normalize: true (use DefaultClusterOptions; Go's zero value is false)
+//   - Prefilter: PrefilterAuto
+type ClusterOptions struct {
+	// Algorithm is the scoring algorithm (see ScoreWithAlgorithm).
+	// Default: AlgorithmLevenshtein
+	Algorithm Algorithm
+
+	// Threshold is the minimum score for two strings to be considered
+	// near-duplicates.
+	// Default: 0.85
+	//
+	// Range: (0.0, 1.0]
+	Threshold float64
+
+	// Normalize controls whether strings are normalized with
+	// Normalize(s, NormalizeOptions{}) before scoring.
+	Normalize bool
+
+	// Prefilter selects the candidate prefilter (see IndexOptions).
+	// Default: PrefilterAuto
+	Prefilter Prefilter
+}
+
+// DefaultClusterOptions returns ClusterOptions tuned for deduplicating
+// identifiers and titles.
+//
+// Returns:
+//   - Algorithm: AlgorithmLevenshtein
+//   - Threshold: 0.85
+//   - Normalize: true
+//   - Prefilter: PrefilterAuto
+func DefaultClusterOptions() ClusterOptions {
+	return ClusterOptions{
+		Algorithm: AlgorithmLevenshtein,
+		Threshold: 0.85,
+		Normalize: true,
+		Prefilter: PrefilterAuto,
+	}
+}
+
+// Group is a cluster of near-duplicate strings.
+type Group struct {
+	// Representative is the first member in input order.
+	Representative string
+
+	// Members lists every input string in the cluster, in input order.
+	// Repeated inputs appear once per occurrence.
+	Members []string
+}
+
+// Cluster groups near-duplicate strings.
+//
+// Two strings are linked when their score is at least opts.Threshold, and
+// clusters are the connected components of those links, so members of a
+// cluster may be linked through intermediate strings rather than directly.
+// Every input string belongs to exactly one cluster; strings with no
+// near-duplicates form single-member clusters. Clusters are ordered by the
+// position of their representative in candidates.
+//
+// Pairwise scores are computed through a SuggestIndex, so the prefilter
+// skips pairs that cannot reach the threshold and exact duplicates (after
+// normalization) are scored once.
+//
+// Returns an error if the options are invalid (see NewSuggestIndex).
+//
+// Example:
+//
+//	groups, err := similarity.Cluster(assetIDs, similarity.DefaultClusterOptions())
+//	if err != nil {
+//	    return err
+//	}
+//	for _, g := range groups {
+//	    if len(g.Members) > 1 {
+//	        fmt.Printf("%s: %v\n", g.Representative, g.Members)
+//	    }
+//	}
+func Cluster(candidates []string, opts ClusterOptions) ([]Group, error) {
+	if opts.Algorithm == "" {
+		opts.Algorithm = AlgorithmLevenshtein
+	}
+	if opts.Threshold == 0 {
+		opts.Threshold = 0.85
+	}
+
+	emitAlgorithmCounter("cluster", opts.Algorithm)
+
+	// Score each distinct normalized value once.
+	unique := make([]string, 0, len(candidates))
+	positions := make(map[string]int, len(candidates))
+	uniqueOf := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		normalized := candidate
+		if opts.Normalize {
+			normalized = Normalize(candidate, NormalizeOptions{})
+		}
+		u, ok := positions[normalized]
+		if !ok {
+			u = len(unique)
+			positions[normalized] = u
+			unique = append(unique, normalized)
+		}
+		uniqueOf[i] = u
+	}
+
+	index, err := NewSuggestIndex(unique, IndexOptions{
+		Algorithm: opts.Algorithm,
+		MinScore:  opts.Threshold,
+		Prefilter: opts.Prefilter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	parent := make([]int, len(unique))
+	for u := range parent {
+		parent[u] = u
+	}
+	find := func(u int) int {
+		for parent[u] != u {
+			parent[u] = parent[parent[u]]
+			u = parent[u]
+		}
+		return u
+	}
+
+	for u, value := range unique {
+		index.match(value, opts.Threshold, func(v int, _ float64) {
+			if ru, rv := find(u), find(v); ru != rv {
+				parent[rv] = ru
+			}
+		})
+	}
+
+	groups := []Group{}
+	groupOf := make(map[int]int)
+	for i, candidate := range candidates {
+		root := find(uniqueOf[i])
+		g, ok := groupOf[root]
+		if !ok {
+			g = len(groups)
+			groupOf[root] = g
+			groups = append(groups, Group{Representative: candidate})
+		}
+		groups[g].Members = append(groups[g].Members, candidate)
+	}
+
+	return groups, nil
+}
+
+// Dedup returns one representative per cluster of near-duplicates, in input
+// order. See Cluster for how clusters are formed.
+//
+// Example:
+//
+//	titles, _ := similarity.Dedup([]string{"Getting Started", "Getting started", "Install"}, similarity.DefaultClusterOptions())
+//	// titles: ["Getting Started", "Install"]
+func Dedup(candidates []string, opts ClusterOptions) ([]string, error) {
+	groups, err := Cluster(candidates, opts)
+	if err != nil {
+		return nil, err
+	}
+	representatives := make([]string, len(groups))
+	for i, g := range groups {
+		representatives[i] = g.Representative
+	}
+	return representatives, nil
+}
@@ -0,0 +1,114 @@
+package similarity
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCluster(t *testing.T) {
+	candidates := []string{
+		"schemas/fulmen/config-v1",
+		"docscribe",
+		"Schemas/Fulmen/Config-v1",
+		"schemas/fulmen/config-v2",
+		"foundry",
+		"docscribe",
+		"pathfinder",
+	}
+
+	groups, err := Cluster(candidates, DefaultClusterOptions())
+	if err != nil {
+		t.Fatalf("Cluster() error: %v", err)
+	}
+
+	want := []Group{
+		{Representative: "schemas/fulmen/config-v1", Members: []string{"schemas/fulmen/config-v1", "Schemas/Fulmen/Config-v1", "schemas/fulmen/config-v2"}},
+		{Representative: "docscribe", Members: []string{"docscribe", "docscribe"}},
+		{Representative: "foundry", Members: []string{"foundry"}},
+		{Representative: "pathfinder", Members: []string{"pathfinder"}},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("Cluster() = %+v, want %+v", groups, want)
+	}
+}
+
+// TestCluster_Transitive tests that clusters are connected components
+func TestCluster_Transitive(t *testing.T) {
+	// "abcdefgh" and "abcdxyzh" score below the threshold directly but are
+	// linked through "abcdefzh".
+	groups, err := Cluster([]string{"abcdefgh", "abcdxyzh", "abcdefzh"}, ClusterOptions{Threshold: 0.75})
+	if err != nil {
+		t.Fatalf("Cluster() error: %v", err)
+	}
+	if len(groups) != 1 || len(groups[0].Members) != 3 {
+		t.Errorf("Cluster() = %+v, want one cluster of 3", groups)
+	}
+}
+
+// TestCluster_MatchesPairwise tests that prefiltered clustering matches brute force
+func TestCluster_MatchesPairwise(t *testing.T) {
+	candidates := indexTestCandidates(300, 11)
+	for i := 0; i < 100; i++ {
+		candidates = append(candidates, fmt.Sprintf("%sx", candidates[i]))
+	}
+
+	for _, prefilter := range []Prefilter{PrefilterTrigram, PrefilterBKTree, PrefilterNone} {
+		opts := ClusterOptions{Threshold: 0.8, Prefilter: prefilter}
+		groups, err := Cluster(candidates, opts)
+		if err != nil {
+			t.Fatalf("Cluster(%s) error: %v", prefilter, err)
+		}
+
+		// Brute-force components by repeated relaxation.
+		label := make([]int, len(candidates))
+		for i := range label {
+			label[i] = i
+		}
+		for changed := true; changed; {
+			changed = false
+			for i := range candidates {
+				for j := i + 1; j < len(candidates); j++ {
+					if label[i] != label[j] && Score(candidates[i], candidates[j]) >= opts.Threshold {
+						low := min(label[i], label[j])
+						label[i], label[j] = low, low
+						changed = true
+					}
+				}
+			}
+		}
+		components := make(map[int]bool)
+		for _, l := range label {
+			components[l] = true
+		}
+
+		if len(groups) != len(components) {
+			t.Errorf("Cluster(%s) returned %d clusters, brute force %d", prefilter, len(groups), len(components))
+		}
+	}
+}
+
+func TestCluster_Options(t *testing.T) {
+	if _, err := Cluster([]string{"a"}, ClusterOptions{Threshold: 1.5}); err == nil {
+		t.Error("Expected error for threshold > 1")
+	}
+	if _, err := Cluster([]string{"a"}, ClusterOptions{Algorithm: AlgorithmDamerauOSA, Prefilter: PrefilterBKTree}); err == nil {
+		t.Error("Expected error for BK-tree with OSA")
+	}
+
+	groups, err := Cluster(nil, DefaultClusterOptions())
+	if err != nil || groups == nil || len(groups) != 0 {
+		t.Errorf("Cluster(nil) = %#v, %v; want empty slice", groups, err)
+	}
+}
+
+func TestDedup(t *testing.T) {
+	got, err := Dedup([]string{"Getting Started", "Getting started", "Install", "Instal"}, DefaultClusterOptions())
+	if err != nil {
+		t.Fatalf("Dedup() error: %v", err)
+	}
+	want := []string{"Getting Started", "Install"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Dedup() = %v, want %v", got, want)
+	}
+}
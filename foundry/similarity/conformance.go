@@ -0,0 +1,301 @@
+package similarity
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FixtureTestCase represents the structure of test cases from Crucible fixtures v2.0.0
+type FixtureTestCase struct {
+	Category string        `yaml:"category"`
+	Cases    []FixtureCase `yaml:"cases"`
+}
+
+// FixtureCase represents a single test case from similarity v2.0.0 schema
+type FixtureCase struct {
+	// Distance test case fields (levenshtein, damerau_osa, damerau_unrestricted)
+	InputA           string  `yaml:"input_a,omitempty"`
+	InputB           string  `yaml:"input_b,omitempty"`
+	ExpectedDistance int     `yaml:"expected_distance,omitempty"`
+	ExpectedScore    float64 `yaml:"expected_score,omitempty"`
+
+	// Jaro-Winkler specific fields
+	PrefixScale float64 `yaml:"prefix_scale,omitempty"` // default 0.1
+	MaxPrefix   int     `yaml:"max_prefix,omitempty"`   // default 4
+
+	// Substring matching fields
+	Needle          string         `yaml:"needle,omitempty"`
+	Haystack        string         `yaml:"haystack,omitempty"`
+	ExpectedRange   map[string]int `yaml:"expected_range,omitempty"`   // {start: int, end: int}
+	NormalizePreset string         `yaml:"normalize_preset,omitempty"` // for substring tests
+
+	// Normalization test case fields
+	Input  string `yaml:"input,omitempty"`
+	Preset string `yaml:"preset,omitempty"` // none, minimal, default, aggressive
+
+	// Suggestion test case fields
+	Options    map[string]interface{} `yaml:"options,omitempty"`
+	Candidates []string               `yaml:"candidates,omitempty"`
+
+	// Expected field - type varies by category
+	// For distance metrics: use ExpectedDistance and ExpectedScore above
+	// For normalization: string
+	// For suggestions: array of {value, score} maps
+	// For substring: ExpectedRange above + ExpectedScore
+	Expected interface{} `yaml:"expected,omitempty"`
+
+	// Common fields
+	Description string   `yaml:"description"`
+	Tags        []string `yaml:"tags,omitempty"`
+}
+
+// FixtureData represents the root structure of the fixtures file (v2.0.0)
+type FixtureData struct {
+	Schema    string            `yaml:"$schema"` // v2.0.0 schema reference
+	Version   string            `yaml:"version"`
+	Notes     string            `yaml:"notes,omitempty"`
+	TestCases []FixtureTestCase `yaml:"test_cases"`
+}
+
+// LoadFixtures reads and parses a Crucible similarity fixtures file (v2.0.0).
+func LoadFixtures(fixturesPath string) (*FixtureData, error) {
+	data, err := os.ReadFile(fixturesPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures file %s: %w", fixturesPath, err)
+	}
+
+	var fixtures FixtureData
+	if err := yaml.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse fixtures file %s: %w", fixturesPath, err)
+	}
+
+	return &fixtures, nil
+}
+
+// ConformanceStatus is the outcome of a single fixture case.
+type ConformanceStatus string
+
+const (
+	ConformancePass ConformanceStatus = "pass"
+	ConformanceFail ConformanceStatus = "fail"
+	// ConformanceSkip marks cases exercising features this implementation does not provide.
+	ConformanceSkip ConformanceStatus = "skip"
+)
+
+// ConformanceTolerance is the maximum allowed difference between expected and actual scores.
+const ConformanceTolerance = 0.0001
+
+// ConformanceResult is the outcome of one fixture case.
+type ConformanceResult struct {
+	Category    string
+	Index       int // position within the category group
+	Description string
+	Status      ConformanceStatus
+	Message     string // mismatch detail (fail) or reason (skip)
+}
+
+// Name returns a stable identifier for the case, e.g. "levenshtein/3 Single substitution".
+func (r ConformanceResult) Name() string {
+	if r.Description == "" {
+		return fmt.Sprintf("%s/%d", r.Category, r.Index)
+	}
+	return fmt.Sprintf("%s/%d %s", r.Category, r.Index, r.Description)
+}
+
+// ConformanceReport summarizes a fixture conformance run.
+type ConformanceReport struct {
+	FixturesPath string
+	Version      string
+	Results      []ConformanceResult
+}
+
+// Count returns the number of results with the given status.
+func (r *ConformanceReport) Count(status ConformanceStatus) int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Failures returns the failed results.
+func (r *ConformanceReport) Failures() []ConformanceResult {
+	var failures []ConformanceResult
+	for _, result := range r.Results {
+		if result.Status == ConformanceFail {
+			failures = append(failures, result)
+		}
+	}
+	return failures
+}
+
+// Passed reports whether no case failed.
+func (r *ConformanceReport) Passed() bool {
+	return r.Count(ConformanceFail) == 0
+}
+
+// RunConformance executes a Crucible similarity fixtures file against this
+// implementation and reports per-case results.
+//
+// Distance metrics (levenshtein, damerau_osa, damerau_unrestricted), jaro_winkler,
+// and substring cases are checked exactly (scores within ConformanceTolerance).
+// Cases needing features this implementation lacks - normalization presets, and
+// suggestions using a non-Levenshtein metric or a preset other than none/default -
+// are reported as ConformanceSkip with the reason. Unknown categories are skipped.
+//
+// An error is returned only if the fixtures file cannot be loaded.
+//
+// Example:
+//
+//	report, err := similarity.RunConformance("config/crucible-go/library/foundry/similarity-fixtures.yaml")
+//	if err != nil {
+//	    return err
+//	}
+//	for _, f := range report.Failures() {
+//	    fmt.Printf("FAIL %s: %s\n", f.Name(), f.Message)
+//	}
+func RunConformance(fixturesPath string) (*ConformanceReport, error) {
+	fixtures, err := LoadFixtures(fixturesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ConformanceReport{FixturesPath: fixturesPath, Version: fixtures.Version}
+	for _, group := range fixtures.TestCases {
+		for i, tc := range group.Cases {
+			status, message := checkFixtureCase(group.Category, tc)
+			report.Results = append(report.Results, ConformanceResult{
+				Category:    group.Category,
+				Index:       i,
+				Description: tc.Description,
+				Status:      status,
+				Message:     message,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// checkFixtureCase evaluates a single case, returning its status and a detail message.
+func checkFixtureCase(category string, tc FixtureCase) (ConformanceStatus, string) {
+	switch Algorithm(category) {
+	case AlgorithmLevenshtein, AlgorithmDamerauOSA, AlgorithmDamerauUnrestricted:
+		return checkDistanceCase(Algorithm(category), tc)
+	case AlgorithmJaroWinkler:
+		opts := DefaultScoreOptions()
+		if tc.PrefixScale > 0 {
+			opts.JaroPrefixScale = tc.PrefixScale
+		}
+		if tc.MaxPrefix > 0 {
+			opts.JaroMaxPrefix = tc.MaxPrefix
+		}
+		score, err := ScoreWithAlgorithm(tc.InputA, tc.InputB, AlgorithmJaroWinkler, opts)
+		if err != nil {
+			return ConformanceFail, err.Error()
+		}
+		if !scoresEqual(score, tc.ExpectedScore) {
+			return ConformanceFail, fmt.Sprintf("score(%q, %q) = %.16f, want %.16f", tc.InputA, tc.InputB, score, tc.ExpectedScore)
+		}
+		return ConformancePass, ""
+	case AlgorithmSubstring:
+		return checkSubstringCase(tc)
+	}
+
+	switch category {
+	case "normalization_presets":
+		return ConformanceSkip, fmt.Sprintf("normalization preset %q is not supported", tc.Preset)
+	case "suggestions":
+		return checkSuggestionCase(tc)
+	default:
+		return ConformanceSkip, fmt.Sprintf("unknown fixture category %q", category)
+	}
+}
+
+func checkDistanceCase(algorithm Algorithm, tc FixtureCase) (ConformanceStatus, string) {
+	distance, err := DistanceWithAlgorithm(tc.InputA, tc.InputB, algorithm)
+	if err != nil {
+		return ConformanceFail, err.Error()
+	}
+	if distance != tc.ExpectedDistance {
+		return ConformanceFail, fmt.Sprintf("distance(%q, %q) = %d, want %d", tc.InputA, tc.InputB, distance, tc.ExpectedDistance)
+	}
+
+	score, err := ScoreWithAlgorithm(tc.InputA, tc.InputB, algorithm, nil)
+	if err != nil {
+		return ConformanceFail, err.Error()
+	}
+	if !scoresEqual(score, tc.ExpectedScore) {
+		return ConformanceFail, fmt.Sprintf("score(%q, %q) = %.16f, want %.16f", tc.InputA, tc.InputB, score, tc.ExpectedScore)
+	}
+	return ConformancePass, ""
+}
+
+func checkSubstringCase(tc FixtureCase) (ConformanceStatus, string) {
+	if tc.NormalizePreset != "" && tc.NormalizePreset != "none" {
+		return ConformanceSkip, fmt.Sprintf("normalization preset %q is not supported", tc.NormalizePreset)
+	}
+
+	match, score := SubstringMatch(tc.Needle, tc.Haystack)
+	if !scoresEqual(score, tc.ExpectedScore) {
+		return ConformanceFail, fmt.Sprintf("score(%q, %q) = %.16f, want %.16f", tc.Needle, tc.Haystack, score, tc.ExpectedScore)
+	}
+
+	if tc.ExpectedRange == nil {
+		if match.Valid {
+			return ConformanceFail, fmt.Sprintf("range = [%d, %d), want no match", match.Start, match.End)
+		}
+		return ConformancePass, ""
+	}
+	if !match.Valid || match.Start != tc.ExpectedRange["start"] || match.End != tc.ExpectedRange["end"] {
+		return ConformanceFail, fmt.Sprintf("range = %+v, want [%d, %d)", match, tc.ExpectedRange["start"], tc.ExpectedRange["end"])
+	}
+	return ConformancePass, ""
+}
+
+func checkSuggestionCase(tc FixtureCase) (ConformanceStatus, string) {
+	opts := DefaultSuggestOptions()
+	if metric, ok := tc.Options["metric"].(string); ok && metric != string(AlgorithmLevenshtein) {
+		return ConformanceSkip, fmt.Sprintf("suggestion metric %q is not supported", metric)
+	}
+	if preset, ok := tc.Options["normalize_preset"].(string); ok {
+		switch preset {
+		case "none":
+			opts.Normalize = false
+		case "default":
+			opts.Normalize = true
+		default:
+			return ConformanceSkip, fmt.Sprintf("normalization preset %q is not supported", preset)
+		}
+	}
+	if minScore, ok := tc.Options["min_score"].(float64); ok {
+		opts.MinScore = minScore
+	}
+	if maxSuggestions, ok := tc.Options["max_suggestions"].(int); ok {
+		opts.MaxSuggestions = maxSuggestions
+	}
+
+	expected, _ := tc.Expected.([]interface{})
+	got := Suggest(tc.Input, tc.Candidates, opts)
+	if len(got) != len(expected) {
+		return ConformanceFail, fmt.Sprintf("got %d suggestions %+v, want %d", len(got), got, len(expected))
+	}
+	for i, item := range expected {
+		itemMap, _ := item.(map[string]interface{})
+		value, _ := itemMap["value"].(string)
+		score, _ := itemMap["score"].(float64)
+		if got[i].Value != value || !scoresEqual(got[i].Score, score) {
+			return ConformanceFail, fmt.Sprintf("suggestion[%d] = {%s %.16f}, want {%s %.16f}", i, got[i].Value, got[i].Score, value, score)
+		}
+	}
+	return ConformancePass, ""
+}
+
+func scoresEqual(a, b float64) bool {
+	return math.Abs(a-b) <= ConformanceTolerance
+}
@@ -0,0 +1,89 @@
+package similarity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunConformance(t *testing.T) {
+	fixturesPath := filepath.Join("..", "..", "config", "crucible-go", "library", "foundry", "similarity-fixtures.yaml")
+
+	report, err := RunConformance(fixturesPath)
+	if err != nil {
+		t.Fatalf("RunConformance failed: %v", err)
+	}
+
+	if !report.Passed() {
+		for _, f := range report.Failures() {
+			t.Errorf("FAIL %s: %s", f.Name(), f.Message)
+		}
+	}
+
+	// Every distance, jaro_winkler, and substring case is checked, never skipped
+	for _, result := range report.Results {
+		switch result.Category {
+		case "levenshtein", "damerau_osa", "damerau_unrestricted", "jaro_winkler", "substring":
+			if result.Status == ConformanceSkip {
+				t.Errorf("%s unexpectedly skipped: %s", result.Name(), result.Message)
+			}
+		case "normalization_presets":
+			if result.Status != ConformanceSkip {
+				t.Errorf("%s status = %s, want skip", result.Name(), result.Status)
+			}
+		}
+	}
+}
+
+func TestRunConformance_ReportsFailures(t *testing.T) {
+	fixtures := `version: test
+test_cases:
+  - category: levenshtein
+    cases:
+      - input_a: kitten
+        input_b: sitting
+        expected_distance: 3
+        expected_score: 0.5714285714285714
+        description: correct
+      - input_a: kitten
+        input_b: sitting
+        expected_distance: 2
+        expected_score: 0.5714285714285714
+        description: wrong distance
+  - category: phonetic
+    cases:
+      - input: smith
+        description: unknown category
+`
+	path := filepath.Join(t.TempDir(), "fixtures.yaml")
+	if err := os.WriteFile(path, []byte(fixtures), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := RunConformance(path)
+	if err != nil {
+		t.Fatalf("RunConformance failed: %v", err)
+	}
+
+	if got := len(report.Results); got != 3 {
+		t.Fatalf("len(Results) = %d, want 3", got)
+	}
+	if report.Passed() {
+		t.Error("Passed() = true, want false")
+	}
+	want := []ConformanceStatus{ConformancePass, ConformanceFail, ConformanceSkip}
+	for i, status := range want {
+		if report.Results[i].Status != status {
+			t.Errorf("Results[%d].Status = %s, want %s", i, report.Results[i].Status, status)
+		}
+	}
+	if name := report.Results[1].Name(); name != "levenshtein/1 wrong distance" {
+		t.Errorf("Name() = %q", name)
+	}
+}
+
+func TestRunConformance_MissingFile(t *testing.T) {
+	if _, err := RunConformance(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for missing fixtures file")
+	}
+}
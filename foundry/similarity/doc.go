@@ -59,6 +59,20 @@ Configure suggestion behavior:
 		Normalize:      true,  // Case-insensitive matching
 	}
 
+# Suggestion Index
+
+For repeated lookups against a large candidate list (asset discovery, CLI
+command registries), build a SuggestIndex once. Candidates are normalized at
+build time and a trigram prefilter skips candidates that cannot reach the
+minimum score, so results match Suggest without scanning every candidate:
+
+	index, err := similarity.NewSuggestIndex(assetIDs, similarity.DefaultIndexOptions())
+	if err != nil {
+		return err
+	}
+	suggestions := index.TopK("schemas/fulmen/confg", 5)
+	batch := index.TopKBatch(queries, 5)
+
 # Performance
 
 Distance and Score operations target ≤0.5ms p95 latency for 128-character strings
@@ -70,6 +84,7 @@ Benchmark results (M1 Pro, 12 cores):
   - Distance (short):     ~125 ns/op
   - Normalize (simple):   ~40 ns/op
   - Suggest (20 items):   ~3 μs/op
+  - SuggestIndex.TopK (100k candidates, MinScore 0.8): ~0.35 ms/op
 
 Run benchmarks with: go test -bench=. ./foundry/similarity/
 
@@ -88,6 +103,10 @@ Shared test fixtures validate cross-language consistency:
   - config/crucible-go/library/foundry/similarity-fixtures.yaml
   - schemas/crucible-go/library/foundry/v1.0.0/similarity.schema.json
 
+RunConformance executes a fixtures file against this implementation and reports
+per-case pass/fail/skip results; the similaritytest subpackage wraps it as
+subtests via RunFixtureConformance(t, fixturesPath).
+
 # Use Cases
 
 Common integration patterns:
@@ -9,58 +9,6 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// FixtureTestCase represents the structure of test cases from Crucible fixtures v2.0.0
-type FixtureTestCase struct {
-	Category string        `yaml:"category"`
-	Cases    []FixtureCase `yaml:"cases"`
-}
-
-// FixtureCase represents a single test case from similarity v2.0.0 schema
-type FixtureCase struct {
-	// Distance test case fields (levenshtein, damerau_osa, damerau_unrestricted)
-	InputA           string  `yaml:"input_a,omitempty"`
-	InputB           string  `yaml:"input_b,omitempty"`
-	ExpectedDistance int     `yaml:"expected_distance,omitempty"`
-	ExpectedScore    float64 `yaml:"expected_score,omitempty"`
-
-	// Jaro-Winkler specific fields
-	PrefixScale float64 `yaml:"prefix_scale,omitempty"` // default 0.1
-	MaxPrefix   int     `yaml:"max_prefix,omitempty"`   // default 4
-
-	// Substring matching fields
-	Needle          string         `yaml:"needle,omitempty"`
-	Haystack        string         `yaml:"haystack,omitempty"`
-	ExpectedRange   map[string]int `yaml:"expected_range,omitempty"`   // {start: int, end: int}
-	NormalizePreset string         `yaml:"normalize_preset,omitempty"` // for substring tests
-
-	// Normalization test case fields
-	Input  string `yaml:"input,omitempty"`
-	Preset string `yaml:"preset,omitempty"` // none, minimal, default, aggressive
-
-	// Suggestion test case fields
-	Options    map[string]interface{} `yaml:"options,omitempty"`
-	Candidates []string               `yaml:"candidates,omitempty"`
-
-	// Expected field - type varies by category
-	// For distance metrics: use ExpectedDistance and ExpectedScore above
-	// For normalization: string
-	// For suggestions: array of {value, score} maps
-	// For substring: ExpectedRange above + ExpectedScore
-	Expected interface{} `yaml:"expected,omitempty"`
-
-	// Common fields
-	Description string   `yaml:"description"`
-	Tags        []string `yaml:"tags,omitempty"`
-}
-
-// FixtureData represents the root structure of the fixtures file (v2.0.0)
-type FixtureData struct {
-	Schema    string            `yaml:"$schema"` // v2.0.0 schema reference
-	Version   string            `yaml:"version"`
-	Notes     string            `yaml:"notes,omitempty"`
-	TestCases []FixtureTestCase `yaml:"test_cases"`
-}
-
 // loadFixtures loads the Crucible similarity fixtures from YAML
 func loadFixtures(t *testing.T) *FixtureData {
 	t.Helper()
@@ -68,17 +16,12 @@ func loadFixtures(t *testing.T) *FixtureData {
 	// Find the fixtures file relative to the gofulmen root
 	fixturesPath := filepath.Join("..", "..", "config", "crucible-go", "library", "foundry", "similarity-fixtures.yaml")
 
-	data, err := os.ReadFile(fixturesPath)
+	fixtures, err := LoadFixtures(fixturesPath)
 	if err != nil {
-		t.Fatalf("Failed to read fixtures file: %v\nPath: %s", err, fixturesPath)
-	}
-
-	var fixtures FixtureData
-	if err := yaml.Unmarshal(data, &fixtures); err != nil {
-		t.Fatalf("Failed to parse fixtures YAML: %v", err)
+		t.Fatalf("Failed to load fixtures: %v", err)
 	}
 
-	return &fixtures
+	return fixtures
 }
 
 // TestFixtures_SchemaValidation validates fixtures YAML against v2.0.0 schema
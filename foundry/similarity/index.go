@@ -0,0 +1,596 @@
+package similarity
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Prefilter selects how a SuggestIndex narrows candidates before scoring.
+//
+// Prefilters are exact: they only skip candidates that cannot reach the
+// minimum score, so results match scoring every candidate.
+type Prefilter string
+
+const (
+	// PrefilterAuto uses PrefilterTrigram for edit-distance algorithms and
+	// PrefilterNone otherwise. This is the default.
+	PrefilterAuto Prefilter = ""
+
+	// PrefilterNone scores every candidate (normalization is still cached).
+	PrefilterNone Prefilter = "none"
+
+	// PrefilterTrigram uses a trigram inverted index with the q-gram count
+	// lemma and a length window. Requires an edit-distance algorithm.
+	PrefilterTrigram Prefilter = "trigram"
+
+	// PrefilterBKTree uses a BK-tree over edit distance. Requires a metric
+	// (AlgorithmLevenshtein or AlgorithmDamerauUnrestricted); OSA violates
+	// the triangle inequality.
+	PrefilterBKTree Prefilter = "bktree"
+)
+
+// IndexOptions configures a SuggestIndex.
+//
+// Default values match DefaultSuggestOptions:
+//   - Algorithm: AlgorithmLevenshtein
+//   - MinScore: 0.6
+//   - Normalize: true (use DefaultIndexOptions; Go's zero value is false)
+//   - Prefilter: PrefilterAuto
+type IndexOptions struct {
+	// Algorithm is the scoring algorithm (see ScoreWithAlgorithm).
+	// Default: AlgorithmLevenshtein
+	Algorithm Algorithm
+
+	// MinScore is the default minimum score for TopK. Must be > 0 for
+	// prefilters to apply.
+	// Default: 0.6
+	//
+	// Range: [0.0, 1.0]
+	MinScore float64
+
+	// Normalize controls whether queries and candidates are normalized with
+	// Normalize(s, NormalizeOptions{}) before scoring. Candidates are
+	// normalized once when the index is built.
+	Normalize bool
+
+	// Prefilter selects the candidate prefilter.
+	// Default: PrefilterAuto
+	Prefilter Prefilter
+}
+
+// DefaultIndexOptions returns IndexOptions with Crucible standard defaults.
+//
+// Returns:
+//   - Algorithm: AlgorithmLevenshtein
+//   - MinScore: 0.6
+//   - Normalize: true
+//   - Prefilter: PrefilterAuto
+func DefaultIndexOptions() IndexOptions {
+	return IndexOptions{
+		Algorithm: AlgorithmLevenshtein,
+		MinScore:  0.6,
+		Normalize: true,
+		Prefilter: PrefilterAuto,
+	}
+}
+
+// SuggestIndex ranks candidates against queries without re-normalizing or
+// re-scanning the candidate list on every call.
+//
+// Build the index once over a candidate list (e.g., all asset IDs or CLI
+// commands) and query it with TopK. An index is immutable and safe for
+// concurrent use.
+//
+// Example:
+//
+//	index, err := similarity.NewSuggestIndex(assetIDs, similarity.DefaultIndexOptions())
+//	if err != nil {
+//	    return err
+//	}
+//	suggestions := index.TopK("schemas/fulmen/confg", 5)
+//	for _, s := range suggestions {
+//	    fmt.Printf("%s (%.2f)\n", s.Value, s.Score)
+//	}
+type SuggestIndex struct {
+	opts       IndexOptions
+	values     []string // original candidates
+	normalized []string // candidates as scored
+	lengths    []int    // rune length of normalized candidates
+	maxLength  int
+
+	trigrams map[uint64][]trigramPosting // PrefilterTrigram
+	bktree   []bkNode                    // PrefilterBKTree; bktree[0] is the root
+
+	scratch sync.Pool // *indexScratch
+}
+
+// trigramPosting records a candidate containing a trigram.
+type trigramPosting struct {
+	candidate int32
+	length    int32 // rune length of the candidate
+	count     int32 // occurrences of the trigram in the candidate
+}
+
+// bkNode is a BK-tree node; children are keyed by edit distance.
+type bkNode struct {
+	candidate int
+	children  map[int]int // distance → node index
+}
+
+// indexScratch holds per-query trigram counters.
+type indexScratch struct {
+	shared  []int32
+	touched []int32
+}
+
+// NewSuggestIndex builds an index over candidates.
+//
+// Returns an error if the algorithm is unknown or the prefilter is not
+// compatible with it.
+func NewSuggestIndex(candidates []string, opts IndexOptions) (*SuggestIndex, error) {
+	if opts.Algorithm == "" {
+		opts.Algorithm = AlgorithmLevenshtein
+	}
+	if opts.MinScore == 0 {
+		opts.MinScore = 0.6 // Crucible default
+	}
+	if opts.MinScore < 0 || opts.MinScore > 1 {
+		return nil, fmt.Errorf("invalid min score: %v (must be in [0, 1])", opts.MinScore)
+	}
+
+	switch opts.Algorithm {
+	case AlgorithmLevenshtein, AlgorithmDamerauOSA, AlgorithmDamerauUnrestricted, AlgorithmJaroWinkler, AlgorithmSubstring:
+	default:
+		return nil, fmt.Errorf("invalid algorithm: %q", opts.Algorithm)
+	}
+
+	switch opts.Prefilter {
+	case PrefilterAuto:
+		if isEditDistance(opts.Algorithm) {
+			opts.Prefilter = PrefilterTrigram
+		} else {
+			opts.Prefilter = PrefilterNone
+		}
+	case PrefilterNone:
+	case PrefilterTrigram:
+		if !isEditDistance(opts.Algorithm) {
+			return nil, fmt.Errorf("prefilter %q requires an edit-distance algorithm, got %q", opts.Prefilter, opts.Algorithm)
+		}
+	case PrefilterBKTree:
+		if opts.Algorithm != AlgorithmLevenshtein && opts.Algorithm != AlgorithmDamerauUnrestricted {
+			return nil, fmt.Errorf("prefilter %q requires a metric algorithm (%s or %s), got %q",
+				opts.Prefilter, AlgorithmLevenshtein, AlgorithmDamerauUnrestricted, opts.Algorithm)
+		}
+	default:
+		return nil, fmt.Errorf("invalid prefilter: %q", opts.Prefilter)
+	}
+
+	index := &SuggestIndex{
+		opts:       opts,
+		values:     make([]string, len(candidates)),
+		normalized: make([]string, len(candidates)),
+		lengths:    make([]int, len(candidates)),
+	}
+	copy(index.values, candidates)
+
+	for i, candidate := range candidates {
+		normalized := candidate
+		if opts.Normalize {
+			normalized = Normalize(candidate, NormalizeOptions{})
+		}
+		index.normalized[i] = normalized
+		index.lengths[i] = len([]rune(normalized))
+		if index.lengths[i] > index.maxLength {
+			index.maxLength = index.lengths[i]
+		}
+	}
+
+	switch opts.Prefilter {
+	case PrefilterTrigram:
+		index.buildTrigrams()
+	case PrefilterBKTree:
+		index.buildBKTree()
+	}
+
+	index.scratch.New = func() interface{} {
+		return &indexScratch{shared: make([]int32, len(index.values))}
+	}
+
+	return index, nil
+}
+
+// Len returns the number of candidates in the index.
+func (ix *SuggestIndex) Len() int {
+	return len(ix.values)
+}
+
+// Options returns the index options with defaults applied.
+func (ix *SuggestIndex) Options() IndexOptions {
+	return ix.opts
+}
+
+// TopK returns up to k candidates scoring at least the index MinScore,
+// sorted by score (descending), then alphabetically. A k <= 0 returns all
+// matching candidates.
+//
+// Example:
+//
+//	index.TopK("docscrib", 3) // [{Value: "docscribe", Score: 0.8889}]
+func (ix *SuggestIndex) TopK(query string, k int) []Suggestion {
+	return ix.TopKWithMinScore(query, k, ix.opts.MinScore)
+}
+
+// TopKWithMinScore is TopK with a per-query minimum score.
+//
+// Prefilters apply only when minScore > 0.
+func (ix *SuggestIndex) TopKWithMinScore(query string, k int, minScore float64) []Suggestion {
+	emitAlgorithmCounter("index", ix.opts.Algorithm)
+
+	if ix.opts.Normalize {
+		query = Normalize(query, NormalizeOptions{})
+	}
+
+	var matches []Suggestion
+	ix.match(query, minScore, func(i int, score float64) {
+		matches = append(matches, Suggestion{Value: ix.values[i], Score: score})
+	})
+
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].Score != matches[b].Score {
+			return matches[a].Score > matches[b].Score
+		}
+		return matches[a].Value < matches[b].Value
+	})
+	if k > 0 && len(matches) > k {
+		matches = matches[:k]
+	}
+	if matches == nil {
+		return []Suggestion{}
+	}
+	return matches
+}
+
+// TopKBatch runs TopK for each query concurrently and returns the results in
+// query order.
+//
+// Example:
+//
+//	results := index.TopKBatch([]string{"confg", "schema"}, 3)
+//	// results[0] holds suggestions for "confg"
+func (ix *SuggestIndex) TopKBatch(queries []string, k int) [][]Suggestion {
+	results := make([][]Suggestion, len(queries))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(queries) {
+		workers = len(queries)
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = ix.TopK(queries[i], k)
+			}
+		}()
+	}
+	for i := range queries {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return results
+}
+
+// match calls fn with the index and score of every candidate scoring at
+// least minScore against an already-normalized query.
+func (ix *SuggestIndex) match(query string, minScore float64, fn func(i int, score float64)) {
+	collect := func(i int) {
+		if score := ix.score(query, i); score >= minScore {
+			fn(i, score)
+		}
+	}
+
+	switch {
+	case minScore <= 0 || ix.opts.Prefilter == PrefilterNone:
+		for i := range ix.values {
+			collect(i)
+		}
+	case ix.opts.Prefilter == PrefilterTrigram:
+		ix.searchTrigrams(query, minScore, collect)
+	case ix.opts.Prefilter == PrefilterBKTree:
+		ix.searchBKTree(query, minScore, collect)
+	}
+}
+
+// score computes the similarity of query to candidate i.
+func (ix *SuggestIndex) score(query string, i int) float64 {
+	candidate := ix.normalized[i]
+	if query == candidate {
+		return 1.0
+	}
+
+	switch ix.opts.Algorithm {
+	case AlgorithmJaroWinkler:
+		opts := DefaultScoreOptions()
+		return jaroWinklerScore(query, candidate, opts.JaroPrefixScale, opts.JaroMaxPrefix)
+	case AlgorithmSubstring:
+		_, score := substringMatch(query, candidate)
+		return score
+	}
+
+	maxLen := len([]rune(query))
+	if ix.lengths[i] > maxLen {
+		maxLen = ix.lengths[i]
+	}
+	if maxLen == 0 {
+		return 1.0
+	}
+	return 1.0 - float64(ix.distance(query, candidate))/float64(maxLen)
+}
+
+// distance computes the index algorithm's edit distance.
+func (ix *SuggestIndex) distance(a, b string) int {
+	switch ix.opts.Algorithm {
+	case AlgorithmDamerauOSA:
+		return damerauOSADistance(a, b)
+	case AlgorithmDamerauUnrestricted:
+		return damerauUnrestrictedDistance(a, b)
+	default:
+		return levenshteinDistance(a, b)
+	}
+}
+
+// isEditDistance reports whether scores derive from an edit distance.
+func isEditDistance(algorithm Algorithm) bool {
+	switch algorithm {
+	case AlgorithmLevenshtein, AlgorithmDamerauOSA, AlgorithmDamerauUnrestricted:
+		return true
+	}
+	return false
+}
+
+// maxDistance returns the largest edit distance that still scores at least
+// minScore when the longer string has maxLen runes.
+func maxDistance(maxLen int, minScore float64) int {
+	return int(math.Floor((1-minScore)*float64(maxLen) + 1e-9))
+}
+
+// lengthWindow returns the candidate lengths that can score at least
+// minScore against a query of length q: since distance >= |q - c|,
+// c must lie in [q*minScore, q/minScore].
+func lengthWindow(q int, minScore float64, maxLength int) (int, int) {
+	low := int(math.Ceil(float64(q)*minScore - 1e-9))
+	high := maxLength
+	if limit := float64(q) / minScore; limit < float64(maxLength) {
+		high = int(math.Floor(limit + 1e-9))
+	}
+	return low, high
+}
+
+// trigramKey packs a trigram of runes into a map key.
+func trigramKey(a, b, c rune) uint64 {
+	return uint64(a)<<42 | uint64(b)<<21 | uint64(c)
+}
+
+// trigramPadStart and trigramPadEnd pad strings so every rune appears in
+// three trigrams and edits at either end are counted.
+const (
+	trigramPadStart rune = 0
+	trigramPadEnd   rune = 1
+)
+
+// trigramCounts returns the multiset of padded trigrams in s. A string of n
+// runes has n+2 trigrams.
+func trigramCounts(s string) map[uint64]int32 {
+	runes := make([]rune, 0, len(s)+4)
+	runes = append(runes, trigramPadStart, trigramPadStart)
+	runes = append(runes, []rune(s)...)
+	runes = append(runes, trigramPadEnd, trigramPadEnd)
+
+	counts := make(map[uint64]int32, len(runes)-2)
+	for i := 0; i+2 < len(runes); i++ {
+		counts[trigramKey(runes[i], runes[i+1], runes[i+2])]++
+	}
+	return counts
+}
+
+// buildTrigrams builds the trigram inverted index. Postings are sorted by
+// candidate length, then candidate, so searches can skip candidates outside
+// the length window.
+func (ix *SuggestIndex) buildTrigrams() {
+	ix.trigrams = make(map[uint64][]trigramPosting)
+	for i, candidate := range ix.normalized {
+		for key, count := range trigramCounts(candidate) {
+			ix.trigrams[key] = append(ix.trigrams[key], trigramPosting{
+				candidate: int32(i),
+				length:    int32(ix.lengths[i]),
+				count:     count,
+			})
+		}
+	}
+	for _, postings := range ix.trigrams {
+		sort.SliceStable(postings, func(a, b int) bool { return postings[a].length < postings[b].length })
+	}
+}
+
+// searchTrigrams calls collect for each candidate that passes the length
+// window and q-gram count filter.
+//
+// By the q-gram lemma, strings within edit distance d share at least
+// max(q, c) + 2 - 3d padded trigrams (4d with transpositions, which touch
+// one more trigram). Lengths where that bound is not positive cannot be
+// filtered and are scanned.
+func (ix *SuggestIndex) searchTrigrams(query string, minScore float64, collect func(int)) {
+	q := len([]rune(query))
+	low, high := lengthWindow(q, minScore, ix.maxLength)
+	if low > high {
+		return
+	}
+
+	perEdit := 3
+	if ix.opts.Algorithm != AlgorithmLevenshtein {
+		perEdit = 4
+	}
+
+	// required[c-low] is the number of shared trigrams a candidate of length
+	// c needs; minNeed is the smallest positive requirement.
+	required := make([]int, high-low+1)
+	minNeed, unfiltered := 0, false
+	for c := low; c <= high; c++ {
+		longer := q
+		if c > longer {
+			longer = c
+		}
+		need := longer + 2 - perEdit*maxDistance(longer, minScore)
+		required[c-low] = need
+		if need <= 0 {
+			unfiltered = true
+		} else if minNeed == 0 || need < minNeed {
+			minNeed = need
+		}
+	}
+
+	if unfiltered {
+		for i, length := range ix.lengths {
+			if length >= low && length <= high && required[length-low] <= 0 {
+				collect(i)
+			}
+		}
+	}
+	if minNeed == 0 {
+		return
+	}
+
+	// Any Q-minNeed+1 of the query's Q trigram occurrences must include a
+	// shared one, so only the postings of the rarest trigrams are scanned;
+	// counts for the remaining trigrams are added by binary search.
+	type queryGram struct {
+		postings []trigramPosting
+		count    int32
+	}
+	counts := trigramCounts(query)
+	grams := make([]queryGram, 0, len(counts))
+	for key, count := range counts {
+		postings := ix.trigrams[key]
+		from := sort.Search(len(postings), func(j int) bool { return int(postings[j].length) >= low })
+		to := sort.Search(len(postings), func(j int) bool { return int(postings[j].length) > high })
+		grams = append(grams, queryGram{postings: postings[from:to], count: count})
+	}
+	sort.Slice(grams, func(a, b int) bool { return len(grams[a].postings) < len(grams[b].postings) })
+
+	total := q + 2
+	selected, covered := 0, 0
+	for selected < len(grams) && covered < total-minNeed+1 {
+		covered += int(grams[selected].count)
+		selected++
+	}
+
+	scratch := ix.scratch.Get().(*indexScratch)
+	defer ix.scratch.Put(scratch)
+
+	for _, gram := range grams[:selected] {
+		for _, posting := range gram.postings {
+			if scratch.shared[posting.candidate] == 0 {
+				scratch.touched = append(scratch.touched, posting.candidate)
+			}
+			scratch.shared[posting.candidate] += min(posting.count, gram.count)
+		}
+	}
+
+	for _, candidate := range scratch.touched {
+		length := int32(ix.lengths[candidate])
+		need := required[length-int32(low)]
+		shared := int(scratch.shared[candidate])
+		scratch.shared[candidate] = 0
+		if need <= 0 {
+			continue // already scanned
+		}
+
+		remaining := total - covered
+		for _, gram := range grams[selected:] {
+			if shared >= need || shared+remaining < need {
+				break
+			}
+			remaining -= int(gram.count)
+			postings := gram.postings
+			j := sort.Search(len(postings), func(j int) bool {
+				if postings[j].length != length {
+					return postings[j].length > length
+				}
+				return postings[j].candidate >= candidate
+			})
+			if j < len(postings) && postings[j].candidate == candidate {
+				shared += int(min(postings[j].count, gram.count))
+			}
+		}
+		if shared >= need {
+			collect(int(candidate))
+		}
+	}
+	scratch.touched = scratch.touched[:0]
+}
+
+// buildBKTree builds the BK-tree over normalized candidates.
+func (ix *SuggestIndex) buildBKTree() {
+	if len(ix.normalized) == 0 {
+		return
+	}
+	ix.bktree = make([]bkNode, 1, len(ix.normalized))
+	ix.bktree[0] = bkNode{candidate: 0}
+
+	for i := 1; i < len(ix.normalized); i++ {
+		node := 0
+		for {
+			d := ix.distance(ix.normalized[i], ix.normalized[ix.bktree[node].candidate])
+			child, ok := ix.bktree[node].children[d]
+			if !ok {
+				if ix.bktree[node].children == nil {
+					ix.bktree[node].children = make(map[int]int)
+				}
+				ix.bktree[node].children[d] = len(ix.bktree)
+				ix.bktree = append(ix.bktree, bkNode{candidate: i})
+				break
+			}
+			node = child
+		}
+	}
+}
+
+// searchBKTree calls collect for each candidate within the largest edit
+// distance that can still reach minScore.
+func (ix *SuggestIndex) searchBKTree(query string, minScore float64, collect func(int)) {
+	if len(ix.bktree) == 0 {
+		return
+	}
+
+	q := len([]rune(query))
+	_, high := lengthWindow(q, minScore, ix.maxLength)
+	longest := q
+	if high > longest {
+		longest = high
+	}
+	radius := maxDistance(longest, minScore)
+
+	stack := []int{0}
+	for len(stack) > 0 {
+		node := ix.bktree[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+
+		d := ix.distance(query, ix.normalized[node.candidate])
+		if d <= radius {
+			collect(node.candidate)
+		}
+		for childDistance, child := range node.children {
+			if childDistance >= d-radius && childDistance <= d+radius {
+				stack = append(stack, child)
+			}
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package similarity
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// indexTestCandidates generates n asset-like identifiers deterministically.
+// Path segments come from a vocabulary of syllable words so trigram
+// frequencies resemble real identifiers.
+func indexTestCandidates(n int, seed uint64) []string {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	syllables := []string{"ka", "lo", "mi", "ne", "ru", "sa", "ti", "vo", "xe", "zu", "br", "qu", "ph", "st", "ng"}
+	vocabulary := make([]string, 300)
+	for i := range vocabulary {
+		var word strings.Builder
+		for j := 0; j < 2+rng.IntN(3); j++ {
+			word.WriteString(syllables[rng.IntN(len(syllables))])
+		}
+		vocabulary[i] = word.String()
+	}
+
+	candidates := make([]string, n)
+	for i := range candidates {
+		parts := make([]string, 1+rng.IntN(3))
+		for j := range parts {
+			parts[j] = vocabulary[rng.IntN(len(vocabulary))]
+		}
+		candidates[i] = fmt.Sprintf("%s/%s-%d", strings.Join(parts, "/"), string(rune('a'+rng.IntN(26))), rng.IntN(1000))
+	}
+	return candidates
+}
+
+// mutate applies a few random edits to s.
+func mutate(rng *rand.Rand, s string, edits int) string {
+	runes := []rune(s)
+	for e := 0; e < edits && len(runes) > 1; e++ {
+		i := rng.IntN(len(runes) - 1)
+		switch rng.IntN(4) {
+		case 0:
+			runes = append(runes[:i], runes[i+1:]...)
+		case 1:
+			runes = append(runes[:i], append([]rune{'x'}, runes[i:]...)...)
+		case 2:
+			runes[i] = 'z'
+		default:
+			runes[i], runes[i+1] = runes[i+1], runes[i]
+		}
+	}
+	return string(runes)
+}
+
+func TestDefaultIndexOptions(t *testing.T) {
+	opts := DefaultIndexOptions()
+	if opts.Algorithm != AlgorithmLevenshtein || opts.MinScore != 0.6 || !opts.Normalize || opts.Prefilter != PrefilterAuto {
+		t.Errorf("DefaultIndexOptions() = %+v", opts)
+	}
+}
+
+func TestNewSuggestIndex_Options(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      IndexOptions
+		prefilter Prefilter
+		wantErr   bool
+	}{
+		{"Defaults", IndexOptions{}, PrefilterTrigram, false},
+		{"Auto_JaroWinkler", IndexOptions{Algorithm: AlgorithmJaroWinkler}, PrefilterNone, false},
+		{"BKTree_Levenshtein", IndexOptions{Prefilter: PrefilterBKTree}, PrefilterBKTree, false},
+		{"BKTree_Unrestricted", IndexOptions{Algorithm: AlgorithmDamerauUnrestricted, Prefilter: PrefilterBKTree}, PrefilterBKTree, false},
+		{"BKTree_OSA", IndexOptions{Algorithm: AlgorithmDamerauOSA, Prefilter: PrefilterBKTree}, "", true},
+		{"Trigram_Substring", IndexOptions{Algorithm: AlgorithmSubstring, Prefilter: PrefilterTrigram}, "", true},
+		{"UnknownAlgorithm", IndexOptions{Algorithm: "soundex"}, "", true},
+		{"UnknownPrefilter", IndexOptions{Prefilter: "lsh"}, "", true},
+		{"InvalidMinScore", IndexOptions{MinScore: 1.5}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, err := NewSuggestIndex([]string{"alpha", "beta"}, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSuggestIndex() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := index.Options().Prefilter; got != tt.prefilter {
+				t.Errorf("Prefilter = %q, want %q", got, tt.prefilter)
+			}
+			if index.Len() != 2 {
+				t.Errorf("Len() = %d, want 2", index.Len())
+			}
+		})
+	}
+}
+
+// TestSuggestIndex_MatchesSuggest tests that the index ranks like Suggest
+func TestSuggestIndex_MatchesSuggest(t *testing.T) {
+	candidates := []string{"docscribe", "crucible", "foundry", "similarity", "Config", "configure", "conform"}
+	index, err := NewSuggestIndex(candidates, DefaultIndexOptions())
+	if err != nil {
+		t.Fatalf("NewSuggestIndex() error: %v", err)
+	}
+
+	for _, query := range []string{"docscrib", "CONFIG", "confgi", "fundry", "xyz", ""} {
+		want := Suggest(query, candidates, DefaultSuggestOptions())
+		got := index.TopK(query, 3)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("TopK(%q) = %v, Suggest = %v", query, got, want)
+		}
+	}
+}
+
+// TestSuggestIndex_PrefiltersExact tests that prefilters never drop matches
+func TestSuggestIndex_PrefiltersExact(t *testing.T) {
+	candidates := indexTestCandidates(500, 1)
+	candidates = append(candidates, "", "a", "ab", "Café", "cafe")
+	rng := rand.New(rand.NewPCG(2, 2))
+
+	queries := []string{"", "a", "cafe", candidates[0]}
+	for i := 0; i < 40; i++ {
+		queries = append(queries, mutate(rng, candidates[rng.IntN(len(candidates))], 1+rng.IntN(4)))
+	}
+
+	configs := []IndexOptions{
+		{Algorithm: AlgorithmLevenshtein, Prefilter: PrefilterTrigram},
+		{Algorithm: AlgorithmLevenshtein, Prefilter: PrefilterBKTree},
+		{Algorithm: AlgorithmDamerauOSA, Prefilter: PrefilterTrigram},
+		{Algorithm: AlgorithmDamerauUnrestricted, Prefilter: PrefilterTrigram},
+		{Algorithm: AlgorithmDamerauUnrestricted, Prefilter: PrefilterBKTree},
+	}
+
+	for _, cfg := range configs {
+		for _, minScore := range []float64{0.3, 0.6, 0.85, 1.0} {
+			name := fmt.Sprintf("%s_%s_%.2f", cfg.Algorithm, cfg.Prefilter, minScore)
+			t.Run(name, func(t *testing.T) {
+				opts := cfg
+				opts.Normalize = true
+				filtered, err := NewSuggestIndex(candidates, opts)
+				if err != nil {
+					t.Fatalf("NewSuggestIndex() error: %v", err)
+				}
+				opts.Prefilter = PrefilterNone
+				exhaustive, err := NewSuggestIndex(candidates, opts)
+				if err != nil {
+					t.Fatalf("NewSuggestIndex() error: %v", err)
+				}
+
+				for _, query := range queries {
+					want := exhaustive.TopKWithMinScore(query, 0, minScore)
+					got := filtered.TopKWithMinScore(query, 0, minScore)
+					if !reflect.DeepEqual(got, want) {
+						t.Fatalf("TopKWithMinScore(%q) returned %d matches, exhaustive %d", query, len(got), len(want))
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestSuggestIndex_ScoresMatchScoreWithAlgorithm tests score parity with the unified API
+func TestSuggestIndex_ScoresMatchScoreWithAlgorithm(t *testing.T) {
+	candidates := []string{"martha", "marhta", "hello world", "kitten", "sitting"}
+	for _, algorithm := range []Algorithm{AlgorithmLevenshtein, AlgorithmDamerauOSA, AlgorithmDamerauUnrestricted, AlgorithmJaroWinkler, AlgorithmSubstring} {
+		index, err := NewSuggestIndex(candidates, IndexOptions{Algorithm: algorithm, MinScore: 0.01})
+		if err != nil {
+			t.Fatalf("NewSuggestIndex(%s) error: %v", algorithm, err)
+		}
+		for _, query := range []string{"martha", "hello", "sitten"} {
+			for _, s := range index.TopK(query, 0) {
+				want, _ := ScoreWithAlgorithm(query, s.Value, algorithm, nil)
+				if s.Score != want {
+					t.Errorf("%s: score(%q, %q) = %v, want %v", algorithm, query, s.Value, s.Score, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSuggestIndex_TopK(t *testing.T) {
+	index, err := NewSuggestIndex([]string{"config", "configs", "confirm", "conform", "zebra"}, DefaultIndexOptions())
+	if err != nil {
+		t.Fatalf("NewSuggestIndex() error: %v", err)
+	}
+
+	got := index.TopK("config", 2)
+	if len(got) != 2 || got[0].Value != "config" || got[0].Score != 1.0 || got[1].Value != "configs" {
+		t.Errorf("TopK(\"config\", 2) = %v", got)
+	}
+
+	all := index.TopK("config", 0)
+	if len(all) != 3 {
+		t.Errorf("TopK(\"config\", 0) = %v, want 3 matches", all)
+	}
+
+	strict := index.TopKWithMinScore("config", 0, 0.99)
+	if len(strict) != 1 {
+		t.Errorf("TopKWithMinScore(0.99) = %v, want exact match only", strict)
+	}
+
+	if none := index.TopK("qqqqqq", 3); none == nil || len(none) != 0 {
+		t.Errorf("Expected empty non-nil slice, got %#v", none)
+	}
+
+	empty, _ := NewSuggestIndex(nil, IndexOptions{Prefilter: PrefilterBKTree})
+	if got := empty.TopK("config", 3); len(got) != 0 {
+		t.Errorf("Expected no results from empty index, got %v", got)
+	}
+}
+
+func TestSuggestIndex_TopKBatch(t *testing.T) {
+	candidates := indexTestCandidates(500, 3)
+	index, err := NewSuggestIndex(candidates, DefaultIndexOptions())
+	if err != nil {
+		t.Fatalf("NewSuggestIndex() error: %v", err)
+	}
+
+	queries := []string{candidates[0], candidates[10], "nothing-like-it", candidates[42]}
+	results := index.TopKBatch(queries, 3)
+	if len(results) != len(queries) {
+		t.Fatalf("TopKBatch() returned %d results, want %d", len(results), len(queries))
+	}
+	for i, query := range queries {
+		if want := index.TopK(query, 3); !reflect.DeepEqual(results[i], want) {
+			t.Errorf("TopKBatch()[%d] = %v, want %v", i, results[i], want)
+		}
+	}
+
+	if got := index.TopKBatch(nil, 3); len(got) != 0 {
+		t.Errorf("TopKBatch(nil) = %v", got)
+	}
+}
+
+// BenchmarkSuggestIndex_TopK_100k benchmarks lookups over 100k candidates
+func BenchmarkSuggestIndex_TopK_100k(b *testing.B) {
+	candidates := indexTestCandidates(100000, 7)
+	rng := rand.New(rand.NewPCG(8, 8))
+	queries := make([]string, 64)
+	for i := range queries {
+		queries[i] = mutate(rng, candidates[rng.IntN(len(candidates))], 2)
+	}
+
+	for _, prefilter := range []Prefilter{PrefilterTrigram, PrefilterBKTree} {
+		index, err := NewSuggestIndex(candidates, IndexOptions{Normalize: true, MinScore: 0.8, Prefilter: prefilter})
+		if err != nil {
+			b.Fatalf("NewSuggestIndex() error: %v", err)
+		}
+		b.Run(string(prefilter), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				index.TopK(queries[i%len(queries)], 5)
+			}
+		})
+	}
+}
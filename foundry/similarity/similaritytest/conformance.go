@@ -0,0 +1,48 @@
+// Package similaritytest provides test helpers for verifying similarity implementations
+// against the shared Crucible fixtures.
+package similaritytest
+
+import (
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/foundry/similarity"
+)
+
+// RunFixtureConformance runs every case in a Crucible similarity fixtures file
+// as a subtest named "<category>/<index> <description>". Failing cases fail their
+// subtest; cases needing unsupported features are skipped with the reason.
+//
+// It returns the underlying report for additional assertions.
+//
+// Example:
+//
+//	func TestSimilarityConformance(t *testing.T) {
+//	    similaritytest.RunFixtureConformance(t, "config/crucible-go/library/foundry/similarity-fixtures.yaml")
+//	}
+func RunFixtureConformance(t *testing.T, fixturesPath string) *similarity.ConformanceReport {
+	t.Helper()
+
+	report, err := similarity.RunConformance(fixturesPath)
+	if err != nil {
+		t.Fatalf("Failed to run similarity conformance: %v", err)
+	}
+
+	for _, result := range report.Results {
+		t.Run(result.Name(), func(t *testing.T) {
+			switch result.Status {
+			case similarity.ConformanceFail:
+				t.Error(result.Message)
+			case similarity.ConformanceSkip:
+				t.Skip(result.Message)
+			}
+		})
+	}
+
+	t.Logf("Similarity conformance (fixtures %s): %d passed, %d failed, %d skipped",
+		report.Version,
+		report.Count(similarity.ConformancePass),
+		report.Count(similarity.ConformanceFail),
+		report.Count(similarity.ConformanceSkip))
+
+	return report
+}
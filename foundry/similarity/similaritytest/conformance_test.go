@@ -0,0 +1,18 @@
+package similaritytest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/foundry/similarity"
+)
+
+func TestRunFixtureConformance(t *testing.T) {
+	fixturesPath := filepath.Join("..", "..", "..", "config", "crucible-go", "library", "foundry", "similarity-fixtures.yaml")
+
+	report := RunFixtureConformance(t, fixturesPath)
+
+	if report.Count(similarity.ConformancePass) == 0 {
+		t.Error("Expected passing conformance cases")
+	}
+}
@@ -0,0 +1,16 @@
+package foundry
+
+import (
+	"context"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+)
+
+// init links foundry correlation IDs to telemetry, so System.CounterCtx and
+// System.HistogramCtx attach the CorrelationID from ctx as an exemplar.
+func init() {
+	telemetry.SetCorrelationIDFunc(func(ctx context.Context) (string, bool) {
+		id, ok := CorrelationIDFromContext(ctx)
+		return string(id), ok
+	})
+}
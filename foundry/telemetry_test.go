@@ -0,0 +1,33 @@
+package foundry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+	teltesting "github.com/fulmenhq/gofulmen/telemetry/testing"
+)
+
+// TestTelemetryExemplarLinkage tests that context correlation IDs reach telemetry exemplars
+func TestTelemetryExemplarLinkage(t *testing.T) {
+	collector := teltesting.NewFakeCollector()
+	sys, err := telemetry.NewSystem(&telemetry.Config{Enabled: true, Emitter: collector})
+	if err != nil {
+		t.Fatalf("NewSystem() error = %v", err)
+	}
+
+	id := NewCorrelationIDValue()
+	ctx := WithCorrelationID(context.Background(), id)
+	if err := sys.HistogramCtx(ctx, "request_ms", 5*time.Millisecond, nil); err != nil {
+		t.Fatalf("HistogramCtx() error = %v", err)
+	}
+
+	exemplars := collector.GetExemplars()
+	if len(exemplars) != 1 {
+		t.Fatalf("expected 1 exemplar, got %d", len(exemplars))
+	}
+	if got := exemplars[0].Exemplar.Labels[telemetry.CorrelationIDTag]; got != string(id) {
+		t.Errorf("exemplar correlation_id = %q, want %q", got, id)
+	}
+}
@@ -3,6 +3,8 @@
 // This package includes:
 //   - RFC3339Nano timestamp generation
 //   - UUIDv7 correlation ID generation
+//   - ULID and UUIDv7 identifier types
+//   - ByteSize and HumanDuration config value types
 //   - Pattern matching from Crucible catalogs
 //   - MIME type detection
 //   - HTTP status helpers
@@ -0,0 +1,150 @@
+package foundry
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Timezone represents an IANA time zone from the Foundry catalog.
+//
+// Offsets reflect the tzdata release the catalog was generated from; use
+// Location for historical or future conversions.
+type Timezone struct {
+	// ID is the IANA time zone identifier (e.g., "America/New_York").
+	ID string
+
+	// Countries are the ISO 3166-1 alpha-2 codes of the countries using this
+	// zone (e.g., ["US"]). Empty for zones such as "Etc/UTC".
+	Countries []string
+
+	// UTCOffset is the standard time offset from UTC (e.g., -5h for New York).
+	UTCOffset time.Duration
+
+	// DSTOffset is the daylight saving time offset from UTC (e.g., -4h for
+	// New York). Equal to UTCOffset for zones without daylight saving time.
+	DSTOffset time.Duration
+
+	// Aliases are IANA link names for this zone (e.g., ["US/Eastern"]).
+	Aliases []string
+}
+
+// ObservesDST reports whether the zone has daylight saving time.
+func (tz *Timezone) ObservesDST() bool {
+	return tz.DSTOffset != tz.UTCOffset
+}
+
+// MatchesID checks if the given ID matches this zone's ID or one of its aliases.
+//
+// Matching is case-insensitive.
+//
+// Example:
+//
+//	timezone, _ := GetTimezone("Asia/Kolkata")
+//	timezone.MatchesID("asia/calcutta") // true
+func (tz *Timezone) MatchesID(id string) bool {
+	if strings.EqualFold(tz.ID, id) {
+		return true
+	}
+	for _, alias := range tz.Aliases {
+		if strings.EqualFold(alias, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Location loads the zone's *time.Location.
+//
+// Requires tzdata on the host, or an import of time/tzdata in the binary.
+func (tz *Timezone) Location() (*time.Location, error) {
+	loc, err := time.LoadLocation(tz.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load location %s: %w", tz.ID, err)
+	}
+	return loc, nil
+}
+
+// FormatUTCOffset formats a UTC offset as "+hh:mm" or "-hh:mm".
+//
+// Example:
+//
+//	FormatUTCOffset(5*time.Hour + 30*time.Minute) // "+05:30"
+func FormatUTCOffset(offset time.Duration) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	minutes := int(offset / time.Minute)
+	return fmt.Sprintf("%s%02d:%02d", sign, minutes/60, minutes%60)
+}
+
+// GetTimezone retrieves a time zone by its IANA ID or alias from the default catalog.
+//
+// Returns nil if the time zone is not found or if an error occurs.
+//
+// Example:
+//
+//	timezone, err := GetTimezone("Europe/Paris")
+//	if err != nil {
+//	    // Handle error
+//	}
+//	if timezone != nil {
+//	    fmt.Println(timezone.Countries) // [FR]
+//	}
+func GetTimezone(id string) (*Timezone, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.GetTimezone(id)
+}
+
+// ValidateTimezoneID checks if the given ID is an IANA time zone ID or alias
+// in the catalog.
+//
+// Matching is case-insensitive.
+//
+// Example:
+//
+//	ValidateTimezoneID("America/New_York") // true
+//	ValidateTimezoneID("US/Eastern")       // true (alias)
+//	ValidateTimezoneID("Mars/Olympus")     // false
+func ValidateTimezoneID(id string) bool {
+	if id == "" {
+		return false
+	}
+	timezone, _ := GetDefaultCatalog().GetTimezone(id)
+	return timezone != nil
+}
+
+// ListTimezones returns all time zones from the default catalog, sorted by ID.
+//
+// Example:
+//
+//	timezones, err := ListTimezones()
+//	if err != nil {
+//	    // Handle error
+//	}
+//	for _, timezone := range timezones {
+//	    fmt.Println(timezone.ID)
+//	}
+func ListTimezones() ([]*Timezone, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.ListTimezones()
+}
+
+// TimezonesForCountry returns the time zones used in a country from the
+// default catalog, sorted by ID.
+//
+// Example:
+//
+//	timezones, err := TimezonesForCountry(MustCountryCode("US"))
+//	if err != nil {
+//	    // Handle error
+//	}
+//	for _, timezone := range timezones {
+//	    fmt.Println(timezone.ID) // "America/Adak", "America/Anchorage", ...
+//	}
+func TimezonesForCountry(code CountryCode) ([]*Timezone, error) {
+	catalog := GetDefaultCatalog()
+	return catalog.TimezonesForCountry(code)
+}
@@ -0,0 +1,221 @@
+package foundry
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimezoneID is a validated IANA time zone identifier.
+//
+// Accepts zone IDs (America/New_York) and their aliases (US/Eastern),
+// case-insensitively, normalized to IANA spelling. Implements standard Go
+// interfaces for seamless integration with JSON, YAML, TOML, and SQL
+// databases.
+//
+// The zero value is an invalid time zone ID. Use NewTimezoneID or
+// MustTimezoneID to create valid instances.
+//
+// Example:
+//
+//	type User struct {
+//	    Name     string     `json:"name"`
+//	    Timezone TimezoneID `json:"timezone" db:"timezone"`
+//	}
+//
+//	user := User{Name: "Alice", Timezone: MustTimezoneID("europe/paris")}
+//	json.Marshal(user) // {"name":"Alice","timezone":"Europe/Paris"}
+type TimezoneID string
+
+// NewTimezoneID creates a validated TimezoneID.
+//
+// Aliases are kept as given (use Canonical to resolve them) but normalized
+// to IANA spelling. Returns an error if the ID is not in the catalog.
+//
+// Example:
+//
+//	id, err := NewTimezoneID("America/New_York") // → "America/New_York"
+//	id, err := NewTimezoneID("asia/tokyo")       // → "Asia/Tokyo"
+//	id, err := NewTimezoneID("US/Pacific")       // → "US/Pacific" (alias)
+func NewTimezoneID(id string) (TimezoneID, error) {
+	if id == "" {
+		return "", fmt.Errorf("timezone ID cannot be empty")
+	}
+
+	timezone, err := GetTimezone(id)
+	if err != nil {
+		return "", err
+	}
+	if timezone == nil {
+		return "", fmt.Errorf("invalid timezone ID: %s", id)
+	}
+
+	// Normalize to the catalog spelling of the matched ID or alias
+	if strings.EqualFold(timezone.ID, id) {
+		return TimezoneID(timezone.ID), nil
+	}
+	for _, alias := range timezone.Aliases {
+		if strings.EqualFold(alias, id) {
+			return TimezoneID(alias), nil
+		}
+	}
+	return TimezoneID(timezone.ID), nil
+}
+
+// MustTimezoneID creates a TimezoneID or panics if invalid.
+//
+// Use this for package-level defaults or when the ID is known to be valid.
+//
+// Example:
+//
+//	var DefaultTimezone = MustTimezoneID("Etc/UTC")
+func MustTimezoneID(id string) TimezoneID {
+	t, err := NewTimezoneID(id)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// String returns the time zone ID as a string.
+func (t TimezoneID) String() string {
+	return string(t)
+}
+
+// Validate checks if the time zone ID is valid.
+//
+// Returns an error if the ID is not a recognized IANA zone or alias.
+func (t TimezoneID) Validate() error {
+	if t == "" {
+		return fmt.Errorf("timezone ID is empty")
+	}
+
+	if !ValidateTimezoneID(string(t)) {
+		return fmt.Errorf("invalid timezone ID: %s", t)
+	}
+
+	return nil
+}
+
+// IsValid returns true if the time zone ID is valid.
+func (t TimezoneID) IsValid() bool {
+	return t.Validate() == nil
+}
+
+// Timezone retrieves the full Timezone metadata from the catalog.
+//
+// Returns an error if the ID is invalid or the catalog cannot be loaded.
+//
+// Example:
+//
+//	id := MustTimezoneID("Asia/Kolkata")
+//	timezone, err := id.Timezone()
+//	if err == nil {
+//	    fmt.Println(FormatUTCOffset(timezone.UTCOffset)) // "+05:30"
+//	}
+func (t TimezoneID) Timezone() (*Timezone, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	timezone, err := GetTimezone(string(t))
+	if err != nil {
+		return nil, err
+	}
+	if timezone == nil {
+		return nil, fmt.Errorf("timezone not found for ID: %s", t)
+	}
+
+	return timezone, nil
+}
+
+// Canonical returns the zone ID for an alias (e.g., "US/Eastern" →
+// "America/New_York"), or the ID itself if it is not an alias.
+//
+// Returns an error if the ID is invalid.
+func (t TimezoneID) Canonical() (TimezoneID, error) {
+	timezone, err := t.Timezone()
+	if err != nil {
+		return "", err
+	}
+	return TimezoneID(timezone.ID), nil
+}
+
+// Location loads the zone's *time.Location.
+//
+// Requires tzdata on the host, or an import of time/tzdata in the binary.
+//
+// Example:
+//
+//	loc, err := MustTimezoneID("Europe/Berlin").Location()
+//	if err == nil {
+//	    fmt.Println(time.Now().In(loc))
+//	}
+func (t TimezoneID) Location() (*time.Location, error) {
+	timezone, err := t.Timezone()
+	if err != nil {
+		return nil, err
+	}
+	return timezone.Location()
+}
+
+// MarshalText implements encoding.TextMarshaler for JSON, YAML, TOML support.
+//
+// The time zone ID is marshaled as-is (IANA spelling).
+func (t TimezoneID) MarshalText() ([]byte, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(t), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for JSON, YAML, TOML support.
+//
+// Validates and normalizes the time zone ID on unmarshal.
+func (t *TimezoneID) UnmarshalText(text []byte) error {
+	id, err := NewTimezoneID(string(text))
+	if err != nil {
+		return err
+	}
+	*t = id
+	return nil
+}
+
+// Value implements database/sql/driver.Valuer for database integration.
+//
+// The time zone ID is stored as a string (VARCHAR/TEXT column).
+func (t TimezoneID) Value() (driver.Value, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return string(t), nil
+}
+
+// Scan implements database/sql.Scanner for database integration.
+//
+// Reads time zone IDs from VARCHAR/TEXT columns with validation.
+func (t *TimezoneID) Scan(src interface{}) error {
+	if src == nil {
+		*t = ""
+		return nil
+	}
+
+	var id string
+	switch v := src.(type) {
+	case string:
+		id = v
+	case []byte:
+		id = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into TimezoneID", src)
+	}
+
+	parsed, err := NewTimezoneID(id)
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
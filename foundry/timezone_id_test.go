@@ -0,0 +1,220 @@
+package foundry
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestNewTimezoneID tests creating TimezoneID from valid and invalid inputs
+func TestNewTimezoneID(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"Zone", "America/New_York", "America/New_York", false},
+		{"Lowercase", "asia/tokyo", "Asia/Tokyo", false},
+		{"Alias", "us/pacific", "US/Pacific", false},
+		{"UTC", "utc", "UTC", false},
+		{"Unknown", "Mars/Olympus_Mons", "", true},
+		{"Abbreviation", "PST", "", true},
+		{"Empty", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := NewTimezoneID(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTimezoneID(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && string(id) != tt.expected {
+				t.Errorf("NewTimezoneID(%q) = %q, want %q", tt.input, id, tt.expected)
+			}
+		})
+	}
+}
+
+// TestMustTimezoneID_Panic tests that MustTimezoneID panics on invalid input
+func TestMustTimezoneID_Panic(t *testing.T) {
+	if id := MustTimezoneID("etc/utc"); id != "Etc/UTC" {
+		t.Errorf("MustTimezoneID(\"etc/utc\") = %q, want \"Etc/UTC\"", id)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected MustTimezoneID to panic on invalid ID")
+		}
+	}()
+	MustTimezoneID("Mars/Olympus_Mons")
+}
+
+// TestTimezoneID_Validate tests Validate and IsValid
+func TestTimezoneID_Validate(t *testing.T) {
+	tests := []struct {
+		id      TimezoneID
+		wantErr bool
+	}{
+		{"Europe/Berlin", false},
+		{"Asia/Calcutta", false},
+		{"Mars/Olympus_Mons", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.id), func(t *testing.T) {
+			err := tt.id.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TimezoneID(%q).Validate() error = %v, wantErr %v", tt.id, err, tt.wantErr)
+			}
+			if tt.id.IsValid() == tt.wantErr {
+				t.Errorf("TimezoneID(%q).IsValid() = %v, want %v", tt.id, !tt.wantErr, !tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestTimezoneID_Canonical tests alias resolution
+func TestTimezoneID_Canonical(t *testing.T) {
+	tests := []struct {
+		id       TimezoneID
+		expected TimezoneID
+	}{
+		{"US/Eastern", "America/New_York"},
+		{"Asia/Calcutta", "Asia/Kolkata"},
+		{"Europe/Paris", "Europe/Paris"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.id), func(t *testing.T) {
+			canonical, err := tt.id.Canonical()
+			if err != nil {
+				t.Fatalf("Canonical() error: %v", err)
+			}
+			if canonical != tt.expected {
+				t.Errorf("Canonical() = %q, want %q", canonical, tt.expected)
+			}
+		})
+	}
+
+	if _, err := TimezoneID("Mars/Olympus_Mons").Canonical(); err == nil {
+		t.Error("Expected error for invalid timezone ID")
+	}
+}
+
+// TestTimezoneID_Location tests loading a *time.Location
+func TestTimezoneID_Location(t *testing.T) {
+	loc, err := MustTimezoneID("UTC").Location()
+	if err != nil {
+		t.Fatalf("Location() error: %v", err)
+	}
+	if loc.String() != "Etc/UTC" {
+		t.Errorf("Location() = %q, want \"Etc/UTC\"", loc.String())
+	}
+
+	if _, err := TimezoneID("Mars/Olympus_Mons").Location(); err == nil {
+		t.Error("Expected error for invalid timezone ID")
+	}
+}
+
+// TestTimezoneID_JSONRoundTrip tests JSON marshaling and unmarshaling
+func TestTimezoneID_JSONRoundTrip(t *testing.T) {
+	type User struct {
+		Name     string     `json:"name"`
+		Timezone TimezoneID `json:"timezone"`
+	}
+
+	data, err := json.Marshal(User{Name: "Alice", Timezone: MustTimezoneID("europe/paris")})
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %v", err)
+	}
+	if string(data) != `{"name":"Alice","timezone":"Europe/Paris"}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var user User
+	if err := json.Unmarshal([]byte(`{"name":"Bob","timezone":"america/chicago"}`), &user); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if user.Timezone != "America/Chicago" {
+		t.Errorf("json.Unmarshal() Timezone = %q, want \"America/Chicago\"", user.Timezone)
+	}
+
+	if err := json.Unmarshal([]byte(`{"timezone":"Mars/Olympus_Mons"}`), &user); err == nil {
+		t.Error("Expected error unmarshaling invalid timezone ID")
+	}
+
+	if _, err := json.Marshal(User{Timezone: "Mars/Olympus_Mons"}); err == nil {
+		t.Error("Expected error marshaling invalid timezone ID")
+	}
+}
+
+// TestTimezoneID_YAMLRoundTrip tests YAML marshaling and unmarshaling
+func TestTimezoneID_YAMLRoundTrip(t *testing.T) {
+	type Config struct {
+		Timezone TimezoneID `yaml:"timezone"`
+	}
+
+	data, err := yaml.Marshal(Config{Timezone: "Asia/Tokyo"})
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error: %v", err)
+	}
+	if !containsString(string(data), "timezone: Asia/Tokyo") {
+		t.Errorf("yaml.Marshal() = %q", data)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal([]byte("timezone: australia/sydney\n"), &config); err != nil {
+		t.Fatalf("yaml.Unmarshal() error: %v", err)
+	}
+	if config.Timezone != "Australia/Sydney" {
+		t.Errorf("yaml.Unmarshal() Timezone = %q, want \"Australia/Sydney\"", config.Timezone)
+	}
+}
+
+// TestTimezoneID_Database tests database/sql Value and Scan
+func TestTimezoneID_Database(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected TimezoneID
+		wantErr  bool
+	}{
+		{"String", "Europe/London", "Europe/London", false},
+		{"Bytes", []byte("asia/singapore"), "Asia/Singapore", false},
+		{"Nil", nil, "", false},
+		{"Invalid_String", "Mars/Olympus_Mons", "", true},
+		{"Invalid_Type", 42, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id TimezoneID
+			err := id.Scan(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TimezoneID.Scan(%v) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr || id == "" {
+				return
+			}
+			if id != tt.expected {
+				t.Errorf("TimezoneID.Scan(%v) = %q, want %q", tt.input, id, tt.expected)
+			}
+
+			value, err := id.Value()
+			if err != nil {
+				t.Fatalf("Value() error: %v", err)
+			}
+			if value != string(tt.expected) {
+				t.Errorf("Value() = %v, want %q", value, tt.expected)
+			}
+		})
+	}
+
+	if _, err := TimezoneID("").Value(); err == nil {
+		t.Error("Expected error from Value() on empty timezone ID")
+	}
+}
@@ -0,0 +1,182 @@
+package foundry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetTimezone(t *testing.T) {
+	tests := []struct {
+		input     string
+		id        string
+		countries []string
+		utcOffset time.Duration
+		dstOffset time.Duration
+	}{
+		{"America/New_York", "America/New_York", []string{"US"}, -5 * time.Hour, -4 * time.Hour},
+		{"us/eastern", "America/New_York", []string{"US"}, -5 * time.Hour, -4 * time.Hour}, // alias
+		{"Asia/Calcutta", "Asia/Kolkata", []string{"IN"}, 5*time.Hour + 30*time.Minute, 5*time.Hour + 30*time.Minute},
+		{"Australia/Sydney", "Australia/Sydney", []string{"AU"}, 10 * time.Hour, 11 * time.Hour},
+		{"UTC", "Etc/UTC", nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			timezone, err := GetTimezone(tt.input)
+			if err != nil {
+				t.Fatalf("Failed to get timezone: %v", err)
+			}
+
+			if timezone == nil {
+				t.Fatalf("Expected non-nil timezone for %q", tt.input)
+			}
+
+			if timezone.ID != tt.id {
+				t.Errorf("Expected ID %q, got %q", tt.id, timezone.ID)
+			}
+			if len(timezone.Countries) != len(tt.countries) || (len(tt.countries) > 0 && timezone.Countries[0] != tt.countries[0]) {
+				t.Errorf("Expected countries %v, got %v", tt.countries, timezone.Countries)
+			}
+			if timezone.UTCOffset != tt.utcOffset {
+				t.Errorf("Expected UTC offset %v, got %v", tt.utcOffset, timezone.UTCOffset)
+			}
+			if timezone.DSTOffset != tt.dstOffset {
+				t.Errorf("Expected DST offset %v, got %v", tt.dstOffset, timezone.DSTOffset)
+			}
+			if timezone.ObservesDST() != (tt.utcOffset != tt.dstOffset) {
+				t.Errorf("ObservesDST() = %v", timezone.ObservesDST())
+			}
+			if !timezone.MatchesID(tt.input) {
+				t.Errorf("Expected %q to match %s", tt.input, timezone.ID)
+			}
+		})
+	}
+}
+
+func TestGetTimezone_NotFound(t *testing.T) {
+	timezone, err := GetTimezone("Mars/Olympus_Mons")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if timezone != nil {
+		t.Error("Expected nil timezone for non-existent ID")
+	}
+}
+
+func TestValidateTimezoneID(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"Europe/London", true},
+		{"europe/london", true},
+		{"GB", true}, // alias
+		{"Etc/UTC", true},
+		{"Mars/Olympus_Mons", false},
+		{"EST", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := ValidateTimezoneID(tt.id); got != tt.valid {
+				t.Errorf("ValidateTimezoneID(%q) = %v, want %v", tt.id, got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestListTimezones(t *testing.T) {
+	timezones, err := ListTimezones()
+	if err != nil {
+		t.Fatalf("Failed to list timezones: %v", err)
+	}
+
+	if len(timezones) < 400 {
+		t.Errorf("Expected at least 400 timezones, got %d", len(timezones))
+	}
+
+	for i, timezone := range timezones {
+		if i > 0 && timezones[i-1].ID >= timezone.ID {
+			t.Errorf("Timezones not sorted: %s before %s", timezones[i-1].ID, timezone.ID)
+		}
+		if timezone.DSTOffset < timezone.UTCOffset {
+			t.Errorf("%s: DST offset %v before standard offset %v", timezone.ID, timezone.DSTOffset, timezone.UTCOffset)
+		}
+	}
+}
+
+func TestTimezonesForCountry(t *testing.T) {
+	tests := []struct {
+		code     CountryCode
+		contains string
+		minCount int
+	}{
+		{"US", "America/New_York", 20},
+		{"us", "America/Los_Angeles", 20},
+		{"USA", "America/Chicago", 20}, // Alpha-3 resolved via country catalog
+		{"840", "America/Denver", 20},  // Numeric resolved via country catalog
+		{"FR", "Europe/Paris", 1},
+		{"IN", "Asia/Kolkata", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			timezones, err := TimezonesForCountry(tt.code)
+			if err != nil {
+				t.Fatalf("TimezonesForCountry(%q) error: %v", tt.code, err)
+			}
+
+			if len(timezones) < tt.minCount {
+				t.Errorf("Expected at least %d timezones, got %d", tt.minCount, len(timezones))
+			}
+
+			found := false
+			for _, timezone := range timezones {
+				if timezone.ID == tt.contains {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Expected %s in timezones for %s", tt.contains, tt.code)
+			}
+		})
+	}
+
+	if _, err := TimezonesForCountry("ZZZ"); err == nil {
+		t.Error("Expected error for invalid Alpha-3 country code")
+	}
+
+	timezones, err := TimezonesForCountry("ZZ")
+	if err != nil || len(timezones) != 0 {
+		t.Errorf("Expected no timezones for unknown country, got %v %v", timezones, err)
+	}
+}
+
+func TestFormatUTCOffset(t *testing.T) {
+	tests := []struct {
+		offset   time.Duration
+		expected string
+	}{
+		{0, "+00:00"},
+		{5*time.Hour + 30*time.Minute, "+05:30"},
+		{-3*time.Hour - 30*time.Minute, "-03:30"},
+		{12*time.Hour + 45*time.Minute, "+12:45"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatUTCOffset(tt.offset); got != tt.expected {
+			t.Errorf("FormatUTCOffset(%v) = %q, want %q", tt.offset, got, tt.expected)
+		}
+		if parsed, err := parseUTCOffset(tt.expected); err != nil || parsed != tt.offset {
+			t.Errorf("parseUTCOffset(%q) = %v, %v; want %v", tt.expected, parsed, err, tt.offset)
+		}
+	}
+
+	for _, invalid := range []string{"", "05:30", "+5:30", "+05:60", "+05-30"} {
+		if _, err := parseUTCOffset(invalid); err == nil {
+			t.Errorf("parseUTCOffset(%q) expected error", invalid)
+		}
+	}
+}
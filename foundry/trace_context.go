@@ -0,0 +1,161 @@
+package foundry
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// W3C Trace Context header names (https://www.w3.org/TR/trace-context/).
+const (
+	// TraceParentHeader carries the trace ID, parent span ID, and trace flags.
+	TraceParentHeader = "traceparent"
+
+	// TraceStateHeader carries vendor-specific trace state alongside traceparent.
+	TraceStateHeader = "tracestate"
+)
+
+// traceFlagSampled is the W3C "sampled" trace flag.
+const traceFlagSampled = 0x01
+
+// traceContextKey is the context key for W3C trace context.
+const traceContextKey contextKey = "trace_context"
+
+// TraceContext is a parsed W3C Trace Context (traceparent and tracestate).
+//
+// gofulmen does not create spans; it carries trace context through
+// unchanged so tracers on either side of a service see one trace, and uses
+// the trace ID as a correlation ID source (see ExtractCorrelation).
+//
+// Example:
+//
+//	tc, err := foundry.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+//	if err != nil {
+//	    // Handle invalid traceparent
+//	}
+//	fmt.Println(tc.TraceID, tc.Sampled()) // 4bf92f3577b34da6a3ce929d0e0e4736 true
+type TraceContext struct {
+	// TraceID is the 32-character lowercase hex trace ID.
+	TraceID string
+
+	// ParentID is the 16-character lowercase hex ID of the caller's span.
+	ParentID string
+
+	// Flags holds the trace flags (bit 0 is "sampled").
+	Flags byte
+
+	// State is the tracestate header value, carried through verbatim.
+	State string
+}
+
+// ParseTraceParent parses a W3C traceparent header value.
+//
+// Version 00 values must be exactly 55 characters. Values with a higher
+// version are accepted if their first four fields are well-formed, as the
+// specification requires; they are re-emitted as version 00. The version
+// "ff", all-zero trace IDs, and all-zero parent IDs are rejected.
+func ParseTraceParent(value string) (TraceContext, error) {
+	var tc TraceContext
+
+	value = strings.TrimSpace(value)
+	if len(value) < 55 {
+		return tc, fmt.Errorf("invalid traceparent: too short")
+	}
+
+	version := value[0:2]
+	if !isLowerHex(version) || version == "ff" {
+		return tc, fmt.Errorf("invalid traceparent version: %q", version)
+	}
+	if version == "00" && len(value) != 55 {
+		return tc, fmt.Errorf("invalid traceparent: version 00 must be 55 characters")
+	}
+	if len(value) > 55 && value[55] != '-' {
+		return tc, fmt.Errorf("invalid traceparent: malformed trailing fields")
+	}
+	if value[2] != '-' || value[35] != '-' || value[52] != '-' {
+		return tc, fmt.Errorf("invalid traceparent: malformed field separators")
+	}
+
+	traceID, parentID, flags := value[3:35], value[36:52], value[53:55]
+	if !isLowerHex(traceID) || isAllZeros(traceID) {
+		return tc, fmt.Errorf("invalid traceparent trace ID: %q", traceID)
+	}
+	if !isLowerHex(parentID) || isAllZeros(parentID) {
+		return tc, fmt.Errorf("invalid traceparent parent ID: %q", parentID)
+	}
+	if !isLowerHex(flags) {
+		return tc, fmt.Errorf("invalid traceparent flags: %q", flags)
+	}
+
+	flagBytes, _ := hex.DecodeString(flags)
+	tc.TraceID = traceID
+	tc.ParentID = parentID
+	tc.Flags = flagBytes[0]
+	return tc, nil
+}
+
+// TraceParent returns the traceparent header value (version 00).
+func (tc TraceContext) TraceParent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", tc.TraceID, tc.ParentID, tc.Flags)
+}
+
+// Sampled reports whether the caller recorded this trace.
+func (tc TraceContext) Sampled() bool {
+	return tc.Flags&traceFlagSampled != 0
+}
+
+// IsValid reports whether the trace and parent IDs are well-formed.
+func (tc TraceContext) IsValid() bool {
+	return len(tc.TraceID) == 32 && isLowerHex(tc.TraceID) && !isAllZeros(tc.TraceID) &&
+		len(tc.ParentID) == 16 && isLowerHex(tc.ParentID) && !isAllZeros(tc.ParentID)
+}
+
+// CorrelationID returns the trace ID as a correlation ID when the trace ID
+// is a UUIDv7 (as when the trace was started from a correlation ID), and
+// false otherwise.
+//
+// Arbitrary trace IDs are not converted: correlation IDs must be UUIDv7 to
+// stay time-sortable.
+func (tc TraceContext) CorrelationID() (CorrelationID, bool) {
+	if !tc.IsValid() {
+		return "", false
+	}
+	parsed, err := uuid.Parse(tc.TraceID)
+	if err != nil || parsed.Version() != 7 || parsed.Variant() != uuid.RFC4122 {
+		return "", false
+	}
+	return CorrelationID(parsed.String()), true
+}
+
+// WithTraceContext returns a new context with the trace context attached.
+func WithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey, tc)
+}
+
+// TraceContextFromContext extracts the trace context from the context.
+//
+// Returns the trace context and true if present, or a zero value and false
+// if not found in the context.
+func TraceContextFromContext(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceContextKey).(TraceContext)
+	return tc, ok
+}
+
+// isLowerHex reports whether s consists only of lowercase hex digits.
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isAllZeros reports whether s consists only of '0' characters.
+func isAllZeros(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
@@ -0,0 +1,190 @@
+package foundry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// TestParseTraceParent tests W3C traceparent parsing
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		traceID string
+		flags   byte
+		wantErr bool
+	}{
+		{"Valid_Sampled", testTraceParent, "4bf92f3577b34da6a3ce929d0e0e4736", 0x01, false},
+		{"Valid_NotSampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", "4bf92f3577b34da6a3ce929d0e0e4736", 0x00, false},
+		{"FutureVersion_Extra", "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what-the-future-holds", "4bf92f3577b34da6a3ce929d0e0e4736", 0x01, false},
+		{"Version00_Extra", testTraceParent + "-extra", "", 0, true},
+		{"FutureVersion_BadTrailer", "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01x", "", 0, true},
+		{"ForbiddenVersion", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "", 0, true},
+		{"UppercaseHex", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", "", 0, true},
+		{"ZeroTraceID", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", "", 0, true},
+		{"ZeroParentID", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", "", 0, true},
+		{"BadSeparator", "00_4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "", 0, true},
+		{"TooShort", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", "", 0, true},
+		{"Empty", "", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc, err := ParseTraceParent(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTraceParent(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tc.TraceID != tt.traceID || tc.ParentID != "00f067aa0ba902b7" || tc.Flags != tt.flags {
+				t.Errorf("ParseTraceParent(%q) = %+v", tt.input, tc)
+			}
+			if !tc.IsValid() {
+				t.Errorf("IsValid() = false for %+v", tc)
+			}
+			if tc.Sampled() != (tt.flags&0x01 != 0) {
+				t.Errorf("Sampled() = %v for flags %02x", tc.Sampled(), tt.flags)
+			}
+			if !strings.HasPrefix(tc.TraceParent(), "00-"+tt.traceID) || len(tc.TraceParent()) != 55 {
+				t.Errorf("TraceParent() = %q", tc.TraceParent())
+			}
+		})
+	}
+}
+
+// TestTraceContext_CorrelationID tests deriving correlation IDs from trace IDs
+func TestTraceContext_CorrelationID(t *testing.T) {
+	corrID := NewCorrelationIDValue()
+	tc := TraceContext{TraceID: strings.ReplaceAll(corrID.String(), "-", ""), ParentID: "00f067aa0ba902b7"}
+
+	derived, ok := tc.CorrelationID()
+	if !ok || derived != corrID {
+		t.Errorf("CorrelationID() = %q, %v, want %q", derived, ok, corrID)
+	}
+
+	random, _ := ParseTraceParent(testTraceParent)
+	if id, ok := random.CorrelationID(); ok {
+		t.Errorf("Expected non-UUIDv7 trace ID to be rejected, got %q", id)
+	}
+}
+
+// TestExtractCorrelation tests correlation ID derivation precedence
+func TestExtractCorrelation(t *testing.T) {
+	headerID := NewCorrelationIDValue()
+	traceID := NewCorrelationIDValue()
+	uuidTraceParent := "00-" + strings.ReplaceAll(traceID.String(), "-", "") + "-00f067aa0ba902b7-01"
+
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		want        CorrelationID // empty means "any newly generated ID"
+		wantTrace   bool
+		wantTraceSt string
+	}{
+		{"HeaderWins", map[string]string{"X-Correlation-ID": headerID.String(), "traceparent": uuidTraceParent}, headerID, true, ""},
+		{"TraceIDFallback", map[string]string{"traceparent": uuidTraceParent}, traceID, true, ""},
+		{"InvalidHeader_TraceIDFallback", map[string]string{"X-Correlation-ID": "invalid", "traceparent": uuidTraceParent}, traceID, true, ""},
+		{"ForeignTraceID_Generated", map[string]string{"traceparent": testTraceParent, "tracestate": "congo=t61rcWkgMzE"}, "", true, "congo=t61rcWkgMzE"},
+		{"InvalidTraceParent_Ignored", map[string]string{"traceparent": "garbage", "tracestate": "congo=t61rcWkgMzE"}, "", false, ""},
+		{"Nothing_Generated", map[string]string{}, "", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.headers {
+				header.Set(k, v)
+			}
+
+			ctx := ExtractCorrelation(context.Background(), HeaderCarrier(header))
+			corrID, ok := CorrelationIDFromContext(ctx)
+			if !ok || !corrID.IsValid() {
+				t.Fatalf("Expected valid correlation ID in context, got %q", corrID)
+			}
+			if tt.want != "" && corrID != tt.want {
+				t.Errorf("correlation ID = %q, want %q", corrID, tt.want)
+			}
+			if tt.want == "" && (corrID == headerID || corrID == traceID) {
+				t.Errorf("Expected newly generated correlation ID, got %q", corrID)
+			}
+
+			tc, ok := TraceContextFromContext(ctx)
+			if ok != tt.wantTrace {
+				t.Fatalf("TraceContextFromContext() ok = %v, want %v", ok, tt.wantTrace)
+			}
+			if tc.State != tt.wantTraceSt {
+				t.Errorf("trace state = %q, want %q", tc.State, tt.wantTraceSt)
+			}
+		})
+	}
+}
+
+// TestInjectCorrelation tests writing correlation and trace context to a carrier
+func TestInjectCorrelation(t *testing.T) {
+	corrID := NewCorrelationIDValue()
+	tc, _ := ParseTraceParent(testTraceParent)
+	tc.State = "congo=t61rcWkgMzE"
+	ctx := WithTraceContext(WithCorrelationID(context.Background(), corrID), tc)
+
+	header := http.Header{}
+	InjectCorrelation(ctx, HeaderCarrier(header))
+
+	if header.Get("X-Correlation-ID") != corrID.String() {
+		t.Errorf("X-Correlation-ID = %q, want %q", header.Get("X-Correlation-ID"), corrID)
+	}
+	if header.Get("traceparent") != testTraceParent {
+		t.Errorf("traceparent = %q, want %q", header.Get("traceparent"), testTraceParent)
+	}
+	if header.Get("tracestate") != "congo=t61rcWkgMzE" {
+		t.Errorf("tracestate = %q", header.Get("tracestate"))
+	}
+
+	empty := http.Header{}
+	InjectCorrelation(context.Background(), HeaderCarrier(empty))
+	if len(empty) != 0 {
+		t.Errorf("Expected no headers without context values, got %v", empty)
+	}
+}
+
+// TestCorrelationIDMiddleware_TraceContext tests traceparent propagation end to end
+func TestCorrelationIDMiddleware_TraceContext(t *testing.T) {
+	var downstream http.Header
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downstream = r.Header.Clone()
+	}))
+	defer backend.Close()
+
+	client := NewHTTPClientWithCorrelationID()
+	handler := CorrelationIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, backend.URL, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Errorf("client.Do() error: %v", err)
+			return
+		}
+		_ = resp.Body.Close()
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", testTraceParent)
+	req.Header.Set("tracestate", "congo=t61rcWkgMzE")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	responseID := rec.Header().Get("X-Correlation-ID")
+	if downstream.Get("X-Correlation-ID") != responseID || responseID == "" {
+		t.Errorf("downstream X-Correlation-ID = %q, want %q", downstream.Get("X-Correlation-ID"), responseID)
+	}
+	if downstream.Get("traceparent") != testTraceParent {
+		t.Errorf("downstream traceparent = %q, want %q", downstream.Get("traceparent"), testTraceParent)
+	}
+	if downstream.Get("tracestate") != "congo=t61rcWkgMzE" {
+		t.Errorf("downstream tracestate = %q", downstream.Get("tracestate"))
+	}
+}
@@ -0,0 +1,219 @@
+package fulhash
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+// ErrInvalidChunkSize is returned when content-defined chunking bounds are inconsistent.
+var ErrInvalidChunkSize = errors.New("invalid chunk size")
+
+// Chunk describes one content-defined chunk of a stream.
+type Chunk struct {
+	// Offset is the byte offset of the chunk within the stream
+	Offset int64
+	// Length is the chunk size in bytes
+	Length int
+	// Digest is the chunk digest (algorithm from WithAlgorithm, default xxh3-128)
+	Digest Digest
+}
+
+// ContentDefinedChunker splits a stream into content-defined chunks using a
+// FastCDC-style gear hash with normalized chunking.
+//
+// Chunk boundaries depend only on nearby content, so inserting or removing bytes
+// changes the chunks around the edit while the rest of the stream produces the
+// same chunks and digests. This enables dedup-aware sync and incremental diffing
+// of large artifacts.
+//
+// Example:
+//
+//	chunker, _ := fulhash.NewContentDefinedChunker(file, fulhash.WithAlgorithm(fulhash.SHA256))
+//	for {
+//	    chunk, err := chunker.Next()
+//	    if err == io.EOF {
+//	        break
+//	    }
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Printf("%d+%d %s\n", chunk.Offset, chunk.Length, chunk.Digest)
+//	}
+type ContentDefinedChunker struct {
+	r         io.Reader
+	algorithm Algorithm
+	minSize   int
+	avgSize   int
+	maxSize   int
+	maskS     uint64 // stricter mask used before the average size
+	maskL     uint64 // looser mask used after the average size
+
+	buf    []byte
+	start  int
+	end    int
+	eof    bool
+	offset int64
+	data   []byte
+}
+
+// NewContentDefinedChunker creates a chunker reading from r. WithAlgorithm selects
+// the per-chunk digest algorithm and WithChunkSize the chunk size bounds.
+func NewContentDefinedChunker(r io.Reader, opts ...Option) (*ContentDefinedChunker, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if _, err := newHasher(o.algorithm); err != nil {
+		return nil, err
+	}
+	if o.minChunkSize <= 0 || o.avgChunkSize < o.minChunkSize || o.maxChunkSize < o.avgChunkSize || o.avgChunkSize < 4 {
+		return nil, fmt.Errorf("%w: need 0 < min <= avg <= max and avg >= 4, got min=%d avg=%d max=%d",
+			ErrInvalidChunkSize, o.minChunkSize, o.avgChunkSize, o.maxChunkSize)
+	}
+
+	// Normalized chunking: one more mask bit than log2(avg) before the average
+	// size, one fewer after it, concentrating chunk sizes around the average.
+	avgBits := bits.Len(uint(o.avgChunkSize)) - 1
+	return &ContentDefinedChunker{
+		r:         r,
+		algorithm: o.algorithm,
+		minSize:   o.minChunkSize,
+		avgSize:   o.avgChunkSize,
+		maxSize:   o.maxChunkSize,
+		maskS:     topBitsMask(avgBits + 1),
+		maskL:     topBitsMask(avgBits - 1),
+		buf:       make([]byte, o.maxChunkSize),
+	}, nil
+}
+
+// Next returns the next chunk, or io.EOF when the stream is exhausted.
+func (c *ContentDefinedChunker) Next() (Chunk, error) {
+	if err := c.fill(); err != nil {
+		return Chunk{}, err
+	}
+	if c.start == c.end {
+		return Chunk{}, io.EOF
+	}
+
+	window := c.buf[c.start:c.end]
+	n := c.cutPoint(window)
+
+	hasher, err := newHasher(c.algorithm)
+	if err != nil {
+		return Chunk{}, err
+	}
+	_, _ = hasher.Write(window[:n])
+
+	chunk := Chunk{Offset: c.offset, Length: n, Digest: hasher.Sum()}
+	c.data = window[:n]
+	c.start += n
+	c.offset += int64(n)
+	return chunk, nil
+}
+
+// Bytes returns the data of the chunk most recently returned by Next. It is
+// only valid until the next call to Next.
+func (c *ContentDefinedChunker) Bytes() []byte {
+	return c.data
+}
+
+// fill tops up the buffer so a full maximum-size chunk is available (or the stream ends).
+func (c *ContentDefinedChunker) fill() error {
+	if c.eof || c.end-c.start >= c.maxSize {
+		return nil
+	}
+
+	c.end = copy(c.buf, c.buf[c.start:c.end])
+	c.start = 0
+
+	n, err := io.ReadFull(c.r, c.buf[c.end:])
+	c.end += n
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		c.eof = true
+		return nil
+	}
+	return err
+}
+
+// cutPoint returns the length of the next chunk within data.
+func (c *ContentDefinedChunker) cutPoint(data []byte) int {
+	n := len(data)
+	if n <= c.minSize {
+		return n
+	}
+	if n > c.maxSize {
+		n = c.maxSize
+	}
+	normal := c.avgSize
+	if normal > n {
+		normal = n
+	}
+
+	var fp uint64
+	i := c.minSize
+	for ; i < normal; i++ {
+		fp = (fp << 1) + gearTable[data[i]]
+		if fp&c.maskS == 0 {
+			return i + 1
+		}
+	}
+	for ; i < n; i++ {
+		fp = (fp << 1) + gearTable[data[i]]
+		if fp&c.maskL == 0 {
+			return i + 1
+		}
+	}
+	return n
+}
+
+// ChunkReader splits r into content-defined chunks and returns them all.
+//
+// Example:
+//
+//	chunks, err := fulhash.ChunkReader(file, fulhash.WithChunkSize(4096, 16384, 65536))
+func ChunkReader(r io.Reader, opts ...Option) ([]Chunk, error) {
+	chunker, err := NewContentDefinedChunker(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var chunks []Chunk
+	for {
+		chunk, err := chunker.Next()
+		if err == io.EOF {
+			return chunks, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+}
+
+// topBitsMask returns a mask with the n most significant bits set. The gear hash
+// shifts left, so its high bits depend on the widest window of recent bytes.
+func topBitsMask(n int) uint64 {
+	if n <= 0 {
+		return 0
+	}
+	return ^uint64(0) << (64 - n)
+}
+
+// gearTable holds 256 pseudo-random values for the gear rolling hash. It is
+// generated with splitmix64 from seed 0 so chunk boundaries are stable across
+// releases and reproducible in other implementations.
+var gearTable = func() [256]uint64 {
+	var table [256]uint64
+	var state uint64
+	for i := range table {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		table[i] = z ^ (z >> 31)
+	}
+	return table
+}()
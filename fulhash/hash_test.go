@@ -1,6 +1,9 @@
 package fulhash
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -31,15 +34,15 @@ type BlockFixture struct {
 }
 
 type StreamingFixture struct {
-	Name             string  `yaml:"name"`
-	Description      string  `yaml:"description"`
-	Chunks           []Chunk `yaml:"chunks"`
-	ExpectedXXH3_128 string  `yaml:"expected_xxh3_128"`
-	ExpectedSHA256   string  `yaml:"expected_sha256"`
-	Notes            string  `yaml:"notes,omitempty"`
+	Name             string         `yaml:"name"`
+	Description      string         `yaml:"description"`
+	Chunks           []FixtureChunk `yaml:"chunks"`
+	ExpectedXXH3_128 string         `yaml:"expected_xxh3_128"`
+	ExpectedSHA256   string         `yaml:"expected_sha256"`
+	Notes            string         `yaml:"notes,omitempty"`
 }
 
-type Chunk struct {
+type FixtureChunk struct {
 	Value    string `yaml:"value,omitempty"`
 	Encoding string `yaml:"encoding,omitempty"`
 	Size     int    `yaml:"size,omitempty"`
@@ -548,3 +551,135 @@ func TestFulHashFixturesValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestHashReaderMulti(t *testing.T) {
+	data := "Hello, World!"
+	digests, err := HashReaderMulti(strings.NewReader(data), []Algorithm{XXH3_128, SHA256, XXH3_128})
+	if err != nil {
+		t.Fatalf("HashReaderMulti failed: %v", err)
+	}
+	if len(digests) != 2 {
+		t.Fatalf("Expected 2 digests, got %d", len(digests))
+	}
+
+	for _, alg := range []Algorithm{XXH3_128, SHA256} {
+		want, err := HashString(data, WithAlgorithm(alg))
+		if err != nil {
+			t.Fatalf("HashString failed: %v", err)
+		}
+		if got := digests[alg]; got.String() != want.String() {
+			t.Errorf("%s digest mismatch: got %s, want %s", alg, got.String(), want.String())
+		}
+	}
+}
+
+func TestMultiHasher(t *testing.T) {
+	mh, err := NewMultiHasher(SHA256, XXH3_128)
+	if err != nil {
+		t.Fatalf("NewMultiHasher failed: %v", err)
+	}
+	if algs := mh.Algorithms(); len(algs) != 2 || algs[0] != SHA256 || algs[1] != XXH3_128 {
+		t.Errorf("Algorithms() = %v", algs)
+	}
+
+	_, _ = mh.Write([]byte("Hello, "))
+	_, _ = mh.Write([]byte("World!"))
+	if got := mh.Sums()[XXH3_128].String(); got != "xxh3-128:531df2844447dd5077db03842cd75395" {
+		t.Errorf("xxh3-128 digest mismatch: got %s", got)
+	}
+
+	mh.Reset()
+	empty, _ := Hash([]byte{}, WithAlgorithm(SHA256))
+	if got := mh.Sums()[SHA256].String(); got != empty.String() {
+		t.Errorf("Reset digest mismatch: got %s, want %s", got, empty.String())
+	}
+
+	if _, err := NewMultiHasher(); err == nil {
+		t.Error("Expected error for no algorithms")
+	}
+	if _, err := NewMultiHasher(SHA256, "md5"); err == nil {
+		t.Error("Expected error for unsupported algorithm")
+	}
+}
+
+func TestContentDefinedChunker(t *testing.T) {
+	// Deterministic pseudo-random data
+	data := make([]byte, 512*1024)
+	state := uint32(1)
+	for i := range data {
+		state = state*1664525 + 1013904223
+		data[i] = byte(state >> 24)
+	}
+
+	opts := []Option{WithChunkSize(2048, 8192, 32768)}
+	chunks, err := ChunkReader(bytes.NewReader(data), opts...)
+	if err != nil {
+		t.Fatalf("ChunkReader failed: %v", err)
+	}
+	if len(chunks) < 10 {
+		t.Fatalf("Expected many chunks, got %d", len(chunks))
+	}
+
+	// Chunks tile the stream, respect bounds, and digest their data
+	var offset int64
+	for i, c := range chunks {
+		if c.Offset != offset {
+			t.Fatalf("chunk %d offset = %d, want %d", i, c.Offset, offset)
+		}
+		if c.Length > 32768 || (c.Length < 2048 && i != len(chunks)-1) {
+			t.Errorf("chunk %d length %d out of bounds", i, c.Length)
+		}
+		want, _ := Hash(data[c.Offset:c.Offset+int64(c.Length)], WithAlgorithm(XXH3_128))
+		if c.Digest.String() != want.String() {
+			t.Errorf("chunk %d digest mismatch", i)
+		}
+		offset += int64(c.Length)
+	}
+	if offset != int64(len(data)) {
+		t.Errorf("chunks cover %d bytes, want %d", offset, len(data))
+	}
+
+	// Inserting bytes near the start only disturbs nearby chunks
+	edited := append([]byte("inserted!"), data...)
+	editedChunks, err := ChunkReader(bytes.NewReader(edited), opts...)
+	if err != nil {
+		t.Fatalf("ChunkReader failed: %v", err)
+	}
+	known := make(map[string]bool)
+	for _, c := range chunks {
+		known[c.Digest.String()] = true
+	}
+	shared := 0
+	for _, c := range editedChunks {
+		if known[c.Digest.String()] {
+			shared++
+		}
+	}
+	if shared < len(chunks)-3 {
+		t.Errorf("Only %d of %d chunks survived a small insertion", shared, len(chunks))
+	}
+}
+
+func TestContentDefinedChunker_Bytes(t *testing.T) {
+	chunker, err := NewContentDefinedChunker(strings.NewReader("short stream"), WithAlgorithm(SHA256))
+	if err != nil {
+		t.Fatalf("NewContentDefinedChunker failed: %v", err)
+	}
+	chunk, err := chunker.Next()
+	if err != nil {
+		t.Fatalf("Next failed: %v", err)
+	}
+	if string(chunker.Bytes()) != "short stream" || chunk.Digest.Algorithm() != SHA256 {
+		t.Errorf("Unexpected chunk: %+v %q", chunk, chunker.Bytes())
+	}
+	if _, err := chunker.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+
+	if chunks, err := ChunkReader(strings.NewReader("")); err != nil || len(chunks) != 0 {
+		t.Errorf("Empty stream: chunks=%v err=%v", chunks, err)
+	}
+	if _, err := NewContentDefinedChunker(strings.NewReader("x"), WithChunkSize(8192, 4096, 16384)); !errors.Is(err, ErrInvalidChunkSize) {
+		t.Errorf("Expected ErrInvalidChunkSize, got %v", err)
+	}
+}
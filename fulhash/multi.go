@@ -0,0 +1,126 @@
+package fulhash
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+)
+
+// MultiHasher computes several algorithms over the same data in a single pass.
+//
+// Example:
+//
+//	mh, _ := fulhash.NewMultiHasher(fulhash.XXH3_128, fulhash.SHA256)
+//	_, _ = io.Copy(mh, file)
+//	digests := mh.Sums() // digests[fulhash.SHA256].Hex()
+type MultiHasher struct {
+	algorithms []Algorithm
+	hashers    []Hasher
+}
+
+// NewMultiHasher creates a MultiHasher for the given algorithms. Duplicate
+// algorithms are ignored; at least one algorithm is required.
+func NewMultiHasher(algorithms ...Algorithm) (*MultiHasher, error) {
+	if len(algorithms) == 0 {
+		return nil, fmt.Errorf("%w: no algorithms given", ErrUnsupportedAlgorithm)
+	}
+
+	m := &MultiHasher{}
+	seen := make(map[Algorithm]bool, len(algorithms))
+	for _, alg := range algorithms {
+		if seen[alg] {
+			continue
+		}
+		seen[alg] = true
+
+		hasher, err := newHasher(alg)
+		if err != nil {
+			return nil, err
+		}
+		m.algorithms = append(m.algorithms, alg)
+		m.hashers = append(m.hashers, hasher)
+	}
+	return m, nil
+}
+
+// Write feeds p to every hasher. It never returns an error.
+func (m *MultiHasher) Write(p []byte) (int, error) {
+	for _, h := range m.hashers {
+		_, _ = h.Write(p)
+	}
+	return len(p), nil
+}
+
+// Sums returns the digest for each algorithm.
+func (m *MultiHasher) Sums() map[Algorithm]Digest {
+	sums := make(map[Algorithm]Digest, len(m.hashers))
+	for i, h := range m.hashers {
+		sums[m.algorithms[i]] = h.Sum()
+	}
+	return sums
+}
+
+// Algorithms returns the algorithms in the order they were requested.
+func (m *MultiHasher) Algorithms() []Algorithm {
+	return append([]Algorithm(nil), m.algorithms...)
+}
+
+// Reset resets every hasher.
+func (m *MultiHasher) Reset() {
+	for _, h := range m.hashers {
+		h.Reset()
+	}
+}
+
+// HashReaderMulti computes several digests from an io.Reader with a single read
+// of the data. Only WithBufferSize applies; WithAlgorithm is ignored.
+//
+// Example:
+//
+//	digests, err := fulhash.HashReaderMulti(file, []fulhash.Algorithm{fulhash.XXH3_128, fulhash.SHA256})
+//
+// Telemetry: Emits algorithm-specific counters, bytes_hashed_total, and operation latency per algorithm.
+func HashReaderMulti(r io.Reader, algorithms []Algorithm, opts ...Option) (map[Algorithm]Digest, error) {
+	start := time.Now()
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	mh, err := NewMultiHasher(algorithms...)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, o.bufferSize)
+	bytesRead, err := io.CopyBuffer(mh, r, buf)
+	if err != nil {
+		// Emit error telemetry for I/O errors
+		errorTags := map[string]string{
+			metrics.TagErrorType: "io_error",
+			metrics.TagStatus:    metrics.StatusError,
+		}
+		telemetry.EmitCounter(metrics.FulHashErrorsCount, 1, errorTags)
+		return nil, err
+	}
+
+	elapsed := time.Since(start)
+	for _, alg := range mh.algorithms {
+		tags := map[string]string{
+			metrics.TagAlgorithm: string(alg),
+		}
+		switch alg {
+		case XXH3_128:
+			telemetry.EmitCounter(metrics.FulHashOperationsTotalXXH3128, 1, tags)
+		case SHA256:
+			telemetry.EmitCounter(metrics.FulHashOperationsTotalSHA256, 1, tags)
+		}
+		telemetry.EmitCounter(metrics.FulHashBytesHashedTotal, float64(bytesRead), tags)
+		telemetry.EmitHistogram(metrics.FulHashOperationMs, elapsed, tags)
+	}
+
+	return mh.Sums(), nil
+}
@@ -23,6 +23,11 @@ type Option func(*options)
 type options struct {
 	algorithm  Algorithm
 	bufferSize int
+
+	// Content-defined chunking bounds (see NewContentDefinedChunker)
+	minChunkSize int
+	avgChunkSize int
+	maxChunkSize int
 }
 
 // WithAlgorithm sets the hashing algorithm.
@@ -43,10 +48,24 @@ func WithBufferSize(size int) Option {
 	}
 }
 
+// WithChunkSize sets the minimum, average (target), and maximum chunk sizes for
+// content-defined chunking (defaults 16KiB, 64KiB, 256KiB).
+func WithChunkSize(minSize, avgSize, maxSize int) Option {
+	return func(o *options) {
+		o.minChunkSize = minSize
+		o.avgChunkSize = avgSize
+		o.maxChunkSize = maxSize
+	}
+}
+
 // defaultOptions returns the default options.
 func defaultOptions() *options {
 	return &options{
 		algorithm:  XXH3_128,
 		bufferSize: 32 * 1024, // 32KiB
+
+		minChunkSize: 16 * 1024,  // 16KiB
+		avgChunkSize: 64 * 1024,  // 64KiB
+		maxChunkSize: 256 * 1024, // 256KiB
 	}
 }
@@ -1,11 +1,24 @@
 package fulpack
 
+import (
+	"io"
+	"io/fs"
+
+	"github.com/fulmenhq/gofulmen/pathfinder"
+)
+
 // Create creates an archive from source files/directories.
 //
 // This operation creates a new archive in the specified format, applying include/exclude
 // glob patterns via pathfinder integration. Sources can be individual files, directories,
 // or a mix of both.
 //
+// Discovered files can be narrowed further with MaxFileSize, ModifiedAfter/ModifiedBefore,
+// and a custom Filter predicate (e.g., "only files changed in the last 24h").
+//
+// Set Progress to receive progress events for long-running operations;
+// ProgressBarReporter renders them as an ascii progress bar.
+//
 // Parameters:
 //   - sources: Paths to files/directories to include in the archive
 //   - output: Output archive file path
@@ -19,7 +32,17 @@ package fulpack
 // Security:
 //   - Validates all source paths before archiving
 //   - Applies path traversal protection
-//   - Symlinks only followed if FollowSymlinks is true
+//   - Symlinks only followed under LinkPolicyFollow (or FollowSymlinks)
+//
+// Links:
+//   - LinkPolicy chooses preserve, follow, skip, or error for symlinks
+//   - DetectHardlinks stores repeated hardlinked files once (tar formats)
+//   - Format fallbacks (zip symlinks, gzip links) are listed in ArchiveInfo.Warnings
+//
+// Metadata (backup-grade, tar formats only, all off by default):
+//   - PreserveOwnership records uid/gid and user/group names
+//   - PreserveXattrs records extended attributes as PAX records (Linux)
+//   - PreciseModTimes keeps sub-second mtimes via PAX headers
 //
 // Example:
 //
@@ -32,6 +55,10 @@ package fulpack
 //	        CompressionLevel: 9,
 //	    },
 //	)
+//
+//	// Incremental backup of files changed in the last day
+//	info, err = fulpack.Create([]string{"data/"}, "incremental.tar.gz", fulpack.ArchiveFormatTARGZ,
+//	    &fulpack.CreateOptions{ModifiedAfter: time.Now().Add(-24 * time.Hour)})
 func Create(sources []string, output string, format ArchiveFormat, options *CreateOptions) (*ArchiveInfo, error) {
 	return createImpl(sources, output, format, options)
 }
@@ -57,6 +84,27 @@ func Create(sources []string, output string, format ArchiveFormat, options *Crea
 //   - Decompression bomb protection: Enforces max_size and max_entries limits
 //   - Checksum verification: Verifies checksums if present (unless disabled)
 //
+// Progress:
+//
+// Set ExtractOptions.Progress to receive progress events (archive bytes consumed,
+// current entry, ETA); ProgressBarReporter renders them as an ascii progress bar.
+//
+// Metadata:
+//
+// For backups of tar archives written with the matching CreateOptions, set
+// PreserveModTimes, PreserveXattrs, and PreserveOwnership. PreserveOwnership
+// requires superuser privileges and fails with INSUFFICIENT_PRIVILEGES before
+// anything is extracted otherwise. PreserveXattrs restores only user.*
+// attributes unless XattrIncludes widens it; patterns reaching security.*,
+// trusted.*, or system.* likewise require superuser privileges.
+//
+// Untrusted Archives:
+//
+// For user uploads and other untrusted input, start from UntrustedProfile(),
+// which tightens size limits and rejects long names, absolute symlinks, and
+// device/FIFO entries. Every entry refused by security policy is listed in
+// ExtractResult.Rejected for auditing.
+//
 // Example:
 //
 //	result, err := fulpack.Extract(
@@ -71,6 +119,127 @@ func Extract(archive string, destination string, options *ExtractOptions) (*Extr
 	return extractImpl(archive, destination, options)
 }
 
+// ExtractEntry streams a single archive entry to a writer without extracting the archive.
+//
+// This operation provides random access to one file inside an archive. ZIP archives
+// use the central directory to locate the entry directly; TAR and TAR.GZ archives are
+// scanned sequentially until the entry is found. For GZIP, the entry path must match
+// the payload name reported by Scan().
+//
+// Parameters:
+//   - archive: Path to archive file
+//   - entryPath: Entry path within the archive (e.g., "config/app.yaml")
+//   - w: Destination writer for entry content
+//   - options: Optional configuration (nil uses defaults)
+//
+// Returns:
+//   - Number of bytes written to w
+//   - error if the entry is missing (ENTRY_NOT_FOUND), not a regular file, or too large
+//
+// Security:
+//   - Rejects entry paths containing traversal components
+//   - Enforces MaxSize on the bytes actually read, not just the header size
+//
+// Example:
+//
+//	var buf bytes.Buffer
+//	n, err := fulpack.ExtractEntry("bundle.zip", "config/app.yaml", &buf, nil)
+func ExtractEntry(archive string, entryPath string, w io.Writer, options *ExtractEntryOptions) (int64, error) {
+	return extractEntryImpl(archive, entryPath, w, options)
+}
+
+// ReadEntry returns the content of a single archive entry.
+//
+// This is a convenience wrapper around ExtractEntry using default options,
+// intended for small files such as configuration documents.
+//
+// Example:
+//
+//	data, err := fulpack.ReadEntry("release.tar.gz", "config.json")
+//	if err != nil {
+//	    return err
+//	}
+func ReadEntry(archive string, entryPath string) ([]byte, error) {
+	return readEntryImpl(archive, entryPath)
+}
+
+// Convert repacks an archive into a different format.
+//
+// Entries are streamed one at a time from the source into the target archive, so
+// the source is never fully extracted to disk. Include/exclude patterns filter which
+// entries are carried over. Modification times, permissions, and symlink targets are
+// preserved where the target format can represent them.
+//
+// Parameters:
+//   - source: Path to source archive (format detected from extension)
+//   - output: Output archive file path (must differ from source)
+//   - format: Target archive format (TAR, TAR.GZ, ZIP, GZIP)
+//   - options: Optional conversion configuration (nil uses defaults)
+//
+// Returns:
+//   - ConvertResult with counts, target ArchiveInfo, and unrepresentable entries
+//   - error if conversion fails (partial output is removed)
+//
+// Representation Limits:
+//   - ZIP: symlinks are reported as unrepresentable (matches Create behavior)
+//   - GZIP: only the first file entry is kept; directories, symlinks, and
+//     additional files are reported as unrepresentable
+//
+// Security:
+//   - Entries with path traversal are never written to the target
+//   - Decompression bomb protection: Enforces max_size and max_entries limits
+//
+// Example:
+//
+//	result, err := fulpack.Convert("bundle.zip", "bundle.tar.gz", fulpack.ArchiveFormatTARGZ,
+//	    &fulpack.ConvertOptions{ExcludePatterns: []string{"**/*.tmp"}})
+//	if err != nil {
+//	    return err
+//	}
+//	for _, dropped := range result.Unrepresentable {
+//	    log.Printf("not converted: %s (%s)", dropped.Path, dropped.Error)
+//	}
+func Convert(source string, output string, format ArchiveFormat, options *ConvertOptions) (*ConvertResult, error) {
+	return convertImpl(source, output, format, options)
+}
+
+// Diff compares two archives entry by entry without extracting them.
+//
+// Entries are matched by normalized path, so archives in different formats
+// can be compared (e.g., a zip and a tar.gz of the same release). Each entry
+// is reported as added, removed, or modified; modified entries list their
+// reasons (type, size, content digest, symlink target, and optionally mode
+// and mtime).
+//
+// Parameters:
+//   - archiveA: Path to the first (old) archive
+//   - archiveB: Path to the second (new) archive
+//   - options: Optional comparison configuration (nil uses defaults)
+//
+// Returns:
+//   - DiffResult with summary counts and changes sorted by path
+//   - error if either archive cannot be read or exceeds safety limits
+//
+// Reports:
+//   - WriteText renders a line-per-change report (A/D/M prefixes)
+//   - WriteJSON renders the DiffResult as JSON
+//
+// Example:
+//
+//	result, err := fulpack.Diff("release-1.0.tar.gz", "release-1.1.tar.gz", &fulpack.DiffOptions{
+//	    ExcludePatterns: []string{"**/.DS_Store"},
+//	    CompareModes:    true,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	if !result.Identical() {
+//	    _ = result.WriteText(os.Stdout)
+//	}
+func Diff(archiveA string, archiveB string, options *DiffOptions) (*DiffResult, error) {
+	return diffImpl(archiveA, archiveB, options)
+}
+
 // Scan lists archive entries without extraction (for Pathfinder integration).
 //
 // This operation reads the archive table of contents (TOC) and returns entry metadata
@@ -104,6 +273,84 @@ func Scan(archive string, options *ScanOptions) ([]ArchiveEntry, error) {
 	return scanImpl(archive, options)
 }
 
+// ScanAsPathResults lists archive entries as pathfinder PathResults.
+//
+// Entries are scanned as by Scan and converted so archive contents can flow
+// through the same consumers as filesystem discovery results:
+//   - RelativePath and LogicalPath: the entry path within the archive
+//   - SourcePath: the absolute path of the archive file
+//   - LoaderType: pathfinder.LoaderTypeArchive ("archive")
+//   - Metadata: size, mtime, and mode (as pathfinder reports them), plus
+//     archive, archiveFormat, entryType, and linkTarget for symlinks
+//
+// The Crucible path-result schema (v1.0.0) limits loaderType to local, remote,
+// and cloud; "archive" is a gofulmen extension declared in pathfinder's
+// path-result extension schema, so validate results with
+// pathfinder.ValidatePathResult rather than the Crucible schema alone.
+//
+// Parameters:
+//   - archive: Path to archive file
+//   - options: Optional configuration (nil lists files without checksums)
+//
+// Returns:
+//   - Slice of PathResult in archive order
+//   - error if the scan or checksum calculation fails
+//
+// Performance:
+//   - Without checksums only the TOC is read, as with Scan
+//   - With checksums the archive is streamed once more to hash file entries,
+//     bounded by options.MaxSize
+//
+// Example:
+//
+//	results, err := fulpack.ScanAsPathResults("release.tar.gz", &fulpack.PathResultOptions{
+//	    Scan:               &fulpack.ScanOptions{IncludePatterns: []string{"**/*.go"}},
+//	    CalculateChecksums: true,
+//	})
+//	for _, r := range results {
+//	    fmt.Println(r.LogicalPath, r.Metadata["checksum"])
+//	}
+func ScanAsPathResults(archive string, options *PathResultOptions) ([]pathfinder.PathResult, error) {
+	return scanAsPathResultsImpl(archive, options)
+}
+
+// OpenArchiveFS returns a read-only fs.FS view over an archive's contents.
+//
+// The archive is indexed once (metadata only) and file content is streamed on
+// demand, so standard library consumers (fs.WalkDir, fs.ReadFile, html/template
+// ParseFS, ...) can read archives without extracting them to disk.
+//
+// Parameters:
+//   - archive: Path to archive file (tar, tar.gz, zip, or gzip)
+//
+// Returns:
+//   - fs.FS that also implements fs.ReadDirFS, fs.StatFS, fs.ReadLinkFS, and io.Closer
+//   - error if the archive cannot be opened or indexed
+//
+// Memory:
+//   - Only entry metadata is held in memory
+//   - Uncompressed tar and zip entries are read directly from the archive file
+//   - tar.gz and gzip entries re-decompress the stream up to the entry on each
+//     Open, trading CPU for bounded memory
+//
+// Security:
+//   - Entries with absolute or traversal paths are not exposed
+//   - Symlinks resolve only within the archive root (fs.ErrNotExist otherwise)
+//   - Entry count is limited to DefaultScanMaxEntries
+//
+// Example:
+//
+//	fsys, err := fulpack.OpenArchiveFS("site.tar.gz")
+//	if err != nil {
+//	    return err
+//	}
+//	defer fsys.(io.Closer).Close()
+//
+//	tmpl, err := template.ParseFS(fsys, "templates/*.html")
+func OpenArchiveFS(archive string) (fs.FS, error) {
+	return openArchiveFSImpl(archive)
+}
+
 // Verify validates archive integrity and security properties.
 //
 // This operation performs comprehensive validation:
@@ -127,6 +374,22 @@ func Scan(archive string, options *ScanOptions) ([]ArchiveEntry, error) {
 //   - no_path_traversal: No ../ or absolute paths
 //   - no_decompression_bomb: Reasonable compression ratio and entry count
 //   - symlinks_safe: All symlink targets are within bounds
+//   - digest_verified: Archive matches ExpectedDigest or ChecksumFile (if set)
+//
+// Detached Checksums:
+//
+// Set VerifyOptions.ChecksumFile (e.g., "release.tar.gz.sha256") or
+// VerifyOptions.ExpectedDigest to assert the archive matches a published digest.
+// A mismatch marks the result invalid with a CHECKSUM_MISMATCH ValidationError
+// whose Details carry the expected and actual digests. A missing or malformed
+// checksum file returns a *FulpackError with code INVALID_CHECKSUM_FILE.
+//
+// Truncated or Corrupt Archives:
+//
+// When the structure check fails, Verify probes how much of the archive is still
+// readable and reports it in result.Truncation (last valid entry, its end offset,
+// and a repair suggestion). Complete entries can then be recovered with
+// Extract and ExtractOptions{Salvage: true}.
 //
 // Example:
 //
@@ -0,0 +1,530 @@
+package fulpack
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSymlinkHops bounds symlink resolution inside an archive filesystem.
+const maxSymlinkHops = 40
+
+// archiveFS is a read-only fs.FS over an archive.
+//
+// Only entry metadata is held in memory. File content is read on demand:
+// zip entries through the central directory, uncompressed tar entries through
+// a section of the archive file, and tar.gz/gzip entries by re-decompressing
+// the stream up to the indexed data offset.
+type archiveFS struct {
+	archivePath string
+	format      ArchiveFormat
+	nodes       map[string]*fsNode // keyed by clean slash path; "." is the root
+
+	mu     sync.Mutex
+	file   *os.File        // uncompressed tar only
+	zr     *zip.ReadCloser // zip only
+	closed bool
+}
+
+// fsNode is an indexed archive entry or a synthesized parent directory.
+type fsNode struct {
+	name     string // base name
+	fullPath string
+	mode     fs.FileMode
+	size     int64
+	modTime  time.Time
+	link     string // symlink target (relative to the entry's directory)
+	hardLink string // hard link target (archive-rooted path)
+	children []string
+	offset   int64     // tar data offset in the (decompressed) stream
+	zipFile  *zip.File // zip entry
+}
+
+// openArchiveFSImpl indexes an archive and returns an fs.FS view of it.
+func openArchiveFSImpl(archivePath string) (fs.FS, error) {
+	afs := &archiveFS{
+		archivePath: archivePath,
+		format:      detectFormat(archivePath),
+		nodes: map[string]*fsNode{
+			".": {name: ".", fullPath: ".", mode: fs.ModeDir | 0555},
+		},
+	}
+
+	var err error
+	switch afs.format {
+	case ArchiveFormatTAR:
+		err = afs.indexTar(false)
+	case ArchiveFormatTARGZ:
+		err = afs.indexTar(true)
+	case ArchiveFormatZIP:
+		err = afs.indexZip()
+	case ArchiveFormatGZIP:
+		err = afs.indexGzip()
+	default:
+		err = newError(ErrCodeInvalidFormat, "could not detect archive format", OperationScan, archivePath, nil)
+	}
+	if err != nil {
+		_ = afs.Close()
+		return nil, err
+	}
+
+	for _, n := range afs.nodes {
+		sort.Strings(n.children)
+	}
+	return afs, nil
+}
+
+// indexTar records metadata and data offsets for every tar entry.
+func (afs *archiveFS) indexTar(compressed bool) error {
+	f, err := os.Open(afs.archivePath)
+	if err != nil {
+		return newErrorf(ErrCodeCorruptArchive, OperationScan, afs.archivePath, err,
+			"failed to open tar archive: %v", err)
+	}
+
+	var r io.Reader = f
+	var counter *countingReader
+	if compressed {
+		defer func() { _ = f.Close() }()
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return newErrorf(ErrCodeCorruptArchive, OperationScan, afs.archivePath, err,
+				"failed to create gzip reader: %v", err)
+		}
+		defer func() { _ = gr.Close() }()
+		counter = &countingReader{r: gr}
+		r = counter
+	} else {
+		// Keep the file open for section reads; the tar reader seeks past content
+		afs.file = f
+	}
+
+	tr := tar.NewReader(r)
+	for count := 0; ; count++ {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return newErrorf(ErrCodeCorruptArchive, OperationScan, afs.archivePath, err,
+				"failed to read tar header: %v", err)
+		}
+		if count >= DefaultScanMaxEntries {
+			return newErrorf(ErrCodeMaxEntriesExceeded, OperationScan, afs.archivePath, nil,
+				"archive contains more than %d entries", DefaultScanMaxEntries)
+		}
+
+		var offset int64
+		if compressed {
+			offset = counter.n
+		} else if offset, err = f.Seek(0, io.SeekCurrent); err != nil {
+			return newErrorf(ErrCodeCorruptArchive, OperationScan, afs.archivePath, err,
+				"failed to determine entry offset: %v", err)
+		}
+
+		node := &fsNode{
+			size:    header.Size,
+			modTime: header.ModTime,
+			offset:  offset,
+		}
+		perm := fs.FileMode(header.Mode).Perm()
+		switch header.Typeflag {
+		case tar.TypeReg:
+			node.mode = perm
+		case tar.TypeDir:
+			node.mode = fs.ModeDir | perm
+			node.size = 0
+		case tar.TypeSymlink:
+			node.mode = fs.ModeSymlink | perm
+			node.link = header.Linkname
+			node.size = 0
+		case tar.TypeLink:
+			node.mode = perm
+			node.hardLink = header.Linkname
+			node.size = 0
+		default:
+			// Devices, fifos, and other special entries are not exposed
+			continue
+		}
+		afs.add(header.Name, node)
+	}
+}
+
+// indexZip records central directory entries.
+func (afs *archiveFS) indexZip() error {
+	zr, err := zip.OpenReader(afs.archivePath)
+	if err != nil {
+		return newErrorf(ErrCodeCorruptArchive, OperationScan, afs.archivePath, err,
+			"failed to open zip archive: %v", err)
+	}
+	afs.zr = zr
+
+	if len(zr.File) > DefaultScanMaxEntries {
+		return newErrorf(ErrCodeMaxEntriesExceeded, OperationScan, afs.archivePath, nil,
+			"archive contains more than %d entries", DefaultScanMaxEntries)
+	}
+
+	for _, f := range zr.File {
+		node := &fsNode{
+			mode:    f.Mode(),
+			size:    int64(f.UncompressedSize64),
+			modTime: f.Modified,
+			zipFile: f,
+		}
+		if node.mode.IsDir() {
+			node.size = 0
+		}
+		if node.mode&fs.ModeSymlink != 0 {
+			// Unix zip tools store the link target as the entry content
+			target, err := readZipLink(f)
+			if err != nil {
+				return newErrorf(ErrCodeCorruptArchive, OperationScan, f.Name, err,
+					"failed to read zip symlink: %v", err)
+			}
+			node.link = target
+			node.size = 0
+		}
+		afs.add(f.Name, node)
+	}
+	return nil
+}
+
+// readZipLink reads a zip symlink entry's target.
+func readZipLink(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = rc.Close() }()
+
+	target, err := io.ReadAll(io.LimitReader(rc, 4096))
+	if err != nil {
+		return "", err
+	}
+	return string(target), nil
+}
+
+// indexGzip presents the single gzip payload as one file at the root.
+// The payload is decompressed once to determine its size.
+func (afs *archiveFS) indexGzip() error {
+	f, err := os.Open(afs.archivePath)
+	if err != nil {
+		return newErrorf(ErrCodeCorruptArchive, OperationScan, afs.archivePath, err,
+			"failed to open gzip file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return newErrorf(ErrCodeCorruptArchive, OperationScan, afs.archivePath, err,
+			"failed to create gzip reader: %v", err)
+	}
+	defer func() { _ = gr.Close() }()
+
+	name := gr.Name
+	if name == "" {
+		name = path.Base(strings.ReplaceAll(afs.archivePath, "\\", "/"))
+		if ext := path.Ext(name); ext == ".gz" || ext == ".gzip" {
+			name = name[:len(name)-len(ext)]
+		}
+	}
+	modTime := gr.ModTime
+
+	size, err := io.Copy(io.Discard, gr)
+	if err != nil {
+		return newErrorf(ErrCodeCorruptArchive, OperationScan, afs.archivePath, err,
+			"failed to read gzip stream: %v", err)
+	}
+
+	afs.add(path.Base(name), &fsNode{mode: 0444, size: size, modTime: modTime})
+	return nil
+}
+
+// add inserts an entry under its cleaned path, synthesizing parent directories.
+// Unsafe names (absolute, traversal) are not exposed.
+func (afs *archiveFS) add(name string, node *fsNode) {
+	clean, ok := cleanArchiveName(name)
+	if !ok {
+		return
+	}
+
+	if existing, dup := afs.nodes[clean]; dup {
+		// Later entries win, matching extraction order; keep known children
+		node.children = existing.children
+	} else {
+		afs.link(clean)
+	}
+	node.name = path.Base(clean)
+	node.fullPath = clean
+	afs.nodes[clean] = node
+}
+
+// link registers clean with its parent directory, creating parents as needed.
+func (afs *archiveFS) link(clean string) {
+	dir := path.Dir(clean)
+	parent, ok := afs.nodes[dir]
+	if !ok {
+		parent = &fsNode{name: path.Base(dir), fullPath: dir, mode: fs.ModeDir | 0555}
+		afs.nodes[dir] = parent
+		afs.link(dir)
+	}
+	parent.children = append(parent.children, path.Base(clean))
+}
+
+// cleanArchiveName normalizes an archive entry name to an fs.FS path.
+func cleanArchiveName(name string) (string, bool) {
+	if isPathTraversal(name) {
+		return "", false
+	}
+	clean := path.Clean(strings.ReplaceAll(name, "\\", "/"))
+	if clean == "." || !fs.ValidPath(clean) {
+		return "", false
+	}
+	return clean, true
+}
+
+// lookup resolves name, following symlinks and hard links when follow is set.
+func (afs *archiveFS) lookup(op, name string, follow bool) (*fsNode, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	current := name
+	for hops := 0; hops <= maxSymlinkHops; hops++ {
+		node, ok := afs.nodes[current]
+		if !ok {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		switch {
+		case follow && node.mode&fs.ModeSymlink != 0:
+			target := path.Join(path.Dir(current), node.link)
+			if path.IsAbs(node.link) || !fs.ValidPath(target) {
+				// Links leaving the archive root cannot be followed
+				return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+			}
+			current = target
+		case node.hardLink != "":
+			target, ok := cleanArchiveName(node.hardLink)
+			if !ok {
+				return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+			}
+			current = target
+		default:
+			return node, nil
+		}
+	}
+	return nil, &fs.PathError{Op: op, Path: name, Err: errors.New("too many levels of symbolic links")}
+}
+
+// Open implements fs.FS.
+func (afs *archiveFS) Open(name string) (fs.File, error) {
+	node, err := afs.lookup("open", name, true)
+	if err != nil {
+		return nil, err
+	}
+	// Report the requested name, with the resolved node's content and metadata
+	info := &archiveFileInfo{node: node, name: path.Base(name)}
+
+	if node.mode.IsDir() {
+		return &archiveDir{fsys: afs, node: node, info: info}, nil
+	}
+
+	rc, err := afs.openContent(node)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &archiveFile{ReadCloser: rc, info: info}, nil
+}
+
+// openContent returns a reader over a file node's content.
+func (afs *archiveFS) openContent(node *fsNode) (io.ReadCloser, error) {
+	afs.mu.Lock()
+	defer afs.mu.Unlock()
+	if afs.closed {
+		return nil, fs.ErrClosed
+	}
+
+	switch afs.format {
+	case ArchiveFormatZIP:
+		if node.zipFile == nil {
+			return nil, fs.ErrNotExist
+		}
+		return node.zipFile.Open()
+	case ArchiveFormatTAR:
+		return io.NopCloser(io.NewSectionReader(afs.file, node.offset, node.size)), nil
+	case ArchiveFormatTARGZ, ArchiveFormatGZIP:
+		return afs.openCompressed(node)
+	default:
+		return nil, fs.ErrInvalid
+	}
+}
+
+// openCompressed re-decompresses the archive stream up to the node's data offset.
+func (afs *archiveFS) openCompressed(node *fsNode) (io.ReadCloser, error) {
+	f, err := os.Open(afs.archivePath)
+	if err != nil {
+		return nil, err
+	}
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if _, err := io.CopyN(io.Discard, gr, node.offset); err != nil {
+		_ = gr.Close()
+		_ = f.Close()
+		return nil, err
+	}
+	return &compressedEntryReader{Reader: io.LimitReader(gr, node.size), gr: gr, f: f}, nil
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (afs *archiveFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	node, err := afs.lookup("readdir", name, true)
+	if err != nil {
+		return nil, err
+	}
+	if !node.mode.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return afs.dirEntries(node), nil
+}
+
+// dirEntries lists a directory node's children in name order.
+func (afs *archiveFS) dirEntries(node *fsNode) []fs.DirEntry {
+	entries := make([]fs.DirEntry, 0, len(node.children))
+	for _, child := range node.children {
+		childNode := afs.nodes[path.Join(node.fullPath, child)]
+		entries = append(entries, fs.FileInfoToDirEntry(&archiveFileInfo{node: childNode, name: child}))
+	}
+	return entries
+}
+
+// Stat implements fs.StatFS.
+func (afs *archiveFS) Stat(name string) (fs.FileInfo, error) {
+	node, err := afs.lookup("stat", name, true)
+	if err != nil {
+		return nil, err
+	}
+	return &archiveFileInfo{node: node, name: path.Base(name)}, nil
+}
+
+// Lstat implements fs.ReadLinkFS.
+func (afs *archiveFS) Lstat(name string) (fs.FileInfo, error) {
+	node, err := afs.lookup("lstat", name, false)
+	if err != nil {
+		return nil, err
+	}
+	return &archiveFileInfo{node: node, name: path.Base(name)}, nil
+}
+
+// ReadLink implements fs.ReadLinkFS.
+func (afs *archiveFS) ReadLink(name string) (string, error) {
+	node, err := afs.lookup("readlink", name, false)
+	if err != nil {
+		return "", err
+	}
+	if node.mode&fs.ModeSymlink == 0 {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+	}
+	return node.link, nil
+}
+
+// Close releases the underlying archive file. Files opened from the FS
+// must not be read after Close.
+func (afs *archiveFS) Close() error {
+	afs.mu.Lock()
+	defer afs.mu.Unlock()
+	if afs.closed {
+		return nil
+	}
+	afs.closed = true
+
+	var err error
+	if afs.file != nil {
+		err = afs.file.Close()
+	}
+	if afs.zr != nil {
+		err = afs.zr.Close()
+	}
+	return err
+}
+
+// compressedEntryReader closes the per-open gzip stream and file handle.
+type compressedEntryReader struct {
+	io.Reader
+	gr *gzip.Reader
+	f  *os.File
+}
+
+func (r *compressedEntryReader) Close() error {
+	_ = r.gr.Close()
+	return r.f.Close()
+}
+
+// archiveFile is an open regular file.
+type archiveFile struct {
+	io.ReadCloser
+	info *archiveFileInfo
+}
+
+func (f *archiveFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+// archiveDir is an open directory.
+type archiveDir struct {
+	fsys    *archiveFS
+	node    *fsNode
+	info    *archiveFileInfo
+	entries []fs.DirEntry
+	pos     int
+}
+
+func (d *archiveDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+
+func (d *archiveDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.node.fullPath, Err: errors.New("is a directory")}
+}
+
+func (d *archiveDir) Close() error { return nil }
+
+// ReadDir implements fs.ReadDirFile.
+func (d *archiveDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.entries == nil {
+		d.entries = d.fsys.dirEntries(d.node)
+	}
+	remaining := d.entries[d.pos:]
+	if n <= 0 {
+		d.pos = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+	d.pos += n
+	return remaining[:n], nil
+}
+
+// archiveFileInfo implements fs.FileInfo for an indexed node.
+type archiveFileInfo struct {
+	node *fsNode
+	name string
+}
+
+func (i *archiveFileInfo) Name() string       { return i.name }
+func (i *archiveFileInfo) Size() int64        { return i.node.size }
+func (i *archiveFileInfo) Mode() fs.FileMode  { return i.node.mode }
+func (i *archiveFileInfo) ModTime() time.Time { return i.node.modTime }
+func (i *archiveFileInfo) IsDir() bool        { return i.node.mode.IsDir() }
+func (i *archiveFileInfo) Sys() any           { return nil }
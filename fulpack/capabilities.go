@@ -0,0 +1,131 @@
+package fulpack
+
+// FormatCapabilities describes what an archive format can represent as
+// implemented by fulpack. Check it before Create or Convert to warn users when a
+// format will silently drop metadata they care about (e.g., symlinks in zip).
+type FormatCapabilities struct {
+	// Format is the archive format described.
+	Format ArchiveFormat `json:"format"`
+
+	// Compression is the compression applied by the format ("none", "gzip", "deflate").
+	Compression string `json:"compression"`
+
+	// MultipleEntries reports whether the format holds more than one file.
+	MultipleEntries bool `json:"multiple_entries"`
+
+	// Directories reports whether directory entries are stored.
+	Directories bool `json:"directories"`
+
+	// Symlinks reports whether symbolic links are stored as links.
+	// See LinkPolicy for how Create handles links in formats without support.
+	Symlinks bool `json:"symlinks"`
+
+	// Permissions reports whether file permission bits are stored.
+	Permissions bool `json:"permissions"`
+
+	// ModTimes reports whether modification times are stored.
+	ModTimes bool `json:"mtimes"`
+
+	// Streaming reports whether the archive can be written and read sequentially
+	// without seeking (e.g., piped over a network).
+	Streaming bool `json:"streaming"`
+
+	// RandomAccess reports whether a single entry can be read without scanning
+	// the entries before it.
+	RandomAccess bool `json:"random_access"`
+
+	// Encryption reports whether fulpack can create encrypted archives in this format.
+	Encryption bool `json:"encryption"`
+
+	// MaxEntrySize is the maximum size of a single entry in bytes (0 = no practical limit).
+	MaxEntrySize int64 `json:"max_entry_size"`
+
+	// MaxEntryNameLength is the maximum entry path length in bytes (0 = no practical limit).
+	MaxEntryNameLength int `json:"max_entry_name_length"`
+
+	// Notes lists caveats that the flags above do not capture.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// formatCapabilities holds the capabilities of each supported format.
+var formatCapabilities = map[ArchiveFormat]FormatCapabilities{
+	ArchiveFormatTAR: {
+		Format:          ArchiveFormatTAR,
+		Compression:     "none",
+		MultipleEntries: true,
+		Directories:     true,
+		Symlinks:        true,
+		Permissions:     true,
+		ModTimes:        true,
+		Streaming:       true,
+		Notes: []string{
+			"PAX headers are used for long names and entries over 8 GiB",
+			"hardlinks are stored as link entries when CreateOptions.DetectHardlinks is set",
+			"ownership, xattrs (Linux), and sub-second mtimes are recorded on request via PAX headers",
+		},
+	},
+	ArchiveFormatTARGZ: {
+		Format:          ArchiveFormatTARGZ,
+		Compression:     "gzip",
+		MultipleEntries: true,
+		Directories:     true,
+		Symlinks:        true,
+		Permissions:     true,
+		ModTimes:        true,
+		Streaming:       true,
+		Notes: []string{
+			"PAX headers are used for long names and entries over 8 GiB",
+			"hardlinks are stored as link entries when CreateOptions.DetectHardlinks is set",
+			"ownership, xattrs (Linux), and sub-second mtimes are recorded on request via PAX headers",
+		},
+	},
+	ArchiveFormatZIP: {
+		Format:             ArchiveFormatZIP,
+		Compression:        "deflate",
+		MultipleEntries:    true,
+		Directories:        true,
+		Symlinks:           false,
+		Permissions:        true,
+		ModTimes:           true,
+		Streaming:          false,
+		RandomAccess:       true,
+		MaxEntryNameLength: 65535,
+		Notes: []string{
+			"permissions are stored as Unix external attributes and ignored by most Windows tools",
+			"symlinks are skipped and hardlinked files stored as separate copies (reported in ArchiveInfo.Warnings)",
+			"reading requires a seekable file (central directory is at the end)",
+			"ZIP64 is used automatically for entries and archives over 4 GiB",
+		},
+	},
+	ArchiveFormatGZIP: {
+		Format:          ArchiveFormatGZIP,
+		Compression:     "gzip",
+		MultipleEntries: false,
+		Directories:     false,
+		Symlinks:        false,
+		Permissions:     false,
+		ModTimes:        true,
+		Streaming:       true,
+		Notes: []string{
+			"holds exactly one regular file; only its base name is kept",
+			"a symlink source is stored as its target's content",
+		},
+	},
+}
+
+// Capabilities reports what the given archive format supports.
+//
+// Example:
+//
+//	caps, err := fulpack.Capabilities(fulpack.ArchiveFormatZIP)
+//	if err == nil && !caps.Symlinks {
+//	    log.Println("warning: zip archives do not preserve symlinks")
+//	}
+func Capabilities(format ArchiveFormat) (FormatCapabilities, error) {
+	caps, ok := formatCapabilities[format]
+	if !ok {
+		return FormatCapabilities{}, newError(ErrCodeInvalidFormat, "unsupported archive format: "+string(format), OperationInfo, "", nil)
+	}
+	caps.Notes = append([]string(nil), caps.Notes...)
+	return caps, nil
+}
@@ -0,0 +1,137 @@
+package fulpack
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/fulhash"
+)
+
+// verifyDetachedDigest compares the archive against a published digest
+// (VerifyOptions.ExpectedDigest or VerifyOptions.ChecksumFile) and records the
+// outcome in result. It returns an error only when the expected digest cannot
+// be determined or the archive cannot be hashed.
+func verifyDetachedDigest(archive string, options *VerifyOptions, result *ValidationResult) error {
+	if options == nil || (options.ChecksumFile == "" && options.ExpectedDigest.Algorithm() == "") {
+		return nil
+	}
+
+	expected := options.ExpectedDigest
+	source := "expected_digest"
+	if expected.Algorithm() == "" {
+		parsed, err := readChecksumFile(options.ChecksumFile, archive)
+		if err != nil {
+			return err
+		}
+		expected = parsed
+		source = options.ChecksumFile
+	}
+
+	f, err := os.Open(archive)
+	if err != nil {
+		return newError(ErrCodeInvalidFormat, "failed to open archive", OperationVerify, archive, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	actual, err := fulhash.HashReader(f, fulhash.WithAlgorithm(expected.Algorithm()))
+	if err != nil {
+		return newError(ErrCodeCorruptArchive, "failed to hash archive", OperationVerify, archive, err)
+	}
+
+	result.ChecksPerformed = append(result.ChecksPerformed, "digest_verified")
+	if !bytes.Equal(actual.Bytes(), expected.Bytes()) {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Code:    ErrCodeChecksumMismatch,
+			Message: "Archive digest does not match published checksum",
+			Path:    archive,
+			Details: map[string]any{
+				"expected": fulhash.FormatDigest(expected),
+				"actual":   fulhash.FormatDigest(actual),
+				"source":   source,
+			},
+		})
+	}
+	return nil
+}
+
+// readChecksumFile parses a detached checksum file for archive.
+//
+// Accepted line formats (blank lines and # comments are ignored):
+//   - "<hex>  <filename>" or "<hex> *<filename>" (sha256sum style)
+//   - "<hex>" (bare digest)
+//   - "<algorithm>:<hex>" (fulhash format)
+//
+// When the file lists several archives, the line naming archive's base name is used.
+// The algorithm is taken from the file extension (.sha256, .xxh3, .xxh3-128) and
+// otherwise inferred from the digest length.
+func readChecksumFile(checksumFile, archive string) (fulhash.Digest, error) {
+	data, err := os.ReadFile(checksumFile)
+	if err != nil {
+		return fulhash.Digest{}, newError(ErrCodeInvalidChecksumFile, "failed to read checksum file", OperationVerify, checksumFile, err)
+	}
+
+	archiveName := filepath.Base(archive)
+	var candidates []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 1 {
+			candidates = append(candidates, fields[0])
+			continue
+		}
+		name := strings.TrimPrefix(strings.Join(fields[1:], " "), "*")
+		if filepath.Base(name) == archiveName {
+			return parseChecksumValue(fields[0], checksumFile)
+		}
+		candidates = append(candidates, "")
+	}
+
+	// An unnamed digest is accepted only when it is the file's sole entry
+	if len(candidates) == 1 && candidates[0] != "" {
+		return parseChecksumValue(candidates[0], checksumFile)
+	}
+	return fulhash.Digest{}, newErrorf(ErrCodeInvalidChecksumFile, OperationVerify, checksumFile, nil,
+		"no checksum found for %s", archiveName)
+}
+
+// parseChecksumValue converts a checksum file value into a fulhash Digest.
+func parseChecksumValue(value, checksumFile string) (fulhash.Digest, error) {
+	if !strings.Contains(value, ":") {
+		alg := checksumAlgorithm(checksumFile, value)
+		if alg == "" {
+			return fulhash.Digest{}, newErrorf(ErrCodeInvalidChecksumFile, OperationVerify, checksumFile, nil,
+				"cannot determine algorithm for %d-character digest", len(value))
+		}
+		value = string(alg) + ":" + strings.ToLower(value)
+	}
+
+	digest, err := fulhash.ParseDigest(value)
+	if err != nil {
+		return fulhash.Digest{}, newError(ErrCodeInvalidChecksumFile, "malformed checksum", OperationVerify, checksumFile, err)
+	}
+	return digest, nil
+}
+
+// checksumAlgorithm determines the digest algorithm from the checksum file
+// extension, falling back to the hex digest length.
+func checksumAlgorithm(checksumFile, hexValue string) fulhash.Algorithm {
+	switch strings.ToLower(filepath.Ext(checksumFile)) {
+	case ".sha256":
+		return fulhash.SHA256
+	case ".xxh3", ".xxh3-128":
+		return fulhash.XXH3_128
+	}
+	switch len(hexValue) {
+	case 64:
+		return fulhash.SHA256
+	case 32:
+		return fulhash.XXH3_128
+	}
+	return ""
+}
@@ -0,0 +1,405 @@
+package fulpack
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// convertImpl implements the Convert operation.
+func convertImpl(source string, output string, format ArchiveFormat, options *ConvertOptions) (*ConvertResult, error) {
+	start := time.Now()
+	var err error
+	var result *ConvertResult
+
+	defer func() {
+		duration := time.Since(start)
+		var entryCount int
+		var bytesProcessed int64
+		if result != nil {
+			entryCount = result.ConvertedCount
+			if result.Info != nil {
+				bytesProcessed = result.Info.TotalSize
+			}
+		}
+		emitOperationMetrics(OperationConvert, format, duration, entryCount, bytesProcessed, err)
+	}()
+
+	// Apply defaults
+	opts := applyConvertDefaults(options)
+
+	if output == "" {
+		err = newError(ErrCodeInvalidFormat, "output path cannot be empty", OperationConvert, "", nil)
+		return nil, err
+	}
+	if sameFile(source, output) {
+		err = newError(ErrCodeInvalidFormat, "output must differ from source archive", OperationConvert, output, nil)
+		return nil, err
+	}
+	if detectFormat(source) == "" {
+		err = newError(ErrCodeInvalidFormat, "could not detect source archive format", OperationConvert, source, nil)
+		return nil, err
+	}
+
+	writer, openErr := newEntryWriter(output, format, opts)
+	if openErr != nil {
+		err = openErr
+		return nil, err
+	}
+
+	result = &ConvertResult{
+		Info: &ArchiveInfo{
+			Format:      format,
+			Compression: getCompressionType(format),
+			Checksums:   make(map[string]string),
+		},
+	}
+
+	var totalSize int64
+	var entryCount int
+	walkErr := walkArchive(source, OperationConvert, func(entry ArchiveEntry, r io.Reader) error {
+		entryCount++
+		if entryCount > opts.MaxEntries {
+			return newErrorf(ErrCodeMaxEntriesExceeded, OperationConvert, source, nil,
+				"archive contains more than %d entries", opts.MaxEntries)
+		}
+
+		// Security: Never carry unsafe paths into the new archive
+		if isPathTraversal(entry.Path) {
+			result.Unrepresentable = append(result.Unrepresentable, ExtractionError{
+				Path:  entry.Path,
+				Error: "path traversal detected",
+				Code:  ErrCodePathTraversal,
+			})
+			return nil
+		}
+
+		normalizedPath := normalizeEntryPath(entry.Path)
+		if normalizedPath == "." || !shouldExtract(normalizedPath, opts.IncludePatterns, opts.ExcludePatterns) {
+			result.SkippedCount++
+			return nil
+		}
+		entry.Path = normalizedPath
+
+		if reason := writer.unrepresentable(entry); reason != "" {
+			result.Unrepresentable = append(result.Unrepresentable, ExtractionError{
+				Path:  entry.Path,
+				Error: reason,
+				Code:  ErrCodeUnrepresentableEntry,
+			})
+			return nil
+		}
+
+		if !*opts.PreservePermissions {
+			entry.Mode = 0
+		}
+
+		// Security: Reject oversized entries before streaming when the size is known
+		if entry.Size > 0 && totalSize+entry.Size > opts.MaxSize.Bytes() {
+			return newErrorf(ErrCodeMaxSizeExceeded, OperationConvert, source, nil,
+				"total uncompressed size exceeds limit of %d bytes", opts.MaxSize)
+		}
+
+		var limited io.Reader
+		if r != nil {
+			// Security: Bound total decompressed bytes across all entries
+			limited = &io.LimitedReader{R: r, N: opts.MaxSize.Bytes() - totalSize + 1}
+		}
+
+		n, writeErr := writer.writeEntry(entry, limited)
+		if writeErr != nil {
+			return newErrorf(ErrCodeCorruptArchive, OperationConvert, entry.Path, writeErr,
+				"failed to write entry: %v", writeErr)
+		}
+		totalSize += n
+		if totalSize > opts.MaxSize.Bytes() {
+			return newErrorf(ErrCodeMaxSizeExceeded, OperationConvert, source, nil,
+				"total uncompressed size exceeds limit of %d bytes", opts.MaxSize)
+		}
+
+		result.ConvertedCount++
+		return nil
+	})
+
+	closeErr := writer.close()
+	if walkErr == nil && closeErr != nil {
+		walkErr = newErrorf(ErrCodeCorruptArchive, OperationConvert, output, closeErr,
+			"failed to finalize archive: %v", closeErr)
+	}
+	if walkErr != nil {
+		_ = os.Remove(output)
+		err = walkErr
+		return result, err
+	}
+
+	result.Info.EntryCount = result.ConvertedCount
+	result.Info.TotalSize = totalSize
+	if fileInfo, statErr := os.Stat(output); statErr == nil {
+		result.Info.CompressedSize = fileInfo.Size()
+		result.Info.CompressionRatio = calculateCompressionRatio(totalSize, fileInfo.Size())
+	}
+	now := time.Now()
+	result.Info.Created = &now
+
+	return result, nil
+}
+
+// sameFile reports whether two paths refer to the same file on disk.
+func sameFile(a, b string) bool {
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return os.SameFile(infoA, infoB)
+}
+
+// entryWriter writes normalized entries into a target archive format.
+type entryWriter interface {
+	// unrepresentable returns a reason if the entry cannot be stored in the format.
+	unrepresentable(entry ArchiveEntry) string
+	// writeEntry writes the entry (r is nil for non-file entries) and returns bytes written.
+	writeEntry(entry ArchiveEntry, r io.Reader) (int64, error)
+	// close flushes and closes the archive.
+	close() error
+}
+
+// newEntryWriter creates an entryWriter for the target format.
+func newEntryWriter(output string, format ArchiveFormat, opts *ConvertOptions) (entryWriter, error) {
+	switch format {
+	case ArchiveFormatTAR, ArchiveFormatTARGZ, ArchiveFormatZIP, ArchiveFormatGZIP:
+	default:
+		return nil, newError(ErrCodeInvalidFormat, "unsupported archive format", OperationConvert, output, nil)
+	}
+
+	outFile, err := os.Create(output)
+	if err != nil {
+		return nil, newErrorf(ErrCodeFileExists, OperationConvert, output, err,
+			"failed to create archive: %v", err)
+	}
+
+	switch format {
+	case ArchiveFormatTAR:
+		return &tarEntryWriter{file: outFile, tw: tar.NewWriter(outFile)}, nil
+	case ArchiveFormatTARGZ:
+		gw, err := gzip.NewWriterLevel(outFile, opts.CompressionLevel)
+		if err != nil {
+			_ = outFile.Close()
+			return nil, newErrorf(ErrCodeUnsupportedCompression, OperationConvert, output, err,
+				"failed to create gzip writer: %v", err)
+		}
+		return &tarEntryWriter{file: outFile, gw: gw, tw: tar.NewWriter(gw)}, nil
+	case ArchiveFormatZIP:
+		zw := zip.NewWriter(outFile)
+		level := opts.CompressionLevel
+		zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(out, level)
+		})
+		return &zipEntryWriter{file: outFile, zw: zw}, nil
+	default:
+		return &gzipEntryWriter{file: outFile, level: opts.CompressionLevel}, nil
+	}
+}
+
+// tarEntryWriter writes entries to a tar (optionally gzip-compressed) stream.
+type tarEntryWriter struct {
+	file *os.File
+	gw   *gzip.Writer
+	tw   *tar.Writer
+}
+
+func (w *tarEntryWriter) unrepresentable(entry ArchiveEntry) string {
+	return ""
+}
+
+func (w *tarEntryWriter) writeEntry(entry ArchiveEntry, r io.Reader) (int64, error) {
+	header := &tar.Header{
+		Name:    entry.Path,
+		Mode:    int64(os.FileMode(entry.Mode).Perm()),
+		ModTime: entry.Modified,
+	}
+
+	switch entry.Type {
+	case EntryTypeDirectory:
+		header.Typeflag = tar.TypeDir
+		header.Name += "/"
+		if header.Mode == 0 {
+			header.Mode = 0755
+		}
+		return 0, w.tw.WriteHeader(header)
+	case EntryTypeSymlink:
+		header.Typeflag = tar.TypeSymlink
+		header.Linkname = entry.LinkTarget
+		if header.Mode == 0 {
+			header.Mode = 0777
+		}
+		return 0, w.tw.WriteHeader(header)
+	}
+
+	header.Typeflag = tar.TypeReg
+	if header.Mode == 0 {
+		header.Mode = 0644
+	}
+
+	// Tar headers need the size up front; spool streams of unknown length (gzip sources)
+	size := entry.Size
+	if size < 0 {
+		spool, n, err := spoolToTemp(r)
+		if err != nil {
+			return 0, err
+		}
+		defer func() {
+			_ = spool.Close()
+			_ = os.Remove(spool.Name())
+		}()
+		r = spool
+		size = n
+	}
+
+	header.Size = size
+	if err := w.tw.WriteHeader(header); err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(w.tw, r)
+	if err != nil {
+		return n, err
+	}
+	if n != size {
+		return n, fmt.Errorf("size mismatch: expected %d bytes, read %d bytes", size, n)
+	}
+	return n, nil
+}
+
+func (w *tarEntryWriter) close() error {
+	err := w.tw.Close()
+	if w.gw != nil {
+		if gzErr := w.gw.Close(); err == nil {
+			err = gzErr
+		}
+	}
+	if fileErr := w.file.Close(); err == nil {
+		err = fileErr
+	}
+	return err
+}
+
+// zipEntryWriter writes entries to a zip archive.
+type zipEntryWriter struct {
+	file *os.File
+	zw   *zip.Writer
+}
+
+func (w *zipEntryWriter) unrepresentable(entry ArchiveEntry) string {
+	if entry.Type == EntryTypeSymlink {
+		// Consistent with Create(): zip has no portable symlink representation
+		return "symlinks cannot be represented in zip archives"
+	}
+	return ""
+}
+
+func (w *zipEntryWriter) writeEntry(entry ArchiveEntry, r io.Reader) (int64, error) {
+	header := &zip.FileHeader{
+		Name:     entry.Path,
+		Method:   zip.Deflate,
+		Modified: entry.Modified,
+	}
+
+	if entry.Type == EntryTypeDirectory {
+		header.Name += "/"
+		mode := os.FileMode(entry.Mode).Perm()
+		if mode == 0 {
+			mode = 0755
+		}
+		header.SetMode(mode | os.ModeDir)
+		_, err := w.zw.CreateHeader(header)
+		return 0, err
+	}
+
+	mode := os.FileMode(entry.Mode).Perm()
+	if mode == 0 {
+		mode = 0644
+	}
+	header.SetMode(mode)
+
+	fw, err := w.zw.CreateHeader(header)
+	if err != nil {
+		return 0, err
+	}
+	return io.Copy(fw, r)
+}
+
+func (w *zipEntryWriter) close() error {
+	err := w.zw.Close()
+	if fileErr := w.file.Close(); err == nil {
+		err = fileErr
+	}
+	return err
+}
+
+// gzipEntryWriter writes the first file entry as a single gzip payload.
+type gzipEntryWriter struct {
+	file    *os.File
+	level   int
+	gw      *gzip.Writer
+	written bool
+}
+
+func (w *gzipEntryWriter) unrepresentable(entry ArchiveEntry) string {
+	switch {
+	case entry.Type != EntryTypeFile:
+		return fmt.Sprintf("gzip format cannot store %s entries", entry.Type)
+	case w.written:
+		return "gzip format holds a single file; entry dropped"
+	}
+	return ""
+}
+
+func (w *gzipEntryWriter) writeEntry(entry ArchiveEntry, r io.Reader) (int64, error) {
+	gw, err := gzip.NewWriterLevel(w.file, w.level)
+	if err != nil {
+		return 0, err
+	}
+	gw.Name = filepath.Base(entry.Path)
+	gw.ModTime = entry.Modified
+	w.gw = gw
+	w.written = true
+	return io.Copy(gw, r)
+}
+
+func (w *gzipEntryWriter) close() error {
+	var err error
+	if w.gw != nil {
+		err = w.gw.Close()
+	}
+	if fileErr := w.file.Close(); err == nil {
+		err = fileErr
+	}
+	if err == nil && !w.written {
+		err = fmt.Errorf("no file entry available for gzip output")
+	}
+	return err
+}
+
+// spoolToTemp copies r into a temporary file and rewinds it.
+func spoolToTemp(r io.Reader) (*os.File, int64, error) {
+	tmp, err := os.CreateTemp("", "fulpack-spool-*")
+	if err != nil {
+		return nil, 0, err
+	}
+	n, err := io.Copy(tmp, r)
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return nil, 0, err
+	}
+	return tmp, n, nil
+}
@@ -48,6 +48,11 @@ func createImpl(sources []string, output string, format ArchiveFormat, options *
 		return nil, err
 	}
 
+	if !validLinkPolicy(opts.LinkPolicy) {
+		err = newError(ErrCodeInvalidFormat, "unsupported link policy: "+string(opts.LinkPolicy), OperationCreate, "", nil)
+		return nil, err
+	}
+
 	// Initialize archive info
 	info = &ArchiveInfo{
 		Format:      format,
@@ -63,17 +68,28 @@ func createImpl(sources []string, output string, format ArchiveFormat, options *
 		err = discoverErr
 		return nil, err
 	}
+	filesToArchive, discoverErr = applyLinkPolicy(filesToArchive, format, opts, info)
+	if discoverErr != nil {
+		err = discoverErr
+		return nil, err
+	}
+	info.Warnings = append(info.Warnings, metadataWarnings(format, opts)...)
+
+	var progress *progressTracker
+	if opts.Progress != nil {
+		progress = newProgressTracker(opts.Progress, OperationCreate, sumFileSizes(filesToArchive, opts.FollowSymlinks))
+	}
 
 	// Create archive based on format
 	switch format {
 	case ArchiveFormatTAR:
-		err = createTar(output, filesToArchive, opts, info)
+		err = createTar(output, filesToArchive, opts, info, progress)
 	case ArchiveFormatTARGZ:
-		err = createTarGz(output, filesToArchive, opts, info)
+		err = createTarGz(output, filesToArchive, opts, info, progress)
 	case ArchiveFormatZIP:
-		err = createZip(output, filesToArchive, opts, info)
+		err = createZip(output, filesToArchive, opts, info, progress)
 	case ArchiveFormatGZIP:
-		err = createGzip(output, filesToArchive, opts, info)
+		err = createGzip(output, filesToArchive, opts, info, progress)
 	default:
 		err = newError(ErrCodeInvalidFormat, "unsupported archive format", OperationCreate, output, nil)
 		return nil, err
@@ -82,6 +98,7 @@ func createImpl(sources []string, output string, format ArchiveFormat, options *
 	if err != nil {
 		return nil, err
 	}
+	progress.finish()
 
 	// Get compressed size
 	if fileInfo, statErr := os.Stat(output); statErr == nil {
@@ -169,7 +186,14 @@ func discoverSourceFiles(sources []string, opts *CreateOptions) ([]string, error
 
 			for _, result := range results {
 				// Use SourcePath which is the actual filesystem path
-				if !seen[result.SourcePath] {
+				if seen[result.SourcePath] {
+					continue
+				}
+				include, filterErr := passesCreateFilters(result.SourcePath, opts)
+				if filterErr != nil {
+					return nil, filterErr
+				}
+				if include {
 					allFiles = append(allFiles, result.SourcePath)
 					seen[result.SourcePath] = true
 				}
@@ -177,8 +201,12 @@ func discoverSourceFiles(sources []string, opts *CreateOptions) ([]string, error
 		} else {
 			// Single file - check against patterns
 			normalizedPath := filepath.ToSlash(source)
-			if shouldIncludeFile(normalizedPath, opts.IncludePatterns, opts.ExcludePatterns) {
-				if !seen[source] {
+			if shouldIncludeFile(normalizedPath, opts.IncludePatterns, opts.ExcludePatterns) && !seen[source] {
+				include, filterErr := passesCreateFilters(source, opts)
+				if filterErr != nil {
+					return nil, filterErr
+				}
+				if include {
 					allFiles = append(allFiles, source)
 					seen[source] = true
 				}
@@ -189,6 +217,39 @@ func discoverSourceFiles(sources []string, opts *CreateOptions) ([]string, error
 	return allFiles, nil
 }
 
+// passesCreateFilters applies the size, modification time, and custom predicate
+// filters from CreateOptions to a discovered source file.
+func passesCreateFilters(path string, opts *CreateOptions) (bool, error) {
+	if opts.MaxFileSize == 0 && opts.ModifiedAfter.IsZero() && opts.ModifiedBefore.IsZero() && opts.Filter == nil {
+		return true, nil
+	}
+
+	stat := os.Lstat
+	if opts.FollowSymlinks {
+		stat = os.Stat
+	}
+	info, err := stat(path)
+	if err != nil {
+		return false, newErrorf(ErrCodeFileAccess, OperationCreate, path, err,
+			"failed to stat source file: %v", err)
+	}
+
+	if opts.MaxFileSize > 0 && info.Mode().IsRegular() && info.Size() > opts.MaxFileSize.Bytes() {
+		return false, nil
+	}
+	if !opts.ModifiedAfter.IsZero() && info.ModTime().Before(opts.ModifiedAfter) {
+		return false, nil
+	}
+	if !opts.ModifiedBefore.IsZero() && !info.ModTime().Before(opts.ModifiedBefore) {
+		return false, nil
+	}
+	if opts.Filter != nil && !opts.Filter(path, info) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
 // shouldIncludeFile checks if a file should be included based on patterns.
 func shouldIncludeFile(normalizedPath string, includePatterns, excludePatterns []string) bool {
 	// Check exclude patterns first
@@ -213,8 +274,23 @@ func shouldIncludeFile(normalizedPath string, includePatterns, excludePatterns [
 	return false
 }
 
+// sumFileSizes totals the sizes of regular files for progress reporting.
+func sumFileSizes(files []string, followSymlinks bool) int64 {
+	stat := os.Lstat
+	if followSymlinks {
+		stat = os.Stat
+	}
+	var total int64
+	for _, path := range files {
+		if fileInfo, err := stat(path); err == nil && fileInfo.Mode().IsRegular() {
+			total += fileInfo.Size()
+		}
+	}
+	return total
+}
+
 // createTar creates an uncompressed tar archive.
-func createTar(output string, files []string, opts *CreateOptions, info *ArchiveInfo) error {
+func createTar(output string, files []string, opts *CreateOptions, info *ArchiveInfo, progress *progressTracker) error {
 	outFile, err := os.Create(output)
 	if err != nil {
 		return newErrorf(ErrCodeFileExists, OperationCreate, output, err,
@@ -225,11 +301,11 @@ func createTar(output string, files []string, opts *CreateOptions, info *Archive
 	tw := tar.NewWriter(outFile)
 	defer func() { _ = tw.Close() }()
 
-	return writeTarEntries(tw, files, opts, info, output)
+	return writeTarEntries(tw, files, opts, info, output, progress)
 }
 
 // createTarGz creates a tar.gz archive.
-func createTarGz(output string, files []string, opts *CreateOptions, info *ArchiveInfo) error {
+func createTarGz(output string, files []string, opts *CreateOptions, info *ArchiveInfo, progress *progressTracker) error {
 	outFile, err := os.Create(output)
 	if err != nil {
 		return newErrorf(ErrCodeFileExists, OperationCreate, output, err,
@@ -248,12 +324,15 @@ func createTarGz(output string, files []string, opts *CreateOptions, info *Archi
 	tw := tar.NewWriter(gw)
 	defer func() { _ = tw.Close() }()
 
-	return writeTarEntries(tw, files, opts, info, output)
+	return writeTarEntries(tw, files, opts, info, output, progress)
 }
 
 // writeTarEntries writes files to a tar writer.
-func writeTarEntries(tw *tar.Writer, files []string, opts *CreateOptions, info *ArchiveInfo, archivePath string) error {
+func writeTarEntries(tw *tar.Writer, files []string, opts *CreateOptions, info *ArchiveInfo, archivePath string, progress *progressTracker) error {
+	hardlinks := newHardlinkTracker(opts.DetectHardlinks)
+	metadata := newMetadataCapture(opts)
 	for _, filePath := range files {
+		progress.setEntry(filePath)
 		fileInfo, err := os.Lstat(filePath)
 		if err != nil {
 			return newErrorf(ErrCodeCorruptArchive, OperationCreate, archivePath, err,
@@ -282,12 +361,17 @@ func writeTarEntries(tw *tar.Writer, files []string, opts *CreateOptions, info *
 					header.Mode = 0777
 				}
 
+				if err := metadata.apply(header, filePath, fileInfo); err != nil {
+					return err
+				}
+
 				if err := tw.WriteHeader(header); err != nil {
 					return newErrorf(ErrCodeCorruptArchive, OperationCreate, archivePath, err,
 						"failed to write symlink header: %v", err)
 				}
 
 				info.EntryCount++
+				progress.entryDone()
 				continue
 			}
 
@@ -313,12 +397,45 @@ func writeTarEntries(tw *tar.Writer, files []string, opts *CreateOptions, info *
 				header.Mode = 0755
 			}
 
+			if err := metadata.apply(header, filePath, fileInfo); err != nil {
+				return err
+			}
+
 			if err := tw.WriteHeader(header); err != nil {
 				return newErrorf(ErrCodeCorruptArchive, OperationCreate, archivePath, err,
 					"failed to write directory header: %v", err)
 			}
 
 			info.EntryCount++
+			progress.entryDone()
+			continue
+		}
+
+		// Store later links to an already archived file as hardlink entries
+		if linkName, ok := hardlinks.previous(fileInfo, filePath); ok {
+			header := &tar.Header{
+				Name:     filePath,
+				Linkname: linkName,
+				Typeflag: tar.TypeLink,
+				Mode:     int64(fileInfo.Mode()),
+				ModTime:  fileInfo.ModTime(),
+			}
+
+			if !*opts.PreservePermissions {
+				header.Mode = 0644
+			}
+
+			if err := metadata.apply(header, filePath, fileInfo); err != nil {
+				return err
+			}
+
+			if err := tw.WriteHeader(header); err != nil {
+				return newErrorf(ErrCodeCorruptArchive, OperationCreate, archivePath, err,
+					"failed to write hardlink header: %v", err)
+			}
+
+			info.EntryCount++
+			progress.entryDone()
 			continue
 		}
 
@@ -340,13 +457,18 @@ func writeTarEntries(tw *tar.Writer, files []string, opts *CreateOptions, info *
 			header.Mode = 0644
 		}
 
+		if err := metadata.apply(header, filePath, fileInfo); err != nil {
+			_ = file.Close()
+			return err
+		}
+
 		if err := tw.WriteHeader(header); err != nil {
 			_ = file.Close()
 			return newErrorf(ErrCodeCorruptArchive, OperationCreate, archivePath, err,
 				"failed to write file header: %v", err)
 		}
 
-		bytesWritten, err := io.Copy(tw, file)
+		bytesWritten, err := io.Copy(tw, progress.reader(file))
 		_ = file.Close()
 
 		if err != nil {
@@ -356,13 +478,14 @@ func writeTarEntries(tw *tar.Writer, files []string, opts *CreateOptions, info *
 
 		info.EntryCount++
 		info.TotalSize += bytesWritten
+		progress.entryDone()
 	}
 
 	return nil
 }
 
 // createZip creates a zip archive.
-func createZip(output string, files []string, opts *CreateOptions, info *ArchiveInfo) error {
+func createZip(output string, files []string, opts *CreateOptions, info *ArchiveInfo, progress *progressTracker) error {
 	outFile, err := os.Create(output)
 	if err != nil {
 		return newErrorf(ErrCodeFileExists, OperationCreate, output, err,
@@ -378,7 +501,10 @@ func createZip(output string, files []string, opts *CreateOptions, info *Archive
 		return flate.NewWriter(out, opts.CompressionLevel)
 	})
 
+	hardlinks := newHardlinkTracker(opts.DetectHardlinks)
+	hardlinkWarned := false
 	for _, filePath := range files {
+		progress.setEntry(filePath)
 		fileInfo, err := os.Lstat(filePath)
 		if err != nil {
 			return newErrorf(ErrCodeCorruptArchive, OperationCreate, output, err,
@@ -395,7 +521,7 @@ func createZip(output string, files []string, opts *CreateOptions, info *Archive
 				}
 				fileInfo = targetInfo
 			} else {
-				// ZIP doesn't have native symlink support - skip
+				// ZIP doesn't have native symlink support - skip (applyLinkPolicy warns)
 				continue
 			}
 		}
@@ -416,9 +542,16 @@ func createZip(output string, files []string, opts *CreateOptions, info *Archive
 			}
 
 			info.EntryCount++
+			progress.entryDone()
 			continue
 		}
 
+		// ZIP has no hardlink entries - each link is stored in full
+		if _, ok := hardlinks.previous(fileInfo, filePath); ok && !hardlinkWarned {
+			info.Warnings = append(info.Warnings, "hardlinked files stored as separate copies (zip does not store hardlinks)")
+			hardlinkWarned = true
+		}
+
 		// Handle regular files
 		file, err := os.Open(filePath)
 		if err != nil {
@@ -446,7 +579,7 @@ func createZip(output string, files []string, opts *CreateOptions, info *Archive
 				"failed to create zip entry: %v", err)
 		}
 
-		bytesWritten, err := io.Copy(writer, file)
+		bytesWritten, err := io.Copy(writer, progress.reader(file))
 		_ = file.Close()
 
 		if err != nil {
@@ -456,13 +589,14 @@ func createZip(output string, files []string, opts *CreateOptions, info *Archive
 
 		info.EntryCount++
 		info.TotalSize += bytesWritten
+		progress.entryDone()
 	}
 
 	return nil
 }
 
 // createGzip creates a gzip file (single file only).
-func createGzip(output string, files []string, opts *CreateOptions, info *ArchiveInfo) error {
+func createGzip(output string, files []string, opts *CreateOptions, info *ArchiveInfo, progress *progressTracker) error {
 	// GZIP format only supports single file
 	if len(files) == 0 {
 		return newError(ErrCodeInvalidFormat, "no files to compress", OperationCreate, output, nil)
@@ -507,11 +641,13 @@ func createGzip(output string, files []string, opts *CreateOptions, info *Archiv
 	}
 	defer func() { _ = gw.Close() }()
 
-	// Set gzip header name to original filename
+	// Record the source file's name and modification time in the gzip header
 	gw.Name = filepath.Base(inputPath)
+	gw.ModTime = fileInfo.ModTime()
 
 	// Compress file
-	bytesWritten, err := io.Copy(gw, inFile)
+	progress.setEntry(inputPath)
+	bytesWritten, err := io.Copy(gw, progress.reader(inFile))
 	if err != nil {
 		return newErrorf(ErrCodeCorruptArchive, OperationCreate, output, err,
 			"failed to compress file: %v", err)
@@ -519,6 +655,7 @@ func createGzip(output string, files []string, opts *CreateOptions, info *Archiv
 
 	info.EntryCount = 1
 	info.TotalSize = bytesWritten
+	progress.entryDone()
 
 	return nil
 }
@@ -0,0 +1,231 @@
+package fulpack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/fulhash"
+)
+
+// diffImpl implements the Diff operation.
+func diffImpl(archiveA string, archiveB string, options *DiffOptions) (*DiffResult, error) {
+	start := time.Now()
+	var err error
+	var result *DiffResult
+	var bytesProcessed int64
+
+	defer func() {
+		duration := time.Since(start)
+		var entryCount int
+		if result != nil {
+			entryCount = result.AddedCount + result.RemovedCount + result.ModifiedCount + result.UnchangedCount
+		}
+		emitOperationMetrics(OperationDiff, detectFormat(archiveA), duration, entryCount, bytesProcessed, err)
+	}()
+
+	// Apply defaults
+	opts := applyDiffDefaults(options)
+
+	alg, algErr := entryChecksumAlgorithm(opts.ChecksumAlgorithm, OperationDiff, archiveA)
+	if algErr != nil {
+		err = algErr
+		return nil, err
+	}
+
+	oldEntries, oldBytes, indexErr := indexForDiff(archiveA, alg, opts)
+	bytesProcessed += oldBytes
+	if indexErr != nil {
+		err = indexErr
+		return nil, err
+	}
+	newEntries, newBytes, indexErr := indexForDiff(archiveB, alg, opts)
+	bytesProcessed += newBytes
+	if indexErr != nil {
+		err = indexErr
+		return nil, err
+	}
+
+	result = &DiffResult{ArchiveA: archiveA, ArchiveB: archiveB, Changes: []DiffEntry{}}
+	for path, oldEntry := range oldEntries {
+		newEntry, ok := newEntries[path]
+		if !ok {
+			result.Changes = append(result.Changes, DiffEntry{Path: path, Change: DiffRemoved, Old: oldEntry})
+			result.RemovedCount++
+			continue
+		}
+		if reasons := diffReasons(oldEntry, newEntry, opts); len(reasons) > 0 {
+			result.Changes = append(result.Changes, DiffEntry{Path: path, Change: DiffModified, Reasons: reasons, Old: oldEntry, New: newEntry})
+			result.ModifiedCount++
+		} else {
+			result.UnchangedCount++
+		}
+	}
+	for path, newEntry := range newEntries {
+		if _, ok := oldEntries[path]; !ok {
+			result.Changes = append(result.Changes, DiffEntry{Path: path, Change: DiffAdded, New: newEntry})
+			result.AddedCount++
+		}
+	}
+	sort.Slice(result.Changes, func(i, j int) bool { return result.Changes[i].Path < result.Changes[j].Path })
+
+	return result, nil
+}
+
+// indexForDiff streams archive once and returns its entries by normalized
+// path, with file content digests in ArchiveEntry.Checksum.
+func indexForDiff(archive string, alg fulhash.Algorithm, opts *DiffOptions) (map[string]*ArchiveEntry, int64, error) {
+	entries := make(map[string]*ArchiveEntry)
+	var totalSize int64
+	var entryCount int
+
+	err := walkArchive(archive, OperationDiff, func(entry ArchiveEntry, r io.Reader) error {
+		entryCount++
+		if entryCount > opts.MaxEntries {
+			return newErrorf(ErrCodeMaxEntriesExceeded, OperationDiff, archive, nil,
+				"archive contains more than %d entries", opts.MaxEntries)
+		}
+
+		normalizedPath := normalizeEntryPath(entry.Path)
+		if normalizedPath == "." || !shouldExtract(normalizedPath, opts.IncludePatterns, opts.ExcludePatterns) {
+			return nil
+		}
+		entry.Path = normalizedPath
+
+		if r != nil {
+			hasher, err := fulhash.NewHasher(fulhash.WithAlgorithm(alg))
+			if err != nil {
+				return newErrorf(ErrCodeUnsupportedChecksumAlgorithm, OperationDiff, archive, err,
+					"failed to create hasher: %v", err)
+			}
+			// Security: Bound total decompressed bytes across all entries
+			n, err := io.Copy(hasher, &io.LimitedReader{R: r, N: opts.MaxSize.Bytes() - totalSize + 1})
+			if err != nil {
+				return newErrorf(ErrCodeCorruptArchive, OperationDiff, entry.Path, err,
+					"failed to read entry: %v", err)
+			}
+			totalSize += n
+			if totalSize > opts.MaxSize.Bytes() {
+				return newErrorf(ErrCodeMaxSizeExceeded, OperationDiff, archive, nil,
+					"total uncompressed size exceeds limit of %d bytes", opts.MaxSize)
+			}
+			entry.Size = n // Exact even where the header size is unknown (gzip)
+			entry.Checksum = hasher.Sum().String()
+		}
+
+		entries[normalizedPath] = &entry
+		return nil
+	})
+	if err != nil {
+		return nil, totalSize, err
+	}
+	return entries, totalSize, nil
+}
+
+// diffReasons lists what differs between two entries with the same path.
+func diffReasons(oldEntry, newEntry *ArchiveEntry, opts *DiffOptions) []string {
+	if oldEntry.Type != newEntry.Type {
+		return []string{DiffReasonType}
+	}
+
+	var reasons []string
+	if oldEntry.Type == EntryTypeFile {
+		if oldEntry.Size != newEntry.Size {
+			reasons = append(reasons, DiffReasonSize)
+		}
+		if oldEntry.Checksum != newEntry.Checksum {
+			reasons = append(reasons, DiffReasonContent)
+		}
+	}
+	if oldEntry.LinkTarget != newEntry.LinkTarget {
+		reasons = append(reasons, DiffReasonLinkTarget)
+	}
+	if opts.CompareModes && oldEntry.Mode != newEntry.Mode {
+		reasons = append(reasons, DiffReasonMode)
+	}
+	if opts.CompareModTimes && !oldEntry.Modified.Equal(newEntry.Modified) {
+		reasons = append(reasons, DiffReasonModTime)
+	}
+	return reasons
+}
+
+// Identical reports whether the compared archives have no differences.
+func (r *DiffResult) Identical() bool {
+	return len(r.Changes) == 0
+}
+
+// WriteText writes a human-readable report: one line per change prefixed with
+// A (added), D (removed), or M (modified), followed by a summary line.
+//
+// Example output:
+//
+//	--- release-1.0.tar.gz
+//	+++ release-1.1.tar.gz
+//	A  docs/upgrade.md (1204 bytes)
+//	D  legacy/config.ini (88 bytes)
+//	M  bin/tool [size, content] (10240 -> 10496 bytes)
+//	1 added, 1 removed, 1 modified, 42 unchanged
+func (r *DiffResult) WriteText(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", r.ArchiveA, r.ArchiveB)
+	for _, change := range r.Changes {
+		switch change.Change {
+		case DiffAdded:
+			fmt.Fprintf(&b, "A  %s%s\n", change.Path, describeDiffEntry(change.New))
+		case DiffRemoved:
+			fmt.Fprintf(&b, "D  %s%s\n", change.Path, describeDiffEntry(change.Old))
+		case DiffModified:
+			fmt.Fprintf(&b, "M  %s [%s]%s\n", change.Path, strings.Join(change.Reasons, ", "), describeDiffModification(change))
+		}
+	}
+	fmt.Fprintf(&b, "%d added, %d removed, %d modified, %d unchanged\n",
+		r.AddedCount, r.RemovedCount, r.ModifiedCount, r.UnchangedCount)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON writes the result as indented JSON.
+func (r *DiffResult) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// describeDiffEntry summarizes an added or removed entry.
+func describeDiffEntry(entry *ArchiveEntry) string {
+	switch entry.Type {
+	case EntryTypeFile:
+		return fmt.Sprintf(" (%d bytes)", entry.Size)
+	case EntryTypeSymlink:
+		return " -> " + entry.LinkTarget
+	default:
+		return "/"
+	}
+}
+
+// describeDiffModification summarizes the old and new values of a modified entry.
+func describeDiffModification(change DiffEntry) string {
+	var parts []string
+	for _, reason := range change.Reasons {
+		switch reason {
+		case DiffReasonType:
+			parts = append(parts, fmt.Sprintf("%s -> %s", change.Old.Type, change.New.Type))
+		case DiffReasonSize:
+			parts = append(parts, fmt.Sprintf("%d -> %d bytes", change.Old.Size, change.New.Size))
+		case DiffReasonLinkTarget:
+			parts = append(parts, fmt.Sprintf("%s -> %s", change.Old.LinkTarget, change.New.LinkTarget))
+		case DiffReasonMode:
+			parts = append(parts, fmt.Sprintf("%04o -> %04o", change.Old.Mode, change.New.Mode))
+		case DiffReasonModTime:
+			parts = append(parts, fmt.Sprintf("%s -> %s", change.Old.Modified.UTC().Format(time.RFC3339), change.New.Modified.UTC().Format(time.RFC3339)))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, "; ") + ")"
+}
@@ -29,6 +29,15 @@
 //   - Verify():  Validate archive integrity, checksums, and security properties
 //   - Info():    Get archive metadata (format, size, compression ratio)
 //
+// Additional helpers build on these operations:
+//
+//   - ExtractEntry()/ReadEntry(): Retrieve a single entry without full extraction
+//   - Convert(): Repack an archive into another format, entry by entry
+//   - Diff(): Compare two archives entry by entry (added/removed/modified), text or JSON report
+//   - OpenArchiveFS(): Read-only fs.FS view over archive contents, no extraction
+//   - ScanAsPathResults(): Archive entries as pathfinder PathResults (optional checksums)
+//   - Capabilities(): Per-format feature report (symlinks, permissions, mtimes, streaming)
+//
 // # Security by Default
 //
 // All operations include mandatory security protections:
@@ -42,7 +51,8 @@
 //
 // Fulpack integrates with the pathfinder module for unified glob-based file discovery
 // across filesystems and archives. The Scan() operation returns entries compatible with
-// pathfinder's matching engine.
+// pathfinder's matching engine, and ScanAsPathResults() converts them to PathResults
+// for consumers of pathfinder discovery results.
 //
 // # Observability
 //
@@ -117,4 +127,8 @@
 //   - Archive Formats Taxonomy v1.0.0 (schemas/crucible-go/taxonomy/library/fulpack/archive-formats/)
 //   - Operations Taxonomy v1.0.0 (schemas/crucible-go/taxonomy/library/fulpack/operations/)
 //   - Entry Types Taxonomy v1.0.0 (schemas/crucible-go/taxonomy/library/fulpack/entry-types/)
+//
+// ExtractOptions and ExtractResult serialize fields the Crucible fulpack
+// v1.0.0 schemas do not define yet (hardening limits, the Rejected audit
+// log); ExtractOptionsExtSchema and ExtractResultExtSchema describe them.
 package fulpack
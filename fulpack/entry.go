@@ -0,0 +1,207 @@
+package fulpack
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// extractEntryImpl implements the ExtractEntry operation.
+func extractEntryImpl(archive string, entryPath string, w io.Writer, options *ExtractEntryOptions) (int64, error) {
+	start := time.Now()
+	var err error
+	var written int64
+
+	defer func() {
+		duration := time.Since(start)
+		format := detectFormat(archive)
+		var entryCount int
+		if err == nil {
+			entryCount = 1
+		}
+		emitOperationMetrics(OperationExtract, format, duration, entryCount, written, err)
+	}()
+
+	// Apply defaults
+	opts := applyExtractEntryDefaults(options)
+
+	if w == nil {
+		err = newError(ErrCodeInvalidFormat, "writer cannot be nil", OperationExtract, entryPath, nil)
+		return 0, err
+	}
+
+	// Security: Reject traversal in the requested path before touching the archive
+	if entryPath == "" || isPathTraversal(entryPath) {
+		err = newError(ErrCodePathTraversal, "invalid entry path", OperationExtract, entryPath, nil)
+		return 0, err
+	}
+	target := normalizeEntryPath(entryPath)
+
+	// Detect format
+	format := detectFormat(archive)
+	if format == "" {
+		err = newError(ErrCodeInvalidFormat, "could not detect archive format", OperationExtract, archive, nil)
+		return 0, err
+	}
+
+	switch format {
+	case ArchiveFormatTAR:
+		written, err = extractEntryTar(archive, target, w, opts, false)
+	case ArchiveFormatTARGZ:
+		written, err = extractEntryTar(archive, target, w, opts, true)
+	case ArchiveFormatZIP:
+		written, err = extractEntryZip(archive, target, w, opts)
+	case ArchiveFormatGZIP:
+		written, err = extractEntryGzip(archive, target, w, opts)
+	default:
+		err = newError(ErrCodeInvalidFormat, "unsupported archive format", OperationExtract, archive, nil)
+	}
+
+	return written, err
+}
+
+// readEntryImpl implements the ReadEntry operation.
+func readEntryImpl(archive string, entryPath string) ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := extractEntryImpl(archive, entryPath, &buf, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// extractEntryTar sequentially scans a tar (or tar.gz) archive for a single entry.
+func extractEntryTar(archivePath string, target string, w io.Writer, opts *ExtractEntryOptions, compressed bool) (int64, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return 0, newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
+			"failed to open tar archive: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var r io.Reader = f
+	if compressed {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return 0, newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
+				"failed to create gzip reader: %v", err)
+		}
+		defer func() { _ = gr.Close() }()
+		r = gr
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
+				"failed to read tar header: %v", err)
+		}
+
+		if normalizeEntryPath(header.Name) != target {
+			continue
+		}
+		if header.Typeflag != tar.TypeReg {
+			return 0, newError(ErrCodeEntryNotFound, "entry is not a regular file", OperationExtract, target, nil)
+		}
+		if header.Size > opts.MaxSize.Bytes() {
+			return 0, newErrorf(ErrCodeMaxSizeExceeded, OperationExtract, target, nil,
+				"entry size (%d bytes) exceeds limit of %d bytes", header.Size, opts.MaxSize)
+		}
+		return copyEntry(w, tr, target, opts.MaxSize.Bytes())
+	}
+
+	return 0, newError(ErrCodeEntryNotFound, "entry not found in archive", OperationExtract, target, nil)
+}
+
+// extractEntryZip uses the zip central directory for random access to a single entry.
+func extractEntryZip(archivePath string, target string, w io.Writer, opts *ExtractEntryOptions) (int64, error) {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return 0, newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
+			"failed to open zip archive: %v", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	for _, f := range zr.File {
+		if normalizeEntryPath(f.Name) != target {
+			continue
+		}
+		if f.FileInfo().IsDir() {
+			return 0, newError(ErrCodeEntryNotFound, "entry is not a regular file", OperationExtract, target, nil)
+		}
+		if int64(f.UncompressedSize64) > opts.MaxSize.Bytes() {
+			return 0, newErrorf(ErrCodeMaxSizeExceeded, OperationExtract, target, nil,
+				"entry size (%d bytes) exceeds limit of %d bytes", f.UncompressedSize64, opts.MaxSize)
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return 0, newErrorf(ErrCodeCorruptArchive, OperationExtract, target, err,
+				"failed to open zip entry: %v", err)
+		}
+		defer func() { _ = rc.Close() }()
+
+		return copyEntry(w, rc, target, opts.MaxSize.Bytes())
+	}
+
+	return 0, newError(ErrCodeEntryNotFound, "entry not found in archive", OperationExtract, target, nil)
+}
+
+// extractEntryGzip returns the single gzip payload if its name matches the requested entry.
+func extractEntryGzip(archivePath string, target string, w io.Writer, opts *ExtractEntryOptions) (int64, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return 0, newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
+			"failed to open gzip file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return 0, newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
+			"failed to create gzip reader: %v", err)
+	}
+	defer func() { _ = gr.Close() }()
+
+	// Match the same name Scan() reports for the payload
+	name := gr.Name
+	if name == "" {
+		name = filepath.Base(archivePath)
+		if ext := filepath.Ext(name); ext == ".gz" || ext == ".gzip" {
+			name = name[:len(name)-len(ext)]
+		}
+	}
+	if normalizeEntryPath(name) != target {
+		return 0, newError(ErrCodeEntryNotFound, "entry not found in archive", OperationExtract, target, nil)
+	}
+
+	return copyEntry(w, gr, target, opts.MaxSize.Bytes())
+}
+
+// copyEntry copies entry content to w, enforcing maxSize on the bytes actually read.
+func copyEntry(w io.Writer, r io.Reader, target string, maxSize int64) (int64, error) {
+	// Read one byte past the limit so oversized (or lying) headers are detected
+	n, err := io.Copy(w, io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return n, newErrorf(ErrCodeCorruptArchive, OperationExtract, target, err,
+			"failed to read entry: %v", err)
+	}
+	if n > maxSize {
+		return n, newErrorf(ErrCodeMaxSizeExceeded, OperationExtract, target, nil,
+			"entry size exceeds limit of %d bytes", maxSize)
+	}
+	return n, nil
+}
+
+// normalizeEntryPath converts an entry path to the slash-separated form used for matching.
+func normalizeEntryPath(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
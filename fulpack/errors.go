@@ -3,6 +3,7 @@ package fulpack
 import (
 	"fmt"
 
+	"github.com/fulmenhq/gofulmen/errors"
 	"github.com/fulmenhq/gofulmen/foundry"
 )
 
@@ -40,6 +41,33 @@ const (
 
 	// ErrCodeUnsupportedCompression indicates unsupported compression algorithm.
 	ErrCodeUnsupportedCompression = "UNSUPPORTED_COMPRESSION"
+
+	// ErrCodeEntryNotFound indicates a requested entry does not exist in the archive.
+	ErrCodeEntryNotFound = "ENTRY_NOT_FOUND"
+
+	// ErrCodeUnrepresentableEntry indicates an entry cannot be stored in the target format.
+	ErrCodeUnrepresentableEntry = "UNREPRESENTABLE_ENTRY"
+
+	// ErrCodeInvalidChecksumFile indicates a detached checksum file is missing or malformed.
+	ErrCodeInvalidChecksumFile = "INVALID_CHECKSUM_FILE"
+
+	// ErrCodeEntryNameTooLong indicates an entry path exceeds MaxEntryNameLength.
+	ErrCodeEntryNameTooLong = "ENTRY_NAME_TOO_LONG"
+
+	// ErrCodeUnsafeEntryType indicates a device, FIFO, or socket entry.
+	ErrCodeUnsafeEntryType = "UNSAFE_ENTRY_TYPE"
+
+	// ErrCodeUnsupportedChecksumAlgorithm indicates an unknown checksum algorithm was requested.
+	ErrCodeUnsupportedChecksumAlgorithm = "UNSUPPORTED_CHECKSUM_ALGORITHM"
+
+	// ErrCodeLinkNotAllowed indicates a symlink source under LinkPolicyError.
+	ErrCodeLinkNotAllowed = "LINK_NOT_ALLOWED"
+
+	// ErrCodeInsufficientPrivileges indicates an option requires privileges the process lacks.
+	ErrCodeInsufficientPrivileges = "INSUFFICIENT_PRIVILEGES"
+
+	// ErrCodeFileAccess indicates a source file could not be stat'ed or read.
+	ErrCodeFileAccess = "FILE_ACCESS_ERROR"
 )
 
 // Foundry exit code mappings for fulpack errors.
@@ -55,6 +83,46 @@ var exitCodeMap = map[string]foundry.ExitCode{
 	ErrCodeMaxSizeExceeded:        foundry.ExitResourceExhausted,
 	ErrCodeMaxEntriesExceeded:     foundry.ExitResourceExhausted,
 	ErrCodeUnsupportedCompression: foundry.ExitInvalidArgument,
+	ErrCodeEntryNotFound:          foundry.ExitFileNotFound,
+	ErrCodeUnrepresentableEntry:   foundry.ExitInvalidArgument,
+	ErrCodeInvalidChecksumFile:    foundry.ExitInvalidArgument,
+	ErrCodeEntryNameTooLong:       foundry.ExitSecurityViolation,
+	ErrCodeUnsafeEntryType:        foundry.ExitSecurityViolation,
+
+	ErrCodeUnsupportedChecksumAlgorithm: foundry.ExitInvalidArgument,
+	ErrCodeLinkNotAllowed:               foundry.ExitInvalidArgument,
+	ErrCodeInsufficientPrivileges:       foundry.ExitPermissionDenied,
+	ErrCodeFileAccess:                   foundry.ExitFileReadError,
+}
+
+// Retry classifications of fulpack error codes, registered with the errors
+// package so envelopes carrying these codes classify the same way.
+var classificationMap = map[string]errors.Classification{
+	ErrCodeInvalidFormat:          errors.ClassificationPermanent,
+	ErrCodePathTraversal:          errors.ClassificationSecurity,
+	ErrCodeAbsolutePath:           errors.ClassificationSecurity,
+	ErrCodeSymlinkEscape:          errors.ClassificationSecurity,
+	ErrCodeDecompressionBomb:      errors.ClassificationSecurity,
+	ErrCodeChecksumMismatch:       errors.ClassificationPermanent,
+	ErrCodeFileExists:             errors.ClassificationPermanent,
+	ErrCodeCorruptArchive:         errors.ClassificationPermanent,
+	ErrCodeMaxSizeExceeded:        errors.ClassificationPermanent,
+	ErrCodeMaxEntriesExceeded:     errors.ClassificationPermanent,
+	ErrCodeUnsupportedCompression: errors.ClassificationPermanent,
+	ErrCodeEntryNotFound:          errors.ClassificationPermanent,
+	ErrCodeUnrepresentableEntry:   errors.ClassificationPermanent,
+	ErrCodeInvalidChecksumFile:    errors.ClassificationPermanent,
+	ErrCodeEntryNameTooLong:       errors.ClassificationSecurity,
+	ErrCodeUnsafeEntryType:        errors.ClassificationSecurity,
+
+	ErrCodeUnsupportedChecksumAlgorithm: errors.ClassificationPermanent,
+	ErrCodeLinkNotAllowed:               errors.ClassificationPermanent,
+	ErrCodeInsufficientPrivileges:       errors.ClassificationPermanent,
+	ErrCodeFileAccess:                   errors.ClassificationTransient,
+}
+
+func init() {
+	errors.RegisterClassifications(classificationMap)
 }
 
 // FulpackError represents a fulpack operation error with context.
@@ -96,6 +164,12 @@ func (e *FulpackError) ExitCode() foundry.ExitCode {
 	return foundry.ExitFailure
 }
 
+// Classification returns the retry classification of the error code, so
+// errors.IsRetryable works on fulpack errors. Unknown codes are unclassified.
+func (e *FulpackError) Classification() errors.Classification {
+	return classificationMap[e.Code]
+}
+
 // newError creates a new FulpackError.
 func newError(code string, message string, op Operation, path string, cause error) *FulpackError {
 	return &FulpackError{
@@ -0,0 +1,27 @@
+package fulpack
+
+import _ "embed"
+
+// The Crucible fulpack schemas are synced from the Crucible SSOT and reject
+// unknown properties, so the fields gofulmen adds ahead of Crucible are
+// described in schemas owned by this package. A serialized value is valid
+// when its extension fields match the extension schema and the remaining
+// fields match the Crucible schema.
+
+//go:embed extract-options-ext.schema.json
+var extractOptionsExtSchema []byte
+
+//go:embed extract-result-ext.schema.json
+var extractResultExtSchema []byte
+
+// ExtractOptionsExtSchema returns the JSON Schema for the ExtractOptions
+// fields gofulmen adds to the Crucible extract-options schema.
+func ExtractOptionsExtSchema() []byte {
+	return append([]byte(nil), extractOptionsExtSchema...)
+}
+
+// ExtractResultExtSchema returns the JSON Schema for the ExtractResult
+// fields gofulmen adds to the Crucible extract-result schema.
+func ExtractResultExtSchema() []byte {
+	return append([]byte(nil), extractResultExtSchema...)
+}
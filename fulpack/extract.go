@@ -4,6 +4,7 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -48,6 +49,19 @@ func extractImpl(archive string, destination string, options *ExtractOptions) (*
 		return nil, err
 	}
 
+	// Security: Only the superuser may restore arbitrary ownership or
+	// privileged extended attributes
+	if opts.PreserveOwnership && !canChangeOwnership() {
+		err = newError(ErrCodeInsufficientPrivileges, "preserving ownership requires superuser privileges", OperationExtract, destination, nil)
+		return nil, err
+	}
+	if opts.PreserveXattrs && includesPrivilegedXattrs(xattrIncludes(opts)) && !canChangeOwnership() {
+		err = newError(ErrCodeInsufficientPrivileges,
+			"restoring security, trusted, or system extended attributes requires superuser privileges",
+			OperationExtract, destination, nil)
+		return nil, err
+	}
+
 	// Create destination directory if it doesn't exist
 	if mkdirErr := os.MkdirAll(destination, 0755); mkdirErr != nil {
 		err = newErrorf(ErrCodeFileExists, OperationExtract, destination, mkdirErr,
@@ -62,16 +76,18 @@ func extractImpl(archive string, destination string, options *ExtractOptions) (*
 		return nil, err
 	}
 
+	progress := newExtractProgress(archive, opts)
+
 	// Extract based on format
 	switch format {
 	case ArchiveFormatTAR:
-		err = extractTar(archive, destination, opts, result)
+		err = extractTar(archive, destination, opts, result, progress)
 	case ArchiveFormatTARGZ:
-		err = extractTarGz(archive, destination, opts, result)
+		err = extractTarGz(archive, destination, opts, result, progress)
 	case ArchiveFormatZIP:
-		err = extractZip(archive, destination, opts, result)
+		err = extractZip(archive, destination, opts, result, progress)
 	case ArchiveFormatGZIP:
-		err = extractGzip(archive, destination, opts, result)
+		err = extractGzip(archive, destination, opts, result, progress)
 	default:
 		err = newError(ErrCodeInvalidFormat, "unsupported archive format", OperationExtract, archive, nil)
 		return nil, err
@@ -80,12 +96,26 @@ func extractImpl(archive string, destination string, options *ExtractOptions) (*
 	if err != nil {
 		return result, err
 	}
+	progress.finish()
 
 	return result, nil
 }
 
+// newExtractProgress returns a tracker measuring archive bytes consumed, or nil
+// when no Progress callback is set.
+func newExtractProgress(archive string, opts *ExtractOptions) *progressTracker {
+	if opts.Progress == nil {
+		return nil
+	}
+	var size int64
+	if fileInfo, err := os.Stat(archive); err == nil {
+		size = fileInfo.Size()
+	}
+	return newProgressTracker(opts.Progress, OperationExtract, size)
+}
+
 // extractTar extracts an uncompressed tar archive.
-func extractTar(archivePath string, destination string, opts *ExtractOptions, result *ExtractResult) error {
+func extractTar(archivePath string, destination string, opts *ExtractOptions, result *ExtractResult, progress *progressTracker) error {
 	f, err := os.Open(archivePath)
 	if err != nil {
 		return newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
@@ -93,12 +123,12 @@ func extractTar(archivePath string, destination string, opts *ExtractOptions, re
 	}
 	defer func() { _ = f.Close() }()
 
-	tr := tar.NewReader(f)
-	return extractTarReader(tr, destination, opts, result, archivePath)
+	tr := tar.NewReader(progress.reader(f))
+	return extractTarReader(tr, destination, opts, result, archivePath, progress)
 }
 
 // extractTarGz extracts a tar.gz archive.
-func extractTarGz(archivePath string, destination string, opts *ExtractOptions, result *ExtractResult) error {
+func extractTarGz(archivePath string, destination string, opts *ExtractOptions, result *ExtractResult, progress *progressTracker) error {
 	f, err := os.Open(archivePath)
 	if err != nil {
 		return newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
@@ -106,7 +136,7 @@ func extractTarGz(archivePath string, destination string, opts *ExtractOptions,
 	}
 	defer func() { _ = f.Close() }()
 
-	gr, err := gzip.NewReader(f)
+	gr, err := gzip.NewReader(progress.reader(f))
 	if err != nil {
 		return newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
 			"failed to create gzip reader: %v", err)
@@ -114,13 +144,15 @@ func extractTarGz(archivePath string, destination string, opts *ExtractOptions,
 	defer func() { _ = gr.Close() }()
 
 	tr := tar.NewReader(gr)
-	return extractTarReader(tr, destination, opts, result, archivePath)
+	return extractTarReader(tr, destination, opts, result, archivePath, progress)
 }
 
 // extractTarReader extracts entries from a tar reader.
-func extractTarReader(tr *tar.Reader, destination string, opts *ExtractOptions, result *ExtractResult, archivePath string) error {
+func extractTarReader(tr *tar.Reader, destination string, opts *ExtractOptions, result *ExtractResult, archivePath string, progress *progressTracker) error {
 	var totalUncompressedSize int64
 	var entryCount int
+	metadata := newMetadataRestore(opts)
+	defer metadata.finish()
 
 	// Get compressed size for decompression bomb detection
 	var compressedSize int64
@@ -134,11 +166,17 @@ func extractTarReader(tr *tar.Reader, destination string, opts *ExtractOptions,
 			break
 		}
 		if err != nil {
+			if opts.Salvage {
+				// Keep what was recovered and stop at the damage
+				recordSalvageStop(result, archivePath, err)
+				break
+			}
 			return newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
 				"failed to read tar header: %v", err)
 		}
 
 		entryCount++
+		progress.setEntry(header.Name)
 
 		// Security: Check max entries limit
 		if entryCount > opts.MaxEntries {
@@ -148,12 +186,13 @@ func extractTarReader(tr *tar.Reader, destination string, opts *ExtractOptions,
 
 		// Security: Check for path traversal
 		if isPathTraversal(header.Name) {
-			result.ErrorCount++
-			result.Errors = append(result.Errors, ExtractionError{
-				Path:  header.Name,
-				Error: "path traversal detected",
-				Code:  ErrCodePathTraversal,
-			})
+			rejectEntry(result, header.Name, "path traversal detected", ErrCodePathTraversal)
+			continue
+		}
+
+		// Security: Check entry name length
+		if msg, ok := checkEntryName(header.Name, opts); !ok {
+			rejectEntry(result, header.Name, msg, ErrCodeEntryNameTooLong)
 			continue
 		}
 
@@ -171,12 +210,7 @@ func extractTarReader(tr *tar.Reader, destination string, opts *ExtractOptions,
 
 		// Security: Verify target is within destination bounds
 		if !isWithinBounds(targetPath, destination) {
-			result.ErrorCount++
-			result.Errors = append(result.Errors, ExtractionError{
-				Path:  header.Name,
-				Error: "path escapes destination bounds",
-				Code:  ErrCodePathTraversal,
-			})
+			rejectEntry(result, header.Name, "path escapes destination bounds", ErrCodePathTraversal)
 			continue
 		}
 
@@ -191,12 +225,20 @@ func extractTarReader(tr *tar.Reader, destination string, opts *ExtractOptions,
 				})
 				continue
 			}
+			restoreEntryMetadata(metadata, targetPath, header, result)
 			result.ExtractedCount++
+			progress.entryDone()
 
 		case tar.TypeReg:
+			// Security: Check per-entry size limit
+			if msg, ok := checkEntrySize(header.Size, opts); !ok {
+				rejectEntry(result, header.Name, msg, ErrCodeMaxSizeExceeded)
+				continue
+			}
+
 			// Security: Check max size limit
 			totalUncompressedSize += header.Size
-			if totalUncompressedSize > opts.MaxSize {
+			if totalUncompressedSize > opts.MaxSize.Bytes() {
 				return newErrorf(ErrCodeMaxSizeExceeded, OperationExtract, archivePath, nil,
 					"total uncompressed size exceeds limit of %d bytes", opts.MaxSize)
 			}
@@ -214,6 +256,12 @@ func extractTarReader(tr *tar.Reader, destination string, opts *ExtractOptions,
 					result.SkippedCount++
 					continue
 				}
+				if opts.Salvage && isTruncationError(extractErr) {
+					// Drop the incomplete file; everything before it is intact
+					_ = os.Remove(targetPath)
+					recordSalvageStop(result, header.Name, extractErr)
+					return nil
+				}
 				result.ErrorCount++
 				result.Errors = append(result.Errors, ExtractionError{
 					Path:  header.Name,
@@ -221,21 +269,41 @@ func extractTarReader(tr *tar.Reader, destination string, opts *ExtractOptions,
 				})
 				continue
 			}
+			restoreEntryMetadata(metadata, targetPath, header, result)
 			result.ExtractedCount++
 			result.BytesWritten += bytesWritten
+			progress.entryDone()
 
-		case tar.TypeSymlink, tar.TypeLink:
-			// Security: Validate symlink target
-			linkTarget := filepath.Join(filepath.Dir(targetPath), header.Linkname)
-			if !isWithinBounds(linkTarget, destination) {
+		case tar.TypeLink:
+			// Security: Hardlink targets are archive paths and must resolve within destination
+			linkTarget := filepath.Join(destination, header.Linkname)
+			if isPathTraversal(header.Linkname) || !isWithinBounds(linkTarget, destination) {
+				rejectEntry(result, header.Name, "hardlink target escapes destination bounds", ErrCodeSymlinkEscape)
+				continue
+			}
+
+			if extractErr := extractHardlink(targetPath, linkTarget, opts); extractErr != nil {
 				result.ErrorCount++
 				result.Errors = append(result.Errors, ExtractionError{
 					Path:  header.Name,
-					Error: "symlink target escapes destination bounds",
-					Code:  ErrCodeSymlinkEscape,
+					Error: extractErr.Error(),
 				})
 				continue
 			}
+			result.ExtractedCount++
+			progress.entryDone()
+
+		case tar.TypeSymlink:
+			// Security: Validate symlink target
+			if opts.RejectAbsoluteSymlinks && isAbsoluteLinkTarget(header.Linkname) {
+				rejectEntry(result, header.Name, "absolute symlink target not allowed", ErrCodeSymlinkEscape)
+				continue
+			}
+			linkTarget := filepath.Join(filepath.Dir(targetPath), header.Linkname)
+			if !isWithinBounds(linkTarget, destination) {
+				rejectEntry(result, header.Name, "symlink target escapes destination bounds", ErrCodeSymlinkEscape)
+				continue
+			}
 
 			if extractErr := extractSymlink(targetPath, header.Linkname, opts); extractErr != nil {
 				result.ErrorCount++
@@ -245,9 +313,15 @@ func extractTarReader(tr *tar.Reader, destination string, opts *ExtractOptions,
 				})
 				continue
 			}
+			restoreEntryMetadata(metadata, targetPath, header, result)
 			result.ExtractedCount++
+			progress.entryDone()
 
 		default:
+			if opts.RejectSpecialEntries && isSpecialTarType(header.Typeflag) {
+				rejectEntry(result, header.Name, "device and FIFO entries not allowed", ErrCodeUnsafeEntryType)
+				continue
+			}
 			// Skip unsupported types
 			result.SkippedCount++
 		}
@@ -256,8 +330,20 @@ func extractTarReader(tr *tar.Reader, destination string, opts *ExtractOptions,
 	return nil
 }
 
+// restoreEntryMetadata applies recorded metadata to an extracted entry. A
+// failure is recorded against the entry; its extracted content is kept.
+func restoreEntryMetadata(metadata *metadataRestore, targetPath string, header *tar.Header, result *ExtractResult) {
+	if err := metadata.apply(targetPath, header); err != nil {
+		result.ErrorCount++
+		result.Errors = append(result.Errors, ExtractionError{
+			Path:  header.Name,
+			Error: err.Error(),
+		})
+	}
+}
+
 // extractZip extracts a zip archive.
-func extractZip(archivePath string, destination string, opts *ExtractOptions, result *ExtractResult) error {
+func extractZip(archivePath string, destination string, opts *ExtractOptions, result *ExtractResult, progress *progressTracker) error {
 	zr, err := zip.OpenReader(archivePath)
 	if err != nil {
 		return newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
@@ -273,7 +359,16 @@ func extractZip(archivePath string, destination string, opts *ExtractOptions, re
 		compressedSize = fileInfo.Size()
 	}
 
+	// Zip declares entry sizes up front, so progress tracks uncompressed bytes
+	if progress != nil {
+		progress.total = 0
+		for _, f := range zr.File {
+			progress.total += int64(f.UncompressedSize64)
+		}
+	}
+
 	for i, f := range zr.File {
+		progress.setEntry(f.Name)
 		// Security: Check max entries limit
 		if i+1 > opts.MaxEntries {
 			return newErrorf(ErrCodeMaxEntriesExceeded, OperationExtract, archivePath, nil,
@@ -282,12 +377,13 @@ func extractZip(archivePath string, destination string, opts *ExtractOptions, re
 
 		// Security: Check for path traversal
 		if isPathTraversal(f.Name) {
-			result.ErrorCount++
-			result.Errors = append(result.Errors, ExtractionError{
-				Path:  f.Name,
-				Error: "path traversal detected",
-				Code:  ErrCodePathTraversal,
-			})
+			rejectEntry(result, f.Name, "path traversal detected", ErrCodePathTraversal)
+			continue
+		}
+
+		// Security: Check entry name length
+		if msg, ok := checkEntryName(f.Name, opts); !ok {
+			rejectEntry(result, f.Name, msg, ErrCodeEntryNameTooLong)
 			continue
 		}
 
@@ -305,12 +401,13 @@ func extractZip(archivePath string, destination string, opts *ExtractOptions, re
 
 		// Security: Verify target is within destination bounds
 		if !isWithinBounds(targetPath, destination) {
-			result.ErrorCount++
-			result.Errors = append(result.Errors, ExtractionError{
-				Path:  f.Name,
-				Error: "path escapes destination bounds",
-				Code:  ErrCodePathTraversal,
-			})
+			rejectEntry(result, f.Name, "path escapes destination bounds", ErrCodePathTraversal)
+			continue
+		}
+
+		// Security: Reject special files
+		if opts.RejectSpecialEntries && isSpecialFileMode(f.Mode()) {
+			rejectEntry(result, f.Name, "device and FIFO entries not allowed", ErrCodeUnsafeEntryType)
 			continue
 		}
 
@@ -325,10 +422,17 @@ func extractZip(archivePath string, destination string, opts *ExtractOptions, re
 				continue
 			}
 			result.ExtractedCount++
+			progress.entryDone()
 		} else {
+			// Security: Check per-entry size limit
+			if msg, ok := checkEntrySize(int64(f.UncompressedSize64), opts); !ok {
+				rejectEntry(result, f.Name, msg, ErrCodeMaxSizeExceeded)
+				continue
+			}
+
 			// Security: Check max size limit
 			totalUncompressedSize += int64(f.UncompressedSize64)
-			if totalUncompressedSize > opts.MaxSize {
+			if totalUncompressedSize > opts.MaxSize.Bytes() {
 				return newErrorf(ErrCodeMaxSizeExceeded, OperationExtract, archivePath, nil,
 					"total uncompressed size exceeds limit of %d bytes", opts.MaxSize)
 			}
@@ -350,7 +454,7 @@ func extractZip(archivePath string, destination string, opts *ExtractOptions, re
 				continue
 			}
 
-			bytesWritten, extractErr := extractFile(rc, targetPath, int64(f.Mode()), int64(f.UncompressedSize64), opts)
+			bytesWritten, extractErr := extractFile(progress.reader(rc), targetPath, int64(f.Mode()), int64(f.UncompressedSize64), opts)
 			_ = rc.Close()
 
 			if extractErr != nil {
@@ -367,6 +471,7 @@ func extractZip(archivePath string, destination string, opts *ExtractOptions, re
 			}
 			result.ExtractedCount++
 			result.BytesWritten += bytesWritten
+			progress.entryDone()
 		}
 	}
 
@@ -374,7 +479,7 @@ func extractZip(archivePath string, destination string, opts *ExtractOptions, re
 }
 
 // extractGzip extracts a gzip file (single file).
-func extractGzip(archivePath string, destination string, opts *ExtractOptions, result *ExtractResult) error {
+func extractGzip(archivePath string, destination string, opts *ExtractOptions, result *ExtractResult, progress *progressTracker) error {
 	f, err := os.Open(archivePath)
 	if err != nil {
 		return newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
@@ -382,7 +487,7 @@ func extractGzip(archivePath string, destination string, opts *ExtractOptions, r
 	}
 	defer func() { _ = f.Close() }()
 
-	gr, err := gzip.NewReader(f)
+	gr, err := gzip.NewReader(progress.reader(f))
 	if err != nil {
 		return newErrorf(ErrCodeCorruptArchive, OperationExtract, archivePath, err,
 			"failed to create gzip reader: %v", err)
@@ -400,6 +505,7 @@ func extractGzip(archivePath string, destination string, opts *ExtractOptions, r
 	}
 
 	targetPath := filepath.Join(destination, name)
+	progress.setEntry(name)
 
 	// Security: Verify target is within destination bounds
 	if !isWithinBounds(targetPath, destination) {
@@ -407,8 +513,34 @@ func extractGzip(archivePath string, destination string, opts *ExtractOptions, r
 			"extracted file would escape destination bounds")
 	}
 
+	// Security: Check entry name length
+	if msg, ok := checkEntryName(name, opts); !ok {
+		rejectEntry(result, name, msg, ErrCodeEntryNameTooLong)
+		return nil
+	}
+
+	// The payload size is unknown up front; read one byte past the per-entry
+	// limit so an oversized payload can be detected
+	var payload io.Reader = gr
+	if opts.MaxEntrySize > 0 {
+		payload = io.LimitReader(gr, opts.MaxEntrySize.Bytes()+1)
+	}
+
 	// Extract the single file
-	bytesWritten, extractErr := extractFile(gr, targetPath, 0644, -1, opts)
+	bytesWritten, extractErr := extractFile(payload, targetPath, 0644, -1, opts)
+	if extractErr == nil && opts.MaxEntrySize > 0 && bytesWritten > opts.MaxEntrySize.Bytes() {
+		_ = os.Remove(targetPath)
+		msg, _ := checkEntrySize(bytesWritten, opts)
+		rejectEntry(result, name, msg, ErrCodeMaxSizeExceeded)
+		return nil
+	}
+	if extractErr != nil && opts.Salvage && isTruncationError(extractErr) {
+		// Keep the partial payload; it is the only entry
+		recordSalvageStop(result, name, extractErr)
+		result.ExtractedCount++
+		result.BytesWritten += bytesWritten
+		return nil
+	}
 	if extractErr != nil {
 		result.ErrorCount++
 		result.Errors = append(result.Errors, ExtractionError{
@@ -420,9 +552,10 @@ func extractGzip(archivePath string, destination string, opts *ExtractOptions, r
 
 	result.ExtractedCount++
 	result.BytesWritten += bytesWritten
+	progress.entryDone()
 
 	// Check max size limit after extraction
-	if result.BytesWritten > opts.MaxSize {
+	if result.BytesWritten > opts.MaxSize.Bytes() {
 		// Clean up extracted file
 		_ = os.Remove(targetPath)
 		return newErrorf(ErrCodeMaxSizeExceeded, OperationExtract, archivePath, nil,
@@ -494,7 +627,7 @@ func extractFile(reader io.Reader, targetPath string, mode int64, expectedSize i
 	// Copy data
 	bytesWritten, err := io.Copy(outFile, reader)
 	if err != nil {
-		return bytesWritten, fmt.Errorf("failed to write file: %v", err)
+		return bytesWritten, fmt.Errorf("failed to write file: %w", err)
 	}
 
 	// Verify expected size if provided
@@ -505,6 +638,22 @@ func extractFile(reader io.Reader, targetPath string, mode int64, expectedSize i
 	return bytesWritten, nil
 }
 
+// isTruncationError reports whether err indicates the archive stream ended early or is corrupt.
+func isTruncationError(err error) bool {
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, gzip.ErrChecksum) ||
+		errors.Is(err, gzip.ErrHeader) || errors.Is(err, tar.ErrHeader)
+}
+
+// recordSalvageStop records the point where salvage extraction stopped.
+func recordSalvageStop(result *ExtractResult, path string, cause error) {
+	result.ErrorCount++
+	result.Errors = append(result.Errors, ExtractionError{
+		Path:  path,
+		Error: fmt.Sprintf("archive damaged, salvage stopped: %v", cause),
+		Code:  ErrCodeCorruptArchive,
+	})
+}
+
 // extractSymlink creates a symbolic link.
 func extractSymlink(targetPath string, linkTarget string, opts *ExtractOptions) error {
 	// Ensure parent directory exists
@@ -536,6 +685,44 @@ func extractSymlink(targetPath string, linkTarget string, opts *ExtractOptions)
 	return nil
 }
 
+// extractHardlink links targetPath to the previously extracted linkTarget.
+func extractHardlink(targetPath string, linkTarget string, opts *ExtractOptions) error {
+	// Ensure parent directory exists
+	parentDir := filepath.Dir(targetPath)
+	if err := os.MkdirAll(parentDir, 0755); err != nil {
+		return fmt.Errorf("failed to create parent directory: %v", err)
+	}
+
+	// Check overwrite policy
+	if _, err := os.Lstat(targetPath); err == nil {
+		switch opts.Overwrite {
+		case OverwritePolicyError:
+			return fmt.Errorf("file already exists: %s", targetPath)
+		case OverwritePolicySkip:
+			return nil
+		case OverwritePolicyOverwrite:
+			if removeErr := os.Remove(targetPath); removeErr != nil {
+				return fmt.Errorf("failed to remove existing file: %v", removeErr)
+			}
+		}
+	}
+
+	// Security: Only link to regular files extracted from this archive
+	targetInfo, err := os.Lstat(linkTarget)
+	if err != nil {
+		return fmt.Errorf("hardlink target not extracted: %v", err)
+	}
+	if !targetInfo.Mode().IsRegular() {
+		return fmt.Errorf("hardlink target is not a regular file: %s", linkTarget)
+	}
+
+	if err := os.Link(linkTarget, targetPath); err != nil {
+		return fmt.Errorf("failed to create hardlink: %v", err)
+	}
+
+	return nil
+}
+
 // shouldExtract checks if an entry should be extracted based on include/exclude patterns.
 func shouldExtract(normalizedPath string, includePatterns []string, excludePatterns []string) bool {
 	// Check exclude patterns first - if matches any, exclude
@@ -1,11 +1,26 @@
 package fulpack_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
+	"github.com/fulmenhq/gofulmen/ascii"
+	gferrors "github.com/fulmenhq/gofulmen/errors"
+	"github.com/fulmenhq/gofulmen/fulhash"
 	"github.com/fulmenhq/gofulmen/fulpack"
+	"github.com/fulmenhq/gofulmen/pathfinder"
+	"github.com/fulmenhq/gofulmen/schema"
 )
 
 // Fixture paths relative to gofulmen root
@@ -247,6 +262,76 @@ func TestScan_WithGlobPatterns(t *testing.T) {
 // Create Operation Tests
 // ========================================
 
+func TestScanAsPathResults(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar.gz")
+
+	results, err := fulpack.ScanAsPathResults(archive, nil)
+	if err != nil {
+		t.Fatalf("ScanAsPathResults() failed: %v", err)
+	}
+	if len(results) == 0 {
+		t.Fatal("Expected non-zero results")
+	}
+
+	absArchive, _ := filepath.Abs(archive)
+	for _, result := range results {
+		if err := pathfinder.ValidatePathResult(result); err != nil {
+			t.Errorf("Result %s is not schema-valid: %v", result.RelativePath, err)
+		}
+		if result.LoaderType != pathfinder.LoaderTypeArchive {
+			t.Errorf("Expected loader type %q for %s, got %q", pathfinder.LoaderTypeArchive, result.RelativePath, result.LoaderType)
+		}
+		if result.SourcePath != absArchive || result.LogicalPath != result.RelativePath {
+			t.Errorf("Unexpected paths for %s: source %q, logical %q", result.RelativePath, result.SourcePath, result.LogicalPath)
+		}
+		if result.Metadata["entryType"] != "file" || result.Metadata["archiveFormat"] != "tar.gz" {
+			t.Errorf("Unexpected metadata for %s: %v", result.RelativePath, result.Metadata)
+		}
+		if _, ok := result.Metadata["checksum"]; ok {
+			t.Errorf("Expected no checksum without CalculateChecksums for %s", result.RelativePath)
+		}
+	}
+}
+
+func TestScanAsPathResults_Checksums(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar.gz")
+
+	results, err := fulpack.ScanAsPathResults(archive, &fulpack.PathResultOptions{
+		Scan:               &fulpack.ScanOptions{IncludePatterns: []string{"**/file3.txt"}},
+		CalculateChecksums: true,
+		ChecksumAlgorithm:  "sha256",
+	})
+	if err != nil {
+		t.Fatalf("ScanAsPathResults() failed: %v", err)
+	}
+	if len(results) != 1 || results[0].RelativePath != "subdir/file3.txt" {
+		t.Fatalf("Expected only subdir/file3.txt, got %v", results)
+	}
+
+	data, err := fulpack.ReadEntry(archive, "subdir/file3.txt")
+	if err != nil {
+		t.Fatalf("ReadEntry() failed: %v", err)
+	}
+	want, _ := fulhash.Hash(data, fulhash.WithAlgorithm(fulhash.SHA256))
+	if got := results[0].Metadata["checksum"]; got != want.String() {
+		t.Errorf("Expected checksum %s, got %v", want.String(), got)
+	}
+	if results[0].Metadata["checksumAlgorithm"] != "sha256" {
+		t.Errorf("Expected sha256 algorithm, got %v", results[0].Metadata["checksumAlgorithm"])
+	}
+
+	_, err = fulpack.ScanAsPathResults(archive, &fulpack.PathResultOptions{CalculateChecksums: true, ChecksumAlgorithm: "md5"})
+	var fpErr *fulpack.FulpackError
+	if !errors.As(err, &fpErr) || fpErr.Code != fulpack.ErrCodeUnsupportedChecksumAlgorithm {
+		t.Errorf("Expected %s error, got %v", fulpack.ErrCodeUnsupportedChecksumAlgorithm, err)
+	}
+
+	_, err = fulpack.ScanAsPathResults(archive, &fulpack.PathResultOptions{CalculateChecksums: true, MaxSize: 4})
+	if !errors.As(err, &fpErr) || fpErr.Code != fulpack.ErrCodeMaxSizeExceeded {
+		t.Errorf("Expected %s error, got %v", fulpack.ErrCodeMaxSizeExceeded, err)
+	}
+}
+
 func TestCreate_BasicTar(t *testing.T) {
 	// Create temp directory for test output
 	tmpDir := t.TempDir()
@@ -381,6 +466,37 @@ func TestCreate_BasicZip(t *testing.T) {
 	t.Logf("Created ZIP archive: %d entries, %d bytes", info.EntryCount, info.TotalSize)
 }
 
+func TestCreate_GzipHeader(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "app.log")
+	if err := os.WriteFile(inputPath, []byte("line one\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	modTime := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(inputPath, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set mtime: %v", err)
+	}
+
+	gzPath := filepath.Join(tmpDir, "out.gz")
+	if _, err := fulpack.Create([]string{inputPath}, gzPath, fulpack.ArchiveFormatGZIP, nil); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	entries, err := fulpack.Scan(gzPath, nil)
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].Path != "app.log" {
+		t.Errorf("Expected header name app.log, got %q", entries[0].Path)
+	}
+	if !entries[0].Modified.Equal(modTime) {
+		t.Errorf("Expected header mtime %v, got %v", modTime, entries[0].Modified)
+	}
+}
+
 func TestCreate_WithPatterns(t *testing.T) {
 	tmpDir := t.TempDir()
 	outputPath := filepath.Join(tmpDir, "filtered.tar")
@@ -421,6 +537,416 @@ func TestCreate_WithPatterns(t *testing.T) {
 	t.Logf("Created filtered archive: %d entries (only .txt files)", info.EntryCount)
 }
 
+func TestCreate_LinkPolicy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks require privileges on Windows")
+	}
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("target.txt", []byte("target"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.Symlink("target.txt", "link.txt"); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+	sources := []string{"target.txt", "link.txt"}
+
+	entryTypes := func(archive string) map[string]fulpack.EntryType {
+		entries, err := fulpack.Scan(archive, nil)
+		if err != nil {
+			t.Fatalf("Scan() failed: %v", err)
+		}
+		types := make(map[string]fulpack.EntryType)
+		for _, e := range entries {
+			types[e.Path] = e.Type
+		}
+		return types
+	}
+
+	tests := []struct {
+		name     string
+		format   fulpack.ArchiveFormat
+		policy   fulpack.LinkPolicy
+		wantLink fulpack.EntryType // "" when link.txt is not archived
+		warning  string
+	}{
+		{"preserve tar", fulpack.ArchiveFormatTAR, "", fulpack.EntryTypeSymlink, ""},
+		{"follow tar", fulpack.ArchiveFormatTAR, fulpack.LinkPolicyFollow, fulpack.EntryTypeFile, ""},
+		{"skip tar", fulpack.ArchiveFormatTAR, fulpack.LinkPolicySkip, "", ""},
+		{"preserve zip", fulpack.ArchiveFormatZIP, fulpack.LinkPolicyPreserve, "", "symlink skipped"},
+		{"follow zip", fulpack.ArchiveFormatZIP, fulpack.LinkPolicyFollow, fulpack.EntryTypeFile, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := strings.ReplaceAll(tt.name, " ", ".")
+			info, err := fulpack.Create(sources, archive, tt.format, &fulpack.CreateOptions{LinkPolicy: tt.policy})
+			if err != nil {
+				t.Fatalf("Create() failed: %v", err)
+			}
+			if got := entryTypes(archive)["link.txt"]; got != tt.wantLink {
+				t.Errorf("Expected link.txt type %q, got %q", tt.wantLink, got)
+			}
+			warnings := strings.Join(info.Warnings, "\n")
+			if (tt.warning == "") != (warnings == "") || !strings.Contains(warnings, tt.warning) {
+				t.Errorf("Expected warning %q, got %q", tt.warning, warnings)
+			}
+		})
+	}
+
+	_, err := fulpack.Create(sources, "error.tar", fulpack.ArchiveFormatTAR, &fulpack.CreateOptions{LinkPolicy: fulpack.LinkPolicyError})
+	var fpErr *fulpack.FulpackError
+	if !errors.As(err, &fpErr) || fpErr.Code != fulpack.ErrCodeLinkNotAllowed || fpErr.Path != "link.txt" {
+		t.Errorf("Expected %s error for link.txt, got %v", fulpack.ErrCodeLinkNotAllowed, err)
+	}
+
+	_, err = fulpack.Create(sources, "invalid.tar", fulpack.ArchiveFormatTAR, &fulpack.CreateOptions{LinkPolicy: "copy"})
+	if !errors.As(err, &fpErr) || fpErr.Code != fulpack.ErrCodeInvalidFormat {
+		t.Errorf("Expected %s error for unknown policy, got %v", fulpack.ErrCodeInvalidFormat, err)
+	}
+}
+
+func TestCreate_DetectHardlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hardlink detection is unavailable on Windows")
+	}
+	t.Chdir(t.TempDir())
+	content := []byte("shared content")
+	if err := os.WriteFile("a.txt", content, 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := os.Link("a.txt", "b.txt"); err != nil {
+		t.Fatalf("Failed to create hardlink: %v", err)
+	}
+	sources := []string{"a.txt", "b.txt"}
+	opts := &fulpack.CreateOptions{DetectHardlinks: true}
+
+	info, err := fulpack.Create(sources, "links.tar.gz", fulpack.ArchiveFormatTARGZ, opts)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if info.EntryCount != 2 || info.TotalSize != int64(len(content)) {
+		t.Errorf("Expected 2 entries storing content once, got %d entries, %d bytes", info.EntryCount, info.TotalSize)
+	}
+
+	result, err := fulpack.Extract("links.tar.gz", "out", nil)
+	if err != nil || result.ErrorCount > 0 {
+		t.Fatalf("Extract() failed: %v %+v", err, result)
+	}
+	a, errA := os.Stat(filepath.Join("out", "a.txt"))
+	b, errB := os.Stat(filepath.Join("out", "b.txt"))
+	if errA != nil || errB != nil || !os.SameFile(a, b) {
+		t.Errorf("Expected b.txt extracted as a hardlink of a.txt (%v, %v)", errA, errB)
+	}
+
+	info, err = fulpack.Create(sources, "links.zip", fulpack.ArchiveFormatZIP, opts)
+	if err != nil {
+		t.Fatalf("Create() zip failed: %v", err)
+	}
+	if info.TotalSize != 2*int64(len(content)) || len(info.Warnings) != 1 {
+		t.Errorf("Expected zip to store both copies with one warning, got %d bytes, warnings %v", info.TotalSize, info.Warnings)
+	}
+
+	// Without detection, tar stores both copies
+	info, err = fulpack.Create(sources, "copies.tar", fulpack.ArchiveFormatTAR, nil)
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if info.TotalSize != 2*int64(len(content)) {
+		t.Errorf("Expected both copies without DetectHardlinks, got %d bytes", info.TotalSize)
+	}
+}
+
+func TestCreateAndExtract_PreserveMetadata(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("ownership is unavailable on Windows")
+	}
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll("data", 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join("data", "a.txt"), []byte("backup"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	mtime := time.Date(2024, 3, 1, 12, 30, 45, 123456789, time.UTC)
+	if err := os.Chtimes(filepath.Join("data", "a.txt"), mtime, mtime); err != nil {
+		t.Fatalf("Failed to set mtime: %v", err)
+	}
+	sources := []string{filepath.Join("data", "a.txt")}
+
+	opts := &fulpack.CreateOptions{PreserveOwnership: true, PreciseModTimes: true}
+	if _, err := fulpack.Create(sources, "backup.tar", fulpack.ArchiveFormatTAR, opts); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	f, err := os.Open("backup.tar")
+	if err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read archive: %v", err)
+		}
+		if hdr.Uid != os.Getuid() || hdr.Gid != os.Getgid() {
+			t.Errorf("%s: expected owner %d:%d, got %d:%d", hdr.Name, os.Getuid(), os.Getgid(), hdr.Uid, hdr.Gid)
+		}
+		if !hdr.ModTime.Equal(mtime) {
+			t.Errorf("%s: expected precise mtime %v, got %v", hdr.Name, mtime, hdr.ModTime)
+		}
+	}
+
+	extractOpts := &fulpack.ExtractOptions{PreserveModTimes: true}
+	result, err := fulpack.Extract("backup.tar", "out", extractOpts)
+	if err != nil || result.ErrorCount > 0 {
+		t.Fatalf("Extract() failed: %v %+v", err, result)
+	}
+	restored, err := os.Stat(filepath.Join("out", "data", "a.txt"))
+	if err != nil {
+		t.Fatalf("Failed to stat extracted file: %v", err)
+	}
+	if !restored.ModTime().Equal(mtime) {
+		t.Errorf("Expected restored mtime %v, got %v", mtime, restored.ModTime())
+	}
+
+	// Restoring ownership needs superuser privileges
+	extractOpts = &fulpack.ExtractOptions{PreserveOwnership: true, Overwrite: fulpack.OverwritePolicyOverwrite}
+	result, err = fulpack.Extract("backup.tar", "out", extractOpts)
+	if os.Geteuid() == 0 {
+		if err != nil || result.ErrorCount > 0 {
+			t.Fatalf("Extract() with ownership failed: %v %+v", err, result)
+		}
+	} else {
+		var fpErr *fulpack.FulpackError
+		if !errors.As(err, &fpErr) || fpErr.Code != fulpack.ErrCodeInsufficientPrivileges {
+			t.Errorf("Expected INSUFFICIENT_PRIVILEGES without superuser, got %v", err)
+		}
+	}
+
+	// zip cannot store the requested metadata
+	info, err := fulpack.Create(sources, "backup.zip", fulpack.ArchiveFormatZIP, opts)
+	if err != nil {
+		t.Fatalf("Create() zip failed: %v", err)
+	}
+	if len(info.Warnings) != 1 {
+		t.Errorf("Expected one metadata warning for zip, got %v", info.Warnings)
+	}
+}
+
+func TestExtract_XattrIncludes(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "xattrs.tar")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	tw := tar.NewWriter(f)
+	hdr := &tar.Header{
+		Name: "a.txt", Mode: 0644, Size: 2, Typeflag: tar.TypeReg, Format: tar.FormatPAX,
+		PAXRecords: map[string]string{"SCHILY.xattr.bogus.attr": "x"},
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		t.Fatalf("WriteHeader() failed: %v", err)
+	}
+	if _, err := tw.Write([]byte("hi")); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	_ = f.Close()
+
+	// Only user.* attributes are restored by default
+	result, err := fulpack.Extract(archive, t.TempDir(), &fulpack.ExtractOptions{PreserveXattrs: true})
+	if err != nil || result.ErrorCount > 0 {
+		t.Fatalf("Extract() failed: %v %+v", err, result)
+	}
+
+	// An included attribute is written; the bogus namespace is refused by the OS
+	result, err = fulpack.Extract(archive, t.TempDir(), &fulpack.ExtractOptions{
+		PreserveXattrs: true,
+		XattrIncludes:  []string{"bogus.*"},
+	})
+	if err != nil || result.ErrorCount != 1 {
+		t.Errorf("Expected the included attribute to be attempted, got %v %+v", err, result)
+	}
+
+	// Privileged namespaces need superuser privileges
+	_, err = fulpack.Extract(archive, t.TempDir(), &fulpack.ExtractOptions{
+		PreserveXattrs: true,
+		XattrIncludes:  []string{"*"},
+	})
+	if os.Geteuid() == 0 {
+		if err != nil {
+			t.Errorf("Extract() as superuser failed: %v", err)
+		}
+	} else {
+		var fpErr *fulpack.FulpackError
+		if !errors.As(err, &fpErr) || fpErr.Code != fulpack.ErrCodeInsufficientPrivileges {
+			t.Errorf("Expected INSUFFICIENT_PRIVILEGES without superuser, got %v", err)
+		}
+	}
+}
+
+func TestCreateAndExtract_Progress(t *testing.T) {
+	tmpDir := t.TempDir()
+	testDir := filepath.Join(tmpDir, "source")
+	if err := os.MkdirAll(testDir, 0755); err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+	for i, size := range []int{100, 2048, 70000} {
+		path := filepath.Join(testDir, "file"+string(rune('a'+i))+".bin")
+		if err := os.WriteFile(path, bytes.Repeat([]byte("y"), size), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	checkEvents := func(t *testing.T, events []fulpack.ProgressEvent, op fulpack.Operation) {
+		t.Helper()
+		if len(events) == 0 {
+			t.Fatal("Expected progress events")
+		}
+		last := events[len(events)-1]
+		if !last.Done || last.Operation != op {
+			t.Errorf("Expected final %s event with Done, got %+v", op, last)
+		}
+		if last.TotalBytes == 0 || last.BytesProcessed != last.TotalBytes {
+			t.Errorf("Expected completed bytes, got %d/%d", last.BytesProcessed, last.TotalBytes)
+		}
+		for i := 1; i < len(events); i++ {
+			if events[i].BytesProcessed < events[i-1].BytesProcessed {
+				t.Errorf("Progress went backwards at event %d", i)
+			}
+		}
+	}
+
+	for _, format := range []fulpack.ArchiveFormat{fulpack.ArchiveFormatTARGZ, fulpack.ArchiveFormatZIP} {
+		t.Run(string(format), func(t *testing.T) {
+			archive := filepath.Join(tmpDir, "progress."+string(format))
+
+			var createEvents []fulpack.ProgressEvent
+			info, err := fulpack.Create([]string{testDir}, archive, format, &fulpack.CreateOptions{
+				Progress: func(e fulpack.ProgressEvent) { createEvents = append(createEvents, e) },
+			})
+			if err != nil {
+				t.Fatalf("Create() failed: %v", err)
+			}
+			checkEvents(t, createEvents, fulpack.OperationCreate)
+			if last := createEvents[len(createEvents)-1]; last.TotalBytes != info.TotalSize || last.EntriesProcessed != info.EntryCount {
+				t.Errorf("Expected %d bytes/%d entries, got %+v", info.TotalSize, info.EntryCount, last)
+			}
+
+			var extractEvents []fulpack.ProgressEvent
+			_, err = fulpack.Extract(archive, t.TempDir(), &fulpack.ExtractOptions{
+				Progress: func(e fulpack.ProgressEvent) { extractEvents = append(extractEvents, e) },
+			})
+			if err != nil {
+				t.Fatalf("Extract() failed: %v", err)
+			}
+			checkEvents(t, extractEvents, fulpack.OperationExtract)
+		})
+	}
+
+	// Ready-made ascii progress bar adapter
+	var out strings.Builder
+	bar := ascii.NewProgressBar(&out, &ascii.ProgressBarOptions{Bytes: true})
+	if _, err := fulpack.Create([]string{testDir}, filepath.Join(tmpDir, "bar.tar"), fulpack.ArchiveFormatTAR,
+		&fulpack.CreateOptions{Progress: fulpack.ProgressBarReporter(bar)}); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "100%") || !strings.HasSuffix(out.String(), "\n") {
+		t.Errorf("Expected completed progress bar, got %q", out.String())
+	}
+}
+
+func TestCreate_WithFileFilters(t *testing.T) {
+	tmpDir := t.TempDir()
+	testDir := filepath.Join(tmpDir, "source")
+	if err := os.MkdirAll(testDir, 0755); err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+
+	now := time.Now()
+	files := []struct {
+		name    string
+		size    int
+		modTime time.Time
+	}{
+		{"recent-small.txt", 10, now.Add(-1 * time.Hour)},
+		{"recent-large.txt", 5000, now.Add(-2 * time.Hour)},
+		{"stale-small.txt", 10, now.Add(-72 * time.Hour)},
+		{"recent-skip.tmp", 10, now.Add(-1 * time.Hour)},
+	}
+	for _, f := range files {
+		path := filepath.Join(testDir, f.name)
+		if err := os.WriteFile(path, bytes.Repeat([]byte("x"), f.size), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		if err := os.Chtimes(path, f.modTime, f.modTime); err != nil {
+			t.Fatalf("Failed to set mtime: %v", err)
+		}
+	}
+
+	archive := filepath.Join(tmpDir, "backup.tar")
+	_, err := fulpack.Create([]string{testDir}, archive, fulpack.ArchiveFormatTAR, &fulpack.CreateOptions{
+		MaxFileSize:   1024,
+		ModifiedAfter: now.Add(-24 * time.Hour),
+		Filter: func(path string, info fs.FileInfo) bool {
+			return filepath.Ext(path) != ".tmp"
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	entries, err := fulpack.Scan(archive, nil)
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.Type == fulpack.EntryTypeFile {
+			names = append(names, filepath.Base(e.Path))
+		}
+	}
+	if len(names) != 1 || names[0] != "recent-small.txt" {
+		t.Errorf("Expected only recent-small.txt, got %v", names)
+	}
+
+	// ModifiedBefore selects the complementary window
+	older := filepath.Join(tmpDir, "older.tar")
+	_, err = fulpack.Create([]string{testDir}, older, fulpack.ArchiveFormatTAR, &fulpack.CreateOptions{
+		ModifiedBefore: now.Add(-24 * time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	entries, err = fulpack.Scan(older, nil)
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	names = names[:0]
+	for _, e := range entries {
+		if e.Type == fulpack.EntryTypeFile {
+			names = append(names, filepath.Base(e.Path))
+		}
+	}
+	if len(names) != 1 || names[0] != "stale-small.txt" {
+		t.Errorf("Expected only stale-small.txt, got %v", names)
+	}
+
+	// Unset time bounds are omitted from serialized options
+	data, err := json.Marshal(fulpack.CreateOptions{ModifiedBefore: now})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(data), "modified_after") || !strings.Contains(string(data), "modified_before") {
+		t.Errorf("Expected only modified_before in %s", data)
+	}
+}
+
 func TestCreate_WithChecksums(t *testing.T) {
 	tmpDir := t.TempDir()
 	outputPath := filepath.Join(tmpDir, "checksummed.tar.gz")
@@ -679,56 +1205,382 @@ func TestVerify_CorruptArchive(t *testing.T) {
 	}
 }
 
-func TestExtract_WithExcludePatterns(t *testing.T) {
-	archive := filepath.Join(fixturesDir, "basic.tar")
-	destDir := t.TempDir()
-
-	// Extract excluding .txt files
-	result, err := fulpack.Extract(archive, destDir, &fulpack.ExtractOptions{
-		ExcludePatterns: []string{"**/*.txt"},
-	})
-	if err != nil {
-		t.Fatalf("Extract() failed: %v", err)
-	}
-
-	// Verify no .txt files were extracted
-	entries, _ := os.ReadDir(destDir)
-	for _, entry := range entries {
-		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".txt" {
-			t.Errorf("Found .txt file that should have been excluded: %s", entry.Name())
+// writeTruncatedTar builds a tar of three 2000-byte files and cuts it off
+// partway through the third file's content.
+func writeTruncatedTar(t *testing.T, path string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range []string{"one.txt", "two.txt", "three.txt"} {
+		content := bytes.Repeat([]byte(name[:1]), 2000)
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("WriteHeader failed: %v", err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatalf("Write failed: %v", err)
 		}
 	}
-
-	// Should have extracted some files and excluded others
-	if result.ExtractedCount == 0 {
-		t.Errorf("Expected some files to be extracted")
-	}
-	if result.SkippedCount == 0 {
-		t.Errorf("Expected some .txt files to be skipped via exclude pattern")
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
 	}
 
-	t.Logf("Extracted %d files, excluded %d .txt files", result.ExtractedCount, result.SkippedCount)
+	// Two complete entries occupy 2*(512+2048) bytes; keep the third header and part of its data
+	if err := os.WriteFile(path, buf.Bytes()[:5120+512+1000], 0644); err != nil {
+		t.Fatalf("Failed to write truncated tar: %v", err)
+	}
 }
 
-func TestCreate_ChecksumAlgorithmFallback(t *testing.T) {
-	tmpDir := t.TempDir()
-	outputPath := filepath.Join(tmpDir, "test.tar.gz")
+func TestVerify_TruncatedTarReportsLastValidEntry(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "truncated.tar")
+	writeTruncatedTar(t, archive)
 
-	// Create test file
-	testDir := filepath.Join(tmpDir, "source")
-	if err := os.MkdirAll(testDir, 0755); err != nil {
-		t.Fatalf("Failed to create test directory: %v", err)
+	result, err := fulpack.Verify(archive, nil)
+	if err != nil {
+		t.Fatalf("Verify() failed: %v", err)
+	}
+	if result.Valid {
+		t.Fatal("Expected truncated archive to fail validation")
+	}
+	if result.Truncation == nil {
+		t.Fatal("Expected truncation report")
+	}
+	if result.Truncation.LastValidEntry != "two.txt" {
+		t.Errorf("Expected last valid entry two.txt, got %q", result.Truncation.LastValidEntry)
+	}
+	if result.Truncation.ReadableEntries != 2 {
+		t.Errorf("Expected 2 readable entries, got %d", result.Truncation.ReadableEntries)
 	}
+	if result.Truncation.LastValidOffset != 5120 {
+		t.Errorf("Expected last valid offset 5120, got %d", result.Truncation.LastValidOffset)
+	}
+	if result.Truncation.Suggestion == "" {
+		t.Error("Expected a repair suggestion")
+	}
+}
 
-	testFile := filepath.Join(testDir, "data.bin")
-	if err := os.WriteFile(testFile, []byte("test data"), 0644); err != nil {
-		t.Fatalf("Failed to create test file: %v", err)
+func TestVerify_TruncatedTarGz(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join(fixturesDir, "basic.tar.gz"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	archive := filepath.Join(t.TempDir(), "truncated.tar.gz")
+	if err := os.WriteFile(archive, data[:len(data)*2/3], 0644); err != nil {
+		t.Fatalf("Failed to write truncated archive: %v", err)
 	}
 
-	// Request sha512 (unsupported) - should fallback to sha256
-	info, err := fulpack.Create(
-		[]string{testFile},
-		outputPath,
+	result, err := fulpack.Verify(archive, nil)
+	if err != nil {
+		t.Fatalf("Verify() failed: %v", err)
+	}
+	if result.Valid {
+		t.Fatal("Expected truncated archive to fail validation")
+	}
+	if result.Truncation == nil {
+		t.Fatal("Expected truncation report")
+	}
+	if result.Truncation.LastValidOffset%512 != 0 {
+		t.Errorf("Expected block-aligned offset, got %d", result.Truncation.LastValidOffset)
+	}
+}
+
+func TestVerify_ValidArchiveHasNoTruncation(t *testing.T) {
+	result, err := fulpack.Verify(filepath.Join(fixturesDir, "basic.tar"), nil)
+	if err != nil {
+		t.Fatalf("Verify() failed: %v", err)
+	}
+	if result.Truncation != nil {
+		t.Errorf("Expected no truncation report for intact archive, got %+v", result.Truncation)
+	}
+}
+
+func TestVerify_ChecksumFile(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar.gz")
+	data, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	digest, err := fulhash.Hash(data, fulhash.WithAlgorithm(fulhash.SHA256))
+	if err != nil {
+		t.Fatalf("Hash() failed: %v", err)
+	}
+
+	dir := t.TempDir()
+	checksumFile := filepath.Join(dir, "basic.tar.gz.sha256")
+	content := strings.Repeat("0", 64) + "  other.tar.gz\n" + digest.Hex() + "  dist/basic.tar.gz\n"
+	if err := os.WriteFile(checksumFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write checksum file: %v", err)
+	}
+
+	result, err := fulpack.Verify(archive, &fulpack.VerifyOptions{ChecksumFile: checksumFile})
+	if err != nil {
+		t.Fatalf("Verify() failed: %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("Expected archive to match checksum file, got errors: %v", result.Errors)
+	}
+	found := false
+	for _, check := range result.ChecksPerformed {
+		if check == "digest_verified" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected digest_verified check, got %v", result.ChecksPerformed)
+	}
+
+	// Bare digest of another archive -> mismatch
+	mismatchFile := filepath.Join(dir, "mismatch.sha256")
+	if err := os.WriteFile(mismatchFile, []byte(strings.Repeat("ab", 32)+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write checksum file: %v", err)
+	}
+	result, err = fulpack.Verify(archive, &fulpack.VerifyOptions{ChecksumFile: mismatchFile})
+	if err != nil {
+		t.Fatalf("Verify() failed: %v", err)
+	}
+	if result.Valid {
+		t.Fatal("Expected mismatch to invalidate archive")
+	}
+	var mismatch *fulpack.ValidationError
+	for i := range result.Errors {
+		if result.Errors[i].Code == fulpack.ErrCodeChecksumMismatch {
+			mismatch = &result.Errors[i]
+		}
+	}
+	if mismatch == nil {
+		t.Fatalf("Expected CHECKSUM_MISMATCH error, got %v", result.Errors)
+	}
+	if mismatch.Details["actual"] != fulhash.FormatDigest(digest) {
+		t.Errorf("Expected actual digest %s, got %v", fulhash.FormatDigest(digest), mismatch.Details["actual"])
+	}
+}
+
+func TestVerify_ExpectedDigest(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar")
+	data, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	digest, err := fulhash.Hash(data)
+	if err != nil {
+		t.Fatalf("Hash() failed: %v", err)
+	}
+
+	result, err := fulpack.Verify(archive, &fulpack.VerifyOptions{ExpectedDigest: digest})
+	if err != nil {
+		t.Fatalf("Verify() failed: %v", err)
+	}
+	if !result.Valid {
+		t.Errorf("Expected archive to match digest, got errors: %v", result.Errors)
+	}
+
+	other, _ := fulhash.HashString("something else")
+	result, err = fulpack.Verify(archive, &fulpack.VerifyOptions{ExpectedDigest: other})
+	if err != nil {
+		t.Fatalf("Verify() failed: %v", err)
+	}
+	if result.Valid {
+		t.Error("Expected digest mismatch to invalidate archive")
+	}
+}
+
+func TestVerify_InvalidChecksumFile(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar.gz")
+	dir := t.TempDir()
+
+	cases := map[string]string{
+		"missing.sha256":   "",
+		"malformed.sha256": "not-hex  basic.tar.gz\n",
+		"unlisted.sha256":  strings.Repeat("0", 64) + "  other.tar.gz\n",
+		"unknown.txt":      "abc123\n",
+	}
+	for name, content := range cases {
+		path := filepath.Join(dir, name)
+		if content != "" {
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write checksum file: %v", err)
+			}
+		}
+
+		_, err := fulpack.Verify(archive, &fulpack.VerifyOptions{ChecksumFile: path})
+		var fpErr *fulpack.FulpackError
+		if !errors.As(err, &fpErr) || fpErr.Code != fulpack.ErrCodeInvalidChecksumFile {
+			t.Errorf("%s: expected INVALID_CHECKSUM_FILE error, got %v", name, err)
+		}
+	}
+}
+
+func TestExtract_UntrustedProfile(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "upload.tar")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	tw := tar.NewWriter(f)
+	writeFile := func(name string, content []byte) {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("WriteHeader(%s) failed: %v", name, err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatalf("Write(%s) failed: %v", name, err)
+		}
+	}
+	writeHeader := func(hdr *tar.Header) {
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("WriteHeader(%s) failed: %v", hdr.Name, err)
+		}
+	}
+	writeFile("ok.txt", []byte("hello"))
+	writeFile("large.bin", bytes.Repeat([]byte("x"), 2048))
+	writeFile(strings.Repeat("n", 300)+".txt", []byte("long name"))
+	writeHeader(&tar.Header{Name: "abs-link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"})
+	writeHeader(&tar.Header{Name: "fifo", Typeflag: tar.TypeFifo, Mode: 0644})
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	_ = f.Close()
+
+	opts := fulpack.UntrustedProfile()
+	opts.MaxEntrySize = 1024
+	destDir := t.TempDir()
+	result, err := fulpack.Extract(archive, destDir, opts)
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	if result.ExtractedCount != 1 {
+		t.Errorf("Expected 1 extracted entry, got %d", result.ExtractedCount)
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "ok.txt")); err != nil {
+		t.Errorf("Expected ok.txt to be extracted: %v", err)
+	}
+
+	codes := make(map[string]string)
+	for _, r := range result.Rejected {
+		codes[r.Path] = r.Code
+	}
+	expected := map[string]string{
+		"large.bin":                       fulpack.ErrCodeMaxSizeExceeded,
+		strings.Repeat("n", 300) + ".txt": fulpack.ErrCodeEntryNameTooLong,
+		"abs-link":                        fulpack.ErrCodeSymlinkEscape,
+		"fifo":                            fulpack.ErrCodeUnsafeEntryType,
+	}
+	for path, code := range expected {
+		if codes[path] != code {
+			t.Errorf("Expected %s rejected with %s, got %q", path[:min(len(path), 20)], code, codes[path])
+		}
+	}
+	if len(result.Rejected) != len(expected) || result.ErrorCount != len(expected) {
+		t.Errorf("Expected %d rejections, got %d (errors=%d)", len(expected), len(result.Rejected), result.ErrorCount)
+	}
+	if _, err := os.Lstat(filepath.Join(destDir, "large.bin")); !os.IsNotExist(err) {
+		t.Error("Expected oversized entry not to be written")
+	}
+
+	// Default options keep the lenient behavior: FIFO skipped, not rejected
+	defaultResult, err := fulpack.Extract(archive, t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("Extract() with defaults failed: %v", err)
+	}
+	for _, r := range defaultResult.Rejected {
+		if r.Path == "fifo" || r.Path == "large.bin" {
+			t.Errorf("Default options should not reject %s", r.Path)
+		}
+	}
+}
+
+func TestExtract_SalvageTruncatedTar(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "truncated.tar")
+	writeTruncatedTar(t, archive)
+
+	// Without salvage the damaged entry surfaces as an error
+	strictDir := t.TempDir()
+	strict, err := fulpack.Extract(archive, strictDir, nil)
+	if err == nil && (strict == nil || strict.ErrorCount == 0) {
+		t.Error("Expected strict extraction to report the damage")
+	}
+
+	destDir := t.TempDir()
+	result, err := fulpack.Extract(archive, destDir, &fulpack.ExtractOptions{Salvage: true})
+	if err != nil {
+		t.Fatalf("Extract() with salvage failed: %v", err)
+	}
+	if result.ExtractedCount != 2 {
+		t.Errorf("Expected 2 salvaged entries, got %d", result.ExtractedCount)
+	}
+	if result.ErrorCount != 1 || len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 salvage error, got %d", result.ErrorCount)
+	}
+	if result.Errors[0].Code != fulpack.ErrCodeCorruptArchive {
+		t.Errorf("Expected CORRUPT_ARCHIVE code, got %s", result.Errors[0].Code)
+	}
+
+	for _, name := range []string{"one.txt", "two.txt"} {
+		info, statErr := os.Stat(filepath.Join(destDir, name))
+		if statErr != nil {
+			t.Errorf("Expected %s to be salvaged: %v", name, statErr)
+			continue
+		}
+		if info.Size() != 2000 {
+			t.Errorf("Expected %s to be 2000 bytes, got %d", name, info.Size())
+		}
+	}
+	if _, statErr := os.Stat(filepath.Join(destDir, "three.txt")); !os.IsNotExist(statErr) {
+		t.Error("Expected partial three.txt to be removed")
+	}
+}
+
+func TestExtract_WithExcludePatterns(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar")
+	destDir := t.TempDir()
+
+	// Extract excluding .txt files
+	result, err := fulpack.Extract(archive, destDir, &fulpack.ExtractOptions{
+		ExcludePatterns: []string{"**/*.txt"},
+	})
+	if err != nil {
+		t.Fatalf("Extract() failed: %v", err)
+	}
+
+	// Verify no .txt files were extracted
+	entries, _ := os.ReadDir(destDir)
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".txt" {
+			t.Errorf("Found .txt file that should have been excluded: %s", entry.Name())
+		}
+	}
+
+	// Should have extracted some files and excluded others
+	if result.ExtractedCount == 0 {
+		t.Errorf("Expected some files to be extracted")
+	}
+	if result.SkippedCount == 0 {
+		t.Errorf("Expected some .txt files to be skipped via exclude pattern")
+	}
+
+	t.Logf("Extracted %d files, excluded %d .txt files", result.ExtractedCount, result.SkippedCount)
+}
+
+func TestCreate_ChecksumAlgorithmFallback(t *testing.T) {
+	tmpDir := t.TempDir()
+	outputPath := filepath.Join(tmpDir, "test.tar.gz")
+
+	// Create test file
+	testDir := filepath.Join(tmpDir, "source")
+	if err := os.MkdirAll(testDir, 0755); err != nil {
+		t.Fatalf("Failed to create test directory: %v", err)
+	}
+
+	testFile := filepath.Join(testDir, "data.bin")
+	if err := os.WriteFile(testFile, []byte("test data"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	// Request sha512 (unsupported) - should fallback to sha256
+	info, err := fulpack.Create(
+		[]string{testFile},
+		outputPath,
 		fulpack.ArchiveFormatTARGZ,
 		&fulpack.CreateOptions{
 			ChecksumAlgorithm: "sha512", // Unsupported - should fallback
@@ -754,3 +1606,645 @@ func TestCreate_ChecksumAlgorithmFallback(t *testing.T) {
 
 	t.Logf("Correctly fell back to sha256 for unsupported sha512 request")
 }
+
+// ========================================
+// ExtractEntry / ReadEntry Tests
+// ========================================
+
+func TestReadEntry_TarGz(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar.gz")
+
+	data, err := fulpack.ReadEntry(archive, "subdir/file3.txt")
+	if err != nil {
+		t.Fatalf("ReadEntry() failed: %v", err)
+	}
+
+	if len(data) == 0 {
+		t.Errorf("Expected non-empty content for subdir/file3.txt")
+	}
+}
+
+func TestReadEntry_Zip(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "nested.zip")
+
+	data, err := fulpack.ReadEntry(archive, "level1/level2/level3/deep.txt")
+	if err != nil {
+		t.Fatalf("ReadEntry() failed: %v", err)
+	}
+
+	if len(data) != 19 {
+		t.Errorf("Expected 19 bytes, got %d", len(data))
+	}
+}
+
+func TestReadEntry_NotFound(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar")
+
+	_, err := fulpack.ReadEntry(archive, "missing.txt")
+	var ferr *fulpack.FulpackError
+	if !errors.As(err, &ferr) {
+		t.Fatalf("Expected FulpackError, got %v", err)
+	}
+	if ferr.Code != fulpack.ErrCodeEntryNotFound {
+		t.Errorf("Expected code %s, got %s", fulpack.ErrCodeEntryNotFound, ferr.Code)
+	}
+}
+
+func TestReadEntry_RejectsTraversal(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar")
+
+	_, err := fulpack.ReadEntry(archive, "../etc/passwd")
+	var ferr *fulpack.FulpackError
+	if !errors.As(err, &ferr) || ferr.Code != fulpack.ErrCodePathTraversal {
+		t.Errorf("Expected PATH_TRAVERSAL error, got %v", err)
+	}
+}
+
+func TestFulpackError_Classification(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar")
+
+	_, err := fulpack.ReadEntry(archive, "../etc/passwd")
+	if got := gferrors.Classify(err); got != gferrors.ClassificationSecurity {
+		t.Errorf("Classify(PATH_TRAVERSAL) = %q, want %q", got, gferrors.ClassificationSecurity)
+	}
+	if gferrors.IsRetryable(err) {
+		t.Error("Expected PATH_TRAVERSAL error not to be retryable")
+	}
+
+	_, err = fulpack.ReadEntry(archive, "missing.txt")
+	if got := gferrors.Classify(err); got != gferrors.ClassificationPermanent {
+		t.Errorf("Classify(ENTRY_NOT_FOUND) = %q, want %q", got, gferrors.ClassificationPermanent)
+	}
+
+	// fulpack registers its codes, so envelopes carrying them classify too
+	for _, code := range []string{
+		fulpack.ErrCodeUnsupportedChecksumAlgorithm,
+		fulpack.ErrCodeLinkNotAllowed,
+		fulpack.ErrCodeInsufficientPrivileges,
+	} {
+		if got := gferrors.ClassifyCode(code); got != gferrors.ClassificationPermanent {
+			t.Errorf("ClassifyCode(%s) = %q, want %q", code, got, gferrors.ClassificationPermanent)
+		}
+		ferr := &fulpack.FulpackError{Code: code}
+		if got := ferr.Classification(); got != gferrors.ClassificationPermanent {
+			t.Errorf("Classification(%s) = %q, want %q", code, got, gferrors.ClassificationPermanent)
+		}
+	}
+	if got := gferrors.ClassifyCode(fulpack.ErrCodeFileAccess); got != gferrors.ClassificationTransient {
+		t.Errorf("ClassifyCode(FILE_ACCESS_ERROR) = %q, want %q", got, gferrors.ClassificationTransient)
+	}
+	if got := gferrors.ClassifyCode(fulpack.ErrCodeDecompressionBomb); got != gferrors.ClassificationSecurity {
+		t.Errorf("ClassifyCode(DECOMPRESSION_BOMB) = %q, want %q", got, gferrors.ClassificationSecurity)
+	}
+}
+
+func TestExtractEntry_MaxSize(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar.gz")
+
+	var buf bytes.Buffer
+	_, err := fulpack.ExtractEntry(archive, "README.md", &buf, &fulpack.ExtractEntryOptions{MaxSize: 10})
+	var ferr *fulpack.FulpackError
+	if !errors.As(err, &ferr) || ferr.Code != fulpack.ErrCodeMaxSizeExceeded {
+		t.Errorf("Expected MAX_SIZE_EXCEEDED error, got %v", err)
+	}
+}
+
+func TestExtractOptions_HumanReadableSizes(t *testing.T) {
+	var opts fulpack.ExtractOptions
+	if err := json.Unmarshal([]byte(`{"max_size": "2GiB", "max_entry_size": 1048576}`), &opts); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if opts.MaxSize.Bytes() != 2<<30 || opts.MaxEntrySize.Bytes() != 1<<20 {
+		t.Errorf("MaxSize = %d, MaxEntrySize = %d", opts.MaxSize, opts.MaxEntrySize)
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"max_size":2147483648`) || !strings.Contains(string(data), `"max_entry_size":1048576`) {
+		t.Errorf("Marshal = %s, want integer byte counts", data)
+	}
+}
+
+func TestExtractOptions_MarshalSchemaValid(t *testing.T) {
+	data, err := json.Marshal(fulpack.ExtractOptions{MaxSize: 1 << 30, MaxEntries: 100})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	diags, err := schema.ValidateDataByID("library/fulpack/v1.0.0/extract-options", data)
+	if err != nil {
+		t.Fatalf("ValidateDataByID failed: %v", err)
+	}
+	if len(diags) > 0 {
+		t.Errorf("%s is not schema-valid: %v", data, diags)
+	}
+}
+
+// validateWithExtension checks that value's extension fields match ext and
+// its remaining fields match the Crucible schema crucibleID.
+func validateWithExtension(t *testing.T, crucibleID string, ext []byte, value any) {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	extValidator, err := schema.NewValidator(ext)
+	if err != nil {
+		t.Fatalf("extension schema does not compile: %v", err)
+	}
+	diags, err := extValidator.ValidateJSON(data)
+	if err != nil {
+		t.Fatalf("ValidateJSON failed: %v", err)
+	}
+	if len(diags) > 0 {
+		t.Errorf("%s does not match the extension schema: %v", data, diags)
+	}
+
+	var extDoc struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(ext, &extDoc); err != nil {
+		t.Fatalf("Unmarshal extension schema failed: %v", err)
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	for name := range extDoc.Properties {
+		delete(fields, name)
+	}
+	core, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	diags, err = schema.ValidateDataByID(crucibleID, core)
+	if err != nil {
+		t.Fatalf("ValidateDataByID failed: %v", err)
+	}
+	if len(diags) > 0 {
+		t.Errorf("%s does not match %s: %v", core, crucibleID, diags)
+	}
+}
+
+func TestExtensionSchemas(t *testing.T) {
+	opts := fulpack.UntrustedProfile()
+	opts.IncludePatterns = []string{"**/*.csv"}
+	opts.ExcludePatterns = []string{"**/__pycache__"}
+	opts.Salvage = true
+	opts.PreserveOwnership = true
+	opts.PreserveXattrs = true
+	opts.XattrIncludes = []string{"user.*"}
+	opts.PreserveModTimes = true
+	validateWithExtension(t, "library/fulpack/v1.0.0/extract-options", fulpack.ExtractOptionsExtSchema(), opts)
+
+	rejection := fulpack.ExtractionError{Path: "../evil", Error: "path traversal", Code: fulpack.ErrCodePathTraversal}
+	result := &fulpack.ExtractResult{
+		ExtractedCount: 2,
+		ErrorCount:     1,
+		Errors:         []fulpack.ExtractionError{rejection},
+		BytesWritten:   1024,
+		Rejected:       []fulpack.ExtractionError{rejection},
+	}
+	validateWithExtension(t, "library/fulpack/v1.0.0/extract-result", fulpack.ExtractResultExtSchema(), result)
+
+	// Accessors return copies
+	fulpack.ExtractOptionsExtSchema()[0] = 'x'
+	if fulpack.ExtractOptionsExtSchema()[0] != '{' {
+		t.Error("ExtractOptionsExtSchema returned the embedded slice")
+	}
+}
+
+// ========================================
+// Convert Operation Tests
+// ========================================
+
+func TestConvert_ZipToTarGz(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "nested.zip")
+	outputPath := filepath.Join(t.TempDir(), "nested.tar.gz")
+
+	result, err := fulpack.Convert(archive, outputPath, fulpack.ArchiveFormatTARGZ, nil)
+	if err != nil {
+		t.Fatalf("Convert() failed: %v", err)
+	}
+
+	source, err := fulpack.Scan(archive, nil)
+	if err != nil {
+		t.Fatalf("Scan() of source failed: %v", err)
+	}
+	if result.ConvertedCount != len(source) {
+		t.Errorf("Expected %d converted entries, got %d", len(source), result.ConvertedCount)
+	}
+
+	data, err := fulpack.ReadEntry(outputPath, "level1/level2/level3/deep.txt")
+	if err != nil {
+		t.Fatalf("ReadEntry() on converted archive failed: %v", err)
+	}
+	if len(data) != 19 {
+		t.Errorf("Expected 19 bytes, got %d", len(data))
+	}
+}
+
+func TestConvert_WithPatterns(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar.gz")
+	outputPath := filepath.Join(t.TempDir(), "basic.zip")
+
+	result, err := fulpack.Convert(archive, outputPath, fulpack.ArchiveFormatZIP, &fulpack.ConvertOptions{
+		IncludePatterns: []string{"**/*.txt"},
+		ExcludePatterns: []string{"**/._*"},
+	})
+	if err != nil {
+		t.Fatalf("Convert() failed: %v", err)
+	}
+	if result.SkippedCount == 0 {
+		t.Errorf("Expected skipped entries from pattern filtering")
+	}
+
+	entries, err := fulpack.Scan(outputPath, nil)
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	for _, entry := range entries {
+		if filepath.Ext(entry.Path) != ".txt" || filepath.Base(entry.Path)[0] == '.' {
+			t.Errorf("Unexpected entry in converted archive: %s", entry.Path)
+		}
+	}
+}
+
+func TestConvert_SymlinkToZipUnrepresentable(t *testing.T) {
+	tmpDir := t.TempDir()
+	tarPath := filepath.Join(tmpDir, "links.tar")
+
+	f, err := os.Create(tarPath)
+	if err != nil {
+		t.Fatalf("Failed to create tar: %v", err)
+	}
+	tw := tar.NewWriter(f)
+	_ = tw.WriteHeader(&tar.Header{Name: "target.txt", Mode: 0644, Size: 4, Typeflag: tar.TypeReg})
+	_, _ = tw.Write([]byte("data"))
+	_ = tw.WriteHeader(&tar.Header{Name: "link.txt", Linkname: "target.txt", Mode: 0777, Typeflag: tar.TypeSymlink})
+	_ = tw.Close()
+	_ = f.Close()
+
+	result, err := fulpack.Convert(tarPath, filepath.Join(tmpDir, "links.zip"), fulpack.ArchiveFormatZIP, nil)
+	if err != nil {
+		t.Fatalf("Convert() failed: %v", err)
+	}
+
+	if result.ConvertedCount != 1 {
+		t.Errorf("Expected 1 converted entry, got %d", result.ConvertedCount)
+	}
+	if len(result.Unrepresentable) != 1 || result.Unrepresentable[0].Code != fulpack.ErrCodeUnrepresentableEntry {
+		t.Errorf("Expected symlink reported as unrepresentable, got %v", result.Unrepresentable)
+	}
+}
+
+func TestConvert_RejectsSameOutput(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar")
+
+	if _, err := fulpack.Convert(archive, archive, fulpack.ArchiveFormatTAR, nil); err == nil {
+		t.Errorf("Expected error when output equals source")
+	}
+}
+
+func TestConvert_GzipToTar(t *testing.T) {
+	tmpDir := t.TempDir()
+	inputPath := filepath.Join(tmpDir, "app.log")
+	if err := os.WriteFile(inputPath, []byte("line one\nline two\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	gzPath := filepath.Join(tmpDir, "app.log.gz")
+	if _, err := fulpack.Create([]string{inputPath}, gzPath, fulpack.ArchiveFormatGZIP, nil); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+
+	tarPath := filepath.Join(tmpDir, "app.tar")
+	if _, err := fulpack.Convert(gzPath, tarPath, fulpack.ArchiveFormatTAR, nil); err != nil {
+		t.Fatalf("Convert() failed: %v", err)
+	}
+
+	data, err := fulpack.ReadEntry(tarPath, "app.log")
+	if err != nil {
+		t.Fatalf("ReadEntry() failed: %v", err)
+	}
+	if string(data) != "line one\nline two\n" {
+		t.Errorf("Unexpected content: %q", data)
+	}
+}
+
+func TestOpenArchiveFS_StandardConformance(t *testing.T) {
+	tests := []struct {
+		archive  string
+		expected []string
+	}{
+		{"basic.tar", []string{"config.json", "README.md", "data/sample.txt", "data/tiny.png"}},
+		{"basic.tar.gz", []string{"file1.txt", "README.md", "subdir/file3.txt"}},
+		{"nested.zip", []string{"level1/level2/level3/deep.txt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.archive, func(t *testing.T) {
+			fsys, err := fulpack.OpenArchiveFS(filepath.Join(fixturesDir, tt.archive))
+			if err != nil {
+				t.Fatalf("OpenArchiveFS() failed: %v", err)
+			}
+			defer func() { _ = fsys.(io.Closer).Close() }()
+
+			if err := fstest.TestFS(fsys, tt.expected...); err != nil {
+				t.Errorf("fstest.TestFS() failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestOpenArchiveFS_ReadFile(t *testing.T) {
+	fsys, err := fulpack.OpenArchiveFS(filepath.Join(fixturesDir, "nested.zip"))
+	if err != nil {
+		t.Fatalf("OpenArchiveFS() failed: %v", err)
+	}
+	defer func() { _ = fsys.(io.Closer).Close() }()
+
+	data, err := fs.ReadFile(fsys, "level1/level2/level3/deep.txt")
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	if len(data) != 19 {
+		t.Errorf("Expected 19 bytes, got %d", len(data))
+	}
+
+	if _, err := fs.ReadFile(fsys, "missing.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected fs.ErrNotExist for missing file, got %v", err)
+	}
+	if _, err := fsys.Open("../escape"); !errors.Is(err, fs.ErrInvalid) {
+		t.Errorf("Expected fs.ErrInvalid for invalid path, got %v", err)
+	}
+}
+
+func TestOpenArchiveFS_TarGzMatchesReadEntry(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar.gz")
+	fsys, err := fulpack.OpenArchiveFS(archive)
+	if err != nil {
+		t.Fatalf("OpenArchiveFS() failed: %v", err)
+	}
+	defer func() { _ = fsys.(io.Closer).Close() }()
+
+	for _, name := range []string{"file1.txt", "subdir/file3.txt"} {
+		fromFS, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			t.Fatalf("ReadFile(%s) failed: %v", name, err)
+		}
+		fromEntry, err := fulpack.ReadEntry(archive, name)
+		if err != nil {
+			t.Fatalf("ReadEntry(%s) failed: %v", name, err)
+		}
+		if !bytes.Equal(fromFS, fromEntry) {
+			t.Errorf("%s: fs content differs from ReadEntry", name)
+		}
+	}
+}
+
+func TestOpenArchiveFS_Symlinks(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	write := func(h *tar.Header, content string) {
+		h.Size = int64(len(content))
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatalf("WriteHeader failed: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	write(&tar.Header{Name: "docs/guide.md", Mode: 0644, Typeflag: tar.TypeReg}, "# Guide\n")
+	write(&tar.Header{Name: "latest.md", Linkname: "docs/guide.md", Mode: 0777, Typeflag: tar.TypeSymlink}, "")
+	write(&tar.Header{Name: "escape", Linkname: "../../etc/passwd", Mode: 0777, Typeflag: tar.TypeSymlink}, "")
+	write(&tar.Header{Name: "../evil.txt", Mode: 0644, Typeflag: tar.TypeReg}, "evil")
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "links.tar")
+	if err := os.WriteFile(archive, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write archive: %v", err)
+	}
+
+	fsys, err := fulpack.OpenArchiveFS(archive)
+	if err != nil {
+		t.Fatalf("OpenArchiveFS() failed: %v", err)
+	}
+	defer func() { _ = fsys.(io.Closer).Close() }()
+
+	data, err := fs.ReadFile(fsys, "latest.md")
+	if err != nil {
+		t.Fatalf("ReadFile(latest.md) failed: %v", err)
+	}
+	if string(data) != "# Guide\n" {
+		t.Errorf("Expected symlink to resolve to guide content, got %q", data)
+	}
+
+	target, err := fs.ReadLink(fsys, "latest.md")
+	if err != nil || target != "docs/guide.md" {
+		t.Errorf("ReadLink() = %q, %v; want docs/guide.md", target, err)
+	}
+
+	if _, err := fsys.Open("escape"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected escaping symlink to be unreadable, got %v", err)
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		t.Fatalf("ReadDir() failed: %v", err)
+	}
+	for _, e := range entries {
+		if strings.Contains(e.Name(), "evil") {
+			t.Errorf("Traversal entry should not be exposed: %s", e.Name())
+		}
+	}
+}
+
+// diffTarEntry describes an entry written by writeDiffTar.
+type diffTarEntry struct {
+	name    string
+	content string
+	mode    int64
+	link    string
+}
+
+// writeDiffTar writes a tar archive of regular files and symlinks.
+func writeDiffTar(t *testing.T, path string, entries []diffTarEntry) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Mode: e.mode, Size: int64(len(e.content)), Typeflag: tar.TypeReg, ModTime: time.Unix(1700000000, 0)}
+		if e.link != "" {
+			header.Typeflag, header.Linkname, header.Size = tar.TypeSymlink, e.link, 0
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("WriteHeader failed: %v", err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("Failed to write tar: %v", err)
+	}
+}
+
+func TestDiff_Changes(t *testing.T) {
+	dir := t.TempDir()
+	archiveA := filepath.Join(dir, "a.tar")
+	archiveB := filepath.Join(dir, "b.tar")
+	writeDiffTar(t, archiveA, []diffTarEntry{
+		{name: "same.txt", content: "same", mode: 0644},
+		{name: "changed.txt", content: "old", mode: 0644},
+		{name: "removed.txt", content: "bye", mode: 0644},
+		{name: "bin/tool", content: "binary", mode: 0755},
+		{name: "current", link: "same.txt", mode: 0777},
+	})
+	writeDiffTar(t, archiveB, []diffTarEntry{
+		{name: "same.txt", content: "same", mode: 0644},
+		{name: "changed.txt", content: "newer", mode: 0644},
+		{name: "added.txt", content: "hi", mode: 0644},
+		{name: "bin/tool", content: "binary", mode: 0644},
+		{name: "current", link: "changed.txt", mode: 0777},
+	})
+
+	result, err := fulpack.Diff(archiveA, archiveB, nil)
+	if err != nil {
+		t.Fatalf("Diff() failed: %v", err)
+	}
+	if result.AddedCount != 1 || result.RemovedCount != 1 || result.ModifiedCount != 2 || result.UnchangedCount != 2 {
+		t.Errorf("Unexpected counts: added=%d removed=%d modified=%d unchanged=%d",
+			result.AddedCount, result.RemovedCount, result.ModifiedCount, result.UnchangedCount)
+	}
+
+	changes := make(map[string]fulpack.DiffEntry)
+	for _, change := range result.Changes {
+		changes[change.Path] = change
+	}
+	if c := changes["changed.txt"]; c.Change != fulpack.DiffModified || strings.Join(c.Reasons, ",") != "size,content" {
+		t.Errorf("Expected changed.txt modified by size and content, got %+v", c)
+	}
+	if c := changes["current"]; strings.Join(c.Reasons, ",") != fulpack.DiffReasonLinkTarget {
+		t.Errorf("Expected current modified by link target, got %+v", c)
+	}
+	if c := changes["added.txt"]; c.Change != fulpack.DiffAdded || c.Old != nil || c.New == nil {
+		t.Errorf("Expected added.txt added, got %+v", c)
+	}
+	if c := changes["removed.txt"]; c.Change != fulpack.DiffRemoved || c.Old == nil || c.New != nil {
+		t.Errorf("Expected removed.txt removed, got %+v", c)
+	}
+
+	var text bytes.Buffer
+	if err := result.WriteText(&text); err != nil {
+		t.Fatalf("WriteText() failed: %v", err)
+	}
+	for _, want := range []string{"A  added.txt (2 bytes)", "D  removed.txt (3 bytes)", "M  changed.txt [size, content] (3 -> 5 bytes)", "1 added, 1 removed, 2 modified, 2 unchanged"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Expected %q in text report:\n%s", want, text.String())
+		}
+	}
+
+	var jsonOut bytes.Buffer
+	if err := result.WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("WriteJSON() failed: %v", err)
+	}
+	var decoded fulpack.DiffResult
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil || len(decoded.Changes) != len(result.Changes) {
+		t.Errorf("Expected JSON report to round-trip, got %v", err)
+	}
+
+	// Mode changes are opt-in
+	result, err = fulpack.Diff(archiveA, archiveB, &fulpack.DiffOptions{CompareModes: true, ExcludePatterns: []string{"*.txt"}})
+	if err != nil {
+		t.Fatalf("Diff() with CompareModes failed: %v", err)
+	}
+	if result.ModifiedCount != 2 || result.Changes[0].Path != "bin/tool" || result.Changes[0].Reasons[0] != fulpack.DiffReasonMode {
+		t.Errorf("Expected bin/tool mode change, got %+v", result.Changes)
+	}
+}
+
+func TestDiff_Identical(t *testing.T) {
+	archive := filepath.Join(fixturesDir, "basic.tar.gz")
+
+	result, err := fulpack.Diff(archive, archive, &fulpack.DiffOptions{CompareModes: true, CompareModTimes: true})
+	if err != nil {
+		t.Fatalf("Diff() failed: %v", err)
+	}
+	if !result.Identical() || result.UnchangedCount == 0 {
+		t.Errorf("Expected identical archives, got %+v", result)
+	}
+
+	_, err = fulpack.Diff(archive, archive, &fulpack.DiffOptions{ChecksumAlgorithm: "md5"})
+	var fpErr *fulpack.FulpackError
+	if !errors.As(err, &fpErr) || fpErr.Code != fulpack.ErrCodeUnsupportedChecksumAlgorithm {
+		t.Errorf("Expected %s error, got %v", fulpack.ErrCodeUnsupportedChecksumAlgorithm, err)
+	}
+
+	if _, err := fulpack.Diff(archive, filepath.Join(t.TempDir(), "missing.tar"), nil); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected not-exist error for missing archive, got %v", err)
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	for _, format := range []fulpack.ArchiveFormat{
+		fulpack.ArchiveFormatTAR, fulpack.ArchiveFormatTARGZ, fulpack.ArchiveFormatZIP, fulpack.ArchiveFormatGZIP,
+	} {
+		caps, err := fulpack.Capabilities(format)
+		if err != nil {
+			t.Fatalf("Capabilities(%s) failed: %v", format, err)
+		}
+		if caps.Format != format {
+			t.Errorf("Capabilities(%s).Format = %s", format, caps.Format)
+		}
+	}
+
+	tarCaps, _ := fulpack.Capabilities(fulpack.ArchiveFormatTARGZ)
+	if !tarCaps.Symlinks || !tarCaps.Permissions || !tarCaps.Streaming || tarCaps.RandomAccess {
+		t.Errorf("unexpected tar.gz capabilities: %+v", tarCaps)
+	}
+	gzipCaps, _ := fulpack.Capabilities(fulpack.ArchiveFormatGZIP)
+	if gzipCaps.MultipleEntries || gzipCaps.Directories {
+		t.Errorf("unexpected gzip capabilities: %+v", gzipCaps)
+	}
+
+	// Reported zip capabilities match what Create actually stores
+	zipCaps, _ := fulpack.Capabilities(fulpack.ArchiveFormatZIP)
+	if zipCaps.Symlinks || !zipCaps.RandomAccess {
+		t.Errorf("unexpected zip capabilities: %+v", zipCaps)
+	}
+	srcDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(srcDir, "file.txt"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("file.txt", filepath.Join(srcDir, "link.txt")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	output := filepath.Join(t.TempDir(), "out.zip")
+	if _, err := fulpack.Create([]string{srcDir}, output, fulpack.ArchiveFormatZIP, nil); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	entries, err := fulpack.Scan(output, nil)
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.Type == fulpack.EntryTypeSymlink || strings.HasSuffix(entry.Path, "link.txt") {
+			t.Errorf("zip archive unexpectedly contains symlink entry %s", entry.Path)
+		}
+	}
+
+	_, err = fulpack.Capabilities("rar")
+	var fpErr *fulpack.FulpackError
+	if !errors.As(err, &fpErr) || fpErr.Code != fulpack.ErrCodeInvalidFormat {
+		t.Errorf("expected INVALID_FORMAT error, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package fulpack
+
+import (
+	"archive/tar"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// Untrusted profile limits (see UntrustedProfile).
+const (
+	UntrustedMaxSizeBytes       = 256 * 1024 * 1024 // 256MB
+	UntrustedMaxEntries         = 5000
+	UntrustedMaxEntrySizeBytes  = 64 * 1024 * 1024 // 64MB
+	UntrustedMaxEntryNameLength = 255
+)
+
+// UntrustedProfile returns ExtractOptions hardened for archives from untrusted
+// sources such as user uploads.
+//
+// Compared to the defaults, the profile:
+//   - Lowers MaxSize (256MB) and MaxEntries (5000)
+//   - Limits each entry to 64MB (MaxEntrySize) and 255-byte names (MaxEntryNameLength)
+//   - Rejects absolute symlink targets, even ones that resolve within the destination
+//   - Rejects device, FIFO, and socket entries instead of silently skipping them
+//   - Does not preserve permissions (no setuid/world-writable files) and never overwrites
+//
+// Rejected entries are listed in ExtractResult.Rejected. Fields can be adjusted
+// on the returned value before use.
+//
+// Example:
+//
+//	opts := fulpack.UntrustedProfile()
+//	opts.IncludePatterns = []string{"**/*.csv"}
+//	result, err := fulpack.Extract(upload, workDir, opts)
+//	for _, r := range result.Rejected {
+//	    auditLog.Warn("rejected archive entry", "path", r.Path, "code", r.Code)
+//	}
+func UntrustedProfile() *ExtractOptions {
+	return &ExtractOptions{
+		Overwrite:              OverwritePolicyError,
+		VerifyChecksums:        boolPtr(true),
+		PreservePermissions:    boolPtr(false),
+		MaxSize:                UntrustedMaxSizeBytes,
+		MaxEntries:             UntrustedMaxEntries,
+		MaxEntrySize:           UntrustedMaxEntrySizeBytes,
+		MaxEntryNameLength:     UntrustedMaxEntryNameLength,
+		RejectAbsoluteSymlinks: true,
+		RejectSpecialEntries:   true,
+	}
+}
+
+// rejectEntry records an entry refused by security policy in both the error
+// list and the rejection audit log.
+func rejectEntry(result *ExtractResult, path string, message string, code string) {
+	rejection := ExtractionError{Path: path, Error: message, Code: code}
+	result.ErrorCount++
+	result.Errors = append(result.Errors, rejection)
+	result.Rejected = append(result.Rejected, rejection)
+}
+
+// checkEntryName applies the MaxEntryNameLength policy.
+func checkEntryName(name string, opts *ExtractOptions) (string, bool) {
+	if opts.MaxEntryNameLength > 0 && len(name) > opts.MaxEntryNameLength {
+		return fmt.Sprintf("entry name length %d exceeds limit of %d", len(name), opts.MaxEntryNameLength), false
+	}
+	return "", true
+}
+
+// checkEntrySize applies the MaxEntrySize policy to a declared entry size.
+func checkEntrySize(size int64, opts *ExtractOptions) (string, bool) {
+	if opts.MaxEntrySize > 0 && size > opts.MaxEntrySize.Bytes() {
+		return fmt.Sprintf("entry size %d exceeds per-entry limit of %d bytes", size, opts.MaxEntrySize), false
+	}
+	return "", true
+}
+
+// isAbsoluteLinkTarget reports whether a link target is absolute on any platform.
+func isAbsoluteLinkTarget(target string) bool {
+	return filepath.IsAbs(target) || strings.HasPrefix(target, "/") || strings.HasPrefix(target, `\`)
+}
+
+// isSpecialTarType reports whether a tar entry is a device, FIFO, or similar special file.
+func isSpecialTarType(typeflag byte) bool {
+	switch typeflag {
+	case tar.TypeChar, tar.TypeBlock, tar.TypeFifo:
+		return true
+	}
+	return false
+}
+
+// isSpecialFileMode reports whether a file mode describes a device, FIFO, or socket.
+func isSpecialFileMode(mode fs.FileMode) bool {
+	return mode&(fs.ModeDevice|fs.ModeCharDevice|fs.ModeNamedPipe|fs.ModeSocket) != 0
+}
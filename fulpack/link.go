@@ -0,0 +1,86 @@
+package fulpack
+
+import "os"
+
+// fileID identifies a file by device and inode.
+type fileID struct {
+	dev uint64
+	ino uint64
+}
+
+// validLinkPolicy reports whether policy is a known LinkPolicy.
+func validLinkPolicy(policy LinkPolicy) bool {
+	switch policy {
+	case LinkPolicyPreserve, LinkPolicyFollow, LinkPolicySkip, LinkPolicyError:
+		return true
+	default:
+		return false
+	}
+}
+
+// applyLinkPolicy removes or rejects symlink sources according to
+// opts.LinkPolicy, recording format fallbacks in info.Warnings.
+func applyLinkPolicy(files []string, format ArchiveFormat, opts *CreateOptions, info *ArchiveInfo) ([]string, error) {
+	if opts.LinkPolicy == LinkPolicyFollow {
+		return files, nil
+	}
+
+	kept := make([]string, 0, len(files))
+	for _, path := range files {
+		fileInfo, err := os.Lstat(path)
+		if err != nil {
+			return nil, newErrorf(ErrCodeCorruptArchive, OperationCreate, path, err,
+				"failed to stat source file: %v", err)
+		}
+		if fileInfo.Mode()&os.ModeSymlink == 0 {
+			kept = append(kept, path)
+			continue
+		}
+
+		switch opts.LinkPolicy {
+		case LinkPolicySkip:
+			continue
+		case LinkPolicyError:
+			return nil, newError(ErrCodeLinkNotAllowed, "symlink not allowed by link policy", OperationCreate, path, nil)
+		}
+
+		// LinkPolicyPreserve: fall back where the format cannot store links
+		switch format {
+		case ArchiveFormatZIP:
+			info.Warnings = append(info.Warnings, "symlink skipped (zip does not store symlinks): "+path)
+			continue
+		case ArchiveFormatGZIP:
+			info.Warnings = append(info.Warnings, "symlink target content stored (gzip does not store symlinks): "+path)
+		}
+		kept = append(kept, path)
+	}
+	return kept, nil
+}
+
+// hardlinkTracker remembers the first archived entry name of each file with
+// more than one link, so later links to it can be detected.
+type hardlinkTracker struct {
+	enabled bool
+	first   map[fileID]string
+}
+
+func newHardlinkTracker(enabled bool) *hardlinkTracker {
+	return &hardlinkTracker{enabled: enabled, first: make(map[fileID]string)}
+}
+
+// previous returns the entry name already archived for the file described by
+// info, or records name as its first entry and returns false.
+func (t *hardlinkTracker) previous(info os.FileInfo, name string) (string, bool) {
+	if !t.enabled || !info.Mode().IsRegular() {
+		return "", false
+	}
+	id, nlink, ok := fileIdentity(info)
+	if !ok || nlink < 2 {
+		return "", false
+	}
+	if first, seen := t.first[id]; seen {
+		return first, true
+	}
+	t.first[id] = name
+	return "", false
+}
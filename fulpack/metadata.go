@@ -0,0 +1,264 @@
+package fulpack
+
+import (
+	"archive/tar"
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// paxXattrPrefix prefixes extended attribute PAX records, as written by GNU tar and star.
+const paxXattrPrefix = "SCHILY.xattr."
+
+// defaultXattrIncludes restores only unprivileged user attributes.
+var defaultXattrIncludes = []string{"user.*"}
+
+// privilegedXattrProbes are sample attribute names from the namespaces only
+// the superuser may set; include patterns matching any of them need root.
+var privilegedXattrProbes = []string{
+	"security.capability",
+	"security.selinux",
+	"trusted.overlay.opaque",
+	"system.posix_acl_access",
+	"system.posix_acl_default",
+}
+
+// xattrIncludes returns the effective extended attribute include patterns.
+func xattrIncludes(opts *ExtractOptions) []string {
+	if len(opts.XattrIncludes) == 0 {
+		return defaultXattrIncludes
+	}
+	return opts.XattrIncludes
+}
+
+// includesPrivilegedXattrs reports whether any pattern reaches a privileged
+// namespace.
+func includesPrivilegedXattrs(patterns []string) bool {
+	for _, probe := range privilegedXattrProbes {
+		if xattrIncluded(probe, patterns) {
+			return true
+		}
+	}
+	return false
+}
+
+// xattrIncluded reports whether the attribute name matches any pattern.
+func xattrIncluded(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := doublestar.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// canChangeOwnership reports whether the process may set arbitrary file
+// ownership. Only the superuser may chown to other users.
+func canChangeOwnership() bool {
+	return os.Geteuid() == 0
+}
+
+// metadataCapture records ownership, extended attributes, and precise
+// modification times in tar headers, as enabled in CreateOptions.
+type metadataCapture struct {
+	opts   *CreateOptions
+	users  map[int]string
+	groups map[int]string
+}
+
+func newMetadataCapture(opts *CreateOptions) *metadataCapture {
+	return &metadataCapture{opts: opts, users: make(map[int]string), groups: make(map[int]string)}
+}
+
+// apply adds the enabled metadata for the file at path to header. info
+// describes the archived file (the link target when following symlinks).
+func (m *metadataCapture) apply(header *tar.Header, path string, info os.FileInfo) error {
+	if m.opts.PreciseModTimes {
+		// PAX keeps sub-second mtimes; other formats round to whole seconds
+		header.Format = tar.FormatPAX
+	}
+
+	if m.opts.PreserveOwnership {
+		if uid, gid, ok := fileOwner(info); ok {
+			header.Uid, header.Gid = uid, gid
+			header.Uname = m.userName(uid)
+			header.Gname = m.groupName(gid)
+		}
+	}
+
+	// Extended attributes of symlinks themselves are not captured
+	if m.opts.PreserveXattrs && header.Typeflag != tar.TypeSymlink {
+		xattrs, err := readXattrs(path)
+		if err != nil {
+			return newErrorf(ErrCodeCorruptArchive, OperationCreate, path, err,
+				"failed to read extended attributes: %v", err)
+		}
+		if len(xattrs) > 0 {
+			if header.PAXRecords == nil {
+				header.PAXRecords = make(map[string]string, len(xattrs))
+			}
+			for name, value := range xattrs {
+				header.PAXRecords[paxXattrPrefix+name] = value
+			}
+			header.Format = tar.FormatPAX
+		}
+	}
+	return nil
+}
+
+func (m *metadataCapture) userName(uid int) string {
+	name, ok := m.users[uid]
+	if !ok {
+		if u, err := user.LookupId(strconv.Itoa(uid)); err == nil {
+			name = u.Username
+		}
+		m.users[uid] = name
+	}
+	return name
+}
+
+func (m *metadataCapture) groupName(gid int) string {
+	name, ok := m.groups[gid]
+	if !ok {
+		if g, err := user.LookupGroupId(strconv.Itoa(gid)); err == nil {
+			name = g.Name
+		}
+		m.groups[gid] = name
+	}
+	return name
+}
+
+// metadataWarnings lists the requested metadata that format or platform
+// cannot store.
+func metadataWarnings(format ArchiveFormat, opts *CreateOptions) []string {
+	var warnings []string
+	switch format {
+	case ArchiveFormatZIP, ArchiveFormatGZIP:
+		if opts.PreserveOwnership || opts.PreserveXattrs || opts.PreciseModTimes {
+			warnings = append(warnings, fmt.Sprintf(
+				"ownership, extended attributes, and sub-second mtimes not stored (%s does not support them)", format))
+		}
+		return warnings
+	}
+	if opts.PreserveXattrs && !xattrsSupported {
+		warnings = append(warnings, "extended attributes not captured (unsupported on this platform)")
+	}
+	return warnings
+}
+
+// metadataRestore applies recorded ownership, extended attributes, and
+// modification times to extracted tar entries, as enabled in ExtractOptions.
+type metadataRestore struct {
+	opts          *ExtractOptions
+	xattrIncludes []string
+	users         map[string]int
+	groups        map[string]int
+	dirTimes      []pendingDirTime
+}
+
+// pendingDirTime is a directory mtime applied after extraction, since
+// creating entries inside a directory updates its mtime.
+type pendingDirTime struct {
+	path    string
+	modTime time.Time
+}
+
+func newMetadataRestore(opts *ExtractOptions) *metadataRestore {
+	return &metadataRestore{
+		opts:          opts,
+		xattrIncludes: xattrIncludes(opts),
+		users:         make(map[string]int),
+		groups:        make(map[string]int),
+	}
+}
+
+// apply restores the enabled metadata of header onto targetPath.
+func (m *metadataRestore) apply(targetPath string, header *tar.Header) error {
+	if m.opts.PreserveXattrs && header.Typeflag != tar.TypeSymlink {
+		for key, value := range header.PAXRecords {
+			name, ok := strings.CutPrefix(key, paxXattrPrefix)
+			if !ok || !xattrIncluded(name, m.xattrIncludes) {
+				continue
+			}
+			if err := writeXattr(targetPath, name, value); err != nil {
+				return fmt.Errorf("failed to set extended attribute %s: %v", name, err)
+			}
+		}
+	}
+
+	if m.opts.PreserveOwnership {
+		uid := m.lookupUser(header.Uname, header.Uid)
+		gid := m.lookupGroup(header.Gname, header.Gid)
+		if err := os.Lchown(targetPath, uid, gid); err != nil {
+			return fmt.Errorf("failed to set ownership: %v", err)
+		}
+	}
+
+	if m.opts.PreserveModTimes && !header.ModTime.IsZero() {
+		switch header.Typeflag {
+		case tar.TypeDir:
+			m.dirTimes = append(m.dirTimes, pendingDirTime{path: targetPath, modTime: header.ModTime})
+		case tar.TypeReg:
+			if err := os.Chtimes(targetPath, time.Time{}, header.ModTime); err != nil {
+				return fmt.Errorf("failed to set modification time: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// finish sets directory mtimes, innermost first.
+func (m *metadataRestore) finish() {
+	for i := len(m.dirTimes) - 1; i >= 0; i-- {
+		_ = os.Chtimes(m.dirTimes[i].path, time.Time{}, m.dirTimes[i].modTime)
+	}
+	m.dirTimes = nil
+}
+
+// lookupUser resolves a recorded user name on this host, falling back to the
+// numeric id when the name is empty or unknown (as GNU tar does).
+func (m *metadataRestore) lookupUser(name string, id int) int {
+	if name == "" {
+		return id
+	}
+	uid, ok := m.users[name]
+	if !ok {
+		uid = -1 // Unknown on this host
+		if u, err := user.Lookup(name); err == nil {
+			if n, convErr := strconv.Atoi(u.Uid); convErr == nil {
+				uid = n
+			}
+		}
+		m.users[name] = uid
+	}
+	if uid < 0 {
+		return id
+	}
+	return uid
+}
+
+// lookupGroup resolves a recorded group name like lookupUser.
+func (m *metadataRestore) lookupGroup(name string, id int) int {
+	if name == "" {
+		return id
+	}
+	gid, ok := m.groups[name]
+	if !ok {
+		gid = -1 // Unknown on this host
+		if g, err := user.LookupGroup(name); err == nil {
+			if n, convErr := strconv.Atoi(g.Gid); convErr == nil {
+				gid = n
+			}
+		}
+		m.groups[name] = gid
+	}
+	if gid < 0 {
+		return id
+	}
+	return gid
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package fulpack
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileOwner returns the numeric owner and group of a file.
+func fileOwner(info os.FileInfo) (int, int, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return int(st.Uid), int(st.Gid), true
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package fulpack
+
+import "os"
+
+// fileOwner is unavailable on this platform; ownership is not captured.
+func fileOwner(info os.FileInfo) (int, int, bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,127 @@
+package fulpack
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/fulhash"
+	"github.com/fulmenhq/gofulmen/pathfinder"
+)
+
+// scanAsPathResultsImpl implements the ScanAsPathResults operation.
+func scanAsPathResultsImpl(archive string, options *PathResultOptions) ([]pathfinder.PathResult, error) {
+	options = applyPathResultDefaults(options)
+
+	var alg fulhash.Algorithm
+	if options.CalculateChecksums {
+		var err error
+		if alg, err = entryChecksumAlgorithm(options.ChecksumAlgorithm, OperationScan, archive); err != nil {
+			return nil, err
+		}
+	}
+
+	// Copy scan options so the caller's struct is not modified by defaults
+	scanOpts := ScanOptions{}
+	if options.Scan != nil {
+		scanOpts = *options.Scan
+	}
+	if len(scanOpts.EntryTypes) == 0 {
+		scanOpts.EntryTypes = []EntryType{EntryTypeFile}
+	}
+	scanOpts.IncludeMetadata = boolPtr(true)
+
+	entries, err := Scan(archive, &scanOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	absArchive, err := filepath.Abs(archive)
+	if err != nil {
+		return nil, newError(ErrCodeInvalidFormat, "failed to resolve archive path", OperationScan, archive, err)
+	}
+	format := detectFormat(archive)
+
+	var checksums map[string]fulhash.Digest
+	if options.CalculateChecksums {
+		if checksums, err = checksumEntries(archive, entries, alg, options.MaxSize); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]pathfinder.PathResult, 0, len(entries))
+	for _, entry := range entries {
+		metadata := map[string]any{
+			"archive":       absArchive,
+			"archiveFormat": string(format),
+			"entryType":     string(entry.Type),
+			"mtime":         entry.Modified.Format("2006-01-02T15:04:05.000000000Z07:00"), // RFC3339Nano, as pathfinder
+		}
+		if entry.Size >= 0 {
+			metadata["size"] = entry.Size
+		}
+		if entry.Mode != 0 {
+			metadata["mode"] = entry.Mode
+		}
+		if entry.LinkTarget != "" {
+			metadata["linkTarget"] = entry.LinkTarget
+		}
+		if digest, ok := checksums[entry.Path]; ok {
+			metadata["checksum"] = digest.String()
+			metadata["checksumAlgorithm"] = string(digest.Algorithm())
+		}
+
+		results = append(results, pathfinder.PathResult{
+			RelativePath: entry.Path,
+			SourcePath:   absArchive,
+			LogicalPath:  entry.Path,
+			LoaderType:   pathfinder.LoaderTypeArchive,
+			Metadata:     metadata,
+		})
+	}
+	return results, nil
+}
+
+// checksumEntries streams archive once and hashes the file entries listed in
+// entries, keyed by entry path. The total bytes hashed are bounded by maxSize.
+func checksumEntries(archive string, entries []ArchiveEntry, alg fulhash.Algorithm, maxSize foundry.ByteSize) (map[string]fulhash.Digest, error) {
+	wanted := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if entry.Type == EntryTypeFile {
+			wanted[entry.Path] = true
+		}
+	}
+
+	checksums := make(map[string]fulhash.Digest, len(wanted))
+	if len(wanted) == 0 {
+		return checksums, nil
+	}
+	var totalSize int64
+	err := walkArchive(archive, OperationScan, func(entry ArchiveEntry, r io.Reader) error {
+		if r == nil || !wanted[entry.Path] {
+			return nil
+		}
+		hasher, err := fulhash.NewHasher(fulhash.WithAlgorithm(alg))
+		if err != nil {
+			return newErrorf(ErrCodeUnsupportedChecksumAlgorithm, OperationScan, archive, err,
+				"failed to create hasher: %v", err)
+		}
+		// Security: Bound total decompressed bytes across all hashed entries
+		n, err := io.Copy(hasher, &io.LimitedReader{R: r, N: maxSize.Bytes() - totalSize + 1})
+		if err != nil {
+			return newErrorf(ErrCodeCorruptArchive, OperationScan, entry.Path, err,
+				"failed to checksum entry: %v", err)
+		}
+		totalSize += n
+		if totalSize > maxSize.Bytes() {
+			return newErrorf(ErrCodeMaxSizeExceeded, OperationScan, archive, nil,
+				"total uncompressed size exceeds limit of %d bytes", maxSize)
+		}
+		checksums[entry.Path] = hasher.Sum()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return checksums, nil
+}
@@ -0,0 +1,148 @@
+package fulpack
+
+import (
+	"io"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/ascii"
+)
+
+// progressInterval throttles in-entry progress events for large files.
+const progressInterval = 100 * time.Millisecond
+
+// ProgressEvent reports the progress of a Create or Extract operation.
+type ProgressEvent struct {
+	// Operation is the running operation (create or extract).
+	Operation Operation
+
+	// Entry is the entry currently being processed.
+	Entry string
+
+	// BytesProcessed and TotalBytes measure overall progress. For Create they
+	// count source file bytes; for Extract they count archive bytes consumed
+	// (uncompressed entry bytes for zip). TotalBytes is 0 when unknown.
+	BytesProcessed int64
+	TotalBytes     int64
+
+	// EntriesProcessed is the number of completed entries.
+	EntriesProcessed int
+
+	// Elapsed is the time since the operation started.
+	Elapsed time.Duration
+
+	// ETA estimates the remaining time from the average rate (0 when unknown).
+	ETA time.Duration
+
+	// Done is set on the final event of a successful operation.
+	Done bool
+}
+
+// ProgressFunc receives progress events. It is called synchronously on the
+// operation's goroutine and should return quickly.
+type ProgressFunc func(event ProgressEvent)
+
+// ProgressBarReporter returns a ProgressFunc that drives an ascii progress bar
+// (bytes processed, current entry, ETA), finishing the bar when the operation completes.
+//
+// Example:
+//
+//	bar := ascii.NewProgressBar(os.Stderr, &ascii.ProgressBarOptions{Bytes: true})
+//	info, err := fulpack.Create(sources, "backup.tar.gz", fulpack.ArchiveFormatTARGZ,
+//	    &fulpack.CreateOptions{Progress: fulpack.ProgressBarReporter(bar)})
+func ProgressBarReporter(bar *ascii.ProgressBar) ProgressFunc {
+	return func(event ProgressEvent) {
+		bar.Update(event.BytesProcessed, event.TotalBytes, event.Entry)
+		if event.Done {
+			bar.Finish()
+		}
+	}
+}
+
+// progressTracker accumulates progress and emits throttled events.
+// A nil tracker is valid and does nothing.
+type progressTracker struct {
+	fn        ProgressFunc
+	op        Operation
+	start     time.Time
+	lastEmit  time.Time
+	total     int64
+	processed int64
+	entries   int
+	entry     string
+}
+
+// newProgressTracker returns a tracker, or nil when fn is nil.
+func newProgressTracker(fn ProgressFunc, op Operation, total int64) *progressTracker {
+	if fn == nil {
+		return nil
+	}
+	return &progressTracker{fn: fn, op: op, start: time.Now(), total: total}
+}
+
+// reader wraps r so bytes read through it count toward progress.
+func (p *progressTracker) reader(r io.Reader) io.Reader {
+	if p == nil {
+		return r
+	}
+	return &progressReader{r: r, p: p}
+}
+
+// setEntry records the entry currently being processed.
+func (p *progressTracker) setEntry(entry string) {
+	if p != nil {
+		p.entry = entry
+	}
+}
+
+// entryDone counts a completed entry and emits an event.
+func (p *progressTracker) entryDone() {
+	if p == nil {
+		return
+	}
+	p.entries++
+	p.emit(false)
+}
+
+// finish emits the final event.
+func (p *progressTracker) finish() {
+	if p == nil {
+		return
+	}
+	if p.total > 0 {
+		p.processed = p.total
+	}
+	p.emit(true)
+}
+
+func (p *progressTracker) emit(done bool) {
+	now := time.Now()
+	p.lastEmit = now
+	event := ProgressEvent{
+		Operation:        p.op,
+		Entry:            p.entry,
+		BytesProcessed:   p.processed,
+		TotalBytes:       p.total,
+		EntriesProcessed: p.entries,
+		Elapsed:          now.Sub(p.start),
+		Done:             done,
+	}
+	if p.total > 0 && p.processed > 0 && p.processed < p.total {
+		event.ETA = time.Duration(float64(event.Elapsed) * float64(p.total-p.processed) / float64(p.processed))
+	}
+	p.fn(event)
+}
+
+// progressReader reports bytes read, emitting at most every progressInterval.
+type progressReader struct {
+	r io.Reader
+	p *progressTracker
+}
+
+func (pr *progressReader) Read(b []byte) (int, error) {
+	n, err := pr.r.Read(b)
+	pr.p.processed += int64(n)
+	if n > 0 && time.Since(pr.p.lastEmit) >= progressInterval {
+		pr.p.emit(false)
+	}
+	return n, err
+}
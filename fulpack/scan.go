@@ -195,6 +195,10 @@ func scanGzip(path string, opts *ScanOptions) ([]ArchiveEntry, error) {
 			entry.CompressedSize = fileInfo.Size()
 			entry.Modified = fileInfo.ModTime()
 		}
+		// Prefer the source file's time recorded in the header
+		if !gr.ModTime.IsZero() {
+			entry.Modified = gr.ModTime
+		}
 	}
 
 	return []ArchiveEntry{entry}, nil
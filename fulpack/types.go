@@ -1,6 +1,12 @@
 package fulpack
 
-import "time"
+import (
+	"io/fs"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/fulhash"
+)
 
 // ArchiveFormat represents supported archive format identifiers.
 // Generated from: schemas/crucible-go/taxonomy/library/fulpack/archive-formats/v1.0.0/formats.yaml
@@ -59,6 +65,14 @@ const (
 
 	// OperationInfo represents getting archive metadata.
 	OperationInfo Operation = "info"
+
+	// OperationConvert represents repacking an archive into another format.
+	// gofulmen extension; not yet part of the Crucible operations taxonomy.
+	OperationConvert Operation = "convert"
+
+	// OperationDiff represents comparing the contents of two archives.
+	// gofulmen extension; not yet part of the Crucible operations taxonomy.
+	OperationDiff Operation = "diff"
 )
 
 // OverwritePolicy defines behavior when extracting over existing files.
@@ -75,6 +89,30 @@ const (
 	OverwritePolicyOverwrite OverwritePolicy = "overwrite"
 )
 
+// LinkPolicy defines how Create handles symbolic links found in sources.
+//
+// Format behavior for LinkPolicyPreserve:
+//   - tar, tar.gz: symlinks are stored as link entries
+//   - zip: symlinks are skipped (zip has no portable symlink entry)
+//   - gzip: the link target's content is compressed (gzip holds one regular file)
+//
+// Each zip and gzip fallback is reported in ArchiveInfo.Warnings.
+type LinkPolicy string
+
+const (
+	// LinkPolicyPreserve stores symlinks as links where the format supports it (default).
+	LinkPolicyPreserve LinkPolicy = "preserve"
+
+	// LinkPolicyFollow stores the content of link targets and descends into linked directories.
+	LinkPolicyFollow LinkPolicy = "follow"
+
+	// LinkPolicySkip leaves symlinks out of the archive.
+	LinkPolicySkip LinkPolicy = "skip"
+
+	// LinkPolicyError fails creation when a source contains a symlink.
+	LinkPolicyError LinkPolicy = "error"
+)
+
 // CreateOptions configures archive creation behavior.
 type CreateOptions struct {
 	// CompressionLevel specifies compression level (1-9, default: 6).
@@ -95,10 +133,52 @@ type CreateOptions struct {
 	PreservePermissions *bool `json:"preserve_permissions,omitempty"`
 
 	// FollowSymlinks follows symbolic links (default: false).
+	// Equivalent to LinkPolicyFollow; ignored when LinkPolicy is set.
 	FollowSymlinks bool `json:"follow_symlinks,omitempty"`
+
+	// LinkPolicy controls symlink handling (default: "preserve", or "follow"
+	// when FollowSymlinks is true). See LinkPolicy for per-format behavior.
+	LinkPolicy LinkPolicy `json:"link_policy,omitempty"`
+
+	// DetectHardlinks stores files that are hardlinks of an already archived
+	// file as tar hardlink entries instead of duplicating their content
+	// (default: false). zip and gzip have no hardlink entries and store each
+	// copy in full, with a warning. Unavailable on Windows.
+	DetectHardlinks bool `json:"detect_hardlinks,omitempty"`
+
+	// PreserveOwnership records each entry's numeric uid/gid and user/group
+	// names (default: false, entries are owned by 0:0). tar formats only.
+	PreserveOwnership bool `json:"preserve_ownership,omitempty"`
+
+	// PreserveXattrs records extended attributes as PAX "SCHILY.xattr."
+	// records, as GNU tar does (default: false). tar formats on Linux only.
+	PreserveXattrs bool `json:"preserve_xattrs,omitempty"`
+
+	// PreciseModTimes writes PAX headers so mtimes keep sub-second
+	// resolution (default: false, rounded to seconds). tar formats only.
+	PreciseModTimes bool `json:"precise_mod_times,omitempty"`
+
+	// MaxFileSize skips files larger than this many bytes (default: 0, no limit).
+	MaxFileSize foundry.ByteSize `json:"max_file_size,omitempty"`
+
+	// ModifiedAfter skips files last modified before this time (default: zero, no bound).
+	ModifiedAfter time.Time `json:"modified_after,omitzero"`
+
+	// ModifiedBefore skips files last modified at or after this time (default: zero, no bound).
+	ModifiedBefore time.Time `json:"modified_before,omitzero"`
+
+	// Filter is an optional predicate applied after patterns and the size/time
+	// filters. Files for which it returns false are skipped. The path is the
+	// source filesystem path.
+	Filter func(path string, info fs.FileInfo) bool `json:"-"`
+
+	// Progress receives progress events (source bytes read, current entry, ETA).
+	// See ProgressBarReporter for a ready-made terminal progress bar.
+	Progress ProgressFunc `json:"-"`
 }
 
-// ExtractOptions configures archive extraction behavior.
+// ExtractOptions configures archive extraction behavior. Fields beyond the
+// Crucible extract-options schema are described by ExtractOptionsExtSchema.
 type ExtractOptions struct {
 	// Overwrite specifies overwrite policy for existing files (default: "error").
 	Overwrite OverwritePolicy `json:"overwrite,omitempty"`
@@ -116,10 +196,83 @@ type ExtractOptions struct {
 	ExcludePatterns []string `json:"exclude_patterns,omitempty"`
 
 	// MaxSize specifies maximum total uncompressed size in bytes (default: 1GB, bomb protection).
-	MaxSize int64 `json:"max_size,omitempty"`
+	MaxSize foundry.ByteSize `json:"max_size,omitempty"`
 
 	// MaxEntries specifies maximum number of entries (default: 10000, bomb protection).
 	MaxEntries int `json:"max_entries,omitempty"`
+
+	// Salvage extracts every complete entry of a truncated or corrupt archive and
+	// stops at the damage instead of failing (default: false). The damaged entry is
+	// recorded in ExtractResult.Errors with CORRUPT_ARCHIVE and its partial output removed
+	// (gzip keeps the partial payload, since it is the only entry).
+	Salvage bool `json:"salvage,omitempty"`
+
+	// MaxEntrySize limits the uncompressed size of any single entry in bytes
+	// (default: 0, no per-entry limit). Oversized entries are rejected, not fatal.
+	MaxEntrySize foundry.ByteSize `json:"max_entry_size,omitempty"`
+
+	// MaxEntryNameLength limits entry path length in bytes (default: 0, no limit).
+	MaxEntryNameLength int `json:"max_entry_name_length,omitempty"`
+
+	// RejectAbsoluteSymlinks rejects symlinks with absolute targets, even when the
+	// target resolves within the destination (default: false).
+	RejectAbsoluteSymlinks bool `json:"reject_absolute_symlinks,omitempty"`
+
+	// RejectSpecialEntries rejects device, FIFO, and socket entries with
+	// UNSAFE_ENTRY_TYPE instead of silently skipping them (default: false).
+	RejectSpecialEntries bool `json:"reject_special_entries,omitempty"`
+
+	// PreserveOwnership restores recorded ownership, resolving user and group
+	// names on this host before falling back to numeric ids (default: false).
+	// Requires superuser privileges; Extract fails with INSUFFICIENT_PRIVILEGES
+	// otherwise. tar formats only.
+	PreserveOwnership bool `json:"preserve_ownership,omitempty"`
+
+	// PreserveXattrs restores recorded extended attributes (default: false).
+	// tar formats on Linux only. Only attributes matching XattrIncludes are set.
+	PreserveXattrs bool `json:"preserve_xattrs,omitempty"`
+
+	// XattrIncludes specifies glob patterns of extended attribute names to
+	// restore, like GNU tar --xattrs-include (default: ["user.*"]). Patterns
+	// reaching the privileged security.*, trusted.*, or system.* namespaces
+	// require superuser privileges; Extract fails with INSUFFICIENT_PRIVILEGES
+	// otherwise.
+	XattrIncludes []string `json:"xattr_includes,omitempty"`
+
+	// PreserveModTimes restores file and directory modification times
+	// (default: false, extracted entries get the current time). tar formats only.
+	PreserveModTimes bool `json:"preserve_mod_times,omitempty"`
+
+	// Progress receives progress events (archive bytes consumed, current entry, ETA).
+	// See ProgressBarReporter for a ready-made terminal progress bar.
+	Progress ProgressFunc `json:"-"`
+}
+
+// ExtractEntryOptions configures single-entry extraction behavior.
+type ExtractEntryOptions struct {
+	// MaxSize specifies maximum uncompressed entry size in bytes (default: 1GB, bomb protection).
+	MaxSize foundry.ByteSize `json:"max_size,omitempty"`
+}
+
+// ConvertOptions configures archive conversion behavior.
+type ConvertOptions struct {
+	// CompressionLevel specifies compression level for the target (1-9, default: 6).
+	CompressionLevel int `json:"compression_level,omitempty"`
+
+	// IncludePatterns specifies glob patterns of source entries to convert (e.g., ["**/*.yaml"]).
+	IncludePatterns []string `json:"include_patterns,omitempty"`
+
+	// ExcludePatterns specifies glob patterns of source entries to drop (e.g., ["**/.git/**"]).
+	ExcludePatterns []string `json:"exclude_patterns,omitempty"`
+
+	// PreservePermissions carries entry permissions into the target (default: true).
+	PreservePermissions *bool `json:"preserve_permissions,omitempty"`
+
+	// MaxSize specifies maximum total uncompressed size in bytes (default: 1GB, bomb protection).
+	MaxSize foundry.ByteSize `json:"max_size,omitempty"`
+
+	// MaxEntries specifies maximum number of source entries (default: 10000, bomb protection).
+	MaxEntries int `json:"max_entries,omitempty"`
 }
 
 // ScanOptions configures archive scanning behavior.
@@ -145,9 +298,59 @@ type ScanOptions struct {
 	ExcludePatterns []string `json:"exclude_patterns,omitempty"`
 }
 
+// PathResultOptions configures ScanAsPathResults.
+type PathResultOptions struct {
+	// Scan filters the entries converted to PathResults (nil uses defaults).
+	// EntryTypes defaults to files only, matching pathfinder discovery.
+	Scan *ScanOptions `json:"scan,omitempty"`
+
+	// CalculateChecksums hashes each file entry's content into
+	// Metadata["checksum"] and Metadata["checksumAlgorithm"].
+	CalculateChecksums bool `json:"calculate_checksums,omitempty"`
+
+	// ChecksumAlgorithm is "xxh3-128" (default) or "sha256", as in pathfinder.FindQuery.
+	ChecksumAlgorithm string `json:"checksum_algorithm,omitempty"`
+
+	// MaxSize specifies maximum total uncompressed size hashed by
+	// CalculateChecksums in bytes (default: 1GB, bomb protection).
+	MaxSize foundry.ByteSize `json:"max_size,omitempty"`
+}
+
+// DiffOptions configures archive comparison behavior.
+type DiffOptions struct {
+	// IncludePatterns specifies glob patterns of entries to compare (e.g., ["**/*.go"]).
+	IncludePatterns []string `json:"include_patterns,omitempty"`
+
+	// ExcludePatterns specifies glob patterns of entries to ignore (e.g., ["**/.DS_Store"]).
+	ExcludePatterns []string `json:"exclude_patterns,omitempty"`
+
+	// CompareModes reports permission changes (default: false).
+	CompareModes bool `json:"compare_modes,omitempty"`
+
+	// CompareModTimes reports modification time changes (default: false).
+	// Rebuilt archives usually differ in mtimes only, so this is opt-in.
+	CompareModTimes bool `json:"compare_mod_times,omitempty"`
+
+	// ChecksumAlgorithm is the content digest algorithm: "xxh3-128" (default) or "sha256".
+	ChecksumAlgorithm string `json:"checksum_algorithm,omitempty"`
+
+	// MaxSize specifies maximum total uncompressed size per archive in bytes (default: 1GB, bomb protection).
+	MaxSize foundry.ByteSize `json:"max_size,omitempty"`
+
+	// MaxEntries specifies maximum number of entries per archive (default: 10000, bomb protection).
+	MaxEntries int `json:"max_entries,omitempty"`
+}
+
 // VerifyOptions configures archive verification behavior.
 type VerifyOptions struct {
-	// Future use - placeholder for verification configuration.
+	// ChecksumFile is a detached checksum file published alongside the archive
+	// (e.g., "release.tar.gz.sha256"). sha256sum-style, bare hex, and fulhash
+	// "algorithm:hex" lines are accepted.
+	ChecksumFile string `json:"checksum_file,omitempty"`
+
+	// ExpectedDigest is the digest the archive must match. Takes precedence
+	// over ChecksumFile when set.
+	ExpectedDigest fulhash.Digest `json:"-"`
 }
 
 // ArchiveInfo contains archive metadata and statistics.
@@ -182,6 +385,9 @@ type ArchiveInfo struct {
 
 	// Checksums maps checksum algorithm to digest value.
 	Checksums map[string]string `json:"checksums,omitempty"`
+
+	// Warnings contains non-fatal notes from Create (e.g., symlinks skipped in zip).
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // ArchiveEntry represents a single entry within an archive.
@@ -211,7 +417,8 @@ type ArchiveEntry struct {
 	LinkTarget string `json:"link_target,omitempty"`
 }
 
-// ExtractResult contains extraction operation results.
+// ExtractResult contains extraction operation results. Fields beyond the
+// Crucible extract-result schema are described by ExtractResultExtSchema.
 type ExtractResult struct {
 	// ExtractedCount is the number of successfully extracted entries.
 	ExtractedCount int `json:"extracted_count"`
@@ -227,6 +434,94 @@ type ExtractResult struct {
 
 	// BytesWritten is the total bytes written to disk.
 	BytesWritten int64 `json:"bytes_written"`
+
+	// Rejected is the audit log of entries refused by security policy (path
+	// traversal, symlink escape, and the ExtractOptions hardening limits).
+	// Rejected entries are also counted in ErrorCount and listed in Errors.
+	Rejected []ExtractionError `json:"rejected,omitempty"`
+}
+
+// ConvertResult contains archive conversion results.
+type ConvertResult struct {
+	// Info describes the written target archive.
+	Info *ArchiveInfo `json:"info"`
+
+	// ConvertedCount is the number of entries written to the target archive.
+	ConvertedCount int `json:"converted_count"`
+
+	// SkippedCount is the number of entries filtered out by include/exclude patterns.
+	SkippedCount int `json:"skipped_count"`
+
+	// Unrepresentable lists source entries that could not be stored in the target
+	// format (e.g., symlinks in zip) or were rejected for safety reasons.
+	Unrepresentable []ExtractionError `json:"unrepresentable,omitempty"`
+}
+
+// DiffChange classifies an entry difference between two archives.
+type DiffChange string
+
+const (
+	// DiffAdded marks an entry present only in the second archive.
+	DiffAdded DiffChange = "added"
+
+	// DiffRemoved marks an entry present only in the first archive.
+	DiffRemoved DiffChange = "removed"
+
+	// DiffModified marks an entry present in both archives with different attributes.
+	DiffModified DiffChange = "modified"
+)
+
+// Reasons reported for DiffModified entries.
+const (
+	DiffReasonType       = "type"        // Entry type changed (e.g., file to symlink)
+	DiffReasonSize       = "size"        // Uncompressed size changed
+	DiffReasonContent    = "content"     // Content digest changed
+	DiffReasonLinkTarget = "link_target" // Symlink target changed
+	DiffReasonMode       = "mode"        // Permissions changed (CompareModes)
+	DiffReasonModTime    = "mtime"       // Modification time changed (CompareModTimes)
+)
+
+// DiffEntry describes one entry that differs between two archives.
+type DiffEntry struct {
+	// Path is the normalized entry path.
+	Path string `json:"path"`
+
+	// Change is the kind of difference.
+	Change DiffChange `json:"change"`
+
+	// Reasons lists what changed for DiffModified entries (DiffReason* values).
+	Reasons []string `json:"reasons,omitempty"`
+
+	// Old is the entry in the first archive (nil for DiffAdded).
+	// Checksum holds the content digest for files.
+	Old *ArchiveEntry `json:"old,omitempty"`
+
+	// New is the entry in the second archive (nil for DiffRemoved).
+	New *ArchiveEntry `json:"new,omitempty"`
+}
+
+// DiffResult contains the outcome of comparing two archives.
+type DiffResult struct {
+	// ArchiveA is the first (old) archive path.
+	ArchiveA string `json:"archive_a"`
+
+	// ArchiveB is the second (new) archive path.
+	ArchiveB string `json:"archive_b"`
+
+	// AddedCount is the number of entries only in ArchiveB.
+	AddedCount int `json:"added_count"`
+
+	// RemovedCount is the number of entries only in ArchiveA.
+	RemovedCount int `json:"removed_count"`
+
+	// ModifiedCount is the number of entries that differ.
+	ModifiedCount int `json:"modified_count"`
+
+	// UnchangedCount is the number of entries that are identical.
+	UnchangedCount int `json:"unchanged_count"`
+
+	// Changes lists differing entries sorted by path.
+	Changes []DiffEntry `json:"changes"`
 }
 
 // ExtractionError represents an error during extraction of a specific entry.
@@ -260,6 +555,26 @@ type ValidationResult struct {
 
 	// ChecksPerformed lists the checks that were performed.
 	ChecksPerformed []string `json:"checks_performed"`
+
+	// Truncation describes the readable prefix of a damaged archive (nil if structure is intact).
+	Truncation *TruncationReport `json:"truncation,omitempty"`
+}
+
+// TruncationReport describes where a truncated or corrupt archive stops being readable.
+type TruncationReport struct {
+	// LastValidEntry is the path of the last entry whose header and content read completely.
+	LastValidEntry string `json:"last_valid_entry,omitempty"`
+
+	// LastValidOffset is the byte offset just past the last valid entry.
+	// For tar.gz this is an offset in the decompressed tar stream; for gzip it is
+	// the number of payload bytes that decompress successfully.
+	LastValidOffset int64 `json:"last_valid_offset"`
+
+	// ReadableEntries is the number of complete entries before the damage.
+	ReadableEntries int `json:"readable_entries"`
+
+	// Suggestion is a human-readable repair or salvage hint.
+	Suggestion string `json:"suggestion"`
 }
 
 // ValidationError represents a validation error.
@@ -3,6 +3,8 @@ package fulpack
 import (
 	"path/filepath"
 	"strings"
+
+	"github.com/fulmenhq/gofulmen/fulhash"
 )
 
 // Default values for options.
@@ -48,6 +50,14 @@ func applyCreateDefaults(opts *CreateOptions) *CreateOptions {
 	if opts.PreservePermissions == nil {
 		opts.PreservePermissions = boolPtr(DefaultPreservePermissions)
 	}
+	if opts.LinkPolicy == "" {
+		opts.LinkPolicy = LinkPolicyPreserve
+		if opts.FollowSymlinks {
+			opts.LinkPolicy = LinkPolicyFollow
+		}
+	}
+	// Discovery and writers follow links only under LinkPolicyFollow
+	opts.FollowSymlinks = opts.LinkPolicy == LinkPolicyFollow
 	return opts
 }
 
@@ -76,6 +86,62 @@ func applyExtractDefaults(opts *ExtractOptions) *ExtractOptions {
 	return opts
 }
 
+// applyExtractEntryDefaults applies default values to ExtractEntryOptions.
+func applyExtractEntryDefaults(opts *ExtractEntryOptions) *ExtractEntryOptions {
+	if opts == nil {
+		opts = &ExtractEntryOptions{}
+	}
+	if opts.MaxSize == 0 {
+		opts.MaxSize = DefaultMaxSizeBytes
+	}
+	return opts
+}
+
+// applyConvertDefaults applies default values to ConvertOptions.
+func applyConvertDefaults(opts *ConvertOptions) *ConvertOptions {
+	if opts == nil {
+		opts = &ConvertOptions{}
+	}
+	if opts.CompressionLevel == 0 {
+		opts.CompressionLevel = DefaultCompressionLevel
+	}
+	if opts.PreservePermissions == nil {
+		opts.PreservePermissions = boolPtr(DefaultPreservePermissions)
+	}
+	if opts.MaxSize == 0 {
+		opts.MaxSize = DefaultMaxSizeBytes
+	}
+	if opts.MaxEntries == 0 {
+		opts.MaxEntries = DefaultMaxEntries
+	}
+	return opts
+}
+
+// applyDiffDefaults applies default values to DiffOptions.
+func applyDiffDefaults(opts *DiffOptions) *DiffOptions {
+	if opts == nil {
+		opts = &DiffOptions{}
+	}
+	if opts.MaxSize == 0 {
+		opts.MaxSize = DefaultMaxSizeBytes
+	}
+	if opts.MaxEntries == 0 {
+		opts.MaxEntries = DefaultMaxEntries
+	}
+	return opts
+}
+
+// applyPathResultDefaults applies default values to PathResultOptions.
+func applyPathResultDefaults(opts *PathResultOptions) *PathResultOptions {
+	if opts == nil {
+		opts = &PathResultOptions{}
+	}
+	if opts.MaxSize == 0 {
+		opts.MaxSize = DefaultMaxSizeBytes
+	}
+	return opts
+}
+
 // applyScanDefaults applies default values to ScanOptions.
 func applyScanDefaults(opts *ScanOptions) *ScanOptions {
 	if opts == nil {
@@ -90,6 +156,20 @@ func applyScanDefaults(opts *ScanOptions) *ScanOptions {
 	return opts
 }
 
+// entryChecksumAlgorithm resolves the algorithm for hashing entry content
+// ("" means xxh3-128, as in pathfinder).
+func entryChecksumAlgorithm(name string, op Operation, archive string) (fulhash.Algorithm, error) {
+	switch name {
+	case "", "xxh3-128":
+		return fulhash.XXH3_128, nil
+	case "sha256":
+		return fulhash.SHA256, nil
+	default:
+		return "", newErrorf(ErrCodeUnsupportedChecksumAlgorithm, op, archive, nil,
+			"unsupported checksum algorithm %q (use xxh3-128 or sha256)", name)
+	}
+}
+
 // detectFormat detects archive format from file extension.
 func detectFormat(path string) ArchiveFormat {
 	lower := strings.ToLower(path)
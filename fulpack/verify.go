@@ -1,6 +1,11 @@
 package fulpack
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
 	"time"
 )
 
@@ -32,6 +37,12 @@ func verifyImpl(archive string, options *VerifyOptions) (*ValidationResult, erro
 		},
 	}
 
+	// Step 0: Compare against the published digest (before the scan, so corrupt
+	// archives still report a mismatch)
+	if err = verifyDetachedDigest(archive, options, result); err != nil {
+		return nil, err
+	}
+
 	// Step 1: Verify archive structure by scanning entries
 	entries, scanErr := scanImpl(archive, nil)
 	if scanErr != nil {
@@ -41,6 +52,11 @@ func verifyImpl(archive string, options *VerifyOptions) (*ValidationResult, erro
 			Message: "Failed to scan archive structure",
 			Details: map[string]any{"error": scanErr.Error()},
 		})
+		// Triage damaged archives: locate the last readable entry for salvage
+		if report := probeTruncation(archive); report != nil {
+			result.Truncation = report
+			result.ChecksPerformed = append(result.ChecksPerformed, "truncation_probe")
+		}
 		// Return result, not error - Verify() should return validation results
 		return result, nil
 	}
@@ -111,3 +127,120 @@ func verifyImpl(archive string, options *VerifyOptions) (*ValidationResult, erro
 
 	return result, nil
 }
+
+// tarBlockSize is the tar record alignment used to compute salvage offsets.
+const tarBlockSize = 512
+
+// countingReader counts bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// probeTruncation reads a damaged archive entry-by-entry (including content)
+// to find where it stops being readable. Returns nil if no report applies.
+func probeTruncation(archive string) *TruncationReport {
+	switch detectFormat(archive) {
+	case ArchiveFormatTAR:
+		return probeTarTruncation(archive, false)
+	case ArchiveFormatTARGZ:
+		return probeTarTruncation(archive, true)
+	case ArchiveFormatGZIP:
+		return probeGzipTruncation(archive)
+	case ArchiveFormatZIP:
+		// Truncated zips lose the central directory, which archive/zip requires
+		return &TruncationReport{
+			Suggestion: "zip central directory is unreadable; entries cannot be salvaged by fulpack " +
+				"(try `zip -FF` to rebuild the directory, or re-download the artifact)",
+		}
+	default:
+		return nil
+	}
+}
+
+// probeTarTruncation walks tar entries and records the last fully readable one.
+func probeTarTruncation(archive string, compressed bool) *TruncationReport {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+
+	var r io.Reader = f
+	if compressed {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return &TruncationReport{
+				Suggestion: "gzip header is unreadable; no entries can be salvaged",
+			}
+		}
+		defer func() { _ = gr.Close() }()
+		r = gr
+	}
+
+	// Hide io.Seeker so entry content is actually read and validated
+	counter := &countingReader{r: r}
+	tr := tar.NewReader(counter)
+	report := &TruncationReport{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			// Archive reads cleanly end-to-end; damage is elsewhere
+			return nil
+		}
+		if err != nil {
+			break
+		}
+		if _, err := io.Copy(io.Discard, tr); err != nil {
+			break
+		}
+		report.LastValidEntry = header.Name
+		report.ReadableEntries++
+		// Entry data is padded to the next block boundary
+		report.LastValidOffset = (counter.n + tarBlockSize - 1) / tarBlockSize * tarBlockSize
+	}
+
+	stream := "archive"
+	if compressed {
+		stream = "decompressed tar stream"
+	}
+	if report.ReadableEntries == 0 {
+		report.Suggestion = fmt.Sprintf("no complete entries found; %s is damaged from the start", stream)
+	} else {
+		report.Suggestion = fmt.Sprintf(
+			"%d complete entries readable up to byte %d of the %s; use Extract with ExtractOptions.Salvage=true to recover them",
+			report.ReadableEntries, report.LastValidOffset, stream)
+	}
+	return report
+}
+
+// probeGzipTruncation reports how much of a single-file gzip stream is readable.
+func probeGzipTruncation(archive string) *TruncationReport {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = f.Close() }()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return &TruncationReport{Suggestion: "gzip header is unreadable; no data can be salvaged"}
+	}
+	defer func() { _ = gr.Close() }()
+
+	n, err := io.Copy(io.Discard, gr)
+	if err == nil {
+		return nil
+	}
+	return &TruncationReport{
+		LastValidOffset: n,
+		Suggestion: fmt.Sprintf(
+			"%d bytes decompress before the stream fails; use Extract with ExtractOptions.Salvage=true to keep the partial file", n),
+	}
+}
@@ -0,0 +1,148 @@
+package fulpack
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// entryVisitor is called for each entry during walkArchive. The reader is only
+// valid for the duration of the call and is nil for non-file entries.
+type entryVisitor func(entry ArchiveEntry, r io.Reader) error
+
+// walkArchive streams every entry of an archive to visit in archive order.
+// Entry metadata is always populated (equivalent to IncludeMetadata=true).
+func walkArchive(archivePath string, op Operation, visit entryVisitor) error {
+	format := detectFormat(archivePath)
+	switch format {
+	case ArchiveFormatTAR:
+		return walkTar(archivePath, op, false, visit)
+	case ArchiveFormatTARGZ:
+		return walkTar(archivePath, op, true, visit)
+	case ArchiveFormatZIP:
+		return walkZip(archivePath, op, visit)
+	case ArchiveFormatGZIP:
+		return walkGzip(archivePath, op, visit)
+	default:
+		return newError(ErrCodeInvalidFormat, "could not detect archive format", op, archivePath, nil)
+	}
+}
+
+// walkTar streams entries from a tar or tar.gz archive.
+func walkTar(archivePath string, op Operation, compressed bool, visit entryVisitor) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return newErrorf(ErrCodeCorruptArchive, op, archivePath, err,
+			"failed to open tar archive: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var r io.Reader = f
+	if compressed {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return newErrorf(ErrCodeCorruptArchive, op, archivePath, err,
+				"failed to create gzip reader: %v", err)
+		}
+		defer func() { _ = gr.Close() }()
+		r = gr
+	}
+
+	metadataOpts := &ScanOptions{IncludeMetadata: boolPtr(true)}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return newErrorf(ErrCodeCorruptArchive, op, archivePath, err,
+				"failed to read tar header: %v", err)
+		}
+
+		entry := convertTarHeader(header, metadataOpts)
+		if entry == nil {
+			// Unsupported entry type (device, fifo, ...)
+			continue
+		}
+
+		var content io.Reader
+		if entry.Type == EntryTypeFile {
+			content = tr
+		}
+		if err := visit(*entry, content); err != nil {
+			return err
+		}
+	}
+}
+
+// walkZip streams entries from a zip archive in central directory order.
+func walkZip(archivePath string, op Operation, visit entryVisitor) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return newErrorf(ErrCodeCorruptArchive, op, archivePath, err,
+			"failed to open zip archive: %v", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	metadataOpts := &ScanOptions{IncludeMetadata: boolPtr(true)}
+	for _, f := range zr.File {
+		entry := convertZipFileHeader(f, metadataOpts)
+		if entry.Type != EntryTypeFile {
+			if err := visit(*entry, nil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return newErrorf(ErrCodeCorruptArchive, op, f.Name, err,
+				"failed to open zip entry: %v", err)
+		}
+		visitErr := visit(*entry, rc)
+		_ = rc.Close()
+		if visitErr != nil {
+			return visitErr
+		}
+	}
+
+	return nil
+}
+
+// walkGzip presents the single gzip payload as one file entry.
+func walkGzip(archivePath string, op Operation, visit entryVisitor) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return newErrorf(ErrCodeCorruptArchive, op, archivePath, err,
+			"failed to open gzip file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return newErrorf(ErrCodeCorruptArchive, op, archivePath, err,
+			"failed to create gzip reader: %v", err)
+	}
+	defer func() { _ = gr.Close() }()
+
+	name := gr.Name
+	if name == "" {
+		name = filepath.Base(archivePath)
+		if ext := filepath.Ext(name); ext == ".gz" || ext == ".gzip" {
+			name = name[:len(name)-len(ext)]
+		}
+	}
+
+	entry := ArchiveEntry{
+		Path:     name,
+		Type:     EntryTypeFile,
+		Size:     -1, // Unknown until the stream is consumed
+		Modified: gr.ModTime,
+		Mode:     0644,
+	}
+	return visit(entry, gr)
+}
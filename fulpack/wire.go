@@ -0,0 +1,64 @@
+package fulpack
+
+import "encoding/json"
+
+// Size limits are foundry.ByteSize so configs can say "1GiB", but the
+// Crucible fulpack schemas (and pyfulmen/tsfulmen) define them as integer
+// byte counts. The MarshalJSON methods below shadow each size field with its
+// byte count so serialized options stay schema-valid; UnmarshalJSON is the
+// embedded ByteSize one and still accepts both forms.
+
+// MarshalJSON encodes MaxFileSize as an integer byte count.
+func (o CreateOptions) MarshalJSON() ([]byte, error) {
+	type wire CreateOptions
+	return json.Marshal(struct {
+		wire
+		MaxFileSize int64 `json:"max_file_size,omitempty"`
+	}{wire(o), o.MaxFileSize.Bytes()})
+}
+
+// MarshalJSON encodes MaxSize and MaxEntrySize as integer byte counts.
+func (o ExtractOptions) MarshalJSON() ([]byte, error) {
+	type wire ExtractOptions
+	return json.Marshal(struct {
+		wire
+		MaxSize      int64 `json:"max_size,omitempty"`
+		MaxEntrySize int64 `json:"max_entry_size,omitempty"`
+	}{wire(o), o.MaxSize.Bytes(), o.MaxEntrySize.Bytes()})
+}
+
+// MarshalJSON encodes MaxSize as an integer byte count.
+func (o ExtractEntryOptions) MarshalJSON() ([]byte, error) {
+	type wire ExtractEntryOptions
+	return json.Marshal(struct {
+		wire
+		MaxSize int64 `json:"max_size,omitempty"`
+	}{wire(o), o.MaxSize.Bytes()})
+}
+
+// MarshalJSON encodes MaxSize as an integer byte count.
+func (o ConvertOptions) MarshalJSON() ([]byte, error) {
+	type wire ConvertOptions
+	return json.Marshal(struct {
+		wire
+		MaxSize int64 `json:"max_size,omitempty"`
+	}{wire(o), o.MaxSize.Bytes()})
+}
+
+// MarshalJSON encodes MaxSize as an integer byte count.
+func (o PathResultOptions) MarshalJSON() ([]byte, error) {
+	type wire PathResultOptions
+	return json.Marshal(struct {
+		wire
+		MaxSize int64 `json:"max_size,omitempty"`
+	}{wire(o), o.MaxSize.Bytes()})
+}
+
+// MarshalJSON encodes MaxSize as an integer byte count.
+func (o DiffOptions) MarshalJSON() ([]byte, error) {
+	type wire DiffOptions
+	return json.Marshal(struct {
+		wire
+		MaxSize int64 `json:"max_size,omitempty"`
+	}{wire(o), o.MaxSize.Bytes()})
+}
@@ -0,0 +1,65 @@
+//go:build linux
+
+package fulpack
+
+import (
+	"bytes"
+	"errors"
+	"syscall"
+)
+
+// xattrsSupported reports whether extended attributes are captured and restored.
+const xattrsSupported = true
+
+// readXattrs returns the extended attributes of path, following symlinks.
+// Filesystems without xattr support yield none.
+func readXattrs(path string) (map[string]string, error) {
+	size, err := syscall.Listxattr(path, nil)
+	if err != nil || size == 0 {
+		if isXattrUnsupported(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	list := make([]byte, size)
+	if size, err = syscall.Listxattr(path, list); err != nil {
+		return nil, err
+	}
+
+	xattrs := make(map[string]string)
+	for _, name := range bytes.Split(list[:size], []byte{0}) {
+		if len(name) == 0 {
+			continue
+		}
+		value, err := getXattr(path, string(name))
+		if err != nil {
+			if errors.Is(err, syscall.ENODATA) {
+				continue // Removed since listing
+			}
+			return nil, err
+		}
+		xattrs[string(name)] = value
+	}
+	return xattrs, nil
+}
+
+func getXattr(path, name string) (string, error) {
+	size, err := syscall.Getxattr(path, name, nil)
+	if err != nil || size == 0 {
+		return "", err
+	}
+	value := make([]byte, size)
+	if size, err = syscall.Getxattr(path, name, value); err != nil {
+		return "", err
+	}
+	return string(value[:size]), nil
+}
+
+// writeXattr sets one extended attribute on path.
+func writeXattr(path, name, value string) error {
+	return syscall.Setxattr(path, name, []byte(value), 0)
+}
+
+func isXattrUnsupported(err error) bool {
+	return errors.Is(err, syscall.ENOTSUP) || errors.Is(err, syscall.EOPNOTSUPP)
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package fulpack
+
+import "errors"
+
+// xattrsSupported reports whether extended attributes are captured and restored.
+const xattrsSupported = false
+
+// readXattrs is unavailable on this platform; no attributes are captured.
+func readXattrs(path string) (map[string]string, error) {
+	return nil, nil
+}
+
+// writeXattr is unavailable on this platform.
+func writeXattr(path, name, value string) error {
+	return errors.New("extended attributes are not supported on this platform")
+}
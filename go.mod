@@ -5,6 +5,7 @@ go 1.25.1
 require (
 	github.com/antzucaro/matchr v0.0.0-20221106193745-7bed6ef61ef9
 	github.com/bmatcuk/doublestar/v4 v4.9.1
+	github.com/clipperhouse/uax29/v2 v2.2.0
 	github.com/fulmenhq/crucible v0.2.19
 	github.com/google/uuid v1.6.0
 	github.com/mattn/go-runewidth v0.0.19
@@ -12,6 +13,7 @@ require (
 	github.com/stretchr/testify v1.8.1
 	github.com/zeebo/xxh3 v1.0.2
 	go.uber.org/zap v1.27.0
+	golang.org/x/crypto v0.43.0
 	golang.org/x/mod v0.30.0
 	golang.org/x/text v0.30.0
 	golang.org/x/time v0.14.0
@@ -20,9 +22,9 @@ require (
 )
 
 require (
-	github.com/clipperhouse/uax29/v2 v2.2.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/klauspost/cpuid/v2 v2.0.9 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	go.uber.org/multierr v1.10.0 // indirect
+	golang.org/x/sys v0.37.0 // indirect
 )
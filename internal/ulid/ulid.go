@@ -0,0 +1,66 @@
+// Package ulid encodes the 128-bit ULID layout shared by foundry.ULID and
+// telemetry event IDs: a 48-bit big-endian millisecond timestamp followed by
+// 80 random bits, written as 26 Crockford base32 characters.
+package ulid
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Alphabet is the Crockford base32 alphabet used by ULIDs.
+const Alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// PutTimestamp writes t as a 48-bit big-endian millisecond count.
+func PutTimestamp(id *[16]byte, t time.Time) {
+	ms := uint64(t.UnixMilli())
+	binary.BigEndian.PutUint16(id[0:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(id[2:6], uint32(ms))
+}
+
+// Timestamp returns the 48-bit millisecond timestamp of id.
+func Timestamp(id [16]byte) int64 {
+	return int64(binary.BigEndian.Uint16(id[0:2]))<<32 | int64(binary.BigEndian.Uint32(id[2:6]))
+}
+
+// Encode encodes 128 bits as 26 Crockford base32 characters.
+func Encode(id [16]byte) string {
+	hi := binary.BigEndian.Uint64(id[0:8])
+	lo := binary.BigEndian.Uint64(id[8:16])
+
+	var out [26]byte
+	// 26 characters * 5 bits = 130 bits; the top character carries only 3 bits
+	for i := 25; i >= 0; i-- {
+		out[i] = Alphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// Decode decodes a canonical (uppercase) ULID string.
+func Decode(s string) ([16]byte, error) {
+	var id [16]byte
+	if len(s) != 26 {
+		return id, fmt.Errorf("ULID must be 26 characters, got %d", len(s))
+	}
+	if s[0] > '7' {
+		return id, errors.New("ULID timestamp overflows 48 bits")
+	}
+
+	var hi, lo uint64
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(Alphabet, s[i])
+		if v < 0 {
+			return id, fmt.Errorf("invalid ULID character %q", s[i])
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	binary.BigEndian.PutUint64(id[0:8], hi)
+	binary.BigEndian.PutUint64(id[8:16], lo)
+	return id, nil
+}
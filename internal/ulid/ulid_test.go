@@ -0,0 +1,40 @@
+package ulid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	var zero [16]byte
+	assert.Equal(t, "00000000000000000000000000", Encode(zero))
+
+	var max [16]byte
+	for i := range max {
+		max[i] = 0xff
+	}
+	assert.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", Encode(max))
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	var id [16]byte
+	PutTimestamp(&id, time.UnixMilli(1700000000123))
+	for i := 6; i < len(id); i++ {
+		id[i] = byte(i * 17)
+	}
+
+	decoded, err := Decode(Encode(id))
+	require.NoError(t, err)
+	assert.Equal(t, id, decoded)
+	assert.Equal(t, int64(1700000000123), Timestamp(decoded))
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, s := range []string{"", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", "0000000000000000000000000U", "01HF8Z3K4M7Q2W5XJ9RT6VBN0"} {
+		_, err := Decode(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -353,11 +353,28 @@ func (l *Logger) WithComponent(component string) *Logger {
 	}
 }
 
-// WithContext extracts trace information from context
+// WithContext extracts trace information from context.
+//
+// If ctx carries a telemetry event ID (see telemetry.EnsureEventID), it is recorded
+// in the "eventId" field so log records can be joined with metrics tagged event_id.
 func (l *Logger) WithContext(ctx context.Context) *Logger {
-	// Extract trace/span IDs if available (placeholder for future tracing integration)
-	// For now, just return the logger
-	return l
+	// Trace/span extraction is a placeholder for future tracing integration
+	eventID := telemetry.EventIDFromContext(ctx)
+	if eventID == "" {
+		return l
+	}
+
+	return &Logger{
+		zap: l.zap.With(
+			zap.String("eventId", eventID),
+		),
+		config:          l.config,
+		atomicLevel:     l.atomicLevel,
+		staticFields:    l.staticFields,
+		pipeline:        l.pipeline,
+		telemetrySystem: l.telemetrySystem,
+		inTelemetry:     l.inTelemetry,
+	}
 }
 
 // Sync flushes any buffered log entries
@@ -590,6 +607,7 @@ type telemetryCore struct {
 	zapcore.Core
 	config          *LoggerConfig
 	telemetrySystem interface{}
+	eventID         string // eventId bound via With, used to tag error counters
 }
 
 // Write emits telemetry metrics for log events
@@ -616,10 +634,21 @@ func (c *telemetryCore) Write(entry zapcore.Entry, fields []zapcore.Field) error
 	}()
 
 	if shouldEmitMetrics && telemetrySys != nil {
-		_ = telemetrySys.Counter(metrics.LoggingEmitCount, 1, map[string]string{
+		tags := map[string]string{
 			metrics.TagComponent: "logging",
 			metrics.TagSeverity:  entry.Level.String(),
-		})
+		}
+		// Tag error counters with the event ID so metrics can pivot to the log record
+		if entry.Level >= zapcore.ErrorLevel {
+			eventID := c.eventID
+			if id := eventIDFromFields(fields); id != "" {
+				eventID = id
+			}
+			if eventID != "" {
+				tags[metrics.TagEventID] = eventID
+			}
+		}
+		_ = telemetrySys.Counter(metrics.LoggingEmitCount, 1, tags)
 	}
 
 	return c.Core.Write(entry, fields)
@@ -627,11 +656,27 @@ func (c *telemetryCore) Write(entry zapcore.Entry, fields []zapcore.Field) error
 
 // With adds fields to the core (pass through)
 func (c *telemetryCore) With(fields []zapcore.Field) zapcore.Core {
+	eventID := c.eventID
+	if id := eventIDFromFields(fields); id != "" {
+		eventID = id
+	}
 	return &telemetryCore{
 		Core:            c.Core.With(fields),
 		config:          c.config,
 		telemetrySystem: c.telemetrySystem,
+		eventID:         eventID,
+	}
+}
+
+// eventIDFromFields returns the last string "eventId" (or "event_id") field value.
+func eventIDFromFields(fields []zapcore.Field) string {
+	var eventID string
+	for _, f := range fields {
+		if (f.Key == "eventId" || f.Key == "event_id") && f.Type == zapcore.StringType {
+			eventID = f.String
+		}
 	}
+	return eventID
 }
 
 // Check determines if the entry should be logged
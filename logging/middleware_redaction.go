@@ -60,6 +60,13 @@ var (
 
 const redactedPlaceholder = "[REDACTED]"
 
+// redactable is implemented by values that are always sensitive, such as
+// config.Secret (see errors.Redactable); they are redacted regardless of
+// field name or content.
+type redactable interface {
+	Redacted() string
+}
+
 // NewRedactSecretsMiddleware creates a new secret redaction middleware instance.
 func NewRedactSecretsMiddleware(config map[string]any) (Middleware, error) {
 	order := 10
@@ -186,6 +193,9 @@ func (m *RedactPIIMiddleware) redactContext(ctx map[string]any, redacted *bool)
 
 func (m *RedactSecretsMiddleware) redactValue(v any, redacted *bool) any {
 	switch val := v.(type) {
+	case redactable:
+		*redacted = true
+		return redactedPlaceholder
 	case string:
 		return m.redactString(val, redacted)
 	case map[string]any:
@@ -175,6 +175,34 @@ func TestRedactSecretsMiddleware_NestedContext(t *testing.T) {
 	}
 }
 
+type testSecret string
+
+func (testSecret) Redacted() string { return "[REDACTED]" }
+
+func TestRedactSecretsMiddleware_RedactableValues(t *testing.T) {
+	middleware, err := NewRedactSecretsMiddleware(map[string]any{})
+	if err != nil {
+		t.Fatalf("NewRedactSecretsMiddleware failed: %v", err)
+	}
+
+	event := &LogEvent{
+		Service: "test",
+		Message: "Loaded config",
+		Context: map[string]any{
+			"db": map[string]any{"dsn": testSecret("postgres://app:s3cret@db/app")},
+		},
+	}
+
+	result := middleware.Process(event)
+
+	if dsn := result.Context["db"].(map[string]any)["dsn"]; dsn != "[REDACTED]" {
+		t.Errorf("Redactable value should be redacted, got %v", dsn)
+	}
+	if len(result.RedactionFlags) != 1 || result.RedactionFlags[0] != "secrets" {
+		t.Errorf("Expected RedactionFlags [secrets], got %v", result.RedactionFlags)
+	}
+}
+
 func TestRedactSecretsMiddleware_RedactionFlags(t *testing.T) {
 	middleware, err := NewRedactSecretsMiddleware(map[string]any{})
 	if err != nil {
@@ -150,6 +150,9 @@ func (m *RedactionMiddleware) redactContext(ctx map[string]any, redacted *bool)
 // redactValue recursively redacts values (strings, maps, slices)
 func (m *RedactionMiddleware) redactValue(v any, redacted *bool) any {
 	switch val := v.(type) {
+	case redactable:
+		*redacted = true
+		return m.replacement
 	case string:
 		return m.redactString(val, redacted)
 	case map[string]any:
@@ -1,6 +1,10 @@
 package logging
 
-import "go.uber.org/zap/zapcore"
+import (
+	"log/slog"
+
+	"go.uber.org/zap/zapcore"
+)
 
 // Severity represents log severity levels matching crucible schema
 type Severity string
@@ -57,6 +61,29 @@ func (s Severity) ToZapLevel() zapcore.Level {
 	}
 }
 
+// ToSlogLevel converts Fulmen severity to a log/slog level. TRACE and FATAL
+// map below slog.LevelDebug and above slog.LevelError; NONE disables logging.
+func (s Severity) ToSlogLevel() slog.Level {
+	switch s {
+	case TRACE:
+		return slogLevelTrace
+	case DEBUG:
+		return slog.LevelDebug
+	case INFO:
+		return slog.LevelInfo
+	case WARN:
+		return slog.LevelWarn
+	case ERROR:
+		return slog.LevelError
+	case FATAL:
+		return slogLevelFatal
+	case NONE:
+		return slogLevelNone
+	default:
+		return slog.LevelInfo
+	}
+}
+
 // String returns the string representation
 func (s Severity) String() string {
 	return string(s)
@@ -0,0 +1,239 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/appidentity"
+	"github.com/fulmenhq/gofulmen/errors"
+	"github.com/fulmenhq/gofulmen/telemetry"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+)
+
+// slog levels for the Fulmen severities slog has no constant for
+const (
+	slogLevelTrace = slog.LevelDebug - 4
+	slogLevelFatal = slog.LevelError + 4
+	slogLevelNone  = slog.Level(math.MaxInt32)
+)
+
+// Slog output formats
+const (
+	SlogFormatJSON    = "json"    // one JSON object per line (default)
+	SlogFormatConsole = "console" // human-readable key=value lines
+)
+
+// SlogLevelEnvVar is the identity-prefixed environment variable that
+// overrides the configured level (e.g., GOFULMEN_LOG_LEVEL=debug).
+const SlogLevelEnvVar = "LOG_LEVEL"
+
+// SlogOptions configures NewSlog.
+type SlogOptions struct {
+	// Identity supplies the service name and the prefix of the level
+	// environment variable. Optional when Service is set.
+	Identity *appidentity.Identity
+
+	// Service overrides Identity.ServiceName() for the "service" attribute.
+	Service string
+
+	// Version is recorded as the "version" attribute when set.
+	Version string
+
+	// Format is SlogFormatJSON (default) or SlogFormatConsole.
+	Format string
+
+	// Level is the configured minimum severity (default INFO). The
+	// <EnvPrefix>LOG_LEVEL environment variable takes precedence.
+	Level Severity
+
+	// LevelVar, when set, receives the resolved level and controls the
+	// logger, so the level can be changed at runtime.
+	LevelVar *slog.LevelVar
+
+	// Writer receives log output (default os.Stderr).
+	Writer io.Writer
+
+	// TelemetrySystem, when set, counts log events by severity
+	// (logging_emit_count) and records handler latency.
+	TelemetrySystem *telemetry.System
+}
+
+// DefaultSlogOptions returns JSON output at INFO to stderr for identity.
+func DefaultSlogOptions(identity *appidentity.Identity) SlogOptions {
+	return SlogOptions{
+		Identity: identity,
+		Format:   SlogFormatJSON,
+		Level:    INFO,
+		Writer:   os.Stderr,
+	}
+}
+
+// NewSlog creates a log/slog logger preconfigured the Fulmen way: every
+// record carries the service name and version, uses the logging schema's
+// timestamp/severity/message keys and severity names, and is enriched with
+// the correlation ID from its context (see errors.NewCorrelationHandler).
+//
+// Example:
+//
+//	logger, err := logging.NewSlog(logging.SlogOptions{
+//	    Identity: identity,
+//	    Version:  version.Version,
+//	    Format:   logging.SlogFormatConsole,
+//	})
+//	ctx := foundry.WithCorrelationID(ctx, foundry.NewCorrelationIDValue())
+//	logger.InfoContext(ctx, "server started", "port", 8080)
+func NewSlog(opts SlogOptions) (*slog.Logger, error) {
+	service := opts.Service
+	if service == "" && opts.Identity != nil {
+		service = opts.Identity.ServiceName()
+	}
+	if service == "" {
+		return nil, fmt.Errorf("service name is required (set Identity or Service)")
+	}
+
+	level, err := resolveSlogLevel(opts)
+	if err != nil {
+		return nil, err
+	}
+	leveler := slog.Leveler(level)
+	if opts.LevelVar != nil {
+		opts.LevelVar.Set(level)
+		leveler = opts.LevelVar
+	}
+
+	writer := opts.Writer
+	if writer == nil {
+		writer = os.Stderr
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: leveler, ReplaceAttr: replaceSlogAttr}
+	var handler slog.Handler
+	switch opts.Format {
+	case "", SlogFormatJSON:
+		handler = slog.NewJSONHandler(writer, handlerOpts)
+	case SlogFormatConsole, "text":
+		handler = slog.NewTextHandler(writer, handlerOpts)
+	default:
+		return nil, fmt.Errorf("unsupported slog format: %s", opts.Format)
+	}
+
+	attrs := []slog.Attr{slog.String("service", service)}
+	if opts.Version != "" {
+		attrs = append(attrs, slog.String("version", opts.Version))
+	}
+	handler = handler.WithAttrs(attrs)
+
+	if opts.TelemetrySystem != nil {
+		handler = &telemetryHandler{next: handler, telemetrySystem: opts.TelemetrySystem}
+	}
+	return slog.New(errors.NewCorrelationHandler(handler)), nil
+}
+
+// FromSlogLevel converts a log/slog level to Severity.
+func FromSlogLevel(level slog.Level) Severity {
+	switch {
+	case level < slog.LevelDebug:
+		return TRACE
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelWarn:
+		return INFO
+	case level < slog.LevelError:
+		return WARN
+	case level < slogLevelFatal:
+		return ERROR
+	default:
+		return FATAL
+	}
+}
+
+// resolveSlogLevel returns the level from the environment, falling back to
+// opts.Level and then INFO.
+func resolveSlogLevel(opts SlogOptions) (slog.Level, error) {
+	severity := opts.Level
+	if opts.Identity != nil {
+		name := opts.Identity.EnvVar(SlogLevelEnvVar)
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			severity = Severity(strings.ToUpper(value))
+			if !isKnownSeverity(severity) {
+				return 0, fmt.Errorf("invalid %s: %q", name, value)
+			}
+		}
+	}
+	if severity == "" {
+		severity = INFO
+	}
+	if !isKnownSeverity(severity) {
+		return 0, fmt.Errorf("invalid log level: %q", severity)
+	}
+	return severity.ToSlogLevel(), nil
+}
+
+func isKnownSeverity(s Severity) bool {
+	switch s {
+	case TRACE, DEBUG, INFO, WARN, ERROR, FATAL, NONE:
+		return true
+	}
+	return false
+}
+
+// replaceSlogAttr renames slog's built-in keys to the logging schema's and
+// writes levels as Fulmen severity names.
+func replaceSlogAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	switch a.Key {
+	case slog.TimeKey:
+		a.Key = "timestamp"
+	case slog.LevelKey:
+		a.Key = "severity"
+		if level, ok := a.Value.Any().(slog.Level); ok {
+			a.Value = slog.StringValue(FromSlogLevel(level).String())
+		}
+	case slog.MessageKey:
+		a.Key = "message"
+	}
+	return a
+}
+
+// telemetryHandler wraps a slog.Handler to emit telemetry metrics for log
+// events, mirroring telemetryCore for the zap logger.
+type telemetryHandler struct {
+	next            slog.Handler
+	telemetrySystem *telemetry.System
+}
+
+func (h *telemetryHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *telemetryHandler) Handle(ctx context.Context, r slog.Record) error {
+	startTime := time.Now()
+	tags := map[string]string{
+		metrics.TagComponent: "logging",
+		metrics.TagSeverity:  strings.ToLower(FromSlogLevel(r.Level).String()),
+	}
+	_ = h.telemetrySystem.Counter(metrics.LoggingEmitCount, 1, tags)
+
+	err := h.next.Handle(ctx, r)
+	_ = h.telemetrySystem.Histogram(metrics.LoggingEmitLatencyMs, time.Since(startTime), map[string]string{
+		metrics.TagComponent: "logging",
+		metrics.TagSeverity:  tags[metrics.TagSeverity],
+	})
+	return err
+}
+
+func (h *telemetryHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &telemetryHandler{next: h.next.WithAttrs(attrs), telemetrySystem: h.telemetrySystem}
+}
+
+func (h *telemetryHandler) WithGroup(name string) slog.Handler {
+	return &telemetryHandler{next: h.next.WithGroup(name), telemetrySystem: h.telemetrySystem}
+}
@@ -0,0 +1,144 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/appidentity"
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/telemetry"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+	telemetrytesting "github.com/fulmenhq/gofulmen/telemetry/testing"
+)
+
+func slogTestIdentity() *appidentity.Identity {
+	return &appidentity.Identity{BinaryName: "sample", Vendor: "fulmenhq", EnvPrefix: "SAMPLE_", ConfigName: "sample"}
+}
+
+func TestNewSlog_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewSlog(SlogOptions{Identity: slogTestIdentity(), Version: "1.2.3", Writer: &buf})
+	if err != nil {
+		t.Fatalf("NewSlog returned error: %v", err)
+	}
+
+	corrID := foundry.NewCorrelationIDValue()
+	ctx := foundry.WithCorrelationID(context.Background(), corrID)
+	logger.InfoContext(ctx, "server started", "port", 8080)
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	want := map[string]any{
+		"severity":       "INFO",
+		"message":        "server started",
+		"service":        "sample",
+		"version":        "1.2.3",
+		"port":           float64(8080),
+		"correlation_id": corrID.String(),
+	}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("%s = %v, want %v", key, record[key], value)
+		}
+	}
+	if _, ok := record["timestamp"]; !ok {
+		t.Error("expected timestamp key")
+	}
+}
+
+func TestNewSlog_Console(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewSlog(SlogOptions{Service: "worker", Format: SlogFormatConsole, Level: TRACE, Writer: &buf})
+	if err != nil {
+		t.Fatalf("NewSlog returned error: %v", err)
+	}
+
+	logger.Log(context.Background(), TRACE.ToSlogLevel(), "tick")
+	out := buf.String()
+	for _, want := range []string{"severity=TRACE", "message=tick", "service=worker"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("console output missing %q: %s", want, out)
+		}
+	}
+}
+
+func TestNewSlog_Level(t *testing.T) {
+	identity := slogTestIdentity()
+	var buf bytes.Buffer
+
+	logger, err := NewSlog(SlogOptions{Identity: identity, Level: WARN, Writer: &buf})
+	if err != nil {
+		t.Fatalf("NewSlog returned error: %v", err)
+	}
+	logger.Info("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("INFO logged at configured WARN level: %s", buf.String())
+	}
+
+	// The environment overrides the configured level
+	t.Setenv("SAMPLE_LOG_LEVEL", "debug")
+	levelVar := new(slog.LevelVar)
+	logger, err = NewSlog(SlogOptions{Identity: identity, Level: WARN, LevelVar: levelVar, Writer: &buf})
+	if err != nil {
+		t.Fatalf("NewSlog returned error: %v", err)
+	}
+	logger.Debug("visible")
+	if !strings.Contains(buf.String(), "visible") {
+		t.Errorf("DEBUG not logged with SAMPLE_LOG_LEVEL=debug: %s", buf.String())
+	}
+
+	buf.Reset()
+	levelVar.Set(ERROR.ToSlogLevel())
+	logger.Warn("hidden")
+	if buf.Len() != 0 {
+		t.Errorf("LevelVar change not applied: %s", buf.String())
+	}
+
+	t.Setenv("SAMPLE_LOG_LEVEL", "loud")
+	if _, err := NewSlog(SlogOptions{Identity: identity}); err == nil {
+		t.Error("expected error for invalid SAMPLE_LOG_LEVEL")
+	}
+}
+
+func TestNewSlog_Errors(t *testing.T) {
+	if _, err := NewSlog(SlogOptions{}); err == nil {
+		t.Error("expected error without identity or service")
+	}
+	if _, err := NewSlog(SlogOptions{Service: "svc", Format: "xml"}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestNewSlog_Telemetry(t *testing.T) {
+	fc := telemetrytesting.NewFakeCollector()
+	sys, err := telemetry.NewSystem(&telemetry.Config{Enabled: true, Emitter: fc})
+	if err != nil {
+		t.Fatalf("Failed to create telemetry system: %v", err)
+	}
+
+	var buf bytes.Buffer
+	logger, err := NewSlog(SlogOptions{Service: "svc", Writer: &buf, TelemetrySystem: sys})
+	if err != nil {
+		t.Fatalf("NewSlog returned error: %v", err)
+	}
+	logger.Info("one")
+	logger.With("component", "db").Error("two")
+	logger.Debug("filtered")
+
+	counts := fc.GetMetricsByName(metrics.LoggingEmitCount)
+	if len(counts) != 2 {
+		t.Fatalf("Expected 2 emit count metrics, got %d", len(counts))
+	}
+	if counts[0].Tags[metrics.TagSeverity] != "info" || counts[1].Tags[metrics.TagSeverity] != "error" {
+		t.Errorf("severity tags = %q, %q", counts[0].Tags[metrics.TagSeverity], counts[1].Tags[metrics.TagSeverity])
+	}
+	if got := fc.CountMetricsByName(metrics.LoggingEmitLatencyMs); got != 2 {
+		t.Errorf("Expected 2 latency metrics, got %d", got)
+	}
+}
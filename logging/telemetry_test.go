@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"context"
 	"testing"
 
 	"github.com/fulmenhq/gofulmen/telemetry"
@@ -298,3 +299,46 @@ func TestLoggingTelemetryWithChildLoggers(t *testing.T) {
 		t.Errorf("Expected 3 emit count metrics from child loggers, got %d", emitCount)
 	}
 }
+
+func TestLoggingTelemetryEventIDCorrelation(t *testing.T) {
+	fc := telemetrytesting.NewFakeCollector()
+
+	sys, err := telemetry.NewSystem(&telemetry.Config{
+		Enabled: true,
+		Emitter: fc,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create telemetry system: %v", err)
+	}
+
+	config := DefaultConfig("test-service")
+	config.EnableTelemetry = true
+	config.TelemetrySystem = sys
+
+	logger, err := New(config)
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+
+	ctx, eventID := telemetry.EnsureEventID(context.Background())
+	scoped := logger.WithContext(ctx)
+	scoped.Info("informational")
+	scoped.Error("failure")
+	_ = logger.Sync()
+
+	var tagged, untagged int
+	for _, m := range fc.GetMetricsByName(metrics.LoggingEmitCount) {
+		if m.Tags[metrics.TagEventID] == eventID {
+			tagged++
+		} else {
+			untagged++
+		}
+	}
+
+	if tagged != 1 {
+		t.Errorf("Expected only the error counter to carry event_id, got %d tagged", tagged)
+	}
+	if untagged != 1 {
+		t.Errorf("Expected the info counter to be untagged, got %d untagged", untagged)
+	}
+}
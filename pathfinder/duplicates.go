@@ -0,0 +1,124 @@
+package pathfinder
+
+import (
+	"context"
+	"os"
+	"sort"
+)
+
+// DuplicateSet is a group of files with identical content.
+type DuplicateSet struct {
+	Digest      string   `json:"digest"`      // FulHash digest of the shared content ("algorithm:hex")
+	Size        int64    `json:"size"`        // Size of each file in bytes
+	Paths       []string `json:"paths"`       // Relative paths of the duplicates, sorted
+	WastedBytes int64    `json:"wastedBytes"` // Size times (distinct copies - 1); hardlinks to one inode count once
+}
+
+// DuplicateReport is the result of FindDuplicates.
+type DuplicateReport struct {
+	Sets         []DuplicateSet `json:"sets"`         // Duplicate sets, most wasted bytes first
+	FilesScanned int            `json:"filesScanned"` // Files matched by the query
+	FilesHashed  int            `json:"filesHashed"`  // Files whose size matched another file and were hashed
+	WastedBytes  int64          `json:"wastedBytes"`  // Total WastedBytes across all sets
+}
+
+// FindDuplicates finds files matched by query that have identical content.
+//
+// Files are grouped by size first, so only files sharing a size with another
+// file are hashed (with query.ChecksumAlgorithm, default xxh3-128). Empty files
+// are ignored. Files that cannot be hashed are reported to query.ErrorHandler
+// and skipped; a non-nil return from the handler aborts the search.
+//
+// Example:
+//
+//	report, err := finder.FindDuplicates(ctx, pathfinder.FindQuery{Root: ".", Include: []string{"**/*"}})
+//	if err != nil {
+//	    return err
+//	}
+//	for _, set := range report.Sets {
+//	    fmt.Printf("%d bytes wasted: %v\n", set.WastedBytes, set.Paths)
+//	}
+func (f *Finder) FindDuplicates(ctx context.Context, query FindQuery) (*DuplicateReport, error) {
+	alg, err := checksumAlgorithm(query.ChecksumAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only size-matched candidates are hashed below
+	query.CalculateChecksums = false
+	results, err := f.FindFiles(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	report := &DuplicateReport{Sets: []DuplicateSet{}, FilesScanned: len(results)}
+
+	bySize := make(map[int64][]PathResult)
+	for _, result := range results {
+		info, err := os.Stat(result.SourcePath) // size of the target for followed symlinks
+		if err != nil || info.Size() == 0 {
+			continue
+		}
+		bySize[info.Size()] = append(bySize[info.Size()], result)
+	}
+
+	for size, candidates := range bySize {
+		if len(candidates) < 2 {
+			continue
+		}
+
+		byDigest := make(map[string][]PathResult)
+		for _, result := range candidates {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+
+			digest, err := checksumFile(result.SourcePath, alg)
+			if err != nil {
+				if query.ErrorHandler != nil {
+					if handlerErr := query.ErrorHandler(result.SourcePath, err); handlerErr != nil {
+						return nil, handlerErr
+					}
+				}
+				continue
+			}
+			report.FilesHashed++
+			byDigest[digest.String()] = append(byDigest[digest.String()], result)
+		}
+
+		for digest, group := range byDigest {
+			if len(group) < 2 {
+				continue
+			}
+			set := DuplicateSet{Digest: digest, Size: size}
+			copies := make(map[fileID]bool)
+			distinct := 0
+			for _, result := range group {
+				set.Paths = append(set.Paths, result.RelativePath)
+				dev, hasDev := result.Metadata["device"].(uint64)
+				ino, hasIno := result.Metadata["inode"].(uint64)
+				if hasDev && hasIno {
+					id := fileID{dev: dev, ino: ino}
+					if copies[id] {
+						continue
+					}
+					copies[id] = true
+				}
+				distinct++
+			}
+			sort.Strings(set.Paths)
+			set.WastedBytes = size * int64(distinct-1)
+			report.WastedBytes += set.WastedBytes
+			report.Sets = append(report.Sets, set)
+		}
+	}
+
+	sort.Slice(report.Sets, func(i, j int) bool {
+		if report.Sets[i].WastedBytes != report.Sets[j].WastedBytes {
+			return report.Sets[i].WastedBytes > report.Sets[j].WastedBytes
+		}
+		return report.Sets[i].Paths[0] < report.Sets[j].Paths[0]
+	})
+	return report, nil
+}
@@ -0,0 +1,23 @@
+package pathfinder
+
+import "github.com/fulmenhq/gofulmen/errors"
+
+// Retry classifications of the error envelope codes pathfinder returns.
+var classificationMap = map[string]errors.Classification{
+	"PATHFINDER_VALIDATION_ERROR":        errors.ClassificationPermanent,
+	"PATHFINDER_INPUT_VALIDATION_ERROR":  errors.ClassificationPermanent,
+	"PATHFINDER_OUTPUT_VALIDATION_ERROR": errors.ClassificationPermanent,
+	"PATHFINDER_SCHEMA_ERROR":            errors.ClassificationPermanent,
+	"PATHFINDER_MAPPING_ERROR":           errors.ClassificationPermanent,
+	"PATHFINDER_ROOT_PATH_ERROR":         errors.ClassificationPermanent,
+	"PATHFINDER_SECURITY_ERROR":          errors.ClassificationSecurity,
+	"INVALID_START_PATH":                 errors.ClassificationPermanent,
+	"INVALID_BOUNDARY":                   errors.ClassificationPermanent,
+	"INVALID_MARKERS":                    errors.ClassificationPermanent,
+	"REPOSITORY_NOT_FOUND":               errors.ClassificationPermanent,
+	"TRAVERSAL_LOOP":                     errors.ClassificationPermanent,
+}
+
+func init() {
+	errors.RegisterClassifications(classificationMap)
+}
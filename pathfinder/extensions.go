@@ -0,0 +1,109 @@
+package pathfinder
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+// The Crucible schemas under schemas/crucible-go are synced from the Crucible
+// SSOT, so fields gofulmen adds ahead of Crucible are described in schemas
+// owned by this package and validated in addition to the Crucible ones.
+
+//go:embed find-query-ext.schema.json
+var findQueryExtSchema []byte
+
+//go:embed finder-config-ext.schema.json
+var finderConfigExtSchema []byte
+
+//go:embed path-result-ext.schema.json
+var pathResultExtSchema []byte
+
+var (
+	findQueryExt  = &extensionSchema{data: findQueryExtSchema}
+	pathResultExt = &extensionSchema{data: pathResultExtSchema}
+)
+
+// LoaderTypeArchive is the PathResult loader type for entries inside an
+// archive file (see fulpack.ScanAsPathResults). It extends the Crucible
+// loaderType enum (local, remote, cloud) through path-result-ext.schema.json.
+const LoaderTypeArchive = "archive"
+
+// extensionLoaderTypes are the loader types only the extension schema knows.
+var extensionLoaderTypes = map[string]bool{LoaderTypeArchive: true}
+
+// withoutExtensionLoaderType drops the Crucible schema's loaderType
+// diagnostics for extension loader types, which the extension schema
+// validates instead, along with the wrapper diagnostics (e.g. "doesn't
+// validate with ...") that only summarized them.
+func withoutExtensionLoaderType(loaderType string, diags []schema.Diagnostic) []schema.Diagnostic {
+	if !extensionLoaderTypes[loaderType] {
+		return diags
+	}
+	wraps := func(d schema.Diagnostic, others []schema.Diagnostic) bool {
+		for _, other := range others {
+			if strings.HasPrefix(other.Keyword, d.Keyword+"/") {
+				return true
+			}
+		}
+		return false
+	}
+	var leaves []schema.Diagnostic
+	for _, d := range diags {
+		if d.Pointer != "/loaderType" && !wraps(d, diags) {
+			leaves = append(leaves, d)
+		}
+	}
+	var kept []schema.Diagnostic
+	for _, d := range diags {
+		if d.Pointer == "/loaderType" || (wraps(d, diags) && !wraps(d, leaves)) {
+			continue
+		}
+		kept = append(kept, d)
+	}
+	return kept
+}
+
+// extensionSchema lazily compiles an embedded extension schema.
+type extensionSchema struct {
+	data      []byte
+	once      sync.Once
+	validator *schema.Validator
+	err       error
+}
+
+// validate validates the JSON form of value against the extension schema.
+func (e *extensionSchema) validate(value any) ([]schema.Diagnostic, error) {
+	e.once.Do(func() {
+		e.validator, e.err = schema.NewValidator(e.data)
+	})
+	if e.err != nil {
+		return nil, e.err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return e.validator.ValidateJSON(data)
+}
+
+// FindQueryExtSchema returns the JSON Schema for the FindQuery fields gofulmen
+// adds to the Crucible find-query schema.
+func FindQueryExtSchema() []byte {
+	return append([]byte(nil), findQueryExtSchema...)
+}
+
+// FinderConfigExtSchema returns the JSON Schema for the FinderConfig fields
+// gofulmen adds to the Crucible finder-config schema.
+func FinderConfigExtSchema() []byte {
+	return append([]byte(nil), finderConfigExtSchema...)
+}
+
+// PathResultExtSchema returns the JSON Schema for the PathResult fields
+// gofulmen adds to the Crucible path-result and metadata schemas.
+func PathResultExtSchema() []byte {
+	return append([]byte(nil), pathResultExtSchema...)
+}
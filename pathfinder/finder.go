@@ -2,6 +2,7 @@ package pathfinder
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,6 +12,7 @@ import (
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/fulmenhq/crucible"
 	"github.com/fulmenhq/gofulmen/errors"
+	"github.com/fulmenhq/gofulmen/foundry"
 	"github.com/fulmenhq/gofulmen/fulhash"
 	"github.com/fulmenhq/gofulmen/schema"
 	"github.com/fulmenhq/gofulmen/telemetry"
@@ -24,7 +26,7 @@ type FinderConfig struct {
 
 	// TODO: Future enhancement - implement result caching
 	CacheEnabled bool `json:"cacheEnabled"` // Currently unused - no caching layer
-	CacheTTL     int  `json:"cacheTTL"`     // Currently unused - cache TTL in seconds
+	CacheTTL     int  `json:"cacheTTL"`     // Currently unused - cache TTL in seconds (integer, per the Crucible finder-config schema)
 
 	// TODO: Future enhancement - implement PathConstraint enforcement
 	Constraint PathConstraint `json:"constraint"` // Currently unused - no constraint validation
@@ -33,6 +35,8 @@ type FinderConfig struct {
 	LoaderType      string `json:"loaderType"`      // Type of loader (default: "local")
 	ValidateInputs  bool   `json:"validateInputs"`  // Validate FindQuery inputs against schema
 	ValidateOutputs bool   `json:"validateOutputs"` // Validate PathResult outputs against schema
+
+	MappingRules []MappingRule `json:"mappingRules,omitempty"` // Default LogicalPath rules for queries without their own
 }
 
 // FindQuery specifies the parameters for discovery
@@ -45,6 +49,13 @@ type FindQuery struct {
 	IncludeHidden      bool                                               `json:"includeHidden,omitempty"`
 	CalculateChecksums bool                                               `json:"calculateChecksums,omitempty"`
 	ChecksumAlgorithm  string                                             `json:"checksumAlgorithm,omitempty"`
+	DedupeInodes       bool                                               `json:"dedupeInodes,omitempty"`    // Report each (device, inode) once, skipping hardlinks and bind-mounted duplicates
+	DetectMimeTypes    bool                                               `json:"detectMimeTypes,omitempty"` // Populate Metadata["mimeType"] from the foundry MIME catalog
+	MinSize            foundry.ByteSize                                   `json:"minSize,omitempty"`         // Skip files smaller than MinSize, e.g. "10KiB" or 10240 (0 = no minimum)
+	MaxSize            foundry.ByteSize                                   `json:"maxSize,omitempty"`         // Skip files larger than MaxSize, e.g. "1GiB" (0 = no maximum)
+	ModifiedSince      time.Time                                          `json:"modifiedSince,omitzero"`    // Skip files last modified before this time (zero = no limit)
+	FileTypes          []string                                           `json:"fileTypes,omitempty"`       // Keep only these file extensions, without the dot (e.g., "go", "tar.gz"); empty = all
+	MappingRules       []MappingRule                                      `json:"mappingRules,omitempty"`    // LogicalPath rewrite rules; replaces FinderConfig.MappingRules when set
 	ErrorHandler       func(path string, err error) error                 `json:"-"`
 	ProgressCallback   func(processed int, total int, currentPath string) `json:"-"`
 }
@@ -82,6 +93,17 @@ func NewFinder() *Finder {
 	}
 }
 
+// NewFinderWithConfig creates a finder with the given config. An empty
+// LoaderType defaults to "local".
+func NewFinderWithConfig(config FinderConfig) *Finder {
+	f := NewFinder()
+	if config.LoaderType == "" {
+		config.LoaderType = f.config.LoaderType
+	}
+	f.config = config
+	return f
+}
+
 // FindFiles performs file discovery based on the query
 func (f *Finder) FindFiles(ctx context.Context, query FindQuery) ([]PathResult, error) {
 	return f.FindFilesWithEnvelope(ctx, query, "")
@@ -89,6 +111,11 @@ func (f *Finder) FindFiles(ctx context.Context, query FindQuery) ([]PathResult,
 
 // FindFilesWithEnvelope performs file discovery based on the query with structured error reporting
 func (f *Finder) FindFilesWithEnvelope(ctx context.Context, query FindQuery, correlationID string) ([]PathResult, error) {
+	return f.findFiles(ctx, query, correlationID, nil)
+}
+
+// findFiles implements discovery, populating record when non-nil.
+func (f *Finder) findFiles(ctx context.Context, query FindQuery, correlationID string, record *ScanRecord) ([]PathResult, error) {
 	start := time.Now()
 	status := metrics.StatusSuccess
 	defer func() {
@@ -133,6 +160,27 @@ func (f *Finder) FindFilesWithEnvelope(ctx context.Context, query FindQuery, cor
 		}
 	}
 
+	// Compile logical path mapping rules (the query's replace the finder defaults)
+	rules := query.MappingRules
+	if len(rules) == 0 {
+		rules = f.config.MappingRules
+	}
+	mappingRules, err := compileMappingRules(rules)
+	if err != nil {
+		status = metrics.StatusError
+		envelope := errors.NewErrorEnvelope("PATHFINDER_MAPPING_ERROR", "Invalid logical path mapping rule")
+		envelope = errors.SafeWithSeverity(envelope, errors.SeverityMedium)
+		envelope = envelope.WithCorrelationID(correlationID)
+		envelope = errors.SafeWithContext(envelope, map[string]interface{}{
+			"component":  "pathfinder",
+			"operation":  "compile_mapping_rules",
+			"error_type": "validation_error",
+			"root":       query.Root,
+		})
+		envelope = envelope.WithOriginal(err)
+		return nil, envelope
+	}
+
 	// Convert root to absolute path for relative path calculations
 	absRoot, err := filepath.Abs(query.Root)
 	if err != nil {
@@ -166,9 +214,16 @@ func (f *Finder) FindFilesWithEnvelope(ctx context.Context, query FindQuery, cor
 			_ = query.ErrorHandler(".fulmenignore", err)
 		}
 	}
+	if record != nil {
+		record.recordIgnoreLayers(query, ignoreMatcher, filepath.Join(absRoot, ".fulmenignore"))
+	}
 
 	var results []PathResult
 
+	// Track (device, inode) pairs so bind-mount and symlink loops are never
+	// descended, and duplicates are skipped when DedupeInodes is set
+	guard := newTraversalGuard(query.DedupeInodes)
+
 	// Collect all matches from include patterns
 	for _, pattern := range query.Include {
 		// Use doublestar for recursive ** support - always use absolute root
@@ -194,6 +249,9 @@ func (f *Finder) FindFilesWithEnvelope(ctx context.Context, query FindQuery, cor
 				_ = query.ErrorHandler(pattern, ErrEscapesRoot)
 			}
 			// Log security warning for path traversal attempt
+			if record != nil {
+				record.SecurityWarnings++
+			}
 			// Emit security warning metric
 			if f.telemetrySystem != nil {
 				_ = f.telemetrySystem.Counter(metrics.PathfinderSecurityWarnings, 1, map[string]string{
@@ -205,7 +263,7 @@ func (f *Finder) FindFilesWithEnvelope(ctx context.Context, query FindQuery, cor
 			continue
 		}
 
-		matches, err := doublestar.FilepathGlob(globPattern)
+		matches, err := globGuarded(globPattern, guard)
 		if err != nil {
 			if query.ErrorHandler != nil {
 				if handlerErr := query.ErrorHandler(pattern, err); handlerErr != nil {
@@ -241,6 +299,9 @@ func (f *Finder) FindFilesWithEnvelope(ctx context.Context, query FindQuery, cor
 			// SECURITY: Ensure the matched path doesn't escape the root directory
 			// This prevents path traversal attacks via glob patterns like ../**/*.go
 			if err := ValidatePathWithinRoot(absMatch, absRoot); err != nil {
+				if record != nil {
+					record.SecurityWarnings++
+				}
 				if query.ErrorHandler != nil {
 					// Error handler call failure is non-critical in pathfinder context
 					_ = query.ErrorHandler(absMatch, err)
@@ -293,50 +354,61 @@ func (f *Finder) FindFilesWithEnvelope(ctx context.Context, query FindQuery, cor
 				continue
 			}
 
+			// Attribute filters and identity apply to the target when following a symlink
+			idInfo := info
+			if info.Mode()&os.ModeSymlink != 0 {
+				if targetInfo, err := os.Stat(absMatch); err == nil {
+					idInfo = targetInfo
+				}
+			}
+			if !query.matchesAttributes(relPath, idInfo) {
+				continue
+			}
+
 			// Populate metadata per Pathfinder spec (size, mtime, checksum)
 			metadata := make(map[string]any)
 			metadata["size"] = info.Size()
 			metadata["mtime"] = info.ModTime().Format("2006-01-02T15:04:05.000000000Z07:00") // RFC3339Nano
 
+			// Device/inode identity
+			if id, nlink, ok := fileIdentity(idInfo); ok {
+				metadata["device"] = id.dev
+				metadata["inode"] = id.ino
+				metadata["nlink"] = nlink
+			}
+
 			// Optional checksum calculation using FulHash
 			if query.CalculateChecksums {
-				algorithm := query.ChecksumAlgorithm
-				if algorithm == "" {
-					algorithm = "xxh3-128" // default
-				}
-
-				var alg fulhash.Algorithm
-				switch algorithm {
-				case "xxh3-128":
-					alg = fulhash.XXH3_128
-				case "sha256":
-					alg = fulhash.SHA256
-				default:
+				alg, err := checksumAlgorithm(query.ChecksumAlgorithm)
+				if err != nil {
 					// This should be caught by validation, but handle gracefully
-					metadata["checksumError"] = fmt.Sprintf("unsupported algorithm: %s", algorithm)
+					metadata["checksumError"] = err.Error()
+				} else if digest, err := checksumFile(absMatch, alg); err != nil {
+					metadata["checksumError"] = err.Error()
+				} else {
+					metadata["checksum"] = digest.String()
+					metadata["checksumAlgorithm"] = string(digest.Algorithm())
 				}
+			}
 
-				if metadata["checksumError"] == nil {
-					file, err := os.Open(absMatch) // #nosec G304 -- absMatch is validated with ValidatePathWithinRoot to prevent path traversal
-					if err != nil {
-						metadata["checksumError"] = fmt.Sprintf("failed to open file: %v", err)
-					} else {
-						digest, err := fulhash.HashReader(file, fulhash.WithAlgorithm(alg))
-						if err != nil {
-							metadata["checksumError"] = fmt.Sprintf("checksum calculation failed: %v", err)
-						} else {
-							metadata["checksum"] = digest.String()
-							metadata["checksumAlgorithm"] = string(digest.Algorithm())
-						}
-						_ = file.Close()
-					}
+			// Optional MIME type detection using the foundry catalog
+			if query.DetectMimeTypes {
+				if mimeType, err := detectMimeType(absMatch); err != nil {
+					metadata["mimeTypeError"] = err.Error()
+				} else if mimeType != "" {
+					metadata["mimeType"] = mimeType
 				}
 			}
 
+			logicalPath := relPath
+			if len(mappingRules) > 0 {
+				logicalPath = applyMappingRules(relPath, mappingRules)
+			}
+
 			result := PathResult{
 				RelativePath: relPath,
 				SourcePath:   absMatch,
-				LogicalPath:  relPath,
+				LogicalPath:  logicalPath,
 				LoaderType:   f.config.LoaderType,
 				Metadata:     metadata,
 			}
@@ -369,6 +441,25 @@ func (f *Finder) FindFilesWithEnvelope(ctx context.Context, query FindQuery, cor
 		results = filtered
 	}
 
+	// Drop hardlinked/bind-mounted duplicates (after exclusions, so an excluded
+	// path never hides an included link to the same file)
+	if query.DedupeInodes {
+		deduped := make([]PathResult, 0, len(results))
+		for _, result := range results {
+			dev, hasDev := result.Metadata["device"].(uint64)
+			ino, hasIno := result.Metadata["inode"].(uint64)
+			if hasDev && hasIno && !guard.allowFile(fileID{dev: dev, ino: ino}) {
+				continue
+			}
+			deduped = append(deduped, result)
+		}
+		results = deduped
+	}
+	if record != nil {
+		record.LoopsSkipped = guard.loops
+		record.DuplicatesSkipped = guard.duplicates
+	}
+
 	// Validate outputs if enabled
 	if f.config.ValidateOutputs {
 		for i, result := range results {
@@ -393,6 +484,78 @@ func (f *Finder) FindFilesWithEnvelope(ctx context.Context, query FindQuery, cor
 	return results, nil
 }
 
+// checksumAlgorithm resolves a FindQuery checksum algorithm name ("" means xxh3-128).
+func checksumAlgorithm(name string) (fulhash.Algorithm, error) {
+	switch name {
+	case "", "xxh3-128":
+		return fulhash.XXH3_128, nil
+	case "sha256":
+		return fulhash.SHA256, nil
+	default:
+		return "", fmt.Errorf("unsupported algorithm: %s", name)
+	}
+}
+
+// checksumFile hashes the file at absPath, which must already be validated
+// to lie within the query root.
+func checksumFile(absPath string, alg fulhash.Algorithm) (fulhash.Digest, error) {
+	file, err := os.Open(absPath) // #nosec G304 -- callers validate absPath with ValidatePathWithinRoot to prevent path traversal
+	if err != nil {
+		return fulhash.Digest{}, fmt.Errorf("failed to open file: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	digest, err := fulhash.HashReader(file, fulhash.WithAlgorithm(alg))
+	if err != nil {
+		return fulhash.Digest{}, fmt.Errorf("checksum calculation failed: %v", err)
+	}
+	return digest, nil
+}
+
+// detectMimeType returns the MIME type of the file at absPath from the foundry
+// catalog, looked up by extension or, for extensionless names, sniffed from the
+// leading bytes. It returns "" when the type is unknown.
+func detectMimeType(absPath string) (string, error) {
+	var mimeType *foundry.MimeType
+	var err error
+	base := filepath.Base(absPath)
+	if ext := filepath.Ext(base); ext != "" && ext != base { // ".env" is a name, not an extension
+		mimeType, err = foundry.GetMimeTypeByExtension(ext)
+	} else {
+		mimeType, err = foundry.DetectMimeTypeFromFile(absPath)
+	}
+	if err != nil || mimeType == nil {
+		return "", err
+	}
+	return mimeType.Mime, nil
+}
+
+// matchesAttributes reports whether a file passes the query's size,
+// modification time, and file type filters.
+func (q FindQuery) matchesAttributes(relPath string, info os.FileInfo) bool {
+	if q.MinSize > 0 && info.Size() < q.MinSize.Bytes() {
+		return false
+	}
+	if q.MaxSize > 0 && info.Size() > q.MaxSize.Bytes() {
+		return false
+	}
+	if !q.ModifiedSince.IsZero() && info.ModTime().Before(q.ModifiedSince) {
+		return false
+	}
+	if len(q.FileTypes) == 0 {
+		return true
+	}
+	// Suffix match so compound extensions like "tar.gz" work
+	base := strings.ToLower(filepath.Base(relPath))
+	for _, fileType := range q.FileTypes {
+		ext := strings.ToLower(strings.TrimPrefix(fileType, "."))
+		if ext != "" && strings.HasSuffix(base, "."+ext) {
+			return true
+		}
+	}
+	return false
+}
+
 // FindGoFiles finds Go source files
 func (f *Finder) FindGoFiles(ctx context.Context, root string) ([]PathResult, error) {
 	query := FindQuery{
@@ -482,6 +645,35 @@ func ValidateFindQueryWithEnvelope(query FindQuery, correlationID string) error
 		}
 	}
 
+	// Validate mapping rules
+	if _, err := compileMappingRules(query.MappingRules); err != nil {
+		envelope := errors.NewErrorEnvelope("PATHFINDER_VALIDATION_ERROR", "Invalid logical path mapping rule")
+		envelope = errors.SafeWithSeverity(envelope, errors.SeverityMedium)
+		envelope = envelope.WithCorrelationID(correlationID)
+		envelope = errors.SafeWithContext(envelope, map[string]interface{}{
+			"component":  "pathfinder",
+			"operation":  "validate_mapping_rules",
+			"error_type": "validation_error",
+		})
+		envelope = envelope.WithOriginal(err)
+		return envelope
+	}
+
+	// Validate size range (negative sizes are rejected by the extension schema)
+	if query.MaxSize > 0 && query.MinSize > query.MaxSize {
+		envelope := errors.NewErrorEnvelope("PATHFINDER_VALIDATION_ERROR", fmt.Sprintf("Invalid size range: minSize %s exceeds maxSize %s", query.MinSize, query.MaxSize))
+		envelope = errors.SafeWithSeverity(envelope, errors.SeverityMedium)
+		envelope = envelope.WithCorrelationID(correlationID)
+		envelope = errors.SafeWithContext(envelope, map[string]interface{}{
+			"component":  "pathfinder",
+			"operation":  "validate_size_range",
+			"error_type": "validation_error",
+			"min_size":   query.MinSize.Bytes(),
+			"max_size":   query.MaxSize.Bytes(),
+		})
+		return envelope
+	}
+
 	pathfinderSchemas, err := crucible.SchemaRegistry.Pathfinder().V1_0_0()
 	if err != nil {
 		envelope := errors.NewErrorEnvelope("PATHFINDER_SCHEMA_ERROR", "Failed to get pathfinder schemas from registry")
@@ -524,7 +716,17 @@ func ValidateFindQueryWithEnvelope(query FindQuery, correlationID string) error
 		return envelope
 	}
 
-	diags, err := validator.ValidateData(query)
+	// Validate the serialized form, which is what other languages exchange,
+	// against the Crucible schema and gofulmen's extensions
+	var diags, extDiags []schema.Diagnostic
+	data, err := json.Marshal(query)
+	if err == nil {
+		diags, err = validator.ValidateJSON(data)
+	}
+	if err == nil {
+		extDiags, err = findQueryExt.validate(query)
+		diags = append(diags, extDiags...)
+	}
 	if err != nil {
 		envelope := errors.NewErrorEnvelope("PATHFINDER_VALIDATION_ERROR", "Failed to validate query data")
 		envelope = errors.SafeWithSeverity(envelope, errors.SeverityHigh)
@@ -621,7 +823,18 @@ func validatePathResultWithTelemetry(result PathResult, correlationID string, te
 		return envelope
 	}
 
-	diags, err := validator.ValidateData(result)
+	// Validate the serialized form against the Crucible schema and
+	// gofulmen's extensions; the validator only accepts JSON values
+	var diags, extDiags []schema.Diagnostic
+	data, err := json.Marshal(result)
+	if err == nil {
+		diags, err = validator.ValidateJSON(data)
+		diags = withoutExtensionLoaderType(result.LoaderType, diags)
+	}
+	if err == nil {
+		extDiags, err = pathResultExt.validate(result)
+		diags = append(diags, extDiags...)
+	}
 	if err != nil {
 		if telSys != nil {
 			_ = telSys.Counter(metrics.PathfinderValidationErrors, 1, map[string]string{
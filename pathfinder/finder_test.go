@@ -2,9 +2,16 @@ package pathfinder
 
 import (
 	"context"
+	"encoding/json"
+	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/foundry"
 )
 
 // TestFindFiles_RecursiveGlob tests recursive glob pattern matching with **
@@ -611,3 +618,496 @@ func TestFindFiles_Checksums(t *testing.T) {
 		})
 	}
 }
+
+// TestFindFilesWithRecord tests scan provenance record generation
+func TestFindFilesWithRecord(t *testing.T) {
+	ctx := context.Background()
+	finder := NewFinder()
+
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"keep.go":       "package keep",
+		"skip.tmp":      "temp",
+		"vendor/dep.go": "package dep",
+		".fulmenignore": "vendor/\n",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	query := FindQuery{
+		Root:    root,
+		Include: []string{"**/*", "../**/*.go"},
+		Exclude: []string{"*.tmp"},
+	}
+	results, record, err := finder.FindFilesWithRecord(ctx, query, "corr-123")
+	if err != nil {
+		t.Fatalf("FindFilesWithRecord() error = %v", err)
+	}
+
+	if record.CorrelationID != "corr-123" {
+		t.Errorf("CorrelationID = %q, expected corr-123", record.CorrelationID)
+	}
+	if record.ResultCount != len(results) || record.ResultCount != 1 {
+		t.Errorf("ResultCount = %d, expected 1 (results=%d)", record.ResultCount, len(results))
+	}
+	if record.SecurityWarnings != 1 {
+		t.Errorf("SecurityWarnings = %d, expected 1 for escaping pattern", record.SecurityWarnings)
+	}
+	if record.Status != "success" || record.LibraryVersion == "" {
+		t.Errorf("Unexpected record status/version: %q %q", record.Status, record.LibraryVersion)
+	}
+	if record.CompletedAt.Before(record.StartedAt) {
+		t.Error("CompletedAt precedes StartedAt")
+	}
+
+	layers := make(map[string]IgnoreLayer)
+	for _, layer := range record.IgnoreLayers {
+		layers[layer.Layer] = layer
+	}
+	if ignore, ok := layers[IgnoreLayerFulmenignore]; !ok || len(ignore.Patterns) != 1 {
+		t.Errorf("Expected fulmenignore layer with 1 pattern, got %+v", ignore)
+	}
+	if exclude, ok := layers[IgnoreLayerQueryExclude]; !ok || exclude.Patterns[0] != "*.tmp" {
+		t.Errorf("Expected query_exclude layer, got %+v", exclude)
+	}
+	if _, ok := layers[IgnoreLayerHidden]; !ok {
+		t.Error("Expected hidden layer")
+	}
+
+	if err := ValidateScanRecord(record); err != nil {
+		t.Errorf("ValidateScanRecord() error = %v", err)
+	}
+
+	// Generated correlation ID when none is supplied
+	_, record, err = finder.FindFilesWithRecord(ctx, FindQuery{Root: "testdata/basic", Include: []string{"*.go"}}, "")
+	if err != nil {
+		t.Fatalf("FindFilesWithRecord() error = %v", err)
+	}
+	if record.CorrelationID == "" {
+		t.Error("Expected generated correlation ID")
+	}
+	if err := ValidateScanRecord(record); err != nil {
+		t.Errorf("ValidateScanRecord() error = %v", err)
+	}
+
+	// Tampered records fail validation
+	record.Status = "unknown"
+	if err := ValidateScanRecord(record); err == nil {
+		t.Error("Expected validation error for invalid status")
+	}
+}
+
+// TestFindFiles_InodeTracking tests loop protection, hardlink deduplication, and inode metadata
+func TestFindFiles_InodeTracking(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("inode tracking is not available on Windows")
+	}
+
+	ctx := context.Background()
+	finder := NewFinder()
+
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "a"), 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	original := filepath.Join(root, "a", "data.txt")
+	if err := os.WriteFile(original, []byte("data"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.Link(original, filepath.Join(root, "hardlink.txt")); err != nil {
+		t.Skipf("hardlinks not supported: %v", err)
+	}
+	// Directory symlink pointing back at the root creates a traversal loop
+	if err := os.Symlink(root, filepath.Join(root, "a", "loop")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	query := FindQuery{Root: root, Include: []string{"**/*.txt"}}
+	results, record, err := finder.FindFilesWithRecord(ctx, query, "")
+	if err != nil {
+		t.Fatalf("FindFilesWithRecord() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results (loop not descended), got %d: %v", len(results), results)
+	}
+	if record.LoopsSkipped == 0 {
+		t.Error("Expected LoopsSkipped > 0")
+	}
+	for _, result := range results {
+		if nlink, _ := result.Metadata["nlink"].(uint64); nlink != 2 {
+			t.Errorf("%s: nlink = %v, want 2", result.RelativePath, result.Metadata["nlink"])
+		}
+		if _, ok := result.Metadata["inode"].(uint64); !ok {
+			t.Errorf("%s: missing inode metadata", result.RelativePath)
+		}
+	}
+
+	query.DedupeInodes = true
+	results, record, err = finder.FindFilesWithRecord(ctx, query, "")
+	if err != nil {
+		t.Fatalf("FindFilesWithRecord() error = %v", err)
+	}
+	if len(results) != 1 {
+		t.Errorf("Expected 1 result with DedupeInodes, got %d", len(results))
+	}
+	if record.DuplicatesSkipped != 1 {
+		t.Errorf("DuplicatesSkipped = %d, want 1", record.DuplicatesSkipped)
+	}
+	if err := ValidateScanRecord(record); err != nil {
+		t.Errorf("ValidateScanRecord() error = %v", err)
+	}
+}
+
+// TestFindFiles_AttributeFilters tests the minSize, maxSize, modifiedSince, and fileTypes filters
+func TestFindFiles_AttributeFilters(t *testing.T) {
+	ctx := context.Background()
+	finder := NewFinder()
+
+	root := t.TempDir()
+	old := time.Now().Add(-48 * time.Hour)
+	for name, size := range map[string]int{
+		"small.go":       10,
+		"large.go":       2000,
+		"notes.MD":       500,
+		"bundle.tar.gz":  500,
+		"old/legacy.txt": 500,
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+	if err := os.Chtimes(filepath.Join(root, "old", "legacy.txt"), old, old); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		query    FindQuery
+		expected []string
+	}{
+		{
+			name:     "size range",
+			query:    FindQuery{MinSize: 100, MaxSize: 1000},
+			expected: []string{"bundle.tar.gz", "notes.MD", filepath.Join("old", "legacy.txt")},
+		},
+		{
+			name:     "modified since",
+			query:    FindQuery{ModifiedSince: time.Now().Add(-time.Hour), FileTypes: []string{"txt"}},
+			expected: nil,
+		},
+		{
+			name:     "file types",
+			query:    FindQuery{FileTypes: []string{"md", ".tar.gz"}},
+			expected: []string{"bundle.tar.gz", "notes.MD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.Root = root
+			tt.query.Include = []string{"**/*"}
+			results, err := finder.FindFiles(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("FindFiles() error = %v", err)
+			}
+			var got []string
+			for _, result := range results {
+				got = append(got, result.RelativePath)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("FindFiles() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	// Filters round-trip through JSON and are recorded as an ignore layer
+	query := FindQuery{Root: root, Include: []string{"**/*"}, MinSize: 100, ModifiedSince: old.Add(time.Hour)}
+	data, err := json.Marshal(query)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded FindQuery
+	if err := json.Unmarshal(data, &decoded); err != nil || !decoded.ModifiedSince.Equal(query.ModifiedSince) || decoded.MinSize != 100 {
+		t.Fatalf("FindQuery did not round-trip: %s (%v)", data, err)
+	}
+	if err := ValidateFindQuery(decoded); err != nil {
+		t.Errorf("ValidateFindQuery() error = %v", err)
+	}
+	_, record, err := finder.FindFilesWithRecord(ctx, decoded, "")
+	if err != nil {
+		t.Fatalf("FindFilesWithRecord() error = %v", err)
+	}
+	if record.ResultCount != 3 {
+		t.Errorf("ResultCount = %d, expected 3", record.ResultCount)
+	}
+	found := false
+	for _, layer := range record.IgnoreLayers {
+		if layer.Layer == IgnoreLayerAttributes && len(layer.Patterns) == 2 && layer.Patterns[0] == "minSize=100" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected attribute_filters layer, got %+v", record.IgnoreLayers)
+	}
+	if err := ValidateScanRecord(record); err != nil {
+		t.Errorf("ValidateScanRecord() error = %v", err)
+	}
+
+	// Sizes accept human-readable units
+	var sized FindQuery
+	if err := json.Unmarshal([]byte(`{"root":"`+filepath.ToSlash(root)+`","include":["**/*"],"maxSize":"1KiB"}`), &sized); err != nil || sized.MaxSize != foundry.KiB {
+		t.Fatalf("Unmarshal(maxSize) = %v, %v", sized.MaxSize, err)
+	}
+	if err := ValidateFindQuery(sized); err != nil {
+		t.Errorf("ValidateFindQuery(maxSize=1KiB) error = %v", err)
+	}
+
+	if err := ValidateFindQuery(FindQuery{Root: root, MinSize: 10, MaxSize: 5}); err == nil {
+		t.Error("Expected error for minSize greater than maxSize")
+	}
+	// Extension fields are validated against the gofulmen-owned schema
+	if err := ValidateFindQuery(FindQuery{Root: root, MinSize: -1}); err == nil {
+		t.Error("Expected schema error for negative minSize")
+	}
+}
+
+// TestFindFiles_MappingRules tests LogicalPath rewriting from query and finder config rules
+func TestFindFiles_MappingRules(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	for _, name := range []string{"docs/Guide.md", "schemas/v1/app.schema.json", "README.md"} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	rules := []MappingRule{
+		{Case: CaseLower},
+		{Match: "docs/**", AddPrefix: "crucible:"},
+		{Match: "schemas/**", StripPrefix: "schemas/", Pattern: `^(v\d+)/(.+)\.schema\.json$`, Replacement: "schema:$2@$1"},
+	}
+	expected := map[string]string{
+		filepath.Join("docs", "Guide.md"):                 "crucible:docs/guide.md",
+		filepath.Join("schemas", "v1", "app.schema.json"): "schema:app@v1",
+		"README.md": "readme.md",
+	}
+
+	check := func(t *testing.T, results []PathResult) {
+		t.Helper()
+		if len(results) != len(expected) {
+			t.Fatalf("Expected %d results, got %d", len(expected), len(results))
+		}
+		for _, result := range results {
+			if want := expected[result.RelativePath]; result.LogicalPath != want {
+				t.Errorf("%s: LogicalPath = %q, expected %q", result.RelativePath, result.LogicalPath, want)
+			}
+		}
+	}
+
+	t.Run("query rules", func(t *testing.T) {
+		results, err := NewFinder().FindFiles(ctx, FindQuery{Root: root, Include: []string{"**/*"}, MappingRules: rules})
+		if err != nil {
+			t.Fatalf("FindFiles() error = %v", err)
+		}
+		check(t, results)
+	})
+
+	t.Run("config rules", func(t *testing.T) {
+		finder := NewFinderWithConfig(FinderConfig{MappingRules: rules})
+		results, err := finder.FindFiles(ctx, FindQuery{Root: root, Include: []string{"**/*"}})
+		if err != nil {
+			t.Fatalf("FindFiles() error = %v", err)
+		}
+		check(t, results)
+
+		// Query rules replace the config defaults
+		results, err = finder.FindFiles(ctx, FindQuery{Root: root, Include: []string{"README.md"}, MappingRules: []MappingRule{{AddPrefix: "root:"}}})
+		if err != nil || len(results) != 1 || results[0].LogicalPath != "root:README.md" {
+			t.Errorf("Expected query rules to replace config rules, got %v (%v)", results, err)
+		}
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		query := FindQuery{Root: root, Include: []string{"**/*"}, MappingRules: []MappingRule{{Pattern: "("}}}
+		if _, err := NewFinder().FindFiles(ctx, query); err == nil {
+			t.Error("Expected error for invalid pattern")
+		}
+		if err := ValidateFindQuery(query); err == nil {
+			t.Error("Expected ValidateFindQuery() error for invalid pattern")
+		}
+		if _, err := MapLogicalPath("a", []MappingRule{{Case: "title"}}); err == nil {
+			t.Error("Expected error for invalid case")
+		}
+	})
+
+	t.Run("config extension schema", func(t *testing.T) {
+		config := FinderConfig{MappingRules: []MappingRule{{Match: "docs/**", Case: CaseLower}}}
+		diags, err := (&extensionSchema{data: FinderConfigExtSchema()}).validate(config)
+		if err != nil || len(diags) > 0 {
+			t.Errorf("Expected config rules to satisfy the extension schema, got %v (%v)", diags, err)
+		}
+		config.MappingRules[0].Case = "title"
+		if diags, _ := (&extensionSchema{data: FinderConfigExtSchema()}).validate(config); len(diags) == 0 {
+			t.Error("Expected extension schema diagnostics for invalid case")
+		}
+	})
+}
+
+func TestFindDuplicates(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"a.txt":        "duplicate content",
+		"sub/b.txt":    "duplicate content",
+		"sub/c.txt":    "duplicate content",
+		"same-size.md": "different content",
+		"pair1.bin":    "xy",
+		"pair2.bin":    "xy",
+		"unique.txt":   "unique",
+		"empty1.txt":   "",
+		"empty2.txt":   "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	report, err := NewFinder().FindDuplicates(ctx, FindQuery{Root: root, Include: []string{"**/*"}})
+	if err != nil {
+		t.Fatalf("FindDuplicates() error = %v", err)
+	}
+	if report.FilesScanned != len(files) {
+		t.Errorf("FilesScanned = %d, expected %d", report.FilesScanned, len(files))
+	}
+	if report.FilesHashed != 6 {
+		t.Errorf("FilesHashed = %d, expected 6 (size-matched files only)", report.FilesHashed)
+	}
+	if len(report.Sets) != 2 {
+		t.Fatalf("Expected 2 duplicate sets, got %d: %+v", len(report.Sets), report.Sets)
+	}
+
+	first := report.Sets[0]
+	expectedPaths := []string{"a.txt", filepath.Join("sub", "b.txt"), filepath.Join("sub", "c.txt")}
+	sort.Strings(expectedPaths)
+	if strings.Join(first.Paths, ",") != strings.Join(expectedPaths, ",") {
+		t.Errorf("Paths = %v, expected %v", first.Paths, expectedPaths)
+	}
+	if first.Size != 17 || first.WastedBytes != 34 {
+		t.Errorf("Size = %d, WastedBytes = %d, expected 17 and 34", first.Size, first.WastedBytes)
+	}
+	if !strings.HasPrefix(first.Digest, "xxh3-128:") {
+		t.Errorf("Digest = %q, expected xxh3-128 prefix", first.Digest)
+	}
+	if report.Sets[1].WastedBytes != 2 || report.WastedBytes != 36 {
+		t.Errorf("Expected 2 and 36 wasted bytes, got %d and %d", report.Sets[1].WastedBytes, report.WastedBytes)
+	}
+
+	t.Run("sha256", func(t *testing.T) {
+		report, err := NewFinder().FindDuplicates(ctx, FindQuery{Root: root, Include: []string{"*.bin"}, ChecksumAlgorithm: "sha256"})
+		if err != nil {
+			t.Fatalf("FindDuplicates() error = %v", err)
+		}
+		if len(report.Sets) != 1 || !strings.HasPrefix(report.Sets[0].Digest, "sha256:") {
+			t.Errorf("Expected one sha256 set, got %+v", report.Sets)
+		}
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		if _, err := NewFinder().FindDuplicates(ctx, FindQuery{Root: root, Include: []string{"**/*"}, ChecksumAlgorithm: "md5"}); err == nil {
+			t.Error("Expected error for unsupported algorithm")
+		}
+	})
+
+	t.Run("hardlinks count once", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("inode identity not available on Windows")
+		}
+		if err := os.Link(filepath.Join(root, "pair1.bin"), filepath.Join(root, "link.bin")); err != nil {
+			t.Skipf("hardlinks not supported: %v", err)
+		}
+		report, err := NewFinder().FindDuplicates(ctx, FindQuery{Root: root, Include: []string{"*.bin"}})
+		if err != nil {
+			t.Fatalf("FindDuplicates() error = %v", err)
+		}
+		if len(report.Sets) != 1 || len(report.Sets[0].Paths) != 3 || report.Sets[0].WastedBytes != 2 {
+			t.Errorf("Expected 3 paths with 2 wasted bytes, got %+v", report.Sets)
+		}
+	})
+}
+
+func TestFindFiles_DetectMimeTypes(t *testing.T) {
+	ctx := context.Background()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"config.yaml": "key: value\n",
+		"data.JSON":   `{"key": "value"}`,
+		"Dockerfile":  `{"sniffed": true}`,
+		"blob.xyz":    "unknown extension",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	results, err := NewFinder().FindFiles(ctx, FindQuery{Root: root, Include: []string{"*"}, DetectMimeTypes: true})
+	if err != nil {
+		t.Fatalf("FindFiles() error = %v", err)
+	}
+
+	expected := map[string]string{
+		"config.yaml": "application/yaml",
+		"data.JSON":   "application/json",
+		"Dockerfile":  "application/json",
+		"blob.xyz":    "",
+	}
+	for _, result := range results {
+		got, _ := result.Metadata["mimeType"].(string)
+		if got != expected[result.RelativePath] {
+			t.Errorf("%s: mimeType = %q, expected %q", result.RelativePath, got, expected[result.RelativePath])
+		}
+	}
+
+	// Detection is opt-in
+	results, err = NewFinder().FindFiles(ctx, FindQuery{Root: root, Include: []string{"*.yaml"}})
+	if err != nil {
+		t.Fatalf("FindFiles() error = %v", err)
+	}
+	if _, ok := results[0].Metadata["mimeType"]; ok {
+		t.Error("Expected no mimeType without DetectMimeTypes")
+	}
+
+	// mimeTypeError is checked against the gofulmen extension schema
+	result := results[0]
+	result.Metadata["mimeTypeError"] = "read failed"
+	if err := ValidatePathResult(result); err != nil {
+		t.Errorf("ValidatePathResult() error = %v", err)
+	}
+	result.Metadata["mimeTypeError"] = 42
+	if err := ValidatePathResult(result); err == nil {
+		t.Error("Expected ValidatePathResult() error for non-string mimeTypeError")
+	}
+}
@@ -0,0 +1,123 @@
+package pathfinder
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// fileID identifies a file by device and inode.
+type fileID struct {
+	dev uint64
+	ino uint64
+}
+
+// traversalGuard tracks (device, inode) pairs seen during discovery.
+//
+// Directories reached again beneath themselves (bind-mount or symlink loops)
+// are never descended. With dedupe enabled, any directory or file already seen
+// under another path (bind mounts, hardlinks) is skipped as a duplicate.
+type traversalGuard struct {
+	dedupe     bool
+	dirs       map[fileID]string // first absolute (slash) path seen per directory
+	files      map[fileID]bool
+	loops      int
+	duplicates int
+}
+
+func newTraversalGuard(dedupe bool) *traversalGuard {
+	return &traversalGuard{
+		dedupe: dedupe,
+		dirs:   make(map[fileID]string),
+		files:  make(map[fileID]bool),
+	}
+}
+
+// allowDir reports whether the directory at absolute slash path dir should be read.
+func (g *traversalGuard) allowDir(dir string, info os.FileInfo) bool {
+	id, _, ok := fileIdentity(info)
+	if !ok {
+		return true
+	}
+	first, seen := g.dirs[id]
+	switch {
+	case !seen:
+		g.dirs[id] = dir
+		return true
+	case first == dir:
+		return true
+	case strings.HasPrefix(dir, strings.TrimSuffix(first, "/")+"/"):
+		g.loops++
+		return false
+	case g.dedupe:
+		g.duplicates++
+		return false
+	default:
+		return true
+	}
+}
+
+// allowFile reports whether a file should be returned, skipping hardlinked or
+// bind-mounted duplicates when dedupe is enabled.
+func (g *traversalGuard) allowFile(id fileID) bool {
+	if !g.dedupe {
+		return true
+	}
+	if g.files[id] {
+		g.duplicates++
+		return false
+	}
+	g.files[id] = true
+	return true
+}
+
+// globGuarded expands an absolute glob pattern like doublestar.FilepathGlob,
+// reading directories through the guard.
+func globGuarded(pattern string, guard *traversalGuard) ([]string, error) {
+	pattern = filepath.ToSlash(filepath.Clean(pattern))
+	base, rest := doublestar.SplitPattern(pattern)
+	if rest == "" || rest == "." || rest == ".." {
+		// Literal path - nothing to traverse
+		return doublestar.FilepathGlob(filepath.FromSlash(pattern))
+	}
+
+	matches, err := doublestar.Glob(newGuardedFS(base, guard), rest)
+	if err != nil {
+		return nil, err
+	}
+	for i := range matches {
+		matches[i] = filepath.FromSlash(path.Join(base, matches[i]))
+	}
+	return matches, nil
+}
+
+// guardedFS is an os.DirFS whose ReadDir consults a traversalGuard, so glob
+// traversal cannot loop through bind mounts or symlinked ancestors.
+type guardedFS struct {
+	base  string // absolute slash path of the FS root
+	fsys  fs.FS
+	guard *traversalGuard
+}
+
+func newGuardedFS(base string, guard *traversalGuard) *guardedFS {
+	return &guardedFS{base: base, fsys: os.DirFS(base), guard: guard}
+}
+
+func (g *guardedFS) Open(name string) (fs.File, error) {
+	return g.fsys.Open(name)
+}
+
+func (g *guardedFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(g.fsys, name)
+}
+
+func (g *guardedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if info, err := fs.Stat(g.fsys, name); err == nil && !g.guard.allowDir(path.Join(g.base, name), info) {
+		return nil, nil
+	}
+	return fs.ReadDir(g.fsys, name)
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package pathfinder
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileIdentity returns the (device, inode) identity and link count of a file.
+func fileIdentity(info os.FileInfo) (fileID, uint64, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileID{}, 0, false
+	}
+	return fileID{dev: uint64(st.Dev), ino: uint64(st.Ino)}, uint64(st.Nlink), true //nolint:unconvert // field types vary by platform
+}
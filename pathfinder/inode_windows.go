@@ -0,0 +1,10 @@
+//go:build windows
+
+package pathfinder
+
+import "os"
+
+// fileIdentity is unavailable on this platform; inode tracking is disabled.
+func fileIdentity(info os.FileInfo) (fileID, uint64, bool) {
+	return fileID{}, 0, false
+}
@@ -0,0 +1,106 @@
+package pathfinder
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// Case normalization modes for MappingRule.Case
+const (
+	CaseLower = "lower"
+	CaseUpper = "upper"
+)
+
+// MappingRule rewrites the LogicalPath of discovered files so assets can be
+// exposed under stable logical namespaces (e.g., docs/** -> crucible:docs/**).
+//
+// Rules are applied in order, each to the output of the previous one, and
+// every rule whose Match pattern matches is applied. Within a rule the steps
+// run as: StripPrefix, Pattern/Replacement, Case, AddPrefix. Logical paths
+// always use forward slashes.
+type MappingRule struct {
+	Match       string `json:"match,omitempty"`       // Doublestar pattern the current logical path must match (empty = all)
+	StripPrefix string `json:"stripPrefix,omitempty"` // Prefix removed when present (e.g., "src/")
+	Pattern     string `json:"pattern,omitempty"`     // Regular expression rewritten with Replacement
+	Replacement string `json:"replacement,omitempty"` // Replacement for Pattern; supports $1-style group references
+	Case        string `json:"case,omitempty"`        // Case normalization: "lower", "upper", or "" (unchanged)
+	AddPrefix   string `json:"addPrefix,omitempty"`   // Prefix prepended last (e.g., "crucible:")
+}
+
+// compiledMappingRule is a MappingRule with its regular expression compiled.
+type compiledMappingRule struct {
+	MappingRule
+	re *regexp.Regexp
+}
+
+// compileMappingRules validates rules and compiles their regular expressions.
+func compileMappingRules(rules []MappingRule) ([]compiledMappingRule, error) {
+	compiled := make([]compiledMappingRule, len(rules))
+	for i, rule := range rules {
+		compiled[i].MappingRule = rule
+		if rule.Match != "" && !doublestar.ValidatePattern(rule.Match) {
+			return nil, fmt.Errorf("mapping rule %d: invalid match pattern %q", i, rule.Match)
+		}
+		if rule.Pattern != "" {
+			re, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("mapping rule %d: invalid pattern: %w", i, err)
+			}
+			compiled[i].re = re
+		}
+		switch rule.Case {
+		case "", CaseLower, CaseUpper:
+		default:
+			return nil, fmt.Errorf("mapping rule %d: invalid case %q (must be %q or %q)", i, rule.Case, CaseLower, CaseUpper)
+		}
+	}
+	return compiled, nil
+}
+
+// applyMappingRules maps a relative path through the rules.
+func applyMappingRules(relPath string, rules []compiledMappingRule) string {
+	logical := filepath.ToSlash(relPath)
+	for _, rule := range rules {
+		if rule.Match != "" {
+			if matched, _ := doublestar.Match(rule.Match, logical); !matched {
+				continue
+			}
+		}
+		if rule.StripPrefix != "" {
+			logical = strings.TrimPrefix(logical, rule.StripPrefix)
+		}
+		if rule.re != nil {
+			logical = rule.re.ReplaceAllString(logical, rule.Replacement)
+		}
+		switch rule.Case {
+		case CaseLower:
+			logical = strings.ToLower(logical)
+		case CaseUpper:
+			logical = strings.ToUpper(logical)
+		}
+		logical = rule.AddPrefix + logical
+	}
+	return logical
+}
+
+// MapLogicalPath returns the logical path for relPath under rules, as FindFiles
+// computes PathResult.LogicalPath. It returns an error for an invalid rule.
+//
+// Example:
+//
+//	rules := []pathfinder.MappingRule{
+//	    {Case: pathfinder.CaseLower},
+//	    {Match: "docs/**", AddPrefix: "crucible:"},
+//	}
+//	logical, _ := pathfinder.MapLogicalPath("docs/Guide.md", rules) // "crucible:docs/guide.md"
+func MapLogicalPath(relPath string, rules []MappingRule) (string, error) {
+	compiled, err := compileMappingRules(rules)
+	if err != nil {
+		return "", err
+	}
+	return applyMappingRules(relPath, compiled), nil
+}
@@ -0,0 +1,186 @@
+package pathfinder
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/schema"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+)
+
+// ScanRecordVersion is the version of the ScanRecord format.
+const ScanRecordVersion = "1.0.0"
+
+//go:embed scan-record.schema.json
+var scanRecordSchema []byte
+
+var (
+	scanRecordValidator     *schema.Validator
+	scanRecordValidatorErr  error
+	scanRecordValidatorOnce sync.Once
+)
+
+// Ignore layer identifiers recorded in ScanRecord.IgnoreLayers.
+const (
+	IgnoreLayerFulmenignore = "fulmenignore"
+	IgnoreLayerQueryExclude = "query_exclude"
+	IgnoreLayerHidden       = "hidden"
+	IgnoreLayerSymlinks     = "symlinks"
+	IgnoreLayerMaxDepth     = "max_depth"
+	IgnoreLayerAttributes   = "attribute_filters"
+)
+
+// ScanRecord is a machine-readable provenance record for one discovery run.
+//
+// Audit pipelines can persist it alongside results to show what was scanned,
+// by which library version, and with which exclusions in effect.
+type ScanRecord struct {
+	RecordVersion     string        `json:"recordVersion"`
+	CorrelationID     string        `json:"correlationId"`
+	LibraryVersion    string        `json:"libraryVersion"`
+	Query             FindQuery     `json:"query"`
+	StartedAt         time.Time     `json:"startedAt"`
+	CompletedAt       time.Time     `json:"completedAt"`
+	DurationMs        int64         `json:"durationMs"`
+	Status            string        `json:"status"`
+	Error             string        `json:"error,omitempty"`
+	ResultCount       int           `json:"resultCount"`
+	SecurityWarnings  int           `json:"securityWarnings"`
+	LoopsSkipped      int           `json:"loopsSkipped,omitempty"`      // Directories not descended because they contain themselves
+	DuplicatesSkipped int           `json:"duplicatesSkipped,omitempty"` // Directories/files skipped by DedupeInodes
+	IgnoreLayers      []IgnoreLayer `json:"ignoreLayers"`
+}
+
+// IgnoreLayer describes one exclusion mechanism applied during a scan.
+type IgnoreLayer struct {
+	Layer    string   `json:"layer"`              // One of the IgnoreLayer* constants
+	Source   string   `json:"source"`             // Where the layer came from (file path, "query", "default")
+	Patterns []string `json:"patterns,omitempty"` // Patterns contributed by the layer, if any
+}
+
+// FindFilesWithRecord performs file discovery and returns a ScanRecord describing the run.
+//
+// A correlation ID is generated when correlationID is empty. The record is returned
+// even when discovery fails, with Status "error" and the failure message.
+//
+// Example:
+//
+//	results, record, err := finder.FindFilesWithRecord(ctx, query, "")
+//	data, _ := json.Marshal(record)
+//	auditSink.Write(data)
+func (f *Finder) FindFilesWithRecord(ctx context.Context, query FindQuery, correlationID string) ([]PathResult, *ScanRecord, error) {
+	if correlationID == "" {
+		correlationID = foundry.GenerateCorrelationID()
+	}
+
+	record := &ScanRecord{
+		RecordVersion:  ScanRecordVersion,
+		CorrelationID:  correlationID,
+		LibraryVersion: foundry.GofulmenVersion(),
+		Query:          query,
+		StartedAt:      time.Now().UTC(),
+		IgnoreLayers:   []IgnoreLayer{},
+	}
+
+	results, err := f.findFiles(ctx, query, correlationID, record)
+
+	record.CompletedAt = time.Now().UTC()
+	record.DurationMs = record.CompletedAt.Sub(record.StartedAt).Milliseconds()
+	record.ResultCount = len(results)
+	record.Status = metrics.StatusSuccess
+	if err != nil {
+		record.Status = metrics.StatusError
+		record.Error = err.Error()
+	}
+
+	return results, record, err
+}
+
+// ValidateScanRecord validates a ScanRecord against the embedded scan-record schema.
+func ValidateScanRecord(record *ScanRecord) error {
+	if record == nil {
+		return fmt.Errorf("scan record is nil")
+	}
+
+	scanRecordValidatorOnce.Do(func() {
+		scanRecordValidator, scanRecordValidatorErr = schema.NewValidator(scanRecordSchema)
+	})
+	if scanRecordValidatorErr != nil {
+		return fmt.Errorf("failed to initialize scan record validator: %w", scanRecordValidatorErr)
+	}
+
+	// Validate the serialized form, which is what audit pipelines persist
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode scan record: %w", err)
+	}
+	diags, err := scanRecordValidator.ValidateJSON(data)
+	if err != nil {
+		return fmt.Errorf("scan record validation failed: %w", err)
+	}
+	if verrs := schema.DiagnosticsToValidationErrors(diags); len(verrs) > 0 {
+		return verrs
+	}
+	return nil
+}
+
+// ScanRecordSchema returns the JSON Schema describing ScanRecord.
+func ScanRecordSchema() []byte {
+	return append([]byte(nil), scanRecordSchema...)
+}
+
+// recordIgnoreLayers records the exclusion mechanisms in effect for a query.
+func (r *ScanRecord) recordIgnoreLayers(query FindQuery, ignoreMatcher *IgnoreMatcher, ignoreFile string) {
+	if ignoreMatcher != nil && len(ignoreMatcher.GetPatterns()) > 0 {
+		r.IgnoreLayers = append(r.IgnoreLayers, IgnoreLayer{
+			Layer:    IgnoreLayerFulmenignore,
+			Source:   ignoreFile,
+			Patterns: ignoreMatcher.GetPatterns(),
+		})
+	}
+	if len(query.Exclude) > 0 {
+		r.IgnoreLayers = append(r.IgnoreLayers, IgnoreLayer{
+			Layer:    IgnoreLayerQueryExclude,
+			Source:   "query",
+			Patterns: append([]string(nil), query.Exclude...),
+		})
+	}
+	if !query.IncludeHidden {
+		r.IgnoreLayers = append(r.IgnoreLayers, IgnoreLayer{Layer: IgnoreLayerHidden, Source: "default"})
+	}
+	if !query.FollowSymlinks {
+		r.IgnoreLayers = append(r.IgnoreLayers, IgnoreLayer{Layer: IgnoreLayerSymlinks, Source: "default"})
+	}
+	if query.MaxDepth > 0 {
+		r.IgnoreLayers = append(r.IgnoreLayers, IgnoreLayer{
+			Layer:  IgnoreLayerMaxDepth,
+			Source: "query",
+		})
+	}
+	var filters []string
+	if query.MinSize > 0 {
+		filters = append(filters, fmt.Sprintf("minSize=%d", query.MinSize.Bytes()))
+	}
+	if query.MaxSize > 0 {
+		filters = append(filters, fmt.Sprintf("maxSize=%d", query.MaxSize.Bytes()))
+	}
+	if !query.ModifiedSince.IsZero() {
+		filters = append(filters, "modifiedSince="+query.ModifiedSince.Format(time.RFC3339Nano))
+	}
+	if len(query.FileTypes) > 0 {
+		filters = append(filters, "fileTypes="+strings.Join(query.FileTypes, ","))
+	}
+	if len(filters) > 0 {
+		r.IgnoreLayers = append(r.IgnoreLayers, IgnoreLayer{
+			Layer:    IgnoreLayerAttributes,
+			Source:   "query",
+			Patterns: filters,
+		})
+	}
+}
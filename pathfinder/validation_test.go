@@ -40,6 +40,24 @@ func TestValidatePathResultWithEnvelope_InvalidInput(t *testing.T) {
 	}
 }
 
+func TestValidatePathResult_ArchiveLoaderType(t *testing.T) {
+	result := PathResult{
+		RelativePath: "docs/README.md",
+		SourcePath:   "/tmp/release.tar.gz",
+		LogicalPath:  "docs/README.md",
+		LoaderType:   LoaderTypeArchive,
+		Metadata:     map[string]any{},
+	}
+	if err := ValidatePathResult(result); err != nil {
+		t.Errorf("expected archive loader type to validate, got %v", err)
+	}
+
+	result.LoaderType = "ftp"
+	if err := ValidatePathResult(result); err == nil {
+		t.Error("expected unknown loader type to fail validation")
+	}
+}
+
 func TestValidatePathResultsWithEnvelope_MixedInput(t *testing.T) {
 	invalidResult := PathResult{
 		RelativePath: "",
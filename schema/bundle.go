@@ -0,0 +1,293 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrUnresolvedRef is returned when a $ref cannot be resolved offline.
+var ErrUnresolvedRef = errors.New("unresolved schema reference")
+
+// SchemaSource loads a schema document (JSON or YAML) by slash-separated path
+// relative to the schema root, e.g. "observability/logging/v1.0.0/logger-config.schema.json".
+// crucible.GetSchema satisfies this signature for the embedded catalog.
+type SchemaSource func(path string) ([]byte, error)
+
+// Bundler produces self-contained schemas by inlining every external $ref.
+//
+// Referenced documents (https://schemas.fulmenhq.dev/ URLs or relative paths)
+// are loaded from the SchemaSource and placed under the root schema's $defs
+// ("definitions" for draft-07); their $refs are rewritten to local pointers.
+// The result validates without network or filesystem access, which makes it
+// suitable for air-gapped environments.
+//
+// Example:
+//
+//	bundled, err := schema.NewBundler(crucible.GetSchema).Bundle("observability/logging/v1.0.0/logger-config.schema.json")
+type Bundler struct {
+	source SchemaSource
+
+	docs  map[string]map[string]any // loaded documents by path
+	keys  map[string]string         // definition key per bundled document path
+	defs  map[string]any            // bundled definitions by key
+	taken map[string]bool           // definition keys in use
+}
+
+// NewBundler creates a Bundler that loads documents from source.
+func NewBundler(source SchemaSource) *Bundler {
+	return &Bundler{source: source}
+}
+
+// NewBundler creates a Bundler that loads documents from the catalog directory.
+func (c *Catalog) NewBundler() *Bundler {
+	return NewBundler(func(p string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(c.baseDir, filepath.FromSlash(p))) // #nosec G304 -- Path is cleaned and confined to the catalog
+	})
+}
+
+// BundleByID bundles the catalog schema identified by ID.
+func (c *Catalog) BundleByID(id string) ([]byte, error) {
+	desc, err := c.GetSchema(id)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(c.baseDir, desc.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate schema %s: %w", id, err)
+	}
+	return c.NewBundler().Bundle(filepath.ToSlash(rel))
+}
+
+// Bundle loads the schema at path and returns it as self-contained JSON.
+func (b *Bundler) Bundle(schemaPath string) ([]byte, error) {
+	schemaPath, err := cleanSchemaPath(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	b.docs = make(map[string]map[string]any)
+	b.keys = map[string]string{schemaPath: ""}
+	b.defs = make(map[string]any)
+	b.taken = make(map[string]bool)
+
+	root, err := b.load(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	container := "$defs"
+	if draft, _ := root["$schema"].(string); strings.Contains(draft, "draft-07") {
+		container = "definitions"
+	}
+	existing, _ := root[container].(map[string]any)
+	for name := range existing {
+		b.taken[name] = true
+	}
+
+	rewritten, err := b.rewrite(root, schemaPath, container)
+	if err != nil {
+		return nil, err
+	}
+	bundled := rewritten.(map[string]any)
+
+	if len(b.defs) > 0 {
+		defs, _ := bundled[container].(map[string]any)
+		if defs == nil {
+			defs = make(map[string]any, len(b.defs))
+		}
+		for key, def := range b.defs {
+			defs[key] = def
+		}
+		bundled[container] = defs
+	}
+
+	return json.MarshalIndent(bundled, "", "  ")
+}
+
+// load reads and caches a document.
+func (b *Bundler) load(docPath string) (map[string]any, error) {
+	if doc, ok := b.docs[docPath]; ok {
+		return doc, nil
+	}
+	raw, err := b.source(docPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrUnresolvedRef, docPath, err)
+	}
+	data, err := normalizeSchemaBytes(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema %s: %w", docPath, err)
+	}
+	doc, err := decodeSchemaDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema %s: %w", docPath, err)
+	}
+	b.docs[docPath] = doc
+	return doc, nil
+}
+
+// include bundles the document at docPath (once) and returns its definition key.
+func (b *Bundler) include(docPath, container string) (string, error) {
+	if key, ok := b.keys[docPath]; ok {
+		return key, nil
+	}
+	doc, err := b.load(docPath)
+	if err != nil {
+		return "", err
+	}
+
+	key := b.definitionKey(docPath)
+	b.keys[docPath] = key // registered before rewriting so reference cycles terminate
+
+	rewritten, err := b.rewrite(doc, docPath, container)
+	if err != nil {
+		return "", err
+	}
+	def := rewritten.(map[string]any)
+	// An embedded $id would change the base URI for the rewritten local pointers
+	delete(def, "$id")
+	delete(def, "$schema")
+	b.defs[key] = def
+	return key, nil
+}
+
+// rewrite copies node, rewriting $refs in a document located at docPath.
+func (b *Bundler) rewrite(node any, docPath, container string) (any, error) {
+	switch v := node.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, child := range v {
+			switch k {
+			case "$ref", "$dynamicRef":
+				if ref, ok := child.(string); ok {
+					resolved, err := b.rewriteRef(ref, docPath, container)
+					if err != nil {
+						return nil, err
+					}
+					out[k] = resolved
+					continue
+				}
+			case "enum", "const", "default", "examples":
+				// Instance data, not subschemas
+				out[k] = child
+				continue
+			}
+			rewritten, err := b.rewrite(child, docPath, container)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = rewritten
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, child := range v {
+			rewritten, err := b.rewrite(child, docPath, container)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = rewritten
+		}
+		return out, nil
+	default:
+		return node, nil
+	}
+}
+
+// rewriteRef maps a $ref in the document at docPath to a pointer into the bundle.
+func (b *Bundler) rewriteRef(ref, docPath, container string) (string, error) {
+	location, fragment, _ := strings.Cut(ref, "#")
+
+	target := docPath
+	if location != "" {
+		resolved, err := b.refPath(docPath, location)
+		if err != nil {
+			return "", err
+		}
+		target = resolved
+	}
+
+	key, err := b.include(target, container)
+	if err != nil {
+		return "", err
+	}
+	if key == "" || (fragment != "" && !strings.HasPrefix(fragment, "/")) {
+		// Root document, or a plain-name anchor (anchors are bundle-wide)
+		return "#" + fragment, nil
+	}
+	return "#/" + container + "/" + escapePointerToken(key) + fragment, nil
+}
+
+// refPath maps a $ref location to a document path relative to the schema root.
+func (b *Bundler) refPath(fromPath, location string) (string, error) {
+	const fulmenPrefix = "https://schemas.fulmenhq.dev/"
+	switch {
+	case strings.HasPrefix(location, fulmenPrefix):
+		return cleanSchemaPath(schemaURLPath(strings.TrimPrefix(location, fulmenPrefix)))
+	case strings.Contains(location, "://"):
+		return "", fmt.Errorf("%w: %s cannot be resolved offline", ErrUnresolvedRef, location)
+	default:
+		return cleanSchemaPath(path.Join(path.Dir(fromPath), location))
+	}
+}
+
+// definitionKey derives a unique definition name from a document path.
+func (b *Bundler) definitionKey(docPath string) string {
+	base := path.Base(docPath)
+	for _, ext := range []string{".json", ".yaml", ".yml", ".schema"} {
+		base = strings.TrimSuffix(base, ext)
+	}
+
+	key := base
+	for i := 2; b.taken[key]; i++ {
+		key = base + "-" + strconv.Itoa(i)
+	}
+	b.taken[key] = true
+	return key
+}
+
+// schemaURLPath maps a schemas.fulmenhq.dev URL path to a schema root path.
+// URL pattern:  crucible/<category>/<name>-v<version>.json
+// Path pattern: <category>/v<version>/<name>.schema.json
+func schemaURLPath(urlPath string) string {
+	urlPath = strings.TrimPrefix(urlPath, "crucible/")
+	category, filename := path.Split(urlPath)
+	category = strings.TrimSuffix(category, "/")
+
+	name := strings.TrimSuffix(strings.TrimSuffix(filename, ".json"), ".schema")
+	version := ""
+	if idx := strings.LastIndex(name, "-v"); idx != -1 && idx+2 < len(name) && name[idx+2] >= '0' && name[idx+2] <= '9' {
+		version = "v" + name[idx+2:]
+		name = name[:idx]
+	}
+
+	for _, part := range strings.Split(category, "/") {
+		if len(part) > 1 && part[0] == 'v' && part[1] >= '0' && part[1] <= '9' {
+			// Version already in category path, e.g. library/fulpack/v1.0.0/archive-entry
+			return path.Join(category, name+".schema.json")
+		}
+	}
+	if version == "" {
+		version = "v1.0.0"
+	}
+	return path.Join(category, version, name+".schema.json")
+}
+
+// cleanSchemaPath normalizes a schema root path and rejects paths escaping the root.
+func cleanSchemaPath(p string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(filepath.ToSlash(p), "/"))
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%w: %s is outside the schema root", ErrUnresolvedRef, p)
+	}
+	return cleaned, nil
+}
+
+// escapePointerToken escapes a JSON pointer reference token.
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,161 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCatalogBundleByID(t *testing.T) {
+	const id = "observability/logging/v1.0.0/logger-config"
+
+	bundled, err := DefaultCatalog().BundleByID(id)
+	if err != nil {
+		t.Fatalf("BundleByID failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(bundled, &doc); err != nil {
+		t.Fatalf("bundled schema is not JSON: %v", err)
+	}
+	defs, _ := doc["$defs"].(map[string]any)
+	for _, key := range []string{"middleware-config", "severity-filter", "logLevel"} {
+		if _, ok := defs[key]; !ok {
+			t.Errorf("expected $defs/%s in bundle", key)
+		}
+	}
+	if strings.Contains(string(bundled), `"$ref": "https://`) {
+		t.Fatalf("bundle still contains remote $ref")
+	}
+
+	// The bundle compiles from memory with no loader access to the catalog
+	validator, err := NewValidator(bundled)
+	if err != nil {
+		t.Fatalf("bundled schema failed to compile: %v", err)
+	}
+	diags, err := validator.ValidateJSON([]byte(`{"service":"svc","profile":"STRUCTURED","sinks":[{"type":"console"}],"middleware":[{"type":"redaction","enabled":true}]}`))
+	if err != nil {
+		t.Fatalf("validation failed: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("expected valid payload, got %v", diags)
+	}
+	diags, err = validator.ValidateJSON([]byte(`{"service":"svc","profile":"STRUCTURED","sinks":[{"type":"console"}],"middleware":[{"type":"bogus"}]}`))
+	if err != nil {
+		t.Fatalf("validation failed: %v", err)
+	}
+	if len(diags) == 0 {
+		t.Fatal("expected diagnostics from bundled middleware schema")
+	}
+}
+
+func TestBundlerRelativeRefs(t *testing.T) {
+	files := map[string]string{
+		"app/v1.0.0/root.schema.json": `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://example.com/root.json",
+  "type": "object",
+  "properties": {
+    "item": {"$ref": "item.schema.json"},
+    "tag": {"$ref": "../../shared/v1.0.0/tag.schema.json#/$defs/tag"}
+  },
+  "$defs": {"item": {"type": "string"}}
+}`,
+		"app/v1.0.0/item.schema.json": `{
+  "$id": "https://example.com/item.json",
+  "type": "object",
+  "properties": {"name": {"$ref": "#/$defs/name"}, "parent": {"$ref": "root.schema.json"}},
+  "$defs": {"name": {"type": "string", "minLength": 1}}
+}`,
+		"shared/v1.0.0/tag.schema.json": `$defs:
+  tag:
+    type: string
+    enum: [a, b]
+`,
+	}
+	source := func(p string) ([]byte, error) {
+		data, ok := files[p]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(data), nil
+	}
+
+	bundled, err := NewBundler(source).Bundle("app/v1.0.0/root.schema.json")
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(bundled, &doc); err != nil {
+		t.Fatalf("bundled schema is not JSON: %v", err)
+	}
+	defs := doc["$defs"].(map[string]any)
+	if _, ok := defs["item-2"]; !ok {
+		t.Fatalf("expected colliding definition to be renamed item-2, got %v", defs)
+	}
+	item := defs["item-2"].(map[string]any)
+	if _, ok := item["$id"]; ok {
+		t.Error("expected $id to be stripped from bundled definition")
+	}
+	props := item["properties"].(map[string]any)
+	if ref := props["name"].(map[string]any)["$ref"]; ref != "#/$defs/item-2/$defs/name" {
+		t.Errorf("unexpected rewritten local ref %v", ref)
+	}
+	if ref := props["parent"].(map[string]any)["$ref"]; ref != "#" {
+		t.Errorf("expected cyclic ref to root to become #, got %v", ref)
+	}
+	rootProps := doc["properties"].(map[string]any)
+	if ref := rootProps["tag"].(map[string]any)["$ref"]; ref != "#/$defs/tag/$defs/tag" {
+		t.Errorf("unexpected rewritten fragment ref %v", ref)
+	}
+
+	validator, err := NewValidator(bundled)
+	if err != nil {
+		t.Fatalf("bundled schema failed to compile: %v", err)
+	}
+	diags, err := validator.ValidateJSON([]byte(`{"item":{"name":""},"tag":"c"}`))
+	if err != nil {
+		t.Fatalf("validation failed: %v", err)
+	}
+	if len(diags) < 2 {
+		t.Fatalf("expected diagnostics from both bundled documents, got %v", diags)
+	}
+}
+
+func TestBundlerUnresolvedRefs(t *testing.T) {
+	tests := map[string]string{
+		"remote":  `{"$ref": "https://example.com/other.json"}`,
+		"missing": `{"$ref": "missing.schema.json"}`,
+		"escape":  `{"$ref": "../../outside.schema.json"}`,
+	}
+	for name, root := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := func(p string) ([]byte, error) {
+				if p == "a/root.schema.json" {
+					return []byte(root), nil
+				}
+				return nil, os.ErrNotExist
+			}
+			_, err := NewBundler(source).Bundle("a/root.schema.json")
+			if !errors.Is(err, ErrUnresolvedRef) {
+				t.Fatalf("expected ErrUnresolvedRef, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSchemaURLPath(t *testing.T) {
+	tests := map[string]string{
+		"crucible/observability/logging/middleware-config-v1.0.0.json": "observability/logging/v1.0.0/middleware-config.schema.json",
+		"library/fulpack/v1.0.0/archive-entry":                         "library/fulpack/v1.0.0/archive-entry.schema.json",
+		"crucible/ascii/string-analysis-v1.2.0.json":                   "ascii/v1.2.0/string-analysis.schema.json",
+	}
+	for in, want := range tests {
+		if got := schemaURLPath(in); got != want {
+			t.Errorf("schemaURLPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package schema
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// defaultValidatorCacheSize bounds the process-wide cache used by CachedValidator.
+const defaultValidatorCacheSize = 256
+
+// ValidatorCacheStats reports ValidatorCache activity.
+type ValidatorCacheStats struct {
+	Entries   int
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// ValidatorCache caches compiled validators keyed by schema ID and a SHA-256
+// hash of the schema content, so edited schemas are recompiled automatically.
+// A positive capacity bounds the cache with least-recently-used eviction;
+// zero means unbounded. It is safe for concurrent use.
+//
+// Example:
+//
+//	cache := schema.NewValidatorCache(64)
+//	validator, err := cache.Validator("app/config", schemaBytes)
+//	if err != nil {
+//	    return err
+//	}
+//	diags, err := validator.ValidateJSON(payload)
+type ValidatorCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[validatorKey]*list.Element
+	order    *list.List // front = most recently used
+	stats    ValidatorCacheStats
+}
+
+type validatorKey struct {
+	id   string
+	hash string
+}
+
+type validatorEntry struct {
+	key       validatorKey
+	validator *Validator
+}
+
+// NewValidatorCache creates a cache holding at most capacity validators (0 = unbounded).
+func NewValidatorCache(capacity int) *ValidatorCache {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &ValidatorCache{
+		capacity: capacity,
+		entries:  make(map[validatorKey]*list.Element),
+		order:    list.New(),
+	}
+}
+
+var (
+	defaultValidatorCacheOnce sync.Once
+	defaultValidatorCacheInst *ValidatorCache
+)
+
+// DefaultValidatorCache returns the process-wide validator cache used by CachedValidator.
+func DefaultValidatorCache() *ValidatorCache {
+	defaultValidatorCacheOnce.Do(func() {
+		defaultValidatorCacheInst = NewValidatorCache(defaultValidatorCacheSize)
+	})
+	return defaultValidatorCacheInst
+}
+
+// CachedValidator returns a validator for schemaData from the process-wide
+// cache, compiling it with NewValidator on first use.
+func CachedValidator(id string, schemaData []byte) (*Validator, error) {
+	return DefaultValidatorCache().Validator(id, schemaData)
+}
+
+// Validator returns the cached validator for id and schemaData, compiling it
+// with NewValidator on a miss.
+func (vc *ValidatorCache) Validator(id string, schemaData []byte) (*Validator, error) {
+	return vc.getOrCompile(id, schemaData, func() (*Validator, error) {
+		return NewValidator(schemaData)
+	})
+}
+
+// newValidatorKey keys content under id by its SHA-256 hash.
+func newValidatorKey(id string, content []byte) validatorKey {
+	sum := sha256.Sum256(content)
+	return validatorKey{id: id, hash: hex.EncodeToString(sum[:])}
+}
+
+// getOrCompile looks up (id, hash(content)) and calls compile on a miss.
+func (vc *ValidatorCache) getOrCompile(id string, content []byte, compile func() (*Validator, error)) (*Validator, error) {
+	return vc.getOrCompileKey(newValidatorKey(id, content), compile)
+}
+
+// getOrCompileKey looks up key and calls compile on a miss. Compilation
+// happens outside the lock; entries for the key's id with a different
+// content hash are dropped when the new validator is stored.
+func (vc *ValidatorCache) getOrCompileKey(key validatorKey, compile func() (*Validator, error)) (*Validator, error) {
+	id := key.id
+	vc.mu.Lock()
+	if elem, ok := vc.entries[key]; ok {
+		vc.order.MoveToFront(elem)
+		vc.stats.Hits++
+		vc.mu.Unlock()
+		return elem.Value.(*validatorEntry).validator, nil
+	}
+	vc.stats.Misses++
+	vc.mu.Unlock()
+
+	validator, err := compile()
+	if err != nil {
+		return nil, err
+	}
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	if elem, ok := vc.entries[key]; ok {
+		// Another goroutine compiled the same schema concurrently
+		vc.order.MoveToFront(elem)
+		return elem.Value.(*validatorEntry).validator, nil
+	}
+	vc.removeLocked(func(k validatorKey) bool { return k.id == id })
+	vc.entries[key] = vc.order.PushFront(&validatorEntry{key: key, validator: validator})
+	for vc.capacity > 0 && vc.order.Len() > vc.capacity {
+		oldest := vc.order.Back()
+		vc.order.Remove(oldest)
+		delete(vc.entries, oldest.Value.(*validatorEntry).key)
+		vc.stats.Evictions++
+	}
+	return validator, nil
+}
+
+// touch records a hit for key and reports whether it is still cached.
+func (vc *ValidatorCache) touch(key validatorKey) bool {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	elem, ok := vc.entries[key]
+	if ok {
+		vc.order.MoveToFront(elem)
+		vc.stats.Hits++
+	}
+	return ok
+}
+
+// Invalidate removes every cached validator for the schema ID and reports
+// how many were removed.
+func (vc *ValidatorCache) Invalidate(id string) int {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	return vc.removeLocked(func(k validatorKey) bool { return k.id == id })
+}
+
+// Purge removes all cached validators.
+func (vc *ValidatorCache) Purge() {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	vc.entries = make(map[validatorKey]*list.Element)
+	vc.order.Init()
+}
+
+// Len returns the number of cached validators.
+func (vc *ValidatorCache) Len() int {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	return vc.order.Len()
+}
+
+// Stats returns a snapshot of cache activity.
+func (vc *ValidatorCache) Stats() ValidatorCacheStats {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	stats := vc.stats
+	stats.Entries = vc.order.Len()
+	return stats
+}
+
+func (vc *ValidatorCache) removeLocked(match func(validatorKey) bool) int {
+	removed := 0
+	for key, elem := range vc.entries {
+		if match(key) {
+			vc.order.Remove(elem)
+			delete(vc.entries, key)
+			removed++
+		}
+	}
+	return removed
+}
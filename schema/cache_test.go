@@ -0,0 +1,227 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const cacheSchemaV1 = `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","required":["name"]}`
+const cacheSchemaV2 = `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","required":["name","port"]}`
+
+func TestValidatorCache(t *testing.T) {
+	cache := NewValidatorCache(2)
+
+	first, err := cache.Validator("a", []byte(cacheSchemaV1))
+	if err != nil {
+		t.Fatalf("Validator failed: %v", err)
+	}
+	again, _ := cache.Validator("a", []byte(cacheSchemaV1))
+	if first != again {
+		t.Error("expected cached validator for identical content")
+	}
+
+	changed, _ := cache.Validator("a", []byte(cacheSchemaV2))
+	if changed == first {
+		t.Error("expected recompilation when content changes")
+	}
+	if cache.Len() != 1 {
+		t.Errorf("expected stale entry for same ID to be replaced, got %d entries", cache.Len())
+	}
+
+	_, _ = cache.Validator("b", []byte(cacheSchemaV1))
+	_, _ = cache.Validator("a", []byte(cacheSchemaV2)) // touch a so b is least recently used
+	_, _ = cache.Validator("c", []byte(cacheSchemaV1))
+	stats := cache.Stats()
+	if stats.Entries != 2 || stats.Evictions != 1 || stats.Hits != 2 || stats.Misses != 4 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if n := cache.Invalidate("b"); n != 0 {
+		t.Errorf("expected b to have been evicted, removed %d", n)
+	}
+	if n := cache.Invalidate("a"); n != 1 {
+		t.Errorf("expected to invalidate a, removed %d", n)
+	}
+	cache.Purge()
+	if cache.Len() != 0 {
+		t.Errorf("expected empty cache after Purge, got %d", cache.Len())
+	}
+
+	if _, err := cache.Validator("bad", []byte(`{"type": 5}`)); err == nil {
+		t.Error("expected compile error")
+	}
+	if cache.Len() != 0 {
+		t.Error("failed compilation should not be cached")
+	}
+}
+
+func TestCatalogValidatorByIDRecompilesOnChange(t *testing.T) {
+	dir := t.TempDir()
+	schemaDir := filepath.Join(dir, "app", "v1.0.0")
+	if err := os.MkdirAll(schemaDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	schemaPath := filepath.Join(schemaDir, "config.schema.json")
+	if err := os.WriteFile(schemaPath, []byte(cacheSchemaV1), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	catalog := NewCatalog(dir)
+	v1, err := catalog.ValidatorByID("app/v1.0.0/config")
+	if err != nil {
+		t.Fatalf("ValidatorByID failed: %v", err)
+	}
+	if cached, _ := catalog.ValidatorByID("app/v1.0.0/config"); cached != v1 {
+		t.Error("expected cached validator")
+	}
+
+	if err := os.WriteFile(schemaPath, []byte(cacheSchemaV2), 0600); err != nil {
+		t.Fatal(err)
+	}
+	diags, err := catalog.ValidateDataByID("app/v1.0.0/config", []byte(`{"name":"svc"}`))
+	if err != nil {
+		t.Fatalf("ValidateDataByID failed: %v", err)
+	}
+	if len(diags) == 0 {
+		t.Error("expected edited schema to require port")
+	}
+
+	catalog.InvalidateValidator("app/v1.0.0/config")
+	if stats := catalog.ValidatorCacheStats(); stats.Entries != 0 || stats.Hits != 1 {
+		t.Errorf("unexpected stats after invalidation %+v", stats)
+	}
+}
+
+func TestCatalogValidatorByIDSkipsUnchangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	schemaDir := filepath.Join(dir, "app", "v1.0.0")
+	if err := os.MkdirAll(schemaDir, 0750); err != nil {
+		t.Fatal(err)
+	}
+	schemaPath := filepath.Join(schemaDir, "config.schema.json")
+	if err := os.WriteFile(schemaPath, []byte(cacheSchemaV2), 0600); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(schemaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	catalog := NewCatalog(dir)
+	v1, err := catalog.ValidatorByID("app/v1.0.0/config")
+	if err != nil {
+		t.Fatalf("ValidatorByID failed: %v", err)
+	}
+
+	// Same size and mtime: the file is not re-read, so the edit goes unnoticed
+	edited := strings.Replace(cacheSchemaV2, `"port"`, `"host"`, 1)
+	if err := os.WriteFile(schemaPath, []byte(edited), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(schemaPath, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if cached, _ := catalog.ValidatorByID("app/v1.0.0/config"); cached != v1 {
+		t.Error("expected cached validator while size and mtime are unchanged")
+	}
+
+	// Invalidation forces a re-read
+	catalog.InvalidateValidator("app/v1.0.0/config")
+	v2, err := catalog.ValidatorByID("app/v1.0.0/config")
+	if err != nil || v2 == v1 {
+		t.Fatalf("expected recompiled validator after invalidation, got %v", err)
+	}
+	if diags, _ := v2.ValidateData(map[string]any{"name": "svc", "port": 80}); len(diags) == 0 {
+		t.Error("expected edited schema to require host")
+	}
+
+	// Catalogs over different trees key their validators apart
+	if id := catalog.cacheID("app/v1.0.0/config"); !strings.HasPrefix(id, dir) {
+		t.Errorf("cache ID %q should include the catalog base directory", id)
+	}
+}
+
+// benchmarkEvent is a representative structured log event, validated once per
+// emission on hot telemetry paths.
+var benchmarkEvent = map[string]any{
+	"timestamp":     "2025-10-13T14:32:15.123456789Z",
+	"severity":      "INFO",
+	"message":       "request completed",
+	"service":       "api",
+	"correlationId": "018f3c1e-7a2b-7c3d-8e4f-5a6b7c8d9e0f",
+	"context":       map[string]any{"status": 200},
+}
+
+const benchmarkSchemaID = "observability/logging/v1.0.0/log-event"
+
+// BenchmarkValidateUncached compiles the log-event schema on every call
+// (the cost paid before validators were cached).
+func BenchmarkValidateUncached(b *testing.B) {
+	catalog := DefaultCatalog()
+	desc, err := catalog.GetSchema(benchmarkSchemaID)
+	if err != nil {
+		b.Skipf("log-event schema unavailable: %v", err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		validator, err := newValidatorFromDescriptor(desc, catalog.metaDir, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := validator.ValidateData(benchmarkEvent); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkValidateByIDCached measures ValidatorByID (stat and cached lookup) plus validation.
+func BenchmarkValidateByIDCached(b *testing.B) {
+	catalog := DefaultCatalog()
+	if _, err := catalog.ValidatorByID(benchmarkSchemaID); err != nil {
+		b.Skipf("log-event schema unavailable: %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		validator, err := catalog.ValidatorByID(benchmarkSchemaID)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := validator.ValidateData(benchmarkEvent); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkValidateHeldValidator is the hot-path floor: validating with a
+// validator obtained once, as telemetry.NewSystem does.
+func BenchmarkValidateHeldValidator(b *testing.B) {
+	validator, err := DefaultCatalog().ValidatorByID(benchmarkSchemaID)
+	if err != nil {
+		b.Skipf("log-event schema unavailable: %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := validator.ValidateData(benchmarkEvent); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCachedValidator measures the process-wide cache for raw schema bytes.
+func BenchmarkCachedValidator(b *testing.B) {
+	data := []byte(cacheSchemaV1)
+	payload := map[string]any{"name": "svc"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		validator, err := CachedValidator("bench/raw", data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := validator.ValidateData(payload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
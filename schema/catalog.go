@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -43,8 +44,21 @@ type Catalog struct {
 
 	mu          sync.RWMutex
 	descriptors map[string]SchemaDescriptor
-	validators  map[string]*Validator
+	validators  *ValidatorCache
 	loaded      bool
+	compileOpts *CompileOptions
+
+	fastMu sync.Mutex
+	fast   map[string]catalogValidator
+}
+
+// catalogValidator records the file state a cached validator was compiled
+// from, so ValidatorByID can skip reading and hashing unchanged schemas.
+type catalogValidator struct {
+	key       validatorKey
+	validator *Validator
+	modTime   time.Time
+	size      int64
 }
 
 // NewCatalog creates a catalog rooted at baseDir.
@@ -55,10 +69,26 @@ func NewCatalog(baseDir string) *Catalog {
 		baseDir:     baseDir,
 		metaDir:     metaDir,
 		descriptors: make(map[string]SchemaDescriptor),
-		validators:  make(map[string]*Validator),
+		validators:  NewValidatorCache(0),
+		fast:        make(map[string]catalogValidator),
 	}
 }
 
+// WithCompileOptions returns a new catalog over the same schemas whose
+// validators are compiled with opts (e.g. to assert registered formats).
+//
+// Example:
+//
+//	catalog := schema.DefaultCatalog().WithCompileOptions(&schema.CompileOptions{AssertFormats: true})
+func (c *Catalog) WithCompileOptions(opts *CompileOptions) *Catalog {
+	clone := NewCatalog(c.baseDir)
+	if opts != nil {
+		copied := *opts
+		clone.compileOpts = &copied
+	}
+	return clone
+}
+
 // DefaultCatalog returns a catalog rooted at the synced Crucible schemas directory.
 func DefaultCatalog() *Catalog {
 	return globalCatalog()
@@ -120,34 +150,66 @@ func (c *Catalog) CompareSchema(id string, other []byte) ([]SchemaDiff, error) {
 	return diffs, nil
 }
 
-// ValidatorByID returns (and caches) a validator for the schema ID.
+// ValidatorByID returns (and caches) a validator for the schema ID. While the
+// schema file's modification time and size are unchanged the cached validator
+// is returned directly; otherwise the file is re-read and looked up by content
+// hash, so a schema edited on disk is recompiled on the next call.
 func (c *Catalog) ValidatorByID(id string) (*Validator, error) {
 	desc, err := c.GetSchema(id)
 	if err != nil {
 		return nil, err
 	}
 
-	c.mu.RLock()
-	if v, ok := c.validators[id]; ok {
-		c.mu.RUnlock()
-		return v, nil
+	info, err := os.Stat(desc.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema %s: %w", id, err)
 	}
-	c.mu.RUnlock()
-
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	if v, ok := c.validators[id]; ok {
-		return v, nil
+	c.fastMu.Lock()
+	cached, ok := c.fast[id]
+	c.fastMu.Unlock()
+	if ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() && c.validators.touch(cached.key) {
+		return cached.validator, nil
 	}
 
-	validator, err := newValidatorFromDescriptor(desc, c.metaDir)
+	content, err := os.ReadFile(desc.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema %s: %w", id, err)
+	}
+	// Qualify the key with baseDir so catalogs over different trees never
+	// share validators for the same ID
+	key := newValidatorKey(c.cacheID(id), content)
+	validator, err := c.validators.getOrCompileKey(key, func() (*Validator, error) {
+		return newValidatorFromDescriptor(desc, c.metaDir, c.compileOpts)
+	})
 	if err != nil {
 		return nil, err
 	}
-	c.validators[id] = validator
+
+	c.fastMu.Lock()
+	c.fast[id] = catalogValidator{key: key, validator: validator, modTime: info.ModTime(), size: info.Size()}
+	c.fastMu.Unlock()
 	return validator, nil
 }
 
+// InvalidateValidator drops the cached validator for the schema ID (e.g. after
+// a schema it references has changed).
+func (c *Catalog) InvalidateValidator(id string) {
+	c.fastMu.Lock()
+	delete(c.fast, id)
+	c.fastMu.Unlock()
+	c.validators.Invalidate(c.cacheID(id))
+}
+
+// cacheID is the validator cache ID for a schema in this catalog.
+func (c *Catalog) cacheID(id string) string {
+	return c.baseDir + "#" + id
+}
+
+// ValidatorCacheStats reports activity of the catalog's validator cache.
+func (c *Catalog) ValidatorCacheStats() ValidatorCacheStats {
+	return c.validators.Stats()
+}
+
 func (c *Catalog) ensureLoaded() error {
 	c.mu.RLock()
 	if c.loaded {
@@ -0,0 +1,207 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateOptions tunes ValidateDataWithOptions and ValidateFileWithOptions.
+// A nil *ValidateOptions behaves like ValidateData.
+type ValidateOptions struct {
+	// ApplyDefaults fills absent object properties (and a null document) from
+	// the schema's `default` keywords before validating.
+	ApplyDefaults bool
+}
+
+// ValidationResult is the outcome of ValidateDataWithOptions.
+type ValidationResult struct {
+	// Diagnostics lists validation failures (empty when valid).
+	Diagnostics []Diagnostic
+
+	// Document is the validated document. With ApplyDefaults it is a copy of
+	// the input with defaults filled in; the input itself is never modified.
+	Document interface{}
+}
+
+// Valid reports whether validation produced no diagnostics.
+func (r *ValidationResult) Valid() bool {
+	return len(r.Diagnostics) == 0
+}
+
+// ValidateDataWithOptions validates data and returns the (optionally
+// default-hydrated) document alongside the diagnostics, so configuration can
+// be validated and defaulted in one step.
+//
+// Defaults are applied through properties, additionalProperties,
+// patternProperties, items/prefixItems, $ref, allOf, and if/then/else (the
+// branch selected by the instance). anyOf/oneOf branches are not applied
+// because the intended branch is ambiguous.
+//
+// Example:
+//
+//	result, err := validator.ValidateDataWithOptions(cfg, &schema.ValidateOptions{ApplyDefaults: true})
+//	if err != nil {
+//	    return err
+//	}
+//	if !result.Valid() {
+//	    return fmt.Errorf("invalid config: %d issue(s)", len(result.Diagnostics))
+//	}
+//	hydrated := result.Document.(map[string]interface{})
+func (v *Validator) ValidateDataWithOptions(data interface{}, opts *ValidateOptions) (*ValidationResult, error) {
+	document := data
+	if opts != nil && opts.ApplyDefaults {
+		document = v.ApplyDefaults(data)
+	}
+
+	diags, err := v.ValidateData(document)
+	if err != nil {
+		return nil, err
+	}
+	return &ValidationResult{Diagnostics: diags, Document: document}, nil
+}
+
+// ValidateFileWithOptions validates a JSON or YAML file like ValidateFile and
+// returns the parsed (optionally default-hydrated) document.
+func (v *Validator) ValidateFileWithOptions(path string, opts *ValidateOptions) (*ValidationResult, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- User-provided path is intentional for validation API
+	if err != nil {
+		return nil, err
+	}
+
+	var payload interface{}
+	if isJSON(content) {
+		if err := json.Unmarshal(content, &payload); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	} else if err := yaml.Unmarshal(content, &payload); err != nil {
+		return nil, err
+	}
+	return v.ValidateDataWithOptions(payload, opts)
+}
+
+// ApplyDefaults returns a copy of data with schema defaults filled in (see
+// ValidateDataWithOptions for the keywords followed). data is not modified.
+func (v *Validator) ApplyDefaults(data interface{}) interface{} {
+	return applyDefaults(v.schema, deepCopyValue(data), make(map[*jsonschema.Schema]int))
+}
+
+// maxDefaultsDepth bounds recursion through self-referencing schemas.
+const maxDefaultsDepth = 32
+
+func applyDefaults(s *jsonschema.Schema, value interface{}, visiting map[*jsonschema.Schema]int) interface{} {
+	if s == nil || visiting[s] >= maxDefaultsDepth {
+		return value
+	}
+	visiting[s]++
+	defer func() { visiting[s]-- }()
+
+	if value == nil && s.Default != nil {
+		value = defaultValue(s.Default)
+	}
+
+	for _, ref := range []*jsonschema.Schema{s.Ref, s.DynamicRef, s.RecursiveRef} {
+		value = applyDefaults(ref, value, visiting)
+	}
+	for _, sub := range s.AllOf {
+		value = applyDefaults(sub, value, visiting)
+	}
+	if s.If != nil {
+		if s.If.Validate(value) == nil {
+			value = applyDefaults(s.Then, value, visiting)
+		} else {
+			value = applyDefaults(s.Else, value, visiting)
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for name, prop := range s.Properties {
+			if _, ok := typed[name]; !ok && prop.Default != nil {
+				typed[name] = defaultValue(prop.Default)
+			}
+		}
+		for name, member := range typed {
+			if prop, ok := s.Properties[name]; ok {
+				typed[name] = applyDefaults(prop, member, visiting)
+				continue
+			}
+			matched := false
+			for pattern, sub := range s.PatternProperties {
+				if pattern.MatchString(name) {
+					matched = true
+					typed[name] = applyDefaults(sub, typed[name], visiting)
+				}
+			}
+			if additional, ok := s.AdditionalProperties.(*jsonschema.Schema); ok && !matched {
+				typed[name] = applyDefaults(additional, member, visiting)
+			}
+		}
+	case []interface{}:
+		prefix := s.PrefixItems
+		if items, ok := s.Items.([]*jsonschema.Schema); ok {
+			prefix = items
+		}
+		rest := s.Items2020
+		if items, ok := s.Items.(*jsonschema.Schema); ok {
+			rest = items
+		}
+		for i := range typed {
+			if i < len(prefix) {
+				typed[i] = applyDefaults(prefix[i], typed[i], visiting)
+			} else {
+				typed[i] = applyDefaults(rest, typed[i], visiting)
+			}
+		}
+	}
+	return value
+}
+
+// defaultValue copies a schema default, converting json.Number (as decoded by
+// the schema compiler) to int64 or float64 so hydrated values are plain Go numbers.
+func defaultValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case json.Number:
+		if i, err := typed.Int64(); err == nil {
+			return i
+		}
+		f, _ := typed.Float64()
+		return f
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			out[k] = defaultValue(v)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(typed))
+		for i, v := range typed {
+			out[i] = defaultValue(v)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+func deepCopyValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			out[k] = deepCopyValue(v)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(typed))
+		for i, v := range typed {
+			out[i] = deepCopyValue(v)
+		}
+		return out
+	default:
+		return value
+	}
+}
@@ -0,0 +1,103 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const defaultsSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["name", "port"],
+  "properties": {
+    "name": {"type": "string"},
+    "port": {"type": "integer", "default": 8080},
+    "server": {"$ref": "#/$defs/server", "default": {}},
+    "sinks": {"type": "array", "items": {"$ref": "#/$defs/sink"}},
+    "labels": {"type": "object", "additionalProperties": {"type": "object", "properties": {"enabled": {"default": true}}}}
+  },
+  "if": {"properties": {"name": {"const": "prod"}}},
+  "then": {"properties": {"debug": {"default": false}}},
+  "else": {"properties": {"debug": {"default": true}}},
+  "$defs": {
+    "server": {"type": "object", "properties": {"timeout": {"type": "string", "default": "30s"}, "tls": {"type": "boolean", "default": false}}},
+    "sink": {"type": "object", "properties": {"type": {"type": "string"}, "level": {"type": "string", "default": "INFO"}}}
+  }
+}`
+
+func TestValidateDataWithDefaults(t *testing.T) {
+	validator, err := NewValidator([]byte(defaultsSchema))
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	input := map[string]interface{}{
+		"name":   "prod",
+		"sinks":  []interface{}{map[string]interface{}{"type": "console"}, map[string]interface{}{"type": "file", "level": "WARN"}},
+		"labels": map[string]interface{}{"team": map[string]interface{}{}},
+	}
+
+	// Without defaults the required port is missing
+	plain, err := validator.ValidateDataWithOptions(input, nil)
+	if err != nil {
+		t.Fatalf("ValidateDataWithOptions failed: %v", err)
+	}
+	if plain.Valid() {
+		t.Error("expected missing port without ApplyDefaults")
+	}
+
+	result, err := validator.ValidateDataWithOptions(input, &ValidateOptions{ApplyDefaults: true})
+	if err != nil {
+		t.Fatalf("ValidateDataWithOptions failed: %v", err)
+	}
+	if !result.Valid() {
+		t.Fatalf("expected hydrated document to be valid, got %v", result.Diagnostics)
+	}
+
+	doc := result.Document.(map[string]interface{})
+	if doc["port"] != int64(8080) {
+		t.Errorf("port default = %#v", doc["port"])
+	}
+	server := doc["server"].(map[string]interface{})
+	if server["timeout"] != "30s" || server["tls"] != false {
+		t.Errorf("nested $ref defaults not applied: %v", server)
+	}
+	sinks := doc["sinks"].([]interface{})
+	if sinks[0].(map[string]interface{})["level"] != "INFO" || sinks[1].(map[string]interface{})["level"] != "WARN" {
+		t.Errorf("item defaults wrong: %v", sinks)
+	}
+	if doc["labels"].(map[string]interface{})["team"].(map[string]interface{})["enabled"] != true {
+		t.Errorf("additionalProperties defaults not applied: %v", doc["labels"])
+	}
+	if doc["debug"] != false {
+		t.Errorf("expected then-branch default debug=false, got %v", doc["debug"])
+	}
+
+	if _, ok := input["port"]; ok {
+		t.Error("input document must not be modified")
+	}
+	if _, ok := input["sinks"].([]interface{})[0].(map[string]interface{})["level"]; ok {
+		t.Error("nested input values must not be modified")
+	}
+}
+
+func TestValidateFileWithDefaults(t *testing.T) {
+	validator, err := NewValidator([]byte(defaultsSchema))
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("name: dev\nport: 9000\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	result, err := validator.ValidateFileWithOptions(path, &ValidateOptions{ApplyDefaults: true})
+	if err != nil {
+		t.Fatalf("ValidateFileWithOptions failed: %v", err)
+	}
+	doc := result.Document.(map[string]interface{})
+	if !result.Valid() || doc["port"] != 9000 || doc["debug"] != true {
+		t.Errorf("unexpected result %v %v", result.Diagnostics, doc)
+	}
+}
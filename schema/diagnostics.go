@@ -19,6 +19,12 @@ const (
 	sourceGoneat   = "goneat"
 )
 
+// SourceLocation is a 1-based line/column position in a source document.
+type SourceLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
 // Diagnostic captures a validation or schema compilation diagnostic.
 type Diagnostic struct {
 	Pointer  string        `json:"pointer"`
@@ -26,6 +32,13 @@ type Diagnostic struct {
 	Message  string        `json:"message"`
 	Severity SeverityLevel `json:"severity"`
 	Source   string        `json:"source"`
+	// Location is the diagnostic's own source position, for checks that run
+	// on the source document (CheckYAML). Renderer locates the others by
+	// Pointer.
+	Location *SourceLocation `json:"location,omitempty"`
+	// Profile is the validation profile the diagnostic was produced under,
+	// for the ...WithProfile methods; empty otherwise.
+	Profile ValidationProfile `json:"profile,omitempty"`
 }
 
 // DiagnosticsToValidationErrors converts diagnostics into ValidationErrors (for legacy callers).
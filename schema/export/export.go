@@ -28,6 +28,18 @@ func Export(ctx context.Context, opts ExportOptions) error {
 	if err != nil {
 		return fmt.Errorf("%w: %q: %v", ErrSchemaNotFound, opts.SchemaID, err)
 	}
+	schemaData, err = normalizeSourceJSON(schemaData)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSchemaValidation, err)
+	}
+
+	// Inline external references if requested
+	if opts.Bundle {
+		schemaData, err = schema.NewBundler(crucible.GetSchema).Bundle(opts.SchemaID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrSchemaBundle, err)
+		}
+	}
 
 	// Validate schema if requested
 	if opts.ValidateSchema {
@@ -96,6 +108,8 @@ func validateSchemaData(data []byte) error {
 // 1. Validates the exported schema structure (skipped if references are unresolved)
 // 2. Strips provenance metadata from the exported schema
 // 3. Compares the payload byte-for-byte with the source from Crucible
+//
+// Bundled exports differ from their source by design and will not match.
 func ValidateExportedSchema(ctx context.Context, schemaID, filePath string) error {
 	return validateExportedSchemaWithOptions(ctx, schemaID, filePath, true)
 }
@@ -120,6 +134,10 @@ func validateExportedSchemaWithOptions(ctx context.Context, schemaID, filePath s
 	if err != nil {
 		return fmt.Errorf("failed to load source schema: %w", err)
 	}
+	sourceData, err = normalizeSourceJSON(sourceData)
+	if err != nil {
+		return fmt.Errorf("failed to load source schema: %w", err)
+	}
 
 	// Validate that the source schema is also valid (optional)
 	if validateStructure {
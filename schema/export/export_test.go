@@ -90,6 +90,30 @@ func TestExportYAML(t *testing.T) {
 	require.NoError(t, err, "Exported data should be valid YAML")
 }
 
+func TestExportBundle(t *testing.T) {
+	ctx := context.Background()
+	tempDir := t.TempDir()
+	outPath := filepath.Join(tempDir, "bundled-schema.json")
+
+	opts := NewExportOptions("observability/logging/v1.0.0/logger-config.schema.json", outPath)
+	opts.Bundle = true
+	opts.IncludeProvenance = false
+
+	err := Export(ctx, opts)
+	require.NoError(t, err, "Bundled export should succeed")
+
+	data, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"$ref": "https://`, "Bundle should not contain remote references")
+
+	var jsonData map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &jsonData))
+	defs, ok := jsonData["$defs"].(map[string]interface{})
+	require.True(t, ok, "Bundle should have $defs")
+	assert.Contains(t, defs, "middleware-config")
+	assert.Contains(t, defs, "severity-filter")
+}
+
 func TestExportOverwrite(t *testing.T) {
 	ctx := context.Background()
 	tempDir := t.TempDir()
@@ -100,3 +100,18 @@ func formatYAML(schemaData []byte, metadata *ProvenanceMetadata, style Provenanc
 
 	return buf.Bytes(), nil
 }
+
+// normalizeSourceJSON converts a YAML-authored Crucible schema to JSON;
+// JSON sources are returned unchanged.
+func normalizeSourceJSON(data []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return data, nil
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to parse schema YAML: %w", err)
+	}
+	return json.Marshal(value)
+}
@@ -100,6 +100,11 @@ type ExportOptions struct {
 	// Default: false (refuse to overwrite existing files)
 	Overwrite bool
 
+	// Bundle inlines external $ref targets so the exported schema is self-contained
+	// and validates offline (see schema.Bundler)
+	// Default: false
+	Bundle bool
+
 	// IdentityProvider optionally provides application identity for provenance
 	// Default: nil (no identity information included)
 	IdentityProvider IdentityProvider
@@ -20,6 +20,9 @@ var (
 	// ErrSchemaValidation is returned when schema validation fails
 	ErrSchemaValidation = errors.New("schema validation failed")
 
+	// ErrSchemaBundle is returned when the schema's references cannot be bundled
+	ErrSchemaBundle = errors.New("schema bundling failed")
+
 	// ErrFileWrite is returned when writing the output file fails
 	ErrFileWrite = errors.New("failed to write file")
 )
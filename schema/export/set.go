@@ -0,0 +1,266 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/crucible"
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/fulhash"
+)
+
+// ManifestVersion is the version of the index manifest written by ExportSet.
+const ManifestVersion = "1.0.0"
+
+// DefaultManifestName is the manifest file name used when ExportSetOptions.ManifestName is empty.
+const DefaultManifestName = "index.json"
+
+var versionSegment = regexp.MustCompile(`^v\d+\.\d+\.\d+`)
+
+// ExportSetOptions configures a batch export of a schema family
+type ExportSetOptions struct {
+	// Prefix selects schemas whose Crucible path starts with it
+	// (e.g., "observability/logging"). Empty exports every schema except metaschemas.
+	Prefix string
+
+	// OutDir is the destination directory; schemas are written under their Crucible path.
+	// This is REQUIRED.
+	OutDir string
+
+	// Format forces JSON or YAML output for every schema
+	// Default: FormatAuto (keep each schema's source format)
+	Format Format
+
+	// ManifestName is the index manifest file name written to OutDir
+	// Default: DefaultManifestName
+	ManifestName string
+
+	// IncludeProvenance, ProvenanceStyle, ValidateSchema, Overwrite, Bundle, and
+	// IdentityProvider apply to each exported schema (see ExportOptions)
+	IncludeProvenance bool
+	ProvenanceStyle   ProvenanceStyle
+	ValidateSchema    bool
+	Overwrite         bool
+	Bundle            bool
+	IdentityProvider  IdentityProvider
+}
+
+// NewExportSetOptions creates ExportSetOptions with the same defaults as NewExportOptions
+func NewExportSetOptions(prefix, outDir string) ExportSetOptions {
+	return ExportSetOptions{
+		Prefix:            prefix,
+		OutDir:            outDir,
+		Format:            FormatAuto,
+		ManifestName:      DefaultManifestName,
+		IncludeProvenance: true,
+		ProvenanceStyle:   ProvenanceObject,
+		ValidateSchema:    true,
+		IdentityProvider:  NewDefaultIdentityProvider(),
+	}
+}
+
+// Manifest indexes a batch export for vendoring into downstream repositories
+type Manifest struct {
+	ManifestVersion string          `json:"manifest_version"`
+	Prefix          string          `json:"prefix,omitempty"`
+	CrucibleVersion string          `json:"crucible_version"`
+	GofulmenVersion string          `json:"gofulmen_version"`
+	GitRevision     string          `json:"git_revision,omitempty"`
+	ExportedAt      time.Time       `json:"exported_at"`
+	Identity        *Identity       `json:"identity,omitempty"`
+	Schemas         []ManifestEntry `json:"schemas"`
+}
+
+// ManifestEntry describes one exported schema
+type ManifestEntry struct {
+	// SchemaID is the Crucible schema path (usable with Export and crucible.GetSchema)
+	SchemaID string `json:"schema_id"`
+	// Version is the schema version path segment (e.g., "v1.0.0"), if any
+	Version string `json:"version,omitempty"`
+	// Path is the exported file relative to the output directory (slash-separated)
+	Path string `json:"path"`
+	// Digest is the sha256 FulHash digest of the exported file
+	Digest string `json:"digest"`
+	// Bundled reports whether external references were inlined
+	Bundled bool `json:"bundled,omitempty"`
+}
+
+// ExportSet exports every schema under opts.Prefix into opts.OutDir, preserving
+// the Crucible directory layout, and writes an index manifest listing each
+// schema's ID, version, path, and digest. Export stops at the first failure.
+//
+// Example:
+//
+//	opts := export.NewExportSetOptions("observability/logging", "vendor/crucible/schemas")
+//	manifest, err := export.ExportSet(ctx, opts)
+func ExportSet(ctx context.Context, opts ExportSetOptions) (*Manifest, error) {
+	if opts.OutDir == "" {
+		return nil, fmt.Errorf("invalid export options: OutDir is required")
+	}
+	if opts.ManifestName == "" {
+		opts.ManifestName = DefaultManifestName
+	}
+
+	ids, err := ListSchemaIDs(opts.Prefix)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: no schemas match prefix %q", ErrSchemaNotFound, opts.Prefix)
+	}
+
+	manifest := &Manifest{
+		ManifestVersion: ManifestVersion,
+		Prefix:          opts.Prefix,
+		CrucibleVersion: foundry.CrucibleVersion(),
+		GofulmenVersion: foundry.GofulmenVersion(),
+		GitRevision:     getGitRevision(),
+		ExportedAt:      time.Now().UTC(),
+		Schemas:         make([]ManifestEntry, 0, len(ids)),
+	}
+	if opts.IdentityProvider != nil {
+		if identity, err := opts.IdentityProvider.GetIdentity(ctx); err == nil {
+			manifest.Identity = identity
+		}
+	}
+
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		relPath := exportSetPath(id, opts.Format)
+		outPath := filepath.Join(opts.OutDir, filepath.FromSlash(relPath))
+		exportOpts := ExportOptions{
+			SchemaID:          id,
+			OutPath:           outPath,
+			Format:            opts.Format,
+			IncludeProvenance: opts.IncludeProvenance,
+			ProvenanceStyle:   opts.ProvenanceStyle,
+			ValidateSchema:    opts.ValidateSchema,
+			Overwrite:         opts.Overwrite,
+			Bundle:            opts.Bundle,
+			IdentityProvider:  opts.IdentityProvider,
+		}
+		if err := Export(ctx, exportOpts); err != nil {
+			return nil, fmt.Errorf("export %s: %w", id, err)
+		}
+
+		data, err := os.ReadFile(outPath) // #nosec G304 -- path was just written by Export
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrFileWrite, err)
+		}
+		digest, err := fulhash.Hash(data, fulhash.WithAlgorithm(fulhash.SHA256))
+		if err != nil {
+			return nil, fmt.Errorf("failed to digest %s: %w", outPath, err)
+		}
+
+		manifest.Schemas = append(manifest.Schemas, ManifestEntry{
+			SchemaID: id,
+			Version:  schemaVersion(id),
+			Path:     relPath,
+			Digest:   digest.String(),
+			Bundled:  opts.Bundle,
+		})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	manifestPath := filepath.Join(opts.OutDir, opts.ManifestName)
+	if err := writeFileSafe(manifestPath, append(data, '\n'), opts.Overwrite); err != nil {
+		if errors.Is(err, ErrFileExists) || errors.Is(err, ErrPathValidation) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrFileWrite, err)
+	}
+
+	return manifest, nil
+}
+
+// ListSchemaIDs returns the Crucible schema paths under prefix, sorted.
+// Metaschemas under "meta/" are included only when the prefix selects them.
+func ListSchemaIDs(prefix string) ([]string, error) {
+	prefix = strings.Trim(prefix, "/")
+	root := prefix
+	// A prefix may end mid-segment (e.g., "observability/log"); list from its directory
+	if entries, err := crucible.ListSchemas(root); err != nil || len(entries) == 0 {
+		root = path.Dir(prefix)
+		if root == "." {
+			root = ""
+		}
+	}
+
+	var ids []string
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		entries, err := crucible.ListSchemas(dir)
+		if err != nil {
+			return err
+		}
+		for _, name := range entries {
+			id := path.Join(dir, name)
+			if isExportableSchema(name) {
+				if strings.HasPrefix(id, prefix) {
+					ids = append(ids, id)
+				}
+				continue
+			}
+			if dir == "" && name == "meta" && !strings.HasPrefix(prefix, "meta") {
+				continue
+			}
+			// Entries that are not directories fail to list and are skipped
+			_ = walk(id)
+		}
+		return nil
+	}
+	if err := walk(root); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSchemaNotFound, err)
+	}
+
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// isExportableSchema reports whether a Crucible file name is a schema document.
+func isExportableSchema(name string) bool {
+	for _, suffix := range []string{".schema.json", ".schema.yaml", ".schema.yml"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return name == "schema.json"
+}
+
+// exportSetPath returns the slash-separated output path for id in the given format.
+func exportSetPath(id string, format Format) string {
+	ext := path.Ext(id)
+	switch {
+	case format == FormatJSON && ext != ".json":
+		return strings.TrimSuffix(id, ext) + ".json"
+	case format == FormatYAML && ext == ".json":
+		return strings.TrimSuffix(id, ext) + ".yaml"
+	default:
+		return id
+	}
+}
+
+// schemaVersion returns the first version-like path segment of id (e.g., "v1.0.0").
+func schemaVersion(id string) string {
+	for _, segment := range strings.Split(id, "/") {
+		if versionSegment.MatchString(segment) {
+			return segment
+		}
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/fulhash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestListSchemaIDs(t *testing.T) {
+	ids, err := ListSchemaIDs("observability/logging")
+	require.NoError(t, err)
+	require.NotEmpty(t, ids)
+	assert.Contains(t, ids, testSchemaID)
+	for _, id := range ids {
+		assert.True(t, strings.HasPrefix(id, "observability/logging/"), id)
+	}
+
+	all, err := ListSchemaIDs("")
+	require.NoError(t, err)
+	assert.Greater(t, len(all), len(ids))
+	assert.Contains(t, all, testSchemaIDBox)
+	for _, id := range all {
+		assert.False(t, strings.HasPrefix(id, "meta/"), "metaschemas are excluded by default: %s", id)
+	}
+
+	meta, err := ListSchemaIDs("meta")
+	require.NoError(t, err)
+	assert.NotEmpty(t, meta)
+}
+
+func TestListSchemaIDsPartialSegment(t *testing.T) {
+	ids, err := ListSchemaIDs("observability/logg")
+	require.NoError(t, err)
+	assert.Contains(t, ids, testSchemaID)
+}
+
+func TestExportSet(t *testing.T) {
+	outDir := t.TempDir()
+	opts := NewExportSetOptions("observability/logging", outDir)
+
+	manifest, err := ExportSet(context.Background(), opts)
+	require.NoError(t, err)
+	require.NotEmpty(t, manifest.Schemas)
+	assert.Equal(t, ManifestVersion, manifest.ManifestVersion)
+	assert.Equal(t, "observability/logging", manifest.Prefix)
+	assert.NotEmpty(t, manifest.CrucibleVersion)
+
+	for _, entry := range manifest.Schemas {
+		assert.Equal(t, "v1.0.0", entry.Version)
+		data, err := os.ReadFile(filepath.Join(outDir, filepath.FromSlash(entry.Path)))
+		require.NoError(t, err)
+		digest, err := fulhash.Hash(data, fulhash.WithAlgorithm(fulhash.SHA256))
+		require.NoError(t, err)
+		assert.Equal(t, digest.String(), entry.Digest, entry.SchemaID)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outDir, DefaultManifestName))
+	require.NoError(t, err)
+	var written Manifest
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, len(manifest.Schemas), len(written.Schemas))
+
+	require.NoError(t, ValidateExportedSchema(context.Background(), testSchemaID, filepath.Join(outDir, testSchemaID)))
+}
+
+func TestExportSetYAMLSource(t *testing.T) {
+	ids, err := ListSchemaIDs("")
+	require.NoError(t, err)
+	var yamlID string
+	for _, id := range ids {
+		if strings.HasSuffix(id, ".yaml") {
+			yamlID = id
+			break
+		}
+	}
+	if yamlID == "" {
+		t.Skip("no YAML-authored schemas in Crucible")
+	}
+
+	outDir := t.TempDir()
+	opts := NewExportSetOptions(yamlID, outDir)
+	opts.Format = FormatJSON
+	opts.ValidateSchema = false
+	manifest, err := ExportSet(context.Background(), opts)
+	require.NoError(t, err)
+	require.Len(t, manifest.Schemas, 1)
+	assert.True(t, strings.HasSuffix(manifest.Schemas[0].Path, ".json"))
+
+	data, err := os.ReadFile(filepath.Join(outDir, filepath.FromSlash(manifest.Schemas[0].Path)))
+	require.NoError(t, err)
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Contains(t, doc, "x-crucible-source")
+}
+
+func TestExportSetFormatYAML(t *testing.T) {
+	outDir := t.TempDir()
+	opts := NewExportSetOptions(testSchemaID, outDir)
+	opts.Format = FormatYAML
+
+	manifest, err := ExportSet(context.Background(), opts)
+	require.NoError(t, err)
+	require.Len(t, manifest.Schemas, 1)
+	assert.Equal(t, "observability/logging/v1.0.0/log-event.schema.yaml", manifest.Schemas[0].Path)
+
+	data, err := os.ReadFile(filepath.Join(outDir, filepath.FromSlash(manifest.Schemas[0].Path)))
+	require.NoError(t, err)
+	var doc interface{}
+	require.NoError(t, yaml.Unmarshal(data, &doc))
+}
+
+func TestExportSetErrors(t *testing.T) {
+	_, err := ExportSet(context.Background(), NewExportSetOptions("observability", ""))
+	require.Error(t, err)
+
+	_, err = ExportSet(context.Background(), NewExportSetOptions("does/not/exist", t.TempDir()))
+	assert.True(t, errors.Is(err, ErrSchemaNotFound), "got %v", err)
+
+	outDir := t.TempDir()
+	opts := NewExportSetOptions(testSchemaID, outDir)
+	_, err = ExportSet(context.Background(), opts)
+	require.NoError(t, err)
+	_, err = ExportSet(context.Background(), opts)
+	assert.True(t, errors.Is(err, ErrFileExists), "got %v", err)
+
+	opts.Overwrite = true
+	_, err = ExportSet(context.Background(), opts)
+	require.NoError(t, err)
+}
+
+func TestSchemaVersion(t *testing.T) {
+	assert.Equal(t, "v1.0.0", schemaVersion("observability/logging/v1.0.0/log-event.schema.json"))
+	assert.Equal(t, "v2.1.0", schemaVersion("foo/v2.1.0/bar.schema.json"))
+	assert.Equal(t, "", schemaVersion("foo/bar.schema.json"))
+}
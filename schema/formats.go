@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// FormatFunc reports whether value satisfies a custom `format`. Per JSON
+// Schema, formats only constrain strings, so implementations should return
+// true for non-string values.
+type FormatFunc func(value interface{}) bool
+
+// CompileOptions controls schema compilation and how the resulting validator
+// reads documents. A nil *CompileOptions uses the defaults (format is an
+// annotation for draft 2019-09 and later; YAML is decoded leniently).
+type CompileOptions struct {
+	// AssertFormats makes `format` a validation assertion for every draft and
+	// enables the formats registered with RegisterFormat.
+	AssertFormats bool
+	// StrictYAML, when set, runs CheckYAML on YAML documents before schema
+	// validation. Its diagnostics are returned ahead of schema diagnostics,
+	// and schema validation is skipped if any of them is an error.
+	StrictYAML *YAMLOptions
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = make(map[string]FormatFunc)
+)
+
+// RegisterFormat registers a custom format validator used by validators
+// compiled with CompileOptions.AssertFormats. Registering an existing custom
+// name replaces it; built-in formats (e.g. "email", "date-time") cannot be
+// overridden. Formats are bound when a schema is compiled, so register them
+// before creating validators (typically from an init function).
+//
+// Example:
+//
+//	_ = schema.RegisterFormat("semver", func(v interface{}) bool {
+//	    s, ok := v.(string)
+//	    return !ok || semverPattern.MatchString(s)
+//	})
+//	validator, err := schema.NewValidatorWithOptions(data, &schema.CompileOptions{AssertFormats: true})
+func RegisterFormat(name string, fn FormatFunc) error {
+	if name == "" {
+		return errors.New("format name is required")
+	}
+	if fn == nil {
+		return fmt.Errorf("format %q: validator function is required", name)
+	}
+	if _, builtin := jsonschema.Formats[name]; builtin {
+		return fmt.Errorf("format %q is a built-in format and cannot be overridden", name)
+	}
+
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[name] = fn
+	return nil
+}
+
+// UnregisterFormat removes a custom format registered with RegisterFormat.
+func UnregisterFormat(name string) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	delete(formats, name)
+}
+
+// RegisteredFormats returns the names of the registered custom formats, sorted.
+func RegisteredFormats() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyCompileOptions configures compiler according to opts.
+func applyCompileOptions(compiler *jsonschema.Compiler, opts *CompileOptions) {
+	if opts == nil || !opts.AssertFormats {
+		return
+	}
+	compiler.AssertFormat = true
+
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	if compiler.Formats == nil {
+		compiler.Formats = make(map[string]func(interface{}) bool, len(formats))
+	}
+	for name, fn := range formats {
+		compiler.Formats[name] = fn
+	}
+}
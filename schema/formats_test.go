@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegisterFormat(t *testing.T) {
+	if err := RegisterFormat("even-length", func(v interface{}) bool {
+		s, ok := v.(string)
+		return !ok || len(s)%2 == 0
+	}); err != nil {
+		t.Fatalf("RegisterFormat failed: %v", err)
+	}
+	defer UnregisterFormat("even-length")
+
+	if err := RegisterFormat("email", func(interface{}) bool { return true }); err == nil {
+		t.Error("expected error overriding built-in format")
+	}
+	if err := RegisterFormat("", func(interface{}) bool { return true }); err == nil {
+		t.Error("expected error for empty name")
+	}
+	if err := RegisterFormat("nil-func", nil); err == nil {
+		t.Error("expected error for nil function")
+	}
+
+	found := false
+	for _, name := range RegisteredFormats() {
+		found = found || name == "even-length"
+	}
+	if !found {
+		t.Error("expected even-length in RegisteredFormats")
+	}
+
+	schemaData := []byte(`{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{"code":{"type":"string","format":"even-length"},"when":{"format":"date-time"}}}`)
+
+	// Without the option format is only an annotation
+	plain, err := NewValidator(schemaData)
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+	if diags, _ := plain.ValidateData(map[string]interface{}{"code": "abc"}); len(diags) != 0 {
+		t.Errorf("expected no diagnostics without AssertFormats, got %v", diags)
+	}
+
+	asserting, err := NewValidatorWithOptions(schemaData, &CompileOptions{AssertFormats: true})
+	if err != nil {
+		t.Fatalf("NewValidatorWithOptions failed: %v", err)
+	}
+	diags, _ := asserting.ValidateData(map[string]interface{}{"code": "abc", "when": "yesterday"})
+	failed := map[string]bool{}
+	for _, d := range diags {
+		if strings.HasSuffix(d.Keyword, "/format") {
+			failed[d.Pointer] = true
+		}
+	}
+	if !failed["/code"] || !failed["/when"] {
+		t.Fatalf("expected custom and built-in format failures, got %v", diags)
+	}
+	if diags, _ := asserting.ValidateData(map[string]interface{}{"code": "abcd"}); len(diags) != 0 {
+		t.Errorf("expected valid format, got %v", diags)
+	}
+}
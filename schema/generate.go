@@ -0,0 +1,431 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const draft202012URI = "https://json-schema.org/draft/2020-12/schema"
+
+// GenerateOptions configures schema generation from Go types.
+type GenerateOptions struct {
+	// ID sets the schema $id (optional).
+	ID string
+
+	// Title and Description set the root schema annotations (optional).
+	Title       string
+	Description string
+
+	// TagName selects the struct tag used for property names: "json" (default) or "yaml".
+	TagName string
+
+	// DisallowAdditionalProperties emits additionalProperties: false on every struct object.
+	DisallowAdditionalProperties bool
+
+	// FieldName names properties for fields without a name in their tag
+	// (optional). Use foundry's acronym-aware case helpers so generated names
+	// match other languages, e.g. foundry.ToSnakeCase ("ServerURL" ->
+	// "server_url"). By default the encoder's name is used: the Go field
+	// name for json, lowercased for yaml.
+	FieldName func(goName string) string
+}
+
+// Generate produces a draft 2020-12 JSON Schema describing the Go value v (a struct,
+// pointer to struct, or any other encodable type).
+//
+// Property names follow json (or yaml, see GenerateOptions.TagName) tags; fields
+// tagged "-" and unexported fields are skipped, and embedded structs (or yaml
+// ",inline" fields) are flattened. Fields without omitempty are required,
+// except pointer fields.
+// Named struct types other than the root are emitted once under $defs and
+// referenced with $ref, so recursive types are supported.
+//
+// Constraints come from an optional `schema` tag with comma-separated items:
+//
+//	min=N, max=N     minimum/maximum for numbers, minLength/maxLength for strings,
+//	                 minItems/maxItems for slices, arrays, and maps
+//	pattern=RE       regular expression for strings (may contain commas)
+//	enum=a|b|c       allowed values, converted to the field's type
+//	format=F         format annotation (e.g., "email", "uri")
+//	description=D    description annotation (may contain commas)
+//	required         force the field into required
+//	optional         keep the field out of required
+//
+// Example:
+//
+//	type Config struct {
+//	    Name  string `json:"name" schema:"min=1,pattern=^[a-z-]+$"`
+//	    Level string `json:"level,omitempty" schema:"enum=debug|info|warn"`
+//	    Port  int    `json:"port" schema:"min=1,max=65535"`
+//	}
+//	data, err := schema.Generate(Config{}, &schema.GenerateOptions{ID: "https://example.com/config.json"})
+func Generate(v any, opts *GenerateOptions) ([]byte, error) {
+	if v == nil {
+		return nil, fmt.Errorf("cannot generate schema from nil value")
+	}
+	if opts == nil {
+		opts = &GenerateOptions{}
+	}
+	tagName := opts.TagName
+	if tagName == "" {
+		tagName = "json"
+	}
+	if tagName != "json" && tagName != "yaml" {
+		return nil, fmt.Errorf("unsupported tag name %q (must be json or yaml)", opts.TagName)
+	}
+
+	rootType := reflect.TypeOf(v)
+	for rootType.Kind() == reflect.Pointer {
+		rootType = rootType.Elem()
+	}
+
+	g := &generator{
+		tagName:    tagName,
+		strict:     opts.DisallowAdditionalProperties,
+		fieldName:  opts.FieldName,
+		root:       rootType,
+		defs:       make(map[string]any),
+		defNames:   make(map[reflect.Type]string),
+		takenNames: make(map[string]reflect.Type),
+	}
+
+	root, err := g.typeSchema(rootType, true)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := map[string]any{"$schema": draft202012URI}
+	if opts.ID != "" {
+		doc["$id"] = opts.ID
+	}
+	if opts.Title != "" {
+		doc["title"] = opts.Title
+	}
+	if opts.Description != "" {
+		doc["description"] = opts.Description
+	}
+	for k, val := range root {
+		doc[k] = val
+	}
+	if len(g.defs) > 0 {
+		doc["$defs"] = g.defs
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// generator holds state for a single Generate call.
+type generator struct {
+	tagName    string
+	strict     bool
+	fieldName  func(string) string
+	root       reflect.Type
+	defs       map[string]any
+	defNames   map[reflect.Type]string
+	takenNames map[string]reflect.Type
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// typeSchema returns the schema for t. Named structs other than the root are
+// emitted under $defs unless inline is set.
+func (g *generator) typeSchema(t reflect.Type, inline bool) (map[string]any, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	case rawMessageType:
+		return map[string]any{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s := map[string]any{"type": "integer"}
+		if t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uintptr {
+			s["minimum"] = 0
+		}
+		return s, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := g.typeSchema(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		s := map[string]any{"type": "array", "items": items}
+		if t.Kind() == reflect.Array {
+			s["minItems"] = t.Len()
+			s["maxItems"] = t.Len()
+		}
+		return s, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s: keys must be strings", t.Key())
+		}
+		values, err := g.typeSchema(t.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if t == g.root && !inline {
+			return map[string]any{"$ref": "#"}, nil
+		}
+		if inline || t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return g.structRef(t)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// structRef emits a named struct under $defs (once) and returns a $ref to it.
+func (g *generator) structRef(t reflect.Type) (map[string]any, error) {
+	name, ok := g.defNames[t]
+	if !ok {
+		name = t.Name()
+		if other, taken := g.takenNames[name]; taken && other != t {
+			pkg := t.PkgPath()
+			name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + t.Name()
+		}
+		g.defNames[t] = name
+		g.takenNames[name] = t
+
+		g.defs[name] = map[string]any{} // placeholder so recursive references terminate
+		s, err := g.structSchema(t)
+		if err != nil {
+			return nil, err
+		}
+		g.defs[name] = s
+	}
+	return map[string]any{"$ref": "#/$defs/" + escapePointerToken(name)}, nil
+}
+
+// structSchema builds an object schema from struct fields.
+func (g *generator) structSchema(t reflect.Type) (map[string]any, error) {
+	properties := make(map[string]any)
+	var required []string
+	if err := g.addFields(t, properties, &required); err != nil {
+		return nil, err
+	}
+
+	s := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	if g.strict {
+		s["additionalProperties"] = false
+	}
+	return s, nil
+}
+
+// addFields adds the properties of t's fields, flattening embedded structs.
+func (g *generator) addFields(t reflect.Type, properties map[string]any, required *[]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup(g.tagName)
+		if tag == "-" {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		omitEmpty := strings.Contains(","+flags+",", ",omitempty,")
+		inlined := strings.Contains(","+flags+",", ",inline,")
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if (field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct) || inlined {
+			if err := g.addFields(fieldType, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+			switch {
+			case g.fieldName != nil:
+				name = g.fieldName(name)
+			case g.tagName == "yaml" && !hasTag:
+				name = strings.ToLower(name) // yaml.v3 lowercases untagged field names
+			}
+		}
+
+		prop, err := g.typeSchema(field.Type, false)
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", t.Name(), field.Name, err)
+		}
+		isRequired, err := applyConstraints(prop, field.Type, field.Tag.Get("schema"), !omitEmpty && field.Type.Kind() != reflect.Pointer)
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		properties[name] = prop
+		if isRequired {
+			*required = append(*required, name)
+		}
+	}
+	return nil
+}
+
+// applyConstraints applies a `schema` tag to prop and returns whether the
+// field is required.
+func applyConstraints(prop map[string]any, t reflect.Type, tag string, required bool) (bool, error) {
+	if tag == "" {
+		return required, nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	for _, item := range splitConstraintTag(tag) {
+		key, value, hasValue := strings.Cut(item, "=")
+		switch key {
+		case "required":
+			required = true
+		case "optional":
+			required = false
+		case "min", "max":
+			if !hasValue {
+				return false, fmt.Errorf("schema tag %q requires a value", key)
+			}
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return false, fmt.Errorf("invalid schema tag %s=%q: %w", key, value, err)
+			}
+			keyword, err := boundKeyword(t, key)
+			if err != nil {
+				return false, err
+			}
+			prop[keyword] = jsonNumber(n)
+		case "pattern":
+			prop["pattern"] = value
+		case "format":
+			prop["format"] = value
+		case "description":
+			prop["description"] = value
+		case "enum":
+			values, err := enumValues(t, strings.Split(value, "|"))
+			if err != nil {
+				return false, err
+			}
+			if prop["type"] == "array" {
+				prop["items"].(map[string]any)["enum"] = values
+			} else {
+				prop["enum"] = values
+			}
+		default:
+			return false, fmt.Errorf("unknown schema tag %q", key)
+		}
+	}
+	return required, nil
+}
+
+// constraintKeys are the schema tag keys; a comma-separated item that does not
+// start with one continues the previous value (e.g. pattern=^a{1,3}$).
+var constraintKeys = []string{"min=", "max=", "pattern=", "enum=", "format=", "description=", "required", "optional"}
+
+// splitConstraintTag splits a schema tag into items, keeping commas inside values.
+func splitConstraintTag(tag string) []string {
+	var items []string
+	for _, part := range strings.Split(tag, ",") {
+		isKey := false
+		for _, key := range constraintKeys {
+			if part == key || (strings.HasSuffix(key, "=") && strings.HasPrefix(part, key)) {
+				isKey = true
+				break
+			}
+		}
+		if !isKey && len(items) > 0 {
+			items[len(items)-1] += "," + part
+			continue
+		}
+		items = append(items, part)
+	}
+	return items
+}
+
+// boundKeyword maps min/max to the keyword for the field's type.
+func boundKeyword(t reflect.Type, key string) (string, error) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return key + "imum", nil
+	case reflect.String:
+		return key + "Length", nil
+	case reflect.Slice, reflect.Array:
+		return key + "Items", nil
+	case reflect.Map:
+		return key + "Properties", nil
+	default:
+		return "", fmt.Errorf("schema tag %s is not supported for %s", key, t)
+	}
+}
+
+// enumValues converts enum tag values to the field's (element) type.
+func enumValues(t reflect.Type, raw []string) ([]any, error) {
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+	}
+
+	values := make([]any, len(raw))
+	for i, r := range raw {
+		switch t.Kind() {
+		case reflect.String:
+			values[i] = r
+		case reflect.Bool:
+			b, err := strconv.ParseBool(r)
+			if err != nil {
+				return nil, fmt.Errorf("invalid enum value %q: %w", r, err)
+			}
+			values[i] = b
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64:
+			n, err := strconv.ParseFloat(r, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid enum value %q: %w", r, err)
+			}
+			values[i] = jsonNumber(n)
+		default:
+			return nil, fmt.Errorf("schema tag enum is not supported for %s", t)
+		}
+	}
+	return values, nil
+}
+
+// jsonNumber renders whole numbers as integers so output reads "1" not "1e+00".
+func jsonNumber(n float64) any {
+	if n == float64(int64(n)) {
+		return int64(n)
+	}
+	return n
+}
@@ -0,0 +1,206 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type generateBase struct {
+	ID string `json:"id" schema:"pattern=^[a-z]{2,8}$"`
+}
+
+type generateSink struct {
+	Type string            `json:"type" schema:"enum=console|file"`
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+type generateNode struct {
+	Name     string          `json:"name"`
+	Children []*generateNode `json:"children,omitempty"`
+}
+
+type generateConfig struct {
+	generateBase
+	Name     string         `json:"name" schema:"min=1,max=64,description=Service name, lowercase"`
+	Port     int            `json:"port" schema:"min=1,max=65535"`
+	Ratio    float64        `json:"ratio,omitempty" schema:"min=0.5"`
+	Levels   []string       `json:"levels,omitempty" schema:"enum=debug|info,min=1"`
+	Sinks    []generateSink `json:"sinks"`
+	Primary  *generateSink  `json:"primary"`
+	Tree     *generateNode  `json:"tree,omitempty"`
+	Started  time.Time      `json:"started" schema:"optional"`
+	Extra    any            `json:"extra,omitempty" schema:"required"`
+	Ignored  string         `json:"-"`
+	internal string
+}
+
+func TestGenerate(t *testing.T) {
+	data, err := Generate(generateConfig{}, &GenerateOptions{ID: "https://example.com/config.json", DisallowAdditionalProperties: true})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("generated schema is not JSON: %v", err)
+	}
+	if doc["$schema"] != draft202012URI || doc["$id"] != "https://example.com/config.json" {
+		t.Errorf("unexpected root annotations: %v %v", doc["$schema"], doc["$id"])
+	}
+
+	props := doc["properties"].(map[string]any)
+	for _, name := range []string{"Ignored", "internal", "generateBase"} {
+		if _, ok := props[name]; ok {
+			t.Errorf("property %s should not be generated", name)
+		}
+	}
+
+	name := props["name"].(map[string]any)
+	if name["minLength"] != float64(1) || name["maxLength"] != float64(64) || name["description"] != "Service name, lowercase" {
+		t.Errorf("unexpected name constraints: %v", name)
+	}
+	if id := props["id"].(map[string]any); id["pattern"] != "^[a-z]{2,8}$" {
+		t.Errorf("expected embedded field with comma pattern, got %v", id)
+	}
+	if ratio := props["ratio"].(map[string]any); ratio["minimum"] != 0.5 {
+		t.Errorf("unexpected ratio constraints: %v", ratio)
+	}
+	levels := props["levels"].(map[string]any)
+	if levels["minItems"] != float64(1) || !reflect.DeepEqual(levels["items"].(map[string]any)["enum"], []any{"debug", "info"}) {
+		t.Errorf("unexpected levels constraints: %v", levels)
+	}
+	if started := props["started"].(map[string]any); started["format"] != "date-time" {
+		t.Errorf("unexpected time schema: %v", started)
+	}
+	if sinks := props["sinks"].(map[string]any); sinks["items"].(map[string]any)["$ref"] != "#/$defs/generateSink" {
+		t.Errorf("expected named struct $ref, got %v", sinks)
+	}
+
+	required := doc["required"].([]any)
+	expected := []any{"id", "name", "port", "sinks", "extra"}
+	if !reflect.DeepEqual(required, expected) {
+		t.Errorf("required = %v, want %v", required, expected)
+	}
+	if doc["additionalProperties"] != false {
+		t.Error("expected additionalProperties false")
+	}
+
+	defs := doc["$defs"].(map[string]any)
+	node := defs["generateNode"].(map[string]any)
+	children := node["properties"].(map[string]any)["children"].(map[string]any)
+	if children["items"].(map[string]any)["$ref"] != "#/$defs/generateNode" {
+		t.Errorf("expected recursive $ref, got %v", children)
+	}
+
+	validator, err := NewValidator(data)
+	if err != nil {
+		t.Fatalf("generated schema failed to compile: %v", err)
+	}
+	valid := generateConfig{
+		generateBase: generateBase{ID: "svc"},
+		Name:         "api",
+		Port:         8080,
+		Sinks:        []generateSink{{Type: "console"}},
+		Primary:      &generateSink{Type: "file"},
+		Tree:         &generateNode{Name: "root", Children: []*generateNode{{Name: "leaf"}}},
+		Extra:        map[string]any{"k": 1},
+	}
+	payload, _ := json.Marshal(valid)
+	diags, err := validator.ValidateJSON(payload)
+	if err != nil || len(diags) != 0 {
+		t.Fatalf("expected marshaled struct to validate, got %v %v", diags, err)
+	}
+
+	invalid := valid
+	invalid.Port = 70000
+	invalid.Sinks = []generateSink{{Type: "syslog"}}
+	payload, _ = json.Marshal(invalid)
+	diags, err = validator.ValidateJSON(payload)
+	if err != nil || len(diags) == 0 {
+		t.Fatalf("expected diagnostics for invalid struct, got %v %v", diags, err)
+	}
+}
+
+func TestGenerateYAMLTags(t *testing.T) {
+	type yamlConfig struct {
+		Inline struct {
+			Host string `yaml:"host"`
+		} `yaml:",inline"`
+		Mode  string `yaml:"mode,omitempty" schema:"enum=fast|safe"`
+		Count int
+	}
+
+	data, err := Generate(&yamlConfig{}, &GenerateOptions{TagName: "yaml"})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("generated schema is not JSON: %v", err)
+	}
+	props := doc["properties"].(map[string]any)
+	for _, name := range []string{"host", "mode", "count"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("expected property %s, got %v", name, props)
+		}
+	}
+	if !reflect.DeepEqual(doc["required"], []any{"host", "count"}) {
+		t.Errorf("unexpected required: %v", doc["required"])
+	}
+}
+
+func TestGenerateFieldName(t *testing.T) {
+	type config struct {
+		ServerURL string `json:",omitempty"`
+		MaxConns  int
+		Named     string `json:"named"`
+	}
+
+	snake := func(name string) string {
+		return map[string]string{"ServerURL": "server_url", "MaxConns": "max_conns"}[name]
+	}
+	data, err := Generate(&config{}, &GenerateOptions{FieldName: snake})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("generated schema is not JSON: %v", err)
+	}
+	props := doc["properties"].(map[string]any)
+	for _, name := range []string{"server_url", "max_conns", "named"} {
+		if _, ok := props[name]; !ok {
+			t.Errorf("expected property %s, got %v", name, props)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := map[string]any{
+		"nil": nil,
+		"unknown tag": struct {
+			A string `schema:"bogus=1"`
+		}{},
+		"bad min": struct {
+			A int `schema:"min=x"`
+		}{},
+		"min on bool": struct {
+			A bool `schema:"min=1"`
+		}{},
+		"bad enum": struct {
+			A int `schema:"enum=a|b"`
+		}{},
+		"int map keys": map[int]string{},
+		"channel":      struct{ C chan int }{},
+	}
+	for name, v := range tests {
+		if _, err := Generate(v, nil); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := Generate(struct{}{}, &GenerateOptions{TagName: "toml"}); err == nil {
+		t.Error("expected error for unsupported tag name")
+	}
+}
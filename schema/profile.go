@@ -0,0 +1,294 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// ValidationProfile selects how strictly documents are checked, so schemas at
+// different lifecycle stages can share one validator.
+//
+//	                     strict   lenient   migration
+//	additional props     ERROR    ignored   WARN
+//	deprecated fields    ERROR    WARN      WARN
+//	unknown formats      ERROR    ignored   WARN
+//
+// "Additional props" covers additionalProperties and unevaluatedProperties
+// violations; "deprecated fields" are values at locations whose schema sets
+// `deprecated: true`; "unknown formats" are `format` names that are neither
+// built in nor registered with RegisterFormat. All other violations are
+// errors under every profile.
+type ValidationProfile string
+
+const (
+	// ProfileStrict enforces the schema as written and rejects deprecated fields
+	// and unknown formats. Suited to stable documents.
+	ProfileStrict ValidationProfile = "strict"
+
+	// ProfileLenient tolerates unknown properties and formats and warns on
+	// deprecated fields. Suited to draft documents.
+	ProfileLenient ValidationProfile = "lenient"
+
+	// ProfileMigration reports everything the strict profile would reject as
+	// warnings, so documents can be migrated without failing validation.
+	// Suited to deprecated documents.
+	ProfileMigration ValidationProfile = "migration"
+)
+
+// profileRules holds the severity of each relaxable check; an empty severity
+// drops the diagnostic.
+type profileRules struct {
+	additional    SeverityLevel
+	deprecated    SeverityLevel
+	unknownFormat SeverityLevel
+}
+
+var profiles = map[ValidationProfile]profileRules{
+	ProfileStrict:    {additional: SeverityError, deprecated: SeverityError, unknownFormat: SeverityError},
+	ProfileLenient:   {deprecated: SeverityWarn},
+	ProfileMigration: {additional: SeverityWarn, deprecated: SeverityWarn, unknownFormat: SeverityWarn},
+}
+
+// ProfileForLifecycle returns the profile for a Crucible document lifecycle
+// stage: draft → lenient, stable → strict, deprecated → migration.
+func ProfileForLifecycle(stage string) (ValidationProfile, error) {
+	switch strings.ToLower(stage) {
+	case "draft":
+		return ProfileLenient, nil
+	case "stable":
+		return ProfileStrict, nil
+	case "deprecated":
+		return ProfileMigration, nil
+	default:
+		return "", fmt.Errorf("unknown lifecycle stage %q (expected draft, stable, or deprecated)", stage)
+	}
+}
+
+// ValidateDataWithProfile validates an in-memory value under profile. Every
+// returned diagnostic carries the profile name.
+func (v *Validator) ValidateDataWithProfile(data interface{}, profile ValidationProfile) ([]Diagnostic, error) {
+	rules, ok := profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown validation profile %q", profile)
+	}
+
+	var diags []Diagnostic
+	if err := v.schema.Validate(data); err != nil {
+		validationErr, ok := err.(*jsonschema.ValidationError)
+		if !ok {
+			return nil, err
+		}
+		rules.collect(validationErr, &diags)
+	}
+
+	checker := &annotationChecker{rules: rules, seen: make(map[string]bool)}
+	checker.walk(v.schema, data, "", 0)
+	diags = append(diags, checker.diags...)
+
+	for i := range diags {
+		diags[i].Profile = profile
+	}
+	return diags, nil
+}
+
+// ValidateJSONWithProfile validates JSON bytes under profile.
+func (v *Validator) ValidateJSONWithProfile(jsonData []byte, profile ValidationProfile) ([]Diagnostic, error) {
+	var payload interface{}
+	if err := json.Unmarshal(jsonData, &payload); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return v.ValidateDataWithProfile(payload, profile)
+}
+
+// ValidateDataByIDWithProfile validates a JSON payload against the schema
+// identified by ID under profile.
+//
+// Example:
+//
+//	profile, _ := schema.ProfileForLifecycle("draft")
+//	diags, err := catalog.ValidateDataByIDWithProfile("pathfinder/v1.0.0/path-result", payload, profile)
+func (c *Catalog) ValidateDataByIDWithProfile(id string, payload []byte, profile ValidationProfile) ([]Diagnostic, error) {
+	validator, err := c.ValidatorByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return validator.ValidateJSONWithProfile(payload, profile)
+}
+
+// collect appends the diagnostics of err and its causes under the profile
+// rules and reports whether err still fails. Wrapper errors whose causes were
+// all relaxed are dropped.
+func (p profileRules) collect(err *jsonschema.ValidationError, out *[]Diagnostic) bool {
+	keyword := lastKeyword(err.KeywordLocation)
+	diag := Diagnostic{
+		Pointer:  err.InstanceLocation,
+		Keyword:  trimKeyword(err.KeywordLocation),
+		Message:  err.Message,
+		Severity: SeverityError,
+		Source:   sourceGoFulmen,
+	}
+
+	if len(err.Causes) == 0 {
+		if keyword == "additionalProperties" || keyword == "unevaluatedProperties" {
+			diag.Severity = p.additional
+		}
+		if diag.Severity == "" {
+			return false
+		}
+		*out = append(*out, diag)
+		return diag.Severity == SeverityError
+	}
+
+	var causes []Diagnostic
+	failing := 0
+	for _, cause := range err.Causes {
+		if p.collect(cause, &causes) {
+			failing++
+		}
+	}
+
+	fails := failing > 0
+	if keyword == "anyOf" || keyword == "oneOf" {
+		// Passes once any branch passes; the failing branches are then irrelevant
+		if failing < len(err.Causes) {
+			return false
+		}
+	}
+	if fails {
+		*out = append(*out, diag)
+	}
+	*out = append(*out, causes...)
+	return fails
+}
+
+func lastKeyword(keywordLocation string) string {
+	keyword := trimKeyword(keywordLocation)
+	return keyword[strings.LastIndex(keyword, "/")+1:]
+}
+
+// annotationChecker walks a document alongside its compiled schema to report
+// deprecated fields and unknown formats, which validation itself ignores.
+type annotationChecker struct {
+	rules profileRules
+	diags []Diagnostic
+	seen  map[string]bool
+}
+
+func (c *annotationChecker) report(pointer, keyword string, severity SeverityLevel, message string) {
+	key := pointer + "\x00" + keyword
+	if severity == "" || c.seen[key] {
+		return
+	}
+	c.seen[key] = true
+	c.diags = append(c.diags, Diagnostic{
+		Pointer:  pointer,
+		Keyword:  keyword,
+		Message:  message,
+		Severity: severity,
+		Source:   sourceGoFulmen,
+	})
+}
+
+func (c *annotationChecker) walk(s *jsonschema.Schema, value interface{}, pointer string, depth int) {
+	if s == nil || depth > maxRefDepth {
+		return
+	}
+
+	if s.Deprecated {
+		c.report(pointer, "deprecated", c.rules.deprecated, "value uses a deprecated field")
+	}
+	if _, isString := value.(string); isString && s.Format != "" && !knownFormat(s.Format) {
+		c.report(pointer, "format", c.rules.unknownFormat, fmt.Sprintf("unknown format %q", s.Format))
+	}
+
+	// Applicators that apply at the same location
+	for _, ref := range []*jsonschema.Schema{s.Ref, s.RecursiveRef, s.DynamicRef} {
+		c.walk(ref, value, pointer, depth+1)
+	}
+	for _, sub := range s.AllOf {
+		c.walk(sub, value, pointer, depth+1)
+	}
+	for _, branches := range [][]*jsonschema.Schema{s.AnyOf, s.OneOf} {
+		for _, sub := range branches {
+			if sub.Validate(value) == nil {
+				c.walk(sub, value, pointer, depth+1)
+			}
+		}
+	}
+	if s.If != nil {
+		if s.If.Validate(value) == nil {
+			c.walk(s.Then, value, pointer, depth+1)
+		} else {
+			c.walk(s.Else, value, pointer, depth+1)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			child := pointer + "/" + escapePointerToken(key)
+			matched := false
+			if sub, ok := s.Properties[key]; ok {
+				c.walk(sub, v[key], child, depth+1)
+				matched = true
+			}
+			for pattern, sub := range s.PatternProperties {
+				if pattern.MatchString(key) {
+					c.walk(sub, v[key], child, depth+1)
+					matched = true
+				}
+			}
+			if sub, ok := s.AdditionalProperties.(*jsonschema.Schema); ok && !matched {
+				c.walk(sub, v[key], child, depth+1)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			child := pointer + "/" + strconv.Itoa(i)
+			c.walk(itemSchema(s, i), item, child, depth+1)
+		}
+	}
+}
+
+// itemSchema returns the schema that applies to array item i.
+func itemSchema(s *jsonschema.Schema, i int) *jsonschema.Schema {
+	if i < len(s.PrefixItems) {
+		return s.PrefixItems[i]
+	}
+	if s.Items2020 != nil {
+		return s.Items2020
+	}
+	switch items := s.Items.(type) {
+	case *jsonschema.Schema:
+		return items
+	case []*jsonschema.Schema:
+		if i < len(items) {
+			return items[i]
+		}
+		if additional, ok := s.AdditionalItems.(*jsonschema.Schema); ok {
+			return additional
+		}
+	}
+	return nil
+}
+
+// knownFormat reports whether name is a built-in or registered format.
+func knownFormat(name string) bool {
+	if _, ok := jsonschema.Formats[name]; ok {
+		return true
+	}
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	_, ok := formats[name]
+	return ok
+}
@@ -0,0 +1,113 @@
+package schema
+
+import (
+	"testing"
+)
+
+const profileTestSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["name"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string"},
+    "legacyId": {"type": "string", "deprecated": true},
+    "build": {"type": "string", "format": "x-build-tag"},
+    "tags": {"type": "array", "items": {"type": "string", "maxLength": 8}}
+  }
+}`
+
+func severities(diags []Diagnostic) map[string]SeverityLevel {
+	out := make(map[string]SeverityLevel, len(diags))
+	for _, d := range diags {
+		out[lastKeyword(d.Keyword)] = d.Severity
+	}
+	return out
+}
+
+func TestValidateDataWithProfile(t *testing.T) {
+	validator, err := NewValidator([]byte(profileTestSchema))
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+	doc := []byte(`{"name": "svc", "legacyId": "42", "build": "b-7", "extra": true}`)
+
+	tests := []struct {
+		profile ValidationProfile
+		want    map[string]SeverityLevel
+	}{
+		{ProfileStrict, map[string]SeverityLevel{"additionalProperties": SeverityError, "deprecated": SeverityError, "format": SeverityError}},
+		{ProfileLenient, map[string]SeverityLevel{"deprecated": SeverityWarn}},
+		{ProfileMigration, map[string]SeverityLevel{"additionalProperties": SeverityWarn, "deprecated": SeverityWarn, "format": SeverityWarn}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.profile), func(t *testing.T) {
+			diags, err := validator.ValidateJSONWithProfile(doc, tt.profile)
+			if err != nil {
+				t.Fatalf("ValidateJSONWithProfile failed: %v", err)
+			}
+			got := severities(diags)
+			for keyword, want := range tt.want {
+				if got[keyword] != want {
+					t.Errorf("%s: expected %s, got %q (diags: %+v)", keyword, want, got[keyword], diags)
+				}
+			}
+			for _, d := range diags {
+				if d.Profile != tt.profile {
+					t.Errorf("expected profile tag %s, got %q", tt.profile, d.Profile)
+				}
+				if _, expected := tt.want[lastKeyword(d.Keyword)]; !expected && d.Keyword != "" { // "" is the root wrapper
+					t.Errorf("unexpected diagnostic %+v", d)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateDataWithProfileKeepsHardErrors(t *testing.T) {
+	validator, err := NewValidator([]byte(profileTestSchema))
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+	for _, profile := range []ValidationProfile{ProfileStrict, ProfileLenient, ProfileMigration} {
+		diags, err := validator.ValidateJSONWithProfile([]byte(`{"tags": ["far-too-long"], "extra": 1}`), profile)
+		if err != nil {
+			t.Fatalf("ValidateJSONWithProfile failed: %v", err)
+		}
+		got := severities(diags)
+		if got["required"] != SeverityError || got["maxLength"] != SeverityError {
+			t.Errorf("%s: expected required and maxLength errors, got %+v", profile, diags)
+		}
+	}
+
+	if _, err := validator.ValidateJSONWithProfile([]byte(`{}`), "relaxed"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+}
+
+func TestProfileForLifecycle(t *testing.T) {
+	for stage, want := range map[string]ValidationProfile{
+		"draft":      ProfileLenient,
+		"stable":     ProfileStrict,
+		"Deprecated": ProfileMigration,
+	} {
+		got, err := ProfileForLifecycle(stage)
+		if err != nil || got != want {
+			t.Errorf("ProfileForLifecycle(%q) = %q, %v; want %q", stage, got, err, want)
+		}
+	}
+	if _, err := ProfileForLifecycle("retired"); err == nil {
+		t.Error("expected error for unknown stage")
+	}
+}
+
+func TestCatalogValidateDataByIDWithProfile(t *testing.T) {
+	catalog := DefaultCatalog()
+	diags, err := catalog.ValidateDataByIDWithProfile("pathfinder/v1.0.0/path-result", []byte(`{}`), ProfileLenient)
+	if err != nil {
+		t.Fatalf("ValidateDataByIDWithProfile failed: %v", err)
+	}
+	if !hasErrors(diags) {
+		t.Fatalf("expected errors for empty path result, got %+v", diags)
+	}
+}
@@ -56,18 +56,21 @@ func (r *SchemaRegistry) LoadSchema(name, version string) ([]byte, error) {
 	return data, nil
 }
 
-// GetValidator gets a validator for a schema
+// GetValidator gets a validator for a schema from the process-wide validator cache
 func (r *SchemaRegistry) GetValidator(name, version string) (*Validator, error) {
 	data, err := r.LoadSchema(name, version)
 	if err != nil {
 		return nil, err
 	}
-	return NewValidator(data)
+	return CachedValidator(fmt.Sprintf("%s/%s", name, version), data)
 }
 
-// ClearCache clears the schema cache
+// ClearCache clears the schema cache and the cached validators for its schemas
 func (r *SchemaRegistry) ClearCache() {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
+	for key := range r.cache {
+		DefaultValidatorCache().Invalidate(key)
+	}
 	r.cache = make(map[string][]byte)
 }
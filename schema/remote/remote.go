@@ -0,0 +1,565 @@
+// Package remote resolves schemas by ID from an HTTPS schema registry, so
+// applications can consume newer Crucible schema versions without waiting for
+// a gofulmen release.
+//
+// A registry serves schemas at <BaseURL>/<id>.schema.json, mirroring the
+// Crucible layout (e.g. https://schemas.example.com/crucible/pathfinder/v1.0.0/path-result.schema.json).
+// Fetched schemas are cached on disk and revalidated with ETags once their
+// freshness lifetime (Cache-Control max-age, or Options.TTL) has passed.
+// Transient registry failures are retried with Options.Retry. When the
+// registry is unreachable the cached copy is used, and when a schema is
+// neither cached nor published remotely the local catalog is used.
+//
+// Digest pins guard against a compromised or mistaken registry: a pinned
+// schema whose content does not match its digest is rejected with
+// ErrPinMismatch and never falls back.
+//
+// Example:
+//
+//	resolver, err := remote.NewResolver(remote.Options{
+//	    BaseURL: "https://schemas.example.com/crucible",
+//	    Pins: map[string]string{
+//	        "pathfinder/v1.0.0/path-result": "sha256:9f86d0...",
+//	    },
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	diags, err := resolver.ValidateDataByID(ctx, "pathfinder/v1.0.0/path-result", payload)
+//
+// Remote schemas are compiled standalone, so cross-file $refs should be
+// bundled into the published document (see schema.Catalog.BundleByID).
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/appidentity"
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/fulhash"
+	"github.com/fulmenhq/gofulmen/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultTimeout bounds each registry request.
+	DefaultTimeout = 10 * time.Second
+
+	// DefaultMaxSchemaSize bounds the size of a fetched schema document.
+	DefaultMaxSchemaSize = 4 << 20 // 4 MiB
+
+	// cacheSubdir is the directory under the application cache dir holding fetched schemas.
+	cacheSubdir = "schemas"
+)
+
+var (
+	// ErrPinMismatch is returned when a schema's content does not match its pinned digest.
+	ErrPinMismatch = errors.New("schema digest does not match pin")
+
+	// ErrNotFound is returned when a schema is in neither the registry, the cache, nor the catalog.
+	ErrNotFound = errors.New("schema not found")
+)
+
+// Source identifies where a resolved schema came from.
+type Source string
+
+const (
+	// SourceRemote is a schema freshly fetched from the registry.
+	SourceRemote Source = "remote"
+
+	// SourceCache is a cached schema, either still fresh, confirmed current by
+	// the registry (304 Not Modified), or used while the registry is unreachable.
+	SourceCache Source = "cache"
+
+	// SourceCatalog is a schema from the local catalog.
+	SourceCatalog Source = "catalog"
+)
+
+// Options configures a Resolver.
+type Options struct {
+	// BaseURL is the registry root. Must use https.
+	BaseURL string
+
+	// CacheDir holds fetched schemas and their ETags (default: the current
+	// application's cache dir from appidentity, plus "schemas"). Set to "-"
+	// to disable the disk cache.
+	CacheDir string
+
+	// Pins maps schema IDs to required content digests in fulhash
+	// "algorithm:hex" form (e.g. "sha256:9f86d0..."). Pins apply to remote and
+	// cached content; catalog schemas ship with gofulmen and are not pinned.
+	Pins map[string]string
+
+	// Catalog is the fallback catalog (default: schema.DefaultCatalog()).
+	Catalog *schema.Catalog
+
+	// HTTPClient performs registry requests (default: a client with DefaultTimeout).
+	HTTPClient *http.Client
+
+	// MaxSchemaSize bounds fetched documents in bytes (default: DefaultMaxSchemaSize).
+	MaxSchemaSize int64
+
+	// TTL is how long a fetched schema is used without contacting the
+	// registry when the response has no Cache-Control max-age (default: 0,
+	// revalidate on every Fetch). A max-age from the registry takes
+	// precedence; no-cache and no-store always revalidate.
+	TTL time.Duration
+
+	// Retry retries registry requests that fail with network errors or
+	// retryable statuses (408, 425, 429, 500, 502, 503, 504), honoring
+	// Retry-After (default: a single attempt, so an unreachable registry
+	// falls back to the cached copy without delay).
+	Retry *foundry.RetryPolicy
+}
+
+// Document is a resolved schema.
+type Document struct {
+	// ID is the schema ID (e.g. "pathfinder/v1.0.0/path-result").
+	ID string
+
+	// Data is the schema as JSON.
+	Data []byte
+
+	// Source is where the schema came from.
+	Source Source
+
+	// Digest is the sha256 digest of the schema as served (empty for catalog schemas).
+	Digest string
+
+	// Stale reports a cached schema used because the registry could not be reached.
+	Stale bool
+}
+
+// Resolver fetches schemas from a registry with caching, pinning, and
+// catalog fallback. It is safe for concurrent use.
+type Resolver struct {
+	baseURL    *url.URL
+	cacheDir   string
+	pins       map[string]fulhash.Digest
+	catalog    *schema.Catalog
+	client     *http.Client
+	maxSize    int64
+	ttl        time.Duration
+	retry      *foundry.RetryPolicy
+	status     *foundry.HTTPStatusHelper
+	validators *schema.ValidatorCache
+
+	mu    sync.Mutex
+	fresh map[string]freshDocument
+}
+
+// freshDocument is a fetched schema that may be used without revalidation
+// until expires.
+type freshDocument struct {
+	doc     *Document
+	expires time.Time
+}
+
+// NewResolver creates a resolver for the registry at opts.BaseURL.
+func NewResolver(opts Options) (*Resolver, error) {
+	base, err := url.Parse(strings.TrimSuffix(opts.BaseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry URL %q: %w", opts.BaseURL, err)
+	}
+	if base.Scheme != "https" || base.Host == "" {
+		return nil, fmt.Errorf("registry URL must be an absolute https URL, got %q", opts.BaseURL)
+	}
+
+	pins := make(map[string]fulhash.Digest, len(opts.Pins))
+	for id, pin := range opts.Pins {
+		digest, err := fulhash.ParseDigest(pin)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pin for %s: %w", id, err)
+		}
+		pins[id] = digest
+	}
+
+	cacheDir := opts.CacheDir
+	switch cacheDir {
+	case "-":
+		cacheDir = ""
+	case "":
+		if cacheDir, err = defaultCacheDir(); err != nil {
+			return nil, err
+		}
+	}
+
+	r := &Resolver{
+		baseURL:    base,
+		cacheDir:   cacheDir,
+		pins:       pins,
+		catalog:    opts.Catalog,
+		client:     opts.HTTPClient,
+		maxSize:    opts.MaxSchemaSize,
+		ttl:        opts.TTL,
+		retry:      opts.Retry,
+		validators: schema.NewValidatorCache(0),
+		fresh:      make(map[string]freshDocument),
+	}
+	if r.catalog == nil {
+		r.catalog = schema.DefaultCatalog()
+	}
+	if r.client == nil {
+		r.client = &http.Client{Timeout: DefaultTimeout}
+	}
+	if r.maxSize <= 0 {
+		r.maxSize = DefaultMaxSchemaSize
+	}
+	if r.retry == nil {
+		r.retry = &foundry.RetryPolicy{MaxAttempts: 1}
+	} else if err := r.retry.Validate(); err != nil {
+		return nil, err
+	}
+	if r.status, err = foundry.GetDefaultCatalog().GetHTTPStatusHelper(); err != nil {
+		// Classification does not depend on the catalog; only reason phrases are lost
+		r.status = foundry.NewHTTPStatusHelper(nil)
+	}
+	return r, nil
+}
+
+// defaultCacheDir returns <app cache dir>/schemas for the current application.
+func defaultCacheDir() (string, error) {
+	identity, err := appidentity.Get(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve schema cache dir (set Options.CacheDir): %w", err)
+	}
+	dir, err := identity.CacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve schema cache dir (set Options.CacheDir): %w", err)
+	}
+	return filepath.Join(dir, cacheSubdir), nil
+}
+
+// Fetch resolves the schema with the given ID. Resolution order:
+//
+//  0. A schema fetched by this resolver that is still fresh
+//  1. Registry, revalidating any cached copy with If-None-Match
+//  2. Cached copy, when the registry is unreachable or errors
+//  3. Local catalog, when the registry reports 404, or fails with nothing cached
+//
+// Pinned schemas must match their digest at steps 1 and 2.
+func (r *Resolver) Fetch(ctx context.Context, id string) (*Document, error) {
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+	if doc := r.freshDocument(id); doc != nil {
+		return doc, nil
+	}
+
+	cached, _ := r.readCache(id)
+	if cached != nil {
+		if err := r.checkPin(id, cached.data); err != nil {
+			// Drop a cache entry that no longer matches its pin and refetch
+			r.removeCache(id)
+			cached = nil
+		}
+	}
+
+	doc, fetchErr := r.fetchRemote(ctx, id, cached)
+	switch {
+	case fetchErr == nil:
+		return doc, nil
+	case errors.Is(fetchErr, ErrPinMismatch):
+		return nil, fetchErr
+	case errors.Is(fetchErr, ErrNotFound):
+		// Not published remotely; fall through to the catalog
+	case cached != nil:
+		return r.document(id, cached.data, SourceCache, true)
+	}
+
+	if _, err := r.catalog.GetSchema(id); err != nil {
+		if errors.Is(fetchErr, ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		return nil, fmt.Errorf("%w: %s (registry: %v)", ErrNotFound, id, fetchErr)
+	}
+	data, err := r.catalog.BundleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &Document{ID: id, Data: data, Source: SourceCatalog}, nil
+}
+
+// fetchRemote requests id from the registry, retrying transient failures,
+// and returns the cached copy on 304.
+func (r *Resolver) fetchRemote(ctx context.Context, id string, cached *cacheEntry) (*Document, error) {
+	var doc *Document
+	err := r.retry.Do(ctx, func(ctx context.Context) error {
+		var err error
+		doc, err = r.fetchOnce(ctx, id, cached)
+		return err
+	})
+	return doc, err
+}
+
+// fetchOnce performs one registry request. Errors that retrying cannot fix
+// are wrapped with foundry.Permanent.
+func (r *Resolver) fetchOnce(ctx context.Context, id string, cached *cacheEntry) (*Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.schemaURL(id), nil)
+	if err != nil {
+		return nil, foundry.Permanent(err)
+	}
+	req.Header.Set("Accept", "application/schema+json, application/json, application/yaml")
+	if cached != nil && cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		if cached == nil {
+			return nil, foundry.Permanent(fmt.Errorf("registry returned 304 for uncached schema %s", id))
+		}
+		doc, err := r.document(id, cached.data, SourceCache, false)
+		if err != nil {
+			return nil, foundry.Permanent(err)
+		}
+		r.markFresh(doc, resp.Header)
+		return doc, nil
+	case http.StatusNotFound, http.StatusGone:
+		return nil, foundry.Permanent(fmt.Errorf("%w: %s", ErrNotFound, id))
+	default:
+		if err := r.status.RetryError(resp); err != nil {
+			return nil, fmt.Errorf("registry request for schema %s: %w", id, err)
+		}
+		return nil, foundry.Permanent(fmt.Errorf("registry returned %s for schema %s", resp.Status, id))
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, r.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema %s: %w", id, err)
+	}
+	if int64(len(raw)) > r.maxSize {
+		return nil, foundry.Permanent(fmt.Errorf("schema %s exceeds %d bytes", id, r.maxSize))
+	}
+	if err := r.checkPin(id, raw); err != nil {
+		return nil, foundry.Permanent(err)
+	}
+
+	doc, err := r.document(id, raw, SourceRemote, false)
+	if err != nil {
+		return nil, foundry.Permanent(err)
+	}
+	r.writeCache(id, &cacheEntry{data: raw, etag: resp.Header.Get("ETag")})
+	r.markFresh(doc, resp.Header)
+	return doc, nil
+}
+
+// freshDocument returns the fetched document for id while it is fresh, as
+// a cache hit.
+func (r *Resolver) freshDocument(id string) *Document {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.fresh[id]
+	if !ok {
+		return nil
+	}
+	if !time.Now().Before(entry.expires) {
+		delete(r.fresh, id)
+		return nil
+	}
+	doc := *entry.doc
+	doc.Source = SourceCache
+	return &doc
+}
+
+// markFresh records doc as usable without revalidation for the lifetime
+// allowed by header and Options.TTL.
+func (r *Resolver) markFresh(doc *Document, header http.Header) {
+	lifetime := freshnessLifetime(header, r.ttl)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if lifetime <= 0 {
+		delete(r.fresh, doc.ID)
+		return
+	}
+	stored := *doc
+	r.fresh[doc.ID] = freshDocument{doc: &stored, expires: time.Now().Add(lifetime)}
+}
+
+// freshnessLifetime returns the Cache-Control max-age of a response, 0 for
+// no-cache or no-store, and fallback when the registry sends neither.
+func freshnessLifetime(header http.Header, fallback time.Duration) time.Duration {
+	lifetime := fallback
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-cache", "no-store":
+			return 0
+		case "max-age":
+			seconds, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+			if err != nil || seconds < 0 {
+				seconds = 0
+			}
+			lifetime = time.Duration(seconds) * time.Second
+		}
+	}
+	return lifetime
+}
+
+// document builds a Document from schema bytes as served, normalizing YAML to JSON.
+func (r *Resolver) document(id string, raw []byte, source Source, stale bool) (*Document, error) {
+	data, err := normalize(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %s from %s: %w", id, source, err)
+	}
+	digest, err := fulhash.Hash(raw, fulhash.WithAlgorithm(fulhash.SHA256))
+	if err != nil {
+		return nil, err
+	}
+	return &Document{ID: id, Data: data, Source: source, Digest: digest.String(), Stale: stale}, nil
+}
+
+// checkPin verifies raw against the pin for id, if any.
+func (r *Resolver) checkPin(id string, raw []byte) error {
+	pin, ok := r.pins[id]
+	if !ok {
+		return nil
+	}
+	digest, err := fulhash.Hash(raw, fulhash.WithAlgorithm(pin.Algorithm()))
+	if err != nil {
+		return err
+	}
+	if digest.String() != pin.String() {
+		return fmt.Errorf("%w: %s is %s, pinned %s", ErrPinMismatch, id, digest, pin)
+	}
+	return nil
+}
+
+// Validator returns a validator for the schema with the given ID. Validators
+// are cached by content, so a schema updated in the registry is recompiled.
+func (r *Resolver) Validator(ctx context.Context, id string) (*schema.Validator, error) {
+	doc, err := r.Fetch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Source == SourceCatalog {
+		return r.catalog.ValidatorByID(id)
+	}
+	return r.validators.Validator(id, doc.Data)
+}
+
+// ValidateDataByID validates JSON bytes against the schema with the given ID.
+func (r *Resolver) ValidateDataByID(ctx context.Context, id string, data []byte) ([]schema.Diagnostic, error) {
+	validator, err := r.Validator(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return validator.ValidateJSON(data)
+}
+
+func (r *Resolver) schemaURL(id string) string {
+	return r.baseURL.JoinPath(id + ".schema.json").String()
+}
+
+// validateID rejects IDs that could escape the registry or cache directory.
+func validateID(id string) error {
+	if id == "" || strings.HasPrefix(id, "/") || strings.Contains(id, "\\") {
+		return fmt.Errorf("invalid schema id %q", id)
+	}
+	for _, part := range strings.Split(id, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid schema id %q", id)
+		}
+	}
+	return nil
+}
+
+// normalize converts a JSON or YAML schema document to JSON.
+func normalize(raw []byte) ([]byte, error) {
+	var value interface{}
+	if err := yaml.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	if _, ok := value.(map[string]interface{}); !ok {
+		return nil, errors.New("schema must be an object")
+	}
+	return json.Marshal(value)
+}
+
+// cacheEntry is a cached schema document and its ETag.
+type cacheEntry struct {
+	data []byte
+	etag string
+}
+
+func (r *Resolver) cachePath(id string) string {
+	return filepath.Join(r.cacheDir, filepath.FromSlash(id)+".schema.json")
+}
+
+func (r *Resolver) readCache(id string) (*cacheEntry, error) {
+	if r.cacheDir == "" {
+		return nil, nil
+	}
+	path := r.cachePath(id)
+	data, err := os.ReadFile(path) // #nosec G304 -- path is derived from a validated schema ID
+	if err != nil {
+		return nil, err
+	}
+	etag, _ := os.ReadFile(path + ".etag") // #nosec G304 -- as above
+	return &cacheEntry{data: data, etag: strings.TrimSpace(string(etag))}, nil
+}
+
+// writeCache stores entry, replacing files atomically. Cache failures are
+// not fatal: the schema was fetched and is still returned.
+func (r *Resolver) writeCache(id string, entry *cacheEntry) {
+	if r.cacheDir == "" {
+		return
+	}
+	path := r.cachePath(id)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	if writeFileAtomic(path, entry.data) != nil {
+		return
+	}
+	if entry.etag == "" {
+		_ = os.Remove(path + ".etag")
+		return
+	}
+	_ = writeFileAtomic(path+".etag", []byte(entry.etag+"\n"))
+}
+
+func (r *Resolver) removeCache(id string) {
+	if r.cacheDir == "" {
+		return
+	}
+	path := r.cachePath(id)
+	_ = os.Remove(path)
+	_ = os.Remove(path + ".etag")
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
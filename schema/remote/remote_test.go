@@ -0,0 +1,260 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/fulmenhq/gofulmen/fulhash"
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+const (
+	testID     = "demo/v1.0.0/widget"
+	testSchema = `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","required":["name"]}`
+)
+
+// newRegistry serves testSchema at /demo/v1.0.0/widget.schema.json with an ETag.
+func newRegistry(t *testing.T, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var notModified atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/"+testID+".schema.json" {
+			http.NotFound(w, req)
+			return
+		}
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &notModified
+}
+
+func newTestResolver(t *testing.T, srv *httptest.Server, opts Options) *Resolver {
+	t.Helper()
+	opts.BaseURL = srv.URL
+	opts.HTTPClient = srv.Client()
+	if opts.CacheDir == "" {
+		opts.CacheDir = t.TempDir()
+	}
+	r, err := NewResolver(opts)
+	if err != nil {
+		t.Fatalf("NewResolver failed: %v", err)
+	}
+	return r
+}
+
+func TestFetchCachesAndRevalidates(t *testing.T) {
+	srv, notModified := newRegistry(t, testSchema)
+	r := newTestResolver(t, srv, Options{})
+	ctx := context.Background()
+
+	doc, err := r.Fetch(ctx, testID)
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if doc.Source != SourceRemote || doc.Digest == "" {
+		t.Fatalf("expected remote document with digest, got %+v", doc)
+	}
+
+	doc, err = r.Fetch(ctx, testID)
+	if err != nil {
+		t.Fatalf("second Fetch failed: %v", err)
+	}
+	if doc.Source != SourceCache || doc.Stale || notModified.Load() != 1 {
+		t.Fatalf("expected revalidated cache hit, got %+v (304s: %d)", doc, notModified.Load())
+	}
+
+	diags, err := r.ValidateDataByID(ctx, testID, []byte(`{}`))
+	if err != nil {
+		t.Fatalf("ValidateDataByID failed: %v", err)
+	}
+	if len(diags) == 0 {
+		t.Fatalf("expected diagnostics for missing required property")
+	}
+}
+
+func TestFetchUsesStaleCacheWhenUnreachable(t *testing.T) {
+	srv, _ := newRegistry(t, testSchema)
+	cacheDir := t.TempDir()
+	r := newTestResolver(t, srv, Options{CacheDir: cacheDir})
+	if _, err := r.Fetch(context.Background(), testID); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	srv.Close()
+
+	doc, err := r.Fetch(context.Background(), testID)
+	if err != nil {
+		t.Fatalf("Fetch with registry down failed: %v", err)
+	}
+	if doc.Source != SourceCache || !doc.Stale {
+		t.Fatalf("expected stale cache document, got %+v", doc)
+	}
+}
+
+func TestFetchFallsBackToCatalog(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "demo", "v1.0.0")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "local.schema.json"), []byte(testSchema), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	srv, _ := newRegistry(t, testSchema)
+	r := newTestResolver(t, srv, Options{Catalog: schema.CatalogForRoot(root)})
+
+	doc, err := r.Fetch(context.Background(), "demo/v1.0.0/local")
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if doc.Source != SourceCatalog {
+		t.Fatalf("expected catalog document, got %+v", doc)
+	}
+	if _, err := r.Validator(context.Background(), "demo/v1.0.0/local"); err != nil {
+		t.Fatalf("Validator failed: %v", err)
+	}
+
+	if _, err := r.Fetch(context.Background(), "demo/v1.0.0/missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestFetchEnforcesPins(t *testing.T) {
+	srv, _ := newRegistry(t, testSchema)
+	digest, err := fulhash.Hash([]byte(testSchema), fulhash.WithAlgorithm(fulhash.SHA256))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := newTestResolver(t, srv, Options{Pins: map[string]string{testID: digest.String()}})
+	doc, err := r.Fetch(context.Background(), testID)
+	if err != nil {
+		t.Fatalf("Fetch with matching pin failed: %v", err)
+	}
+	if doc.Digest != digest.String() {
+		t.Fatalf("expected digest %s, got %s", digest, doc.Digest)
+	}
+
+	other, err := fulhash.Hash([]byte("{}"), fulhash.WithAlgorithm(fulhash.SHA256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = newTestResolver(t, srv, Options{Pins: map[string]string{testID: other.String()}})
+	if _, err := r.Fetch(context.Background(), testID); !errors.Is(err, ErrPinMismatch) {
+		t.Fatalf("expected ErrPinMismatch, got %v", err)
+	}
+}
+
+func TestFetchHonorsFreshness(t *testing.T) {
+	var requests atomic.Int32
+	maxAge := "max-age=60"
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Cache-Control", maxAge)
+		_, _ = w.Write([]byte(testSchema))
+	}))
+	t.Cleanup(srv.Close)
+	ctx := context.Background()
+
+	r := newTestResolver(t, srv, Options{})
+	if _, err := r.Fetch(ctx, testID); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	doc, err := r.Fetch(ctx, testID)
+	if err != nil {
+		t.Fatalf("second Fetch failed: %v", err)
+	}
+	if doc.Source != SourceCache || requests.Load() != 1 {
+		t.Fatalf("expected fresh cache hit without a request, got %+v (requests: %d)", doc, requests.Load())
+	}
+
+	// no-cache overrides Options.TTL
+	maxAge = "no-cache"
+	r = newTestResolver(t, srv, Options{TTL: time.Hour})
+	for i := 0; i < 2; i++ {
+		if _, err := r.Fetch(ctx, testID); err != nil {
+			t.Fatalf("Fetch failed: %v", err)
+		}
+	}
+	if requests.Load() != 3 {
+		t.Errorf("expected no-cache responses to be revalidated, got %d requests", requests.Load())
+	}
+
+	if got := freshnessLifetime(http.Header{}, time.Minute); got != time.Minute {
+		t.Errorf("expected TTL fallback without Cache-Control, got %v", got)
+	}
+	if got := freshnessLifetime(http.Header{"Cache-Control": {"public, max-age=30"}}, time.Minute); got != 30*time.Second {
+		t.Errorf("expected max-age to take precedence, got %v", got)
+	}
+}
+
+func TestFetchRetriesTransientFailures(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(testSchema))
+	}))
+	t.Cleanup(srv.Close)
+
+	policy := &foundry.RetryPolicy{
+		InitialInterval: foundry.HumanDuration(time.Millisecond),
+		MaxInterval:     foundry.HumanDuration(10 * time.Millisecond),
+		Multiplier:      2,
+		MaxAttempts:     3,
+	}
+	r := newTestResolver(t, srv, Options{Retry: policy})
+	doc, err := r.Fetch(context.Background(), testID)
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if doc.Source != SourceRemote || requests.Load() != 2 {
+		t.Fatalf("expected remote document after one retry, got %+v (requests: %d)", doc, requests.Load())
+	}
+
+	// Without a retry policy the failure is reported after one attempt
+	requests.Store(0)
+	r = newTestResolver(t, srv, Options{})
+	if _, err := r.Fetch(context.Background(), testID); err == nil || requests.Load() != 1 {
+		t.Fatalf("expected a single failed attempt, got %v (requests: %d)", err, requests.Load())
+	}
+}
+
+func TestNewResolverValidation(t *testing.T) {
+	for _, opts := range []Options{
+		{BaseURL: "http://schemas.example.com", CacheDir: "-"},
+		{BaseURL: "schemas.example.com", CacheDir: "-"},
+		{BaseURL: "https://schemas.example.com", CacheDir: "-", Pins: map[string]string{testID: "md5:abc"}},
+	} {
+		if _, err := NewResolver(opts); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+
+	r, err := NewResolver(Options{BaseURL: "https://schemas.example.com", CacheDir: "-"})
+	if err != nil {
+		t.Fatalf("NewResolver failed: %v", err)
+	}
+	for _, id := range []string{"", "../etc/passwd", "/abs/v1/x", "a//b"} {
+		if _, err := r.Fetch(context.Background(), id); err == nil {
+			t.Errorf("expected invalid id error for %q", id)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RenderFormat selects the output of Renderer.Render.
+type RenderFormat string
+
+const (
+	// RenderText renders human-readable "file:line:col" lines grouped by instance path.
+	RenderText RenderFormat = "text"
+	// RenderJSON renders grouped diagnostics as JSON.
+	RenderJSON RenderFormat = "json"
+	// RenderSARIF renders a SARIF 2.1.0 log for CI code-scanning annotations.
+	RenderSARIF RenderFormat = "sarif"
+)
+
+// DiagnosticGroup collects the diagnostics reported for one instance path.
+type DiagnosticGroup struct {
+	Pointer     string         `json:"pointer"`
+	Location    SourceLocation `json:"location"`
+	Diagnostics []Diagnostic   `json:"diagnostics"`
+}
+
+// Renderer maps diagnostics back to positions in the validated JSON or YAML
+// source and renders them as text, JSON, or SARIF.
+//
+// Example:
+//
+//	content, _ := os.ReadFile("config.yaml")
+//	diags, _ := schema.ValidateFileByID("observability/logging/v1.0.0/logger-config", "config.yaml")
+//	renderer, err := schema.NewRenderer("config.yaml", content)
+//	if err == nil {
+//	    _ = renderer.Render(os.Stdout, diags, schema.RenderSARIF)
+//	}
+type Renderer struct {
+	path      string
+	locations map[string]SourceLocation
+}
+
+// NewRenderer indexes source (JSON or YAML) so JSON pointers can be mapped to
+// line/column positions. path is the file name used in rendered output.
+func NewRenderer(path string, source []byte) (*Renderer, error) {
+	r := &Renderer{path: path, locations: make(map[string]SourceLocation)}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(source, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse source: %w", err)
+	}
+	if len(doc.Content) > 0 {
+		r.index(doc.Content[0], "", doc.Content[0])
+	}
+	return r, nil
+}
+
+// index records positions for node and its descendants. Object members are
+// located at their key so annotations point at the property name.
+func (r *Renderer) index(node *yaml.Node, pointer string, at *yaml.Node) {
+	r.locations[pointer] = SourceLocation{Line: at.Line, Column: at.Column}
+
+	switch node.Kind {
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			r.index(node.Alias, pointer, at)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			r.index(value, pointer+"/"+escapePointerToken(key.Value), key)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			r.index(item, pointer+"/"+strconv.Itoa(i), item)
+		}
+	}
+}
+
+// Locate returns the source position for a JSON pointer, falling back to the
+// nearest ancestor present in the source. ok is false when the source is
+// empty or pointer is not a JSON pointer (non-empty without a leading "/").
+func (r *Renderer) Locate(pointer string) (SourceLocation, bool) {
+	for {
+		if loc, ok := r.locations[pointer]; ok {
+			return loc, true
+		}
+		i := strings.LastIndex(pointer, "/")
+		if i < 0 {
+			return SourceLocation{}, false
+		}
+		pointer = pointer[:i]
+	}
+}
+
+// location returns the diagnostic's own position if it has one, else the
+// position of its pointer.
+func (r *Renderer) location(d Diagnostic) SourceLocation {
+	if d.Location != nil {
+		return *d.Location
+	}
+	loc, _ := r.Locate(d.Pointer)
+	return loc
+}
+
+// Group groups diagnostics by instance path and source position, in source
+// order. Wrapper diagnostics that only summarize more specific causes (e.g.
+// "doesn't validate with ...", "allOf failed") are dropped.
+func (r *Renderer) Group(diags []Diagnostic) []DiagnosticGroup {
+	type groupKey struct {
+		pointer  string
+		location SourceLocation
+	}
+	var groups []DiagnosticGroup
+	index := make(map[groupKey]int)
+	for _, d := range leafDiagnostics(diags) {
+		key := groupKey{pointer: d.Pointer, location: r.location(d)}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, DiagnosticGroup{Pointer: d.Pointer, Location: key.location})
+		}
+		groups[i].Diagnostics = append(groups[i].Diagnostics, d)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i].Location, groups[j].Location
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return groups[i].Pointer < groups[j].Pointer
+	})
+	return groups
+}
+
+// Render writes diags to w in the given format.
+func (r *Renderer) Render(w io.Writer, diags []Diagnostic, format RenderFormat) error {
+	groups := r.Group(diags)
+	switch format {
+	case RenderText, "":
+		return r.renderText(w, groups)
+	case RenderJSON:
+		payload := map[string]any{
+			"file":   r.path,
+			"valid":  len(groups) == 0,
+			"groups": groups,
+		}
+		return writeJSON(w, payload)
+	case RenderSARIF:
+		return writeJSON(w, r.sarifLog(groups))
+	default:
+		return fmt.Errorf("unsupported render format %q (must be text, json, or sarif)", format)
+	}
+}
+
+func (r *Renderer) renderText(w io.Writer, groups []DiagnosticGroup) error {
+	for _, g := range groups {
+		pointer := g.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s\n", r.path, g.Location.Line, g.Location.Column, pointer); err != nil {
+			return err
+		}
+		for _, d := range g.Diagnostics {
+			if _, err := fmt.Fprintf(w, "  %s %s: %s\n", d.Severity, ruleID(d), d.Message); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SARIF 2.1.0 structures (only the fields gofulmen emits).
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+func (r *Renderer) sarifLog(groups []DiagnosticGroup) sarifLog {
+	results := []sarifResult{}
+	seenRules := make(map[string]bool)
+	rules := []sarifRule{}
+
+	for _, g := range groups {
+		location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: r.path},
+		}}
+		if g.Location.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{StartLine: g.Location.Line, StartColumn: g.Location.Column}
+		}
+
+		for _, d := range g.Diagnostics {
+			id := ruleID(d)
+			if !seenRules[id] {
+				seenRules[id] = true
+				rules = append(rules, sarifRule{ID: id})
+			}
+			level := "error"
+			if d.Severity == SeverityWarn {
+				level = "warning"
+			}
+			results = append(results, sarifResult{
+				RuleID:     id,
+				Level:      level,
+				Message:    sarifMessage{Text: d.Message},
+				Locations:  []sarifLocation{location},
+				Properties: map[string]string{"pointer": d.Pointer, "keyword": d.Keyword},
+			})
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           sourceGoFulmen,
+				InformationURI: "https://github.com/fulmenhq/gofulmen",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// ruleID returns the failing keyword (last keyword location segment), e.g. "enum".
+func ruleID(d Diagnostic) string {
+	keyword := d.Keyword[strings.LastIndex(d.Keyword, "/")+1:]
+	if keyword == "" {
+		return "schema"
+	}
+	return keyword
+}
+
+// leafDiagnostics drops diagnostics whose keyword location is a strict prefix
+// of another diagnostic's (their message only summarizes the nested failure).
+func leafDiagnostics(diags []Diagnostic) []Diagnostic {
+	var leaves []Diagnostic
+	for i, d := range diags {
+		wrapper := false
+		for j, other := range diags {
+			if i != j && strings.HasPrefix(other.Keyword, d.Keyword+"/") {
+				wrapper = true
+				break
+			}
+		}
+		if !wrapper {
+			leaves = append(leaves, d)
+		}
+	}
+	return leaves
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,185 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const renderSchema = `{
+  "type": "object",
+  "required": ["name", "port"],
+  "properties": {
+    "name": {"type": "string"},
+    "port": {"type": "integer", "maximum": 65535},
+    "sinks": {"type": "array", "items": {"type": "object", "properties": {"type": {"enum": ["console", "file"]}}}}
+  },
+  "allOf": [{"properties": {"name": {"minLength": 3}}}]
+}`
+
+const renderYAML = `name: ab
+port: 70000
+sinks:
+  - type: console
+  - type: syslog
+`
+
+func renderDiagnostics(t *testing.T) []Diagnostic {
+	t.Helper()
+	validator, err := NewValidator([]byte(renderSchema))
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+	diags, err := validator.ValidateData(map[string]any{
+		"name":  "ab",
+		"port":  70000,
+		"sinks": []any{map[string]any{"type": "console"}, map[string]any{"type": "syslog"}},
+	})
+	if err != nil {
+		t.Fatalf("ValidateData failed: %v", err)
+	}
+	return diags
+}
+
+func TestRendererLocate(t *testing.T) {
+	r, err := NewRenderer("config.yaml", []byte(renderYAML))
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+	tests := map[string]SourceLocation{
+		"":              {Line: 1, Column: 1},
+		"/port":         {Line: 2, Column: 1},
+		"/sinks/1":      {Line: 5, Column: 5},
+		"/sinks/1/type": {Line: 5, Column: 5},
+		"/sinks/1/nope": {Line: 5, Column: 5}, // falls back to nearest ancestor
+	}
+	for pointer, want := range tests {
+		got, ok := r.Locate(pointer)
+		if !ok || got != want {
+			t.Errorf("Locate(%q) = %v, %v; want %v", pointer, got, ok, want)
+		}
+	}
+
+	// Not JSON pointers: no leading "/"
+	for _, pointer := range []string{"port", "sinks/1"} {
+		if got, ok := r.Locate(pointer); ok {
+			t.Errorf("Locate(%q) = %v, true; want false", pointer, got)
+		}
+	}
+
+	jsonSource := "{\n  \"a\": {\n    \"b~/c\": [1, 2]\n  }\n}\n"
+
+	r, err = NewRenderer("data.json", []byte(jsonSource))
+	if err != nil {
+		t.Fatalf("NewRenderer (JSON) failed: %v", err)
+	}
+	if got, _ := r.Locate("/a/b~0~1c/1"); got != (SourceLocation{Line: 3, Column: 17}) {
+		t.Errorf("unexpected JSON location %v", got)
+	}
+}
+
+func TestRendererGroup(t *testing.T) {
+	r, err := NewRenderer("config.yaml", []byte(renderYAML))
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+	groups := r.Group(renderDiagnostics(t))
+
+	var pointers []string
+	for _, g := range groups {
+		pointers = append(pointers, g.Pointer)
+		for _, d := range g.Diagnostics {
+			if strings.HasPrefix(d.Message, "doesn't validate with") || d.Message == "allOf failed" {
+				t.Errorf("wrapper diagnostic not dropped: %+v", d)
+			}
+		}
+	}
+	if got := strings.Join(pointers, ","); got != "/name,/port,/sinks/1/type" {
+		t.Errorf("groups in source order = %s", got)
+	}
+	if groups[2].Location.Line != 5 {
+		t.Errorf("expected /sinks/1/type on line 5, got %v", groups[2].Location)
+	}
+}
+
+func TestRendererGroupLocatedDiagnostics(t *testing.T) {
+	content := []byte("name: service\nbase: &defaults\n  retries: 3\n")
+	r, err := NewRenderer("config.yaml", content)
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+	strict, err := CheckYAML(content, nil)
+	if err != nil {
+		t.Fatalf("CheckYAML failed: %v", err)
+	}
+	schemaDiag := Diagnostic{Pointer: "/base", Keyword: "/properties/base/type", Message: "expected string", Severity: SeverityError}
+
+	// The anchor is reported at its own position on the value, apart from
+	// the schema diagnostic located at the /base key
+	groups := r.Group(append(strict, schemaDiag))
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %+v", groups)
+	}
+	if groups[0].Location != (SourceLocation{Line: 2, Column: 1}) || groups[0].Diagnostics[0].Keyword != schemaDiag.Keyword {
+		t.Errorf("unexpected first group: %+v", groups[0])
+	}
+	if groups[1].Location != (SourceLocation{Line: 2, Column: 7}) || groups[1].Diagnostics[0].Keyword != keywordAnchor {
+		t.Errorf("unexpected second group: %+v", groups[1])
+	}
+}
+
+func TestRendererRender(t *testing.T) {
+	r, err := NewRenderer("config.yaml", []byte(renderYAML))
+	if err != nil {
+		t.Fatalf("NewRenderer failed: %v", err)
+	}
+	diags := renderDiagnostics(t)
+
+	var text bytes.Buffer
+	if err := r.Render(&text, diags, RenderText); err != nil {
+		t.Fatalf("Render text failed: %v", err)
+	}
+	if !strings.Contains(text.String(), "config.yaml:2:1: /port\n  ERROR maximum:") {
+		t.Errorf("unexpected text output:\n%s", text.String())
+	}
+
+	var jsonOut bytes.Buffer
+	if err := r.Render(&jsonOut, diags, RenderJSON); err != nil {
+		t.Fatalf("Render json failed: %v", err)
+	}
+	var payload struct {
+		Valid  bool              `json:"valid"`
+		Groups []DiagnosticGroup `json:"groups"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &payload); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if payload.Valid || len(payload.Groups) != 3 {
+		t.Errorf("unexpected JSON payload: %+v", payload)
+	}
+
+	var sarif bytes.Buffer
+	if err := r.Render(&sarif, diags, RenderSARIF); err != nil {
+		t.Fatalf("Render sarif failed: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(sarif.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF output: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != 3 {
+		t.Fatalf("unexpected SARIF log: %+v", log)
+	}
+	result := log.Runs[0].Results[2]
+	if result.RuleID != "enum" || result.Level != "error" || result.Locations[0].PhysicalLocation.Region.StartLine != 5 {
+		t.Errorf("unexpected SARIF result: %+v", result)
+	}
+
+	var empty bytes.Buffer
+	if err := r.Render(&empty, nil, RenderSARIF); err != nil || !strings.Contains(empty.String(), `"results": []`) {
+		t.Errorf("expected empty SARIF results array, got %s (%v)", empty.String(), err)
+	}
+	if err := r.Render(&empty, diags, "xml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
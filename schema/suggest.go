@@ -0,0 +1,358 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Suggestion kinds returned by SuggestValues.
+const (
+	SuggestionEnum     = "enum"
+	SuggestionConst    = "const"
+	SuggestionProperty = "property"
+)
+
+// ValueSuggestion is a completion candidate at a document location.
+type ValueSuggestion struct {
+	// Value is the literal value (enum/const) or property name.
+	Value any
+	// Label is the display form of Value (strings unquoted, other values as JSON).
+	Label string
+	// Kind is SuggestionEnum, SuggestionConst, or SuggestionProperty.
+	Kind string
+	// Description is the schema description of the candidate, when available.
+	Description string
+}
+
+// SuggestValues returns completions for the document location identified by a
+// JSON pointer, using the default catalog.
+//
+// See Catalog.SuggestValues.
+func SuggestValues(schemaID, pointer, prefix string) ([]ValueSuggestion, error) {
+	return globalCatalog().SuggestValues(schemaID, pointer, prefix)
+}
+
+// SuggestValues returns valid enum/const values at the document location
+// identified by pointer (a JSON pointer into the instance document, e.g.
+// "/sinks/0/type"), plus property-name completions when the location is an object.
+//
+// Only candidates whose label starts with prefix (case-insensitive) are returned.
+// The schema is walked through properties, patternProperties, additionalProperties,
+// items/prefixItems, allOf/anyOf/oneOf, and $ref (local, relative file, and
+// schemas.fulmenhq.dev references).
+//
+// Example:
+//
+//	suggestions, err := schema.SuggestValues("observability/logging/v1.0.0/logger-config", "/profile", "ST")
+//	// suggestions[0].Label == "STRUCTURED"
+func (c *Catalog) SuggestValues(schemaID, pointer, prefix string) ([]ValueSuggestion, error) {
+	desc, err := c.GetSchema(schemaID)
+	if err != nil {
+		return nil, err
+	}
+	root, err := loadSchemaDocument(desc.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load schema %s: %w", schemaID, err)
+	}
+
+	resolver := &suggestResolver{baseDir: c.baseDir, docs: map[string]any{desc.Path: root}}
+	return resolver.suggest(schemaNode{value: root, path: desc.Path, root: root}, pointer, prefix)
+}
+
+// SuggestValuesFromSchema returns completions from raw schema bytes (JSON or YAML).
+// Only local ($ref "#/...") references are resolved.
+func SuggestValuesFromSchema(schemaData []byte, pointer, prefix string) ([]ValueSuggestion, error) {
+	normalized, err := normalizeSchemaBytes(schemaData)
+	if err != nil {
+		return nil, err
+	}
+	var root any
+	if err := json.Unmarshal(normalized, &root); err != nil {
+		return nil, err
+	}
+
+	resolver := &suggestResolver{docs: map[string]any{}}
+	return resolver.suggest(schemaNode{value: root, root: root}, pointer, prefix)
+}
+
+// schemaNode is a subschema together with the document it belongs to.
+type schemaNode struct {
+	value any
+	path  string // file path of the containing document ("" for in-memory schemas)
+	root  any    // containing document root, for local $ref resolution
+}
+
+// suggestResolver walks schemas, caching referenced documents.
+type suggestResolver struct {
+	baseDir string
+	docs    map[string]any
+}
+
+// maxRefDepth bounds $ref chains to guard against reference cycles.
+const maxRefDepth = 32
+
+func (r *suggestResolver) suggest(root schemaNode, pointer, prefix string) ([]ValueSuggestion, error) {
+	tokens, err := splitPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := r.expand(root, 0)
+	for _, token := range tokens {
+		var next []schemaNode
+		for _, node := range nodes {
+			for _, child := range r.children(node, token) {
+				next = append(next, r.expand(child, 0)...)
+			}
+		}
+		nodes = next
+		if len(nodes) == 0 {
+			return nil, nil
+		}
+	}
+
+	return collectSuggestions(nodes, prefix), nil
+}
+
+// expand resolves $ref and flattens allOf/anyOf/oneOf into the set of schemas
+// that apply at one location.
+func (r *suggestResolver) expand(node schemaNode, depth int) []schemaNode {
+	obj, ok := node.value.(map[string]any)
+	if !ok || depth > maxRefDepth {
+		return nil
+	}
+
+	result := []schemaNode{node}
+	if ref, ok := obj["$ref"].(string); ok {
+		if target, ok := r.resolveRef(node, ref); ok {
+			result = append(result, r.expand(target, depth+1)...)
+		}
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		branches, _ := obj[keyword].([]any)
+		for _, branch := range branches {
+			result = append(result, r.expand(schemaNode{value: branch, path: node.path, root: node.root}, depth+1)...)
+		}
+	}
+	return result
+}
+
+// children returns the subschemas governing a child token of an instance location.
+func (r *suggestResolver) children(node schemaNode, token string) []schemaNode {
+	obj, ok := node.value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	child := func(v any) schemaNode {
+		return schemaNode{value: v, path: node.path, root: node.root}
+	}
+
+	var result []schemaNode
+	matched := false
+	if props, ok := obj["properties"].(map[string]any); ok {
+		if sub, ok := props[token]; ok {
+			result = append(result, child(sub))
+			matched = true
+		}
+	}
+	if patterns, ok := obj["patternProperties"].(map[string]any); ok {
+		for pattern, sub := range patterns {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(token) {
+				result = append(result, child(sub))
+				matched = true
+			}
+		}
+	}
+	if !matched {
+		if sub, ok := obj["additionalProperties"].(map[string]any); ok {
+			result = append(result, child(sub))
+		}
+	}
+
+	if index, err := strconv.Atoi(token); err == nil && index >= 0 {
+		prefixItems, _ := obj["prefixItems"].([]any)
+		switch {
+		case index < len(prefixItems):
+			result = append(result, child(prefixItems[index]))
+		case obj["items"] != nil:
+			if items, ok := obj["items"].([]any); ok {
+				// Draft-07 tuple form
+				if index < len(items) {
+					result = append(result, child(items[index]))
+				}
+			} else {
+				result = append(result, child(obj["items"]))
+			}
+		}
+	}
+	return result
+}
+
+// resolveRef resolves a $ref relative to the node's document.
+func (r *suggestResolver) resolveRef(node schemaNode, ref string) (schemaNode, bool) {
+	location, fragment, _ := strings.Cut(ref, "#")
+
+	target := node
+	if location != "" {
+		path := r.refPath(node.path, location)
+		if path == "" {
+			return schemaNode{}, false
+		}
+		doc, ok := r.docs[path]
+		if !ok {
+			loaded, err := loadSchemaDocument(path)
+			if err != nil {
+				return schemaNode{}, false
+			}
+			r.docs[path] = loaded
+			doc = loaded
+		}
+		target = schemaNode{value: doc, path: path, root: doc}
+	}
+
+	if fragment == "" {
+		return schemaNode{value: target.root, path: target.path, root: target.root}, true
+	}
+	value, ok := lookupSchemaPointer(target.root, fragment)
+	if !ok {
+		return schemaNode{}, false
+	}
+	return schemaNode{value: value, path: target.path, root: target.root}, true
+}
+
+// refPath maps a $ref location to a local schema file, or "" if unsupported.
+func (r *suggestResolver) refPath(fromPath, location string) string {
+	const fulmenPrefix = "https://schemas.fulmenhq.dev/"
+	switch {
+	case strings.HasPrefix(location, fulmenPrefix):
+		if r.baseDir == "" {
+			return ""
+		}
+		return mapSchemaURLToPath(r.baseDir, strings.TrimPrefix(location, fulmenPrefix))
+	case strings.Contains(location, "://"):
+		return ""
+	case fromPath == "":
+		return ""
+	default:
+		return filepath.Join(filepath.Dir(fromPath), filepath.FromSlash(location))
+	}
+}
+
+// collectSuggestions gathers enum, const, and property-name candidates.
+func collectSuggestions(nodes []schemaNode, prefix string) []ValueSuggestion {
+	var values, properties []ValueSuggestion
+	seen := make(map[string]bool)
+	add := func(list *[]ValueSuggestion, s ValueSuggestion) {
+		key := s.Kind + "\x00" + s.Label
+		if seen[key] || !strings.HasPrefix(strings.ToLower(s.Label), strings.ToLower(prefix)) {
+			return
+		}
+		seen[key] = true
+		*list = append(*list, s)
+	}
+
+	for _, node := range nodes {
+		obj, ok := node.value.(map[string]any)
+		if !ok {
+			continue
+		}
+		description, _ := obj["description"].(string)
+
+		if enum, ok := obj["enum"].([]any); ok {
+			for _, v := range enum {
+				add(&values, ValueSuggestion{Value: v, Label: suggestionLabel(v), Kind: SuggestionEnum, Description: description})
+			}
+		}
+		if v, ok := obj["const"]; ok {
+			add(&values, ValueSuggestion{Value: v, Label: suggestionLabel(v), Kind: SuggestionConst, Description: description})
+		}
+		if props, ok := obj["properties"].(map[string]any); ok {
+			for name, sub := range props {
+				propDescription := ""
+				if subObj, ok := sub.(map[string]any); ok {
+					propDescription, _ = subObj["description"].(string)
+				}
+				add(&properties, ValueSuggestion{Value: name, Label: name, Kind: SuggestionProperty, Description: propDescription})
+			}
+		}
+	}
+
+	// Enum order is meaningful in the schema; property maps are unordered
+	sort.SliceStable(properties, func(i, j int) bool {
+		return properties[i].Label < properties[j].Label
+	})
+	return append(values, properties...)
+}
+
+// suggestionLabel renders a value for display.
+func suggestionLabel(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// splitPointer splits a JSON pointer into unescaped reference tokens.
+func splitPointer(pointer string) ([]string, error) {
+	if pointer == "" || pointer == "/" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// lookupSchemaPointer resolves a JSON pointer fragment within a schema document.
+func lookupSchemaPointer(doc any, fragment string) (any, bool) {
+	tokens, err := splitPointer(fragment)
+	if err != nil {
+		return nil, false
+	}
+	current := doc
+	for _, token := range tokens {
+		switch node := current.(type) {
+		case map[string]any:
+			next, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// loadSchemaDocument reads a schema file (JSON or YAML) as a generic document.
+func loadSchemaDocument(path string) (any, error) {
+	data, err := loadAndNormalize(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -0,0 +1,97 @@
+package schema
+
+import "testing"
+
+func suggestionLabels(suggestions []ValueSuggestion) []string {
+	labels := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		labels[i] = s.Label
+	}
+	return labels
+}
+
+func TestSuggestValues(t *testing.T) {
+	const id = "observability/logging/v1.0.0/logger-config"
+
+	tests := []struct {
+		name     string
+		pointer  string
+		prefix   string
+		expected []string
+		kind     string
+	}{
+		{name: "enum with prefix", pointer: "/profile", prefix: "st", expected: []string{"STRUCTURED"}, kind: SuggestionEnum},
+		{name: "local ref", pointer: "/defaultLevel", prefix: "", expected: []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL", "NONE"}, kind: SuggestionEnum},
+		{name: "array items", pointer: "/sinks/0/type", prefix: "", expected: []string{"console", "file", "rolling-file", "external"}, kind: SuggestionEnum},
+		{name: "remote ref", pointer: "/middleware/2/type", prefix: "re", expected: []string{"redaction"}, kind: SuggestionEnum},
+		{name: "property names", pointer: "/sinks/0", prefix: "f", expected: []string{"format"}, kind: SuggestionProperty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions, err := SuggestValues(id, tt.pointer, tt.prefix)
+			if err != nil {
+				t.Fatalf("SuggestValues failed: %v", err)
+			}
+			labels := suggestionLabels(suggestions)
+			if len(labels) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, labels)
+			}
+			for i, label := range tt.expected {
+				if labels[i] != label {
+					t.Errorf("suggestion %d: expected %q, got %q", i, label, labels[i])
+				}
+				if suggestions[i].Kind != tt.kind {
+					t.Errorf("suggestion %d: expected kind %q, got %q", i, tt.kind, suggestions[i].Kind)
+				}
+			}
+		})
+	}
+
+	if _, err := SuggestValues("does/not/exist", "/", ""); err == nil {
+		t.Error("expected error for unknown schema")
+	}
+}
+
+func TestSuggestValuesFromSchema(t *testing.T) {
+	schemaData := []byte(`{
+		"type": "object",
+		"properties": {
+			"mode": {"oneOf": [{"const": "fast"}, {"const": "safe"}, {"const": 3}]},
+			"labels": {"type": "object", "additionalProperties": {"enum": ["a", "b"]}},
+			"tier": {"$ref": "#/$defs/tier"}
+		},
+		"patternProperties": {"^x-": {"enum": [true, false]}},
+		"$defs": {"tier": {"enum": ["gold", "silver"]}}
+	}`)
+
+	cases := map[string][]string{
+		"/mode":       {"fast", "safe", "3"},
+		"/labels/any": {"a", "b"},
+		"/tier":       {"gold", "silver"},
+		"/x-flag":     {"true", "false"},
+		"":            {"labels", "mode", "tier"},
+		"/missing":    {},
+	}
+	for pointer, expected := range cases {
+		suggestions, err := SuggestValuesFromSchema(schemaData, pointer, "")
+		if err != nil {
+			t.Fatalf("%s: SuggestValuesFromSchema failed: %v", pointer, err)
+		}
+		labels := suggestionLabels(suggestions)
+		if len(labels) != len(expected) {
+			t.Errorf("%s: expected %v, got %v", pointer, expected, labels)
+			continue
+		}
+		for i := range expected {
+			if labels[i] != expected[i] {
+				t.Errorf("%s: expected %v, got %v", pointer, expected, labels)
+				break
+			}
+		}
+	}
+
+	if _, err := SuggestValuesFromSchema(schemaData, "mode", ""); err == nil {
+		t.Error("expected error for pointer without leading slash")
+	}
+}
@@ -0,0 +1,59 @@
+package trust
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+// cosignKey is a cosign public key for key-based blob signatures.
+type cosignKey struct {
+	ecdsa   *ecdsa.PublicKey
+	ed25519 ed25519.PublicKey
+}
+
+// parseCosignPublicKey parses a PEM-encoded PKIX public key (cosign.pub).
+func parseCosignPublicKey(text string) (*cosignKey, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(text)))
+	if block == nil {
+		return nil, fmt.Errorf("invalid cosign public key: expected PEM")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cosign public key: %w", err)
+	}
+
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		return &cosignKey{ecdsa: key}, nil
+	case ed25519.PublicKey:
+		return &cosignKey{ed25519: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported cosign public key type %T", pub)
+	}
+}
+
+// verify checks a base64-encoded signature from `cosign sign-blob`.
+func (k *cosignKey) verify(data, signature []byte) error {
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("invalid cosign signature encoding: %w", err)
+	}
+
+	if k.ed25519 != nil {
+		if !ed25519.Verify(k.ed25519, data, sig) {
+			return fmt.Errorf("cosign signature does not match")
+		}
+		return nil
+	}
+	digest := sha256.Sum256(data)
+	if !ecdsa.VerifyASN1(k.ecdsa, digest[:], sig) {
+		return fmt.Errorf("cosign signature does not match")
+	}
+	return nil
+}
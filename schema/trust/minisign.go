@@ -0,0 +1,93 @@
+package trust
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	minisignAlgPure      = "Ed" // signature over the message
+	minisignAlgPrehashed = "ED" // signature over BLAKE2b-512(message), the default since minisign 0.8
+
+	minisignTrustedPrefix = "trusted comment: "
+)
+
+// minisignKey is a minisign Ed25519 public key.
+type minisignKey struct {
+	keyID [8]byte
+	key   ed25519.PublicKey
+}
+
+// parseMinisignPublicKey parses a minisign public key, either the bare base64
+// line or the full .pub file with its untrusted comment.
+func parseMinisignPublicKey(text string) (*minisignKey, error) {
+	var encoded string
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "untrusted comment:") {
+			encoded = line
+			break
+		}
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid minisign public key")
+	}
+	if string(raw[:2]) != minisignAlgPure {
+		return nil, fmt.Errorf("unsupported minisign key algorithm %q", raw[:2])
+	}
+
+	k := &minisignKey{key: ed25519.PublicKey(raw[10:])}
+	copy(k.keyID[:], raw[2:10])
+	return k, nil
+}
+
+// verify checks a .minisig file: the signature over data and the global
+// signature binding the trusted comment.
+func (k *minisignKey) verify(data, signature []byte) error {
+	lines := strings.Split(strings.TrimSpace(string(signature)), "\n")
+	if len(lines) < 4 {
+		return fmt.Errorf("invalid minisign signature: expected 4 lines")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("invalid minisign signature encoding")
+	}
+	if !bytes.Equal(sig[2:10], k.keyID[:]) {
+		return fmt.Errorf("minisign signature key ID does not match")
+	}
+
+	message := data
+	switch string(sig[:2]) {
+	case minisignAlgPure:
+	case minisignAlgPrehashed:
+		digest := blake2b.Sum512(data)
+		message = digest[:]
+	default:
+		return fmt.Errorf("unsupported minisign signature algorithm %q", sig[:2])
+	}
+	if !ed25519.Verify(k.key, message, sig[10:]) {
+		return fmt.Errorf("minisign signature does not match")
+	}
+
+	trusted := strings.TrimRight(lines[2], "\r")
+	if !strings.HasPrefix(trusted, minisignTrustedPrefix) {
+		return fmt.Errorf("invalid minisign signature: missing trusted comment")
+	}
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid minisign global signature encoding")
+	}
+	signed := append(append([]byte{}, sig[10:]...), strings.TrimPrefix(trusted, minisignTrustedPrefix)...)
+	if !ed25519.Verify(k.key, signed, global) {
+		return fmt.Errorf("minisign trusted comment signature does not match")
+	}
+	return nil
+}
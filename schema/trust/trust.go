@@ -0,0 +1,326 @@
+// Package trust verifies signatures on exported or bundled schemas against a
+// trust policy before they are used for validation.
+//
+// Supported signature formats:
+//
+//   - minisign: Ed25519 signatures (legacy and BLAKE2b-prehashed) with trusted
+//     comments, as produced by `minisign -S`
+//   - cosign: key-based blob signatures (ECDSA P-256 or Ed25519 PKIX public keys),
+//     as produced by `cosign sign-blob --key`. Keyless (Fulcio/Rekor) signatures
+//     are not supported.
+//
+// A Policy lists trusted signers, optionally requires specific signers, and may
+// allow unsigned schemas for development. Invalid signatures are always rejected,
+// even when unsigned schemas are allowed.
+//
+// Example:
+//
+//	policy, err := trust.LoadPolicy("schema-trust.yaml")
+//	if err != nil {
+//	    return err
+//	}
+//	validator, err := policy.NewValidator("vendor/schemas/logger-config.json") // checks .minisig / .sig
+package trust
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/schema"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrUnsigned is returned when no signature is present and the policy does not allow unsigned schemas.
+	ErrUnsigned = errors.New("schema is not signed")
+
+	// ErrUntrusted is returned when signatures are invalid or required signers are missing.
+	ErrUntrusted = errors.New("schema signature not trusted")
+)
+
+// SignatureFormat identifies a signature scheme.
+type SignatureFormat string
+
+const (
+	// FormatMinisign is a minisign signature (.minisig).
+	FormatMinisign SignatureFormat = "minisign"
+
+	// FormatCosign is a cosign key-based blob signature (.sig).
+	FormatCosign SignatureFormat = "cosign"
+)
+
+// signatureExtensions maps detached signature file extensions to formats.
+var signatureExtensions = []struct {
+	ext    string
+	format SignatureFormat
+}{
+	{".minisig", FormatMinisign},
+	{".sig", FormatCosign},
+}
+
+// Signer is a trusted public key.
+type Signer struct {
+	// Name identifies the signer in RequiredSigners and verification results.
+	Name string `json:"name" yaml:"name"`
+
+	// Format is the signature scheme used by this signer.
+	Format SignatureFormat `json:"format" yaml:"format"`
+
+	// PublicKey is the inline public key: a minisign public key (with or without
+	// its comment line) or a PEM-encoded cosign public key.
+	PublicKey string `json:"publicKey,omitempty" yaml:"publicKey,omitempty"`
+
+	// PublicKeyFile is a path to the public key, relative to the policy file when
+	// loaded with LoadPolicy.
+	PublicKeyFile string `json:"publicKeyFile,omitempty" yaml:"publicKeyFile,omitempty"`
+}
+
+// Policy is a schema trust policy.
+type Policy struct {
+	// Signers lists trusted public keys.
+	Signers []Signer `json:"signers" yaml:"signers"`
+
+	// RequiredSigners lists signer names that must all have signed. When empty,
+	// a valid signature from any trusted signer is sufficient.
+	RequiredSigners []string `json:"requiredSigners,omitempty" yaml:"requiredSigners,omitempty"`
+
+	// AllowUnsigned accepts schemas without signatures (intended for development).
+	AllowUnsigned bool `json:"allowUnsigned,omitempty" yaml:"allowUnsigned,omitempty"`
+}
+
+// Signature is a detached signature over a schema document.
+type Signature struct {
+	// Format is the signature scheme.
+	Format SignatureFormat
+
+	// Data is the signature file contents.
+	Data []byte
+
+	// Source describes where the signature came from (e.g., its file path).
+	Source string
+}
+
+// VerifyResult describes a successful verification.
+type VerifyResult struct {
+	// Signers lists the names of signers with valid signatures, sorted.
+	Signers []string `json:"signers,omitempty"`
+
+	// Unsigned is true when the schema was accepted without signatures under AllowUnsigned.
+	Unsigned bool `json:"unsigned,omitempty"`
+}
+
+// LoadPolicy reads a policy from a YAML or JSON file. Relative PublicKeyFile
+// paths are resolved against the policy file's directory.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- Policy path is caller-provided configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust policy: %w", err)
+	}
+
+	var policy Policy
+	if strings.HasSuffix(path, ".json") {
+		err = json.Unmarshal(data, &policy)
+	} else {
+		err = yaml.Unmarshal(data, &policy)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trust policy %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	for i := range policy.Signers {
+		if keyFile := policy.Signers[i].PublicKeyFile; keyFile != "" && !filepath.IsAbs(keyFile) {
+			policy.Signers[i].PublicKeyFile = filepath.Join(dir, keyFile)
+		}
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Validate checks that signers are well-formed and their keys parse.
+func (p *Policy) Validate() error {
+	names := make(map[string]bool, len(p.Signers))
+	for _, signer := range p.Signers {
+		if signer.Name == "" {
+			return fmt.Errorf("trust policy signer requires a name")
+		}
+		if names[signer.Name] {
+			return fmt.Errorf("duplicate trust policy signer %q", signer.Name)
+		}
+		names[signer.Name] = true
+		if _, err := signer.verifier(); err != nil {
+			return err
+		}
+	}
+	for _, name := range p.RequiredSigners {
+		if !names[name] {
+			return fmt.Errorf("required signer %q is not defined in signers", name)
+		}
+	}
+	if len(p.Signers) == 0 && !p.AllowUnsigned {
+		return fmt.Errorf("trust policy has no signers and does not allow unsigned schemas")
+	}
+	return nil
+}
+
+// Verify checks data against the policy using the given detached signatures.
+func (p *Policy) Verify(data []byte, signatures ...Signature) (*VerifyResult, error) {
+	if len(signatures) == 0 {
+		if p.AllowUnsigned {
+			return &VerifyResult{Unsigned: true}, nil
+		}
+		return nil, ErrUnsigned
+	}
+
+	verified := make(map[string]bool)
+	var failures []string
+	for _, sig := range signatures {
+		matched := false
+		for _, signer := range p.Signers {
+			if signer.Format != sig.Format {
+				continue
+			}
+			v, err := signer.verifier()
+			if err != nil {
+				return nil, err
+			}
+			if v.verify(data, sig.Data) == nil {
+				verified[signer.Name] = true
+				matched = true
+			}
+		}
+		if !matched {
+			failures = append(failures, signatureLabel(sig))
+		}
+	}
+
+	if len(failures) > 0 {
+		// A signature that matches no trusted signer may indicate tampering
+		return nil, fmt.Errorf("%w: no trusted signer verifies %s", ErrUntrusted, strings.Join(failures, ", "))
+	}
+
+	var missing []string
+	for _, name := range p.RequiredSigners {
+		if !verified[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing required signers: %s", ErrUntrusted, strings.Join(missing, ", "))
+	}
+
+	result := &VerifyResult{}
+	for name := range verified {
+		result.Signers = append(result.Signers, name)
+	}
+	sort.Strings(result.Signers)
+	return result, nil
+}
+
+// VerifyFile verifies a schema file using detached signatures found next to it
+// (<path>.minisig for minisign, <path>.sig for cosign).
+func (p *Policy) VerifyFile(path string) (*VerifyResult, error) {
+	_, result, err := p.loadVerified(path)
+	return result, err
+}
+
+// LoadSchema verifies a schema file and returns its contents.
+func (p *Policy) LoadSchema(path string) ([]byte, error) {
+	data, _, err := p.loadVerified(path)
+	return data, err
+}
+
+// NewValidator verifies a schema file and compiles it.
+func (p *Policy) NewValidator(path string) (*schema.Validator, error) {
+	data, err := p.LoadSchema(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml") {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse schema %s: %w", path, err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("failed to convert schema %s: %w", path, err)
+		}
+	}
+	return schema.NewValidator(data)
+}
+
+// loadVerified reads a schema file and its detached signatures and verifies them.
+func (p *Policy) loadVerified(path string) ([]byte, *VerifyResult, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- Schema path is caller-provided
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	var signatures []Signature
+	for _, candidate := range signatureExtensions {
+		sigPath := path + candidate.ext
+		sigData, err := os.ReadFile(sigPath) // #nosec G304 -- Derived from the schema path
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read signature %s: %w", sigPath, err)
+		}
+		signatures = append(signatures, Signature{Format: candidate.format, Data: sigData, Source: sigPath})
+	}
+
+	result, err := p.Verify(data, signatures...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return data, result, nil
+}
+
+func signatureLabel(sig Signature) string {
+	if sig.Source != "" {
+		return sig.Source
+	}
+	return string(sig.Format) + " signature"
+}
+
+// verifier checks a detached signature for one public key.
+type verifier interface {
+	verify(data, signature []byte) error
+}
+
+// verifier parses the signer's public key.
+func (s Signer) verifier() (verifier, error) {
+	key := s.PublicKey
+	if s.PublicKeyFile != "" {
+		data, err := os.ReadFile(s.PublicKeyFile) // #nosec G304 -- Key path is from the trust policy
+		if err != nil {
+			return nil, fmt.Errorf("signer %q: failed to read public key: %w", s.Name, err)
+		}
+		key = string(data)
+	}
+	if strings.TrimSpace(key) == "" {
+		return nil, fmt.Errorf("signer %q: publicKey or publicKeyFile is required", s.Name)
+	}
+
+	var v verifier
+	var err error
+	switch s.Format {
+	case FormatMinisign:
+		v, err = parseMinisignPublicKey(key)
+	case FormatCosign:
+		v, err = parseCosignPublicKey(key)
+	default:
+		return nil, fmt.Errorf("signer %q: unsupported signature format %q", s.Name, s.Format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("signer %q: %w", s.Name, err)
+	}
+	return v, nil
+}
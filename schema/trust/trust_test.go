@@ -0,0 +1,227 @@
+package trust
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const testSchema = `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","required":["name"]}`
+
+// minisignTestKey mirrors `minisign -G` output.
+type minisignTestKey struct {
+	id   [8]byte
+	priv ed25519.PrivateKey
+	pub  string
+}
+
+func newMinisignTestKey(t *testing.T) *minisignTestKey {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := &minisignTestKey{priv: priv}
+	_, _ = rand.Read(k.id[:])
+	raw := append(append([]byte("Ed"), k.id[:]...), pub...)
+	k.pub = "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(raw) + "\n"
+	return k
+}
+
+// sign mirrors `minisign -S` output (prehashed unless legacy is set).
+func (k *minisignTestKey) sign(data []byte, legacy bool) []byte {
+	alg, message := "ED", data
+	if legacy {
+		alg = "Ed"
+	} else {
+		digest := blake2b.Sum512(data)
+		message = digest[:]
+	}
+	sig := ed25519.Sign(k.priv, message)
+	trusted := "timestamp:1700000000\tfile:schema.json"
+	global := ed25519.Sign(k.priv, append(append([]byte{}, sig...), trusted...))
+
+	raw := append(append([]byte(alg), k.id[:]...), sig...)
+	return []byte("untrusted comment: signature from minisign secret key\n" +
+		base64.StdEncoding.EncodeToString(raw) + "\n" +
+		"trusted comment: " + trusted + "\n" +
+		base64.StdEncoding.EncodeToString(global) + "\n")
+}
+
+func newCosignTestKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func cosignSign(t *testing.T, priv *ecdsa.PrivateKey, data []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	key := newMinisignTestKey(t)
+	other := newMinisignTestKey(t)
+	policy := &Policy{Signers: []Signer{{Name: "release", Format: FormatMinisign, PublicKey: key.pub}}}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	data := []byte(testSchema)
+
+	for _, legacy := range []bool{false, true} {
+		result, err := policy.Verify(data, Signature{Format: FormatMinisign, Data: key.sign(data, legacy)})
+		if err != nil {
+			t.Fatalf("Verify (legacy=%v) failed: %v", legacy, err)
+		}
+		if len(result.Signers) != 1 || result.Signers[0] != "release" {
+			t.Errorf("unexpected signers %v", result.Signers)
+		}
+	}
+
+	tampered := []byte(strings.Replace(testSchema, "name", "nom", 1))
+	if _, err := policy.Verify(tampered, Signature{Format: FormatMinisign, Data: key.sign(data, false)}); !errors.Is(err, ErrUntrusted) {
+		t.Errorf("expected ErrUntrusted for modified schema, got %v", err)
+	}
+	if _, err := policy.Verify(data, Signature{Format: FormatMinisign, Data: other.sign(data, false)}); !errors.Is(err, ErrUntrusted) {
+		t.Errorf("expected ErrUntrusted for unknown signer, got %v", err)
+	}
+
+	sig := strings.Replace(string(key.sign(data, false)), "file:schema.json", "file:other.json", 1)
+	if _, err := policy.Verify(data, Signature{Format: FormatMinisign, Data: []byte(sig)}); !errors.Is(err, ErrUntrusted) {
+		t.Errorf("expected ErrUntrusted for modified trusted comment, got %v", err)
+	}
+}
+
+func TestVerifyCosign(t *testing.T) {
+	priv, pub := newCosignTestKey(t)
+	policy := &Policy{Signers: []Signer{{Name: "ci", Format: FormatCosign, PublicKey: pub}}}
+	data := []byte(testSchema)
+
+	if _, err := policy.Verify(data, Signature{Format: FormatCosign, Data: cosignSign(t, priv, data)}); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if _, err := policy.Verify(append(data, ' '), Signature{Format: FormatCosign, Data: cosignSign(t, priv, data)}); !errors.Is(err, ErrUntrusted) {
+		t.Errorf("expected ErrUntrusted for modified schema, got %v", err)
+	}
+}
+
+func TestPolicyRules(t *testing.T) {
+	minisignKey := newMinisignTestKey(t)
+	cosignPriv, cosignPub := newCosignTestKey(t)
+	data := []byte(testSchema)
+	minisig := Signature{Format: FormatMinisign, Data: minisignKey.sign(data, false)}
+	cosig := Signature{Format: FormatCosign, Data: cosignSign(t, cosignPriv, data)}
+
+	policy := &Policy{
+		Signers: []Signer{
+			{Name: "release", Format: FormatMinisign, PublicKey: minisignKey.pub},
+			{Name: "ci", Format: FormatCosign, PublicKey: cosignPub},
+		},
+		RequiredSigners: []string{"release", "ci"},
+	}
+	if _, err := policy.Verify(data, minisig); !errors.Is(err, ErrUntrusted) || !strings.Contains(err.Error(), "ci") {
+		t.Errorf("expected missing required signer error, got %v", err)
+	}
+	result, err := policy.Verify(data, minisig, cosig)
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if strings.Join(result.Signers, ",") != "ci,release" {
+		t.Errorf("unexpected signers %v", result.Signers)
+	}
+
+	if _, err := policy.Verify(data); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("expected ErrUnsigned, got %v", err)
+	}
+	policy.AllowUnsigned = true
+	result, err = policy.Verify(data)
+	if err != nil || !result.Unsigned {
+		t.Errorf("expected unsigned schema to be allowed, got %v %v", result, err)
+	}
+	// Invalid signatures are rejected even when unsigned schemas are allowed
+	if _, err := policy.Verify(append(data, ' '), minisig); !errors.Is(err, ErrUntrusted) {
+		t.Errorf("expected ErrUntrusted, got %v", err)
+	}
+
+	invalid := []*Policy{
+		{},
+		{Signers: []Signer{{Format: FormatMinisign, PublicKey: minisignKey.pub}}},
+		{Signers: []Signer{{Name: "a", Format: "gpg", PublicKey: "key"}}},
+		{Signers: []Signer{{Name: "a", Format: FormatCosign, PublicKey: "not pem"}}},
+		{Signers: []Signer{{Name: "a", Format: FormatMinisign, PublicKey: minisignKey.pub}}, RequiredSigners: []string{"b"}},
+	}
+	for i, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Errorf("policy %d: expected validation error", i)
+		}
+	}
+}
+
+func TestLoadPolicyAndValidator(t *testing.T) {
+	dir := t.TempDir()
+	key := newMinisignTestKey(t)
+	if err := os.WriteFile(filepath.Join(dir, "release.pub"), []byte(key.pub), 0600); err != nil {
+		t.Fatal(err)
+	}
+	policyPath := filepath.Join(dir, "trust.yaml")
+	policyYAML := "signers:\n  - name: release\n    format: minisign\n    publicKeyFile: release.pub\nrequiredSigners: [release]\n"
+	if err := os.WriteFile(policyPath, []byte(policyYAML), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	policy, err := LoadPolicy(policyPath)
+	if err != nil {
+		t.Fatalf("LoadPolicy failed: %v", err)
+	}
+
+	schemaPath := filepath.Join(dir, "schema.json")
+	if err := os.WriteFile(schemaPath, []byte(testSchema), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := policy.NewValidator(schemaPath); !errors.Is(err, ErrUnsigned) {
+		t.Fatalf("expected ErrUnsigned without signature file, got %v", err)
+	}
+
+	if err := os.WriteFile(schemaPath+".minisig", key.sign([]byte(testSchema), false), 0600); err != nil {
+		t.Fatal(err)
+	}
+	validator, err := policy.NewValidator(schemaPath)
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+	diags, err := validator.ValidateJSON([]byte(`{}`))
+	if err != nil || len(diags) == 0 {
+		t.Errorf("expected diagnostics from verified schema, got %v %v", diags, err)
+	}
+
+	if err := os.WriteFile(schemaPath, []byte(`{"type":"object"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := policy.VerifyFile(schemaPath); !errors.Is(err, ErrUntrusted) {
+		t.Errorf("expected ErrUntrusted after modification, got %v", err)
+	}
+}
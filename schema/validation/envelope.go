@@ -11,6 +11,21 @@ import (
 	"github.com/fulmenhq/gofulmen/schema"
 )
 
+// Retry classifications of the error envelope codes this package returns.
+var classificationMap = map[string]errors.Classification{
+	"SCHEMA_VALIDATION_ERROR":  errors.ClassificationPermanent,
+	"SCHEMA_VALIDATION_FAILED": errors.ClassificationPermanent,
+	"SCHEMA_COMPILATION_ERROR": errors.ClassificationPermanent,
+	"SCHEMA_LOAD_ERROR":        errors.ClassificationPermanent,
+	"SCHEMA_REGISTRY_ERROR":    errors.ClassificationPermanent,
+	"JSON_PARSE_ERROR":         errors.ClassificationPermanent,
+	"FILE_ACCESS_ERROR":        errors.ClassificationTransient,
+}
+
+func init() {
+	errors.RegisterClassifications(classificationMap)
+}
+
 // ErrorEnvelope wraps schema validation operations with structured error envelopes
 type ErrorEnvelope struct {
 	validator *schema.Validator
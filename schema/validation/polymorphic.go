@@ -0,0 +1,163 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/foundry/similarity"
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+// Diagnostic keywords emitted by ValidatePolymorphic when schema selection fails.
+const (
+	KeywordDiscriminatorMissing = "discriminator/missing"
+	KeywordDiscriminatorUnknown = "discriminator/unknown"
+)
+
+// PolymorphicResult describes the outcome of discriminator-based validation.
+type PolymorphicResult struct {
+	// Discriminator is the value found at the discriminator pointer (empty if missing).
+	Discriminator string `json:"discriminator,omitempty"`
+
+	// SchemaID is the schema selected for validation (empty if none matched).
+	SchemaID string `json:"schema_id,omitempty"`
+
+	// Diagnostics contains selection and validation diagnostics.
+	Diagnostics []schema.Diagnostic `json:"diagnostics,omitempty"`
+
+	// Suggestions lists known discriminator values similar to an unknown one.
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// Valid reports whether a schema was selected and the payload produced no error diagnostics.
+func (r *PolymorphicResult) Valid() bool {
+	if r.SchemaID == "" {
+		return false
+	}
+	for _, d := range r.Diagnostics {
+		if d.Severity == schema.SeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidatePolymorphic selects a schema by discriminator value and validates JSON data against it
+// using the default catalog.
+//
+// The discriminator is located with a JSON Pointer (e.g., "/kind"), and its value is looked up in
+// mapping to obtain a schema ID (e.g., "Deployment" -> "k8s/v1.0.0/deployment"). A missing or
+// unknown discriminator is reported as a diagnostic rather than an error; unknown values include
+// similarity-based suggestions drawn from the mapping keys.
+func ValidatePolymorphic(data []byte, mapping map[string]string, discriminatorPointer string) (*PolymorphicResult, error) {
+	return ValidatePolymorphicWithCatalog(schema.DefaultCatalog(), data, mapping, discriminatorPointer)
+}
+
+// ValidatePolymorphicWithCatalog is ValidatePolymorphic against an explicit catalog.
+func ValidatePolymorphicWithCatalog(catalog *schema.Catalog, data []byte, mapping map[string]string, discriminatorPointer string) (*PolymorphicResult, error) {
+	if catalog == nil {
+		return nil, fmt.Errorf("catalog is required")
+	}
+	if len(mapping) == 0 {
+		return nil, fmt.Errorf("discriminator mapping is empty")
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	result := &PolymorphicResult{}
+
+	raw, ok, err := resolvePointer(payload, discriminatorPointer)
+	if err != nil {
+		return nil, err
+	}
+	value, isString := raw.(string)
+	if !ok || !isString {
+		result.Diagnostics = append(result.Diagnostics, schema.Diagnostic{
+			Pointer:  discriminatorPointer,
+			Keyword:  KeywordDiscriminatorMissing,
+			Message:  fmt.Sprintf("discriminator at %q is missing or not a string", discriminatorPointer),
+			Severity: schema.SeverityError,
+			Source:   "gofulmen",
+		})
+		return result, nil
+	}
+	result.Discriminator = value
+
+	schemaID, known := mapping[value]
+	if !known {
+		keys := make([]string, 0, len(mapping))
+		for k := range mapping {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, s := range similarity.Suggest(value, keys, similarity.DefaultSuggestOptions()) {
+			result.Suggestions = append(result.Suggestions, s.Value)
+		}
+
+		message := fmt.Sprintf("unknown discriminator %q (expected one of: %s)", value, strings.Join(keys, ", "))
+		if len(result.Suggestions) > 0 {
+			message = fmt.Sprintf("unknown discriminator %q (did you mean %s?)", value, strings.Join(result.Suggestions, ", "))
+		}
+		result.Diagnostics = append(result.Diagnostics, schema.Diagnostic{
+			Pointer:  discriminatorPointer,
+			Keyword:  KeywordDiscriminatorUnknown,
+			Message:  message,
+			Severity: schema.SeverityError,
+			Source:   "gofulmen",
+		})
+		return result, nil
+	}
+	result.SchemaID = schemaID
+
+	validator, err := catalog.ValidatorByID(schemaID)
+	if err != nil {
+		return nil, fmt.Errorf("discriminator %q maps to unavailable schema %q: %w", value, schemaID, err)
+	}
+
+	diags, err := validator.ValidateData(payload)
+	if err != nil {
+		return nil, err
+	}
+	result.Diagnostics = append(result.Diagnostics, diags...)
+	return result, nil
+}
+
+// resolvePointer resolves an RFC 6901 JSON Pointer against a decoded JSON document.
+// The boolean result reports whether the pointer resolved to a value.
+func resolvePointer(doc interface{}, pointer string) (interface{}, bool, error) {
+	if pointer == "" {
+		return doc, true, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false, fmt.Errorf("invalid JSON pointer %q: must start with '/'", pointer)
+	}
+
+	current := doc
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[token]
+			if !ok {
+				return nil, false, nil
+			}
+			current = next
+		case []interface{}:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, false, nil
+			}
+			current = node[idx]
+		default:
+			return nil, false, nil
+		}
+	}
+	return current, true, nil
+}
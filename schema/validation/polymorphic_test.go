@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var polymorphicMapping = map[string]string{
+	"local":  "pathfinder/v1.0.0/path-result",
+	"remote": "pathfinder/v1.0.0/path-result",
+}
+
+func TestValidatePolymorphic(t *testing.T) {
+	t.Run("valid payload selects mapped schema", func(t *testing.T) {
+		data := []byte(`{"relativePath":"a.yaml","sourcePath":"/tmp/a.yaml","logicalPath":"a.yaml","loaderType":"local"}`)
+
+		result, err := ValidatePolymorphic(data, polymorphicMapping, "/loaderType")
+		require.NoError(t, err)
+		assert.Equal(t, "local", result.Discriminator)
+		assert.Equal(t, "pathfinder/v1.0.0/path-result", result.SchemaID)
+		assert.Empty(t, result.Diagnostics)
+		assert.True(t, result.Valid())
+	})
+
+	t.Run("invalid payload reports schema diagnostics", func(t *testing.T) {
+		data := []byte(`{"sourcePath":"/tmp/a.yaml","loaderType":"local"}`)
+
+		result, err := ValidatePolymorphic(data, polymorphicMapping, "/loaderType")
+		require.NoError(t, err)
+		assert.Equal(t, "pathfinder/v1.0.0/path-result", result.SchemaID)
+		assert.NotEmpty(t, result.Diagnostics)
+		assert.False(t, result.Valid())
+	})
+
+	t.Run("unknown discriminator suggests close match", func(t *testing.T) {
+		data := []byte(`{"loaderType":"locl"}`)
+
+		result, err := ValidatePolymorphic(data, polymorphicMapping, "/loaderType")
+		require.NoError(t, err)
+		assert.Empty(t, result.SchemaID)
+		assert.Contains(t, result.Suggestions, "local")
+		require.Len(t, result.Diagnostics, 1)
+		assert.Equal(t, KeywordDiscriminatorUnknown, result.Diagnostics[0].Keyword)
+		assert.Equal(t, schema.SeverityError, result.Diagnostics[0].Severity)
+		assert.False(t, result.Valid())
+	})
+
+	t.Run("missing discriminator is reported", func(t *testing.T) {
+		result, err := ValidatePolymorphic([]byte(`{"spec":{}}`), polymorphicMapping, "/loaderType")
+		require.NoError(t, err)
+		require.Len(t, result.Diagnostics, 1)
+		assert.Equal(t, KeywordDiscriminatorMissing, result.Diagnostics[0].Keyword)
+	})
+
+	t.Run("invalid pointer is an error", func(t *testing.T) {
+		_, err := ValidatePolymorphic([]byte(`{}`), polymorphicMapping, "loaderType")
+		assert.Error(t, err)
+	})
+}
+
+func TestResolvePointer(t *testing.T) {
+	doc := map[string]interface{}{
+		"metadata": map[string]interface{}{"a/b": "slash", "c~d": "tilde"},
+		"items":    []interface{}{"zero", "one"},
+	}
+
+	value, ok, err := resolvePointer(doc, "/metadata/a~1b")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "slash", value)
+
+	value, ok, err = resolvePointer(doc, "/metadata/c~0d")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "tilde", value)
+
+	value, ok, err = resolvePointer(doc, "/items/1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "one", value)
+
+	_, ok, err = resolvePointer(doc, "/items/5")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
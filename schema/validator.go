@@ -19,16 +19,23 @@ type Validator struct {
 	schema     *jsonschema.Schema
 	descriptor SchemaDescriptor
 	metaDir    string
+	strictYAML *YAMLOptions
 }
 
 // NewValidator compiles a schema from raw bytes. Intended for standalone schemas that
 // do not rely on relative references.
 func NewValidator(schemaData []byte) (*Validator, error) {
+	return NewValidatorWithOptions(schemaData, nil)
+}
+
+// NewValidatorWithOptions compiles a schema from raw bytes using opts (nil for defaults).
+func NewValidatorWithOptions(schemaData []byte, opts *CompileOptions) (*Validator, error) {
 	metaDir := filepath.Join(defaultSchemaBaseDir, metaDirName)
 	compiler, err := newCompiler(metaDir)
 	if err != nil {
 		return nil, err
 	}
+	applyCompileOptions(compiler, opts)
 
 	const virtualURL = "memory://schema.json"
 	if err := compiler.AddResource(virtualURL, strings.NewReader(string(schemaData))); err != nil {
@@ -40,16 +47,18 @@ func NewValidator(schemaData []byte) (*Validator, error) {
 	}
 
 	return &Validator{
-		schema:  compiled,
-		metaDir: metaDir,
+		schema:     compiled,
+		metaDir:    metaDir,
+		strictYAML: strictYAMLOption(opts),
 	}, nil
 }
 
-func newValidatorFromDescriptor(desc SchemaDescriptor, metaDir string) (*Validator, error) {
+func newValidatorFromDescriptor(desc SchemaDescriptor, metaDir string, opts *CompileOptions) (*Validator, error) {
 	compiler, err := newCompiler(metaDir)
 	if err != nil {
 		return nil, err
 	}
+	applyCompileOptions(compiler, opts)
 
 	schemaURL := fileURL(desc.Path)
 	compiled, err := compiler.Compile(schemaURL)
@@ -61,9 +70,18 @@ func newValidatorFromDescriptor(desc SchemaDescriptor, metaDir string) (*Validat
 		schema:     compiled,
 		descriptor: desc,
 		metaDir:    metaDir,
+		strictYAML: strictYAMLOption(opts),
 	}, nil
 }
 
+func strictYAMLOption(opts *CompileOptions) *YAMLOptions {
+	if opts == nil || opts.StrictYAML == nil {
+		return nil
+	}
+	copied := *opts.StrictYAML
+	return &copied
+}
+
 // ValidateData validates an in-memory value against the schema and returns diagnostics.
 func (v *Validator) ValidateData(data interface{}) ([]Diagnostic, error) {
 	err := v.schema.Validate(data)
@@ -97,12 +115,34 @@ func (v *Validator) ValidateFile(path string) ([]Diagnostic, error) {
 	if isJSON(content) {
 		return v.ValidateJSON(content)
 	}
+	return v.ValidateYAML(content)
+}
+
+// ValidateYAML validates YAML bytes. When the validator was compiled with
+// CompileOptions.StrictYAML, strict YAML diagnostics come first and schema
+// validation only runs if none of them is an error.
+func (v *Validator) ValidateYAML(content []byte) ([]Diagnostic, error) {
+	var strict []Diagnostic
+	if v.strictYAML != nil {
+		diags, err := CheckYAML(content, v.strictYAML)
+		if err != nil {
+			return nil, err
+		}
+		if hasErrors(diags) {
+			return diags, nil
+		}
+		strict = diags
+	}
 
 	var payload interface{}
 	if err := yaml.Unmarshal(content, &payload); err != nil {
 		return nil, err
 	}
-	return v.ValidateData(payload)
+	diags, err := v.ValidateData(payload)
+	if err != nil {
+		return nil, err
+	}
+	return append(strict, diags...), nil
 }
 
 func newCompiler(metaDir string) (*jsonschema.Compiler, error) {
@@ -113,6 +153,7 @@ func newCompiler(metaDir string) (*jsonschema.Compiler, error) {
 	loader := &localLoader{metaDir: metaDir}
 	compiler := jsonschema.NewCompiler()
 	compiler.LoadURL = loader.Load
+	compiler.ExtractAnnotations = true // keeps `default` for ApplyDefaults
 	return compiler, nil
 }
 
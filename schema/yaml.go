@@ -0,0 +1,195 @@
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLOptions enables strict YAML checks that run before schema validation
+// (see CompileOptions.StrictYAML). Duplicate keys and tab indentation are
+// always reported as errors; the remaining checks report warnings unless
+// allowed.
+type YAMLOptions struct {
+	// AllowAnchors permits anchors, aliases, and merge keys (<<).
+	AllowAnchors bool
+	// AllowAmbiguousScalars permits plain scalars whose type differs between
+	// YAML 1.1 and 1.2 (yes/no/on/off booleans, leading-zero octals).
+	AllowAmbiguousScalars bool
+	// AllowTags permits explicit tags (!!binary, !custom).
+	AllowTags bool
+	// AllowNonStringKeys permits mapping keys that are not strings (1: one),
+	// which have no JSON equivalent.
+	AllowNonStringKeys bool
+}
+
+// Strict YAML diagnostic keywords.
+const (
+	keywordDuplicateKey    = "yaml/duplicate-key"
+	keywordTabIndentation  = "yaml/tab-indentation"
+	keywordAnchor          = "yaml/anchor"
+	keywordAlias           = "yaml/alias"
+	keywordMergeKey        = "yaml/merge-key"
+	keywordAmbiguousScalar = "yaml/ambiguous-scalar"
+	keywordExplicitTag     = "yaml/explicit-tag"
+	keywordNonStringKey    = "yaml/non-string-key"
+)
+
+var (
+	// yaml11Booleans are strings in YAML 1.2 but booleans in YAML 1.1.
+	yaml11Booleans = regexp.MustCompile(`^(?:y|Y|yes|Yes|YES|n|N|no|No|NO|on|On|ON|off|Off|OFF)$`)
+	// leadingZeroInt is an integer that YAML 1.1 (and yaml.v3) reads as octal.
+	leadingZeroInt = regexp.MustCompile(`^[-+]?0[0-9_]+$`)
+)
+
+// CheckYAML runs strict YAML checks on content and returns diagnostics with
+// source positions. opts may be nil for the defaults. An error is returned
+// only when content is not valid YAML and no tab indentation explains it.
+//
+// Example:
+//
+//	diags, err := schema.CheckYAML(content, &schema.YAMLOptions{AllowAnchors: true})
+func CheckYAML(content []byte, opts *YAMLOptions) ([]Diagnostic, error) {
+	if opts == nil {
+		opts = &YAMLOptions{}
+	}
+
+	var doc yaml.Node
+	parseErr := yaml.Unmarshal(content, &doc)
+
+	c := &yamlChecker{opts: opts}
+	if parseErr == nil && len(doc.Content) > 0 {
+		c.check(doc.Content[0], "")
+	}
+	tabs := tabIndentDiagnostics(content, c.blockLines)
+	if parseErr != nil {
+		if len(tabs) > 0 {
+			return tabs, nil
+		}
+		return nil, fmt.Errorf("invalid YAML: %w", parseErr)
+	}
+	return append(tabs, c.diags...), nil
+}
+
+type yamlChecker struct {
+	opts  *YAMLOptions
+	diags []Diagnostic
+	// blockLines holds the lines of literal and folded block scalars, whose
+	// content may legitimately contain tabs.
+	blockLines map[int]bool
+}
+
+func (c *yamlChecker) report(node *yaml.Node, pointer, keyword string, severity SeverityLevel, format string, args ...interface{}) {
+	c.diags = append(c.diags, Diagnostic{
+		Pointer:  pointer,
+		Keyword:  keyword,
+		Message:  fmt.Sprintf(format, args...),
+		Severity: severity,
+		Source:   sourceGoFulmen,
+		Location: &SourceLocation{Line: node.Line, Column: node.Column},
+	})
+}
+
+func (c *yamlChecker) check(node *yaml.Node, pointer string) {
+	if node.Anchor != "" && !c.opts.AllowAnchors {
+		c.report(node, pointer, keywordAnchor, SeverityWarn, "anchor &%s; anchors make the document harder to validate, inline the value instead", node.Anchor)
+	}
+	if node.Style&yaml.TaggedStyle != 0 && !c.opts.AllowTags {
+		c.report(node, pointer, keywordExplicitTag, SeverityWarn, "explicit tag %s; tagged values have no JSON equivalent", node.Tag)
+	}
+
+	switch node.Kind {
+	case yaml.AliasNode:
+		if !c.opts.AllowAnchors {
+			c.report(node, pointer, keywordAlias, SeverityWarn, "alias *%s; inline the referenced value instead", node.Value)
+		}
+	case yaml.MappingNode:
+		seen := make(map[string]*yaml.Node)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			child := pointer + "/" + escapePointerToken(key.Value)
+			if key.ShortTag() == "!!merge" {
+				if !c.opts.AllowAnchors {
+					c.report(key, pointer, keywordMergeKey, SeverityWarn, "merge key (<<) is not part of YAML 1.2; inline the merged keys instead")
+				}
+				continue // the merged value is an alias or list of aliases
+			}
+			if (key.Kind != yaml.ScalarNode || key.ShortTag() != "!!str") && !c.opts.AllowNonStringKeys {
+				c.report(key, child, keywordNonStringKey, SeverityWarn, "mapping key %q is not a string; quote it", key.Value)
+			}
+			if key.Kind == yaml.ScalarNode {
+				if first, ok := seen[key.Value]; ok {
+					c.report(key, child, keywordDuplicateKey, SeverityError, "duplicate key %q (first defined at line %d)", key.Value, first.Line)
+				} else {
+					seen[key.Value] = key
+				}
+			}
+			c.check(value, child)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			c.check(item, pointer+"/"+strconv.Itoa(i))
+		}
+	case yaml.ScalarNode:
+		c.checkScalar(node, pointer)
+	}
+}
+
+func (c *yamlChecker) checkScalar(node *yaml.Node, pointer string) {
+	if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		if c.blockLines == nil {
+			c.blockLines = make(map[int]bool)
+		}
+		end := node.Line + strings.Count(node.Value, "\n")
+		for line := node.Line + 1; line <= end; line++ {
+			c.blockLines[line] = true
+		}
+		return
+	}
+	if c.opts.AllowAmbiguousScalars || node.Style != 0 {
+		return // quoted or explicitly tagged
+	}
+	switch {
+	case yaml11Booleans.MatchString(node.Value):
+		c.report(node, pointer, keywordAmbiguousScalar, SeverityWarn, "%q is a string in YAML 1.2 but a boolean in YAML 1.1; quote it or use true/false", node.Value)
+	case node.ShortTag() == "!!int" && leadingZeroInt.MatchString(node.Value):
+		c.report(node, pointer, keywordAmbiguousScalar, SeverityWarn, "%q is read as an octal integer; quote it, or write 0o%s for octal", node.Value, strings.TrimLeft(strings.TrimLeft(node.Value, "+-"), "0_"))
+	}
+}
+
+// tabIndentDiagnostics reports lines indented with tabs, outside block scalars.
+func tabIndentDiagnostics(content []byte, blockLines map[int]bool) []Diagnostic {
+	var diags []Diagnostic
+	for i, line := range bytes.Split(content, []byte("\n")) {
+		lineNum := i + 1
+		if blockLines[lineNum] {
+			continue
+		}
+		indent := line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+		col := bytes.IndexByte(indent, '\t')
+		if col < 0 || len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		diags = append(diags, Diagnostic{
+			Keyword:  keywordTabIndentation,
+			Message:  "tab character used for indentation; YAML requires spaces",
+			Severity: SeverityError,
+			Source:   sourceGoFulmen,
+			Location: &SourceLocation{Line: lineNum, Column: col + 1},
+		})
+	}
+	return diags
+}
+
+func hasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
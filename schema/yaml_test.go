@@ -0,0 +1,144 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+// diagLine returns the line of a diagnostic's own location, or 0.
+func diagLine(d Diagnostic) int {
+	if d.Location == nil {
+		return 0
+	}
+	return d.Location.Line
+}
+
+func TestCheckYAML(t *testing.T) {
+	content := []byte(`name: service
+base: &defaults
+  retries: 3
+override:
+  <<: *defaults
+  enabled: yes
+mode: 0755
+name: duplicate
+script: |
+  build:
+  	go build ./...
+quoted: "no"
+`)
+
+	diags, err := CheckYAML(content, nil)
+	if err != nil {
+		t.Fatalf("CheckYAML failed: %v", err)
+	}
+
+	got := map[string]Diagnostic{}
+	for _, d := range diags {
+		got[d.Keyword] = d
+	}
+	if d, ok := got[keywordDuplicateKey]; !ok || diagLine(d) != 8 || d.Pointer != "/name" || d.Severity != SeverityError {
+		t.Errorf("duplicate key diagnostic = %+v", d)
+	}
+	if !strings.Contains(got[keywordDuplicateKey].Message, "line 1") {
+		t.Errorf("duplicate key message should reference the first definition: %q", got[keywordDuplicateKey].Message)
+	}
+	if d := got[keywordAnchor]; diagLine(d) != 2 || d.Severity != SeverityWarn {
+		t.Errorf("anchor diagnostic = %+v", d)
+	}
+	if d := got[keywordMergeKey]; diagLine(d) != 5 || d.Pointer != "/override" {
+		t.Errorf("merge key diagnostic = %+v", d)
+	}
+	if _, ok := got[keywordTabIndentation]; ok {
+		t.Error("tabs inside a block scalar should not be reported")
+	}
+
+	ambiguous := 0
+	for _, d := range diags {
+		if d.Keyword == keywordAmbiguousScalar {
+			ambiguous++
+			if d.Pointer != "/override/enabled" && d.Pointer != "/mode" {
+				t.Errorf("unexpected ambiguous scalar at %s", d.Pointer)
+			}
+		}
+	}
+	if ambiguous != 2 {
+		t.Errorf("expected 2 ambiguous scalars (yes, 0755), got %d: %v", ambiguous, diags)
+	}
+
+	relaxed, err := CheckYAML(content, &YAMLOptions{AllowAnchors: true, AllowAmbiguousScalars: true})
+	if err != nil {
+		t.Fatalf("CheckYAML failed: %v", err)
+	}
+	if len(relaxed) != 1 || relaxed[0].Keyword != keywordDuplicateKey {
+		t.Errorf("expected only the duplicate key when relaxed, got %v", relaxed)
+	}
+}
+
+func TestCheckYAMLTagsAndKeys(t *testing.T) {
+	content := []byte("data: !!binary aGVsbG8=\n1: one\n")
+	diags, err := CheckYAML(content, nil)
+	if err != nil {
+		t.Fatalf("CheckYAML failed: %v", err)
+	}
+	if len(diags) != 2 || diags[0].Keyword != keywordExplicitTag || diags[1].Keyword != keywordNonStringKey || diagLine(diags[1]) != 2 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+
+	relaxed, err := CheckYAML(content, &YAMLOptions{AllowTags: true, AllowNonStringKeys: true})
+	if err != nil || len(relaxed) != 0 {
+		t.Errorf("expected no diagnostics when allowed, got %v, %v", relaxed, err)
+	}
+}
+
+func TestCheckYAMLTabIndentation(t *testing.T) {
+	diags, err := CheckYAML([]byte("server:\n\tport: 8080\n"), nil)
+	if err != nil {
+		t.Fatalf("expected tab diagnostics instead of a parse error: %v", err)
+	}
+	if len(diags) != 1 || diags[0].Keyword != keywordTabIndentation || diags[0].Location == nil || *diags[0].Location != (SourceLocation{Line: 2, Column: 1}) {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+
+	if _, err := CheckYAML([]byte("key: [unclosed\n"), nil); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
+
+func TestValidateYAMLStrict(t *testing.T) {
+	schemaData := []byte(`{"type":"object","properties":{"port":{"type":"integer"},"debug":{"type":"boolean"}}}`)
+	content := []byte("port: 80\ndebug: false\nport: 8080\n")
+
+	lenient, err := NewValidator(schemaData)
+	if err != nil {
+		t.Fatalf("NewValidator failed: %v", err)
+	}
+	if _, err := lenient.ValidateYAML(content); err == nil {
+		t.Error("expected yaml.v3 decode error for duplicate key without strict mode")
+	}
+
+	strict, err := NewValidatorWithOptions(schemaData, &CompileOptions{StrictYAML: &YAMLOptions{}})
+	if err != nil {
+		t.Fatalf("NewValidatorWithOptions failed: %v", err)
+	}
+	diags, err := strict.ValidateYAML(content)
+	if err != nil {
+		t.Fatalf("ValidateYAML failed: %v", err)
+	}
+	if len(diags) != 1 || diags[0].Keyword != keywordDuplicateKey || diagLine(diags[0]) != 3 {
+		t.Errorf("expected duplicate key diagnostic only, got %v", diags)
+	}
+
+	// Warnings are reported alongside schema diagnostics.
+	diags, err = strict.ValidateYAML([]byte("port: 80\ndebug: yes\n"))
+	if err != nil {
+		t.Fatalf("ValidateYAML failed: %v", err)
+	}
+	keywords := map[string]bool{}
+	for _, d := range diags {
+		keywords[ruleID(d)] = true
+	}
+	if !keywords["ambiguous-scalar"] || !keywords["type"] {
+		t.Errorf("expected ambiguous scalar warning and type error, got %v", diags)
+	}
+}
@@ -38,6 +38,27 @@
 //	    log.Fatal(err)
 //	}
 //
+// # Independent Listeners
+//
+// Package-level functions share a default manager. Libraries, embedded services,
+// and tests that need isolated state create their own listener with New:
+//
+//	listener, err := signals.New(signals.Options{Context: ctx})
+//	if err != nil {
+//	    return err
+//	}
+//	listener.OnShutdown(func(ctx context.Context) error {
+//	    return pool.Close()
+//	})
+//	go worker.Run(listener.Context()) // cancelled when shutdown begins
+//	go listener.Listen(ctx)
+//
+// Pass a listener down a call tree with WithListener; ListenerFromContext
+// returns it (or the default manager when none is attached):
+//
+//	ctx = signals.WithListener(ctx, listener)
+//	signals.ListenerFromContext(ctx).OnShutdown(cache.Flush)
+//
 // # Unix vs Windows
 //
 // On Unix systems, the package registers OS signal handlers for SIGTERM, SIGINT,
@@ -50,6 +71,16 @@
 // The package automatically logs INFO messages and emits telemetry events when
 // unsupported signals are registered on Windows.
 //
+// # Controlling a Running Instance
+//
+// WritePIDFile records the process ID; NotifyProcess then delivers Reload
+// (SIGHUP) or Shutdown (SIGTERM) to that process, falling back to the HTTP
+// admin endpoint on Windows:
+//
+//	if err := signals.NotifyProcess("/run/myapp/myapp.pid", signals.Reload); err != nil {
+//	    return err
+//	}
+//
 // # Cleanup Chains
 //
 // Cleanup functions are executed in reverse registration order (LIFO) with
@@ -65,6 +96,40 @@
 //	    return stopWorkers(ctx)
 //	})
 //
+// When ordering matters across subsystems, register prioritized groups. Groups
+// run in ascending priority before the LIFO chain; handlers within a group run
+// in parallel under an optional per-group timeout:
+//
+//	signals.OnShutdownGroup(10, stopWorkers)
+//	signals.OnShutdownGroup(20, server.Shutdown)
+//	signals.OnShutdownGroup(30, closeDatabase)
+//	signals.SetShutdownGroupTimeout(20, 15*time.Second)
+//
+// LastShutdownReport (or the returned *ShutdownError) records which handlers
+// failed or timed out.
+//
+// Handlers can tell why a shutdown is running (OS signal, admin endpoint, or
+// RequestShutdown) from the ShutdownReason in their context:
+//
+//	reason, _ := signals.ShutdownReasonFromContext(ctx)
+//	log.Printf("shutting down: %s", reason)
+//
+// # User Signals
+//
+// OnSignal registers handlers for SIGUSR1/SIGUSR2. They run without ending
+// Listen and are exposed on the HTTP admin endpoint for Windows parity:
+//
+//	signals.OnSignal(signals.SIGUSR1, dumpGoroutines)
+//
+// # Readiness
+//
+// ReadinessHandler responds 503 as soon as a shutdown begins (State Draining),
+// so probes flip during graceful drain; LivenessHandler stays 200 until cleanup
+// finishes:
+//
+//	http.Handle("/readyz", signals.ReadinessHandler())
+//	http.Handle("/livez", signals.LivenessHandler())
+//
 // # Config Reload
 //
 // Config reload enforces validation before restart:
@@ -2,10 +2,12 @@ package signals
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -28,7 +30,10 @@ type CancelFunc func()
 type Manager struct {
 	mu               sync.RWMutex
 	handlers         map[os.Signal][]HandlerFunc
+	userSignals      map[os.Signal]bool
 	shutdownHandlers []CleanupFunc
+	shutdownGroups   map[int]*shutdownGroup
+	shutdownReport   *ShutdownReport
 	reloadHandlers   []ReloadFunc
 	doubleTapConfig  *DoubleTapConfig
 	doubleTapTimer   *time.Timer
@@ -38,6 +43,11 @@ type Manager struct {
 	stopChan         chan struct{}
 	running          bool
 	quietMode        bool
+	subscriptions    []os.Signal
+	lifecycleCtx     context.Context
+	lifecycleCancel  context.CancelFunc
+	state            atomic.Int32
+	shutdownDone     chan error
 }
 
 // DoubleTapConfig configures Ctrl+C double-tap behavior.
@@ -57,13 +67,24 @@ type DoubleTapConfig struct {
 
 // NewManager creates a new signal manager.
 func NewManager() *Manager {
+	return newManager(context.Background())
+}
+
+// newManager creates a manager whose lifecycle context derives from parent.
+func newManager(parent context.Context) *Manager {
+	lifecycleCtx, lifecycleCancel := context.WithCancel(parent)
 	return &Manager{
 		handlers:         make(map[os.Signal][]HandlerFunc),
+		userSignals:      make(map[os.Signal]bool),
 		shutdownHandlers: make([]CleanupFunc, 0),
+		shutdownGroups:   make(map[int]*shutdownGroup),
 		reloadHandlers:   make([]ReloadFunc, 0),
 		catalog:          fsignals.GetDefaultCatalog(),
 		signalChan:       make(chan os.Signal, 1),
 		stopChan:         make(chan struct{}),
+		lifecycleCtx:     lifecycleCtx,
+		lifecycleCancel:  lifecycleCancel,
+		shutdownDone:     make(chan error, 1),
 	}
 }
 
@@ -104,7 +125,12 @@ func (m *Manager) Handle(sig os.Signal, handler HandlerFunc) (CancelFunc, error)
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	return m.addHandler(sig, handler), nil
+}
 
+// addHandler appends a handler for sig and returns its cancel function.
+// Callers hold m.mu.
+func (m *Manager) addHandler(sig os.Signal, handler HandlerFunc) CancelFunc {
 	m.handlers[sig] = append(m.handlers[sig], handler)
 	idx := len(m.handlers[sig]) - 1
 
@@ -115,12 +141,13 @@ func (m *Manager) Handle(sig os.Signal, handler HandlerFunc) (CancelFunc, error)
 		if handlers, exists := m.handlers[sig]; exists && idx < len(handlers) {
 			m.handlers[sig] = append(handlers[:idx], handlers[idx+1:]...)
 		}
-	}, nil
+	}
 }
 
 // OnShutdown registers a cleanup function to be called during graceful shutdown.
 //
-// Cleanup functions are executed in reverse registration order (LIFO).
+// Cleanup functions are executed in reverse registration order (LIFO), after
+// all OnShutdownGroup groups have finished.
 //
 // Example:
 //
@@ -239,34 +266,64 @@ func (m *Manager) Listen(ctx context.Context) error {
 	m.running = true
 	m.mu.Unlock()
 
-	// Register default signals if no handlers registered
+	signal.Notify(m.signalChan, m.subscribedSignals()...)
+
+	// Wait for signal or context cancellation. User signals (OnSignal) are
+	// handled without ending Listen.
+	for {
+		select {
+		case sig := <-m.signalChan:
+			if m.isUserSignal(sig) {
+				if err := m.dispatchSignal(ctx, sig, SourceOS); err != nil {
+					fmt.Fprintf(os.Stderr, "WARN: %v\n", err)
+				}
+				continue
+			}
+			return m.dispatchSignal(ctx, sig, SourceOS)
+		case err := <-m.shutdownDone:
+			// RequestShutdown finished a shutdown
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-m.stopChan:
+			return nil
+		}
+	}
+}
+
+// subscribedSignals returns the OS signals Listen should subscribe to.
+//
+// Explicit subscriptions (from Options.Signals) are always included. Without
+// them, a manager with no handlers subscribes to SIGTERM, SIGINT, and SIGHUP,
+// while a manager with handlers subscribes to exactly the handled signals.
+func (m *Manager) subscribedSignals() []os.Signal {
 	m.mu.RLock()
-	hasHandlers := len(m.handlers) > 0
-	m.mu.RUnlock()
+	defer m.mu.RUnlock()
 
-	if !hasHandlers {
-		// Register default SIGTERM and SIGINT handlers
-		signal.Notify(m.signalChan, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
-	} else {
-		// Register for all signals that have handlers
-		m.mu.RLock()
-		signals := make([]os.Signal, 0, len(m.handlers))
-		for sig := range m.handlers {
-			signals = append(signals, sig)
-		}
-		m.mu.RUnlock()
-		signal.Notify(m.signalChan, signals...)
+	if len(m.subscriptions) == 0 && len(m.handlers) == 0 {
+		return []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP}
 	}
 
-	// Wait for signal or context cancellation
-	select {
-	case sig := <-m.signalChan:
-		return m.handleSignal(ctx, sig)
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-m.stopChan:
-		return nil
+	signals := make([]os.Signal, 0, len(m.subscriptions)+len(m.handlers))
+	signals = append(signals, m.subscriptions...)
+	for sig := range m.handlers {
+		signals = append(signals, sig)
 	}
+	return signals
+}
+
+// Context returns the manager's lifecycle context.
+//
+// The context is cancelled when a shutdown signal is dispatched (before cleanup
+// handlers run) or when Stop is called, so long-running work owned by a library
+// can observe shutdown without registering its own handlers.
+//
+// Example:
+//
+//	listener, _ := signals.New(signals.Options{})
+//	go worker.Run(listener.Context())
+func (m *Manager) Context() context.Context {
+	return m.lifecycleCtx
 }
 
 // Stop stops the signal listener.
@@ -279,6 +336,8 @@ func (m *Manager) Stop() {
 		close(m.stopChan)
 		m.running = false
 	}
+	m.setState(Stopping)
+	m.lifecycleCancel()
 }
 
 // handleSignal dispatches a signal to registered handlers.
@@ -291,6 +350,7 @@ func (m *Manager) handleSignal(ctx context.Context, sig os.Signal) error {
 			if m.doubleTapConfig != nil && m.doubleTapConfig.ExitCode != 0 {
 				exitCode = m.doubleTapConfig.ExitCode
 			}
+			emitForcedQuit(sig)
 			os.Exit(exitCode)
 		}
 	}
@@ -350,8 +410,21 @@ func (m *Manager) handleDoubleTap() bool {
 	return false
 }
 
-// executeShutdown runs all cleanup handlers in reverse order.
-func (m *Manager) executeShutdown(ctx context.Context) error {
+// executeShutdown runs shutdown groups in priority order, then the OnShutdown
+// handlers in reverse order.
+func (m *Manager) executeShutdown(ctx context.Context) (err error) {
+	// Signal shutdown to readiness probes and lifecycle context observers first
+	m.setState(Draining)
+	m.lifecycleCancel()
+	defer m.setState(Stopping)
+
+	reason, _ := ShutdownReasonFromContext(ctx)
+	emitDrainStarted(reason)
+	start := time.Now()
+	defer func() { emitShutdownFinished(reason, time.Since(start), err) }()
+
+	groupErr := m.executeShutdownGroups(ctx)
+
 	m.mu.RLock()
 	handlers := make([]CleanupFunc, len(m.shutdownHandlers))
 	copy(handlers, m.shutdownHandlers)
@@ -359,12 +432,15 @@ func (m *Manager) executeShutdown(ctx context.Context) error {
 
 	// Execute in reverse order (LIFO)
 	for i := len(handlers) - 1; i >= 0; i-- {
-		if err := handlers[i](ctx); err != nil {
-			return fmt.Errorf("cleanup handler failed: %w", err)
+		handlerStart := time.Now()
+		err := handlers[i](ctx)
+		emitHandlerDuration(handlerName(handlers[i]), time.Since(handlerStart), err)
+		if err != nil {
+			return errors.Join(groupErr, fmt.Errorf("cleanup handler failed: %w", err))
 		}
 	}
 
-	return nil
+	return groupErr
 }
 
 // executeReload runs all reload handlers in order.
@@ -3,9 +3,13 @@ package signals
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"path"
+	"strings"
 	"syscall"
 	"time"
 
@@ -126,11 +130,15 @@ func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse request
+	// An empty body is allowed when the signal is named in the path
 	var req SignalRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
 		h.sendError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
 		return
 	}
+	if req.Signal == "" {
+		req.Signal = signalFromPath(r.URL.Path)
+	}
 
 	// Validate signal
 	if req.Signal == "" {
@@ -145,8 +153,9 @@ func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check if signal is supported
-	if !Supports(sig) {
+	// Check if signal is supported (OnSignal handlers are reachable over HTTP
+	// even where the OS cannot deliver the signal)
+	if !Supports(sig) && !h.manager.isUserSignal(sig) {
 		h.sendError(w, http.StatusBadRequest, fmt.Sprintf("signal %s is not supported on this platform", req.Signal))
 		return
 	}
@@ -159,8 +168,18 @@ func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		defer cancel()
 	}
 
+	// Let cleanup handlers see who asked for the shutdown and why
+	if isShutdownSignal(sig) {
+		ctx = withShutdownReason(ctx, ShutdownReason{
+			Source:    SourceHTTP,
+			Signal:    sig,
+			Message:   req.Reason,
+			Requester: req.Requester,
+		})
+	}
+
 	// Dispatch signal
-	if err := h.manager.handleSignal(ctx, sig); err != nil {
+	if err := h.manager.dispatchSignal(ctx, sig, SourceHTTP); err != nil {
 		h.sendError(w, http.StatusInternalServerError, fmt.Sprintf("signal processing failed: %v", err))
 		return
 	}
@@ -210,6 +229,20 @@ func (h *HTTPHandler) parseSignal(name string) (os.Signal, error) {
 	return sig, nil
 }
 
+// signalFromPath derives a signal name from the last path segment, so
+// POST /admin/signal/usr1 (or /admin/signal/SIGUSR1) names SIGUSR1.
+func signalFromPath(urlPath string) string {
+	name := strings.ToUpper(path.Base(urlPath))
+	// The conventional mount path itself (/admin/signal) names no signal
+	if name == "" || name == "/" || name == "." || name == "SIGNAL" {
+		return ""
+	}
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	return name
+}
+
 var httpSignalMap = func() map[string]os.Signal {
 	base := map[string]os.Signal{
 		"SIGTERM": syscall.SIGTERM,
@@ -225,6 +258,16 @@ var httpSignalMap = func() map[string]os.Signal {
 	return base
 }()
 
+// signalName returns the admin endpoint name for sig (e.g., "SIGUSR1").
+func signalName(sig os.Signal) string {
+	for name, s := range httpSignalMap {
+		if s == sig {
+			return name
+		}
+	}
+	return sig.String()
+}
+
 // sendError sends an error response.
 func (h *HTTPHandler) sendError(w http.ResponseWriter, status int, message string) {
 	w.Header().Set("Content-Type", "application/json")
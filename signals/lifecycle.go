@@ -0,0 +1,178 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+)
+
+// Source identifies where a signal or shutdown request came from. It is
+// recorded in the "source" tag of the signals telemetry.
+type Source string
+
+const (
+	// SourceOS is a signal delivered by the operating system.
+	SourceOS Source = "os"
+
+	// SourceHTTP is a signal sent through the HTTP admin endpoint.
+	SourceHTTP Source = "http"
+
+	// SourceProgrammatic is a shutdown requested with RequestShutdown.
+	SourceProgrammatic Source = "programmatic"
+)
+
+// ErrShutdownInProgress is returned by RequestShutdown when a shutdown has
+// already started.
+var ErrShutdownInProgress = errors.New("shutdown already in progress")
+
+// ShutdownReason describes why a shutdown is running. Cleanup handlers read
+// it from their context with ShutdownReasonFromContext.
+type ShutdownReason struct {
+	// Source is where the shutdown came from.
+	Source Source
+
+	// Signal is the shutdown signal (SIGTERM or SIGINT), or nil for
+	// RequestShutdown.
+	Signal os.Signal
+
+	// Message is the RequestShutdown reason or the admin request's "reason".
+	Message string
+
+	// Requester is the admin request's "requester", if any.
+	Requester string
+}
+
+// String returns a short description such as "SIGTERM via http: deploy".
+func (r ShutdownReason) String() string {
+	s := string(r.Source)
+	if r.Signal != nil {
+		s = signalName(r.Signal) + " via " + s
+	}
+	if r.Message != "" {
+		s += ": " + r.Message
+	}
+	return s
+}
+
+type shutdownReasonKey struct{}
+
+// withShutdownReason returns a copy of ctx carrying reason.
+func withShutdownReason(ctx context.Context, reason ShutdownReason) context.Context {
+	return context.WithValue(ctx, shutdownReasonKey{}, reason)
+}
+
+// ShutdownReasonFromContext returns the reason for the shutdown in progress.
+// Handlers registered with OnShutdown, OnShutdownGroup, and Handle (for
+// SIGTERM and SIGINT) receive a context that carries it.
+//
+// Example:
+//
+//	signals.OnShutdown(func(ctx context.Context) error {
+//	    if reason, ok := signals.ShutdownReasonFromContext(ctx); ok && reason.Signal == syscall.SIGINT {
+//	        return nil // interactive stop: skip the final checkpoint
+//	    }
+//	    return checkpoint(ctx)
+//	})
+func ShutdownReasonFromContext(ctx context.Context) (ShutdownReason, bool) {
+	reason, ok := ctx.Value(shutdownReasonKey{}).(ShutdownReason)
+	return reason, ok
+}
+
+// isShutdownSignal reports whether sig starts a graceful shutdown.
+func isShutdownSignal(sig os.Signal) bool {
+	return sig == syscall.SIGTERM || sig == syscall.SIGINT
+}
+
+// RequestShutdown runs a graceful shutdown on the default manager without a
+// signal, as if SIGTERM had been received, and returns the cleanup result.
+// If Listen is running, it returns the same result.
+//
+// Cleanup handlers see a ShutdownReason with Source SourceProgrammatic and
+// Message reason. ErrShutdownInProgress is returned if a shutdown has already
+// started.
+//
+// Example:
+//
+//	if err := migrate(ctx); err != nil {
+//	    _ = signals.RequestShutdown(ctx, "migration failed")
+//	}
+func RequestShutdown(ctx context.Context, reason string) error {
+	return GetDefaultManager().RequestShutdown(ctx, reason)
+}
+
+// RequestShutdown runs a graceful shutdown on this manager.
+func (m *Manager) RequestShutdown(ctx context.Context, reason string) error {
+	// Claim the transition so concurrent requests run cleanup once
+	if !m.state.CompareAndSwap(int32(Running), int32(Draining)) {
+		return ErrShutdownInProgress
+	}
+
+	ctx = withShutdownReason(WithListener(ctx, m), ShutdownReason{
+		Source:  SourceProgrammatic,
+		Message: reason,
+	})
+	err := m.executeShutdown(ctx)
+
+	// End Listen with the same result
+	select {
+	case m.shutdownDone <- err:
+	default:
+	}
+	return err
+}
+
+// reasonTags returns the telemetry tags describing reason.
+func reasonTags(reason ShutdownReason) map[string]string {
+	tags := map[string]string{metrics.TagSource: string(reason.Source)}
+	if reason.Signal != nil {
+		tags[metrics.TagSignal] = signalName(reason.Signal)
+	}
+	return tags
+}
+
+// emitSignalReceived records that sig arrived from source.
+func emitSignalReceived(sig os.Signal, source Source) {
+	telemetry.EmitCounter(metrics.SignalsReceivedTotal, 1, map[string]string{
+		metrics.TagSignal: signalName(sig),
+		metrics.TagSource: string(source),
+	})
+}
+
+// emitDrainStarted records the start of a shutdown.
+func emitDrainStarted(reason ShutdownReason) {
+	telemetry.EmitCounter(metrics.SignalsDrainStartedTotal, 1, reasonTags(reason))
+}
+
+// emitShutdownFinished records the total shutdown duration and outcome.
+func emitShutdownFinished(reason ShutdownReason, duration time.Duration, err error) {
+	tags := reasonTags(reason)
+	tags[metrics.TagStatus] = statusOf(err)
+	telemetry.EmitHistogram(metrics.SignalsShutdownMs, duration, tags)
+}
+
+// emitHandlerDuration records how long a cleanup handler ran.
+func emitHandlerDuration(name string, duration time.Duration, err error) {
+	telemetry.EmitHistogram(metrics.SignalsShutdownHandlerMs, duration, map[string]string{
+		metrics.TagHandler: name,
+		metrics.TagStatus:  statusOf(err),
+	})
+}
+
+// emitForcedQuit records a double-tap force quit before the process exits.
+func emitForcedQuit(sig os.Signal) {
+	telemetry.EmitCounter(metrics.SignalsForcedQuitTotal, 1, map[string]string{
+		metrics.TagSignal: signalName(sig),
+	})
+}
+
+func statusOf(err error) string {
+	if err != nil {
+		return metrics.StatusError
+	}
+	return metrics.StatusSuccess
+}
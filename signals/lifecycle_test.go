@@ -0,0 +1,133 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+	teltesting "github.com/fulmenhq/gofulmen/telemetry/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureReason registers a cleanup handler that records the shutdown reason.
+func captureReason(t *testing.T, m *Manager) chan ShutdownReason {
+	t.Helper()
+	reasons := make(chan ShutdownReason, 1)
+	m.OnShutdown(func(ctx context.Context) error {
+		reason, ok := ShutdownReasonFromContext(ctx)
+		assert.True(t, ok, "cleanup context should carry a shutdown reason")
+		reasons <- reason
+		return nil
+	})
+	return reasons
+}
+
+func TestShutdownReason_OSSignal(t *testing.T) {
+	m := NewManager()
+	reasons := captureReason(t, m)
+
+	require.NoError(t, m.dispatchSignal(context.Background(), syscall.SIGINT, SourceOS))
+
+	reason := <-reasons
+	assert.Equal(t, SourceOS, reason.Source)
+	assert.Equal(t, syscall.SIGINT, reason.Signal)
+	assert.Equal(t, "SIGINT via os", reason.String())
+}
+
+func TestShutdownReason_HTTP(t *testing.T) {
+	m := NewManager()
+	reasons := captureReason(t, m)
+	handler := NewHTTPHandler(HTTPConfig{Manager: m, RateLimit: 600, RateBurst: 10})
+
+	body := `{"signal":"SIGTERM","reason":"deploy","requester":"ops"}`
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/signal", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	reason := <-reasons
+	assert.Equal(t, SourceHTTP, reason.Source)
+	assert.Equal(t, syscall.SIGTERM, reason.Signal)
+	assert.Equal(t, "deploy", reason.Message)
+	assert.Equal(t, "ops", reason.Requester)
+	assert.Equal(t, "SIGTERM via http: deploy", reason.String())
+}
+
+func TestRequestShutdown(t *testing.T) {
+	m := NewManager()
+	reasons := captureReason(t, m)
+
+	require.NoError(t, m.RequestShutdown(context.Background(), "migration failed"))
+
+	reason := <-reasons
+	assert.Equal(t, SourceProgrammatic, reason.Source)
+	assert.Nil(t, reason.Signal)
+	assert.Equal(t, "programmatic: migration failed", reason.String())
+	assert.Equal(t, Stopping, m.State())
+	assert.Error(t, m.Context().Err(), "lifecycle context should be cancelled")
+
+	err := m.RequestShutdown(context.Background(), "again")
+	assert.ErrorIs(t, err, ErrShutdownInProgress)
+}
+
+func TestRequestShutdown_EndsListen(t *testing.T) {
+	m := NewManager()
+	injector := NewInjector(m)
+	boom := errors.New("boom")
+	m.OnShutdown(func(ctx context.Context) error { return boom })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- m.Listen(ctx) }()
+	require.NoError(t, injector.WaitForListen(time.Second))
+
+	assert.ErrorIs(t, m.RequestShutdown(ctx, "test"), boom)
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, boom, "Listen should return the shutdown result")
+	case <-time.After(time.Second):
+		t.Fatal("Listen did not return after RequestShutdown")
+	}
+}
+
+func TestShutdownTelemetry(t *testing.T) {
+	collector := teltesting.NewFakeCollector()
+	telSys, err := telemetry.NewSystem(&telemetry.Config{Enabled: true, Emitter: collector})
+	require.NoError(t, err)
+	telemetry.SetGlobalSystem(telSys)
+	defer telemetry.SetGlobalSystem(nil)
+
+	m := NewManager()
+	m.OnShutdownGroup(10, func(ctx context.Context) error { return nil })
+	m.OnShutdown(func(ctx context.Context) error { return nil })
+
+	require.NoError(t, m.dispatchSignal(context.Background(), syscall.SIGTERM, SourceOS))
+
+	received := collector.GetMetricsByName(metrics.SignalsReceivedTotal)
+	require.Len(t, received, 1)
+	assert.Equal(t, "SIGTERM", received[0].Tags[metrics.TagSignal])
+	assert.Equal(t, "os", received[0].Tags[metrics.TagSource])
+
+	drain := collector.GetMetricsByName(metrics.SignalsDrainStartedTotal)
+	require.Len(t, drain, 1)
+	assert.Equal(t, "SIGTERM", drain[0].Tags[metrics.TagSignal])
+
+	handlers := collector.GetMetricsByName(metrics.SignalsShutdownHandlerMs)
+	require.Len(t, handlers, 2)
+	for _, h := range handlers {
+		assert.Contains(t, h.Tags[metrics.TagHandler], "TestShutdownTelemetry")
+		assert.Equal(t, metrics.StatusSuccess, h.Tags[metrics.TagStatus])
+	}
+
+	total := collector.GetMetricsByName(metrics.SignalsShutdownMs)
+	require.Len(t, total, 1)
+	assert.Equal(t, metrics.StatusSuccess, total[0].Tags[metrics.TagStatus])
+}
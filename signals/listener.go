@@ -0,0 +1,97 @@
+package signals
+
+import (
+	"context"
+	"os"
+)
+
+// Listener is an independent signal-handling instance created with New.
+//
+// Each Listener owns its handler registry, cleanup chain, signal subscriptions,
+// and lifecycle context, so a library and its host application (or parallel
+// tests) can manage shutdown without sharing the package-level default manager.
+// Listener is the same type as Manager; all Manager methods are available.
+type Listener = Manager
+
+// Options configures a Listener created with New.
+type Options struct {
+	// Context is the parent of the listener's lifecycle context (see Manager.Context).
+	// Default: context.Background()
+	Context context.Context
+
+	// Signals lists OS signals to subscribe to in addition to signals with
+	// registered handlers.
+	// Default: SIGTERM, SIGINT, SIGHUP when no handlers are registered
+	Signals []os.Signal
+
+	// DoubleTap enables Ctrl+C double-tap behavior when non-nil.
+	// Zero-valued fields are filled from the signal catalog.
+	DoubleTap *DoubleTapConfig
+
+	// Quiet suppresses double-tap messages on stderr.
+	Quiet bool
+}
+
+// New creates an independent signal listener.
+//
+// Unlike the package-level functions, which share a default manager, each
+// listener keeps its own handlers and subscriptions.
+//
+// Example:
+//
+//	listener, err := signals.New(signals.Options{
+//	    Signals: []os.Signal{syscall.SIGTERM, syscall.SIGINT},
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	listener.OnShutdown(func(ctx context.Context) error {
+//	    return server.Shutdown(ctx)
+//	})
+//	go listener.Listen(ctx)
+func New(opts Options) (*Listener, error) {
+	parent := opts.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	l := newManager(parent)
+	l.quietMode = opts.Quiet
+	l.subscriptions = append(l.subscriptions, opts.Signals...)
+
+	if opts.DoubleTap != nil {
+		if err := l.EnableDoubleTap(*opts.DoubleTap); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+type listenerContextKey struct{}
+
+// WithListener returns a copy of ctx carrying l, so code several layers below
+// the service that owns a listener registers handlers on it rather than on the
+// package-level default manager.
+//
+// Handlers invoked by a listener receive a context that carries it.
+//
+// Example:
+//
+//	listener, _ := signals.New(signals.Options{})
+//	ctx = signals.WithListener(ctx, listener)
+//	pool := db.Open(ctx) // calls signals.ListenerFromContext(ctx).OnShutdown(...)
+func WithListener(ctx context.Context, l *Listener) context.Context {
+	return context.WithValue(ctx, listenerContextKey{}, l)
+}
+
+// ListenerFromContext returns the listener carried by ctx, or the default
+// manager if ctx carries none.
+func ListenerFromContext(ctx context.Context) *Listener {
+	if ctx != nil {
+		if l, ok := ctx.Value(listenerContextKey{}).(*Listener); ok && l != nil {
+			return l
+		}
+	}
+	return GetDefaultManager()
+}
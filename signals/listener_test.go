@@ -0,0 +1,133 @@
+package signals
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_IndependentListeners(t *testing.T) {
+	a, err := New(Options{Quiet: true})
+	require.NoError(t, err)
+	b, err := New(Options{Quiet: true})
+	require.NoError(t, err)
+
+	assert.NotSame(t, a, b, "New should return independent instances")
+	assert.NotSame(t, GetDefaultManager(), a, "New should not return the default manager")
+
+	a.OnShutdown(func(ctx context.Context) error { return nil })
+
+	a.mu.RLock()
+	assert.Len(t, a.shutdownHandlers, 1)
+	a.mu.RUnlock()
+
+	b.mu.RLock()
+	assert.Len(t, b.shutdownHandlers, 0, "Handlers must not leak between listeners")
+	b.mu.RUnlock()
+}
+
+func TestNew_Options(t *testing.T) {
+	l, err := New(Options{
+		Signals:   []os.Signal{syscall.SIGTERM},
+		DoubleTap: &DoubleTapConfig{},
+		Quiet:     true,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, l.quietMode)
+	require.NotNil(t, l.doubleTapConfig, "DoubleTap should be enabled")
+	assert.NotZero(t, l.doubleTapConfig.Window, "Window should be filled from catalog")
+	assert.Equal(t, []os.Signal{syscall.SIGTERM}, l.subscribedSignals())
+}
+
+func TestSubscribedSignals(t *testing.T) {
+	l, err := New(Options{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP}, l.subscribedSignals(),
+		"Listener without handlers should subscribe to default signals")
+
+	l, err = New(Options{Signals: []os.Signal{syscall.SIGTERM}})
+	require.NoError(t, err)
+	_, err = l.Handle(syscall.SIGINT, func(ctx context.Context, sig os.Signal) error { return nil })
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []os.Signal{syscall.SIGTERM, syscall.SIGINT}, l.subscribedSignals(),
+		"Explicit subscriptions should be combined with handled signals")
+}
+
+func TestListener_ContextCancelledOnShutdown(t *testing.T) {
+	l, err := New(Options{Quiet: true})
+	require.NoError(t, err)
+
+	select {
+	case <-l.Context().Done():
+		t.Fatal("Lifecycle context should not be done before shutdown")
+	default:
+	}
+
+	injector := NewInjector(l)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = l.Listen(ctx)
+	}()
+	require.NoError(t, injector.WaitForListen(time.Second))
+	require.NoError(t, injector.Inject(syscall.SIGTERM))
+
+	select {
+	case <-l.Context().Done():
+	case <-time.After(time.Second):
+		t.Fatal("Lifecycle context not cancelled after SIGTERM")
+	}
+}
+
+func TestListener_ParentContext(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	l, err := New(Options{Context: parent})
+	require.NoError(t, err)
+
+	cancel()
+	select {
+	case <-l.Context().Done():
+	case <-time.After(time.Second):
+		t.Fatal("Lifecycle context should follow parent cancellation")
+	}
+}
+
+func TestListener_StopCancelsContext(t *testing.T) {
+	l, err := New(Options{})
+	require.NoError(t, err)
+
+	l.Stop()
+	assert.Error(t, l.Context().Err(), "Stop should cancel lifecycle context")
+}
+
+func TestListenerFromContext(t *testing.T) {
+	assert.Same(t, GetDefaultManager(), ListenerFromContext(context.Background()))
+	//nolint:staticcheck // nil context fallback is part of the contract
+	assert.Same(t, GetDefaultManager(), ListenerFromContext(nil))
+
+	l, err := New(Options{Quiet: true})
+	require.NoError(t, err)
+	ctx := WithListener(context.Background(), l)
+	assert.Same(t, l, ListenerFromContext(ctx))
+}
+
+func TestListenerFromContext_InHandlers(t *testing.T) {
+	l, err := New(Options{Quiet: true})
+	require.NoError(t, err)
+
+	var got *Listener
+	l.OnShutdown(func(ctx context.Context) error {
+		got = ListenerFromContext(ctx)
+		return nil
+	})
+
+	require.NoError(t, l.dispatchSignal(context.Background(), syscall.SIGTERM, SourceOS))
+	assert.Same(t, l, got, "handlers should receive their listener in ctx")
+}
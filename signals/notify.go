@@ -0,0 +1,177 @@
+package signals
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"syscall"
+	"time"
+)
+
+// Action is a control request sent to a running instance.
+type Action string
+
+const (
+	// Reload asks the instance to reload its configuration (SIGHUP).
+	Reload Action = "reload"
+
+	// Shutdown asks the instance to shut down gracefully (SIGTERM).
+	Shutdown Action = "shutdown"
+)
+
+// ErrNoAdminEndpoint is returned when a notification requires the HTTP admin
+// endpoint (on Windows, or with NotifyOptions.UseHTTP) but no URL is configured.
+var ErrNoAdminEndpoint = errors.New("no HTTP admin endpoint configured")
+
+// DefaultNotifyTimeout bounds HTTP admin endpoint requests made by NotifyProcess.
+const DefaultNotifyTimeout = 10 * time.Second
+
+// NotifyOptions configures NotifyProcessWithOptions.
+type NotifyOptions struct {
+	// AdminURL is the full URL of the instance's HTTP admin signal endpoint
+	// (e.g., "http://127.0.0.1:8080/admin/signal"). Required on Windows.
+	AdminURL string
+
+	// Token is the bearer token expected by the endpoint (HTTPConfig.TokenAuth).
+	Token string
+
+	// UseHTTP sends the notification through AdminURL even where OS signals
+	// are available.
+	UseHTTP bool
+
+	// Reason is recorded in the HTTP request for audit logging.
+	Reason string
+
+	// Requester identifies the caller in the HTTP request for audit logging.
+	Requester string
+
+	// GracePeriod is forwarded as grace_period_seconds for Shutdown over HTTP.
+	GracePeriod time.Duration
+
+	// Client is the HTTP client used for the admin endpoint.
+	// Default: a client with DefaultNotifyTimeout.
+	Client *http.Client
+}
+
+// signal returns the OS signal that implements the action.
+func (a Action) signal() (syscall.Signal, error) {
+	switch a {
+	case Reload:
+		return syscall.SIGHUP, nil
+	case Shutdown:
+		return syscall.SIGTERM, nil
+	default:
+		return 0, fmt.Errorf("unknown action: %q", string(a))
+	}
+}
+
+// NotifyProcess sends action to the instance recorded in pidfile, so a CLI can
+// implement commands such as "myapp reload" or "myapp stop".
+//
+// On Unix the action is delivered as SIGHUP (Reload) or SIGTERM (Shutdown).
+// Windows cannot deliver those signals to another process; use
+// NotifyProcessWithOptions with AdminURL there (NotifyProcess returns
+// ErrNoAdminEndpoint).
+//
+// Example:
+//
+//	if err := signals.NotifyProcess("/run/myapp/myapp.pid", signals.Reload); err != nil {
+//	    return err
+//	}
+func NotifyProcess(pidfile string, action Action) error {
+	return NotifyProcessWithOptions(context.Background(), pidfile, action, NotifyOptions{})
+}
+
+// NotifyProcessWithOptions sends action to the instance recorded in pidfile.
+// The PID file is validated first (ErrNoPIDFile, ErrStalePIDFile). If the
+// platform cannot deliver the signal, or opts.UseHTTP is set, the action is
+// POSTed to the HTTP admin endpoint at opts.AdminURL (see NewHTTPHandler).
+//
+// Example:
+//
+//	err := signals.NotifyProcessWithOptions(ctx, pidfile, signals.Reload, signals.NotifyOptions{
+//	    AdminURL: "http://127.0.0.1:8080/admin/signal",
+//	    Token:    os.Getenv("SIGNAL_ADMIN_TOKEN"),
+//	    Reason:   "config updated",
+//	})
+func NotifyProcessWithOptions(ctx context.Context, pidfile string, action Action, opts NotifyOptions) error {
+	sig, err := action.signal()
+	if err != nil {
+		return err
+	}
+
+	pid, err := ReadPIDFile(pidfile)
+	if err != nil {
+		return err
+	}
+
+	if !opts.UseHTTP {
+		delivered, err := sendProcessSignal(pid, sig)
+		if err != nil {
+			return fmt.Errorf("failed to send %s to pid %d: %w", action, pid, err)
+		}
+		if delivered {
+			return nil
+		}
+	}
+
+	return notifyHTTP(ctx, sig, opts)
+}
+
+// notifyHTTP POSTs a SignalRequest to the HTTP admin endpoint.
+func notifyHTTP(ctx context.Context, sig syscall.Signal, opts NotifyOptions) error {
+	if opts.AdminURL == "" {
+		return ErrNoAdminEndpoint
+	}
+
+	name := signalName(sig)
+	req := SignalRequest{
+		Signal:    name,
+		Reason:    opts.Reason,
+		Requester: opts.Requester,
+	}
+	if opts.Requester == "" {
+		req.Requester = fmt.Sprintf("%s (pid %d)", os.Args[0], os.Getpid())
+	}
+	if sig == syscall.SIGTERM && opts.GracePeriod > 0 {
+		seconds := int(opts.GracePeriod / time.Second)
+		req.GracePeriodSeconds = &seconds
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode signal request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, opts.AdminURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid admin endpoint: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if opts.Token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+opts.Token)
+	}
+
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultNotifyTimeout}
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to reach admin endpoint: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var result SignalResponse
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	if resp.StatusCode != http.StatusOK || !result.Success {
+		if result.Error != "" {
+			return fmt.Errorf("admin endpoint rejected %s: %s (HTTP %d)", name, result.Error, resp.StatusCode)
+		}
+		return fmt.Errorf("admin endpoint rejected %s: HTTP %d", name, resp.StatusCode)
+	}
+	return nil
+}
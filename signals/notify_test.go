@@ -0,0 +1,78 @@
+package signals
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyProcess_HTTP(t *testing.T) {
+	pidfile := filepath.Join(t.TempDir(), "app.pid")
+	require.NoError(t, WritePIDFile(pidfile))
+
+	manager := NewManager()
+	reloaded := make(chan struct{}, 1)
+	manager.OnReload(func(ctx context.Context) error {
+		reloaded <- struct{}{}
+		return nil
+	})
+	server := httptest.NewServer(NewHTTPHandler(HTTPConfig{TokenAuth: "secret", Manager: manager}))
+	defer server.Close()
+
+	err := NotifyProcessWithOptions(context.Background(), pidfile, Reload, NotifyOptions{
+		AdminURL: server.URL,
+		Token:    "secret",
+		UseHTTP:  true,
+		Reason:   "test",
+	})
+	require.NoError(t, err)
+
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Fatal("reload handler was not called")
+	}
+}
+
+func TestNotifyProcess_HTTPRejected(t *testing.T) {
+	pidfile := filepath.Join(t.TempDir(), "app.pid")
+	require.NoError(t, WritePIDFile(pidfile))
+
+	server := httptest.NewServer(NewHTTPHandler(HTTPConfig{TokenAuth: "secret", Manager: NewManager()}))
+	defer server.Close()
+
+	err := NotifyProcessWithOptions(context.Background(), pidfile, Reload, NotifyOptions{
+		AdminURL: server.URL,
+		Token:    "wrong",
+		UseHTTP:  true,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "authentication failed")
+}
+
+func TestNotifyProcess_Errors(t *testing.T) {
+	dir := t.TempDir()
+	pidfile := filepath.Join(dir, "app.pid")
+	require.NoError(t, WritePIDFile(pidfile))
+
+	err := NotifyProcessWithOptions(context.Background(), pidfile, Reload, NotifyOptions{UseHTTP: true})
+	assert.ErrorIs(t, err, ErrNoAdminEndpoint)
+
+	err = NotifyProcess(pidfile, Action("restart"))
+	assert.Error(t, err)
+
+	err = NotifyProcess(filepath.Join(dir, "missing.pid"), Reload)
+	assert.ErrorIs(t, err, ErrNoPIDFile)
+
+	stale := filepath.Join(dir, "stale.pid")
+	require.NoError(t, os.WriteFile(stale, []byte(strconv.Itoa(deadPID(t))), 0o644))
+	err = NotifyProcess(stale, Shutdown)
+	assert.ErrorIs(t, err, ErrStalePIDFile)
+}
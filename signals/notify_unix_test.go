@@ -0,0 +1,32 @@
+//go:build !windows
+
+package signals
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyProcess_UnixSignal(t *testing.T) {
+	pidfile := filepath.Join(t.TempDir(), "app.pid")
+	require.NoError(t, WritePIDFile(pidfile))
+
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, syscall.SIGHUP)
+	defer signal.Stop(received)
+
+	require.NoError(t, NotifyProcess(pidfile, Reload))
+
+	select {
+	case sig := <-received:
+		require.Equal(t, syscall.SIGHUP, sig)
+	case <-time.After(2 * time.Second):
+		t.Fatal("SIGHUP was not delivered")
+	}
+}
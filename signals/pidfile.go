@@ -0,0 +1,136 @@
+package signals
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNoPIDFile is returned when a PID file does not exist.
+	ErrNoPIDFile = errors.New("pid file not found")
+
+	// ErrStalePIDFile is returned when a PID file names a process that is no
+	// longer running.
+	ErrStalePIDFile = errors.New("pid file is stale: process not running")
+
+	// ErrProcessRunning is returned by WritePIDFile when the PID file belongs
+	// to another running process.
+	ErrProcessRunning = errors.New("process already running")
+)
+
+// WritePIDFile records the current process ID in path, creating parent
+// directories as needed.
+//
+// An existing PID file is replaced only if it is stale (its process has
+// exited); if it belongs to another running process ErrProcessRunning is
+// returned so a second instance does not hijack the first one's PID file.
+// Creation is exclusive, so of several instances starting at once exactly
+// one succeeds.
+//
+// Example:
+//
+//	if err := signals.WritePIDFile("/run/myapp/myapp.pid"); err != nil {
+//	    return err
+//	}
+//	signals.OnShutdown(func(ctx context.Context) error {
+//	    return signals.RemovePIDFile("/run/myapp/myapp.pid")
+//	})
+func WritePIDFile(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil { // #nosec G301 -- PID directories are conventionally world-readable
+		return fmt.Errorf("failed to create pid file directory: %w", err)
+	}
+
+	// Write the PID to a private temporary file first so readers never see a
+	// partial PID, then claim path with a hard link, which fails if path
+	// exists just like O_CREATE|O_EXCL.
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write pid file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+	if err == nil {
+		err = tmp.Chmod(0o644) // #nosec G302 -- PID files are conventionally world-readable
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write pid file: %w", err)
+	}
+
+	// A stale file is removed and creation retried once
+	for attempt := 0; attempt < 2; attempt++ {
+		err := os.Link(tmp.Name(), path)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("failed to write pid file: %w", err)
+		}
+
+		pid, err := ReadPIDFile(path)
+		switch {
+		case err == nil && pid == os.Getpid():
+			return nil
+		case err == nil:
+			return fmt.Errorf("%w: pid %d (%s)", ErrProcessRunning, pid, path)
+		case errors.Is(err, ErrStalePIDFile):
+			if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to remove stale pid file: %w", err)
+			}
+		case !errors.Is(err, ErrNoPIDFile):
+			return err
+		}
+	}
+	return fmt.Errorf("%w: pid file %s was claimed during startup", ErrProcessRunning, path)
+}
+
+// ReadPIDFile reads and validates a PID file. It returns ErrNoPIDFile if the
+// file does not exist and ErrStalePIDFile (along with the recorded PID) if the
+// process is no longer running.
+func ReadPIDFile(path string) (int, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- PID file path is supplied by the application
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, fmt.Errorf("%w: %s", ErrNoPIDFile, path)
+		}
+		return 0, fmt.Errorf("failed to read pid file: %w", err)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid pid file %s: %q", path, strings.TrimSpace(string(data)))
+	}
+
+	if !processAlive(pid) {
+		return pid, fmt.Errorf("%w: pid %d (%s)", ErrStalePIDFile, pid, path)
+	}
+	return pid, nil
+}
+
+// RemovePIDFile removes path if it records the current process. PID files
+// owned by other processes and missing files are left alone without error.
+func RemovePIDFile(path string) error {
+	data, err := os.ReadFile(path) // #nosec G304 -- PID file path is supplied by the application
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read pid file: %w", err)
+	}
+
+	if pid, err := strconv.Atoi(strings.TrimSpace(string(data))); err != nil || pid != os.Getpid() {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove pid file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package signals
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deadPID returns a PID that is very unlikely to belong to a running process.
+func deadPID(t *testing.T) int {
+	t.Helper()
+	for pid := 4_000_000; pid > 3_000_000; pid -= 7919 {
+		if !processAlive(pid) {
+			return pid
+		}
+	}
+	t.Skip("could not find an unused pid")
+	return 0
+}
+
+func TestWritePIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run", "app.pid")
+
+	require.NoError(t, WritePIDFile(path))
+
+	pid, err := ReadPIDFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), pid)
+
+	// Rewriting our own PID file is allowed
+	require.NoError(t, WritePIDFile(path))
+}
+
+func TestWritePIDFile_ReplacesStale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.pid")
+	require.NoError(t, os.WriteFile(path, []byte(strconv.Itoa(deadPID(t))), 0o644))
+
+	require.NoError(t, WritePIDFile(path))
+
+	pid, err := ReadPIDFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), pid)
+}
+
+func TestWritePIDFile_RefusesRunning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.pid")
+	require.NoError(t, os.WriteFile(path, []byte(strconv.Itoa(os.Getppid())), 0o644))
+
+	err := WritePIDFile(path)
+	assert.ErrorIs(t, err, ErrProcessRunning)
+}
+
+// pidFileHelperEnv makes TestPIDFileHelperProcess act as a starting instance
+// writing the PID file named by its value.
+const pidFileHelperEnv = "GOFULMEN_PIDFILE_HELPER"
+
+func TestWritePIDFile_ConcurrentStart(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spawns processes")
+	}
+	path := filepath.Join(t.TempDir(), "app.pid")
+	startAt := time.Now().Add(500 * time.Millisecond).UnixNano()
+
+	const instances = 8
+	results := make(chan string, instances)
+	for i := 0; i < instances; i++ {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestPIDFileHelperProcess$") // #nosec G204 -- re-executes the test binary
+		cmd.Env = append(os.Environ(), pidFileHelperEnv+"="+path, "GOFULMEN_PIDFILE_START="+strconv.FormatInt(startAt, 10))
+		stdin, err := cmd.StdinPipe()
+		require.NoError(t, err)
+		stdout, err := cmd.StdoutPipe()
+		require.NoError(t, err)
+		require.NoError(t, cmd.Start())
+
+		// Helpers stay alive until stdin closes, so the winner's PID file is live
+		t.Cleanup(func() {
+			_ = stdin.Close()
+			_ = cmd.Wait()
+		})
+		go func() {
+			line, _ := bufio.NewReader(stdout).ReadString('\n')
+			results <- line
+		}()
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < instances; i++ {
+		select {
+		case line := <-results:
+			counts[line]++
+		case <-time.After(30 * time.Second):
+			t.Fatalf("timed out waiting for helpers, got %v", counts)
+		}
+	}
+	assert.Equal(t, map[string]int{"ok\n": 1, "running\n": instances - 1}, counts)
+
+	pid, err := ReadPIDFile(path)
+	require.NoError(t, err)
+	assert.NotEqual(t, os.Getpid(), pid)
+}
+
+// TestPIDFileHelperProcess is not a real test; see TestWritePIDFile_ConcurrentStart.
+func TestPIDFileHelperProcess(t *testing.T) {
+	path := os.Getenv(pidFileHelperEnv)
+	if path == "" {
+		return
+	}
+	if startAt, err := strconv.ParseInt(os.Getenv("GOFULMEN_PIDFILE_START"), 10, 64); err == nil {
+		// Spin rather than sleep so all instances wake within microseconds
+		for time.Now().UnixNano() < startAt {
+		}
+	}
+
+	switch err := WritePIDFile(path); {
+	case err == nil:
+		fmt.Println("ok")
+	case errors.Is(err, ErrProcessRunning):
+		fmt.Println("running")
+	default:
+		fmt.Println(err)
+	}
+	_, _ = io.Copy(io.Discard, os.Stdin)
+	os.Exit(0)
+}
+
+func TestReadPIDFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := ReadPIDFile(filepath.Join(dir, "missing.pid"))
+	assert.ErrorIs(t, err, ErrNoPIDFile)
+
+	stale := filepath.Join(dir, "stale.pid")
+	dead := deadPID(t)
+	require.NoError(t, os.WriteFile(stale, []byte(strconv.Itoa(dead)+"\n"), 0o644))
+	pid, err := ReadPIDFile(stale)
+	assert.ErrorIs(t, err, ErrStalePIDFile)
+	assert.Equal(t, dead, pid)
+
+	invalid := filepath.Join(dir, "invalid.pid")
+	require.NoError(t, os.WriteFile(invalid, []byte("not-a-pid"), 0o644))
+	_, err = ReadPIDFile(invalid)
+	assert.Error(t, err)
+}
+
+func TestRemovePIDFile(t *testing.T) {
+	dir := t.TempDir()
+
+	own := filepath.Join(dir, "own.pid")
+	require.NoError(t, WritePIDFile(own))
+	require.NoError(t, RemovePIDFile(own))
+	assert.NoFileExists(t, own)
+
+	// Missing files are not an error
+	require.NoError(t, RemovePIDFile(own))
+
+	// Other processes' PID files are left in place
+	other := filepath.Join(dir, "other.pid")
+	require.NoError(t, os.WriteFile(other, []byte(strconv.Itoa(os.Getppid())), 0o644))
+	require.NoError(t, RemovePIDFile(other))
+	assert.FileExists(t, other)
+}
@@ -7,6 +7,14 @@ import (
 	"syscall"
 )
 
+// SIGUSR1 and SIGUSR2 are the user-defined signals for OnSignal. On Windows,
+// where the OS cannot deliver them, they are placeholders that can only be
+// triggered through the HTTP admin endpoint.
+var (
+	SIGUSR1 os.Signal = syscall.SIGUSR1
+	SIGUSR2 os.Signal = syscall.SIGUSR2
+)
+
 var platformSpecificSignals = map[string]os.Signal{
 	"SIGUSR1": syscall.SIGUSR1,
 	"SIGUSR2": syscall.SIGUSR2,
@@ -4,4 +4,22 @@ package signals
 
 import "os"
 
-var platformSpecificSignals = map[string]os.Signal{}
+// userSignal stands in for a Unix user-defined signal on Windows. It is never
+// delivered by the OS; the HTTP admin endpoint dispatches it instead.
+type userSignal string
+
+func (s userSignal) String() string { return string(s) }
+func (s userSignal) Signal()        {}
+
+// SIGUSR1 and SIGUSR2 are the user-defined signals for OnSignal. On Windows,
+// where the OS cannot deliver them, they are placeholders that can only be
+// triggered through the HTTP admin endpoint.
+var (
+	SIGUSR1 os.Signal = userSignal("user defined signal 1")
+	SIGUSR2 os.Signal = userSignal("user defined signal 2")
+)
+
+var platformSpecificSignals = map[string]os.Signal{
+	"SIGUSR1": SIGUSR1,
+	"SIGUSR2": SIGUSR2,
+}
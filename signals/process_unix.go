@@ -0,0 +1,21 @@
+//go:build !windows
+
+package signals
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processAlive reports whether a process with the given PID exists.
+func processAlive(pid int) bool {
+	// Signal 0 performs existence and permission checks without delivering anything
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// sendProcessSignal delivers sig to pid. It reports false when the platform
+// cannot deliver the signal and the HTTP admin endpoint must be used instead.
+func sendProcessSignal(pid int, sig syscall.Signal) (bool, error) {
+	return true, syscall.Kill(pid, sig)
+}
@@ -0,0 +1,28 @@
+//go:build windows
+
+package signals
+
+import (
+	"os"
+	"syscall"
+)
+
+// processAlive reports whether a process with the given PID exists.
+func processAlive(pid int) bool {
+	// FindProcess opens a handle on Windows and fails if the process is gone
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = process.Release()
+	return true
+}
+
+// sendProcessSignal delivers sig to pid. It reports false when the platform
+// cannot deliver the signal and the HTTP admin endpoint must be used instead.
+//
+// Windows has no equivalent of SIGHUP/SIGTERM for another process, so every
+// notification goes through the HTTP admin endpoint.
+func sendProcessSignal(pid int, sig syscall.Signal) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,302 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// shutdownGroup holds the cleanup handlers registered at one priority.
+type shutdownGroup struct {
+	timeout  time.Duration
+	handlers []CleanupFunc
+}
+
+// ShutdownReport describes the most recent shutdown of prioritized groups.
+type ShutdownReport struct {
+	// Groups are the executed groups in execution (ascending priority) order.
+	Groups []GroupReport
+
+	// Duration is the total time spent running the groups.
+	Duration time.Duration
+}
+
+// GroupReport describes the execution of one shutdown group.
+type GroupReport struct {
+	// Priority is the group's priority.
+	Priority int
+
+	// Timeout is the group's timeout (zero means bounded only by the shutdown context).
+	Timeout time.Duration
+
+	// Duration is the time until every handler finished or the group timed out.
+	Duration time.Duration
+
+	// Handlers are the group's handlers in registration order.
+	Handlers []HandlerReport
+}
+
+// HandlerReport describes the outcome of one shutdown handler.
+type HandlerReport struct {
+	// Name is the handler's function name (e.g., "main.main.func2").
+	Name string
+
+	// Duration is how long the handler ran (until the timeout, if it timed out).
+	Duration time.Duration
+
+	// TimedOut is true if the group deadline passed before the handler returned.
+	TimedOut bool
+
+	// Err is the handler's error, or the context error if it timed out.
+	Err error
+}
+
+// TimedOut returns the handlers that did not finish within their group's timeout.
+func (r *ShutdownReport) TimedOut() []HandlerReport {
+	var timedOut []HandlerReport
+	for _, group := range r.Groups {
+		for _, handler := range group.Handlers {
+			if handler.TimedOut {
+				timedOut = append(timedOut, handler)
+			}
+		}
+	}
+	return timedOut
+}
+
+// ShutdownError is returned by Listen when one or more shutdown group
+// handlers failed or timed out. Report lists every handler's outcome.
+type ShutdownError struct {
+	Report *ShutdownReport
+}
+
+// Error implements error.
+func (e *ShutdownError) Error() string {
+	var failures []string
+	for _, group := range e.Report.Groups {
+		for _, handler := range group.Handlers {
+			switch {
+			case handler.TimedOut && group.Timeout > 0:
+				failures = append(failures, fmt.Sprintf("%s (priority %d) timed out after %s", handler.Name, group.Priority, group.Timeout))
+			case handler.TimedOut:
+				failures = append(failures, fmt.Sprintf("%s (priority %d) did not finish: %v", handler.Name, group.Priority, handler.Err))
+			case handler.Err != nil:
+				failures = append(failures, fmt.Sprintf("%s (priority %d): %v", handler.Name, group.Priority, handler.Err))
+			}
+		}
+	}
+	return "shutdown group handlers failed: " + strings.Join(failures, "; ")
+}
+
+// Unwrap returns the handler errors, so errors.Is(err, context.DeadlineExceeded)
+// reports a timeout.
+func (e *ShutdownError) Unwrap() []error {
+	var errs []error
+	for _, group := range e.Report.Groups {
+		for _, handler := range group.Handlers {
+			if handler.Err != nil {
+				errs = append(errs, handler.Err)
+			}
+		}
+	}
+	return errs
+}
+
+// OnShutdownGroup registers a cleanup function in a prioritized shutdown group.
+//
+// Groups run in ascending priority order before the OnShutdown handlers.
+// Handlers within a group run in parallel, and the next group starts once all
+// of them have returned or the group timeout (SetShutdownGroupTimeout) expires.
+// A failing or timed-out handler does not stop later groups; failures are
+// returned from Listen as a *ShutdownError and recorded in LastShutdownReport.
+//
+// Example:
+//
+//	signals.OnShutdownGroup(10, stopWorkers)
+//	signals.OnShutdownGroup(20, server.Shutdown) // drain after workers stop
+//	signals.OnShutdownGroup(30, func(ctx context.Context) error {
+//	    return db.Close()
+//	})
+//	signals.SetShutdownGroupTimeout(20, 15*time.Second)
+func OnShutdownGroup(priority int, handler CleanupFunc) {
+	GetDefaultManager().OnShutdownGroup(priority, handler)
+}
+
+// OnShutdownGroup registers a prioritized cleanup function on this manager.
+func (m *Manager) OnShutdownGroup(priority int, handler CleanupFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	group := m.shutdownGroup(priority)
+	group.handlers = append(group.handlers, handler)
+}
+
+// SetShutdownGroupTimeout bounds how long the group at priority may run.
+// Handlers receive a context with this deadline; a zero timeout (the default)
+// leaves the group bounded only by the shutdown context.
+func SetShutdownGroupTimeout(priority int, timeout time.Duration) {
+	GetDefaultManager().SetShutdownGroupTimeout(priority, timeout)
+}
+
+// SetShutdownGroupTimeout sets a group timeout on this manager.
+func (m *Manager) SetShutdownGroupTimeout(priority int, timeout time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shutdownGroup(priority).timeout = timeout
+}
+
+// LastShutdownReport returns the report of the most recent shutdown, or nil
+// if no shutdown has run.
+func LastShutdownReport() *ShutdownReport {
+	return GetDefaultManager().LastShutdownReport()
+}
+
+// LastShutdownReport returns this manager's most recent shutdown report.
+func (m *Manager) LastShutdownReport() *ShutdownReport {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.shutdownReport
+}
+
+// shutdownGroup returns the group at priority, creating it. Callers hold m.mu.
+func (m *Manager) shutdownGroup(priority int) *shutdownGroup {
+	group, ok := m.shutdownGroups[priority]
+	if !ok {
+		group = &shutdownGroup{}
+		m.shutdownGroups[priority] = group
+	}
+	return group
+}
+
+// executeShutdownGroups runs each group in priority order and records a report.
+func (m *Manager) executeShutdownGroups(ctx context.Context) error {
+	m.mu.RLock()
+	priorities := make([]int, 0, len(m.shutdownGroups))
+	groups := make(map[int]shutdownGroup, len(m.shutdownGroups))
+	for priority, group := range m.shutdownGroups {
+		if len(group.handlers) == 0 {
+			continue
+		}
+		priorities = append(priorities, priority)
+		groups[priority] = shutdownGroup{
+			timeout:  group.timeout,
+			handlers: append([]CleanupFunc(nil), group.handlers...),
+		}
+	}
+	m.mu.RUnlock()
+
+	if len(priorities) == 0 {
+		return nil
+	}
+	sort.Ints(priorities)
+
+	start := time.Now()
+	report := &ShutdownReport{Groups: make([]GroupReport, 0, len(priorities))}
+	failed := false
+	for _, priority := range priorities {
+		group := runShutdownGroup(ctx, priority, groups[priority])
+		for _, handler := range group.Handlers {
+			emitHandlerDuration(handler.Name, handler.Duration, handler.Err)
+			if handler.Err != nil {
+				failed = true
+			}
+		}
+		report.Groups = append(report.Groups, group)
+	}
+	report.Duration = time.Since(start)
+
+	m.mu.Lock()
+	m.shutdownReport = report
+	m.mu.Unlock()
+
+	if failed {
+		return &ShutdownError{Report: report}
+	}
+	return nil
+}
+
+// handlerResult carries a finished handler's outcome.
+type handlerResult struct {
+	index    int
+	duration time.Duration
+	err      error
+}
+
+// runShutdownGroup runs a group's handlers in parallel and waits for them to
+// return or for the group deadline. Timed-out handlers keep running in the
+// background with a cancelled context.
+func runShutdownGroup(ctx context.Context, priority int, group shutdownGroup) GroupReport {
+	groupCtx, cancel := ctx, context.CancelFunc(func() {})
+	if group.timeout > 0 {
+		groupCtx, cancel = context.WithTimeout(ctx, group.timeout)
+	}
+	defer cancel()
+
+	report := GroupReport{
+		Priority: priority,
+		Timeout:  group.timeout,
+		Handlers: make([]HandlerReport, len(group.handlers)),
+	}
+	start := time.Now()
+
+	// Buffered so handlers finishing after the deadline do not leak blocked goroutines
+	results := make(chan handlerResult, len(group.handlers))
+	for i, handler := range group.handlers {
+		report.Handlers[i].Name = handlerName(handler)
+		go func(i int, handler CleanupFunc) {
+			handlerStart := time.Now()
+			err := handler(groupCtx)
+			results <- handlerResult{index: i, duration: time.Since(handlerStart), err: err}
+		}(i, handler)
+	}
+
+	finished := make([]bool, len(group.handlers))
+	record := func(result handlerResult) {
+		finished[result.index] = true
+		report.Handlers[result.index].Duration = result.duration
+		report.Handlers[result.index].Err = result.err
+	}
+
+	for remaining := len(group.handlers); remaining > 0; remaining-- {
+		select {
+		case result := <-results:
+			record(result)
+			continue
+		case <-groupCtx.Done():
+		}
+
+		// Deadline passed: collect handlers that already returned, mark the rest
+	drain:
+		for {
+			select {
+			case result := <-results:
+				record(result)
+			default:
+				break drain
+			}
+		}
+		elapsed := time.Since(start)
+		for i := range report.Handlers {
+			if !finished[i] {
+				report.Handlers[i].TimedOut = true
+				report.Handlers[i].Duration = elapsed
+				report.Handlers[i].Err = groupCtx.Err()
+			}
+		}
+		break
+	}
+
+	report.Duration = time.Since(start)
+	return report
+}
+
+// handlerName returns the function name of handler for reports.
+func handlerName(handler CleanupFunc) string {
+	if fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return "unknown"
+}
@@ -0,0 +1,122 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdownGroups_PriorityOrder(t *testing.T) {
+	m := NewManager()
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) CleanupFunc {
+		return func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	m.OnShutdown(record("legacy"))
+	m.OnShutdownGroup(30, record("db"))
+	m.OnShutdownGroup(10, record("workers"))
+	m.OnShutdownGroup(20, record("http"))
+
+	require.NoError(t, m.executeShutdown(context.Background()))
+	assert.Equal(t, []string{"workers", "http", "db", "legacy"}, order)
+
+	report := m.LastShutdownReport()
+	require.NotNil(t, report)
+	require.Len(t, report.Groups, 3)
+	assert.Equal(t, 10, report.Groups[0].Priority)
+	assert.Equal(t, 30, report.Groups[2].Priority)
+	assert.Empty(t, report.TimedOut())
+}
+
+func TestShutdownGroups_ParallelWithinGroup(t *testing.T) {
+	m := NewManager()
+
+	// Each handler waits for the other; sequential execution would deadlock
+	var wg sync.WaitGroup
+	wg.Add(2)
+	handler := func(ctx context.Context) error {
+		wg.Done()
+		wg.Wait()
+		return nil
+	}
+	m.OnShutdownGroup(0, handler)
+	m.OnShutdownGroup(0, handler)
+	m.SetShutdownGroupTimeout(0, 2*time.Second)
+
+	require.NoError(t, m.executeShutdown(context.Background()))
+	assert.Empty(t, m.LastShutdownReport().TimedOut())
+}
+
+func TestShutdownGroups_Timeout(t *testing.T) {
+	m := NewManager()
+
+	ran := make(chan struct{}, 1)
+	m.OnShutdownGroup(1, func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond) // ignore the deadline briefly
+		return ctx.Err()
+	})
+	m.OnShutdownGroup(1, func(ctx context.Context) error { return nil })
+	m.SetShutdownGroupTimeout(1, 20*time.Millisecond)
+	m.OnShutdownGroup(2, func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	})
+
+	err := m.executeShutdown(context.Background())
+	require.Error(t, err)
+
+	var shutdownErr *ShutdownError
+	require.True(t, errors.As(err, &shutdownErr))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "timed out after 20ms")
+
+	// Later groups still run after a timeout
+	select {
+	case <-ran:
+	default:
+		t.Fatal("priority 2 group did not run")
+	}
+
+	timedOut := shutdownErr.Report.TimedOut()
+	require.Len(t, timedOut, 1)
+	assert.Contains(t, timedOut[0].Name, "TestShutdownGroups_Timeout")
+	assert.False(t, shutdownErr.Report.Groups[0].Handlers[1].TimedOut)
+}
+
+func TestShutdownGroups_ErrorsDoNotStopLegacyHandlers(t *testing.T) {
+	m := NewManager()
+
+	legacyRan := false
+	m.OnShutdown(func(ctx context.Context) error {
+		legacyRan = true
+		return nil
+	})
+	boom := errors.New("boom")
+	m.OnShutdownGroup(5, func(ctx context.Context) error { return boom })
+
+	err := m.executeShutdown(context.Background())
+	assert.ErrorIs(t, err, boom)
+	assert.True(t, legacyRan)
+}
+
+func TestShutdownGroups_NoGroups(t *testing.T) {
+	m := NewManager()
+	m.SetShutdownGroupTimeout(1, time.Second)
+
+	require.NoError(t, m.executeShutdown(context.Background()))
+	assert.Nil(t, m.LastShutdownReport())
+}
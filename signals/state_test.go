@@ -0,0 +1,77 @@
+package signals
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func probe(t *testing.T, handler http.Handler) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var resp ProbeResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return rec.Code, resp.State
+}
+
+func TestState_String(t *testing.T) {
+	assert.Equal(t, "running", Running.String())
+	assert.Equal(t, "draining", Draining.String())
+	assert.Equal(t, "stopping", Stopping.String())
+	assert.Equal(t, "unknown", State(42).String())
+}
+
+func TestState_ShutdownTransitions(t *testing.T) {
+	m := NewManager()
+	assert.Equal(t, Running, m.State())
+
+	readiness := m.ReadinessHandler()
+	liveness := m.LivenessHandler()
+
+	code, state := probe(t, readiness)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "running", state)
+
+	m.OnShutdown(func(ctx context.Context) error {
+		// During drain: not ready, still live
+		assert.Equal(t, Draining, m.State())
+		code, state := probe(t, readiness)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, "draining", state)
+		code, _ = probe(t, liveness)
+		assert.Equal(t, http.StatusOK, code)
+		return nil
+	})
+
+	require.NoError(t, m.executeShutdown(context.Background()))
+	assert.Equal(t, Stopping, m.State())
+
+	code, state = probe(t, liveness)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "stopping", state)
+}
+
+func TestState_StopAndNoRegression(t *testing.T) {
+	m := NewManager()
+	m.Stop()
+	assert.Equal(t, Stopping, m.State())
+
+	// States never move backwards
+	m.setState(Draining)
+	assert.Equal(t, Stopping, m.State())
+}
+
+func TestState_HeadRequest(t *testing.T) {
+	m := NewManager()
+	rec := httptest.NewRecorder()
+	m.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+}
@@ -0,0 +1,76 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+)
+
+// OnSignal registers a handler for a user-defined signal such as SIGUSR1 or
+// SIGUSR2 (dump goroutines, rotate logs, toggle debug logging).
+//
+// Unlike shutdown signals, user signals do not end Listen: the handler runs
+// and the listener keeps waiting. Handler errors are logged to stderr.
+//
+// The signal is also reachable through the HTTP admin endpoint (see
+// NewHTTPHandler) as {"signal":"SIGUSR1"} or POST <mount>/usr1, which is the
+// only way to trigger it on Windows. Each dispatch increments the
+// signals_handled_total counter tagged with the signal, source, and status.
+//
+// SIGTERM, SIGINT, and SIGHUP are rejected; use OnShutdown and OnReload.
+//
+// Example:
+//
+//	signals.OnSignal(signals.SIGUSR1, func(ctx context.Context, sig os.Signal) error {
+//	    return pprof.Lookup("goroutine").WriteTo(os.Stderr, 1)
+//	})
+func OnSignal(sig os.Signal, handler HandlerFunc) (CancelFunc, error) {
+	return GetDefaultManager().OnSignal(sig, handler)
+}
+
+// OnSignal registers a user-defined signal handler on this manager.
+func (m *Manager) OnSignal(sig os.Signal, handler HandlerFunc) (CancelFunc, error) {
+	switch sig {
+	case syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP:
+		return nil, fmt.Errorf("signal %s controls shutdown/reload: use OnShutdown or OnReload", sig)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.userSignals[sig] = true
+	return m.addHandler(sig, handler), nil
+}
+
+// isUserSignal reports whether sig was registered with OnSignal.
+func (m *Manager) isUserSignal(sig os.Signal) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.userSignals[sig]
+}
+
+// dispatchSignal handles sig and records it in telemetry. Shutdown signals
+// carry a ShutdownReason in ctx (kept if the caller already attached one).
+func (m *Manager) dispatchSignal(ctx context.Context, sig os.Signal, source Source) error {
+	emitSignalReceived(sig, source)
+
+	ctx = WithListener(ctx, m)
+	if _, ok := ShutdownReasonFromContext(ctx); !ok && isShutdownSignal(sig) {
+		ctx = withShutdownReason(ctx, ShutdownReason{Source: source, Signal: sig})
+	}
+	err := m.handleSignal(ctx, sig)
+
+	status := metrics.StatusSuccess
+	if err != nil {
+		status = metrics.StatusError
+	}
+	telemetry.EmitCounter(metrics.SignalsHandledTotal, 1, map[string]string{
+		metrics.TagSignal: signalName(sig),
+		metrics.TagSource: string(source),
+		metrics.TagStatus: status,
+	})
+	return err
+}
@@ -0,0 +1,115 @@
+package signals
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+	teltesting "github.com/fulmenhq/gofulmen/telemetry/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnSignal_RejectsLifecycleSignals(t *testing.T) {
+	m := NewManager()
+	for _, sig := range []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP} {
+		_, err := m.OnSignal(sig, func(ctx context.Context, sig os.Signal) error { return nil })
+		assert.Error(t, err, sig.String())
+	}
+}
+
+func TestOnSignal_ListenContinues(t *testing.T) {
+	m := NewManager()
+	injector := NewInjector(m)
+
+	received := make(chan os.Signal, 4)
+	_, err := m.OnSignal(SIGUSR1, func(ctx context.Context, sig os.Signal) error {
+		received <- sig
+		return nil
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- m.Listen(ctx) }()
+	require.NoError(t, injector.WaitForListen(time.Second))
+
+	// Two user signals are handled without ending Listen
+	for i := 0; i < 2; i++ {
+		require.NoError(t, injector.Inject(SIGUSR1))
+		select {
+		case sig := <-received:
+			assert.Equal(t, SIGUSR1, sig)
+		case <-time.After(time.Second):
+			t.Fatal("SIGUSR1 handler was not called")
+		}
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("Listen returned after a user signal: %v", err)
+	default:
+	}
+
+	require.NoError(t, injector.Inject(syscall.SIGTERM))
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Listen did not return after SIGTERM")
+	}
+}
+
+func TestOnSignal_HTTPRouteAndTelemetry(t *testing.T) {
+	collector := teltesting.NewFakeCollector()
+	telSys, err := telemetry.NewSystem(&telemetry.Config{Enabled: true, Emitter: collector})
+	require.NoError(t, err)
+	telemetry.SetGlobalSystem(telSys)
+	defer telemetry.SetGlobalSystem(nil)
+
+	m := NewManager()
+	called := 0
+	_, err = m.OnSignal(SIGUSR2, func(ctx context.Context, sig os.Signal) error {
+		called++
+		return nil
+	})
+	require.NoError(t, err)
+	handler := NewHTTPHandler(HTTPConfig{Manager: m, RateLimit: 600, RateBurst: 10})
+
+	// Path route with an empty body
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/signal/usr2", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// JSON body
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/signal", strings.NewReader(`{"signal":"SIGUSR2"}`)))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, 2, called)
+
+	// No signal named anywhere
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/signal", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	counters := collector.GetMetricsByName(metrics.SignalsHandledTotal)
+	require.Len(t, counters, 2)
+	assert.Equal(t, "SIGUSR2", counters[0].Tags[metrics.TagSignal])
+	assert.Equal(t, "http", counters[0].Tags[metrics.TagSource])
+	assert.Equal(t, metrics.StatusSuccess, counters[0].Tags[metrics.TagStatus])
+}
+
+func TestSignalFromPath(t *testing.T) {
+	assert.Equal(t, "SIGUSR1", signalFromPath("/admin/signal/usr1"))
+	assert.Equal(t, "SIGUSR1", signalFromPath("/admin/signal/SIGUSR1"))
+	assert.Equal(t, "SIGHUP", signalFromPath("/admin/signal/hup"))
+	assert.Equal(t, "", signalFromPath("/admin/signal"))
+	assert.Equal(t, "", signalFromPath("/"))
+}
@@ -0,0 +1,202 @@
+package telemetry
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+)
+
+// CardinalityPolicy selects how a tag value that violates a CardinalityConfig
+// limit is rewritten.
+type CardinalityPolicy string
+
+const (
+	// CardinalityDrop removes the offending tag from the event.
+	CardinalityDrop CardinalityPolicy = "drop"
+
+	// CardinalityHash replaces an over-long value with a short hash, and a value
+	// beyond MaxValuesPerKey with one of MaxValuesPerKey hash buckets.
+	CardinalityHash CardinalityPolicy = "hash"
+
+	// CardinalityTruncate shortens an over-long value to MaxValueLength, and
+	// replaces a value beyond MaxValuesPerKey with OverflowTagValue.
+	CardinalityTruncate CardinalityPolicy = "truncate"
+)
+
+// OverflowTagValue replaces tag values beyond MaxValuesPerKey under CardinalityTruncate.
+const OverflowTagValue = "__overflow__"
+
+// Cardinality violation reasons, used as the "reason" tag of
+// metrics.TelemetryCardinalityViolations.
+const (
+	violationTagCount       = "tag_count"
+	violationValueLength    = "value_length"
+	violationDistinctValues = "distinct_values"
+)
+
+// CardinalityConfig limits metric tag sets so a bad label (e.g., raw file
+// paths) cannot explode the number of series in a metrics backend.
+// Zero limits are disabled.
+type CardinalityConfig struct {
+	// MaxTags is the maximum number of tags per event. Extra tags (in key order)
+	// are dropped regardless of Policy.
+	MaxTags int `json:"maxTags,omitempty"`
+
+	// MaxValueLength is the maximum tag value length in characters.
+	MaxValueLength int `json:"maxValueLength,omitempty"`
+
+	// MaxValuesPerKey is the maximum number of distinct values tracked per
+	// metric name and tag key. Values first seen after the limit is reached
+	// are rewritten according to Policy.
+	MaxValuesPerKey int `json:"maxValuesPerKey,omitempty"`
+
+	// Policy rewrites violating values. Default: CardinalityDrop.
+	Policy CardinalityPolicy `json:"policy,omitempty"`
+}
+
+// cardinalityKey identifies a tag key within a metric.
+type cardinalityKey struct {
+	metric string
+	tag    string
+}
+
+// cardinalityViolation is a violation to be reported after the guard releases s.mu.
+type cardinalityViolation struct {
+	reason string
+	tag    string
+}
+
+// guardTags applies Config.Cardinality to tags, returning the (possibly
+// rewritten) tags. The caller's map is never modified. Violations are counted
+// and reported as metrics.TelemetryCardinalityViolations.
+func (s *System) guardTags(name string, tags map[string]string) map[string]string {
+	cfg := s.config.Cardinality
+	if cfg == nil || len(tags) == 0 || name == metrics.TelemetryCardinalityViolations {
+		return tags
+	}
+
+	s.mu.Lock()
+	guarded, violations := s.applyCardinalityLocked(cfg, name, tags)
+	s.cardinalityViolations += int64(len(violations))
+	s.mu.Unlock()
+
+	for _, v := range violations {
+		s.reportCardinalityViolation(name, v)
+	}
+	return guarded
+}
+
+// applyCardinalityLocked enforces the limits. Callers hold s.mu.
+func (s *System) applyCardinalityLocked(cfg *CardinalityConfig, name string, tags map[string]string) (map[string]string, []cardinalityViolation) {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var violations []cardinalityViolation
+	if cfg.MaxTags > 0 && len(keys) > cfg.MaxTags {
+		for _, k := range keys[cfg.MaxTags:] {
+			violations = append(violations, cardinalityViolation{reason: violationTagCount, tag: k})
+		}
+		keys = keys[:cfg.MaxTags]
+	}
+
+	guarded := make(map[string]string, len(keys))
+	for _, k := range keys {
+		value := tags[k]
+
+		if cfg.MaxValueLength > 0 && utf8.RuneCountInString(value) > cfg.MaxValueLength {
+			violations = append(violations, cardinalityViolation{reason: violationValueLength, tag: k})
+			var keep bool
+			if value, keep = rewriteLongValue(cfg, value); !keep {
+				continue
+			}
+		}
+
+		if cfg.MaxValuesPerKey > 0 {
+			var keep, violated bool
+			value, keep, violated = s.limitDistinctLocked(cfg, cardinalityKey{metric: name, tag: k}, value)
+			if violated {
+				violations = append(violations, cardinalityViolation{reason: violationDistinctValues, tag: k})
+			}
+			if !keep {
+				continue
+			}
+		}
+
+		guarded[k] = value
+	}
+	return guarded, violations
+}
+
+// rewriteLongValue applies the policy to a value longer than MaxValueLength.
+func rewriteLongValue(cfg *CardinalityConfig, value string) (string, bool) {
+	switch cfg.Policy {
+	case CardinalityHash:
+		return hashTagValue(value), true
+	case CardinalityTruncate:
+		runes := []rune(value)
+		return string(runes[:cfg.MaxValueLength]), true
+	default:
+		return "", false
+	}
+}
+
+// limitDistinctLocked records value for key and rewrites it if the key already
+// has MaxValuesPerKey distinct values. Callers hold s.mu.
+func (s *System) limitDistinctLocked(cfg *CardinalityConfig, key cardinalityKey, value string) (string, bool, bool) {
+	if s.tagValues == nil {
+		s.tagValues = make(map[cardinalityKey]map[string]struct{})
+	}
+	seen := s.tagValues[key]
+	if seen == nil {
+		seen = make(map[string]struct{})
+		s.tagValues[key] = seen
+	}
+	if _, ok := seen[value]; ok {
+		return value, true, false
+	}
+	if len(seen) < cfg.MaxValuesPerKey {
+		seen[value] = struct{}{}
+		return value, true, false
+	}
+
+	switch cfg.Policy {
+	case CardinalityHash:
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(value))
+		return fmt.Sprintf("bucket_%d", h.Sum32()%uint32(cfg.MaxValuesPerKey)), true, true // #nosec G115 -- MaxValuesPerKey is positive here
+	case CardinalityTruncate:
+		return OverflowTagValue, true, true
+	default:
+		return "", false, true
+	}
+}
+
+// hashTagValue returns a short, stable replacement for a long tag value.
+func hashTagValue(value string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(value))
+	return fmt.Sprintf("h_%016x", h.Sum64())
+}
+
+// reportCardinalityViolation emits the self-monitoring violation counter.
+func (s *System) reportCardinalityViolation(name string, v cardinalityViolation) {
+	_ = s.Counter(metrics.TelemetryCardinalityViolations, 1, map[string]string{
+		"metric":          name,
+		"tag_key":         v.tag,
+		metrics.TagReason: v.reason,
+	})
+}
+
+// CardinalityViolations returns the number of tag violations rewritten or
+// dropped by the cardinality guard.
+func (s *System) CardinalityViolations() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cardinalityViolations
+}
@@ -0,0 +1,195 @@
+package telemetry
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// taggedEvent is a metric recorded by tagRecorder
+type taggedEvent struct {
+	name string
+	tags map[string]string
+}
+
+// tagRecorder records emitted metric names and tags
+type tagRecorder struct {
+	mu     sync.Mutex
+	events []taggedEvent
+}
+
+func (r *tagRecorder) record(name string, tags map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, taggedEvent{name: name, tags: tags})
+	return nil
+}
+
+func (r *tagRecorder) Counter(name string, value float64, tags map[string]string) error {
+	return r.record(name, tags)
+}
+
+func (r *tagRecorder) Histogram(name string, duration time.Duration, tags map[string]string) error {
+	return r.record(name, tags)
+}
+
+func (r *tagRecorder) HistogramSummary(name string, summary HistogramSummary, tags map[string]string) error {
+	return r.record(name, tags)
+}
+
+func (r *tagRecorder) Gauge(name string, value float64, tags map[string]string) error {
+	return r.record(name, tags)
+}
+
+// byName returns the recorded events with the given name
+func (r *tagRecorder) byName(name string) []taggedEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []taggedEvent
+	for _, e := range r.events {
+		if e.name == name {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func newGuardedSystem(t *testing.T, cfg CardinalityConfig) (*System, *tagRecorder) {
+	t.Helper()
+	recorder := &tagRecorder{}
+	sys, err := NewSystem(&Config{
+		Enabled:     true,
+		Emitter:     recorder,
+		Cardinality: &cfg,
+	})
+	require.NoError(t, err)
+	return sys, recorder
+}
+
+// TestCardinality_MaxTags verifies extra tags are dropped in key order
+func TestCardinality_MaxTags(t *testing.T) {
+	sys, recorder := newGuardedSystem(t, CardinalityConfig{MaxTags: 2})
+
+	tags := map[string]string{"c": "3", "a": "1", "b": "2"}
+	require.NoError(t, sys.Counter("ops_total", 1, tags))
+
+	events := recorder.byName("ops_total")
+	require.Len(t, events, 1)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, events[0].tags)
+	assert.Len(t, tags, 3, "caller's tags must not be modified")
+
+	violations := recorder.byName(metrics.TelemetryCardinalityViolations)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "c", violations[0].tags["tag_key"])
+	assert.Equal(t, "tag_count", violations[0].tags[metrics.TagReason])
+	assert.Equal(t, int64(1), sys.CardinalityViolations())
+}
+
+// TestCardinality_ValueLength verifies each policy for over-long values
+func TestCardinality_ValueLength(t *testing.T) {
+	long := "/very/long/path/" + strings.Repeat("x", 100)
+
+	tests := []struct {
+		policy CardinalityPolicy
+		check  func(t *testing.T, tags map[string]string)
+	}{
+		{CardinalityDrop, func(t *testing.T, tags map[string]string) {
+			assert.NotContains(t, tags, metrics.TagPath)
+		}},
+		{CardinalityTruncate, func(t *testing.T, tags map[string]string) {
+			assert.Equal(t, long[:16], tags[metrics.TagPath])
+		}},
+		{CardinalityHash, func(t *testing.T, tags map[string]string) {
+			assert.Equal(t, hashTagValue(long), tags[metrics.TagPath])
+			assert.True(t, strings.HasPrefix(tags[metrics.TagPath], "h_"))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			sys, recorder := newGuardedSystem(t, CardinalityConfig{MaxValueLength: 16, Policy: tt.policy})
+			require.NoError(t, sys.Gauge("open_files", 1, map[string]string{metrics.TagPath: long, "ok": "short"}))
+
+			events := recorder.byName("open_files")
+			require.Len(t, events, 1)
+			assert.Equal(t, "short", events[0].tags["ok"])
+			tt.check(t, events[0].tags)
+			assert.Equal(t, int64(1), sys.CardinalityViolations())
+		})
+	}
+}
+
+// TestCardinality_DistinctValues verifies values beyond MaxValuesPerKey are rewritten
+func TestCardinality_DistinctValues(t *testing.T) {
+	tests := []struct {
+		policy CardinalityPolicy
+		want   func(value string) (string, bool)
+	}{
+		{CardinalityDrop, func(string) (string, bool) { return "", false }},
+		{CardinalityTruncate, func(string) (string, bool) { return OverflowTagValue, true }},
+		{CardinalityHash, func(v string) (string, bool) { return "bucket_", true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			sys, recorder := newGuardedSystem(t, CardinalityConfig{MaxValuesPerKey: 3, Policy: tt.policy})
+
+			for i := 0; i < 5; i++ {
+				require.NoError(t, sys.Counter("requests_total", 1, map[string]string{"user": fmt.Sprintf("u%d", i)}))
+			}
+			// Values seen before the limit keep working
+			require.NoError(t, sys.Counter("requests_total", 1, map[string]string{"user": "u0"}))
+			// Limits are tracked per metric
+			require.NoError(t, sys.Counter("logins_total", 1, map[string]string{"user": "u4"}))
+
+			events := recorder.byName("requests_total")
+			require.Len(t, events, 6)
+			for i := 0; i < 3; i++ {
+				assert.Equal(t, fmt.Sprintf("u%d", i), events[i].tags["user"])
+			}
+			for _, e := range events[3:5] {
+				want, present := tt.want(e.tags["user"])
+				value, ok := e.tags["user"]
+				assert.Equal(t, present, ok)
+				if present {
+					assert.True(t, strings.HasPrefix(value, want), value)
+				}
+			}
+			assert.Equal(t, "u0", events[5].tags["user"])
+			assert.Equal(t, "u4", recorder.byName("logins_total")[0].tags["user"])
+
+			assert.Equal(t, int64(2), sys.CardinalityViolations())
+			assert.Len(t, recorder.byName(metrics.TelemetryCardinalityViolations), 2)
+		})
+	}
+}
+
+// TestCardinality_HistogramSummary verifies guarded tags on ms histograms
+func TestCardinality_HistogramSummary(t *testing.T) {
+	sys, recorder := newGuardedSystem(t, CardinalityConfig{MaxTags: 1})
+
+	require.NoError(t, sys.Histogram("op_ms", 5*time.Millisecond, map[string]string{"a": "1", "b": "2"}))
+
+	events := recorder.byName("op_ms")
+	require.Len(t, events, 1)
+	assert.Equal(t, map[string]string{"a": "1"}, events[0].tags)
+	assert.Equal(t, int64(1), sys.CardinalityViolations())
+}
+
+// TestCardinality_Disabled verifies tags pass through without a config
+func TestCardinality_Disabled(t *testing.T) {
+	recorder := &tagRecorder{}
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: recorder})
+	require.NoError(t, err)
+
+	tags := map[string]string{metrics.TagPath: strings.Repeat("x", 1000)}
+	require.NoError(t, sys.Counter("ops_total", 1, tags))
+	assert.Equal(t, tags, recorder.byName("ops_total")[0].tags)
+	assert.Equal(t, int64(0), sys.CardinalityViolations())
+}
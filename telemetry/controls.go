@@ -0,0 +1,229 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// RuntimeControls is the runtime on/off state of a telemetry system. It is
+// the wire format of System.AdminHandler and the input of System.ReloadHook.
+type RuntimeControls struct {
+	// Enabled switches the whole system (nil = unchanged).
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Namespaces enables or disables metric names or "prefix*" patterns
+	// (see Config.Namespaces).
+	Namespaces map[string]bool `json:"namespaces,omitempty"`
+
+	// Reset clears existing namespace overrides before Namespaces is applied.
+	Reset bool `json:"reset,omitempty"`
+}
+
+// SetEnabled turns the system on or off at runtime. Metrics emitted while
+// disabled are dropped. A system created disabled loads the metrics schema
+// when first enabled.
+func (s *System) SetEnabled(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setEnabledLocked(enabled)
+}
+
+// Enabled reports whether the system is enabled.
+func (s *System) Enabled() bool {
+	return s.isEnabled()
+}
+
+// SetNamespaceEnabled enables or disables a metric name or "prefix*" pattern
+// at runtime. Enabling a narrower pattern re-enables part of a disabled
+// broader one; nothing is emitted while the system itself is disabled.
+//
+// Example:
+//
+//	// Silence similarity metrics during an incident, keep fulpack metrics
+//	sys.SetNamespaceEnabled("foundry.similarity.*", false)
+//	sys.SetNamespaceEnabled("fulpack.*", true)
+func (s *System) SetNamespaceEnabled(pattern string, enabled bool) {
+	s.ApplyControls(RuntimeControls{Namespaces: map[string]bool{pattern: enabled}})
+}
+
+// ClearNamespaces removes all namespace overrides.
+func (s *System) ClearNamespaces() {
+	s.ApplyControls(RuntimeControls{Reset: true})
+}
+
+// ApplyControls applies a runtime controls update.
+func (s *System) ApplyControls(controls RuntimeControls) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Copy on write: the caller's Config map is never modified
+	namespaces := make(map[string]bool, len(s.namespaces)+len(controls.Namespaces))
+	if !controls.Reset {
+		for k, v := range s.namespaces {
+			namespaces[k] = v
+		}
+	}
+	for k, v := range controls.Namespaces {
+		namespaces[k] = v
+	}
+	s.setNamespacesLocked(namespaces)
+
+	if controls.Enabled != nil {
+		s.setEnabledLocked(*controls.Enabled)
+	}
+}
+
+// Controls returns the current runtime controls.
+func (s *System) Controls() RuntimeControls {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	enabled := s.config.Enabled
+	namespaces := make(map[string]bool, len(s.namespaces))
+	for k, v := range s.namespaces {
+		namespaces[k] = v
+	}
+	return RuntimeControls{Enabled: &enabled, Namespaces: namespaces}
+}
+
+// ReloadHook returns a reload handler that applies the controls returned by
+// load, replacing existing namespace overrides. Its signature matches
+// signals.ReloadFunc.
+//
+// Example:
+//
+//	signals.OnReload(sys.ReloadHook(func(ctx context.Context) (telemetry.RuntimeControls, error) {
+//	    cfg, err := loadAppConfig()
+//	    if err != nil {
+//	        return telemetry.RuntimeControls{}, err
+//	    }
+//	    return cfg.Telemetry, nil
+//	}))
+func (s *System) ReloadHook(load func(ctx context.Context) (RuntimeControls, error)) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		controls, err := load(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load telemetry controls: %w", err)
+		}
+		controls.Reset = true
+		s.ApplyControls(controls)
+		return nil
+	}
+}
+
+// namespacePrefix is a normalized "prefix*" namespace override.
+type namespacePrefix struct {
+	prefix  string
+	enabled bool
+}
+
+// setEnabledLocked switches the system on or off, loading the metrics schema
+// on first enable. The caller must hold s.mu.
+func (s *System) setEnabledLocked(enabled bool) {
+	if enabled && s.validator.Load() == nil {
+		s.validator.Store(loadMetricsSchema())
+	}
+	s.config.Enabled = enabled
+}
+
+// setNamespacesLocked normalizes namespace overrides once, so isEnabledFor
+// needs a single map lookup plus a prefix scan. The caller must hold s.mu.
+func (s *System) setNamespacesLocked(overrides map[string]bool) {
+	namespaces := make(map[string]bool, len(overrides))
+	var prefixes []namespacePrefix
+	for key, on := range overrides {
+		key = normalizeNamespace(key)
+		namespaces[key] = on
+		if prefix, ok := strings.CutSuffix(key, "*"); ok {
+			prefixes = append(prefixes, namespacePrefix{prefix: prefix, enabled: on})
+		}
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i].prefix) > len(prefixes[j].prefix)
+	})
+	s.namespaces = namespaces
+	s.namespacePrefixes = prefixes
+}
+
+// isEnabledFor checks if metrics named name are enabled. Enabled is the
+// master switch; namespace overrides then apply, with the exact name, then
+// the longest matching prefix, winning.
+func (s *System) isEnabledFor(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.config.Enabled {
+		return false
+	}
+	if on, ok := s.namespaces[name]; ok {
+		return on
+	}
+	for _, p := range s.namespacePrefixes {
+		if strings.HasPrefix(name, p.prefix) {
+			return p.enabled
+		}
+	}
+	return true
+}
+
+// normalizeNamespace maps dotted namespaces ("foundry.similarity.*") to the
+// snake_case metric naming convention ("foundry_similarity_*").
+func normalizeNamespace(pattern string) string {
+	return strings.ReplaceAll(pattern, ".", "_")
+}
+
+// AdminHandler returns an HTTP handler for runtime telemetry controls. GET
+// returns the current RuntimeControls as JSON; POST or PUT applies a
+// RuntimeControls body and returns the resulting state. If token is
+// non-empty, requests must carry "Authorization: Bearer <token>".
+//
+// Example:
+//
+//	http.Handle("/admin/telemetry", sys.AdminHandler(os.Getenv("TELEMETRY_ADMIN_TOKEN")))
+//
+//	// curl -X POST -H "Authorization: Bearer $TOKEN" \
+//	//   -d '{"namespaces":{"foundry.similarity.*":false}}' http://localhost:8080/admin/telemetry
+func (s *System) AdminHandler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token != "" {
+			expected := "Bearer " + token
+			if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(expected)) != 1 {
+				writeControlsError(w, http.StatusUnauthorized, "authentication failed")
+				return
+			}
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost, http.MethodPut:
+			var controls RuntimeControls
+			decoder := json.NewDecoder(io.LimitReader(r.Body, 1<<20))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&controls); err != nil && !errors.Is(err, io.EOF) {
+				writeControlsError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+				return
+			}
+			s.ApplyControls(controls)
+		default:
+			w.Header().Set("Allow", "GET, POST, PUT")
+			writeControlsError(w, http.StatusMethodNotAllowed, "only GET, POST, and PUT requests are allowed")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(s.Controls())
+	})
+}
+
+// writeControlsError writes a JSON error response.
+func writeControlsError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
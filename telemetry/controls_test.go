@@ -0,0 +1,164 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// emittedNames returns the names recorded by a tagRecorder
+func emittedNames(r *tagRecorder) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.events))
+	for _, e := range r.events {
+		names = append(names, e.name)
+	}
+	return names
+}
+
+// TestSetEnabled verifies the runtime master switch
+func TestSetEnabled(t *testing.T) {
+	recorder := &tagRecorder{}
+	sys, err := NewSystem(&Config{Enabled: false, Emitter: recorder})
+	require.NoError(t, err)
+	assert.False(t, sys.Enabled())
+
+	require.NoError(t, sys.Counter("dropped_total", 1, nil))
+	sys.SetEnabled(true)
+	assert.True(t, sys.Enabled())
+	require.NoError(t, sys.Counter("kept_total", 1, nil))
+
+	// Namespace overrides cannot bypass the master switch
+	sys.SetNamespaceEnabled("kept_*", true)
+	sys.SetEnabled(false)
+	require.NoError(t, sys.Counter("kept_total", 1, nil))
+
+	assert.Equal(t, []string{"kept_total"}, emittedNames(recorder))
+}
+
+// TestSetEnabledConcurrentEmit toggles the system while metrics are emitted;
+// run with -race to check the schema is published safely
+func TestSetEnabledConcurrentEmit(t *testing.T) {
+	sys, err := NewSystem(&Config{Enabled: false, Emitter: &tagRecorder{}})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			sys.SetEnabled(i%2 == 0)
+			sys.SetNamespaceEnabled("toggled.*", i%3 == 0)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = sys.Counter("toggled_total", 1, nil)
+		}
+	}()
+	wg.Wait()
+}
+
+// TestNamespaceControls verifies prefix and exact overrides with dotted namespaces
+func TestNamespaceControls(t *testing.T) {
+	recorder := &tagRecorder{}
+	sys, err := NewSystem(&Config{
+		Enabled:    true,
+		Emitter:    recorder,
+		Namespaces: map[string]bool{"foundry.similarity.*": false},
+	})
+	require.NoError(t, err)
+
+	sys.SetNamespaceEnabled("foundry_similarity_cache_*", true)
+	sys.SetNamespaceEnabled("fulpack_errors_total", false)
+
+	require.NoError(t, sys.Counter("foundry_similarity_comparisons_total", 1, nil))
+	require.NoError(t, sys.Counter("foundry_similarity_cache_hits_total", 1, nil))
+	require.NoError(t, sys.Gauge("fulpack_entries_total", 3, nil))
+	require.NoError(t, sys.Counter("fulpack_errors_total", 1, nil))
+	require.NoError(t, sys.HistogramSummary("foundry_similarity_distance_ms", HistogramSummary{Count: 1}, nil))
+
+	assert.Equal(t, []string{"foundry_similarity_cache_hits_total", "fulpack_entries_total"}, emittedNames(recorder))
+
+	sys.ClearNamespaces()
+	require.NoError(t, sys.Counter("foundry_similarity_comparisons_total", 1, nil))
+	assert.Len(t, emittedNames(recorder), 3)
+}
+
+// TestAdminHandler verifies reading and updating controls over HTTP
+func TestAdminHandler(t *testing.T) {
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: &tagRecorder{}})
+	require.NoError(t, err)
+	handler := sys.AdminHandler("secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/telemetry", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	body := `{"namespaces":{"foundry.similarity.*":false}}`
+	req = httptest.NewRequest(http.MethodPost, "/admin/telemetry", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var controls RuntimeControls
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &controls))
+	require.NotNil(t, controls.Enabled)
+	assert.True(t, *controls.Enabled)
+	assert.Equal(t, map[string]bool{"foundry_similarity_*": false}, controls.Namespaces)
+	assert.False(t, sys.isEnabledFor("foundry_similarity_comparisons_total"))
+
+	req = httptest.NewRequest(http.MethodPut, "/admin/telemetry", strings.NewReader(`{"enabled":false}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, sys.Enabled())
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/telemetry", strings.NewReader(`{"enable":true}`))
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "unknown fields are rejected")
+
+	req = httptest.NewRequest(http.MethodDelete, "/admin/telemetry", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+// TestReloadHook verifies reloaded controls replace namespace overrides
+func TestReloadHook(t *testing.T) {
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: &tagRecorder{}})
+	require.NoError(t, err)
+	sys.SetNamespaceEnabled("pathfinder_*", false)
+
+	disabled := false
+	reload := sys.ReloadHook(func(ctx context.Context) (RuntimeControls, error) {
+		return RuntimeControls{Enabled: &disabled, Namespaces: map[string]bool{"fulpack_*": false}}, nil
+	})
+	require.NoError(t, reload(context.Background()))
+
+	controls := sys.Controls()
+	assert.False(t, *controls.Enabled)
+	assert.Equal(t, map[string]bool{"fulpack_*": false}, controls.Namespaces)
+
+	failing := sys.ReloadHook(func(ctx context.Context) (RuntimeControls, error) {
+		return RuntimeControls{}, errors.New("bad config")
+	})
+	assert.ErrorContains(t, failing(context.Background()), "bad config")
+	assert.Equal(t, map[string]bool{"fulpack_*": false}, sys.Controls().Namespaces, "failed reloads leave controls unchanged")
+}
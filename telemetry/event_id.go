@@ -0,0 +1,101 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/internal/ulid"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+)
+
+// EventIDTag is the tag key used to attach an event ID to metrics.
+// The logging module records the same value in the LogEvent "eventId" field,
+// allowing observability backends to pivot between a metric and its log records.
+const EventIDTag = metrics.TagEventID
+
+type eventIDKey struct{}
+
+// NewEventID generates a new ULID event ID.
+//
+// ULIDs are 26-character, lexicographically sortable identifiers composed of a
+// 48-bit millisecond timestamp and 80 bits of randomness.
+func NewEventID() string {
+	var id [16]byte
+	ulid.PutTimestamp(&id, time.Now())
+	_, _ = rand.Read(id[6:])
+	return ulid.Encode(id)
+}
+
+// WithEventID returns a copy of ctx carrying the given event ID.
+func WithEventID(ctx context.Context, eventID string) context.Context {
+	return context.WithValue(ctx, eventIDKey{}, eventID)
+}
+
+// EventIDFromContext returns the event ID carried by ctx, or "" if none.
+func EventIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(eventIDKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// EnsureEventID returns ctx and its event ID, generating and attaching a new
+// ULID if ctx does not carry one yet.
+//
+// Example:
+//
+//	ctx, eventID := telemetry.EnsureEventID(ctx)
+//	logger.WithContext(ctx).Error("upload failed")
+//	telemetry.EmitCounterContext(ctx, "upload_errors", 1, nil)
+func EnsureEventID(ctx context.Context) (context.Context, string) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if id := EventIDFromContext(ctx); id != "" {
+		return ctx, id
+	}
+	id := NewEventID()
+	return WithEventID(ctx, id), id
+}
+
+// TagsWithEventID returns a copy of tags with the event_id tag set from ctx.
+// If ctx carries no event ID, tags is returned unchanged.
+func TagsWithEventID(ctx context.Context, tags map[string]string) map[string]string {
+	id := EventIDFromContext(ctx)
+	if id == "" {
+		return tags
+	}
+	tagged := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		tagged[k] = v
+	}
+	tagged[EventIDTag] = id
+	return tagged
+}
+
+// EmitCounterContext is EmitCounter with the event_id tag taken from ctx.
+// A correlation ID in ctx is linked as an exemplar (see System.CounterCtx).
+func EmitCounterContext(ctx context.Context, name string, value float64, tags map[string]string) {
+	system := GetGlobalSystem()
+	if system != nil {
+		_ = system.CounterCtx(ctx, name, value, TagsWithEventID(ctx, tags))
+	}
+}
+
+// EmitHistogramContext is EmitHistogram with the event_id tag taken from ctx.
+// A correlation ID in ctx is linked as an exemplar (see System.HistogramCtx).
+func EmitHistogramContext(ctx context.Context, name string, duration time.Duration, tags map[string]string) {
+	system := GetGlobalSystem()
+	if system != nil {
+		_ = system.HistogramCtx(ctx, name, duration, TagsWithEventID(ctx, tags))
+	}
+}
+
+// EmitGaugeContext is EmitGauge with the event_id tag taken from ctx.
+func EmitGaugeContext(ctx context.Context, name string, value float64, tags map[string]string) {
+	EmitGauge(name, value, TagsWithEventID(ctx, tags))
+}
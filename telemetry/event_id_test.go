@@ -0,0 +1,51 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/internal/ulid"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEventID(t *testing.T) {
+	id := NewEventID()
+	assert.Len(t, id, 26)
+	for _, c := range id {
+		assert.Contains(t, ulid.Alphabet, string(c))
+	}
+	// 48-bit timestamp fits in 10 characters; the first one carries at most 3 bits
+	assert.LessOrEqual(t, id[0], byte('7'))
+
+	assert.NotEqual(t, id, NewEventID())
+}
+
+func TestEventIDContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, EventIDFromContext(ctx))
+
+	ctx, id := EnsureEventID(ctx)
+	require.NotEmpty(t, id)
+	assert.Equal(t, id, EventIDFromContext(ctx))
+
+	// Existing IDs are propagated, not regenerated
+	same, sameID := EnsureEventID(ctx)
+	assert.Equal(t, id, sameID)
+	assert.Equal(t, ctx, same)
+
+	assert.Equal(t, "custom", EventIDFromContext(WithEventID(ctx, "custom")))
+}
+
+func TestTagsWithEventID(t *testing.T) {
+	tags := map[string]string{"component": "test"}
+
+	assert.Equal(t, tags, TagsWithEventID(context.Background(), tags))
+
+	ctx := WithEventID(context.Background(), "01HZX3K0000000000000000000")
+	tagged := TagsWithEventID(ctx, tags)
+	assert.Equal(t, "01HZX3K0000000000000000000", tagged[metrics.TagEventID])
+	assert.Equal(t, "test", tagged["component"])
+	assert.NotContains(t, tags, metrics.TagEventID, "input tags must not be mutated")
+}
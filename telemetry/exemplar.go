@@ -0,0 +1,145 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+)
+
+// CorrelationIDTag is the exemplar label (and, with Config.CorrelationIDAsTag,
+// the tag) carrying the correlation ID.
+const CorrelationIDTag = metrics.TagCorrelationID
+
+// Exemplar links one observation of a metric series to a trace or request.
+type Exemplar struct {
+	// Labels identify the trace or request (e.g., {"correlation_id": "..."}).
+	Labels map[string]string
+
+	// Value is the observed value: the counter increment after sampling
+	// weights, or the observation in milliseconds for histograms.
+	Value float64
+
+	// Timestamp is when the observation was made.
+	Timestamp time.Time
+}
+
+// ExemplarRecorder is implemented by emitters that support exemplars. When a
+// CounterCtx or HistogramCtx context carries a correlation ID, the system
+// calls RecordExemplar after the event reaches the emitter; events dropped by
+// sampling or suppression record no exemplar.
+type ExemplarRecorder interface {
+	RecordExemplar(name string, tags map[string]string, exemplar Exemplar) error
+}
+
+var (
+	correlationIDMu   sync.RWMutex
+	correlationIDFunc func(context.Context) (string, bool)
+)
+
+// SetCorrelationIDFunc registers the function used by the ctx-accepting
+// variants to read a correlation ID from a context. The foundry package
+// registers foundry.CorrelationIDFromContext when it is imported; other
+// request-ID schemes can register their own.
+func SetCorrelationIDFunc(fn func(context.Context) (string, bool)) {
+	correlationIDMu.Lock()
+	defer correlationIDMu.Unlock()
+	correlationIDFunc = fn
+}
+
+// correlationIDFromContext returns the correlation ID carried by ctx, if any.
+func correlationIDFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	correlationIDMu.RLock()
+	fn := correlationIDFunc
+	correlationIDMu.RUnlock()
+	if fn == nil {
+		return "", false
+	}
+	id, ok := fn(ctx)
+	return id, ok && id != ""
+}
+
+// CounterCtx emits a counter like Counter and links it to the correlation ID
+// carried by ctx: as an exemplar for emitters implementing ExemplarRecorder,
+// and as a correlation_id tag when Config.CorrelationIDAsTag is set.
+//
+// Example:
+//
+//	ctx := foundry.WithCorrelationID(r.Context(), foundry.NewCorrelationIDValue())
+//	_ = sys.CounterCtx(ctx, "uploads_total", 1, nil)
+func (s *System) CounterCtx(ctx context.Context, name string, value float64, tags map[string]string) error {
+	id, ok := correlationIDFromContext(ctx)
+	if !ok {
+		return s.Counter(name, value, tags)
+	}
+	return s.counter(name, value, s.correlationTags(tags, id), id)
+}
+
+// HistogramCtx emits a histogram like Histogram and links it to the
+// correlation ID carried by ctx (see CounterCtx).
+//
+// Example:
+//
+//	start := time.Now()
+//	defer func() { _ = sys.HistogramCtx(ctx, "request_ms", time.Since(start), nil) }()
+func (s *System) HistogramCtx(ctx context.Context, name string, duration time.Duration, tags map[string]string) error {
+	id, ok := correlationIDFromContext(ctx)
+	if !ok {
+		return s.Histogram(name, duration, tags)
+	}
+	return s.histogram(name, duration, s.correlationTags(tags, id), id)
+}
+
+// correlationTags adds the correlation ID tag when Config.CorrelationIDAsTag is set.
+func (s *System) correlationTags(tags map[string]string, id string) map[string]string {
+	if !s.config.CorrelationIDAsTag {
+		return tags
+	}
+	tagged := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		tagged[k] = v
+	}
+	tagged[CorrelationIDTag] = id
+	return tagged
+}
+
+// newExemplar returns the exemplar for an observation linked to
+// correlationID, or nil when there is none.
+func (s *System) newExemplar(correlationID string, value float64) *Exemplar {
+	if correlationID == "" {
+		return nil
+	}
+	return &Exemplar{
+		Labels:    map[string]string{CorrelationIDTag: correlationID},
+		Value:     value,
+		Timestamp: s.now().UTC(),
+	}
+}
+
+// recordExemplar passes the exemplar of an emitted event to the emitter if
+// it supports them.
+func (s *System) recordExemplar(event MetricsEvent) error {
+	if event.exemplar == nil {
+		return nil
+	}
+	recorder, ok := s.config.Emitter.(ExemplarRecorder)
+	if !ok {
+		return nil
+	}
+	tags := event.Tags
+	if s.config.CorrelationIDAsTag {
+		// The series tags exclude the exemplar label
+		series := make(map[string]string, len(tags))
+		for k, v := range tags {
+			if k != CorrelationIDTag {
+				series[k] = v
+			}
+		}
+		tags = series
+	}
+	return recorder.RecordExemplar(event.Name, tags, *event.exemplar)
+}
@@ -0,0 +1,138 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCorrelationKey struct{}
+
+// exemplarRecorder records metric tags and exemplars
+type exemplarRecorder struct {
+	tagRecorder
+	mu        sync.Mutex
+	exemplars []Exemplar
+	names     []string
+	series    []map[string]string
+}
+
+func (r *exemplarRecorder) RecordExemplar(name string, tags map[string]string, exemplar Exemplar) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names = append(r.names, name)
+	r.series = append(r.series, tags)
+	r.exemplars = append(r.exemplars, exemplar)
+	return nil
+}
+
+// withTestCorrelationIDs registers a correlation ID extractor for the test
+func withTestCorrelationIDs(t *testing.T) {
+	t.Helper()
+	correlationIDMu.RLock()
+	previous := correlationIDFunc
+	correlationIDMu.RUnlock()
+	SetCorrelationIDFunc(func(ctx context.Context) (string, bool) {
+		id, ok := ctx.Value(testCorrelationKey{}).(string)
+		return id, ok
+	})
+	t.Cleanup(func() { SetCorrelationIDFunc(previous) })
+}
+
+// TestCounterCtx_Exemplar verifies the correlation ID is recorded as an exemplar
+func TestCounterCtx_Exemplar(t *testing.T) {
+	withTestCorrelationIDs(t)
+	recorder := &exemplarRecorder{}
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: recorder})
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), testCorrelationKey{}, "corr-1")
+	require.NoError(t, sys.CounterCtx(ctx, "uploads_total", 2, map[string]string{"status": "success"}))
+	require.NoError(t, sys.HistogramCtx(ctx, "upload_ms", 1500*time.Microsecond, nil))
+
+	require.Len(t, recorder.exemplars, 2)
+	assert.Equal(t, []string{"uploads_total", "upload_ms"}, recorder.names)
+	assert.Equal(t, map[string]string{CorrelationIDTag: "corr-1"}, recorder.exemplars[0].Labels)
+	assert.Equal(t, 2.0, recorder.exemplars[0].Value)
+	assert.Equal(t, 1.5, recorder.exemplars[1].Value)
+	assert.Equal(t, map[string]string{"status": "success"}, recorder.series[0])
+
+	// Series tags stay free of the correlation ID by default
+	for _, event := range recorder.events {
+		assert.NotContains(t, event.tags, CorrelationIDTag)
+	}
+}
+
+// TestCounterCtx_NoCorrelationID verifies plain emission without an ID in ctx
+func TestCounterCtx_NoCorrelationID(t *testing.T) {
+	withTestCorrelationIDs(t)
+	recorder := &exemplarRecorder{}
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: recorder})
+	require.NoError(t, err)
+
+	require.NoError(t, sys.CounterCtx(context.Background(), "uploads_total", 1, nil))
+	require.NoError(t, sys.HistogramCtx(context.Background(), "upload_ms", time.Millisecond, nil))
+	assert.Empty(t, recorder.exemplars)
+	assert.Len(t, recorder.events, 2)
+}
+
+// TestCounterCtx_CorrelationIDAsTag verifies the opt-in tag for backends without exemplars
+func TestCounterCtx_CorrelationIDAsTag(t *testing.T) {
+	withTestCorrelationIDs(t)
+	recorder := &exemplarRecorder{}
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: recorder, CorrelationIDAsTag: true})
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), testCorrelationKey{}, "corr-2")
+	tags := map[string]string{"status": "success"}
+	require.NoError(t, sys.CounterCtx(ctx, "uploads_total", 1, tags))
+
+	require.Len(t, recorder.events, 1)
+	assert.Equal(t, "corr-2", recorder.events[0].tags[CorrelationIDTag])
+	assert.NotContains(t, tags, CorrelationIDTag, "caller tags must not be modified")
+	require.Len(t, recorder.series, 1)
+	assert.Equal(t, map[string]string{"status": "success"}, recorder.series[0])
+}
+
+// TestCounterCtx_Disabled verifies nothing is emitted when telemetry is disabled
+func TestCounterCtx_Disabled(t *testing.T) {
+	withTestCorrelationIDs(t)
+	recorder := &exemplarRecorder{}
+	sys, err := NewSystem(&Config{Enabled: false, Emitter: recorder})
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), testCorrelationKey{}, "corr-3")
+	require.NoError(t, sys.CounterCtx(ctx, "uploads_total", 1, nil))
+	assert.Empty(t, recorder.exemplars)
+	assert.Empty(t, recorder.events)
+}
+
+// TestCounterCtx_ExemplarFollowsEmission verifies exemplars are only recorded
+// for emitted events and carry the sampled weight
+func TestCounterCtx_ExemplarFollowsEmission(t *testing.T) {
+	withTestCorrelationIDs(t)
+	recorder := &exemplarRecorder{}
+	sys, err := NewSystem(&Config{
+		Enabled:            true,
+		Emitter:            recorder,
+		SuppressZeroValues: true,
+		Sampling:           map[string]SamplingPolicy{"sampled_total": {Rate: 0.5}},
+	})
+	require.NoError(t, err)
+	sys.rand = sequence(0.9, 0.1)
+
+	ctx := context.WithValue(context.Background(), testCorrelationKey{}, "corr-4")
+	require.NoError(t, sys.CounterCtx(ctx, "sampled_total", 1, nil)) // sampled out
+	require.NoError(t, sys.CounterCtx(ctx, "sampled_total", 1, nil)) // kept with weight 2
+	require.NoError(t, sys.CounterCtx(ctx, "idle_total", 0, nil))    // first sample emitted
+	require.NoError(t, sys.CounterCtx(ctx, "idle_total", 0, nil))    // suppressed
+
+	require.Len(t, recorder.events, 2)
+	require.Len(t, recorder.exemplars, 2)
+	assert.Equal(t, []string{"sampled_total", "idle_total"}, recorder.names)
+	assert.Equal(t, 2.0, recorder.exemplars[0].Value)
+}
@@ -34,6 +34,12 @@ type PrometheusConfig struct {
 	// ReadHeaderTimeout prevents Slowloris attacks
 	// Default: 10 seconds
 	ReadHeaderTimeout time.Duration
+
+	// MaxSeries caps the number of retained series (name + labels). When the
+	// cap is reached, the least recently updated series is evicted and counted
+	// in prometheus_exporter_series_evicted_total.
+	// Default: 10000
+	MaxSeries int
 }
 
 // DefaultPrometheusConfig returns sensible defaults for Prometheus exporter
@@ -47,6 +53,7 @@ func DefaultPrometheusConfig() *PrometheusConfig {
 		RefreshInterval:    0, // Immediate refresh on emission
 		QuietMode:          false,
 		ReadHeaderTimeout:  10 * time.Second,
+		MaxSeries:          10000,
 	}
 }
 
@@ -64,5 +71,8 @@ func (c *PrometheusConfig) Validate() error {
 	if c.ReadHeaderTimeout <= 0 {
 		c.ReadHeaderTimeout = 10 * time.Second
 	}
+	if c.MaxSeries <= 0 {
+		c.MaxSeries = 10000
+	}
 	return nil
 }
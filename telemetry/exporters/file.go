@@ -0,0 +1,357 @@
+package exporters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+)
+
+// SyncPolicy controls when the file emitter fsyncs written metrics.
+type SyncPolicy string
+
+const (
+	// SyncNone leaves flushing to the operating system.
+	SyncNone SyncPolicy = "none"
+
+	// SyncAlways fsyncs after every event. Safest, slowest.
+	SyncAlways SyncPolicy = "always"
+
+	// SyncInterval fsyncs every FileConfig.SyncInterval.
+	SyncInterval SyncPolicy = "interval"
+)
+
+// ErrFileEmitterClosed is returned when emitting to a closed FileEmitter.
+var ErrFileEmitterClosed = errors.New("file emitter closed")
+
+// FileConfig holds configuration for the file emitter
+type FileConfig struct {
+	// Path is the active metrics file. Rotated files are Path.1 (newest)
+	// through Path.MaxFiles (oldest).
+	Path string
+
+	// MaxSizeBytes rotates the file before a write would exceed this size
+	// (0 = no size-based rotation)
+	// Default: 10 MiB
+	MaxSizeBytes int64
+
+	// RotateInterval rotates the file once it has been open this long
+	// (0 = no time-based rotation)
+	RotateInterval time.Duration
+
+	// MaxFiles is the number of rotated files kept; older files are deleted,
+	// so disk usage is bounded like a ring buffer
+	// Default: 5
+	MaxFiles int
+
+	// Sync selects the fsync policy
+	// Default: SyncInterval
+	Sync SyncPolicy
+
+	// SyncInterval is the fsync period for SyncInterval
+	// Default: 1 second
+	SyncInterval time.Duration
+}
+
+// DefaultFileConfig returns sensible defaults for a file emitter writing to path
+func DefaultFileConfig(path string) *FileConfig {
+	return &FileConfig{
+		Path:         path,
+		MaxSizeBytes: 10 << 20,
+		MaxFiles:     5,
+		Sync:         SyncInterval,
+		SyncInterval: time.Second,
+	}
+}
+
+// Validate checks configuration values and returns an error if invalid
+func (c *FileConfig) Validate() error {
+	if c.Path == "" {
+		return errors.New("file emitter path is required")
+	}
+	if c.MaxSizeBytes < 0 {
+		c.MaxSizeBytes = 0
+	}
+	if c.RotateInterval < 0 {
+		c.RotateInterval = 0
+	}
+	if c.MaxFiles <= 0 {
+		c.MaxFiles = 5
+	}
+	switch c.Sync {
+	case "":
+		c.Sync = SyncInterval
+	case SyncNone, SyncAlways, SyncInterval:
+	default:
+		return fmt.Errorf("unknown sync policy: %q", c.Sync)
+	}
+	if c.SyncInterval <= 0 {
+		c.SyncInterval = time.Second
+	}
+	return nil
+}
+
+// FileEmitter writes newline-delimited MetricsEvents to a rotating file, so
+// deployments without a metrics backend (e.g., air-gapped sites) can collect
+// metrics for later ingestion.
+//
+// Example:
+//
+//	emitter, err := exporters.NewFileEmitter(exporters.DefaultFileConfig("/var/lib/myapp/metrics.jsonl"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer emitter.Close()
+//
+//	sys, _ := telemetry.NewSystem(&telemetry.Config{Enabled: true, Emitter: emitter})
+//	telemetry.SetGlobalSystem(sys)
+type FileEmitter struct {
+	mu       sync.Mutex
+	config   *FileConfig
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	dirty    bool
+	closed   bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewFileEmitter opens (or appends to) the metrics file described by config
+func NewFileEmitter(config *FileConfig) (*FileEmitter, error) {
+	if config == nil {
+		return nil, errors.New("file emitter config is required")
+	}
+	cfg := *config
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	e := &FileEmitter{config: &cfg}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create metrics directory: %w", err)
+	}
+	if err := e.openLocked(); err != nil {
+		return nil, err
+	}
+
+	if cfg.Sync == SyncInterval {
+		e.stop = make(chan struct{})
+		e.done = make(chan struct{})
+		go e.syncLoop()
+	}
+	return e, nil
+}
+
+// Counter implements telemetry.MetricsEmitter
+func (e *FileEmitter) Counter(name string, value float64, tags map[string]string) error {
+	return e.write(telemetry.MetricsEvent{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Name:      name,
+		Type:      telemetry.TypeCounter,
+		Value:     value,
+		Tags:      tags,
+	})
+}
+
+// Histogram implements telemetry.MetricsEmitter
+func (e *FileEmitter) Histogram(name string, duration time.Duration, tags map[string]string) error {
+	return e.write(telemetry.MetricsEvent{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Name:      name,
+		Type:      telemetry.TypeHistogram,
+		Value:     float64(duration.Nanoseconds()) / 1e6, // Convert to milliseconds
+		Tags:      tags,
+		Unit:      "ms",
+	})
+}
+
+// HistogramSummary implements telemetry.MetricsEmitter
+func (e *FileEmitter) HistogramSummary(name string, summary telemetry.HistogramSummary, tags map[string]string) error {
+	return e.write(telemetry.MetricsEvent{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Name:      name,
+		Type:      telemetry.TypeHistogram,
+		Value:     summary,
+		Tags:      tags,
+		Unit:      "ms",
+	})
+}
+
+// Gauge implements telemetry.MetricsEmitter
+func (e *FileEmitter) Gauge(name string, value float64, tags map[string]string) error {
+	return e.write(telemetry.MetricsEvent{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Name:      name,
+		Type:      telemetry.TypeGauge,
+		Value:     value,
+		Tags:      tags,
+	})
+}
+
+// Sync fsyncs the active file
+func (e *FileEmitter) Sync() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return ErrFileEmitterClosed
+	}
+	return e.syncLocked()
+}
+
+// Rotate rotates the active file immediately
+func (e *FileEmitter) Rotate() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return ErrFileEmitterClosed
+	}
+	return e.rotateLocked()
+}
+
+// Close syncs and closes the active file and stops the sync loop
+func (e *FileEmitter) Close() error {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return nil
+	}
+	e.closed = true
+	syncErr := e.syncLocked()
+	closeErr := e.file.Close()
+	e.mu.Unlock()
+
+	if e.stop != nil {
+		close(e.stop)
+		<-e.done
+	}
+	return errors.Join(syncErr, closeErr)
+}
+
+// write appends one event as a JSON line, rotating first if needed
+func (e *FileEmitter) write(event telemetry.MetricsEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metric event: %w", err)
+	}
+	line = append(line, '\n')
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return ErrFileEmitterClosed
+	}
+
+	if e.shouldRotateLocked(int64(len(line))) {
+		if err := e.rotateLocked(); err != nil {
+			return err
+		}
+	}
+
+	n, err := e.file.Write(line)
+	e.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write metric event: %w", err)
+	}
+	e.dirty = true
+
+	if e.config.Sync == SyncAlways {
+		return e.syncLocked()
+	}
+	return nil
+}
+
+// shouldRotateLocked reports whether the active file must rotate before a write of n bytes
+func (e *FileEmitter) shouldRotateLocked(n int64) bool {
+	if e.size == 0 {
+		return false
+	}
+	if e.config.MaxSizeBytes > 0 && e.size+n > e.config.MaxSizeBytes {
+		return true
+	}
+	return e.config.RotateInterval > 0 && time.Since(e.openedAt) >= e.config.RotateInterval
+}
+
+// rotateLocked shifts Path.N-1 → Path.N (dropping the oldest) and starts a new file
+func (e *FileEmitter) rotateLocked() error {
+	if err := e.syncLocked(); err != nil {
+		return err
+	}
+	if err := e.file.Close(); err != nil {
+		return fmt.Errorf("failed to close metrics file: %w", err)
+	}
+
+	path := e.config.Path
+	_ = os.Remove(rotatedPath(path, e.config.MaxFiles))
+	for i := e.config.MaxFiles - 1; i >= 1; i-- {
+		if err := os.Rename(rotatedPath(path, i), rotatedPath(path, i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to rotate metrics file: %w", err)
+		}
+	}
+	if err := os.Rename(path, rotatedPath(path, 1)); err != nil {
+		return fmt.Errorf("failed to rotate metrics file: %w", err)
+	}
+
+	return e.openLocked()
+}
+
+// openLocked opens the active file for appending
+func (e *FileEmitter) openLocked() error {
+	file, err := os.OpenFile(e.config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640) // #nosec G302 G304 -- metrics file path is operator-configured
+	if err != nil {
+		return fmt.Errorf("failed to open metrics file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat metrics file: %w", err)
+	}
+
+	e.file = file
+	e.size = info.Size()
+	e.openedAt = time.Now()
+	e.dirty = false
+	return nil
+}
+
+// syncLocked fsyncs the active file if it has unsynced writes
+func (e *FileEmitter) syncLocked() error {
+	if !e.dirty {
+		return nil
+	}
+	if err := e.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync metrics file: %w", err)
+	}
+	e.dirty = false
+	return nil
+}
+
+// syncLoop fsyncs the active file every SyncInterval until Close
+func (e *FileEmitter) syncLoop() {
+	defer close(e.done)
+	ticker := time.NewTicker(e.config.SyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-ticker.C:
+			e.mu.Lock()
+			if !e.closed {
+				_ = e.syncLocked()
+			}
+			e.mu.Unlock()
+		}
+	}
+}
+
+// rotatedPath returns the path of the n-th rotated file
+func rotatedPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
@@ -0,0 +1,146 @@
+package exporters
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readEvents decodes the JSON lines in path
+func readEvents(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+	f, err := os.Open(path) // #nosec G304 -- test file
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var events []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+// TestFileEmitterWritesEvents tests newline-delimited output for each metric type
+func TestFileEmitterWritesEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics", "metrics.jsonl")
+	emitter, err := NewFileEmitter(DefaultFileConfig(path))
+	require.NoError(t, err)
+
+	require.NoError(t, emitter.Counter("requests_total", 1, map[string]string{"status": "success"}))
+	require.NoError(t, emitter.Gauge("queue_depth", 7, nil))
+	require.NoError(t, emitter.Histogram("latency", 1500*time.Microsecond, nil))
+	require.NoError(t, emitter.HistogramSummary("op_ms", telemetry.HistogramSummary{Count: 1, Sum: 5}, nil))
+	require.NoError(t, emitter.Close())
+
+	events := readEvents(t, path)
+	require.Len(t, events, 4)
+	assert.Equal(t, "requests_total", events[0]["name"])
+	assert.Equal(t, "counter", events[0]["type"])
+	assert.Equal(t, "success", events[0]["tags"].(map[string]interface{})["status"])
+	assert.Equal(t, "gauge", events[1]["type"])
+	assert.Equal(t, 1.5, events[2]["value"])
+	assert.Equal(t, "ms", events[3]["unit"])
+
+	assert.ErrorIs(t, emitter.Counter("late", 1, nil), ErrFileEmitterClosed)
+	assert.NoError(t, emitter.Close(), "Close should be idempotent")
+}
+
+// TestFileEmitterAppends tests that reopening appends to an existing file
+func TestFileEmitterAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	for i := 0; i < 2; i++ {
+		emitter, err := NewFileEmitter(DefaultFileConfig(path))
+		require.NoError(t, err)
+		require.NoError(t, emitter.Counter("starts_total", 1, nil))
+		require.NoError(t, emitter.Close())
+	}
+	assert.Len(t, readEvents(t, path), 2)
+}
+
+// TestFileEmitterSizeRotation tests the ring of rotated files
+func TestFileEmitterSizeRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	config := DefaultFileConfig(path)
+	config.MaxSizeBytes = 200
+	config.MaxFiles = 2
+	config.Sync = SyncAlways
+
+	emitter, err := NewFileEmitter(config)
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		require.NoError(t, emitter.Counter("requests_total", float64(i), nil))
+	}
+	require.NoError(t, emitter.Close())
+
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(p)
+		require.NoError(t, err, p)
+		assert.LessOrEqual(t, info.Size(), int64(200), p)
+	}
+	assert.NoFileExists(t, path+".3", "only MaxFiles rotated files are kept")
+
+	// The newest events are in the active file, older ones in .1
+	active := readEvents(t, path)
+	assert.Equal(t, 19.0, active[len(active)-1]["value"])
+	previous := readEvents(t, path+".1")
+	assert.Less(t, previous[len(previous)-1]["value"].(float64), active[0]["value"].(float64))
+}
+
+// TestFileEmitterTimeRotation tests rotation after RotateInterval
+func TestFileEmitterTimeRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	config := DefaultFileConfig(path)
+	config.MaxSizeBytes = 0
+	config.RotateInterval = 20 * time.Millisecond
+
+	emitter, err := NewFileEmitter(config)
+	require.NoError(t, err)
+	require.NoError(t, emitter.Counter("first", 1, nil))
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, emitter.Counter("second", 1, nil))
+	require.NoError(t, emitter.Close())
+
+	assert.Equal(t, "first", readEvents(t, path+".1")[0]["name"])
+	assert.Equal(t, "second", readEvents(t, path)[0]["name"])
+}
+
+// TestFileConfigValidate tests defaults and invalid configuration
+func TestFileConfigValidate(t *testing.T) {
+	_, err := NewFileEmitter(&FileConfig{})
+	assert.Error(t, err, "path is required")
+
+	_, err = NewFileEmitter(&FileConfig{Path: filepath.Join(t.TempDir(), "m.jsonl"), Sync: "sometimes"})
+	assert.Error(t, err)
+
+	config := &FileConfig{Path: "metrics.jsonl", MaxFiles: -1}
+	require.NoError(t, config.Validate())
+	assert.Equal(t, 5, config.MaxFiles)
+	assert.Equal(t, SyncInterval, config.Sync)
+	assert.Equal(t, time.Second, config.SyncInterval)
+}
+
+// TestFileEmitterWithSystem tests the emitter behind a telemetry system
+func TestFileEmitterWithSystem(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.jsonl")
+	emitter, err := NewFileEmitter(DefaultFileConfig(path))
+	require.NoError(t, err)
+
+	sys, err := telemetry.NewSystem(&telemetry.Config{Enabled: true, Emitter: emitter})
+	require.NoError(t, err)
+	require.NoError(t, sys.Counter("schema_validations", 1, nil))
+	require.NoError(t, sys.Histogram("operation_ms", 3*time.Millisecond, nil))
+	require.NoError(t, emitter.Close())
+
+	assert.Len(t, readEvents(t, path), 2)
+}
@@ -3,8 +3,13 @@
 // The Prometheus exporter implements enterprise-grade HTTP metrics exposition with:
 //   - Bearer token authentication
 //   - Per-IP rate limiting
-//   - Comprehensive health instrumentation (7 built-in metrics)
+//   - Comprehensive health instrumentation (9 built-in metrics)
+//   - Per-series aggregation: monotonic counters, last-value gauges, and
+//     cumulative histograms with _bucket, _sum, and _count series
+//   - A MaxSeries cap with least-recently-updated eviction
 //   - Automatic millisecond-to-second conversion for histograms
+//   - OpenMetrics exposition with correlation ID exemplars, negotiated
+//     through the Accept header
 //   - Three-phase refresh pipeline (collect, convert, export)
 //
 // Basic usage:
@@ -17,15 +22,22 @@
 //
 // See PrometheusConfig for advanced configuration options including authentication,
 // rate limiting, and refresh intervals.
+//
+// FileEmitter writes newline-delimited metrics events to a size- or time-rotated
+// file for deployments without a metrics backend; see FileConfig.
 package exporters
 
 import (
+	"bytes"
+	"container/list"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -35,12 +47,17 @@ import (
 	"github.com/fulmenhq/gofulmen/telemetry/metrics"
 )
 
-// PrometheusExporter implements a Prometheus metrics exporter with health instrumentation
+// PrometheusExporter implements a Prometheus metrics exporter with health instrumentation.
+//
+// Emitted metrics are aggregated per series (name + labels): counters
+// accumulate, gauges keep their last value, and histograms merge into
+// cumulative buckets exposed as _bucket, _sum, and _count series.
 type PrometheusExporter struct {
-	mu      sync.RWMutex
-	metrics []telemetry.MetricsEvent
-	config  *PrometheusConfig
-	server  *http.Server
+	mu     sync.RWMutex
+	series map[string]*promSeries
+	lru    *list.List // front = most recently updated
+	config *PrometheusConfig
+	server *http.Server
 
 	// HTTP handler with middleware
 	httpHandler *httpHandler
@@ -48,6 +65,21 @@ type PrometheusExporter struct {
 	// Refresh tracking
 	refreshInflight atomic.Int64
 	restartCount    atomic.Int64
+	evicted         atomic.Int64
+}
+
+// promSeries is the aggregated state of one series
+type promSeries struct {
+	key       string
+	name      string
+	typ       telemetry.MetricType
+	tags      map[string]string
+	value     float64                    // counter total or last gauge value
+	histogram telemetry.HistogramSummary // cumulative buckets, in milliseconds
+	exemplar  *telemetry.Exemplar        // latest counter exemplar
+	exemplars []*telemetry.Exemplar      // latest exemplar per histogram bucket
+	updated   time.Time
+	elem      *list.Element
 }
 
 // NewPrometheusExporter creates a new Prometheus exporter (legacy constructor for backward compatibility)
@@ -69,77 +101,133 @@ func NewPrometheusExporterWithConfig(config *PrometheusConfig) *PrometheusExport
 	}
 
 	return &PrometheusExporter{
-		metrics: make([]telemetry.MetricsEvent, 0),
-		config:  config,
+		series: make(map[string]*promSeries),
+		lru:    list.New(),
+		config: config,
 	}
 }
 
-// Counter implements telemetry.MetricsEmitter
+// Counter implements telemetry.MetricsEmitter. Increments accumulate into a
+// monotonic total per series.
 func (e *PrometheusExporter) Counter(name string, value float64, tags map[string]string) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-
-	event := telemetry.MetricsEvent{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Name:      name,
-		Type:      telemetry.TypeCounter,
-		Value:     value,
-		Tags:      tags,
+	if value < 0 || math.IsNaN(value) {
+		return fmt.Errorf("counter %s: increment must be non-negative, got %v", name, value)
 	}
-	e.metrics = append(e.metrics, event)
+	e.update(telemetry.TypeCounter, name, tags, func(s *promSeries) {
+		s.value += value
+	})
 	return nil
 }
 
-// Histogram implements telemetry.MetricsEmitter
+// Histogram implements telemetry.MetricsEmitter. The observation is counted
+// into the series' buckets (telemetry.DefaultHistogramBucketsMS for new series).
 func (e *PrometheusExporter) Histogram(name string, duration time.Duration, tags map[string]string) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-
-	event := telemetry.MetricsEvent{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Name:      name,
-		Type:      telemetry.TypeHistogram,
-		Value:     float64(duration.Nanoseconds()) / 1e6, // Convert to milliseconds
-		Tags:      tags,
-		Unit:      "ms",
-	}
-	e.metrics = append(e.metrics, event)
+	ms := float64(duration.Nanoseconds()) / 1e6 // Convert to milliseconds
+	e.update(telemetry.TypeHistogram, name, tags, func(s *promSeries) {
+		if len(s.histogram.Buckets) == 0 {
+			s.histogram.Buckets = newBuckets(telemetry.DefaultHistogramBucketsMS)
+		}
+		for i := range s.histogram.Buckets {
+			if ms <= s.histogram.Buckets[i].LE {
+				s.histogram.Buckets[i].Count++
+			}
+		}
+		s.histogram.Count++
+		s.histogram.Sum += ms
+	})
 	return nil
 }
 
-// HistogramSummary implements telemetry.MetricsEmitter
+// HistogramSummary implements telemetry.MetricsEmitter. The summary is merged
+// into the series; the series keeps the bucket layout of its first summary.
 func (e *PrometheusExporter) HistogramSummary(name string, summary telemetry.HistogramSummary, tags map[string]string) error {
-	e.mu.Lock()
-	defer e.mu.Unlock()
-
-	event := telemetry.MetricsEvent{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Name:      name,
-		Type:      telemetry.TypeHistogram,
-		Value:     summary,
-		Tags:      tags,
-		Unit:      "ms",
-	}
-	e.metrics = append(e.metrics, event)
+	e.update(telemetry.TypeHistogram, name, tags, func(s *promSeries) {
+		mergeSummary(&s.histogram, summary)
+	})
 	return nil
 }
 
 // Gauge implements telemetry.MetricsEmitter
 func (e *PrometheusExporter) Gauge(name string, value float64, tags map[string]string) error {
+	e.update(telemetry.TypeGauge, name, tags, func(s *promSeries) {
+		s.value = value
+	})
+	return nil
+}
+
+// RecordExemplar implements telemetry.ExemplarRecorder. A counter keeps its
+// latest exemplar; a histogram keeps the latest exemplar of each bucket,
+// filed under the smallest bucket containing the observation. Exemplars are
+// exposed in the OpenMetrics format only.
+func (e *PrometheusExporter) RecordExemplar(name string, tags map[string]string, exemplar telemetry.Exemplar) error {
+	exemplar.Labels = copyLabels(exemplar.Labels)
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
-
-	event := telemetry.MetricsEvent{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Name:      name,
-		Type:      telemetry.TypeGauge,
-		Value:     value,
-		Tags:      tags,
+	if s, ok := e.series[seriesKey(telemetry.TypeCounter, name, tags)]; ok {
+		s.exemplar = &exemplar
+		return nil
+	}
+	if s, ok := e.series[seriesKey(telemetry.TypeHistogram, name, tags)]; ok {
+		if len(s.exemplars) != len(s.histogram.Buckets) {
+			s.exemplars = make([]*telemetry.Exemplar, len(s.histogram.Buckets))
+		}
+		for i, bucket := range s.histogram.Buckets {
+			if exemplar.Value <= bucket.LE {
+				s.exemplars[i] = &exemplar
+				break
+			}
+		}
 	}
-	e.metrics = append(e.metrics, event)
 	return nil
 }
 
+// update applies fn to the series for (typ, name, tags), creating it and
+// evicting the least recently updated series at MaxSeries.
+func (e *PrometheusExporter) update(typ telemetry.MetricType, name string, tags map[string]string, fn func(*promSeries)) {
+	key := seriesKey(typ, name, tags)
+	evicted := 0
+
+	e.mu.Lock()
+	s, ok := e.series[key]
+	if ok {
+		e.lru.MoveToFront(s.elem)
+	} else {
+		for len(e.series) >= e.config.MaxSeries {
+			oldest := e.lru.Back()
+			e.lru.Remove(oldest)
+			delete(e.series, oldest.Value.(*promSeries).key)
+			evicted++
+		}
+		s = &promSeries{key: key, name: name, typ: typ, tags: copyLabels(tags)}
+		s.elem = e.lru.PushFront(s)
+		e.series[key] = s
+	}
+	fn(s)
+	s.updated = time.Now()
+	count := len(e.series)
+	e.mu.Unlock()
+
+	// Emit outside the lock: the global system may route back to this exporter
+	if evicted > 0 {
+		e.evicted.Add(int64(evicted))
+		telemetry.EmitCounter(metrics.PrometheusExporterSeriesEvictedTotal, float64(evicted), nil)
+		telemetry.EmitGauge(metrics.PrometheusExporterSeries, float64(count), nil)
+	}
+}
+
+// EvictedSeries returns the number of series evicted because of MaxSeries
+func (e *PrometheusExporter) EvictedSeries() int64 {
+	return e.evicted.Load()
+}
+
+// SeriesCount returns the number of retained series
+func (e *PrometheusExporter) SeriesCount() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return len(e.series)
+}
+
 // Start starts the HTTP server for Prometheus metrics endpoint with instrumentation
 func (e *PrometheusExporter) Start() error {
 	// Emit restart metric
@@ -214,46 +302,32 @@ func (e *PrometheusExporter) metricsHandler(w http.ResponseWriter, r *http.Reque
 	// Emit inflight gauge
 	telemetry.EmitGauge(metrics.PrometheusExporterRefreshInflight, float64(e.refreshInflight.Load()), nil)
 
-	// Phase 1: Collect - snapshot metrics
+	// Phase 1: Collect - snapshot series state
 	collectStart := time.Now()
-	e.mu.RLock()
-	snapshot := make([]telemetry.MetricsEvent, len(e.metrics))
-	copy(snapshot, e.metrics)
-	e.mu.RUnlock()
+	snapshot := e.snapshot()
 	collectDuration := time.Since(collectStart)
 	telemetry.EmitHistogram(metrics.PrometheusExporterRefreshDurationSeconds, collectDuration, map[string]string{metrics.TagPhase: metrics.PhaseCollect})
 
-	// Phase 2: Convert - group and prepare Prometheus format
+	// Phase 2: Convert - render Prometheus text or OpenMetrics format
 	convertStart := time.Now()
-	metricGroups := make(map[string][]telemetry.MetricsEvent)
-	for _, metric := range snapshot {
-		key := fmt.Sprintf("%s_%s", metric.Name, e.getMetricType(metric))
-		metricGroups[key] = append(metricGroups[key], metric)
-	}
+	openMetrics := r != nil && acceptsOpenMetrics(r.Header.Get("Accept"))
+	var buf bytes.Buffer
+	e.writeSeries(&buf, snapshot, openMetrics)
 	convertDuration := time.Since(convertStart)
 	telemetry.EmitHistogram(metrics.PrometheusExporterRefreshDurationSeconds, convertDuration, map[string]string{metrics.TagPhase: metrics.PhaseConvert})
 
 	// Phase 3: Export - write to HTTP response
 	exportStart := time.Now()
-	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
-
-	// Write metrics in Prometheus format
-	for _, metricsGroup := range metricGroups {
-		if len(metricsGroup) == 0 {
-			continue
-		}
-
-		// Get the first metric to determine type
-		firstMetric := metricsGroup[0]
-
-		switch firstMetric.Type {
-		case telemetry.TypeCounter:
-			e.writeCounterMetrics(w, metricsGroup)
-		case telemetry.TypeGauge:
-			e.writeGaugeMetrics(w, metricsGroup)
-		case telemetry.TypeHistogram:
-			e.writeHistogramMetrics(w, metricsGroup)
-		}
+	if openMetrics {
+		w.Header().Set("Content-Type", openMetricsContentType)
+	} else {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	}
+	result := metrics.ResultSuccess
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		fmt.Printf("Error writing metrics: %v\n", err)
+		result = metrics.ResultError
+		telemetry.EmitCounter(metrics.PrometheusExporterRefreshErrorsTotal, 1, map[string]string{metrics.TagPhase: metrics.PhaseExport})
 	}
 	exportDuration := time.Since(exportStart)
 	telemetry.EmitHistogram(metrics.PrometheusExporterRefreshDurationSeconds, exportDuration, map[string]string{metrics.TagPhase: metrics.PhaseExport})
@@ -261,7 +335,26 @@ func (e *PrometheusExporter) metricsHandler(w http.ResponseWriter, r *http.Reque
 	// Emit overall refresh metrics
 	overallDuration := time.Since(overallStart)
 	telemetry.EmitHistogram(metrics.PrometheusExporterRefreshDurationSeconds, overallDuration, map[string]string{metrics.TagPhase: "overall"})
-	telemetry.EmitCounter(metrics.PrometheusExporterRefreshTotal, 1, map[string]string{metrics.TagResult: metrics.ResultSuccess})
+	telemetry.EmitCounter(metrics.PrometheusExporterRefreshTotal, 1, map[string]string{metrics.TagResult: result})
+}
+
+// snapshot returns copies of all series sorted by name, type, and labels
+func (e *PrometheusExporter) snapshot() []promSeries {
+	e.mu.RLock()
+	result := make([]promSeries, 0, len(e.series))
+	for _, s := range e.series {
+		c := *s
+		c.elem = nil
+		c.histogram.Buckets = append([]telemetry.HistogramBucket(nil), s.histogram.Buckets...)
+		c.exemplars = append([]*telemetry.Exemplar(nil), s.exemplars...)
+		result = append(result, c)
+	}
+	e.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].key < result[j].key
+	})
+	return result
 }
 
 // formatPrometheusName converts metric name to Prometheus format
@@ -314,160 +407,188 @@ func (e *PrometheusExporter) formatPrometheusLabelsWithAdditional(tags map[strin
 	return e.formatPrometheusLabels(allTags)
 }
 
-// getMetricType determines the metric type from the event
-func (e *PrometheusExporter) getMetricType(event telemetry.MetricsEvent) string {
-	return string(event.Type)
+// openMetricsContentType is the Content-Type of the OpenMetrics exposition
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// acceptsOpenMetrics reports whether an Accept header asks for OpenMetrics
+func acceptsOpenMetrics(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, _ := strings.Cut(mediaRange, ";")
+		if strings.EqualFold(strings.TrimSpace(mediaType), "application/openmetrics-text") {
+			return true
+		}
+	}
+	return false
 }
 
-// writeCounterMetrics writes counter metrics in Prometheus format
-func (e *PrometheusExporter) writeCounterMetrics(w io.Writer, metrics []telemetry.MetricsEvent) {
-	for _, metric := range metrics {
-		name := e.formatPrometheusName(metric.Name)
-		labels := e.formatPrometheusLabels(metric.Tags)
-		value := e.extractMetricValue(metric.Value)
+// writeSeries writes series in Prometheus text format, with one # TYPE line
+// per metric family. In OpenMetrics format, counter samples carry the _total
+// suffix, samples carry their exemplars, and the exposition ends with # EOF.
+func (e *PrometheusExporter) writeSeries(w io.Writer, series []promSeries, openMetrics bool) {
+	lastFamily := ""
+	for _, s := range series {
+		name := e.formatPrometheusName(s.name)
+		familyName := name
+		if openMetrics && s.typ == telemetry.TypeCounter {
+			familyName = strings.TrimSuffix(name, "_total")
+		}
+		if family := familyName + " " + string(s.typ); family != lastFamily {
+			fmt.Fprintf(w, "# TYPE %s %s\n", familyName, s.typ)
+			lastFamily = family
+		}
 
-		if labels != "" {
-			if _, err := fmt.Fprintf(w, "%s{%s} %f\n", name, labels, value); err != nil {
-				fmt.Printf("Error writing counter metric: %v\n", err)
-				return
-			}
-		} else {
-			if _, err := fmt.Fprintf(w, "%s %f\n", name, value); err != nil {
-				fmt.Printf("Error writing counter metric: %v\n", err)
-				return
+		switch s.typ {
+		case telemetry.TypeCounter:
+			if openMetrics {
+				writeSample(w, familyName+"_total", e.formatPrometheusLabels(s.tags), formatValue(s.value), e.formatExemplar(s.exemplar, 1))
+				continue
 			}
+			writeSample(w, name, e.formatPrometheusLabels(s.tags), formatValue(s.value), "")
+		case telemetry.TypeGauge:
+			writeSample(w, name, e.formatPrometheusLabels(s.tags), formatValue(s.value), "")
+		case telemetry.TypeHistogram:
+			e.writeHistogram(w, s, openMetrics)
 		}
 	}
+	if openMetrics {
+		fmt.Fprint(w, "# EOF\n")
+	}
+}
+
+// formatExemplar renders an OpenMetrics exemplar suffix, dividing the value by scale
+func (e *PrometheusExporter) formatExemplar(exemplar *telemetry.Exemplar, scale float64) string {
+	if exemplar == nil {
+		return ""
+	}
+	timestamp := strconv.FormatFloat(float64(exemplar.Timestamp.UnixMilli())/1000, 'f', -1, 64)
+	return fmt.Sprintf(" # {%s} %s %s", e.formatPrometheusLabels(exemplar.Labels), formatValue(exemplar.Value/scale), timestamp)
 }
 
-// writeGaugeMetrics writes gauge metrics in Prometheus format
-func (e *PrometheusExporter) writeGaugeMetrics(w io.Writer, metrics []telemetry.MetricsEvent) {
-	for _, metric := range metrics {
-		name := e.formatPrometheusName(metric.Name)
-		labels := e.formatPrometheusLabels(metric.Tags)
-		value := e.extractMetricValue(metric.Value)
+// writeHistogram writes the _bucket, _sum, and _count series of a histogram
+func (e *PrometheusExporter) writeHistogram(w io.Writer, s promSeries, openMetrics bool) {
+	// Prometheus expects seconds for duration metrics, but ADR-0007 uses milliseconds
+	// Convert if metric ends with _ms or _seconds
+	scale := 1.0
+	if strings.HasSuffix(s.name, "_ms") || strings.HasSuffix(s.name, "_seconds") {
+		scale = 1000.0
+	}
 
-		if labels != "" {
-			if _, err := fmt.Fprintf(w, "%s{%s} %f\n", name, labels, value); err != nil {
-				fmt.Printf("Error writing gauge metric: %v\n", err)
-				return
-			}
-		} else {
-			if _, err := fmt.Fprintf(w, "%s %f\n", name, value); err != nil {
-				fmt.Printf("Error writing gauge metric: %v\n", err)
-				return
-			}
+	bucketName := e.formatPrometheusName(s.name + "_bucket")
+	for i, bucket := range s.histogram.Buckets {
+		labels := e.formatPrometheusLabelsWithAdditional(s.tags, "le", formatValue(bucket.LE/scale))
+		exemplar := ""
+		if openMetrics && i < len(s.exemplars) {
+			exemplar = e.formatExemplar(s.exemplars[i], scale)
 		}
+		writeSample(w, bucketName, labels, strconv.FormatInt(bucket.Count, 10), exemplar)
 	}
+
+	labels := e.formatPrometheusLabels(s.tags)
+	writeSample(w, e.formatPrometheusName(s.name+"_sum"), labels, formatValue(s.histogram.Sum/scale), "")
+	writeSample(w, e.formatPrometheusName(s.name+"_count"), labels, strconv.FormatInt(s.histogram.Count, 10), "")
 }
 
-// writeHistogramMetrics writes histogram metrics in Prometheus format with bucket conversion
-func (e *PrometheusExporter) writeHistogramMetrics(w io.Writer, metrics []telemetry.MetricsEvent) {
-	for _, metric := range metrics {
-		switch v := metric.Value.(type) {
-		case telemetry.HistogramSummary:
-			// Prometheus expects seconds for duration metrics, but ADR-0007 uses milliseconds
-			// Convert if metric ends with _ms or _seconds
-			convertToSeconds := strings.HasSuffix(metric.Name, "_ms") || strings.HasSuffix(metric.Name, "_seconds")
-
-			// Write bucket series
-			for _, bucket := range v.Buckets {
-				bucketLE := bucket.LE
-				if convertToSeconds {
-					// Convert milliseconds to seconds
-					bucketLE = bucketLE / 1000.0
-				}
-				bucketLabels := e.formatPrometheusLabelsWithAdditional(metric.Tags, "le", fmt.Sprintf("%g", bucketLE))
-				name := e.formatPrometheusName(metric.Name + "_bucket")
-				if bucketLabels != "" {
-					if _, err := fmt.Fprintf(w, "%s{%s} %d\n", name, bucketLabels, bucket.Count); err != nil {
-						fmt.Printf("Error writing histogram bucket: %v\n", err)
-						return
-					}
-				} else {
-					if _, err := fmt.Fprintf(w, "%s %d\n", name, bucket.Count); err != nil {
-						fmt.Printf("Error writing histogram bucket: %v\n", err)
-						return
-					}
-				}
-			}
+// writeSample writes one sample line, followed by an optional exemplar suffix
+func writeSample(w io.Writer, name, labels, value, exemplar string) {
+	if labels != "" {
+		fmt.Fprintf(w, "%s{%s} %s%s\n", name, labels, value, exemplar)
+		return
+	}
+	fmt.Fprintf(w, "%s %s%s\n", name, value, exemplar)
+}
 
-			// Write sum and count (also convert sum if needed)
-			sum := v.Sum
-			if convertToSeconds {
-				sum = sum / 1000.0
-			}
+// formatValue formats a sample value or bucket bound in Prometheus text format
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
 
-			sumName := e.formatPrometheusName(metric.Name + "_sum")
-			countName := e.formatPrometheusName(metric.Name + "_count")
-			labels := e.formatPrometheusLabels(metric.Tags)
-
-			if labels != "" {
-				if _, err := fmt.Fprintf(w, "%s{%s} %f\n", sumName, labels, sum); err != nil {
-					fmt.Printf("Error writing histogram sum: %v\n", err)
-					return
-				}
-				if _, err := fmt.Fprintf(w, "%s{%s} %d\n", countName, labels, v.Count); err != nil {
-					fmt.Printf("Error writing histogram count: %v\n", err)
-					return
-				}
-			} else {
-				if _, err := fmt.Fprintf(w, "%s %f\n", sumName, sum); err != nil {
-					fmt.Printf("Error writing histogram sum: %v\n", err)
-					return
-				}
-				if _, err := fmt.Fprintf(w, "%s %d\n", countName, v.Count); err != nil {
-					fmt.Printf("Error writing histogram count: %v\n", err)
-					return
-				}
-			}
-		case float64:
-			// Single histogram value - convert if needed
-			value := v
-			if strings.HasSuffix(metric.Name, "_ms") || strings.HasSuffix(metric.Name, "_seconds") {
-				value = value / 1000.0
-			}
+// seriesKey identifies a series by type, name, and sorted labels
+func seriesKey(typ telemetry.MetricType, name string, tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
 
-			name := e.formatPrometheusName(metric.Name)
-			labels := e.formatPrometheusLabels(metric.Tags)
-			if labels != "" {
-				if _, err := fmt.Fprintf(w, "%s{%s} %f\n", name, labels, value); err != nil {
-					fmt.Printf("Error writing histogram value: %v\n", err)
-					return
-				}
-			} else {
-				if _, err := fmt.Fprintf(w, "%s %f\n", name, value); err != nil {
-					fmt.Printf("Error writing histogram value: %v\n", err)
-					return
-				}
-			}
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte(0)
+	b.WriteString(string(typ))
+	for _, k := range keys {
+		b.WriteByte(0)
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(tags[k])
+	}
+	return b.String()
+}
+
+// copyLabels copies tags so later caller mutations do not affect the series
+func copyLabels(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(tags))
+	for k, v := range tags {
+		result[k] = v
+	}
+	return result
+}
+
+// newBuckets returns empty cumulative buckets for bounds plus +Inf
+func newBuckets(bounds []float64) []telemetry.HistogramBucket {
+	buckets := make([]telemetry.HistogramBucket, 0, len(bounds)+1)
+	for _, le := range bounds {
+		if !math.IsInf(le, 1) {
+			buckets = append(buckets, telemetry.HistogramBucket{LE: le})
 		}
 	}
+	return append(buckets, telemetry.HistogramBucket{LE: math.Inf(1)})
 }
 
-// extractMetricValue extracts the numeric value from a metric event
-func (e *PrometheusExporter) extractMetricValue(value interface{}) float64 {
-	switch v := value.(type) {
-	case float64:
-		return v
-	case int:
-		return float64(v)
-	case int64:
-		return float64(v)
-	case telemetry.HistogramSummary:
-		// For histograms, export the sum as the main metric
-		return v.Sum
-	default:
-		return 0.0
+// mergeSummary adds in to state. A new state takes the bucket layout of in.
+// For a different layout, each state bucket receives the cumulative count of
+// the largest bucket of in that fits within it (a lower bound).
+func mergeSummary(state *telemetry.HistogramSummary, in telemetry.HistogramSummary) {
+	if len(state.Buckets) == 0 {
+		bounds := make([]float64, len(in.Buckets))
+		for i, b := range in.Buckets {
+			bounds[i] = b.LE
+		}
+		sort.Float64s(bounds)
+		state.Buckets = newBuckets(bounds)
+	}
+
+	for i := range state.Buckets {
+		le := state.Buckets[i].LE
+		if math.IsInf(le, 1) {
+			state.Buckets[i].Count += in.Count
+			continue
+		}
+		var count int64
+		best := math.Inf(-1)
+		for _, b := range in.Buckets {
+			if b.LE <= le && b.LE > best {
+				best, count = b.LE, b.Count
+			}
+		}
+		state.Buckets[i].Count += count
 	}
+	state.Count += in.Count
+	state.Sum += in.Sum
 }
 
-// WriteMetrics writes current metrics to a writer (for debugging)
+// WriteMetrics writes the current series as JSON lines (for debugging)
 func (e *PrometheusExporter) WriteMetrics(w io.Writer) error {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
-
-	for _, metric := range e.metrics {
+	for _, metric := range e.GetMetrics() {
 		jsonData, err := json.Marshal(metric)
 		if err != nil {
 			return err
@@ -482,19 +603,33 @@ func (e *PrometheusExporter) WriteMetrics(w io.Writer) error {
 	return nil
 }
 
-// Clear clears all stored metrics
+// Clear removes all retained series
 func (e *PrometheusExporter) Clear() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	e.metrics = e.metrics[:0]
+	e.series = make(map[string]*promSeries)
+	e.lru.Init()
 }
 
-// GetMetrics returns a copy of current metrics (for testing)
+// GetMetrics returns the current state of each series as a MetricsEvent,
+// sorted by name (for testing). Counter values are cumulative totals and
+// histogram values are cumulative HistogramSummary values in milliseconds.
 func (e *PrometheusExporter) GetMetrics() []telemetry.MetricsEvent {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
-
-	result := make([]telemetry.MetricsEvent, len(e.metrics))
-	copy(result, e.metrics)
+	snapshot := e.snapshot()
+	result := make([]telemetry.MetricsEvent, 0, len(snapshot))
+	for _, s := range snapshot {
+		event := telemetry.MetricsEvent{
+			Timestamp: s.updated.UTC().Format(time.RFC3339),
+			Name:      s.name,
+			Type:      s.typ,
+			Value:     s.value,
+			Tags:      copyLabels(s.tags),
+		}
+		if s.typ == telemetry.TypeHistogram {
+			event.Value = s.histogram
+			event.Unit = "ms"
+		}
+		result = append(result, event)
+	}
 	return result
 }
@@ -94,8 +94,11 @@ func TestPrometheusMetricTypeRouting(t *testing.T) {
 	// Verify gauge is formatted correctly
 	assert.Contains(t, output, "test_cpu_usage_percent{host=\"server1\"} 75.5")
 
-	// Verify histogram single value is handled (converted to seconds per Prometheus convention)
-	assert.Contains(t, output, "test_request_duration_ms{endpoint=\"/api\"} 0.05")
+	// Verify histogram single value is bucketed (converted to seconds per Prometheus convention)
+	assert.Contains(t, output, "test_request_duration_ms_bucket{endpoint=\"/api\",le=\"0.01\"} 0")
+	assert.Contains(t, output, "test_request_duration_ms_bucket{endpoint=\"/api\",le=\"0.05\"} 1")
+	assert.Contains(t, output, "test_request_duration_ms_sum{endpoint=\"/api\"} 0.05")
+	assert.Contains(t, output, "test_request_duration_ms_count{endpoint=\"/api\"} 1")
 
 	// Verify histogram summary is formatted correctly with buckets, sum, and count
 	// Note: Buckets are converted from milliseconds to seconds per Prometheus convention
@@ -3,7 +3,9 @@ package exporters
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -26,25 +28,28 @@ func TestPrometheusExporterBasic(t *testing.T) {
 	// Test histogram metric
 	assert.NoError(t, exporter.Histogram("request_duration_ms", 50*time.Millisecond, map[string]string{"endpoint": "/api"}))
 
-	// Verify metrics were stored
+	// Verify series were stored (sorted by name)
 	metrics := exporter.GetMetrics()
-	assert.Len(t, metrics, 3)
-
-	// Check counter metric
-	assert.Equal(t, "requests_total", metrics[0].Name)
-	assert.Equal(t, float64(100), metrics[0].Value)
-	assert.Equal(t, "200", metrics[0].Tags["status"])
+	require.Len(t, metrics, 3)
 
 	// Check gauge metric
-	assert.Equal(t, "cpu_usage_percent", metrics[1].Name)
-	assert.Equal(t, 75.5, metrics[1].Value)
-	assert.Equal(t, "server1", metrics[1].Tags["host"])
+	assert.Equal(t, "cpu_usage_percent", metrics[0].Name)
+	assert.Equal(t, 75.5, metrics[0].Value)
+	assert.Equal(t, "server1", metrics[0].Tags["host"])
 
 	// Check histogram metric
-	assert.Equal(t, "request_duration_ms", metrics[2].Name)
-	assert.Equal(t, float64(50), metrics[2].Value) // Should be converted to milliseconds
-	assert.Equal(t, "ms", metrics[2].Unit)
-	assert.Equal(t, "/api", metrics[2].Tags["endpoint"])
+	assert.Equal(t, "request_duration_ms", metrics[1].Name)
+	summary, ok := metrics[1].Value.(telemetry.HistogramSummary)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), summary.Count)
+	assert.Equal(t, float64(50), summary.Sum) // Should be converted to milliseconds
+	assert.Equal(t, "ms", metrics[1].Unit)
+	assert.Equal(t, "/api", metrics[1].Tags["endpoint"])
+
+	// Check counter metric
+	assert.Equal(t, "requests_total", metrics[2].Name)
+	assert.Equal(t, float64(100), metrics[2].Value)
+	assert.Equal(t, "200", metrics[2].Tags["status"])
 }
 
 // TestPrometheusExporterFormat tests Prometheus format output
@@ -63,10 +68,6 @@ func TestPrometheusExporterFormat(t *testing.T) {
 	assert.Contains(t, labels, `status="200"`)
 	assert.Contains(t, labels, `method="GET"`)
 	assert.Equal(t, "", exporter.formatPrometheusLabels(nil))
-
-	// Test value extraction
-	assert.Equal(t, 1000.0, exporter.extractMetricValue(float64(1000)))
-	assert.Equal(t, 100.0, exporter.extractMetricValue(float64(100)))
 }
 
 // TestPrometheusExporterHTTP tests the HTTP server functionality
@@ -186,7 +187,143 @@ func TestPrometheusExporterHistogramSummary(t *testing.T) {
 	assert.Equal(t, "request_duration", metrics[0].Name)
 	assert.Equal(t, "ms", metrics[0].Unit)
 
-	// Check that histogram summary value is extracted correctly
-	extracted := exporter.extractMetricValue(metrics[0].Value)
-	assert.Equal(t, 5000.0, extracted) // Should be the sum
+	// Check that the histogram summary is stored as given
+	stored, ok := metrics[0].Value.(telemetry.HistogramSummary)
+	require.True(t, ok)
+	assert.Equal(t, 5000.0, stored.Sum)
+}
+
+// TestPrometheusExporterCounterAccumulates tests monotonic counter totals per series
+func TestPrometheusExporterCounterAccumulates(t *testing.T) {
+	exporter := NewPrometheusExporter("app", ":0")
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, exporter.Counter("requests_total", 2, map[string]string{"status": "200"}))
+	}
+	require.NoError(t, exporter.Counter("requests_total", 1, map[string]string{"status": "500"}))
+	assert.Error(t, exporter.Counter("requests_total", -1, nil), "counters are monotonic")
+
+	require.NoError(t, exporter.Gauge("queue_depth", 5, nil))
+	require.NoError(t, exporter.Gauge("queue_depth", 3, nil))
+
+	writer := newMockResponseWriter()
+	exporter.metricsHandler(writer, nil)
+	output := writer.String()
+
+	assert.Contains(t, output, "# TYPE app_requests_total counter\n")
+	assert.Equal(t, 1, strings.Count(output, "# TYPE app_requests_total"), "one TYPE line per family")
+	assert.Contains(t, output, "app_requests_total{status=\"200\"} 6\n")
+	assert.Contains(t, output, "app_requests_total{status=\"500\"} 1\n")
+	assert.Contains(t, output, "app_queue_depth 3\n")
+	assert.Equal(t, 3, exporter.SeriesCount())
+
+	// Scraping again reports the same totals
+	writer = newMockResponseWriter()
+	exporter.metricsHandler(writer, nil)
+	assert.Equal(t, output, writer.String())
+}
+
+// TestPrometheusExporterOpenMetricsExemplars tests exemplars in the OpenMetrics exposition
+func TestPrometheusExporterOpenMetricsExemplars(t *testing.T) {
+	exporter := NewPrometheusExporter("app", ":0")
+	at := time.UnixMilli(1700000000500)
+	tags := map[string]string{"route": "/users"}
+
+	require.NoError(t, exporter.Counter("requests_total", 2, tags))
+	require.NoError(t, exporter.RecordExemplar("requests_total", tags, telemetry.Exemplar{
+		Labels: map[string]string{"correlation_id": "corr-1"}, Value: 2, Timestamp: at,
+	}))
+	require.NoError(t, exporter.Histogram("latency_ms", 3*time.Millisecond, tags))
+	require.NoError(t, exporter.RecordExemplar("latency_ms", tags, telemetry.Exemplar{
+		Labels: map[string]string{"correlation_id": "corr-2"}, Value: 3, Timestamp: at,
+	}))
+	// Exemplars for unknown series are ignored
+	require.NoError(t, exporter.RecordExemplar("missing_total", nil, telemetry.Exemplar{Value: 1, Timestamp: at}))
+
+	request, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+	require.NoError(t, err)
+	request.Header.Set("Accept", "application/openmetrics-text; version=1.0.0, text/plain;q=0.5")
+	writer := newMockResponseWriter()
+	exporter.metricsHandler(writer, request)
+	output := writer.String()
+
+	assert.Equal(t, "application/openmetrics-text; version=1.0.0; charset=utf-8", writer.Header().Get("Content-Type"))
+	assert.Contains(t, output, "# TYPE app_requests counter\n")
+	assert.Contains(t, output, "app_requests_total{route=\"/users\"} 2 # {correlation_id=\"corr-1\"} 2 1700000000.5\n")
+	assert.Contains(t, output, "app_latency_ms_bucket{le=\"0.005\",route=\"/users\"} 1 # {correlation_id=\"corr-2\"} 0.003 1700000000.5\n")
+	assert.Contains(t, output, "app_latency_ms_bucket{le=\"0.01\",route=\"/users\"} 1\n")
+	assert.True(t, strings.HasSuffix(output, "# EOF\n"))
+
+	// The Prometheus text format has no exemplars
+	writer = newMockResponseWriter()
+	exporter.metricsHandler(writer, nil)
+	assert.Contains(t, writer.String(), "app_requests_total{route=\"/users\"} 2\n")
+	assert.NotContains(t, writer.String(), "corr-")
+}
+
+// TestPrometheusExporterHistogramMerge tests cumulative histogram state across observations
+func TestPrometheusExporterHistogramMerge(t *testing.T) {
+	exporter := NewPrometheusExporter("app", ":0")
+	tags := map[string]string{"route": "/users"}
+
+	require.NoError(t, exporter.Histogram("latency_ms", 3*time.Millisecond, tags))
+	require.NoError(t, exporter.Histogram("latency_ms", 80*time.Millisecond, tags))
+	require.NoError(t, exporter.HistogramSummary("latency_ms", telemetry.HistogramSummary{
+		Count: 2,
+		Sum:   20,
+		Buckets: []telemetry.HistogramBucket{
+			{LE: 5, Count: 1},
+			{LE: 100, Count: 2},
+			{LE: math.Inf(1), Count: 2},
+		},
+	}, tags))
+
+	metrics := exporter.GetMetrics()
+	require.Len(t, metrics, 1)
+	summary := metrics[0].Value.(telemetry.HistogramSummary)
+	assert.Equal(t, int64(4), summary.Count)
+	assert.Equal(t, 103.0, summary.Sum)
+
+	counts := make(map[float64]int64)
+	for _, b := range summary.Buckets {
+		counts[b.LE] = b.Count
+	}
+	assert.Equal(t, int64(2), counts[5])   // 3ms + one from the summary
+	assert.Equal(t, int64(2), counts[50])  // the summary's le=50 lower bound is its le=5 bucket
+	assert.Equal(t, int64(4), counts[100]) // 3ms, 80ms, and both summary observations
+	assert.Equal(t, int64(4), counts[math.Inf(1)])
+
+	writer := newMockResponseWriter()
+	exporter.metricsHandler(writer, nil)
+	output := writer.String()
+	assert.Contains(t, output, "# TYPE app_latency_ms histogram\n")
+	assert.Contains(t, output, "app_latency_ms_bucket{le=\"0.1\",route=\"/users\"} 4\n")
+	assert.Contains(t, output, "app_latency_ms_bucket{le=\"+Inf\",route=\"/users\"} 4\n")
+	assert.Contains(t, output, "app_latency_ms_sum{route=\"/users\"} 0.103\n")
+	assert.Contains(t, output, "app_latency_ms_count{route=\"/users\"} 4\n")
+}
+
+// TestPrometheusExporterSeriesCap tests least recently updated eviction at MaxSeries
+func TestPrometheusExporterSeriesCap(t *testing.T) {
+	config := DefaultPrometheusConfig()
+	config.MaxSeries = 2
+	exporter := NewPrometheusExporterWithConfig(config)
+
+	require.NoError(t, exporter.Counter("a_total", 1, nil))
+	require.NoError(t, exporter.Counter("b_total", 1, nil))
+	require.NoError(t, exporter.Counter("a_total", 1, nil)) // a is now most recent
+	require.NoError(t, exporter.Counter("c_total", 1, nil)) // evicts b
+
+	assert.Equal(t, 2, exporter.SeriesCount())
+	assert.Equal(t, int64(1), exporter.EvictedSeries())
+
+	names := make([]string, 0, 2)
+	for _, m := range exporter.GetMetrics() {
+		names = append(names, m.Name)
+	}
+	assert.Equal(t, []string{"a_total", "c_total"}, names)
+	assert.Equal(t, float64(2), exporter.GetMetrics()[0].Value)
+
+	exporter.Clear()
+	assert.Equal(t, 0, exporter.SeriesCount())
 }
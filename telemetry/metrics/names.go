@@ -1,5 +1,7 @@
 package metrics
 
+import "sort"
+
 // Core metrics from Crucible taxonomy
 const (
 	SchemaValidations          = "schema_validations"
@@ -26,6 +28,8 @@ const (
 	PrometheusExporterHTTPRequestsTotal      = "prometheus_exporter_http_requests_total"
 	PrometheusExporterHTTPErrorsTotal        = "prometheus_exporter_http_errors_total"
 	PrometheusExporterRestartsTotal          = "prometheus_exporter_restarts_total"
+	PrometheusExporterSeries                 = "prometheus_exporter_series"
+	PrometheusExporterSeriesEvictedTotal     = "prometheus_exporter_series_evicted_total"
 )
 
 // Foundry Module Metrics (MIME detection)
@@ -63,6 +67,23 @@ const (
 	FulpackErrorsTotal         = "fulpack_errors_total"
 )
 
+// Telemetry Self-Monitoring Metrics
+const (
+	TelemetrySelfPrefix            = "telemetry_self_"
+	TelemetryCardinalityViolations = "telemetry_self_cardinality_violations"
+	TelemetryTaxonomyViolations    = "telemetry_self_taxonomy_violations"
+)
+
+// Signals Module Metrics
+const (
+	SignalsHandledTotal      = "signals_handled_total"
+	SignalsReceivedTotal     = "signals_received_total"
+	SignalsDrainStartedTotal = "signals_drain_started_total"
+	SignalsShutdownMs        = "signals_shutdown_ms"
+	SignalsShutdownHandlerMs = "signals_shutdown_handler_ms"
+	SignalsForcedQuitTotal   = "signals_forced_quit_total"
+)
+
 // HTTP Server Metrics (Crucible v0.2.18 taxonomy)
 const (
 	HTTPRequestsTotal          = "http_requests_total"
@@ -72,6 +93,43 @@ const (
 	HTTPActiveRequests         = "http_active_requests"
 )
 
+// libraryMetrics lists every metric name above.
+var libraryMetrics = map[string]bool{}
+
+func init() {
+	for _, name := range []string{
+		SchemaValidations, SchemaValidationErrors, ConfigLoadMs, ConfigLoadErrors, PathfinderFindMs, PathfinderValidationErrors, PathfinderSecurityWarnings, FoundryLookupCount, LoggingEmitCount, LoggingEmitLatencyMs, GoneatCommandDurationMs, FulHashHashCount, FulHashErrorsCount,
+		PrometheusExporterRefreshDurationSeconds, PrometheusExporterRefreshTotal, PrometheusExporterRefreshErrorsTotal, PrometheusExporterRefreshInflight, PrometheusExporterHTTPRequestsTotal, PrometheusExporterHTTPErrorsTotal, PrometheusExporterRestartsTotal, PrometheusExporterSeries, PrometheusExporterSeriesEvictedTotal,
+		FoundryMimeDetectionsTotalJSON, FoundryMimeDetectionsTotalXML, FoundryMimeDetectionsTotalYAML, FoundryMimeDetectionsTotalCSV, FoundryMimeDetectionsTotalPlainText, FoundryMimeDetectionsTotalUnknown, FoundryMimeDetectionMs,
+		ErrorHandlingWrapsTotal, ErrorHandlingWrapMs,
+		FulHashOperationsTotalXXH3128, FulHashOperationsTotalSHA256, FulHashHashStringTotal, FulHashBytesHashedTotal, FulHashOperationMs,
+		FulpackOperationsTotal, FulpackOperationMs, FulpackBytesProcessedTotal, FulpackEntriesTotal, FulpackErrorsTotal,
+		TelemetryCardinalityViolations, TelemetryTaxonomyViolations,
+		SignalsHandledTotal, SignalsReceivedTotal, SignalsDrainStartedTotal, SignalsShutdownMs, SignalsShutdownHandlerMs, SignalsForcedQuitTotal,
+		HTTPRequestsTotal, HTTPRequestDurationSeconds, HTTPRequestSizeBytes, HTTPResponseSizeBytes, HTTPActiveRequests,
+	} {
+		libraryMetrics[name] = true
+	}
+}
+
+// IsLibraryMetric reports whether name is one of the metrics emitted by
+// gofulmen modules. Some are not in the Crucible taxonomy yet; the telemetry
+// taxonomy check accepts them as known.
+func IsLibraryMetric(name string) bool {
+	return libraryMetrics[name]
+}
+
+// LibraryMetrics returns the names of the metrics emitted by gofulmen
+// modules, sorted.
+func LibraryMetrics() []string {
+	names := make([]string, 0, len(libraryMetrics))
+	for name := range libraryMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Metric units
 const (
 	UnitCount   = "count"
@@ -83,27 +141,32 @@ const (
 
 // Standard tag keys
 const (
-	TagStatus    = "status"
-	TagComponent = "component"
-	TagOperation = "operation"
-	TagCategory  = "category"
-	TagVersion   = "version"
-	TagSeverity  = "severity"
-	TagLayer     = "layer"
-	TagRoot      = "root"
-	TagEndpoint  = "endpoint"
-	TagHost      = "host"
-	TagAlgorithm = "algorithm"
-	TagErrorType = "error_type"
-	TagPhase     = "phase"
-	TagResult    = "result"
-	TagReason    = "reason"
-	TagPath      = "path"
-	TagClient    = "client"
-	TagMimeType  = "mime_type"
-	TagMethod    = "method"
-	TagRoute     = "route"
-	TagService   = "service"
+	TagStatus        = "status"
+	TagComponent     = "component"
+	TagOperation     = "operation"
+	TagCategory      = "category"
+	TagVersion       = "version"
+	TagSeverity      = "severity"
+	TagLayer         = "layer"
+	TagRoot          = "root"
+	TagEndpoint      = "endpoint"
+	TagHost          = "host"
+	TagAlgorithm     = "algorithm"
+	TagErrorType     = "error_type"
+	TagPhase         = "phase"
+	TagResult        = "result"
+	TagReason        = "reason"
+	TagPath          = "path"
+	TagClient        = "client"
+	TagMimeType      = "mime_type"
+	TagMethod        = "method"
+	TagRoute         = "route"
+	TagService       = "service"
+	TagEventID       = "event_id"
+	TagSignal        = "signal"
+	TagSource        = "source"
+	TagHandler       = "handler"
+	TagCorrelationID = "correlation_id"
 )
 
 // Standard tag values
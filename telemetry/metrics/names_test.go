@@ -1,6 +1,11 @@
 package metrics_test
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -21,6 +26,8 @@ func TestPrometheusExporterMetricNames(t *testing.T) {
 		{"http requests", metrics.PrometheusExporterHTTPRequestsTotal, metrics.UnitCount},
 		{"http errors", metrics.PrometheusExporterHTTPErrorsTotal, metrics.UnitCount},
 		{"restarts", metrics.PrometheusExporterRestartsTotal, metrics.UnitCount},
+		{"series", metrics.PrometheusExporterSeries, metrics.UnitCount},
+		{"series evicted", metrics.PrometheusExporterSeriesEvictedTotal, metrics.UnitCount},
 	}
 
 	for _, tt := range tests {
@@ -36,7 +43,7 @@ func TestPrometheusExporterMetricNames(t *testing.T) {
 			}
 
 			// Verify counter metrics end with _total
-			if tt.wantUnit == metrics.UnitCount && tt.metric != metrics.PrometheusExporterRefreshInflight {
+			if tt.wantUnit == metrics.UnitCount && tt.metric != metrics.PrometheusExporterRefreshInflight && tt.metric != metrics.PrometheusExporterSeries {
 				if !strings.HasSuffix(tt.metric, "_total") && !strings.HasSuffix(tt.metric, "_inflight") {
 					t.Errorf("counter metric %q should end with _total or _inflight", tt.metric)
 				}
@@ -127,16 +134,21 @@ func TestFulHashMetricNames(t *testing.T) {
 // TestLabelConstants verifies label key constants
 func TestLabelConstants(t *testing.T) {
 	labels := map[string]string{
-		"status":     metrics.TagStatus,
-		"component":  metrics.TagComponent,
-		"operation":  metrics.TagOperation,
-		"phase":      metrics.TagPhase,
-		"result":     metrics.TagResult,
-		"error_type": metrics.TagErrorType,
-		"reason":     metrics.TagReason,
-		"path":       metrics.TagPath,
-		"client":     metrics.TagClient,
-		"mime_type":  metrics.TagMimeType,
+		"status":         metrics.TagStatus,
+		"component":      metrics.TagComponent,
+		"operation":      metrics.TagOperation,
+		"phase":          metrics.TagPhase,
+		"result":         metrics.TagResult,
+		"error_type":     metrics.TagErrorType,
+		"reason":         metrics.TagReason,
+		"path":           metrics.TagPath,
+		"client":         metrics.TagClient,
+		"mime_type":      metrics.TagMimeType,
+		"event_id":       metrics.TagEventID,
+		"signal":         metrics.TagSignal,
+		"source":         metrics.TagSource,
+		"handler":        metrics.TagHandler,
+		"correlation_id": metrics.TagCorrelationID,
 	}
 
 	for expected, actual := range labels {
@@ -204,3 +216,41 @@ func TestRestartReasonValues(t *testing.T) {
 		}
 	}
 }
+
+// TestLibraryMetricsComplete ensures every metric name constant is listed in LibraryMetrics
+func TestLibraryMetricsComplete(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "names.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonMetric := []string{"Tag", "Unit", "Status", "Phase", "Result", "ErrorType", "RestartReason", "TelemetrySelfPrefix"}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+	specs:
+		for _, spec := range gen.Specs {
+			for i, ident := range spec.(*ast.ValueSpec).Names {
+				for _, prefix := range nonMetric {
+					if strings.HasPrefix(ident.Name, prefix) {
+						continue specs
+					}
+				}
+				lit, ok := spec.(*ast.ValueSpec).Values[i].(*ast.BasicLit)
+				if !ok {
+					continue
+				}
+				name, _ := strconv.Unquote(lit.Value)
+				if !metrics.IsLibraryMetric(name) {
+					t.Errorf("%s (%q) is not listed in LibraryMetrics", ident.Name, name)
+				}
+			}
+		}
+	}
+
+	names := metrics.LibraryMetrics()
+	if !sort.StringsAreSorted(names) {
+		t.Error("LibraryMetrics should be sorted")
+	}
+}
@@ -0,0 +1,184 @@
+package telemetry
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// SamplingPolicy reduces the event cost of a high-frequency counter or
+// histogram. Emitted samples carry the weight of the events they represent:
+// counter increments are multiplied by it, and histogram events carry it in
+// MetricsEvent.Weight with their observations unscaled, so totals remain
+// unbiased.
+type SamplingPolicy struct {
+	// Rate is the probability of keeping an event, in (0, 1]. Each kept event
+	// has weight 1/Rate. Zero means 1 (no probabilistic sampling).
+	Rate float64 `json:"rate,omitempty"`
+
+	// MaxPerSecond caps emitted events per second for the metric (0 = no cap).
+	// Events over the cap are dropped, and their weight is carried by the next
+	// emitted event.
+	MaxPerSecond float64 `json:"maxPerSecond,omitempty"`
+
+	// Burst is the number of events allowed above MaxPerSecond in a burst.
+	// Default: max(1, MaxPerSecond).
+	Burst int `json:"burst,omitempty"`
+}
+
+// samplerState is the rate-limiter state of one sampled metric.
+type samplerState struct {
+	tokens  float64
+	last    time.Time
+	pending float64 // weight of rate-limited events not yet emitted
+}
+
+// samplingPolicy returns the policy for name: an exact Config.Sampling key, or
+// else the longest matching "prefix*" key.
+func (s *System) samplingPolicy(name string) (SamplingPolicy, bool) {
+	if len(s.config.Sampling) == 0 {
+		return SamplingPolicy{}, false
+	}
+	if policy, ok := s.config.Sampling[name]; ok {
+		return policy, true
+	}
+
+	var best string
+	var policy SamplingPolicy
+	found := false
+	for key, p := range s.config.Sampling {
+		prefix, ok := strings.CutSuffix(key, "*")
+		if ok && strings.HasPrefix(name, prefix) && (!found || len(prefix) > len(best)) {
+			best, policy, found = prefix, p, true
+		}
+	}
+	return policy, found
+}
+
+// sample decides whether an event for name is emitted and returns its weight.
+func (s *System) sample(name string) (float64, bool) {
+	policy, ok := s.samplingPolicy(name)
+	if !ok {
+		return 1, true
+	}
+
+	weight := 1.0
+	if policy.Rate > 0 && policy.Rate < 1 {
+		if s.randFloat() >= policy.Rate {
+			s.countSampledOut()
+			return 0, false
+		}
+		weight = 1 / policy.Rate
+	}
+	if policy.MaxPerSecond <= 0 {
+		return weight, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	burst := float64(policy.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, policy.MaxPerSecond)
+	}
+	if s.samplers == nil {
+		s.samplers = make(map[string]*samplerState)
+	}
+	now := s.now()
+	state, ok := s.samplers[name]
+	if !ok {
+		state = &samplerState{tokens: burst, last: now}
+		s.samplers[name] = state
+	}
+	state.tokens = math.Min(burst, state.tokens+now.Sub(state.last).Seconds()*policy.MaxPerSecond)
+	state.last = now
+
+	if state.tokens < 1 {
+		state.pending += weight
+		s.sampledOut++
+		return 0, false
+	}
+	state.tokens--
+	weight += state.pending
+	state.pending = 0
+	return weight, true
+}
+
+// randFloat returns a uniform value in [0, 1).
+func (s *System) randFloat() float64 {
+	if s.rand != nil {
+		return s.rand()
+	}
+	return rand.Float64() // #nosec G404 -- sampling does not need cryptographic randomness
+}
+
+// countSampledOut records an event dropped by sampling.
+func (s *System) countSampledOut() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sampledOut++
+}
+
+// SampledOutCount returns the number of events dropped by sampling policies.
+func (s *System) SampledOutCount() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sampledOut
+}
+
+// WeightedHistogramEmitter is implemented by emitters that record sampled
+// histogram observations with their weight. Sampled observations sent to
+// emitters without it arrive as a HistogramSummary scaled to whole counts.
+type WeightedHistogramEmitter interface {
+	WeightedHistogramSummary(name string, summary HistogramSummary, weight float64, tags map[string]string) error
+}
+
+// eventWeight returns the MetricsEvent.Weight for a sample weight, leaving the
+// default weight of 1 unset.
+func eventWeight(weight float64) float64 {
+	if weight == 1 {
+		return 0
+	}
+	return weight
+}
+
+// emitWeightedHistogram passes a sampled histogram event to a custom emitter.
+// Single observations are passed as one-observation summaries.
+func emitWeightedHistogram(emitter MetricsEmitter, event MetricsEvent) error {
+	var summary HistogramSummary
+	switch v := event.Value.(type) {
+	case float64:
+		summary = HistogramSummary{Count: 1, Sum: v, Buckets: []HistogramBucket{}}
+	case HistogramSummary:
+		summary = v
+	default:
+		return fmt.Errorf("histogram metric value must be float64 or HistogramSummary, got %T", v)
+	}
+	if weighted, ok := emitter.(WeightedHistogramEmitter); ok {
+		return weighted.WeightedHistogramSummary(event.Name, summary, event.Weight, event.Tags)
+	}
+	return emitter.HistogramSummary(event.Name, summary.scaled(event.Weight), event.Tags)
+}
+
+// scaled returns the summary with its count, sum, and buckets multiplied by weight.
+func (h HistogramSummary) scaled(weight float64) HistogramSummary {
+	if weight == 1 {
+		return h
+	}
+	scaled := HistogramSummary{
+		Count:   scaleCount(h.Count, weight),
+		Sum:     h.Sum * weight,
+		Buckets: make([]HistogramBucket, len(h.Buckets)),
+	}
+	for i, b := range h.Buckets {
+		scaled.Buckets[i] = HistogramBucket{LE: b.LE, Count: scaleCount(b.Count, weight)}
+	}
+	return scaled
+}
+
+// scaleCount multiplies a histogram count by weight, rounding to the nearest integer.
+func scaleCount(count int64, weight float64) int64 {
+	return int64(math.Round(float64(count) * weight))
+}
@@ -0,0 +1,230 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// weightRecorder records counter values and histogram summaries by name
+type weightRecorder struct {
+	mu        sync.Mutex
+	counters  map[string][]float64
+	summaries map[string][]HistogramSummary
+	singles   int
+}
+
+func newWeightRecorder() *weightRecorder {
+	return &weightRecorder{
+		counters:  make(map[string][]float64),
+		summaries: make(map[string][]HistogramSummary),
+	}
+}
+
+func (r *weightRecorder) Counter(name string, value float64, tags map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[name] = append(r.counters[name], value)
+	return nil
+}
+
+func (r *weightRecorder) Histogram(name string, duration time.Duration, tags map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.singles++
+	return nil
+}
+
+func (r *weightRecorder) HistogramSummary(name string, summary HistogramSummary, tags map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.summaries[name] = append(r.summaries[name], summary)
+	return nil
+}
+
+func (r *weightRecorder) Gauge(name string, value float64, tags map[string]string) error {
+	return r.Counter(name, value, tags)
+}
+
+func newSamplingSystem(t *testing.T, sampling map[string]SamplingPolicy) (*System, *weightRecorder) {
+	t.Helper()
+	recorder := newWeightRecorder()
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: recorder, Sampling: sampling})
+	require.NoError(t, err)
+	return sys, recorder
+}
+
+// sequence returns a deterministic random source cycling through values
+func sequence(values ...float64) func() float64 {
+	i := 0
+	return func() float64 {
+		v := values[i%len(values)]
+		i++
+		return v
+	}
+}
+
+// TestSampling_Probabilistic verifies kept counters carry weight 1/Rate
+func TestSampling_Probabilistic(t *testing.T) {
+	sys, recorder := newSamplingSystem(t, map[string]SamplingPolicy{
+		"similarity_comparisons_total": {Rate: 0.25},
+	})
+	sys.rand = sequence(0.1, 0.5, 0.9, 0.3)
+
+	for i := 0; i < 8; i++ {
+		require.NoError(t, sys.Counter("similarity_comparisons_total", 1, nil))
+	}
+	require.NoError(t, sys.Counter("unsampled_total", 1, nil))
+
+	assert.Equal(t, []float64{4, 4}, recorder.counters["similarity_comparisons_total"])
+	assert.Equal(t, []float64{1}, recorder.counters["unsampled_total"])
+	assert.Equal(t, int64(6), sys.SampledOutCount())
+}
+
+// TestSampling_RateLimited verifies dropped events' weight moves to the next emitted event
+func TestSampling_RateLimited(t *testing.T) {
+	sys, recorder := newSamplingSystem(t, map[string]SamplingPolicy{
+		"pathfinder_*": {MaxPerSecond: 1, Burst: 2},
+	})
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, sys.Counter("pathfinder_matches_total", 1, nil))
+	}
+	assert.Equal(t, []float64{1, 1}, recorder.counters["pathfinder_matches_total"])
+	assert.Equal(t, int64(3), sys.SampledOutCount())
+
+	// Refill one token; the next event represents the three dropped ones too
+	sys.mu.Lock()
+	sys.samplers["pathfinder_matches_total"].last = time.Now().Add(-time.Second)
+	sys.mu.Unlock()
+	require.NoError(t, sys.Counter("pathfinder_matches_total", 1, nil))
+	assert.Equal(t, []float64{1, 1, 4}, recorder.counters["pathfinder_matches_total"])
+}
+
+// TestSampling_HistogramWeights verifies emitters without WeightedHistogramEmitter
+// receive histogram summaries scaled by weight
+func TestSampling_HistogramWeights(t *testing.T) {
+	sys, recorder := newSamplingSystem(t, map[string]SamplingPolicy{
+		"similarity_*": {Rate: 0.5},
+	})
+	sys.rand = sequence(0.1)
+
+	require.NoError(t, sys.Histogram("similarity_distance_ms", 7*time.Millisecond, nil))
+	require.NoError(t, sys.Histogram("similarity_distance", 3*time.Millisecond, nil))
+
+	ms := recorder.summaries["similarity_distance_ms"]
+	require.Len(t, ms, 1)
+	assert.Equal(t, int64(2), ms[0].Count)
+	assert.Equal(t, 14.0, ms[0].Sum)
+	for _, b := range ms[0].Buckets {
+		assert.Contains(t, []int64{0, 2}, b.Count)
+	}
+
+	// Weighted single observations arrive as summaries
+	single := recorder.summaries["similarity_distance"]
+	require.Len(t, single, 1)
+	assert.Equal(t, int64(2), single[0].Count)
+	assert.InDelta(t, 6.0, single[0].Sum, 1e-9)
+	assert.Equal(t, 0, recorder.singles)
+}
+
+// weightedRecorder records sampled histogram summaries with their weights
+type weightedRecorder struct {
+	*weightRecorder
+	weights map[string][]float64
+}
+
+func (r *weightedRecorder) WeightedHistogramSummary(name string, summary HistogramSummary, weight float64, tags map[string]string) error {
+	r.mu.Lock()
+	r.weights[name] = append(r.weights[name], weight)
+	r.mu.Unlock()
+	return r.HistogramSummary(name, summary, tags)
+}
+
+// TestSampling_WeightedHistogramEmitter verifies weight-aware emitters receive
+// unscaled observations and their exact weight
+func TestSampling_WeightedHistogramEmitter(t *testing.T) {
+	recorder := &weightedRecorder{weightRecorder: newWeightRecorder(), weights: make(map[string][]float64)}
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: recorder, Sampling: map[string]SamplingPolicy{
+		"similarity_*": {Rate: 0.3},
+	}})
+	require.NoError(t, err)
+	sys.rand = sequence(0.1)
+
+	require.NoError(t, sys.Histogram("similarity_distance_ms", 7*time.Millisecond, nil))
+	require.NoError(t, sys.Observe("similarity_size_bytes", 3, "bytes", nil))
+
+	for _, name := range []string{"similarity_distance_ms", "similarity_size_bytes"} {
+		require.Len(t, recorder.summaries[name], 1, name)
+		assert.Equal(t, int64(1), recorder.summaries[name][0].Count, name)
+		assert.InDelta(t, 1/0.3, recorder.weights[name][0], 1e-9, name)
+	}
+}
+
+// TestSampling_EventWeight verifies sampled histogram events keep their shape
+// and carry the weight in a separate field
+func TestSampling_EventWeight(t *testing.T) {
+	sys, err := NewSystem(&Config{Enabled: true, BatchSize: 100, Sampling: map[string]SamplingPolicy{
+		"similarity_*": {Rate: 0.5},
+	}})
+	require.NoError(t, err)
+	sys.rand = sequence(0.1)
+
+	require.NoError(t, sys.Histogram("similarity_distance", 3*time.Millisecond, nil))
+	require.NoError(t, sys.Histogram("unsampled_distance", 3*time.Millisecond, nil))
+
+	sys.mu.Lock()
+	events := append([]MetricsEvent(nil), sys.metricBuffer...)
+	sys.mu.Unlock()
+	require.Len(t, events, 2)
+
+	assert.Equal(t, 3.0, events[0].Value)
+	assert.Equal(t, 2.0, events[0].Weight)
+	data, err := json.Marshal(events[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"value":3,`)
+	assert.Contains(t, string(data), `"weight":2`)
+
+	data, err = json.Marshal(events[1])
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "weight")
+}
+
+// TestSampling_PolicyLookup verifies exact names win over the longest prefix
+func TestSampling_PolicyLookup(t *testing.T) {
+	sys, _ := newSamplingSystem(t, map[string]SamplingPolicy{
+		"a_*":       {Rate: 0.5},
+		"a_b_*":     {Rate: 0.25},
+		"a_b_exact": {Rate: 0.1},
+	})
+
+	policy, ok := sys.samplingPolicy("a_b_exact")
+	require.True(t, ok)
+	assert.Equal(t, 0.1, policy.Rate)
+
+	policy, ok = sys.samplingPolicy("a_b_other")
+	require.True(t, ok)
+	assert.Equal(t, 0.25, policy.Rate)
+
+	policy, ok = sys.samplingPolicy("a_other")
+	require.True(t, ok)
+	assert.Equal(t, 0.5, policy.Rate)
+
+	_, ok = sys.samplingPolicy("b_other")
+	assert.False(t, ok)
+}
+
+// TestSampling_GaugesUnaffected verifies gauges are never sampled
+func TestSampling_GaugesUnaffected(t *testing.T) {
+	sys, recorder := newSamplingSystem(t, map[string]SamplingPolicy{"*": {Rate: 0.01}})
+	sys.rand = sequence(0.99)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, sys.Gauge("queue_depth", 5, nil))
+	}
+	assert.Len(t, recorder.counters["queue_depth"], 3)
+}
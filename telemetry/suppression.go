@@ -0,0 +1,135 @@
+package telemetry
+
+import (
+	"container/list"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultSuppressionMaxSeries is the number of series tracked for zero-value
+// suppression when Config.SuppressionMaxSeries is zero.
+const DefaultSuppressionMaxSeries = 10000
+
+// seriesState tracks the last emitted sample of a metric series (name + tags)
+// for zero-value suppression.
+type seriesState struct {
+	key      string
+	value    float64
+	lastEmit time.Time
+	lastSeen time.Time
+}
+
+// shouldSuppress reports whether a counter or gauge sample should be dropped
+// under Config.SuppressZeroValues, recording the sample when it is emitted.
+//
+// Zero-valued counter increments and gauges that repeat their last emitted
+// value are suppressed, unless the series has not been emitted within
+// Config.HeartbeatInterval, in which case the sample is emitted as a heartbeat.
+// Heartbeats are only checked when a sample arrives; a series that stops
+// reporting entirely sends none.
+//
+// Series are kept in least-recently-seen order and evicted once they exceed
+// Config.SuppressionMaxSeries, or once their heartbeat is due (when they would
+// be emitted anyway). An evicted series' next sample is emitted as its first.
+func (s *System) shouldSuppress(metricType MetricType, name string, value float64, tags map[string]string) bool {
+	if !s.config.SuppressZeroValues {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.series == nil {
+		s.series = make(map[string]*list.Element)
+		s.seriesLRU = list.New()
+	}
+	key := seriesKey(metricType, name, tags)
+	now := s.now()
+	s.evictSeriesLocked(now)
+
+	var state *seriesState
+	elem, seen := s.series[key]
+	if seen {
+		state = elem.Value.(*seriesState)
+		state.lastSeen = now
+		s.seriesLRU.MoveToFront(elem)
+	}
+
+	idle := false
+	switch metricType {
+	case TypeCounter:
+		idle = value == 0
+	case TypeGauge:
+		idle = seen && state.value == value
+	}
+
+	if idle && seen && (s.config.HeartbeatInterval <= 0 || now.Sub(state.lastEmit) < s.config.HeartbeatInterval) {
+		s.suppressed++
+		return true
+	}
+
+	if !seen {
+		state = &seriesState{key: key}
+		s.series[key] = s.seriesLRU.PushFront(state)
+	}
+	state.value, state.lastEmit, state.lastSeen = value, now, now
+
+	maxSeries := s.config.SuppressionMaxSeries
+	if maxSeries <= 0 {
+		maxSeries = DefaultSuppressionMaxSeries
+	}
+	for s.seriesLRU.Len() > maxSeries {
+		s.removeSeriesLocked(s.seriesLRU.Back())
+	}
+	return false
+}
+
+// evictSeriesLocked drops least-recently-seen series whose heartbeat is due.
+// A series' last emission is no later than its last sighting, so such a
+// series would be emitted on its next sample whether tracked or not.
+func (s *System) evictSeriesLocked(now time.Time) {
+	if s.config.HeartbeatInterval <= 0 {
+		return
+	}
+	for elem := s.seriesLRU.Back(); elem != nil; elem = s.seriesLRU.Back() {
+		if now.Sub(elem.Value.(*seriesState).lastSeen) < s.config.HeartbeatInterval {
+			return
+		}
+		s.removeSeriesLocked(elem)
+	}
+}
+
+// removeSeriesLocked stops tracking a series.
+func (s *System) removeSeriesLocked(elem *list.Element) {
+	delete(s.series, elem.Value.(*seriesState).key)
+	s.seriesLRU.Remove(elem)
+}
+
+// seriesKey builds a stable key for a metric series.
+func seriesKey(metricType MetricType, name string, tags map[string]string) string {
+	var sb strings.Builder
+	sb.WriteString(string(metricType))
+	sb.WriteByte(0)
+	sb.WriteString(name)
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteByte(0)
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(tags[k])
+	}
+	return sb.String()
+}
+
+// SuppressedCount returns the number of samples dropped by zero-value suppression.
+func (s *System) SuppressedCount() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.suppressed
+}
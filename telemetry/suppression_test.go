@@ -0,0 +1,129 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEmitter records emitted counter and gauge values by name
+type recordingEmitter struct {
+	values map[string][]float64
+}
+
+func (e *recordingEmitter) Counter(name string, value float64, tags map[string]string) error {
+	e.values[name] = append(e.values[name], value)
+	return nil
+}
+
+func (e *recordingEmitter) Histogram(name string, duration time.Duration, tags map[string]string) error {
+	return nil
+}
+
+func (e *recordingEmitter) HistogramSummary(name string, summary HistogramSummary, tags map[string]string) error {
+	return nil
+}
+
+func (e *recordingEmitter) Gauge(name string, value float64, tags map[string]string) error {
+	e.values[name] = append(e.values[name], value)
+	return nil
+}
+
+func newSuppressingSystem(t *testing.T, heartbeat time.Duration) (*System, *recordingEmitter) {
+	t.Helper()
+	emitter := &recordingEmitter{values: make(map[string][]float64)}
+	sys, err := NewSystem(&Config{
+		Enabled:            true,
+		Emitter:            emitter,
+		SuppressZeroValues: true,
+		HeartbeatInterval:  heartbeat,
+	})
+	require.NoError(t, err)
+	return sys, emitter
+}
+
+// TestSuppressZeroValues verifies idle counters and unchanged gauges are dropped
+func TestSuppressZeroValues(t *testing.T) {
+	sys, emitter := newSuppressingSystem(t, 0)
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, sys.Counter("signals_received", 0, map[string]string{"signal": "SIGHUP"}))
+		require.NoError(t, sys.Gauge("queue_depth", 0, nil))
+	}
+	require.NoError(t, sys.Counter("signals_received", 1, map[string]string{"signal": "SIGHUP"}))
+	require.NoError(t, sys.Gauge("queue_depth", 3, nil))
+	require.NoError(t, sys.Gauge("queue_depth", 3, nil))
+	require.NoError(t, sys.Gauge("queue_depth", 0, nil))
+
+	// First sample of each series is emitted, then only changes
+	assert.Equal(t, []float64{0, 1}, emitter.values["signals_received"])
+	assert.Equal(t, []float64{0, 3, 0}, emitter.values["queue_depth"])
+	assert.Equal(t, int64(9), sys.SuppressedCount())
+
+	// Series are tracked per tag set
+	require.NoError(t, sys.Counter("signals_received", 0, map[string]string{"signal": "SIGTERM"}))
+	assert.Len(t, emitter.values["signals_received"], 3)
+}
+
+// TestSuppressZeroValues_Heartbeat verifies suppressed series are re-emitted periodically
+func TestSuppressZeroValues_Heartbeat(t *testing.T) {
+	sys, emitter := newSuppressingSystem(t, 20*time.Millisecond)
+
+	require.NoError(t, sys.Gauge("idle_gauge", 0, nil))
+	require.NoError(t, sys.Gauge("idle_gauge", 0, nil))
+	assert.Len(t, emitter.values["idle_gauge"], 1)
+
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, sys.Gauge("idle_gauge", 0, nil))
+	require.NoError(t, sys.Gauge("idle_gauge", 0, nil))
+	assert.Len(t, emitter.values["idle_gauge"], 2)
+}
+
+// TestSuppressZeroValues_Disabled verifies the default configuration emits everything
+func TestSuppressZeroValues_Disabled(t *testing.T) {
+	emitter := &recordingEmitter{values: make(map[string][]float64)}
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: emitter})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, sys.Counter("noop", 0, nil))
+	}
+	assert.Len(t, emitter.values["noop"], 3)
+	assert.Zero(t, sys.SuppressedCount())
+}
+
+// TestSuppressZeroValues_MaxSeries verifies the least recently seen series are evicted
+func TestSuppressZeroValues_MaxSeries(t *testing.T) {
+	emitter := &recordingEmitter{values: make(map[string][]float64)}
+	sys, err := NewSystem(&Config{
+		Enabled:              true,
+		Emitter:              emitter,
+		SuppressZeroValues:   true,
+		SuppressionMaxSeries: 2,
+	})
+	require.NoError(t, err)
+
+	for _, name := range []string{"a_total", "b_total", "c_total", "c_total", "a_total"} {
+		require.NoError(t, sys.Counter(name, 0, nil))
+	}
+
+	// a_total was evicted by c_total, so its next sample is emitted as a first sample
+	assert.Len(t, emitter.values["a_total"], 2)
+	assert.Len(t, emitter.values["c_total"], 1)
+	assert.Equal(t, int64(1), sys.SuppressedCount())
+	assert.Len(t, sys.series, 2)
+	assert.Equal(t, 2, sys.seriesLRU.Len())
+}
+
+// TestSuppressZeroValues_HeartbeatEviction verifies series whose heartbeat is due are evicted
+func TestSuppressZeroValues_HeartbeatEviction(t *testing.T) {
+	sys, _ := newSuppressingSystem(t, 20*time.Millisecond)
+
+	require.NoError(t, sys.Gauge("stale_gauge", 0, nil))
+	time.Sleep(30 * time.Millisecond)
+	require.NoError(t, sys.Gauge("live_gauge", 0, nil))
+
+	assert.Len(t, sys.series, 1)
+}
@@ -0,0 +1,215 @@
+package telemetry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fulmenhq/crucible"
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+	"gopkg.in/yaml.v3"
+)
+
+// TaxonomyMode selects how metrics that do not conform to the canonical
+// taxonomy (config/crucible-go/taxonomy/metrics.yaml) are handled.
+type TaxonomyMode string
+
+const (
+	// TaxonomyWarn emits non-conforming metrics and counts them in
+	// telemetry_self_taxonomy_violations.
+	TaxonomyWarn TaxonomyMode = "warn"
+
+	// TaxonomyStrict rejects non-conforming metrics with a *TaxonomyError
+	// (they are counted as with TaxonomyWarn).
+	TaxonomyStrict TaxonomyMode = "strict"
+)
+
+// Taxonomy violation reasons, used as the "reason" tag of
+// metrics.TelemetryTaxonomyViolations and in TaxonomyError.
+const (
+	TaxonomyUnknownMetric = "unknown_metric"
+	TaxonomyMissingTag    = "missing_tag"
+)
+
+// TaxonomyConfig checks metric names, and the tags the taxonomy requires for
+// them, against the canonical metrics taxonomy embedded from Crucible.
+// Self-monitoring metrics (telemetry_self_*) and the metrics emitted by
+// gofulmen modules (metrics.LibraryMetrics) are always accepted; library
+// metrics that are in the taxonomy still need their required tags.
+type TaxonomyConfig struct {
+	// Mode handles violations. Default: TaxonomyWarn.
+	Mode TaxonomyMode `json:"mode,omitempty"`
+
+	// Allow accepts additional metric names outside the taxonomy, such as
+	// application metrics. Entries are exact names or prefixes ending in "*"
+	// (e.g., "myapp_*"). Allowed metrics have no required tags.
+	Allow []string `json:"allow,omitempty"`
+}
+
+// TaxonomyError reports a metric rejected by TaxonomyStrict.
+type TaxonomyError struct {
+	Metric string // Metric name
+	Reason string // TaxonomyUnknownMetric or TaxonomyMissingTag
+	Tag    string // Missing tag key (TaxonomyMissingTag only)
+}
+
+func (e *TaxonomyError) Error() string {
+	if e.Reason == TaxonomyMissingTag {
+		return fmt.Sprintf("metric %s is missing required tag %q", e.Metric, e.Tag)
+	}
+	return fmt.Sprintf("metric %s is not in the metrics taxonomy", e.Metric)
+}
+
+// TaxonomyMetric is a metric defined in the canonical taxonomy.
+type TaxonomyMetric struct {
+	Name         string   // Metric name (e.g., "pathfinder_find_ms")
+	Unit         string   // Taxonomy unit (e.g., "ms", "count", "s")
+	RequiredTags []string // Tags the taxonomy requires, sorted
+}
+
+var (
+	taxonomyOnce    sync.Once
+	taxonomyMetrics map[string]TaxonomyMetric
+	taxonomyErr     error
+)
+
+// LookupTaxonomyMetric returns the taxonomy definition of a metric name.
+// It returns an error if the embedded taxonomy cannot be loaded.
+func LookupTaxonomyMetric(name string) (TaxonomyMetric, bool, error) {
+	taxonomy, err := loadTaxonomy()
+	if err != nil {
+		return TaxonomyMetric{}, false, err
+	}
+	metric, ok := taxonomy[name]
+	return metric, ok, nil
+}
+
+// loadTaxonomy parses the embedded metrics taxonomy once.
+func loadTaxonomy() (map[string]TaxonomyMetric, error) {
+	taxonomyOnce.Do(func() {
+		data, err := crucible.GetConfig("taxonomy/metrics.yaml")
+		if err != nil {
+			taxonomyErr = fmt.Errorf("failed to load metrics taxonomy: %w", err)
+			return
+		}
+		var doc struct {
+			Metrics []struct {
+				Name        string `yaml:"name"`
+				Unit        string `yaml:"unit"`
+				Description string `yaml:"description"`
+			} `yaml:"metrics"`
+		}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			taxonomyErr = fmt.Errorf("failed to parse metrics taxonomy: %w", err)
+			return
+		}
+		taxonomyMetrics = make(map[string]TaxonomyMetric, len(doc.Metrics))
+		for _, m := range doc.Metrics {
+			taxonomyMetrics[m.Name] = TaxonomyMetric{
+				Name:         m.Name,
+				Unit:         m.Unit,
+				RequiredTags: requiredLabels(m.Description),
+			}
+		}
+	})
+	return taxonomyMetrics, taxonomyErr
+}
+
+// requiredLabels extracts the tag keys from a taxonomy description's
+// "Required labels: a (...), b." sentence.
+func requiredLabels(description string) []string {
+	_, rest, ok := strings.Cut(description, "Required labels:")
+	if !ok {
+		return nil
+	}
+
+	var labels []string
+	var current strings.Builder
+	depth := 0
+	flush := func() {
+		if fields := strings.Fields(current.String()); len(fields) > 0 {
+			labels = append(labels, fields[0])
+		}
+		current.Reset()
+	}
+scan:
+	for _, r := range rest {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case depth > 0:
+		case r == ',':
+			flush()
+		case r == '.':
+			break scan
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	sort.Strings(labels)
+	return labels
+}
+
+// checkTaxonomy applies Config.Taxonomy to an event about to be emitted.
+// It returns a *TaxonomyError when the event must be rejected.
+func (s *System) checkTaxonomy(name string, tags map[string]string) error {
+	cfg := s.config.Taxonomy
+	if cfg == nil || strings.HasPrefix(name, metrics.TelemetrySelfPrefix) {
+		return nil
+	}
+
+	violation := s.taxonomyViolation(cfg, name, tags)
+	if violation == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	s.taxonomyViolations++
+	s.mu.Unlock()
+	selfTags := map[string]string{"metric": name, metrics.TagReason: violation.Reason}
+	if violation.Tag != "" {
+		selfTags["tag_key"] = violation.Tag
+	}
+	_ = s.Counter(metrics.TelemetryTaxonomyViolations, 1, selfTags)
+
+	if cfg.Mode == TaxonomyStrict {
+		return violation
+	}
+	return nil
+}
+
+// taxonomyViolation returns the first way name and tags violate the taxonomy.
+func (s *System) taxonomyViolation(cfg *TaxonomyConfig, name string, tags map[string]string) *TaxonomyError {
+	for _, allowed := range cfg.Allow {
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok && strings.HasPrefix(name, prefix) || allowed == name {
+			return nil
+		}
+	}
+
+	metric, ok, err := LookupTaxonomyMetric(name)
+	if (err != nil || !ok) && metrics.IsLibraryMetric(name) {
+		return nil
+	}
+	if err != nil || !ok {
+		// An unloadable taxonomy leaves every name unknown
+		return &TaxonomyError{Metric: name, Reason: TaxonomyUnknownMetric}
+	}
+	for _, tag := range metric.RequiredTags {
+		if _, present := tags[tag]; !present {
+			return &TaxonomyError{Metric: name, Reason: TaxonomyMissingTag, Tag: tag}
+		}
+	}
+	return nil
+}
+
+// TaxonomyViolations returns the number of events that did not conform to
+// the metrics taxonomy.
+func (s *System) TaxonomyViolations() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.taxonomyViolations
+}
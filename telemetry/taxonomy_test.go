@@ -0,0 +1,133 @@
+package telemetry
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLookupTaxonomyMetric verifies taxonomy units and required tags are parsed
+func TestLookupTaxonomyMetric(t *testing.T) {
+	metric, ok, err := LookupTaxonomyMetric(metrics.PrometheusExporterRefreshDurationSeconds)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "s", metric.Unit)
+	assert.Equal(t, []string{"phase", "result"}, metric.RequiredTags)
+
+	metric, ok, err = LookupTaxonomyMetric(metrics.HTTPRequestsTotal)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, []string{"method", "route", "service", "status"}, metric.RequiredTags)
+
+	metric, ok, err = LookupTaxonomyMetric(metrics.PathfinderFindMs)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Empty(t, metric.RequiredTags)
+
+	_, ok, err = LookupTaxonomyMetric("not_a_metric")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+// TestTaxonomyWarn verifies unknown metrics are emitted and counted
+func TestTaxonomyWarn(t *testing.T) {
+	recorder := &tagRecorder{}
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: recorder, Taxonomy: &TaxonomyConfig{}})
+	require.NoError(t, err)
+
+	require.NoError(t, sys.Counter("made_up_total", 1, nil))
+	require.NoError(t, sys.Counter(metrics.SchemaValidations, 1, nil))
+
+	assert.Len(t, recorder.byName("made_up_total"), 1, "warn mode should still emit")
+	assert.Equal(t, int64(1), sys.TaxonomyViolations())
+	violations := recorder.byName(metrics.TelemetryTaxonomyViolations)
+	require.Len(t, violations, 1)
+	assert.Equal(t, map[string]string{"metric": "made_up_total", "reason": TaxonomyUnknownMetric}, violations[0].tags)
+}
+
+// TestTaxonomyStrict verifies unknown metrics and missing required tags are rejected
+func TestTaxonomyStrict(t *testing.T) {
+	recorder := &tagRecorder{}
+	sys, err := NewSystem(&Config{
+		Enabled:  true,
+		Emitter:  recorder,
+		Taxonomy: &TaxonomyConfig{Mode: TaxonomyStrict, Allow: []string{"myapp_*", "exact_metric"}},
+	})
+	require.NoError(t, err)
+
+	var taxErr *TaxonomyError
+	err = sys.Counter("made_up_total", 1, nil)
+	require.True(t, errors.As(err, &taxErr), "expected TaxonomyError, got %v", err)
+	assert.Equal(t, TaxonomyUnknownMetric, taxErr.Reason)
+	assert.Empty(t, recorder.byName("made_up_total"))
+
+	err = sys.Counter(metrics.PrometheusExporterRestartsTotal, 1, nil)
+	require.True(t, errors.As(err, &taxErr), "expected TaxonomyError, got %v", err)
+	assert.Equal(t, TaxonomyMissingTag, taxErr.Reason)
+	assert.Equal(t, "reason", taxErr.Tag)
+
+	assert.NoError(t, sys.Counter(metrics.PrometheusExporterRestartsTotal, 1, map[string]string{"reason": "manual"}))
+	assert.NoError(t, sys.Counter("myapp_jobs_total", 1, nil))
+	assert.NoError(t, sys.Gauge("exact_metric", 3, nil))
+	assert.Equal(t, int64(2), sys.TaxonomyViolations())
+	assert.Len(t, recorder.byName(metrics.TelemetryTaxonomyViolations), 2)
+}
+
+// TestTaxonomyRequiredLabels checks the labels parsed from every taxonomy entry
+func TestTaxonomyRequiredLabels(t *testing.T) {
+	expected := map[string][]string{
+		metrics.PrometheusExporterRefreshDurationSeconds: {"phase", "result"},
+		metrics.PrometheusExporterRefreshTotal:           {"result"},
+		metrics.PrometheusExporterRefreshErrorsTotal:     {"error_type"},
+		metrics.PrometheusExporterHTTPRequestsTotal:      {"path", "status"},
+		metrics.PrometheusExporterHTTPErrorsTotal:        {"path", "status"},
+		metrics.PrometheusExporterRestartsTotal:          {"reason"},
+		metrics.HTTPRequestsTotal:                        {"method", "route", "service", "status"},
+		metrics.HTTPRequestDurationSeconds:               {"method", "route", "service", "status"},
+		metrics.HTTPRequestSizeBytes:                     {"method", "route", "service"},
+		metrics.HTTPResponseSizeBytes:                    {"method", "route", "service", "status"},
+		metrics.HTTPActiveRequests:                       {"service"},
+	}
+
+	taxonomy, err := loadTaxonomy()
+	require.NoError(t, err)
+	require.NotEmpty(t, taxonomy)
+	for name := range expected {
+		assert.Contains(t, taxonomy, name)
+	}
+	label := regexp.MustCompile(`^[a-z_]+$`)
+	for name, metric := range taxonomy {
+		if want, ok := expected[name]; ok {
+			assert.Equal(t, want, metric.RequiredTags, name)
+		} else {
+			assert.Empty(t, metric.RequiredTags, name)
+		}
+		for _, tag := range metric.RequiredTags {
+			assert.True(t, label.MatchString(tag), "%s: bad label %q", name, tag)
+		}
+	}
+}
+
+// TestTaxonomyStrictAcceptsLibraryMetrics verifies gofulmen's own metrics pass strict mode
+func TestTaxonomyStrictAcceptsLibraryMetrics(t *testing.T) {
+	recorder := &tagRecorder{}
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: recorder, Taxonomy: &TaxonomyConfig{Mode: TaxonomyStrict}})
+	require.NoError(t, err)
+
+	for _, name := range metrics.LibraryMetrics() {
+		metric, ok, err := LookupTaxonomyMetric(name)
+		require.NoError(t, err)
+		tags := map[string]string{}
+		if ok {
+			for _, tag := range metric.RequiredTags {
+				tags[tag] = "x"
+			}
+		}
+		assert.NoError(t, sys.Counter(name, 1, tags), name)
+	}
+	assert.Zero(t, sys.TaxonomyViolations())
+}
@@ -5,11 +5,15 @@
 package telemetry
 
 import (
+	"container/list"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
+	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fulmenhq/gofulmen/schema"
@@ -64,6 +68,13 @@ type MetricsEvent struct {
 	Value     interface{}       `json:"value"`
 	Tags      map[string]string `json:"tags,omitempty"`
 	Unit      string            `json:"unit,omitempty"`
+
+	// Weight is the number of events a sampled histogram observation
+	// represents (see SamplingPolicy); zero means 1. Value is not scaled.
+	Weight float64 `json:"weight,omitempty"`
+
+	// exemplar is passed to an ExemplarRecorder once the event is emitted
+	exemplar *Exemplar
 }
 
 // calculateHistogramBuckets calculates histogram buckets for a given duration using ADR-0007 defaults
@@ -99,11 +110,70 @@ func calculateHistogramBuckets(duration time.Duration, buckets []float64) []Hist
 type Config struct {
 	Enabled       bool              `json:"enabled"`
 	Emitter       MetricsEmitter    `json:"-"`
+	Output        io.Writer         `json:"-"` // Destination of default JSON emission when Emitter is nil (nil = DefaultOutput)
 	Schema        *schema.Validator `json:"-"`
 	BatchSize     int               `json:"batchSize,omitempty"`     // Maximum number of metrics in a batch (0 = no batching)
 	BatchInterval time.Duration     `json:"batchInterval,omitempty"` // Maximum time to wait before emitting a batch (0 = immediate)
+
+	// SuppressZeroValues drops zero-valued counter increments and gauges that repeat
+	// their last emitted value (per name + tags series), cutting event volume from
+	// mostly-idle modules. The first sample of each series is always emitted.
+	SuppressZeroValues bool `json:"suppressZeroValues,omitempty"`
+	// HeartbeatInterval re-emits a suppressed series once this long has passed since
+	// its last emission, so absence-of-data alerts keep working (0 = no heartbeat).
+	// The heartbeat is the next sample to arrive after the interval; a series
+	// that stops reporting sends none.
+	HeartbeatInterval time.Duration `json:"heartbeatInterval,omitempty"`
+	// SuppressionMaxSeries bounds the series tracked for suppression; the least
+	// recently seen are evicted and their next sample is emitted as a first
+	// sample (0 = DefaultSuppressionMaxSeries).
+	SuppressionMaxSeries int `json:"suppressionMaxSeries,omitempty"`
+
+	// Cardinality limits tag count, tag value length, and distinct values per
+	// tag key (nil = no limits). Violations are counted in
+	// telemetry_self_cardinality_violations.
+	Cardinality *CardinalityConfig `json:"cardinality,omitempty"`
+
+	// Namespaces enables or disables metrics by name at runtime. Keys are
+	// exact names or prefixes ending in "*"; dots are treated as underscores,
+	// so "foundry.similarity.*" matches "foundry_similarity_*". The exact
+	// name, then the longest prefix, wins; unmatched names are enabled.
+	// Enabled remains the master switch. See System.SetNamespaceEnabled and
+	// System.AdminHandler.
+	Namespaces map[string]bool `json:"namespaces,omitempty"`
+
+	// Sampling maps metric names to sampling policies for counters and
+	// histograms. Keys are exact names or prefixes ending in "*" (e.g.,
+	// "pathfinder_*"); the exact name, then the longest prefix, wins.
+	Sampling map[string]SamplingPolicy `json:"sampling,omitempty"`
+
+	// CorrelationIDAsTag attaches the context's correlation ID as a
+	// correlation_id tag in CounterCtx and HistogramCtx, for backends without
+	// exemplar support. Each ID creates a new series, so pair it with
+	// Cardinality limits.
+	CorrelationIDAsTag bool `json:"correlationIdAsTag,omitempty"`
+
+	// Taxonomy checks metric names and required tags against the canonical
+	// metrics taxonomy (nil = no checks). Violations are counted in
+	// telemetry_self_taxonomy_violations; TaxonomyStrict also rejects them.
+	Taxonomy *TaxonomyConfig `json:"taxonomy,omitempty"`
+
+	// Clock supplies event timestamps, Timer durations, and the time used by
+	// sampling, zero-value suppression, and batch intervals (nil = system
+	// clock). Tests can substitute a fake clock (see telemetrytest.FakeClock).
+	Clock Clock `json:"-"`
+}
+
+// Clock is a source of the current time.
+type Clock interface {
+	Now() time.Time
 }
 
+// systemClock is the default Clock.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
 // DefaultConfig returns a default telemetry configuration
 func DefaultConfig() *Config {
 	return &Config{
@@ -118,11 +188,38 @@ type System struct {
 	config *Config
 	mu     sync.RWMutex
 
+	// validator checks emitted events; loaded when a disabled system is
+	// first enabled, so it is read without holding mu
+	validator atomic.Pointer[schema.Validator]
+
+	// Namespace overrides, normalized on write: exact names and "*"
+	// prefixes (longest first)
+	namespaces        map[string]bool
+	namespacePrefixes []namespacePrefix
+
 	// Batching support
 	metricBuffer  []MetricsEvent
 	lastFlushTime time.Time
 	flushTimer    *time.Timer
 
+	// Zero-value suppression state (series elements hold *seriesState,
+	// most recently seen first in seriesLRU)
+	series     map[string]*list.Element
+	seriesLRU  *list.List
+	suppressed int64
+
+	// Cardinality guard state
+	tagValues             map[cardinalityKey]map[string]struct{}
+	cardinalityViolations int64
+
+	// Taxonomy check state
+	taxonomyViolations int64
+
+	// Sampling state (rand is replaceable in tests)
+	samplers   map[string]*samplerState
+	sampledOut int64
+	rand       func() float64
+
 	// Internal counters for tracking telemetry health
 	validationErrors int64
 	emissionErrors   int64
@@ -136,35 +233,67 @@ func NewSystem(config *Config) (*System, error) {
 
 	// Load metrics schema if not provided and enabled
 	if config.Schema == nil && config.Enabled {
-		catalog := schema.DefaultCatalog()
-		validator, err := catalog.ValidatorByID("observability/metrics/v1.0.0/metrics-event")
-		if err != nil {
-			// Schema loading can fail due to reference resolution issues
-			// We'll continue without schema validation in this case
-			// This allows the system to work even if schema references aren't perfectly resolved
-			config.Schema = nil
-		} else {
-			config.Schema = validator
-		}
+		config.Schema = loadMetricsSchema()
 	}
 
-	return &System{
+	s := &System{
 		config: config,
-	}, nil
+	}
+	s.validator.Store(config.Schema)
+	s.setNamespacesLocked(config.Namespaces)
+	return s, nil
+}
+
+// now returns the current time from Config.Clock.
+func (s *System) now() time.Time {
+	if s.config.Clock != nil {
+		return s.config.Clock.Now()
+	}
+	return systemClock{}.Now()
+}
+
+// loadMetricsSchema returns the metrics event schema validator, or nil if it
+// cannot be loaded
+func loadMetricsSchema() *schema.Validator {
+	catalog := schema.DefaultCatalog()
+	validator, err := catalog.ValidatorByID("observability/metrics/v1.0.0/metrics-event")
+	if err != nil {
+		// Schema loading can fail due to reference resolution issues
+		// We'll continue without schema validation in this case
+		// This allows the system to work even if schema references aren't perfectly resolved
+		return nil
+	}
+	return validator
 }
 
 // Counter emits a counter metric increment
 func (s *System) Counter(name string, value float64, tags map[string]string) error {
-	if !s.isEnabled() {
+	return s.counter(name, value, tags, "")
+}
+
+// counter implements Counter and CounterCtx. A non-empty correlationID
+// attaches an exemplar carrying the weighted increment.
+func (s *System) counter(name string, value float64, tags map[string]string, correlationID string) error {
+	if !s.isEnabledFor(name) {
+		return nil
+	}
+	weight, keep := s.sample(name)
+	if !keep {
+		return nil
+	}
+	value *= weight
+	tags = s.guardTags(name, tags)
+	if s.shouldSuppress(TypeCounter, name, value, tags) {
 		return nil
 	}
 
 	event := MetricsEvent{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Timestamp: s.now().UTC().Format(time.RFC3339),
 		Name:      name,
 		Type:      TypeCounter,
 		Value:     value,
 		Tags:      tags,
+		exemplar:  s.newExemplar(correlationID, value),
 	}
 
 	return s.emit(event)
@@ -172,12 +301,16 @@ func (s *System) Counter(name string, value float64, tags map[string]string) err
 
 // Gauge emits a gauge metric with current value
 func (s *System) Gauge(name string, value float64, tags map[string]string) error {
-	if !s.isEnabled() {
+	if !s.isEnabledFor(name) {
+		return nil
+	}
+	tags = s.guardTags(name, tags)
+	if s.shouldSuppress(TypeGauge, name, value, tags) {
 		return nil
 	}
 
 	event := MetricsEvent{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Timestamp: s.now().UTC().Format(time.RFC3339),
 		Name:      name,
 		Type:      TypeGauge,
 		Value:     value,
@@ -190,9 +323,21 @@ func (s *System) Gauge(name string, value float64, tags map[string]string) error
 // Histogram emits a histogram metric with timing data
 // Automatically uses ADR-0007 default buckets for metrics ending with "_ms"
 func (s *System) Histogram(name string, duration time.Duration, tags map[string]string) error {
-	if !s.isEnabled() {
+	return s.histogram(name, duration, tags, "")
+}
+
+// histogram implements Histogram and HistogramCtx. A non-empty correlationID
+// attaches an exemplar carrying the observation in milliseconds.
+func (s *System) histogram(name string, duration time.Duration, tags map[string]string, correlationID string) error {
+	if !s.isEnabledFor(name) {
 		return nil
 	}
+	weight, keep := s.sample(name)
+	if !keep {
+		return nil
+	}
+	ms := float64(duration.Nanoseconds()) / 1e6 // Convert to milliseconds
+	exemplar := s.newExemplar(correlationID, ms)
 
 	// Check if this is a millisecond metric that should use ADR-0007 buckets
 	if strings.HasSuffix(name, "_ms") {
@@ -202,18 +347,20 @@ func (s *System) Histogram(name string, duration time.Duration, tags map[string]
 			Sum:     float64(duration.Milliseconds()),
 			Buckets: calculateHistogramBuckets(duration, DefaultHistogramBucketsMS),
 		}
-		return s.HistogramSummary(name, summary, tags)
+		return s.emitHistogramSummary(name, summary, weight, "ms", tags, exemplar)
 	}
 
 	// For non-ms metrics, emit as single value (backward compatibility)
-	ms := float64(duration.Nanoseconds()) / 1e6 // Convert to milliseconds
+	tags = s.guardTags(name, tags)
 	event := MetricsEvent{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Timestamp: s.now().UTC().Format(time.RFC3339),
 		Name:      name,
 		Type:      TypeHistogram,
 		Value:     ms,
 		Tags:      tags,
 		Unit:      "ms",
+		Weight:    eventWeight(weight),
+		exemplar:  exemplar,
 	}
 
 	return s.emit(event)
@@ -221,17 +368,30 @@ func (s *System) Histogram(name string, duration time.Duration, tags map[string]
 
 // HistogramSummary emits a pre-calculated histogram summary
 func (s *System) HistogramSummary(name string, summary HistogramSummary, tags map[string]string) error {
-	if !s.isEnabled() {
+	if !s.isEnabledFor(name) {
 		return nil
 	}
+	weight, keep := s.sample(name)
+	if !keep {
+		return nil
+	}
+	return s.emitHistogramSummary(name, summary, weight, "ms", tags, nil)
+}
+
+// emitHistogramSummary emits a histogram summary that has already been sampled
+// with the given weight
+func (s *System) emitHistogramSummary(name string, summary HistogramSummary, weight float64, unit string, tags map[string]string, exemplar *Exemplar) error {
+	tags = s.guardTags(name, tags)
 
 	event := MetricsEvent{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Timestamp: s.now().UTC().Format(time.RFC3339),
 		Name:      name,
 		Type:      TypeHistogram,
 		Value:     summary,
 		Tags:      tags,
-		Unit:      "ms",
+		Unit:      unit,
+		Weight:    eventWeight(weight),
+		exemplar:  exemplar,
 	}
 
 	return s.emit(event)
@@ -239,6 +399,10 @@ func (s *System) HistogramSummary(name string, summary HistogramSummary, tags ma
 
 // emit handles the actual emission and validation
 func (s *System) emit(event MetricsEvent) error {
+	if err := s.checkTaxonomy(event.Name, event.Tags); err != nil {
+		return err
+	}
+
 	// Check if batching is enabled
 	if s.config.BatchSize > 0 || s.config.BatchInterval > 0 {
 		return s.bufferMetric(event)
@@ -261,7 +425,7 @@ func (s *System) bufferMetric(event MetricsEvent) error {
 	}
 
 	// Check if we should flush based on time interval
-	if s.config.BatchInterval > 0 && time.Since(s.lastFlushTime) >= s.config.BatchInterval {
+	if s.config.BatchInterval > 0 && s.now().Sub(s.lastFlushTime) >= s.config.BatchInterval {
 		return s.flushBufferLocked()
 	}
 
@@ -303,7 +467,7 @@ func (s *System) flushBufferLocked() error {
 
 	// Clear buffer and update flush time
 	s.metricBuffer = s.metricBuffer[:0]
-	s.lastFlushTime = time.Now()
+	s.lastFlushTime = s.now()
 
 	return nil
 }
@@ -323,7 +487,7 @@ func (s *System) Flush() error {
 // emitImmediate handles immediate emission without batching
 func (s *System) emitImmediate(event MetricsEvent) error {
 	// Validate against schema if available
-	if s.config.Schema != nil {
+	if validator := s.validator.Load(); validator != nil {
 		// Convert struct to map for schema validation
 		eventJSON, err := json.Marshal(event)
 		if err != nil {
@@ -336,8 +500,10 @@ func (s *System) emitImmediate(event MetricsEvent) error {
 			s.incrementValidationErrors()
 			return fmt.Errorf("failed to unmarshal event for validation: %w", err)
 		}
+		// weight is a gofulmen sampling field not in the Crucible metrics-event schema
+		delete(eventMap, "weight")
 
-		diagnostics, err := s.config.Schema.ValidateData(eventMap)
+		diagnostics, err := validator.ValidateData(eventMap)
 		if err != nil {
 			s.incrementValidationErrors()
 			return fmt.Errorf("schema validation failed: %w", err)
@@ -350,30 +516,10 @@ func (s *System) emitImmediate(event MetricsEvent) error {
 
 	// Use custom emitter if provided
 	if s.config.Emitter != nil {
-		switch event.Type {
-		case TypeCounter:
-			if v, ok := event.Value.(float64); ok {
-				return s.config.Emitter.Counter(event.Name, v, event.Tags)
-			}
-			return fmt.Errorf("counter metric value must be float64, got %T", event.Value)
-		case TypeGauge:
-			if v, ok := event.Value.(float64); ok {
-				return s.config.Emitter.Gauge(event.Name, v, event.Tags)
-			}
-			return fmt.Errorf("gauge metric value must be float64, got %T", event.Value)
-		case TypeHistogram:
-			switch v := event.Value.(type) {
-			case float64:
-				// Single histogram value - convert back to duration
-				return s.config.Emitter.Histogram(event.Name, time.Duration(v*1e6)*time.Nanosecond, event.Tags)
-			case HistogramSummary:
-				return s.config.Emitter.HistogramSummary(event.Name, v, event.Tags)
-			default:
-				return fmt.Errorf("histogram metric value must be float64 or HistogramSummary, got %T", v)
-			}
-		default:
-			return fmt.Errorf("unsupported metric type: %s", event.Type)
+		if err := emitTo(s.config.Emitter, event); err != nil {
+			return err
 		}
+		return s.recordExemplar(event)
 	}
 
 	// Default JSON emission
@@ -383,10 +529,56 @@ func (s *System) emitImmediate(event MetricsEvent) error {
 		return fmt.Errorf("failed to marshal metric event: %w", err)
 	}
 
-	fmt.Println(string(jsonData))
+	fmt.Fprintln(s.output(), string(jsonData))
 	return nil
 }
 
+// output returns the destination of default JSON emission.
+func (s *System) output() io.Writer {
+	if s.config.Output != nil {
+		return s.config.Output
+	}
+	return DefaultOutput()
+}
+
+// emitTo passes an event to a custom emitter
+func emitTo(emitter MetricsEmitter, event MetricsEvent) error {
+	switch event.Type {
+	case TypeCounter:
+		if v, ok := event.Value.(float64); ok {
+			return emitter.Counter(event.Name, v, event.Tags)
+		}
+		return fmt.Errorf("counter metric value must be float64, got %T", event.Value)
+	case TypeGauge:
+		if v, ok := event.Value.(float64); ok {
+			return emitter.Gauge(event.Name, v, event.Tags)
+		}
+		return fmt.Errorf("gauge metric value must be float64, got %T", event.Value)
+	case TypeHistogram:
+		if event.Weight != 0 && event.Weight != 1 {
+			return emitWeightedHistogram(emitter, event)
+		}
+		switch v := event.Value.(type) {
+		case float64:
+			if event.Unit != "" && event.Unit != "ms" {
+				// Non-duration observation (see Observe)
+				if observer, ok := emitter.(ValueObserver); ok {
+					return observer.Observe(event.Name, v, event.Unit, event.Tags)
+				}
+				return emitter.HistogramSummary(event.Name, HistogramSummary{Count: 1, Sum: v, Buckets: []HistogramBucket{}}, event.Tags)
+			}
+			// Single histogram value - convert back to duration
+			return emitter.Histogram(event.Name, time.Duration(v*1e6)*time.Nanosecond, event.Tags)
+		case HistogramSummary:
+			return emitter.HistogramSummary(event.Name, v, event.Tags)
+		default:
+			return fmt.Errorf("histogram metric value must be float64 or HistogramSummary, got %T", v)
+		}
+	default:
+		return fmt.Errorf("unsupported metric type: %s", event.Type)
+	}
+}
+
 // isEnabled checks if telemetry is enabled
 func (s *System) isEnabled() bool {
 	s.mu.RLock()
@@ -452,6 +644,32 @@ func (e MetricsEvent) MarshalJSON() ([]byte, error) {
 	return json.Marshal((*Alias)(&e))
 }
 
+// Default JSON emission destination for systems without Config.Output
+var (
+	defaultOutput   io.Writer = os.Stdout
+	defaultOutputMu sync.RWMutex
+)
+
+// SetDefaultOutput sets where systems without Config.Output write default JSON
+// emission, including the systems gofulmen modules create internally.
+// Commands that reserve stdout for their own output can pass os.Stderr; nil
+// restores os.Stdout.
+func SetDefaultOutput(w io.Writer) {
+	if w == nil {
+		w = os.Stdout
+	}
+	defaultOutputMu.Lock()
+	defer defaultOutputMu.Unlock()
+	defaultOutput = w
+}
+
+// DefaultOutput returns the writer set by SetDefaultOutput (os.Stdout by default).
+func DefaultOutput() io.Writer {
+	defaultOutputMu.RLock()
+	defer defaultOutputMu.RUnlock()
+	return defaultOutput
+}
+
 // Global telemetry system for module instrumentation
 var (
 	globalSystem     *System
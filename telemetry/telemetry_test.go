@@ -1,7 +1,9 @@
 package telemetry
 
 import (
+	"bytes"
 	"encoding/json"
+	"os"
 	"testing"
 	"time"
 
@@ -163,6 +165,25 @@ func TestHistogramSummary(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDefaultEmissionOutput(t *testing.T) {
+	var configured, fallback bytes.Buffer
+	SetDefaultOutput(&fallback)
+	defer SetDefaultOutput(nil)
+
+	sys, err := NewSystem(&Config{Enabled: true, Output: &configured})
+	require.NoError(t, err)
+	require.NoError(t, sys.Counter("schema_validations", 1, nil))
+	assert.Contains(t, configured.String(), `"name":"schema_validations"`)
+
+	sys, err = NewSystem(&Config{Enabled: true})
+	require.NoError(t, err)
+	require.NoError(t, sys.Counter("schema_validations", 1, nil))
+	assert.Contains(t, fallback.String(), `"name":"schema_validations"`)
+
+	SetDefaultOutput(nil)
+	assert.Equal(t, os.Stdout, DefaultOutput())
+}
+
 func TestDisabledSystem(t *testing.T) {
 	sys, err := NewSystem(&Config{Enabled: false})
 	require.NoError(t, err)
@@ -0,0 +1,42 @@
+package telemetrytest
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a telemetry.Clock that only moves when told to. It is safe
+// for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a FakeClock set to start (a fixed instant if zero).
+func NewFakeClock(start time.Time) *FakeClock {
+	if start.IsZero() {
+		start = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return &FakeClock{now: start}
+}
+
+// Now implements telemetry.Clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to t.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
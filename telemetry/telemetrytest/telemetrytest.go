@@ -0,0 +1,180 @@
+// Package telemetrytest provides a deterministic harness for unit-testing
+// code that emits metrics: a telemetry.System wired to a capturing
+// FakeCollector and a FakeClock, plus assertion helpers.
+//
+// Example:
+//
+//	func TestPack(t *testing.T) {
+//	    h := telemetrytest.NewGlobal(t) // module helpers (telemetry.EmitCounter, ...) emit here
+//
+//	    stop := h.System.Timer("fulpack_operation_ms", nil)
+//	    h.Clock.Advance(42 * time.Millisecond)
+//	    _ = stop()
+//
+//	    telemetrytest.AssertHistogramBuckets(t, h.Collector, "fulpack_operation_ms", nil,
+//	        []telemetry.HistogramBucket{{LE: 10, Count: 0}, {LE: 50, Count: 1}})
+//	}
+package telemetrytest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+	telemetrytesting "github.com/fulmenhq/gofulmen/telemetry/testing"
+)
+
+// Harness is a telemetry system whose events are captured by Collector and
+// whose time is controlled by Clock.
+type Harness struct {
+	System    *telemetry.System
+	Collector *telemetrytesting.FakeCollector
+	Clock     *FakeClock
+}
+
+// New returns a Harness with an enabled system. configure, if given, can
+// adjust the config (e.g., Sampling or Cardinality) before the system is
+// created; Emitter and Clock are preset.
+func New(t testing.TB, configure ...func(*telemetry.Config)) *Harness {
+	t.Helper()
+	h := &Harness{
+		Collector: telemetrytesting.NewFakeCollector(),
+		Clock:     NewFakeClock(time.Time{}),
+	}
+	config := &telemetry.Config{Enabled: true, Emitter: h.Collector, Clock: h.Clock}
+	for _, fn := range configure {
+		fn(config)
+	}
+	system, err := telemetry.NewSystem(config)
+	if err != nil {
+		t.Fatalf("telemetrytest: NewSystem: %v", err)
+	}
+	h.System = system
+	return h
+}
+
+// NewGlobal returns a Harness installed as the global telemetry system, so
+// module instrumentation using telemetry.EmitCounter and friends is
+// captured. The previous global system is restored when the test ends.
+func NewGlobal(t testing.TB, configure ...func(*telemetry.Config)) *Harness {
+	t.Helper()
+	h := New(t, configure...)
+	previous := telemetry.GetGlobalSystem()
+	telemetry.SetGlobalSystem(h.System)
+	t.Cleanup(func() { telemetry.SetGlobalSystem(previous) })
+	return h
+}
+
+// CounterTotal returns the sum of the counter increments recorded for name
+// on series whose tags include tags (nil matches every series).
+func CounterTotal(fc *telemetrytesting.FakeCollector, name string, tags map[string]string) float64 {
+	var total float64
+	for _, m := range fc.GetMetricsByName(name) {
+		if v, ok := m.Value.(float64); ok && m.Type == telemetrytesting.MetricTypeCounter && hasTags(m.Tags, tags) {
+			total += v
+		}
+	}
+	return total
+}
+
+// HistogramBuckets returns cumulative counts at the bucket boundaries les
+// for the histogram observations recorded for name on series whose tags
+// include tags. Durations are counted in milliseconds. For a pre-computed
+// summary, a boundary receives the count of the summary's largest bucket
+// within it (exact when the layouts match); +Inf receives the total count.
+func HistogramBuckets(fc *telemetrytesting.FakeCollector, name string, tags map[string]string, les []float64) []telemetry.HistogramBucket {
+	buckets := make([]telemetry.HistogramBucket, len(les))
+	for i, le := range les {
+		buckets[i].LE = le
+	}
+
+	for _, m := range fc.GetMetricsByName(name) {
+		if m.Type != telemetrytesting.MetricTypeHistogram || !hasTags(m.Tags, tags) {
+			continue
+		}
+		switch v := m.Value.(type) {
+		case telemetry.HistogramSummary:
+			for i := range buckets {
+				buckets[i].Count += summaryCountAt(v, buckets[i].LE)
+			}
+		case time.Duration:
+			observe(buckets, float64(v.Nanoseconds())/1e6)
+		case float64:
+			observe(buckets, v)
+		}
+	}
+	return buckets
+}
+
+// AssertCounter fails t unless the counter total for name and tags (see
+// CounterTotal) equals want.
+func AssertCounter(t testing.TB, fc *telemetrytesting.FakeCollector, name string, tags map[string]string, want float64) bool {
+	t.Helper()
+	if got := CounterTotal(fc, name, tags); got != want {
+		t.Errorf("counter %s%v = %v, want %v", name, tagSuffix(tags), got, want)
+		return false
+	}
+	return true
+}
+
+// AssertHistogramBuckets fails t unless the cumulative counts for name and
+// tags (see HistogramBuckets) match want at each of want's boundaries.
+func AssertHistogramBuckets(t testing.TB, fc *telemetrytesting.FakeCollector, name string, tags map[string]string, want []telemetry.HistogramBucket) bool {
+	t.Helper()
+	les := make([]float64, len(want))
+	for i, b := range want {
+		les[i] = b.LE
+	}
+	got := HistogramBuckets(fc, name, tags, les)
+	ok := true
+	for i := range want {
+		if got[i].Count != want[i].Count {
+			t.Errorf("histogram %s%v bucket le=%v: count = %d, want %d", name, tagSuffix(tags), want[i].LE, got[i].Count, want[i].Count)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// observe counts a single observation into cumulative buckets.
+func observe(buckets []telemetry.HistogramBucket, value float64) {
+	for i := range buckets {
+		if value <= buckets[i].LE {
+			buckets[i].Count++
+		}
+	}
+}
+
+// summaryCountAt returns the cumulative count of summary at boundary le.
+func summaryCountAt(summary telemetry.HistogramSummary, le float64) int64 {
+	if math.IsInf(le, 1) {
+		return summary.Count
+	}
+	var count int64
+	best := math.Inf(-1)
+	for _, b := range summary.Buckets {
+		if b.LE <= le && b.LE > best {
+			best, count = b.LE, b.Count
+		}
+	}
+	return count
+}
+
+// hasTags reports whether tags contains every key/value in want.
+func hasTags(tags, want map[string]string) bool {
+	for k, v := range want {
+		if got, ok := tags[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// tagSuffix formats tags for failure messages.
+func tagSuffix(tags map[string]string) interface{} {
+	if len(tags) == 0 {
+		return ""
+	}
+	return tags
+}
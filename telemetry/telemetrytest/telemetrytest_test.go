@@ -0,0 +1,125 @@
+package telemetrytest
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+)
+
+// failureRecorder captures Errorf calls so failing assertions can be tested
+type failureRecorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *failureRecorder) Helper() {}
+
+func (r *failureRecorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestTimerWithFakeClock(t *testing.T) {
+	h := New(t)
+
+	stop := h.System.Timer("fulpack_operation_ms", map[string]string{"operation": "create"})
+	h.Clock.Advance(42 * time.Millisecond)
+	if err := stop(); err != nil {
+		t.Fatalf("stop() error = %v", err)
+	}
+
+	AssertHistogramBuckets(t, h.Collector, "fulpack_operation_ms", map[string]string{"operation": "create"}, []telemetry.HistogramBucket{
+		{LE: 10, Count: 0},
+		{LE: 50, Count: 1},
+		{LE: math.Inf(1), Count: 1},
+	})
+}
+
+func TestHistogramBuckets_DurationsAndValues(t *testing.T) {
+	h := New(t)
+
+	for _, d := range []time.Duration{3 * time.Millisecond, 30 * time.Millisecond, 300 * time.Millisecond} {
+		if err := h.System.Histogram("request_duration", d, nil); err != nil {
+			t.Fatalf("Histogram() error = %v", err)
+		}
+	}
+	AssertHistogramBuckets(t, h.Collector, "request_duration", nil, []telemetry.HistogramBucket{
+		{LE: 5, Count: 1},
+		{LE: 50, Count: 2},
+		{LE: 500, Count: 3},
+	})
+
+	if err := h.System.Observe("payload_bytes", 2048, "bytes", nil); err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	AssertHistogramBuckets(t, h.Collector, "payload_bytes", nil, []telemetry.HistogramBucket{
+		{LE: 1024, Count: 0},
+		{LE: 4096, Count: 1},
+	})
+}
+
+func TestAssertCounter(t *testing.T) {
+	h := New(t)
+
+	_ = h.System.Counter("jobs_total", 2, map[string]string{"status": "success"})
+	_ = h.System.Counter("jobs_total", 1, map[string]string{"status": "error"})
+
+	AssertCounter(t, h.Collector, "jobs_total", nil, 3)
+	AssertCounter(t, h.Collector, "jobs_total", map[string]string{"status": "success"}, 2)
+
+	recorder := &failureRecorder{TB: t}
+	if AssertCounter(recorder, h.Collector, "jobs_total", map[string]string{"status": "error"}, 5) {
+		t.Error("Expected AssertCounter to fail")
+	}
+	if len(recorder.failures) != 1 {
+		t.Errorf("Expected 1 failure, got %v", recorder.failures)
+	}
+}
+
+func TestSuppressionHeartbeatWithFakeClock(t *testing.T) {
+	h := New(t, func(config *telemetry.Config) {
+		config.SuppressZeroValues = true
+		config.HeartbeatInterval = time.Minute
+	})
+
+	_ = h.System.Gauge("queue_depth", 5, nil)
+	_ = h.System.Gauge("queue_depth", 5, nil) // repeat is suppressed
+	h.Clock.Advance(time.Minute)
+	_ = h.System.Gauge("queue_depth", 5, nil) // heartbeat re-emits
+
+	if got := h.Collector.CountMetricsByName("queue_depth"); got != 2 {
+		t.Errorf("Expected 2 emitted gauges, got %d", got)
+	}
+}
+
+func TestNewGlobal(t *testing.T) {
+	previous := telemetry.GetGlobalSystem()
+
+	t.Run("captures module helpers", func(t *testing.T) {
+		h := NewGlobal(t)
+		telemetry.EmitCounter("fulhash_hash_count", 1, nil)
+		AssertCounter(t, h.Collector, "fulhash_hash_count", nil, 1)
+	})
+
+	if telemetry.GetGlobalSystem() != previous {
+		t.Error("Expected the previous global system to be restored")
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2030, time.June, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	clock.Advance(time.Hour)
+	if want := start.Add(time.Hour); !clock.Now().Equal(want) {
+		t.Errorf("Now() = %v, want %v", clock.Now(), want)
+	}
+	clock.Set(start)
+	if !clock.Now().Equal(start) {
+		t.Errorf("Now() = %v after Set, want %v", clock.Now(), start)
+	}
+	if NewFakeClock(time.Time{}).Now().IsZero() {
+		t.Error("Expected a non-zero default start time")
+	}
+}
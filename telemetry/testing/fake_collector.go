@@ -24,9 +24,17 @@ type RecordedMetric struct {
 	Timestamp time.Time
 }
 
+// RecordedExemplar is an exemplar passed to FakeCollector.RecordExemplar
+type RecordedExemplar struct {
+	Name     string
+	Tags     map[string]string
+	Exemplar telemetry.Exemplar
+}
+
 type FakeCollector struct {
-	mu      sync.RWMutex
-	metrics []RecordedMetric
+	mu        sync.RWMutex
+	metrics   []RecordedMetric
+	exemplars []RecordedExemplar
 }
 
 func NewFakeCollector() *FakeCollector {
@@ -89,6 +97,21 @@ func (fc *FakeCollector) HistogramSummary(name string, summary telemetry.Histogr
 	return nil
 }
 
+// Observe implements telemetry.ValueObserver, recording the value with its unit
+func (fc *FakeCollector) Observe(name string, value float64, unit string, tags map[string]string) error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.metrics = append(fc.metrics, RecordedMetric{
+		Name:      name,
+		Type:      MetricTypeHistogram,
+		Value:     value,
+		Tags:      copyTags(tags),
+		Unit:      unit,
+		Timestamp: time.Now(),
+	})
+	return nil
+}
+
 func (fc *FakeCollector) GetMetrics() []RecordedMetric {
 	fc.mu.RLock()
 	defer fc.mu.RUnlock()
@@ -139,6 +162,28 @@ func (fc *FakeCollector) CountMetricsByName(name string) int {
 	return count
 }
 
+// RecordExemplar implements telemetry.ExemplarRecorder
+func (fc *FakeCollector) RecordExemplar(name string, tags map[string]string, exemplar telemetry.Exemplar) error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	exemplar.Labels = copyTags(exemplar.Labels)
+	fc.exemplars = append(fc.exemplars, RecordedExemplar{
+		Name:     name,
+		Tags:     copyTags(tags),
+		Exemplar: exemplar,
+	})
+	return nil
+}
+
+// GetExemplars returns all recorded exemplars
+func (fc *FakeCollector) GetExemplars() []RecordedExemplar {
+	fc.mu.RLock()
+	defer fc.mu.RUnlock()
+	result := make([]RecordedExemplar, len(fc.exemplars))
+	copy(result, fc.exemplars)
+	return result
+}
+
 func (fc *FakeCollector) HasMetric(name string) bool {
 	return fc.CountMetricsByName(name) > 0
 }
@@ -147,6 +192,7 @@ func (fc *FakeCollector) Reset() {
 	fc.mu.Lock()
 	defer fc.mu.Unlock()
 	fc.metrics = make([]RecordedMetric, 0)
+	fc.exemplars = nil
 }
 
 func copyTags(tags map[string]string) map[string]string {
@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/telemetry/metrics"
+)
+
+// ValueObserver is implemented by emitters that record histogram observations
+// in units other than milliseconds (bytes, counts). Observe values sent to
+// emitters without it arrive as a single-sample HistogramSummary.
+type ValueObserver interface {
+	Observe(name string, value float64, unit string, tags map[string]string) error
+}
+
+// Timer starts timing and returns a stop function that records the elapsed
+// time as a histogram (see Histogram). Only the first call to stop records;
+// later calls return nil.
+//
+// Example:
+//
+//	stop := sys.Timer(metrics.PathfinderFindMs, map[string]string{"root": "."})
+//	defer stop()
+func (s *System) Timer(name string, tags map[string]string) func() error {
+	start := s.now()
+	var once sync.Once
+	return func() error {
+		var err error
+		once.Do(func() {
+			err = s.Histogram(name, s.now().Sub(start), tags)
+		})
+		return err
+	}
+}
+
+// Time runs fn and records its duration as a histogram, whether or not fn
+// fails. It returns fn's error, or the emission error if fn succeeded.
+//
+// Example:
+//
+//	err := sys.Time(metrics.ConfigLoadMs, nil, func() error {
+//	    return loadConfig(path)
+//	})
+func (s *System) Time(name string, tags map[string]string, fn func() error) error {
+	stop := s.Timer(name, tags)
+	err := fn()
+	if stopErr := stop(); err == nil {
+		err = stopErr
+	}
+	return err
+}
+
+// Observe emits a histogram observation of a non-duration value, such as a
+// payload size in bytes or an item count. Unit is a taxonomy unit ("bytes",
+// "count", "percent", ...); "ms" values are recorded as durations through
+// Histogram.
+//
+// Example:
+//
+//	_ = sys.Observe("upload_size_bytes", float64(len(body)), metrics.UnitBytes, nil)
+func (s *System) Observe(name string, value float64, unit string, tags map[string]string) error {
+	if unit == metrics.UnitMs {
+		return s.Histogram(name, time.Duration(value*float64(time.Millisecond)), tags)
+	}
+	if !s.isEnabledFor(name) {
+		return nil
+	}
+	weight, keep := s.sample(name)
+	if !keep {
+		return nil
+	}
+	tags = s.guardTags(name, tags)
+	event := MetricsEvent{
+		Timestamp: s.now().UTC().Format(time.RFC3339),
+		Name:      name,
+		Type:      TypeHistogram,
+		Value:     value,
+		Tags:      tags,
+		Unit:      unit,
+		Weight:    eventWeight(weight),
+	}
+	return s.emit(event)
+}
@@ -0,0 +1,128 @@
+package telemetry
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// observation is a metric received by observingEmitter
+type observation struct {
+	name    string
+	value   interface{}
+	unit    string
+	summary bool
+}
+
+// observingEmitter records histogram values, optionally implementing ValueObserver
+type observingEmitter struct {
+	tagRecorder
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (e *observingEmitter) Histogram(name string, duration time.Duration, tags map[string]string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.observations = append(e.observations, observation{name: name, value: duration, unit: "ms"})
+	return nil
+}
+
+func (e *observingEmitter) HistogramSummary(name string, summary HistogramSummary, tags map[string]string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.observations = append(e.observations, observation{name: name, value: summary, summary: true})
+	return nil
+}
+
+type valueObservingEmitter struct {
+	observingEmitter
+}
+
+func (e *valueObservingEmitter) Observe(name string, value float64, unit string, tags map[string]string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.observations = append(e.observations, observation{name: name, value: value, unit: unit})
+	return nil
+}
+
+// TestTimer verifies the stop function records the elapsed time once
+func TestTimer(t *testing.T) {
+	emitter := &observingEmitter{}
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: emitter})
+	require.NoError(t, err)
+
+	stop := sys.Timer("operation_duration", nil)
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, stop())
+	require.NoError(t, stop())
+
+	require.Len(t, emitter.observations, 1)
+	elapsed, ok := emitter.observations[0].value.(time.Duration)
+	require.True(t, ok, "expected a duration, got %T", emitter.observations[0].value)
+	assert.GreaterOrEqual(t, elapsed, 2*time.Millisecond)
+}
+
+// TestTime verifies the duration is recorded and fn's error returned
+func TestTime(t *testing.T) {
+	emitter := &observingEmitter{}
+	sys, err := NewSystem(&Config{Enabled: true, Emitter: emitter})
+	require.NoError(t, err)
+
+	require.NoError(t, sys.Time("load_ms", nil, func() error { return nil }))
+
+	failure := errors.New("load failed")
+	err = sys.Time("load_ms", nil, func() error { return failure })
+	assert.ErrorIs(t, err, failure)
+
+	assert.Len(t, emitter.observations, 2, "duration should be recorded even when fn fails")
+}
+
+// TestObserve verifies non-duration values keep their unit
+func TestObserve(t *testing.T) {
+	t.Run("value observer", func(t *testing.T) {
+		emitter := &valueObservingEmitter{}
+		sys, err := NewSystem(&Config{Enabled: true, Emitter: emitter})
+		require.NoError(t, err)
+
+		require.NoError(t, sys.Observe("upload_size_bytes", 2048, "bytes", nil))
+		require.Len(t, emitter.observations, 1)
+		assert.Equal(t, observation{name: "upload_size_bytes", value: 2048.0, unit: "bytes"}, emitter.observations[0])
+	})
+
+	t.Run("summary fallback", func(t *testing.T) {
+		emitter := &observingEmitter{}
+		sys, err := NewSystem(&Config{Enabled: true, Emitter: emitter})
+		require.NoError(t, err)
+
+		require.NoError(t, sys.Observe("batch_items", 42, "count", nil))
+		require.Len(t, emitter.observations, 1)
+		summary, ok := emitter.observations[0].value.(HistogramSummary)
+		require.True(t, ok, "expected a HistogramSummary, got %T", emitter.observations[0].value)
+		assert.Equal(t, int64(1), summary.Count)
+		assert.Equal(t, 42.0, summary.Sum)
+	})
+
+	t.Run("milliseconds are durations", func(t *testing.T) {
+		emitter := &valueObservingEmitter{}
+		sys, err := NewSystem(&Config{Enabled: true, Emitter: emitter})
+		require.NoError(t, err)
+
+		require.NoError(t, sys.Observe("latency", 250, "ms", nil))
+		require.Len(t, emitter.observations, 1)
+		assert.Equal(t, 250*time.Millisecond, emitter.observations[0].value)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		emitter := &valueObservingEmitter{}
+		sys, err := NewSystem(&Config{Enabled: false, Emitter: emitter})
+		require.NoError(t, err)
+
+		require.NoError(t, sys.Observe("upload_size_bytes", 1, "bytes", nil))
+		assert.Empty(t, emitter.observations)
+	})
+}